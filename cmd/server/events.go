@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/config"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/objectstore"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/service"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// runEvents implements the events subcommand, which inspects and restores the event store, and
+// returns the process exit code.
+func runEvents(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "replay":
+			return runEventsReplay(args[1:])
+		case "restore":
+			return runEventsRestore(args[1:])
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "usage: server events replay [--aggregate-type TYPE] [--aggregate-id ID] [--since DATE]")
+	fmt.Fprintln(os.Stderr, "       server events restore [--allow-existing]")
+	return 1
+}
+
+// runEventsReplay projects a slice of the event store into a scratch read model and prints how it
+// differs from the stored read models, which it does not modify.
+func runEventsReplay(args []string) int {
+	flags := flag.NewFlagSet("events replay", flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file; environment variables override its values")
+	aggregateType := flags.String("aggregate-type", "", "replay only this aggregate type: user, balance or transaction")
+	aggregateID := flags.String("aggregate-id", "", "replay only this aggregate; requires --aggregate-type")
+	since := flags.String("since", "", "replay only events created at or after this date (YYYY-MM-DD) or RFC3339 timestamp")
+	_ = flags.Parse(args) // ExitOnError exits on invalid flags
+
+	req := &domain.ProjectionReplayRequest{}
+	if *aggregateType != "" {
+		t := domain.AggregateType(*aggregateType)
+		req.AggregateType = &t
+	}
+	if *aggregateID != "" {
+		id, err := uuid.Parse(*aggregateID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid --aggregate-id: %v\n", err)
+			return 1
+		}
+		req.AggregateID = &id
+	}
+	if *since != "" {
+		t, err := parseReplaySince(*since)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		req.Since = &t
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if cfg.Storage != config.StoragePostgres {
+		// A memory store lives in the server process, so there is no event store to read from here
+		fmt.Fprintf(os.Stderr, "replaying events requires %s storage, got %s\n", config.StoragePostgres, cfg.Storage)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	db, err := connectEventStore(ctx, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer db.Close()
+
+	repos := repository.NewRepositories(db.Pool)
+	projector := service.NewProjectorService(repos.Events, repos.Users, repos.Balances, repos.Transactions)
+
+	report, err := projector.Replay(ctx, req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Replayed %d events (%d failed) into %d users, %d balances and %d transactions\n",
+		report.EventsReplayed, report.EventsFailed, report.Users, report.Balances, report.Transactions)
+
+	if len(report.Differences) == 0 {
+		fmt.Println("No differences from the read models")
+		return 0
+	}
+
+	fmt.Printf("%d differences from the read models:\n", len(report.Differences))
+	for _, d := range report.Differences {
+		fmt.Printf("  %s %s %s: projected %s, current %s\n", d.AggregateType, d.AggregateID, d.Field, d.Projected, d.Current)
+	}
+
+	return 0
+}
+
+// runEventsRestore imports the events exported to the backup bucket into the configured database
+// and rebuilds the read models from them, for disaster-recovery drills.
+func runEventsRestore(args []string) int {
+	flags := flag.NewFlagSet("events restore", flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file; environment variables override its values")
+	allowExisting := flags.Bool("allow-existing", false, "restore into an event store that already holds events, such as after an interrupted restore")
+	_ = flags.Parse(args) // ExitOnError exits on invalid flags
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if cfg.Storage != config.StoragePostgres {
+		// A memory store lives in the server process, so there is no event store to restore into here
+		fmt.Fprintf(os.Stderr, "restoring events requires %s storage, got %s\n", config.StoragePostgres, cfg.Storage)
+		return 1
+	}
+
+	// The bucket is read even when the worker that fills it is disabled on this instance
+	store, err := newBackupStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid backup configuration: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+	defer cancel()
+
+	db, err := connectEventStore(ctx, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer db.Close()
+
+	repos := repository.NewRepositories(db.Pool)
+	backup := service.NewEventBackupService(repos.Events, store, cfg.Backup.Prefix)
+
+	restore, err := backup.Restore(ctx, *allowExisting)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "restore failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Read %d events from %d objects and imported %d\n", restore.EventsRead, restore.Objects, restore.EventsImported)
+
+	projector := service.NewProjectorService(repos.Events, repos.Users, repos.Balances, repos.Transactions)
+	failed := 0
+	err = projector.Rebuild(ctx, &domain.ProjectionRebuildRequest{}, func(_, failedSoFar, _ int) {
+		failed = failedSoFar
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rebuilding read models failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Rebuilt the read models (%d events could not be applied)\n", failed)
+
+	return 0
+}
+
+// newBackupStore opens the bucket event backups are exported to.
+func newBackupStore(cfg *config.Config) (*objectstore.S3Store, error) {
+	return objectstore.NewS3Store(objectstore.S3Config{
+		Endpoint:        cfg.Backup.S3.Endpoint,
+		Region:          cfg.Backup.S3.Region,
+		Bucket:          cfg.Backup.S3.Bucket,
+		AccessKeyID:     cfg.Backup.S3.AccessKeyID,
+		SecretAccessKey: cfg.Backup.S3.SecretAccessKey,
+		Timeout:         cfg.Backup.S3.Timeout,
+	})
+}
+
+// connectEventStore connects to the configured database, retrying while it starts up.
+func connectEventStore(ctx context.Context, cfg *config.Config) (*repository.DB, error) {
+	var db *repository.DB
+	err := utils.Retry(ctx, "postgres", utils.RetryPolicy{
+		InitialBackoff: cfg.StartupRetry.InitialBackoff,
+		MaxBackoff:     cfg.StartupRetry.MaxBackoff,
+		MaxWait:        cfg.StartupRetry.MaxWait,
+	}, func(ctx context.Context) error {
+		var connectErr error
+		db, connectErr = repository.Connect(ctx, cfg.DBUrl, dbPoolConfig(cfg.DBPool, nil))
+		return connectErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
+// parseReplaySince parses a --since value given as a date or an RFC3339 timestamp.
+func parseReplaySince(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: must be YYYY-MM-DD or an RFC3339 timestamp", value)
+	}
+	return t, nil
+}
@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -12,133 +13,122 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
 	v1 "github.com/sefa-b/go-banking-sim/internal/api/v1"
 	"github.com/sefa-b/go-banking-sim/internal/auth"
+	"github.com/sefa-b/go-banking-sim/internal/clock"
 	"github.com/sefa-b/go-banking-sim/internal/config"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/featureflags"
+	"github.com/sefa-b/go-banking-sim/internal/health"
+	"github.com/sefa-b/go-banking-sim/internal/notifications"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
 	"github.com/sefa-b/go-banking-sim/internal/service"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
 	"github.com/sefa-b/go-banking-sim/internal/worker"
 )
 
-// transactionServiceAdapter adapts the service.TransactionService to worker.TransactionService interface
-type transactionServiceAdapter struct {
-	service service.TransactionService
-}
-
-func (a *transactionServiceAdapter) CreditSync(ctx context.Context, userID string, req interface{}) (interface{}, error) {
-	// Parse userID
-	uid, err := uuid.Parse(userID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
-	}
-
-	// Type assert the request
-	creditReq, ok := req.(*domain.CreditRequest)
-	if !ok {
-		return nil, fmt.Errorf("invalid credit request type")
-	}
-
-	// Call the sync version to avoid circular dependency
-	return a.service.CreditSync(ctx, uid, creditReq)
-}
-
-func (a *transactionServiceAdapter) DebitSync(ctx context.Context, userID string, req interface{}) (interface{}, error) {
-	// Parse userID
-	uid, err := uuid.Parse(userID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		os.Exit(runSeed(os.Args[2:]))
 	}
-
-	// Type assert the request
-	debitReq, ok := req.(*domain.DebitRequest)
-	if !ok {
-		return nil, fmt.Errorf("invalid debit request type")
+	if len(os.Args) > 1 && os.Args[1] == "events" {
+		os.Exit(runEvents(os.Args[2:]))
 	}
 
-	return a.service.DebitSync(ctx, uid, debitReq)
-}
-
-func (a *transactionServiceAdapter) TransferSync(ctx context.Context, fromUserID string, req interface{}) (interface{}, error) {
-	// Parse fromUserID
-	fromUID, err := uuid.Parse(fromUserID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid from user ID: %w", err)
-	}
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file; environment variables override its values")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
 
-	// Type assert the request
-	transferReq, ok := req.(*domain.TransferRequest)
-	if !ok {
-		return nil, fmt.Errorf("invalid transfer request type")
+	cfg, err := config.Load(*configPath)
+	if *printConfig && cfg != nil {
+		if writeErr := cfg.Redacted().WriteYAML(os.Stdout); writeErr != nil {
+			fmt.Fprintln(os.Stderr, writeErr)
+			os.Exit(1)
+		}
 	}
-
-	return a.service.TransferSync(ctx, fromUID, transferReq)
-}
-
-func (a *transactionServiceAdapter) RollbackSync(ctx context.Context, transactionID string, requestingUserID string) (interface{}, error) {
-	// Parse transactionID
-	txID, err := uuid.Parse(transactionID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid transaction ID: %w", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	// Parse requestingUserID
-	reqUID, err := uuid.Parse(requestingUserID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid requesting user ID: %w", err)
+	if *printConfig {
+		return
 	}
 
-	return a.service.RollbackSync(ctx, txID, reqUID)
-}
-
-func main() {
-	cfg := config.Load()
-
 	// Initialize structured logger
-	utils.InitLogger(cfg.Environment, "go-banking-sim")
+	utils.InitLogger(cfg.Environment, "go-banking-sim", cfg.Log)
+
+	// Amount limits are enforced wherever credits, debits and transfers are validated
+	domain.SetAmountLimits(amountLimits(cfg.AmountLimits))
 
 	// Initialize metrics collector
 	metricsCollector := utils.NewMetricsCollector()
 
 	// Initialize distributed tracing
 	ctx := context.Background()
-	shutdownTracer, err := utils.InitTracer(ctx, "go-banking-sim", "1.0.0", "jaeger:4317")
+	shutdownTracer, err := utils.InitTracer(ctx, "go-banking-sim", "1.0.0", cfg.Tracing)
 	if err != nil {
 		utils.Error("failed to initialize tracer", "error", err.Error())
 		os.Exit(1)
 	}
 	defer shutdownTracer()
 
-	// Initialize database connection (if DB_URL is provided)
-	var db *repository.DB
-	if cfg.DBUrl != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+	// Dependencies that are still starting, as is common under docker-compose, are retried with
+	// backoff; an interrupt during the wait stops the server
+	startupRetry := utils.RetryPolicy{
+		InitialBackoff: cfg.StartupRetry.InitialBackoff,
+		MaxBackoff:     cfg.StartupRetry.MaxBackoff,
+		MaxWait:        cfg.StartupRetry.MaxWait,
+	}
+	startupCtx, stopStartup := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopStartup()
 
-		var err error
-		db, err = repository.Connect(ctx, cfg.DBUrl)
+	// Initialize database connection; memory storage runs without one
+	var db *repository.DB
+	if cfg.Storage == config.StoragePostgres {
+		err = utils.Retry(startupCtx, "postgres", startupRetry, func(ctx context.Context) error {
+			connectCtx, connectCancel := context.WithTimeout(ctx, 10*time.Second)
+			defer connectCancel()
+			var connectErr error
+			db, connectErr = repository.Connect(connectCtx, cfg.DBUrl, dbPoolConfig(cfg.DBPool, metricsCollector))
+			return connectErr
+		})
 		if err != nil {
 			utils.Error("failed to connect to database", slog.String("error", err.Error()))
 			os.Exit(1)
 		}
 		defer db.Close()
-	} else {
-		utils.Warn("no database URL provided, running without database")
 	}
 
+	// Circuit breakers fail Postgres and Redis calls fast while either is unavailable
+	dbBreaker := utils.GetCircuitBreaker(repository.PostgresBreakerName, utils.CircuitBreakerConfig{
+		Name:             repository.PostgresBreakerName,
+		FailureThreshold: int32(cfg.DBBreaker.FailureThreshold),
+		ResetTimeout:     cfg.DBBreaker.ResetTimeout,
+	})
+	redisBreaker := utils.GetCircuitBreaker(repository.RedisBreakerName, utils.CircuitBreakerConfig{
+		Name:             repository.RedisBreakerName,
+		FailureThreshold: int32(cfg.RedisBreaker.FailureThreshold),
+		ResetTimeout:     cfg.RedisBreaker.ResetTimeout,
+	})
+
 	// Initialize Redis connection
 	var redisClient *repository.RedisClient
 	redisConfig := repository.RedisConfig{
-		Addr:     "redis:6379", // Default Redis address in Docker
-		Password: "redis_password",
-		DB:       0,
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+		Breaker:  redisBreaker,
 	}
 
-	redisClient, err = repository.NewRedisClient(redisConfig)
+	err = utils.Retry(startupCtx, "redis", startupRetry, func(context.Context) error {
+		var connectErr error
+		redisClient, connectErr = repository.NewRedisClient(redisConfig)
+		return connectErr
+	})
+	stopStartup()
 	if err != nil {
 		utils.Warn("failed to connect to Redis, running without cache", slog.String("error", err.Error()))
 	} else {
@@ -149,17 +139,40 @@ func main() {
 		}()
 	}
 
-	// Initialize repositories (if database is available)
+	// Feature flags follow configuration; runtime overrides need Redis
+	var flagStore featureflags.Store
+	if redisClient != nil {
+		flagStore = featureflags.NewRedisStore(redisClient)
+	}
+	flags, err := featureflags.NewManager(cfg.FeatureFlags, flagStore)
+	if err != nil {
+		utils.Error("invalid feature flag configuration", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Initialize repositories on the configured storage
 	var repos *repository.Repositories
+	var uow repository.UnitOfWork
 	if db != nil {
-		repos = &repository.Repositories{
-			Users:                 repository.NewUsersRepo(db.Pool),
-			Balances:              repository.NewBalancesRepo(db.Pool),
-			Transactions:          repository.NewTransactionsRepo(db.Pool),
-			Audit:                 repository.NewAuditRepo(db.Pool),
-			Events:                repository.NewEventRepository(db.Pool),
-			ScheduledTransactions: repository.NewScheduledTransactionRepository(db.Pool),
+		guardedDB := repository.NewBreakerDB(db.Pool, dbBreaker)
+		repos = repository.NewRepositories(guardedDB)
+		uow = repository.NewUnitOfWork(guardedDB)
+	} else {
+		repos, uow, err = newMemoryRepositories()
+		if err != nil {
+			utils.Error("failed to initialize memory storage", slog.String("error", err.Error()))
+			os.Exit(1)
 		}
+		utils.Warn("using memory storage; all data is lost when the server stops")
+	}
+	if repos != nil {
+		// Audit entries logged under an impersonation token name the impersonating admin,
+		// within transactions too
+		decorate := func(repos *repository.Repositories) {
+			repos.Audit = service.NewImpersonationAuditRepo(repos.Audit)
+		}
+		decorate(repos)
+		uow = repository.DecorateUnitOfWork(uow, decorate)
 	}
 
 	// Initialize JWT manager
@@ -167,27 +180,227 @@ func main() {
 
 	// Initialize services first
 	var services *service.Services
+	var statusBroker *service.TransactionStatusBroker
+	var localCache *service.LocalCache
 	if repos != nil {
+		// Status broker carries live transaction status updates, across instances when Redis is available
+		statusBroker = service.NewTransactionStatusBroker(redisClient)
+
 		// Create event service first as it's needed by other services
 		eventSvc := service.NewEventService(repos.Events)
+		eventSvc.SetFeatureFlags(flags)
+
+		// Notification service queues notifications; channels beyond the in-app inbox are optional
+		notificationSvc := service.NewNotificationService(repos, cfg.Notifications.MaxAttempts)
+		if notifySvc, ok := notificationSvc.(*service.NotificationServiceImpl); ok {
+			if cfg.Notifications.SMTP.Host != "" {
+				notifySvc.RegisterChannel(notifications.NewEmailChannel(notifications.EmailConfig{
+					Host:     cfg.Notifications.SMTP.Host,
+					Port:     cfg.Notifications.SMTP.Port,
+					Username: cfg.Notifications.SMTP.Username,
+					Password: cfg.Notifications.SMTP.Password,
+					From:     cfg.Notifications.SMTP.From,
+				}))
+			}
+			notifySvc.RegisterChannel(notifications.NewWebhookChannel(cfg.Notifications.Webhook.Secret, cfg.Notifications.Webhook.Timeout))
+		}
+
+		// Low-balance alerts are checked after every completed transaction
+		balanceAlertSvc := service.NewBalanceAlertService(repos, eventSvc, notificationSvc)
+		budgetSvc := service.NewBudgetService(repos, notificationSvc)
 
 		// Create balance service first since transaction service depends on it
 		balanceSvc := service.NewBalanceService(repos)
-		transactionSvc := service.NewTransactionService(repos, balanceSvc, nil, eventSvc, db.Pool) // Worker pool will be set later
+		transactionSvc := service.NewTransactionService(repos, balanceSvc, nil, eventSvc, uow) // Worker pool will be set later
+
+		// Users whose money movement looks suspicious must confirm their password before moving more
+		var moneyPolicy service.MoneyMovementPolicy
+		if cfg.Anomaly.Enabled {
+			moneyPolicy = service.NewAnomalyPolicy(repos, cfg.Anomaly.Threshold, cfg.Anomaly.HalfLife, map[domain.AnomalySignal]float64{
+				domain.AnomalyRollback:          cfg.Anomaly.RollbackWeight,
+				domain.AnomalyInsufficientFunds: cfg.Anomaly.InsufficientFundsWeight,
+			})
+		}
+
+		if txSvc, ok := transactionSvc.(*service.TransactionServiceImpl); ok {
+			txSvc.SetRollbackWindow(cfg.RollbackWindow)
+			txSvc.SetFeatureFlags(flags)
+			txSvc.SetStatusBroker(statusBroker)
+			txSvc.SetNotifier(notificationSvc)
+			txSvc.SetBalanceMonitor(balanceAlertSvc)
+			txSvc.SetBudgetMonitor(budgetSvc)
+			txSvc.SetMoneyMovementPolicy(moneyPolicy)
+		}
+
+		// Scheduled occurrences on weekends and configured holidays may move to business days
+		calendar, err := domain.NewBusinessCalendar(cfg.Scheduled.Calendar.DefaultRegion, cfg.Scheduled.Calendar.Holidays)
+		if err != nil {
+			utils.Error("failed to build business day calendar", "error", err.Error())
+			os.Exit(1)
+		}
+
+		// Receipts are signed with their own key, or one derived from the JWT secret without it
+		receiptKey := []byte(cfg.ReceiptSigningKey)
+		if len(receiptKey) == 0 {
+			receiptKey = service.ReceiptKeyFromSecret(cfg.JWTSecret)
+		}
+
+		// Scheduled transactions and simulations run on a clock admins can fast-forward when it is enabled
+		var simulationClock *clock.Simulated
+		var serverClock clock.Clock = clock.Real{}
+		if cfg.Simulation.Clock {
+			simulationClock = clock.NewSimulated()
+			serverClock = simulationClock
+		}
+
+		scheduledSvc := service.NewScheduledTransactionService(repos, transactionSvc)
+		if schedSvc, ok := scheduledSvc.(*service.ScheduledTransactionServiceImpl); ok {
+			schedSvc.SetNotifier(notificationSvc)
+			schedSvc.SetExecutionPolicy(cfg.Scheduled.FundingCheckLead, cfg.Scheduled.GraceRetries, cfg.Scheduled.RetryInterval)
+			schedSvc.SetCalendar(calendar)
+			schedSvc.SetClock(serverClock)
+		}
+
+		// Invariant checker exports money supply drift as a metric
+		invariantSvc := service.NewInvariantService(repos)
+		if checker, ok := invariantSvc.(*service.InvariantServiceImpl); ok {
+			checker.SetMetrics(metricsCollector)
+		}
 
 		services = &service.Services{
-			Auth:                 service.NewAuthService(repos, jwtManager, eventSvc),
+			Auth:                 service.NewAuthService(repos, jwtManager, eventSvc, uow, cfg.WelcomeBonus),
 			User:                 service.NewUserService(repos),
 			Balance:              balanceSvc,
 			Transaction:          transactionSvc,
-			ScheduledTransaction: service.NewScheduledTransactionService(repos, transactionSvc),
+			ScheduledTransaction: scheduledSvc,
+			Calendar:             service.NewCalendarService(calendar),
+			Receipt:              service.NewReceiptService(repos, receiptKey),
+			PaymentQR:            service.NewPaymentQRService(repos),
+			Dispute:              service.NewDisputeService(repos, transactionSvc, eventSvc),
+			PaymentRequest:       service.NewPaymentRequestService(repos, transactionSvc, eventSvc),
+			TransferTemplate:     service.NewTransferTemplateService(repos, transactionSvc),
+			Contact:              service.NewContactService(repos),
+			Security:             service.NewSecurityService(repos),
 			Event:                eventSvc,
 			Projector:            service.NewProjectorService(repos.Events, repos.Users, repos.Balances, repos.Transactions),
+			FeatureFlags:         flags,
+			RequestLog:           service.NewRequestLogService(repos, cfg.RequestLog.Retention),
+			TransactionExport:    service.NewTransactionExportService(repos),
+			TransactionStatus:    service.NewTransactionStatusService(repos, statusBroker),
+			Notification:         notificationSvc,
+			BalanceAlert:         balanceAlertSvc,
+			Budget:               budgetSvc,
+			Treasury:             service.NewTreasuryService(repos),
+			Currency:             service.NewCurrencyService(repos),
+			Import:               service.NewImportService(repos, uow, eventSvc),
+			Invariant:            invariantSvc,
+		}
+
+		// Logins from new devices notify their users, who may revoke the sessions tokens are checked against;
+		// users stepping up clear their anomaly score
+		if authSvc, ok := services.Auth.(*service.AuthServiceImpl); ok {
+			authSvc.SetNotifier(notificationSvc)
+			authSvc.SetMoneyMovementPolicy(moneyPolicy)
+			jwtManager.SetSessionChecker(authSvc)
+		}
+
+		// Validate and format with the persisted currency registry; until it loads, the defaults apply
+		if err := services.Currency.Refresh(ctx); err != nil {
+			utils.Error("failed to load currency registry; using default currencies", slog.String("error", err.Error()))
+		}
+
+		services.ProjectionRebuild = service.NewProjectionRebuildService(services.Projector)
+
+		// The configured rollback window and amount limits apply until admins store a policy version
+		services.Policy = service.NewPolicyService(repos, domain.Policy{
+			RollbackWindow: domain.Duration(cfg.RollbackWindow),
+			AmountLimits:   amountLimits(cfg.AmountLimits),
+		})
+		if err := services.Policy.Refresh(ctx); err != nil {
+			utils.Error("failed to load policy; using configured policy", slog.String("error", err.Error()))
+		}
+		if txSvc, ok := transactionSvc.(*service.TransactionServiceImpl); ok {
+			txSvc.SetPolicySource(services.Policy)
+		}
+
+		monthlySummarySvc := service.NewMonthlySummaryService(repos)
+		if summarySvc, ok := monthlySummarySvc.(*service.MonthlySummaryServiceImpl); ok {
+			summarySvc.SetNotifier(notificationSvc)
+		}
+		services.MonthlySummary = monthlySummarySvc
+
+		services.Forecast = service.NewForecastService(repos, scheduledSvc)
+		services.Activity = service.NewActivityService(repos)
+		services.Dormancy = service.NewDormancyService(repos, eventSvc)
+		services.Search = service.NewSearchService(repos)
+
+		// Destructive admin operations configured for four-eyes approval wait for a second admin
+		approvalActions := make([]domain.ApprovalAction, 0, len(cfg.Approvals.Actions))
+		for _, action := range cfg.Approvals.Actions {
+			approvalActions = append(approvalActions, domain.ApprovalAction(action))
+		}
+		services.Approval = service.NewApprovalService(repos, transactionSvc, services.User, approvalActions)
+		if approvalSvc, ok := services.Approval.(*service.ApprovalServiceImpl); ok {
+			approvalSvc.SetPolicyService(services.Policy)
+		}
+
+		// Netted transfers stay pending until their batch settles, so netting is only on when enabled
+		if txSvc, ok := transactionSvc.(*service.TransactionServiceImpl); ok && cfg.Netting.Enabled {
+			txSvc.SetNetting(cfg.Netting.Window, cfg.Netting.MaxAmount, cfg.Netting.BatchSize)
+			txSvc.SetNettingMetrics(metricsCollector)
+			services.Netting = txSvc
+		}
+
+		// Simulations create users and mint money, so they are only available when enabled
+		if cfg.Simulation.Enabled {
+			services.Simulation = service.NewSimulationService(repos, transactionSvc, services.Treasury)
+			if simSvc, ok := services.Simulation.(*service.SimulationServiceImpl); ok {
+				simSvc.SetClock(serverClock)
+				simSvc.SetSeed(cfg.Simulation.Seed)
+			}
+		}
+		if simulationClock != nil {
+			services.Clock = service.NewClockService(repos, simulationClock)
+			utils.Warn("simulation clock enabled; admins can fast-forward scheduled transactions")
+		}
+
+		// Index advice explains queries with PostgreSQL's planner, which a memory store lacks
+		if db != nil {
+			services.IndexAdvisor = service.NewIndexAdvisorService(repository.NewIndexAdvisorRepo(db.Pool))
+		}
+
+		// Archival works on PostgreSQL partitions, so a memory store keeps everything live
+		if cfg.Archive.Enabled {
+			if db != nil {
+				services.Archive = service.NewArchiveService(repository.NewArchiveRepo(db.Pool), cfg.Archive.PartitionsAhead, cfg.Archive.AfterMonths)
+			} else {
+				utils.Warn("archival requires postgres storage; ignoring ARCHIVE_ENABLED")
+			}
+		}
+
+		// Event backups work with either storage, so memory-backed dev servers can exercise them too
+		if cfg.Backup.Enabled {
+			store, err := newBackupStore(cfg)
+			if err != nil {
+				utils.Error("invalid backup configuration", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+			services.EventBackup = service.NewEventBackupService(repos.Events, store, cfg.Backup.Prefix)
 		}
 
 		// Initialize cache service if Redis is available
 		if redisClient != nil {
-			cacheService := service.NewCacheService(redisClient)
+			// Local cache in front of Redis, kept coherent across instances through pub/sub
+			if cfg.Cache.LocalTTL > 0 {
+				localCache = service.NewLocalCache(redisClient, cfg.Cache.LocalTTL)
+			}
+
+			cacheService := service.NewCacheService(redisClient, metricsCollector, flags, service.CacheTTLs{
+				BalanceSoft: cfg.Cache.Balance.SoftTTL,
+				BalanceHard: cfg.Cache.Balance.HardTTL,
+				UserSoft:    cfg.Cache.User.SoftTTL,
+				UserHard:    cfg.Cache.User.HardTTL,
+			}, localCache)
 			services.Cache = cacheService
 
 			// Inject cache service into existing services
@@ -200,6 +413,15 @@ func main() {
 			if transactionSvc, ok := services.Transaction.(*service.TransactionServiceImpl); ok {
 				transactionSvc.SetCacheService(cacheService)
 			}
+			if authSvc, ok := services.Auth.(*service.AuthServiceImpl); ok {
+				authSvc.SetLocalCache(localCache)
+			}
+
+			services.CacheWarmup = service.NewCacheWarmupService(repos, cacheService)
+
+			// Authenticated API calls are counted per user against admin-set quotas
+			services.APIUsage = service.NewAPIUsageService(redisClient, repos)
+			jwtManager.SetUsageMeter(services.APIUsage)
 		}
 	}
 
@@ -209,9 +431,8 @@ func main() {
 	if repos != nil && services != nil {
 		jobQueue = worker.NewJobQueue(100) // Buffer size of 100 jobs
 
-		// Create an adapter that implements the worker's TransactionService interface
-		adapter := &transactionServiceAdapter{service: services.Transaction}
-		pool = worker.NewPool(jobQueue, adapter)
+		pool = worker.NewPool(jobQueue, services.Transaction)
+		pool.SetMetrics(metricsCollector)
 
 		// Set the worker pool on the transaction service to enable job submission
 		services.Transaction.SetPool(pool)
@@ -233,20 +454,110 @@ func main() {
 	var projectorWorker *worker.ProjectorWorker
 	if services != nil && services.Projector != nil {
 		projectorWorker = worker.NewProjectorWorker(services.Projector)
+		if db != nil {
+			// A memory store belongs to one instance, which always leads
+			projectorWorker.SetLeaderElector(repository.NewAdvisoryLock(db.Pool, repository.ProjectorLeaderLockKey))
+		}
+		projectorWorker.SetMetrics(metricsCollector)
+	}
+
+	// Initialize cache warm-up worker
+	var cacheWarmupWorker *worker.CacheWarmupWorker
+	if services != nil && services.CacheWarmup != nil {
+		cacheWarmupWorker = worker.NewCacheWarmupWorker(services.CacheWarmup)
+	}
+
+	// Initialize request log retention worker; it runs even with recording disabled so old entries still expire
+	var requestLogRetentionWorker *worker.RequestLogRetentionWorker
+	if services != nil && services.RequestLog != nil {
+		requestLogRetentionWorker = worker.NewRequestLogRetentionWorker(services.RequestLog)
+	}
+
+	// Initialize notification dispatch worker
+	var notificationDispatchWorker *worker.NotificationDispatchWorker
+	if services != nil && services.Notification != nil {
+		notificationDispatchWorker = worker.NewNotificationDispatchWorker(services.Notification)
+	}
+
+	// Initialize invariant check worker
+	var invariantCheckWorker *worker.InvariantCheckWorker
+	if services != nil && services.Invariant != nil {
+		invariantCheckWorker = worker.NewInvariantCheckWorker(services.Invariant)
+	}
+
+	// Initialize netting worker
+	var nettingWorker *worker.NettingWorker
+	if services != nil && services.Netting != nil {
+		nettingWorker = worker.NewNettingWorker(services.Netting)
+	}
+
+	// Initialize currency refresh worker
+	var currencyRefreshWorker *worker.CurrencyRefreshWorker
+	if services != nil && services.Currency != nil {
+		currencyRefreshWorker = worker.NewCurrencyRefreshWorker(services.Currency)
+	}
+
+	// Initialize policy refresh worker
+	var policyRefreshWorker *worker.PolicyRefreshWorker
+	if services != nil && services.Policy != nil {
+		policyRefreshWorker = worker.NewPolicyRefreshWorker(services.Policy)
+	}
+
+	// Initialize monthly summary worker
+	var monthlySummaryWorker *worker.MonthlySummaryWorker
+	if services != nil && services.MonthlySummary != nil && cfg.MonthlySummaries.Enabled {
+		monthlySummaryWorker = worker.NewMonthlySummaryWorker(services.MonthlySummary)
+	}
+
+	// Initialize dormancy worker
+	var dormancyWorker *worker.DormancyWorker
+	if services != nil && services.Dormancy != nil && cfg.Dormancy.Enabled {
+		dormancyWorker = worker.NewDormancyWorker(services.Dormancy, cfg.Dormancy.Days)
+	}
+
+	// Initialize archive worker
+	var archiveWorker *worker.ArchiveWorker
+	if services != nil && services.Archive != nil {
+		archiveWorker = worker.NewArchiveWorker(services.Archive)
+	}
+
+	// Initialize event backup worker
+	var eventBackupWorker *worker.EventBackupWorker
+	if services != nil && services.EventBackup != nil {
+		eventBackupWorker = worker.NewEventBackupWorker(services.EventBackup)
+		if db != nil {
+			eventBackupWorker.SetLeaderElector(repository.NewAdvisoryLock(db.Pool, repository.EventBackupLockKey))
+		}
 	}
 
 	// Create HTTP server
 	mux := http.NewServeMux()
 
-	// Add health endpoint
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status":"ok"}`))
-	})
+	// Register dependency health checks
+	healthChecker := health.NewChecker(health.DefaultCheckTimeout)
+	if db != nil {
+		healthChecker.Register("postgres", true, db.Health)
+		healthChecker.Register("postgres_breaker", true, health.BreakerCheck(dbBreaker))
+	}
+	if redisClient != nil {
+		healthChecker.Register("redis", false, redisClient.Ping)
+		healthChecker.Register("redis_breaker", false, health.BreakerCheck(redisBreaker))
+	}
+	if pool != nil {
+		healthChecker.Register("worker_pool", false, pool.CheckHealth)
+	}
+	if projectorWorker != nil {
+		healthChecker.Register("projector", false, projectorWorker.CheckHealth)
+	}
+
+	// Add health endpoints: /healthz/live never checks dependencies, /healthz and /healthz/ready do
+	mux.HandleFunc("GET /healthz", healthChecker.ReadinessHandler())
+	mux.HandleFunc("GET /healthz/ready", healthChecker.ReadinessHandler())
+	mux.HandleFunc("GET /healthz/live", healthChecker.LivenessHandler())
 
-	// Add Prometheus metrics endpoint
-	mux.Handle("/metrics", promhttp.Handler())
+	// Add Prometheus metrics endpoint; OpenMetrics is negotiated so scrapers receive trace exemplars
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})))
 
 	// Add basic metrics endpoint (JSON format)
 	mux.HandleFunc("/api/v1/metrics/basic", func(w http.ResponseWriter, _ *http.Request) {
@@ -265,32 +576,81 @@ func main() {
 
 	// Register v1 API routes
 	if repos != nil && services != nil {
+		// Test-only routes are left out in production
+		routes := v1.NewRouteRegistry(mux, cfg.Environment)
 		apiRouter := v1.NewRouter(repos, services, jwtManager)
-		apiRouter.RegisterRoutes(mux)
+		apiRouter.RegisterRoutes(routes)
 
 		// Apply circuit breaker middleware to test endpoints
-		mux.Handle("GET /api/v1/test/circuit-breaker/success",
+		routes.HandleTestOnly("GET /api/v1/test/circuit-breaker/success",
 			middleware.CircuitBreakerMiddleware("test-success-service", 3, 10*time.Second)(
 				http.HandlerFunc(apiRouter.HandleCircuitBreakerSuccess)))
-		mux.Handle("GET /api/v1/test/circuit-breaker/failure",
+		routes.HandleTestOnly("GET /api/v1/test/circuit-breaker/failure",
 			middleware.CircuitBreakerMiddleware("test-failure-service", 2, 10*time.Second)(
 				http.HandlerFunc(apiRouter.HandleCircuitBreakerFailure)))
-		mux.Handle("GET /api/v1/test/circuit-breaker/timeout",
+		routes.HandleTestOnly("GET /api/v1/test/circuit-breaker/timeout",
 			middleware.CircuitBreakerMiddleware("test-timeout-service", 2, 10*time.Second)(
 				http.HandlerFunc(apiRouter.HandleCircuitBreakerTimeout)))
-	} else {
-		utils.Warn("skipping API routes registration due to missing database")
+	}
+
+	// Cancel slow requests with a 504 once their route's deadline passes
+	var apiHandler http.Handler = mux
+	if cfg.RequestTimeout.Enabled {
+		apiHandler = middleware.TimeoutMiddleware(middleware.TimeoutOptions{
+			Read:         cfg.RequestTimeout.Read,
+			Write:        cfg.RequestTimeout.Write,
+			Routes:       cfg.RequestTimeout.Routes,
+			WriteTimeout: cfg.Server.WriteTimeout,
+			Route: func(r *http.Request) string {
+				_, pattern := mux.Handler(r)
+				return pattern
+			},
+		}, metricsCollector)(apiHandler)
+	}
+
+	// Limit concurrent requests per route group, so a flood of exports cannot starve transfers.
+	// Requests wait for a slot outside their deadline and are rejected with a 503 when none frees up
+	if cfg.Bulkhead.Enabled {
+		apiHandler = middleware.BulkheadMiddleware(middleware.BulkheadOptions{
+			Limits:       cfg.Bulkhead.Limits,
+			QueueSize:    cfg.Bulkhead.QueueSize,
+			QueueTimeout: cfg.Bulkhead.QueueTimeout,
+			Routes:       cfg.Bulkhead.Routes,
+			Route: func(r *http.Request) string {
+				_, pattern := mux.Handler(r)
+				return pattern
+			},
+		}, metricsCollector)(apiHandler)
+	}
+
+	// Record money-movement requests and responses into the audit store when enabled
+	if cfg.RequestLog.Enabled && repos != nil {
+		apiHandler = middleware.RequestLogMiddleware(repos.Audit, middleware.RequestLogOptions{
+			MaxBodyBytes: cfg.RequestLog.MaxBodyBytes,
+			RedactFields: cfg.RequestLog.RedactFields,
+		})(apiHandler)
+	}
+
+	// Translate error messages into the language the client asks for; request logs keep English
+	apiHandler = middleware.LocaleMiddleware(apiHandler)
+
+	// Compress large JSON and CSV responses; recorded request logs stay uncompressed
+	if cfg.Compression.Enabled {
+		apiHandler = middleware.CompressionMiddleware(middleware.CompressionOptions{
+			MinBytes:     cfg.Compression.MinBytes,
+			Level:        cfg.Compression.Level,
+			ContentTypes: cfg.Compression.ContentTypes,
+		})(apiHandler)
 	}
 
 	// Basic server setup with OpenTelemetry tracing, metrics and logging middleware
-	server := &http.Server{
-		Addr: cfg.GetAddr(),
-		Handler: middleware.LoggingMiddleware(
-			middleware.TracingMiddleware("go-banking-sim")(
-				middleware.MetricsMiddleware(metricsCollector)(mux),
+	server, redirectServer := newServer(cfg, middleware.LoggingMiddleware(
+		middleware.TracingMiddleware("go-banking-sim")(
+			middleware.MetricsMiddleware(metricsCollector)(
+				middleware.DependencyCircuitBreakerMiddleware(dbBreaker)(apiHandler),
 			),
 		),
-	}
+	))
 
 	// Channel to listen for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -311,19 +671,96 @@ func main() {
 		projectorWorker.Start(60 * time.Second) // Process events every 60 seconds
 	}
 
+	// Start cache warm-up worker if available
+	if cacheWarmupWorker != nil {
+		cacheWarmupWorker.Start(1 * time.Minute) // Warm on boot, then re-warm within a minute of a flush
+	}
+
+	// Start request log retention worker if available
+	if requestLogRetentionWorker != nil {
+		requestLogRetentionWorker.Start(1 * time.Hour)
+	}
+
+	// Start notification dispatch worker if available
+	if notificationDispatchWorker != nil {
+		notificationDispatchWorker.Start(cfg.Notifications.DispatchInterval)
+	}
+
+	// Start invariant check worker if available
+	if invariantCheckWorker != nil {
+		invariantCheckWorker.Start(1 * time.Minute)
+	}
+
+	// Start netting worker if available
+	if nettingWorker != nil {
+		nettingWorker.Start(cfg.Netting.Interval)
+	}
+
+	// Start currency refresh worker if available
+	if currencyRefreshWorker != nil {
+		currencyRefreshWorker.Start(1 * time.Minute) // Pick up registry changes made through other instances
+	}
+
+	// Start policy refresh worker if available
+	if policyRefreshWorker != nil {
+		policyRefreshWorker.Start(1 * time.Minute) // Pick up policy versions stored through other instances
+	}
+
+	// Start monthly summary worker if available
+	if monthlySummaryWorker != nil {
+		monthlySummaryWorker.Start(cfg.MonthlySummaries.Interval)
+	}
+
+	// Start dormancy worker if available
+	if dormancyWorker != nil {
+		dormancyWorker.Start(cfg.Dormancy.Interval)
+	}
+
+	// Start archive worker if available
+	if archiveWorker != nil {
+		archiveWorker.Start(cfg.Archive.Interval)
+	}
+
+	// Start event backup worker if available
+	if eventBackupWorker != nil {
+		eventBackupWorker.Start(cfg.Backup.Interval)
+	}
+
+	// Relay transaction status updates broadcast by other instances
+	statusCtx, statusCancel := context.WithCancel(context.Background())
+	go statusBroker.Run(statusCtx)
+
+	// Drop local cache entries invalidated by other instances
+	invalidationCtx, invalidationCancel := context.WithCancel(context.Background())
+	go localCache.Run(invalidationCtx)
+
 	// Start server in goroutine
 	go func() {
 		utils.Info("server starting",
 			slog.String("addr", cfg.GetAddr()),
 			slog.String("env", cfg.Environment),
+			slog.String("storage", cfg.Storage),
+			slog.Bool("tls", cfg.Server.TLS.Enabled),
 		)
 
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := listenAndServe(server, cfg.Server.TLS); err != nil && err != http.ErrServerClosed {
 			utils.Error("server failed to start", slog.String("error", err.Error()))
 			os.Exit(1)
 		}
 	}()
 
+	// Start HTTP to HTTPS redirect server if configured
+	if redirectServer != nil {
+		go func() {
+			utils.Info("redirect server starting", slog.String("addr", redirectServer.Addr))
+
+			if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				utils.Error("redirect server failed to start", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	<-quit
 	utils.Info("shutting down server")
@@ -355,11 +792,119 @@ func main() {
 		shutdownCancel()
 	}
 
+	// Stop cache warm-up worker gracefully
+	if cacheWarmupWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := cacheWarmupWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("cache warm-up worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop request log retention worker gracefully
+	if requestLogRetentionWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := requestLogRetentionWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("request log retention worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop notification dispatch worker gracefully
+	if notificationDispatchWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := notificationDispatchWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("notification dispatch worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop invariant check worker gracefully
+	if invariantCheckWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := invariantCheckWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("invariant check worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop netting worker gracefully
+	if nettingWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := nettingWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("netting worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop currency refresh worker gracefully
+	if currencyRefreshWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := currencyRefreshWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("currency refresh worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop policy refresh worker gracefully
+	if policyRefreshWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := policyRefreshWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("policy refresh worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop monthly summary worker gracefully
+	if monthlySummaryWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := monthlySummaryWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("monthly summary worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop dormancy worker gracefully
+	if dormancyWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := dormancyWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("dormancy worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop archive worker gracefully
+	if archiveWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := archiveWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("archive worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop event backup worker gracefully
+	if eventBackupWorker != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := eventBackupWorker.Stop(shutdownCtx); err != nil {
+			utils.Error("event backup worker shutdown error", slog.String("error", err.Error()))
+		}
+		shutdownCancel()
+	}
+
+	// Stop relaying transaction status updates and cache invalidations
+	statusCancel()
+	invalidationCancel()
+
 	// Create context with 5 second timeout for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// Attempt graceful shutdown
+	if redirectServer != nil {
+		if err := redirectServer.Shutdown(ctx); err != nil {
+			utils.Error("redirect server forced to shutdown", slog.String("error", err.Error()))
+		}
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		utils.Error("server forced to shutdown", slog.String("error", err.Error()))
 		os.Exit(1)
@@ -367,3 +912,28 @@ func main() {
 
 	utils.Info("server stopped gracefully")
 }
+
+// amountLimits converts the configured amount limits to the limits request validation consults.
+func amountLimits(configured config.AmountLimitsConfig) domain.AmountLimits {
+	limits := make(domain.AmountLimits, len(configured))
+	for currency, byType := range configured {
+		limits[currency] = make(map[domain.TransactionType]domain.AmountLimit, len(byType))
+		for txType, limit := range byType {
+			limits[currency][domain.TransactionType(txType)] = domain.AmountLimit{Min: limit.Min, Max: limit.Max}
+		}
+	}
+	return limits
+}
+
+// dbPoolConfig converts the configured pool settings to the settings the repository connects with.
+// Pool metrics are published when metrics is set.
+func dbPoolConfig(configured config.DBPoolConfig, metrics *utils.MetricsCollector) repository.PoolConfig {
+	return repository.PoolConfig{
+		MaxConns:          int32(configured.MaxConns),
+		MinConns:          int32(configured.MinConns),
+		MaxConnLifetime:   configured.MaxConnLifetime,
+		MaxConnIdleTime:   configured.MaxConnIdleTime,
+		HealthCheckPeriod: configured.HealthCheckPeriod,
+		Metrics:           metrics,
+	}
+}
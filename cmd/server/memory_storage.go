@@ -0,0 +1,14 @@
+//go:build memrepo
+
+package main
+
+import (
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/repository/memory"
+)
+
+// newMemoryRepositories creates repositories, and a unit of work over them, that keep all data in process.
+func newMemoryRepositories() (*repository.Repositories, repository.UnitOfWork, error) {
+	store := memory.NewStore()
+	return store.Repositories(), store.UnitOfWork(), nil
+}
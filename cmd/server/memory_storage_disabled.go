@@ -0,0 +1,14 @@
+//go:build !memrepo
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// newMemoryRepositories reports that the in-memory repositories were not compiled in.
+func newMemoryRepositories() (*repository.Repositories, repository.UnitOfWork, error) {
+	return nil, nil, fmt.Errorf("memory storage is not available in this build; build the server with -tags memrepo")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/config"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/seed"
+	"github.com/sefa-b/go-banking-sim/internal/service"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// runSeed implements the seed subcommand, which loads a fixture file into the database, and
+// returns the process exit code.
+func runSeed(args []string) int {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file; environment variables override its values")
+	fixturePath := flags.String("file", "scripts/seed.yaml", "path to the YAML fixture file to load")
+	_ = flags.Parse(args) // ExitOnError exits on invalid flags
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if cfg.Storage != config.StoragePostgres {
+		// A memory store lives in the server process, so there is nothing to seed from here
+		fmt.Fprintf(os.Stderr, "seeding requires %s storage, got %s\n", config.StoragePostgres, cfg.Storage)
+		return 1
+	}
+
+	fixture, err := seed.LoadFixture(*fixturePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var db *repository.DB
+	err = utils.Retry(ctx, "postgres", utils.RetryPolicy{
+		InitialBackoff: cfg.StartupRetry.InitialBackoff,
+		MaxBackoff:     cfg.StartupRetry.MaxBackoff,
+		MaxWait:        cfg.StartupRetry.MaxWait,
+	}, func(ctx context.Context) error {
+		var connectErr error
+		db, connectErr = repository.Connect(ctx, cfg.DBUrl, dbPoolConfig(cfg.DBPool, nil))
+		return connectErr
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	// Seeding uses the synchronous transaction path; the worker pool and cache are not needed
+	repos := repository.NewRepositories(db.Pool)
+	eventSvc := service.NewEventService(repos.Events)
+	transactionSvc := service.NewTransactionService(repos, service.NewBalanceService(repos), nil, eventSvc, repository.NewUnitOfWork(db.Pool))
+	seeder := seed.NewSeeder(repos, transactionSvc, service.NewScheduledTransactionService(repos, transactionSvc), service.NewTreasuryService(repos))
+
+	summary, err := seeder.Run(ctx, fixture)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seeding failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Seeded %d users, %d transactions and %d scheduled transactions from %s\n",
+		summary.Users, summary.Transactions, summary.ScheduledTransactions, *fixturePath)
+
+	currencies := make([]string, 0, len(summary.Minted))
+	for currency := range summary.Minted {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		fmt.Printf("Minted %.2f %s\n", summary.Minted[currency], currency)
+	}
+
+	return 0
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/config"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// tlsVersions maps the configurable minimum TLS versions to their crypto/tls constants.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newServer creates the HTTP server for handler with the configured timeouts, protocols and TLS.
+// With TLS and a redirect address it also returns a plain HTTP server that redirects to HTTPS
+// and, with autocert, answers the ACME HTTP challenges; otherwise redirect is nil.
+func newServer(cfg *config.Config, handler http.Handler) (server, redirect *http.Server) {
+	server = &http.Server{
+		Addr:              cfg.GetAddr(),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		Protocols:         new(http.Protocols),
+	}
+	server.Protocols.SetHTTP1(true)
+	server.Protocols.SetHTTP2(cfg.Server.HTTP2)
+	server.Protocols.SetUnencryptedHTTP2(cfg.Server.H2C)
+
+	tlsCfg := cfg.Server.TLS
+	if !tlsCfg.Enabled {
+		return server, nil
+	}
+
+	var redirectHandler http.Handler = httpsRedirectHandler(cfg.Port)
+	if len(tlsCfg.Autocert.Domains) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(tlsCfg.Autocert.Domains...),
+			Cache:      autocert.DirCache(tlsCfg.Autocert.CacheDir),
+			Email:      tlsCfg.Autocert.Email,
+		}
+		server.TLSConfig = manager.TLSConfig()
+		redirectHandler = manager.HTTPHandler(redirectHandler)
+	} else {
+		server.TLSConfig = &tls.Config{}
+	}
+	server.TLSConfig.MinVersion = tlsVersions[tlsCfg.MinVersion] // Validated by config
+
+	if tlsCfg.RedirectAddr != "" {
+		redirect = &http.Server{
+			Addr:              tlsCfg.RedirectAddr,
+			Handler:           redirectHandler,
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+			ReadTimeout:       cfg.Server.ReadTimeout,
+			WriteTimeout:      cfg.Server.WriteTimeout,
+			IdleTimeout:       cfg.Server.IdleTimeout,
+		}
+	}
+
+	return server, redirect
+}
+
+// listenAndServe serves the server over TLS when it is enabled, with the certificate files or
+// autocert certificates newServer configured, and over plain HTTP otherwise.
+func listenAndServe(server *http.Server, tlsCfg config.TLSConfig) error {
+	if !tlsCfg.Enabled {
+		return server.ListenAndServe()
+	}
+	// Empty file names make the server use the autocert certificates of its TLS config
+	return server.ListenAndServeTLS(tlsCfg.CertFile, tlsCfg.KeyFile)
+}
+
+// httpsRedirectHandler permanently redirects requests to the same URL over HTTPS on port.
+// 308 is used so clients repeat non-GET requests with the same method and body.
+func httpsRedirectHandler(port string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	}
+}
@@ -3,10 +3,17 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/auth"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
 
 // ContextKey is a type for context keys to avoid collisions.
@@ -49,8 +56,42 @@ func AuthMiddleware(jwtManager *auth.JWTManager) func(http.Handler) http.Handler
 				return
 			}
 
+			// Reject tokens of revoked login sessions
+			if err := jwtManager.CheckSession(r.Context(), claims); err != nil {
+				if errors.Is(err, auth.ErrSessionRevoked) {
+					writeUnauthorized(w, "session revoked")
+					return
+				}
+				utils.ErrorContext(r.Context(), "failed to check login session", "error", err.Error())
+				respond.Error(w, http.StatusServiceUnavailable, "Service temporarily unavailable")
+				return
+			}
+
+			// Count the call against the user's API quota. The quota is soft: calls are let through
+			// while usage cannot be counted
+			if err := jwtManager.MeterCall(r.Context(), claims, string(domain.ClassifyEndpoint(r.Method, r.URL.Path))); err != nil {
+				var quotaErr *auth.QuotaExceededError
+				if errors.As(err, &quotaErr) {
+					writeQuotaExceeded(w, quotaErr)
+					return
+				}
+				utils.WarnContext(r.Context(), "failed to meter API call", "error", err.Error())
+			}
+
 			// Add user claims to request context
 			ctx := context.WithValue(r.Context(), UserContextKey, claims)
+			setUserLogFields(ctx, r, claims)
+
+			// Mark requests made with an impersonation token so downstream audit logs are flagged
+			if claims.IsImpersonation() {
+				ctx = auth.WithImpersonation(ctx, claims)
+				utils.InfoContext(ctx, "impersonated request",
+					"session_id", claims.ID,
+					"impersonator_id", claims.ImpersonatorID.String(),
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+			}
 			r = r.WithContext(ctx)
 
 			// Continue to next handler
@@ -73,9 +114,10 @@ func OptionalAuthMiddleware(jwtManager *auth.JWTManager) func(http.Handler) http
 					token := strings.TrimPrefix(authHeader, bearerPrefix)
 					if token != "" {
 						// Try to validate token
-						if claims, err := jwtManager.ValidateAccessToken(token); err == nil {
+						if claims, err := jwtManager.ValidateAccessToken(token); err == nil && jwtManager.CheckSession(r.Context(), claims) == nil {
 							// Add user claims to request context if valid
 							ctx := context.WithValue(r.Context(), UserContextKey, claims)
+							setUserLogFields(ctx, r, claims)
 							r = r.WithContext(ctx)
 						}
 					}
@@ -108,10 +150,22 @@ func RequireUser(next http.Handler) http.Handler {
 	})
 }
 
+// writeQuotaExceeded writes a 429 Too Many Requests response for a user who used up their API quota.
+func writeQuotaExceeded(w http.ResponseWriter, err *auth.QuotaExceededError) {
+	w.Header().Set("X-Quota-Limit", strconv.FormatInt(err.Limit, 10))
+	w.Header().Set("X-Quota-Reset", err.ResetAt.UTC().Format(time.RFC3339))
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(err.ResetAt).Seconds()))))
+	respond.Write(w, respond.New(http.StatusTooManyRequests, "API quota exceeded").With("reset_at", err.ResetAt.UTC()))
+}
+
 // writeUnauthorized writes a 401 Unauthorized response.
 func writeUnauthorized(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	response := `{"error":"` + message + `","code":401}`
-	_, _ = w.Write([]byte(response))
+	respond.Error(w, http.StatusUnauthorized, message)
+}
+
+// setUserLogFields records the authenticated user and matched route for request logging.
+func setUserLogFields(ctx context.Context, r *http.Request, claims *auth.Claims) {
+	logFields := utils.LogFieldsFromContext(ctx)
+	logFields.SetUserID(claims.UserID.String())
+	logFields.SetRoute(r.Pattern)
 }
@@ -2,13 +2,16 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/auth"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 func TestAuthMiddleware(t *testing.T) {
@@ -119,6 +122,122 @@ func TestAuthMiddleware(t *testing.T) {
 	}
 }
 
+// sessionChecker is an auth.SessionChecker over fixed revoked sessions that fails for sessions
+// mapped to an error.
+type sessionChecker map[uuid.UUID]error
+
+func (c sessionChecker) SessionRevoked(_ context.Context, sessionID uuid.UUID) (bool, error) {
+	err, ok := c[sessionID]
+	if !ok || err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func TestAuthMiddlewareRejectsRevokedSessions(t *testing.T) {
+	jwtManager := auth.NewJWTManager("test-secret", "test-issuer")
+	active, revoked, failing := uuid.New(), uuid.New(), uuid.New()
+	jwtManager.SetSessionChecker(sessionChecker{revoked: nil, failing: errors.New("database down")})
+
+	handler := AuthMiddleware(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		sessionID      uuid.UUID
+		expectedStatus int
+	}{
+		{"active session", active, http.StatusOK},
+		{"revoked session", revoked, http.StatusUnauthorized},
+		{"session lookup fails", failing, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := jwtManager.GenerateSessionTokenPair(tt.sessionID, uuid.New(), "testuser", "test@example.com", "user")
+			if err != nil {
+				t.Fatalf("Failed to generate test tokens: %v", err)
+			}
+
+			req := httptest.NewRequest("GET", "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+// usageMeter is an auth.UsageMeter counting calls per user and class against a fixed limit per
+// user, failing for users mapped to an error.
+type usageMeter struct {
+	limits  map[uuid.UUID]int64
+	failing map[uuid.UUID]error
+	calls   map[string]int64
+}
+
+func (m *usageMeter) MeterCall(_ context.Context, userID uuid.UUID, class string) error {
+	if err := m.failing[userID]; err != nil {
+		return err
+	}
+	if limit, ok := m.limits[userID]; ok && m.calls[userID.String()] >= limit {
+		return &auth.QuotaExceededError{Limit: limit, ResetAt: time.Now().Add(time.Hour)}
+	}
+	m.calls[userID.String()]++
+	m.calls[class]++
+	return nil
+}
+
+func TestAuthMiddlewareEnforcesAPIQuotas(t *testing.T) {
+	jwtManager := auth.NewJWTManager("test-secret", "test-issuer")
+	limited, failing := uuid.New(), uuid.New()
+	meter := &usageMeter{
+		limits:  map[uuid.UUID]int64{limited: 1},
+		failing: map[uuid.UUID]error{failing: errors.New("redis down")},
+		calls:   map[string]int64{},
+	}
+	jwtManager.SetUsageMeter(meter)
+
+	handler := AuthMiddleware(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	call := func(userID uuid.UUID, method, path string) *httptest.ResponseRecorder {
+		token, err := jwtManager.GenerateAccessToken(userID, "testuser", "test@example.com", "user")
+		if err != nil {
+			t.Fatalf("Failed to generate test token: %v", err)
+		}
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := call(limited, "POST", "/api/v1/transactions/debit"); rr.Code != http.StatusOK {
+		t.Fatalf("Expected the first call within the quota to pass, got %d", rr.Code)
+	}
+	if meter.calls[string(domain.EndpointClassMoneyMovement)] != 1 {
+		t.Errorf("Expected the debit to be counted as money movement, got %v", meter.calls)
+	}
+
+	rr := call(limited, "GET", "/api/v1/balances/current")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d over the quota, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if rr.Header().Get("X-Quota-Limit") != "1" || rr.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected quota headers, got %v", rr.Header())
+	}
+
+	// The quota is soft: calls pass while usage cannot be counted
+	if rr := call(failing, "GET", "/api/v1/balances/current"); rr.Code != http.StatusOK {
+		t.Errorf("Expected a call to pass while metering fails, got %d", rr.Code)
+	}
+}
+
 func TestOptionalAuthMiddleware(t *testing.T) {
 	// Setup JWT manager
 	jwtManager := auth.NewJWTManager("test-secret", "test-issuer")
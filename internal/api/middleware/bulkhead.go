@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// Route groups requests are isolated in unless BulkheadOptions.Routes assigns another.
+const (
+	BulkheadTransactions = "transactions" // Requests changing state, such as transfers
+	BulkheadReads        = "reads"        // GET and HEAD requests
+	BulkheadAdmin        = "admin"        // Requests under /api/v1/admin/
+)
+
+// BulkheadOptions configures BulkheadMiddleware.
+type BulkheadOptions struct {
+	Limits       map[string]int             // Requests of each group served at once; groups left out or set to 0 are not limited
+	QueueSize    int                        // Requests of each group waiting for a slot; more are rejected at once
+	QueueTimeout time.Duration              // How long a queued request waits for a slot before it is rejected
+	Routes       map[string]string          // Groups of route patterns, e.g. "GET /api/v1/users"; "" exempts a route
+	Route        func(*http.Request) string // Returns the pattern a request matches, e.g. through ServeMux.Handler
+}
+
+// bulkhead bounds the requests of one route group being served and waiting.
+type bulkhead struct {
+	slots chan struct{}
+	queue chan struct{}
+}
+
+// BulkheadMiddleware isolates route groups from each other by limiting how many requests of each
+// group are served at once, so a flood of one kind of request, such as exports, cannot take every
+// connection and worker from another, such as transfers. A request finding its group full waits
+// in the group's queue for up to QueueTimeout; when the queue is full too, or the wait runs out,
+// it is answered with a 503 at once. Routes outside /api/ (health checks and metrics) are exempt.
+func BulkheadMiddleware(opts BulkheadOptions, metricsCollector *utils.MetricsCollector) func(http.Handler) http.Handler {
+	bulkheads := make(map[string]*bulkhead, len(opts.Limits))
+	for group, limit := range opts.Limits {
+		if limit > 0 {
+			bulkheads[group] = &bulkhead{
+				slots: make(chan struct{}, limit),
+				queue: make(chan struct{}, opts.QueueSize),
+			}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pattern := opts.Route(r)
+			group, ok := opts.Routes[pattern]
+			if !ok {
+				group = bulkheadGroup(r, pattern)
+			}
+			b := bulkheads[group]
+			if b == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if reason := b.acquire(r, opts.QueueTimeout); reason != "" {
+				if r.Context().Err() != nil {
+					return // The client gave up waiting
+				}
+				utils.Warn("request rejected by bulkhead",
+					"group", group,
+					"reason", reason,
+					"method", r.Method,
+					"route", routeLabel(pattern),
+				)
+				metricsCollector.RecordBulkheadRejection(group, reason)
+				w.Header().Set("Retry-After", "1")
+				respond.Write(w, respond.New(http.StatusServiceUnavailable, "Server is busy, please retry").With("group", group))
+				return
+			}
+			defer func() { <-b.slots }()
+			defer metricsCollector.TrackBulkheadSlot(group)()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bulkheadGroup returns the group of a request whose route has none configured, or "" for
+// routes outside the API.
+func bulkheadGroup(r *http.Request, pattern string) string {
+	path := routeLabel(pattern)
+	switch {
+	case !strings.HasPrefix(path, "/api/"):
+		return ""
+	case strings.HasPrefix(path, "/api/v1/admin/"):
+		return BulkheadAdmin
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return BulkheadReads
+	default:
+		return BulkheadTransactions
+	}
+}
+
+// acquire takes a slot of the bulkhead, queueing for up to timeout when all are taken. It returns
+// why the request was rejected, or "" once it holds a slot.
+func (b *bulkhead) acquire(r *http.Request, timeout time.Duration) string {
+	select {
+	case b.slots <- struct{}{}:
+		return ""
+	default:
+	}
+
+	select {
+	case b.queue <- struct{}{}:
+	default:
+		return "queue_full"
+	}
+	defer func() { <-b.queue }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case b.slots <- struct{}{}:
+		return ""
+	case <-timer.C:
+		return "queue_timeout"
+	case <-r.Context().Done():
+		return "cancelled"
+	}
+}
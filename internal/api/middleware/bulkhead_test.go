@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+func TestBulkheadMiddleware(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	block := func(w http.ResponseWriter, _ *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/transactions/history/export", block)
+	mux.HandleFunc("POST /api/v1/transactions/transfer", block)
+	mux.HandleFunc("GET /api/v1/transactions/{id}/events", block)
+	mux.HandleFunc("GET /healthz", block)
+
+	handler := BulkheadMiddleware(BulkheadOptions{
+		Limits:       map[string]int{"exports": 1, BulkheadTransactions: 1, BulkheadReads: 1},
+		QueueSize:    1,
+		QueueTimeout: 200 * time.Millisecond,
+		Routes: map[string]string{
+			"GET /api/v1/transactions/history/export": "exports",
+			"GET /api/v1/transactions/{id}/events":    "",
+		},
+		Route: func(r *http.Request) string {
+			_, pattern := mux.Handler(r)
+			return pattern
+		},
+	}, utils.NewMetricsCollector())(mux)
+
+	serve := func(method, path string) <-chan *httptest.ResponseRecorder {
+		done := make(chan *httptest.ResponseRecorder, 1)
+		go func() {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+			done <- rr
+		}()
+		return done
+	}
+	wait := func(done <-chan *httptest.ResponseRecorder) *httptest.ResponseRecorder {
+		select {
+		case rr := <-done:
+			return rr
+		case <-time.After(2 * time.Second):
+			t.Fatal("request did not complete")
+			return nil
+		}
+	}
+
+	// The export takes the only slot of its group; the next one waits in the queue and times out
+	export := serve(http.MethodGet, "/api/v1/transactions/history/export")
+	<-started
+	queued := serve(http.MethodGet, "/api/v1/transactions/history/export")
+	time.Sleep(5 * time.Millisecond)
+	if rr := wait(serve(http.MethodGet, "/api/v1/transactions/history/export")); rr.Code != http.StatusServiceUnavailable || rr.Header().Get("Retry-After") != "1" {
+		t.Errorf("export with a full queue: status = %d, Retry-After = %q; want a 503 at once", rr.Code, rr.Header().Get("Retry-After"))
+	}
+	if rr := wait(queued); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("queued export: status = %d, want 503 once the queue timeout passed", rr.Code)
+	}
+
+	// Transfers and exempt routes are served while exports are full
+	transfer := serve(http.MethodPost, "/api/v1/transactions/transfer")
+	stream := serve(http.MethodGet, "/api/v1/transactions/1/events")
+	health := serve(http.MethodGet, "/healthz")
+	for range 3 {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("request of another group was not served")
+		}
+	}
+
+	// A queued request gets the slot its group frees
+	queued = serve(http.MethodPost, "/api/v1/transactions/transfer")
+	time.Sleep(5 * time.Millisecond)
+	close(release)
+	for _, done := range []<-chan *httptest.ResponseRecorder{export, transfer, stream, health} {
+		if rr := wait(done); rr.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200", rr.Code)
+		}
+	}
+	if rr := wait(queued); rr.Code != http.StatusOK {
+		t.Errorf("queued transfer: status = %d, want 200 once the first transfer finished", rr.Code)
+	}
+}
+
+func TestBulkheadGroup(t *testing.T) {
+	tests := []struct {
+		method, pattern, want string
+	}{
+		{http.MethodPost, "POST /api/v1/transactions/transfer", BulkheadTransactions},
+		{http.MethodGet, "GET /api/v1/balances/current", BulkheadReads},
+		{http.MethodHead, "GET /api/v1/balances/current", BulkheadReads},
+		{http.MethodGet, "GET /api/v1/admin/users", BulkheadAdmin},
+		{http.MethodPost, "POST /api/v1/admin/import", BulkheadAdmin},
+		{http.MethodGet, "GET /healthz", ""},
+		{http.MethodGet, "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := bulkheadGroup(httptest.NewRequest(tt.method, "/", nil), tt.pattern); got != tt.want {
+			t.Errorf("bulkheadGroup(%s, %q) = %q, want %q", tt.method, tt.pattern, got, tt.want)
+		}
+	}
+}
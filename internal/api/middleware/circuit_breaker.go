@@ -5,8 +5,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
 
@@ -25,9 +27,7 @@ func CircuitBreakerMiddleware(serviceName string, failureThreshold int32, resetT
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Check if circuit breaker allows the request
 			if breaker.GetState() == utils.StateOpen {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusServiceUnavailable)
-				_, _ = w.Write([]byte(fmt.Sprintf(`{"error":"Service temporarily unavailable","code":503,"service":"%s"}`, serviceName)))
+				writeServiceUnavailable(w, serviceName, "")
 				return
 			}
 
@@ -59,22 +59,51 @@ func CircuitBreakerMiddleware(serviceName string, failureThreshold int32, resetT
 			if err != nil {
 				if cbErr, ok := err.(*utils.CircuitBreakerError); ok {
 					// Circuit breaker is open
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusServiceUnavailable)
-					_, _ = w.Write([]byte(fmt.Sprintf(`{"error":"Service temporarily unavailable","code":503,"service":"%s","state":"%s"}`, serviceName, cbErr.State)))
+					writeServiceUnavailable(w, serviceName, cbErr.State.String())
 					return
 				}
 				// Other error - log it and return 503
 				utils.Error("circuit breaker call failed", "error", err.Error(), "service", serviceName)
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusServiceUnavailable)
-				_, _ = w.Write([]byte(fmt.Sprintf(`{"error":"Service temporarily unavailable","code":503,"service":"%s"}`, serviceName)))
+				writeServiceUnavailable(w, serviceName, "")
 				return
 			}
 		})
 	}
 }
 
+// DependencyCircuitBreakerMiddleware rejects API requests with a fast 503 while any of the given
+// dependency breakers is open, rather than letting them queue up against an unavailable dependency.
+// Health, metrics and other non-API endpoints are always served.
+func DependencyCircuitBreakerMiddleware(breakers ...*utils.CircuitBreaker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, "/api/v1/metrics/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, breaker := range breakers {
+				if breaker != nil && breaker.IsOpen() {
+					writeServiceUnavailable(w, breaker.Name(), breaker.GetState().String())
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeServiceUnavailable writes a 503 Service Unavailable problem naming the unavailable service
+// and, when known, the state of its circuit breaker.
+func writeServiceUnavailable(w http.ResponseWriter, service, state string) {
+	problem := respond.New(http.StatusServiceUnavailable, "Service temporarily unavailable").With("service", service)
+	if state != "" {
+		problem.With("state", state)
+	}
+	respond.Write(w, problem)
+}
+
 // responseWriterWrapper wraps http.ResponseWriter to capture status codes
 type responseWriterWrapper struct {
 	http.ResponseWriter
@@ -86,6 +115,11 @@ func (w *responseWriterWrapper) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it, e.g. to flush.
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // CircuitBreakerMetricsHandler provides circuit breaker metrics endpoint
 func CircuitBreakerMetricsHandler(w http.ResponseWriter, _ *http.Request) {
 	metrics := utils.GetCircuitBreakerMetrics()
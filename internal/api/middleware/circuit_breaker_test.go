@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+func TestDependencyCircuitBreakerMiddleware(t *testing.T) {
+	breaker := utils.NewCircuitBreaker(utils.CircuitBreakerConfig{
+		Name:             "postgres",
+		FailureThreshold: 2,
+		ResetTimeout:     time.Minute,
+		CallTimeout:      time.Second,
+	})
+
+	handler := DependencyCircuitBreakerMiddleware(breaker)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(path string) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Code
+	}
+
+	if code := serve("/api/v1/me"); code != http.StatusOK {
+		t.Fatalf("closed breaker: expected %d, got %d", http.StatusOK, code)
+	}
+
+	// Open the breaker
+	for i := 0; i < 2; i++ {
+		_ = breaker.Call(context.Background(), func(_ context.Context) error {
+			return errors.New("connection refused")
+		})
+	}
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{"/api/v1/me", http.StatusServiceUnavailable},
+		{"/api/v1/metrics/circuit-breakers", http.StatusOK},
+		{"/healthz/ready", http.StatusOK},
+		{"/metrics", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		if code := serve(tt.path); code != tt.expectedStatus {
+			t.Errorf("open breaker %s: expected %d, got %d", tt.path, tt.expectedStatus, code)
+		}
+	}
+}
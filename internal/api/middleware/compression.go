@@ -0,0 +1,237 @@
+// Package middleware provides response compression.
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CompressionOptions configures CompressionMiddleware.
+type CompressionOptions struct {
+	MinBytes     int      // Responses smaller than this are sent uncompressed, as compressing them gains little
+	Level        int      // Compression level, 1 (fastest) to 9 (smallest)
+	ContentTypes []string // Media types that are compressed, e.g. application/json
+}
+
+// compressionEncodings lists the supported content codings in order of preference.
+var compressionEncodings = []string{"gzip", "deflate"}
+
+// compressor is a pooled writer for one content coding.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// CompressionMiddleware compresses responses of the allowed content types with gzip or deflate,
+// whichever the client prefers, once they reach the minimum size. Responses that already carry a
+// Content-Encoding, such as event streams, and responses to HEAD requests are left alone.
+// It must run outside RequestLogMiddleware so recorded bodies are stored uncompressed.
+func CompressionMiddleware(opts CompressionOptions) func(http.Handler) http.Handler {
+	contentTypes := make([]string, len(opts.ContentTypes))
+	for i, contentType := range opts.ContentTypes {
+		contentTypes[i] = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	pools := map[string]*sync.Pool{
+		"gzip": {New: func() any {
+			w, _ := gzip.NewWriterLevel(io.Discard, opts.Level) // The level is validated by config
+			return w
+		}},
+		"deflate": {New: func() any {
+			w, _ := zlib.NewWriterLevel(io.Discard, opts.Level)
+			return w
+		}},
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				pool:           pools[encoding],
+				minBytes:       opts.MinBytes,
+				contentTypes:   contentTypes,
+				statusCode:     http.StatusOK,
+			}
+			defer cw.close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks the preferred supported coding of an Accept-Encoding header, or ""
+// when the client accepts none of them. Codings with q=0 are refused.
+func negotiateEncoding(header string) string {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		candidates := []string{name}
+		if name == "*" {
+			candidates = compressionEncodings
+		}
+		for _, candidate := range candidates {
+			rank := slices.Index(compressionEncodings, candidate)
+			if rank < 0 || quality <= 0 {
+				continue
+			}
+			if quality > bestQuality || (quality == bestQuality && rank < slices.Index(compressionEncodings, best)) {
+				best, bestQuality = candidate, quality
+			}
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the start of a response until it knows whether to compress it: once
+// the buffer reaches the minimum size the response is compressed, and a response that ends or
+// is flushed before then is sent as is.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding     string
+	pool         *sync.Pool
+	minBytes     int
+	contentTypes []string
+
+	statusCode int
+	buf        bytes.Buffer
+	decided    bool
+	compressor compressor // Set once the response is being compressed
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.decided {
+		return
+	}
+	cw.statusCode = code
+	// Informational responses are sent straight away, as the final header follows them
+	if code >= 100 && code < 200 {
+		cw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		if !cw.compressible() {
+			if err := cw.start(false); err != nil {
+				return 0, err
+			}
+		} else {
+			cw.buf.Write(p)
+			if cw.buf.Len() < cw.minBytes {
+				return len(p), nil
+			}
+			return len(p), cw.start(true)
+		}
+	}
+
+	if cw.compressor != nil {
+		return cw.compressor.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// FlushError sends what has been written so far, deciding against compression if the minimum
+// size has not been reached yet, so streamed responses are not held back.
+func (cw *compressWriter) FlushError() error {
+	if !cw.decided {
+		if err := cw.start(false); err != nil {
+			return err
+		}
+	}
+	if cw.compressor != nil {
+		if err := cw.compressor.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// compressible reports whether the response may be compressed, judging by its status and headers.
+func (cw *compressWriter) compressible() bool {
+	if cw.statusCode < http.StatusOK || cw.statusCode == http.StatusNoContent || cw.statusCode == http.StatusNotModified {
+		return false
+	}
+
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && slices.Contains(cw.contentTypes, strings.ToLower(mediaType))
+}
+
+// start writes the header, compressed or not, followed by the buffered start of the body.
+func (cw *compressWriter) start(compress bool) error {
+	cw.decided = true
+
+	header := cw.Header()
+	if cw.compressible() {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if compress {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+
+		cw.compressor = cw.pool.Get().(compressor)
+		cw.compressor.Reset(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.compressor != nil {
+		_, err = cw.compressor.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+	return err
+}
+
+// close sends a response that stayed below the minimum size and finishes a compressed one.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		// A handler that wrote nothing still gets its status
+		_ = cw.start(false)
+	}
+	if cw.compressor != nil {
+		_ = cw.compressor.Close()
+		cw.compressor.Reset(io.Discard)
+		cw.pool.Put(cw.compressor)
+		cw.compressor = nil
+	}
+}
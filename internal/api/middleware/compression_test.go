@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                      "",
+		"gzip":                  "gzip",
+		"deflate, gzip":         "gzip",
+		"gzip;q=0.5, deflate":   "deflate",
+		"gzip;q=0, deflate;q=0": "",
+		"br":                    "",
+		"br, *":                 "gzip",
+		"identity":              "",
+	}
+	for header, want := range tests {
+		if got := negotiateEncoding(header); got != want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	large := `{"users":[` + strings.Repeat(`{"username":"alice"},`, 200) + `{}]}`
+	handler := CompressionMiddleware(CompressionOptions{MinBytes: 1024, Level: 5, ContentTypes: []string{"application/json"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/large":
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				// Written in pieces, so the decision is made partway through
+				for i := 0; i < len(large); i += 100 {
+					_, _ = w.Write([]byte(large[i:min(i+100, len(large))]))
+				}
+			case "/small":
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			case "/text":
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte(large))
+			case "/stream":
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status":"pending"}`))
+				if err := http.NewResponseController(w).Flush(); err != nil {
+					t.Errorf("Flush: %v", err)
+				}
+				_, _ = w.Write([]byte(large))
+			}
+		}))
+
+	serve := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for encoding, reader := range map[string]func(io.Reader) (io.Reader, error){
+		"gzip":    func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"deflate": func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+	} {
+		rr := serve("/large", encoding)
+		if rr.Code != http.StatusOK || rr.Header().Get("Content-Encoding") != encoding || rr.Header().Get("Vary") != "Accept-Encoding" {
+			t.Fatalf("%s: status = %d, headers = %v; want a compressed response", encoding, rr.Code, rr.Header())
+		}
+		if rr.Body.Len() >= len(large) {
+			t.Errorf("%s: compressed body is %d bytes, not smaller than %d", encoding, rr.Body.Len(), len(large))
+		}
+		decompressed, err := reader(rr.Body)
+		if err != nil {
+			t.Fatalf("%s: %v", encoding, err)
+		}
+		if body, err := io.ReadAll(decompressed); err != nil || string(body) != large {
+			t.Errorf("%s: decompressed body differs: %v", encoding, err)
+		}
+	}
+
+	if rr := serve("/large", ""); rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != large {
+		t.Errorf("without Accept-Encoding: headers = %v, want an uncompressed response", rr.Header())
+	}
+	if rr := serve("/small", "gzip"); rr.Code != http.StatusCreated || rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != `{"ok":true}` {
+		t.Errorf("small response: status = %d, headers = %v, body = %q; want it sent as is", rr.Code, rr.Header(), rr.Body.String())
+	}
+	if rr := serve("/text", "gzip"); rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != large {
+		t.Errorf("text response: headers = %v, want content types outside the allowlist sent as is", rr.Header())
+	}
+	if rr := serve("/stream", "gzip"); rr.Header().Get("Content-Encoding") != "" || rr.Body.String() != `{"status":"pending"}`+large || !rr.Flushed {
+		t.Errorf("flushed response: headers = %v, flushed = %v; want it sent as is", rr.Header(), rr.Flushed)
+	}
+}
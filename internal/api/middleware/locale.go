@@ -0,0 +1,144 @@
+// Package middleware provides per-request languages for error messages.
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/i18n"
+)
+
+// LocaleMiddleware negotiates the language of each request from its Accept-Language header and
+// stores it in the request context for i18n.T. Handlers keep writing English messages: in any
+// other language the title and detail of a problem response and the message of each of its
+// validation errors are translated on the way out. Successful responses pass through untouched.
+// It must run inside CompressionMiddleware so it sees uncompressed bodies.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", string(language))
+
+		r = r.WithContext(i18n.WithLanguage(r.Context(), language))
+		if language == i18n.English {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		lw := &localeWriter{ResponseWriter: w, language: language, statusCode: http.StatusOK}
+		defer lw.close()
+
+		next.ServeHTTP(lw, r)
+	})
+}
+
+// localeWriter holds back problem responses until they are complete, so their messages can be
+// translated, and passes every other response straight through.
+type localeWriter struct {
+	http.ResponseWriter
+	language i18n.Language
+
+	statusCode int
+	buf        bytes.Buffer
+	decided    bool
+	buffering  bool // Set once the response is known to be a problem
+}
+
+func (lw *localeWriter) WriteHeader(code int) {
+	if lw.decided {
+		return
+	}
+	lw.statusCode = code
+	// Informational responses are sent straight away, as the final header follows them
+	if code >= 100 && code < 200 {
+		lw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	lw.decide()
+}
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it.
+func (lw *localeWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
+
+func (lw *localeWriter) Write(p []byte) (int, error) {
+	if !lw.decided {
+		lw.decide()
+	}
+	if lw.buffering {
+		return lw.buf.Write(p)
+	}
+	return lw.ResponseWriter.Write(p)
+}
+
+// FlushError sends a response that is not being held back for translation.
+func (lw *localeWriter) FlushError() error {
+	if !lw.decided {
+		lw.decide()
+	}
+	if lw.buffering {
+		return nil
+	}
+	return http.NewResponseController(lw.ResponseWriter).Flush()
+}
+
+// decide holds back a problem response, and writes the header of any other one.
+func (lw *localeWriter) decide() {
+	lw.decided = true
+
+	mediaType, _, err := mime.ParseMediaType(lw.Header().Get("Content-Type"))
+	if lw.statusCode >= http.StatusBadRequest && err == nil && mediaType == respond.ContentType {
+		lw.buffering = true
+		return
+	}
+	lw.ResponseWriter.WriteHeader(lw.statusCode)
+}
+
+// close translates and sends a held back problem response.
+func (lw *localeWriter) close() {
+	if !lw.decided {
+		// A handler that wrote nothing still gets its status
+		lw.decide()
+	}
+	if !lw.buffering {
+		return
+	}
+
+	body := translateProblem(lw.buf.Bytes(), lw.language)
+	lw.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	lw.ResponseWriter.WriteHeader(lw.statusCode)
+	_, _ = lw.ResponseWriter.Write(body)
+}
+
+// translateProblem translates the title and detail of a problem and the message of each of its
+// errors, keeping every other member. A body that is not a problem is returned as is.
+func translateProblem(body []byte, language i18n.Language) []byte {
+	problem, err := respond.Decode(body)
+	if err != nil {
+		return body
+	}
+	problem.Title = i18n.Translate(language, problem.Title)
+	problem.Detail = i18n.Translate(language, problem.Detail)
+
+	var errors []map[string]json.RawMessage
+	if raw, ok := problem.Extensions["errors"].(json.RawMessage); ok && json.Unmarshal(raw, &errors) == nil {
+		for _, validationErr := range errors {
+			var msg string
+			if json.Unmarshal(validationErr["message"], &msg) == nil {
+				validationErr["message"], _ = json.Marshal(i18n.Translate(language, msg))
+			}
+		}
+		problem.With("errors", errors)
+	}
+
+	translated, err := problem.Encode()
+	if err != nil {
+		return body
+	}
+	return translated
+}
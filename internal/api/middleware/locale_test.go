@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/i18n"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	handler := LocaleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/error":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"about:blank","title":"Not Found",`))
+			_, _ = w.Write([]byte(`"status":404,"detail":"Transaction not found"}`))
+		case "/validation":
+			writeValidationError(w, []ValidationError{
+				{Field: "amount", Message: "amount must be greater than 0"},
+				{Field: "currency", Message: "unsupported currency: XYZ"},
+			})
+		case "/ok":
+			w.Header().Set("Content-Type", "application/problem+json")
+			_, _ = w.Write([]byte(`{"detail":"Transaction not found"}`))
+		case "/text":
+			http.Error(w, "Access denied", http.StatusForbidden)
+		case "/language":
+			_, _ = w.Write([]byte(i18n.FromContext(r.Context())))
+		}
+	}))
+
+	serve := func(path, acceptLanguage string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("error translated", func(t *testing.T) {
+		rec := serve("/error", "tr-TR,tr;q=0.9")
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", rec.Code)
+		}
+		if got := rec.Header().Get("Content-Language"); got != "tr" {
+			t.Errorf("Content-Language = %q, want tr", got)
+		}
+		if got := rec.Header().Get("Vary"); got != "Accept-Language" {
+			t.Errorf("Vary = %q, want Accept-Language", got)
+		}
+		var body respond.Problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+		}
+		if body.Title != "Bulunamadı" || body.Detail != "İşlem bulunamadı" || body.Status != 404 || body.Type != "about:blank" {
+			t.Errorf("body = %+v", body)
+		}
+	})
+
+	t.Run("validation errors translated", func(t *testing.T) {
+		rec := serve("/validation", "tr")
+		var body ValidationResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+		}
+		if body.Detail != "doğrulama başarısız oldu" || body.Status != 422 || len(body.Errors) != 2 {
+			t.Fatalf("body = %+v", body)
+		}
+		if body.Errors[0].Field != "amount" || body.Errors[0].Message != "tutar 0'dan büyük olmalı" {
+			t.Errorf("errors[0] = %+v", body.Errors[0])
+		}
+		if body.Errors[1].Message != "desteklenmeyen para birimi: XYZ" {
+			t.Errorf("errors[1] = %+v", body.Errors[1])
+		}
+	})
+
+	t.Run("english untouched", func(t *testing.T) {
+		rec := serve("/error", "")
+		if got := rec.Body.String(); got != `{"type":"about:blank","title":"Not Found","status":404,"detail":"Transaction not found"}` {
+			t.Errorf("body = %q", got)
+		}
+		if got := rec.Header().Get("Content-Language"); got != "en" {
+			t.Errorf("Content-Language = %q, want en", got)
+		}
+	})
+
+	t.Run("success and non-JSON untouched", func(t *testing.T) {
+		if got := serve("/ok", "tr").Body.String(); got != `{"detail":"Transaction not found"}` {
+			t.Errorf("success body = %q", got)
+		}
+		rec := serve("/text", "tr")
+		if rec.Code != http.StatusForbidden || rec.Body.String() != "Access denied\n" {
+			t.Errorf("text response = %d %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("language in context", func(t *testing.T) {
+		if got := serve("/language", "de, tr;q=0.5").Body.String(); got != "tr" {
+			t.Errorf("language = %q, want tr", got)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,11 +19,21 @@ type contextKey string
 // requestIDKey is the context key for request ID
 const requestIDKey contextKey = "request_id"
 
-// MetricsMiddleware creates middleware that records HTTP request metrics.
+// MetricsMiddleware creates middleware that records RED metrics (rate, errors, duration) per route
+// template and the number of requests in flight. Raw paths are never used as labels, so IDs in
+// URLs don't create a series per resource.
 func MetricsMiddleware(metricsCollector *utils.MetricsCollector) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Skip /healthz and /metrics endpoints to avoid recursion
+			if r.URL.Path == "/healthz" || r.URL.Path == "/metrics" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			start := time.Now()
+			done := metricsCollector.TrackHTTPRequest(r.Method)
+			defer done()
 
 			// Create a response writer wrapper to capture status code
 			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
@@ -30,17 +41,36 @@ func MetricsMiddleware(metricsCollector *utils.MetricsCollector) func(http.Handl
 			// Call the next handler
 			next.ServeHTTP(rw, r)
 
-			// Calculate duration
-			duration := time.Since(start)
-
-			// Record metrics (skip /healthz and /metrics endpoints to avoid recursion)
-			if r.URL.Path != "/healthz" && r.URL.Path != "/metrics" {
-				metricsCollector.RecordHTTPRequest(r.Method, r.URL.Path, rw.statusCode, duration)
+			// The mux has set the matched pattern on r by now, unless a handler saw a copy of r;
+			// authenticated routes also record it in the log fields
+			fields := utils.LogFieldsFromContext(r.Context())
+			pattern := r.Pattern
+			if pattern == "" {
+				pattern = fields.Route()
+			} else {
+				fields.SetRoute(pattern)
 			}
+
+			metricsCollector.RecordHTTPRequest(r.Context(), r.Method, routeLabel(pattern), rw.statusCode, time.Since(start))
 		})
 	}
 }
 
+// unmatchedRoute labels requests that matched no route, so scans of random paths share one series.
+const unmatchedRoute = "unmatched"
+
+// routeLabel returns the route template of a mux pattern without its method, e.g.
+// "/api/v1/users/{id}" for "GET /api/v1/users/{id}".
+func routeLabel(pattern string) string {
+	if pattern == "" {
+		return unmatchedRoute
+	}
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return strings.TrimSpace(path)
+	}
+	return pattern
+}
+
 // LoggingMiddleware creates middleware that logs HTTP requests with structured logging.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -55,9 +85,14 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		// Create a response writer wrapper to capture status code
 		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
-		// Add request ID to request context for downstream use
+		// Add request ID to request context for downstream use; it also correlates async work and events
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, requestIDKey, requestID)
+		ctx = utils.WithCorrelationID(ctx, requestID)
+
+		// Attach log fields that inner middleware fill in (user, route, trace)
+		ctx, logFields := utils.WithLogFields(ctx)
+		logFields.SetRequestID(requestID)
 		r = r.WithContext(ctx)
 
 		// Call the next handler
@@ -66,9 +101,8 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		// Calculate duration
 		duration := time.Since(start)
 
-		// Log the request
-		utils.Info("http_request",
-			"request_id", requestID,
+		// Log the request; request_id, user_id, route and trace_id come from the context
+		utils.InfoContext(ctx, "http_request",
 			"method", r.Method,
 			"path", r.URL.Path,
 			"query", r.URL.RawQuery,
@@ -104,6 +138,7 @@ func TracingMiddleware(serviceName string) func(http.Handler) http.Handler {
 			if traceID != "" {
 				w.Header().Set("X-Trace-ID", traceID)
 			}
+			utils.LogFieldsFromContext(ctx).SetTraceID(traceID)
 
 			// Call next handler with the span context
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -121,3 +156,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it, e.g. to flush.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
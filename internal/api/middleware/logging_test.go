@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+func TestRouteLabel(t *testing.T) {
+	tests := map[string]string{
+		"GET /api/v1/users/{id}": "/api/v1/users/{id}",
+		"/api/v1/metrics/basic":  "/api/v1/metrics/basic",
+		"":                       unmatchedRoute,
+	}
+	for pattern, want := range tests {
+		if got := routeLabel(pattern); got != want {
+			t.Errorf("routeLabel(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestMetricsMiddlewareLabelsRouteTemplate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/users/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	handler := MetricsMiddleware(utils.NewMetricsCollector())(mux)
+
+	for _, path := range []string{"/api/v1/users/1", "/api/v1/users/2", "/no/such/route"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	requests := gatherCounters(t, "banking_http_requests_total")
+	if got := requests["/api/v1/users/{id}"]; got != 2 {
+		t.Errorf("requests to the route template = %v, want 2", got)
+	}
+	if got := requests[unmatchedRoute]; got != 1 {
+		t.Errorf("unmatched requests = %v, want 1", got)
+	}
+	for endpoint := range requests {
+		if endpoint == "/api/v1/users/1" || endpoint == "/api/v1/users/2" {
+			t.Errorf("raw path %q used as a label", endpoint)
+		}
+	}
+
+	if got := gatherCounters(t, "banking_http_request_errors_total")["/api/v1/users/{id}"]; got != 2 {
+		t.Errorf("errors on the route template = %v, want 2", got)
+	}
+}
+
+// gatherCounters returns the values of a counter from the default registry, summed by endpoint.
+func gatherCounters(t *testing.T, name string) map[string]float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("gather metrics: %v", err)
+	}
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "endpoint" {
+					values[label.GetValue()] += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return values
+}
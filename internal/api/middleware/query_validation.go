@@ -0,0 +1,186 @@
+// Package middleware provides reusable validators of query parameters.
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QueryRule checks some of the query parameters of a request, returning an error for each invalid
+// one. Absent parameters are valid unless the rule says otherwise.
+type QueryRule func(query url.Values) []ValidationError
+
+// Query combines rules into a validator for ValidateQueryParams, so a route declares the query
+// parameters it accepts once and every invalid one is reported in the same 422 response, e.g.
+//
+//	ValidateQueryParams(Query(Pagination(100), Enum("status", "open", "resolved")))
+//
+// Handlers then read the validated values with QueryInt, QueryTime and the like.
+func Query(rules ...QueryRule) func(*http.Request) []ValidationError {
+	rule := All(rules...)
+	return func(r *http.Request) []ValidationError {
+		return rule(r.URL.Query())
+	}
+}
+
+// MaxPageLimit is the largest page most list endpoints serve.
+const MaxPageLimit = 100
+
+// NoMax leaves an Int parameter without an upper bound.
+const NoMax = math.MaxInt32
+
+// Pagination accepts limit between 1 and maxLimit and a non-negative offset.
+func Pagination(maxLimit int) QueryRule {
+	return All(Int("limit", 1, maxLimit), Int("offset", 0, NoMax))
+}
+
+// Int accepts an integer parameter between min and max inclusive.
+func Int(param string, min, max int) QueryRule {
+	return func(query url.Values) []ValidationError {
+		value := query.Get(param)
+		if value == "" {
+			return nil
+		}
+		if n, err := strconv.Atoi(value); err == nil && n >= min && n <= max {
+			return nil
+		}
+
+		var message string
+		switch {
+		case max == NoMax && min == 0:
+			message = param + " must be a non-negative integer"
+		case max == NoMax:
+			message = fmt.Sprintf("%s must be an integer of at least %d", param, min)
+		default:
+			message = fmt.Sprintf("%s must be an integer between %d and %d", param, min, max)
+		}
+		return []ValidationError{{Field: param, Message: message}}
+	}
+}
+
+// Enum accepts a parameter that is one of the allowed values.
+func Enum(param string, allowed ...string) QueryRule {
+	return func(query url.Values) []ValidationError {
+		value := query.Get(param)
+		if value == "" {
+			return nil
+		}
+		for _, candidate := range allowed {
+			if value == candidate {
+				return nil
+			}
+		}
+		return []ValidationError{{Field: param, Message: param + " must be one of " + strings.Join(allowed, ", ")}}
+	}
+}
+
+// Bool accepts true or false.
+func Bool(param string) QueryRule {
+	return Enum(param, "true", "false")
+}
+
+// UUID accepts a parameter holding a UUID.
+func UUID(param string) QueryRule {
+	return func(query url.Values) []ValidationError {
+		if value := query.Get(param); value != "" {
+			if _, err := uuid.Parse(value); err != nil {
+				return []ValidationError{{Field: param, Message: param + " must be a UUID"}}
+			}
+		}
+		return nil
+	}
+}
+
+// Check accepts a parameter that check accepts, reporting the error of check otherwise. It lets
+// routes validate parameters with the parsers of their domain types.
+func Check(param string, check func(value string) error) QueryRule {
+	return func(query url.Values) []ValidationError {
+		if value := query.Get(param); value != "" {
+			if err := check(value); err != nil {
+				return []ValidationError{{Field: param, Message: err.Error()}}
+			}
+		}
+		return nil
+	}
+}
+
+// Timestamp accepts an RFC 3339 timestamp.
+func Timestamp(param string) QueryRule {
+	return func(query url.Values) []ValidationError {
+		if value := query.Get(param); value != "" {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return []ValidationError{{Field: param, Message: param + " must be an RFC 3339 timestamp"}}
+			}
+		}
+		return nil
+	}
+}
+
+// TimeRange accepts a range of RFC 3339 timestamps, either end of which may be left open. When
+// both are given, the end must be after the start.
+func TimeRange(from, to string) QueryRule {
+	return func(query url.Values) []ValidationError {
+		errors := append(Timestamp(from)(query), Timestamp(to)(query)...)
+		if len(errors) > 0 {
+			return errors
+		}
+
+		start, end := queryTime(query, from), queryTime(query, to)
+		if start != nil && end != nil && !end.After(*start) {
+			return []ValidationError{{Field: to, Message: to + " must be after " + from}}
+		}
+		return nil
+	}
+}
+
+// All joins rules into one, so routes can share the parameters they have in common.
+func All(rules ...QueryRule) QueryRule {
+	return func(query url.Values) []ValidationError {
+		var errors []ValidationError
+		for _, rule := range rules {
+			errors = append(errors, rule(query)...)
+		}
+		return errors
+	}
+}
+
+// QueryInt returns an integer query parameter checked by Int or Pagination, or def when it is absent.
+func QueryInt(r *http.Request, param string, def int) int {
+	if n, err := strconv.Atoi(r.URL.Query().Get(param)); err == nil {
+		return n
+	}
+	return def
+}
+
+// QueryPage returns the limit and offset checked by Pagination, defaulting to defaultLimit and 0.
+func QueryPage(r *http.Request, defaultLimit int) (limit, offset int) {
+	return QueryInt(r, "limit", defaultLimit), QueryInt(r, "offset", 0)
+}
+
+// QueryTime returns a timestamp query parameter checked by Timestamp or TimeRange, or nil when it
+// is absent.
+func QueryTime(r *http.Request, param string) *time.Time {
+	return queryTime(r.URL.Query(), param)
+}
+
+// queryTime returns a timestamp parameter of query, or nil when it is absent or invalid.
+func queryTime(query url.Values, param string) *time.Time {
+	t, err := time.Parse(time.RFC3339, query.Get(param))
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// QueryUUID returns a UUID query parameter checked by UUID, or uuid.Nil when it is absent.
+func QueryUUID(r *http.Request, param string) uuid.UUID {
+	id, _ := uuid.Parse(r.URL.Query().Get(param))
+	return id
+}
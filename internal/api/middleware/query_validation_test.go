@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestQueryRules(t *testing.T) {
+	rule := All(
+		Pagination(MaxPageLimit),
+		Enum("status", "open", "closed"),
+		Bool("unread"),
+		UUID("user_id"),
+		Check("format", func(value string) error {
+			if value != "csv" {
+				return errors.New("format must be csv")
+			}
+			return nil
+		}),
+		TimeRange("from", "to"),
+	)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []ValidationError
+	}{
+		{
+			name:  "no parameters",
+			query: "",
+		},
+		{
+			name:  "valid parameters",
+			query: "limit=100&offset=0&status=open&unread=false&user_id=" + uuid.NewString() + "&format=csv&from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z",
+		},
+		{
+			name:  "open time range",
+			query: "from=2024-01-01T00:00:00Z",
+		},
+		{
+			name:  "fractional seconds",
+			query: "from=2024-01-01T00:00:00.123456Z",
+		},
+		{
+			name:  "every invalid parameter is reported",
+			query: "limit=500&offset=-1&status=pending",
+			expected: []ValidationError{
+				{Field: "limit", Message: "limit must be an integer between 1 and 100"},
+				{Field: "offset", Message: "offset must be a non-negative integer"},
+				{Field: "status", Message: "status must be one of open, closed"},
+			},
+		},
+		{
+			name:     "limit is not a number",
+			query:    "limit=ten",
+			expected: []ValidationError{{Field: "limit", Message: "limit must be an integer between 1 and 100"}},
+		},
+		{
+			name:     "limit of 0",
+			query:    "limit=0",
+			expected: []ValidationError{{Field: "limit", Message: "limit must be an integer between 1 and 100"}},
+		},
+		{
+			name:     "invalid bool",
+			query:    "unread=yes",
+			expected: []ValidationError{{Field: "unread", Message: "unread must be one of true, false"}},
+		},
+		{
+			name:     "invalid UUID",
+			query:    "user_id=42",
+			expected: []ValidationError{{Field: "user_id", Message: "user_id must be a UUID"}},
+		},
+		{
+			name:     "check reports its error",
+			query:    "format=xml",
+			expected: []ValidationError{{Field: "format", Message: "format must be csv"}},
+		},
+		{
+			name:  "invalid timestamps",
+			query: "from=yesterday&to=2024-13-01",
+			expected: []ValidationError{
+				{Field: "from", Message: "from must be an RFC 3339 timestamp"},
+				{Field: "to", Message: "to must be an RFC 3339 timestamp"},
+			},
+		},
+		{
+			name:     "range ends before it starts",
+			query:    "from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			expected: []ValidationError{{Field: "to", Message: "to must be after from"}},
+		},
+		{
+			name:     "empty range",
+			query:    "from=2024-01-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			expected: []ValidationError{{Field: "to", Message: "to must be after from"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Failed to parse query: %v", err)
+			}
+
+			errors := rule(query)
+			if !reflect.DeepEqual(errors, tt.expected) {
+				t.Errorf("Expected errors %v, got %v", tt.expected, errors)
+			}
+		})
+	}
+}
+
+func TestIntMessages(t *testing.T) {
+	tests := []struct {
+		rule     QueryRule
+		expected string
+	}{
+		{Int("n", 0, NoMax), "n must be a non-negative integer"},
+		{Int("n", 1, NoMax), "n must be an integer of at least 1"},
+		{Int("n", 0, 50), "n must be an integer between 0 and 50"},
+	}
+
+	for _, tt := range tests {
+		errors := tt.rule(url.Values{"n": {"-5"}})
+		if len(errors) != 1 || errors[0].Message != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, errors)
+		}
+	}
+}
+
+func TestQueryReaders(t *testing.T) {
+	id := uuid.New()
+	req := httptest.NewRequest("GET", "/test?limit=25&since=2024-01-01T00:00:00Z&user_id="+id.String(), nil)
+
+	if limit, offset := QueryPage(req, 10); limit != 25 || offset != 0 {
+		t.Errorf("Expected page 25/0, got %d/%d", limit, offset)
+	}
+	if days := QueryInt(req, "days", 7); days != 7 {
+		t.Errorf("Expected default 7, got %d", days)
+	}
+
+	since := QueryTime(req, "since")
+	if since == nil || !since.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected since of 2024-01-01, got %v", since)
+	}
+	if until := QueryTime(req, "until"); until != nil {
+		t.Errorf("Expected no until, got %v", until)
+	}
+
+	if got := QueryUUID(req, "user_id"); got != id {
+		t.Errorf("Expected user_id %s, got %s", id, got)
+	}
+	if got := QueryUUID(req, "account_id"); got != uuid.Nil {
+		t.Errorf("Expected no account_id, got %s", got)
+	}
+}
+
+func TestQueryWithValidateQueryParams(t *testing.T) {
+	reached := false
+	handler := ValidateQueryParams(Query(Pagination(MaxPageLimit), Enum("status", "open")))(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			reached = true
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/test?limit=500&status=bogus", nil))
+
+	if reached {
+		t.Error("Expected the handler not to be reached")
+	}
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+
+	var response ValidationResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse error response: %v", err)
+	}
+	if len(response.Errors) != 2 {
+		t.Errorf("Expected 2 errors, got %v", response.Errors)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/test?limit=5&status=open", nil))
+	if !reached || rr.Code != http.StatusOK {
+		t.Errorf("Expected a valid query to reach the handler, got status %d", rr.Code)
+	}
+}
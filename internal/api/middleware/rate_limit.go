@@ -7,19 +7,30 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/service"
 )
 
 // RateLimitMiddleware creates middleware that enforces rate limits using Redis
 func RateLimitMiddleware(cacheService service.CacheService, maxRequests int, window time.Duration) func(http.Handler) http.Handler {
+	return ScopedRateLimitMiddleware(cacheService, "", maxRequests, window)
+}
+
+// ScopedRateLimitMiddleware creates middleware that enforces a rate limit counted apart from
+// other scopes, so requests to one group of routes do not use up the limit of another. The
+// limit is soft: requests are let through while Redis is unavailable.
+func ScopedRateLimitMiddleware(cacheService service.CacheService, scope string, maxRequests int, window time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get client IP
-			clientIP := getClientIP(r)
+			// Get client IP, counted per scope
+			client := ClientIP(r)
+			if scope != "" {
+				client = scope + ":" + client
+			}
 
 			// Check rate limit
 			if cacheService != nil {
-				allowed, err := cacheService.CheckRateLimit(r.Context(), clientIP, maxRequests, window)
+				allowed, err := cacheService.CheckRateLimit(r.Context(), client, maxRequests, window)
 				if err != nil {
 					// Log error but allow request to proceed
 					// In production, you might want to handle this differently
@@ -28,11 +39,9 @@ func RateLimitMiddleware(cacheService service.CacheService, maxRequests int, win
 				}
 
 				if !allowed {
-					w.Header().Set("Content-Type", "application/json")
 					w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
 					w.Header().Set("X-RateLimit-Window", window.String())
-					w.WriteHeader(http.StatusTooManyRequests)
-					_, _ = w.Write([]byte(`{"error":"Rate limit exceeded","code":429,"retry_after":"` + window.String() + `"}`))
+					respond.Write(w, respond.New(http.StatusTooManyRequests, "Rate limit exceeded").With("retry_after", window.String()))
 					return
 				}
 			}
@@ -42,8 +51,8 @@ func RateLimitMiddleware(cacheService service.CacheService, maxRequests int, win
 	}
 }
 
-// getClientIP extracts the real client IP from the request
-func getClientIP(r *http.Request) string {
+// ClientIP extracts the real client IP from the request
+func ClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header (common with proxies/load balancers)
 	xff := r.Header.Get("X-Forwarded-For")
 	if xff != "" {
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
@@ -123,8 +124,5 @@ func GetCurrentUserID(r *http.Request) (string, bool) {
 
 // writeForbidden writes a 403 Forbidden response.
 func writeForbidden(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusForbidden)
-	response := `{"error":"` + message + `","code":403}`
-	_, _ = w.Write([]byte(response))
+	respond.Error(w, http.StatusForbidden, message)
 }
@@ -0,0 +1,222 @@
+// Package middleware provides request/response recording for compliance audits.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// DefaultRedactedFields are JSON field name fragments that are always redacted from recorded bodies.
+// A field is redacted when its lowercased name contains any of them, e.g. "new_password" or "refresh_token".
+var DefaultRedactedFields = []string{"password", "token", "secret", "authorization"}
+
+// moneyMovementPaths are the path prefixes whose non-GET requests are recorded.
+var moneyMovementPaths = []string{
+	"/api/v1/transactions/",
+	"/api/v1/scheduled-transactions",
+	"/api/v1/disputes/",
+	"/api/v1/payment-requests",
+	"/api/v1/transfer-templates",
+}
+
+// redactedPlaceholder replaces redacted field values.
+const redactedPlaceholder = "[REDACTED]"
+
+// requestLogWriteTimeout bounds how long recording a single exchange may take.
+const requestLogWriteTimeout = 5 * time.Second
+
+// AuditLogger stores audit log entries; it is satisfied by repository.AuditRepo.
+type AuditLogger interface {
+	Log(ctx context.Context, entityType string, entityID uuid.UUID, action string, details interface{}) error
+}
+
+// RequestLogOptions configures RequestLogMiddleware.
+type RequestLogOptions struct {
+	MaxBodyBytes int      // Bodies larger than this are omitted rather than stored
+	RedactFields []string // Field name fragments redacted in addition to DefaultRedactedFields
+}
+
+// RequestLogMiddleware records the request and response bodies of money-movement endpoints
+// into the audit store, keyed by request ID, with sensitive fields redacted. Bodies that are not
+// JSON or exceed the size limit are omitted, since they cannot be redacted reliably.
+// It must run inside LoggingMiddleware so the request ID and user are available.
+func RequestLogMiddleware(audit AuditLogger, opts RequestLogOptions) func(http.Handler) http.Handler {
+	redactFields := append(append([]string{}, DefaultRedactedFields...), opts.RedactFields...)
+	for i, field := range redactFields {
+		redactFields[i] = strings.ToLower(field)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMoneyMovement(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+
+			// Read the request body up to one byte past the limit and hand the handler an identical stream
+			var requestBody []byte
+			if r.Body != nil {
+				var err error
+				requestBody, err = io.ReadAll(io.LimitReader(r.Body, int64(opts.MaxBodyBytes)+1))
+				if err != nil {
+					utils.WarnContext(r.Context(), "failed to read request body for request log", "error", err.Error())
+				}
+				r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(requestBody), r.Body), Closer: r.Body}
+			}
+
+			rec := &bodyRecorder{ResponseWriter: w, statusCode: http.StatusOK, limit: opts.MaxBodyBytes + 1}
+			next.ServeHTTP(rec, r)
+
+			fields := utils.LogFieldsFromContext(r.Context())
+			details := domain.RequestLogDetails{
+				Method:       r.Method,
+				Path:         r.URL.Path,
+				Route:        r.Pattern,
+				Status:       rec.statusCode,
+				DurationMs:   time.Since(start).Milliseconds(),
+				UserID:       fields.UserID(),
+				RemoteAddr:   r.RemoteAddr,
+				RequestBody:  redactBody(requestBody, opts.MaxBodyBytes, redactFields),
+				ResponseBody: redactBody(rec.body.Bytes(), opts.MaxBodyBytes, redactFields),
+			}
+
+			requestID, err := uuid.Parse(GetRequestID(r.Context()))
+			if err != nil {
+				requestID = uuid.New()
+			}
+
+			// Store the entry off the request path; the client already has its response
+			ctx := context.WithoutCancel(r.Context())
+			go func() {
+				ctx, cancel := context.WithTimeout(ctx, requestLogWriteTimeout)
+				defer cancel()
+
+				if err := audit.Log(ctx, string(domain.EntityHTTPRequest), requestID, string(domain.ActionRecorded), details); err != nil {
+					utils.WarnContext(ctx, "failed to record request log", "error", err.Error())
+				}
+			}()
+		})
+	}
+}
+
+// GetRequestID returns the request ID set by LoggingMiddleware, or an empty string.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// isMoneyMovement reports whether r changes balances or scheduled payments and should be recorded.
+func isMoneyMovement(r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+		return false
+	}
+	for _, prefix := range moneyMovementPaths {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns body as JSON with sensitive fields replaced. Empty bodies yield nil; bodies that
+// are too large or not JSON are replaced by a note, so unredacted content is never stored.
+func redactBody(body []byte, maxBytes int, fields []string) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if len(body) > maxBytes {
+		return omittedBody(fmt.Sprintf("body exceeds %d bytes", maxBytes))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber() // Keep amounts exactly as sent
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return omittedBody("body is not valid JSON")
+	}
+
+	redacted, err := json.Marshal(redactValue(value, fields))
+	if err != nil {
+		return omittedBody("body could not be encoded")
+	}
+	return redacted
+}
+
+// redactValue replaces the values of sensitive fields in decoded JSON, recursing into objects and arrays.
+func redactValue(value interface{}, fields []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if isRedactedField(key, fields) {
+				v[key] = redactedPlaceholder
+			} else {
+				v[key] = redactValue(child, fields)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child, fields)
+		}
+	}
+	return value
+}
+
+// isRedactedField reports whether the field name contains any of the redacted fragments.
+func isRedactedField(name string, fields []string) bool {
+	name = strings.ToLower(name)
+	for _, field := range fields {
+		if field != "" && strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// omittedBody is stored in place of a body that could not be recorded.
+func omittedBody(reason string) json.RawMessage {
+	note, _ := json.Marshal(map[string]string{"omitted": reason})
+	return note
+}
+
+// readCloser combines the replayed request body with the original body's Close.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyRecorder wraps http.ResponseWriter to capture the status code and the start of the response body.
+type bodyRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+	limit      int
+}
+
+func (rw *bodyRecorder) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it, e.g. to flush.
+func (rw *bodyRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+func (rw *bodyRecorder) Write(p []byte) (int, error) {
+	if remaining := rw.limit - rw.body.Len(); remaining > 0 {
+		rw.body.Write(p[:min(len(p), remaining)])
+	}
+	return rw.ResponseWriter.Write(p)
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// recordingAuditLogger captures audit log entries written by RequestLogMiddleware.
+type recordingAuditLogger struct {
+	entries chan domain.RequestLogDetails
+	ids     chan uuid.UUID
+}
+
+func newRecordingAuditLogger() *recordingAuditLogger {
+	return &recordingAuditLogger{entries: make(chan domain.RequestLogDetails, 1), ids: make(chan uuid.UUID, 1)}
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, entityType string, entityID uuid.UUID, action string, details interface{}) error {
+	if entityType != string(domain.EntityHTTPRequest) || action != string(domain.ActionRecorded) {
+		return nil
+	}
+	l.ids <- entityID
+	l.entries <- details.(domain.RequestLogDetails)
+	return nil
+}
+
+func TestRequestLogMiddleware(t *testing.T) {
+	audit := newRecordingAuditLogger()
+
+	handler := LoggingMiddleware(RequestLogMiddleware(audit, RequestLogOptions{MaxBodyBytes: 1024, RedactFields: []string{"iban"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "hunter2") {
+				t.Errorf("handler should receive the unredacted body, got %s", body)
+			}
+			utils.LogFieldsFromContext(r.Context()).SetUserID("user-1")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"tx-1","access_token":"abc","amount":"10.50"}`))
+		}),
+	))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/credit",
+		strings.NewReader(`{"amount":10.50,"password":"hunter2","meta":{"Payer_IBAN":"DE00"}}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, rr.Code)
+	}
+
+	var details domain.RequestLogDetails
+	select {
+	case id := <-audit.ids:
+		if id.String() != rr.Header().Get("X-Request-ID") {
+			t.Errorf("expected entry keyed by request ID %s, got %s", rr.Header().Get("X-Request-ID"), id)
+		}
+		details = <-audit.entries
+	case <-time.After(time.Second):
+		t.Fatal("request was not recorded")
+	}
+
+	if details.Status != http.StatusCreated || details.Method != http.MethodPost || details.UserID != "user-1" {
+		t.Errorf("unexpected details: %+v", details)
+	}
+
+	var requestBody map[string]interface{}
+	if err := json.Unmarshal(details.RequestBody, &requestBody); err != nil {
+		t.Fatalf("request body is not JSON: %v", err)
+	}
+	if requestBody["password"] != redactedPlaceholder {
+		t.Errorf("expected password to be redacted, got %v", requestBody["password"])
+	}
+	if iban := requestBody["meta"].(map[string]interface{})["Payer_IBAN"]; iban != redactedPlaceholder {
+		t.Errorf("expected configured nested field to be redacted, got %v", iban)
+	}
+	if !strings.Contains(string(details.RequestBody), `"amount":10.50`) {
+		t.Errorf("expected amount to be kept exactly, got %s", details.RequestBody)
+	}
+	if strings.Contains(string(details.ResponseBody), "abc") {
+		t.Errorf("expected token to be redacted from response, got %s", details.ResponseBody)
+	}
+}
+
+func TestRequestLogMiddlewareSkipsOtherEndpoints(t *testing.T) {
+	audit := newRecordingAuditLogger()
+	handler := RequestLogMiddleware(audit, RequestLogOptions{MaxBodyBytes: 1024})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/transactions/history", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"password":"x"}`)),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	select {
+	case details := <-audit.entries:
+		t.Errorf("expected nothing to be recorded, got %+v", details)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	fields := DefaultRedactedFields
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"redacts nested arrays", `{"items":[{"refresh_token":"t"}]}`, `{"items":[{"refresh_token":"[REDACTED]"}]}`},
+		{"not JSON", `amount=10&password=x`, `{"omitted":"body is not valid JSON"}`},
+		{"too large", `{"note":"` + strings.Repeat("a", 64) + `"}`, `{"omitted":"body exceeds 64 bytes"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactBody([]byte(tt.body), 64, fields)); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// timeoutWriteGrace is how long a response may take to write once a route's deadline has passed.
+const timeoutWriteGrace = 10 * time.Second
+
+// TimeoutOptions configures TimeoutMiddleware.
+type TimeoutOptions struct {
+	Read         time.Duration              // Deadline of GET and HEAD requests; 0 sets none
+	Write        time.Duration              // Deadline of requests with other methods; 0 sets none
+	Routes       map[string]time.Duration   // Deadlines of route patterns, e.g. "GET /api/v1/users"; 0 sets none
+	WriteTimeout time.Duration              // The server's write timeout, extended for routes allowed longer
+	Route        func(*http.Request) string // Returns the pattern a request matches, e.g. through ServeMux.Handler
+}
+
+// TimeoutMiddleware sets a deadline on each request context, so database queries of a slow request
+// are cancelled rather than piling up. The deadline depends on the route, falling back to the read
+// or write deadline by method. When a request fails because its deadline passed, the handler's
+// error response is replaced with a 504 and the timeout is counted in the metrics.
+func TimeoutMiddleware(opts TimeoutOptions, metricsCollector *utils.MetricsCollector) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pattern := opts.Route(r)
+			timeout, ok := opts.Routes[pattern]
+			if !ok {
+				timeout = opts.Write
+				if r.Method == http.MethodGet || r.Method == http.MethodHead {
+					timeout = opts.Read
+				}
+			}
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if opts.WriteTimeout > 0 && timeout+timeoutWriteGrace > opts.WriteTimeout {
+				_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + timeoutWriteGrace))
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w, ctx: ctx}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			// A handler that gave up without responding gets the 504 as well
+			if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				tw.WriteHeader(http.StatusGatewayTimeout)
+			}
+			if tw.timedOut {
+				utils.Warn("request timed out",
+					"method", r.Method,
+					"route", routeLabel(pattern),
+					"timeout", timeout,
+				)
+				metricsCollector.RecordHTTPTimeout(r.Method, routeLabel(pattern))
+			}
+		})
+	}
+}
+
+// timeoutWriter replaces a server error response written after the request deadline passed with
+// a 504, dropping the handler's body. Responses already started are left alone.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	if tw.wroteHeader {
+		return
+	}
+	if code >= 100 && code < 200 {
+		tw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	tw.wroteHeader = true
+
+	if code >= http.StatusInternalServerError && errors.Is(tw.ctx.Err(), context.DeadlineExceeded) {
+		tw.timedOut = true
+		header := tw.Header()
+		header.Del("Content-Length")
+		header.Del("Content-Disposition")
+		respond.Error(tw.ResponseWriter, http.StatusGatewayTimeout, "Request timed out")
+		return
+	}
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.timedOut {
+		return len(p), nil
+	}
+	return tw.ResponseWriter.Write(p)
+}
+
+// FlushError sends the header before flushing, so a flushed response is never replaced.
+func (tw *timeoutWriter) FlushError() error {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.timedOut {
+		return nil
+	}
+	return http.NewResponseController(tw.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it.
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fast", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("GET /fast: expected the read deadline on the request context")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+	mux.HandleFunc("POST /slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done() // Like a query cancelled by the deadline
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"Failed to transfer","code":500}`))
+	})
+	mux.HandleFunc("GET /silent", func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("GET /stream", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("GET /stream: expected no deadline for a route set to 0")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := TimeoutMiddleware(TimeoutOptions{
+		Read:   time.Second,
+		Write:  10 * time.Millisecond,
+		Routes: map[string]time.Duration{"GET /silent": 10 * time.Millisecond, "GET /stream": 0},
+		Route: func(r *http.Request) string {
+			_, pattern := mux.Handler(r)
+			return pattern
+		},
+	}, utils.NewMetricsCollector())(mux)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+		return rr
+	}
+
+	if rr := serve(http.MethodGet, "/fast"); rr.Code != http.StatusOK || rr.Body.String() != `{"ok":true}` {
+		t.Errorf("GET /fast: status = %d, body = %q; want the handler's response", rr.Code, rr.Body.String())
+	}
+	for _, route := range [][2]string{{http.MethodPost, "/slow"}, {http.MethodGet, "/silent"}} {
+		rr := serve(route[0], route[1])
+		if rr.Code != http.StatusGatewayTimeout || rr.Body.String() != `{"type":"https://go-banking-sim.dev/problems/timeout","title":"Gateway Timeout","status":504,"detail":"Request timed out"}` {
+			t.Errorf("%s %s: status = %d, body = %q; want a 504", route[0], route[1], rr.Code, rr.Body.String())
+		}
+	}
+	if rr := serve(http.MethodGet, "/stream"); rr.Code != http.StatusOK {
+		t.Errorf("GET /stream: status = %d, want 200", rr.Code)
+	}
+}
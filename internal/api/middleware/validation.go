@@ -1,11 +1,17 @@
 package middleware
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // Validator interface for types that can validate themselves.
@@ -17,12 +23,15 @@ type Validator interface {
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
+
+	// Allowed lists the range an amount outside its transaction limits must fall within
+	Allowed *domain.AmountLimitError `json:"allowed,omitempty"`
 }
 
-// ValidationResponse represents the error response format.
+// ValidationResponse represents the body of a validation problem: a problem details object with
+// the field errors as its errors member.
 type ValidationResponse struct {
-	Error  string            `json:"error"`
-	Code   int               `json:"code"`
+	respond.Problem
 	Errors []ValidationError `json:"errors"`
 }
 
@@ -39,9 +48,18 @@ func ValidateJSON[T Validator](next func(w http.ResponseWriter, r *http.Request,
 			return
 		}
 
+		// Read the body once, so amounts can be checked as written after it is decoded
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeValidationError(w, []ValidationError{
+				{Field: "json", Message: "failed to parse JSON: " + err.Error()},
+			})
+			return
+		}
+
 		// Parse JSON body
 		var body T
-		decoder := json.NewDecoder(r.Body)
+		decoder := json.NewDecoder(bytes.NewReader(raw))
 		decoder.DisallowUnknownFields() // Reject unknown fields
 
 		if err := decoder.Decode(&body); err != nil {
@@ -76,6 +94,12 @@ func ValidateJSON[T Validator](next func(w http.ResponseWriter, r *http.Request,
 			return
 		}
 
+		// Decoding into float64 rounds away precision, so amounts are parsed strictly from the raw body
+		if amountErrors := checkAmounts(raw); len(amountErrors) > 0 {
+			writeValidationError(w, amountErrors)
+			return
+		}
+
 		// Validate the parsed body
 		if err := body.Validate(); err != nil {
 			validationErrors := parseValidationError(err)
@@ -133,8 +157,61 @@ func RequiredFields(fields ...string) func(*http.Request) []ValidationError {
 	}
 }
 
+// checkAmounts parses the amount fields of a JSON body with domain.ParseAmount, in the currency
+// given next to them, so amounts more precise than their currency allows are rejected rather
+// than rounded.
+func checkAmounts(raw []byte) []ValidationError {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil // The body was already decoded into its request type
+	}
+
+	var errors []ValidationError
+	collectAmountErrors(value, &errors)
+	return errors
+}
+
+// collectAmountErrors checks the amount fields of every object within value.
+func collectAmountErrors(value interface{}, errors *[]ValidationError) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		currency, _ := v["currency"].(string)
+		for _, field := range domain.AmountFields {
+			number, ok := v[field].(json.Number)
+			if !ok {
+				continue
+			}
+			if _, err := domain.ParseAmount(number.String(), currency); err != nil {
+				*errors = append(*errors, ValidationError{Field: field, Message: err.Error()})
+			}
+		}
+		for _, nested := range v {
+			collectAmountErrors(nested, errors)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			collectAmountErrors(nested, errors)
+		}
+	}
+}
+
+// WriteValidationErrors writes a 422 Unprocessable Entity response for an error returned by
+// request validation, e.g. one a service returned after validating its request.
+func WriteValidationErrors(w http.ResponseWriter, err error) {
+	writeValidationError(w, parseValidationError(err))
+}
+
 // parseValidationError converts a validation error into ValidationError slice.
 func parseValidationError(err error) []ValidationError {
+	// Amount limit errors carry their allowed range, whatever they are wrapped in
+	var limitErr *domain.AmountLimitError
+	if errors.As(err, &limitErr) {
+		return []ValidationError{{Field: "amount", Message: limitErr.Error(), Allowed: limitErr}}
+	}
+
 	var errors []ValidationError
 	errorMsg := err.Error()
 
@@ -200,21 +277,9 @@ func isEmpty(value interface{}) bool {
 	}
 }
 
-// writeValidationError writes a 422 Unprocessable Entity response with validation errors.
+// writeValidationError writes a 422 Unprocessable Entity problem with validation errors.
 func writeValidationError(w http.ResponseWriter, errors []ValidationError) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnprocessableEntity)
-
-	response := ValidationResponse{
-		Error:  "validation failed",
-		Code:   422,
-		Errors: errors,
-	}
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode validation error response", http.StatusInternalServerError)
-		return
-	}
+	respond.Write(w, respond.New(http.StatusUnprocessableEntity, "validation failed").With("errors", errors))
 }
 
 // ValidateContentType creates middleware that validates request content type.
@@ -8,6 +8,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // Test struct that implements Validator
@@ -135,8 +137,8 @@ func TestValidateJSON(t *testing.T) {
 					t.Errorf("Failed to parse error response: %v", err)
 				}
 
-				if response.Code != 422 {
-					t.Errorf("Expected error code 422, got %d", response.Code)
+				if response.Status != 422 {
+					t.Errorf("Expected status 422 in problem, got %d", response.Status)
 				}
 
 				if len(response.Errors) == 0 {
@@ -154,6 +156,88 @@ func TestValidateJSON(t *testing.T) {
 	}
 }
 
+// amountRequest is a request holding an amount in a currency.
+type amountRequest struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+func (amountRequest) Validate() error {
+	return nil
+}
+
+func TestValidateJSONAmounts(t *testing.T) {
+	handler := ValidateJSON(func(w http.ResponseWriter, _ *http.Request, _ amountRequest) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		body      string
+		wantField string // Empty when the body is accepted
+	}{
+		{`{"amount":1234.5,"currency":"USD"}`, ""},
+		{`{"amount":1500,"currency":"JPY"}`, ""},
+		{`{"amount":10.10,"currency":"USD"}`, ""},
+		{`{"amount":10.001,"currency":"USD"}`, "amount"},
+		{`{"amount":10.0000000000000001,"currency":"USD"}`, "amount"}, // Rounds to 10 as a float64
+		{`{"amount":1500.5,"currency":"JPY"}`, "amount"},
+		{`{"amount":1e3,"currency":"USD"}`, "amount"},
+		{`{"amount":1.234,"currency":"XYZ"}`, "amount"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if tt.wantField == "" {
+			if rr.Code != http.StatusOK {
+				t.Errorf("%s: status = %d, want 200: %s", tt.body, rr.Code, rr.Body.String())
+			}
+			continue
+		}
+
+		var response ValidationResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil || rr.Code != http.StatusUnprocessableEntity ||
+			len(response.Errors) != 1 || response.Errors[0].Field != tt.wantField {
+			t.Errorf("%s: status = %d, response = %s; want an error on %s", tt.body, rr.Code, rr.Body.String(), tt.wantField)
+		}
+	}
+}
+
+func TestValidateJSONAmountLimits(t *testing.T) {
+	domain.SetAmountLimits(domain.AmountLimits{"USD": {domain.TypeCredit: {Min: 1, Max: 500}}})
+	defer domain.SetAmountLimits(nil)
+
+	handler := ValidateJSON(func(w http.ResponseWriter, _ *http.Request, _ *domain.CreditRequest) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"amount":750,"currency":"USD"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var response ValidationResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil || rr.Code != http.StatusUnprocessableEntity || len(response.Errors) != 1 {
+		t.Fatalf("status = %d, response = %s; want one validation error", rr.Code, rr.Body.String())
+	}
+	got := response.Errors[0]
+	want := domain.AmountLimitError{Currency: "USD", Type: domain.TypeCredit, Min: 1, Max: 500}
+	if got.Field != "amount" || got.Allowed == nil || *got.Allowed != want {
+		t.Errorf("error = %+v, want the allowed range %+v on amount", got, want)
+	}
+
+	// Services wrap the limit errors of the requests they validate
+	rr = httptest.NewRecorder()
+	WriteValidationErrors(rr, fmt.Errorf("invalid credit request: %w", &want))
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil || rr.Code != http.StatusUnprocessableEntity ||
+		len(response.Errors) != 1 || response.Errors[0].Field != "amount" || response.Errors[0].Allowed == nil {
+		t.Errorf("status = %d, response = %s; want the allowed range on amount", rr.Code, rr.Body.String())
+	}
+}
+
 func TestValidateQueryParams(t *testing.T) {
 	// Create validator function
 	validator := func(r *http.Request) []ValidationError {
@@ -317,12 +401,20 @@ func TestValidationErrorResponse(t *testing.T) {
 	}
 
 	// Verify response structure
-	if response.Error != "validation failed" {
-		t.Errorf("Expected error 'validation failed', got '%s'", response.Error)
+	if got := rr.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("Expected Content-Type application/problem+json, got '%s'", got)
+	}
+
+	if response.Type != "https://go-banking-sim.dev/problems/validation-failed" {
+		t.Errorf("Expected the validation-failed problem type, got '%s'", response.Type)
+	}
+
+	if response.Detail != "validation failed" {
+		t.Errorf("Expected detail 'validation failed', got '%s'", response.Detail)
 	}
 
-	if response.Code != 422 {
-		t.Errorf("Expected code 422, got %d", response.Code)
+	if response.Status != 422 {
+		t.Errorf("Expected status 422, got %d", response.Status)
 	}
 
 	if len(response.Errors) != 2 {
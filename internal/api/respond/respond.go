@@ -0,0 +1,158 @@
+// Package respond writes API error responses as RFC 7807 problem details
+// (application/problem+json).
+package respond
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// ContentType is the media type of problem details responses.
+const ContentType = "application/problem+json"
+
+// TypeBaseURI prefixes the type URI of every problem, e.g. .../problems/not-found.
+const TypeBaseURI = "https://go-banking-sim.dev/problems/"
+
+// RequestIDHeader is the response header LoggingMiddleware sets the request ID in. Problems name
+// it as their instance, so a client can quote the request an error came from.
+const RequestIDHeader = "X-Request-ID"
+
+// problemTypes holds the type of each status with its own problem type; any other status is
+// about:blank, which RFC 7807 defines as carrying no meaning beyond the status itself.
+var problemTypes = map[int]string{
+	http.StatusBadRequest:            "bad-request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not-found",
+	http.StatusMethodNotAllowed:      "method-not-allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusGone:                  "gone",
+	http.StatusRequestEntityTooLarge: "payload-too-large",
+	http.StatusUnprocessableEntity:   "validation-failed",
+	http.StatusTooManyRequests:       "rate-limited",
+	http.StatusInternalServerError:   "internal-error",
+	http.StatusNotImplemented:        "not-implemented",
+	http.StatusServiceUnavailable:    "service-unavailable",
+	http.StatusGatewayTimeout:        "timeout",
+}
+
+// TypeURI returns the problem type URI of a status.
+func TypeURI(status int) string {
+	if name, ok := problemTypes[status]; ok {
+		return TypeBaseURI + name
+	}
+	return "about:blank"
+}
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Extensions holds further members, e.g. the field errors of a validation problem
+	Extensions map[string]any `json:"-"`
+}
+
+// New returns the problem of a status, titled with the status text and explained by detail.
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   TypeURI(status),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// With adds an extension member to the problem.
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+// Error writes the problem of a status with detail as its explanation.
+func Error(w http.ResponseWriter, status int, detail string) {
+	Write(w, New(status, detail))
+}
+
+// Write writes a problem, naming the request ID as its instance when the response carries one.
+func Write(w http.ResponseWriter, p *Problem) {
+	if p.Instance == "" {
+		if requestID := w.Header().Get(RequestIDHeader); requestID != "" {
+			p.Instance = "urn:uuid:" + requestID
+		}
+	}
+
+	body, err := p.Encode()
+	if err != nil {
+		// Extensions are plain values, so only the members every problem has are left to send
+		p.Extensions = nil
+		body, _ = p.Encode()
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(body)
+}
+
+// Encode encodes the problem with its extensions as members after the standard ones, which
+// extensions cannot override.
+func (p *Problem) Encode() ([]byte, error) {
+	type problem Problem // Without methods, so encoding it does not recurse
+	body, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return body, err
+	}
+
+	keys := make([]string, 0, len(p.Extensions))
+	for key := range p.Extensions {
+		if !standardMembers[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(body[:len(body)-1]) // Reopen the object
+	for _, key := range keys {
+		value, err := json.Marshal(p.Extensions[key])
+		if err != nil {
+			return nil, err
+		}
+		name, _ := json.Marshal(key)
+		buf.WriteByte(',')
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// standardMembers lists the members RFC 7807 defines.
+var standardMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// Decode decodes a problem, keeping members other than the standard ones as raw extensions.
+func Decode(body []byte) (*Problem, error) {
+	type problem Problem
+	var p problem
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+	for key, value := range members {
+		if !standardMembers[key] {
+			(*Problem)(&p).With(key, value)
+		}
+	}
+	return (*Problem)(&p), nil
+}
@@ -0,0 +1,81 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(RequestIDHeader, "3f2b8c1e-9d4a-4f6e-8a7b-1c2d3e4f5a6b")
+	Error(rec, http.StatusNotFound, `Transaction "abc" not found`)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+	want := `{"type":"https://go-banking-sim.dev/problems/not-found","title":"Not Found","status":404,` +
+		`"detail":"Transaction \"abc\" not found","instance":"urn:uuid:3f2b8c1e-9d4a-4f6e-8a7b-1c2d3e4f5a6b"}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestWriteExtensions(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, New(http.StatusServiceUnavailable, "Service temporarily unavailable").
+		With("state", "open").
+		With("service", "database").
+		With("status", 200)) // Cannot override a standard member
+
+	want := `{"type":"https://go-banking-sim.dev/problems/service-unavailable","title":"Service Unavailable","status":503,` +
+		`"detail":"Service temporarily unavailable","service":"database","state":"open"}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+
+	// An extension that cannot be encoded is dropped rather than failing the response
+	rec = httptest.NewRecorder()
+	Write(rec, New(http.StatusBadRequest, "bad").With("callback", func() {}))
+	want = `{"type":"https://go-banking-sim.dev/problems/bad-request","title":"Bad Request","status":400,"detail":"bad"}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestTypeURI(t *testing.T) {
+	if got := TypeURI(http.StatusUnprocessableEntity); got != TypeBaseURI+"validation-failed" {
+		t.Errorf("TypeURI(422) = %q", got)
+	}
+	if got := TypeURI(http.StatusTeapot); got != "about:blank" {
+		t.Errorf("TypeURI(418) = %q, want about:blank", got)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	body := `{"type":"https://go-banking-sim.dev/problems/validation-failed","title":"Unprocessable Entity","status":422,` +
+		`"detail":"validation failed","errors":[{"field":"amount","message":"amount must be greater than 0"}]}`
+
+	p, err := Decode([]byte(body))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if p.Status != http.StatusUnprocessableEntity || p.Detail != "validation failed" || len(p.Extensions) != 1 {
+		t.Errorf("Decode() = %+v", p)
+	}
+
+	encoded, err := p.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if string(encoded) != body {
+		t.Errorf("Encode() = %s, want %s", encoded, body)
+	}
+
+	if _, err := Decode([]byte("not json")); err == nil {
+		t.Error("Decode() of a non-JSON body succeeded")
+	}
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// handleLookupAccount handles resolving an account number, so a sender can confirm a transfer's
+// destination before sending.
+func (r *Router) handleLookupAccount(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		account, err := r.services.User.LookupAccount(req.Context(), req.URL.Query().Get("number"))
+		if err != nil {
+			writeAccountLookupError(w, err)
+			return
+		}
+
+		jsonResponse, err := json.Marshal(account)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeAccountLookupError maps account lookup errors to HTTP responses.
+func writeAccountLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case err.Error() == "account not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, "Failed to look up account")
+	}
+}
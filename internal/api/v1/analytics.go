@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// forecastQuery accepts the number of days a cash-flow forecast covers.
+var forecastQuery = middleware.Query(middleware.Int("days", 1, domain.MaxForecastDays))
+
+// activityQuery accepts the number of months a monthly activity report covers.
+var activityQuery = middleware.Query(middleware.Int("months", 1, domain.MaxActivityMonths))
+
+// spendingQuery accepts the yyyy-mm month a spending report covers.
+var spendingQuery = middleware.Query(middleware.Check("month", func(month string) error {
+	_, _, err := domain.ParseSummaryMonth(month)
+	return err
+}))
+
+// handleGetForecast handles projecting the authenticated user's daily balance from their current
+// balance, scheduled transactions and recurring history.
+func (r *Router) handleGetForecast(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(forecastQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		forecast, err := r.services.Forecast.Forecast(req.Context(), userID, middleware.QueryInt(req, "days", domain.DefaultForecastDays))
+		if err != nil {
+			writeAnalyticsError(w, err, "Failed to forecast cash flow")
+			return
+		}
+
+		writeAnalyticsJSON(w, http.StatusOK, forecast)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetSpending handles reporting the authenticated user's spending of a month by category,
+// with their progress against each budget. The month defaults to the current one.
+func (r *Router) handleGetSpending(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(spendingQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		report, err := r.services.Budget.Spending(req.Context(), userID, req.URL.Query().Get("month"))
+		if err != nil {
+			writeAnalyticsError(w, err, "Failed to report spending")
+			return
+		}
+
+		writeAnalyticsJSON(w, http.StatusOK, report)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetMonthlyActivity handles reporting the money the authenticated user received and sent in
+// each of their last months, the current one included.
+func (r *Router) handleGetMonthlyActivity(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(activityQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		report, err := r.services.Activity.Monthly(req.Context(), userID, middleware.QueryInt(req, "months", domain.DefaultActivityMonths))
+		if err != nil {
+			writeAnalyticsError(w, err, "Failed to report monthly activity")
+			return
+		}
+
+		writeAnalyticsJSON(w, http.StatusOK, report)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeAnalyticsError maps analytics service errors to HTTP responses.
+func writeAnalyticsError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeAnalyticsJSON marshals an analytics response with the given status code.
+func writeAnalyticsJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
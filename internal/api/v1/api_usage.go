@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// apiUsageQuery checks the parameters of an API usage report.
+var apiUsageQuery = middleware.Query(middleware.Int("days", 1, domain.MaxAPIUsageDays))
+
+// handleGetMyUsage handles reporting the authenticated user's API calls by endpoint class and what
+// remains of their quota today.
+func (r *Router) handleGetMyUsage(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(apiUsageQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		r.writeAPIUsage(w, req, userID)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetUserUsage handles reporting a user's API calls and quota (admin only).
+func (r *Router) handleGetUserUsage(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(apiUsageQuery)
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
+			return
+		}
+
+		r.writeAPIUsage(w, req, userID)
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeAPIUsage writes the usage report of a user.
+func (r *Router) writeAPIUsage(w http.ResponseWriter, req *http.Request, userID uuid.UUID) {
+	if r.services.APIUsage == nil {
+		respond.Error(w, http.StatusServiceUnavailable, "API usage tracking requires Redis")
+		return
+	}
+
+	report, err := r.services.APIUsage.Usage(req.Context(), userID, middleware.QueryInt(req, "days", domain.DefaultAPIUsageDays))
+	if err != nil {
+		writeAPIUsageError(w, err, "Failed to get API usage")
+		return
+	}
+
+	writeAPIUsageJSON(w, report)
+}
+
+// handleSetAPIQuota handles creating or replacing a user's API quota (admin only).
+func (r *Router) handleSetAPIQuota(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+		userID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
+			return
+		}
+		if r.services.APIUsage == nil {
+			respond.Error(w, http.StatusServiceUnavailable, "API usage tracking requires Redis")
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SetAPIQuotaRequest) {
+			quota, err := r.services.APIUsage.SetQuota(req.Context(), adminID, userID, body)
+			if err != nil {
+				writeAPIUsageError(w, err, "Failed to set API quota")
+				return
+			}
+
+			writeAPIUsageJSON(w, quota)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleDeleteAPIQuota handles removing a user's API quota (admin only).
+func (r *Router) handleDeleteAPIQuota(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+		userID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
+			return
+		}
+		if r.services.APIUsage == nil {
+			respond.Error(w, http.StatusServiceUnavailable, "API usage tracking requires Redis")
+			return
+		}
+
+		if err := r.services.APIUsage.DeleteQuota(req.Context(), adminID, userID); err != nil {
+			writeAPIUsageError(w, err, "Failed to delete API quota")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"API quota deleted successfully"}`))
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeAPIUsageError maps API usage service errors to HTTP responses.
+func writeAPIUsageError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "user not found", err.Error() == "api quota not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeAPIUsageJSON marshals an API usage response.
+func writeAPIUsageJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
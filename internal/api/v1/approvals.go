@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// approvalsQuery accepts the filters of approval listings.
+var approvalsQuery = middleware.Query(
+	middleware.Pagination(middleware.MaxPageLimit),
+	middleware.Enum("status",
+		string(domain.ApprovalPending), string(domain.ApprovalApproved), string(domain.ApprovalRejected),
+		string(domain.ApprovalExecuted), string(domain.ApprovalFailed)),
+	middleware.Enum("action",
+		string(domain.ApprovalRollback), string(domain.ApprovalBalanceAdjustment), string(domain.ApprovalRoleChange),
+		string(domain.ApprovalPolicyChange)),
+)
+
+// submitForApproval queues an admin action that requires a second admin's approval, answering
+// 202 Accepted with the pending approval. It reports whether the action was queued; if not, the
+// caller executes it at once.
+func (r *Router) submitForApproval(w http.ResponseWriter, req *http.Request, adminID uuid.UUID, action domain.ApprovalAction, payload any) bool {
+	if r.services.Approval == nil || !r.services.Approval.Required(req.Context(), action, payload) {
+		return false
+	}
+
+	approval, err := r.services.Approval.Submit(req.Context(), adminID, action, payload)
+	if err != nil {
+		writeApprovalError(w, err, "Failed to submit for approval")
+		return true
+	}
+
+	writeApprovalJSON(w, http.StatusAccepted, approval)
+	return true
+}
+
+// handleListApprovals handles listing the four-eyes approval queue (admin only).
+func (r *Router) handleListApprovals(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(approvalsQuery)
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		limit, offset := middleware.QueryPage(req, 50)
+		filter := &domain.ApprovalFilter{Limit: limit, Offset: offset}
+		if status := req.URL.Query().Get("status"); status != "" {
+			s := domain.ApprovalStatus(status)
+			filter.Status = &s
+		}
+		if action := req.URL.Query().Get("action"); action != "" {
+			a := domain.ApprovalAction(action)
+			filter.Action = &a
+		}
+
+		approvals, err := r.services.Approval.List(req.Context(), filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list approvals")
+			return
+		}
+
+		writeApprovalJSON(w, http.StatusOK, map[string]interface{}{
+			"approvals": approvals,
+			"limit":     filter.Limit,
+			"offset":    filter.Offset,
+		})
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetApproval handles retrieving an approval with the outcome of its action (admin only).
+func (r *Router) handleGetApproval(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		approvalID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid approval ID format")
+			return
+		}
+
+		approval, err := r.services.Approval.GetByID(req.Context(), approvalID)
+		if err != nil {
+			writeApprovalError(w, err, "Failed to get approval")
+			return
+		}
+
+		writeApprovalJSON(w, http.StatusOK, approval)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleApproveApproval handles approving a pending action, which executes it on behalf of the
+// admin who requested it (admin only). Admins cannot approve their own requests.
+func (r *Router) handleApproveApproval(w http.ResponseWriter, req *http.Request) {
+	r.reviewApproval(w, req, true)
+}
+
+// handleRejectApproval handles rejecting a pending action (admin only). Admins may reject their
+// own requests to withdraw them.
+func (r *Router) handleRejectApproval(w http.ResponseWriter, req *http.Request) {
+	r.reviewApproval(w, req, false)
+}
+
+// reviewApproval approves or rejects a pending action with an optional note.
+func (r *Router) reviewApproval(w http.ResponseWriter, req *http.Request, approve bool) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		approvalID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid approval ID format")
+			return
+		}
+
+		// Parse the optional body; a note explains the decision
+		var reviewReq domain.ReviewApprovalRequest
+		if req.Body != nil && req.ContentLength != 0 {
+			if err := json.NewDecoder(req.Body).Decode(&reviewReq); err != nil && err != io.EOF {
+				respond.Error(w, http.StatusBadRequest, "Invalid JSON request body")
+				return
+			}
+		}
+
+		if err := reviewReq.Validate(); err != nil {
+			respond.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var approval *domain.Approval
+		if approve {
+			approval, err = r.services.Approval.Approve(req.Context(), approvalID, adminID, reviewReq.Note)
+		} else {
+			approval, err = r.services.Approval.Reject(req.Context(), approvalID, adminID, reviewReq.Note)
+		}
+		if err != nil {
+			writeApprovalError(w, err, "Failed to review approval")
+			return
+		}
+
+		writeApprovalJSON(w, http.StatusOK, approval)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeApprovalError maps approval service errors to HTTP responses.
+func writeApprovalError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "approval not found":
+		respond.Error(w, http.StatusNotFound, "Approval not found")
+	case err.Error() == "approval already reviewed":
+		respond.Error(w, http.StatusConflict, "Approval already reviewed")
+	case err.Error() == "cannot approve your own request":
+		respond.Error(w, http.StatusForbidden, "Cannot approve your own request")
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeApprovalJSON marshals an approval response with the given status code.
+func writeApprovalJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
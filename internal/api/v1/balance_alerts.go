@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListBalanceAlerts handles listing the user's low-balance alert thresholds.
+func (r *Router) handleListBalanceAlerts(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		alerts, err := r.services.BalanceAlert.List(req.Context(), userID)
+		if err != nil {
+			writeBalanceAlertError(w, err, "Failed to list balance alerts")
+			return
+		}
+
+		writeBalanceAlertJSON(w, http.StatusOK, map[string]interface{}{
+			"alerts": alerts,
+		})
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleSetBalanceAlert handles creating or changing the user's alert threshold for a currency.
+func (r *Router) handleSetBalanceAlert(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		currency := req.PathValue("currency")
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SetBalanceAlertRequest) {
+			alert, err := r.services.BalanceAlert.SetThreshold(req.Context(), userID, currency, body)
+			if err != nil {
+				writeBalanceAlertError(w, err, "Failed to set balance alert")
+				return
+			}
+
+			writeBalanceAlertJSON(w, http.StatusOK, alert)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleDeleteBalanceAlert handles removing the user's alert threshold for a currency.
+func (r *Router) handleDeleteBalanceAlert(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		if err := r.services.BalanceAlert.Delete(req.Context(), userID, req.PathValue("currency")); err != nil {
+			writeBalanceAlertError(w, err, "Failed to delete balance alert")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"Balance alert deleted successfully"}`))
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeBalanceAlertError maps balance alert service errors to HTTP responses.
+func writeBalanceAlertError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "balance alert not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeBalanceAlertJSON marshals a balance alert response with the given status code.
+func writeBalanceAlertJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
@@ -21,26 +25,20 @@ func (r *Router) handleGetCurrentBalance(w http.ResponseWriter, req *http.Reques
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Get the user's current balance
 		balance, err := r.services.Balance.GetCurrent(req.Context(), userID)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to get balance","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to get balance")
 			return
 		}
 
@@ -62,8 +60,7 @@ func (r *Router) handleGetCurrentBalance(w http.ResponseWriter, req *http.Reques
 		}
 
 		if err := json.NewEncoder(w).Encode(response); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to encode response","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to encode response")
 			return
 		}
 	}))
@@ -74,40 +71,28 @@ func (r *Router) handleGetCurrentBalance(w http.ResponseWriter, req *http.Reques
 // handleGetHistoricalBalance handles getting historical balance snapshots.
 func (r *Router) handleGetHistoricalBalance(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.Int("limit", 1, middleware.MaxPageLimit)))
 
-	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
-		// Parse limit query parameter
-		limitStr := req.URL.Query().Get("limit")
-		limit := 10 // Default limit
-		if limitStr != "" {
-			if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
-				limit = parsedLimit
-			}
-		}
+		limit := middleware.QueryInt(req, "limit", 10)
 
 		// Get historical balance snapshots
 		history, err := r.services.Balance.GetHistorical(req.Context(), userID, limit)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to get balance history","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to get balance history")
 			return
 		}
 
@@ -128,7 +113,7 @@ func (r *Router) handleGetHistoricalBalance(w http.ResponseWriter, req *http.Req
 		response += `],"limit":` + strconv.Itoa(limit) + `}`
 
 		_, _ = w.Write([]byte(response))
-	}))
+	})))
 
 	finalHandler.ServeHTTP(w, req)
 }
@@ -141,17 +126,13 @@ func (r *Router) handleGetBalanceAtTime(w http.ResponseWriter, req *http.Request
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
@@ -159,18 +140,14 @@ func (r *Router) handleGetBalanceAtTime(w http.ResponseWriter, req *http.Request
 		timestampStr := req.URL.Query().Get("timestamp")
 
 		if timestampStr == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Timestamp parameter is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Timestamp parameter is required")
 			return
 		}
 
 		//use repository to get at time
 		balance, err := r.services.Balance.GetAtTime(req.Context(), userID, timestampStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(fmt.Sprintf(`{"error":"Failed to get balance at time: %s","code":500}`, err.Error())))
+			respond.Error(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get balance at time: %s", err.Error()))
 			return
 		}
 		//return the balance
@@ -186,6 +163,64 @@ func (r *Router) handleGetBalanceAtTime(w http.ResponseWriter, req *http.Request
 	finalHandler.ServeHTTP(w, req)
 }
 
+// balanceTimelineQuery accepts the range and interval of a balance timeline.
+var balanceTimelineQuery = middleware.Query(
+	middleware.TimeRange("from", "to"),
+	middleware.Enum("interval", domain.TimelineIntervals...),
+)
+
+// handleGetBalanceTimeline handles retrieving a series of the current user's balances over a
+// range, for drawing balance-over-time charts. The range defaults to the last 30 days and the
+// interval to a day.
+func (r *Router) handleGetBalanceTimeline(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(balanceTimelineQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		to := time.Now().UTC().Truncate(time.Second)
+		if t := middleware.QueryTime(req, "to"); t != nil {
+			to = *t
+		}
+
+		from := to.Add(-domain.DefaultTimelineRange)
+		if t := middleware.QueryTime(req, "from"); t != nil {
+			from = *t
+		}
+
+		interval := domain.TimelineDay
+		if value := req.URL.Query().Get("interval"); value != "" {
+			interval = domain.TimelineInterval(value)
+		}
+
+		timeline, err := r.services.Balance.GetTimeline(req.Context(), userID, from, to, interval)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "invalid request") {
+				respond.Error(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			respond.Error(w, http.StatusInternalServerError, "Failed to get balance timeline")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(timeline)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
 // Helper functions for JSON parsing and UUID formatting
 func parseJSONBody(req *http.Request, v interface{}) error {
 	if req.Body == nil {
@@ -206,6 +241,38 @@ func formatUUID(uuidPtr *uuid.UUID) string {
 	return `"` + uuidPtr.String() + `"`
 }
 
+// formatOptionalString formats a string field for the hand-built JSON responses, preceded by a
+// comma, or returns nothing when the value is empty. Values are escaped as they may be user input.
+func formatOptionalString(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	encoded, _ := json.Marshal(value) // Marshaling a string cannot fail
+	return `,"` + name + `":` + string(encoded)
+}
+
+// isAmountLimitError reports whether err rejects an amount outside its transaction limits.
+func isAmountLimitError(err error) bool {
+	var limitErr *domain.AmountLimitError
+	return errors.As(err, &limitErr)
+}
+
+// writeTransactionError writes a failed credit, debit or transfer: amounts outside their limits
+// get a 422 listing the allowed range, movements held up until the user steps up a 403, anything
+// else a 400.
+func writeTransactionError(w http.ResponseWriter, err error) {
+	if isAmountLimitError(err) {
+		middleware.WriteValidationErrors(w, err)
+		return
+	}
+	if isStepUpRequired(err) {
+		writeStepUpRequired(w)
+		return
+	}
+
+	respond.Error(w, http.StatusBadRequest, err.Error())
+}
+
 // handleCredit handles crediting money to a user's account.
 func (r *Router) handleCredit(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
@@ -214,35 +281,27 @@ func (r *Router) handleCredit(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Parse request body
 		var creditReq domain.CreditRequest
 		if err := parseJSONBody(req, &creditReq); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid JSON request body","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid JSON request body")
 			return
 		}
 
 		// Process the credit transaction
 		transaction, err := r.services.Transaction.Credit(req.Context(), userID, &creditReq)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"` + err.Error() + `","code":400}`))
+			writeTransactionError(w, err)
 			return
 		}
 
@@ -256,7 +315,9 @@ func (r *Router) handleCredit(w http.ResponseWriter, req *http.Request) {
 			`,"amount":` + fmt.Sprintf("%.2f", transaction.Amount) +
 			`,"currency":"` + transaction.Currency + `","type":"` + transaction.Type +
 			`","status":"` + transaction.Status +
-			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"}`
+			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"` +
+			formatOptionalString("description", transaction.Description) +
+			formatOptionalString("client_reference", transaction.ClientReference) + `}`
 
 		_, _ = w.Write([]byte(response))
 	}))
@@ -272,35 +333,27 @@ func (r *Router) handleDebit(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Parse request body
 		var debitReq domain.DebitRequest
 		if err := parseJSONBody(req, &debitReq); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid JSON request body","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid JSON request body")
 			return
 		}
 
 		// Process the debit transaction
 		transaction, err := r.services.Transaction.Debit(req.Context(), userID, &debitReq)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"` + err.Error() + `","code":400}`))
+			writeTransactionError(w, err)
 			return
 		}
 
@@ -314,7 +367,10 @@ func (r *Router) handleDebit(w http.ResponseWriter, req *http.Request) {
 			`,"amount":` + fmt.Sprintf("%.2f", transaction.Amount) +
 			`,"currency":"` + transaction.Currency + `","type":"` + transaction.Type +
 			`","status":"` + transaction.Status +
-			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"}`
+			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"` +
+			formatOptionalString("description", transaction.Description) +
+			formatOptionalString("category", transaction.Category) +
+			formatOptionalString("client_reference", transaction.ClientReference) + `}`
 
 		_, _ = w.Write([]byte(response))
 	}))
@@ -330,35 +386,27 @@ func (r *Router) handleTransfer(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		fromUserID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Parse request body
 		var transferReq domain.TransferRequest
 		if err := parseJSONBody(req, &transferReq); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid JSON request body","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid JSON request body")
 			return
 		}
 
 		// Process the transfer transaction
 		transaction, err := r.services.Transaction.Transfer(req.Context(), fromUserID, &transferReq)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"` + err.Error() + `","code":400}`))
+			writeTransactionError(w, err)
 			return
 		}
 
@@ -372,7 +420,10 @@ func (r *Router) handleTransfer(w http.ResponseWriter, req *http.Request) {
 			`,"amount":` + fmt.Sprintf("%.2f", transaction.Amount) +
 			`,"currency":"` + transaction.Currency + `","type":"` + transaction.Type +
 			`","status":"` + transaction.Status +
-			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"}`
+			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"` +
+			formatOptionalString("description", transaction.Description) +
+			formatOptionalString("category", transaction.Category) +
+			formatOptionalString("client_reference", transaction.ClientReference) + `}`
 
 		_, _ = w.Write([]byte(response))
 	}))
@@ -380,25 +431,33 @@ func (r *Router) handleTransfer(w http.ResponseWriter, req *http.Request) {
 	finalHandler.ServeHTTP(w, req)
 }
 
-// handleGetTransaction handles retrieving a specific transaction by ID.
+// transactionWaitQuery checks how long a transaction lookup waits for the transaction to settle.
+var transactionWaitQuery = middleware.Query(middleware.Check("wait", func(wait string) error {
+	_, err := domain.ParseTransactionWait(wait)
+	return err
+}))
+
+// transactionWaitMargin is kept of the request deadline to answer once a wait runs out.
+const transactionWaitMargin = time.Second
+
+// handleGetTransaction handles retrieving a specific transaction by ID. With wait, e.g. wait=30s,
+// a pending transaction is held until it succeeds or fails or the wait runs out, whichever comes
+// first, so clients without server-sent events learn the outcome without polling.
 func (r *Router) handleGetTransaction(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(transactionWaitQuery)
 
-	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		requestingUserID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
@@ -407,35 +466,34 @@ func (r *Router) handleGetTransaction(w http.ResponseWriter, req *http.Request)
 		// Path format: /api/v1/transactions/{id}
 		pathParts := strings.Split(path, "/")
 		if len(pathParts) < 5 || pathParts[4] == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Transaction ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Transaction ID is required")
 			return
 		}
 
 		transactionIDStr := pathParts[4]
 		transactionID, err := uuid.Parse(transactionIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid transaction ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid transaction ID format")
 			return
 		}
 
+		// Wait for a pending transaction to settle; failures are reported by the lookup below
+		if wait := r.transactionWait(req); wait > 0 {
+			ctx, cancel := context.WithTimeout(req.Context(), wait)
+			_, _ = r.services.TransactionStatus.AwaitSettled(ctx, transactionID, requestingUserID)
+			cancel()
+		}
+
 		// Get the transaction with authorization check
 		transaction, err := r.services.Transaction.GetByID(req.Context(), transactionID, requestingUserID)
 		if err != nil {
 			// Check if it's an access denied error
 			if err.Error() == "access denied: you don't have permission to view this transaction" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusForbidden)
-				_, _ = w.Write([]byte(`{"error":"Access denied: you don't have permission to view this transaction","code":403}`))
+				respond.Error(w, http.StatusForbidden, "Access denied: you don't have permission to view this transaction")
 				return
 			}
 
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_, _ = w.Write([]byte(`{"error":"Transaction not found","code":404}`))
+			respond.Error(w, http.StatusNotFound, "Transaction not found")
 			return
 		}
 
@@ -446,125 +504,57 @@ func (r *Router) handleGetTransaction(w http.ResponseWriter, req *http.Request)
 		// Use proper JSON marshaling to ensure valid JSON
 		jsonResponse, err := json.Marshal(transaction)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to marshal transaction response","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal transaction response")
 			return
 		}
 
 		_, _ = w.Write(jsonResponse)
-	}))
+	})))
 
 	finalHandler.ServeHTTP(w, req)
 }
 
+// transactionWait returns how long a transaction lookup waits, cut short so the request still
+// answers before its deadline.
+func (r *Router) transactionWait(req *http.Request) time.Duration {
+	wait, _ := domain.ParseTransactionWait(req.URL.Query().Get("wait")) // Checked by transactionWaitQuery
+	if deadline, ok := req.Context().Deadline(); ok {
+		wait = min(wait, time.Until(deadline)-transactionWaitMargin)
+	}
+	return wait
+}
+
 // handleGetTransactionHistory handles retrieving transaction history for the authenticated user.
+// It also serves GET /api/v1/transactions, where integrators look transactions up by the
+// client_reference they gave them.
 func (r *Router) handleGetTransactionHistory(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		transactionFilterQuery,
+	))
 
-	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
-		// Parse query parameters
-		filter := &domain.TransactionFilter{}
-
-		// Parse limit parameter
-		if limitStr := req.URL.Query().Get("limit"); limitStr != "" {
-			if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
-				filter.Limit = limit
-			} else if limit <= 0 || limit > 100 {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Limit must be between 1 and 100","code":400}`))
-				return
-			}
-		}
-
-		// Parse offset parameter
-		if offsetStr := req.URL.Query().Get("offset"); offsetStr != "" {
-			if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
-				filter.Offset = offset
-			} else if offset < 0 {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Offset must be non-negative","code":400}`))
-				return
-			}
-		}
-
-		// Parse type parameter
-		if typeStr := req.URL.Query().Get("type"); typeStr != "" {
-			switch typeStr {
-			case "credit":
-				transactionType := domain.TypeCredit
-				filter.Type = &transactionType
-			case "debit":
-				transactionType := domain.TypeDebit
-				filter.Type = &transactionType
-			case "transfer":
-				transactionType := domain.TypeTransfer
-				filter.Type = &transactionType
-			default:
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Invalid type. Must be 'credit', 'debit', or 'transfer'","code":400}`))
-				return
-			}
-		}
-
-		// Parse status parameter
-		if statusStr := req.URL.Query().Get("status"); statusStr != "" {
-			switch statusStr {
-			case "pending":
-				transactionStatus := domain.StatusPending
-				filter.Status = &transactionStatus
-			case "success":
-				transactionStatus := domain.StatusSuccess
-				filter.Status = &transactionStatus
-			case "failed":
-				transactionStatus := domain.StatusFailed
-				filter.Status = &transactionStatus
-			default:
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Invalid status. Must be 'pending', 'success', or 'failed'","code":400}`))
-				return
-			}
-		}
-
-		// Parse since parameter (RFC3339 timestamp)
-		if sinceStr := req.URL.Query().Get("since"); sinceStr != "" {
-			if sinceTime, err := time.Parse(time.RFC3339, sinceStr); err == nil {
-				filter.Since = &sinceTime
-			} else {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Invalid since parameter. Must be RFC3339 timestamp","code":400}`))
-				return
-			}
-		}
+		filter := transactionHistoryFilter(req)
+		filter.Limit, filter.Offset = middleware.QueryPage(req, 0)
 
 		// Get transaction history
 		transactions, err := r.services.Transaction.GetHistory(req.Context(), userID, filter)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to get transaction history","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to get transaction history")
 			return
 		}
 
@@ -591,18 +581,56 @@ func (r *Router) handleGetTransactionHistory(w http.ResponseWriter, req *http.Re
 
 		jsonResponse, err := json.Marshal(responseData)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to marshal response","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
 			return
 		}
 
 		_, _ = w.Write(jsonResponse)
-	}))
+	})))
 
 	finalHandler.ServeHTTP(w, req)
 }
 
+// transactionFilterQuery checks the type, status, since, description and client_reference
+// parameters that filter the transaction history and its exports.
+var transactionFilterQuery = middleware.All(
+	middleware.Enum("type", domain.TransactionTypeNames()...),
+	middleware.Enum("status", string(domain.StatusPending), string(domain.StatusSuccess), string(domain.StatusFailed)),
+	middleware.Timestamp("since"),
+	middleware.Check("description", func(description string) error {
+		if len(strings.TrimSpace(description)) > domain.MaxTransactionDescriptionLength {
+			return fmt.Errorf("description must be at most %d characters", domain.MaxTransactionDescriptionLength)
+		}
+		return nil
+	}),
+	middleware.Check("client_reference", domain.ValidateClientReference),
+)
+
+// transactionHistoryFilter builds the filter of the parameters checked by transactionFilterQuery.
+func transactionHistoryFilter(req *http.Request) *domain.TransactionFilter {
+	query := req.URL.Query()
+	filter := &domain.TransactionFilter{Since: middleware.QueryTime(req, "since")}
+
+	if typeStr := query.Get("type"); typeStr != "" {
+		transactionType := domain.TransactionType(typeStr)
+		filter.Type = &transactionType
+	}
+	if statusStr := query.Get("status"); statusStr != "" {
+		transactionStatus := domain.TransactionStatus(statusStr)
+		filter.Status = &transactionStatus
+	}
+	// Case-insensitive substring
+	if description := strings.TrimSpace(query.Get("description")); description != "" {
+		filter.Description = &description
+	}
+	// Exact match, so integrators can find the transactions they tagged
+	if clientReference := query.Get("client_reference"); clientReference != "" {
+		filter.ClientReference = &clientReference
+	}
+
+	return filter
+}
+
 // handleRollbackTransaction handles rolling back a completed transaction.
 func (r *Router) handleRollbackTransaction(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
@@ -611,17 +639,13 @@ func (r *Router) handleRollbackTransaction(w http.ResponseWriter, req *http.Requ
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		requestingUserID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
@@ -630,31 +654,54 @@ func (r *Router) handleRollbackTransaction(w http.ResponseWriter, req *http.Requ
 		// Path format: /api/v1/transactions/{id}/rollback
 		pathParts := strings.Split(path, "/")
 		if len(pathParts) < 6 || pathParts[4] == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Transaction ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Transaction ID is required")
 			return
 		}
 
 		transactionIDStr := pathParts[4]
 		transactionID, err := uuid.Parse(transactionIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid transaction ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid transaction ID format")
+			return
+		}
+
+		// Parse the optional body; an amount requests a partial rollback
+		var rollbackReq domain.RollbackRequest
+		if req.Body != nil && req.ContentLength != 0 {
+			if err := json.NewDecoder(req.Body).Decode(&rollbackReq); err != nil && err != io.EOF {
+				respond.Error(w, http.StatusBadRequest, "Invalid JSON request body")
+				return
+			}
+		}
+
+		if err := rollbackReq.Validate(); err != nil {
+			respond.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		// Check if user is admin
 		isAdmin := middleware.IsAdmin(req)
 
+		// Admin rollbacks may have to wait for a second admin's approval
+		if isAdmin && r.submitForApproval(w, req, requestingUserID, domain.ApprovalRollback, &domain.RollbackApproval{
+			TransactionID: transactionID,
+			Amount:        rollbackReq.Amount,
+		}) {
+			return
+		}
+
 		// Process the rollback transaction
 		var transaction *domain.TransactionResponse
 
-		if isAdmin {
+		switch {
+		case isAdmin && rollbackReq.Amount != nil:
+			transaction, err = r.services.Transaction.PartialRollbackByAdmin(req.Context(), transactionID, *rollbackReq.Amount)
+		case isAdmin:
 			// Admin can rollback any transaction
 			transaction, err = r.services.Transaction.RollbackByAdmin(req.Context(), transactionID)
-		} else {
+		case rollbackReq.Amount != nil:
+			transaction, err = r.services.Transaction.PartialRollback(req.Context(), transactionID, requestingUserID, *rollbackReq.Amount)
+		default:
 			// Regular user can only rollback their own transactions
 			transaction, err = r.services.Transaction.Rollback(req.Context(), transactionID, requestingUserID)
 		}
@@ -662,20 +709,23 @@ func (r *Router) handleRollbackTransaction(w http.ResponseWriter, req *http.Requ
 		if err != nil {
 			// Check for specific error types
 			switch {
+			case isStepUpRequired(err):
+				writeStepUpRequired(w)
+				return
 			case err.Error() == "access denied: you don't have permission to rollback this transaction":
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusForbidden)
-				_, _ = w.Write([]byte(`{"error":"Access denied: you don't have permission to rollback this transaction","code":403}`))
+				respond.Error(w, http.StatusForbidden, "Access denied: you don't have permission to rollback this transaction")
 				return
 			case err.Error() == "can only rollback completed transactions":
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Can only rollback completed transactions","code":400}`))
+				respond.Error(w, http.StatusBadRequest, "Can only rollback completed transactions")
+				return
+			case err.Error() == "transaction has already been rolled back":
+				respond.Error(w, http.StatusConflict, "Transaction has already been rolled back")
+				return
+			case strings.HasPrefix(err.Error(), "rollback window expired"):
+				respond.Error(w, http.StatusForbidden, err.Error())
 				return
 			default:
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"` + err.Error() + `","code":400}`))
+				respond.Error(w, http.StatusBadRequest, err.Error())
 				return
 			}
 		}
@@ -690,10 +740,60 @@ func (r *Router) handleRollbackTransaction(w http.ResponseWriter, req *http.Requ
 			`,"amount":` + fmt.Sprintf("%.2f", transaction.Amount) +
 			`,"currency":"` + transaction.Currency + `","type":"` + transaction.Type +
 			`","status":"` + transaction.Status +
-			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") + `"}`
+			`","created_at":"` + transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
+			`","reversal_of_transaction_id":` + formatUUID(transaction.ReversalOfTransactionID) +
+			formatOptionalString("description", transaction.Description) + `}`
 
 		_, _ = w.Write([]byte(response))
 	}))
 
 	finalHandler.ServeHTTP(w, req)
 }
+
+// handleAdjustBalance handles correcting a user's balance with a credit or debit (admin only).
+func (r *Router) handleAdjustBalance(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+		userID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.BalanceAdjustmentRequest) {
+			// Adjustments may have to wait for a second admin's approval
+			if r.submitForApproval(w, req, adminID, domain.ApprovalBalanceAdjustment, &domain.BalanceAdjustmentApproval{
+				UserID:  userID,
+				Request: *body,
+			}) {
+				return
+			}
+
+			transaction, err := r.services.Transaction.AdjustBalance(req.Context(), userID, body)
+			if err != nil {
+				writeTransactionError(w, err)
+				return
+			}
+
+			jsonResponse, err := json.Marshal(transaction)
+			if err != nil {
+				respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(jsonResponse)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
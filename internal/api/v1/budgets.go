@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListBudgets handles listing the user's category budgets.
+func (r *Router) handleListBudgets(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		budgets, err := r.services.Budget.List(req.Context(), userID)
+		if err != nil {
+			writeBudgetError(w, err, "Failed to list budgets")
+			return
+		}
+
+		writeBudgetJSON(w, http.StatusOK, map[string]interface{}{
+			"budgets": budgets,
+		})
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleSetBudget handles creating or changing the user's monthly budget for a category.
+func (r *Router) handleSetBudget(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		category := req.PathValue("category")
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SetBudgetRequest) {
+			budget, err := r.services.Budget.Set(req.Context(), userID, category, body)
+			if err != nil {
+				writeBudgetError(w, err, "Failed to set budget")
+				return
+			}
+
+			writeBudgetJSON(w, http.StatusOK, budget)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleDeleteBudget handles removing the user's budget for a category.
+func (r *Router) handleDeleteBudget(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		if err := r.services.Budget.Delete(req.Context(), userID, req.PathValue("category")); err != nil {
+			writeBudgetError(w, err, "Failed to delete budget")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"Budget deleted successfully"}`))
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeBudgetError maps budget service errors to HTTP responses.
+func writeBudgetError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "budget not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeBudgetJSON marshals a budget response with the given status code.
+func writeBudgetJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// handleGetCalendar handles describing the weekends and holidays of a calendar region, the default
+// region unless the region query parameter names another, along with the known regions.
+func (r *Router) handleGetCalendar(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		region, err := r.services.Calendar.Region(req.Context(), req.URL.Query().Get("region"))
+		if err != nil {
+			respond.Error(w, http.StatusNotFound, "Unknown calendar region")
+			return
+		}
+
+		writeCalendarJSON(w, http.StatusOK, map[string]interface{}{
+			"region":       region.Region,
+			"is_default":   region.IsDefault,
+			"weekend_days": region.WeekendDays,
+			"holidays":     region.Holidays,
+			"regions":      r.services.Calendar.Regions(req.Context()),
+		})
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeCalendarJSON marshals a calendar response with the given status code.
+func writeCalendarJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
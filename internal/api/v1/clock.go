@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleGetClock handles reporting the time the simulation clock shows (admin only).
+func (r *Router) handleGetClock(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.clockEnabled(w) {
+			return
+		}
+
+		writeSimulationJSON(w, http.StatusOK, r.services.Clock.State())
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleAdvanceClock handles fast-forwarding the simulation clock (admin only). Scheduled
+// transactions that come due catch up on the following scheduler cycles.
+func (r *Router) handleAdvanceClock(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.clockEnabled(w) {
+			return
+		}
+
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.ClockAdvanceRequest) {
+			state, err := r.services.Clock.Advance(req.Context(), adminID, body)
+			if err != nil {
+				if strings.HasPrefix(err.Error(), "invalid request") {
+					respond.Error(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				respond.Error(w, http.StatusInternalServerError, "Failed to advance clock")
+				return
+			}
+
+			writeSimulationJSON(w, http.StatusOK, state)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// clockEnabled reports whether the simulation clock is available, writing an error response if not.
+func (r *Router) clockEnabled(w http.ResponseWriter) bool {
+	if r.services.Clock == nil {
+		respond.Error(w, http.StatusServiceUnavailable, "Simulation clock is disabled")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListContacts handles listing the user's saved contacts together with their recent
+// transfer counterparties.
+func (r *Router) handleListContacts(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.Int("recent", 0, 50)))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		contacts, err := r.services.Contact.List(req.Context(), userID, middleware.QueryInt(req, "recent", 10))
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list contacts")
+			return
+		}
+
+		writeContactJSON(w, http.StatusOK, map[string]interface{}{
+			"contacts": contacts,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleCreateContact handles saving a contact.
+func (r *Router) handleCreateContact(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.CreateContactRequest) {
+			contact, err := r.services.Contact.Create(req.Context(), userID, body)
+			if err != nil {
+				writeContactError(w, err, "Failed to create contact")
+				return
+			}
+
+			writeContactJSON(w, http.StatusCreated, contact)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleDeleteContact handles removing a saved contact.
+func (r *Router) handleDeleteContact(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		contactID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid contact ID format")
+			return
+		}
+
+		if err := r.services.Contact.Delete(req.Context(), contactID, userID); err != nil {
+			writeContactError(w, err, "Failed to delete contact")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"Contact deleted successfully"}`))
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeContactError maps contact service errors to HTTP responses.
+func writeContactError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "contact not found", err.Error() == "contact user not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "access denied"):
+		respond.Error(w, http.StatusForbidden, err.Error())
+	case err.Error() == "contact already exists":
+		respond.Error(w, http.StatusConflict, err.Error())
+	case err.Error() == "cannot add self as contact", strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeContactJSON marshals a contact response with the given status code.
+func writeContactJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListCurrencies handles listing the currencies requests may be made in.
+func (r *Router) handleListCurrencies(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		currencies, err := r.services.Currency.List(req.Context(), false)
+		if err != nil {
+			writeCurrencyError(w, err, "Failed to list currencies")
+			return
+		}
+
+		writeCurrencyJSON(w, http.StatusOK, map[string]interface{}{
+			"currencies": currencies,
+		})
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleAdminListCurrencies handles listing every currency of the registry, disabled ones included (admin only).
+func (r *Router) handleAdminListCurrencies(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		currencies, err := r.services.Currency.List(req.Context(), true)
+		if err != nil {
+			writeCurrencyError(w, err, "Failed to list currencies")
+			return
+		}
+
+		writeCurrencyJSON(w, http.StatusOK, map[string]interface{}{
+			"currencies": currencies,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleCreateCurrency handles adding a currency to the registry (admin only).
+func (r *Router) handleCreateCurrency(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.CreateCurrencyRequest) {
+			currency, err := r.services.Currency.Create(req.Context(), adminID, body)
+			if err != nil {
+				writeCurrencyError(w, err, "Failed to create currency")
+				return
+			}
+
+			writeCurrencyJSON(w, http.StatusCreated, currency)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleUpdateCurrency handles changing a currency of the registry (admin only).
+func (r *Router) handleUpdateCurrency(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.UpdateCurrencyRequest) {
+			currency, err := r.services.Currency.Update(req.Context(), adminID, req.PathValue("code"), body)
+			if err != nil {
+				writeCurrencyError(w, err, "Failed to update currency")
+				return
+			}
+
+			writeCurrencyJSON(w, http.StatusOK, currency)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeCurrencyError maps currency service errors to HTTP responses.
+func writeCurrencyError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "currency not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeCurrencyJSON marshals a currency response with the given status code.
+func writeCurrencyJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,240 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleOpenDispute handles opening a dispute on a transaction.
+func (r *Router) handleOpenDispute(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		transactionID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid transaction ID format")
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.OpenDisputeRequest) {
+			dispute, err := r.services.Dispute.Open(req.Context(), transactionID, userID, body)
+			if err != nil {
+				writeDisputeError(w, err, "Failed to open dispute")
+				return
+			}
+
+			writeDisputeJSON(w, http.StatusCreated, dispute)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListDisputes handles listing disputes. Admins see all disputes, users see their own.
+func (r *Router) handleListDisputes(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Enum("status",
+			string(domain.DisputeStatusOpen), string(domain.DisputeStatusUnderReview),
+			string(domain.DisputeStatusRefunded), string(domain.DisputeStatusRejected)),
+	))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		// Parse query parameters
+		status := req.URL.Query().Get("status")
+		limit, offset := middleware.QueryPage(req, 10)
+
+		filter := &domain.DisputeFilter{
+			Limit:  limit,
+			Offset: offset,
+		}
+
+		if status != "" {
+			filter.Status = &status
+		}
+
+		disputes, err := r.services.Dispute.List(req.Context(), userID, middleware.IsAdmin(req), filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list disputes")
+			return
+		}
+
+		if disputes == nil {
+			disputes = []*domain.Dispute{}
+		}
+
+		writeDisputeJSON(w, http.StatusOK, map[string]interface{}{
+			"disputes": disputes,
+			"limit":    limit,
+			"offset":   offset,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetDispute handles retrieving a dispute with its comments.
+func (r *Router) handleGetDispute(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		disputeID, ok := disputeIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		details, err := r.services.Dispute.GetByID(req.Context(), disputeID, userID, middleware.IsAdmin(req))
+		if err != nil {
+			writeDisputeError(w, err, "Failed to get dispute")
+			return
+		}
+
+		writeDisputeJSON(w, http.StatusOK, details)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleAddDisputeComment handles adding a comment to a dispute.
+func (r *Router) handleAddDisputeComment(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		disputeID, ok := disputeIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.DisputeCommentRequest) {
+			comment, err := r.services.Dispute.AddComment(req.Context(), disputeID, userID, middleware.IsAdmin(req), body)
+			if err != nil {
+				writeDisputeError(w, err, "Failed to add comment")
+				return
+			}
+
+			writeDisputeJSON(w, http.StatusCreated, comment)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleResolveDispute handles resolving a dispute by refund or rejection (admin only).
+func (r *Router) handleResolveDispute(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		disputeID, ok := disputeIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.ResolveDisputeRequest) {
+			dispute, err := r.services.Dispute.Resolve(req.Context(), disputeID, adminID, body)
+			if err != nil {
+				writeDisputeError(w, err, "Failed to resolve dispute")
+				return
+			}
+
+			writeDisputeJSON(w, http.StatusOK, dispute)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// currentUserUUID returns the authenticated user's ID, writing an error response if unavailable.
+func currentUserUUID(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := middleware.GetCurrentUserID(req)
+	if !ok {
+		respond.Error(w, http.StatusUnauthorized, "User not authenticated")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// disputeIDFromPath parses the dispute ID path value, writing an error response if invalid.
+func disputeIDFromPath(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	disputeID, err := uuid.Parse(req.PathValue("id"))
+	if err != nil {
+		respond.Error(w, http.StatusBadRequest, "Invalid dispute ID format")
+		return uuid.Nil, false
+	}
+
+	return disputeID, true
+}
+
+// writeDisputeError maps dispute service errors to HTTP responses.
+func writeDisputeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "transaction not found", err.Error() == "dispute not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "access denied"):
+		respond.Error(w, http.StatusForbidden, err.Error())
+	case err.Error() == "transaction already has an open dispute", err.Error() == "dispute is already resolved":
+		respond.Error(w, http.StatusConflict, err.Error())
+	case err.Error() == "can only dispute completed transactions", strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeDisputeJSON marshals a dispute response with the given status code.
+func writeDisputeJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// dormantAccountsQuery checks the parameters of the dormant accounts report.
+var dormantAccountsQuery = middleware.Query(
+	middleware.Pagination(middleware.MaxPageLimit),
+	middleware.Int("days", 1, domain.MaxDormancyDays),
+	middleware.Enum("format", "json", "csv"),
+)
+
+// handleGetDormantAccounts handles listing the accounts without transactions in the last days days,
+// with their balances and last activity, as JSON or as a CSV download of every account (admin only).
+func (r *Router) handleGetDormantAccounts(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(dormantAccountsQuery)
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		days := middleware.QueryInt(req, "days", domain.DefaultDormancyDays)
+
+		if req.URL.Query().Get("format") == "csv" {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="dormant-accounts-`+time.Now().UTC().Format("20060102-150405")+`.csv"`)
+			w.WriteHeader(http.StatusOK)
+
+			// Headers are already sent, so a failure part way through can only be logged
+			if _, err := r.services.Dormancy.WriteCSV(req.Context(), w, days); err != nil {
+				utils.ErrorContext(req.Context(), "dormant accounts export interrupted", "error", err.Error())
+			}
+			return
+		}
+
+		limit, offset := middleware.QueryPage(req, 50)
+		report, err := r.services.Dormancy.Report(req.Context(), days, limit, offset)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to build dormant accounts report")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(report)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListEvents handles querying the event store (admin only).
+func (r *Router) handleListEvents(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Int("limit", 1, 500),
+		middleware.Check("aggregate_type", checkAggregateType),
+		middleware.UUID("aggregate_id"),
+		middleware.TimeRange("since", "until"),
+	))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		filter := &domain.EventFilter{
+			Limit: middleware.QueryInt(req, "limit", 100),
+			Since: middleware.QueryTime(req, "since"),
+			Until: middleware.QueryTime(req, "until"),
+		}
+
+		if typeStr := query.Get("aggregate_type"); typeStr != "" {
+			aggregateType := domain.AggregateType(typeStr)
+			filter.AggregateType = &aggregateType
+		}
+
+		if aggregateID := middleware.QueryUUID(req, "aggregate_id"); aggregateID != uuid.Nil {
+			filter.AggregateID = &aggregateID
+		}
+
+		if eventType := query.Get("event_type"); eventType != "" {
+			filter.EventType = &eventType
+		}
+
+		page, err := r.services.Event.QueryEvents(req.Context(), filter)
+		if err != nil {
+			writeEventError(w, err, "Failed to query events")
+			return
+		}
+
+		writeEventJSON(w, page)
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetAggregateEvents handles listing the full history of an aggregate (admin only).
+func (r *Router) handleGetAggregateEvents(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		aggregateType := domain.AggregateType(req.PathValue("type"))
+		if !aggregateType.IsValid() {
+			respond.Error(w, http.StatusBadRequest, "Unknown aggregate type")
+			return
+		}
+
+		aggregateID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid aggregate ID format")
+			return
+		}
+
+		events, err := r.services.Event.GetAggregateEvents(req.Context(), aggregateType, aggregateID)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to get aggregate events")
+			return
+		}
+		if len(events) == 0 {
+			respond.Error(w, http.StatusNotFound, "Aggregate has no events")
+			return
+		}
+
+		responses := make([]domain.EventResponse, 0, len(events))
+		for _, event := range events {
+			responses = append(responses, event.ToResponse())
+		}
+
+		writeEventJSON(w, map[string]interface{}{
+			"aggregate_type": aggregateType,
+			"aggregate_id":   aggregateID,
+			"version":        events[len(events)-1].Version,
+			"events":         responses,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeEventError maps event store query errors to HTTP responses.
+func writeEventError(w http.ResponseWriter, err error, fallback string) {
+	if strings.HasPrefix(err.Error(), "invalid request") {
+		respond.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respond.Error(w, http.StatusInternalServerError, fallback)
+}
+
+// writeEventJSON marshals an event store response.
+func writeEventJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
+
+// checkAggregateType accepts the aggregate types the event store records.
+func checkAggregateType(value string) error {
+	if !domain.AggregateType(value).IsValid() {
+		return errors.New("unknown aggregate_type")
+	}
+	return nil
+}
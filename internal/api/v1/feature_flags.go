@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/featureflags"
+)
+
+// handleListFeatureFlags handles listing every feature flag and its current state (admin only).
+func (r *Router) handleListFeatureFlags(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeFeatureFlagJSON(w, map[string]interface{}{
+			"flags": r.services.FeatureFlags.List(req.Context()),
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleSetFeatureFlag handles overriding a feature flag for all instances (admin only).
+func (r *Router) handleSetFeatureFlag(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		flag, err := featureflags.Parse(req.PathValue("name"))
+		if err != nil {
+			writeFeatureFlagError(w, err)
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SetFeatureFlagRequest) {
+			if err := r.services.FeatureFlags.Set(req.Context(), flag, *body.Enabled); err != nil {
+				writeFeatureFlagError(w, err)
+				return
+			}
+
+			writeFeatureFlagState(w, req, r.services.FeatureFlags, flag)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleResetFeatureFlag handles removing a runtime override so the flag follows configuration again (admin only).
+func (r *Router) handleResetFeatureFlag(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		flag, err := featureflags.Parse(req.PathValue("name"))
+		if err != nil {
+			writeFeatureFlagError(w, err)
+			return
+		}
+
+		if err := r.services.FeatureFlags.Reset(req.Context(), flag); err != nil {
+			writeFeatureFlagError(w, err)
+			return
+		}
+
+		writeFeatureFlagState(w, req, r.services.FeatureFlags, flag)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeFeatureFlagState writes the current state of a single flag.
+func writeFeatureFlagState(w http.ResponseWriter, req *http.Request, flags *featureflags.Manager, flag featureflags.Flag) {
+	for _, state := range flags.List(req.Context()) {
+		if state.Flag == flag {
+			writeFeatureFlagJSON(w, state)
+			return
+		}
+	}
+}
+
+// writeFeatureFlagError maps feature flag errors to HTTP responses.
+func writeFeatureFlagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, featureflags.ErrUnknownFlag):
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, featureflags.ErrNoStore):
+		respond.Error(w, http.StatusServiceUnavailable, "Runtime feature flags require Redis")
+	default:
+		respond.Error(w, http.StatusInternalServerError, "Failed to update feature flag")
+	}
+}
+
+// writeFeatureFlagJSON marshals a feature flag response.
+func writeFeatureFlagJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleImpersonateUser handles minting a short-lived impersonation token for a user (admin only).
+func (r *Router) handleImpersonateUser(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminIDStr, ok := middleware.GetCurrentUserID(req)
+		if !ok {
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
+			return
+		}
+
+		adminID, err := uuid.Parse(adminIDStr)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
+			return
+		}
+
+		// Extract target user ID from URL path
+		targetIDStr := req.PathValue("id")
+		if targetIDStr == "" {
+			respond.Error(w, http.StatusBadRequest, "User ID is required")
+			return
+		}
+
+		targetID, err := uuid.Parse(targetIDStr)
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.ImpersonationRequest) {
+			impersonation, err := r.services.Auth.Impersonate(req.Context(), adminID, targetID, body.Reason)
+			if err != nil {
+				switch err.Error() {
+				case "user not found":
+					respond.Error(w, http.StatusNotFound, "User not found")
+				case "cannot impersonate yourself", "cannot impersonate while impersonating",
+					"cannot impersonate an admin", "cannot impersonate an inactive user":
+					respond.Error(w, http.StatusForbidden, err.Error())
+				default:
+					respond.Error(w, http.StatusInternalServerError, "Failed to start impersonation")
+				}
+				return
+			}
+
+			jsonResponse, err := json.Marshal(impersonation)
+			if err != nil {
+				respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(jsonResponse)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListImpersonationSessions handles listing active impersonation sessions (admin only).
+func (r *Router) handleListImpersonationSessions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		sessions, err := r.services.Auth.ListActiveImpersonations(req.Context())
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list impersonation sessions")
+			return
+		}
+
+		if sessions == nil {
+			sessions = []*domain.ImpersonationSession{}
+		}
+
+		responseData := map[string]interface{}{
+			"sessions": sessions,
+			"total":    len(sessions),
+		}
+
+		jsonResponse, err := json.Marshal(responseData)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// maxImportBytes is the largest CSV an import accepts.
+const maxImportBytes = 32 << 20
+
+// handleImportUsers handles importing users and their opening balances from a CSV (admin only).
+// With dry_run=true the file is only validated. A file with invalid rows is rejected whole with
+// 422 and the errors of every row.
+func (r *Router) handleImportUsers(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.Bool("dry_run")))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		dryRun := req.URL.Query().Get("dry_run") == "true"
+
+		body := http.MaxBytesReader(w, req.Body, maxImportBytes)
+		result, err := r.services.Import.Import(req.Context(), adminID, body, dryRun)
+		if err != nil {
+			writeImportError(w, err, "Failed to import users")
+			return
+		}
+
+		status := http.StatusCreated
+		switch {
+		case len(result.Errors) > 0:
+			status = http.StatusUnprocessableEntity
+		case result.DryRun:
+			status = http.StatusOK
+		}
+		writeImportJSON(w, status, result)
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeImportError maps import service errors to HTTP responses.
+func writeImportError(w http.ResponseWriter, err error, fallback string) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		respond.Error(w, http.StatusRequestEntityTooLarge, "File too large: at most "+strconv.FormatInt(tooLarge.Limit, 10)+" bytes")
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeImportJSON marshals an import response with the given status code.
+func writeImportJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// handleGetIndexAdvice handles explaining the hottest queries against current statistics and
+// suggesting the indexes they miss (admin only). Queries are planned, never run.
+func (r *Router) handleGetIndexAdvice(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.services.IndexAdvisor == nil {
+			respond.Error(w, http.StatusServiceUnavailable, "Index advice requires PostgreSQL storage")
+			return
+		}
+
+		report, err := r.services.IndexAdvisor.Report(req.Context())
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to advise indexes")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(report)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
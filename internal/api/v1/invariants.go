@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// handleGetInvariants handles checking the money supply invariant of every currency (admin only).
+// The check runs on request; a violated invariant is reported with holds=false rather than an error status.
+func (r *Router) handleGetInvariants(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report, err := r.services.Invariant.Check(req.Context())
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to check invariants")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(report)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
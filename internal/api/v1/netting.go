@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListNettingBatches handles listing netting batches, newest first (admin only).
+func (r *Router) handleListNettingBatches(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Enum("status", string(domain.NettingOpen), string(domain.NettingSettled), string(domain.NettingFailed)),
+	))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.services.Netting == nil {
+			respond.Error(w, http.StatusServiceUnavailable, "Netting is disabled")
+			return
+		}
+
+		// Parse query parameters
+		status := domain.NettingStatus(req.URL.Query().Get("status"))
+		limit, offset := middleware.QueryPage(req, 20)
+
+		filter := &domain.NettingBatchFilter{
+			Limit:  limit,
+			Offset: offset,
+		}
+
+		if status != "" {
+			filter.Status = &status
+		}
+
+		batches, err := r.services.Netting.ListNettingBatches(req.Context(), filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list netting batches")
+			return
+		}
+
+		if batches == nil {
+			batches = []*domain.NettingBatch{}
+		}
+
+		writeNettingJSON(w, http.StatusOK, map[string]interface{}{
+			"batches": batches,
+			"limit":   limit,
+			"offset":  offset,
+		})
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeNettingJSON marshals a netting response with the given status code.
+func writeNettingJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListNotifications handles listing the user's in-app inbox. unread=true lists unread notifications only.
+func (r *Router) handleListNotifications(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Bool("unread"),
+	))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		// Parse query parameters
+		unreadOnly := req.URL.Query().Get("unread") == "true"
+		limit, offset := middleware.QueryPage(req, 20)
+
+		filter := &domain.NotificationFilter{
+			UnreadOnly: unreadOnly,
+			Limit:      limit,
+			Offset:     offset,
+		}
+
+		items, unread, err := r.services.Notification.List(req.Context(), userID, filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list notifications")
+			return
+		}
+
+		writeNotificationJSON(w, http.StatusOK, map[string]interface{}{
+			"notifications": items,
+			"unread_count":  unread,
+			"limit":         limit,
+			"offset":        offset,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleMarkNotificationRead handles marking one of the user's notifications as read.
+func (r *Router) handleMarkNotificationRead(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		notificationID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid notification ID format")
+			return
+		}
+
+		notification, err := r.services.Notification.MarkRead(req.Context(), notificationID, userID)
+		if err != nil {
+			writeNotificationError(w, err, "Failed to mark notification as read")
+			return
+		}
+
+		writeNotificationJSON(w, http.StatusOK, notification)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleMarkAllNotificationsRead handles marking every unread notification of the user as read.
+func (r *Router) handleMarkAllNotificationsRead(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		marked, err := r.services.Notification.MarkAllRead(req.Context(), userID)
+		if err != nil {
+			writeNotificationError(w, err, "Failed to mark notifications as read")
+			return
+		}
+
+		writeNotificationJSON(w, http.StatusOK, map[string]interface{}{
+			"marked": marked,
+		})
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetNotificationPreferences handles retrieving the user's notification preferences.
+func (r *Router) handleGetNotificationPreferences(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		prefs, err := r.services.Notification.GetPreferences(req.Context(), userID)
+		if err != nil {
+			writeNotificationError(w, err, "Failed to get notification preferences")
+			return
+		}
+
+		writeNotificationJSON(w, http.StatusOK, prefs)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleUpdateNotificationPreferences handles changing the user's notification preferences.
+func (r *Router) handleUpdateNotificationPreferences(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.UpdateNotificationPreferencesRequest) {
+			prefs, err := r.services.Notification.UpdatePreferences(req.Context(), userID, body)
+			if err != nil {
+				writeNotificationError(w, err, "Failed to update notification preferences")
+				return
+			}
+
+			writeNotificationJSON(w, http.StatusOK, prefs)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeNotificationError maps notification service errors to HTTP responses.
+func writeNotificationError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "notification not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "access denied"):
+		respond.Error(w, http.StatusForbidden, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeNotificationJSON marshals a notification response with the given status code.
+func writeNotificationJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
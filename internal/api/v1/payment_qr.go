@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/qrcode"
+)
+
+// paymentQRModuleSize is the pixels per module of payment QR code images.
+const paymentQRModuleSize = 8
+
+// handleGetPaymentQR handles creating a payment QR code for the authenticated user's account,
+// optionally with an amount, currency and description. With format=png the code is returned as
+// an image instead of its payload.
+func (r *Router) handleGetPaymentQR(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.Enum("format", "json", "png")))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		query := req.URL.Query()
+		format := query.Get("format")
+
+		qrReq := &domain.PaymentQRRequest{
+			Currency:    query.Get("currency"),
+			Description: query.Get("description"),
+		}
+		if amount := query.Get("amount"); amount != "" {
+			var err error
+			if qrReq.Amount, err = domain.ParseAmount(amount, qrReq.Currency); err != nil || qrReq.Amount <= 0 {
+				respond.Error(w, http.StatusBadRequest, "Invalid amount parameter. Must be a positive decimal number, e.g. 12.50")
+				return
+			}
+		}
+
+		q, err := r.services.PaymentQR.Generate(req.Context(), userID, qrReq)
+		if err != nil {
+			writePaymentQRError(w, err, "Failed to create payment QR code")
+			return
+		}
+
+		if format == "png" {
+			code, err := qrcode.Encode([]byte(q.Payload()))
+			if err != nil {
+				writePaymentQRError(w, err, "Failed to create payment QR code")
+				return
+			}
+			image, err := code.PNG(paymentQRModuleSize)
+			if err != nil {
+				writePaymentQRError(w, err, "Failed to render payment QR code")
+				return
+			}
+
+			w.Header().Set("Content-Type", "image/png")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(image)
+			return
+		}
+
+		writePaymentQRJSON(w, struct {
+			*domain.PaymentQR
+			Payload string `json:"payload"`
+		}{q, q.Payload()})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleTransferFromQR handles decoding a scanned payment QR payload into a prefilled transfer
+// for the authenticated user to confirm. No money moves.
+func (r *Router) handleTransferFromQR(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.QRTransferRequest) {
+			userID, ok := currentUserUUID(w, req)
+			if !ok {
+				return
+			}
+
+			draft, err := r.services.PaymentQR.PrefillTransfer(req.Context(), userID, body)
+			if err != nil {
+				writePaymentQRError(w, err, "Failed to read payment QR code")
+				return
+			}
+
+			writePaymentQRJSON(w, draft)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writePaymentQRError maps payment QR errors to HTTP responses.
+func writePaymentQRError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "account not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writePaymentQRJSON marshals a payment QR response.
+func writePaymentQRJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleCreatePaymentRequest handles requesting a payment from another user.
+func (r *Router) handleCreatePaymentRequest(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.CreatePaymentRequestRequest) {
+			request, err := r.services.PaymentRequest.Create(req.Context(), userID, body)
+			if err != nil {
+				writePaymentRequestError(w, err, "Failed to create payment request")
+				return
+			}
+
+			writePaymentRequestJSON(w, http.StatusCreated, request)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListPaymentRequests handles listing the user's payment requests. By default it lists
+// requests the user has been asked to pay; direction=outgoing lists requests the user has sent.
+func (r *Router) handleListPaymentRequests(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Enum("status",
+			string(domain.PaymentRequestPending), string(domain.PaymentRequestApproved),
+			string(domain.PaymentRequestDeclined), string(domain.PaymentRequestExpired)),
+		middleware.Enum("direction", "incoming", "outgoing"),
+	))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		// Parse query parameters
+		status := req.URL.Query().Get("status")
+		direction := req.URL.Query().Get("direction")
+		limit, offset := middleware.QueryPage(req, 10)
+
+		filter := &domain.PaymentRequestFilter{
+			Limit:  limit,
+			Offset: offset,
+		}
+
+		if status != "" {
+			filter.Status = &status
+		}
+
+		var (
+			requests []*domain.PaymentRequest
+			err      error
+		)
+		if direction == "outgoing" {
+			requests, err = r.services.PaymentRequest.ListOutgoing(req.Context(), userID, filter)
+		} else {
+			direction = "incoming"
+			requests, err = r.services.PaymentRequest.ListIncoming(req.Context(), userID, filter)
+		}
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list payment requests")
+			return
+		}
+
+		writePaymentRequestJSON(w, http.StatusOK, map[string]interface{}{
+			"payment_requests": requests,
+			"direction":        direction,
+			"limit":            limit,
+			"offset":           offset,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetPaymentRequest handles retrieving a payment request the user sent or received.
+func (r *Router) handleGetPaymentRequest(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		requestID, ok := paymentRequestIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		request, err := r.services.PaymentRequest.GetByID(req.Context(), requestID, userID)
+		if err != nil {
+			writePaymentRequestError(w, err, "Failed to get payment request")
+			return
+		}
+
+		writePaymentRequestJSON(w, http.StatusOK, request)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleApprovePaymentRequest handles the payer approving a payment request, which transfers the amount.
+func (r *Router) handleApprovePaymentRequest(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		requestID, ok := paymentRequestIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		request, err := r.services.PaymentRequest.Approve(req.Context(), requestID, userID)
+		if err != nil {
+			writePaymentRequestError(w, err, "Failed to approve payment request")
+			return
+		}
+
+		writePaymentRequestJSON(w, http.StatusOK, request)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleDeclinePaymentRequest handles the payer declining a payment request.
+func (r *Router) handleDeclinePaymentRequest(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		requestID, ok := paymentRequestIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		request, err := r.services.PaymentRequest.Decline(req.Context(), requestID, userID)
+		if err != nil {
+			writePaymentRequestError(w, err, "Failed to decline payment request")
+			return
+		}
+
+		writePaymentRequestJSON(w, http.StatusOK, request)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// paymentRequestIDFromPath parses the payment request ID path value, writing an error response if invalid.
+func paymentRequestIDFromPath(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	requestID, err := uuid.Parse(req.PathValue("id"))
+	if err != nil {
+		respond.Error(w, http.StatusBadRequest, "Invalid payment request ID format")
+		return uuid.Nil, false
+	}
+
+	return requestID, true
+}
+
+// writePaymentRequestError maps payment request service errors to HTTP responses.
+func writePaymentRequestError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case isAmountLimitError(err):
+		middleware.WriteValidationErrors(w, err)
+	case isStepUpRequired(err):
+		writeStepUpRequired(w)
+	case err.Error() == "payment request not found", err.Error() == "payer not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "access denied"):
+		respond.Error(w, http.StatusForbidden, err.Error())
+	case err.Error() == "payment request is no longer pending", err.Error() == "payment request has expired":
+		respond.Error(w, http.StatusConflict, err.Error())
+	case err.Error() == "cannot request payment from yourself",
+		strings.HasPrefix(err.Error(), "invalid request"),
+		strings.HasPrefix(err.Error(), "failed to pay payment request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writePaymentRequestJSON marshals a payment request response with the given status code.
+func writePaymentRequestJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// policyVersionsQuery accepts the pagination of policy version listings.
+var policyVersionsQuery = middleware.Query(middleware.Pagination(middleware.MaxPageLimit))
+
+// handleGetPolicy handles retrieving the policy version in effect now (admin only).
+func (r *Router) handleGetPolicy(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writePolicyJSON(w, http.StatusOK, r.services.Policy.Effective())
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleSetPolicy handles storing a new version of the policy, effective now or at a later time
+// (admin only). Policy changes may have to wait for a second admin's approval.
+func (r *Router) handleSetPolicy(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SetPolicyRequest) {
+			if r.submitForApproval(w, req, adminID, domain.ApprovalPolicyChange, body) {
+				return
+			}
+
+			version, err := r.services.Policy.Set(req.Context(), adminID, body)
+			if err != nil {
+				writePolicyError(w, err, "Failed to set policy")
+				return
+			}
+
+			writePolicyJSON(w, http.StatusCreated, version)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListPolicyVersions handles listing stored versions of the policy, newest first (admin only).
+func (r *Router) handleListPolicyVersions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(policyVersionsQuery)
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		limit, offset := middleware.QueryPage(req, 50)
+		filter := &domain.PolicyVersionFilter{Limit: limit, Offset: offset}
+
+		versions, err := r.services.Policy.List(req.Context(), filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list policy versions")
+			return
+		}
+
+		writePolicyJSON(w, http.StatusOK, map[string]interface{}{
+			"versions": versions,
+			"limit":    filter.Limit,
+			"offset":   filter.Offset,
+		})
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetPolicyVersion handles retrieving a stored version of the policy (admin only).
+func (r *Router) handleGetPolicyVersion(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		number, err := strconv.Atoi(req.PathValue("version"))
+		if err != nil || number < 1 {
+			respond.Error(w, http.StatusBadRequest, "Invalid policy version")
+			return
+		}
+
+		version, err := r.services.Policy.GetByVersion(req.Context(), number)
+		if err != nil {
+			writePolicyError(w, err, "Failed to get policy version")
+			return
+		}
+
+		writePolicyJSON(w, http.StatusOK, version)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writePolicyError maps policy service errors to HTTP responses.
+func writePolicyError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "policy version not found":
+		respond.Error(w, http.StatusNotFound, "Policy version not found")
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writePolicyJSON marshals a policy response with the given status code.
+func writePolicyJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleRebuildProjections handles starting a background rebuild of the read models (admin only).
+func (r *Router) handleRebuildProjections(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.ProjectionRebuildRequest) {
+			rebuild, err := r.services.ProjectionRebuild.Start(req.Context(), adminID, body)
+			if err != nil {
+				writeProjectionError(w, err, "Failed to start projection rebuild")
+				return
+			}
+
+			writeProjectionJSON(w, http.StatusAccepted, rebuild)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetProjectionStatus handles reporting the progress of recent read model rebuilds (admin only).
+func (r *Router) handleGetProjectionStatus(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		status, err := r.services.ProjectionRebuild.Status(req.Context())
+		if err != nil {
+			writeProjectionError(w, err, "Failed to get projection status")
+			return
+		}
+
+		writeProjectionJSON(w, http.StatusOK, status)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeProjectionError maps projection rebuild errors to HTTP responses.
+func writeProjectionError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.HasPrefix(err.Error(), "projection rebuild already running"):
+		respond.Error(w, http.StatusConflict, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeProjectionJSON marshals a projection rebuild response with the given status code.
+func writeProjectionJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
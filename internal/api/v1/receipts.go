@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleGetTransactionReceipt handles issuing a signed receipt of a successful transaction the
+// authenticated user sent or received.
+func (r *Router) handleGetTransactionReceipt(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		transactionID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid transaction ID format")
+			return
+		}
+
+		receipt, err := r.services.Receipt.Issue(req.Context(), transactionID, userID)
+		if err != nil {
+			switch {
+			case strings.HasSuffix(err.Error(), "transaction not found"):
+				respond.Error(w, http.StatusNotFound, "Transaction not found")
+			case strings.HasPrefix(err.Error(), "access denied"):
+				respond.Error(w, http.StatusForbidden, "Access denied: you don't have permission to view this transaction")
+			case strings.HasPrefix(err.Error(), "cannot "):
+				respond.Error(w, http.StatusConflict, err.Error())
+			default:
+				respond.Error(w, http.StatusInternalServerError, "Failed to issue receipt")
+			}
+			return
+		}
+
+		writeReceiptJSON(w, receipt)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleVerifyReceipt handles checking a signed receipt. It needs no authentication, so whoever
+// was handed a receipt can check it.
+func (r *Router) handleVerifyReceipt(w http.ResponseWriter, req *http.Request) {
+	handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SignedReceipt) {
+		verification, err := r.services.Receipt.Verify(req.Context(), body)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to verify receipt")
+			return
+		}
+
+		writeReceiptJSON(w, verification)
+	})
+
+	handler.ServeHTTP(w, req)
+}
+
+// writeReceiptJSON marshals a receipt response.
+func writeReceiptJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
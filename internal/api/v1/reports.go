@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// handleGetMonthlySummary handles retrieving the authenticated user's account summary of a
+// yyyy-mm month that has ended.
+func (r *Router) handleGetMonthlySummary(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		summary, err := r.services.MonthlySummary.Get(req.Context(), userID, req.PathValue("month"))
+		if err != nil {
+			writeReportError(w, err, "Failed to get monthly summary")
+			return
+		}
+
+		writeReportJSON(w, http.StatusOK, summary)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeReportError maps report service errors to HTTP responses.
+func writeReportError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "monthly summary not found":
+		respond.Error(w, http.StatusNotFound, "Monthly summary not found")
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeReportJSON marshals a report response with the given status code.
+func writeReportJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
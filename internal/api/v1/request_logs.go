@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListRequestLogs handles listing recorded money-movement requests and responses (admin only).
+func (r *Router) handleListRequestLogs(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Timestamp("since"),
+	))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		limit, offset := middleware.QueryPage(req, 20)
+
+		filter := &domain.RequestLogFilter{
+			Limit:  limit,
+			Offset: offset,
+			Since:  middleware.QueryTime(req, "since"),
+		}
+
+		requestLogs, total, err := r.services.RequestLog.List(req.Context(), filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list request logs")
+			return
+		}
+
+		writeRequestLogJSON(w, map[string]interface{}{
+			"request_logs": requestLogs,
+			"total":        total,
+			"limit":        limit,
+			"offset":       offset,
+		})
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetRequestLog handles retrieving the exchange recorded for a request ID (admin only).
+// The request ID is the X-Request-ID header returned with the original response.
+func (r *Router) handleGetRequestLog(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestID, err := uuid.Parse(req.PathValue("request_id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid request ID format")
+			return
+		}
+
+		requestLog, err := r.services.RequestLog.GetByRequestID(req.Context(), requestID)
+		if err != nil {
+			if err.Error() == "request log not found" {
+				respond.Error(w, http.StatusNotFound, "Request log not found")
+				return
+			}
+			respond.Error(w, http.StatusInternalServerError, "Failed to get request log")
+			return
+		}
+
+		writeRequestLogJSON(w, requestLog)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeRequestLogJSON marshals a request log response.
+func writeRequestLogJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
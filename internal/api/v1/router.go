@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/auth"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
@@ -31,46 +32,227 @@ func NewRouter(repos *repository.Repositories, services *service.Services, jwtMa
 	}
 }
 
-// RegisterRoutes registers all v1 API routes on the provided mux.
-func (r *Router) RegisterRoutes(mux *http.ServeMux) {
+// RegisterRoutes registers all v1 API routes on the provided route registry.
+func (r *Router) RegisterRoutes(routes *RouteRegistry) {
 	// Health/ping endpoint
-	mux.HandleFunc("GET /api/v1/ping", r.handlePing)
+	routes.HandleFunc("GET /api/v1/ping", r.handlePing)
 
-	// Test endpoint to retrieve all users (no validation)
-	mux.HandleFunc("GET /api/v1/test/users", r.handleTestGetAllUsers)
+	// Test endpoint to retrieve all users (admin only; not served in production)
+	rateLimitedTest := middleware.ScopedRateLimitMiddleware(r.services.Cache, "test", 10, time.Minute)
+	routes.HandleTestOnly("GET /api/v1/test/users", rateLimitedTest(http.HandlerFunc(r.handleTestGetAllUsers)))
 
 	// Circuit breaker test endpoints (registered in main.go with middleware)
 
 	// Auth routes with rate limiting (5 requests per minute)
 	rateLimitedAuth := middleware.RateLimitMiddleware(r.services.Cache, 5, time.Minute)
-	mux.Handle("POST /api/v1/auth/register", rateLimitedAuth(http.HandlerFunc(r.handleRegister)))
-	mux.Handle("POST /api/v1/auth/login", rateLimitedAuth(http.HandlerFunc(r.handleLogin)))
-	mux.Handle("POST /api/v1/auth/refresh", rateLimitedAuth(http.HandlerFunc(r.handleRefresh)))
+	routes.Handle("POST /api/v1/auth/register", rateLimitedAuth(http.HandlerFunc(r.handleRegister)))
+	routes.Handle("POST /api/v1/auth/login", rateLimitedAuth(http.HandlerFunc(r.handleLogin)))
+	routes.Handle("POST /api/v1/auth/refresh", rateLimitedAuth(http.HandlerFunc(r.handleRefresh)))
+	routes.Handle("POST /api/v1/auth/step-up", rateLimitedAuth(http.HandlerFunc(r.handleStepUp)))
 
 	// User routes (admin only)
-	mux.HandleFunc("GET /api/v1/users", r.handleListUsers)
-	mux.HandleFunc("GET /api/v1/users/{id}", r.handleGetUser)
-	mux.HandleFunc("PUT /api/v1/users/{id}", r.handleUpdateUser)
-	mux.HandleFunc("DELETE /api/v1/users/{id}", r.handleDeleteUser)
+	routes.HandleFunc("GET /api/v1/users", r.handleListUsers)
+	routes.HandleFunc("GET /api/v1/users/{id}", r.handleGetUser)
+	routes.HandleFunc("PUT /api/v1/users/{id}", r.handleUpdateUser)
+	routes.HandleFunc("DELETE /api/v1/users/{id}", r.handleDeleteUser)
+
+	// Account number lookup, for addressing transfers by account number
+	routes.HandleFunc("GET /api/v1/accounts/lookup", r.handleLookupAccount)
+
+	// Payment QR codes asking for a transfer to the authenticated user's account
+	routes.HandleFunc("GET /api/v1/me/payment-qr", r.handleGetPaymentQR)
+
+	// Login sessions of the authenticated user
+	routes.HandleFunc("GET /api/v1/sessions", r.handleListSessions)
+	routes.HandleFunc("DELETE /api/v1/sessions/{id}", r.handleRevokeSession)
+
+	// Security activity of the authenticated user
+	routes.HandleFunc("GET /api/v1/me/security/activity", r.handleListSecurityActivity)
+
+	// API usage of the authenticated user
+	routes.HandleFunc("GET /api/v1/me/usage", r.handleGetMyUsage)
+
+	// Impersonation routes (admin only)
+	routes.HandleFunc("POST /api/v1/admin/impersonate/{id}", r.handleImpersonateUser)
+	routes.HandleFunc("GET /api/v1/admin/impersonations", r.handleListImpersonationSessions)
+
+	// API usage and quota routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/users/{id}/usage", r.handleGetUserUsage)
+	routes.HandleFunc("PUT /api/v1/admin/users/{id}/api-quota", r.handleSetAPIQuota)
+	routes.HandleFunc("DELETE /api/v1/admin/users/{id}/api-quota", r.handleDeleteAPIQuota)
+
+	// Balance adjustment routes (admin only)
+	routes.HandleFunc("POST /api/v1/admin/users/{id}/balance-adjustments", r.handleAdjustBalance)
+
+	// Security activity of every user (admin only)
+	routes.HandleFunc("GET /api/v1/admin/security/activity", r.handleListAllSecurityActivity)
+
+	// Four-eyes approval routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/approvals", r.handleListApprovals)
+	routes.HandleFunc("GET /api/v1/admin/approvals/{id}", r.handleGetApproval)
+	routes.HandleFunc("POST /api/v1/admin/approvals/{id}/approve", r.handleApproveApproval)
+	routes.HandleFunc("POST /api/v1/admin/approvals/{id}/reject", r.handleRejectApproval)
+
+	// Runtime policy routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/policy", r.handleGetPolicy)
+	routes.HandleFunc("PUT /api/v1/admin/policy", r.handleSetPolicy)
+	routes.HandleFunc("GET /api/v1/admin/policy/versions", r.handleListPolicyVersions)
+	routes.HandleFunc("GET /api/v1/admin/policy/versions/{version}", r.handleGetPolicyVersion)
+
+	// Report routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/reports/dormant-accounts", r.handleGetDormantAccounts)
+
+	// Admin dashboard routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/stats/transactions", r.handleGetTransactionStats)
+	routes.HandleFunc("GET /api/v1/admin/cache/stats", r.handleGetCacheStats)
+
+	// Feature flag routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/feature-flags", r.handleListFeatureFlags)
+	routes.HandleFunc("PUT /api/v1/admin/feature-flags/{name}", r.handleSetFeatureFlag)
+	routes.HandleFunc("DELETE /api/v1/admin/feature-flags/{name}", r.handleResetFeatureFlag)
+
+	// Treasury routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/treasury", r.handleListTreasury)
+	routes.HandleFunc("GET /api/v1/admin/treasury/{currency}/entries", r.handleListTreasuryEntries)
+	routes.HandleFunc("POST /api/v1/admin/treasury/{currency}/mint", r.handleMintTreasury)
+	routes.HandleFunc("POST /api/v1/admin/treasury/{currency}/burn", r.handleBurnTreasury)
+	routes.HandleFunc("PUT /api/v1/admin/treasury/{currency}/caps", r.handleSetTreasuryCaps)
+
+	// Currency registry routes (listing enabled currencies is open to every user)
+	routes.HandleFunc("GET /api/v1/currencies", r.handleListCurrencies)
+	routes.HandleFunc("GET /api/v1/admin/currencies", r.handleAdminListCurrencies)
+	routes.HandleFunc("POST /api/v1/admin/currencies", r.handleCreateCurrency)
+	routes.HandleFunc("PATCH /api/v1/admin/currencies/{code}", r.handleUpdateCurrency)
+
+	// Import routes (admin only)
+	routes.HandleFunc("POST /api/v1/admin/import", r.handleImportUsers)
+
+	// Invariant routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/invariants", r.handleGetInvariants)
+
+	// Index advisor routes (admin only; available with PostgreSQL storage)
+	routes.HandleFunc("GET /api/v1/admin/diagnostics/index-advice", r.handleGetIndexAdvice)
+
+	// Netting routes (admin only; available when netting is enabled)
+	routes.HandleFunc("GET /api/v1/admin/netting/batches", r.handleListNettingBatches)
+
+	// Simulation routes (admin only; available when simulation is enabled)
+	routes.HandleFunc("POST /api/v1/admin/simulations", r.handleStartSimulation)
+	routes.HandleFunc("GET /api/v1/admin/simulations", r.handleListSimulations)
+	routes.HandleFunc("GET /api/v1/admin/simulations/{id}", r.handleGetSimulation)
+	routes.HandleFunc("DELETE /api/v1/admin/simulations/{id}", r.handleCancelSimulation)
+	routes.HandleFunc("GET /api/v1/admin/simulations/{id}/trace", r.handleGetSimulationTrace)
+
+	// Simulation clock routes (admin only; available when the simulation clock is enabled)
+	routes.HandleFunc("GET /api/v1/admin/clock", r.handleGetClock)
+	routes.HandleFunc("POST /api/v1/admin/clock/advance", r.handleAdvanceClock)
+
+	// Scheduled transaction routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/scheduled-transactions", r.handleAdminListScheduledTransactions)
+	routes.HandleFunc("GET /api/v1/admin/scheduled-transactions/failures", r.handleGetScheduledFailureReport)
+	routes.HandleFunc("POST /api/v1/admin/users/{id}/scheduled-transactions/cancel", r.handleAdminCancelUserScheduledTransactions)
+	routes.HandleFunc("POST /api/v1/admin/users/{id}/scheduled-transactions/reassign", r.handleAdminReassignUserScheduledTransactions)
+
+	// Event store routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/events", r.handleListEvents)
+	routes.HandleFunc("GET /api/v1/admin/aggregates/{type}/{id}/events", r.handleGetAggregateEvents)
+
+	// Projection routes (admin only)
+	routes.HandleFunc("POST /api/v1/admin/projections/rebuild", r.handleRebuildProjections)
+	routes.HandleFunc("GET /api/v1/admin/projections/status", r.handleGetProjectionStatus)
+
+	// Request log routes (admin only)
+	routes.HandleFunc("GET /api/v1/admin/request-logs", r.handleListRequestLogs)
+	routes.HandleFunc("GET /api/v1/admin/request-logs/{request_id}", r.handleGetRequestLog)
 
 	// Balance routes
-	mux.HandleFunc("GET /api/v1/balances/current", r.handleGetCurrentBalance)
-	mux.HandleFunc("GET /api/v1/balances/historical", r.handleGetHistoricalBalance)
-	mux.HandleFunc("GET /api/v1/balances/at-time", r.handleGetBalanceAtTime)
+	routes.HandleFunc("GET /api/v1/balances/current", r.handleGetCurrentBalance)
+	routes.HandleFunc("GET /api/v1/balances/historical", r.handleGetHistoricalBalance)
+	routes.HandleFunc("GET /api/v1/balances/at-time", r.handleGetBalanceAtTime)
+	routes.HandleFunc("GET /api/v1/balances/timeline", r.handleGetBalanceTimeline)
+	routes.HandleFunc("GET /api/v1/balances/alerts", r.handleListBalanceAlerts)
+	routes.HandleFunc("PUT /api/v1/balances/alerts/{currency}", r.handleSetBalanceAlert)
+	routes.HandleFunc("DELETE /api/v1/balances/alerts/{currency}", r.handleDeleteBalanceAlert)
+
+	// Analytics routes
+	routes.HandleFunc("GET /api/v1/analytics/forecast", r.handleGetForecast)
+	routes.HandleFunc("GET /api/v1/analytics/spending", r.handleGetSpending)
+	routes.HandleFunc("GET /api/v1/analytics/monthly", r.handleGetMonthlyActivity)
+
+	// Budget routes
+	routes.HandleFunc("GET /api/v1/budgets", r.handleListBudgets)
+	routes.HandleFunc("PUT /api/v1/budgets/{category}", r.handleSetBudget)
+	routes.HandleFunc("DELETE /api/v1/budgets/{category}", r.handleDeleteBudget)
+
+	// Report routes
+	routes.HandleFunc("GET /api/v1/reports/monthly/{month}", r.handleGetMonthlySummary)
+
+	// Search routes
+	routes.HandleFunc("GET /api/v1/search", r.handleSearch)
 
 	// Scheduled transaction routes (avoid conflict with transaction routes)
-	mux.HandleFunc("POST /api/v1/scheduled-transactions", r.handleScheduleTransaction)
-	mux.HandleFunc("GET /api/v1/scheduled-transactions", r.handleGetScheduledTransactions)
-	mux.HandleFunc("GET /api/v1/scheduled-transactions/{id}", r.handleGetScheduledTransaction)
-	mux.HandleFunc("DELETE /api/v1/scheduled-transactions/{id}", r.handleCancelScheduledTransaction)
+	routes.HandleFunc("POST /api/v1/scheduled-transactions", r.handleScheduleTransaction)
+	routes.HandleFunc("GET /api/v1/scheduled-transactions", r.handleGetScheduledTransactions)
+	routes.HandleFunc("GET /api/v1/scheduled-transactions/upcoming", r.handleGetUpcomingScheduledTransactions)
+	routes.HandleFunc("GET /api/v1/scheduled-transactions/{id}", r.handleGetScheduledTransaction)
+	routes.HandleFunc("DELETE /api/v1/scheduled-transactions/{id}", r.handleCancelScheduledTransaction)
+	routes.HandleFunc("POST /api/v1/scheduled-transactions/{id}/pause", r.handlePauseScheduledTransaction)
+	routes.HandleFunc("POST /api/v1/scheduled-transactions/{id}/resume", r.handleResumeScheduledTransaction)
+	routes.HandleFunc("POST /api/v1/scheduled-transactions/{id}/skip-next", r.handleSkipNextScheduledTransaction)
+
+	// Business day calendar routes (weekends and holidays scheduled occurrences move off)
+	routes.HandleFunc("GET /api/v1/calendar", r.handleGetCalendar)
 
 	// Transaction routes
-	mux.HandleFunc("POST /api/v1/transactions/credit", r.handleCredit)
-	mux.HandleFunc("POST /api/v1/transactions/debit", r.handleDebit)
-	mux.HandleFunc("POST /api/v1/transactions/transfer", r.handleTransfer)
-	mux.HandleFunc("POST /api/v1/transactions/{id}/rollback", r.handleRollbackTransaction)
-	mux.HandleFunc("GET /api/v1/transactions/{id}", r.handleGetTransaction)
-	mux.HandleFunc("GET /api/v1/transactions/history", r.handleGetTransactionHistory)
+	routes.HandleFunc("POST /api/v1/transactions/credit", r.handleCredit)
+	routes.HandleFunc("POST /api/v1/transactions/debit", r.handleDebit)
+	routes.HandleFunc("POST /api/v1/transactions/transfer", r.handleTransfer)
+	routes.HandleFunc("POST /api/v1/transactions/transfer/from-qr", r.handleTransferFromQR)
+	routes.HandleFunc("POST /api/v1/transactions/{id}/rollback", r.handleRollbackTransaction)
+	routes.HandleFunc("GET /api/v1/transactions/{id}", r.handleGetTransaction)
+	routes.HandleFunc("GET /api/v1/transactions/{id}/events", r.handleTransactionEvents)
+	routes.HandleFunc("GET /api/v1/transactions/{id}/receipt", r.handleGetTransactionReceipt)
+	routes.HandleFunc("GET /api/v1/transactions", r.handleGetTransactionHistory)
+	routes.HandleFunc("GET /api/v1/transactions/history", r.handleGetTransactionHistory)
+	routes.HandleFunc("GET /api/v1/transactions/history/export", r.handleExportTransactionHistory)
+	routes.HandleFunc("GET /api/v1/transactions/history/exports/{id}", r.handleGetTransactionExport)
+
+	// Receipt routes (verification is public)
+	routes.HandleFunc("POST /api/v1/receipts/verify", r.handleVerifyReceipt)
+
+	// Dispute routes
+	routes.HandleFunc("POST /api/v1/transactions/{id}/disputes", r.handleOpenDispute)
+	routes.HandleFunc("GET /api/v1/disputes", r.handleListDisputes)
+	routes.HandleFunc("GET /api/v1/disputes/{id}", r.handleGetDispute)
+	routes.HandleFunc("POST /api/v1/disputes/{id}/comments", r.handleAddDisputeComment)
+	routes.HandleFunc("POST /api/v1/disputes/{id}/resolve", r.handleResolveDispute)
+
+	// Payment request routes
+	routes.HandleFunc("POST /api/v1/payment-requests", r.handleCreatePaymentRequest)
+	routes.HandleFunc("GET /api/v1/payment-requests", r.handleListPaymentRequests)
+	routes.HandleFunc("GET /api/v1/payment-requests/{id}", r.handleGetPaymentRequest)
+	routes.HandleFunc("POST /api/v1/payment-requests/{id}/approve", r.handleApprovePaymentRequest)
+	routes.HandleFunc("POST /api/v1/payment-requests/{id}/decline", r.handleDeclinePaymentRequest)
+
+	// Transfer template routes
+	routes.HandleFunc("POST /api/v1/transfer-templates", r.handleCreateTransferTemplate)
+	routes.HandleFunc("GET /api/v1/transfer-templates", r.handleListTransferTemplates)
+	routes.HandleFunc("GET /api/v1/transfer-templates/{id}", r.handleGetTransferTemplate)
+	routes.HandleFunc("PUT /api/v1/transfer-templates/{id}", r.handleUpdateTransferTemplate)
+	routes.HandleFunc("DELETE /api/v1/transfer-templates/{id}", r.handleDeleteTransferTemplate)
+	routes.HandleFunc("POST /api/v1/transfer-templates/{id}/execute", r.handleExecuteTransferTemplate)
+
+	// Contact routes
+	routes.HandleFunc("GET /api/v1/contacts", r.handleListContacts)
+	routes.HandleFunc("POST /api/v1/contacts", r.handleCreateContact)
+	routes.HandleFunc("DELETE /api/v1/contacts/{id}", r.handleDeleteContact)
+
+	// Notification routes
+	routes.HandleFunc("GET /api/v1/notifications", r.handleListNotifications)
+	routes.HandleFunc("POST /api/v1/notifications/{id}/read", r.handleMarkNotificationRead)
+	routes.HandleFunc("POST /api/v1/notifications/read-all", r.handleMarkAllNotificationsRead)
+	routes.HandleFunc("GET /api/v1/notifications/preferences", r.handleGetNotificationPreferences)
+	routes.HandleFunc("PUT /api/v1/notifications/preferences", r.handleUpdateNotificationPreferences)
 }
 
 // handlePing responds to ping requests for testing connectivity.
@@ -92,19 +274,13 @@ func (r *Router) handleRegister(w http.ResponseWriter, req *http.Request) {
 			// Check for specific error types to return appropriate status codes
 			switch {
 			case err.Error() == "email already registered":
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusConflict)
-				_, _ = w.Write([]byte(`{"error":"Email already registered","code":409}`))
+				respond.Error(w, http.StatusConflict, "Email already registered")
 				return
 			case err.Error() == "username already taken":
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusConflict)
-				_, _ = w.Write([]byte(`{"error":"Username already taken","code":409}`))
+				respond.Error(w, http.StatusConflict, "Username already taken")
 				return
 			default:
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Registration failed","code":400}`))
+				respond.Error(w, http.StatusBadRequest, "Registration failed")
 				return
 			}
 		}
@@ -120,7 +296,8 @@ func (r *Router) handleRegister(w http.ResponseWriter, req *http.Request) {
 			`","role":"` + userResponse.Role +
 			`","created_at":"` + userResponse.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
 			`","updated_at":"` + userResponse.UpdatedAt.Format("2006-01-02T15:04:05Z07:00") +
-			`","is_active":` + strconv.FormatBool(userResponse.IsActive) + `}`
+			`","is_active":` + strconv.FormatBool(userResponse.IsActive) +
+			`,"account_number":"` + userResponse.AccountNumber + `"}`
 
 		_, _ = w.Write([]byte(response))
 	})
@@ -133,12 +310,11 @@ func (r *Router) handleLogin(w http.ResponseWriter, req *http.Request) {
 	// Use validation middleware to parse and validate the request
 	handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.LoginRequest) {
 		// Call the auth service to login the user
-		loginResponse, err := r.services.Auth.Login(req.Context(), body.Email, body.Password)
+		device := domain.LoginDevice{IP: middleware.ClientIP(req), UserAgent: req.UserAgent()}
+		loginResponse, err := r.services.Auth.Login(req.Context(), body.Email, body.Password, device)
 		if err != nil {
 			// Return 401 for authentication failures
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"Invalid email or password","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "Invalid email or password")
 			return
 		}
 
@@ -153,7 +329,8 @@ func (r *Router) handleLogin(w http.ResponseWriter, req *http.Request) {
 			`","role":"` + loginResponse.User.Role +
 			`","created_at":"` + loginResponse.User.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
 			`","updated_at":"` + loginResponse.User.UpdatedAt.Format("2006-01-02T15:04:05Z07:00") +
-			`","is_active":` + strconv.FormatBool(loginResponse.User.IsActive) + `}`
+			`","is_active":` + strconv.FormatBool(loginResponse.User.IsActive) +
+			`,"account_number":"` + loginResponse.User.AccountNumber + `"}`
 
 		response := `{"user":` + userJSON +
 			`,"access_token":"` + loginResponse.AccessToken +
@@ -171,44 +348,19 @@ func (r *Router) handleListUsers(w http.ResponseWriter, req *http.Request) {
 	// Apply authentication and admin authorization middleware
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
 	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Int("limit", 0, middleware.NoMax),
+		middleware.Int("offset", 0, middleware.NoMax),
+	))
 
-	// Chain middlewares: auth -> admin -> handler
-	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		// Parse query parameters
-		limitStr := req.URL.Query().Get("limit")
-		offsetStr := req.URL.Query().Get("offset")
-
-		limit := 0  // Default (no limit)
-		offset := 0 // Default
-
-		if limitStr != "" {
-			if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit >= 0 {
-				limit = parsedLimit
-			} else if parsedLimit < 0 {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Limit must be non-negative","code":400}`))
-				return
-			}
-		}
-
-		if offsetStr != "" {
-			if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-				offset = parsedOffset
-			} else if parsedOffset < 0 {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Offset must be non-negative","code":400}`))
-				return
-			}
-		}
+	// Chain middlewares: auth -> admin -> query -> handler
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		limit, offset := middleware.QueryPage(req, 0) // A limit of 0 lists every user
 
 		// Call the user service to list users
 		users, err := r.services.User.List(req.Context(), limit, offset)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to list users","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to list users")
 			return
 		}
 
@@ -228,12 +380,13 @@ func (r *Router) handleListUsers(w http.ResponseWriter, req *http.Request) {
 				`","role":"` + user.Role +
 				`","created_at":"` + user.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
 				`","updated_at":"` + user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00") +
-				`","is_active":` + strconv.FormatBool(user.IsActive) + `}`
+				`","is_active":` + strconv.FormatBool(user.IsActive) +
+				`,"account_number":"` + user.AccountNumber + `"}`
 		}
 		response += `],"limit":` + strconv.Itoa(limit) + `,"offset":` + strconv.Itoa(offset) + `}`
 
 		_, _ = w.Write([]byte(response))
-	})))
+	}))))
 
 	finalHandler.ServeHTTP(w, req)
 }
@@ -242,12 +395,11 @@ func (r *Router) handleRefresh(w http.ResponseWriter, req *http.Request) {
 	// Use validation middleware to parse and validate the request
 	handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.RefreshRequest) {
 		// Call the auth service to refresh the token
-		tokenResponse, err := r.services.Auth.RefreshToken(req.Context(), body.RefreshToken)
+		device := domain.LoginDevice{IP: middleware.ClientIP(req), UserAgent: req.UserAgent()}
+		tokenResponse, err := r.services.Auth.RefreshToken(req.Context(), body.RefreshToken, device)
 		if err != nil {
 			// Return 401 for invalid refresh tokens
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"Invalid refresh token","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "Invalid refresh token")
 			return
 		}
 
@@ -265,38 +417,44 @@ func (r *Router) handleRefresh(w http.ResponseWriter, req *http.Request) {
 	handler.ServeHTTP(w, req)
 }
 
-// handleTestGetAllUsers handles retrieving all users for testing (no validation).
+// handleTestGetAllUsers handles retrieving all users for testing, straight from the repository.
 func (r *Router) handleTestGetAllUsers(w http.ResponseWriter, req *http.Request) {
-	// Call the repository directly to get all users
-	users, err := r.repos.Users.ListAll(req.Context())
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(`{"error":"Failed to retrieve users","code":500}`))
-		return
-	}
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
 
-	// Return 200 OK with users list
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Call the repository directly to get all users
+		users, err := r.repos.Users.ListAll(req.Context())
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to retrieve users")
+			return
+		}
 
-	// Build JSON response manually
-	response := `{"users":[`
-	for i, user := range users {
-		if i > 0 {
-			response += ","
+		// Return 200 OK with users list
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		// Build JSON response manually
+		response := `{"users":[`
+		for i, user := range users {
+			if i > 0 {
+				response += ","
+			}
+			response += `{"id":"` + user.ID.String() +
+				`","username":"` + user.Username +
+				`","email":"` + user.Email +
+				`","role":"` + user.Role +
+				`","created_at":"` + user.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
+				`","updated_at":"` + user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00") +
+				`","is_active":` + strconv.FormatBool(user.IsActive) +
+				`,"account_number":"` + user.AccountNumber + `"}`
 		}
-		response += `{"id":"` + user.ID.String() +
-			`","username":"` + user.Username +
-			`","email":"` + user.Email +
-			`","role":"` + user.Role +
-			`","created_at":"` + user.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
-			`","updated_at":"` + user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00") +
-			`","is_active":` + strconv.FormatBool(user.IsActive) + `}`
-	}
-	response += `],"total":` + fmt.Sprintf("%d", len(users)) + `}`
+		response += `],"total":` + fmt.Sprintf("%d", len(users)) + `}`
 
-	_, _ = w.Write([]byte(response))
+		_, _ = w.Write([]byte(response))
+	})))
+
+	finalHandler.ServeHTTP(w, req)
 }
 
 // HandleCircuitBreakerSuccess handles a successful circuit breaker test endpoint
@@ -308,9 +466,7 @@ func (r *Router) HandleCircuitBreakerSuccess(w http.ResponseWriter, _ *http.Requ
 
 // HandleCircuitBreakerFailure handles a failing circuit breaker test endpoint
 func (r *Router) HandleCircuitBreakerFailure(w http.ResponseWriter, _ *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
-	_, _ = w.Write([]byte(`{"error":"Circuit breaker test - simulated failure","code":500}`))
+	respond.Error(w, http.StatusInternalServerError, "Circuit breaker test - simulated failure")
 }
 
 // HandleCircuitBreakerTimeout handles a timeout circuit breaker test endpoint
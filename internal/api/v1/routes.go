@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// productionEnvironment is the environment test-only routes are never served in.
+const productionEnvironment = "prod"
+
+// RouteRegistry registers routes on a mux according to the environment the server runs in.
+// Test-only routes are left off the mux in production entirely, so they cannot be reached
+// there whatever middleware they are wrapped in.
+type RouteRegistry struct {
+	mux        *http.ServeMux
+	production bool
+}
+
+// NewRouteRegistry creates a route registry for a mux in the given environment.
+func NewRouteRegistry(mux *http.ServeMux, environment string) *RouteRegistry {
+	return &RouteRegistry{
+		mux:        mux,
+		production: environment == productionEnvironment,
+	}
+}
+
+// Handle registers a route served in every environment.
+func (r *RouteRegistry) Handle(pattern string, handler http.Handler) {
+	r.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers a route served in every environment.
+func (r *RouteRegistry) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.mux.HandleFunc(pattern, handler)
+}
+
+// HandleTestOnly registers a test or diagnostic route outside production; in production the
+// route is not registered and requests to it get a 404 like any unknown path.
+func (r *RouteRegistry) HandleTestOnly(pattern string, handler http.Handler) {
+	if r.production {
+		utils.Debug("test-only route not registered in production", slog.String("route", pattern))
+		return
+	}
+	r.mux.Handle(pattern, handler)
+}
@@ -2,13 +2,17 @@
 package v1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
@@ -22,24 +26,18 @@ func (r *Router) handleScheduleTransaction(w http.ResponseWriter, req *http.Requ
 			// Get user ID from context
 			userIDStr, ok := middleware.GetCurrentUserID(req)
 			if !ok {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+				respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 				return
 			}
 			userID, err := uuid.Parse(userIDStr)
 			if err != nil {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+				respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 				return
 			}
 
 			scheduledTx, err := r.services.ScheduledTransaction.Create(req.Context(), userID, body)
 			if err != nil {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"` + err.Error() + `","code":400}`))
+				writeTransactionError(w, err)
 				return
 			}
 
@@ -54,9 +52,14 @@ func (r *Router) handleScheduleTransaction(w http.ResponseWriter, req *http.Requ
 				`","description":"` + scheduledTx.Description +
 				`","schedule_type":"` + scheduledTx.ScheduleType +
 				`","execute_at":"` + scheduledTx.ExecuteAt.Format(time.RFC3339) +
+				`","business_day_rule":"` + scheduledTx.BusinessDayRule +
 				`","status":"` + scheduledTx.Status +
 				`","is_active":` + strconv.FormatBool(scheduledTx.IsActive) + `,` +
-				`"created_at":"` + scheduledTx.CreatedAt.Format(time.RFC3339) + `"}`
+				`"created_at":"` + scheduledTx.CreatedAt.Format(time.RFC3339) + `"`
+			if scheduledTx.AdjustedExecuteAt != nil {
+				response += `,"adjusted_execute_at":"` + scheduledTx.AdjustedExecuteAt.Format(time.RFC3339) + `"`
+			}
+			response += `}`
 
 			_, _ = w.Write([]byte(response))
 		})
@@ -70,45 +73,30 @@ func (r *Router) handleScheduleTransaction(w http.ResponseWriter, req *http.Requ
 // handleGetScheduledTransactions handles listing user's scheduled transactions.
 func (r *Router) handleGetScheduledTransactions(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Enum("status", "active", "paused", "cancelled", "completed"),
+		middleware.Bool("is_active"),
+	))
 
-	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Parse query parameters
-		limitStr := req.URL.Query().Get("limit")
-		offsetStr := req.URL.Query().Get("offset")
 		status := req.URL.Query().Get("status")
 		isActiveStr := req.URL.Query().Get("is_active")
-
-		limit := 10 // Default
-		offset := 0
-
-		if limitStr != "" {
-			if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
-				limit = parsedLimit
-			}
-		}
-
-		if offsetStr != "" {
-			if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-				offset = parsedOffset
-			}
-		}
+		limit, offset := middleware.QueryPage(req, 10)
 
 		// Build filter
 		filter := &domain.ScheduledTransactionFilter{
@@ -121,16 +109,13 @@ func (r *Router) handleGetScheduledTransactions(w http.ResponseWriter, req *http
 		}
 
 		if isActiveStr != "" {
-			if isActive, err := strconv.ParseBool(isActiveStr); err == nil {
-				filter.IsActive = &isActive
-			}
+			isActive := isActiveStr == "true"
+			filter.IsActive = &isActive
 		}
 
 		scheduledTxs, err := r.services.ScheduledTransaction.List(req.Context(), userID, filter)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Failed to list scheduled transactions","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Failed to list scheduled transactions")
 			return
 		}
 
@@ -156,11 +141,209 @@ func (r *Router) handleGetScheduledTransactions(w http.ResponseWriter, req *http
 		response += `],"limit":` + strconv.Itoa(limit) + `,"offset":` + strconv.Itoa(offset) + `}`
 
 		_, _ = w.Write([]byte(response))
-	}))
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetUpcomingScheduledTransactions handles projecting the user's scheduled executions within a
+// window, expanding recurring schedules into each occurrence for a payment calendar.
+func (r *Router) handleGetUpcomingScheduledTransactions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.TimeRange("from", "to")))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		from := time.Now().UTC()
+		if t := middleware.QueryTime(req, "from"); t != nil {
+			from = *t
+		}
+
+		to := from.Add(domain.DefaultUpcomingWindow)
+		if t := middleware.QueryTime(req, "to"); t != nil {
+			to = *t
+		}
+
+		upcoming, err := r.services.ScheduledTransaction.Upcoming(req.Context(), userID, from, to)
+		if err != nil {
+			writeScheduledTransactionError(w, err, "Failed to list upcoming scheduled transactions")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(upcoming)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleAdminListScheduledTransactions handles listing the scheduled transactions of all users (admin only).
+func (r *Router) handleAdminListScheduledTransactions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Enum("status", "active", "paused", "cancelled", "completed"),
+		middleware.UUID("user_id"),
+		middleware.Int("failed_within_days", 1, domain.MaxFailureReportDays),
+	))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		limit, offset := middleware.QueryPage(req, 20)
+
+		filter := &domain.ScheduledTransactionFilter{
+			Limit:  limit,
+			Offset: offset,
+		}
+
+		if status := req.URL.Query().Get("status"); status != "" {
+			filter.Status = &status
+		}
+
+		if userID := middleware.QueryUUID(req, "user_id"); userID != uuid.Nil {
+			filter.UserID = &userID
+		}
+
+		// Only schedules with a failed execution in the last N days
+		if days := middleware.QueryInt(req, "failed_within_days", 0); days > 0 {
+			since := time.Now().AddDate(0, 0, -days)
+			filter.FailedSince = &since
+		}
+
+		scheduledTxs, total, err := r.services.ScheduledTransaction.ListAll(req.Context(), filter)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list scheduled transactions")
+			return
+		}
+
+		writeScheduledAdminJSON(w, map[string]interface{}{
+			"scheduled_transactions": scheduledTxs,
+			"total":                  total,
+			"limit":                  limit,
+			"offset":                 offset,
+		})
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetScheduledFailureReport handles aggregating failed scheduled executions of the last
+// days by reason (admin only).
+func (r *Router) handleGetScheduledFailureReport(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.Int("days", 1, domain.MaxFailureReportDays)))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		days := middleware.QueryInt(req, "days", 7)
+
+		report, err := r.services.ScheduledTransaction.FailureReport(req.Context(), time.Now().AddDate(0, 0, -days))
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to build scheduled failure report")
+			return
+		}
+
+		writeScheduledAdminJSON(w, report)
+	}))))
 
 	finalHandler.ServeHTTP(w, req)
 }
 
+// handleAdminCancelUserScheduledTransactions handles cancelling the open scheduled transactions
+// of a user, e.g. on account closure (admin only).
+func (r *Router) handleAdminCancelUserScheduledTransactions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+		userID, ok := scheduledOwnerIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		action, err := r.services.ScheduledTransaction.CancelForUser(req.Context(), adminID, userID)
+		if err != nil {
+			writeScheduledTransactionError(w, err, "Failed to cancel scheduled transactions")
+			return
+		}
+
+		writeScheduledAdminJSON(w, action)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleAdminReassignUserScheduledTransactions handles handing the open scheduled transactions of
+// a deactivated user to an active one (admin only).
+func (r *Router) handleAdminReassignUserScheduledTransactions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.ScheduledReassignRequest) {
+			adminID, ok := currentUserUUID(w, req)
+			if !ok {
+				return
+			}
+			userID, ok := scheduledOwnerIDFromPath(w, req)
+			if !ok {
+				return
+			}
+
+			action, err := r.services.ScheduledTransaction.ReassignForUser(req.Context(), adminID, userID, body)
+			if err != nil {
+				writeScheduledTransactionError(w, err, "Failed to reassign scheduled transactions")
+				return
+			}
+
+			writeScheduledAdminJSON(w, action)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// scheduledOwnerIDFromPath parses the user ID path value, writing an error response if invalid.
+func scheduledOwnerIDFromPath(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(req.PathValue("id"))
+	if err != nil {
+		respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// writeScheduledAdminJSON marshals an admin scheduled transaction response.
+func writeScheduledAdminJSON(w http.ResponseWriter, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
+
 // handleGetScheduledTransaction handles getting a specific scheduled transaction.
 func (r *Router) handleGetScheduledTransaction(w http.ResponseWriter, req *http.Request) {
 	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
@@ -169,48 +352,36 @@ func (r *Router) handleGetScheduledTransaction(w http.ResponseWriter, req *http.
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Extract transaction ID from URL path
 		txIDStr := req.PathValue("id")
 		if txIDStr == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Scheduled transaction ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Scheduled transaction ID is required")
 			return
 		}
 
 		txID, err := uuid.Parse(txIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid scheduled transaction ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid scheduled transaction ID format")
 			return
 		}
 
 		scheduledTx, err := r.services.ScheduledTransaction.GetByID(req.Context(), txID, userID)
 		if err != nil {
 			if err.Error() == "access denied: not owner of scheduled transaction" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusForbidden)
-				_, _ = w.Write([]byte(`{"error":"Access denied","code":403}`))
+				respond.Error(w, http.StatusForbidden, "Access denied")
 				return
 			}
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_, _ = w.Write([]byte(`{"error":"Scheduled transaction not found","code":404}`))
+			respond.Error(w, http.StatusNotFound, "Scheduled transaction not found")
 			return
 		}
 
@@ -243,48 +414,36 @@ func (r *Router) handleCancelScheduledTransaction(w http.ResponseWriter, req *ht
 		// Get user ID from context
 		userIDStr, ok := middleware.GetCurrentUserID(req)
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(`{"error":"User not authenticated","code":401}`))
+			respond.Error(w, http.StatusUnauthorized, "User not authenticated")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID","code":500}`))
+			respond.Error(w, http.StatusInternalServerError, "Invalid user ID")
 			return
 		}
 
 		// Extract transaction ID from URL path
 		txIDStr := req.PathValue("id")
 		if txIDStr == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Scheduled transaction ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Scheduled transaction ID is required")
 			return
 		}
 
 		txID, err := uuid.Parse(txIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid scheduled transaction ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid scheduled transaction ID format")
 			return
 		}
 
 		err = r.services.ScheduledTransaction.Cancel(req.Context(), txID, userID)
 		if err != nil {
 			if err.Error() == "access denied: not owner of scheduled transaction" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusForbidden)
-				_, _ = w.Write([]byte(`{"error":"Access denied","code":403}`))
+				respond.Error(w, http.StatusForbidden, "Access denied")
 				return
 			}
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_, _ = w.Write([]byte(`{"error":"Scheduled transaction not found","code":404}`))
+			respond.Error(w, http.StatusNotFound, "Scheduled transaction not found")
 			return
 		}
 
@@ -295,3 +454,76 @@ func (r *Router) handleCancelScheduledTransaction(w http.ResponseWriter, req *ht
 
 	finalHandler.ServeHTTP(w, req)
 }
+
+// handlePauseScheduledTransaction handles pausing a scheduled transaction.
+func (r *Router) handlePauseScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	r.handleScheduledTransactionStateChange(w, req, r.services.ScheduledTransaction.Pause, "Failed to pause scheduled transaction")
+}
+
+// handleResumeScheduledTransaction handles resuming a paused scheduled transaction.
+func (r *Router) handleResumeScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	r.handleScheduledTransactionStateChange(w, req, r.services.ScheduledTransaction.Resume, "Failed to resume scheduled transaction")
+}
+
+// handleSkipNextScheduledTransaction handles skipping the upcoming occurrence of a recurring scheduled transaction.
+func (r *Router) handleSkipNextScheduledTransaction(w http.ResponseWriter, req *http.Request) {
+	r.handleScheduledTransactionStateChange(w, req, r.services.ScheduledTransaction.SkipNext, "Failed to skip scheduled transaction occurrence")
+}
+
+// handleScheduledTransactionStateChange applies a pause, resume or skip to the scheduled transaction
+// in the path and responds with its new state.
+func (r *Router) handleScheduledTransactionStateChange(w http.ResponseWriter, req *http.Request, change func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error), fallback string) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		txID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid scheduled transaction ID format")
+			return
+		}
+
+		scheduledTx, err := change(req.Context(), txID, userID)
+		if err != nil {
+			writeScheduledTransactionError(w, err, fallback)
+			return
+		}
+
+		jsonResponse, err := json.Marshal(scheduledTx)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeScheduledTransactionError maps scheduled transaction state change and calendar errors to HTTP responses.
+func writeScheduledTransactionError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case isAmountLimitError(err):
+		middleware.WriteValidationErrors(w, err)
+	case strings.HasSuffix(err.Error(), "scheduled transaction not found"):
+		respond.Error(w, http.StatusNotFound, "Scheduled transaction not found")
+	case strings.HasPrefix(err.Error(), "access denied"):
+		respond.Error(w, http.StatusForbidden, "Access denied")
+	case strings.HasPrefix(err.Error(), "cannot "), strings.HasPrefix(err.Error(), "no occurrences remain"),
+		err.Error() == "scheduled transaction is already paused", err.Error() == "scheduled transaction is not paused":
+		respond.Error(w, http.StatusConflict, err.Error())
+	case err.Error() == "only recurring scheduled transactions can skip an occurrence":
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// searchQuery checks the parameters of a full-text search.
+var searchQuery = middleware.Query(
+	middleware.Pagination(middleware.MaxPageLimit),
+	middleware.Check("q", domain.ValidateSearchQuery),
+	middleware.Enum("scope", string(domain.SearchScopeMine), string(domain.SearchScopeAudit)),
+)
+
+// handleSearch handles full-text search of the authenticated user's transactions and payment
+// requests by description, client reference, note and the other party's username, best match
+// first. Admins search the details of every audit log entry with scope=audit.
+func (r *Router) handleSearch(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(searchQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		scope, err := domain.ParseSearchScope(req.URL.Query().Get("scope"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if scope == domain.SearchScopeAudit && !middleware.IsAdmin(req) {
+			respond.Error(w, http.StatusForbidden, "Access denied: only admins can search the audit log")
+			return
+		}
+
+		limit, offset := middleware.QueryPage(req, 20)
+		results, err := r.services.Search.Search(req.Context(), userID, req.URL.Query().Get("q"), scope, limit, offset)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "invalid request") {
+				respond.Error(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			respond.Error(w, http.StatusInternalServerError, "Failed to search")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(results)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// securityActivityQuery accepts the filters of security activity listings.
+var securityActivityQuery = middleware.All(
+	middleware.Pagination(middleware.MaxPageLimit),
+	middleware.Enum("type",
+		string(domain.SecurityLogin), string(domain.SecurityLoginFailed),
+		string(domain.SecurityTokenRefreshed), string(domain.SecuritySessionRevoked),
+		string(domain.SecurityStepUpRequired), string(domain.SecurityStepUp), string(domain.SecurityStepUpFailed)),
+	middleware.Timestamp("since"),
+)
+
+// handleListSecurityActivity handles listing the user's recent security activity: logins, failed
+// logins with their email, token refreshes and session revocations.
+func (r *Router) handleListSecurityActivity(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(securityActivityQuery))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		filter := securityEventFilter(req)
+		filter.UserID = &userID
+		r.writeSecurityActivity(w, req, filter)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListAllSecurityActivity handles listing the security activity of every user, or of one
+// user given by user_id (admin only).
+func (r *Router) handleListAllSecurityActivity(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		securityActivityQuery,
+		middleware.UUID("user_id"),
+	))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		filter := securityEventFilter(req)
+		if userID := middleware.QueryUUID(req, "user_id"); userID != uuid.Nil {
+			filter.UserID = &userID
+		}
+		r.writeSecurityActivity(w, req, filter)
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// securityEventFilter builds the filter of a security activity listing from its query.
+func securityEventFilter(req *http.Request) *domain.SecurityEventFilter {
+	limit, offset := middleware.QueryPage(req, 50)
+	filter := &domain.SecurityEventFilter{
+		Since:  middleware.QueryTime(req, "since"),
+		Limit:  limit,
+		Offset: offset,
+	}
+	if eventType := req.URL.Query().Get("type"); eventType != "" {
+		t := domain.SecurityEventType(eventType)
+		filter.Type = &t
+	}
+	return filter
+}
+
+// writeSecurityActivity lists the security events matching filter.
+func (r *Router) writeSecurityActivity(w http.ResponseWriter, req *http.Request, filter *domain.SecurityEventFilter) {
+	events, err := r.services.Security.List(req.Context(), filter)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to list security activity")
+		return
+	}
+
+	if events == nil {
+		events = []*domain.SecurityEvent{}
+	}
+
+	jsonResponse, err := json.Marshal(map[string]interface{}{
+		"events": events,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListSessions handles listing the user's active login sessions, marking the one the request
+// was made in.
+func (r *Router) handleListSessions(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		sessions, err := r.services.Auth.ListSessions(req.Context(), userID)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list sessions")
+			return
+		}
+
+		if sessions == nil {
+			sessions = []*domain.LoginSession{}
+		}
+
+		responseData := map[string]interface{}{
+			"sessions": sessions,
+			"total":    len(sessions),
+		}
+		if claims, ok := middleware.GetUserFromContext(req.Context()); ok && claims.SessionID != nil {
+			responseData["current_session_id"] = claims.SessionID
+		}
+
+		writeSessionJSON(w, http.StatusOK, responseData)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleRevokeSession handles revoking one of the user's login sessions, e.g. after being notified
+// of a login from a new device that was not theirs.
+func (r *Router) handleRevokeSession(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		sessionID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid session ID format")
+			return
+		}
+
+		device := domain.LoginDevice{IP: middleware.ClientIP(req), UserAgent: req.UserAgent()}
+		if err := r.services.Auth.RevokeSession(req.Context(), userID, sessionID, device); err != nil {
+			switch err.Error() {
+			case "login session not found":
+				respond.Error(w, http.StatusNotFound, "Session not found")
+			case "login session already revoked":
+				respond.Error(w, http.StatusConflict, "Session already revoked")
+			default:
+				respond.Error(w, http.StatusInternalServerError, "Failed to revoke session")
+			}
+			return
+		}
+
+		writeSessionJSON(w, http.StatusOK, map[string]interface{}{
+			"message": "Session revoked successfully",
+		})
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeSessionJSON marshals a session response with the given status code.
+func writeSessionJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleStartSimulation handles starting a synthetic traffic simulation (admin only).
+func (r *Router) handleStartSimulation(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.simulationEnabled(w) {
+			return
+		}
+
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SimulationRequest) {
+			run, err := r.services.Simulation.Start(req.Context(), adminID, body)
+			if err != nil {
+				writeSimulationError(w, err, "Failed to start simulation")
+				return
+			}
+
+			writeSimulationJSON(w, http.StatusAccepted, run)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListSimulations handles listing recent simulations, newest first (admin only).
+func (r *Router) handleListSimulations(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.simulationEnabled(w) {
+			return
+		}
+
+		runs, err := r.services.Simulation.List(req.Context())
+		if err != nil {
+			writeSimulationError(w, err, "Failed to list simulations")
+			return
+		}
+
+		writeSimulationJSON(w, http.StatusOK, map[string]interface{}{
+			"simulations": runs,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetSimulation handles retrieving a simulation and its results so far (admin only).
+func (r *Router) handleGetSimulation(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.simulationEnabled(w) {
+			return
+		}
+
+		simulationID, ok := parseSimulationID(w, req)
+		if !ok {
+			return
+		}
+
+		run, err := r.services.Simulation.Get(req.Context(), simulationID)
+		if err != nil {
+			writeSimulationError(w, err, "Failed to get simulation")
+			return
+		}
+
+		writeSimulationJSON(w, http.StatusOK, run)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleCancelSimulation handles stopping a running simulation (admin only).
+func (r *Router) handleCancelSimulation(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.simulationEnabled(w) {
+			return
+		}
+
+		simulationID, ok := parseSimulationID(w, req)
+		if !ok {
+			return
+		}
+
+		run, err := r.services.Simulation.Cancel(req.Context(), simulationID)
+		if err != nil {
+			writeSimulationError(w, err, "Failed to cancel simulation")
+			return
+		}
+
+		writeSimulationJSON(w, http.StatusAccepted, run)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetSimulationTrace handles retrieving the trace of a simulation's operations as JSON
+// lines, which hash to the run's trace digest when the trace is complete (admin only).
+func (r *Router) handleGetSimulationTrace(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.simulationEnabled(w) {
+			return
+		}
+
+		simulationID, ok := parseSimulationID(w, req)
+		if !ok {
+			return
+		}
+
+		entries, err := r.services.Simulation.Trace(req.Context(), simulationID)
+		if err != nil {
+			writeSimulationError(w, err, "Failed to get simulation trace")
+			return
+		}
+
+		var body bytes.Buffer
+		for _, entry := range entries {
+			line, err := json.Marshal(entry)
+			if err != nil {
+				respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+				return
+			}
+			body.Write(line)
+			body.WriteByte('\n')
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body.Bytes())
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// simulationEnabled reports whether the simulation service is available, writing an error response if not.
+func (r *Router) simulationEnabled(w http.ResponseWriter) bool {
+	if r.services.Simulation == nil {
+		respond.Error(w, http.StatusServiceUnavailable, "Simulation is disabled")
+		return false
+	}
+	return true
+}
+
+// parseSimulationID parses the simulation ID path value, writing an error response if it is invalid.
+func parseSimulationID(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	simulationID, err := uuid.Parse(req.PathValue("id"))
+	if err != nil {
+		respond.Error(w, http.StatusBadRequest, "Invalid simulation ID format")
+		return uuid.Nil, false
+	}
+	return simulationID, true
+}
+
+// writeSimulationError maps simulation service errors to HTTP responses.
+func writeSimulationError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "simulation not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "simulation already running"),
+		strings.HasPrefix(err.Error(), "simulation is not running"),
+		strings.HasPrefix(err.Error(), "simulation seed already used"):
+		respond.Error(w, http.StatusConflict, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeSimulationJSON marshals a simulation response with the given status code.
+func writeSimulationJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
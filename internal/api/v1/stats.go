@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+// handleGetTransactionStats handles retrieving aggregate transaction statistics (admin only).
+func (r *Router) handleGetTransactionStats(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		window := req.URL.Query().Get("window")
+
+		stats, err := r.services.Transaction.GetStats(req.Context(), window)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "invalid window") {
+				respond.Error(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			respond.Error(w, http.StatusInternalServerError, "Failed to get transaction stats")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(stats)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetCacheStats handles retrieving cache key counts and Redis memory/keyspace stats (admin only).
+func (r *Router) handleGetCacheStats(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.services.Cache == nil {
+			respond.Error(w, http.StatusServiceUnavailable, "Cache not available")
+			return
+		}
+
+		stats, err := r.services.Cache.GetCacheStats(req.Context())
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to get cache stats")
+			return
+		}
+
+		jsonResponse, err := json.Marshal(stats)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonResponse)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
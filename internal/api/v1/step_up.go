@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleStepUp handles confirming the user's password, letting a user whose money movement looks
+// suspicious debit, transfer and roll back again.
+func (r *Router) handleStepUp(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.StepUpRequest) {
+			device := domain.LoginDevice{IP: middleware.ClientIP(req), UserAgent: req.UserAgent()}
+			if err := r.services.Auth.StepUp(req.Context(), userID, body.Password, device); err != nil {
+				if err.Error() == "invalid password" {
+					respond.Error(w, http.StatusUnauthorized, "Invalid password")
+					return
+				}
+				respond.Error(w, http.StatusInternalServerError, "Failed to confirm password")
+				return
+			}
+
+			writeStepUpJSON(w, http.StatusOK, map[string]interface{}{
+				"message": "Password confirmed",
+			})
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// isStepUpRequired reports whether err holds up money movement until the user steps up.
+func isStepUpRequired(err error) bool {
+	return errors.Is(err, domain.ErrStepUpRequired)
+}
+
+// writeStepUpRequired writes a 403 telling the user to confirm their password at
+// POST /api/v1/auth/step-up.
+func writeStepUpRequired(w http.ResponseWriter) {
+	respond.Error(w, http.StatusForbidden, "Step-up authentication required")
+}
+
+// writeStepUpJSON marshals a step-up response with the given status code.
+func writeStepUpJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
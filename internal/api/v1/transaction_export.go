@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/service"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// exportQuery checks the parameters of a transaction history export: its format, the locale of
+// its amounts and the filter of the transaction history.
+var exportQuery = middleware.Query(
+	transactionFilterQuery,
+	middleware.Check("format", func(format string) error {
+		_, err := domain.ParseExportFormat(format)
+		return err
+	}),
+	middleware.Check("locale", func(locale string) error {
+		_, err := domain.ParseLocale(locale)
+		return err
+	}),
+	middleware.Bool("async"),
+)
+
+// handleExportTransactionHistory handles exporting the authenticated user's full transaction history
+// as CSV or JSON. Small exports are streamed directly; exports above service.BackgroundExportThreshold
+// rows, or when async=true, are generated in the background and answered with 202 Accepted.
+func (r *Router) handleExportTransactionHistory(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(exportQuery)
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		// Checked by exportQuery
+		format, _ := domain.ParseExportFormat(req.URL.Query().Get("format"))
+		locale, _ := domain.ParseLocale(req.URL.Query().Get("locale"))
+		filter := transactionHistoryFilter(req)
+
+		background, _ := strconv.ParseBool(req.URL.Query().Get("async"))
+		if !background {
+			count, err := r.services.TransactionExport.Count(req.Context(), userID, filter)
+			if err != nil {
+				respond.Error(w, http.StatusInternalServerError, "Failed to export transaction history")
+				return
+			}
+			background = count > service.BackgroundExportThreshold
+		}
+
+		if background {
+			export, err := r.services.TransactionExport.StartBackground(req.Context(), userID, filter, format, locale)
+			if err != nil {
+				respond.Error(w, http.StatusInternalServerError, "Failed to start transaction export")
+				return
+			}
+
+			w.Header().Set("Location", "/api/v1/transactions/history/exports/"+export.ID.String())
+			writeExportJSON(w, http.StatusAccepted, export)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="`+format.FileName(time.Now())+`"`)
+		w.WriteHeader(http.StatusOK)
+
+		// Headers are already sent, so a failure part way through can only be logged
+		if _, err := r.services.TransactionExport.Write(req.Context(), w, userID, filter, format, locale); err != nil {
+			utils.ErrorContext(req.Context(), "transaction history export interrupted", "error", err.Error())
+		}
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetTransactionExport handles checking on and downloading a background export.
+// Pending exports return 202 with their status; ready exports are downloaded as a file.
+func (r *Router) handleGetTransactionExport(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		exportID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid export ID format")
+			return
+		}
+
+		export, err := r.services.TransactionExport.Get(req.Context(), userID, exportID)
+		if err != nil {
+			respond.Error(w, http.StatusNotFound, "Export not found")
+			return
+		}
+
+		switch export.Status {
+		case domain.ExportStatusPending:
+			writeExportJSON(w, http.StatusAccepted, export)
+			return
+		case domain.ExportStatusFailed:
+			writeExportJSON(w, http.StatusInternalServerError, export)
+			return
+		}
+
+		file, export, err := r.services.TransactionExport.Open(req.Context(), userID, exportID)
+		if err != nil {
+			respond.Error(w, http.StatusNotFound, "Export not found")
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		w.Header().Set("Content-Type", export.Format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="`+export.Format.FileName(export.CreatedAt)+`"`)
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(w, file); err != nil {
+			utils.ErrorContext(req.Context(), "transaction export download interrupted", "error", err.Error())
+		}
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeExportJSON marshals an export status response.
+func writeExportJSON(w http.ResponseWriter, status int, export *domain.TransactionExport) {
+	jsonResponse, err := json.Marshal(export)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
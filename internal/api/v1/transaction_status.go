@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+)
+
+const (
+	// transactionEventsHeartbeat is how often a comment is sent on an idle stream so proxies keep it open.
+	transactionEventsHeartbeat = 15 * time.Second
+	// transactionEventsMaxDuration bounds how long a single stream stays open; clients reconnect if needed.
+	transactionEventsMaxDuration = 10 * time.Minute
+)
+
+// handleTransactionEvents handles streaming a transaction's status as server-sent events.
+// A "status" event carries the current status and each transition after it; the stream
+// ends once the transaction succeeds or fails.
+func (r *Router) handleTransactionEvents(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestingUserID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		transactionID, err := uuid.Parse(req.PathValue("id"))
+		if err != nil {
+			respond.Error(w, http.StatusBadRequest, "Invalid transaction ID format")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), transactionEventsMaxDuration)
+		defer cancel()
+
+		updates, err := r.services.TransactionStatus.Watch(ctx, transactionID, requestingUserID)
+		if err != nil {
+			switch err.Error() {
+			case "access denied: you don't have permission to view this transaction":
+				respond.Error(w, http.StatusForbidden, "Access denied: you don't have permission to view this transaction")
+			case "transaction not found":
+				respond.Error(w, http.StatusNotFound, "Transaction not found")
+			default:
+				respond.Error(w, http.StatusInternalServerError, "Failed to watch transaction")
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no") // Disable nginx response buffering
+		w.WriteHeader(http.StatusOK)
+
+		rc := http.NewResponseController(w)
+		// The stream outlives the server's write timeout; transactionEventsMaxDuration bounds it instead
+		_ = rc.SetWriteDeadline(time.Time{})
+		_ = rc.Flush()
+
+		heartbeat := time.NewTicker(transactionEventsHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+
+				data, err := json.Marshal(update)
+				if err != nil {
+					return
+				}
+				if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := w.Write([]byte(": heartbeat\n\n")); err != nil {
+					return
+				}
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
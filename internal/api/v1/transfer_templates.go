@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleCreateTransferTemplate handles saving a transfer template.
+func (r *Router) handleCreateTransferTemplate(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.TransferTemplateRequest) {
+			template, err := r.services.TransferTemplate.Create(req.Context(), userID, body)
+			if err != nil {
+				writeTransferTemplateError(w, err, "Failed to create transfer template")
+				return
+			}
+
+			writeTransferTemplateJSON(w, http.StatusCreated, template)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListTransferTemplates handles listing the user's transfer templates.
+func (r *Router) handleListTransferTemplates(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(middleware.Pagination(middleware.MaxPageLimit)))
+
+	finalHandler := authMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		limit, offset := middleware.QueryPage(req, 20)
+
+		templates, err := r.services.TransferTemplate.List(req.Context(), userID, limit, offset)
+		if err != nil {
+			respond.Error(w, http.StatusInternalServerError, "Failed to list transfer templates")
+			return
+		}
+
+		writeTransferTemplateJSON(w, http.StatusOK, map[string]interface{}{
+			"transfer_templates": templates,
+			"limit":              limit,
+			"offset":             offset,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleGetTransferTemplate handles retrieving a transfer template with its usage statistics.
+func (r *Router) handleGetTransferTemplate(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		templateID, ok := transferTemplateIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		template, err := r.services.TransferTemplate.GetByID(req.Context(), templateID, userID)
+		if err != nil {
+			writeTransferTemplateError(w, err, "Failed to get transfer template")
+			return
+		}
+
+		writeTransferTemplateJSON(w, http.StatusOK, template)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleUpdateTransferTemplate handles changing a transfer template.
+func (r *Router) handleUpdateTransferTemplate(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		templateID, ok := transferTemplateIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.UpdateTransferTemplateRequest) {
+			template, err := r.services.TransferTemplate.Update(req.Context(), templateID, userID, body)
+			if err != nil {
+				writeTransferTemplateError(w, err, "Failed to update transfer template")
+				return
+			}
+
+			writeTransferTemplateJSON(w, http.StatusOK, template)
+		})
+
+		handler.ServeHTTP(w, req)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleDeleteTransferTemplate handles deleting a transfer template.
+func (r *Router) handleDeleteTransferTemplate(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		templateID, ok := transferTemplateIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		if err := r.services.TransferTemplate.Delete(req.Context(), templateID, userID); err != nil {
+			writeTransferTemplateError(w, err, "Failed to delete transfer template")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"Transfer template deleted successfully"}`))
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleExecuteTransferTemplate handles executing a transfer template in one call.
+// The optional body's amount overrides the template's default amount.
+func (r *Router) handleExecuteTransferTemplate(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+
+	finalHandler := authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		templateID, ok := transferTemplateIDFromPath(w, req)
+		if !ok {
+			return
+		}
+
+		var executeReq domain.ExecuteTransferTemplateRequest
+		if req.Body != nil && req.ContentLength != 0 {
+			if err := json.NewDecoder(req.Body).Decode(&executeReq); err != nil && err != io.EOF {
+				respond.Error(w, http.StatusBadRequest, "Invalid JSON request body")
+				return
+			}
+		}
+
+		transaction, err := r.services.TransferTemplate.Execute(req.Context(), templateID, userID, &executeReq)
+		if err != nil {
+			writeTransferTemplateError(w, err, "Failed to execute transfer template")
+			return
+		}
+
+		writeTransferTemplateJSON(w, http.StatusCreated, transaction)
+	}))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// transferTemplateIDFromPath parses the transfer template ID path value, writing an error response if invalid.
+func transferTemplateIDFromPath(w http.ResponseWriter, req *http.Request) (uuid.UUID, bool) {
+	templateID, err := uuid.Parse(req.PathValue("id"))
+	if err != nil {
+		respond.Error(w, http.StatusBadRequest, "Invalid transfer template ID format")
+		return uuid.Nil, false
+	}
+
+	return templateID, true
+}
+
+// writeTransferTemplateError maps transfer template service errors to HTTP responses.
+func writeTransferTemplateError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case isAmountLimitError(err):
+		middleware.WriteValidationErrors(w, err)
+	case isStepUpRequired(err):
+		writeStepUpRequired(w)
+	case err.Error() == "transfer template not found", err.Error() == "payee not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "access denied"):
+		respond.Error(w, http.StatusForbidden, err.Error())
+	case err.Error() == "template name already exists":
+		respond.Error(w, http.StatusConflict, err.Error())
+	case err.Error() == "cannot transfer to self",
+		strings.HasPrefix(err.Error(), "invalid request"),
+		strings.HasPrefix(err.Error(), "failed to execute transfer template"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeTransferTemplateJSON marshals a transfer template response with the given status code.
+func writeTransferTemplateJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
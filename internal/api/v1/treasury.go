@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// handleListTreasury handles reporting the money supply of every currency (admin only).
+func (r *Router) handleListTreasury(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		supply, err := r.services.Treasury.ListSupply(req.Context())
+		if err != nil {
+			writeTreasuryError(w, err, "Failed to list treasury accounts")
+			return
+		}
+
+		writeTreasuryJSON(w, http.StatusOK, map[string]interface{}{
+			"treasuries": supply,
+		})
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleListTreasuryEntries handles listing the ledger of a currency's treasury (admin only).
+func (r *Router) handleListTreasuryEntries(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+	queryMiddleware := middleware.ValidateQueryParams(middleware.Query(
+		middleware.Pagination(middleware.MaxPageLimit),
+		middleware.Enum("kind", string(domain.TreasuryMint), string(domain.TreasuryBurn), string(domain.TreasuryIssue), string(domain.TreasuryRedeem)),
+	))
+
+	finalHandler := authMiddleware(adminMiddleware(queryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Parse query parameters
+		kindStr := req.URL.Query().Get("kind")
+		limit, offset := middleware.QueryPage(req, 20)
+
+		filter := &domain.TreasuryEntryFilter{
+			Currency: req.PathValue("currency"),
+			Limit:    limit,
+			Offset:   offset,
+		}
+
+		if kindStr != "" {
+			kind := domain.TreasuryEntryKind(kindStr)
+			filter.Kind = &kind
+		}
+
+		entries, err := r.services.Treasury.ListEntries(req.Context(), filter)
+		if err != nil {
+			writeTreasuryError(w, err, "Failed to list treasury entries")
+			return
+		}
+
+		writeTreasuryJSON(w, http.StatusOK, map[string]interface{}{
+			"entries": entries,
+			"limit":   limit,
+			"offset":  offset,
+		})
+	}))))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleMintTreasury handles minting money into a currency's treasury (admin only).
+func (r *Router) handleMintTreasury(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.TreasuryOperationRequest) {
+			entry, err := r.services.Treasury.Mint(req.Context(), adminID, req.PathValue("currency"), body)
+			if err != nil {
+				writeTreasuryError(w, err, "Failed to mint")
+				return
+			}
+
+			writeTreasuryJSON(w, http.StatusCreated, entry)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleBurnTreasury handles burning money held by a currency's treasury (admin only).
+func (r *Router) handleBurnTreasury(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.TreasuryOperationRequest) {
+			entry, err := r.services.Treasury.Burn(req.Context(), adminID, req.PathValue("currency"), body)
+			if err != nil {
+				writeTreasuryError(w, err, "Failed to burn")
+				return
+			}
+
+			writeTreasuryJSON(w, http.StatusCreated, entry)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// handleSetTreasuryCaps handles replacing the credit caps of a currency's treasury (admin only).
+func (r *Router) handleSetTreasuryCaps(w http.ResponseWriter, req *http.Request) {
+	authMiddleware := middleware.AuthMiddleware(r.jwtManager)
+	adminMiddleware := middleware.RequireAdmin
+
+	finalHandler := authMiddleware(adminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		adminID, ok := currentUserUUID(w, req)
+		if !ok {
+			return
+		}
+
+		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.SetTreasuryCapsRequest) {
+			account, err := r.services.Treasury.SetCaps(req.Context(), adminID, req.PathValue("currency"), body)
+			if err != nil {
+				writeTreasuryError(w, err, "Failed to set treasury caps")
+				return
+			}
+
+			writeTreasuryJSON(w, http.StatusOK, account)
+		})
+
+		handler.ServeHTTP(w, req)
+	})))
+
+	finalHandler.ServeHTTP(w, req)
+}
+
+// writeTreasuryError maps treasury service errors to HTTP responses.
+func writeTreasuryError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "treasury account not found":
+		respond.Error(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "insufficient treasury funds"):
+		respond.Error(w, http.StatusConflict, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid request"):
+		respond.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		respond.Error(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// writeTreasuryJSON marshals a treasury response with the given status code.
+func writeTreasuryJSON(w http.ResponseWriter, status int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonResponse)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/api/middleware"
+	"github.com/sefa-b/go-banking-sim/internal/api/respond"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
@@ -19,25 +20,19 @@ func (r *Router) handleGetUser(w http.ResponseWriter, req *http.Request) {
 		// Extract user ID from URL path
 		userIDStr := req.PathValue("id")
 		if userIDStr == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"User ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "User ID is required")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
 			return
 		}
 
 		user, err := r.services.User.GetByID(req.Context(), userID)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_, _ = w.Write([]byte(`{"error":"User not found","code":404}`))
+			respond.Error(w, http.StatusNotFound, "User not found")
 			return
 		}
 
@@ -66,33 +61,50 @@ func (r *Router) handleUpdateUser(w http.ResponseWriter, req *http.Request) {
 		// Extract user ID from URL path
 		userIDStr := req.PathValue("id")
 		if userIDStr == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"User ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "User ID is required")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
 			return
 		}
 
 		// Parse and validate request body
 		handler := middleware.ValidateJSON(func(w http.ResponseWriter, req *http.Request, body *domain.UpdateUserRequest) {
+			// Updates that change the user's role may have to wait for a second admin's approval
+			if body.Role != "" && r.services.Approval != nil && r.services.Approval.Required(req.Context(), domain.ApprovalRoleChange, nil) {
+				current, err := r.services.User.GetByID(req.Context(), userID)
+				if err != nil {
+					if err.Error() == "failed to get user: user not found" {
+						respond.Error(w, http.StatusNotFound, "User not found")
+						return
+					}
+					respond.Error(w, http.StatusInternalServerError, "Failed to get user")
+					return
+				}
+
+				if current.Role != body.Role {
+					adminID, ok := currentUserUUID(w, req)
+					if !ok {
+						return
+					}
+					r.submitForApproval(w, req, adminID, domain.ApprovalRoleChange, &domain.RoleChangeApproval{
+						UserID:  userID,
+						Request: *body,
+					})
+					return
+				}
+			}
+
 			user, err := r.services.User.Update(req.Context(), userID, body)
 			if err != nil {
 				if err.Error() == "failed to get user: user not found" {
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusNotFound)
-					_, _ = w.Write([]byte(`{"error":"User not found","code":404}`))
+					respond.Error(w, http.StatusNotFound, "User not found")
 					return
 				}
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte(`{"error":"Failed to update user","code":400}`))
+				respond.Error(w, http.StatusBadRequest, "Failed to update user")
 				return
 			}
 
@@ -105,7 +117,8 @@ func (r *Router) handleUpdateUser(w http.ResponseWriter, req *http.Request) {
 				`","role":"` + user.Role +
 				`","created_at":"` + user.CreatedAt.Format("2006-01-02T15:04:05Z07:00") +
 				`","updated_at":"` + user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00") +
-				`","is_active":` + strconv.FormatBool(user.IsActive) + `}`
+				`","is_active":` + strconv.FormatBool(user.IsActive) +
+				`,"account_number":"` + user.AccountNumber + `"}`
 
 			_, _ = w.Write([]byte(response))
 		})
@@ -125,17 +138,13 @@ func (r *Router) handleDeleteUser(w http.ResponseWriter, req *http.Request) {
 		// Extract user ID from URL path
 		userIDStr := req.PathValue("id")
 		if userIDStr == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"User ID is required","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "User ID is required")
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"error":"Invalid user ID format","code":400}`))
+			respond.Error(w, http.StatusBadRequest, "Invalid user ID format")
 			return
 		}
 
@@ -144,18 +153,13 @@ func (r *Router) handleDeleteUser(w http.ResponseWriter, req *http.Request) {
 			switch err.Error() {
 			case "failed to get user: user not found", "failed to delete user: user not found", "user not found: user not found or already inactive":
 				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusNotFound)
-				_, _ = w.Write([]byte(`{"error":"User not found","code":404}`))
+				respond.Error(w, http.StatusNotFound, "User not found")
 				return
 			case "user cannot be deleted: associated transactions exist":
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusConflict) // 409 Conflict
-				_, _ = w.Write([]byte(`{"error":"User cannot be deleted: associated transactions exist","code":409}`))
+				respond.Error(w, http.StatusConflict, "User cannot be deleted: associated transactions exist")
 				return
 			default:
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				_, _ = w.Write([]byte(`{"error":"Failed to delete user : ` + err.Error() + `","code":500}`))
+				respond.Error(w, http.StatusInternalServerError, "Failed to delete user : "+err.Error())
 				return
 			}
 		}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// impersonationContextKey is the context key for impersonation details.
+type impersonationContextKey struct{}
+
+// Impersonation describes an admin acting on behalf of another user.
+type Impersonation struct {
+	SessionID      string
+	ImpersonatorID uuid.UUID
+	UserID         uuid.UUID
+}
+
+// WithImpersonation stores impersonation details from the claims in the context.
+// Claims that are not impersonation claims leave the context unchanged.
+func WithImpersonation(ctx context.Context, claims *Claims) context.Context {
+	if claims == nil || !claims.IsImpersonation() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, impersonationContextKey{}, &Impersonation{
+		SessionID:      claims.ID,
+		ImpersonatorID: *claims.ImpersonatorID,
+		UserID:         claims.UserID,
+	})
+}
+
+// ImpersonationFromContext returns the impersonation details stored in the context, if any.
+func ImpersonationFromContext(ctx context.Context) (*Impersonation, bool) {
+	impersonation, ok := ctx.Value(impersonationContextKey{}).(*Impersonation)
+	return impersonation, ok
+}
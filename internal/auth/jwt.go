@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,8 +22,9 @@ const (
 
 // Token durations
 const (
-	AccessTokenDuration  = 15 * time.Minute
-	RefreshTokenDuration = 7 * 24 * time.Hour
+	AccessTokenDuration        = 15 * time.Minute
+	RefreshTokenDuration       = 7 * 24 * time.Hour
+	ImpersonationTokenDuration = 10 * time.Minute
 )
 
 // Claims represents JWT claims structure.
@@ -31,13 +34,59 @@ type Claims struct {
 	Email    string    `json:"email"`
 	Role     string    `json:"role"`
 	Type     TokenType `json:"type"`
+	// ImpersonatorID is set when an admin is acting as this user.
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
+	// SessionID is the login session the token was issued for; revoking it revokes the token.
+	SessionID *uuid.UUID `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsImpersonation reports whether the token was minted for admin impersonation.
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatorID != nil
+}
+
+// ErrSessionRevoked is returned for tokens whose login session was revoked.
+var ErrSessionRevoked = errors.New("session revoked")
+
+// SessionChecker looks up whether login sessions were revoked.
+type SessionChecker interface {
+	// SessionRevoked reports whether the login session was revoked.
+	SessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+// ErrQuotaExceeded is returned for calls of users who used up their API quota.
+var ErrQuotaExceeded = errors.New("api quota exceeded")
+
+// QuotaExceededError tells which limit of their API quota a user went over and when it resets.
+type QuotaExceededError struct {
+	Limit   int64
+	ResetAt time.Time
+}
+
+// Error implements error.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("api quota of %d calls exceeded until %s", e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+// Is makes errors.Is match the error against ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// UsageMeter counts the API calls of authenticated users against their quotas.
+type UsageMeter interface {
+	// MeterCall counts a call of the user to an endpoint class, failing with a *QuotaExceededError
+	// instead when it would go over their quota.
+	MeterCall(ctx context.Context, userID uuid.UUID, class string) error
+}
+
 // JWTManager handles JWT token operations.
 type JWTManager struct {
 	secretKey []byte
 	issuer    string
+	sessions  SessionChecker // Optional; without it login sessions are never revoked
+	usage     UsageMeter     // Optional; without it API calls are neither counted nor limited
 }
 
 // NewJWTManager creates a new JWT manager.
@@ -48,6 +97,45 @@ func NewJWTManager(secretKey, issuer string) *JWTManager {
 	}
 }
 
+// SetSessionChecker sets the checker CheckSession looks up login sessions with. Call it before the
+// manager is in use.
+func (m *JWTManager) SetSessionChecker(checker SessionChecker) {
+	m.sessions = checker
+}
+
+// CheckSession fails with ErrSessionRevoked when the login session of a validated token was
+// revoked. Tokens issued without a session, such as impersonation tokens, always pass.
+func (m *JWTManager) CheckSession(ctx context.Context, claims *Claims) error {
+	if m.sessions == nil || claims.SessionID == nil {
+		return nil
+	}
+
+	revoked, err := m.sessions.SessionRevoked(ctx, *claims.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if revoked {
+		return ErrSessionRevoked
+	}
+
+	return nil
+}
+
+// SetUsageMeter sets the meter MeterCall counts API calls with. Call it before the manager is in use.
+func (m *JWTManager) SetUsageMeter(meter UsageMeter) {
+	m.usage = meter
+}
+
+// MeterCall counts a call made with a validated token to an endpoint class, failing with
+// ErrQuotaExceeded once the user has used up their API quota. Calls made with impersonation tokens
+// are the admin's rather than the user's, so they are neither counted nor limited.
+func (m *JWTManager) MeterCall(ctx context.Context, claims *Claims, class string) error {
+	if m.usage == nil || claims.IsImpersonation() {
+		return nil
+	}
+	return m.usage.MeterCall(ctx, claims.UserID, class)
+}
+
 // GenerateAccessToken generates an access token for a user.
 func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, username, email, role string) (string, error) {
 	return m.generateToken(userID, username, email, role, AccessToken, AccessTokenDuration)
@@ -58,11 +146,30 @@ func (m *JWTManager) GenerateRefreshToken(userID uuid.UUID, username, email, rol
 	return m.generateToken(userID, username, email, role, RefreshToken, RefreshTokenDuration)
 }
 
+// GenerateImpersonationToken generates a short-lived access token that lets an admin act as a user.
+// The returned claims carry the session ID (jti) and expiry so the session can be tracked.
+func (m *JWTManager) GenerateImpersonationToken(userID uuid.UUID, username, email, role string, impersonatorID uuid.UUID) (string, *Claims, error) {
+	claims := m.newClaims(userID, username, email, role, AccessToken, ImpersonationTokenDuration)
+	claims.ImpersonatorID = &impersonatorID
+
+	tokenString, err := m.signClaims(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return tokenString, claims, nil
+}
+
 // generateToken generates a JWT token with specified parameters.
 func (m *JWTManager) generateToken(userID uuid.UUID, username, email, role string, tokenType TokenType, duration time.Duration) (string, error) {
+	return m.signClaims(m.newClaims(userID, username, email, role, tokenType, duration))
+}
+
+// newClaims builds the claims for a token with specified parameters.
+func (m *JWTManager) newClaims(userID uuid.UUID, username, email, role string, tokenType TokenType, duration time.Duration) *Claims {
 	now := time.Now()
 
-	claims := &Claims{
+	return &Claims{
 		UserID:   userID,
 		Username: username,
 		Email:    email,
@@ -78,7 +185,10 @@ func (m *JWTManager) generateToken(userID uuid.UUID, username, email, role strin
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+}
 
+// signClaims signs the claims and returns the encoded token.
+func (m *JWTManager) signClaims(claims *Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(m.secretKey)
 	if err != nil {
@@ -149,8 +259,10 @@ func (m *JWTManager) RefreshAccessToken(refreshTokenString string) (string, erro
 		return "", fmt.Errorf("invalid refresh token: %w", err)
 	}
 
-	// Generate new access token with same user info
-	return m.GenerateAccessToken(claims.UserID, claims.Username, claims.Email, claims.Role)
+	// Generate new access token with same user info and session
+	access := m.newClaims(claims.UserID, claims.Username, claims.Email, claims.Role, AccessToken, AccessTokenDuration)
+	access.SessionID = claims.SessionID
+	return m.signClaims(access)
 }
 
 // GetUserFromToken extracts user information from a valid token.
@@ -186,12 +298,29 @@ type TokenPair struct {
 
 // GenerateTokenPair generates both access and refresh tokens.
 func (m *JWTManager) GenerateTokenPair(userID uuid.UUID, username, email, role string) (*TokenPair, error) {
-	accessToken, err := m.GenerateAccessToken(userID, username, email, role)
+	return m.generateTokenPair(userID, username, email, role, nil)
+}
+
+// GenerateSessionTokenPair generates access and refresh tokens for a login session, so revoking the
+// session revokes both. The session should expire with the refresh token, RefreshTokenDuration
+// from now.
+func (m *JWTManager) GenerateSessionTokenPair(sessionID, userID uuid.UUID, username, email, role string) (*TokenPair, error) {
+	return m.generateTokenPair(userID, username, email, role, &sessionID)
+}
+
+// generateTokenPair generates access and refresh tokens for a login session, or none when
+// sessionID is nil.
+func (m *JWTManager) generateTokenPair(userID uuid.UUID, username, email, role string, sessionID *uuid.UUID) (*TokenPair, error) {
+	access := m.newClaims(userID, username, email, role, AccessToken, AccessTokenDuration)
+	access.SessionID = sessionID
+	accessToken, err := m.signClaims(access)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := m.GenerateRefreshToken(userID, username, email, role)
+	refresh := m.newClaims(userID, username, email, role, RefreshToken, RefreshTokenDuration)
+	refresh.SessionID = sessionID
+	refreshToken, err := m.signClaims(refresh)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
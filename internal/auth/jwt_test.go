@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -138,6 +140,165 @@ func TestJWTManager(t *testing.T) {
 			t.Errorf("Refresh token from pair should be valid: %v", err)
 		}
 	})
+
+	t.Run("generate impersonation token", func(t *testing.T) {
+		adminID := uuid.New()
+
+		token, issued, err := manager.GenerateImpersonationToken(userID, username, email, role, adminID)
+		if err != nil {
+			t.Fatalf("Failed to generate impersonation token: %v", err)
+		}
+
+		// Impersonation tokens are accepted as access tokens
+		claims, err := manager.ValidateAccessToken(token)
+		if err != nil {
+			t.Fatalf("Failed to validate impersonation token: %v", err)
+		}
+
+		if !claims.IsImpersonation() {
+			t.Error("Impersonation token should be marked as impersonation")
+		}
+		if *claims.ImpersonatorID != adminID {
+			t.Errorf("Expected ImpersonatorID %v, got %v", adminID, *claims.ImpersonatorID)
+		}
+		if claims.UserID != userID {
+			t.Errorf("Expected UserID %v, got %v", userID, claims.UserID)
+		}
+		if claims.ID != issued.ID {
+			t.Errorf("Expected session ID %v, got %v", issued.ID, claims.ID)
+		}
+
+		lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+		if lifetime != ImpersonationTokenDuration {
+			t.Errorf("Expected lifetime %v, got %v", ImpersonationTokenDuration, lifetime)
+		}
+
+		// Regular access tokens must not be marked
+		regular, err := manager.GenerateAccessToken(userID, username, email, role)
+		if err != nil {
+			t.Fatalf("Failed to generate access token: %v", err)
+		}
+		regularClaims, err := manager.ValidateAccessToken(regular)
+		if err != nil {
+			t.Fatalf("Failed to validate access token: %v", err)
+		}
+		if regularClaims.IsImpersonation() {
+			t.Error("Regular access token should not be marked as impersonation")
+		}
+	})
+}
+
+// revokedSessions is a SessionChecker over a fixed set of revoked sessions.
+type revokedSessions map[uuid.UUID]bool
+
+func (r revokedSessions) SessionRevoked(_ context.Context, sessionID uuid.UUID) (bool, error) {
+	return r[sessionID], nil
+}
+
+func TestJWTSessions(t *testing.T) {
+	manager := NewJWTManager("test-secret-key", "go-banking-sim-test")
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	pair, err := manager.GenerateSessionTokenPair(sessionID, userID, "testuser", "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("Failed to generate token pair: %v", err)
+	}
+
+	access, err := manager.ValidateAccessToken(pair.AccessToken)
+	if err != nil || access.SessionID == nil || *access.SessionID != sessionID {
+		t.Fatalf("Expected access token of session %v, got %+v, %v", sessionID, access, err)
+	}
+
+	// Refreshed access tokens stay in the session
+	refreshed, err := manager.RefreshAccessToken(pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Failed to refresh access token: %v", err)
+	}
+	claims, err := manager.ValidateAccessToken(refreshed)
+	if err != nil || claims.SessionID == nil || *claims.SessionID != sessionID {
+		t.Fatalf("Expected refreshed token of session %v, got %+v, %v", sessionID, claims, err)
+	}
+
+	// Without a checker every session is valid
+	if err := manager.CheckSession(context.Background(), claims); err != nil {
+		t.Errorf("Expected no error without a checker, got %v", err)
+	}
+
+	revoked := revokedSessions{}
+	manager.SetSessionChecker(revoked)
+	if err := manager.CheckSession(context.Background(), claims); err != nil {
+		t.Errorf("Expected an active session to pass, got %v", err)
+	}
+
+	revoked[sessionID] = true
+	if err := manager.CheckSession(context.Background(), claims); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("Expected ErrSessionRevoked, got %v", err)
+	}
+
+	// Tokens without a session are never revoked
+	sessionless, err := manager.GenerateAccessToken(userID, "testuser", "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("Failed to generate access token: %v", err)
+	}
+	claims, err = manager.ValidateAccessToken(sessionless)
+	if err != nil || claims.SessionID != nil {
+		t.Fatalf("Expected a token without a session, got %+v, %v", claims, err)
+	}
+	if err := manager.CheckSession(context.Background(), claims); err != nil {
+		t.Errorf("Expected a token without a session to pass, got %v", err)
+	}
+}
+
+// countingMeter is a UsageMeter counting the calls of each user.
+type countingMeter map[uuid.UUID]int
+
+func (m countingMeter) MeterCall(_ context.Context, userID uuid.UUID, _ string) error {
+	m[userID]++
+	return nil
+}
+
+func TestJWTMeterCall(t *testing.T) {
+	manager := NewJWTManager("test-secret-key", "go-banking-sim-test")
+	userID, adminID := uuid.New(), uuid.New()
+
+	token, err := manager.GenerateAccessToken(userID, "testuser", "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("Failed to generate access token: %v", err)
+	}
+	claims, err := manager.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("Failed to validate access token: %v", err)
+	}
+
+	// Without a meter calls are not counted
+	if err := manager.MeterCall(context.Background(), claims, "read"); err != nil {
+		t.Errorf("Expected no error without a meter, got %v", err)
+	}
+
+	meter := countingMeter{}
+	manager.SetUsageMeter(meter)
+	if err := manager.MeterCall(context.Background(), claims, "read"); err != nil || meter[userID] != 1 {
+		t.Errorf("Expected the call to be counted, got %d calls, %v", meter[userID], err)
+	}
+
+	// Impersonated calls are the admin's and are not counted
+	impersonation, _, err := manager.GenerateImpersonationToken(userID, "testuser", "test@example.com", "user", adminID)
+	if err != nil {
+		t.Fatalf("Failed to generate impersonation token: %v", err)
+	}
+	claims, err = manager.ValidateAccessToken(impersonation)
+	if err != nil {
+		t.Fatalf("Failed to validate impersonation token: %v", err)
+	}
+	if err := manager.MeterCall(context.Background(), claims, "read"); err != nil || meter[userID] != 1 {
+		t.Errorf("Expected the impersonated call not to be counted, got %d calls, %v", meter[userID], err)
+	}
+
+	quotaErr := &QuotaExceededError{Limit: 10, ResetAt: time.Now()}
+	if !errors.Is(quotaErr, ErrQuotaExceeded) {
+		t.Error("Expected QuotaExceededError to match ErrQuotaExceeded")
+	}
 }
 
 func TestJWTExpiration(t *testing.T) {
@@ -0,0 +1,94 @@
+// Package clock tells the time to features that depend on it, such as scheduled transactions, so
+// they can run on the wall clock, on a simulation clock that admins fast-forward, or on a fake
+// clock in tests.
+package clock
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the wall clock.
+type Real struct{}
+
+// Now returns the wall clock time.
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Simulated is the wall clock fast-forwarded by an offset. The offset only grows, so time never
+// runs backwards for the features reading it. It is kept in memory and starts at zero on every
+// start of the server.
+type Simulated struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+// NewSimulated creates a simulation clock showing the wall clock time.
+func NewSimulated() *Simulated {
+	return &Simulated{}
+}
+
+// Now returns the wall clock time plus the offset.
+func (c *Simulated) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// Offset returns how far the clock runs ahead of the wall clock.
+func (c *Simulated) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+// Advance fast-forwards the clock by d, which must be positive.
+func (c *Simulated) Advance(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("clock can only be advanced by a positive duration, got %s", d)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset += d
+	return nil
+}
+
+// Fake is a clock that stands still until it is advanced or set, for tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake creates a fake clock standing at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time the clock stands at.
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *Fake) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to now.
+func (c *Fake) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulated(t *testing.T) {
+	c := NewSimulated()
+	if c.Offset() != 0 {
+		t.Fatalf("Offset = %s, want 0", c.Offset())
+	}
+	if drift := time.Since(c.Now()); drift < 0 || drift > time.Second {
+		t.Errorf("Now drifts %s from the wall clock, want none", drift)
+	}
+
+	if err := c.Advance(30 * 24 * time.Hour); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if err := c.Advance(12 * time.Hour); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	if c.Offset() != 30*24*time.Hour+12*time.Hour {
+		t.Errorf("Offset = %s, want 732h", c.Offset())
+	}
+	if ahead := time.Until(c.Now()); ahead < c.Offset()-time.Second || ahead > c.Offset() {
+		t.Errorf("Now is %s ahead, want the offset", ahead)
+	}
+
+	// Time never runs backwards
+	for _, d := range []time.Duration{0, -time.Hour} {
+		if err := c.Advance(d); err == nil {
+			t.Errorf("Advance(%s) accepted", d)
+		}
+	}
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+	if !c.Now().Equal(start) {
+		t.Fatalf("Now = %s, want %s", c.Now(), start)
+	}
+
+	c.Advance(time.Hour)
+	if want := start.Add(time.Hour); !c.Now().Equal(want) {
+		t.Errorf("after Advance Now = %s, want %s", c.Now(), want)
+	}
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("after Set Now = %s, want %s", c.Now(), start)
+	}
+
+	var _ Clock = c
+	var _ Clock = Real{}
+}
@@ -1,37 +1,959 @@
-// Package config handles environment configuration loading.
+// Package config handles configuration loading from defaults, an optional YAML file and environment variables.
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds all configuration values for the application.
 type Config struct {
-	Port           string
-	Environment    string
-	DBUrl          string
-	JWTSecret      string
-	AllowedOrigins string
+	Port              string               `yaml:"port"`
+	Environment       string               `yaml:"environment"`
+	Storage           string               `yaml:"storage"` // "postgres" or "memory"
+	DBUrl             string               `yaml:"db_url"`  // Required with postgres storage
+	DBPool            DBPoolConfig         `yaml:"db_pool"`
+	JWTSecret         string               `yaml:"jwt_secret"`
+	ReceiptSigningKey string               `yaml:"receipt_signing_key"` // Optional; derived from the JWT secret when empty
+	AllowedOrigins    string               `yaml:"allowed_origins"`
+	RollbackWindow    time.Duration        `yaml:"rollback_window"`
+	WelcomeBonus      float64              `yaml:"welcome_bonus"` // USD issued from the treasury to each new user; 0 disables
+	Server            ServerConfig         `yaml:"server"`
+	AmountLimits      AmountLimitsConfig   `yaml:"amount_limits"`
+	Redis             RedisConfig          `yaml:"redis"`
+	Cache             CacheConfig          `yaml:"cache"`
+	Tracing           TracingConfig        `yaml:"tracing"`
+	Log               LogConfig            `yaml:"log"`
+	DBBreaker         BreakerConfig        `yaml:"db_breaker"`
+	RedisBreaker      BreakerConfig        `yaml:"redis_breaker"`
+	StartupRetry      RetryConfig          `yaml:"startup_retry"`
+	FeatureFlags      map[string]bool      `yaml:"feature_flags"` // Flag defaults; runtime overrides are stored in Redis
+	RequestLog        RequestLogConfig     `yaml:"request_log"`
+	Compression       CompressionConfig    `yaml:"compression"`
+	RequestTimeout    RequestTimeoutConfig `yaml:"request_timeout"`
+	Bulkhead          BulkheadConfig       `yaml:"bulkhead"`
+	Notifications     NotificationsConfig  `yaml:"notifications"`
+	Scheduled         ScheduledConfig      `yaml:"scheduled"`
+	Simulation        SimulationConfig     `yaml:"simulation"`
+	Netting           NettingConfig        `yaml:"netting"`
+	Anomaly           AnomalyConfig        `yaml:"anomaly"`
+	Approvals         ApprovalsConfig      `yaml:"approvals"`
+	MonthlySummaries  MonthlySummaryConfig `yaml:"monthly_summaries"`
+	Dormancy          DormancyConfig       `yaml:"dormancy"`
+	Archive           ArchiveConfig        `yaml:"archive"`
+	Backup            BackupConfig         `yaml:"backup"`
+}
+
+// ServerConfig holds settings for the HTTP server.
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"` // Time allowed to read request headers
+	ReadTimeout       time.Duration `yaml:"read_timeout"`        // Time allowed to read a whole request, body included
+	WriteTimeout      time.Duration `yaml:"write_timeout"`       // Time allowed to write a response; event streams are exempt
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`        // How long keep-alive connections wait for the next request
+	HTTP2             bool          `yaml:"http2"`               // Serve HTTP/2 to clients that negotiate it over TLS
+	H2C               bool          `yaml:"h2c"`                 // Serve unencrypted HTTP/2, e.g. behind a proxy that terminates TLS
+	TLS               TLSConfig     `yaml:"tls"`
+}
+
+// TLSConfig holds settings for terminating TLS in the server. Certificates are read from files,
+// or obtained from Let's Encrypt for the autocert domains.
+type TLSConfig struct {
+	Enabled      bool           `yaml:"enabled"`
+	CertFile     string         `yaml:"cert_file"`
+	KeyFile      string         `yaml:"key_file"`
+	Autocert     AutocertConfig `yaml:"autocert"`
+	MinVersion   string         `yaml:"min_version"`   // "1.2" or "1.3"
+	RedirectAddr string         `yaml:"redirect_addr"` // Plain HTTP listener redirecting to HTTPS, e.g. ":80"; empty disables
+}
+
+// AutocertConfig holds settings for obtaining certificates from Let's Encrypt. It is used instead
+// of certificate files when domains are set.
+type AutocertConfig struct {
+	Domains  []string `yaml:"domains"`   // Hosts certificates are obtained for
+	Email    string   `yaml:"email"`     // Contact address for expiry notices; optional
+	CacheDir string   `yaml:"cache_dir"` // Directory certificates are kept in across restarts
+}
+
+// DBPoolConfig holds the PostgreSQL connection pool settings.
+type DBPoolConfig struct {
+	MaxConns          int           `yaml:"max_conns"`
+	MinConns          int           `yaml:"min_conns"`           // Connections kept open even when idle
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`   // Age after which a connection is closed and replaced
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`  // Idle time after which a connection above min_conns is closed
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"` // How often idle connections are checked and expired
+}
+
+// RedisConfig holds the Redis connection settings.
+type RedisConfig struct {
+	Addr     string `yaml:"addr"` // host:port
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+}
+
+// CacheConfig holds the TTLs of cached entities.
+type CacheConfig struct {
+	Balance  CacheTTLConfig `yaml:"balance"`
+	User     CacheTTLConfig `yaml:"user"`
+	LocalTTL time.Duration  `yaml:"local_ttl"` // How long entries held in process outlive a missed invalidation; 0 disables the local cache
+}
+
+// CacheTTLConfig holds the TTLs of one kind of cached entity. Entries older than the soft TTL are
+// still served, but refreshed in the background; entries older than the hard TTL are evicted.
+type CacheTTLConfig struct {
+	SoftTTL time.Duration `yaml:"soft_ttl"` // Age after which an entry is refreshed in the background
+	HardTTL time.Duration `yaml:"hard_ttl"` // Age after which an entry is no longer served
+}
+
+// BreakerConfig holds circuit breaker thresholds for a dependency.
+type BreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold"` // Consecutive failures that open the circuit
+	ResetTimeout     time.Duration `yaml:"reset_timeout"`     // How long the circuit stays open before a trial call is let through
+}
+
+// RetryConfig holds how long and how often an unavailable dependency is retried.
+type RetryConfig struct {
+	InitialBackoff time.Duration `yaml:"initial_backoff"` // Wait after the first failed attempt; doubled after each further failure
+	MaxBackoff     time.Duration `yaml:"max_backoff"`     // Longest wait between attempts
+	MaxWait        time.Duration `yaml:"max_wait"`        // Total time to keep retrying; 0 makes a single attempt
+}
+
+// RequestLogConfig holds settings for recording money-movement requests and responses into the audit store.
+type RequestLogConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	Retention    time.Duration `yaml:"retention"`      // How long recorded exchanges are kept before being purged
+	MaxBodyBytes int           `yaml:"max_body_bytes"` // Larger bodies are omitted rather than stored
+	RedactFields []string      `yaml:"redact_fields"`  // Field name fragments redacted in addition to passwords, tokens and secrets
+}
+
+// CompressionConfig holds settings for compressing responses.
+type CompressionConfig struct {
+	Enabled      bool     `yaml:"enabled"`
+	MinBytes     int      `yaml:"min_bytes"`     // Smaller responses are sent uncompressed
+	Level        int      `yaml:"level"`         // 1 (fastest) to 9 (smallest)
+	ContentTypes []string `yaml:"content_types"` // Media types that are compressed
+}
+
+// RequestTimeoutConfig holds the deadlines of request contexts, so slow database queries are
+// cancelled rather than piling up.
+type RequestTimeoutConfig struct {
+	Enabled bool                     `yaml:"enabled"`
+	Read    time.Duration            `yaml:"read"`   // GET and HEAD requests
+	Write   time.Duration            `yaml:"write"`  // Requests with other methods
+	Routes  map[string]time.Duration `yaml:"routes"` // Per route pattern, e.g. "GET /api/v1/users"; 0 sets no deadline
+}
+
+// BulkheadConfig holds the limits isolating route groups from each other, so a flood of one kind
+// of request cannot starve another. Routes are grouped as transactions (requests changing state),
+// reads (GET and HEAD) and admin (/api/v1/admin/) unless Routes assigns another group.
+type BulkheadConfig struct {
+	Enabled      bool              `yaml:"enabled"`
+	Limits       map[string]int    `yaml:"limits"`        // Requests of each group served at once; 0 sets no limit
+	QueueSize    int               `yaml:"queue_size"`    // Requests of each group waiting for a slot before more are rejected with a 503
+	QueueTimeout time.Duration     `yaml:"queue_timeout"` // How long a queued request waits for a slot
+	Routes       map[string]string `yaml:"routes"`        // Group of route patterns, e.g. "GET /api/v1/users"; "" exempts a route
+}
+
+// NotificationsConfig holds settings for dispatching queued notifications to their channels.
+type NotificationsConfig struct {
+	DispatchInterval time.Duration `yaml:"dispatch_interval"` // How often queued notifications are sent
+	MaxAttempts      int           `yaml:"max_attempts"`      // Attempts per delivery before giving up
+	SMTP             SMTPConfig    `yaml:"smtp"`
+	Webhook          WebhookConfig `yaml:"webhook"`
+}
+
+// SMTPConfig holds the SMTP server email notifications are sent through. Email is disabled without a host.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"` // Optional; PLAIN authentication is used when set
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// WebhookConfig holds settings for webhook notifications.
+type WebhookConfig struct {
+	Secret  string        `yaml:"secret"`  // Optional; requests are signed with HMAC-SHA256 when set
+	Timeout time.Duration `yaml:"timeout"` // Per-request timeout
+}
+
+// ScheduledConfig holds settings for executing scheduled transactions.
+type ScheduledConfig struct {
+	FundingCheckLead time.Duration  `yaml:"funding_check_lead"` // How far ahead owners are warned about debits and transfers their balance does not cover; 0 disables
+	GraceRetries     int            `yaml:"grace_retries"`      // Retries of a failed execution on the same day before it is marked failed
+	RetryInterval    time.Duration  `yaml:"retry_interval"`     // Wait between retries of a failed execution
+	Calendar         CalendarConfig `yaml:"calendar"`
+}
+
+// CalendarConfig holds the business day calendar scheduled transactions are moved off weekends
+// and holidays by. Saturdays and Sundays are never business days.
+type CalendarConfig struct {
+	DefaultRegion string              `yaml:"default_region"` // Region of schedules created without one
+	Holidays      map[string][]string `yaml:"holidays"`       // Holiday dates (YYYY-MM-DD) per region, e.g. US or DE-BY
+}
+
+// AmountLimitsConfig maps a currency code to the amount limits of its transaction types: credit,
+// debit, transfer, or default for the types without a limit of their own.
+type AmountLimitsConfig map[string]map[string]AmountLimitConfig
+
+// AmountLimitConfig bounds the amounts of one transaction type in one currency. A zero bound is not enforced.
+type AmountLimitConfig struct {
+	Min float64 `yaml:"min"`
+	Max float64 `yaml:"max"`
+}
+
+// SimulationConfig holds settings for synthetic traffic simulation.
+type SimulationConfig struct {
+	Enabled bool   `yaml:"enabled"` // Simulations create users and mint money, so they are off by default
+	Clock   bool   `yaml:"clock"`   // Run time-dependent features on a clock admins can fast-forward
+	Seed    uint64 `yaml:"seed"`    // Seeds runs started without a seed reproducibly; 0 seeds them randomly
+}
+
+// NettingConfig holds settings for netting small transfers between the same pair of users.
+type NettingConfig struct {
+	Enabled   bool          `yaml:"enabled"`    // Netted transfers stay pending until their batch settles, so netting is off by default
+	Window    time.Duration `yaml:"window"`     // How long a batch accepts transfers before it is settled
+	MaxAmount float64       `yaml:"max_amount"` // Largest transfer that is netted; larger ones execute at once
+	Interval  time.Duration `yaml:"interval"`   // How often due batches are settled
+	BatchSize int           `yaml:"batch_size"` // Most batches settled per run
+}
+
+// AnomalyConfig holds settings for scoring suspicious money movement. Users whose score reaches the
+// threshold must confirm their password before they may move money again.
+type AnomalyConfig struct {
+	Enabled                 bool          `yaml:"enabled"`
+	Threshold               float64       `yaml:"threshold"`                 // Score at which money movement requires step-up authentication
+	HalfLife                time.Duration `yaml:"half_life"`                 // How long it takes a score to halve
+	RollbackWeight          float64       `yaml:"rollback_weight"`           // Points added by each rollback of a user's own transaction
+	InsufficientFundsWeight float64       `yaml:"insufficient_funds_weight"` // Points added by each debit or transfer rejected for insufficient funds
+}
+
+// ApprovalsConfig holds settings for four-eyes approval of destructive admin operations.
+type ApprovalsConfig struct {
+	Actions []string `yaml:"actions"` // Admin actions held until a second admin approves them: rollback, balance_adjustment or role_change
+}
+
+// MonthlySummaryConfig holds settings for generating users' monthly account summaries once a month ends.
+type MonthlySummaryConfig struct {
+	Enabled  bool          `yaml:"enabled"`  // Summaries of months that were not generated are still generated when requested
+	Interval time.Duration `yaml:"interval"` // How often to check whether a month ended and its summaries are due
+}
+
+// DormancyConfig holds settings for announcing accounts that stopped transacting.
+type DormancyConfig struct {
+	Enabled  bool          `yaml:"enabled"`  // Publish AccountDormant events; the admin report works either way
+	Days     int           `yaml:"days"`     // Days without transactions after which an account is dormant
+	Interval time.Duration `yaml:"interval"` // How often newly dormant accounts are looked for
+}
+
+// ArchiveConfig holds settings for partitioning the event store and transactions and archiving old rows.
+type ArchiveConfig struct {
+	Enabled         bool          `yaml:"enabled"`          // Requires PostgreSQL storage; off by default since archival moves rows out of the live tables
+	Interval        time.Duration `yaml:"interval"`         // How often partitions are created and old rows archived
+	PartitionsAhead int           `yaml:"partitions_ahead"` // Months of event and transaction partitions created beyond the current one
+	AfterMonths     int           `yaml:"after_months"`     // Whole months after which events and transactions are archived; 0 keeps everything live
+}
+
+// BackupConfig holds settings for exporting the event store to S3-compatible object storage.
+type BackupConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"` // How often new events are exported
+	Prefix   string        `yaml:"prefix"`   // Key prefix of exports in the bucket
+	S3       S3Config      `yaml:"s3"`
+}
+
+// S3Config holds the settings of an S3-compatible bucket.
+type S3Config struct {
+	Endpoint        string        `yaml:"endpoint"` // Base URL, e.g. http://localhost:9000 for MinIO
+	Region          string        `yaml:"region"`
+	Bucket          string        `yaml:"bucket"`
+	AccessKeyID     string        `yaml:"access_key_id"`
+	SecretAccessKey string        `yaml:"secret_access_key"`
+	Timeout         time.Duration `yaml:"timeout"` // Per-request timeout
+}
+
+// LogConfig holds structured logging settings.
+type LogConfig struct {
+	Format           string            `yaml:"format"`             // "json" or "text"
+	Level            string            `yaml:"level"`              // Default level: debug, info, warn or error
+	ModuleLevels     map[string]string `yaml:"module_levels"`      // Per-module level overrides keyed by package name, e.g. "worker"
+	File             string            `yaml:"file"`               // Optional file logs are also written to
+	FileMaxSizeMB    int               `yaml:"file_max_size_mb"`   // Size at which the log file is rotated
+	FileMaxBackups   int               `yaml:"file_max_backups"`   // Number of rotated files kept
+	FileMaxAgeDays   int               `yaml:"file_max_age_days"`  // Days rotated files are kept
+	DebugSampleEvery int               `yaml:"debug_sample_every"` // Keep one in N debug records; 1 keeps all, 0 picks a default for the environment
+}
+
+// TracingConfig holds OpenTelemetry trace export settings.
+type TracingConfig struct {
+	Enabled     bool              `yaml:"enabled"`
+	Endpoint    string            `yaml:"endpoint"`     // OTLP collector endpoint (host:port)
+	Protocol    string            `yaml:"protocol"`     // "grpc" or "http/protobuf"
+	Headers     map[string]string `yaml:"headers"`      // Extra headers sent with every export, e.g. auth tokens
+	Insecure    bool              `yaml:"insecure"`     // Disable TLS for the exporter connection
+	SampleRatio float64           `yaml:"sample_ratio"` // Fraction of root traces sampled, 0 to 1
 }
 
-// Load reads configuration from environment variables with sensible defaults.
-func Load() *Config {
+// Supported OTLP exporter protocols.
+const (
+	TracingProtocolGRPC = "grpc"
+	TracingProtocolHTTP = "http/protobuf"
+)
+
+// Supported storage backends. Memory storage keeps all data in process and loses it on restart,
+// so it is meant for demos and local development.
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Defaults returns the configuration used when neither a config file nor environment variables set a value.
+func Defaults() *Config {
 	return &Config{
-		Port:           getEnv("PORT", "8080"),
-		Environment:    getEnv("ENV", "dev"),
-		DBUrl:          getEnv("DB_URL", ""),
-		JWTSecret:      getEnv("JWT_SECRET", ""),
-		AllowedOrigins: getEnv("ALLOWED_ORIGINS", "*"),
+		Port:           "8080",
+		Environment:    "dev",
+		Storage:        StoragePostgres,
+		AllowedOrigins: "*",
+		RollbackWindow: 24 * time.Hour,
+		AmountLimits:   AmountLimitsConfig{},
+		Server: ServerConfig{
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			HTTP2:             true,
+			TLS: TLSConfig{
+				MinVersion: "1.2",
+				Autocert: AutocertConfig{
+					Domains:  []string{},
+					CacheDir: "autocert-cache",
+				},
+			},
+		},
+		DBPool: DBPoolConfig{
+			MaxConns:          30,
+			MinConns:          5,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   30 * time.Minute,
+			HealthCheckPeriod: time.Minute,
+		},
+		Redis: RedisConfig{
+			Addr:     "redis:6379", // Default Redis address in Docker
+			Password: "redis_password",
+		},
+		Cache: CacheConfig{
+			Balance: CacheTTLConfig{
+				SoftTTL: 30 * time.Second,
+				HardTTL: 10 * time.Minute,
+			},
+			User: CacheTTLConfig{
+				SoftTTL: 5 * time.Minute,
+				HardTTL: 30 * time.Minute,
+			},
+			LocalTTL: 30 * time.Second,
+		},
+		DBBreaker: BreakerConfig{
+			FailureThreshold: 5,
+			ResetTimeout:     30 * time.Second,
+		},
+		RedisBreaker: BreakerConfig{
+			FailureThreshold: 5,
+			ResetTimeout:     15 * time.Second,
+		},
+		StartupRetry: RetryConfig{
+			InitialBackoff: 500 * time.Millisecond,
+			MaxBackoff:     10 * time.Second,
+			MaxWait:        time.Minute,
+		},
+		FeatureFlags: map[string]bool{},
+		RequestLog: RequestLogConfig{
+			Retention:    90 * 24 * time.Hour,
+			MaxBodyBytes: 16 * 1024,
+			RedactFields: []string{},
+		},
+		Compression: CompressionConfig{
+			Enabled:      true,
+			MinBytes:     1024,
+			Level:        5,
+			ContentTypes: []string{"application/json", "application/x-ndjson", "text/csv"},
+		},
+		RequestTimeout: RequestTimeoutConfig{
+			Enabled: true,
+			Read:    10 * time.Second,
+			Write:   30 * time.Second,
+			Routes: map[string]time.Duration{
+				"GET /api/v1/transactions/history/export": 2 * time.Minute,
+				"GET /api/v1/transactions/{id}":           40 * time.Second, // Long enough for the longest wait=, 30s
+				"GET /api/v1/transactions/{id}/events":    0,                // Bounded by the stream's own maximum duration
+				"POST /api/v1/admin/import":               5 * time.Minute,
+			},
+		},
+		Bulkhead: BulkheadConfig{
+			Enabled:      true,
+			Limits:       map[string]int{"transactions": 200, "reads": 200, "exports": 4, "admin": 20},
+			QueueSize:    50,
+			QueueTimeout: 250 * time.Millisecond,
+			Routes: map[string]string{
+				"GET /api/v1/transactions/history/export":       "exports",
+				"GET /api/v1/transactions/history/exports/{id}": "exports",
+				"GET /api/v1/transactions/{id}/events":          "", // Streams would hold their slot for minutes
+			},
+		},
+		Notifications: NotificationsConfig{
+			DispatchInterval: 5 * time.Second,
+			MaxAttempts:      5,
+			SMTP: SMTPConfig{
+				Port: 587,
+			},
+			Webhook: WebhookConfig{
+				Timeout: 5 * time.Second,
+			},
+		},
+		Scheduled: ScheduledConfig{
+			FundingCheckLead: 24 * time.Hour,
+			GraceRetries:     3,
+			RetryInterval:    time.Hour,
+			Calendar: CalendarConfig{
+				DefaultRegion: "US",
+				Holidays:      map[string][]string{},
+			},
+		},
+		Netting: NettingConfig{
+			Window:    10 * time.Second,
+			MaxAmount: 100,
+			Interval:  time.Second,
+			BatchSize: 100,
+		},
+		Anomaly: AnomalyConfig{
+			Enabled:                 true,
+			Threshold:               5,
+			HalfLife:                10 * time.Minute,
+			RollbackWeight:          2,
+			InsufficientFundsWeight: 1,
+		},
+		Approvals: ApprovalsConfig{
+			Actions: []string{},
+		},
+		MonthlySummaries: MonthlySummaryConfig{
+			Enabled:  true,
+			Interval: time.Hour,
+		},
+		Dormancy: DormancyConfig{
+			Days:     90,
+			Interval: 24 * time.Hour,
+		},
+		Archive: ArchiveConfig{
+			Interval:        6 * time.Hour,
+			PartitionsAhead: 3,
+			AfterMonths:     12,
+		},
+		Backup: BackupConfig{
+			Interval: 5 * time.Minute,
+			Prefix:   "event-store",
+			S3: S3Config{
+				Region:  "us-east-1",
+				Timeout: 30 * time.Second,
+			},
+		},
+		Log: LogConfig{
+			Format:         "json",
+			Level:          "info",
+			ModuleLevels:   map[string]string{},
+			FileMaxSizeMB:  100,
+			FileMaxBackups: 5,
+			FileMaxAgeDays: 28,
+		},
+		Tracing: TracingConfig{
+			Enabled:     true,
+			Endpoint:    "jaeger:4317",
+			Protocol:    TracingProtocolGRPC,
+			Headers:     map[string]string{},
+			Insecure:    true,
+			SampleRatio: 1.0,
+		},
 	}
 }
 
-// getEnv reads an environment variable or returns a default value.
-func getEnv(key, defaultValue string) string {
+// Load builds the configuration from defaults, the YAML file at path (if any) and environment
+// variables, each layer overriding the previous one, and validates the result.
+// If only validation fails, the loaded configuration is returned together with a *ValidationError
+// so it can still be inspected.
+func Load(path string) (*Config, error) {
+	cfg := Defaults()
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	env := &envLoader{}
+	cfg.applyEnv(env)
+
+	if cfg.Log.DebugSampleEvery == 0 {
+		cfg.Log.DebugSampleEvery = defaultDebugSampleEvery(cfg.Environment)
+	}
+
+	problems := append(env.problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return cfg, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// loadFile overlays the YAML config file at path. Unknown keys are rejected so typos are not silently ignored.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteYAML writes the configuration in the config file format.
+func (c *Config) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	return encoder.Close()
+}
+
+// applyEnv overlays environment variables onto the configuration.
+func (c *Config) applyEnv(env *envLoader) {
+	c.Port = env.getEnv("PORT", c.Port)
+	c.Environment = env.getEnv("ENV", c.Environment)
+	c.Storage = env.getEnv("STORAGE", c.Storage)
+	c.DBUrl = env.getEnv("DB_URL", c.DBUrl)
+	c.DBPool.MaxConns = env.getEnvInt("DB_POOL_MAX_CONNS", c.DBPool.MaxConns)
+	c.DBPool.MinConns = env.getEnvInt("DB_POOL_MIN_CONNS", c.DBPool.MinConns)
+	c.DBPool.MaxConnLifetime = env.getEnvDuration("DB_POOL_MAX_CONN_LIFETIME", c.DBPool.MaxConnLifetime)
+	c.DBPool.MaxConnIdleTime = env.getEnvDuration("DB_POOL_MAX_CONN_IDLE_TIME", c.DBPool.MaxConnIdleTime)
+	c.DBPool.HealthCheckPeriod = env.getEnvDuration("DB_POOL_HEALTH_CHECK_PERIOD", c.DBPool.HealthCheckPeriod)
+	c.JWTSecret = env.getEnv("JWT_SECRET", c.JWTSecret)
+	c.ReceiptSigningKey = env.getEnv("RECEIPT_SIGNING_KEY", c.ReceiptSigningKey)
+	c.AllowedOrigins = env.getEnv("ALLOWED_ORIGINS", c.AllowedOrigins)
+	c.RollbackWindow = env.getEnvDuration("ROLLBACK_WINDOW", c.RollbackWindow)
+	c.WelcomeBonus = env.getEnvFloat("WELCOME_BONUS", c.WelcomeBonus)
+
+	c.Server.ReadHeaderTimeout = env.getEnvDuration("SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout)
+	c.Server.ReadTimeout = env.getEnvDuration("SERVER_READ_TIMEOUT", c.Server.ReadTimeout)
+	c.Server.WriteTimeout = env.getEnvDuration("SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	c.Server.IdleTimeout = env.getEnvDuration("SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout)
+	c.Server.HTTP2 = env.getEnvBool("SERVER_HTTP2", c.Server.HTTP2)
+	c.Server.H2C = env.getEnvBool("SERVER_H2C", c.Server.H2C)
+	c.Server.TLS.Enabled = env.getEnvBool("TLS_ENABLED", c.Server.TLS.Enabled)
+	c.Server.TLS.CertFile = env.getEnv("TLS_CERT_FILE", c.Server.TLS.CertFile)
+	c.Server.TLS.KeyFile = env.getEnv("TLS_KEY_FILE", c.Server.TLS.KeyFile)
+	c.Server.TLS.Autocert.Domains = env.getEnvList("TLS_AUTOCERT_DOMAINS", c.Server.TLS.Autocert.Domains)
+	c.Server.TLS.Autocert.Email = env.getEnv("TLS_AUTOCERT_EMAIL", c.Server.TLS.Autocert.Email)
+	c.Server.TLS.Autocert.CacheDir = env.getEnv("TLS_AUTOCERT_CACHE_DIR", c.Server.TLS.Autocert.CacheDir)
+	c.Server.TLS.MinVersion = env.getEnv("TLS_MIN_VERSION", c.Server.TLS.MinVersion)
+	c.Server.TLS.RedirectAddr = env.getEnv("TLS_REDIRECT_ADDR", c.Server.TLS.RedirectAddr)
+
+	c.Redis.Addr = env.getEnv("REDIS_ADDR", c.Redis.Addr)
+	c.Redis.Password = env.getEnv("REDIS_PASSWORD", c.Redis.Password)
+	c.Redis.DB = env.getEnvInt("REDIS_DB", c.Redis.DB)
+
+	c.Cache.Balance.SoftTTL = env.getEnvDuration("CACHE_BALANCE_SOFT_TTL", c.Cache.Balance.SoftTTL)
+	c.Cache.Balance.HardTTL = env.getEnvDuration("CACHE_BALANCE_HARD_TTL", c.Cache.Balance.HardTTL)
+	c.Cache.User.SoftTTL = env.getEnvDuration("CACHE_USER_SOFT_TTL", c.Cache.User.SoftTTL)
+	c.Cache.User.HardTTL = env.getEnvDuration("CACHE_USER_HARD_TTL", c.Cache.User.HardTTL)
+	c.Cache.LocalTTL = env.getEnvDuration("CACHE_LOCAL_TTL", c.Cache.LocalTTL)
+
+	c.DBBreaker.FailureThreshold = env.getEnvInt("DB_BREAKER_FAILURE_THRESHOLD", c.DBBreaker.FailureThreshold)
+	c.DBBreaker.ResetTimeout = env.getEnvDuration("DB_BREAKER_RESET_TIMEOUT", c.DBBreaker.ResetTimeout)
+	c.RedisBreaker.FailureThreshold = env.getEnvInt("REDIS_BREAKER_FAILURE_THRESHOLD", c.RedisBreaker.FailureThreshold)
+	c.RedisBreaker.ResetTimeout = env.getEnvDuration("REDIS_BREAKER_RESET_TIMEOUT", c.RedisBreaker.ResetTimeout)
+
+	c.StartupRetry.InitialBackoff = env.getEnvDuration("STARTUP_RETRY_INITIAL_BACKOFF", c.StartupRetry.InitialBackoff)
+	c.StartupRetry.MaxBackoff = env.getEnvDuration("STARTUP_RETRY_MAX_BACKOFF", c.StartupRetry.MaxBackoff)
+	c.StartupRetry.MaxWait = env.getEnvDuration("STARTUP_RETRY_MAX_WAIT", c.StartupRetry.MaxWait)
+
+	c.FeatureFlags = env.getEnvFlags("FEATURE_FLAGS", c.FeatureFlags)
+
+	c.RequestLog.Enabled = env.getEnvBool("REQUEST_LOG_ENABLED", c.RequestLog.Enabled)
+	c.RequestLog.Retention = env.getEnvDuration("REQUEST_LOG_RETENTION", c.RequestLog.Retention)
+	c.RequestLog.MaxBodyBytes = env.getEnvInt("REQUEST_LOG_MAX_BODY_BYTES", c.RequestLog.MaxBodyBytes)
+	c.RequestLog.RedactFields = env.getEnvList("REQUEST_LOG_REDACT_FIELDS", c.RequestLog.RedactFields)
+
+	c.Compression.Enabled = env.getEnvBool("COMPRESSION_ENABLED", c.Compression.Enabled)
+	c.Compression.MinBytes = env.getEnvInt("COMPRESSION_MIN_BYTES", c.Compression.MinBytes)
+	c.Compression.Level = env.getEnvInt("COMPRESSION_LEVEL", c.Compression.Level)
+	c.Compression.ContentTypes = env.getEnvList("COMPRESSION_CONTENT_TYPES", c.Compression.ContentTypes)
+
+	c.RequestTimeout.Enabled = env.getEnvBool("REQUEST_TIMEOUT_ENABLED", c.RequestTimeout.Enabled)
+	c.RequestTimeout.Read = env.getEnvDuration("REQUEST_TIMEOUT_READ", c.RequestTimeout.Read)
+	c.RequestTimeout.Write = env.getEnvDuration("REQUEST_TIMEOUT_WRITE", c.RequestTimeout.Write)
+	c.RequestTimeout.Routes = env.getEnvDurations("REQUEST_TIMEOUT_ROUTES", c.RequestTimeout.Routes)
+
+	c.Bulkhead.Enabled = env.getEnvBool("BULKHEAD_ENABLED", c.Bulkhead.Enabled)
+	c.Bulkhead.Limits = env.getEnvInts("BULKHEAD_LIMITS", c.Bulkhead.Limits)
+	c.Bulkhead.QueueSize = env.getEnvInt("BULKHEAD_QUEUE_SIZE", c.Bulkhead.QueueSize)
+	c.Bulkhead.QueueTimeout = env.getEnvDuration("BULKHEAD_QUEUE_TIMEOUT", c.Bulkhead.QueueTimeout)
+	c.Bulkhead.Routes = env.getEnvMergedPairs("BULKHEAD_ROUTES", c.Bulkhead.Routes)
+
+	c.Notifications.DispatchInterval = env.getEnvDuration("NOTIFICATIONS_DISPATCH_INTERVAL", c.Notifications.DispatchInterval)
+	c.Notifications.MaxAttempts = env.getEnvInt("NOTIFICATIONS_MAX_ATTEMPTS", c.Notifications.MaxAttempts)
+	c.Notifications.SMTP.Host = env.getEnv("SMTP_HOST", c.Notifications.SMTP.Host)
+	c.Notifications.SMTP.Port = env.getEnvInt("SMTP_PORT", c.Notifications.SMTP.Port)
+	c.Notifications.SMTP.Username = env.getEnv("SMTP_USERNAME", c.Notifications.SMTP.Username)
+	c.Notifications.SMTP.Password = env.getEnv("SMTP_PASSWORD", c.Notifications.SMTP.Password)
+	c.Notifications.SMTP.From = env.getEnv("SMTP_FROM", c.Notifications.SMTP.From)
+	c.Notifications.Webhook.Secret = env.getEnv("NOTIFICATIONS_WEBHOOK_SECRET", c.Notifications.Webhook.Secret)
+	c.Notifications.Webhook.Timeout = env.getEnvDuration("NOTIFICATIONS_WEBHOOK_TIMEOUT", c.Notifications.Webhook.Timeout)
+
+	c.Scheduled.FundingCheckLead = env.getEnvDuration("SCHEDULED_FUNDING_CHECK_LEAD", c.Scheduled.FundingCheckLead)
+	c.Scheduled.GraceRetries = env.getEnvInt("SCHEDULED_GRACE_RETRIES", c.Scheduled.GraceRetries)
+	c.Scheduled.RetryInterval = env.getEnvDuration("SCHEDULED_RETRY_INTERVAL", c.Scheduled.RetryInterval)
+	c.Scheduled.Calendar.DefaultRegion = env.getEnv("SCHEDULED_CALENDAR_REGION", c.Scheduled.Calendar.DefaultRegion)
+	c.Scheduled.Calendar.Holidays = env.getEnvHolidays("SCHEDULED_HOLIDAYS", c.Scheduled.Calendar.Holidays)
+
+	c.Simulation.Enabled = env.getEnvBool("SIMULATION_ENABLED", c.Simulation.Enabled)
+	c.Simulation.Clock = env.getEnvBool("SIMULATION_CLOCK_ENABLED", c.Simulation.Clock)
+	c.Simulation.Seed = env.getEnvUint64("SIMULATION_SEED", c.Simulation.Seed)
+
+	c.Netting.Enabled = env.getEnvBool("NETTING_ENABLED", c.Netting.Enabled)
+	c.Netting.Window = env.getEnvDuration("NETTING_WINDOW", c.Netting.Window)
+	c.Netting.MaxAmount = env.getEnvFloat("NETTING_MAX_AMOUNT", c.Netting.MaxAmount)
+	c.Netting.Interval = env.getEnvDuration("NETTING_INTERVAL", c.Netting.Interval)
+	c.Netting.BatchSize = env.getEnvInt("NETTING_BATCH_SIZE", c.Netting.BatchSize)
+
+	c.Anomaly.Enabled = env.getEnvBool("ANOMALY_ENABLED", c.Anomaly.Enabled)
+	c.Anomaly.Threshold = env.getEnvFloat("ANOMALY_THRESHOLD", c.Anomaly.Threshold)
+	c.Anomaly.HalfLife = env.getEnvDuration("ANOMALY_HALF_LIFE", c.Anomaly.HalfLife)
+	c.Anomaly.RollbackWeight = env.getEnvFloat("ANOMALY_ROLLBACK_WEIGHT", c.Anomaly.RollbackWeight)
+	c.Anomaly.InsufficientFundsWeight = env.getEnvFloat("ANOMALY_INSUFFICIENT_FUNDS_WEIGHT", c.Anomaly.InsufficientFundsWeight)
+
+	c.Approvals.Actions = env.getEnvList("APPROVAL_ACTIONS", c.Approvals.Actions)
+
+	c.MonthlySummaries.Enabled = env.getEnvBool("MONTHLY_SUMMARY_ENABLED", c.MonthlySummaries.Enabled)
+	c.MonthlySummaries.Interval = env.getEnvDuration("MONTHLY_SUMMARY_INTERVAL", c.MonthlySummaries.Interval)
+
+	c.Dormancy.Enabled = env.getEnvBool("DORMANCY_EVENTS_ENABLED", c.Dormancy.Enabled)
+	c.Dormancy.Days = env.getEnvInt("DORMANCY_DAYS", c.Dormancy.Days)
+	c.Dormancy.Interval = env.getEnvDuration("DORMANCY_INTERVAL", c.Dormancy.Interval)
+
+	c.AmountLimits = env.getEnvAmountLimits("AMOUNT_LIMITS", c.AmountLimits)
+
+	c.Archive.Enabled = env.getEnvBool("ARCHIVE_ENABLED", c.Archive.Enabled)
+	c.Archive.Interval = env.getEnvDuration("ARCHIVE_INTERVAL", c.Archive.Interval)
+	c.Archive.PartitionsAhead = env.getEnvInt("ARCHIVE_PARTITIONS_AHEAD", c.Archive.PartitionsAhead)
+	c.Archive.AfterMonths = env.getEnvInt("ARCHIVE_AFTER_MONTHS", c.Archive.AfterMonths)
+
+	c.Backup.Enabled = env.getEnvBool("BACKUP_ENABLED", c.Backup.Enabled)
+	c.Backup.Interval = env.getEnvDuration("BACKUP_INTERVAL", c.Backup.Interval)
+	c.Backup.Prefix = env.getEnv("BACKUP_PREFIX", c.Backup.Prefix)
+	c.Backup.S3.Endpoint = env.getEnv("BACKUP_S3_ENDPOINT", c.Backup.S3.Endpoint)
+	c.Backup.S3.Region = env.getEnv("BACKUP_S3_REGION", c.Backup.S3.Region)
+	c.Backup.S3.Bucket = env.getEnv("BACKUP_S3_BUCKET", c.Backup.S3.Bucket)
+	c.Backup.S3.AccessKeyID = env.getEnv("BACKUP_S3_ACCESS_KEY_ID", c.Backup.S3.AccessKeyID)
+	c.Backup.S3.SecretAccessKey = env.getEnv("BACKUP_S3_SECRET_ACCESS_KEY", c.Backup.S3.SecretAccessKey)
+	c.Backup.S3.Timeout = env.getEnvDuration("BACKUP_S3_TIMEOUT", c.Backup.S3.Timeout)
+
+	c.Log.Format = env.getEnv("LOG_FORMAT", c.Log.Format)
+	c.Log.Level = env.getEnv("LOG_LEVEL", c.Log.Level)
+	c.Log.ModuleLevels = env.getEnvPairs("LOG_MODULE_LEVELS", c.Log.ModuleLevels)
+	c.Log.File = env.getEnv("LOG_FILE", c.Log.File)
+	c.Log.FileMaxSizeMB = env.getEnvInt("LOG_FILE_MAX_SIZE_MB", c.Log.FileMaxSizeMB)
+	c.Log.FileMaxBackups = env.getEnvInt("LOG_FILE_MAX_BACKUPS", c.Log.FileMaxBackups)
+	c.Log.FileMaxAgeDays = env.getEnvInt("LOG_FILE_MAX_AGE_DAYS", c.Log.FileMaxAgeDays)
+	c.Log.DebugSampleEvery = env.getEnvInt("LOG_DEBUG_SAMPLE_EVERY", c.Log.DebugSampleEvery)
+
+	c.Tracing.Enabled = env.getEnvBool("TRACING_ENABLED", c.Tracing.Enabled)
+	c.Tracing.Endpoint = env.getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", c.Tracing.Endpoint)
+	c.Tracing.Protocol = env.getEnv("OTEL_EXPORTER_OTLP_PROTOCOL", c.Tracing.Protocol)
+	c.Tracing.Headers = env.getEnvPairs("OTEL_EXPORTER_OTLP_HEADERS", c.Tracing.Headers)
+	c.Tracing.Insecure = env.getEnvBool("OTEL_EXPORTER_OTLP_INSECURE", c.Tracing.Insecure)
+	c.Tracing.SampleRatio = env.getEnvFloat("TRACING_SAMPLE_RATIO", c.Tracing.SampleRatio)
+}
+
+// envLoader reads environment variables over existing values and records values that cannot be parsed.
+type envLoader struct {
+	problems []string
+}
+
+// invalid records an unparseable environment variable.
+func (l *envLoader) invalid(key, value, expected string) {
+	l.problems = append(l.problems, fmt.Sprintf("%s: expected %s, got %q", key, expected, value))
+}
+
+// getEnv reads an environment variable or returns the current value.
+func (l *envLoader) getEnv(key, current string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
-	return defaultValue
+	return current
+}
+
+// getEnvDuration reads a duration environment variable (e.g. "24h") or returns the current value.
+func (l *envLoader) getEnvDuration(key string, current time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		l.invalid(key, value, "a duration such as 30s or 24h")
+		return current
+	}
+	return d
+}
+
+// getEnvInt reads an integer environment variable or returns the current value.
+func (l *envLoader) getEnvInt(key string, current int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		l.invalid(key, value, "an integer")
+		return current
+	}
+	return i
+}
+
+// getEnvUint64 reads an unsigned 64-bit integer environment variable or returns the current value.
+func (l *envLoader) getEnvUint64(key string, current uint64) uint64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+	u, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		l.invalid(key, value, "an unsigned integer")
+		return current
+	}
+	return u
+}
+
+// getEnvBool reads a boolean environment variable (e.g. "true", "0") or returns the current value.
+func (l *envLoader) getEnvBool(key string, current bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.invalid(key, value, "a boolean")
+		return current
+	}
+	return b
+}
+
+// getEnvFloat reads a floating point environment variable or returns the current value.
+func (l *envLoader) getEnvFloat(key string, current float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		l.invalid(key, value, "a number")
+		return current
+	}
+	return f
+}
+
+// getEnvPairs reads a comma-separated list of key=value pairs or returns the current value.
+// Pairs set through the environment replace the whole map rather than merging into it.
+func (l *envLoader) getEnvPairs(key string, current map[string]string) map[string]string {
+	if value := os.Getenv(key); value != "" {
+		return parseHeaders(value)
+	}
+	return current
+}
+
+// getEnvMergedPairs reads a comma-separated list of key=value pairs, merged into the current
+// value, or returns the current value.
+func (l *envLoader) getEnvMergedPairs(key string, current map[string]string) map[string]string {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	pairs := make(map[string]string, len(current))
+	for name, v := range current {
+		pairs[name] = v
+	}
+	for name, v := range parseHeaders(value) {
+		pairs[name] = v
+	}
+	return pairs
+}
+
+// getEnvList reads a comma-separated list, skipping empty entries.
+func (l *envLoader) getEnvList(key string, current []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvFlags reads a comma-separated list of name=bool pairs, merging them over the current values.
+func (l *envLoader) getEnvFlags(key string, current map[string]bool) map[string]bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	flags := make(map[string]bool, len(current))
+	for name, enabled := range current {
+		flags[name] = enabled
+	}
+	for name, raw := range parseHeaders(value) {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			l.invalid(key, name+"="+raw, "name=true or name=false")
+			continue
+		}
+		flags[name] = enabled
+	}
+	return flags
+}
+
+// getEnvDurations reads a comma-separated list of name=duration pairs, merging them over the current values.
+func (l *envLoader) getEnvDurations(key string, current map[string]time.Duration) map[string]time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	durations := make(map[string]time.Duration, len(current))
+	for name, d := range current {
+		durations[name] = d
+	}
+	for name, raw := range parseHeaders(value) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			l.invalid(key, name+"="+raw, "name=duration, e.g. GET /api/v1/users=5s")
+			continue
+		}
+		durations[name] = d
+	}
+	return durations
+}
+
+// getEnvInts reads a comma-separated list of name=integer pairs or returns the current value.
+// Pairs set through the environment are merged into the current map, like getEnvDurations.
+func (l *envLoader) getEnvInts(key string, current map[string]int) map[string]int {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	ints := make(map[string]int, len(current))
+	for name, i := range current {
+		ints[name] = i
+	}
+	for name, raw := range parseHeaders(value) {
+		i, err := strconv.Atoi(raw)
+		if err != nil {
+			l.invalid(key, name+"="+raw, "name=integer, e.g. exports=4")
+			continue
+		}
+		ints[name] = i
+	}
+	return ints
+}
+
+// defaultDebugSampleEvery samples high-volume debug logs in production and keeps all of them elsewhere.
+func defaultDebugSampleEvery(env string) int {
+	if env == "prod" {
+		return 100
+	}
+	return 1
+}
+
+// getEnvAmountLimits reads a comma-separated list of CURRENCY[.type]=min:max entries, e.g.
+// "USD=1:50000,JPY.transfer=100:", or returns the current value. Either bound may be left empty;
+// an entry without a type sets the currency's default limit. Limits set through the environment
+// replace the whole map rather than merging into it.
+func (l *envLoader) getEnvAmountLimits(key string, current AmountLimitsConfig) AmountLimitsConfig {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	limits := AmountLimitsConfig{}
+	for name, raw := range parseHeaders(value) {
+		currency, txType, ok := strings.Cut(name, ".")
+		if !ok {
+			txType = "default"
+		}
+		var limit AmountLimitConfig
+		var minErr, maxErr error
+		minRaw, maxRaw, ok := strings.Cut(raw, ":")
+		if minRaw != "" {
+			limit.Min, minErr = strconv.ParseFloat(minRaw, 64)
+		}
+		if maxRaw != "" {
+			limit.Max, maxErr = strconv.ParseFloat(maxRaw, 64)
+		}
+		if !ok || minErr != nil || maxErr != nil {
+			l.invalid(key, name+"="+raw, "CURRENCY[.type]=min:max")
+			continue
+		}
+
+		if limits[currency] == nil {
+			limits[currency] = map[string]AmountLimitConfig{}
+		}
+		limits[currency][txType] = limit
+	}
+	return limits
+}
+
+// getEnvHolidays reads a comma-separated list of REGION=dates entries with space-separated dates,
+// e.g. "US=2026-01-01 2026-12-25,TR=2026-10-29", or returns the current value. Holidays set through
+// the environment replace the whole map rather than merging into it; dates are checked by Validate.
+func (l *envLoader) getEnvHolidays(key string, current map[string][]string) map[string][]string {
+	value := os.Getenv(key)
+	if value == "" {
+		return current
+	}
+
+	holidays := make(map[string][]string)
+	for region, dates := range parseHeaders(value) {
+		holidays[region] = strings.Fields(dates)
+	}
+	return holidays
+}
+
+// parseHeaders parses a comma-separated list of key=value pairs, skipping malformed entries.
+// It is used for exporter headers and per-module log levels.
+func parseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		headers[key] = strings.TrimSpace(val)
+	}
+	return headers
 }
 
 // GetPortInt returns the port as an integer.
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadLayersFileAndEnv(t *testing.T) {
+	path := writeConfigFile(t, `
+port: "9090"
+db_url: postgres://app:secret@db:5432/banking_sim
+jwt_secret: file-secret
+rollback_window: 2h
+log:
+  level: debug
+db_breaker:
+  failure_threshold: 3
+feature_flags:
+  caching: false
+`)
+	t.Setenv("PORT", "7070")
+	t.Setenv("DB_BREAKER_RESET_TIMEOUT", "1m")
+	t.Setenv("FEATURE_FLAGS", "async_processing=true")
+	t.Setenv("REQUEST_LOG_REDACT_FIELDS", "iban, card_number,")
+	t.Setenv("CACHE_USER_SOFT_TTL", "1m")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.Port != "7070" {
+		t.Errorf("expected env to override file port, got %q", cfg.Port)
+	}
+	if cfg.JWTSecret != "file-secret" || cfg.RollbackWindow != 2*time.Hour || cfg.Log.Level != "debug" {
+		t.Errorf("file values not applied: %+v", cfg)
+	}
+	if cfg.DBBreaker.FailureThreshold != 3 || cfg.DBBreaker.ResetTimeout != time.Minute {
+		t.Errorf("expected breaker from file and env, got %+v", cfg.DBBreaker)
+	}
+	if enabled, ok := cfg.FeatureFlags["caching"]; !ok || enabled || !cfg.FeatureFlags["async_processing"] {
+		t.Errorf("expected feature flags from file and env to be merged, got %v", cfg.FeatureFlags)
+	}
+	if fields := cfg.RequestLog.RedactFields; len(fields) != 2 || fields[0] != "iban" || fields[1] != "card_number" {
+		t.Errorf("expected request log redact fields from env, got %v", fields)
+	}
+	if cfg.Cache.User.SoftTTL != time.Minute || cfg.Cache.User.HardTTL != 30*time.Minute {
+		t.Errorf("expected user cache soft TTL from env and default hard TTL, got %+v", cfg.Cache.User)
+	}
+	if cfg.Log.Format != "json" || cfg.Redis.Addr != "redis:6379" {
+		t.Errorf("expected defaults for unset values, got format %q and redis addr %q", cfg.Log.Format, cfg.Redis.Addr)
+	}
+}
+
+func TestLoadRejectsUnknownFileKeys(t *testing.T) {
+	path := writeConfigFile(t, "db_ulr: postgres://localhost/banking_sim\n")
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "db_ulr") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestLoadReportsAllProblems(t *testing.T) {
+	t.Setenv("ENV", "prod")
+	t.Setenv("DB_URL", "")
+	t.Setenv("JWT_SECRET", "short")
+	t.Setenv("LOG_FORMAT", "xml")
+	t.Setenv("TRACING_SAMPLE_RATIO", "2")
+	t.Setenv("REDIS_DB", "one")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("CACHE_BALANCE_HARD_TTL", "10s")
+	t.Setenv("CACHE_LOCAL_TTL", "-1s")
+	t.Setenv("STARTUP_RETRY_MAX_WAIT", "-1s")
+	t.Setenv("SCHEDULED_RETRY_INTERVAL", "0s")
+	t.Setenv("WELCOME_BONUS", "-5")
+	t.Setenv("ARCHIVE_PARTITIONS_AHEAD", "0")
+	t.Setenv("COMPRESSION_LEVEL", "0")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "-1s")
+	t.Setenv("TLS_ENABLED", "true")
+	t.Setenv("DB_POOL_MIN_CONNS", "50")
+	t.Setenv("BACKUP_ENABLED", "true")
+	t.Setenv("BACKUP_S3_ENDPOINT", "minio:9000")
+	t.Setenv("NETTING_MAX_AMOUNT", "2000000")
+	t.Setenv("SCHEDULED_HOLIDAYS", "US=2026-12-25 25.12.2026")
+	t.Setenv("RECEIPT_SIGNING_KEY", "too-short")
+	t.Setenv("ANOMALY_HALF_LIFE", "0s")
+	t.Setenv("APPROVAL_ACTIONS", "rollback,delete_user")
+	t.Setenv("MONTHLY_SUMMARY_INTERVAL", "0s")
+	t.Setenv("DORMANCY_DAYS", "0")
+	t.Setenv("SIMULATION_SEED", "-1")
+	t.Setenv("BULKHEAD_LIMITS", "exports=-1")
+	t.Setenv("BULKHEAD_ROUTES", "GET /api/v1/users=bulk")
+
+	cfg, err := Load("")
+	if cfg == nil {
+		t.Fatal("expected configuration to be returned with validation errors")
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	for _, want := range []string{"REDIS_DB", "DB_URL", "JWT_SECRET", "LOG_FORMAT", "TRACING_SAMPLE_RATIO", "SMTP_FROM", "CACHE_BALANCE_HARD_TTL", "CACHE_LOCAL_TTL", "STARTUP_RETRY_MAX_WAIT", "SCHEDULED_RETRY_INTERVAL", "WELCOME_BONUS", "ARCHIVE_PARTITIONS_AHEAD", "COMPRESSION_LEVEL", "SERVER_WRITE_TIMEOUT", "TLS_CERT_FILE", "DB_POOL_MIN_CONNS", "BACKUP_S3_ENDPOINT", "BACKUP_S3_BUCKET", "NETTING_MAX_AMOUNT", "SCHEDULED_HOLIDAYS", "RECEIPT_SIGNING_KEY", "ANOMALY_HALF_LIFE", "APPROVAL_ACTIONS", "MONTHLY_SUMMARY_INTERVAL", "DORMANCY_DAYS", "SIMULATION_SEED", "BULKHEAD_LIMITS", "BULKHEAD_ROUTES"} {
+		found := false
+		for _, problem := range validationErr.Problems {
+			if strings.Contains(problem, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected a problem mentioning %s, got %v", want, validationErr.Problems)
+		}
+	}
+}
+
+func TestAmountLimits(t *testing.T) {
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("DB_URL", "postgres://localhost/banking_sim")
+	path := writeConfigFile(t, `
+amount_limits:
+  USD:
+    default: {min: 1, max: 50000}
+    transfer: {min: 10}
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := cfg.AmountLimits["USD"]; got["default"] != (AmountLimitConfig{Min: 1, Max: 50000}) || got["transfer"] != (AmountLimitConfig{Min: 10}) {
+		t.Errorf("expected amount limits from file, got %v", cfg.AmountLimits)
+	}
+
+	t.Setenv("AMOUNT_LIMITS", "JPY=100:,EUR.debit=:2500")
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(cfg.AmountLimits) != 2 || cfg.AmountLimits["JPY"]["default"] != (AmountLimitConfig{Min: 100}) ||
+		cfg.AmountLimits["EUR"]["debit"] != (AmountLimitConfig{Max: 2500}) {
+		t.Errorf("expected amount limits from env to replace the file's, got %v", cfg.AmountLimits)
+	}
+
+	for _, value := range []string{"USD=5", "USD=a:10", "usd=1:10", "USD.refund=1:10", "USD=10:5", "USD=:2000000"} {
+		t.Setenv("AMOUNT_LIMITS", value)
+		if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "AMOUNT_LIMITS") {
+			t.Errorf("AMOUNT_LIMITS=%s: expected a problem mentioning AMOUNT_LIMITS, got %v", value, err)
+		}
+	}
+}
+
+func TestRequestTimeoutRoutes(t *testing.T) {
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("DB_URL", "postgres://localhost/banking_sim")
+	t.Setenv("REQUEST_TIMEOUT_ROUTES", "GET /api/v1/users=5s,GET /api/v1/transactions/{id}/events=1m")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	routes := cfg.RequestTimeout.Routes
+	if routes["GET /api/v1/users"] != 5*time.Second || routes["GET /api/v1/transactions/{id}/events"] != time.Minute ||
+		routes["GET /api/v1/transactions/history/export"] != 2*time.Minute {
+		t.Errorf("expected route timeouts from env merged over the defaults, got %v", routes)
+	}
+
+	for _, value := range []string{"GET /api/v1/users=soon", "/api/v1/users=5s", "GET /api/v1/users=-1s"} {
+		t.Setenv("REQUEST_TIMEOUT_ROUTES", value)
+		if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "REQUEST_TIMEOUT_ROUTES") {
+			t.Errorf("REQUEST_TIMEOUT_ROUTES=%s: expected a problem mentioning REQUEST_TIMEOUT_ROUTES, got %v", value, err)
+		}
+	}
+}
+
+func TestMemoryStorageDoesNotNeedDatabase(t *testing.T) {
+	t.Setenv("STORAGE", StorageMemory)
+	t.Setenv("DB_URL", "")
+	t.Setenv("JWT_SECRET", "secret")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("expected memory storage without DB_URL to be valid, got %v", err)
+	}
+	if cfg.Storage != StorageMemory {
+		t.Errorf("expected memory storage, got %q", cfg.Storage)
+	}
+
+	t.Setenv("ENV", "prod")
+	t.Setenv("JWT_SECRET", strings.Repeat("s", minProdJWTSecretLength))
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "STORAGE") {
+		t.Errorf("expected memory storage to be rejected in prod, got %v", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Defaults()
+	cfg.DBUrl = "postgres://app:secret@db:5432/banking_sim"
+	cfg.JWTSecret = "jwt-secret"
+	cfg.ReceiptSigningKey = "receipt-signing-key"
+	cfg.Tracing.Headers = map[string]string{"authorization": "Bearer token"}
+	cfg.Notifications.SMTP.Password = "smtp-password"
+	cfg.Notifications.Webhook.Secret = "webhook-secret"
+	cfg.Backup.S3.SecretAccessKey = "s3-secret"
+
+	redacted := cfg.Redacted()
+
+	if strings.Contains(redacted.DBUrl, "secret") || !strings.Contains(redacted.DBUrl, "app:REDACTED@db") {
+		t.Errorf("db url not redacted: %s", redacted.DBUrl)
+	}
+	if redacted.JWTSecret != redactedValue || redacted.Redis.Password != redactedValue || redacted.ReceiptSigningKey != redactedValue {
+		t.Errorf("secrets not redacted: %q, %q, %q", redacted.JWTSecret, redacted.Redis.Password, redacted.ReceiptSigningKey)
+	}
+	if redacted.Notifications.SMTP.Password != redactedValue || redacted.Notifications.Webhook.Secret != redactedValue {
+		t.Errorf("notification secrets not redacted: %q, %q", redacted.Notifications.SMTP.Password, redacted.Notifications.Webhook.Secret)
+	}
+	if redacted.Backup.S3.SecretAccessKey != redactedValue {
+		t.Errorf("backup secret not redacted: %q", redacted.Backup.S3.SecretAccessKey)
+	}
+	if redacted.Tracing.Headers["authorization"] != redactedValue {
+		t.Errorf("tracing header not redacted: %v", redacted.Tracing.Headers)
+	}
+	if cfg.JWTSecret != "jwt-secret" || cfg.Tracing.Headers["authorization"] != "Bearer token" {
+		t.Error("Redacted modified the original configuration")
+	}
+
+	if got := redactDBUrl("host=db user=app password=secret dbname=banking_sim"); strings.Contains(got, "secret") {
+		t.Errorf("dsn password not redacted: %s", got)
+	}
+}
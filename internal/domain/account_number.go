@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Account numbers follow the IBAN layout: a country code, two check digits and a basic account
+// number. XS is a user-assigned ISO 3166 code, so numbers cannot be mistaken for real IBANs.
+const (
+	accountNumberCountry = "XS"
+	accountNumberBBANLen = 16
+	accountNumberLen     = len(accountNumberCountry) + 2 + accountNumberBBANLen
+)
+
+// AccountNumberFor returns the account number of a user. It is derived from the user ID so every
+// environment seeded with the same users has the same numbers; migration 022 computes the same
+// number in SQL for users inserted without one.
+func AccountNumberFor(userID uuid.UUID) string {
+	sum := md5.Sum([]byte(userID.String())) // Spreads IDs over numbers; not a security measure
+	hexDigits := hex.EncodeToString(sum[:])[:15]
+	n, _ := strconv.ParseUint(hexDigits, 16, 64) // 60 bits always fit
+	bban := fmt.Sprintf("%0*d", accountNumberBBANLen, n%10_000_000_000_000_000)
+
+	return accountNumberCountry + accountNumberCheckDigits(bban) + bban
+}
+
+// ParseAccountNumber normalizes an account number as people write it, ignoring spaces and case,
+// and checks its format and check digits.
+func ParseAccountNumber(s string) (string, error) {
+	number := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if number == "" {
+		return "", fmt.Errorf("account number is required")
+	}
+
+	if len(number) != accountNumberLen || !strings.HasPrefix(number, accountNumberCountry) || !isDigits(number[len(accountNumberCountry):]) {
+		return "", fmt.Errorf("account number must be %s followed by %d digits", accountNumberCountry, accountNumberLen-len(accountNumberCountry))
+	}
+
+	checkDigits, bban := number[2:4], number[4:]
+	if accountNumberCheckDigits(bban) != checkDigits {
+		return "", fmt.Errorf("account number check digits do not match")
+	}
+
+	return number, nil
+}
+
+// accountNumberCheckDigits computes the ISO 13616 check digits of a basic account number.
+func accountNumberCheckDigits(bban string) string {
+	// The country code is moved behind the BBAN with letters as numbers (A=10 ... Z=35)
+	var rearranged strings.Builder
+	rearranged.WriteString(bban)
+	for _, c := range accountNumberCountry {
+		rearranged.WriteString(strconv.Itoa(int(c-'A') + 10))
+	}
+	rearranged.WriteString("00")
+
+	return fmt.Sprintf("%02d", 98-mod97(rearranged.String()))
+}
+
+// mod97 returns a decimal number, given as digits, modulo 97.
+func mod97(digits string) int {
+	remainder := 0
+	for _, d := range digits {
+		remainder = (remainder*10 + int(d-'0')) % 97
+	}
+	return remainder
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// AccountLookup is the public view of an account resolved from its number, used to confirm a
+// transfer's destination before sending.
+type AccountLookup struct {
+	AccountNumber string    `json:"account_number"`
+	UserID        uuid.UUID `json:"user_id"`
+	Username      string    `json:"username"`
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Locale selects the separators amounts are formatted with.
+type Locale string
+
+const (
+	// LocaleEN formats amounts like 1,234.56
+	LocaleEN Locale = "en"
+	// LocaleDE formats amounts like 1.234,56
+	LocaleDE Locale = "de"
+	// LocaleFR formats amounts like 1 234,56, grouping with a no-break space
+	LocaleFR Locale = "fr"
+)
+
+// localeSeparators holds the digit group and decimal separators of each locale.
+var localeSeparators = map[Locale]struct{ group, decimal string }{
+	LocaleEN: {",", "."},
+	LocaleDE: {".", ","},
+	LocaleFR: {"\u00a0", ","},
+}
+
+// ParseLocale parses a locale such as "de" or "de-DE"; only the language is used. An empty
+// locale is returned as is and formats amounts without digit grouping.
+func ParseLocale(locale string) (Locale, error) {
+	if locale == "" {
+		return "", nil
+	}
+
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	language, _, _ = strings.Cut(language, "_")
+	if _, ok := localeSeparators[Locale(language)]; !ok {
+		return "", fmt.Errorf("locale must be one of en, de or fr")
+	}
+	return Locale(language), nil
+}
+
+// FormatDecimal formats an amount with its currency's decimal places and the separators of
+// locale, e.g. "1.234,50" for 1234.5 USD in LocaleDE. Without a locale the amount is formatted
+// plainly, e.g. "1234.50", so it can be read back with ParseAmount.
+func FormatDecimal(amount float64, currency string, locale Locale) string {
+	digits := strconv.FormatFloat(amount, 'f', currencyDecimalPlaces(currency), 64)
+	separators, ok := localeSeparators[locale]
+	if !ok {
+		return digits
+	}
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(separators.group)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteString(separators.decimal)
+		b.WriteString(fraction)
+	}
+
+	return b.String()
+}
+
+// FormatAmountLocale formats an amount followed by its currency code, e.g. "1.500 JPY" in LocaleDE.
+func FormatAmountLocale(amount float64, currency string, locale Locale) string {
+	return FormatDecimal(amount, currency, locale) + " " + currency
+}
+
+// FormatAmount formats an amount for people in LocaleEN, e.g. "1,234.50 USD" or "1,500 JPY".
+func FormatAmount(amount float64, currency string) string {
+	return FormatAmountLocale(amount, currency, LocaleEN)
+}
+
+// AmountFields lists the JSON fields of requests that hold amounts. Request bodies are checked
+// with ParseAmount before they are decoded, since decoding into a float64 rounds away precision.
+var AmountFields = []string{
+	"amount", "default_amount", "threshold", "initial_balance", "max_amount", "max_credit_amount", "daily_credit_cap",
+}
+
+// amountPattern matches a plain decimal number: no exponent, no leading zeros and no separators.
+var amountPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// ParseAmount strictly parses an amount written as a plain decimal number, e.g. "1234.50",
+// rejecting more decimal places than the currency allows; trailing zeros do not count. Without a
+// known currency at most MaxCurrencyDecimalPlaces are allowed. Signs are left to request validation.
+func ParseAmount(s string, currency string) (float64, error) {
+	if !amountPattern.MatchString(s) {
+		return 0, fmt.Errorf("amount must be a plain decimal number, e.g. 1234.50")
+	}
+
+	places := MaxCurrencyDecimalPlaces
+	info, known := LookupCurrency(currency)
+	if known {
+		places = info.DecimalPlaces
+	}
+	if _, fraction, ok := strings.Cut(s, "."); ok && len(strings.TrimRight(fraction, "0")) > places {
+		if !known {
+			currency = ""
+		}
+		return 0, decimalPlacesError(currency, places)
+	}
+
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount must be a plain decimal number, e.g. 1234.50")
+	}
+	return amount, nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// MaxTransactionAmount is the largest amount any transaction may have, whatever its limits.
+const MaxTransactionAmount = 1000000
+
+// AnyTransactionType keys the amount limit applying to the transaction types of a currency
+// without a limit of their own.
+const AnyTransactionType TransactionType = "default"
+
+// AmountLimit bounds the amount of one type of transaction in one currency. A zero bound is not
+// enforced; amounts must still be greater than 0 and at most MaxTransactionAmount.
+type AmountLimit struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// AmountLimits maps a currency code to the amount limits of its transaction types.
+type AmountLimits map[string]map[TransactionType]AmountLimit
+
+// amountLimits holds the limits request validation consults. None are set until SetAmountLimits.
+var amountLimits atomic.Pointer[AmountLimits]
+
+func init() {
+	SetAmountLimits(nil)
+}
+
+// SetAmountLimits replaces the amount limits request validation consults.
+func SetAmountLimits(limits AmountLimits) {
+	snapshot := make(AmountLimits, len(limits))
+	for currency, byType := range limits {
+		snapshot[currency] = make(map[TransactionType]AmountLimit, len(byType))
+		for txType, limit := range byType {
+			snapshot[currency][txType] = limit
+		}
+	}
+	amountLimits.Store(&snapshot)
+}
+
+// AmountLimitFor returns the amount limit of a transaction type in a currency, falling back to
+// the currency's AnyTransactionType limit.
+func AmountLimitFor(currency string, txType TransactionType) (AmountLimit, bool) {
+	byType := (*amountLimits.Load())[currency]
+	if limit, ok := byType[txType]; ok {
+		return limit, true
+	}
+	limit, ok := byType[AnyTransactionType]
+	return limit, ok
+}
+
+// AmountLimitError reports an amount outside the range allowed for its currency and transaction type.
+type AmountLimitError struct {
+	Currency string          `json:"currency"`
+	Type     TransactionType `json:"type"`
+	Min      float64         `json:"min"` // Amounts must be greater than 0 when Min is 0
+	Max      float64         `json:"max"`
+}
+
+func (e *AmountLimitError) Error() string {
+	if e.Min == 0 {
+		return fmt.Sprintf("%s amounts in %s cannot exceed %s", e.Type, e.Currency, FormatAmount(e.Max, e.Currency))
+	}
+	return fmt.Sprintf("%s amounts in %s must be between %s and %s",
+		e.Type, e.Currency, FormatAmount(e.Min, e.Currency), FormatAmount(e.Max, e.Currency))
+}
+
+// CheckAmountLimit checks a positive amount against the limit of its currency and transaction
+// type, returning an *AmountLimitError with the allowed range when it falls outside it.
+func CheckAmountLimit(currency string, txType TransactionType, amount float64) error {
+	limit, ok := AmountLimitFor(currency, txType)
+	if !ok {
+		return nil
+	}
+
+	allowed := &AmountLimitError{Currency: currency, Type: txType, Min: limit.Min, Max: MaxTransactionAmount}
+	if limit.Max > 0 && limit.Max < MaxTransactionAmount {
+		allowed.Max = limit.Max
+	}
+	if amount < allowed.Min || amount > allowed.Max {
+		return allowed
+	}
+
+	return nil
+}
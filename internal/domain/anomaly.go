@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnomalySignal is suspicious money movement that raises a user's anomaly score.
+type AnomalySignal string
+
+const (
+	// AnomalyRollback is raised by every rollback a user makes of their own transaction
+	AnomalyRollback AnomalySignal = "rollback"
+	// AnomalyInsufficientFunds is raised by every debit or transfer rejected for insufficient funds
+	AnomalyInsufficientFunds AnomalySignal = "insufficient_funds"
+)
+
+// ErrStepUpRequired holds up the money movement of a user whose anomaly score reached the threshold
+// until they confirm their password.
+var ErrStepUpRequired = errors.New("step-up authentication required: confirm your password to continue moving money")
+
+// AnomalyScore is a user's anomaly score as of UpdatedAt. The score halves every half-life after,
+// so only signals raised in quick succession add up to much.
+type AnomalyScore struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Score     float64   `json:"score" db:"score"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// At returns the score decayed to the given time.
+func (s *AnomalyScore) At(now time.Time, halfLife time.Duration) float64 {
+	return DecayScore(s.Score, now.Sub(s.UpdatedAt), halfLife)
+}
+
+// DecayScore returns what is left of score after elapsed has passed, halving every half-life.
+// Scores do not grow when elapsed is negative, e.g. from clock skew between instances.
+func DecayScore(score float64, elapsed, halfLife time.Duration) float64 {
+	if elapsed <= 0 || halfLife <= 0 {
+		return score
+	}
+	return score * math.Pow(0.5, elapsed.Seconds()/halfLife.Seconds())
+}
+
+// StepUpRequest represents the password a user confirms to move money again.
+type StepUpRequest struct {
+	Password string `json:"password"`
+}
+
+// Validate validates the step-up request.
+func (r *StepUpRequest) Validate() error {
+	if r.Password == "" {
+		return fmt.Errorf("password: password is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIEndpointClass groups API endpoints for usage reporting and quotas.
+type APIEndpointClass string
+
+const (
+	// EndpointClassRead is reading the user's own data
+	EndpointClassRead APIEndpointClass = "read"
+	// EndpointClassWrite is changing the user's own data without moving money
+	EndpointClassWrite APIEndpointClass = "write"
+	// EndpointClassMoneyMovement is crediting, debiting, transferring and rolling back money
+	EndpointClassMoneyMovement APIEndpointClass = "money_movement"
+	// EndpointClassAdmin is every admin endpoint
+	EndpointClassAdmin APIEndpointClass = "admin"
+)
+
+// APIEndpointClasses lists the endpoint classes API calls are counted by.
+var APIEndpointClasses = []string{
+	string(EndpointClassRead), string(EndpointClassWrite), string(EndpointClassMoneyMovement), string(EndpointClassAdmin),
+}
+
+// API usage is reported for the last DefaultAPIUsageDays UTC days unless asked otherwise, and is
+// kept for MaxAPIUsageDays.
+const (
+	DefaultAPIUsageDays = 7
+	MaxAPIUsageDays     = 31
+)
+
+// moneyMovementSuffixes are the endpoints, by the end of their path, that move money.
+var moneyMovementSuffixes = []string{"/credit", "/debit", "/transfer", "/transfer/from-qr", "/rollback", "/execute", "/approve"}
+
+// ClassifyEndpoint returns the class of the endpoint a request is made to.
+func ClassifyEndpoint(method, path string) APIEndpointClass {
+	if strings.HasPrefix(path, "/api/v1/admin/") || path == "/api/v1/users" || strings.HasPrefix(path, "/api/v1/users/") {
+		return EndpointClassAdmin
+	}
+	if method == "GET" || method == "HEAD" {
+		return EndpointClassRead
+	}
+
+	movesMoney := strings.HasPrefix(path, "/api/v1/transactions/") ||
+		strings.HasPrefix(path, "/api/v1/transfer-templates/") ||
+		strings.HasPrefix(path, "/api/v1/payment-requests/")
+	if movesMoney && slices.ContainsFunc(moneyMovementSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(path, suffix)
+	}) {
+		return EndpointClassMoneyMovement
+	}
+	return EndpointClassWrite
+}
+
+// APIQuota is an admin-set limit on the API calls a user makes per UTC day. Calls beyond it are
+// rejected until the day ends.
+type APIQuota struct {
+	UserID      uuid.UUID        `json:"user_id"`
+	DailyLimit  int64            `json:"daily_limit,omitempty"`  // Across every class; 0 leaves the total unlimited
+	ClassLimits map[string]int64 `json:"class_limits,omitempty"` // Per endpoint class
+	UpdatedBy   uuid.UUID        `json:"updated_by"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// Exceeded reports whether a day's usage, including the call being made to class, goes over the
+// quota, and the limit it goes over.
+func (q *APIQuota) Exceeded(usage *APIUsageDay, class string) (bool, int64) {
+	if limit, ok := q.ClassLimits[class]; ok && usage.ByClass[class] > limit {
+		return true, limit
+	}
+	if q.DailyLimit > 0 && usage.Total > q.DailyLimit {
+		return true, q.DailyLimit
+	}
+	return false, 0
+}
+
+// SetAPIQuotaRequest represents the limits of a user's API quota.
+type SetAPIQuotaRequest struct {
+	DailyLimit  int64            `json:"daily_limit"`
+	ClassLimits map[string]int64 `json:"class_limits,omitempty"`
+}
+
+// Validate validates the set API quota request.
+func (r *SetAPIQuotaRequest) Validate() error {
+	if r.DailyLimit < 0 {
+		return fmt.Errorf("daily_limit must not be negative")
+	}
+	for class, limit := range r.ClassLimits {
+		if !slices.Contains(APIEndpointClasses, class) {
+			return fmt.Errorf("class_limits: class must be one of %s, got %q", strings.Join(APIEndpointClasses, ", "), class)
+		}
+		if limit < 1 {
+			return fmt.Errorf("class_limits: %s must be at least 1", class)
+		}
+	}
+	if r.DailyLimit == 0 && len(r.ClassLimits) == 0 {
+		return fmt.Errorf("daily_limit or class_limits is required")
+	}
+
+	return nil
+}
+
+// APIUsageDay is the number of API calls a user made during a UTC day, in total and per endpoint
+// class. Calls rejected by the quota are not counted.
+type APIUsageDay struct {
+	Date    string           `json:"date"` // yyyy-mm-dd
+	Total   int64            `json:"total"`
+	ByClass map[string]int64 `json:"by_class"`
+}
+
+// NewAPIUsageDay builds a day's usage from its per-class counts.
+func NewAPIUsageDay(date string, byClass map[string]int64) APIUsageDay {
+	day := APIUsageDay{Date: date, ByClass: make(map[string]int64, len(byClass))}
+	for class, count := range byClass {
+		day.ByClass[class] = count
+		day.Total += count
+	}
+	return day
+}
+
+// APIUsageReport is a user's recent API usage and what remains of their quota today.
+type APIUsageReport struct {
+	UserID         uuid.UUID        `json:"user_id"`
+	Quota          *APIQuota        `json:"quota,omitempty"`
+	Today          APIUsageDay      `json:"today"`
+	Remaining      *int64           `json:"remaining,omitempty"`       // Of the daily limit
+	ClassRemaining map[string]int64 `json:"class_remaining,omitempty"` // Of the class limits
+	ResetAt        time.Time        `json:"reset_at"`                  // When today's usage starts over
+	Days           []APIUsageDay    `json:"days"`                      // Today first
+}
+
+// BuildAPIUsageReport reports a user's usage of the days given, today first, against their quota.
+func BuildAPIUsageReport(userID uuid.UUID, quota *APIQuota, days []APIUsageDay, now time.Time) *APIUsageReport {
+	report := &APIUsageReport{UserID: userID, Quota: quota, ResetAt: APIUsageResetAt(now), Days: days}
+	if len(days) > 0 {
+		report.Today = days[0]
+	} else {
+		report.Today = NewAPIUsageDay(APIUsageDate(now), nil)
+	}
+	if quota == nil {
+		return report
+	}
+
+	if quota.DailyLimit > 0 {
+		remaining := max(quota.DailyLimit-report.Today.Total, 0)
+		report.Remaining = &remaining
+	}
+	if len(quota.ClassLimits) > 0 {
+		report.ClassRemaining = make(map[string]int64, len(quota.ClassLimits))
+		for class, limit := range quota.ClassLimits {
+			report.ClassRemaining[class] = max(limit-report.Today.ByClass[class], 0)
+		}
+	}
+	return report
+}
+
+// ValidateAPIUsageDays checks the number of days API usage is requested for.
+func ValidateAPIUsageDays(days int) error {
+	if days < 1 || days > MaxAPIUsageDays {
+		return fmt.Errorf("days must be between 1 and %d, got %d", MaxAPIUsageDays, days)
+	}
+	return nil
+}
+
+// APIUsageDate returns the yyyy-mm-dd UTC day API calls made at t are counted in.
+func APIUsageDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// APIUsageResetAt returns when the UTC day t falls in ends and its usage starts over.
+func APIUsageResetAt(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalAction identifies a destructive admin operation that may require a second admin's approval.
+type ApprovalAction string
+
+const (
+	// ApprovalRollback is a full or partial admin rollback of a transaction
+	ApprovalRollback ApprovalAction = "rollback"
+	// ApprovalBalanceAdjustment is an admin correction of a user's balance
+	ApprovalBalanceAdjustment ApprovalAction = "balance_adjustment"
+	// ApprovalRoleChange is an update of a user that changes their role
+	ApprovalRoleChange ApprovalAction = "role_change"
+	// ApprovalPolicyChange is a new version of the policy
+	ApprovalPolicyChange ApprovalAction = "policy_change"
+)
+
+// ApprovalStatus defines the states of an approval.
+type ApprovalStatus string
+
+const (
+	// ApprovalPending represents an action waiting for a second admin
+	ApprovalPending ApprovalStatus = "pending"
+	// ApprovalApproved represents an approved action that is being executed
+	ApprovalApproved ApprovalStatus = "approved"
+	// ApprovalRejected represents an action a second admin rejected; it was never executed
+	ApprovalRejected ApprovalStatus = "rejected"
+	// ApprovalExecuted represents an approved action that was executed
+	ApprovalExecuted ApprovalStatus = "executed"
+	// ApprovalFailed represents an approved action whose execution failed
+	ApprovalFailed ApprovalStatus = "failed"
+)
+
+// Approval is an admin operation held in the four-eyes queue until an admin other than the one who
+// requested it approves or rejects it. Payload holds the request the action is executed with.
+type Approval struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	Action      ApprovalAction  `json:"action" db:"action"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	RequestedBy uuid.UUID       `json:"requested_by" db:"requested_by"`
+	Status      ApprovalStatus  `json:"status" db:"status"`
+	ReviewedBy  *uuid.UUID      `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote  string          `json:"review_note,omitempty" db:"review_note"`
+	Result      json.RawMessage `json:"result,omitempty" db:"result"` // What the executed action returned
+	Error       string          `json:"error,omitempty" db:"error"`   // Why execution failed
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	ReviewedAt  *time.Time      `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// RollbackApproval is the payload of an admin rollback awaiting approval. A nil amount reverses
+// everything not yet reversed.
+type RollbackApproval struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Amount        *float64  `json:"amount,omitempty"`
+}
+
+// BalanceAdjustmentApproval is the payload of an adjustment of a user's balance awaiting approval.
+type BalanceAdjustmentApproval struct {
+	UserID  uuid.UUID                `json:"user_id"`
+	Request BalanceAdjustmentRequest `json:"request"`
+}
+
+// RoleChangeApproval is the payload of a user update changing the user's role awaiting approval.
+type RoleChangeApproval struct {
+	UserID  uuid.UUID         `json:"user_id"`
+	Request UpdateUserRequest `json:"request"`
+}
+
+// ApprovalFilter represents filters for listing approvals.
+type ApprovalFilter struct {
+	Status *ApprovalStatus `json:"status,omitempty"`
+	Action *ApprovalAction `json:"action,omitempty"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// ReviewApprovalRequest represents a note an admin may leave when approving or rejecting.
+type ReviewApprovalRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// Validate validates the review request.
+func (r *ReviewApprovalRequest) Validate() error {
+	if len(strings.TrimSpace(r.Note)) > 500 {
+		return fmt.Errorf("note: note must be at most 500 characters")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MonthStart returns the first instant of the UTC month containing t. Partitions and archival
+// cutoffs are aligned to UTC months.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// PartitionName returns the name of a table's partition for the month containing month,
+// e.g. events_y2024m01.
+func PartitionName(table string, month time.Time) string {
+	month = MonthStart(month)
+	return fmt.Sprintf("%s_y%04dm%02d", table, month.Year(), int(month.Month()))
+}
+
+// ParsePartitionMonth returns the month of a partition named by PartitionName for table,
+// and false for any other name, such as the default partition's.
+func ParsePartitionMonth(table, name string) (time.Time, bool) {
+	var year, month int
+	var rest string
+	n, _ := fmt.Sscanf(name, table+"_y%04dm%02d%s", &year, &month, &rest)
+	if n != 2 || month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+
+	parsed := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if PartitionName(table, parsed) != name {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// ArchiveCutoff returns the instant before which events and transactions are archived: the start
+// of the month afterMonths months before the one containing now. Zero months archives nothing.
+func ArchiveCutoff(now time.Time, afterMonths int) (time.Time, bool) {
+	if afterMonths <= 0 {
+		return time.Time{}, false
+	}
+	return MonthStart(now).AddDate(0, -afterMonths, 0), true
+}
+
+// ArchiveRun summarizes one run of partition maintenance and archival.
+type ArchiveRun struct {
+	PartitionsCreated             []string   `json:"partitions_created"`              // Monthly partitions added to the live tables
+	EventPartitionsArchived       []string   `json:"event_partitions_archived"`       // Event partitions moved into the archive schema
+	TransactionPartitionsArchived []string   `json:"transaction_partitions_archived"` // Transaction partitions moved into the archive schema
+	Cutoff                        *time.Time `json:"cutoff,omitempty"`                // Rows created before it were archived; nil when archival is off
+}
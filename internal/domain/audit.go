@@ -27,6 +27,18 @@ const (
 	EntityTransaction EntityType = "transaction"
 	// EntityBalance represents balance entity type for audit logs
 	EntityBalance EntityType = "balance"
+	// EntityHTTPRequest represents a recorded HTTP request/response exchange, keyed by request ID
+	EntityHTTPRequest EntityType = "http_request"
+	// EntityTreasury represents a treasury account entity type for audit logs, keyed by account ID
+	EntityTreasury EntityType = "treasury"
+	// EntityCurrency represents a currency of the registry for audit logs, keyed by the ID of its treasury account
+	EntityCurrency EntityType = "currency"
+	// EntityImport represents a bulk import of users for audit logs, keyed by import ID
+	EntityImport EntityType = "import"
+	// EntityApproval represents an admin operation in the four-eyes queue for audit logs, keyed by approval ID
+	EntityApproval EntityType = "approval"
+	// EntityPolicy represents a version of the runtime policy for audit logs, keyed by version ID
+	EntityPolicy EntityType = "policy"
 )
 
 // AuditAction defines common audit actions.
@@ -45,6 +57,16 @@ const (
 	ActionFailed AuditAction = "failed"
 	// ActionRolledBack represents rolled back action for audit logs
 	ActionRolledBack AuditAction = "rolled_back"
+	// ActionRecorded represents a recorded HTTP exchange for audit logs
+	ActionRecorded AuditAction = "recorded"
+	// ActionMinted represents money minted into a treasury for audit logs
+	ActionMinted AuditAction = "minted"
+	// ActionBurned represents money burned from a treasury for audit logs
+	ActionBurned AuditAction = "burned"
+	// ActionApproved represents an admin operation approved by a second admin for audit logs
+	ActionApproved AuditAction = "approved"
+	// ActionRejected represents an admin operation rejected by an admin for audit logs
+	ActionRejected AuditAction = "rejected"
 )
 
 // CreateAuditLogRequest represents the data needed to create an audit log.
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// eventBackupKeyTime formats the creation time of an export's first event in its object key.
+// The fixed width keeps keys in creation order when sorted lexically.
+const eventBackupKeyTime = "20060102T150405.000000000Z"
+
+// EventBackupKey returns the object key of an event export whose first event was created at
+// first, e.g. backups/events/2024/01/15/20240115T103000.123456000Z.ndjson.gz.
+func EventBackupKey(prefix string, first time.Time) string {
+	first = first.UTC()
+	return path.Join(EventBackupPrefix(prefix), first.Format("2006/01/02"), first.Format(eventBackupKeyTime)+".ndjson.gz")
+}
+
+// EventBackupPrefix returns the key prefix under which event exports are stored.
+func EventBackupPrefix(prefix string) string {
+	return path.Join(strings.Trim(prefix, "/"), "events") + "/"
+}
+
+// EventBackupCheckpointKey returns the key of the checkpoint recording how far events were exported.
+func EventBackupCheckpointKey(prefix string) string {
+	return path.Join(strings.Trim(prefix, "/"), "checkpoint.json")
+}
+
+// EventBackupCheckpoint records the last exported event. Exports continue with the events created
+// after it.
+type EventBackupCheckpoint struct {
+	LastCreatedAt time.Time `json:"last_created_at"`
+	LastEventID   uuid.UUID `json:"last_event_id"`
+	LastObject    string    `json:"last_object"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// EventExportRun summarizes one export of new events.
+type EventExportRun struct {
+	Objects []string   `json:"objects"` // Keys of the objects written
+	Events  int        `json:"events"`
+	Through *time.Time `json:"through,omitempty"` // Creation time of the last exported event
+}
+
+// EventRestoreRun summarizes a restore of exported events.
+type EventRestoreRun struct {
+	Objects        int `json:"objects"`
+	EventsRead     int `json:"events_read"`
+	EventsImported int `json:"events_imported"` // Read events the event store did not hold yet
+}
+
+// ToEvent converts an EventResponse back to the Event it was made from.
+func (r *EventResponse) ToEvent() *Event {
+	event := &Event{
+		ID:            r.ID,
+		AggregateType: r.AggregateType,
+		AggregateID:   r.AggregateID,
+		EventType:     r.EventType,
+		EventData:     []byte(r.Data),
+		CreatedAt:     r.CreatedAt,
+		Version:       r.Version,
+		EventVersion:  r.EventVersion,
+	}
+	if len(r.Metadata) > 0 {
+		event.EventMetadata = []byte(r.Metadata)
+	}
+	return event
+}
@@ -60,31 +60,12 @@ const (
 	CurrencyAUD Currency = "AUD"
 )
 
-// SupportedCurrencies returns all supported currency codes
-func SupportedCurrencies() []Currency {
-	return []Currency{
-		CurrencyUSD, CurrencyEUR, CurrencyGBP,
-		CurrencyJPY, CurrencyCAD, CurrencyAUD,
-	}
-}
-
-// IsValidCurrency checks if a currency code is supported
-func IsValidCurrency(currency string) bool {
-	supported := SupportedCurrencies()
-	for _, c := range supported {
-		if string(c) == currency {
-			return true
-		}
-	}
-	return false
-}
-
 // Validate validates the balance data including currency
 func (b *Balance) Validate() error {
 	if err := validateAmount(b.Amount); err != nil {
 		return err
 	}
-	if !IsValidCurrency(b.Currency) {
+	if !IsKnownCurrency(b.Currency) {
 		return fmt.Errorf("unsupported currency: %s", b.Currency)
 	}
 	return nil
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LowBalanceRearmRatio is how far above its threshold a balance must recover before a triggered
+// low-balance alert can fire again. It keeps a balance hovering around the threshold from
+// alerting on every debit.
+const LowBalanceRearmRatio = 0.1
+
+// BalanceAlert represents a user's low-balance alert threshold for one currency.
+type BalanceAlert struct {
+	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
+	Currency        string     `json:"currency" db:"currency"`
+	Threshold       float64    `json:"threshold" db:"threshold"`
+	Triggered       bool       `json:"triggered" db:"triggered"` // Alerted and waiting for the balance to recover
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty" db:"last_triggered_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// BalanceAlertTransition is the change a balance movement causes to an alert.
+type BalanceAlertTransition int
+
+const (
+	// BalanceAlertUnchanged leaves the alert as it is
+	BalanceAlertUnchanged BalanceAlertTransition = iota
+	// BalanceAlertTriggered fires the alert
+	BalanceAlertTriggered
+	// BalanceAlertRearmed lets a triggered alert fire again
+	BalanceAlertRearmed
+)
+
+// RearmLevel returns the balance at or above which a triggered alert re-arms, rounded to cents.
+func (a *BalanceAlert) RearmLevel() float64 {
+	return roundCents(a.Threshold * (1 + LowBalanceRearmRatio))
+}
+
+// Evaluate returns how a balance movement to balance changes the alert. Only a debit can fire
+// an armed alert, when it leaves the balance below the threshold; any movement that brings the
+// balance back to the re-arm level re-arms a triggered alert.
+func (a *BalanceAlert) Evaluate(balance float64, debited bool) BalanceAlertTransition {
+	switch {
+	case !a.Triggered && debited && balance < a.Threshold:
+		return BalanceAlertTriggered
+	case a.Triggered && balance >= a.RearmLevel():
+		return BalanceAlertRearmed
+	default:
+		return BalanceAlertUnchanged
+	}
+}
+
+// SetBalanceAlertRequest represents the threshold of a low-balance alert.
+type SetBalanceAlertRequest struct {
+	Threshold float64 `json:"threshold"`
+}
+
+// Validate validates the set balance alert request.
+func (r *SetBalanceAlertRequest) Validate() error {
+	if err := validateTransactionAmount(r.Threshold); err != nil {
+		return fmt.Errorf("threshold: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimelineInterval is the spacing of the points of a balance timeline.
+type TimelineInterval string
+
+const (
+	// TimelineHour spaces points an hour apart
+	TimelineHour TimelineInterval = "hour"
+	// TimelineDay spaces points a day apart
+	TimelineDay TimelineInterval = "day"
+	// TimelineWeek spaces points a week apart
+	TimelineWeek TimelineInterval = "week"
+	// TimelineMonth spaces points a calendar month apart
+	TimelineMonth TimelineInterval = "month"
+)
+
+// Balance timelines cover 30 days unless asked otherwise, and have at most MaxTimelinePoints points.
+const (
+	DefaultTimelineRange = 30 * 24 * time.Hour
+	MaxTimelinePoints    = 1000
+)
+
+// TimelineIntervals lists the intervals a balance timeline may be requested at.
+var TimelineIntervals = []string{string(TimelineHour), string(TimelineDay), string(TimelineWeek), string(TimelineMonth)}
+
+// after returns the time n intervals after t. Counting from t rather than stepping keeps monthly
+// points on the same day of the month after a short month.
+func (i TimelineInterval) after(t time.Time, n int) time.Time {
+	switch i {
+	case TimelineHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case TimelineWeek:
+		return t.AddDate(0, 0, 7*n)
+	case TimelineMonth:
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// BalancePoint is the balance at the end of an instant: every transaction up to and including it
+// has been applied.
+type BalancePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Amount    float64   `json:"amount"`
+}
+
+// BalanceTimeline is a series of balance points, earliest first, for drawing balance-over-time
+// charts. Timelines are computed from transactions on request and never stored.
+type BalanceTimeline struct {
+	UserID   uuid.UUID        `json:"user_id"`
+	Currency string           `json:"currency"`
+	From     time.Time        `json:"from"`
+	To       time.Time        `json:"to"`
+	Interval TimelineInterval `json:"interval"`
+	Points   []BalancePoint   `json:"points"`
+}
+
+// TimelinePoints returns the instants of a balance timeline: from, then every interval after it
+// before to, then to itself, so the last point is always the balance at the end of the range.
+func TimelinePoints(from, to time.Time, interval TimelineInterval) ([]time.Time, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("to must be after from")
+	}
+
+	var points []time.Time
+	for n := 0; interval.after(from, n).Before(to); n++ {
+		if len(points) == MaxTimelinePoints-1 {
+			return nil, fmt.Errorf("range holds more than %d %s points; choose a shorter range or a longer interval", MaxTimelinePoints, interval)
+		}
+		points = append(points, interval.after(from, n))
+	}
+
+	return append(points, to), nil
+}
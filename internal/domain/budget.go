@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionCategory classifies spending so it can be tracked against budgets.
+type TransactionCategory string
+
+// Spending categories debits and transfers may be tagged with.
+const (
+	CategoryGroceries     TransactionCategory = "groceries"
+	CategoryDining        TransactionCategory = "dining"
+	CategoryTransport     TransactionCategory = "transport"
+	CategoryHousing       TransactionCategory = "housing"
+	CategoryUtilities     TransactionCategory = "utilities"
+	CategoryHealth        TransactionCategory = "health"
+	CategoryEntertainment TransactionCategory = "entertainment"
+	CategoryShopping      TransactionCategory = "shopping"
+	CategoryTravel        TransactionCategory = "travel"
+	CategoryEducation     TransactionCategory = "education"
+	CategoryOther         TransactionCategory = "other"
+)
+
+// TransactionCategories lists the categories spending may be tagged with.
+var TransactionCategories = []string{
+	string(CategoryGroceries), string(CategoryDining), string(CategoryTransport), string(CategoryHousing),
+	string(CategoryUtilities), string(CategoryHealth), string(CategoryEntertainment), string(CategoryShopping),
+	string(CategoryTravel), string(CategoryEducation), string(CategoryOther),
+}
+
+// IsTransactionCategory reports whether category is a known spending category.
+func IsTransactionCategory(category string) bool {
+	return slices.Contains(TransactionCategories, category)
+}
+
+// validateTransactionCategory validates an optional transaction category.
+func validateTransactionCategory(category string) error {
+	if category != "" && !IsTransactionCategory(category) {
+		return fmt.Errorf("category must be one of %s, got %q", strings.Join(TransactionCategories, ", "), category)
+	}
+	return nil
+}
+
+// Budget is a user's monthly spending limit for a category, in the currency of their balance.
+type Budget struct {
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Category     string    `json:"category" db:"category"`
+	Limit        float64   `json:"limit" db:"limit_amount"`
+	AlertedMonth string    `json:"alerted_month,omitempty" db:"alerted_month"` // The last yyyy-mm month the user was told they went over
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SetBudgetRequest represents the monthly limit of a budget.
+type SetBudgetRequest struct {
+	Limit float64 `json:"limit"`
+}
+
+// Validate validates the set budget request.
+func (r *SetBudgetRequest) Validate() error {
+	if err := validateTransactionAmount(r.Limit); err != nil {
+		return fmt.Errorf("limit: %w", err)
+	}
+
+	return nil
+}
+
+// CategorySpending is what a user spent in a category during a month, against its budget if
+// they set one.
+type CategorySpending struct {
+	Category    string   `json:"category"` // Empty for uncategorized spending
+	Spent       float64  `json:"spent"`
+	Limit       *float64 `json:"limit,omitempty"`
+	Remaining   *float64 `json:"remaining,omitempty"`    // Negative once over budget
+	PercentUsed *float64 `json:"percent_used,omitempty"` // Of the limit
+	OverBudget  bool     `json:"over_budget"`
+}
+
+// SpendingReport is a user's spending of a month by category. Spending is successful debits and
+// outgoing transfers, less what was rolled back of them.
+type SpendingReport struct {
+	UserID     uuid.UUID          `json:"user_id"`
+	Month      string             `json:"month"` // yyyy-mm
+	Currency   string             `json:"currency"`
+	Total      float64            `json:"total"`
+	Categories []CategorySpending `json:"categories"` // Budgeted categories first, then by amount spent
+}
+
+// NewCategorySpending measures spending in a category against its budget, if any.
+func NewCategorySpending(category string, spent float64, budget *Budget, currency string) CategorySpending {
+	spending := CategorySpending{Category: category, Spent: RoundAmount(spent, currency)}
+	if budget == nil {
+		return spending
+	}
+
+	limit := budget.Limit
+	remaining := RoundAmount(limit-spending.Spent, currency)
+	percent := roundCents(spending.Spent / limit * 100)
+	spending.Limit, spending.Remaining, spending.PercentUsed = &limit, &remaining, &percent
+	spending.OverBudget = spending.Spent > limit
+	return spending
+}
+
+// BuildSpendingReport reports a month's spending by category against the user's budgets.
+// Budgets without spending are listed with nothing spent.
+func BuildSpendingReport(userID uuid.UUID, month, currency string, spent map[string]float64, budgets []*Budget) *SpendingReport {
+	report := &SpendingReport{UserID: userID, Month: month, Currency: currency, Categories: []CategorySpending{}}
+
+	budgeted := make(map[string]bool, len(budgets))
+	for _, budget := range budgets {
+		budgeted[budget.Category] = true
+		report.Categories = append(report.Categories, NewCategorySpending(budget.Category, spent[budget.Category], budget, currency))
+	}
+
+	var unbudgeted []CategorySpending
+	for category, amount := range spent {
+		report.Total += amount
+		if !budgeted[category] {
+			unbudgeted = append(unbudgeted, NewCategorySpending(category, amount, nil, currency))
+		}
+	}
+	slices.SortFunc(unbudgeted, func(a, b CategorySpending) int {
+		if a.Spent != b.Spent {
+			if a.Spent > b.Spent {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Category, b.Category)
+	})
+
+	report.Categories = append(report.Categories, unbudgeted...)
+	report.Total = RoundAmount(report.Total, currency)
+	return report
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Business day rules decide when a scheduled occurrence falling on a weekend or holiday executes.
+const (
+	BusinessDayNone      = "none"      // Execute on the day itself
+	BusinessDayFollowing = "following" // Execute on the next business day
+	BusinessDayPreceding = "preceding" // Execute on the previous business day
+)
+
+// HolidayDateLayout is the layout of holiday dates in calendar configuration and responses.
+const HolidayDateLayout = "2006-01-02"
+
+// MaxBusinessDayShift bounds how many days a business day rule may move an occurrence. An
+// occurrence with no business day within it executes on the day itself.
+const MaxBusinessDayShift = 14
+
+// IsValidBusinessDayRule reports whether rule is a supported business day rule.
+func IsValidBusinessDayRule(rule string) bool {
+	return rule == BusinessDayNone || rule == BusinessDayFollowing || rule == BusinessDayPreceding
+}
+
+// BusinessCalendar knows the non-business days of each region: Saturdays and Sundays everywhere,
+// plus the holidays configured for the region. Days are UTC calendar days.
+type BusinessCalendar struct {
+	defaultRegion string
+	holidays      map[string]map[string]bool // Region to holiday dates in HolidayDateLayout
+}
+
+// NewBusinessCalendar creates a calendar from holiday dates per region. The default region is
+// used for schedules without a region of their own and is known even without holidays.
+func NewBusinessCalendar(defaultRegion string, holidays map[string][]string) (*BusinessCalendar, error) {
+	c := &BusinessCalendar{
+		defaultRegion: defaultRegion,
+		holidays:      map[string]map[string]bool{defaultRegion: {}},
+	}
+	for region, dates := range holidays {
+		if c.holidays[region] == nil {
+			c.holidays[region] = make(map[string]bool, len(dates))
+		}
+		for _, date := range dates {
+			day, err := time.Parse(HolidayDateLayout, date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid holiday %q in region %s: expected YYYY-MM-DD", date, region)
+			}
+			c.holidays[region][day.Format(HolidayDateLayout)] = true
+		}
+	}
+	return c, nil
+}
+
+// DefaultRegion returns the region used for schedules without a region of their own.
+func (c *BusinessCalendar) DefaultRegion() string {
+	return c.defaultRegion
+}
+
+// HasRegion reports whether the calendar knows a region. The empty region is the default one.
+func (c *BusinessCalendar) HasRegion(region string) bool {
+	_, ok := c.holidays[c.region(region)]
+	return ok
+}
+
+// Regions lists the known regions in alphabetical order.
+func (c *BusinessCalendar) Regions() []string {
+	regions := make([]string, 0, len(c.holidays))
+	for region := range c.holidays {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// Holidays lists the holiday dates of a region, earliest first.
+func (c *BusinessCalendar) Holidays(region string) []string {
+	dates := make([]string, 0, len(c.holidays[c.region(region)]))
+	for date := range c.holidays[c.region(region)] {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// IsBusinessDay reports whether t falls on a weekday that is not a holiday of the region.
+func (c *BusinessCalendar) IsBusinessDay(t time.Time, region string) bool {
+	day := t.UTC()
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[c.region(region)][day.Format(HolidayDateLayout)]
+}
+
+// Adjust moves t to the next or previous business day of the region according to rule, keeping
+// its time of day. Business days, the none rule and a nil calendar leave t as it is.
+func (c *BusinessCalendar) Adjust(t time.Time, rule, region string) time.Time {
+	step := 0
+	switch rule {
+	case BusinessDayFollowing:
+		step = 1
+	case BusinessDayPreceding:
+		step = -1
+	}
+	if c == nil || step == 0 || c.IsBusinessDay(t, region) {
+		return t
+	}
+
+	for shift := 1; shift <= MaxBusinessDayShift; shift++ {
+		if adjusted := t.AddDate(0, 0, step*shift); c.IsBusinessDay(adjusted, region) {
+			return adjusted
+		}
+	}
+	return t
+}
+
+// region resolves the empty region to the default one.
+func (c *BusinessCalendar) region(region string) string {
+	if region == "" {
+		return c.defaultRegion
+	}
+	return region
+}
+
+// CalendarRegion describes the non-business days of one region.
+type CalendarRegion struct {
+	Region      string   `json:"region"`
+	IsDefault   bool     `json:"is_default"`
+	WeekendDays []string `json:"weekend_days"`
+	Holidays    []string `json:"holidays"` // Dates in HolidayDateLayout, earliest first
+}
+
+// Region describes a known region of the calendar.
+func (c *BusinessCalendar) Region(region string) (*CalendarRegion, error) {
+	if !c.HasRegion(region) {
+		return nil, fmt.Errorf("unknown calendar region: %s", region)
+	}
+	return &CalendarRegion{
+		Region:      c.region(region),
+		IsDefault:   c.region(region) == c.defaultRegion,
+		WeekendDays: []string{"saturday", "sunday"},
+		Holidays:    c.Holidays(region),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxClockAdvance is the most the simulation clock is fast-forwarded by at once.
+const MaxClockAdvance = 400 * 24 * time.Hour
+
+// ClockState is the time the simulation clock shows.
+type ClockState struct {
+	Now    time.Time `json:"now"`
+	Offset string    `json:"offset"` // How far the clock runs ahead of the wall clock, e.g. "720h0m0s"
+}
+
+// ClockAdvanceRequest represents a request to fast-forward the simulation clock.
+type ClockAdvanceRequest struct {
+	Duration string `json:"duration"` // A duration such as "36h", or a number of days such as "30d"
+}
+
+// Validate validates the clock advance request.
+func (r *ClockAdvanceRequest) Validate() error {
+	if _, err := ParseClockAdvance(r.Duration); err != nil {
+		return fmt.Errorf("duration: %w", err)
+	}
+	return nil
+}
+
+// ParseClockAdvance parses how far to fast-forward the simulation clock: a duration such as
+// "36h", or a number of days such as "30d".
+func ParseClockAdvance(value string) (time.Duration, error) {
+	advance, err := time.ParseDuration(value)
+	if err != nil {
+		days, convErr := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if !strings.HasSuffix(value, "d") || convErr != nil {
+			return 0, fmt.Errorf("duration must be a duration such as 36h or a number of days such as 30d, got %q", value)
+		}
+		advance = time.Duration(days) * 24 * time.Hour
+	}
+	if advance <= 0 || advance > MaxClockAdvance {
+		return 0, fmt.Errorf("duration must be greater than 0 and at most %d days, got %s", MaxClockAdvance/(24*time.Hour), advance)
+	}
+	return advance, nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Contact is a counterparty a user saved to send money to again.
+type Contact struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	UserID        uuid.UUID `json:"user_id" db:"user_id"`
+	ContactUserID uuid.UUID `json:"contact_user_id" db:"contact_user_id"`
+	Nickname      string    `json:"nickname" db:"nickname"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateContactRequest represents the data needed to save a contact. The counterparty is given
+// by user ID or by account number.
+type CreateContactRequest struct {
+	ContactUserID uuid.UUID `json:"contact_user_id"`
+	AccountNumber string    `json:"account_number,omitempty"`
+	Nickname      string    `json:"nickname"`
+}
+
+// Validate validates the create contact request.
+func (r *CreateContactRequest) Validate() error {
+	if r.ContactUserID == uuid.Nil && strings.TrimSpace(r.AccountNumber) == "" {
+		return fmt.Errorf("contact_user_id or account_number is required")
+	}
+
+	if len(strings.TrimSpace(r.Nickname)) > 100 {
+		return fmt.Errorf("nickname: nickname must be at most 100 characters")
+	}
+
+	return nil
+}
+
+// Counterparty summarizes the successful transfers a user sent to another user.
+type Counterparty struct {
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	TransferCount  int       `json:"transfer_count" db:"transfer_count"`
+	LastTransferAt time.Time `json:"last_transfer_at" db:"last_transfer_at"`
+}
+
+// ContactEntry is a user the caller can send money to: a saved contact, a recent counterparty
+// or both.
+type ContactEntry struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	Username       string     `json:"username"`
+	AccountNumber  string     `json:"account_number"`
+	Saved          bool       `json:"saved"`
+	ContactID      *uuid.UUID `json:"contact_id,omitempty"` // Set for saved contacts
+	Nickname       string     `json:"nickname,omitempty"`
+	TransferCount  int        `json:"transfer_count"`
+	LastTransferAt *time.Time `json:"last_transfer_at,omitempty"`
+}
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCurrencyDecimalPlaces is the most decimal places a currency may have; amounts are stored in cents.
+const MaxCurrencyDecimalPlaces = 2
+
+// currencyCodePattern matches ISO 4217 style currency codes.
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// CurrencyInfo describes a currency of the registry. Disabled currencies stay known, so balances
+// and transactions already held in them keep working, but new requests in them are rejected.
+type CurrencyInfo struct {
+	Code          string    `json:"code"`
+	Symbol        string    `json:"symbol"`
+	DecimalPlaces int       `json:"decimal_places"` // Digits allowed after the decimal point, e.g. 0 for JPY
+	Enabled       bool      `json:"enabled"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// DefaultCurrencies returns the currencies a new registry starts with.
+func DefaultCurrencies() []CurrencyInfo {
+	return []CurrencyInfo{
+		{Code: string(CurrencyUSD), Symbol: "$", DecimalPlaces: 2, Enabled: true},
+		{Code: string(CurrencyEUR), Symbol: "€", DecimalPlaces: 2, Enabled: true},
+		{Code: string(CurrencyGBP), Symbol: "£", DecimalPlaces: 2, Enabled: true},
+		{Code: string(CurrencyJPY), Symbol: "¥", DecimalPlaces: 0, Enabled: true},
+		{Code: string(CurrencyCAD), Symbol: "CA$", DecimalPlaces: 2, Enabled: true},
+		{Code: string(CurrencyAUD), Symbol: "A$", DecimalPlaces: 2, Enabled: true},
+	}
+}
+
+// currencyRegistry holds the currencies validation and formatting consult. It starts with the
+// defaults and is replaced with the persisted registry by SetCurrencies.
+var currencyRegistry atomic.Pointer[[]CurrencyInfo]
+
+func init() {
+	SetCurrencies(DefaultCurrencies())
+}
+
+// SetCurrencies replaces the currencies validation and formatting consult.
+func SetCurrencies(currencies []CurrencyInfo) {
+	snapshot := append([]CurrencyInfo(nil), currencies...)
+	currencyRegistry.Store(&snapshot)
+}
+
+// Currencies returns every currency of the registry, disabled ones included.
+func Currencies() []CurrencyInfo {
+	return append([]CurrencyInfo(nil), *currencyRegistry.Load()...)
+}
+
+// LookupCurrency returns the registry entry of a currency code, enabled or not.
+func LookupCurrency(code string) (CurrencyInfo, bool) {
+	for _, currency := range *currencyRegistry.Load() {
+		if currency.Code == code {
+			return currency, true
+		}
+	}
+	return CurrencyInfo{}, false
+}
+
+// SupportedCurrencies returns the codes of the enabled currencies
+func SupportedCurrencies() []Currency {
+	var codes []Currency
+	for _, currency := range *currencyRegistry.Load() {
+		if currency.Enabled {
+			codes = append(codes, Currency(currency.Code))
+		}
+	}
+	return codes
+}
+
+// IsValidCurrency checks if a currency code is enabled in the registry
+func IsValidCurrency(currency string) bool {
+	info, ok := LookupCurrency(currency)
+	return ok && info.Enabled
+}
+
+// IsKnownCurrency checks if a currency code is in the registry, enabled or not
+func IsKnownCurrency(currency string) bool {
+	_, ok := LookupCurrency(currency)
+	return ok
+}
+
+// ValidateCurrencyAmount checks that an amount has no more decimal places than its currency
+// allows, e.g. none for JPY. Decimal places are counted on the shortest representation of the
+// amount, which for an amount decoded from JSON is the number as the client wrote it. Unknown
+// currencies are left to the currency check.
+func ValidateCurrencyAmount(currency string, amount float64) error {
+	info, ok := LookupCurrency(currency)
+	if !ok {
+		return nil
+	}
+
+	digits := strconv.FormatFloat(math.Abs(amount), 'f', -1, 64)
+	if point := strings.IndexByte(digits, '.'); point >= 0 && len(digits)-point-1 > info.DecimalPlaces {
+		return decimalPlacesError(currency, info.DecimalPlaces)
+	}
+
+	return nil
+}
+
+// decimalPlacesError reports an amount with more decimal places than its currency allows.
+func decimalPlacesError(currency string, places int) error {
+	if currency == "" {
+		return fmt.Errorf("amounts cannot have more than %d decimal places", places)
+	}
+	if places == 0 {
+		return fmt.Errorf("%s amounts cannot have decimal places", currency)
+	}
+	return fmt.Errorf("%s amounts cannot have more than %d decimal places", currency, places)
+}
+
+// currencyDecimalPlaces returns the decimal places of a currency; unknown currencies get two.
+func currencyDecimalPlaces(currency string) int {
+	if info, ok := LookupCurrency(currency); ok {
+		return info.DecimalPlaces
+	}
+	return MaxCurrencyDecimalPlaces
+}
+
+// RoundAmount rounds an amount to its currency's decimal places; unknown currencies get two.
+func RoundAmount(amount float64, currency string) float64 {
+	scale := math.Pow10(currencyDecimalPlaces(currency))
+	return math.Round(amount*scale) / scale
+}
+
+// CreateCurrencyRequest adds a currency to the registry. Codes are upper case.
+type CreateCurrencyRequest struct {
+	Code          string `json:"code"`
+	Symbol        string `json:"symbol"`
+	DecimalPlaces *int   `json:"decimal_places"`
+	Enabled       *bool  `json:"enabled,omitempty"` // Defaults to true
+}
+
+// Validate validates a create currency request.
+func (r *CreateCurrencyRequest) Validate() error {
+	if !currencyCodePattern.MatchString(r.Code) {
+		return fmt.Errorf("code: code must be three upper-case letters")
+	}
+
+	if err := validateCurrencySymbol(r.Symbol); err != nil {
+		return err
+	}
+
+	if r.DecimalPlaces == nil {
+		return fmt.Errorf("decimal_places: decimal_places is required")
+	}
+	return validateCurrencyDecimalPlaces(*r.DecimalPlaces)
+}
+
+// UpdateCurrencyRequest changes a currency of the registry. Missing fields are left unchanged.
+type UpdateCurrencyRequest struct {
+	Symbol        *string `json:"symbol,omitempty"`
+	DecimalPlaces *int    `json:"decimal_places,omitempty"`
+	Enabled       *bool   `json:"enabled,omitempty"`
+}
+
+// Validate validates an update currency request.
+func (r *UpdateCurrencyRequest) Validate() error {
+	if r.Symbol == nil && r.DecimalPlaces == nil && r.Enabled == nil {
+		return fmt.Errorf("symbol, decimal_places or enabled is required")
+	}
+
+	if r.Symbol != nil {
+		if err := validateCurrencySymbol(*r.Symbol); err != nil {
+			return err
+		}
+	}
+
+	if r.DecimalPlaces != nil {
+		return validateCurrencyDecimalPlaces(*r.DecimalPlaces)
+	}
+
+	return nil
+}
+
+// Apply applies the request's changes to a currency.
+func (r *UpdateCurrencyRequest) Apply(currency *CurrencyInfo) {
+	if r.Symbol != nil {
+		currency.Symbol = strings.TrimSpace(*r.Symbol)
+	}
+	if r.DecimalPlaces != nil {
+		currency.DecimalPlaces = *r.DecimalPlaces
+	}
+	if r.Enabled != nil {
+		currency.Enabled = *r.Enabled
+	}
+}
+
+// validateCurrencySymbol validates a currency symbol.
+func validateCurrencySymbol(symbol string) error {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return fmt.Errorf("symbol: symbol is required")
+	}
+	if utf8.RuneCountInString(symbol) > 8 {
+		return fmt.Errorf("symbol: symbol must be at most 8 characters")
+	}
+	return nil
+}
+
+// validateCurrencyDecimalPlaces validates the decimal places of a currency.
+func validateCurrencyDecimalPlaces(places int) error {
+	if places < 0 || places > MaxCurrencyDecimalPlaces {
+		return fmt.Errorf("decimal_places: decimal_places must be between 0 and %d", MaxCurrencyDecimalPlaces)
+	}
+	return nil
+}
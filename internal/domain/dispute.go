@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dispute represents a user's dispute of a completed transaction.
+type Dispute struct {
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	TransactionID       uuid.UUID  `json:"transaction_id" db:"transaction_id"`
+	UserID              uuid.UUID  `json:"user_id" db:"user_id"`
+	Reason              string     `json:"reason" db:"reason"`
+	Status              string     `json:"status" db:"status"`
+	Resolution          string     `json:"resolution,omitempty" db:"resolution"`
+	ResolvedBy          *uuid.UUID `json:"resolved_by,omitempty" db:"resolved_by"`
+	RefundTransactionID *uuid.UUID `json:"refund_transaction_id,omitempty" db:"refund_transaction_id"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+	ResolvedAt          *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// DisputeStatus defines valid dispute statuses.
+type DisputeStatus string
+
+const (
+	// DisputeStatusOpen represents a newly opened dispute
+	DisputeStatusOpen DisputeStatus = "open"
+	// DisputeStatusUnderReview represents a dispute an admin has started reviewing
+	DisputeStatusUnderReview DisputeStatus = "under_review"
+	// DisputeStatusRefunded represents a dispute resolved by refunding the transaction
+	DisputeStatusRefunded DisputeStatus = "refunded"
+	// DisputeStatusRejected represents a dispute rejected by an admin
+	DisputeStatusRejected DisputeStatus = "rejected"
+)
+
+// DisputeResolution defines the actions an admin can take to resolve a dispute.
+type DisputeResolution string
+
+const (
+	// ResolutionRefund resolves a dispute by rolling back the transaction
+	ResolutionRefund DisputeResolution = "refund"
+	// ResolutionReject resolves a dispute without changing the transaction
+	ResolutionReject DisputeResolution = "reject"
+)
+
+// IsClosed reports whether the dispute has been resolved.
+func (d *Dispute) IsClosed() bool {
+	return d.Status == string(DisputeStatusRefunded) || d.Status == string(DisputeStatusRejected)
+}
+
+// DisputeComment represents a comment on a dispute by the user or an admin.
+type DisputeComment struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	DisputeID uuid.UUID `json:"dispute_id" db:"dispute_id"`
+	AuthorID  uuid.UUID `json:"author_id" db:"author_id"`
+	IsAdmin   bool      `json:"is_admin" db:"is_admin"`
+	Body      string    `json:"body" db:"body"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OpenDisputeRequest represents the data needed to open a dispute.
+type OpenDisputeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate validates the open dispute request.
+func (r *OpenDisputeRequest) Validate() error {
+	reason := strings.TrimSpace(r.Reason)
+	if reason == "" {
+		return fmt.Errorf("reason: reason is required")
+	}
+
+	if len(reason) > 1000 {
+		return fmt.Errorf("reason: reason must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// ResolveDisputeRequest represents an admin's resolution of a dispute.
+type ResolveDisputeRequest struct {
+	Action     string `json:"action"`
+	Resolution string `json:"resolution"`
+}
+
+// Validate validates the resolve dispute request.
+func (r *ResolveDisputeRequest) Validate() error {
+	if r.Action != string(ResolutionRefund) && r.Action != string(ResolutionReject) {
+		return fmt.Errorf("action: must be 'refund' or 'reject'")
+	}
+
+	if strings.TrimSpace(r.Resolution) == "" {
+		return fmt.Errorf("resolution: resolution is required")
+	}
+
+	if len(r.Resolution) > 1000 {
+		return fmt.Errorf("resolution: resolution must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// DisputeCommentRequest represents the data needed to comment on a dispute.
+type DisputeCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// Validate validates the dispute comment request.
+func (r *DisputeCommentRequest) Validate() error {
+	body := strings.TrimSpace(r.Body)
+	if body == "" {
+		return fmt.Errorf("body: body is required")
+	}
+
+	if len(body) > 2000 {
+		return fmt.Errorf("body: body must be at most 2000 characters")
+	}
+
+	return nil
+}
+
+// DisputeFilter represents filters for dispute queries.
+type DisputeFilter struct {
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Status *string    `json:"status,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+	Offset int        `json:"offset,omitempty"`
+}
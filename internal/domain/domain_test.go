@@ -1,7 +1,12 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -267,3 +272,2364 @@ func TestCreateUserRequestValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveDisputeRequestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request ResolveDisputeRequest
+		wantErr bool
+	}{
+		{
+			name:    "valid refund",
+			request: ResolveDisputeRequest{Action: "refund", Resolution: "Merchant confirmed duplicate charge"},
+			wantErr: false,
+		},
+		{
+			name:    "valid reject",
+			request: ResolveDisputeRequest{Action: "reject", Resolution: "Transaction was authorized"},
+			wantErr: false,
+		},
+		{
+			name:    "unknown action",
+			request: ResolveDisputeRequest{Action: "escalate", Resolution: "Needs more info"},
+			wantErr: true,
+		},
+		{
+			name:    "missing resolution",
+			request: ResolveDisputeRequest{Action: "refund", Resolution: "  "},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveDisputeRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionWithinRollbackWindow(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		want      bool
+	}{
+		{name: "just created", createdAt: now, want: true},
+		{name: "inside window", createdAt: now.Add(-23 * time.Hour), want: true},
+		{name: "outside window", createdAt: now.Add(-25 * time.Hour), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := Transaction{CreatedAt: tt.createdAt}
+			if got := tx.WithinRollbackWindow(DefaultRollbackWindow, now); got != tt.want {
+				t.Errorf("Transaction.WithinRollbackWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransactionToResponseReversal(t *testing.T) {
+	original := Transaction{ID: uuid.New(), Status: "success", CreatedAt: time.Now()}
+
+	if original.ToResponse().IsReversed {
+		t.Errorf("expected transaction without reversal to not be reversed")
+	}
+
+	reversalID := uuid.New()
+	original.ReversedByTransactionID = &reversalID
+
+	response := original.ToResponse()
+	if !response.IsReversed {
+		t.Errorf("expected transaction with reversal to be reversed")
+	}
+	if response.ReversedByTransactionID == nil || *response.ReversedByTransactionID != reversalID {
+		t.Errorf("ReversedByTransactionID mismatch: %v != %v", response.ReversedByTransactionID, reversalID)
+	}
+}
+
+func TestTransactionTypeRegistry(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+
+	credit := &Transaction{ID: uuid.New(), ToUserID: &alice, Amount: 30, Currency: "USD", Type: string(TypeCredit)}
+	reversal, spec, err := credit.Reversal(10)
+	if err != nil || spec.Type != TypeDebit || reversal.FromUserID != &alice || reversal.ToUserID != nil || *reversal.ReversalOfTransactionID != credit.ID {
+		t.Fatalf("credit reversal = %+v, %v, %v; want a debit from alice", reversal, spec.Type, err)
+	}
+	transfer := &Transaction{FromUserID: &alice, ToUserID: &bob, Amount: 30, Currency: "USD", Type: string(TypeTransfer), Status: string(StatusSuccess)}
+	if got := transfer.BalanceDelta(alice); got != -30 {
+		t.Errorf("sender's delta = %v, want -30", got)
+	}
+	if got := transfer.BalanceDelta(bob); got != 30 {
+		t.Errorf("receiver's delta = %v, want 30", got)
+	}
+	if !transfer.IsSpending() || credit.IsSpending() {
+		t.Errorf("IsSpending() = %v for the transfer, %v for the credit; want only the transfer", transfer.IsSpending(), credit.IsSpending())
+	}
+
+	// A new type plugs into validation, reversals and spending without further changes
+	if err := RegisterTransactionType(TransactionTypeSpec{Type: "test_fee", Settlement: SettlementRedeem, Reversal: TypeCredit, SenderEvent: EventAmountDebited}); err != nil {
+		t.Fatalf("RegisterTransactionType() = %v", err)
+	}
+	t.Cleanup(func() {
+		transactionTypes.Lock()
+		defer transactionTypes.Unlock()
+		transactionTypes.specs = slices.DeleteFunc(transactionTypes.specs, func(s TransactionTypeSpec) bool { return s.Type == "test_fee" })
+	})
+
+	fee := &Transaction{ID: uuid.New(), FromUserID: &alice, Amount: 2, Currency: "USD", Type: "test_fee", Status: string(StatusSuccess)}
+	if err := fee.Validate(); err != nil {
+		t.Errorf("fee Validate() = %v", err)
+	}
+	fee.ToUserID = &bob
+	if err := fee.Validate(); err == nil {
+		t.Error("fee with a receiver validated, want error")
+	}
+	fee.ToUserID = nil
+	if reversal, spec, err := fee.Reversal(2); err != nil || spec.Type != TypeCredit || reversal.ToUserID != &alice {
+		t.Errorf("fee reversal = %+v, %v, %v; want a credit to alice", reversal, spec.Type, err)
+	}
+	if fee.IsSpending() {
+		t.Error("fee counts as spending, want not")
+	}
+
+	for _, spec := range []TransactionTypeSpec{
+		{Type: "test_fee", Settlement: SettlementRedeem, Reversal: TypeCredit},
+		{Type: "Hold", Settlement: SettlementRedeem, Reversal: TypeCredit},
+		{Type: "hold", Settlement: "freeze", Reversal: TypeCredit},
+		{Type: "hold", Settlement: SettlementRedeem},
+	} {
+		if err := RegisterTransactionType(spec); err == nil {
+			t.Errorf("RegisterTransactionType(%+v) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestRollbackRequestValidation(t *testing.T) {
+	validAmount := 25.0
+	zeroAmount := 0.0
+
+	tests := []struct {
+		name    string
+		request RollbackRequest
+		wantErr bool
+	}{
+		{name: "full rollback", request: RollbackRequest{}, wantErr: false},
+		{name: "partial rollback", request: RollbackRequest{Amount: &validAmount}, wantErr: false},
+		{name: "zero amount", request: RollbackRequest{Amount: &zeroAmount}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RollbackRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionDescriptionValidation(t *testing.T) {
+	long := strings.Repeat("a", MaxTransactionDescriptionLength+1)
+	to := uuid.New()
+
+	tests := []struct {
+		name      string
+		validator interface{ Validate() error }
+		wantErr   bool
+	}{
+		{"credit with description", &CreditRequest{Amount: 10, Currency: "USD", Description: "Salary"}, false},
+		{"credit with long description", &CreditRequest{Amount: 10, Currency: "USD", Description: long}, true},
+		{"debit with long description", &DebitRequest{Amount: 10, Currency: "USD", Description: long}, true},
+		{"transfer with description", &TransferRequest{ToUserID: to, Amount: 10, Currency: "USD", Description: "Rent"}, false},
+		{"transfer with long description", &TransferRequest{ToUserID: to, Amount: 10, Currency: "USD", Description: long}, true},
+		{"scheduled update with long description", &ScheduledTransactionUpdateRequest{Description: &long}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validator.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientReferenceValidation(t *testing.T) {
+	to := uuid.New()
+
+	tests := []struct {
+		name      string
+		validator interface{ Validate() error }
+		wantErr   bool
+	}{
+		{"credit with reference", &CreditRequest{Amount: 10, Currency: "USD", ClientReference: "order-8812"}, false},
+		{"debit with reference", &DebitRequest{Amount: 10, Currency: "USD", ClientReference: "INV 2026/001"}, false},
+		{"transfer with reference", &TransferRequest{ToUserID: to, Amount: 10, Currency: "USD", ClientReference: "a1b2c3"}, false},
+		{"reference too long", &CreditRequest{Amount: 10, Currency: "USD", ClientReference: strings.Repeat("r", MaxClientReferenceLength+1)}, true},
+		{"reference with surrounding space", &DebitRequest{Amount: 10, Currency: "USD", ClientReference: " order-8812"}, true},
+		{"reference with control character", &TransferRequest{ToUserID: to, Amount: 10, Currency: "USD", ClientReference: "order\n8812"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validator.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionOrigin(t *testing.T) {
+	tx := &Transaction{ID: uuid.New()}
+	tx.SetOrigin(nil)
+	if tx.Origin() != nil || tx.ToResponse().Origin != nil {
+		t.Fatalf("direct transaction has origin %+v, want none", tx.Origin())
+	}
+
+	origin := &TransactionOrigin{ScheduledTransactionID: uuid.New(), ExecutionID: uuid.New()}
+	tx.SetOrigin(origin)
+	origin.ExecutionID = uuid.New() // The transaction keeps its own copy
+	if *tx.ScheduledTransactionID != origin.ScheduledTransactionID || *tx.ScheduledExecutionID == origin.ExecutionID {
+		t.Errorf("SetOrigin stored %v/%v", tx.ScheduledTransactionID, tx.ScheduledExecutionID)
+	}
+	if got := tx.ToResponse().Origin; got == nil || got.ScheduledTransactionID != origin.ScheduledTransactionID || got.ExecutionID != *tx.ScheduledExecutionID {
+		t.Errorf("response origin = %+v, want the scheduled execution", got)
+	}
+}
+
+func TestScheduledTransactionPauseResume(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	daily := "daily"
+
+	st := &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &daily, ExecuteAt: now.AddDate(0, 0, -3).Add(time.Hour), Status: "active", IsActive: true}
+	if err := st.Resume(now); err == nil {
+		t.Error("Resume() of an active transaction succeeded, want error")
+	}
+	if err := st.Pause(now); err != nil || st.Status != "paused" {
+		t.Fatalf("Pause() = %v, status %q; want paused", err, st.Status)
+	}
+	if err := st.Pause(now); err == nil {
+		t.Error("Pause() of a paused transaction succeeded, want error")
+	}
+
+	// Occurrences missed while paused are skipped
+	if err := st.Resume(now); err != nil || st.Status != "active" {
+		t.Fatalf("Resume() = %v, status %q; want active", err, st.Status)
+	}
+	if want := now.Add(time.Hour); !st.ExecuteAt.Equal(want) {
+		t.Errorf("ExecuteAt = %v, want %v", st.ExecuteAt, want)
+	}
+	if st.NextExecutionAt == nil || !st.NextExecutionAt.Equal(now.AddDate(0, 0, 1).Add(time.Hour)) {
+		t.Errorf("NextExecutionAt = %v, want the day after ExecuteAt", st.NextExecutionAt)
+	}
+
+	ended := now.Add(-time.Minute)
+	st = &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &daily, RecurrenceEndDate: &ended, ExecuteAt: now.AddDate(0, 0, -2), Status: "paused", IsActive: true}
+	if err := st.Resume(now); err == nil {
+		t.Error("Resume() past the recurrence end date succeeded, want error")
+	}
+
+	for _, status := range []string{"cancelled", "completed"} {
+		st := &ScheduledTransaction{ScheduleType: "one-time", Status: status}
+		if err := st.Pause(now); err == nil {
+			t.Errorf("Pause() of a %s transaction succeeded, want error", status)
+		}
+		if err := st.Resume(now); err == nil {
+			t.Errorf("Resume() of a %s transaction succeeded, want error", status)
+		}
+	}
+}
+
+func TestScheduledTransactionSkipNext(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	monthly := "monthly"
+	executeAt := now.AddDate(0, 0, 5)
+
+	st := &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, ExecuteAt: executeAt, Status: "active", IsActive: true}
+	if err := st.SkipNext(now); err != nil {
+		t.Fatalf("SkipNext() error = %v", err)
+	}
+	if want := executeAt.AddDate(0, 1, 0); !st.ExecuteAt.Equal(want) || st.CurrentOccurrence != 0 {
+		t.Errorf("ExecuteAt = %v, occurrence %d; want %v, 0", st.ExecuteAt, st.CurrentOccurrence, want)
+	}
+
+	// After an execution the upcoming occurrence follows the last execution
+	lastExecuted := now.AddDate(0, 0, -1)
+	st = &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, ExecuteAt: now.AddDate(0, -1, -1), LastExecutedAt: &lastExecuted, Status: "paused"}
+	if err := st.SkipNext(now); err != nil {
+		t.Fatalf("SkipNext() of a paused transaction error = %v", err)
+	}
+	if want := lastExecuted.AddDate(0, 2, 0); !st.ExecuteAt.Equal(want) {
+		t.Errorf("ExecuteAt = %v, want %v", st.ExecuteAt, want)
+	}
+
+	endDate := executeAt.AddDate(0, 0, 10)
+	tests := []struct {
+		name string
+		st   ScheduledTransaction
+	}{
+		{"one-time", ScheduledTransaction{ScheduleType: "one-time", ExecuteAt: executeAt, Status: "active"}},
+		{"cancelled", ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, ExecuteAt: executeAt, Status: "cancelled"}},
+		{"last occurrence", ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, RecurrenceEndDate: &endDate, ExecuteAt: executeAt, Status: "active"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.st.SkipNext(now); err == nil {
+				t.Error("SkipNext() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestScheduledTransactionRetries(t *testing.T) {
+	monthly := "monthly"
+	morning := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	st := &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, ExecuteAt: morning, Status: "active", IsActive: true}
+	for attempt := 1; attempt <= 2; attempt++ {
+		if !st.ScheduleRetry(morning, 2, time.Hour) {
+			t.Fatalf("attempt %d: ScheduleRetry() = false, want true", attempt)
+		}
+		if st.FailedAttempts != attempt || st.RetryAt == nil || !st.RetryAt.Equal(morning.Add(time.Hour)) {
+			t.Errorf("attempt %d: failed attempts %d, retry at %v", attempt, st.FailedAttempts, st.RetryAt)
+		}
+	}
+	if st.ScheduleRetry(morning, 2, time.Hour) {
+		t.Error("ScheduleRetry() beyond the grace retries = true, want false")
+	}
+
+	// Retries never run into the next day
+	late := &ScheduledTransaction{ScheduleType: "one-time", ExecuteAt: morning, Status: "active"}
+	if late.ScheduleRetry(time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC), 3, time.Hour) {
+		t.Error("ScheduleRetry() past midnight = true, want false")
+	}
+
+	// A recurring transaction gives up on the failed occurrence only
+	st.MarkFailed()
+	if st.Status != "active" || st.FailedAttempts != 0 || st.RetryAt != nil || !st.ExecuteAt.Equal(morning.AddDate(0, 1, 0)) {
+		t.Errorf("after MarkFailed = %s at %v with %d attempts, want active the next month", st.Status, st.ExecuteAt, st.FailedAttempts)
+	}
+
+	endDate := morning.AddDate(0, 0, 10)
+	last := &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, RecurrenceEndDate: &endDate, ExecuteAt: morning, Status: "active", IsActive: true}
+	last.MarkFailed()
+	if last.Status != "cancelled" || last.IsActive {
+		t.Errorf("after MarkFailed of the last occurrence = %s, want cancelled", last.Status)
+	}
+}
+
+func TestScheduledTransactionProjectExecutions(t *testing.T) {
+	weekly := "weekly"
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	from, to := start.AddDate(0, 0, 7), start.AddDate(0, 0, 28)
+	maxOccurrences := 4
+
+	tests := []struct {
+		name string
+		st   ScheduledTransaction
+		want []int // Days after start
+	}{
+		{"weekly", ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &weekly, ExecuteAt: start, Status: "active", IsActive: true}, []int{7, 14, 21}},
+		{"max occurrences", ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &weekly, ExecuteAt: start, MaxOccurrences: &maxOccurrences, CurrentOccurrence: 2, Status: "active", IsActive: true}, []int{7}},
+		{"one-time", ScheduledTransaction{ScheduleType: "one-time", ExecuteAt: start.AddDate(0, 0, 10), Status: "active", IsActive: true}, []int{10}},
+		{"one-time outside window", ScheduledTransaction{ScheduleType: "one-time", ExecuteAt: start, Status: "active", IsActive: true}, nil},
+		{"paused", ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &weekly, ExecuteAt: start, Status: "paused", IsActive: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.st.ProjectExecutions(from, to, 10, nil)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ProjectExecutions() = %d executions, want %d", len(got), len(tt.want))
+			}
+			for i, days := range tt.want {
+				if want := start.AddDate(0, 0, days); !got[i].ExecuteAt.Equal(want) {
+					t.Errorf("execution %d at %v, want %v", i, got[i].ExecuteAt, want)
+				}
+			}
+		})
+	}
+
+	st := ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &weekly, ExecuteAt: from, Status: "active", IsActive: true}
+	if got := st.ProjectExecutions(from, to, 2, nil); len(got) != 2 || got[0].Occurrence != 1 || got[1].Occurrence != 2 {
+		t.Errorf("limited ProjectExecutions() = %+v, want the first two occurrences", got)
+	}
+	if err := ValidateUpcomingWindow(to, from); err == nil {
+		t.Error("ValidateUpcomingWindow() of a reversed window succeeded, want error")
+	}
+	if err := ValidateUpcomingWindow(from, from.Add(MaxUpcomingWindow+time.Hour)); err == nil {
+		t.Error("ValidateUpcomingWindow() of a window over a year succeeded, want error")
+	}
+}
+
+func TestTransactionRemainingReversibleAmount(t *testing.T) {
+	tx := Transaction{Amount: 100.10, ReversedAmount: 40.05}
+
+	if got := tx.RemainingReversibleAmount(); got != 60.05 {
+		t.Errorf("Transaction.RemainingReversibleAmount() = %v, want 60.05", got)
+	}
+
+	// Amounts are rounded to the currency's decimal places
+	yen := Transaction{Amount: 1000, ReversedAmount: 333.4, Currency: string(CurrencyJPY)}
+	if got := yen.RemainingReversibleAmount(); got != 667 {
+		t.Errorf("Transaction.RemainingReversibleAmount() = %v, want 667 for JPY", got)
+	}
+}
+
+func TestParseStatsWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "default", window: "", want: 24 * time.Hour},
+		{name: "one hour", window: "1h", want: time.Hour},
+		{name: "thirty days", window: "30d", want: 30 * 24 * time.Hour},
+		{name: "unsupported", window: "90m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatsWindow(tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStatsWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseStatsWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExportFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		want    ExportFormat
+		wantErr bool
+	}{
+		{name: "default", format: "", want: ExportFormatCSV},
+		{name: "csv", format: "csv", want: ExportFormatCSV},
+		{name: "json", format: "json", want: ExportFormatJSON},
+		{name: "unsupported", format: "xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExportFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExportFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseExportFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	createdAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	if got := ExportFormatJSON.FileName(createdAt); got != "transactions-20240301-093000.json" {
+		t.Errorf("FileName() = %q", got)
+	}
+}
+
+func TestTransactionStatusUpdateIsTerminal(t *testing.T) {
+	tests := []struct {
+		status TransactionStatus
+		want   bool
+	}{
+		{status: StatusPending, want: false},
+		{status: StatusSuccess, want: true},
+		{status: StatusFailed, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			update := NewTransactionStatusUpdate(&Transaction{ID: uuid.New(), Status: string(tt.status)})
+			if got := update.IsTerminal(); got != tt.want {
+				t.Errorf("IsTerminal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePaymentRequestRequestValidation(t *testing.T) {
+	soon := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	tooLate := time.Now().Add(MaxPaymentRequestExpiry + time.Hour)
+	payerID := uuid.New()
+
+	tests := []struct {
+		name    string
+		request CreatePaymentRequestRequest
+		wantErr bool
+	}{
+		{
+			name:    "valid without expiry",
+			request: CreatePaymentRequestRequest{PayerID: payerID, Amount: 25, Currency: "USD", Note: "Dinner"},
+			wantErr: false,
+		},
+		{
+			name:    "valid with expiry",
+			request: CreatePaymentRequestRequest{PayerID: payerID, Amount: 25, Currency: "EUR", ExpiresAt: &soon},
+			wantErr: false,
+		},
+		{
+			name:    "missing payer",
+			request: CreatePaymentRequestRequest{Amount: 25, Currency: "USD"},
+			wantErr: true,
+		},
+		{
+			name:    "zero amount",
+			request: CreatePaymentRequestRequest{PayerID: payerID, Amount: 0, Currency: "USD"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported currency",
+			request: CreatePaymentRequestRequest{PayerID: payerID, Amount: 25, Currency: "XYZ"},
+			wantErr: true,
+		},
+		{
+			name:    "expiry in the past",
+			request: CreatePaymentRequestRequest{PayerID: payerID, Amount: 25, Currency: "USD", ExpiresAt: &past},
+			wantErr: true,
+		},
+		{
+			name:    "expiry too far ahead",
+			request: CreatePaymentRequestRequest{PayerID: payerID, Amount: 25, Currency: "USD", ExpiresAt: &tooLate},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreatePaymentRequestRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentRequestIsExpiredAt(t *testing.T) {
+	now := time.Now()
+	request := PaymentRequest{Status: string(PaymentRequestPending), ExpiresAt: now}
+
+	if request.IsExpiredAt(now.Add(-time.Minute)) {
+		t.Error("IsExpiredAt() before expiry = true, want false")
+	}
+	if !request.IsExpiredAt(now) {
+		t.Error("IsExpiredAt() at expiry = false, want true")
+	}
+
+	request.Status = string(PaymentRequestDeclined)
+	if request.IsExpiredAt(now.Add(time.Hour)) {
+		t.Error("IsExpiredAt() for declined request = true, want false")
+	}
+}
+
+func TestTransferTemplateRequestValidation(t *testing.T) {
+	payeeID := uuid.New()
+
+	tests := []struct {
+		name    string
+		request TransferTemplateRequest
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			request: TransferTemplateRequest{Name: "Rent", PayeeID: payeeID, DefaultAmount: 950, Currency: "USD"},
+			wantErr: false,
+		},
+		{
+			name:    "blank name",
+			request: TransferTemplateRequest{Name: "  ", PayeeID: payeeID, DefaultAmount: 950, Currency: "USD"},
+			wantErr: true,
+		},
+		{
+			name:    "missing payee",
+			request: TransferTemplateRequest{Name: "Rent", DefaultAmount: 950, Currency: "USD"},
+			wantErr: true,
+		},
+		{
+			name:    "negative amount",
+			request: TransferTemplateRequest{Name: "Rent", PayeeID: payeeID, DefaultAmount: -1, Currency: "USD"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported currency",
+			request: TransferTemplateRequest{Name: "Rent", PayeeID: payeeID, DefaultAmount: 950, Currency: "ABC"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransferTemplateRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateTransferTemplateRequestValidation(t *testing.T) {
+	name := "Groceries"
+	zero := 0.0
+
+	if err := (&UpdateTransferTemplateRequest{}).Validate(); err == nil {
+		t.Error("Validate() with no fields = nil, want error")
+	}
+	if err := (&UpdateTransferTemplateRequest{Name: &name}).Validate(); err != nil {
+		t.Errorf("Validate() with name = %v, want nil", err)
+	}
+	if err := (&UpdateTransferTemplateRequest{DefaultAmount: &zero}).Validate(); err == nil {
+		t.Error("Validate() with zero amount = nil, want error")
+	}
+}
+
+func TestUpdateNotificationPreferencesRequestValidation(t *testing.T) {
+	webhookURL := "https://example.com/hooks"
+	badURL := "ftp://example.com"
+	emptyURL := ""
+
+	tests := []struct {
+		name    string
+		request UpdateNotificationPreferencesRequest
+		wantErr bool
+	}{
+		{
+			name: "valid channels",
+			request: UpdateNotificationPreferencesRequest{Channels: map[NotificationType][]NotificationChannel{
+				NotificationLowBalance: {ChannelInApp, ChannelEmail},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "muted type",
+			request: UpdateNotificationPreferencesRequest{Channels: map[NotificationType][]NotificationChannel{
+				NotificationTransactionCompleted: {},
+			}},
+			wantErr: false,
+		},
+		{name: "webhook url", request: UpdateNotificationPreferencesRequest{WebhookURL: &webhookURL}, wantErr: false},
+		{name: "cleared webhook url", request: UpdateNotificationPreferencesRequest{WebhookURL: &emptyURL}, wantErr: false},
+		{name: "no fields", request: UpdateNotificationPreferencesRequest{}, wantErr: true},
+		{name: "non-http webhook url", request: UpdateNotificationPreferencesRequest{WebhookURL: &badURL}, wantErr: true},
+		{
+			name: "unknown type",
+			request: UpdateNotificationPreferencesRequest{Channels: map[NotificationType][]NotificationChannel{
+				"marketing": {ChannelInApp},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "unknown channel",
+			request: UpdateNotificationPreferencesRequest{Channels: map[NotificationType][]NotificationChannel{
+				NotificationLowBalance: {"sms"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateNotificationPreferencesRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferencesChannelsFor(t *testing.T) {
+	prefs := DefaultNotificationPreferences(uuid.New())
+	prefs.Channels[NotificationLowBalance] = []NotificationChannel{ChannelEmail}
+	delete(prefs.Channels, NotificationScheduledExecutionFailed)
+
+	if got := prefs.ChannelsFor(NotificationLowBalance); len(got) != 1 || got[0] != ChannelEmail {
+		t.Errorf("ChannelsFor(low_balance) = %v, want [email]", got)
+	}
+	if got := prefs.ChannelsFor(NotificationScheduledExecutionFailed); len(got) != 1 || got[0] != ChannelInApp {
+		t.Errorf("ChannelsFor(missing type) = %v, want [in_app]", got)
+	}
+}
+
+func TestBalanceAlertEvaluate(t *testing.T) {
+	tests := []struct {
+		name      string
+		triggered bool
+		balance   float64
+		debited   bool
+		want      BalanceAlertTransition
+	}{
+		{name: "debit below threshold fires", triggered: false, balance: 99, debited: true, want: BalanceAlertTriggered},
+		{name: "debit to threshold stays armed", triggered: false, balance: 100, debited: true, want: BalanceAlertUnchanged},
+		{name: "credit below threshold does not fire", triggered: false, balance: 50, debited: false, want: BalanceAlertUnchanged},
+		{name: "triggered alert does not fire again", triggered: true, balance: 40, debited: true, want: BalanceAlertUnchanged},
+		{name: "recovery below re-arm level stays triggered", triggered: true, balance: 105, debited: false, want: BalanceAlertUnchanged},
+		{name: "recovery to re-arm level re-arms", triggered: true, balance: 110, debited: false, want: BalanceAlertRearmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := &BalanceAlert{Threshold: 100, Triggered: tt.triggered}
+			if got := alert.Evaluate(tt.balance, tt.debited); got != tt.want {
+				t.Errorf("BalanceAlert.Evaluate(%v, %v) = %v, want %v", tt.balance, tt.debited, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetBalanceAlertRequestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request SetBalanceAlertRequest
+		wantErr bool
+	}{
+		{name: "valid threshold", request: SetBalanceAlertRequest{Threshold: 100}, wantErr: false},
+		{name: "zero threshold", request: SetBalanceAlertRequest{Threshold: 0}, wantErr: true},
+		{name: "negative threshold", request: SetBalanceAlertRequest{Threshold: -5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetBalanceAlertRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTreasuryAccountApply(t *testing.T) {
+	account := &TreasuryAccount{Currency: "USD"}
+
+	steps := []struct {
+		kind    TreasuryEntryKind
+		amount  float64
+		wantErr bool
+		balance float64
+	}{
+		{kind: TreasuryIssue, amount: 10, wantErr: true, balance: 0},
+		{kind: TreasuryMint, amount: 100.10, balance: 100.10},
+		{kind: TreasuryIssue, amount: 60.05, balance: 40.05},
+		{kind: TreasuryRedeem, amount: 20, balance: 60.05},
+		{kind: TreasuryBurn, amount: 70, wantErr: true, balance: 60.05},
+		{kind: TreasuryBurn, amount: 0.05, balance: 60},
+		{kind: "unknown", amount: 1, wantErr: true, balance: 60},
+	}
+
+	for i, step := range steps {
+		err := account.Apply(step.kind, step.amount)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("step %d: Apply(%s, %v) error = %v, wantErr %v", i, step.kind, step.amount, err, step.wantErr)
+		}
+		if account.Balance != step.balance {
+			t.Fatalf("step %d: balance = %v, want %v", i, account.Balance, step.balance)
+		}
+	}
+
+	// Minted 100.10, burned 0.05, treasury holds 60: users hold the remaining 40.05
+	if got := account.TotalSupply(); got != 100.05 {
+		t.Errorf("TotalSupply() = %v, want 100.05", got)
+	}
+	if got := account.Circulating(); got != 40.05 {
+		t.Errorf("Circulating() = %v, want 40.05", got)
+	}
+}
+
+func TestTreasuryAccountCheckCredit(t *testing.T) {
+	maxCredit := 500.0
+	dailyCap := 1000.0
+
+	tests := []struct {
+		name        string
+		account     TreasuryAccount
+		amount      float64
+		issuedToday float64
+		wantErr     bool
+	}{
+		{name: "uncapped", account: TreasuryAccount{}, amount: 999999, issuedToday: 999999, wantErr: false},
+		{name: "at credit limit", account: TreasuryAccount{MaxCreditAmount: &maxCredit}, amount: 500, wantErr: false},
+		{name: "above credit limit", account: TreasuryAccount{MaxCreditAmount: &maxCredit}, amount: 500.01, wantErr: true},
+		{name: "reaches daily cap", account: TreasuryAccount{DailyCreditCap: &dailyCap}, amount: 400, issuedToday: 600, wantErr: false},
+		{name: "exceeds daily cap", account: TreasuryAccount{DailyCreditCap: &dailyCap}, amount: 400.01, issuedToday: 600, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.account.CheckCredit(tt.amount, tt.issuedToday)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TreasuryAccount.CheckCredit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTreasuryRequestValidation(t *testing.T) {
+	validCap := 100.0
+	zero := 0.0
+
+	tests := []struct {
+		name    string
+		request interface{ Validate() error }
+		wantErr bool
+	}{
+		{name: "valid mint", request: &TreasuryOperationRequest{Amount: 100, Reason: "initial supply"}, wantErr: false},
+		{name: "missing reason", request: &TreasuryOperationRequest{Amount: 100, Reason: "  "}, wantErr: true},
+		{name: "zero amount", request: &TreasuryOperationRequest{Amount: 0, Reason: "initial supply"}, wantErr: true},
+		{name: "no caps", request: &SetTreasuryCapsRequest{}, wantErr: false},
+		{name: "valid caps", request: &SetTreasuryCapsRequest{MaxCreditAmount: &validCap, DailyCreditCap: &validCap}, wantErr: false},
+		{name: "zero daily cap", request: &SetTreasuryCapsRequest{DailyCreditCap: &zero}, wantErr: true},
+		{name: "zero credit limit", request: &SetTreasuryCapsRequest{MaxCreditAmount: &zero}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewMoneySupplyCheck(t *testing.T) {
+	account := &TreasuryAccount{Currency: "USD", Balance: 600, TotalMinted: 1000.10, TotalBurned: 0.10}
+
+	tests := []struct {
+		name         string
+		userBalances float64
+		wantDrift    float64
+		wantHolds    bool
+	}{
+		{name: "balanced", userBalances: 400, wantDrift: 0, wantHolds: true},
+		{name: "floating point noise", userBalances: 400.0000001, wantDrift: 0, wantHolds: true},
+		{name: "money created", userBalances: 425.50, wantDrift: 25.50, wantHolds: false},
+		{name: "money leaked", userBalances: 399.99, wantDrift: -0.01, wantHolds: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewMoneySupplyCheck(account, tt.userBalances)
+			if check.Drift != tt.wantDrift || check.Holds != tt.wantHolds {
+				t.Errorf("NewMoneySupplyCheck() drift = %v, holds = %v, want drift = %v, holds = %v", check.Drift, check.Holds, tt.wantDrift, tt.wantHolds)
+			}
+			if check.ExpectedSupply != 1000 {
+				t.Errorf("ExpectedSupply = %v, want 1000", check.ExpectedSupply)
+			}
+		})
+	}
+}
+
+func TestSimulationRequestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request SimulationRequest
+		wantErr bool
+	}{
+		{name: "defaults applied", request: SimulationRequest{Users: 10, DurationSeconds: 30, TargetTPS: 5}, wantErr: false},
+		{name: "single user", request: SimulationRequest{Users: 1, DurationSeconds: 30, TargetTPS: 5}, wantErr: true},
+		{name: "too long", request: SimulationRequest{Users: 10, DurationSeconds: MaxSimulationDuration + 1, TargetTPS: 5}, wantErr: true},
+		{name: "zero tps", request: SimulationRequest{Users: 10, DurationSeconds: 30}, wantErr: true},
+		{name: "negative weight", request: SimulationRequest{Users: 10, DurationSeconds: 30, TargetTPS: 5, CreditWeight: -1, TransferWeight: 1}, wantErr: true},
+		{name: "failure rate above one", request: SimulationRequest{Users: 10, DurationSeconds: 30, TargetTPS: 5, FailureRate: 1.5}, wantErr: true},
+		{name: "unsupported currency", request: SimulationRequest{Users: 10, DurationSeconds: 30, TargetTPS: 5, Currency: "XYZ"}, wantErr: true},
+		{name: "funding above mint limit", request: SimulationRequest{Users: 1000, DurationSeconds: 30, TargetTPS: 5, InitialBalance: 1000.01}, wantErr: true},
+		{name: "too much concurrency", request: SimulationRequest{Users: 10, DurationSeconds: 30, TargetTPS: 5, Concurrency: MaxSimulationConcurrency + 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.ApplyDefaults()
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	request := SimulationRequest{Currency: " eur "}
+	request.ApplyDefaults()
+	if request.Currency != "EUR" || request.InitialBalance != 1000 || request.MaxAmount != 100 || request.Concurrency != 20 {
+		t.Errorf("ApplyDefaults() = %+v", request)
+	}
+	if request.CreditWeight != 1 || request.DebitWeight != 1 || request.TransferWeight != 2 {
+		t.Errorf("ApplyDefaults() weights = %v/%v/%v, want 1/1/2", request.CreditWeight, request.DebitWeight, request.TransferWeight)
+	}
+}
+
+func TestSummarizeLatencies(t *testing.T) {
+	if got := SummarizeLatencies(nil); got != (SimulationLatency{}) {
+		t.Errorf("SummarizeLatencies(nil) = %+v, want zero", got)
+	}
+
+	latencies := make([]float64, 0, 100)
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, float64(i))
+	}
+
+	got := SummarizeLatencies(latencies)
+	want := SimulationLatency{P50: 50, P95: 95, P99: 99, Max: 100}
+	if got != want {
+		t.Errorf("SummarizeLatencies() = %+v, want %+v", got, want)
+	}
+	if latencies[0] != 100 {
+		t.Error("SummarizeLatencies() modified its input")
+	}
+}
+
+func TestAccountNumber(t *testing.T) {
+	id := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	number := AccountNumberFor(id)
+	if len(number) != 20 || number[:2] != "XS" {
+		t.Fatalf("AccountNumberFor() = %q, want XS followed by 18 digits", number)
+	}
+	if again := AccountNumberFor(id); again != number {
+		t.Errorf("AccountNumberFor() is not stable: %q then %q", number, again)
+	}
+
+	// Spaced and lower-case input parses to the compact form
+	spaced := strings.ToLower(number[:4] + " " + number[4:8] + " " + number[8:12] + " " + number[12:16] + " " + number[16:])
+	if parsed, err := ParseAccountNumber(spaced); err != nil || parsed != number {
+		t.Errorf("ParseAccountNumber(%q) = %q, %v; want %q", spaced, parsed, err, number)
+	}
+
+	// Changing any digit breaks the check digits
+	last := number[len(number)-1]
+	typo := number[:len(number)-1] + string('0'+(last-'0'+1)%10)
+	if _, err := ParseAccountNumber(typo); err == nil {
+		t.Errorf("ParseAccountNumber(%q) accepted a typo", typo)
+	}
+
+	for _, invalid := range []string{"", "XS12", "GB82WEST12345698765432", number[:19] + "X"} {
+		if _, err := ParseAccountNumber(invalid); err == nil {
+			t.Errorf("ParseAccountNumber(%q) succeeded, want an error", invalid)
+		}
+	}
+
+	// A valid IBAN check is that the rearranged number is 1 modulo 97
+	if got := mod97(number[4:] + "3328" + number[2:4]); got != 1 {
+		t.Errorf("rearranged %q mod 97 = %d, want 1", number, got)
+	}
+}
+
+func TestCreateContactRequestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateContactRequest
+		wantErr bool
+	}{
+		{"by user ID", CreateContactRequest{ContactUserID: uuid.New(), Nickname: "Mom"}, false},
+		{"by account number", CreateContactRequest{AccountNumber: "XS61 2116 5386 2623 8777"}, false},
+		{"no counterparty", CreateContactRequest{Nickname: "Mom"}, true},
+		{"blank account number", CreateContactRequest{AccountNumber: "  "}, true},
+		{"long nickname", CreateContactRequest{ContactUserID: uuid.New(), Nickname: strings.Repeat("a", 101)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventFilterMatches(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	event, err := NewEvent(AggregateBalance, uuid.New(), EventAmountDebited, map[string]float64{"amount": 5}, nil)
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	event.CreatedAt = created
+
+	balance, user := AggregateBalance, AggregateUser
+	debited, credited := string(EventAmountDebited), string(EventAmountCredited)
+	otherID := uuid.New()
+	before, after := created.Add(-time.Minute), created.Add(time.Minute)
+
+	tests := []struct {
+		name   string
+		filter EventFilter
+		want   bool
+	}{
+		{"no conditions", EventFilter{}, true},
+		{"aggregate type", EventFilter{AggregateType: &balance}, true},
+		{"other aggregate type", EventFilter{AggregateType: &user}, false},
+		{"aggregate ID", EventFilter{AggregateID: &event.AggregateID}, true},
+		{"other aggregate ID", EventFilter{AggregateID: &otherID}, false},
+		{"event type", EventFilter{EventType: &debited}, true},
+		{"other event type", EventFilter{EventType: &credited}, false},
+		{"since is inclusive", EventFilter{Since: &created}, true},
+		{"since after", EventFilter{Since: &after}, false},
+		{"until is exclusive", EventFilter{Until: &created}, false},
+		{"within range", EventFilter{Since: &before, Until: &after}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(event); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	data, err := json.Marshal(event.ToResponse())
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+	if !strings.Contains(string(data), `"data":{"amount":5}`) || strings.Contains(string(data), `"metadata"`) {
+		t.Errorf("response = %s, want data as JSON and no metadata", data)
+	}
+
+	if !AggregatePaymentRequest.IsValid() || AggregateType("account").IsValid() {
+		t.Error("IsValid() should accept known aggregate types only")
+	}
+}
+
+func TestProjectionRebuildRequestValidate(t *testing.T) {
+	balance := AggregateBalance
+	payment := AggregatePaymentRequest
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		req     ProjectionRebuildRequest
+		wantErr bool
+	}{
+		{"everything", ProjectionRebuildRequest{}, false},
+		{"aggregate type", ProjectionRebuildRequest{AggregateType: &balance}, false},
+		{"single aggregate", ProjectionRebuildRequest{AggregateType: &balance, AggregateID: &id}, false},
+		{"unprojected aggregate type", ProjectionRebuildRequest{AggregateType: &payment}, true},
+		{"aggregate ID without type", ProjectionRebuildRequest{AggregateID: &id}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	rebuild := &ProjectionRebuild{Status: ProjectionRebuildRunning, EventsTotal: 4, EventsProcessed: 1}
+	rebuild.ComputeProgress()
+	if rebuild.Progress != 0.25 {
+		t.Errorf("Progress = %v, want 0.25", rebuild.Progress)
+	}
+
+	empty := &ProjectionRebuild{Status: ProjectionRebuildCompleted}
+	empty.ComputeProgress()
+	if empty.Progress != 1 {
+		t.Errorf("Progress of an empty completed rebuild = %v, want 1", empty.Progress)
+	}
+}
+
+func TestEventUpcastersUpcast(t *testing.T) {
+	upcasters := EventUpcasters{
+		EventAmountCredited: {
+			// Version 2 renamed value to amount
+			func(data map[string]interface{}) (map[string]interface{}, error) {
+				data["amount"] = data["value"]
+				delete(data, "value")
+				return data, nil
+			},
+			// Version 3 added a currency
+			func(data map[string]interface{}) (map[string]interface{}, error) {
+				data["currency"] = "USD"
+				return data, nil
+			},
+		},
+	}
+
+	if got := upcasters.CurrentVersion(EventAmountCredited); got != 3 {
+		t.Errorf("CurrentVersion() = %d, want 3", got)
+	}
+	if got := upcasters.CurrentVersion(EventAmountDebited); got != 1 {
+		t.Errorf("CurrentVersion() without upcasters = %d, want 1", got)
+	}
+
+	for _, version := range []int{0, 1} {
+		data, err := upcasters.Upcast(EventAmountCredited, version, []byte(`{"value":5}`))
+		if err != nil {
+			t.Fatalf("Upcast(version %d) error = %v", version, err)
+		}
+		var credited AmountCreditedEvent
+		if err := json.Unmarshal(data, &credited); err != nil {
+			t.Fatalf("unmarshal upcast data: %v", err)
+		}
+		if credited.Amount != 5 || credited.Currency != "USD" {
+			t.Errorf("Upcast(version %d) = %s, want amount 5 in USD", version, data)
+		}
+	}
+
+	current := []byte(`{"amount":7,"currency":"EUR"}`)
+	if data, err := upcasters.Upcast(EventAmountCredited, 3, current); err != nil || string(data) != string(current) {
+		t.Errorf("Upcast(current version) = %s, %v; want the data unchanged", data, err)
+	}
+	if _, err := upcasters.Upcast(EventAmountCredited, 4, current); err == nil {
+		t.Error("Upcast() should reject versions newer than the current one")
+	}
+
+	event, err := NewEvent(AggregateBalance, uuid.New(), EventAmountCredited, AmountCreditedEvent{Amount: 3}, nil)
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	if event.EventVersion != CurrentEventVersion(EventAmountCredited) {
+		t.Errorf("EventVersion = %d, want the current version", event.EventVersion)
+	}
+}
+
+func TestPartitionNames(t *testing.T) {
+	month := time.Date(2024, time.January, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	if got := PartitionName("events", month); got != "events_y2024m02" {
+		t.Errorf("PartitionName() = %q, want events_y2024m02 since partitions follow UTC months", got)
+	}
+
+	parsed, ok := ParsePartitionMonth("events", "events_y2024m02")
+	if !ok || !parsed.Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParsePartitionMonth() = %v, %v; want 2024-02-01", parsed, ok)
+	}
+	for _, name := range []string{"events_default", "events_y2024m13", "events_y2024m02_old", "transactions_y2024m02"} {
+		if _, ok := ParsePartitionMonth("events", name); ok {
+			t.Errorf("ParsePartitionMonth(%q) should not match", name)
+		}
+	}
+
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	if cutoff, ok := ArchiveCutoff(now, 2); !ok || !cutoff.Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ArchiveCutoff(2 months) = %v, %v; want 2024-01-01", cutoff, ok)
+	}
+	if _, ok := ArchiveCutoff(now, 0); ok {
+		t.Error("ArchiveCutoff(0 months) should archive nothing")
+	}
+}
+
+func TestEventBackupKeys(t *testing.T) {
+	first := time.Date(2024, time.January, 15, 10, 30, 0, 123456000, time.FixedZone("UTC+1", 60*60))
+	if got := EventBackupKey("/backups/", first); got != "backups/events/2024/01/15/20240115T093000.123456000Z.ndjson.gz" {
+		t.Errorf("EventBackupKey() = %q", got)
+	}
+	if got := EventBackupKey("", first); !strings.HasPrefix(got, EventBackupPrefix("")) || EventBackupPrefix("") != "events/" {
+		t.Errorf("EventBackupKey() without prefix = %q, want it under events/", got)
+	}
+	if later := EventBackupKey("backups", first.Add(time.Millisecond)); later <= EventBackupKey("backups", first) {
+		t.Errorf("EventBackupKey() of a later event = %q, want it to sort after the earlier one", later)
+	}
+	if got := EventBackupCheckpointKey("backups"); got != "backups/checkpoint.json" {
+		t.Errorf("EventBackupCheckpointKey() = %q", got)
+	}
+
+	event, err := NewEvent(AggregateUser, uuid.New(), EventUserRegistered, UserRegisteredEvent{Username: "alice"}, &EventMetadata{CorrelationID: "c1"})
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	event.Version = 3
+	event.CreatedAt = first
+
+	response := event.ToResponse()
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+	var decoded EventResponse
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+
+	restored := decoded.ToEvent()
+	if restored.ID != event.ID || restored.Version != 3 || restored.EventVersion != event.EventVersion ||
+		!restored.CreatedAt.Equal(first) || string(restored.EventData) != string(event.EventData) ||
+		string(restored.EventMetadata) != string(event.EventMetadata) {
+		t.Errorf("ToEvent() = %+v, want the original event %+v", restored, event)
+	}
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	defer SetCurrencies(DefaultCurrencies())
+
+	amounts := []struct {
+		currency string
+		amount   float64
+		wantErr  string
+	}{
+		{"USD", 12.5, ""},
+		{"USD", 12.34, ""},
+		{"USD", 12.345, "USD amounts cannot have more than 2 decimal places"},
+		{"JPY", 1500, ""},
+		{"JPY", 1500.5, "JPY amounts cannot have decimal places"},
+		{"XYZ", 1.234, ""}, // Left to the currency check
+	}
+	for _, tt := range amounts {
+		err := ValidateCurrencyAmount(tt.currency, tt.amount)
+		if (tt.wantErr == "" && err != nil) || (tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr)) {
+			t.Errorf("ValidateCurrencyAmount(%s, %v) = %v, want %q", tt.currency, tt.amount, err, tt.wantErr)
+		}
+	}
+
+	if err := (&CreditRequest{Amount: 10.5, Currency: "JPY"}).Validate(); err == nil || err.Error() != "amount: JPY amounts cannot have decimal places" {
+		t.Errorf("credit of 10.5 JPY: err = %v, want the decimal places error", err)
+	}
+
+	if got := FormatAmount(1500, "JPY"); got != "1,500 JPY" {
+		t.Errorf("FormatAmount(JPY) = %q", got)
+	}
+	if got := FormatAmount(12.5, "USD"); got != "12.50 USD" {
+		t.Errorf("FormatAmount(USD) = %q", got)
+	}
+	if got := RoundAmount(12.6, "JPY"); got != 13 {
+		t.Errorf("RoundAmount(JPY) = %v, want 13", got)
+	}
+
+	currencies := DefaultCurrencies()
+	currencies[0].Enabled = false // USD
+	currencies = append(currencies, CurrencyInfo{Code: "KWD", Symbol: "KD", DecimalPlaces: 2, Enabled: true})
+	SetCurrencies(currencies)
+
+	if IsValidCurrency("USD") || !IsKnownCurrency("USD") {
+		t.Error("disabled USD should be known but not valid for new requests")
+	}
+	if !IsValidCurrency("KWD") || len(SupportedCurrencies()) != len(currencies)-1 {
+		t.Errorf("supported currencies = %v, want the enabled ones including KWD", SupportedCurrencies())
+	}
+	if err := (&Balance{UserID: uuid.New(), Currency: "USD"}).Validate(); err != nil {
+		t.Errorf("balance in disabled USD: %v", err)
+	}
+
+	zero, two, three, negative := 0, 2, 3, -1
+	disabled := false
+	requests := []struct {
+		name    string
+		req     interface{ Validate() error }
+		wantErr string
+	}{
+		{"valid create", &CreateCurrencyRequest{Code: "CHF", Symbol: "Fr.", DecimalPlaces: &two}, ""},
+		{"lower-case code", &CreateCurrencyRequest{Code: "chf", Symbol: "Fr.", DecimalPlaces: &two}, "code: code must be three upper-case letters"},
+		{"missing symbol", &CreateCurrencyRequest{Code: "CHF", Symbol: " ", DecimalPlaces: &two}, "symbol: symbol is required"},
+		{"missing decimal places", &CreateCurrencyRequest{Code: "CHF", Symbol: "Fr."}, "decimal_places: decimal_places is required"},
+		{"three decimal places", &CreateCurrencyRequest{Code: "BHD", Symbol: "BD", DecimalPlaces: &three}, "decimal_places: decimal_places must be between 0 and 2"},
+		{"empty update", &UpdateCurrencyRequest{}, "symbol, decimal_places or enabled is required"},
+		{"disable", &UpdateCurrencyRequest{Enabled: &disabled}, ""},
+		{"no decimal places", &UpdateCurrencyRequest{DecimalPlaces: &zero}, ""},
+		{"negative decimal places", &UpdateCurrencyRequest{DecimalPlaces: &negative}, "decimal_places: decimal_places must be between 0 and 2"},
+	}
+	for _, tt := range requests {
+		err := tt.req.Validate()
+		if (tt.wantErr == "" && err != nil) || (tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr)) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	jpy, _ := LookupCurrency("JPY")
+	symbol := " 円 "
+	(&UpdateCurrencyRequest{Symbol: &symbol, DecimalPlaces: &two}).Apply(&jpy)
+	if jpy.Symbol != "円" || jpy.DecimalPlaces != 2 || !jpy.Enabled {
+		t.Errorf("Apply() = %+v, want the trimmed symbol and two decimal places", jpy)
+	}
+}
+
+func TestAmountFormattingAndParsing(t *testing.T) {
+	formats := []struct {
+		amount   float64
+		currency string
+		locale   Locale
+		want     string
+	}{
+		{1234567.891, "USD", LocaleEN, "1,234,567.89"},
+		{1234.5, "EUR", LocaleDE, "1.234,50"},
+		{1234.5, "EUR", LocaleFR, "1\u00a0234,50"},
+		{1500, "JPY", LocaleEN, "1,500"},
+		{-1234.5, "USD", LocaleEN, "-1,234.50"},
+		{999, "USD", LocaleDE, "999,00"},
+		{1234.5, "USD", "", "1234.50"},
+		{1500, "JPY", "", "1500"},
+	}
+	for _, tt := range formats {
+		if got := FormatDecimal(tt.amount, tt.currency, tt.locale); got != tt.want {
+			t.Errorf("FormatDecimal(%v, %s, %q) = %q, want %q", tt.amount, tt.currency, tt.locale, got, tt.want)
+		}
+	}
+	if got := FormatAmountLocale(1500, "JPY", LocaleDE); got != "1.500 JPY" {
+		t.Errorf("FormatAmountLocale() = %q", got)
+	}
+
+	for locale, want := range map[string]Locale{"": "", "en": LocaleEN, "de-DE": LocaleDE, "FR_fr": LocaleFR} {
+		if got, err := ParseLocale(locale); err != nil || got != want {
+			t.Errorf("ParseLocale(%q) = %q, %v; want %q", locale, got, err, want)
+		}
+	}
+	if _, err := ParseLocale("tr"); err == nil {
+		t.Error("ParseLocale(tr) should be rejected")
+	}
+
+	parses := []struct {
+		s        string
+		currency string
+		want     float64
+		wantErr  string
+	}{
+		{"1234.50", "USD", 1234.5, ""},
+		{"10.500", "USD", 10.5, ""},
+		{"1500", "JPY", 1500, ""},
+		{"1500.0", "JPY", 1500, ""},
+		{"-5", "USD", -5, ""},
+		{"0.01", "", 0.01, ""},
+		{"1500.5", "JPY", 0, "JPY amounts cannot have decimal places"},
+		{"10.001", "USD", 0, "USD amounts cannot have more than 2 decimal places"},
+		{"1.234", "XYZ", 0, "amounts cannot have more than 2 decimal places"},
+		{"1e3", "USD", 0, "amount must be a plain decimal number, e.g. 1234.50"},
+		{"1,000", "USD", 0, "amount must be a plain decimal number, e.g. 1234.50"},
+		{"007", "USD", 0, "amount must be a plain decimal number, e.g. 1234.50"},
+		{".5", "USD", 0, "amount must be a plain decimal number, e.g. 1234.50"},
+		{" 5", "USD", 0, "amount must be a plain decimal number, e.g. 1234.50"},
+	}
+	for _, tt := range parses {
+		got, err := ParseAmount(tt.s, tt.currency)
+		if tt.wantErr == "" && (err != nil || got != tt.want) {
+			t.Errorf("ParseAmount(%q, %s) = %v, %v; want %v", tt.s, tt.currency, got, err, tt.want)
+		}
+		if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+			t.Errorf("ParseAmount(%q, %s) error = %v, want %q", tt.s, tt.currency, err, tt.wantErr)
+		}
+	}
+
+	// Amounts decoded from JSON are checked as the client wrote them
+	if err := ValidateCurrencyAmount("USD", 0.29); err != nil {
+		t.Errorf("ValidateCurrencyAmount(0.29): %v", err)
+	}
+	if err := ValidateCurrencyAmount("USD", 10.0000001); err == nil {
+		t.Error("ValidateCurrencyAmount(10.0000001) should be rejected")
+	}
+}
+
+func TestAmountLimits(t *testing.T) {
+	SetAmountLimits(AmountLimits{
+		"USD": {AnyTransactionType: {Min: 1, Max: 50000}, TypeTransfer: {Min: 10}},
+		"JPY": {TypeCredit: {Max: 2000000}},
+	})
+	defer SetAmountLimits(nil)
+
+	tests := []struct {
+		currency string
+		txType   TransactionType
+		amount   float64
+		want     *AmountLimitError // Nil when the amount is allowed
+	}{
+		{"USD", TypeCredit, 1, nil},
+		{"USD", TypeDebit, 0.5, &AmountLimitError{Currency: "USD", Type: TypeDebit, Min: 1, Max: 50000}},
+		{"USD", TypeCredit, 50000.01, &AmountLimitError{Currency: "USD", Type: TypeCredit, Min: 1, Max: 50000}},
+		{"USD", TypeTransfer, 9.99, &AmountLimitError{Currency: "USD", Type: TypeTransfer, Min: 10, Max: MaxTransactionAmount}},
+		{"USD", TypeTransfer, 60000, nil},
+		{"JPY", TypeCredit, 1500000, &AmountLimitError{Currency: "JPY", Type: TypeCredit, Max: MaxTransactionAmount}},
+		{"JPY", TypeDebit, 1, nil},
+		{"EUR", TypeDebit, 0.01, nil},
+	}
+	for _, tt := range tests {
+		err := CheckAmountLimit(tt.currency, tt.txType, tt.amount)
+		var limitErr *AmountLimitError
+		if tt.want == nil && err != nil {
+			t.Errorf("CheckAmountLimit(%s, %s, %v) = %v, want nil", tt.currency, tt.txType, tt.amount, err)
+		}
+		if tt.want != nil && (!errors.As(err, &limitErr) || *limitErr != *tt.want) {
+			t.Errorf("CheckAmountLimit(%s, %s, %v) = %#v, want %#v", tt.currency, tt.txType, tt.amount, err, tt.want)
+		}
+	}
+
+	err := (&TransferRequest{ToUserID: uuid.New(), Amount: 5, Currency: "USD"}).Validate()
+	if err == nil || err.Error() != "amount: transfer amounts in USD must be between 10.00 USD and 1,000,000.00 USD" {
+		t.Errorf("TransferRequest.Validate() = %v, want the allowed range", err)
+	}
+	err = (&CreditRequest{Amount: 60000, Currency: "USD"}).Validate()
+	if err == nil || err.Error() != "amount: credit amounts in USD must be between 1.00 USD and 50,000.00 USD" {
+		t.Errorf("CreditRequest.Validate() = %v, want the allowed range", err)
+	}
+	err = (&ScheduledTransactionRequest{TransactionType: "debit", Amount: 0.5, Currency: "USD", ScheduleType: "once", ExecuteAt: time.Now().Add(time.Hour)}).Validate()
+	if !errors.As(err, new(*AmountLimitError)) {
+		t.Errorf("ScheduledTransactionRequest.Validate() = %v, want an amount limit error", err)
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	hash := "$2a$10$" + strings.Repeat("a", 53)
+	csvData := "Username,email,currency,opening_balance,password_hash\n" +
+		"alice,Alice@Example.com,,100.50," + hash + "\n" +
+		"bob,bob@example.com,JPY,1500,\n" +
+		"al,short@example.com,USD,1,\n" +
+		"carol,carol@example.com,USD,-5,\n" +
+		"dave,dave@example.com,XXX,5,\n" +
+		"erin,erin@example.com,JPY,1.5,\n" +
+		"ALICE,alice2@example.com,USD,1,\n" +
+		"frank,alice@example.com,USD,1,\n" +
+		"grace,grace@example.com,USD\n" +
+		"heidi,heidi@example.com,USD,0,not-a-hash\n"
+
+	rows, rowErrors, err := ParseImportCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("ParseImportCSV: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("got %d valid rows, want 2: %+v", len(rows), rows)
+	}
+	alice := *rows[0]
+	if alice != (ImportRow{Line: 2, Username: "alice", Email: "alice@example.com", Role: "user", Currency: "USD", OpeningBalance: 100.5, PasswordHash: hash}) {
+		t.Errorf("row = %+v, want alice with the defaults filled in", alice)
+	}
+	if rows[1].Currency != "JPY" || rows[1].OpeningBalance != 1500 || rows[1].PasswordHash != "" {
+		t.Errorf("row = %+v, want bob with 1500 JPY", rows[1])
+	}
+
+	want := []struct {
+		line  int
+		field string
+	}{
+		{4, ImportColumnUsername},
+		{5, ImportColumnOpeningBalance},
+		{6, ImportColumnCurrency},
+		{7, ImportColumnOpeningBalance},
+		{8, ImportColumnUsername},
+		{9, ImportColumnEmail},
+		{10, ""},
+		{11, ImportColumnPasswordHash},
+	}
+	if len(rowErrors) != len(want) {
+		t.Fatalf("got row errors %+v, want %d", rowErrors, len(want))
+	}
+	for i, w := range want {
+		if rowErrors[i].Line != w.line || rowErrors[i].Field != w.field || rowErrors[i].Message == "" {
+			t.Errorf("row error %d = %+v, want line %d field %q", i, rowErrors[i], w.line, w.field)
+		}
+	}
+
+	for name, data := range map[string]string{
+		"empty file":       "",
+		"unknown column":   "username,email,balance\n",
+		"missing column":   "username,currency\n",
+		"repeated column":  "username,email,email\n",
+		"malformed quotes": "username,email\n\"alice,alice@example.com\n",
+	} {
+		if _, _, err := ParseImportCSV(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: ParseImportCSV should fail", name)
+		}
+	}
+}
+
+func TestNettingBatchNet(t *testing.T) {
+	x, y := uuid.New(), uuid.New()
+	a, b := NettingPair(x, y)
+	if a2, b2 := NettingPair(y, x); a2 != a || b2 != b {
+		t.Fatalf("NettingPair is not symmetric: (%s, %s) vs (%s, %s)", a, b, a2, b2)
+	}
+
+	batch := &NettingBatch{UserAID: a, UserBID: b}
+	batch.Net([]*NettedTransfer{
+		{FromUserID: a, Amount: 10.10},
+		{FromUserID: b, Amount: 25.20},
+		{FromUserID: a, Amount: 0.05},
+	})
+	if batch.TransferCount != 3 || batch.GrossAmount != 35.35 || batch.NetAmount != 15.05 {
+		t.Errorf("batch = %+v, want 3 transfers, gross 35.35, net 15.05", batch)
+	}
+	if batch.NetFromUserID == nil || *batch.NetFromUserID != b {
+		t.Errorf("net payer = %v, want user B", batch.NetFromUserID)
+	}
+	if saved := batch.MovementsSaved(); saved != 2 {
+		t.Errorf("MovementsSaved = %d, want 2", saved)
+	}
+
+	// Transfers that cancel out move no money at all
+	batch.Net([]*NettedTransfer{
+		{FromUserID: a, Amount: 5},
+		{FromUserID: b, Amount: 5},
+	})
+	if batch.NetAmount != 0 || batch.NetFromUserID != nil || batch.MovementsSaved() != 2 {
+		t.Errorf("batch = %+v, want no net movement and 2 movements saved", batch)
+	}
+}
+
+func TestBusinessCalendarAdjust(t *testing.T) {
+	calendar, err := NewBusinessCalendar("US", map[string][]string{
+		"US": {"2026-12-25", "2026-12-28"},
+		"TR": {"2026-10-29"},
+	})
+	if err != nil {
+		t.Fatalf("NewBusinessCalendar: %v", err)
+	}
+	if _, err := NewBusinessCalendar("US", map[string][]string{"US": {"25/12/2026"}}); err == nil {
+		t.Error("NewBusinessCalendar with a malformed date should fail")
+	}
+
+	at := func(day int) time.Time { return time.Date(2026, 12, day, 9, 30, 0, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		t      time.Time
+		rule   string
+		region string
+		want   time.Time
+	}{
+		{"business day", at(23), BusinessDayFollowing, "", at(23)},
+		{"no rule", at(25), BusinessDayNone, "", at(25)},
+		{"holiday then weekend then holiday", at(25), BusinessDayFollowing, "", at(29)},
+		{"back over weekend and holiday", at(28), BusinessDayPreceding, "", at(24)},
+		{"other region", at(25), BusinessDayFollowing, "TR", at(25)},
+		{"weekend in other region", at(26), BusinessDayFollowing, "TR", at(28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendar.Adjust(tt.t, tt.rule, tt.region); !got.Equal(tt.want) {
+				t.Errorf("Adjust() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := (*BusinessCalendar)(nil).Adjust(at(26), BusinessDayFollowing, ""); !got.Equal(at(26)) {
+		t.Errorf("nil calendar Adjust() = %v, want the day itself", got)
+	}
+	if !calendar.HasRegion("") || !calendar.HasRegion("TR") || calendar.HasRegion("DE") {
+		t.Error("HasRegion should know the default and configured regions only")
+	}
+
+	// A moved occurrence does not shift the ones after it
+	monthly := "monthly"
+	st := &ScheduledTransaction{ScheduleType: "recurring", RecurrencePattern: &monthly, ExecuteAt: at(26).AddDate(0, -1, 0),
+		BusinessDayRule: BusinessDayFollowing, Status: "active", IsActive: true}
+	st.ApplyCalendar(calendar)
+	if st.AdjustedExecuteAt != nil {
+		t.Errorf("November 26 moved to %v, want no adjustment", st.AdjustedExecuteAt)
+	}
+	if !st.AdvanceOccurrence() {
+		t.Fatal("AdvanceOccurrence() = false, want true")
+	}
+	st.ApplyCalendar(calendar)
+	if !st.DueAt().Equal(at(29)) || !st.ExecuteAt.Equal(at(26)) {
+		t.Errorf("December occurrence due %v for %v, want December 29 for December 26", st.DueAt(), st.ExecuteAt)
+	}
+	projected := st.ProjectExecutions(at(1), at(1).AddDate(0, 2, 0), 10, calendar)
+	if len(projected) != 2 || !projected[0].ExecuteAt.Equal(at(29)) || !projected[1].ExecuteAt.Equal(at(26).AddDate(0, 1, 0)) {
+		t.Errorf("ProjectExecutions() = %+v, want December 29 and January 26", projected)
+	}
+}
+
+func TestScheduledTransactionReassign(t *testing.T) {
+	now := time.Now()
+	payee, owner := uuid.New(), uuid.New()
+	templateID, alertedFor, retryAt := uuid.New(), now, now.Add(time.Hour)
+
+	st := &ScheduledTransaction{UserID: uuid.New(), ToUserID: &payee, TemplateID: &templateID, FundingAlertedFor: &alertedFor,
+		FailedAttempts: 2, RetryAt: &retryAt, Status: "paused"}
+	if err := st.Reassign(owner, now); err != nil {
+		t.Fatalf("Reassign() error = %v", err)
+	}
+	if st.UserID != owner || st.TemplateID != nil || st.FundingAlertedFor != nil || st.FailedAttempts != 0 || st.RetryAt != nil {
+		t.Errorf("after Reassign() = %+v, want owned by the new owner without template, alert or retry", st)
+	}
+
+	if err := st.Reassign(payee, now); err == nil || err.Error() != "cannot reassign a transfer to its recipient" {
+		t.Errorf("Reassign() to the payee error = %v", err)
+	}
+	st.Status = "cancelled"
+	if err := st.Reassign(uuid.New(), now); err == nil || err.Error() != "cannot reassign a cancelled scheduled transaction" {
+		t.Errorf("Reassign() of a cancelled transaction error = %v", err)
+	}
+}
+
+func TestTransactionReceiptSignature(t *testing.T) {
+	from, to := uuid.New(), uuid.New()
+	tx := &Transaction{ID: uuid.New(), FromUserID: &from, ToUserID: &to, Amount: 12.5, Currency: "USD", Type: "transfer",
+		Status: "success", Description: "rent", CreatedAt: time.Now()}
+	key := []byte("receipt-signing-key")
+
+	receipt, err := NewTransactionReceipt(tx, time.Now())
+	if err != nil {
+		t.Fatalf("NewTransactionReceipt() error = %v", err)
+	}
+	if receipt.FromAccountNumber != AccountNumberFor(from) || !receipt.Matches(tx) {
+		t.Errorf("receipt = %+v, want the transaction's details", receipt)
+	}
+	signed, err := receipt.Sign(key)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// A receipt survives the round trip through JSON
+	data, _ := json.Marshal(signed)
+	var decoded SignedReceipt
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := decoded.Validate(); err != nil || !decoded.VerifySignature(key) || !decoded.Receipt.Matches(tx) {
+		t.Errorf("decoded receipt does not verify: %v", err)
+	}
+
+	if decoded.VerifySignature([]byte("other-key")) {
+		t.Error("receipt verified under another key")
+	}
+	decoded.Receipt.Amount = 125
+	if decoded.VerifySignature(key) {
+		t.Error("altered receipt verified")
+	}
+
+	tx.Status = "pending"
+	if _, err := NewTransactionReceipt(tx, time.Now()); err == nil {
+		t.Error("NewTransactionReceipt() of a pending transaction succeeded")
+	}
+}
+
+func TestPaymentQRPayload(t *testing.T) {
+	account := AccountNumberFor(uuid.New())
+	q := &PaymentQR{AccountNumber: account, Amount: 12.5, Currency: "USD", Description: "Lunch & coffee"}
+
+	payload := q.Payload()
+	if !strings.HasPrefix(payload, "bankingsim://pay?") || !strings.Contains(payload, "amount=12.50") {
+		t.Errorf("Payload() = %q", payload)
+	}
+	parsed, err := ParsePaymentQR(payload)
+	if err != nil || *parsed != *q {
+		t.Fatalf("ParsePaymentQR() = %+v, %v; want %+v", parsed, err, q)
+	}
+
+	for _, bad := range []string{
+		"https://pay?account=" + account + "&currency=USD",
+		"bankingsim://pay?account=XS00&currency=USD",
+		"bankingsim://pay?account=" + account + "&currency=XXX",
+		"bankingsim://pay?account=" + account + "&currency=JPY&amount=1.5",
+		"bankingsim://pay?account=" + account + "&currency=USD&amount=0",
+	} {
+		if _, err := ParsePaymentQR(bad); err == nil {
+			t.Errorf("ParsePaymentQR(%q) succeeded", bad)
+		}
+	}
+
+	// The payer fills in only what the code leaves open
+	recipient := &AccountLookup{AccountNumber: account, UserID: uuid.New(), Username: "bob"}
+	draft, err := NewQRTransferDraft(q, &QRTransferRequest{Amount: 12.5}, recipient)
+	if err != nil || !draft.AmountFixed || draft.Amount != 12.5 || draft.ToUserID != recipient.UserID {
+		t.Errorf("draft = %+v, %v; want the fixed amount", draft, err)
+	}
+	if _, err := NewQRTransferDraft(q, &QRTransferRequest{Amount: 20}, recipient); err == nil {
+		t.Error("overriding a fixed amount succeeded")
+	}
+	open := &PaymentQR{AccountNumber: account, Currency: "USD"}
+	draft, err = NewQRTransferDraft(open, &QRTransferRequest{Amount: 20, Description: "rent"}, recipient)
+	if err != nil || draft.AmountFixed || draft.Amount != 20 || draft.Description != "rent" {
+		t.Errorf("draft = %+v, %v; want the payer's amount and description", draft, err)
+	}
+}
+
+func TestLoginSessions(t *testing.T) {
+	laptop := LoginDevice{IP: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+	fingerprint := laptop.Fingerprint()
+	if len(fingerprint) != 64 || strings.Contains(fingerprint, laptop.IP) {
+		t.Errorf("Fingerprint() = %q, want a hex SHA-256 hash", fingerprint)
+	}
+	if laptop.Fingerprint() != fingerprint {
+		t.Error("Fingerprint() differs for the same device")
+	}
+	for _, other := range []LoginDevice{
+		{IP: "198.51.100.2", UserAgent: laptop.UserAgent},
+		{IP: laptop.IP, UserAgent: "curl/8.0"},
+	} {
+		if other.Fingerprint() == fingerprint {
+			t.Errorf("Fingerprint() of %+v matches %+v", other, laptop)
+		}
+	}
+
+	now := time.Now()
+	session := &LoginSession{CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	if !session.IsActive(now) {
+		t.Error("new session is not active")
+	}
+	if session.IsActive(now.Add(2 * time.Hour)) {
+		t.Error("expired session is active")
+	}
+	session.RevokedAt = &now
+	if session.IsActive(now) {
+		t.Error("revoked session is active")
+	}
+}
+
+func TestAnomalyScores(t *testing.T) {
+	halfLife := 10 * time.Minute
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		halfLife time.Duration
+		expected float64
+	}{
+		{"no time passed", 0, halfLife, 8},
+		{"one half-life", halfLife, halfLife, 4},
+		{"three half-lives", 3 * halfLife, halfLife, 1},
+		{"clock skew", -time.Minute, halfLife, 8},
+		{"no half-life", time.Hour, 0, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecayScore(8, tt.elapsed, tt.halfLife); got != tt.expected {
+				t.Errorf("DecayScore() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	now := time.Now()
+	score := &AnomalyScore{Score: 6, UpdatedAt: now.Add(-halfLife)}
+	if got := score.At(now, halfLife); got != 3 {
+		t.Errorf("At() = %v, want 3", got)
+	}
+
+	if err := (&StepUpRequest{}).Validate(); err == nil || !strings.HasPrefix(err.Error(), "password:") {
+		t.Errorf("Validate() of an empty password = %v, want a password error", err)
+	}
+	if err := (&StepUpRequest{Password: "password123"}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestReviewApprovalRequestValidate(t *testing.T) {
+	if err := (&ReviewApprovalRequest{}).Validate(); err != nil {
+		t.Errorf("Validate() without a note = %v, want nil", err)
+	}
+	if err := (&ReviewApprovalRequest{Note: strings.Repeat("a", 500)}).Validate(); err != nil {
+		t.Errorf("Validate() of a 500 character note = %v, want nil", err)
+	}
+	if err := (&ReviewApprovalRequest{Note: strings.Repeat("a", 501)}).Validate(); err == nil || !strings.HasPrefix(err.Error(), "note:") {
+		t.Errorf("Validate() of a 501 character note = %v, want a note error", err)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	valid := func() Policy {
+		return Policy{
+			RollbackWindow:     Duration(24 * time.Hour),
+			AmountLimits:       AmountLimits{"USD": {TypeTransfer: {Min: 1, Max: 1000}}},
+			ApprovalThresholds: map[ApprovalAction]float64{ApprovalRollback: 500},
+			TransferFees:       map[string]TransferFee{"USD": {Fixed: 0.25, Percent: 1}},
+		}
+	}
+
+	policy := valid()
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *Policy)
+		prefix string
+	}{
+		{"no rollback window", func(p *Policy) { p.RollbackWindow = 0 }, "rollback_window:"},
+		{"unknown limit currency", func(p *Policy) { p.AmountLimits["XXX"] = p.AmountLimits["USD"] }, "amount_limits:"},
+		{"min above max", func(p *Policy) { p.AmountLimits["USD"][TypeTransfer] = AmountLimit{Min: 10, Max: 5} }, "amount_limits:"},
+		{"threshold of role changes", func(p *Policy) { p.ApprovalThresholds[ApprovalRoleChange] = 1 }, "approval_thresholds:"},
+		{"negative threshold", func(p *Policy) { p.ApprovalThresholds[ApprovalRollback] = -1 }, "approval_thresholds:"},
+		{"unknown fee currency", func(p *Policy) { p.TransferFees["XXX"] = TransferFee{} }, "transfer_fees:"},
+		{"percent above 100", func(p *Policy) { p.TransferFees["USD"] = TransferFee{Percent: 101} }, "transfer_fees:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid()
+			tt.modify(&policy)
+			if err := policy.Validate(); err == nil || !strings.HasPrefix(err.Error(), tt.prefix) {
+				t.Errorf("Validate() = %v, want a %s error", err, tt.prefix)
+			}
+		})
+	}
+
+	if fee := policy.TransferFee(100, "USD"); fee != 1.25 {
+		t.Errorf("TransferFee(100, USD) = %v, want 1.25", fee)
+	}
+	if fee := policy.TransferFee(100, "EUR"); fee != 0 {
+		t.Errorf("TransferFee(100, EUR) = %v, want 0", fee)
+	}
+
+	if policy.NeedsApproval(ApprovalRollback, 499.99) {
+		t.Error("NeedsApproval() below the threshold = true, want false")
+	}
+	if !policy.NeedsApproval(ApprovalRollback, 500) {
+		t.Error("NeedsApproval() at the threshold = false, want true")
+	}
+	if !policy.NeedsApproval(ApprovalBalanceAdjustment, 0.01) {
+		t.Error("NeedsApproval() without a threshold = false, want true")
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"rollback_window":"24h0m0s"`) {
+		t.Errorf("Marshal() = %s, want the rollback window as a duration string", data)
+	}
+	var decoded Policy
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.RollbackWindow != policy.RollbackWindow {
+		t.Errorf("Unmarshal() rollback window = %v, want %v", decoded.RollbackWindow, policy.RollbackWindow)
+	}
+	if err := json.Unmarshal([]byte(`{"rollback_window":86400}`), &decoded); err == nil {
+		t.Error("Unmarshal() of a numeric duration = nil, want an error")
+	}
+}
+
+func TestSetPolicyRequestValidate(t *testing.T) {
+	req := SetPolicyRequest{Policy: Policy{RollbackWindow: Duration(time.Hour)}}
+	if err := req.Validate(); err == nil || !strings.HasPrefix(err.Error(), "reason:") {
+		t.Errorf("Validate() without a reason = %v, want a reason error", err)
+	}
+	req.Reason = "Shorter rollback window"
+	if err := req.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestTimelinePoints(t *testing.T) {
+	from := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	points, err := TimelinePoints(from, from.Add(50*time.Hour), TimelineDay)
+	if err != nil {
+		t.Fatalf("TimelinePoints() error = %v", err)
+	}
+	want := []time.Time{from, from.AddDate(0, 0, 1), from.AddDate(0, 0, 2), from.Add(50 * time.Hour)}
+	if len(points) != len(want) {
+		t.Fatalf("TimelinePoints() = %v, want %v", points, want)
+	}
+	for i := range want {
+		if !points[i].Equal(want[i]) {
+			t.Errorf("point %d = %v, want %v", i, points[i], want[i])
+		}
+	}
+
+	// A range that is a whole number of intervals ends on its last interval, not a repeat of it
+	if points, _ := TimelinePoints(from, from.AddDate(0, 0, 14), TimelineWeek); len(points) != 3 {
+		t.Errorf("two weeks by week = %v, want 3 points", points)
+	}
+	if points, _ := TimelinePoints(from, from.AddDate(0, 3, 0), TimelineMonth); len(points) != 4 || !points[2].Equal(from.AddDate(0, 2, 0)) {
+		t.Errorf("three months by month = %v, want 4 points counted from the start", points)
+	}
+
+	if _, err := TimelinePoints(from, from, TimelineDay); err == nil {
+		t.Error("TimelinePoints() of an empty range = nil error, want an error")
+	}
+	if _, err := TimelinePoints(from, from.Add(MaxTimelinePoints*time.Hour), TimelineHour); err == nil {
+		t.Error("TimelinePoints() over the point limit = nil error, want an error")
+	}
+	if points, err := TimelinePoints(from, from.Add((MaxTimelinePoints-1)*time.Hour), TimelineHour); err != nil || len(points) != MaxTimelinePoints {
+		t.Errorf("TimelinePoints() at the point limit = %d points, %v; want %d", len(points), err, MaxTimelinePoints)
+	}
+}
+
+func TestParseSummaryMonth(t *testing.T) {
+	start, end, err := ParseSummaryMonth("2026-02")
+	if err != nil {
+		t.Fatalf("ParseSummaryMonth() error = %v", err)
+	}
+	if !start.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseSummaryMonth() = %v, %v; want February 2026", start, end)
+	}
+	if SummaryMonth(end.Add(-time.Nanosecond)) != "2026-02" {
+		t.Errorf("SummaryMonth() of the month's last instant = %q, want 2026-02", SummaryMonth(end.Add(-time.Nanosecond)))
+	}
+
+	for _, month := range []string{"2026-2", "2026-13", "02-2026", ""} {
+		if _, _, err := ParseSummaryMonth(month); err == nil {
+			t.Errorf("ParseSummaryMonth(%q) succeeded, want error", month)
+		}
+	}
+}
+
+func TestSummarizeMonth(t *testing.T) {
+	user, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	tx := func(typ TransactionType, status TransactionStatus, from, to *uuid.UUID, amount float64, description string) *Transaction {
+		return &Transaction{ID: uuid.New(), Type: string(typ), Status: string(status), FromUserID: from, ToUserID: to, Amount: amount, Description: description}
+	}
+	transfer := tx(TypeTransfer, StatusSuccess, &user, &alice, 30, "")
+
+	summary := SummarizeMonth(user, "2026-02", "USD", 100, []*Transaction{
+		tx(TypeCredit, StatusSuccess, nil, &user, 20, ""),
+		transfer,
+		tx(TypeDebit, StatusSuccess, &user, nil, 0.3, TransferFeeDescription(transfer.ID)),
+		tx(TypeTransfer, StatusSuccess, &bob, &user, 5, ""),
+		tx(TypeTransfer, StatusSuccess, &bob, &user, 5, ""),
+		tx(TypeDebit, StatusFailed, &user, nil, 1000, ""),
+	})
+
+	if summary.OpeningBalance != 100 || summary.ClosingBalance != 99.7 {
+		t.Errorf("balances = %v to %v, want 100 to 99.7", summary.OpeningBalance, summary.ClosingBalance)
+	}
+	if summary.TotalIn != 30 || summary.TotalOut != 30.3 || summary.FeesPaid != 0.3 {
+		t.Errorf("totals = %v in, %v out, %v fees; want 30, 30.3, 0.3", summary.TotalIn, summary.TotalOut, summary.FeesPaid)
+	}
+	if summary.TransactionCount != 5 {
+		t.Errorf("TransactionCount = %d, want 5 (failed transactions do not count)", summary.TransactionCount)
+	}
+
+	want := []SummaryCounterparty{
+		{UserID: alice, Sent: 30, TransferCount: 1},
+		{UserID: bob, Received: 10, TransferCount: 2},
+	}
+	if len(summary.TopCounterparties) != len(want) {
+		t.Fatalf("TopCounterparties = %+v, want %+v", summary.TopCounterparties, want)
+	}
+	for i := range want {
+		if summary.TopCounterparties[i] != want[i] {
+			t.Errorf("TopCounterparties[%d] = %+v, want %+v", i, summary.TopCounterparties[i], want[i])
+		}
+	}
+
+	var many []*Transaction
+	for i := 0; i < MonthlySummaryTopCounterparties+2; i++ {
+		other := uuid.New()
+		many = append(many, tx(TypeTransfer, StatusSuccess, &user, &other, 1, ""))
+	}
+	if got := SummarizeMonth(user, "2026-02", "USD", 10, many).TopCounterparties; len(got) != MonthlySummaryTopCounterparties {
+		t.Errorf("len(TopCounterparties) = %d, want %d", len(got), MonthlySummaryTopCounterparties)
+	}
+}
+
+func TestDetectRecurring(t *testing.T) {
+	user, landlord := uuid.New(), uuid.New()
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	tx := func(typ TransactionType, from, to *uuid.UUID, amount float64, at time.Time) *Transaction {
+		return &Transaction{ID: uuid.New(), Type: string(typ), Status: string(StatusSuccess), FromUserID: from, ToUserID: to, Amount: amount, CreatedAt: at}
+	}
+
+	var history []*Transaction
+	for _, at := range []time.Time{
+		time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC),
+	} {
+		history = append(history, tx(TypeTransfer, &user, &landlord, 800, at))
+	}
+	// Weekly credits that stopped a month ago
+	for i := 0; i < 4; i++ {
+		history = append(history, tx(TypeCredit, nil, &user, 50, time.Date(2026, 2, 1+7*i, 9, 0, 0, 0, time.UTC)))
+	}
+	// Irregular debits
+	for _, day := range []int{1, 3, 20} {
+		history = append(history, tx(TypeDebit, &user, nil, 10, time.Date(2026, 3, day, 9, 0, 0, 0, time.UTC)))
+	}
+
+	patterns := DetectRecurring(user, history, now)
+	if len(patterns) != 1 {
+		t.Fatalf("DetectRecurring() = %+v, want only the monthly rent", patterns)
+	}
+	rent := patterns[0]
+	if rent.Period != RecurringMonthly || rent.Direction != CashFlowOut || rent.Amount != 800 || rent.Occurrences != 4 {
+		t.Errorf("pattern = %+v, want monthly outgoing 800 seen 4 times", rent)
+	}
+	if rent.CounterpartyID == nil || *rent.CounterpartyID != landlord {
+		t.Errorf("CounterpartyID = %v, want %v", rent.CounterpartyID, landlord)
+	}
+	if !rent.NextAt.Equal(time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextAt = %v, want 2026-05-01T09:00:00Z", rent.NextAt)
+	}
+}
+
+func TestForecastCashFlow(t *testing.T) {
+	user, landlord := uuid.New(), uuid.New()
+	now := time.Date(2026, 4, 28, 12, 0, 0, 0, time.UTC)
+	rent := RecurringPattern{
+		Type: string(TypeTransfer), Direction: CashFlowOut, CounterpartyID: &landlord, Amount: 800,
+		Period: RecurringMonthly, LastAt: time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC), NextAt: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+	}
+	salary := UpcomingExecution{TransactionType: string(TypeCredit), Amount: 500, ExecuteAt: time.Date(2026, 4, 30, 8, 0, 0, 0, time.UTC)}
+
+	forecast := ForecastCashFlow(user, "USD", 200, now, 5, []UpcomingExecution{salary}, []RecurringPattern{rent})
+
+	var balances []float64
+	for _, day := range forecast.Days {
+		balances = append(balances, day.Balance)
+	}
+	want := []float64{200, 200, 700, -100, -100}
+	for i := range want {
+		if balances[i] != want[i] {
+			t.Fatalf("balances = %v, want %v", balances, want)
+		}
+	}
+	if forecast.Days[0].Date != "2026-04-28" || forecast.Days[3].Outflow != 800 {
+		t.Errorf("days = %+v, want 2026-04-28 first and the rent on 2026-05-01", forecast.Days)
+	}
+	if forecast.FirstNegativeDate == nil || *forecast.FirstNegativeDate != "2026-05-01" {
+		t.Errorf("FirstNegativeDate = %v, want 2026-05-01", forecast.FirstNegativeDate)
+	}
+	if forecast.LowestBalance != -100 || len(forecast.RecurringPatterns) != 1 {
+		t.Errorf("LowestBalance = %v with %d patterns, want -100 with 1", forecast.LowestBalance, len(forecast.RecurringPatterns))
+	}
+
+	// A scheduled transfer moving the same money replaces the pattern instead of adding to it
+	scheduledRent := UpcomingExecution{TransactionType: string(TypeTransfer), Amount: 800, ToUserID: &landlord, ExecuteAt: rent.NextAt}
+	forecast = ForecastCashFlow(user, "USD", 200, now, 5, []UpcomingExecution{scheduledRent}, []RecurringPattern{rent})
+	if len(forecast.RecurringPatterns) != 0 || forecast.Days[4].Balance != -600 {
+		t.Errorf("with a scheduled rent, patterns = %d and final balance = %v; want 0 and -600", len(forecast.RecurringPatterns), forecast.Days[4].Balance)
+	}
+}
+
+func TestTransactionCategoryValidation(t *testing.T) {
+	to := uuid.New()
+
+	tests := []struct {
+		name      string
+		validator interface{ Validate() error }
+		wantErr   bool
+	}{
+		{"uncategorized debit", &DebitRequest{Amount: 10, Currency: "USD"}, false},
+		{"debit with category", &DebitRequest{Amount: 10, Currency: "USD", Category: "groceries"}, false},
+		{"debit with unknown category", &DebitRequest{Amount: 10, Currency: "USD", Category: "yachts"}, true},
+		{"transfer with category", &TransferRequest{ToUserID: to, Amount: 10, Currency: "USD", Category: "housing"}, false},
+		{"transfer with unknown category", &TransferRequest{ToUserID: to, Amount: 10, Currency: "USD", Category: "Housing"}, true},
+		{"budget", &SetBudgetRequest{Limit: 250}, false},
+		{"budget without limit", &SetBudgetRequest{}, true},
+		{"negative budget", &SetBudgetRequest{Limit: -5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validator.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildSpendingReport(t *testing.T) {
+	user := uuid.New()
+	budgets := []*Budget{
+		{UserID: user, Category: "dining", Limit: 100},
+		{UserID: user, Category: "travel", Limit: 400},
+	}
+	spent := map[string]float64{"dining": 120.5, "groceries": 80, "": 15, "transport": 80}
+
+	report := BuildSpendingReport(user, "2026-05", "USD", spent, budgets)
+	if report.Total != 295.5 || report.Month != "2026-05" || report.Currency != "USD" {
+		t.Errorf("report = %+v, want 295.50 USD spent in 2026-05", report)
+	}
+
+	var categories []string
+	for _, c := range report.Categories {
+		categories = append(categories, c.Category)
+	}
+	want := []string{"dining", "travel", "groceries", "transport", ""}
+	if strings.Join(categories, ",") != strings.Join(want, ",") {
+		t.Fatalf("categories = %q, want %q", categories, want)
+	}
+
+	dining := report.Categories[0]
+	if !dining.OverBudget || dining.Remaining == nil || *dining.Remaining != -20.5 || *dining.PercentUsed != 120.5 {
+		t.Errorf("dining = %+v, want 20.50 over budget at 120.5%%", dining)
+	}
+	travel := report.Categories[1]
+	if travel.OverBudget || travel.Spent != 0 || travel.Remaining == nil || *travel.Remaining != 400 || *travel.PercentUsed != 0 {
+		t.Errorf("travel = %+v, want an untouched budget of 400", travel)
+	}
+	if groceries := report.Categories[2]; groceries.Limit != nil || groceries.OverBudget {
+		t.Errorf("groceries = %+v, want no budget", groceries)
+	}
+}
+
+func TestDormantAccountCSVRecord(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	last := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	account := &DormantAccount{
+		UserID:         uuid.New(),
+		Username:       "alice",
+		Email:          "alice@example.com",
+		AccountNumber:  "1000000001",
+		Currency:       "USD",
+		Balance:        1234.5,
+		LastActivityAt: &last,
+		InactiveSince:  last,
+		CreatedAt:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	account.SetDaysInactive(now)
+	if account.DaysInactive != 121 {
+		t.Errorf("DaysInactive = %d, want 121", account.DaysInactive)
+	}
+
+	record := account.CSVRecord()
+	if len(record) != len(DormantAccountCSVHeader) {
+		t.Fatalf("record has %d fields, header has %d", len(record), len(DormantAccountCSVHeader))
+	}
+	if record[5] != "1234.50" || record[6] != "2026-03-01T09:30:00Z" || record[8] != "121" {
+		t.Errorf("record = %q, want balance 1234.50, last activity 2026-03-01T09:30:00Z and 121 days", record)
+	}
+
+	account.LastActivityAt = nil
+	if record := account.CSVRecord(); record[6] != "" {
+		t.Errorf("last_activity_at = %q, want empty without a transaction", record[6])
+	}
+
+	for _, days := range []int{0, MaxDormancyDays + 1} {
+		if err := ValidateDormancyDays(days); err == nil {
+			t.Errorf("ValidateDormancyDays(%d) = nil, want error", days)
+		}
+	}
+	if err := ValidateDormancyDays(DefaultDormancyDays); err != nil {
+		t.Errorf("ValidateDormancyDays(%d) = %v", DefaultDormancyDays, err)
+	}
+}
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         APIEndpointClass
+	}{
+		{"GET", "/api/v1/balances/current", EndpointClassRead},
+		{"GET", "/api/v1/admin/treasury", EndpointClassAdmin},
+		{"DELETE", "/api/v1/users/abc", EndpointClassAdmin},
+		{"POST", "/api/v1/transactions/transfer", EndpointClassMoneyMovement},
+		{"POST", "/api/v1/transactions/abc/rollback", EndpointClassMoneyMovement},
+		{"POST", "/api/v1/transfer-templates/abc/execute", EndpointClassMoneyMovement},
+		{"POST", "/api/v1/payment-requests/abc/approve", EndpointClassMoneyMovement},
+		{"POST", "/api/v1/payment-requests/abc/decline", EndpointClassWrite},
+		{"POST", "/api/v1/transactions/abc/disputes", EndpointClassWrite},
+		{"PUT", "/api/v1/budgets/dining", EndpointClassWrite},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyEndpoint(tt.method, tt.path); got != tt.want {
+			t.Errorf("ClassifyEndpoint(%s %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAPIQuota(t *testing.T) {
+	invalid := []SetAPIQuotaRequest{
+		{},
+		{DailyLimit: -1},
+		{ClassLimits: map[string]int64{"money_movement": 0}},
+		{ClassLimits: map[string]int64{"uploads": 10}},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", req)
+		}
+	}
+
+	quota := &APIQuota{DailyLimit: 100, ClassLimits: map[string]int64{"money_movement": 5}}
+	usage := NewAPIUsageDay("2026-06-01", map[string]int64{"read": 90, "money_movement": 6})
+	if exceeded, limit := quota.Exceeded(&usage, "money_movement"); !exceeded || limit != 5 {
+		t.Errorf("Exceeded(money_movement) = %v, %d; want the class limit of 5", exceeded, limit)
+	}
+	if exceeded, _ := quota.Exceeded(&usage, "read"); exceeded {
+		t.Error("Exceeded(read) = true at 96 of 100 calls")
+	}
+
+	now := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
+	report := BuildAPIUsageReport(uuid.New(), quota, []APIUsageDay{usage}, now)
+	if report.Remaining == nil || *report.Remaining != 4 || report.ClassRemaining["money_movement"] != 0 {
+		t.Errorf("report = %+v, want 4 calls and no money movement remaining", report)
+	}
+	if !report.ResetAt.Equal(time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ResetAt = %v, want the next UTC midnight", report.ResetAt)
+	}
+}
+
+func TestParseTransactionWait(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"1500ms", 1500 * time.Millisecond, false},
+		{"10", 10 * time.Second, false},
+		{"0", 0, false},
+		{"31s", 0, true},
+		{"-1s", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTransactionWait(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTransactionWait(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMonthlyActivityReport(t *testing.T) {
+	userID := uuid.New()
+	aggregates := []*TransactionAggregate{
+		{Month: "2026-04", Direction: DirectionIn, Type: "credit", Currency: "USD", Count: 2, Amount: 150.10},
+		{Month: "2026-04", Direction: DirectionOut, Type: "debit", Category: "groceries", Currency: "USD", Count: 3, Amount: 60, ReversedAmount: 10},
+		{Month: "2026-04", Direction: DirectionOut, Type: "transfer", Category: "groceries", Currency: "USD", Count: 1, Amount: 25.5},
+		{Month: "2026-06", Direction: DirectionIn, Type: "transfer", Currency: "USD", Count: 1, Amount: 40},
+		{Month: "2026-06", Direction: DirectionIn, Type: "credit", Currency: "EUR", Count: 1, Amount: 999},
+		{Month: "2026-01", Direction: DirectionIn, Type: "credit", Currency: "USD", Count: 1, Amount: 5},
+	}
+
+	from, to := ActivityRange(time.Date(2026, 6, 20, 23, 0, 0, 0, time.UTC), 3)
+	if !from.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("ActivityRange = %v, %v; want April through June", from, to)
+	}
+
+	report := BuildMonthlyActivityReport(userID, "USD", aggregates, from, to)
+	if len(report.Months) != 3 || report.Months[0].Month != "2026-04" || report.Months[2].Month != "2026-06" {
+		t.Fatalf("months = %+v, want April, May and June", report.Months)
+	}
+	if april := report.Months[0]; april.In != 150.10 || april.Out != 85.5 || april.Net != 64.6 || april.CountIn != 2 || april.CountOut != 4 {
+		t.Errorf("April = %+v, want 150.10 in and 85.50 out in 6 transactions", april)
+	}
+	if may := report.Months[1]; may.In != 0 || may.Out != 0 || may.CountIn != 0 {
+		t.Errorf("May = %+v, want zeros", may)
+	}
+	if june := report.Months[2]; june.In != 40 || june.CountIn != 1 {
+		t.Errorf("June = %+v, want only the USD transfer", june)
+	}
+
+	spending := SpendingByCategory(aggregates)
+	if len(spending) != 1 || spending["groceries"] != 75.5 {
+		t.Errorf("spending = %v, want 75.50 on groceries less the reversal", spending)
+	}
+
+	for _, months := range []int{0, MaxActivityMonths + 1} {
+		if err := ValidateActivityMonths(months); err == nil {
+			t.Errorf("ValidateActivityMonths(%d) accepted", months)
+		}
+	}
+}
+
+func TestAdviseIndexes(t *testing.T) {
+	tables := map[string]TableStatistics{
+		"public.transactions":           {Rows: 250000},
+		"public.events_2026_06":         {Rows: 80000, Parent: "public.events"},
+		"archive.events_2025_01":        {Rows: 500, Parent: "archive.events"},
+		"public.scheduled_transactions": {Rows: 40000},
+	}
+
+	history := &QueryPlan{Name: "transaction_history", Plan: []byte(`[{"Plan": {
+		"Node Type": "Limit", "Total Cost": 9120.5, "Plan Rows": 50,
+		"Plans": [{"Node Type": "Sort", "Plans": [{
+			"Node Type": "Seq Scan", "Schema": "public", "Relation Name": "transactions", "Plan Rows": 25,
+			"Filter": "((transactions.from_user_id = '00000000-0000-0000-0000-000000000000'::uuid) OR (transactions.to_user_id = '00000000-0000-0000-0000-000000000000'::uuid))"
+		}]}]
+	}}]`)}
+	advice, err := AdviseIndexes(history, tables, MinIndexAdviceTableRows)
+	if err != nil {
+		t.Fatalf("AdviseIndexes: %v", err)
+	}
+	if advice.TotalCost != 9120.5 || advice.EstimatedRows != 50 || len(advice.SequentialScans) != 1 || advice.SequentialScans[0].TableRows != 250000 {
+		t.Errorf("advice = %+v, want the cost and the scan of transactions", advice)
+	}
+	if len(advice.Suggestions) != 2 || advice.Suggestions[0].Statement != "CREATE INDEX CONCURRENTLY idx_transactions_from_user_id ON public.transactions (from_user_id)" ||
+		!slices.Equal(advice.Suggestions[1].Columns, []string{"to_user_id"}) {
+		t.Errorf("history suggestions = %+v, want an index per alternative", advice.Suggestions)
+	}
+
+	// Partitions are indexed through their partitioned table, small ones not at all
+	events := &QueryPlan{Name: "events_by_aggregate", Plan: []byte(`[{"Plan": {"Node Type": "Append", "Plans": [
+		{"Node Type": "Seq Scan", "Schema": "public", "Relation Name": "events_2026_06",
+		 "Filter": "(((events_2026_06.aggregate_type)::text = 'user'::text) AND (events_2026_06.aggregate_id = '00000000-0000-0000-0000-000000000000'::uuid))"},
+		{"Node Type": "Seq Scan", "Schema": "archive", "Relation Name": "events_2025_01",
+		 "Filter": "(((events_2025_01.aggregate_type)::text = 'user'::text) AND (events_2025_01.aggregate_id = '00000000-0000-0000-0000-000000000000'::uuid))"},
+		{"Node Type": "Index Scan", "Index Name": "idx_events_default_aggregate", "Schema": "public", "Relation Name": "events_default"}
+	]}}]`)}
+	if advice, err = AdviseIndexes(events, tables, MinIndexAdviceTableRows); err != nil {
+		t.Fatalf("AdviseIndexes: %v", err)
+	}
+	if len(advice.Suggestions) != 1 || advice.Suggestions[0].Statement != "CREATE INDEX idx_events_aggregate_type_aggregate_id ON public.events (aggregate_type, aggregate_id)" {
+		t.Errorf("events suggestions = %+v, want one on the partitioned table", advice.Suggestions)
+	}
+	if len(advice.SequentialScans) != 2 || !slices.Equal(advice.IndexesUsed, []string{"idx_events_default_aggregate"}) {
+		t.Errorf("advice = %+v, want both scans and the index used", advice)
+	}
+
+	// Only conditions every row must meet are indexed, equality before range
+	due := &QueryPlan{Name: "due_schedules", Plan: []byte(`[{"Plan": {"Node Type": "Seq Scan", "Schema": "public", "Relation Name": "scheduled_transactions",
+		"Filter": "(scheduled_transactions.is_active AND ((scheduled_transactions.locked_until IS NULL) OR (scheduled_transactions.locked_until < now())) AND (scheduled_transactions.execute_at <= now()) AND ((scheduled_transactions.status)::text = 'active'::text))"}}]`)}
+	if advice, err = AdviseIndexes(due, tables, MinIndexAdviceTableRows); err != nil {
+		t.Fatalf("AdviseIndexes: %v", err)
+	}
+	if len(advice.Suggestions) != 1 || !slices.Equal(advice.Suggestions[0].Columns, []string{"status", "execute_at"}) {
+		t.Errorf("due suggestions = %+v, want status then execute_at", advice.Suggestions)
+	}
+
+	if _, err := AdviseIndexes(&QueryPlan{Name: "broken", Plan: []byte(`[]`)}, tables, MinIndexAdviceTableRows); err == nil {
+		t.Error("AdviseIndexes accepted an empty plan")
+	}
+}
+
+func TestParseClockAdvance(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "36h", want: 36 * time.Hour},
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "400d", want: MaxClockAdvance},
+		{value: "401d", wantErr: true},
+		{value: "0d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "1w", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseClockAdvance(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseClockAdvance(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSimulationTrace(t *testing.T) {
+	to := 2
+	entries := []SimulationTraceEntry{
+		{Seq: 0, AtMs: 5, Op: SimulationCredit, From: 1, Amount: 10, Result: "ok"},
+		{Seq: 1, AtMs: 9, Op: SimulationTransfer, From: 0, To: &to, Amount: 3.5, Result: "ok"},
+		{Seq: 2, AtMs: 12, Op: SimulationDebit, From: 2, Amount: 7, Injected: true, Result: "unsupported currency"},
+	}
+
+	inOrder := NewSimulationTrace()
+	var body strings.Builder
+	for _, entry := range entries {
+		inOrder.Add(entry)
+		line, _ := json.Marshal(entry)
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+	sum := sha256.Sum256([]byte(body.String()))
+	if got := inOrder.Digest(); got != hex.EncodeToString(sum[:]) {
+		t.Errorf("Digest() = %s, want the SHA-256 of the entries as JSON lines", got)
+	}
+
+	// Operations completing out of order are hashed in sequence order
+	outOfOrder := NewSimulationTrace()
+	outOfOrder.Add(entries[2])
+	outOfOrder.Add(entries[1])
+	if got := outOfOrder.Entries(); len(got) != 0 {
+		t.Errorf("Entries() = %d before the first operation completed, want none", len(got))
+	}
+	outOfOrder.Add(entries[0])
+	if outOfOrder.Digest() != inOrder.Digest() {
+		t.Error("out of order trace has a different digest")
+	}
+	if got := outOfOrder.Entries(); len(got) != 3 || got[1].Seq != 1 || *got[1].To != 2 {
+		t.Errorf("Entries() = %+v, want the entries in sequence order", got)
+	}
+
+	// Only the first entries are kept, but all are hashed
+	long := NewSimulationTrace()
+	for seq := int64(0); seq <= MaxSimulationTraceLength; seq++ {
+		long.Add(SimulationTraceEntry{Seq: seq, Op: SimulationCredit, Result: "ok"})
+	}
+	before := long.Digest()
+	long.Add(SimulationTraceEntry{Seq: MaxSimulationTraceLength + 1, Op: SimulationCredit, Result: "ok"})
+	if got := long.Entries(); len(got) != MaxSimulationTraceLength {
+		t.Errorf("Entries() = %d, want %d", len(got), MaxSimulationTraceLength)
+	}
+	if long.Digest() == before {
+		t.Error("Digest() ignores entries past the kept ones")
+	}
+}
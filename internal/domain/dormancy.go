@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Accounts are reported dormant after DefaultDormancyDays without transactions unless asked
+// otherwise, and the threshold is at most MaxDormancyDays.
+const (
+	DefaultDormancyDays = 90
+	MaxDormancyDays     = 3650
+)
+
+// DormantAccount is an active user who has not sent or received a transaction since a cutoff.
+type DormantAccount struct {
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	Username       string     `json:"username" db:"username"`
+	Email          string     `json:"email" db:"email"`
+	AccountNumber  string     `json:"account_number" db:"account_number"`
+	Currency       string     `json:"currency" db:"currency"`
+	Balance        float64    `json:"balance" db:"balance"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty" db:"last_activity_at"` // The latest transaction; nil if the user never had one
+	InactiveSince  time.Time  `json:"inactive_since" db:"inactive_since"`               // The latest transaction, or account creation without one
+	DaysInactive   int        `json:"days_inactive"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// DormantAccountsReport lists the accounts dormant for at least Days days, longest inactive first.
+type DormantAccountsReport struct {
+	Days     int               `json:"days"`
+	Cutoff   time.Time         `json:"cutoff"` // Accounts inactive since before this instant are dormant
+	Total    int               `json:"total"`  // Across every page
+	Accounts []*DormantAccount `json:"accounts"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// ValidateDormancyDays checks the number of inactive days after which an account is dormant.
+func ValidateDormancyDays(days int) error {
+	if days < 1 || days > MaxDormancyDays {
+		return fmt.Errorf("days must be between 1 and %d, got %d", MaxDormancyDays, days)
+	}
+	return nil
+}
+
+// DormancyCutoff returns the instant accounts inactive since before are dormant after days days.
+func DormancyCutoff(now time.Time, days int) time.Time {
+	return now.AddDate(0, 0, -days)
+}
+
+// SetDaysInactive sets how many whole days the account has been inactive at now.
+func (a *DormantAccount) SetDaysInactive(now time.Time) {
+	a.DaysInactive = int(now.Sub(a.InactiveSince) / (24 * time.Hour))
+}
+
+// DormantAccountCSVHeader is the header row of dormant account CSV exports.
+var DormantAccountCSVHeader = []string{
+	"user_id", "username", "email", "account_number", "currency", "balance",
+	"last_activity_at", "inactive_since", "days_inactive", "created_at",
+}
+
+// CSVRecord returns the account as a row of a CSV export, in the order of DormantAccountCSVHeader.
+func (a *DormantAccount) CSVRecord() []string {
+	lastActivity := ""
+	if a.LastActivityAt != nil {
+		lastActivity = a.LastActivityAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		a.UserID.String(),
+		a.Username,
+		a.Email,
+		a.AccountNumber,
+		a.Currency,
+		FormatDecimal(a.Balance, a.Currency, ""),
+		lastActivity,
+		a.InactiveSince.UTC().Format(time.RFC3339),
+		strconv.Itoa(a.DaysInactive),
+		a.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
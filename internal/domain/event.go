@@ -18,6 +18,7 @@ type Event struct {
 	EventMetadata []byte    `json:"event_metadata,omitempty" db:"event_metadata"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	Version       int       `json:"version" db:"version"`
+	EventVersion  int       `json:"event_version" db:"event_version"` // Shape of EventData, see CurrentEventVersion
 }
 
 // EventEnvelope wraps an event with metadata for serialization
@@ -37,8 +38,23 @@ const (
 	AggregateBalance AggregateType = "balance"
 	// AggregateTransaction represents transaction aggregate type
 	AggregateTransaction AggregateType = "transaction"
+	// AggregateDispute represents dispute aggregate type
+	AggregateDispute AggregateType = "dispute"
+	// AggregatePaymentRequest represents payment request aggregate type
+	AggregatePaymentRequest AggregateType = "payment_request"
+	// AggregateBalanceAlert represents low-balance alert aggregate type, keyed by user ID
+	AggregateBalanceAlert AggregateType = "balance_alert"
 )
 
+// IsValid reports whether t is a known aggregate type.
+func (t AggregateType) IsValid() bool {
+	switch t {
+	case AggregateUser, AggregateBalance, AggregateTransaction, AggregateDispute, AggregatePaymentRequest, AggregateBalanceAlert:
+		return true
+	}
+	return false
+}
+
 // EventType defines valid event types for the event sourcing system.
 type EventType string
 
@@ -67,6 +83,31 @@ const (
 	EventTransactionRolledBack EventType = "TransactionRolledBack"
 	// EventTransferExecuted represents transfer executed event
 	EventTransferExecuted EventType = "TransferExecuted"
+	// EventTransactionReversed represents a transaction being fully or partially rolled back
+	EventTransactionReversed EventType = "TransactionReversed"
+
+	// EventDisputeOpened represents dispute opened event
+	EventDisputeOpened EventType = "DisputeOpened"
+	// EventDisputeStatusChanged represents dispute status change event
+	EventDisputeStatusChanged EventType = "DisputeStatusChanged"
+
+	// EventPaymentRequested represents a payment request being sent to a payer
+	EventPaymentRequested EventType = "PaymentRequested"
+	// EventPaymentRequestApproved represents a payment request approved and paid by the payer
+	EventPaymentRequestApproved EventType = "PaymentRequestApproved"
+	// EventPaymentRequestDeclined represents a payment request declined by the payer
+	EventPaymentRequestDeclined EventType = "PaymentRequestDeclined"
+	// EventPaymentRequestExpired represents a payment request expiring unanswered
+	EventPaymentRequestExpired EventType = "PaymentRequestExpired"
+
+	// EventLowBalanceAlert represents a debit leaving a balance below the user's alert threshold
+	EventLowBalanceAlert EventType = "LowBalanceAlert"
+
+	// EventLoginFromNewDevice represents a login from a device the user had not used before
+	EventLoginFromNewDevice EventType = "LoginFromNewDevice"
+
+	// EventAccountDormant represents an account going without transactions for the dormancy period
+	EventAccountDormant EventType = "AccountDormant"
 )
 
 // UserRegisteredEvent represents a user registration event
@@ -121,12 +162,17 @@ type TransferExecutedEvent struct {
 
 // TransactionStartedEvent represents transaction initiation
 type TransactionStartedEvent struct {
-	TransactionID uuid.UUID  `json:"transaction_id"`
-	UserID        uuid.UUID  `json:"user_id,omitempty"`
-	FromUserID    *uuid.UUID `json:"from_user_id,omitempty"`
-	ToUserID      *uuid.UUID `json:"to_user_id,omitempty"`
-	Amount        float64    `json:"amount"`
-	Type          string     `json:"type"`
+	TransactionID   uuid.UUID  `json:"transaction_id"`
+	UserID          uuid.UUID  `json:"user_id,omitempty"`
+	FromUserID      *uuid.UUID `json:"from_user_id,omitempty"`
+	ToUserID        *uuid.UUID `json:"to_user_id,omitempty"`
+	Amount          float64    `json:"amount"`
+	Type            string     `json:"type"`
+	Description     string     `json:"description,omitempty"`
+	Category        string     `json:"category,omitempty"`
+	ClientReference string     `json:"client_reference,omitempty"`
+
+	Origin *TransactionOrigin `json:"origin,omitempty"`
 }
 
 // TransactionCompletedEvent represents transaction completion
@@ -150,6 +196,78 @@ type TransactionFailedEvent struct {
 	Error         string     `json:"error"`
 }
 
+// TransactionReversedEvent represents a rollback moving money of a transaction back
+type TransactionReversedEvent struct {
+	TransactionID         uuid.UUID  `json:"transaction_id"`          // The rollback transaction
+	OriginalTransactionID uuid.UUID  `json:"original_transaction_id"` // The transaction rolled back
+	FromUserID            *uuid.UUID `json:"from_user_id,omitempty"`
+	ToUserID              *uuid.UUID `json:"to_user_id,omitempty"`
+	Amount                float64    `json:"amount"`
+	Currency              string     `json:"currency"`
+	Type                  string     `json:"type"`
+	Partial               bool       `json:"partial"`
+	ReversedBy            *uuid.UUID `json:"reversed_by,omitempty"` // Unset for admin rollbacks
+}
+
+// DisputeOpenedEvent represents a dispute being opened on a transaction
+type DisputeOpenedEvent struct {
+	DisputeID     uuid.UUID `json:"dispute_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Reason        string    `json:"reason"`
+}
+
+// DisputeStatusChangedEvent represents a dispute moving between statuses
+type DisputeStatusChangedEvent struct {
+	DisputeID           uuid.UUID  `json:"dispute_id"`
+	TransactionID       uuid.UUID  `json:"transaction_id"`
+	OldStatus           string     `json:"old_status"`
+	NewStatus           string     `json:"new_status"`
+	ChangedBy           uuid.UUID  `json:"changed_by"`
+	Resolution          string     `json:"resolution,omitempty"`
+	RefundTransactionID *uuid.UUID `json:"refund_transaction_id,omitempty"`
+}
+
+// PaymentRequestEvent represents a payment request being created or changing status
+type PaymentRequestEvent struct {
+	PaymentRequestID uuid.UUID  `json:"payment_request_id"`
+	RequesterID      uuid.UUID  `json:"requester_id"`
+	PayerID          uuid.UUID  `json:"payer_id"`
+	Amount           float64    `json:"amount"`
+	Currency         string     `json:"currency"`
+	Note             string     `json:"note,omitempty"`
+	Status           string     `json:"status"`
+	TransactionID    *uuid.UUID `json:"transaction_id,omitempty"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+}
+
+// LowBalanceAlertEvent represents a debit leaving a balance below the user's alert threshold
+type LowBalanceAlertEvent struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Currency      string    `json:"currency"`
+	Balance       float64   `json:"balance"`
+	Threshold     float64   `json:"threshold"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+// LoginFromNewDeviceEvent represents a login from a device the user had not used before
+type LoginFromNewDeviceEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+}
+
+// AccountDormantEvent represents an account going without transactions for the dormancy period
+type AccountDormantEvent struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	Currency       string     `json:"currency"`
+	Balance        float64    `json:"balance"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	InactiveSince  time.Time  `json:"inactive_since"`
+	DormancyDays   int        `json:"dormancy_days"`
+}
+
 // EventMetadata represents optional event metadata
 type EventMetadata struct {
 	CorrelationID string                 `json:"correlation_id,omitempty"`
@@ -182,12 +300,18 @@ func NewEvent(aggregateType AggregateType, aggregateID uuid.UUID, eventType Even
 		EventMetadata: metadataBytes,
 		CreatedAt:     time.Now(),
 		Version:       1, // Will be set by repository based on current version
+		EventVersion:  CurrentEventVersion(eventType),
 	}, nil
 }
 
-// UnmarshalData deserializes the event data into the provided interface
+// UnmarshalData deserializes the event data into the provided interface, upcasting payloads
+// stored in an older shape to the current one first
 func (e *Event) UnmarshalData(target interface{}) error {
-	return json.Unmarshal(e.EventData, target)
+	data, err := eventUpcasters.Upcast(EventType(e.EventType), e.EventVersion, e.EventData)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
 }
 
 // UnmarshalMetadata deserializes the event metadata
@@ -204,3 +328,70 @@ func (e *Event) UnmarshalMetadata() (*EventMetadata, error) {
 
 	return &metadata, nil
 }
+
+// EventResponse represents a stored event in API responses, with its data and metadata as JSON.
+type EventResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Version       int             `json:"version"`
+	EventVersion  int             `json:"event_version"`
+	Data          json.RawMessage `json:"data"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ToResponse converts an Event to EventResponse.
+func (e *Event) ToResponse() EventResponse {
+	return EventResponse{
+		ID:            e.ID,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		EventType:     e.EventType,
+		Version:       e.Version,
+		EventVersion:  e.EventVersion,
+		Data:          e.EventData,
+		Metadata:      e.EventMetadata,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+// EventFilter selects events from the event store for inspection.
+type EventFilter struct {
+	AggregateType *AggregateType
+	AggregateID   *uuid.UUID
+	EventType     *string
+	Since         *time.Time // Inclusive
+	Until         *time.Time // Exclusive
+	Limit         int
+}
+
+// Matches reports whether an event satisfies every condition of the filter; the limit is ignored.
+func (f *EventFilter) Matches(e *Event) bool {
+	if f.AggregateType != nil && e.AggregateType != string(*f.AggregateType) {
+		return false
+	}
+	if f.AggregateID != nil && e.AggregateID != *f.AggregateID {
+		return false
+	}
+	if f.EventType != nil && e.EventType != *f.EventType {
+		return false
+	}
+	if f.Since != nil && e.CreatedAt.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !e.CreatedAt.Before(*f.Until) {
+		return false
+	}
+	return true
+}
+
+// EventPage is a page of events, oldest first.
+type EventPage struct {
+	Events []EventResponse `json:"events"`
+	Count  int             `json:"count"`
+	// NextSince continues the query when more events may match. Events created at exactly that
+	// instant are listed again, so clients should skip IDs they have already seen.
+	NextSince *time.Time `json:"next_since,omitempty"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EventUpcaster migrates an event payload from one version of its shape to the next.
+type EventUpcaster func(data map[string]interface{}) (map[string]interface{}, error)
+
+// EventUpcasters holds the upcasters of each event type in version order: the first migrates
+// payloads from version 1 to 2, the second from 2 to 3, and so on.
+type EventUpcasters map[EventType][]EventUpcaster
+
+// eventUpcasters is the registry applied when events are read. When an event struct changes shape,
+// append an upcaster from the previous shape to its event type; new events are then written with
+// the next version and stored ones keep replaying.
+var eventUpcasters = EventUpcasters{}
+
+// CurrentEventVersion returns the payload version new events of a type are written with.
+func CurrentEventVersion(eventType EventType) int {
+	return eventUpcasters.CurrentVersion(eventType)
+}
+
+// CurrentVersion returns the payload version of an event type after every registered upcaster.
+func (u EventUpcasters) CurrentVersion(eventType EventType) int {
+	return len(u[eventType]) + 1
+}
+
+// Upcast migrates a payload stored at version to the current version of its event type. Events
+// stored before versioning count as version 1.
+func (u EventUpcasters) Upcast(eventType EventType, version int, data []byte) ([]byte, error) {
+	if version < 1 {
+		version = 1
+	}
+
+	current := u.CurrentVersion(eventType)
+	if version > current {
+		return nil, fmt.Errorf("%s event version %d is newer than the supported version %d", eventType, version, current)
+	}
+	if version == current {
+		return data, nil
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s event version %d: %w", eventType, version, err)
+	}
+
+	for v := version; v < current; v++ {
+		var err error
+		payload, err = u[eventType][v-1](payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upcast %s event from version %d: %w", eventType, v, err)
+		}
+	}
+
+	return json.Marshal(payload)
+}
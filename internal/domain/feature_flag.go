@@ -0,0 +1,16 @@
+package domain
+
+import "fmt"
+
+// SetFeatureFlagRequest represents a request to override a feature flag at runtime.
+type SetFeatureFlagRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// Validate validates the set feature flag request.
+func (r *SetFeatureFlagRequest) Validate() error {
+	if r.Enabled == nil {
+		return fmt.Errorf("enabled: enabled is required")
+	}
+	return nil
+}
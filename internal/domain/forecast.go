@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cash-flow forecasts cover 30 days unless asked otherwise, and at most a year. Recurring patterns
+// are detected in the last RecurringLookback of history and need MinRecurringOccurrences.
+const (
+	DefaultForecastDays     = 30
+	MaxForecastDays         = 366
+	RecurringLookback       = 120 * 24 * time.Hour
+	MinRecurringOccurrences = 3
+)
+
+// CashFlowDirection tells whether money flows into or out of the account.
+type CashFlowDirection string
+
+const (
+	// CashFlowIn is money received: credits and incoming transfers
+	CashFlowIn CashFlowDirection = "in"
+	// CashFlowOut is money spent: debits, including fees, and outgoing transfers
+	CashFlowOut CashFlowDirection = "out"
+)
+
+// RecurringPeriod is the spacing of a recurring pattern detected in transaction history.
+type RecurringPeriod string
+
+const (
+	// RecurringDaily repeats about every day
+	RecurringDaily RecurringPeriod = "daily"
+	// RecurringWeekly repeats about every week
+	RecurringWeekly RecurringPeriod = "weekly"
+	// RecurringMonthly repeats about every calendar month
+	RecurringMonthly RecurringPeriod = "monthly"
+)
+
+// recurringPeriods lists the periods patterns are detected at, with the shortest and longest gap
+// between two occurrences that still counts as the period.
+var recurringPeriods = []struct {
+	period   RecurringPeriod
+	min, max time.Duration
+}{
+	{RecurringDaily, 20 * time.Hour, 28 * time.Hour},
+	{RecurringWeekly, 6 * 24 * time.Hour, 8 * 24 * time.Hour},
+	{RecurringMonthly, 27 * 24 * time.Hour, 33 * 24 * time.Hour},
+}
+
+// after returns the time n periods after t.
+func (p RecurringPeriod) after(t time.Time, n int) time.Time {
+	switch p {
+	case RecurringDaily:
+		return t.AddDate(0, 0, n)
+	case RecurringWeekly:
+		return t.AddDate(0, 0, 7*n)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
+
+// tolerance is how late an occurrence may be before the pattern is considered to have stopped.
+func (p RecurringPeriod) tolerance() time.Duration {
+	for _, candidate := range recurringPeriods {
+		if candidate.period == p {
+			return candidate.max - candidate.min
+		}
+	}
+	return 0
+}
+
+// RecurringPattern is a transaction that repeated at a regular period with the same amount and
+// counterparty, expected to keep repeating.
+type RecurringPattern struct {
+	Type           string            `json:"type"`
+	Direction      CashFlowDirection `json:"direction"`
+	CounterpartyID *uuid.UUID        `json:"counterparty_id,omitempty"` // Set for transfers
+	Amount         float64           `json:"amount"`
+	Period         RecurringPeriod   `json:"period"`
+	Occurrences    int               `json:"occurrences"` // Seen within the lookback
+	LastAt         time.Time         `json:"last_at"`
+	NextAt         time.Time         `json:"next_at"`
+}
+
+// matches reports whether a scheduled execution moves the same money as the pattern, in which case
+// the pattern is most likely made of that schedule's past executions.
+func (p RecurringPattern) matches(execution UpcomingExecution) bool {
+	if p.Type != execution.TransactionType || p.Amount != execution.Amount {
+		return false
+	}
+	if p.CounterpartyID == nil || execution.ToUserID == nil {
+		return p.CounterpartyID == nil && execution.ToUserID == nil
+	}
+	return *p.CounterpartyID == *execution.ToUserID
+}
+
+// ForecastDay is the projected cash flow of a UTC calendar day and the balance at its end.
+type ForecastDay struct {
+	Date    string  `json:"date"` // yyyy-mm-dd
+	Inflow  float64 `json:"inflow"`
+	Outflow float64 `json:"outflow"`
+	Balance float64 `json:"balance"`
+}
+
+// CashFlowForecast projects a user's daily balance from their current balance, their scheduled
+// transactions and the recurring patterns of their history. Forecasts are computed on request
+// and never stored.
+type CashFlowForecast struct {
+	UserID              uuid.UUID          `json:"user_id"`
+	Currency            string             `json:"currency"`
+	From                time.Time          `json:"from"`
+	CurrentBalance      float64            `json:"current_balance"`
+	Days                []ForecastDay      `json:"days"` // Today first
+	LowestBalance       float64            `json:"lowest_balance"`
+	FirstNegativeDate   *string            `json:"first_negative_date,omitempty"` // The first day projected to end below zero
+	ScheduledExecutions int                `json:"scheduled_executions"`          // Executions of the user's scheduled transactions within the forecast
+	RecurringPatterns   []RecurringPattern `json:"recurring_patterns"`            // Patterns not already covered by a scheduled transaction
+	Truncated           bool               `json:"truncated,omitempty"`           // Too many scheduled executions fell within the forecast to count them all
+}
+
+// ValidateForecastDays checks the number of days a forecast is requested for.
+func ValidateForecastDays(days int) error {
+	if days < 1 || days > MaxForecastDays {
+		return fmt.Errorf("days must be between 1 and %d, got %d", MaxForecastDays, days)
+	}
+	return nil
+}
+
+// recurringKey groups the transactions that move the same money the same way.
+type recurringKey struct {
+	typ          string
+	direction    CashFlowDirection
+	counterparty uuid.UUID
+	amount       float64
+}
+
+// DetectRecurring finds the recurring patterns of a user's successful transactions that are still
+// expected to repeat after now: at least MinRecurringOccurrences transactions of the same type,
+// direction, counterparty and amount, each a period after the last.
+func DetectRecurring(userID uuid.UUID, transactions []*Transaction, now time.Time) []RecurringPattern {
+	groups := make(map[recurringKey][]time.Time)
+	for _, tx := range transactions {
+		if tx.Status != string(StatusSuccess) {
+			continue
+		}
+
+		key := recurringKey{typ: tx.Type, amount: tx.Amount}
+		switch delta := tx.BalanceDelta(userID); {
+		case delta > 0:
+			key.direction = CashFlowIn
+			if tx.FromUserID != nil {
+				key.counterparty = *tx.FromUserID
+			}
+		case delta < 0:
+			key.direction = CashFlowOut
+			if tx.ToUserID != nil {
+				key.counterparty = *tx.ToUserID
+			}
+		default:
+			continue
+		}
+		groups[key] = append(groups[key], tx.CreatedAt)
+	}
+
+	patterns := []RecurringPattern{}
+	for key, times := range groups {
+		if len(times) < MinRecurringOccurrences {
+			continue
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+		period, ok := recurringPeriod(times)
+		if !ok {
+			continue
+		}
+
+		last := times[len(times)-1]
+		next := period.after(last, 1)
+		// A pattern whose next occurrence is overdue has stopped
+		if now.After(next.Add(period.tolerance())) {
+			continue
+		}
+		for n := 2; !next.After(now); n++ {
+			next = period.after(last, n)
+		}
+
+		pattern := RecurringPattern{
+			Type:        key.typ,
+			Direction:   key.direction,
+			Amount:      key.amount,
+			Period:      period,
+			Occurrences: len(times),
+			LastAt:      last,
+			NextAt:      next,
+		}
+		if key.counterparty != uuid.Nil {
+			counterparty := key.counterparty
+			pattern.CounterpartyID = &counterparty
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if !patterns[i].NextAt.Equal(patterns[j].NextAt) {
+			return patterns[i].NextAt.Before(patterns[j].NextAt)
+		}
+		return patterns[i].Amount > patterns[j].Amount
+	})
+	return patterns
+}
+
+// recurringPeriod returns the period every gap between consecutive times falls within.
+func recurringPeriod(times []time.Time) (RecurringPeriod, bool) {
+	for _, candidate := range recurringPeriods {
+		regular := true
+		for i := 1; i < len(times) && regular; i++ {
+			gap := times[i].Sub(times[i-1])
+			regular = gap >= candidate.min && gap <= candidate.max
+		}
+		if regular {
+			return candidate.period, true
+		}
+	}
+	return "", false
+}
+
+// ForecastCashFlow projects the balance at the end of each of days UTC days starting with the
+// one from falls in, applying the scheduled executions and the occurrences of the recurring
+// patterns that no scheduled execution accounts for.
+func ForecastCashFlow(userID uuid.UUID, currency string, balance float64, from time.Time, days int, scheduled []UpcomingExecution, patterns []RecurringPattern) *CashFlowForecast {
+	forecast := &CashFlowForecast{
+		UserID:              userID,
+		Currency:            currency,
+		From:                from.UTC(),
+		CurrentBalance:      RoundAmount(balance, currency),
+		Days:                make([]ForecastDay, days),
+		ScheduledExecutions: len(scheduled),
+		RecurringPatterns:   []RecurringPattern{},
+	}
+
+	from = from.UTC()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to := start.AddDate(0, 0, days)
+	flow := func(at time.Time, direction CashFlowDirection, amount float64) {
+		if at.Before(from) || !at.Before(to) {
+			return
+		}
+		day := &forecast.Days[int(at.Sub(start)/(24*time.Hour))]
+		if direction == CashFlowIn {
+			day.Inflow += amount
+		} else {
+			day.Outflow += amount
+		}
+	}
+
+	for _, execution := range scheduled {
+		direction := CashFlowOut
+		if execution.TransactionType == string(TypeCredit) {
+			direction = CashFlowIn
+		}
+		flow(execution.ExecuteAt, direction, execution.Amount)
+	}
+
+	for _, pattern := range patterns {
+		covered := false
+		for _, execution := range scheduled {
+			if pattern.matches(execution) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		forecast.RecurringPatterns = append(forecast.RecurringPatterns, pattern)
+		for n := 1; ; n++ {
+			at := pattern.Period.after(pattern.LastAt, n)
+			if !at.Before(to) {
+				break
+			}
+			if !at.Before(pattern.NextAt) {
+				flow(at, pattern.Direction, pattern.Amount)
+			}
+		}
+	}
+
+	running := balance
+	forecast.LowestBalance = forecast.CurrentBalance
+	for i := range forecast.Days {
+		day := &forecast.Days[i]
+		day.Date = start.AddDate(0, 0, i).Format("2006-01-02")
+		running += day.Inflow - day.Outflow
+		day.Inflow = RoundAmount(day.Inflow, currency)
+		day.Outflow = RoundAmount(day.Outflow, currency)
+		day.Balance = RoundAmount(running, currency)
+
+		if day.Balance < forecast.LowestBalance {
+			forecast.LowestBalance = day.Balance
+		}
+		if day.Balance < 0 && forecast.FirstNegativeDate == nil {
+			date := day.Date
+			forecast.FirstNegativeDate = &date
+		}
+	}
+
+	return forecast
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImpersonationSession represents an admin acting as another user with a scoped token.
+type ImpersonationSession struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	AdminID      uuid.UUID `json:"admin_id" db:"admin_id"`
+	TargetUserID uuid.UUID `json:"target_user_id" db:"target_user_id"`
+	Reason       string    `json:"reason" db:"reason"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+}
+
+// IsActive reports whether the impersonation session has not yet expired.
+func (s *ImpersonationSession) IsActive(now time.Time) bool {
+	return now.Before(s.ExpiresAt)
+}
+
+// ImpersonationRequest represents the data needed to start an impersonation session.
+type ImpersonationRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate validates the impersonation request.
+func (r *ImpersonationRequest) Validate() error {
+	reason := strings.TrimSpace(r.Reason)
+	if reason == "" {
+		return fmt.Errorf("reason: reason is required")
+	}
+
+	if len(reason) > 500 {
+		return fmt.Errorf("reason: reason must be at most 500 characters")
+	}
+
+	return nil
+}
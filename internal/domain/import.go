@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxImportRows is the largest number of data rows one import may contain.
+const MaxImportRows = 100000
+
+// Import CSV columns. Username and email are required; the others may be left out.
+const (
+	ImportColumnUsername       = "username"
+	ImportColumnEmail          = "email"
+	ImportColumnRole           = "role"            // user or admin; defaults to user
+	ImportColumnCurrency       = "currency"        // Currency of the balance; defaults to USD
+	ImportColumnOpeningBalance = "opening_balance" // Plain decimal number; defaults to 0
+	ImportColumnPasswordHash   = "password_hash"   // bcrypt hash; without one the user cannot log in
+)
+
+// importColumns lists the columns an import CSV may have, in any order.
+var importColumns = []string{
+	ImportColumnUsername, ImportColumnEmail, ImportColumnRole,
+	ImportColumnCurrency, ImportColumnOpeningBalance, ImportColumnPasswordHash,
+}
+
+// bcryptHashPattern matches a bcrypt password hash, as stored in users.password_hash.
+var bcryptHashPattern = regexp.MustCompile(`^\$2[aby]\$[0-9]{2}\$[./A-Za-z0-9]{53}$`)
+
+// ImportRow is one user of an import with the balance they open with.
+type ImportRow struct {
+	Line           int     `json:"line"` // Line of the CSV the row was read from
+	Username       string  `json:"username"`
+	Email          string  `json:"email"`
+	Role           string  `json:"role"`
+	Currency       string  `json:"currency"`
+	OpeningBalance float64 `json:"opening_balance"`
+	PasswordHash   string  `json:"-"`
+}
+
+// ImportRowError reports why one row of an import was rejected.
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"` // Column the error is about, if any
+	Message string `json:"message"`
+}
+
+// ImportResult reports the outcome of an import. Nothing is imported while any row has errors.
+type ImportResult struct {
+	ID       uuid.UUID          `json:"id"`
+	DryRun   bool               `json:"dry_run"`
+	Rows     int                `json:"rows"`
+	Imported int                `json:"imported"`
+	Funding  map[string]float64 `json:"funding"` // Opening balances per currency, minted into and issued from the treasury
+	Errors   []ImportRowError   `json:"errors"`
+}
+
+// ParseImportCSV reads the users of an import from a CSV with a header row naming its columns.
+// Rows that fail validation, including usernames or emails repeated within the file, are
+// reported as row errors; an error is returned only when the file as a whole cannot be read.
+func ParseImportCSV(r io.Reader) ([]*ImportRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("file is empty: a header row is required")
+		}
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns, err := importColumnIndexes(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []*ImportRow
+	var rowErrors []ImportRowError
+	usernames := make(map[string]int)
+	emails := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows)+len(rowErrors) >= MaxImportRows {
+			return nil, nil, fmt.Errorf("file has more than %d rows", MaxImportRows)
+		}
+		if err != nil {
+			// A row with the wrong number of fields is rejected; other syntax errors end the file
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) || !errors.Is(err, csv.ErrFieldCount) {
+				return nil, nil, fmt.Errorf("failed to read file: %w", err)
+			}
+			rowErrors = append(rowErrors, ImportRowError{Line: parseErr.StartLine, Message: fmt.Sprintf("row has %d fields, header has %d", len(record), len(header))})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		row, fieldErr := parseImportRow(record, columns, line)
+		if fieldErr != nil {
+			rowErrors = append(rowErrors, *fieldErr)
+			continue
+		}
+
+		if first, ok := usernames[strings.ToLower(row.Username)]; ok {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Field: ImportColumnUsername, Message: fmt.Sprintf("username repeats line %d", first)})
+			continue
+		}
+		if first, ok := emails[row.Email]; ok {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Field: ImportColumnEmail, Message: fmt.Sprintf("email repeats line %d", first)})
+			continue
+		}
+		usernames[strings.ToLower(row.Username)] = line
+		emails[row.Email] = line
+
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// importColumnIndexes maps each column of an import header to its position.
+func importColumnIndexes(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("unknown column %q: columns must be among %s", name, strings.Join(importColumns, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column %q appears more than once", name)
+		}
+		columns[name] = i
+	}
+
+	for _, required := range []string{ImportColumnUsername, ImportColumnEmail} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("column %q is required", required)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseImportRow validates one record of an import, filling in the defaults of missing values.
+func parseImportRow(record []string, columns map[string]int, line int) (*ImportRow, *ImportRowError) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	fail := func(name string, err error) *ImportRowError {
+		return &ImportRowError{Line: line, Field: name, Message: err.Error()}
+	}
+
+	row := &ImportRow{
+		Line:         line,
+		Username:     field(ImportColumnUsername),
+		Email:        strings.ToLower(field(ImportColumnEmail)),
+		Role:         strings.ToLower(field(ImportColumnRole)),
+		Currency:     strings.ToUpper(field(ImportColumnCurrency)),
+		PasswordHash: field(ImportColumnPasswordHash),
+	}
+
+	if err := validateUsername(row.Username); err != nil {
+		return nil, fail(ImportColumnUsername, err)
+	}
+	if err := validateEmail(row.Email); err != nil {
+		return nil, fail(ImportColumnEmail, err)
+	}
+
+	if row.Role == "" {
+		row.Role = string(RoleUser)
+	}
+	if err := validateRole(row.Role); err != nil {
+		return nil, fail(ImportColumnRole, err)
+	}
+
+	if row.Currency == "" {
+		row.Currency = string(CurrencyUSD)
+	}
+	if !IsValidCurrency(row.Currency) {
+		return nil, fail(ImportColumnCurrency, fmt.Errorf("unsupported currency: %s", row.Currency))
+	}
+
+	if balance := field(ImportColumnOpeningBalance); balance != "" {
+		amount, err := ParseAmount(balance, row.Currency)
+		if err != nil {
+			return nil, fail(ImportColumnOpeningBalance, err)
+		}
+		// Opening balances are issued as credits, so they are bounded like one
+		if amount < 0 {
+			return nil, fail(ImportColumnOpeningBalance, fmt.Errorf("opening balance cannot be negative"))
+		}
+		if amount > 0 {
+			if err := validateTransactionAmount(amount); err != nil {
+				return nil, fail(ImportColumnOpeningBalance, err)
+			}
+		}
+		row.OpeningBalance = amount
+	}
+
+	if row.PasswordHash != "" && !bcryptHashPattern.MatchString(row.PasswordHash) {
+		return nil, fail(ImportColumnPasswordHash, fmt.Errorf("password hash must be a bcrypt hash"))
+	}
+
+	return row, nil
+}
@@ -0,0 +1,271 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MinIndexAdviceTableRows is how many rows a table needs before sequentially scanning it is worth
+// an index. Smaller tables are cheaper to scan than to index.
+const MinIndexAdviceTableRows = 10000
+
+// PlanNode is a node of a PostgreSQL query plan as EXPLAIN (VERBOSE, FORMAT JSON) reports it.
+type PlanNode struct {
+	NodeType     string     `json:"Node Type"`
+	Schema       string     `json:"Schema"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Filter       string     `json:"Filter"`
+	TotalCost    float64    `json:"Total Cost"`
+	PlanRows     float64    `json:"Plan Rows"`
+	Plans        []PlanNode `json:"Plans"`
+}
+
+// QueryPlan is the plan of one of the hottest queries, explained against current statistics.
+type QueryPlan struct {
+	Name        string
+	Description string
+	Plan        []byte // EXPLAIN (VERBOSE, FORMAT JSON) output
+}
+
+// TableStatistics is what the planner knows of a table as of its last analyze.
+type TableStatistics struct {
+	Rows   float64 // Estimated rows; negative if the table was never analyzed
+	Parent string  // Schema-qualified partitioned table the table is a partition of, "" if none
+}
+
+// SequentialScan is a sequential scan of a table in a query plan.
+type SequentialScan struct {
+	Table         string  `json:"table"` // schema.table
+	Filter        string  `json:"filter,omitempty"`
+	EstimatedRows float64 `json:"estimated_rows"` // Rows the planner expects the scan to return
+	TableRows     float64 `json:"table_rows"`
+}
+
+// IndexSuggestion is an index that would let a query look up rows instead of scanning their table.
+type IndexSuggestion struct {
+	Table     string   `json:"table"` // schema.table
+	Columns   []string `json:"columns"`
+	Statement string   `json:"statement"`
+	Reason    string   `json:"reason"`
+}
+
+// QueryPlanAdvice is what the plan of one hot query tells about the indexes it is missing.
+type QueryPlanAdvice struct {
+	Name            string            `json:"name"`
+	Description     string            `json:"description"`
+	TotalCost       float64           `json:"total_cost"`
+	EstimatedRows   float64           `json:"estimated_rows"`
+	IndexesUsed     []string          `json:"indexes_used"`
+	SequentialScans []SequentialScan  `json:"sequential_scans"`
+	Suggestions     []IndexSuggestion `json:"suggestions"`
+}
+
+// IndexAdvisorReport is the index advice for the hottest queries.
+type IndexAdvisorReport struct {
+	GeneratedAt  time.Time         `json:"generated_at"`
+	MinTableRows float64           `json:"min_table_rows"`
+	Queries      []QueryPlanAdvice `json:"queries"`
+}
+
+// filterComparison matches a column compared to a value in a plan filter, e.g.
+// "(t.from_user_id = '…'::uuid)" or "((e.aggregate_type)::text = 'user'::text)".
+var filterComparison = regexp.MustCompile(`\(\(?(?:\w+\.)?([a-z_][a-z0-9_]*)\)?(?:::[a-z ]+)?\s+(=|<=|>=|<|>|IS NULL)`)
+
+// AdviseIndexes reads the plan of a hot query and suggests an index for every filtered sequential
+// scan of a table with at least minTableRows rows. Equality columns come first in a suggested
+// index, followed by at most one range column; a filter of alternatives gets an index per
+// equality column, which the planner can combine. Partitions are advised through their
+// partitioned table.
+func AdviseIndexes(plan *QueryPlan, tables map[string]TableStatistics, minTableRows float64) (*QueryPlanAdvice, error) {
+	var explained []struct {
+		Plan PlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan.Plan, &explained); err != nil {
+		return nil, fmt.Errorf("invalid plan of %s: %w", plan.Name, err)
+	}
+	if len(explained) == 0 {
+		return nil, fmt.Errorf("invalid plan of %s: no plan", plan.Name)
+	}
+
+	root := explained[0].Plan
+	advice := &QueryPlanAdvice{
+		Name:            plan.Name,
+		Description:     plan.Description,
+		TotalCost:       root.TotalCost,
+		EstimatedRows:   root.PlanRows,
+		IndexesUsed:     []string{},
+		SequentialScans: []SequentialScan{},
+		Suggestions:     []IndexSuggestion{},
+	}
+	suggested := make(map[string]bool)
+
+	var walk func(node *PlanNode)
+	walk = func(node *PlanNode) {
+		for i := range node.Plans {
+			walk(&node.Plans[i])
+		}
+		if node.IndexName != "" && !slices.Contains(advice.IndexesUsed, node.IndexName) {
+			advice.IndexesUsed = append(advice.IndexesUsed, node.IndexName)
+		}
+		if node.NodeType != "Seq Scan" {
+			return
+		}
+
+		table := node.Schema + "." + node.RelationName
+		stats := tables[table]
+		advice.SequentialScans = append(advice.SequentialScans, SequentialScan{
+			Table:         table,
+			Filter:        node.Filter,
+			EstimatedRows: node.PlanRows,
+			TableRows:     stats.Rows,
+		})
+		if node.Filter == "" || stats.Rows < minTableRows {
+			return
+		}
+
+		target := table
+		if stats.Parent != "" {
+			target = stats.Parent
+		}
+		for _, columns := range indexColumns(node.Filter) {
+			key := target + " " + strings.Join(columns, ",")
+			if suggested[key] {
+				continue
+			}
+			suggested[key] = true
+			advice.Suggestions = append(advice.Suggestions, IndexSuggestion{
+				Table:     target,
+				Columns:   columns,
+				Statement: createIndexStatement(target, columns, stats.Parent != ""),
+				Reason:    fmt.Sprintf("sequential scan of %.0f rows of %s filtered by %s", stats.Rows, table, node.Filter),
+			})
+		}
+	}
+	walk(&root)
+
+	return advice, nil
+}
+
+// indexColumns returns the column lists of the indexes that would serve a plan filter. A filter
+// of alternatives is served by an index per alternative; otherwise only the conditions that
+// every row must meet are indexed.
+func indexColumns(filter string) [][]string {
+	conditions := splitTopLevel(filter, " AND ")
+	if len(conditions) == 1 {
+		if alternatives := splitTopLevel(conditions[0], " OR "); len(alternatives) > 1 {
+			var indexes [][]string
+			for _, alternative := range alternatives {
+				columns := comparedColumns([]string{alternative})
+				if columns == nil {
+					return nil // An alternative no index serves leaves the scan in place
+				}
+				indexes = append(indexes, columns)
+			}
+			return indexes
+		}
+	}
+
+	var required []string
+	for _, condition := range conditions {
+		if len(splitTopLevel(condition, " OR ")) == 1 {
+			required = append(required, condition)
+		}
+	}
+	if columns := comparedColumns(required); columns != nil {
+		return [][]string{columns}
+	}
+	return nil
+}
+
+// comparedColumns returns the columns conditions compare to values, equality columns first and
+// then at most one range column, or nil if there are none.
+func comparedColumns(conditions []string) []string {
+	var equality, ranges []string
+	for _, condition := range conditions {
+		for _, match := range filterComparison.FindAllStringSubmatch("("+condition+")", -1) {
+			column, operator := match[1], match[2]
+			if operator == "=" || operator == "IS NULL" {
+				if !slices.Contains(equality, column) {
+					equality = append(equality, column)
+				}
+			} else if !slices.Contains(ranges, column) {
+				ranges = append(ranges, column)
+			}
+		}
+	}
+
+	columns := equality
+	if len(ranges) > 0 {
+		columns = append(columns, ranges[0])
+	}
+	return columns
+}
+
+// splitTopLevel splits a plan filter at the occurrences of sep outside parentheses and quotes,
+// after unwrapping the parentheses enclosing all of it.
+func splitTopLevel(expr, sep string) []string {
+	expr = strings.TrimSpace(expr)
+	for strings.HasPrefix(expr, "(") && closingParen(expr, 0) == len(expr)-1 {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+
+	var parts []string
+	depth, quoted, start := 0, false, 0
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(expr[i:], sep):
+			parts = append(parts, expr[start:i])
+			start = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+// closingParen returns the index of the parenthesis closing the one at open in expr, or -1.
+func closingParen(expr string, open int) int {
+	depth, quoted := 0, false
+	for i := open; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// createIndexStatement builds the statement creating an index on columns of table. Partitioned
+// tables cannot be indexed concurrently.
+func createIndexStatement(table string, columns []string, partitioned bool) string {
+	name := table
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	name = "idx_" + name + "_" + strings.Join(columns, "_")
+
+	concurrently := " CONCURRENTLY"
+	if partitioned {
+		concurrently = ""
+	}
+	return fmt.Sprintf("CREATE INDEX%s %s ON %s (%s)", concurrently, name, table, strings.Join(columns, ", "))
+}
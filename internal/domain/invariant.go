@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MoneySupplyDriftTolerance is the largest drift still treated as rounding rather than a leak.
+const MoneySupplyDriftTolerance = 0.005
+
+// MoneySupplyCheck reports whether the money held by users and the treasury of one currency
+// adds up to the money minted minus the money burned.
+type MoneySupplyCheck struct {
+	Currency        string  `json:"currency"`
+	UserBalances    float64 `json:"user_balances"`
+	TreasuryBalance float64 `json:"treasury_balance"`
+	TotalMinted     float64 `json:"total_minted"`
+	TotalBurned     float64 `json:"total_burned"`
+	ExpectedSupply  float64 `json:"expected_supply"` // Minted minus burned
+	ActualSupply    float64 `json:"actual_supply"`   // User balances plus the treasury balance
+	Drift           float64 `json:"drift"`           // Positive when money appeared from nowhere, negative when it leaked
+	Holds           bool    `json:"holds"`
+}
+
+// NewMoneySupplyCheck checks the money supply invariant of a treasury against the sum of the
+// user balances in its currency.
+func NewMoneySupplyCheck(account *TreasuryAccount, userBalances float64) *MoneySupplyCheck {
+	expected := account.TotalSupply()
+	actual := roundCents(userBalances + account.Balance)
+	drift := roundCents(actual - expected)
+
+	return &MoneySupplyCheck{
+		Currency:        account.Currency,
+		UserBalances:    roundCents(userBalances),
+		TreasuryBalance: account.Balance,
+		TotalMinted:     account.TotalMinted,
+		TotalBurned:     account.TotalBurned,
+		ExpectedSupply:  expected,
+		ActualSupply:    actual,
+		Drift:           drift,
+		Holds:           math.Abs(drift) < MoneySupplyDriftTolerance,
+	}
+}
+
+// InvariantReport represents the outcome of one run of the invariant checker.
+type InvariantReport struct {
+	Holds       bool                `json:"holds"`
+	MoneySupply []*MoneySupplyCheck `json:"money_supply"`
+	CheckedAt   time.Time           `json:"checked_at"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginDevice describes the client a login came from.
+type LoginDevice struct {
+	IP        string
+	UserAgent string
+}
+
+// Fingerprint identifies the device by a SHA-256 hash of its user agent and IP, so devices can be
+// recognized without keeping where users logged in from. A new IP counts as a new device.
+func (d LoginDevice) Fingerprint() string {
+	sum := sha256.Sum256([]byte(d.UserAgent + "\n" + d.IP))
+	return hex.EncodeToString(sum[:])
+}
+
+// KnownDevice is a device a user has logged in from before.
+type KnownDevice struct {
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	Fingerprint string    `json:"fingerprint" db:"fingerprint"`
+	FirstSeenAt time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// LoginSession is the session a login starts. Its ID is carried by the access and refresh tokens
+// of the login, so revoking the session signs out every token issued for it.
+type LoginSession struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	IP        string     `json:"ip" db:"ip"`
+	UserAgent string     `json:"user_agent" db:"user_agent"`
+	NewDevice bool       `json:"new_device" db:"new_device"` // Set when the login came from a device the user had not used before
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// IsActive reports whether the session has neither expired nor been revoked.
+func (s *LoginSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
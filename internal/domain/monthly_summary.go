@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonthlySummaryTopCounterparties is how many counterparties a monthly summary lists.
+const MonthlySummaryTopCounterparties = 5
+
+// SummaryCounterparty is a user the account holder exchanged transfers with during the month.
+type SummaryCounterparty struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Sent          float64   `json:"sent"`
+	Received      float64   `json:"received"`
+	TransferCount int       `json:"transfer_count"`
+}
+
+// MonthlySummary is a user's account summary of a calendar month (UTC), generated once the month
+// has ended and kept unchanged afterwards.
+type MonthlySummary struct {
+	ID                uuid.UUID             `json:"id" db:"id"`
+	UserID            uuid.UUID             `json:"user_id" db:"user_id"`
+	Month             string                `json:"month" db:"month"` // yyyy-mm
+	Currency          string                `json:"currency" db:"currency"`
+	OpeningBalance    float64               `json:"opening_balance" db:"opening_balance"`
+	ClosingBalance    float64               `json:"closing_balance" db:"closing_balance"`
+	TotalIn           float64               `json:"total_in" db:"total_in"`
+	TotalOut          float64               `json:"total_out" db:"total_out"` // Includes fees
+	FeesPaid          float64               `json:"fees_paid" db:"fees_paid"`
+	TransactionCount  int                   `json:"transaction_count" db:"transaction_count"`
+	TopCounterparties []SummaryCounterparty `json:"top_counterparties" db:"top_counterparties"` // By amount exchanged, largest first
+	GeneratedAt       time.Time             `json:"generated_at" db:"generated_at"`
+}
+
+// ParseSummaryMonth parses a yyyy-mm month into the UTC instants it starts and ends at.
+func ParseSummaryMonth(month string) (start, end time.Time, err error) {
+	start, err = time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("month must be formatted as yyyy-mm, got %q", month)
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// SummaryMonth returns the yyyy-mm month t falls in, in UTC.
+func SummaryMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// SummarizeMonth builds the summary of a month from the balance it opened with and the user's
+// successful transactions created during it.
+func SummarizeMonth(userID uuid.UUID, month, currency string, opening float64, transactions []*Transaction) *MonthlySummary {
+	summary := &MonthlySummary{
+		UserID:            userID,
+		Month:             month,
+		Currency:          currency,
+		OpeningBalance:    RoundAmount(opening, currency),
+		TopCounterparties: []SummaryCounterparty{},
+	}
+
+	counterparties := make(map[uuid.UUID]*SummaryCounterparty)
+	counterparty := func(id uuid.UUID) *SummaryCounterparty {
+		if c, ok := counterparties[id]; ok {
+			return c
+		}
+		c := &SummaryCounterparty{UserID: id}
+		counterparties[id] = c
+		return c
+	}
+
+	for _, tx := range transactions {
+		if tx.Status != string(StatusSuccess) {
+			continue
+		}
+
+		// Money moved between two users has a counterparty; money issued or redeemed has none
+		switch delta := tx.BalanceDelta(userID); {
+		case delta > 0:
+			summary.TotalIn += tx.Amount
+			if tx.FromUserID != nil {
+				c := counterparty(*tx.FromUserID)
+				c.Received += tx.Amount
+				c.TransferCount++
+			}
+		case delta < 0:
+			summary.TotalOut += tx.Amount
+			if IsTransferFee(tx) {
+				summary.FeesPaid += tx.Amount
+			}
+			if tx.ToUserID != nil {
+				c := counterparty(*tx.ToUserID)
+				c.Sent += tx.Amount
+				c.TransferCount++
+			}
+		default:
+			continue
+		}
+		summary.TransactionCount++
+	}
+
+	summary.TotalIn = RoundAmount(summary.TotalIn, currency)
+	summary.TotalOut = RoundAmount(summary.TotalOut, currency)
+	summary.FeesPaid = RoundAmount(summary.FeesPaid, currency)
+	summary.ClosingBalance = RoundAmount(summary.OpeningBalance+summary.TotalIn-summary.TotalOut, currency)
+
+	for _, c := range counterparties {
+		c.Sent = RoundAmount(c.Sent, currency)
+		c.Received = RoundAmount(c.Received, currency)
+		summary.TopCounterparties = append(summary.TopCounterparties, *c)
+	}
+	sort.Slice(summary.TopCounterparties, func(i, j int) bool {
+		a, b := summary.TopCounterparties[i], summary.TopCounterparties[j]
+		if a.Sent+a.Received != b.Sent+b.Received {
+			return a.Sent+a.Received > b.Sent+b.Received
+		}
+		if a.TransferCount != b.TransferCount {
+			return a.TransferCount > b.TransferCount
+		}
+		return a.UserID.String() < b.UserID.String()
+	})
+	if len(summary.TopCounterparties) > MonthlySummaryTopCounterparties {
+		summary.TopCounterparties = summary.TopCounterparties[:MonthlySummaryTopCounterparties]
+	}
+
+	return summary
+}
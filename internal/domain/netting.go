@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NettingStatus defines the states of a netting batch.
+type NettingStatus string
+
+const (
+	// NettingOpen batches accept transfers until their window closes
+	NettingOpen NettingStatus = "open"
+	// NettingSettled batches moved their net amount and completed their transfers
+	NettingSettled NettingStatus = "settled"
+	// NettingFailed batches could not move their net amount and failed their transfers
+	NettingFailed NettingStatus = "failed"
+)
+
+// NettingBatch accumulates the transfers between a pair of users in one currency over a window.
+// When the window closes the batch is settled as a single balance movement of the net amount,
+// while each transfer keeps its own transaction record.
+type NettingBatch struct {
+	ID            uuid.UUID     `json:"id" db:"id"`
+	UserAID       uuid.UUID     `json:"user_a_id" db:"user_a_id"` // The pair in NettingPair order
+	UserBID       uuid.UUID     `json:"user_b_id" db:"user_b_id"`
+	Currency      string        `json:"currency" db:"currency"`
+	Status        NettingStatus `json:"status" db:"status"`
+	TransferCount int           `json:"transfer_count" db:"transfer_count"`
+	GrossAmount   float64       `json:"gross_amount" db:"gross_amount"` // Sum of the transfers in both directions
+	NetAmount     float64       `json:"net_amount" db:"net_amount"`
+	NetFromUserID *uuid.UUID    `json:"net_from_user_id,omitempty" db:"net_from_user_id"` // Nil when the transfers cancel out
+	FailureReason string        `json:"failure_reason,omitempty" db:"failure_reason"`
+	OpenedAt      time.Time     `json:"opened_at" db:"opened_at"`
+	ClosesAt      time.Time     `json:"closes_at" db:"closes_at"`
+	SettledAt     *time.Time    `json:"settled_at,omitempty" db:"settled_at"`
+}
+
+// NettedTransfer is a pending transfer recorded in a netting batch.
+type NettedTransfer struct {
+	TransactionID uuid.UUID `json:"transaction_id" db:"transaction_id"`
+	FromUserID    uuid.UUID `json:"from_user_id" db:"from_user_id"`
+	Amount        float64   `json:"amount" db:"amount"`
+}
+
+// NettingBatchFilter represents filters for listing netting batches.
+type NettingBatchFilter struct {
+	Status *NettingStatus `json:"status,omitempty"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// NettingPair orders two users the way netting batches store them, so transfers in either
+// direction between them land in the same batch.
+func NettingPair(x, y uuid.UUID) (a, b uuid.UUID) {
+	if bytes.Compare(x[:], y[:]) <= 0 {
+		return x, y
+	}
+	return y, x
+}
+
+// Net totals the transfers of the batch and works out the single movement settling them: the
+// difference between the two directions, paid by the user who sent more.
+func (b *NettingBatch) Net(transfers []*NettedTransfer) {
+	var fromA, fromB float64
+	for _, transfer := range transfers {
+		if transfer.FromUserID == b.UserAID {
+			fromA += transfer.Amount
+		} else {
+			fromB += transfer.Amount
+		}
+	}
+
+	b.TransferCount = len(transfers)
+	b.GrossAmount = roundCents(fromA + fromB)
+	b.NetAmount = roundCents(fromA - fromB)
+	b.NetFromUserID = nil
+	switch {
+	case b.NetAmount > 0:
+		payer := b.UserAID
+		b.NetFromUserID = &payer
+	case b.NetAmount < 0:
+		payer := b.UserBID
+		b.NetFromUserID = &payer
+		b.NetAmount = -b.NetAmount
+	}
+}
+
+// MovementsSaved returns how many balance movements netting the batch saved over settling each
+// transfer on its own.
+func (b *NettingBatch) MovementsSaved() int {
+	if b.NetFromUserID == nil {
+		return b.TransferCount
+	}
+	return b.TransferCount - 1
+}
@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType identifies what a notification is about and which template renders it.
+type NotificationType string
+
+const (
+	// NotificationTransactionCompleted is sent to each participant when a transaction completes
+	NotificationTransactionCompleted NotificationType = "transaction_completed"
+	// NotificationLowBalance is sent when a balance drops below the user's alert threshold
+	NotificationLowBalance NotificationType = "low_balance"
+	// NotificationScheduledExecutionFailed is sent when a scheduled transaction could not be executed
+	NotificationScheduledExecutionFailed NotificationType = "scheduled_execution_failed"
+	// NotificationScheduledInsufficientFunds is sent ahead of a scheduled debit or transfer the balance does not cover
+	NotificationScheduledInsufficientFunds NotificationType = "scheduled_insufficient_funds"
+	// NotificationNewDeviceLogin is sent when the user logs in from a device they had not used before
+	NotificationNewDeviceLogin NotificationType = "new_device_login"
+	// NotificationMonthlySummary is sent when the summary of a month with account activity is ready
+	NotificationMonthlySummary NotificationType = "monthly_summary"
+	// NotificationBudgetExceeded is sent the first time in a month spending in a category goes over its budget
+	NotificationBudgetExceeded NotificationType = "budget_exceeded"
+)
+
+// NotificationTypes lists every notification type users can set preferences for.
+var NotificationTypes = []NotificationType{
+	NotificationTransactionCompleted,
+	NotificationLowBalance,
+	NotificationScheduledExecutionFailed,
+	NotificationScheduledInsufficientFunds,
+	NotificationNewDeviceLogin,
+	NotificationMonthlySummary,
+	NotificationBudgetExceeded,
+}
+
+// IsValidNotificationType reports whether t is a known notification type.
+func IsValidNotificationType(t NotificationType) bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannel identifies where a notification is delivered.
+type NotificationChannel string
+
+const (
+	// ChannelInApp delivers to the user's in-app inbox
+	ChannelInApp NotificationChannel = "in_app"
+	// ChannelEmail delivers to the user's email address
+	ChannelEmail NotificationChannel = "email"
+	// ChannelWebhook delivers to the user's webhook URL
+	ChannelWebhook NotificationChannel = "webhook"
+)
+
+// IsValidNotificationChannel reports whether c is a known notification channel.
+func IsValidNotificationChannel(c NotificationChannel) bool {
+	switch c {
+	case ChannelInApp, ChannelEmail, ChannelWebhook:
+		return true
+	}
+	return false
+}
+
+// Notification represents a rendered notification in a user's in-app inbox.
+type Notification struct {
+	ID        uuid.UUID              `json:"id" db:"id"`
+	UserID    uuid.UUID              `json:"user_id" db:"user_id"`
+	Type      NotificationType       `json:"type" db:"type"`
+	Title     string                 `json:"title" db:"title"`
+	Body      string                 `json:"body" db:"body"`
+	Data      map[string]interface{} `json:"data" db:"data"`
+	ReadAt    *time.Time             `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time              `json:"created_at" db:"created_at"`
+}
+
+// NotificationFilter represents filters for listing a user's inbox.
+type NotificationFilter struct {
+	UserID     uuid.UUID `json:"user_id"`
+	UnreadOnly bool      `json:"unread_only"`
+	Limit      int       `json:"limit"`
+	Offset     int       `json:"offset"`
+}
+
+// NotificationPreferences represents the channels a user receives each notification type on.
+type NotificationPreferences struct {
+	UserID     uuid.UUID                                  `json:"user_id" db:"user_id"`
+	Channels   map[NotificationType][]NotificationChannel `json:"channels" db:"channels"`
+	WebhookURL string                                     `json:"webhook_url,omitempty" db:"webhook_url"`
+	UpdatedAt  time.Time                                  `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultNotificationPreferences returns the preferences of a user who has not changed them:
+// every notification type goes to the in-app inbox only.
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	channels := make(map[NotificationType][]NotificationChannel, len(NotificationTypes))
+	for _, t := range NotificationTypes {
+		channels[t] = []NotificationChannel{ChannelInApp}
+	}
+
+	return &NotificationPreferences{
+		UserID:   userID,
+		Channels: channels,
+	}
+}
+
+// ChannelsFor returns the channels notifications of type t are delivered on.
+// Types without a stored preference fall back to the in-app inbox.
+func (p *NotificationPreferences) ChannelsFor(t NotificationType) []NotificationChannel {
+	if channels, ok := p.Channels[t]; ok {
+		return channels
+	}
+	return []NotificationChannel{ChannelInApp}
+}
+
+// UpdateNotificationPreferencesRequest represents changes to a user's notification preferences.
+// Types missing from channels keep their current channels; an empty list mutes a type.
+type UpdateNotificationPreferencesRequest struct {
+	Channels   map[NotificationType][]NotificationChannel `json:"channels,omitempty"`
+	WebhookURL *string                                    `json:"webhook_url,omitempty"`
+}
+
+// Validate validates the update notification preferences request.
+func (r *UpdateNotificationPreferencesRequest) Validate() error {
+	if r.Channels == nil && r.WebhookURL == nil {
+		return fmt.Errorf("at least one field must be provided")
+	}
+
+	for t, channels := range r.Channels {
+		if !IsValidNotificationType(t) {
+			return fmt.Errorf("channels: unknown notification type: %s", t)
+		}
+		for _, c := range channels {
+			if !IsValidNotificationChannel(c) {
+				return fmt.Errorf("channels: unknown notification channel: %s", c)
+			}
+		}
+	}
+
+	if r.WebhookURL != nil && *r.WebhookURL != "" {
+		u, err := url.Parse(*r.WebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("webhook_url: webhook_url must be an absolute http or https URL")
+		}
+	}
+
+	return nil
+}
+
+// NotificationDeliveryStatus defines valid notification delivery statuses.
+type NotificationDeliveryStatus string
+
+const (
+	// DeliveryPending is waiting for the dispatcher, including retries after a failed attempt
+	DeliveryPending NotificationDeliveryStatus = "pending"
+	// DeliverySent was delivered to its channel
+	DeliverySent NotificationDeliveryStatus = "sent"
+	// DeliveryFailed gave up after the maximum number of attempts
+	DeliveryFailed NotificationDeliveryStatus = "failed"
+)
+
+// NotificationDelivery represents a notification queued for delivery on one channel.
+// Rendering happens at dispatch time so the queue only holds the template data.
+type NotificationDelivery struct {
+	ID            uuid.UUID                  `json:"id" db:"id"`
+	UserID        uuid.UUID                  `json:"user_id" db:"user_id"`
+	Type          NotificationType           `json:"type" db:"type"`
+	Channel       NotificationChannel        `json:"channel" db:"channel"`
+	Data          map[string]interface{}     `json:"data" db:"data"`
+	Status        NotificationDeliveryStatus `json:"status" db:"status"`
+	Attempts      int                        `json:"attempts" db:"attempts"`
+	LastError     string                     `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time                  `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time                  `json:"created_at" db:"created_at"`
+	SentAt        *time.Time                 `json:"sent_at,omitempty" db:"sent_at"`
+}
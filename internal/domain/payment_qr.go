@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// PaymentQRScheme is the URI scheme of payment QR payloads, e.g.
+// bankingsim://pay?account=XS448215280719321798&amount=12.50&currency=USD&description=Lunch
+const PaymentQRScheme = "bankingsim"
+
+// MaxPaymentQRDescriptionLength bounds the description of a payment QR code, so the payload
+// still fits a QR code that scans reliably.
+const MaxPaymentQRDescriptionLength = 80
+
+// PaymentQR is what a payment QR code asks for: a transfer to an account, optionally of a fixed
+// amount and with a description.
+type PaymentQR struct {
+	AccountNumber string  `json:"account_number"`
+	Amount        float64 `json:"amount,omitempty"` // 0 leaves the amount to the payer
+	Currency      string  `json:"currency"`
+	Description   string  `json:"description,omitempty"`
+}
+
+// PaymentQRRequest represents the optional amount and description of a payment QR code.
+type PaymentQRRequest struct {
+	Amount      float64
+	Currency    string
+	Description string
+}
+
+// Validate validates the payment QR code request
+func (r *PaymentQRRequest) Validate() error {
+	if r.Amount < 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if r.Currency != "" && !IsValidCurrency(r.Currency) {
+		return fmt.Errorf("unsupported currency: %s", r.Currency)
+	}
+	if r.Amount > 0 && r.Currency != "" {
+		if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+			return fmt.Errorf("amount: %w", err)
+		}
+	}
+	if utf8.RuneCountInString(r.Description) > MaxPaymentQRDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", MaxPaymentQRDescriptionLength)
+	}
+	return nil
+}
+
+// Payload encodes the payment QR code as a URI.
+func (q *PaymentQR) Payload() string {
+	query := url.Values{}
+	query.Set("account", q.AccountNumber)
+	if q.Amount > 0 {
+		query.Set("amount", FormatDecimal(q.Amount, q.Currency, ""))
+	}
+	query.Set("currency", q.Currency)
+	if q.Description != "" {
+		query.Set("description", q.Description)
+	}
+	return PaymentQRScheme + "://pay?" + query.Encode()
+}
+
+// ParsePaymentQR decodes a payment QR payload, checking its account number, amount and currency.
+func ParsePaymentQR(payload string) (*PaymentQR, error) {
+	uri, err := url.Parse(strings.TrimSpace(payload))
+	if err != nil || uri.Scheme != PaymentQRScheme || uri.Host != "pay" {
+		return nil, fmt.Errorf("not a payment QR code")
+	}
+	query := uri.Query()
+
+	accountNumber, err := ParseAccountNumber(query.Get("account"))
+	if err != nil {
+		return nil, err
+	}
+
+	q := &PaymentQR{
+		AccountNumber: accountNumber,
+		Currency:      query.Get("currency"),
+		Description:   query.Get("description"),
+	}
+	if !IsValidCurrency(q.Currency) {
+		return nil, fmt.Errorf("unsupported currency: %s", q.Currency)
+	}
+	if amount := query.Get("amount"); amount != "" {
+		if q.Amount, err = ParseAmount(amount, q.Currency); err != nil {
+			return nil, err
+		}
+		if q.Amount <= 0 {
+			return nil, fmt.Errorf("amount must be greater than 0")
+		}
+	}
+	if utf8.RuneCountInString(q.Description) > MaxPaymentQRDescriptionLength {
+		return nil, fmt.Errorf("description must be at most %d characters", MaxPaymentQRDescriptionLength)
+	}
+
+	return q, nil
+}
+
+// QRTransferRequest represents a scanned payment QR payload, with the amount and description the
+// payer fills in when the code leaves them open.
+type QRTransferRequest struct {
+	Payload     string  `json:"payload"`
+	Amount      float64 `json:"amount,omitempty"`
+	Description string  `json:"description,omitempty"`
+}
+
+// Validate validates the QR transfer request
+func (r *QRTransferRequest) Validate() error {
+	if strings.TrimSpace(r.Payload) == "" {
+		return fmt.Errorf("payload is required")
+	}
+	if r.Amount < 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	return validateTransactionDescription(r.Description)
+}
+
+// QRTransferDraft is a transfer prefilled from a payment QR code, for the payer to confirm and
+// send to the transfer endpoint. Nothing moves until then.
+type QRTransferDraft struct {
+	ToUserID        uuid.UUID `json:"to_user_id"`
+	ToAccountNumber string    `json:"to_account_number"`
+	ToUsername      string    `json:"to_username"`
+	Amount          float64   `json:"amount"` // 0 until the payer fills it in
+	Currency        string    `json:"currency"`
+	Description     string    `json:"description,omitempty"`
+	AmountFixed     bool      `json:"amount_fixed"` // Set by the QR code; the payer cannot change it
+}
+
+// NewQRTransferDraft prefills a transfer from a payment QR code and the payer's request. The
+// payer may fill in the amount and description only where the code leaves them open.
+func NewQRTransferDraft(q *PaymentQR, req *QRTransferRequest, recipient *AccountLookup) (*QRTransferDraft, error) {
+	draft := &QRTransferDraft{
+		ToUserID:        recipient.UserID,
+		ToAccountNumber: recipient.AccountNumber,
+		ToUsername:      recipient.Username,
+		Amount:          q.Amount,
+		Currency:        q.Currency,
+		Description:     q.Description,
+		AmountFixed:     q.Amount > 0,
+	}
+
+	if req.Amount > 0 {
+		if draft.AmountFixed && req.Amount != q.Amount {
+			return nil, fmt.Errorf("the payment QR code fixes the amount at %s", FormatAmount(q.Amount, q.Currency))
+		}
+		if err := ValidateCurrencyAmount(q.Currency, req.Amount); err != nil {
+			return nil, fmt.Errorf("amount: %w", err)
+		}
+		draft.Amount = req.Amount
+	}
+	if req.Description != "" {
+		if q.Description != "" && req.Description != q.Description {
+			return nil, fmt.Errorf("the payment QR code fixes the description")
+		}
+		draft.Description = req.Description
+	}
+
+	return draft, nil
+}
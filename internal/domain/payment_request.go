@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPaymentRequestExpiry is how long a payment request stays open when no expiry is given.
+	DefaultPaymentRequestExpiry = 7 * 24 * time.Hour
+	// MaxPaymentRequestExpiry is the furthest in the future a payment request may expire.
+	MaxPaymentRequestExpiry = 30 * 24 * time.Hour
+)
+
+// PaymentRequest represents a request from one user (the requester) for another user (the payer) to pay them.
+type PaymentRequest struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	RequesterID   uuid.UUID  `json:"requester_id" db:"requester_id"`
+	PayerID       uuid.UUID  `json:"payer_id" db:"payer_id"`
+	Amount        float64    `json:"amount" db:"amount"`
+	Currency      string     `json:"currency" db:"currency"`
+	Note          string     `json:"note,omitempty" db:"note"`
+	Status        string     `json:"status" db:"status"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty" db:"transaction_id"`
+	ExpiresAt     time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+}
+
+// PaymentRequestStatus defines valid payment request statuses.
+type PaymentRequestStatus string
+
+const (
+	// PaymentRequestPending represents a request awaiting the payer's response
+	PaymentRequestPending PaymentRequestStatus = "pending"
+	// PaymentRequestApproved represents a request the payer approved and paid
+	PaymentRequestApproved PaymentRequestStatus = "approved"
+	// PaymentRequestDeclined represents a request the payer declined
+	PaymentRequestDeclined PaymentRequestStatus = "declined"
+	// PaymentRequestExpired represents a request that expired before the payer responded
+	PaymentRequestExpired PaymentRequestStatus = "expired"
+)
+
+// IsExpiredAt reports whether a pending request has passed its expiry at the given time.
+func (p *PaymentRequest) IsExpiredAt(now time.Time) bool {
+	return p.Status == string(PaymentRequestPending) && !now.Before(p.ExpiresAt)
+}
+
+// CreatePaymentRequestRequest represents the data needed to request a payment from another user.
+type CreatePaymentRequestRequest struct {
+	PayerID   uuid.UUID  `json:"payer_id"`
+	Amount    float64    `json:"amount"`
+	Currency  string     `json:"currency"`
+	Note      string     `json:"note"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Validate validates the create payment request request.
+func (r *CreatePaymentRequestRequest) Validate() error {
+	if r.PayerID == uuid.Nil {
+		return fmt.Errorf("payer_id: payer_id is required")
+	}
+
+	if err := validateTransactionAmount(r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if !IsValidCurrency(r.Currency) {
+		return fmt.Errorf("currency: unsupported currency: %s", r.Currency)
+	}
+
+	if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	// The payment request is paid with a transfer of its amount
+	if err := CheckAmountLimit(r.Currency, TypeTransfer, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if len(strings.TrimSpace(r.Note)) > 500 {
+		return fmt.Errorf("note: note must be at most 500 characters")
+	}
+
+	if r.ExpiresAt != nil {
+		now := time.Now()
+		if !r.ExpiresAt.After(now) {
+			return fmt.Errorf("expires_at: expires_at must be in the future")
+		}
+		if r.ExpiresAt.After(now.Add(MaxPaymentRequestExpiry)) {
+			return fmt.Errorf("expires_at: expires_at must be within 30 days")
+		}
+	}
+
+	return nil
+}
+
+// PaymentRequestFilter represents filters for payment request queries.
+type PaymentRequestFilter struct {
+	RequesterID *uuid.UUID `json:"requester_id,omitempty"`
+	PayerID     *uuid.UUID `json:"payer_id,omitempty"`
+	Status      *string    `json:"status,omitempty"`
+	Limit       int        `json:"limit,omitempty"`
+	Offset      int        `json:"offset,omitempty"`
+}
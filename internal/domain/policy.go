@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Duration is a time.Duration written in JSON as a Go duration string, e.g. "24h".
+type Duration time.Duration
+
+// MarshalJSON writes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON reads a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string, e.g. \"24h\"")
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// TransferFee is the fee charged to the sender of a transfer in one currency: a fixed amount plus
+// a percentage of the amount transferred.
+type TransferFee struct {
+	Fixed   float64 `json:"fixed"`
+	Percent float64 `json:"percent"`
+}
+
+// For returns the fee of transferring amount, rounded to the currency's decimal places.
+func (f TransferFee) For(amount float64, currency string) float64 {
+	return RoundAmount(f.Fixed+amount*f.Percent/100, currency)
+}
+
+// transferFeePrefix starts the description of the debit charging a transfer's fee.
+const transferFeePrefix = "Fee for transfer "
+
+// TransferFeeDescription returns the description of the debit charging the fee of a transfer.
+func TransferFeeDescription(transferID uuid.UUID) string {
+	return transferFeePrefix + transferID.String()
+}
+
+// IsTransferFee reports whether a transaction is the debit charging a transfer's fee.
+func IsTransferFee(t *Transaction) bool {
+	return t.Type == string(TypeDebit) && strings.HasPrefix(t.Description, transferFeePrefix)
+}
+
+// Policy holds the business rules admins may change at runtime. Each change is stored as a new
+// version of the whole policy, taking effect at its effective time.
+type Policy struct {
+	RollbackWindow Duration     `json:"rollback_window"` // How long users may roll back their own transactions
+	AmountLimits   AmountLimits `json:"amount_limits"`
+	// Amount from which rollbacks and balance adjustments listed in APPROVAL_ACTIONS need a second
+	// admin; smaller ones execute at once. Actions without a threshold always need approval.
+	ApprovalThresholds map[ApprovalAction]float64 `json:"approval_thresholds"`
+	TransferFees       map[string]TransferFee     `json:"transfer_fees"` // Keyed by currency; transfers in other currencies are free
+}
+
+// Validate validates the policy.
+func (p *Policy) Validate() error {
+	if p.RollbackWindow <= 0 {
+		return fmt.Errorf("rollback_window: rollback_window must be positive")
+	}
+
+	for _, currency := range sortedKeys(p.AmountLimits) {
+		if !IsKnownCurrency(currency) {
+			return fmt.Errorf("amount_limits: unsupported currency: %s", currency)
+		}
+		for txType, limit := range p.AmountLimits[currency] {
+			switch {
+			case !IsTransactionType(string(txType)) && txType != AnyTransactionType:
+				return fmt.Errorf("amount_limits: type for %s must be one of %s, %s, got %q", currency, strings.Join(TransactionTypeNames(), ", "), AnyTransactionType, txType)
+			case limit.Min < 0 || limit.Max < 0:
+				return fmt.Errorf("amount_limits: %s %s limits must not be negative", currency, txType)
+			case limit.Min > MaxTransactionAmount || limit.Max > MaxTransactionAmount:
+				return fmt.Errorf("amount_limits: %s %s limits must not exceed %d", currency, txType, MaxTransactionAmount)
+			case limit.Max > 0 && limit.Min > limit.Max:
+				return fmt.Errorf("amount_limits: %s %s min must not exceed max", currency, txType)
+			}
+		}
+	}
+
+	for action, threshold := range p.ApprovalThresholds {
+		if action != ApprovalRollback && action != ApprovalBalanceAdjustment {
+			return fmt.Errorf("approval_thresholds: only rollback and balance_adjustment take a threshold, got %q", action)
+		}
+		if threshold < 0 {
+			return fmt.Errorf("approval_thresholds: %s threshold must not be negative", action)
+		}
+	}
+
+	for _, currency := range sortedKeys(p.TransferFees) {
+		fee := p.TransferFees[currency]
+		switch {
+		case !IsKnownCurrency(currency):
+			return fmt.Errorf("transfer_fees: unsupported currency: %s", currency)
+		case fee.Fixed < 0 || fee.Percent < 0:
+			return fmt.Errorf("transfer_fees: %s fee must not be negative", currency)
+		case fee.Percent > 100:
+			return fmt.Errorf("transfer_fees: %s percent must be at most 100", currency)
+		}
+	}
+
+	return nil
+}
+
+// TransferFee returns the fee of transferring amount in currency.
+func (p *Policy) TransferFee(amount float64, currency string) float64 {
+	fee, ok := p.TransferFees[currency]
+	if !ok {
+		return 0
+	}
+	return fee.For(amount, currency)
+}
+
+// NeedsApproval reports whether an action moving amount reaches the action's approval threshold.
+func (p *Policy) NeedsApproval(action ApprovalAction, amount float64) bool {
+	threshold, ok := p.ApprovalThresholds[action]
+	return !ok || amount >= threshold
+}
+
+// sortedKeys returns the keys of a map keyed by currency in order, so validation reports the same
+// problem first every time.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PolicyVersion is a stored version of the policy. The version in effect at any time is the one
+// with the latest effective time not after it; version 0 is the configured policy in effect
+// before any version was stored.
+type PolicyVersion struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	Version       int       `json:"version" db:"version"`
+	Policy        Policy    `json:"policy" db:"policy"`
+	EffectiveFrom time.Time `json:"effective_from" db:"effective_from"`
+	Reason        string    `json:"reason,omitempty" db:"reason"`
+	CreatedBy     uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// PolicyVersionFilter represents filters for listing policy versions.
+type PolicyVersionFilter struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// SetPolicyRequest represents a new version of the policy. Without an effective time it takes
+// effect as soon as it is stored.
+type SetPolicyRequest struct {
+	Policy        Policy     `json:"policy"`
+	EffectiveFrom *time.Time `json:"effective_from,omitempty"`
+	Reason        string     `json:"reason"`
+}
+
+// Validate validates the policy request.
+func (r *SetPolicyRequest) Validate() error {
+	if strings.TrimSpace(r.Reason) == "" {
+		return fmt.Errorf("reason: reason is required")
+	}
+	if len(r.Reason) > 500 {
+		return fmt.Errorf("reason: reason must be at most 500 characters")
+	}
+
+	return r.Policy.Validate()
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectionRebuildStatus defines the lifecycle states of a read model rebuild.
+type ProjectionRebuildStatus string
+
+const (
+	// ProjectionRebuildRunning is replaying events
+	ProjectionRebuildRunning ProjectionRebuildStatus = "running"
+	// ProjectionRebuildCompleted replayed every event it selected
+	ProjectionRebuildCompleted ProjectionRebuildStatus = "completed"
+	// ProjectionRebuildFailed could not read its events or was interrupted
+	ProjectionRebuildFailed ProjectionRebuildStatus = "failed"
+)
+
+// ProjectedAggregateTypes are the aggregate types whose events the projector applies to read models.
+var ProjectedAggregateTypes = []AggregateType{AggregateUser, AggregateBalance, AggregateTransaction}
+
+// ProjectionRebuildRequest selects what a rebuild replays: every projected aggregate by default,
+// one aggregate type, or a single aggregate when an ID is given as well.
+type ProjectionRebuildRequest struct {
+	AggregateType *AggregateType `json:"aggregate_type,omitempty"`
+	AggregateID   *uuid.UUID     `json:"aggregate_id,omitempty"`
+}
+
+// Validate validates a projection rebuild request.
+func (r *ProjectionRebuildRequest) Validate() error {
+	if r.AggregateType != nil {
+		projected := false
+		for _, aggregateType := range ProjectedAggregateTypes {
+			projected = projected || *r.AggregateType == aggregateType
+		}
+		if !projected {
+			return fmt.Errorf("aggregate_type: aggregate_type must be user, balance or transaction")
+		}
+	}
+
+	if r.AggregateID != nil && r.AggregateType == nil {
+		return fmt.Errorf("aggregate_type: aggregate_type is required with aggregate_id")
+	}
+
+	return nil
+}
+
+// ProjectionRebuild represents one rebuild of the read models and its progress.
+type ProjectionRebuild struct {
+	ID              uuid.UUID                `json:"id"`
+	Status          ProjectionRebuildStatus  `json:"status"`
+	Request         ProjectionRebuildRequest `json:"request"`
+	EventsTotal     int                      `json:"events_total"`     // Known once the events are read
+	EventsProcessed int                      `json:"events_processed"` // Applied or skipped so far
+	EventsFailed    int                      `json:"events_failed"`    // Processed events that could not be applied
+	Progress        float64                  `json:"progress"`         // Processed share of the total, from 0 to 1
+	StartedBy       uuid.UUID                `json:"started_by"`
+	StartedAt       time.Time                `json:"started_at"`
+	FinishedAt      *time.Time               `json:"finished_at,omitempty"`
+	Error           string                   `json:"error,omitempty"` // Why the rebuild failed
+}
+
+// ComputeProgress fills in the processed share of the total.
+func (r *ProjectionRebuild) ComputeProgress() {
+	switch {
+	case r.EventsTotal > 0:
+		r.Progress = float64(r.EventsProcessed) / float64(r.EventsTotal)
+	case r.Status == ProjectionRebuildCompleted:
+		r.Progress = 1
+	default:
+		r.Progress = 0
+	}
+}
+
+// ProjectionStatus reports whether a rebuild is running and the recent rebuilds, newest first.
+type ProjectionStatus struct {
+	Running  bool                 `json:"running"`
+	Rebuilds []*ProjectionRebuild `json:"rebuilds"`
+}
+
+// ProjectionReplayRequest selects the events a dry-run replay projects: those a rebuild would
+// select, optionally only the ones created at or after Since.
+type ProjectionReplayRequest struct {
+	ProjectionRebuildRequest
+	Since *time.Time `json:"since,omitempty"`
+}
+
+// ProjectionDifference is a read model field whose replayed value differs from the stored one.
+type ProjectionDifference struct {
+	AggregateType AggregateType `json:"aggregate_type"`
+	AggregateID   uuid.UUID     `json:"aggregate_id"`
+	Field         string        `json:"field"` // "missing" when the read model has no such record
+	Projected     string        `json:"projected"`
+	Current       string        `json:"current"`
+}
+
+// ProjectionReplayReport compares a dry-run replay with the read models.
+type ProjectionReplayReport struct {
+	EventsReplayed int                    `json:"events_replayed"`
+	EventsFailed   int                    `json:"events_failed"` // Events the replay could not apply
+	Users          int                    `json:"users"`         // Records the replay produced, by kind
+	Balances       int                    `json:"balances"`
+	Transactions   int                    `json:"transactions"`
+	Differences    []ProjectionDifference `json:"differences"`
+}
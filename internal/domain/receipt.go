@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReceiptAlgorithm names how receipts are signed: the hex HMAC-SHA256 of the receipt's JSON
+// encoding under the server's receipt signing key.
+const ReceiptAlgorithm = "HMAC-SHA256"
+
+// ReceiptVersion is the layout version of receipts issued by this server.
+const ReceiptVersion = 1
+
+// TransactionReceipt holds the details of a successful transaction that never change, e.g. to
+// prove a payment to someone who cannot see the transaction.
+type TransactionReceipt struct {
+	Version           int        `json:"version"`
+	TransactionID     uuid.UUID  `json:"transaction_id"`
+	Type              string     `json:"type"`
+	Status            string     `json:"status"`
+	FromUserID        *uuid.UUID `json:"from_user_id,omitempty"`
+	FromAccountNumber string     `json:"from_account_number,omitempty"`
+	ToUserID          *uuid.UUID `json:"to_user_id,omitempty"`
+	ToAccountNumber   string     `json:"to_account_number,omitempty"`
+	Amount            float64    `json:"amount"`
+	Currency          string     `json:"currency"`
+	Description       string     `json:"description,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	IssuedAt          time.Time  `json:"issued_at"`
+}
+
+// NewTransactionReceipt creates the receipt of a successful transaction.
+func NewTransactionReceipt(tx *Transaction, issuedAt time.Time) (*TransactionReceipt, error) {
+	if tx.Status != string(StatusSuccess) {
+		return nil, fmt.Errorf("cannot issue a receipt for a %s transaction", tx.Status)
+	}
+
+	receipt := &TransactionReceipt{
+		Version:       ReceiptVersion,
+		TransactionID: tx.ID,
+		Type:          tx.Type,
+		Status:        tx.Status,
+		FromUserID:    tx.FromUserID,
+		ToUserID:      tx.ToUserID,
+		Amount:        tx.Amount,
+		Currency:      tx.Currency,
+		Description:   tx.Description,
+		CreatedAt:     tx.CreatedAt.UTC(),
+		IssuedAt:      issuedAt.UTC(),
+	}
+	if tx.FromUserID != nil {
+		receipt.FromAccountNumber = AccountNumberFor(*tx.FromUserID)
+	}
+	if tx.ToUserID != nil {
+		receipt.ToAccountNumber = AccountNumberFor(*tx.ToUserID)
+	}
+	return receipt, nil
+}
+
+// Matches reports whether the receipt describes tx as it is stored.
+func (r *TransactionReceipt) Matches(tx *Transaction) bool {
+	return r.TransactionID == tx.ID &&
+		r.Type == tx.Type &&
+		sameUser(r.FromUserID, tx.FromUserID) &&
+		sameUser(r.ToUserID, tx.ToUserID) &&
+		r.Amount == tx.Amount &&
+		r.Currency == tx.Currency &&
+		r.Description == tx.Description &&
+		r.CreatedAt.Equal(tx.CreatedAt)
+}
+
+// sameUser reports whether two optional user IDs are both unset or equal.
+func sameUser(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Sign signs the receipt with key.
+func (r *TransactionReceipt) Sign(key []byte) (*SignedReceipt, error) {
+	signature, err := r.signature(key)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedReceipt{Receipt: *r, Algorithm: ReceiptAlgorithm, Signature: signature}, nil
+}
+
+// signature returns the hex HMAC-SHA256 of the receipt's JSON encoding under key.
+func (r *TransactionReceipt) signature(key []byte) (string, error) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode receipt: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// SignedReceipt is a receipt with the server's signature over it.
+type SignedReceipt struct {
+	Receipt   TransactionReceipt `json:"receipt"`
+	Algorithm string             `json:"algorithm"`
+	Signature string             `json:"signature"`
+}
+
+// Validate validates a signed receipt submitted for verification
+func (s *SignedReceipt) Validate() error {
+	if s.Receipt.TransactionID == uuid.Nil {
+		return fmt.Errorf("receipt.transaction_id is required")
+	}
+	if s.Algorithm != ReceiptAlgorithm {
+		return fmt.Errorf("algorithm must be %s", ReceiptAlgorithm)
+	}
+	if s.Signature == "" {
+		return fmt.Errorf("signature is required")
+	}
+	return nil
+}
+
+// VerifySignature reports whether the signature was made with key over the receipt as it is.
+func (s *SignedReceipt) VerifySignature(key []byte) bool {
+	want, err := s.Receipt.signature(key)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(want), []byte(s.Signature))
+}
+
+// ReceiptVerification reports whether a signed receipt is genuine. A genuine receipt carries the
+// transaction's current status, e.g. to show it was reversed after the receipt was issued.
+type ReceiptVerification struct {
+	Valid             bool      `json:"valid"`
+	Reason            string    `json:"reason,omitempty"` // Why an invalid receipt was rejected
+	TransactionID     uuid.UUID `json:"transaction_id"`
+	TransactionStatus string    `json:"transaction_status,omitempty"`
+	ReversedAmount    float64   `json:"reversed_amount,omitempty"`
+}
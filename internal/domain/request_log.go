@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestLogDetails is the audit log payload of a recorded request/response exchange.
+// Bodies are stored with sensitive fields redacted.
+type RequestLogDetails struct {
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Route        string          `json:"route,omitempty"`
+	Status       int             `json:"status"`
+	DurationMs   int64           `json:"duration_ms"`
+	UserID       string          `json:"user_id,omitempty"`
+	RemoteAddr   string          `json:"remote_addr,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+}
+
+// RequestLog represents a recorded exchange for a money-movement endpoint.
+type RequestLog struct {
+	ID        uuid.UUID `json:"id"`
+	RequestID uuid.UUID `json:"request_id"` // Matches the X-Request-ID response header
+	RequestLogDetails
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RequestLogFromAudit converts an http_request audit log entry to a RequestLog.
+func RequestLogFromAudit(a *AuditLog) (*RequestLog, error) {
+	requestLog := &RequestLog{
+		ID:        a.ID,
+		RequestID: a.EntityID,
+		CreatedAt: a.CreatedAt,
+	}
+	if len(a.Details) > 0 {
+		if err := json.Unmarshal(a.Details, &requestLog.RequestLogDetails); err != nil {
+			return nil, fmt.Errorf("failed to decode request log details: %w", err)
+		}
+	}
+	return requestLog, nil
+}
+
+// RequestLogFilter represents filters for request log queries.
+type RequestLogFilter struct {
+	Since  *time.Time `json:"since,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+	Offset int        `json:"offset,omitempty"`
+}
@@ -16,6 +16,7 @@ type ScheduledTransaction struct {
 	Currency        string     `json:"currency" db:"currency"`
 	Description     string     `json:"description,omitempty" db:"description"`
 	ToUserID        *uuid.UUID `json:"to_user_id,omitempty" db:"to_user_id"`
+	TemplateID      *uuid.UUID `json:"template_id,omitempty" db:"template_id"`
 
 	// Scheduling
 	ScheduleType      string     `json:"schedule_type" db:"schedule_type"`
@@ -25,10 +26,23 @@ type ScheduledTransaction struct {
 	MaxOccurrences    *int       `json:"max_occurrences,omitempty" db:"max_occurrences"`
 	CurrentOccurrence int        `json:"current_occurrence" db:"current_occurrence"`
 
+	// Occurrences on weekends and holidays of the calendar region move to a business day under
+	// the business day rule; AdjustedExecuteAt is set while the upcoming one is moved
+	BusinessDayRule   string     `json:"business_day_rule" db:"business_day_rule"`
+	CalendarRegion    string     `json:"calendar_region,omitempty" db:"calendar_region"` // Empty for the default region
+	AdjustedExecuteAt *time.Time `json:"adjusted_execute_at,omitempty" db:"adjusted_execute_at"`
+
 	// Status
 	Status   string `json:"status" db:"status"`
 	IsActive bool   `json:"is_active" db:"is_active"`
 
+	// Failed executions of the current occurrence, retried at RetryAt within the same day
+	FailedAttempts int        `json:"failed_attempts" db:"failed_attempts"`
+	RetryAt        *time.Time `json:"retry_at,omitempty" db:"retry_at"`
+
+	// Occurrence the owner was last warned about insufficient funds for
+	FundingAlertedFor *time.Time `json:"funding_alerted_for,omitempty" db:"funding_alerted_for"`
+
 	// Audit
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
@@ -45,6 +59,7 @@ type ScheduledTransactionResponse struct {
 	Currency        string     `json:"currency"`
 	Description     string     `json:"description,omitempty"`
 	ToUserID        *uuid.UUID `json:"to_user_id,omitempty"`
+	TemplateID      *uuid.UUID `json:"template_id,omitempty"`
 
 	ScheduleType      string     `json:"schedule_type"`
 	ExecuteAt         time.Time  `json:"execute_at"`
@@ -53,9 +68,16 @@ type ScheduledTransactionResponse struct {
 	MaxOccurrences    *int       `json:"max_occurrences,omitempty"`
 	CurrentOccurrence int        `json:"current_occurrence"`
 
+	BusinessDayRule   string     `json:"business_day_rule"`
+	CalendarRegion    string     `json:"calendar_region,omitempty"`
+	AdjustedExecuteAt *time.Time `json:"adjusted_execute_at,omitempty"`
+
 	Status   string `json:"status"`
 	IsActive bool   `json:"is_active"`
 
+	FailedAttempts int        `json:"failed_attempts,omitempty"`
+	RetryAt        *time.Time `json:"retry_at,omitempty"`
+
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	LastExecutedAt  *time.Time `json:"last_executed_at,omitempty"`
@@ -72,14 +94,20 @@ func (st *ScheduledTransaction) ToResponse() ScheduledTransactionResponse {
 		Currency:          st.Currency,
 		Description:       st.Description,
 		ToUserID:          st.ToUserID,
+		TemplateID:        st.TemplateID,
 		ScheduleType:      st.ScheduleType,
 		ExecuteAt:         st.ExecuteAt,
 		RecurrencePattern: st.RecurrencePattern,
 		RecurrenceEndDate: st.RecurrenceEndDate,
 		MaxOccurrences:    st.MaxOccurrences,
 		CurrentOccurrence: st.CurrentOccurrence,
+		BusinessDayRule:   st.BusinessDayRule,
+		CalendarRegion:    st.CalendarRegion,
+		AdjustedExecuteAt: st.AdjustedExecuteAt,
 		Status:            st.Status,
 		IsActive:          st.IsActive,
+		FailedAttempts:    st.FailedAttempts,
+		RetryAt:           st.RetryAt,
 		CreatedAt:         st.CreatedAt,
 		UpdatedAt:         st.UpdatedAt,
 		LastExecutedAt:    st.LastExecutedAt,
@@ -95,11 +123,20 @@ type ScheduledTransactionRequest struct {
 	Description     string     `json:"description,omitempty"`
 	ToUserID        *uuid.UUID `json:"to_user_id,omitempty"`
 
+	// TemplateID binds the scheduled transaction to a transfer template. The template's payee,
+	// currency and default amount fill in any of to_user_id, currency and amount left empty.
+	TemplateID *uuid.UUID `json:"template_id,omitempty"`
+
 	ScheduleType      string     `json:"schedule_type"`
 	ExecuteAt         time.Time  `json:"execute_at"`
 	RecurrencePattern *string    `json:"recurrence_pattern,omitempty"`
 	RecurrenceEndDate *time.Time `json:"recurrence_end_date,omitempty"`
 	MaxOccurrences    *int       `json:"max_occurrences,omitempty"`
+
+	// BusinessDayRule moves occurrences falling on weekends and holidays: "following" or
+	// "preceding" business day, or "none" (the default) to execute on the day itself.
+	BusinessDayRule string `json:"business_day_rule,omitempty"`
+	CalendarRegion  string `json:"calendar_region,omitempty"` // Holidays of this region apply; defaults to the default region
 }
 
 // Validate validates the scheduled transaction request
@@ -118,6 +155,17 @@ func (r *ScheduledTransactionRequest) Validate() error {
 	if !IsValidCurrency(r.Currency) {
 		return fmt.Errorf("unsupported currency: %s", r.Currency)
 	}
+	if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+		return err
+	}
+	if err := CheckAmountLimit(r.Currency, TransactionType(r.TransactionType), r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	// Executions copy the description onto the transactions they create
+	if err := validateTransactionDescription(r.Description); err != nil {
+		return err
+	}
 
 	// Validate schedule type (accept both "once" and "one-time" for better UX)
 	if r.ScheduleType != "once" && r.ScheduleType != "one-time" && r.ScheduleType != "recurring" {
@@ -129,6 +177,10 @@ func (r *ScheduledTransactionRequest) Validate() error {
 		return fmt.Errorf("execute_at must be in the future")
 	}
 
+	if r.BusinessDayRule != "" && !IsValidBusinessDayRule(r.BusinessDayRule) {
+		return fmt.Errorf("invalid business_day_rule: must be 'none', 'following', or 'preceding'")
+	}
+
 	// Validate transfer-specific fields
 	if r.TransactionType == "transfer" {
 		if r.ToUserID == nil {
@@ -226,10 +278,60 @@ type ScheduledTransactionFilter struct {
 	IsActive    *bool      `json:"is_active,omitempty"`
 	ExecuteFrom *time.Time `json:"execute_from,omitempty"`
 	ExecuteTo   *time.Time `json:"execute_to,omitempty"`
+	FailedSince *time.Time `json:"failed_since,omitempty"` // Only schedules with a failed execution since then
 	Limit       int        `json:"limit,omitempty"`
 	Offset      int        `json:"offset,omitempty"`
 }
 
+// MaxFailureReportDays bounds how far back the scheduled failure report and filter look.
+const MaxFailureReportDays = 90
+
+// ScheduledFailureReason aggregates failed executions sharing an error message prefix, the part
+// before the first colon, so that messages differing only in amounts group together.
+type ScheduledFailureReason struct {
+	Reason                string    `json:"reason"`
+	Failures              int       `json:"failures"`
+	ScheduledTransactions int       `json:"scheduled_transactions"` // Distinct schedules that failed for this reason
+	LastFailedAt          time.Time `json:"last_failed_at"`
+}
+
+// ScheduledFailureReport summarizes failed scheduled executions since a point in time, most
+// frequent reason first.
+type ScheduledFailureReport struct {
+	Since         time.Time                `json:"since"`
+	TotalFailures int                      `json:"total_failures"`
+	Reasons       []ScheduledFailureReason `json:"reasons"`
+}
+
+// ScheduledReassignRequest names the active user an admin hands the open scheduled transactions
+// of a deactivated user to, e.g. on account closure.
+type ScheduledReassignRequest struct {
+	ToUserID uuid.UUID `json:"to_user_id"`
+}
+
+// Validate validates the reassign request
+func (r *ScheduledReassignRequest) Validate() error {
+	if r.ToUserID == uuid.Nil {
+		return fmt.Errorf("to_user_id is required")
+	}
+	return nil
+}
+
+// ScheduledOwnerAction reports an admin cancelling or reassigning the open (active or paused)
+// scheduled transactions of a user.
+type ScheduledOwnerAction struct {
+	UserID                  uuid.UUID                     `json:"user_id"`
+	ToUserID                *uuid.UUID                    `json:"to_user_id,omitempty"` // Set when reassigning
+	ScheduledTransactionIDs []uuid.UUID                   `json:"scheduled_transaction_ids"`
+	Skipped                 []SkippedScheduledTransaction `json:"skipped"`
+}
+
+// SkippedScheduledTransaction is an open scheduled transaction an admin action left as it was.
+type SkippedScheduledTransaction struct {
+	ID     uuid.UUID `json:"id"`
+	Reason string    `json:"reason"`
+}
+
 // ScheduledTransactionUpdateRequest represents request to update scheduled transaction
 type ScheduledTransactionUpdateRequest struct {
 	Description       *string    `json:"description,omitempty"`
@@ -256,6 +358,12 @@ func (r *ScheduledTransactionUpdateRequest) Validate() error {
 		}
 	}
 
+	if r.Description != nil {
+		if err := validateTransactionDescription(*r.Description); err != nil {
+			return err
+		}
+	}
+
 	if r.ExecuteAt != nil && r.ExecuteAt.Before(time.Now()) {
 		return fmt.Errorf("execute_at must be in the future")
 	}
@@ -277,24 +385,16 @@ func (st *ScheduledTransaction) CalculateNextExecution() *time.Time {
 		return nil
 	}
 
-	var nextTime time.Time
 	baseTime := st.ExecuteAt
 
-	// If this is not the first occurrence, use last execution time as base
-	if st.LastExecutedAt != nil {
+	// If this is not the first occurrence, use last execution time as base, unless the
+	// schedule was moved past it since (e.g. when resumed after a pause)
+	if st.LastExecutedAt != nil && st.LastExecutedAt.After(st.ExecuteAt) {
 		baseTime = *st.LastExecutedAt
 	}
 
-	switch *st.RecurrencePattern {
-	case "daily":
-		nextTime = baseTime.AddDate(0, 0, 1)
-	case "weekly":
-		nextTime = baseTime.AddDate(0, 0, 7)
-	case "monthly":
-		nextTime = baseTime.AddDate(0, 1, 0)
-	case "yearly":
-		nextTime = baseTime.AddDate(1, 0, 0)
-	default:
+	nextTime, ok := nextOccurrence(*st.RecurrencePattern, baseTime)
+	if !ok {
 		return nil
 	}
 
@@ -310,14 +410,305 @@ func (st *ScheduledTransaction) CalculateNextExecution() *time.Time {
 	return &nextTime
 }
 
+// DueAt returns when the upcoming occurrence executes: ExecuteAt, or the business day the
+// calendar moved it to.
+func (st *ScheduledTransaction) DueAt() time.Time {
+	if st.AdjustedExecuteAt != nil {
+		return *st.AdjustedExecuteAt
+	}
+	return st.ExecuteAt
+}
+
+// ApplyCalendar moves the upcoming occurrence to a business day of the schedule's calendar region
+// if its business day rule asks for it. It must be called whenever ExecuteAt changes. Recurrences
+// keep following ExecuteAt, so a moved occurrence does not shift the ones after it.
+func (st *ScheduledTransaction) ApplyCalendar(calendar *BusinessCalendar) {
+	st.AdjustedExecuteAt = nil
+	if adjusted := calendar.Adjust(st.ExecuteAt, st.BusinessDayRule, st.CalendarRegion); !adjusted.Equal(st.ExecuteAt) {
+		st.AdjustedExecuteAt = &adjusted
+	}
+}
+
+// nextOccurrence returns the occurrence following t in a recurrence pattern.
+func nextOccurrence(pattern string, t time.Time) (time.Time, bool) {
+	switch pattern {
+	case "daily":
+		return t.AddDate(0, 0, 1), true
+	case "weekly":
+		return t.AddDate(0, 0, 7), true
+	case "monthly":
+		return t.AddDate(0, 1, 0), true
+	case "yearly":
+		return t.AddDate(1, 0, 0), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Pause stops an active scheduled transaction from executing until it is resumed.
+func (st *ScheduledTransaction) Pause(now time.Time) error {
+	switch st.Status {
+	case "active":
+	case "paused":
+		return fmt.Errorf("scheduled transaction is already paused")
+	default:
+		return fmt.Errorf("cannot pause a %s scheduled transaction", st.Status)
+	}
+
+	st.Status = "paused"
+	st.UpdatedAt = now
+	return nil
+}
+
+// Resume reactivates a paused scheduled transaction. Recurring occurrences missed while paused
+// are skipped, so the next execution is the first occurrence from now on; a one-time transaction
+// whose time has passed executes right away.
+func (st *ScheduledTransaction) Resume(now time.Time) error {
+	switch st.Status {
+	case "paused":
+	case "active":
+		return fmt.Errorf("scheduled transaction is not paused")
+	default:
+		return fmt.Errorf("cannot resume a %s scheduled transaction", st.Status)
+	}
+
+	if st.ScheduleType == "recurring" && st.RecurrencePattern != nil {
+		executeAt := st.ExecuteAt
+		for executeAt.Before(now) {
+			next, ok := nextOccurrence(*st.RecurrencePattern, executeAt)
+			if !ok {
+				break
+			}
+			executeAt = next
+		}
+		if st.RecurrenceEndDate != nil && executeAt.After(*st.RecurrenceEndDate) {
+			return fmt.Errorf("no occurrences remain before recurrence_end_date")
+		}
+		st.ExecuteAt = executeAt
+	}
+
+	st.Status = "active"
+	st.IsActive = true
+	st.UpdatedAt = now
+	st.NextExecutionAt = st.CalculateNextExecution()
+	st.clearRetry()
+	return nil
+}
+
+// SkipNext moves a recurring scheduled transaction past its upcoming occurrence without
+// executing it. Skipped occurrences do not count towards max_occurrences.
+func (st *ScheduledTransaction) SkipNext(now time.Time) error {
+	if st.ScheduleType != "recurring" || st.RecurrencePattern == nil {
+		return fmt.Errorf("only recurring scheduled transactions can skip an occurrence")
+	}
+
+	switch st.Status {
+	case "active", "paused":
+	default:
+		return fmt.Errorf("cannot skip an occurrence of a %s scheduled transaction", st.Status)
+	}
+
+	// Once executed, the upcoming occurrence follows the last execution
+	upcoming := st.ExecuteAt
+	if st.LastExecutedAt != nil && !st.LastExecutedAt.Before(st.ExecuteAt) {
+		next, ok := nextOccurrence(*st.RecurrencePattern, *st.LastExecutedAt)
+		if !ok {
+			return fmt.Errorf("invalid recurrence pattern: %s", *st.RecurrencePattern)
+		}
+		upcoming = next
+	}
+
+	executeAt, ok := nextOccurrence(*st.RecurrencePattern, upcoming)
+	if !ok {
+		return fmt.Errorf("invalid recurrence pattern: %s", *st.RecurrencePattern)
+	}
+	if st.RecurrenceEndDate != nil && executeAt.After(*st.RecurrenceEndDate) {
+		return fmt.Errorf("no occurrences remain after the skipped one")
+	}
+
+	st.ExecuteAt = executeAt
+	st.UpdatedAt = now
+	st.NextExecutionAt = st.CalculateNextExecution()
+	st.clearRetry()
+	return nil
+}
+
+// IsOpen reports whether a scheduled transaction may still execute, now or once resumed.
+func (st *ScheduledTransaction) IsOpen() bool {
+	return st.Status == "active" || st.Status == "paused"
+}
+
+// Reassign hands an open scheduled transaction to another owner. The previous owner's transfer
+// template binding, funding alert and pending retry do not carry over.
+func (st *ScheduledTransaction) Reassign(userID uuid.UUID, now time.Time) error {
+	if !st.IsOpen() {
+		return fmt.Errorf("cannot reassign a %s scheduled transaction", st.Status)
+	}
+	if st.ToUserID != nil && *st.ToUserID == userID {
+		return fmt.Errorf("cannot reassign a transfer to its recipient")
+	}
+
+	st.UserID = userID
+	st.TemplateID = nil
+	st.FundingAlertedFor = nil
+	st.UpdatedAt = now
+	st.clearRetry()
+	return nil
+}
+
+// AdvanceOccurrence moves a recurring scheduled transaction from the occurrence it just
+// executed to the following one. It reports false when no occurrence remains before the
+// recurrence end date.
+func (st *ScheduledTransaction) AdvanceOccurrence() bool {
+	if st.RecurrencePattern == nil {
+		return false
+	}
+
+	next, ok := nextOccurrence(*st.RecurrencePattern, st.ExecuteAt)
+	if !ok || (st.RecurrenceEndDate != nil && next.After(*st.RecurrenceEndDate)) {
+		return false
+	}
+
+	st.ExecuteAt = next
+	return true
+}
+
+// ScheduleRetry schedules another attempt at the current occurrence after a failed execution.
+// Attempts are only retried on the day they failed, at most maxRetries times; it reports false
+// when no retry remains.
+func (st *ScheduledTransaction) ScheduleRetry(now time.Time, maxRetries int, interval time.Duration) bool {
+	retryAt := now.Add(interval)
+	if st.FailedAttempts >= maxRetries || !sameDay(now, retryAt) {
+		return false
+	}
+
+	st.FailedAttempts++
+	st.RetryAt = &retryAt
+	st.UpdatedAt = now
+	return true
+}
+
+// clearRetry forgets the failed attempts of the current occurrence.
+func (st *ScheduledTransaction) clearRetry() {
+	st.FailedAttempts = 0
+	st.RetryAt = nil
+}
+
+// sameDay reports whether a and b fall on the same UTC calendar day.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// NeedsFundingAlert reports whether the owner has not yet been warned about insufficient funds
+// for the upcoming occurrence.
+func (st *ScheduledTransaction) NeedsFundingAlert() bool {
+	return st.FundingAlertedFor == nil || !st.FundingAlertedFor.Equal(st.ExecuteAt)
+}
+
+// Projected executions can be requested for at most a year at a time.
+const (
+	DefaultUpcomingWindow = 30 * 24 * time.Hour
+	MaxUpcomingWindow     = 366 * 24 * time.Hour
+)
+
+// UpcomingExecution is a projected execution of a scheduled transaction. Projections are
+// computed on request and never stored.
+type UpcomingExecution struct {
+	ScheduledTransactionID uuid.UUID  `json:"scheduled_transaction_id"`
+	ExecuteAt              time.Time  `json:"execute_at"`
+	Occurrence             int        `json:"occurrence"` // 1 for the first execution of the schedule
+	TransactionType        string     `json:"transaction_type"`
+	Amount                 float64    `json:"amount"`
+	Currency               string     `json:"currency"`
+	Description            string     `json:"description,omitempty"`
+	ToUserID               *uuid.UUID `json:"to_user_id,omitempty"`
+	ScheduleType           string     `json:"schedule_type"`
+}
+
+// UpcomingExecutions lists the projected executions within a window, earliest first.
+type UpcomingExecutions struct {
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Executions []UpcomingExecution `json:"executions"`
+	Count      int                 `json:"count"`
+	Truncated  bool                `json:"truncated"` // More executions fall within the window than were listed
+}
+
+// ValidateUpcomingWindow checks the window of an upcoming executions request.
+func ValidateUpcomingWindow(from, to time.Time) error {
+	if !to.After(from) {
+		return fmt.Errorf("to must be after from")
+	}
+	if to.Sub(from) > MaxUpcomingWindow {
+		return fmt.Errorf("window must not exceed %d days", int(MaxUpcomingWindow.Hours()/24))
+	}
+	return nil
+}
+
+// ProjectExecutions returns the executions of an active scheduled transaction at or after from
+// and before to, at most limit of them, moved to business days of the calendar under the
+// schedule's business day rule. A pending retry replaces the occurrence it retries.
+func (st *ScheduledTransaction) ProjectExecutions(from, to time.Time, limit int, calendar *BusinessCalendar) []UpcomingExecution {
+	if !st.IsActive || st.Status != "active" {
+		return nil
+	}
+	if st.ScheduleType != "recurring" && st.LastExecutedAt != nil {
+		return nil
+	}
+
+	var executions []UpcomingExecution
+	occurrence := st.CurrentOccurrence + 1
+	for at := st.ExecuteAt; len(executions) < limit; occurrence++ {
+		if st.RecurrenceEndDate != nil && at.After(*st.RecurrenceEndDate) {
+			break
+		}
+		if st.MaxOccurrences != nil && occurrence > *st.MaxOccurrences {
+			break
+		}
+
+		executeAt := calendar.Adjust(at, st.BusinessDayRule, st.CalendarRegion)
+		if occurrence == st.CurrentOccurrence+1 && st.RetryAt != nil {
+			executeAt = *st.RetryAt
+		}
+		if !executeAt.Before(to) {
+			break
+		}
+		if !executeAt.Before(from) {
+			executions = append(executions, UpcomingExecution{
+				ScheduledTransactionID: st.ID,
+				ExecuteAt:              executeAt,
+				Occurrence:             occurrence,
+				TransactionType:        st.TransactionType,
+				Amount:                 st.Amount,
+				Currency:               st.Currency,
+				Description:            st.Description,
+				ToUserID:               st.ToUserID,
+				ScheduleType:           st.ScheduleType,
+			})
+		}
+
+		if st.ScheduleType != "recurring" || st.RecurrencePattern == nil {
+			break
+		}
+		next, ok := nextOccurrence(*st.RecurrencePattern, at)
+		if !ok {
+			break
+		}
+		at = next
+	}
+
+	return executions
+}
+
 // ShouldExecute checks if the scheduled transaction should be executed
 func (st *ScheduledTransaction) ShouldExecute() bool {
 	if !st.IsActive || st.Status != "active" {
 		return false
 	}
 
-	now := time.Now()
-	return st.ExecuteAt.Before(now) || st.ExecuteAt.Equal(now)
+	return !st.DueAt().After(time.Now())
 }
 
 // MarkExecuted updates the scheduled transaction after successful execution
@@ -343,16 +734,17 @@ func (st *ScheduledTransaction) MarkExecuted(executionTime time.Time) {
 	}
 }
 
-// MarkFailed updates the scheduled transaction after failed execution
+// MarkFailed updates the scheduled transaction after a failed execution that is not retried.
+// Recurring transactions give up on the failed occurrence and move on to the next one.
 func (st *ScheduledTransaction) MarkFailed() {
 	st.UpdatedAt = time.Now()
+	st.clearRetry()
 
-	// For recurring transactions, we might want to retry later
-	// For now, we'll pause the transaction
-	if st.ScheduleType == "recurring" {
-		st.Status = "paused"
-	} else {
-		st.Status = "cancelled"
-		st.IsActive = false
+	if st.ScheduleType == "recurring" && st.AdvanceOccurrence() {
+		st.NextExecutionAt = st.CalculateNextExecution()
+		return
 	}
+
+	st.Status = "cancelled"
+	st.IsActive = false
 }
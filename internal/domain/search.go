@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxSearchQueryLength is the longest full-text search query accepted.
+const MaxSearchQueryLength = 200
+
+// SearchScope is what a full-text search looks through.
+type SearchScope string
+
+const (
+	// SearchScopeMine searches the user's own transactions and payment requests
+	SearchScopeMine SearchScope = "mine"
+	// SearchScopeAudit searches the details of every audit log entry (admin only)
+	SearchScopeAudit SearchScope = "audit"
+)
+
+// SearchHitKind is the kind of record a search hit is.
+type SearchHitKind string
+
+const (
+	// SearchHitTransaction is a transaction, matched by its description, client reference or counterparty's username
+	SearchHitTransaction SearchHitKind = "transaction"
+	// SearchHitPaymentRequest is a payment request, matched by its note or counterparty's username
+	SearchHitPaymentRequest SearchHitKind = "payment_request"
+	// SearchHitAuditLog is an audit log entry, matched by its action or the strings of its details
+	SearchHitAuditLog SearchHitKind = "audit_log"
+)
+
+// Search snippets wrap the words matching the query in these markers.
+const (
+	SearchHighlightStart = "<b>"
+	SearchHighlightStop  = "</b>"
+)
+
+// SearchHit is a record matching a full-text search. Records are fetched in full by ID from their
+// own endpoints.
+type SearchHit struct {
+	Kind      SearchHitKind `json:"kind"`
+	ID        uuid.UUID     `json:"id"`
+	Rank      float64       `json:"rank"`    // Higher ranks match better
+	Snippet   string        `json:"snippet"` // The searched text with the matching words highlighted
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// SearchResults is a page of the hits of a full-text search, best match first.
+type SearchResults struct {
+	Query  string       `json:"query"`
+	Scope  SearchScope  `json:"scope"`
+	Total  int          `json:"total"` // Across every page
+	Hits   []*SearchHit `json:"hits"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// ValidateSearchQuery checks a full-text search query. Queries use web search syntax: words must
+// all match unless joined by "or", quoted words must match in order and -word excludes a word.
+func ValidateSearchQuery(query string) error {
+	switch {
+	case strings.TrimSpace(query) == "":
+		return fmt.Errorf("q is required")
+	case utf8.RuneCountInString(query) > MaxSearchQueryLength:
+		return fmt.Errorf("q must be at most %d characters", MaxSearchQueryLength)
+	case strings.IndexFunc(query, func(r rune) bool { return !unicode.IsPrint(r) && r != ' ' }) >= 0:
+		return fmt.Errorf("q must not contain control characters")
+	}
+	return nil
+}
+
+// ParseSearchScope parses the scope of a full-text search, mine unless given.
+func ParseSearchScope(scope string) (SearchScope, error) {
+	switch SearchScope(scope) {
+	case "", SearchScopeMine:
+		return SearchScopeMine, nil
+	case SearchScopeAudit:
+		return SearchScopeAudit, nil
+	}
+	return "", fmt.Errorf("scope must be one of mine, audit, got %q", scope)
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType identifies a security-relevant action on an account.
+type SecurityEventType string
+
+const (
+	// SecurityLogin is recorded for every successful login
+	SecurityLogin SecurityEventType = "login"
+	// SecurityLoginFailed is recorded for every login rejected for a wrong email or password
+	SecurityLoginFailed SecurityEventType = "login_failed"
+	// SecurityTokenRefreshed is recorded when an access token is refreshed
+	SecurityTokenRefreshed SecurityEventType = "token_refreshed"
+	// SecuritySessionRevoked is recorded when a user revokes one of their login sessions
+	SecuritySessionRevoked SecurityEventType = "session_revoked"
+	// SecurityStepUpRequired is recorded when a user's anomaly score holds up their money movement
+	SecurityStepUpRequired SecurityEventType = "step_up_required"
+	// SecurityStepUp is recorded when a user confirms their password to resume money movement
+	SecurityStepUp SecurityEventType = "step_up"
+	// SecurityStepUpFailed is recorded for every step-up rejected for a wrong password
+	SecurityStepUpFailed SecurityEventType = "step_up_failed"
+)
+
+// SecurityEvent records a security-relevant action on an account, kept apart from the audit log so
+// users can review their own security activity.
+type SecurityEvent struct {
+	ID        uuid.UUID         `json:"id" db:"id"`
+	UserID    *uuid.UUID        `json:"user_id,omitempty" db:"user_id"` // Unset for failed logins with an unknown email
+	Email     string            `json:"email,omitempty" db:"email"`     // The email a login was attempted with
+	Type      SecurityEventType `json:"type" db:"type"`
+	IP        string            `json:"ip" db:"ip"`
+	UserAgent string            `json:"user_agent" db:"user_agent"`
+	SessionID *uuid.UUID        `json:"session_id,omitempty" db:"session_id"`
+	Reason    string            `json:"reason,omitempty" db:"reason"` // Why a failed action failed
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// NewSecurityEvent returns an event of the given type for a user's action from device.
+func NewSecurityEvent(eventType SecurityEventType, userID *uuid.UUID, device LoginDevice) *SecurityEvent {
+	return &SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      eventType,
+		IP:        device.IP,
+		UserAgent: device.UserAgent,
+		CreatedAt: time.Now(),
+	}
+}
+
+// SecurityEventFilter represents filters for listing security events.
+type SecurityEventFilter struct {
+	UserID *uuid.UUID         `json:"user_id,omitempty"`
+	Type   *SecurityEventType `json:"type,omitempty"`
+	Since  *time.Time         `json:"since,omitempty"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
@@ -0,0 +1,273 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SimulationStatus defines the lifecycle states of a traffic simulation.
+type SimulationStatus string
+
+const (
+	// SimulationRunning is creating its users or generating traffic
+	SimulationRunning SimulationStatus = "running"
+	// SimulationCompleted generated traffic for its full duration
+	SimulationCompleted SimulationStatus = "completed"
+	// SimulationCancelled was stopped by an admin before its duration elapsed
+	SimulationCancelled SimulationStatus = "cancelled"
+	// SimulationFailed could not set up its users or money
+	SimulationFailed SimulationStatus = "failed"
+)
+
+// SimulationOperation defines the kinds of synthetic operations a simulation generates.
+type SimulationOperation string
+
+const (
+	// SimulationCredit adds money to a synthetic user
+	SimulationCredit SimulationOperation = "credit"
+	// SimulationDebit removes money from a synthetic user
+	SimulationDebit SimulationOperation = "debit"
+	// SimulationTransfer moves money between two synthetic users
+	SimulationTransfer SimulationOperation = "transfer"
+)
+
+// Simulation limits keep a single run from overwhelming the server or the treasury.
+const (
+	MaxSimulationUsers       = 1000
+	MaxSimulationDuration    = 600 // seconds
+	MaxSimulationTPS         = 1000
+	MaxSimulationConcurrency = 200
+	MaxSimulationFunding     = 1000000 // users * initial_balance, minted in one operation
+	MaxSimulationTraceLength = 20000   // Operations a run's trace keeps; its digest covers them all
+)
+
+// SimulationRequest configures a synthetic traffic run. Operations arrive as a Poisson process at
+// TargetTPS, and each one is a credit, debit or transfer picked in proportion to the weights.
+type SimulationRequest struct {
+	Users           int     `json:"users"`
+	DurationSeconds int     `json:"duration_seconds"`
+	TargetTPS       float64 `json:"target_tps"`
+	CreditWeight    float64 `json:"credit_weight"`
+	DebitWeight     float64 `json:"debit_weight"`
+	TransferWeight  float64 `json:"transfer_weight"`
+	FailureRate     float64 `json:"failure_rate"`    // Share of operations deliberately made invalid, from 0 to 1
+	Currency        string  `json:"currency"`        // Defaults to USD
+	InitialBalance  float64 `json:"initial_balance"` // Money each user is funded with; defaults to 1000
+	MaxAmount       float64 `json:"max_amount"`      // Largest generated amount; defaults to 100
+	Concurrency     int     `json:"concurrency"`     // Operations in flight at once; defaults to 20
+	Seed            *uint64 `json:"seed,omitempty"`  // Seeds all of the run's randomness; drawn when omitted and reported either way
+}
+
+// ApplyDefaults fills in the optional fields of the simulation request.
+func (r *SimulationRequest) ApplyDefaults() {
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if r.Currency == "" {
+		r.Currency = "USD"
+	}
+
+	if r.CreditWeight == 0 && r.DebitWeight == 0 && r.TransferWeight == 0 {
+		r.CreditWeight, r.DebitWeight, r.TransferWeight = 1, 1, 2
+	}
+
+	if r.InitialBalance == 0 {
+		r.InitialBalance = 1000
+	}
+
+	if r.MaxAmount == 0 {
+		r.MaxAmount = 100
+	}
+
+	if r.Concurrency == 0 {
+		r.Concurrency = 20
+	}
+}
+
+// Validate validates the simulation request. Call ApplyDefaults first.
+func (r *SimulationRequest) Validate() error {
+	if r.Users < 2 || r.Users > MaxSimulationUsers {
+		return fmt.Errorf("users: users must be between 2 and %d", MaxSimulationUsers)
+	}
+
+	if r.DurationSeconds < 1 || r.DurationSeconds > MaxSimulationDuration {
+		return fmt.Errorf("duration_seconds: duration_seconds must be between 1 and %d", MaxSimulationDuration)
+	}
+
+	if r.TargetTPS <= 0 || r.TargetTPS > MaxSimulationTPS {
+		return fmt.Errorf("target_tps: target_tps must be greater than 0 and at most %d", MaxSimulationTPS)
+	}
+
+	if r.CreditWeight < 0 || r.DebitWeight < 0 || r.TransferWeight < 0 {
+		return fmt.Errorf("weights: weights cannot be negative")
+	}
+
+	if r.FailureRate < 0 || r.FailureRate > 1 {
+		return fmt.Errorf("failure_rate: failure_rate must be between 0 and 1")
+	}
+
+	if !IsValidCurrency(r.Currency) {
+		return fmt.Errorf("currency: unsupported currency: %s", r.Currency)
+	}
+
+	if err := validateTransactionAmount(r.InitialBalance); err != nil {
+		return fmt.Errorf("initial_balance: %w", err)
+	}
+	if err := ValidateCurrencyAmount(r.Currency, r.InitialBalance); err != nil {
+		return fmt.Errorf("initial_balance: %w", err)
+	}
+
+	if float64(r.Users)*r.InitialBalance > MaxSimulationFunding {
+		return fmt.Errorf("initial_balance: users * initial_balance cannot exceed %d", MaxSimulationFunding)
+	}
+
+	if err := validateTransactionAmount(r.MaxAmount); err != nil {
+		return fmt.Errorf("max_amount: %w", err)
+	}
+
+	if r.MaxAmount < 0.01 {
+		return fmt.Errorf("max_amount: max_amount must be at least 0.01")
+	}
+
+	if r.Concurrency < 1 || r.Concurrency > MaxSimulationConcurrency {
+		return fmt.Errorf("concurrency: concurrency must be between 1 and %d", MaxSimulationConcurrency)
+	}
+
+	return nil
+}
+
+// SimulationRun represents one traffic simulation and its results so far.
+type SimulationRun struct {
+	ID         uuid.UUID         `json:"id"`
+	Status     SimulationStatus  `json:"status"`
+	Request    SimulationRequest `json:"request"`
+	StartedBy  uuid.UUID         `json:"started_by"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+	Error      string            `json:"error,omitempty"` // Why setup failed
+	Stats      *SimulationStats  `json:"stats"`
+	// TraceDigest is the SHA-256 of the run's trace once it finished traffic. Replaying the run's
+	// request and seed against a fresh store yields the same digest when operations run one at a
+	// time.
+	TraceDigest string `json:"trace_digest,omitempty"`
+}
+
+// SimulationTraceEntry is one operation of a run as the run's seed planned it and as it ended.
+// Users are numbered in the order the run created them.
+type SimulationTraceEntry struct {
+	Seq      int64               `json:"seq"`
+	AtMs     int64               `json:"at_ms"` // Planned offset from the start of the traffic
+	Op       SimulationOperation `json:"op"`
+	From     int                 `json:"from"`
+	To       *int                `json:"to,omitempty"`
+	Amount   float64             `json:"amount"`
+	Injected bool                `json:"injected"`
+	Result   string              `json:"result"` // "ok", or the error message before its first colon
+}
+
+// SimulationTrace is the ordered trace of a run's operations. Entries are hashed into the
+// digest in sequence order, whatever order the operations complete in; the first
+// MaxSimulationTraceLength are kept.
+type SimulationTrace struct {
+	entries []SimulationTraceEntry
+	pending map[int64]SimulationTraceEntry // Completed ahead of an earlier operation
+	next    int64                          // Seq of the next entry to hash
+	hash    hash.Hash
+}
+
+// NewSimulationTrace creates an empty trace.
+func NewSimulationTrace() *SimulationTrace {
+	return &SimulationTrace{pending: make(map[int64]SimulationTraceEntry), hash: sha256.New()}
+}
+
+// Add adds the entry of a completed operation. Seqs start at 0 and each is added once.
+func (t *SimulationTrace) Add(entry SimulationTraceEntry) {
+	t.pending[entry.Seq] = entry
+	for {
+		next, ok := t.pending[t.next]
+		if !ok {
+			return
+		}
+		delete(t.pending, t.next)
+		t.next++
+
+		line, _ := json.Marshal(next)
+		t.hash.Write(append(line, '\n'))
+		if len(t.entries) < MaxSimulationTraceLength {
+			t.entries = append(t.entries, next)
+		}
+	}
+}
+
+// Entries returns the kept entries in sequence order.
+func (t *SimulationTrace) Entries() []SimulationTraceEntry {
+	return append([]SimulationTraceEntry(nil), t.entries...)
+}
+
+// Digest returns the hex SHA-256 of the trace's entries so far as JSON lines.
+func (t *SimulationTrace) Digest() string {
+	return hex.EncodeToString(t.hash.Sum(nil))
+}
+
+// SimulationStats reports the throughput and error rates of a simulation.
+type SimulationStats struct {
+	ElapsedSeconds    float64                                           `json:"elapsed_seconds"`
+	Attempted         int64                                             `json:"attempted"`
+	Succeeded         int64                                             `json:"succeeded"`
+	Failed            int64                                             `json:"failed"`
+	InjectedFailures  int64                                             `json:"injected_failures"`
+	InjectedSucceeded int64                                             `json:"injected_succeeded"` // Injected failures the server accepted; should stay 0
+	ThroughputTPS     float64                                           `json:"throughput_tps"`     // Completed operations per second
+	ErrorRate         float64                                           `json:"error_rate"`         // Failed share of attempted operations, injected failures included
+	Latency           SimulationLatency                                 `json:"latency_ms"`
+	ByOperation       map[SimulationOperation]*SimulationOperationStats `json:"by_operation"`
+	Errors            map[string]int64                                  `json:"errors"` // Failures by error message prefix
+}
+
+// SimulationOperationStats counts the outcomes of one kind of simulated operation.
+type SimulationOperationStats struct {
+	Attempted int64 `json:"attempted"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+}
+
+// SimulationLatency summarizes operation latencies in milliseconds.
+type SimulationLatency struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// SummarizeLatencies computes latency percentiles with the nearest-rank method. The input is not modified.
+func SummarizeLatencies(latenciesMs []float64) SimulationLatency {
+	if len(latenciesMs) == 0 {
+		return SimulationLatency{}
+	}
+
+	sorted := make([]float64, len(latenciesMs))
+	copy(sorted, latenciesMs)
+	sort.Float64s(sorted)
+
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	return SimulationLatency{
+		P50: percentile(50),
+		P95: percentile(95),
+		P99: percentile(99),
+		Max: sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// StatsWindows maps the selectable dashboard time windows to their durations.
+var StatsWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// DefaultStatsWindow is the window used when none is selected.
+const DefaultStatsWindow = "24h"
+
+// ParseStatsWindow returns the duration for a dashboard time window.
+func ParseStatsWindow(window string) (time.Duration, error) {
+	if window == "" {
+		window = DefaultStatsWindow
+	}
+
+	duration, ok := StatsWindows[window]
+	if !ok {
+		return 0, fmt.Errorf("invalid window: must be one of 1h, 24h, 7d, 30d")
+	}
+
+	return duration, nil
+}
+
+// TransactionStats represents aggregate transaction statistics over a time window.
+type TransactionStats struct {
+	Window       string           `json:"window"`
+	Since        time.Time        `json:"since"`
+	Until        time.Time        `json:"until"`
+	TotalCount   int64            `json:"total_count"`
+	SuccessCount int64            `json:"success_count"`
+	FailedCount  int64            `json:"failed_count"`
+	PendingCount int64            `json:"pending_count"`
+	SuccessRate  float64          `json:"success_rate"`
+	FailureRate  float64          `json:"failure_rate"`
+	P95Amount    float64          `json:"p95_amount"`
+	ByCurrency   []CurrencyVolume `json:"by_currency"`
+}
+
+// CurrencyVolume represents successful transaction volume in a single currency.
+type CurrencyVolume struct {
+	Currency  string  `json:"currency"`
+	Count     int64   `json:"count"`
+	Volume    float64 `json:"volume"`
+	P95Amount float64 `json:"p95_amount"`
+}
+
+// ComputeRates fills in the success and failure rates from the counts.
+func (s *TransactionStats) ComputeRates() {
+	if s.TotalCount == 0 {
+		s.SuccessRate = 0
+		s.FailureRate = 0
+		return
+	}
+
+	s.SuccessRate = float64(s.SuccessCount) / float64(s.TotalCount)
+	s.FailureRate = float64(s.FailedCount) / float64(s.TotalCount)
+}
@@ -4,20 +4,62 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
 // Transaction represents a financial transaction.
 type Transaction struct {
-	ID         uuid.UUID  `json:"id" db:"id"`
-	FromUserID *uuid.UUID `json:"from_user_id,omitempty" db:"from_user_id"`
-	ToUserID   *uuid.UUID `json:"to_user_id,omitempty" db:"to_user_id"`
-	Amount     float64    `json:"amount" db:"amount"`
-	Currency   string     `json:"currency" db:"currency"`
-	Type       string     `json:"type" db:"type"`
-	Status     string     `json:"status" db:"status"`
-	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ID                      uuid.UUID  `json:"id" db:"id"`
+	FromUserID              *uuid.UUID `json:"from_user_id,omitempty" db:"from_user_id"`
+	ToUserID                *uuid.UUID `json:"to_user_id,omitempty" db:"to_user_id"`
+	Amount                  float64    `json:"amount" db:"amount"`
+	Currency                string     `json:"currency" db:"currency"`
+	Type                    string     `json:"type" db:"type"`
+	Status                  string     `json:"status" db:"status"`
+	Description             string     `json:"description,omitempty" db:"description"`
+	Category                string     `json:"category,omitempty" db:"category"`                 // Set on spending to track it against budgets
+	ClientReference         string     `json:"client_reference,omitempty" db:"client_reference"` // The caller's own ID for the transaction
+	CreatedAt               time.Time  `json:"created_at" db:"created_at"`
+	ReversalOfTransactionID *uuid.UUID `json:"reversal_of_transaction_id,omitempty" db:"reversal_of_transaction_id"`
+	ReversedByTransactionID *uuid.UUID `json:"reversed_by_transaction_id,omitempty" db:"reversed_by_transaction_id"`
+	ReversedAmount          float64    `json:"reversed_amount" db:"reversed_amount"`
+	ScheduledTransactionID  *uuid.UUID `json:"scheduled_transaction_id,omitempty" db:"scheduled_transaction_id"` // Set when a scheduled execution created the transaction
+	ScheduledExecutionID    *uuid.UUID `json:"scheduled_execution_id,omitempty" db:"scheduled_execution_id"`
+}
+
+// TransactionOrigin links a transaction to the scheduled execution that created it.
+type TransactionOrigin struct {
+	ScheduledTransactionID uuid.UUID `json:"scheduled_transaction_id"`
+	ExecutionID            uuid.UUID `json:"execution_id"`
+}
+
+// Origin returns the scheduled execution that created the transaction, or nil when a user or
+// admin made it directly.
+func (t *Transaction) Origin() *TransactionOrigin {
+	if t.ScheduledTransactionID == nil || t.ScheduledExecutionID == nil {
+		return nil
+	}
+	return &TransactionOrigin{ScheduledTransactionID: *t.ScheduledTransactionID, ExecutionID: *t.ScheduledExecutionID}
+}
+
+// SetOrigin records the scheduled execution that created the transaction; a nil origin leaves
+// it unset.
+func (t *Transaction) SetOrigin(origin *TransactionOrigin) {
+	if origin == nil {
+		return
+	}
+	scheduledTransactionID, executionID := origin.ScheduledTransactionID, origin.ExecutionID
+	t.ScheduledTransactionID, t.ScheduledExecutionID = &scheduledTransactionID, &executionID
+}
+
+// DefaultRollbackWindow is how long after creation a user may roll back their own transaction.
+const DefaultRollbackWindow = 24 * time.Hour
+
+// WithinRollbackWindow reports whether the transaction is still young enough to be rolled back.
+func (t *Transaction) WithinRollbackWindow(window time.Duration, now time.Time) bool {
+	return now.Sub(t.CreatedAt) <= window
 }
 
 // TransactionType defines valid transaction types.
@@ -53,46 +95,147 @@ type CreateTransactionRequest struct {
 
 // TransferRequest represents the data needed for a transfer transaction.
 type TransferRequest struct {
-	ToUserID uuid.UUID `json:"to_user_id"`
-	Amount   float64   `json:"amount"`
-	Currency string    `json:"currency"`
+	ToUserID        uuid.UUID `json:"to_user_id"`
+	ToAccountNumber string    `json:"to_account_number,omitempty"` // Alternative to to_user_id; resolved to it before the transfer runs
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	Description     string    `json:"description,omitempty"`
+	Category        string    `json:"category,omitempty"`
+	ClientReference string    `json:"client_reference,omitempty"`
+
+	Origin *TransactionOrigin `json:"-"` // Set by the scheduler, never by callers
 }
 
 // CreditRequest represents the data needed for a credit transaction.
 type CreditRequest struct {
-	Amount   float64 `json:"amount"`
-	Currency string  `json:"currency"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+	Description     string  `json:"description,omitempty"`
+	ClientReference string  `json:"client_reference,omitempty"`
+
+	Origin *TransactionOrigin `json:"-"` // Set by the scheduler, never by callers
 }
 
 // DebitRequest represents the data needed for a debit transaction.
 type DebitRequest struct {
-	Amount   float64 `json:"amount"`
-	Currency string  `json:"currency"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+	Description     string  `json:"description,omitempty"`
+	Category        string  `json:"category,omitempty"`
+	ClientReference string  `json:"client_reference,omitempty"`
+
+	Origin *TransactionOrigin `json:"-"` // Set by the scheduler, never by callers
+}
+
+// RollbackRequest represents an optional partial amount for a rollback.
+// A nil amount rolls back everything not yet reversed.
+type RollbackRequest struct {
+	Amount *float64 `json:"amount,omitempty"`
+}
+
+// Validate validates the rollback request.
+func (r *RollbackRequest) Validate() error {
+	if r.Amount == nil {
+		return nil
+	}
+
+	if err := validateTransactionAmount(*r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	return nil
+}
+
+// BalanceAdjustmentRequest represents an admin correction of a user's balance, made as an
+// ordinary credit or debit described by its reason.
+type BalanceAdjustmentRequest struct {
+	Type     TransactionType `json:"type"` // TypeCredit or TypeDebit
+	Amount   float64         `json:"amount"`
+	Currency string          `json:"currency"`
+	Reason   string          `json:"reason"`
+}
+
+// Validate validates the balance adjustment request.
+func (r *BalanceAdjustmentRequest) Validate() error {
+	if r.Type != TypeCredit && r.Type != TypeDebit {
+		return fmt.Errorf("type: type must be credit or debit")
+	}
+
+	if err := validateTransactionAmount(r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if !IsValidCurrency(r.Currency) {
+		return fmt.Errorf("currency: unsupported currency: %s", r.Currency)
+	}
+
+	if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if strings.TrimSpace(r.Reason) == "" {
+		return fmt.Errorf("reason: reason is required")
+	}
+
+	if err := validateTransactionDescription(r.Reason); err != nil {
+		return fmt.Errorf("reason: %w", err)
+	}
+
+	return nil
 }
 
 // TransactionResponse represents a transaction in API responses.
 type TransactionResponse struct {
-	ID         uuid.UUID  `json:"id"`
-	FromUserID *uuid.UUID `json:"from_user_id,omitempty"`
-	ToUserID   *uuid.UUID `json:"to_user_id,omitempty"`
-	Amount     float64    `json:"amount"`
-	Currency   string     `json:"currency"`
-	Type       string     `json:"type"`
-	Status     string     `json:"status"`
-	CreatedAt  time.Time  `json:"created_at"`
+	ID              uuid.UUID  `json:"id"`
+	FromUserID      *uuid.UUID `json:"from_user_id,omitempty"`
+	ToUserID        *uuid.UUID `json:"to_user_id,omitempty"`
+	Amount          float64    `json:"amount"`
+	Currency        string     `json:"currency"`
+	Type            string     `json:"type"`
+	Status          string     `json:"status"`
+	Description     string     `json:"description,omitempty"`
+	Category        string     `json:"category,omitempty"`
+	ClientReference string     `json:"client_reference,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	ReversalOfTransactionID *uuid.UUID `json:"reversal_of_transaction_id,omitempty"`
+	ReversedByTransactionID *uuid.UUID `json:"reversed_by_transaction_id,omitempty"`
+	ReversedAmount          float64    `json:"reversed_amount"`
+	IsReversed              bool       `json:"is_reversed"`
+
+	Origin *TransactionOrigin `json:"origin,omitempty"` // The scheduled execution that created the transaction
+}
+
+// IsReversed reports whether the transaction has been fully rolled back.
+func (t *Transaction) IsReversed() bool {
+	return t.ReversedByTransactionID != nil
+}
+
+// RemainingReversibleAmount returns how much of the transaction can still be rolled back, rounded
+// to its currency's decimal places.
+func (t *Transaction) RemainingReversibleAmount() float64 {
+	return RoundAmount(t.Amount-t.ReversedAmount, t.Currency)
 }
 
 // ToResponse converts a Transaction to TransactionResponse.
 func (t *Transaction) ToResponse() TransactionResponse {
 	return TransactionResponse{
-		ID:         t.ID,
-		FromUserID: t.FromUserID,
-		ToUserID:   t.ToUserID,
-		Amount:     t.Amount,
-		Currency:   t.Currency,
-		Type:       t.Type,
-		Status:     t.Status,
-		CreatedAt:  t.CreatedAt,
+		ID:                      t.ID,
+		FromUserID:              t.FromUserID,
+		ToUserID:                t.ToUserID,
+		Amount:                  t.Amount,
+		Currency:                t.Currency,
+		Type:                    t.Type,
+		Status:                  t.Status,
+		Description:             t.Description,
+		Category:                t.Category,
+		ClientReference:         t.ClientReference,
+		CreatedAt:               t.CreatedAt,
+		ReversalOfTransactionID: t.ReversalOfTransactionID,
+		ReversedByTransactionID: t.ReversedByTransactionID,
+		ReversedAmount:          t.ReversedAmount,
+		IsReversed:              t.IsReversed(),
+		Origin:                  t.Origin(),
 	}
 }
 
@@ -104,6 +247,46 @@ type TransactionFilter struct {
 	Since  *time.Time         `json:"since,omitempty"`
 	Limit  int                `json:"limit,omitempty"`
 	Offset int                `json:"offset,omitempty"`
+
+	// Description matches transactions whose description contains it, ignoring case
+	Description *string `json:"description,omitempty"`
+	// ClientReference matches transactions carrying exactly this client reference
+	ClientReference *string `json:"client_reference,omitempty"`
+}
+
+// MaxTransactionDescriptionLength is the longest description a transaction may carry. It fits a
+// payment request's note, which becomes the description of the transfer paying it.
+const MaxTransactionDescriptionLength = 500
+
+// validateTransactionDescription validates an optional transaction description.
+func validateTransactionDescription(description string) error {
+	if len(strings.TrimSpace(description)) > MaxTransactionDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", MaxTransactionDescriptionLength)
+	}
+
+	return nil
+}
+
+// MaxClientReferenceLength is the longest client reference a transaction may carry.
+const MaxClientReferenceLength = 100
+
+// ValidateClientReference validates an optional client reference: the ID a caller gives a
+// transaction to correlate it with their own records. It is matched exactly, so it may not carry
+// surrounding whitespace or control characters.
+func ValidateClientReference(reference string) error {
+	if len(reference) > MaxClientReferenceLength {
+		return fmt.Errorf("client_reference must be at most %d characters", MaxClientReferenceLength)
+	}
+	if strings.TrimSpace(reference) != reference {
+		return fmt.Errorf("client_reference must not start or end with whitespace")
+	}
+	for _, r := range reference {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("client_reference must only contain printable characters")
+		}
+	}
+
+	return nil
 }
 
 // validateTransactionAmount validates transaction amount.
@@ -112,7 +295,7 @@ func validateTransactionAmount(amount float64) error {
 		return fmt.Errorf("amount must be greater than 0")
 	}
 
-	if amount > 1000000 { // reasonable upper limit
+	if amount > MaxTransactionAmount {
 		return fmt.Errorf("amount cannot exceed 1,000,000")
 	}
 
@@ -130,7 +313,7 @@ func (t *Transaction) Validate() error {
 	if currency == "" {
 		currency = "USD"
 	}
-	if !IsValidCurrency(currency) {
+	if !IsKnownCurrency(currency) {
 		return fmt.Errorf("unsupported currency: %s", currency)
 	}
 
@@ -160,8 +343,28 @@ func (r *TransferRequest) Validate() error {
 		return fmt.Errorf("unsupported currency: %s", r.Currency)
 	}
 
+	if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if err := CheckAmountLimit(r.Currency, TypeTransfer, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
 	if r.ToUserID == uuid.Nil {
-		return fmt.Errorf("to_user_id is required")
+		return fmt.Errorf("to_user_id or to_account_number is required")
+	}
+
+	if err := validateTransactionDescription(r.Description); err != nil {
+		return err
+	}
+
+	if err := validateTransactionCategory(r.Category); err != nil {
+		return err
+	}
+
+	if err := ValidateClientReference(r.ClientReference); err != nil {
+		return err
 	}
 
 	return nil
@@ -177,6 +380,22 @@ func (r *CreditRequest) Validate() error {
 		return fmt.Errorf("unsupported currency: %s", r.Currency)
 	}
 
+	if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if err := CheckAmountLimit(r.Currency, TypeCredit, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if err := validateTransactionDescription(r.Description); err != nil {
+		return err
+	}
+
+	if err := ValidateClientReference(r.ClientReference); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -190,14 +409,33 @@ func (r *DebitRequest) Validate() error {
 		return fmt.Errorf("unsupported currency: %s", r.Currency)
 	}
 
+	if err := ValidateCurrencyAmount(r.Currency, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if err := CheckAmountLimit(r.Currency, TypeDebit, r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if err := validateTransactionDescription(r.Description); err != nil {
+		return err
+	}
+
+	if err := validateTransactionCategory(r.Category); err != nil {
+		return err
+	}
+
+	if err := ValidateClientReference(r.ClientReference); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // validateTransactionType validates transaction type.
 func validateTransactionType(txType string) error {
-	txType = strings.ToLower(txType)
-	if txType != string(TypeCredit) && txType != string(TypeDebit) && txType != string(TypeTransfer) {
-		return fmt.Errorf("invalid type, must be 'credit', 'debit', or 'transfer'")
+	if !IsTransactionType(strings.ToLower(txType)) {
+		return fmt.Errorf("invalid type, must be %s", transactionTypeList())
 	}
 
 	return nil
@@ -215,34 +453,10 @@ func validateTransactionStatus(status string) error {
 
 // validateBusinessRules validates business logic rules for transactions.
 func (t *Transaction) validateBusinessRules() error {
-	switch t.Type {
-	case string(TypeCredit):
-		if t.ToUserID == nil {
-			return fmt.Errorf("credit transaction must have to_user_id")
-		}
-		if t.FromUserID != nil {
-			return fmt.Errorf("credit transaction must not have from_user_id")
-		}
-
-	case string(TypeDebit):
-		if t.FromUserID == nil {
-			return fmt.Errorf("debit transaction must have from_user_id")
-		}
-		if t.ToUserID != nil {
-			return fmt.Errorf("debit transaction must not have to_user_id")
-		}
-
-	case string(TypeTransfer):
-		if t.FromUserID == nil {
-			return fmt.Errorf("transfer transaction must have from_user_id")
-		}
-		if t.ToUserID == nil {
-			return fmt.Errorf("transfer transaction must have to_user_id")
-		}
-		if t.FromUserID != nil && t.ToUserID != nil && *t.FromUserID == *t.ToUserID {
-			return fmt.Errorf("transfer cannot be to the same user")
-		}
+	spec, ok := LookupTransactionType(t.Type)
+	if !ok {
+		return nil
 	}
 
-	return nil
+	return spec.CheckParties(t.FromUserID, t.ToUserID)
 }
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionDirection is which side of a transaction a user is on.
+type TransactionDirection string
+
+const (
+	// DirectionIn is receiving a transaction's money
+	DirectionIn TransactionDirection = "in"
+	// DirectionOut is sending a transaction's money
+	DirectionOut TransactionDirection = "out"
+)
+
+// Monthly activity is reported for the last DefaultActivityMonths months unless asked otherwise,
+// and for at most MaxActivityMonths.
+const (
+	DefaultActivityMonths = 12
+	MaxActivityMonths     = 60
+)
+
+// TransactionAggregate totals a user's successful transactions of one type, category and currency
+// on one side during a UTC month. Aggregates are maintained as transactions complete and are
+// rolled back, and keep counting transactions once they are archived.
+type TransactionAggregate struct {
+	UserID         uuid.UUID            `json:"user_id"`
+	Month          string               `json:"month"` // yyyy-mm
+	Direction      TransactionDirection `json:"direction"`
+	Type           string               `json:"type"`
+	Category       string               `json:"category"`
+	Currency       string               `json:"currency"`
+	Count          int                  `json:"count"`
+	Amount         float64              `json:"amount"`
+	ReversedAmount float64              `json:"reversed_amount"` // Rolled back of Amount so far
+}
+
+// SpendingByCategory sums the spending of aggregates per category, less what was rolled back of
+// it: what their user sent in transactions of the types counted as spending. Uncategorized
+// spending is keyed by "".
+func SpendingByCategory(aggregates []*TransactionAggregate) map[string]float64 {
+	spending := make(map[string]float64)
+	for _, aggregate := range aggregates {
+		spec, ok := LookupTransactionType(aggregate.Type)
+		if aggregate.Direction != DirectionOut || !ok || !spec.Spending {
+			continue
+		}
+		spending[aggregate.Category] = math.Round((spending[aggregate.Category]+aggregate.Amount-aggregate.ReversedAmount)*100) / 100
+	}
+	return spending
+}
+
+// MonthlyActivity is the money a user received and sent during a UTC month. Rollbacks count in the
+// month they happened, like monthly summaries.
+type MonthlyActivity struct {
+	Month    string  `json:"month"` // yyyy-mm
+	In       float64 `json:"in"`
+	Out      float64 `json:"out"`
+	Net      float64 `json:"net"` // In less Out
+	CountIn  int     `json:"count_in"`
+	CountOut int     `json:"count_out"`
+}
+
+// MonthlyActivityReport is a user's activity of consecutive months, oldest first, the current
+// month included. Months without transactions are reported with zeros.
+type MonthlyActivityReport struct {
+	UserID   uuid.UUID         `json:"user_id"`
+	Currency string            `json:"currency"`
+	Months   []MonthlyActivity `json:"months"`
+}
+
+// ValidateActivityMonths checks the number of months activity is requested for.
+func ValidateActivityMonths(months int) error {
+	if months < 1 || months > MaxActivityMonths {
+		return fmt.Errorf("months must be between 1 and %d, got %d", MaxActivityMonths, months)
+	}
+	return nil
+}
+
+// ActivityRange returns the start of the first of the last months UTC months up to now, and the
+// end of the current one.
+func ActivityRange(now time.Time, months int) (from, to time.Time) {
+	now = now.UTC()
+	to = time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return to.AddDate(0, -months, 0), to
+}
+
+// BuildMonthlyActivityReport reports the activity of the months in [from, to) from the user's
+// aggregates in currency.
+func BuildMonthlyActivityReport(userID uuid.UUID, currency string, aggregates []*TransactionAggregate, from, to time.Time) *MonthlyActivityReport {
+	report := &MonthlyActivityReport{UserID: userID, Currency: currency, Months: []MonthlyActivity{}}
+	index := make(map[string]int)
+	for month := from; month.Before(to); month = month.AddDate(0, 1, 0) {
+		index[SummaryMonth(month)] = len(report.Months)
+		report.Months = append(report.Months, MonthlyActivity{Month: SummaryMonth(month)})
+	}
+
+	for _, aggregate := range aggregates {
+		i, ok := index[aggregate.Month]
+		if !ok || aggregate.Currency != currency {
+			continue
+		}
+		activity := &report.Months[i]
+		switch aggregate.Direction {
+		case DirectionIn:
+			activity.In += aggregate.Amount
+			activity.CountIn += aggregate.Count
+		case DirectionOut:
+			activity.Out += aggregate.Amount
+			activity.CountOut += aggregate.Count
+		}
+	}
+
+	for i := range report.Months {
+		activity := &report.Months[i]
+		activity.In = RoundAmount(activity.In, currency)
+		activity.Out = RoundAmount(activity.Out, currency)
+		activity.Net = RoundAmount(activity.In-activity.Out, currency)
+	}
+	return report
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportFormat defines the file formats transaction history can be exported in.
+type ExportFormat string
+
+const (
+	// ExportFormatCSV exports one transaction per CSV row
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSON exports a JSON array of transactions
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// ParseExportFormat parses an export format, defaulting to CSV when empty.
+func ParseExportFormat(format string) (ExportFormat, error) {
+	switch ExportFormat(format) {
+	case "", ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatJSON:
+		return ExportFormatJSON, nil
+	default:
+		return "", fmt.Errorf("format must be 'csv' or 'json'")
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatJSON {
+		return "application/json"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// FileName returns the download file name for an export created at the given time.
+func (f ExportFormat) FileName(createdAt time.Time) string {
+	return fmt.Sprintf("transactions-%s.%s", createdAt.UTC().Format("20060102-150405"), f)
+}
+
+// TransactionCursor marks a position in newest-first transaction history.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// ExportStatus defines the status of a background export.
+type ExportStatus string
+
+const (
+	// ExportStatusPending means the export is still being generated
+	ExportStatusPending ExportStatus = "pending"
+	// ExportStatusReady means the export can be downloaded
+	ExportStatusReady ExportStatus = "ready"
+	// ExportStatusFailed means the export could not be generated
+	ExportStatusFailed ExportStatus = "failed"
+)
+
+// TransactionExport represents a transaction history export generated in the background.
+type TransactionExport struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Format      ExportFormat `json:"format"`
+	Locale      Locale       `json:"locale,omitempty"` // Separators of CSV amounts; plain without one
+	Status      ExportStatus `json:"status"`
+	Rows        int          `json:"rows"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxTransactionWait is the longest a transaction lookup may wait for the transaction to leave pending.
+const MaxTransactionWait = 30 * time.Second
+
+// TransactionStatusUpdate announces a transaction's status, e.g. its transition from pending to success.
+type TransactionStatusUpdate struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewTransactionStatusUpdate creates a status update from a transaction's current state.
+func NewTransactionStatusUpdate(tx *Transaction) TransactionStatusUpdate {
+	return TransactionStatusUpdate{
+		TransactionID: tx.ID,
+		Type:          tx.Type,
+		Status:        tx.Status,
+		Amount:        tx.Amount,
+		Currency:      tx.Currency,
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// IsTerminal reports whether the status can no longer change.
+func (u TransactionStatusUpdate) IsTerminal() bool {
+	return u.Status == string(StatusSuccess) || u.Status == string(StatusFailed)
+}
+
+// ParseTransactionWait parses how long a transaction lookup waits for the transaction to leave
+// pending: a duration such as "30s", or a number of seconds.
+func ParseTransactionWait(value string) (time.Duration, error) {
+	wait, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, fmt.Errorf("wait must be a duration such as 30s, got %q", value)
+		}
+		wait = time.Duration(seconds) * time.Second
+	}
+	if wait < 0 || wait > MaxTransactionWait {
+		return 0, fmt.Errorf("wait must be between 0s and %s, got %s", MaxTransactionWait, wait)
+	}
+	return wait, nil
+}
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Settlement is how a transaction type moves money between balances and the treasury. It decides
+// which parties a transaction of the type has and how its balances change.
+type Settlement string
+
+const (
+	// SettlementIssue moves new money from the treasury into the receiver's balance; there is no sender
+	SettlementIssue Settlement = "issue"
+	// SettlementRedeem moves money out of the sender's balance back into the treasury; there is no receiver
+	SettlementRedeem Settlement = "redeem"
+	// SettlementMove moves money from the sender's balance into the receiver's
+	SettlementMove Settlement = "move"
+)
+
+// TransactionTypeSpec declares how transactions of a type behave, so that validation, rollbacks,
+// balance computations and spending reports need not know the type itself.
+type TransactionTypeSpec struct {
+	Type       TransactionType
+	Settlement Settlement
+	// Reversal is the type of the transaction rolling one of this type back, with sender and
+	// receiver swapped. Its settlement must mirror this one's.
+	Reversal TransactionType
+	// Spending counts successful transactions of the type against their sender's budgets
+	Spending bool
+	// ReceiverEvent and SenderEvent are the balance events a rollback of this type publishes for
+	// its receiver and sender; empty publishes none, e.g. for transfers, whose reversal event says it all
+	ReceiverEvent EventType
+	SenderEvent   EventType
+}
+
+// HasSender reports whether transactions of the type take money out of a sender's balance.
+func (s TransactionTypeSpec) HasSender() bool {
+	return s.Settlement != SettlementIssue
+}
+
+// HasReceiver reports whether transactions of the type put money into a receiver's balance.
+func (s TransactionTypeSpec) HasReceiver() bool {
+	return s.Settlement != SettlementRedeem
+}
+
+// CheckParties checks that a transaction of the type has exactly the parties its settlement needs.
+func (s TransactionTypeSpec) CheckParties(fromUserID, toUserID *uuid.UUID) error {
+	switch {
+	case s.HasReceiver() && toUserID == nil:
+		return fmt.Errorf("%s transaction must have to_user_id", s.Type)
+	case !s.HasReceiver() && toUserID != nil:
+		return fmt.Errorf("%s transaction must not have to_user_id", s.Type)
+	case s.HasSender() && fromUserID == nil:
+		return fmt.Errorf("%s transaction must have from_user_id", s.Type)
+	case !s.HasSender() && fromUserID != nil:
+		return fmt.Errorf("%s transaction must not have from_user_id", s.Type)
+	case fromUserID != nil && toUserID != nil && *fromUserID == *toUserID:
+		return fmt.Errorf("%s cannot be to the same user", s.Type)
+	}
+
+	return nil
+}
+
+// transactionTypeName matches type names that fit the transactions.type column.
+var transactionTypeName = regexp.MustCompile(`^[a-z][a-z_]{0,19}$`)
+
+// transactionTypes is the registry of transaction types, in registration order.
+var transactionTypes = struct {
+	sync.RWMutex
+	specs []TransactionTypeSpec
+}{}
+
+func init() {
+	for _, spec := range []TransactionTypeSpec{
+		{Type: TypeCredit, Settlement: SettlementIssue, Reversal: TypeDebit, ReceiverEvent: EventAmountCredited},
+		{Type: TypeDebit, Settlement: SettlementRedeem, Reversal: TypeCredit, Spending: true, SenderEvent: EventAmountDebited},
+		{Type: TypeTransfer, Settlement: SettlementMove, Reversal: TypeTransfer, Spending: true},
+	} {
+		if err := RegisterTransactionType(spec); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterTransactionType adds a transaction type to the registry. Types are registered once,
+// before transactions of them are created; a reversal type may be registered after the types
+// reversing into it.
+func RegisterTransactionType(spec TransactionTypeSpec) error {
+	if !transactionTypeName.MatchString(string(spec.Type)) {
+		return fmt.Errorf("transaction type must be 1-20 lowercase letters or underscores, got %q", spec.Type)
+	}
+	if spec.Settlement != SettlementIssue && spec.Settlement != SettlementRedeem && spec.Settlement != SettlementMove {
+		return fmt.Errorf("transaction type %s: settlement must be issue, redeem or move, got %q", spec.Type, spec.Settlement)
+	}
+	if spec.Reversal == "" {
+		return fmt.Errorf("transaction type %s: reversal type is required", spec.Type)
+	}
+
+	transactionTypes.Lock()
+	defer transactionTypes.Unlock()
+
+	if slices.ContainsFunc(transactionTypes.specs, func(s TransactionTypeSpec) bool { return s.Type == spec.Type }) {
+		return fmt.Errorf("transaction type %s is already registered", spec.Type)
+	}
+	transactionTypes.specs = append(transactionTypes.specs, spec)
+	return nil
+}
+
+// LookupTransactionType returns the spec of a registered transaction type.
+func LookupTransactionType(name string) (TransactionTypeSpec, bool) {
+	transactionTypes.RLock()
+	defer transactionTypes.RUnlock()
+
+	for _, spec := range transactionTypes.specs {
+		if string(spec.Type) == name {
+			return spec, true
+		}
+	}
+	return TransactionTypeSpec{}, false
+}
+
+// IsTransactionType reports whether name is a registered transaction type.
+func IsTransactionType(name string) bool {
+	_, ok := LookupTransactionType(name)
+	return ok
+}
+
+// TransactionTypeNames returns the names of the registered transaction types.
+func TransactionTypeNames() []string {
+	return transactionTypeNames(func(TransactionTypeSpec) bool { return true })
+}
+
+// SpendingTransactionTypes returns the names of the transaction types counted as spending.
+func SpendingTransactionTypes() []string {
+	return transactionTypeNames(func(spec TransactionTypeSpec) bool { return spec.Spending })
+}
+
+// transactionTypeNames returns the names of the registered transaction types matching keep.
+func transactionTypeNames(keep func(TransactionTypeSpec) bool) []string {
+	transactionTypes.RLock()
+	defer transactionTypes.RUnlock()
+
+	var names []string
+	for _, spec := range transactionTypes.specs {
+		if keep(spec) {
+			names = append(names, string(spec.Type))
+		}
+	}
+	return names
+}
+
+// IsSpending reports whether the transaction counts as its sender's spending.
+func (t *Transaction) IsSpending() bool {
+	spec, ok := LookupTransactionType(t.Type)
+	return ok && spec.Spending && t.FromUserID != nil
+}
+
+// BalanceDelta returns how the transaction changes a user's balance: its amount into the
+// receiver's balance and out of the sender's, nothing for anyone else.
+func (t *Transaction) BalanceDelta(userID uuid.UUID) float64 {
+	switch {
+	case t.ToUserID != nil && *t.ToUserID == userID:
+		return t.Amount
+	case t.FromUserID != nil && *t.FromUserID == userID:
+		return -t.Amount
+	}
+	return 0
+}
+
+// Reversal builds the pending transaction rolling amount of the transaction back: one of its
+// type's reversal type, with sender and receiver swapped. It returns the reversal's spec too.
+func (t *Transaction) Reversal(amount float64) (*Transaction, TransactionTypeSpec, error) {
+	spec, ok := LookupTransactionType(t.Type)
+	if !ok {
+		return nil, TransactionTypeSpec{}, fmt.Errorf("unknown transaction type: %s", t.Type)
+	}
+	reversalSpec, ok := LookupTransactionType(string(spec.Reversal))
+	if !ok {
+		return nil, TransactionTypeSpec{}, fmt.Errorf("unknown reversal type %s of %s transactions", spec.Reversal, t.Type)
+	}
+
+	reversal := &Transaction{
+		FromUserID:              t.ToUserID,
+		ToUserID:                t.FromUserID,
+		Amount:                  amount,
+		Currency:                t.Currency,
+		Type:                    string(reversalSpec.Type),
+		Status:                  string(StatusPending),
+		ReversalOfTransactionID: &t.ID,
+	}
+	if err := reversalSpec.CheckParties(reversal.FromUserID, reversal.ToUserID); err != nil {
+		return nil, TransactionTypeSpec{}, fmt.Errorf("cannot reverse %s transaction: %w", t.Type, err)
+	}
+	return reversal, reversalSpec, nil
+}
+
+// transactionTypeList joins the registered type names for error messages, e.g. "'credit', 'debit', or 'transfer'".
+func transactionTypeList() string {
+	names := TransactionTypeNames()
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + ", or " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferTemplate represents a saved transfer a user can execute repeatedly or bind to a scheduled transaction.
+type TransferTemplate struct {
+	ID            uuid.UUID             `json:"id" db:"id"`
+	UserID        uuid.UUID             `json:"user_id" db:"user_id"`
+	Name          string                `json:"name" db:"name"`
+	PayeeID       uuid.UUID             `json:"payee_id" db:"payee_id"`
+	DefaultAmount float64               `json:"default_amount" db:"default_amount"`
+	Currency      string                `json:"currency" db:"currency"`
+	Usage         TransferTemplateUsage `json:"usage"`
+	CreatedAt     time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// TransferTemplateUsage summarizes the transfers made from a template, directly or by scheduled transactions.
+type TransferTemplateUsage struct {
+	UseCount    int        `json:"use_count" db:"use_count"`
+	TotalAmount float64    `json:"total_amount" db:"total_amount"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+// TransferTemplateRequest represents the data needed to create a transfer template.
+type TransferTemplateRequest struct {
+	Name          string    `json:"name"`
+	PayeeID       uuid.UUID `json:"payee_id"`
+	DefaultAmount float64   `json:"default_amount"`
+	Currency      string    `json:"currency"`
+}
+
+// Validate validates the transfer template request.
+func (r *TransferTemplateRequest) Validate() error {
+	if err := validateTemplateName(r.Name); err != nil {
+		return err
+	}
+
+	if r.PayeeID == uuid.Nil {
+		return fmt.Errorf("payee_id: payee_id is required")
+	}
+
+	if err := validateTransactionAmount(r.DefaultAmount); err != nil {
+		return fmt.Errorf("default_amount: %w", err)
+	}
+
+	if !IsValidCurrency(r.Currency) {
+		return fmt.Errorf("currency: unsupported currency: %s", r.Currency)
+	}
+
+	if err := ValidateCurrencyAmount(r.Currency, r.DefaultAmount); err != nil {
+		return fmt.Errorf("default_amount: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTransferTemplateRequest represents changes to a transfer template. Omitted fields are left unchanged.
+type UpdateTransferTemplateRequest struct {
+	Name          *string    `json:"name,omitempty"`
+	PayeeID       *uuid.UUID `json:"payee_id,omitempty"`
+	DefaultAmount *float64   `json:"default_amount,omitempty"`
+	Currency      *string    `json:"currency,omitempty"`
+}
+
+// Validate validates the update transfer template request.
+func (r *UpdateTransferTemplateRequest) Validate() error {
+	if r.Name == nil && r.PayeeID == nil && r.DefaultAmount == nil && r.Currency == nil {
+		return fmt.Errorf("at least one field must be provided")
+	}
+
+	if r.Name != nil {
+		if err := validateTemplateName(*r.Name); err != nil {
+			return err
+		}
+	}
+
+	if r.PayeeID != nil && *r.PayeeID == uuid.Nil {
+		return fmt.Errorf("payee_id: payee_id must not be empty")
+	}
+
+	if r.DefaultAmount != nil {
+		if err := validateTransactionAmount(*r.DefaultAmount); err != nil {
+			return fmt.Errorf("default_amount: %w", err)
+		}
+	}
+
+	if r.Currency != nil && !IsValidCurrency(*r.Currency) {
+		return fmt.Errorf("currency: unsupported currency: %s", *r.Currency)
+	}
+
+	if r.Currency != nil && r.DefaultAmount != nil {
+		if err := ValidateCurrencyAmount(*r.Currency, *r.DefaultAmount); err != nil {
+			return fmt.Errorf("default_amount: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ExecuteTransferTemplateRequest represents an optional amount overriding the template's default amount.
+type ExecuteTransferTemplateRequest struct {
+	Amount *float64 `json:"amount,omitempty"`
+}
+
+// Validate validates the execute transfer template request.
+func (r *ExecuteTransferTemplateRequest) Validate() error {
+	if r.Amount == nil {
+		return nil
+	}
+
+	if err := validateTransactionAmount(*r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	return nil
+}
+
+// validateTemplateName validates a transfer template name.
+func validateTemplateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("name: name is required")
+	}
+
+	if len(name) > 100 {
+		return fmt.Errorf("name: name must be at most 100 characters")
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TreasuryEntryKind defines the kinds of treasury ledger entries.
+type TreasuryEntryKind string
+
+const (
+	// TreasuryMint creates money in a treasury (admin only)
+	TreasuryMint TreasuryEntryKind = "mint"
+	// TreasuryBurn destroys money held by a treasury (admin only)
+	TreasuryBurn TreasuryEntryKind = "burn"
+	// TreasuryIssue moves money from a treasury to a user, backing a credit
+	TreasuryIssue TreasuryEntryKind = "issue"
+	// TreasuryRedeem moves money from a user back to a treasury, backing a debit
+	TreasuryRedeem TreasuryEntryKind = "redeem"
+)
+
+// TreasuryAccount represents the system account that holds the uncirculated money of one
+// currency. Every credit is issued from it and every debit is redeemed into it, so
+// TotalMinted - TotalBurned = Balance + money held by users at all times.
+type TreasuryAccount struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	Currency        string    `json:"currency" db:"currency"`
+	Balance         float64   `json:"balance" db:"balance"`
+	TotalMinted     float64   `json:"total_minted" db:"total_minted"`
+	TotalBurned     float64   `json:"total_burned" db:"total_burned"`
+	MaxCreditAmount *float64  `json:"max_credit_amount,omitempty" db:"max_credit_amount"` // Largest single credit; nil is uncapped
+	DailyCreditCap  *float64  `json:"daily_credit_cap,omitempty" db:"daily_credit_cap"`   // Credits issued per UTC day; nil is uncapped
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TotalSupply returns the money in existence in the treasury's currency.
+func (a *TreasuryAccount) TotalSupply() float64 {
+	return roundCents(a.TotalMinted - a.TotalBurned)
+}
+
+// Circulating returns the money of the treasury's currency held by users.
+func (a *TreasuryAccount) Circulating() float64 {
+	return roundCents(a.TotalSupply() - a.Balance)
+}
+
+// CheckCredit returns an error if a credit of amount exceeds the treasury's caps, given the
+// amount already issued today.
+func (a *TreasuryAccount) CheckCredit(amount float64, issuedToday float64) error {
+	if a.MaxCreditAmount != nil && amount > *a.MaxCreditAmount {
+		return fmt.Errorf("treasury cap exceeded: credit of %.2f %s is above the limit of %.2f", amount, a.Currency, *a.MaxCreditAmount)
+	}
+
+	if a.DailyCreditCap != nil && issuedToday+amount > *a.DailyCreditCap {
+		return fmt.Errorf("treasury cap exceeded: daily credit cap of %.2f %s would be exceeded (issued today: %.2f)", *a.DailyCreditCap, a.Currency, issuedToday)
+	}
+
+	return nil
+}
+
+// Apply applies a ledger entry of kind and amount to the treasury's balance and totals.
+// Burns and issues fail when the treasury holds less than amount.
+func (a *TreasuryAccount) Apply(kind TreasuryEntryKind, amount float64) error {
+	switch kind {
+	case TreasuryMint:
+		a.Balance = roundCents(a.Balance + amount)
+		a.TotalMinted = roundCents(a.TotalMinted + amount)
+	case TreasuryBurn:
+		if amount > a.Balance {
+			return fmt.Errorf("insufficient treasury funds: %s treasury holds %.2f, requested %.2f", a.Currency, a.Balance, amount)
+		}
+		a.Balance = roundCents(a.Balance - amount)
+		a.TotalBurned = roundCents(a.TotalBurned + amount)
+	case TreasuryIssue:
+		if amount > a.Balance {
+			return fmt.Errorf("insufficient treasury funds: %s treasury holds %.2f, requested %.2f", a.Currency, a.Balance, amount)
+		}
+		a.Balance = roundCents(a.Balance - amount)
+	case TreasuryRedeem:
+		a.Balance = roundCents(a.Balance + amount)
+	default:
+		return fmt.Errorf("unknown treasury entry kind: %s", kind)
+	}
+
+	return nil
+}
+
+// TreasuryEntry represents one movement on a treasury's ledger.
+type TreasuryEntry struct {
+	ID            uuid.UUID         `json:"id" db:"id"`
+	Currency      string            `json:"currency" db:"currency"`
+	Kind          TreasuryEntryKind `json:"kind" db:"kind"`
+	Amount        float64           `json:"amount" db:"amount"`
+	BalanceAfter  float64           `json:"balance_after" db:"balance_after"`
+	TransactionID *uuid.UUID        `json:"transaction_id,omitempty" db:"transaction_id"` // Set for issues and redemptions
+	ActorID       *uuid.UUID        `json:"actor_id,omitempty" db:"actor_id"`             // Admin who minted or burned
+	Reason        string            `json:"reason,omitempty" db:"reason"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+}
+
+// TreasuryEntryFilter represents filters for listing a treasury's ledger.
+type TreasuryEntryFilter struct {
+	Currency string             `json:"currency"`
+	Kind     *TreasuryEntryKind `json:"kind,omitempty"`
+	Limit    int                `json:"limit"`
+	Offset   int                `json:"offset"`
+}
+
+// TreasurySupply reports the money supply of one currency, reconciled against user balances.
+type TreasurySupply struct {
+	*TreasuryAccount
+	TotalSupply  float64 `json:"total_supply"`
+	Circulating  float64 `json:"circulating"`   // Derived from the treasury ledger
+	UserBalances float64 `json:"user_balances"` // Sum of user balances; differs from circulating only if money moved outside the treasury
+}
+
+// TreasuryOperationRequest represents an admin mint or burn.
+type TreasuryOperationRequest struct {
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason"`
+}
+
+// Validate validates the treasury operation request.
+func (r *TreasuryOperationRequest) Validate() error {
+	if err := validateTransactionAmount(r.Amount); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	if strings.TrimSpace(r.Reason) == "" {
+		return fmt.Errorf("reason: reason is required")
+	}
+
+	if len(r.Reason) > 500 {
+		return fmt.Errorf("reason: reason must be at most 500 characters")
+	}
+
+	return nil
+}
+
+// SetTreasuryCapsRequest replaces a treasury's credit caps. A missing cap removes that cap.
+type SetTreasuryCapsRequest struct {
+	MaxCreditAmount *float64 `json:"max_credit_amount,omitempty"`
+	DailyCreditCap  *float64 `json:"daily_credit_cap,omitempty"`
+}
+
+// Validate validates the set treasury caps request.
+func (r *SetTreasuryCapsRequest) Validate() error {
+	if r.MaxCreditAmount != nil {
+		if err := validateTransactionAmount(*r.MaxCreditAmount); err != nil {
+			return fmt.Errorf("max_credit_amount: %w", err)
+		}
+	}
+
+	if r.DailyCreditCap != nil && *r.DailyCreditCap <= 0 {
+		return fmt.Errorf("daily_credit_cap: daily_credit_cap must be greater than 0")
+	}
+
+	return nil
+}
+
+// roundCents rounds an amount to cents, removing floating-point drift from running totals.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
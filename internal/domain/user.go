@@ -12,14 +12,15 @@ import (
 
 // User represents a user in the banking system.
 type User struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	Username     string    `json:"username" db:"username"`
-	Email        string    `json:"email" db:"email"`
-	PasswordHash string    `json:"-" db:"password_hash"` // Never expose password hash
-	Role         string    `json:"role" db:"role"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
-	IsActive     bool      `json:"is_active" db:"is_active"`
+	ID            uuid.UUID `json:"id" db:"id"`
+	Username      string    `json:"username" db:"username"`
+	Email         string    `json:"email" db:"email"`
+	PasswordHash  string    `json:"-" db:"password_hash"` // Never expose password hash
+	Role          string    `json:"role" db:"role"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	IsActive      bool      `json:"is_active" db:"is_active"`
+	AccountNumber string    `json:"account_number" db:"account_number"` // Derived from ID; see AccountNumberFor
 }
 
 // UserRole defines valid user roles.
@@ -61,25 +62,27 @@ type RefreshRequest struct {
 
 // UserResponse represents a user in API responses (without sensitive data).
 type UserResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	IsActive  bool      `json:"is_active"`
+	ID            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	IsActive      bool      `json:"is_active"`
+	AccountNumber string    `json:"account_number"`
 }
 
 // ToResponse converts a User to UserResponse.
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		Role:      u.Role,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
-		IsActive:  u.IsActive,
+		ID:            u.ID,
+		Username:      u.Username,
+		Email:         u.Email,
+		Role:          u.Role,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+		IsActive:      u.IsActive,
+		AccountNumber: u.AccountNumber,
 	}
 }
 
@@ -0,0 +1,231 @@
+// Package featureflags provides feature toggles with defaults from configuration and
+// runtime overrides stored in Redis, so features can be flipped without redeploying.
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// Flag names a feature that can be toggled.
+type Flag string
+
+const (
+	// AsyncProcessing routes credits, debits and transfers through the worker pool.
+	AsyncProcessing Flag = "async_processing"
+	// Caching serves reads from the Redis cache.
+	Caching Flag = "caching"
+	// EventPublishing appends domain events to the event store.
+	EventPublishing Flag = "event_publishing"
+)
+
+// Definition describes a flag and its built-in default.
+type Definition struct {
+	Flag        Flag
+	Description string
+	Default     bool
+}
+
+// Definitions lists every known flag.
+var Definitions = []Definition{
+	{Flag: AsyncProcessing, Description: "Process credits, debits and transfers on the worker pool", Default: false},
+	{Flag: Caching, Description: "Serve users, balances and transactions from the Redis cache", Default: true},
+	{Flag: EventPublishing, Description: "Append domain events to the event store", Default: true},
+}
+
+// DefaultRefreshInterval is how long runtime overrides are cached before being re-read from the store.
+const DefaultRefreshInterval = 5 * time.Second
+
+var (
+	// ErrUnknownFlag is returned for a flag name that is not in Definitions.
+	ErrUnknownFlag = errors.New("unknown feature flag")
+	// ErrNoStore is returned when flipping a flag without a runtime override store.
+	ErrNoStore = errors.New("runtime feature flag overrides are not available")
+)
+
+// Store persists runtime overrides shared by all instances.
+type Store interface {
+	GetOverrides(ctx context.Context) (map[Flag]bool, error)
+	SetOverride(ctx context.Context, flag Flag, enabled bool) error
+	ClearOverride(ctx context.Context, flag Flag) error
+}
+
+// State is the current state of a flag.
+type State struct {
+	Flag        Flag   `json:"flag"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Default     bool   `json:"default"`
+	Overridden  bool   `json:"overridden"` // Set at runtime rather than by configuration
+}
+
+// Manager evaluates flags. Overrides from the store take precedence over configured defaults
+// and are cached for the refresh interval so checking a flag does not hit Redis on every call.
+type Manager struct {
+	defaults map[Flag]bool
+	store    Store // Optional; without it flags only follow configuration
+	refresh  time.Duration
+
+	mu        sync.RWMutex
+	overrides map[Flag]bool
+	loadedAt  time.Time
+}
+
+// NewManager creates a manager from configured defaults keyed by flag name. store may be nil.
+func NewManager(configured map[string]bool, store Store) (*Manager, error) {
+	defaults := make(map[Flag]bool, len(Definitions))
+	for _, def := range Definitions {
+		defaults[def.Flag] = def.Default
+	}
+	for name, enabled := range configured {
+		flag, err := Parse(name)
+		if err != nil {
+			return nil, err
+		}
+		defaults[flag] = enabled
+	}
+
+	return &Manager{
+		defaults: defaults,
+		store:    store,
+		refresh:  DefaultRefreshInterval,
+	}, nil
+}
+
+// Parse returns the flag with the given name.
+func Parse(name string) (Flag, error) {
+	for _, def := range Definitions {
+		if string(def.Flag) == name {
+			return def.Flag, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+}
+
+// Enabled reports whether flag is enabled. A nil manager reports every flag's built-in default.
+func (m *Manager) Enabled(ctx context.Context, flag Flag) bool {
+	if m == nil {
+		for _, def := range Definitions {
+			if def.Flag == flag {
+				return def.Default
+			}
+		}
+		return false
+	}
+
+	if enabled, ok := m.currentOverrides(ctx)[flag]; ok {
+		return enabled
+	}
+	return m.defaults[flag]
+}
+
+// Set overrides flag for all instances until it is reset.
+func (m *Manager) Set(ctx context.Context, flag Flag, enabled bool) error {
+	if _, err := Parse(string(flag)); err != nil {
+		return err
+	}
+	if m.store == nil {
+		return ErrNoStore
+	}
+
+	if err := m.store.SetOverride(ctx, flag, enabled); err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+
+	m.updateOverride(flag, &enabled)
+	utils.InfoContext(ctx, "feature flag set", "flag", string(flag), "enabled", enabled)
+	return nil
+}
+
+// Reset removes the runtime override for flag so it follows configuration again.
+func (m *Manager) Reset(ctx context.Context, flag Flag) error {
+	if _, err := Parse(string(flag)); err != nil {
+		return err
+	}
+	if m.store == nil {
+		return ErrNoStore
+	}
+
+	if err := m.store.ClearOverride(ctx, flag); err != nil {
+		return fmt.Errorf("failed to reset feature flag: %w", err)
+	}
+
+	m.updateOverride(flag, nil)
+	utils.InfoContext(ctx, "feature flag reset", "flag", string(flag))
+	return nil
+}
+
+// List returns the state of every flag in definition order.
+func (m *Manager) List(ctx context.Context) []State {
+	overrides := m.currentOverrides(ctx)
+
+	states := make([]State, 0, len(Definitions))
+	for _, def := range Definitions {
+		state := State{
+			Flag:        def.Flag,
+			Description: def.Description,
+			Enabled:     m.defaults[def.Flag],
+			Default:     m.defaults[def.Flag],
+		}
+		if enabled, ok := overrides[def.Flag]; ok {
+			state.Enabled = enabled
+			state.Overridden = true
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// currentOverrides returns the cached overrides, re-reading them from the store once the refresh
+// interval has passed. If the store is unavailable the last known overrides are kept.
+func (m *Manager) currentOverrides(ctx context.Context) map[Flag]bool {
+	if m.store == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	overrides, fresh := m.overrides, time.Since(m.loadedAt) < m.refresh
+	m.mu.RUnlock()
+	if fresh {
+		return overrides
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Since(m.loadedAt) < m.refresh {
+		return m.overrides
+	}
+
+	// Retry at most once per interval, even when the store is unavailable
+	m.loadedAt = time.Now()
+	loaded, err := m.store.GetOverrides(ctx)
+	if err != nil {
+		utils.WarnContext(ctx, "failed to load feature flag overrides", "error", err.Error())
+		return m.overrides
+	}
+	m.overrides = loaded
+	return m.overrides
+}
+
+// updateOverride applies a local change immediately rather than waiting for the next refresh.
+// The map is copied because callers may still be reading the previous one.
+func (m *Manager) updateOverride(flag Flag, enabled *bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overrides := make(map[Flag]bool, len(m.overrides)+1)
+	for f, e := range m.overrides {
+		overrides[f] = e
+	}
+	if enabled != nil {
+		overrides[flag] = *enabled
+	} else {
+		delete(overrides, flag)
+	}
+	m.overrides = overrides
+}
@@ -0,0 +1,129 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// memoryStore is an in-memory Store for tests.
+type memoryStore struct {
+	overrides map[Flag]bool
+	err       error
+	loads     int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{overrides: make(map[Flag]bool)}
+}
+
+func (s *memoryStore) GetOverrides(_ context.Context) (map[Flag]bool, error) {
+	s.loads++
+	if s.err != nil {
+		return nil, s.err
+	}
+	overrides := make(map[Flag]bool, len(s.overrides))
+	for flag, enabled := range s.overrides {
+		overrides[flag] = enabled
+	}
+	return overrides, nil
+}
+
+func (s *memoryStore) SetOverride(_ context.Context, flag Flag, enabled bool) error {
+	s.overrides[flag] = enabled
+	return nil
+}
+
+func (s *memoryStore) ClearOverride(_ context.Context, flag Flag) error {
+	delete(s.overrides, flag)
+	return nil
+}
+
+func TestManagerDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	manager, err := NewManager(map[string]bool{"caching": false}, nil)
+	if err != nil {
+		t.Fatalf("NewManager returned error: %v", err)
+	}
+
+	if manager.Enabled(ctx, Caching) {
+		t.Error("expected configured value to override the built-in default")
+	}
+	if !manager.Enabled(ctx, EventPublishing) {
+		t.Error("expected built-in default for unconfigured flag")
+	}
+	if err := manager.Set(ctx, Caching, true); !errors.Is(err, ErrNoStore) {
+		t.Errorf("expected ErrNoStore without a store, got %v", err)
+	}
+
+	var nilManager *Manager
+	if !nilManager.Enabled(ctx, Caching) || nilManager.Enabled(ctx, AsyncProcessing) {
+		t.Error("expected nil manager to report built-in defaults")
+	}
+
+	if _, err := NewManager(map[string]bool{"cachin": true}, nil); !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("expected ErrUnknownFlag for misspelled flag, got %v", err)
+	}
+}
+
+func TestManagerOverrides(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	manager, err := NewManager(nil, store)
+	if err != nil {
+		t.Fatalf("NewManager returned error: %v", err)
+	}
+
+	if err := manager.Set(ctx, AsyncProcessing, true); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if !manager.Enabled(ctx, AsyncProcessing) {
+		t.Error("expected override to apply immediately")
+	}
+
+	for _, state := range manager.List(ctx) {
+		if state.Flag == AsyncProcessing && (!state.Enabled || !state.Overridden || state.Default) {
+			t.Errorf("unexpected state for overridden flag: %+v", state)
+		}
+	}
+
+	if err := manager.Reset(ctx, AsyncProcessing); err != nil {
+		t.Fatalf("Reset returned error: %v", err)
+	}
+	if manager.Enabled(ctx, AsyncProcessing) {
+		t.Error("expected flag to follow its default after reset")
+	}
+
+	if err := manager.Set(ctx, Flag("unknown"), true); !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("expected ErrUnknownFlag, got %v", err)
+	}
+}
+
+func TestManagerCachesOverrides(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	store.overrides[Caching] = false
+
+	manager, err := NewManager(nil, store)
+	if err != nil {
+		t.Fatalf("NewManager returned error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if manager.Enabled(ctx, Caching) {
+			t.Fatal("expected override from store")
+		}
+	}
+	if store.loads != 1 {
+		t.Errorf("expected overrides to be loaded once within the refresh interval, got %d loads", store.loads)
+	}
+
+	// A failing store keeps the last known overrides
+	store.err = errors.New("connection refused")
+	manager.loadedAt = manager.loadedAt.Add(-2 * DefaultRefreshInterval)
+	if manager.Enabled(ctx, Caching) {
+		t.Error("expected last known override while the store is unavailable")
+	}
+}
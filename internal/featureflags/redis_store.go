@@ -0,0 +1,55 @@
+package featureflags
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// redisOverridesKey is the Redis hash holding runtime overrides, one field per flag.
+const redisOverridesKey = "feature_flags:overrides"
+
+// RedisStore keeps runtime overrides in a Redis hash shared by all instances.
+type RedisStore struct {
+	client *repository.RedisClient
+}
+
+// NewRedisStore creates a store backed by client.
+func NewRedisStore(client *repository.RedisClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// GetOverrides returns all overrides, skipping unknown flags and malformed values.
+func (s *RedisStore) GetOverrides(ctx context.Context) (map[Flag]bool, error) {
+	fields, err := s.client.HGetAll(ctx, redisOverridesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[Flag]bool, len(fields))
+	for name, value := range fields {
+		flag, err := Parse(name)
+		if err != nil {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.WarnContext(ctx, "ignoring malformed feature flag override", "flag", name, "value", value)
+			continue
+		}
+		overrides[flag] = enabled
+	}
+	return overrides, nil
+}
+
+// SetOverride stores an override for flag.
+func (s *RedisStore) SetOverride(ctx context.Context, flag Flag, enabled bool) error {
+	return s.client.HSet(ctx, redisOverridesKey, string(flag), enabled)
+}
+
+// ClearOverride removes the override for flag.
+func (s *RedisStore) ClearOverride(ctx context.Context, flag Flag) error {
+	return s.client.HDel(ctx, redisOverridesKey, string(flag))
+}
@@ -0,0 +1,171 @@
+// Package health provides dependency health checks with liveness and readiness semantics.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// Status represents the health of a single dependency or the service as a whole.
+type Status string
+
+const (
+	// StatusUp indicates the dependency is healthy.
+	StatusUp Status = "up"
+	// StatusDegraded indicates a non-critical dependency is unhealthy; the service can still serve traffic.
+	StatusDegraded Status = "degraded"
+	// StatusDown indicates a critical dependency is unhealthy; the service should not receive traffic.
+	StatusDown Status = "down"
+)
+
+// DefaultCheckTimeout bounds how long a single check may run.
+const DefaultCheckTimeout = 2 * time.Second
+
+// CheckFunc checks a single dependency and returns an error if it is unhealthy.
+type CheckFunc func(ctx context.Context) error
+
+// check is a registered dependency check.
+type check struct {
+	name     string
+	critical bool
+	fn       CheckFunc
+}
+
+// CheckResult is the outcome of a single dependency check.
+type CheckResult struct {
+	Status    Status  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the aggregated result of all dependency checks.
+type Report struct {
+	Status    Status                 `json:"status"`
+	Timestamp time.Time              `json:"timestamp"`
+	Checks    map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs registered dependency checks.
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+}
+
+// NewChecker creates a new checker. A non-positive timeout uses DefaultCheckTimeout.
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a dependency check. A failing critical check marks the service down
+// (not ready); a failing non-critical check only marks it degraded.
+// Must be called before the checker serves requests.
+func (c *Checker) Register(name string, critical bool, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, critical: critical, fn: fn})
+}
+
+// Run executes all checks concurrently and aggregates their results.
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{
+		Status:    StatusUp,
+		Timestamp: time.Now().UTC(),
+		Checks:    make(map[string]CheckResult, len(c.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, chk := range c.checks {
+		wg.Add(1)
+		go func(chk check) {
+			defer wg.Done()
+			result := c.runCheck(ctx, chk)
+
+			mu.Lock()
+			report.Checks[chk.name] = result
+			mu.Unlock()
+		}(chk)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusDown {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusDown
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+// runCheck executes a single check with the checker's timeout.
+func (c *Checker) runCheck(ctx context.Context, chk check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := chk.fn(ctx)
+	result := CheckResult{
+		Status:    StatusUp,
+		Critical:  chk.critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// BreakerCheck returns a check that fails while breaker is open and rejecting calls.
+func BreakerCheck(breaker *utils.CircuitBreaker) CheckFunc {
+	return func(_ context.Context) error {
+		if breaker.IsOpen() {
+			return fmt.Errorf("circuit breaker %s is %s", breaker.Name(), breaker.GetState())
+		}
+		return nil
+	}
+}
+
+// LivenessHandler reports whether the process is running. It never checks dependencies,
+// so an orchestrator will not restart the service because a database is unavailable.
+func (c *Checker) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]Status{"status": StatusUp})
+	}
+}
+
+// ReadinessHandler runs all checks and responds 503 if any critical dependency is down.
+func (c *Checker) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := c.Run(r.Context())
+
+		statusCode := http.StatusOK
+		if report.Status == StatusDown {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, statusCode, report)
+	}
+}
+
+// writeJSON writes a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
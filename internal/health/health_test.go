@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckerRun(t *testing.T) {
+	healthy := func(_ context.Context) error { return nil }
+	failing := func(_ context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name           string
+		register       func(c *Checker)
+		expectedStatus Status
+	}{
+		{
+			name:           "no checks",
+			register:       func(_ *Checker) {},
+			expectedStatus: StatusUp,
+		},
+		{
+			name: "all healthy",
+			register: func(c *Checker) {
+				c.Register("postgres", true, healthy)
+				c.Register("redis", false, healthy)
+			},
+			expectedStatus: StatusUp,
+		},
+		{
+			name: "non-critical failing",
+			register: func(c *Checker) {
+				c.Register("postgres", true, healthy)
+				c.Register("redis", false, failing)
+			},
+			expectedStatus: StatusDegraded,
+		},
+		{
+			name: "critical failing",
+			register: func(c *Checker) {
+				c.Register("postgres", true, failing)
+				c.Register("redis", false, failing)
+			},
+			expectedStatus: StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(time.Second)
+			tt.register(checker)
+
+			report := checker.Run(context.Background())
+			if report.Status != tt.expectedStatus {
+				t.Errorf("expected status %s, got %s", tt.expectedStatus, report.Status)
+			}
+		})
+	}
+}
+
+func TestCheckerRunReportsErrorAndTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Register("slow", true, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Run(context.Background())
+
+	result, ok := report.Checks["slow"]
+	if !ok {
+		t.Fatal("expected result for slow check")
+	}
+	if result.Status != StatusDown {
+		t.Errorf("expected slow check to be down, got %s", result.Status)
+	}
+	if result.Error == "" {
+		t.Error("expected error message for timed out check")
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", true, func(_ context.Context) error { return errors.New("down") })
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		expectedCode int
+	}{
+		{"liveness ignores dependencies", checker.LivenessHandler(), http.StatusOK},
+		{"readiness fails on critical dependency", checker.ReadinessHandler(), http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handler(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			if rr.Code != tt.expectedCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedCode, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package i18n
+
+// turkish is the Turkish catalog. Verbs may be numbered, e.g. %[2]s, where Turkish word order
+// needs the varying parts in a different order than English.
+var turkish = map[string]string{
+	// Problem titles, the status texts of error responses
+	"Bad Request":              "Hatalı İstek",
+	"Unauthorized":             "Yetkisiz",
+	"Forbidden":                "Yasak",
+	"Not Found":                "Bulunamadı",
+	"Method Not Allowed":       "İzin Verilmeyen Yöntem",
+	"Conflict":                 "Çakışma",
+	"Gone":                     "Artık Mevcut Değil",
+	"Request Entity Too Large": "İstek Çok Büyük",
+	"Unprocessable Entity":     "İşlenemeyen İçerik",
+	"Too Many Requests":        "Çok Fazla İstek",
+	"Internal Server Error":    "Sunucu Hatası",
+	"Not Implemented":          "Uygulanmadı",
+	"Service Unavailable":      "Hizmet Kullanılamıyor",
+	"Gateway Timeout":          "Ağ Geçidi Zaman Aşımı",
+
+	// Authentication and access
+	"User not authenticated":                                "Kullanıcı kimliği doğrulanmadı",
+	"Invalid email or password":                             "Geçersiz e-posta veya şifre",
+	"invalid email or password":                             "geçersiz e-posta veya şifre",
+	"Invalid refresh token":                                 "Geçersiz yenileme belirteci",
+	"Access denied":                                         "Erişim reddedildi",
+	"missing authorization header":                          "Authorization başlığı eksik",
+	"invalid authorization header format":                   "geçersiz Authorization başlığı biçimi",
+	"missing token":                                         "belirteç eksik",
+	"invalid token":                                         "geçersiz belirteç",
+	"authentication required":                               "kimlik doğrulaması gerekli",
+	"session revoked":                                       "oturum iptal edilmiş",
+	"Session not found":                                     "Oturum bulunamadı",
+	"Session already revoked":                               "Oturum zaten iptal edilmiş",
+	"Invalid session ID format":                             "Geçersiz oturum kimliği biçimi",
+	"Invalid password":                                      "Geçersiz şifre",
+	"Step-up authentication required":                       "Ek kimlik doğrulaması gerekli",
+	"insufficient permissions":                              "yetersiz yetki",
+	"can only access your own resources":                    "yalnızca kendi kaynaklarınıza erişebilirsiniz",
+	"Email already registered":                              "E-posta zaten kayıtlı",
+	"Username already taken":                                "Kullanıcı adı zaten alınmış",
+	"Registration failed":                                   "Kayıt başarısız oldu",
+	"User is deleted":                                       "Kullanıcı silinmiş",
+	"User not found":                                        "Kullanıcı bulunamadı",
+	"user not found":                                        "kullanıcı bulunamadı",
+	"User ID is required":                                   "Kullanıcı kimliği gerekli",
+	"Invalid user ID":                                       "Geçersiz kullanıcı kimliği",
+	"Invalid user ID format":                                "Geçersiz kullanıcı kimliği biçimi",
+	"User cannot be deleted: associated transactions exist": "Kullanıcı silinemez: ilişkili işlemler mevcut",
+
+	// Transactions
+	"Transaction not found":         "İşlem bulunamadı",
+	"transaction not found":         "işlem bulunamadı",
+	"Transaction ID is required":    "İşlem kimliği gerekli",
+	"Invalid transaction ID format": "Geçersiz işlem kimliği biçimi",
+	"Access denied: you don't have permission to view this transaction":                                   "Erişim reddedildi: bu işlemi görüntüleme yetkiniz yok",
+	"access denied: you don't have permission to view this transaction":                                   "erişim reddedildi: bu işlemi görüntüleme yetkiniz yok",
+	"Access denied: you don't have permission to rollback this transaction":                               "Erişim reddedildi: bu işlemi geri alma yetkiniz yok",
+	"Can only rollback completed transactions":                                                            "Yalnızca tamamlanmış işlemler geri alınabilir",
+	"can only rollback completed transactions":                                                            "yalnızca tamamlanmış işlemler geri alınabilir",
+	"Transaction has already been rolled back":                                                            "İşlem zaten geri alınmış",
+	"insufficient funds: current balance %s %s, requested %s %s":                                          "yetersiz bakiye: mevcut bakiye %s %s, istenen %s %s",
+	"insufficient funds: current balance %s %s with %s %s reserved by pending transfers, requested %s %s": "yetersiz bakiye: mevcut bakiye %s %s, bekleyen transferlerce ayrılan %s %s, istenen %s %s",
+	"currency mismatch: user balance is in %s but transaction is in %s":                                   "para birimi uyuşmazlığı: kullanıcı bakiyesi %s, işlem ise %s cinsinden",
+	"cannot transfer to self":                                                                             "kendinize transfer yapamazsınız",
+	"transfer cannot be to the same user":                                                                 "transfer aynı kullanıcıya yapılamaz",
+	"Failed to transfer":                                                                                  "Transfer başarısız oldu",
+
+	// Scheduled transactions
+	"Scheduled transaction not found":              "Planlanmış işlem bulunamadı",
+	"scheduled transaction not found":              "planlanmış işlem bulunamadı",
+	"Scheduled transaction ID is required":         "Planlanmış işlem kimliği gerekli",
+	"Invalid scheduled transaction ID format":      "Geçersiz planlanmış işlem kimliği biçimi",
+	"Scheduled transaction cancelled successfully": "Planlanmış işlem başarıyla iptal edildi",
+	"scheduled transaction is already paused":      "planlanmış işlem zaten duraklatılmış",
+	"scheduled transaction is not paused":          "planlanmış işlem duraklatılmamış",
+	"execute_at must be in the future":             "execute_at gelecekte olmalı",
+	"recurrence_end_date must be after execute_at": "recurrence_end_date, execute_at tarihinden sonra olmalı",
+	"max_occurrences must be greater than 0":       "max_occurrences 0'dan büyük olmalı",
+	"invalid recurrence pattern: %s":               "geçersiz tekrar deseni: %s",
+	"Failed to list scheduled transactions":        "Planlanmış işlemler listelenemedi",
+
+	// Validation
+	"validation failed":                                           "doğrulama başarısız oldu",
+	"invalid credit request: %s":                                  "geçersiz yatırma isteği: %s",
+	"invalid debit request: %s":                                   "geçersiz çekme isteği: %s",
+	"invalid transfer request: %s":                                "geçersiz transfer isteği: %s",
+	"invalid rollback request: %s":                                "geçersiz geri alma isteği: %s",
+	"invalid request: %s":                                         "geçersiz istek: %s",
+	"validation failed: %s":                                       "doğrulama başarısız oldu: %s",
+	"Content-Type must be application/json":                       "Content-Type application/json olmalı",
+	"invalid JSON format":                                         "geçersiz JSON biçimi",
+	"Invalid JSON request body":                                   "Geçersiz JSON istek gövdesi",
+	"request body is required":                                    "istek gövdesi gerekli",
+	"failed to parse JSON: %s":                                    "JSON ayrıştırılamadı: %s",
+	"unknown field":                                               "bilinmeyen alan",
+	"field is required":                                           "alan gerekli",
+	"field cannot be empty":                                       "alan boş olamaz",
+	"at least one field must be provided":                         "en az bir alan belirtilmeli",
+	"amount must be greater than 0":                               "tutar 0'dan büyük olmalı",
+	"amount must be a plain decimal number, e.g. 1234.50":         "tutar düz bir ondalık sayı olmalı, örn. 1234.50",
+	"unsupported currency: %s":                                    "desteklenmeyen para birimi: %s",
+	"currency: unsupported currency: %s":                          "currency: desteklenmeyen para birimi: %s",
+	"description must be at most %d characters":                   "açıklama en fazla %d karakter olmalı",
+	"q is required":                                               "q gerekli",
+	"q must be at most %d characters":                             "q en fazla %d karakter olmalı",
+	"q must not contain control characters":                       "q kontrol karakterleri içermemeli",
+	"username is required":                                        "kullanıcı adı gerekli",
+	"username must be at least 3 characters":                      "kullanıcı adı en az 3 karakter olmalı",
+	"username must be at most 50 characters":                      "kullanıcı adı en fazla 50 karakter olmalı",
+	"username can only contain letters, numbers, and underscores": "kullanıcı adı yalnızca harf, rakam ve alt çizgi içerebilir",
+	"email is required":                                           "e-posta gerekli",
+	"invalid email format":                                        "geçersiz e-posta biçimi",
+	"password is required":                                        "şifre gerekli",
+	"password must be at least 8 characters":                      "şifre en az 8 karakter olmalı",
+	"password must be at most 72 characters":                      "şifre en fazla 72 karakter olmalı",
+	"reason is required":                                          "gerekçe gerekli",
+	"to_user_id is required":                                      "to_user_id gerekli",
+	"to_user_id or to_account_number is required":                 "to_user_id veya to_account_number gerekli",
+	"payload is required":                                         "payload gerekli",
+	"signature is required":                                       "imza gerekli",
+	"%s must be a non-negative integer":                           "%s negatif olmayan bir tam sayı olmalı",
+	"%s must be an integer of at least %d":                        "%s en az %d olan bir tam sayı olmalı",
+	"%s must be an integer between %d and %d":                     "%s, %d ile %d arasında bir tam sayı olmalı",
+	"%s must be one of %s":                                        "%s şunlardan biri olmalı: %s",
+	"%s must be a UUID":                                           "%s bir UUID olmalı",
+	"%s must be an RFC 3339 timestamp":                            "%s bir RFC 3339 zaman damgası olmalı",
+	"%s must be after %s":                                         "%s, %s değerinden sonra olmalı",
+	"format must be 'csv' or 'json'":                              "format 'csv' veya 'json' olmalı",
+	"locale must be one of en, de or fr":                          "locale en, de veya fr olmalı",
+	"unknown aggregate_type":                                      "bilinmeyen aggregate_type",
+	"Timestamp parameter is required":                             "Zaman damgası parametresi gerekli",
+	"Invalid amount parameter. Must be a positive decimal number, e.g. 12.50": "Geçersiz amount parametresi. Pozitif bir ondalık sayı olmalı, örn. 12.50",
+
+	// Other resources
+	"account not found":                                   "hesap bulunamadı",
+	"Export not found":                                    "Dışa aktarma bulunamadı",
+	"Request log not found":                               "İstek kaydı bulunamadı",
+	"dispute not found":                                   "itiraz bulunamadı",
+	"dispute is already resolved":                         "itiraz zaten sonuçlandırılmış",
+	"transfer template not found":                         "transfer şablonu bulunamadı",
+	"payment request is no longer pending":                "ödeme talebi artık beklemede değil",
+	"the payment QR code fixes the amount at %s":          "ödeme QR kodu tutarı %s olarak sabitliyor",
+	"the payment QR code fixes the description":           "ödeme QR kodu açıklamayı sabitliyor",
+	"not a payment QR code":                               "bir ödeme QR kodu değil",
+	"Contact deleted successfully":                        "Kişi başarıyla silindi",
+	"Balance alert deleted successfully":                  "Bakiye uyarısı başarıyla silindi",
+	"Transfer template deleted successfully":              "Transfer şablonu başarıyla silindi",
+	"Approval not found":                                  "Onay bulunamadı",
+	"Approval already reviewed":                           "Onay zaten incelenmiş",
+	"Cannot approve your own request":                     "Kendi talebinizi onaylayamazsınız",
+	"Policy version not found":                            "Politika sürümü bulunamadı",
+	"Invalid policy version":                              "Geçersiz politika sürümü",
+	"Monthly summary not found":                           "Aylık özet bulunamadı",
+	"Failed to get monthly summary":                       "Aylık özet alınamadı",
+	"budget not found":                                    "bütçe bulunamadı",
+	"Budget deleted successfully":                         "Bütçe başarıyla silindi",
+	"api quota not found":                                 "API kotası bulunamadı",
+	"API quota deleted successfully":                      "API kotası başarıyla silindi",
+	"Access denied: only admins can search the audit log": "Erişim reddedildi: denetim kaydında yalnızca yöneticiler arama yapabilir",
+	"Failed to report monthly activity":                   "Aylık hareketler raporlanamadı",
+	"Failed to search":                                    "Arama yapılamadı",
+	"Failed to advance clock":                             "Saat ileri alınamadı",
+	"Failed to advise indexes":                            "İndeks önerileri hazırlanamadı",
+
+	// Server
+	"Failed to marshal response":               "Yanıt oluşturulamadı",
+	"Failed to encode response":                "Yanıt kodlanamadı",
+	"Failed to get balance":                    "Bakiye alınamadı",
+	"Failed to get transaction history":        "İşlem geçmişi alınamadı",
+	"Rate limit exceeded":                      "İstek sınırı aşıldı",
+	"API quota exceeded":                       "API kotası aşıldı",
+	"Request timed out":                        "İstek zaman aşımına uğradı",
+	"Service temporarily unavailable":          "Hizmet geçici olarak kullanılamıyor",
+	"Server is busy, please retry":             "Sunucu meşgul, lütfen tekrar deneyin",
+	"Simulation is disabled":                   "Simülasyon devre dışı",
+	"Netting is disabled":                      "Netleştirme devre dışı",
+	"Simulation clock is disabled":             "Simülasyon saati devre dışı",
+	"Index advice requires PostgreSQL storage": "İndeks önerileri PostgreSQL depolaması gerektirir",
+}
@@ -0,0 +1,195 @@
+// Package i18n translates user-facing messages into the language a client asks for with
+// Accept-Language.
+//
+// Messages are keyed by their English text, so code keeps writing English and the English text
+// is also the fallback for a message a catalog lacks. A catalog entry is either a plain message
+// or a template whose fmt verbs (%s, %d, %.2f, ...) stand for the parts that vary, e.g.
+// "unsupported currency: %s". Those parts are translated in turn, so a wrapped message such as
+// "invalid request: amount must be greater than 0" is translated as a whole.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language is a language messages can be translated into.
+type Language string
+
+const (
+	// English is the language messages are written in
+	English Language = "en"
+	// Turkish translates messages into Turkish
+	Turkish Language = "tr"
+)
+
+// DefaultLanguage is used when a client asks for no supported language.
+const DefaultLanguage = English
+
+// catalogs holds the translations of each language other than English, keyed by English text.
+var catalogs = map[Language]map[string]string{
+	Turkish: turkish,
+}
+
+// Supported reports whether messages can be translated into language.
+func Supported(language Language) bool {
+	_, ok := catalogs[language]
+	return language == English || ok
+}
+
+// Negotiate picks the preferred supported language of an Accept-Language header, matching a
+// regional tag such as tr-TR by its primary language. Languages with q=0 are refused, and
+// DefaultLanguage is returned when none of the others is acceptable.
+func Negotiate(header string) Language {
+	best, bestQuality := DefaultLanguage, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		primary, _, _ := strings.Cut(tag, "-")
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		language := Language(primary)
+		if tag == "*" {
+			language = DefaultLanguage
+		}
+		if !Supported(language) || quality <= 0 {
+			continue
+		}
+		// Earlier languages win ties, as clients list them in order of preference
+		if quality > bestQuality {
+			best, bestQuality = language, quality
+		}
+	}
+	return best
+}
+
+// languageKey is the context key of the request language.
+type languageKey struct{}
+
+// WithLanguage returns a context carrying the language of the request.
+func WithLanguage(ctx context.Context, language Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// FromContext returns the language of the request, or DefaultLanguage when none was negotiated.
+func FromContext(ctx context.Context) Language {
+	if language, ok := ctx.Value(languageKey{}).(Language); ok {
+		return language
+	}
+	return DefaultLanguage
+}
+
+// T translates msg into the language of the request.
+func T(ctx context.Context, msg string) string {
+	return Translate(FromContext(ctx), msg)
+}
+
+// Translate translates msg into language, returning msg unchanged when the catalog has no entry
+// for it.
+func Translate(language Language, msg string) string {
+	catalog, ok := catalogs[language]
+	if !ok || msg == "" {
+		return msg
+	}
+	if translated, ok := catalog[msg]; ok {
+		return translated
+	}
+
+	for _, t := range templates[language] {
+		match := t.pattern.FindStringSubmatch(msg)
+		if match == nil {
+			continue
+		}
+		args := make([]any, len(match)-1)
+		for i, part := range match[1:] {
+			args[i] = Translate(language, part)
+		}
+		return fmt.Sprintf(t.translation, args...)
+	}
+	return msg
+}
+
+// template is a catalog entry with varying parts.
+type template struct {
+	pattern     *regexp.Regexp // Matches the English message, capturing each varying part
+	translation string         // The translation with every verb rewritten to an indexed %s
+	literal     int            // Length of the fixed text, so more specific templates are tried first
+}
+
+// templates holds the catalog entries of each language that contain verbs.
+var templates = compileTemplates()
+
+// verbPattern matches the fmt verbs of a catalog entry, with an optional argument index.
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?(\.\d+)?[sdfvq]`)
+
+// compileTemplates turns the catalog entries with verbs into patterns. Entries without any fixed
+// text are skipped, as they would match every message.
+func compileTemplates() map[Language][]template {
+	compiled := make(map[Language][]template, len(catalogs))
+	for language, catalog := range catalogs {
+		for msg, translation := range catalog {
+			verbs := verbPattern.FindAllStringIndex(msg, -1)
+			if len(verbs) == 0 {
+				continue
+			}
+
+			var pattern strings.Builder
+			pattern.WriteString("^")
+			literal, last := 0, 0
+			for _, verb := range verbs {
+				pattern.WriteString(regexp.QuoteMeta(msg[last:verb[0]]))
+				pattern.WriteString("(.*?)")
+				literal += verb[0] - last
+				last = verb[1]
+			}
+			pattern.WriteString(regexp.QuoteMeta(msg[last:]))
+			pattern.WriteString("$")
+			literal += len(msg) - last
+			if literal == 0 {
+				continue
+			}
+
+			compiled[language] = append(compiled[language], template{
+				pattern:     regexp.MustCompile(pattern.String()),
+				translation: indexVerbs(translation),
+				literal:     literal,
+			})
+		}
+		sort.Slice(compiled[language], func(i, j int) bool {
+			a, b := compiled[language][i], compiled[language][j]
+			if a.literal != b.literal {
+				return a.literal > b.literal
+			}
+			return a.pattern.String() < b.pattern.String()
+		})
+	}
+	return compiled
+}
+
+// indexVerbs rewrites the verbs of a translation to %[n]s, numbering unindexed verbs in order, so
+// captured parts fill them whatever verb the English text used and in whatever order the
+// translation needs them.
+func indexVerbs(translation string) string {
+	next := 0
+	return verbPattern.ReplaceAllStringFunc(translation, func(verb string) string {
+		if index := verbPattern.FindStringSubmatch(verb)[1]; index != "" {
+			n, _ := strconv.Atoi(strings.Trim(index, "[]"))
+			next = n
+			return "%" + index + "s"
+		}
+		next++
+		return "%[" + strconv.Itoa(next) + "]s"
+	})
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]Language{
+		"":                          English,
+		"tr":                        Turkish,
+		"tr-TR":                     Turkish,
+		"TR-tr, en;q=0.8":           Turkish,
+		"en-US, tr;q=0.9":           English,
+		"de, tr;q=0.5":              Turkish,
+		"de, fr":                    English,
+		"tr;q=0":                    English,
+		"tr;q=0.3, en;q=0.7":        English,
+		"*":                         English,
+		"tr;q=oops, en":             English,
+		"fr-CH, fr;q=0.9, tr;q=0.8": Turkish,
+	}
+	for header, want := range tests {
+		if got := Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		language Language
+		msg      string
+		want     string
+	}{
+		{English, "Transaction not found", "Transaction not found"},
+		{Turkish, "Transaction not found", "İşlem bulunamadı"},
+		{Turkish, "not in the catalog", "not in the catalog"},
+		{Turkish, "", ""},
+		{Language("xx"), "Transaction not found", "Transaction not found"},
+		{Turkish, "unsupported currency: XYZ", "desteklenmeyen para birimi: XYZ"},
+		{Turkish, "description must be at most 80 characters", "açıklama en fazla 80 karakter olmalı"},
+		// Wrapped messages are translated part by part
+		{Turkish, "invalid request: amount must be greater than 0", "geçersiz istek: tutar 0'dan büyük olmalı"},
+		{Turkish, "invalid request: unsupported currency: XYZ", "geçersiz istek: desteklenmeyen para birimi: XYZ"},
+		{Turkish, "insufficient funds: current balance 10.00 USD, requested 25.00 USD",
+			"yetersiz bakiye: mevcut bakiye 10.00 USD, istenen 25.00 USD"},
+		// Query parameter errors name the parameter, which stays as is
+		{Turkish, "limit must be an integer between 1 and 100", "limit, 1 ile 100 arasında bir tam sayı olmalı"},
+		{Turkish, "status must be one of open, closed", "status şunlardan biri olmalı: open, closed"},
+		{Turkish, "to must be after from", "to, from değerinden sonra olmalı"},
+	}
+	for _, tt := range tests {
+		if got := Translate(tt.language, tt.msg); got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tt.language, tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestIndexVerbs(t *testing.T) {
+	tests := map[string]string{
+		"no verbs":           "no verbs",
+		"%s and %d":          "%[1]s and %[2]s",
+		"%[2]s before %[1]s": "%[2]s before %[1]s",
+		"%.2f %s":            "%[1]s %[2]s",
+	}
+	for translation, want := range tests {
+		if got := indexVerbs(translation); got != want {
+			t.Errorf("indexVerbs(%q) = %q, want %q", translation, got, want)
+		}
+	}
+}
+
+func TestCatalogsCoverTheirVerbs(t *testing.T) {
+	for language, catalog := range catalogs {
+		for msg, translation := range catalog {
+			if got, want := len(verbPattern.FindAllString(translation, -1)), len(verbPattern.FindAllString(msg, -1)); got != want {
+				t.Errorf("%s translation of %q has %d verbs, want %d", language, msg, got, want)
+			}
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := FromContext(ctx); got != DefaultLanguage {
+		t.Errorf("FromContext() without a language = %q, want %q", got, DefaultLanguage)
+	}
+
+	ctx = WithLanguage(ctx, Turkish)
+	if got := FromContext(ctx); got != Turkish {
+		t.Errorf("FromContext() = %q, want %q", got, Turkish)
+	}
+	if got := T(ctx, "Access denied"); got != "Erişim reddedildi" {
+		t.Errorf("T() = %q", got)
+	}
+}
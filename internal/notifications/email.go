@@ -0,0 +1,81 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// EmailConfig holds the SMTP server emails are sent through.
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string // Optional; PLAIN authentication is used when set
+	Password string
+	From     string
+}
+
+// EmailChannel delivers messages by email over SMTP.
+type EmailChannel struct {
+	cfg      EmailConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailChannel creates a channel that sends through the configured SMTP server.
+func NewEmailChannel(cfg EmailConfig) *EmailChannel {
+	return &EmailChannel{
+		cfg:      cfg,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Name identifies the channel in user preferences.
+func (c *EmailChannel) Name() domain.NotificationChannel {
+	return domain.ChannelEmail
+}
+
+// Send emails the message to the user's address. net/smtp does not take a context, so a
+// cancelled context only stops a send that has not started yet.
+func (c *EmailChannel) Send(ctx context.Context, msg *Message) error {
+	if msg.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.cfg.Username != "" {
+		auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port))
+	if err := c.sendMail(addr, auth, c.cfg.From, []string{msg.Email}, c.compose(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// compose builds a plain-text RFC 5322 email for the message.
+func (c *EmailChannel) compose(msg *Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", c.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.Email)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Title))
+	fmt.Fprintf(&buf, "Date: %s\r\n", msg.CreatedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@go-banking-sim>\r\n", msg.ID)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
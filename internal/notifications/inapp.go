@@ -0,0 +1,41 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// InboxStore stores notifications in users' in-app inboxes.
+type InboxStore interface {
+	CreateNotification(ctx context.Context, notification *domain.Notification) error
+}
+
+// InAppChannel delivers messages to the user's in-app inbox.
+type InAppChannel struct {
+	store InboxStore
+}
+
+// NewInAppChannel creates a channel that writes to the given inbox store.
+func NewInAppChannel(store InboxStore) *InAppChannel {
+	return &InAppChannel{store: store}
+}
+
+// Name identifies the channel in user preferences.
+func (c *InAppChannel) Name() domain.NotificationChannel {
+	return domain.ChannelInApp
+}
+
+// Send adds the message to the user's inbox. The inbox notification reuses the message ID,
+// so a retried delivery does not appear twice.
+func (c *InAppChannel) Send(ctx context.Context, msg *Message) error {
+	return c.store.CreateNotification(ctx, &domain.Notification{
+		ID:        msg.ID,
+		UserID:    msg.UserID,
+		Type:      msg.Type,
+		Title:     msg.Title,
+		Body:      msg.Body,
+		Data:      msg.Data,
+		CreatedAt: msg.CreatedAt,
+	})
+}
@@ -0,0 +1,133 @@
+// Package notifications renders notifications from templates and sends them over pluggable
+// channels: the in-app inbox, email and webhooks.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// Message is a rendered notification addressed to one user.
+type Message struct {
+	ID         uuid.UUID // Delivery ID; channels use it to make retries idempotent
+	UserID     uuid.UUID
+	Type       domain.NotificationType
+	Title      string
+	Body       string
+	Data       map[string]interface{}
+	Email      string // Recipient address for the email channel
+	WebhookURL string // Recipient URL for the webhook channel
+	CreatedAt  time.Time
+}
+
+// Channel delivers messages to users.
+type Channel interface {
+	// Name identifies the channel in user preferences.
+	Name() domain.NotificationChannel
+
+	// Send delivers a message. A returned error means the delivery should be retried.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// messageTemplate pairs the title and body templates of a notification type.
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+// templateFuncs are available to every template.
+var templateFuncs = template.FuncMap{
+	// money formats an amount with its currency's decimal places, e.g. "12.50 USD" or "1500 JPY"
+	"money": func(amount interface{}, currency interface{}) string {
+		return domain.FormatAmount(toFloat(amount), fmt.Sprint(currency))
+	},
+}
+
+// newMessageTemplate parses a title and body template. Missing data keys are errors rather
+// than "<no value>" so a malformed notification fails loudly instead of reaching the user.
+func newMessageTemplate(name, title, body string) messageTemplate {
+	return messageTemplate{
+		title: template.Must(template.New(name + ".title").Funcs(templateFuncs).Option("missingkey=error").Parse(title)),
+		body:  template.Must(template.New(name + ".body").Funcs(templateFuncs).Option("missingkey=error").Parse(body)),
+	}
+}
+
+// templates holds the template of every notification type.
+var templates = map[domain.NotificationType]messageTemplate{
+	domain.NotificationTransactionCompleted: newMessageTemplate(string(domain.NotificationTransactionCompleted),
+		`{{if eq .direction "received"}}Money received{{else}}Transaction completed{{end}}`,
+		`{{if eq .direction "received"}}You received {{money .amount .currency}}.`+
+			`{{else if eq .direction "sent"}}You sent {{money .amount .currency}}.`+
+			`{{else}}Your {{.type}} of {{money .amount .currency}} has completed.{{end}}`+
+			` Transaction ID: {{.transaction_id}}.`),
+	domain.NotificationLowBalance: newMessageTemplate(string(domain.NotificationLowBalance),
+		`Low {{.currency}} balance`,
+		`Your {{.currency}} balance is {{money .balance .currency}}, below your alert threshold of {{money .threshold .currency}}.`),
+	domain.NotificationScheduledExecutionFailed: newMessageTemplate(string(domain.NotificationScheduledExecutionFailed),
+		`Scheduled {{.transaction_type}} failed`,
+		`Your scheduled {{.transaction_type}} of {{money .amount .currency}} could not be executed: {{.error}}.`+
+			` Scheduled transaction ID: {{.scheduled_transaction_id}}.`),
+	domain.NotificationScheduledInsufficientFunds: newMessageTemplate(string(domain.NotificationScheduledInsufficientFunds),
+		`Insufficient funds for scheduled {{.transaction_type}}`,
+		`Your scheduled {{.transaction_type}} of {{money .amount .currency}} on {{.execute_at}} is {{money .shortfall .currency}} short:`+
+			` your balance is {{money .balance .currency}}. Scheduled transaction ID: {{.scheduled_transaction_id}}.`),
+	domain.NotificationNewDeviceLogin: newMessageTemplate(string(domain.NotificationNewDeviceLogin),
+		`New login to your account`,
+		`Your account was logged in to from a new device at {{.logged_in_at}}: {{.user_agent}} from IP {{.ip}}.`+
+			` If this was not you, revoke the session with DELETE /api/v1/sessions/{{.session_id}} and change your password.`),
+	domain.NotificationMonthlySummary: newMessageTemplate(string(domain.NotificationMonthlySummary),
+		`Your account summary for {{.month}}`,
+		`Your balance went from {{money .opening_balance .currency}} to {{money .closing_balance .currency}} in {{.month}}:`+
+			` {{money .total_in .currency}} in and {{money .total_out .currency}} out over {{.transaction_count}} transactions,`+
+			` including {{money .fees_paid .currency}} in fees. See the full summary at GET /api/v1/reports/monthly/{{.month}}.`),
+	domain.NotificationBudgetExceeded: newMessageTemplate(string(domain.NotificationBudgetExceeded),
+		`Over your {{.category}} budget`,
+		`You have spent {{money .spent .currency}} on {{.category}} in {{.month}}, over your monthly budget of {{money .limit .currency}}.`+
+			` Transaction ID: {{.transaction_id}}.`),
+}
+
+// Render renders the title and body of a notification of type t from its template data.
+func Render(t domain.NotificationType, data map[string]interface{}) (title, body string, err error) {
+	tmpl, ok := templates[t]
+	if !ok {
+		return "", "", fmt.Errorf("no template for notification type %s", t)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.title.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s title: %w", t, err)
+	}
+	title = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := tmpl.body.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s body: %w", t, err)
+	}
+	body = strings.TrimSpace(buf.String())
+
+	return title, body, nil
+}
+
+// toFloat converts a template amount to a float64. Amounts are float64 once they have been
+// round-tripped through JSON, but callers rendering directly may pass other numeric types.
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	default:
+		return 0
+	}
+}
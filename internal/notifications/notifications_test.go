@@ -0,0 +1,159 @@
+package notifications
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       domain.NotificationType
+		data      map[string]interface{}
+		wantTitle string
+		wantBody  string
+	}{
+		{
+			name: "transfer received",
+			typ:  domain.NotificationTransactionCompleted,
+			data: map[string]interface{}{
+				"type": "transfer", "direction": "received", "amount": 12.5, "currency": "USD", "transaction_id": "tx-1",
+			},
+			wantTitle: "Money received",
+			wantBody:  "You received 12.50 USD. Transaction ID: tx-1.",
+		},
+		{
+			name: "credit completed",
+			typ:  domain.NotificationTransactionCompleted,
+			data: map[string]interface{}{
+				"type": "credit", "direction": "", "amount": 100, "currency": "EUR", "transaction_id": "tx-2",
+			},
+			wantTitle: "Transaction completed",
+			wantBody:  "Your credit of 100.00 EUR has completed. Transaction ID: tx-2.",
+		},
+		{
+			name:      "low balance",
+			typ:       domain.NotificationLowBalance,
+			data:      map[string]interface{}{"currency": "USD", "balance": 4.2, "threshold": 10.0},
+			wantTitle: "Low USD balance",
+			wantBody:  "Your USD balance is 4.20 USD, below your alert threshold of 10.00 USD.",
+		},
+		{
+			name: "scheduled insufficient funds",
+			typ:  domain.NotificationScheduledInsufficientFunds,
+			data: map[string]interface{}{
+				"transaction_type": "transfer", "amount": 50, "currency": "USD", "execute_at": "2026-03-01T09:00:00Z",
+				"balance": 30.0, "shortfall": 20.0, "scheduled_transaction_id": "st-1",
+			},
+			wantTitle: "Insufficient funds for scheduled transfer",
+			wantBody:  "Your scheduled transfer of 50.00 USD on 2026-03-01T09:00:00Z is 20.00 USD short: your balance is 30.00 USD. Scheduled transaction ID: st-1.",
+		},
+		{
+			name: "new device login",
+			typ:  domain.NotificationNewDeviceLogin,
+			data: map[string]interface{}{
+				"session_id": "s-1", "ip": "203.0.113.7", "user_agent": "curl/8.0", "logged_in_at": "2026-03-01T09:00:00Z",
+			},
+			wantTitle: "New login to your account",
+			wantBody:  "Your account was logged in to from a new device at 2026-03-01T09:00:00Z: curl/8.0 from IP 203.0.113.7. If this was not you, revoke the session with DELETE /api/v1/sessions/s-1 and change your password.",
+		},
+		{
+			name: "monthly summary",
+			typ:  domain.NotificationMonthlySummary,
+			data: map[string]interface{}{
+				"month": "2026-02", "currency": "USD", "opening_balance": 100.0, "closing_balance": 68.5,
+				"total_in": 20.0, "total_out": 51.5, "fees_paid": 1.5, "transaction_count": 3,
+			},
+			wantTitle: "Your account summary for 2026-02",
+			wantBody:  "Your balance went from 100.00 USD to 68.50 USD in 2026-02: 20.00 USD in and 51.50 USD out over 3 transactions, including 1.50 USD in fees. See the full summary at GET /api/v1/reports/monthly/2026-02.",
+		},
+		{
+			name: "budget exceeded",
+			typ:  domain.NotificationBudgetExceeded,
+			data: map[string]interface{}{
+				"category": "dining", "month": "2026-02", "currency": "EUR", "spent": 212.4, "limit": 200.0, "transaction_id": "tx-3",
+			},
+			wantTitle: "Over your dining budget",
+			wantBody:  "You have spent 212.40 EUR on dining in 2026-02, over your monthly budget of 200.00 EUR. Transaction ID: tx-3.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body, err := Render(tt.typ, tt.data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRenderRejectsMissingData(t *testing.T) {
+	if _, _, err := Render(domain.NotificationLowBalance, map[string]interface{}{"currency": "USD"}); err == nil {
+		t.Error("expected an error for missing template data")
+	}
+	if _, _, err := Render("unknown", nil); err == nil {
+		t.Error("expected an error for an unknown notification type")
+	}
+}
+
+func TestWebhookChannelSignsRequests(t *testing.T) {
+	var gotSignature, gotTimestamp string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	channel := NewWebhookChannel("secret", time.Second)
+	msg := &Message{
+		ID:         uuid.New(),
+		Type:       domain.NotificationLowBalance,
+		Title:      "Low USD balance",
+		WebhookURL: server.URL,
+		CreatedAt:  time.Now(),
+	}
+	if err := channel.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if gotTimestamp == "" {
+		t.Fatal("expected a timestamp header")
+	}
+	if want := "sha256=" + Sign("secret", gotTimestamp, gotBody); gotSignature != want {
+		t.Errorf("signature = %q, want %q", gotSignature, want)
+	}
+	if !strings.Contains(string(gotBody), msg.ID.String()) {
+		t.Errorf("payload does not contain the message ID: %s", gotBody)
+	}
+}
+
+func TestWebhookChannelFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	channel := NewWebhookChannel("", time.Second)
+	err := channel.Send(context.Background(), &Message{ID: uuid.New(), WebhookURL: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+}
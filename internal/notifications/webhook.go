@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// Webhook request headers.
+const (
+	// SignatureHeader carries "sha256=<hex HMAC>" of the timestamp, a dot and the request body
+	SignatureHeader = "X-Notification-Signature"
+	// TimestampHeader carries the Unix time the request was signed at, so receivers can reject replays
+	TimestampHeader = "X-Notification-Timestamp"
+)
+
+// WebhookChannel delivers messages as JSON POST requests to the user's webhook URL.
+type WebhookChannel struct {
+	client *http.Client
+	secret string // Optional; requests are signed when set
+}
+
+// NewWebhookChannel creates a webhook channel. Requests are signed with secret when it is not empty.
+func NewWebhookChannel(secret string, timeout time.Duration) *WebhookChannel {
+	return &WebhookChannel{
+		client: &http.Client{Timeout: timeout},
+		secret: secret,
+	}
+}
+
+// Name identifies the channel in user preferences.
+func (c *WebhookChannel) Name() domain.NotificationChannel {
+	return domain.ChannelWebhook
+}
+
+// webhookPayload is the JSON body posted to webhooks.
+type webhookPayload struct {
+	ID        string                  `json:"id"`
+	Type      domain.NotificationType `json:"type"`
+	Title     string                  `json:"title"`
+	Body      string                  `json:"body"`
+	Data      map[string]interface{}  `json:"data"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// Send posts the message to the user's webhook URL. Any non-2xx response is a failed delivery.
+func (c *WebhookChannel) Send(ctx context.Context, msg *Message) error {
+	if msg.WebhookURL == "" {
+		return fmt.Errorf("recipient has no webhook URL")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ID:        msg.ID.String(),
+		Type:      msg.Type,
+		Title:     msg.Title,
+		Body:      msg.Body,
+		Data:      msg.Data,
+		CreatedAt: msg.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, "sha256="+Sign(c.secret, timestamp, body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of timestamp, a dot and body under secret.
+// Receivers recompute it to verify a request came from this service.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,513 @@
+// Package qrcode encodes short byte strings, such as payment payloads, as QR codes (ISO/IEC
+// 18004) and renders them as PNG images.
+//
+// Codes use byte mode and error correction level M, which restores up to 15% of a damaged code,
+// in the smallest of versions 1 to 10 the data fits; that holds up to 213 bytes.
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// MaxDataLength is the longest data a code holds.
+const MaxDataLength = 213
+
+// quietZone is the light border around a code, in modules, that scanners need to find it.
+const quietZone = 4
+
+// versionInfo describes the error correction blocks of a version at level M.
+type versionInfo struct {
+	rawCodewords int   // Data and error correction codewords
+	eccPerBlock  int   // Error correction codewords of each block
+	blocks       int   // Blocks the codewords are split into
+	alignment    []int // Row and column centers of alignment patterns
+}
+
+// versions lists versions 1 to 10 at level M.
+var versions = []versionInfo{
+	{26, 10, 1, nil},
+	{44, 16, 1, []int{6, 18}},
+	{70, 26, 1, []int{6, 22}},
+	{100, 18, 2, []int{6, 26}},
+	{134, 24, 2, []int{6, 30}},
+	{172, 16, 4, []int{6, 34}},
+	{196, 18, 4, []int{6, 22, 38}},
+	{242, 22, 4, []int{6, 24, 42}},
+	{292, 22, 5, []int{6, 26, 46}},
+	{346, 26, 5, []int{6, 28, 50}},
+}
+
+// Code is an encoded QR code: a square of dark and light modules.
+type Code struct {
+	version  int
+	size     int
+	modules  [][]bool // Dark modules, by row and column
+	function [][]bool // Modules of finder, timing, alignment, format and version patterns
+}
+
+// Encode encodes data as a QR code in the smallest version it fits.
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for v := 1; v <= len(versions); v++ {
+		if len(data) <= dataCapacity(v) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("data too long for a QR code: %d bytes, at most %d", len(data), MaxDataLength)
+	}
+
+	c := newCode(version)
+	c.drawFunctionPatterns()
+	c.drawCodewords(addErrorCorrection(version, dataCodewords(version, data)))
+
+	// Keep the mask that leaves the fewest patterns confusing scanners
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // Masks are their own inverse
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+
+	return c, nil
+}
+
+// Version returns the version of the code, from 1 to 10.
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Size returns the number of modules on each side of the code, without the quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark reports whether the module at column x and row y is dark. Modules outside the code are light.
+func (c *Code) Dark(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.size && y < c.size && c.modules[y][x]
+}
+
+// PNG renders the code as a black on white PNG image with moduleSize pixels per module, including
+// the quiet zone around it.
+func (c *Code) PNG(moduleSize int) ([]byte, error) {
+	if moduleSize < 1 {
+		return nil, fmt.Errorf("module size must be positive, got %d", moduleSize)
+	}
+
+	side := (c.size + 2*quietZone) * moduleSize
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			if c.Dark(x/moduleSize-quietZone, y/moduleSize-quietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// dataCapacity returns the bytes a version holds after the mode indicator and character count.
+func dataCapacity(version int) int {
+	return (dataCodewordCount(version)*8 - 4 - countBits(version)) / 8
+}
+
+// dataCodewordCount returns the data codewords of a version, leaving out error correction.
+func dataCodewordCount(version int) int {
+	info := versions[version-1]
+	return info.rawCodewords - info.eccPerBlock*info.blocks
+}
+
+// countBits returns the length of the byte mode character count of a version.
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// dataCodewords encodes data in byte mode and pads it to the data codewords of the version.
+func dataCodewords(version int, data []byte) []byte {
+	var bits bitBuffer
+	bits.append(0x4, 4) // Byte mode
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := dataCodewordCount(version) * 8
+	bits.append(0, min(4, capacity-len(bits))) // Terminator
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	return bits.bytes()
+}
+
+// addErrorCorrection splits data codewords into the blocks of the version, adds Reed-Solomon error
+// correction to each and interleaves them.
+func addErrorCorrection(version int, data []byte) []byte {
+	info := versions[version-1]
+	divisor := reedSolomonDivisor(info.eccPerBlock)
+
+	// Later blocks are one data codeword longer when codewords do not split evenly
+	shortBlocks := info.blocks - info.rawCodewords%info.blocks
+	shortLen := info.rawCodewords/info.blocks - info.eccPerBlock
+
+	blocks := make([][]byte, info.blocks)
+	ecc := make([][]byte, info.blocks)
+	for i, k := 0, 0; i < info.blocks; i++ {
+		n := shortLen
+		if i >= shortBlocks {
+			n++
+		}
+		blocks[i] = data[k : k+n]
+		ecc[i] = reedSolomonRemainder(blocks[i], divisor)
+		k += n
+	}
+
+	result := make([]byte, 0, info.rawCodewords)
+	for i := 0; i <= shortLen; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < info.eccPerBlock; i++ {
+		for _, block := range ecc {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor returns the generator polynomial of the given degree, highest coefficient
+// first and without the leading 1.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer collects bits, most significant first.
+type bitBuffer []bool
+
+// append appends the low n bits of value.
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+// bytes packs the bits into bytes; the length must be a multiple of 8.
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return result
+}
+
+// newCode creates an empty code of a version.
+func newCode(version int) *Code {
+	size := version*4 + 17
+	c := &Code{version: version, size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range c.modules {
+		c.modules[y] = make([]bool, size)
+		c.function[y] = make([]bool, size)
+	}
+	return c
+}
+
+// setFunction sets a module that belongs to a function pattern.
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns and the version
+// information, and reserves the format information modules.
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.size-4, 3)
+	c.drawFinder(3, c.size-4)
+
+	// Alignment patterns go everywhere on the grid of centers except over the finder patterns
+	centers := versions[c.version-1].alignment
+	last := len(centers) - 1
+	for i, x := range centers {
+		for j, y := range centers {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	c.drawFormatBits(0)
+	c.drawVersionBits()
+}
+
+// drawFinder draws a finder pattern and its separator around the center x, y.
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.size || yy >= c.size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, distance != 2 && distance != 4)
+		}
+	}
+}
+
+// formatBits returns the 15 format information bits of level M with mask.
+func formatBits(mask int) int {
+	data := mask // Level M is 00
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// drawFormatBits draws both copies of the format information and the dark module.
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(i))
+	}
+	c.setFunction(8, c.size-8, true)
+}
+
+// versionBits returns the 18 version information bits of a version.
+func versionBits(version int) int {
+	remainder := version
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	return version<<12 | remainder
+}
+
+// drawVersionBits draws both copies of the version information of versions 7 and up.
+func (c *Code) drawVersionBits() {
+	if c.version < 7 {
+		return
+	}
+
+	bits := versionBits(c.version)
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 == 1
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the modules not used by function patterns, in two-module
+// wide columns zigzagging up and down from the bottom right corner.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vertical := 0; vertical < c.size; vertical++ {
+			y := vertical
+			if upward {
+				y = c.size - 1 - vertical
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = (codewords[i/8]>>(7-i%8))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by a mask pattern.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.function[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			c.modules[y][x] = c.modules[y][x] != invert
+		}
+	}
+}
+
+// penalty scores how hard the code is to scan: long runs of one color, 2x2 blocks of one color,
+// patterns resembling finder patterns and an unbalanced share of dark modules.
+func (c *Code) penalty() int {
+	penalty := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	for _, vertical := range []bool{false, true} {
+		at := func(line, i int) bool {
+			if vertical {
+				return c.modules[i][line]
+			}
+			return c.modules[line][i]
+		}
+		for line := 0; line < c.size; line++ {
+			run := 1
+			for i := 1; i <= c.size; i++ {
+				if i < c.size && at(line, i) == at(line, i-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += 3 + run - 5
+				}
+				run = 1
+			}
+			for i := 0; i+11 <= c.size; i++ {
+				for _, pattern := range finderLike {
+					matches := true
+					for k, dark := range pattern {
+						if at(line, i+k) != dark {
+							matches = false
+							break
+						}
+					}
+					if matches {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.size && y+1 < c.size {
+				color := c.modules[y][x]
+				if c.modules[y][x+1] == color && c.modules[y+1][x] == color && c.modules[y+1][x+1] == color {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := c.size * c.size
+	penalty += abs(dark*20-total*10) / total * 10
+
+	return penalty
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,207 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// The HELLO WORLD example of version 1-M from the Thonky QR code tutorial
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("reedSolomonRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	if got := formatBits(0); got != 0b101010000010010 {
+		t.Errorf("formatBits(0) = %015b, want 101010000010010", got)
+	}
+	if got := formatBits(5); got != 0b100000011001110 {
+		t.Errorf("formatBits(5) = %015b, want 100000011001110", got)
+	}
+	if got := versionBits(7); got != 0x07C94 {
+		t.Errorf("versionBits(7) = %#x, want 0x7c94", got)
+	}
+	if got := versionBits(10); got != 0x0A4D3 {
+		t.Errorf("versionBits(10) = %#x, want 0xa4d3", got)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"hello",
+		"bankingsim://pay?account=XS448215280719321798&amount=12.50&currency=USD",
+		strings.Repeat("x", 100),
+		strings.Repeat("y", MaxDataLength),
+	} {
+		code, err := Encode([]byte(data))
+		if err != nil {
+			t.Fatalf("Encode(%d bytes) error = %v", len(data), err)
+		}
+		if code.Size() != code.Version()*4+17 {
+			t.Errorf("size %d does not match version %d", code.Size(), code.Version())
+		}
+		if got := decode(t, code); got != data {
+			t.Errorf("decoded %q, want %q", got, data)
+		}
+	}
+
+	if _, err := Encode(make([]byte, MaxDataLength+1)); err == nil {
+		t.Error("Encode() of too much data succeeded")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	code, err := Encode([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	data, err := code.PNG(4)
+	if err != nil {
+		t.Fatalf("PNG() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+
+	side := (code.Size() + 2*quietZone) * 4
+	if img.Bounds().Dx() != side || img.Bounds().Dy() != side {
+		t.Errorf("image is %v, want %dx%d", img.Bounds(), side, side)
+	}
+	// The quiet zone is white and the corner of the top left finder pattern black
+	if r, _, _, _ := img.At(0, 0).RGBA(); r == 0 {
+		t.Error("quiet zone is dark")
+	}
+	if r, _, _, _ := img.At(quietZone*4, quietZone*4).RGBA(); r != 0 {
+		t.Error("finder pattern corner is light")
+	}
+}
+
+// decode reads the data back from a code, checking the format information and the error
+// correction of every block.
+func decode(t *testing.T, code *Code) string {
+	t.Helper()
+
+	// Both copies of the format information must agree
+	first, second := 0, 0
+	for i := 0; i < 15; i++ {
+		var x, y int
+		switch {
+		case i <= 5:
+			x, y = 8, i
+		case i == 6:
+			x, y = 8, 7
+		case i == 7:
+			x, y = 8, 8
+		case i == 8:
+			x, y = 7, 8
+		default:
+			x, y = 14-i, 8
+		}
+		if code.Dark(x, y) {
+			first |= 1 << i
+		}
+		if i < 8 {
+			x, y = code.Size()-1-i, 8
+		} else {
+			x, y = 8, code.Size()-15+i
+		}
+		if code.Dark(x, y) {
+			second |= 1 << i
+		}
+	}
+	if first != second {
+		t.Fatalf("format information copies differ: %015b and %015b", first, second)
+	}
+	mask := first ^ 0x5412
+	if mask>>13 != 0 || formatBits(mask>>10) != first {
+		t.Fatalf("format information %015b is not level M", first)
+	}
+
+	unmasked := *code
+	unmasked.modules = make([][]bool, code.size)
+	for y := range code.modules {
+		unmasked.modules[y] = append([]bool(nil), code.modules[y]...)
+	}
+	unmasked.applyMask(mask >> 10 & 7)
+
+	// Read the codewords in placement order
+	info := versions[code.version-1]
+	var bits bitBuffer
+	for right := code.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < code.size; vertical++ {
+			y := vertical
+			if (right+1)&2 == 0 {
+				y = code.size - 1 - vertical
+			}
+			for x := right; x > right-2; x-- {
+				if !code.function[y][x] {
+					bits = append(bits, unmasked.modules[y][x])
+				}
+			}
+		}
+	}
+	codewords := bitBuffer(bits[:info.rawCodewords*8]).bytes()
+
+	// De-interleave the blocks and check their error correction
+	shortBlocks := info.blocks - info.rawCodewords%info.blocks
+	shortLen := info.rawCodewords/info.blocks - info.eccPerBlock
+	blocks := make([][]byte, info.blocks)
+	k := 0
+	for i := 0; i <= shortLen; i++ {
+		for b := range blocks {
+			if i < shortLen || b >= shortBlocks {
+				blocks[b] = append(blocks[b], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for b, block := range blocks {
+		var ecc []byte
+		for i := 0; i < info.eccPerBlock; i++ {
+			ecc = append(ecc, codewords[k+b+i*info.blocks])
+		}
+		if want := reedSolomonRemainder(block, reedSolomonDivisor(info.eccPerBlock)); !bytes.Equal(ecc, want) {
+			t.Fatalf("block %d error correction = %v, want %v", b, ecc, want)
+		}
+		data = append(data, block...)
+	}
+
+	// Byte mode header, then the data
+	var stream bitBuffer
+	for _, b := range data {
+		stream.append(int(b), 8)
+	}
+	read := func(n int) int {
+		value := 0
+		for i := 0; i < n; i++ {
+			value <<= 1
+			if stream[0] {
+				value |= 1
+			}
+			stream = stream[1:]
+		}
+		return value
+	}
+	if mode := read(4); mode != 0x4 {
+		t.Fatalf("mode = %#x, want byte mode", mode)
+	}
+	length := read(countBits(code.version))
+	decoded := make([]byte, length)
+	for i := range decoded {
+		decoded[i] = byte(read(8))
+	}
+	return string(decoded)
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ProjectorLeaderLockKey is the Postgres advisory lock key held by the instance running event projections.
+const ProjectorLeaderLockKey int64 = 0x70726f6a // "proj"
+
+// EventBackupLockKey is the Postgres advisory lock key held by the instance exporting events.
+const EventBackupLockKey int64 = 0x6261636b // "back"
+
+// AdvisoryLock is a session-level Postgres advisory lock.
+// The lock is tied to a dedicated pooled connection, so it is released automatically
+// by Postgres if the holding instance dies or loses its connection.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn // Connection holding the lock, nil when not held
+}
+
+// NewAdvisoryLock creates an advisory lock for the given key.
+func NewAdvisoryLock(pool *pgxpool.Pool, key int64) *AdvisoryLock {
+	return &AdvisoryLock{
+		pool: pool,
+		key:  key,
+	}
+}
+
+// TryAcquire attempts to take the lock without blocking and reports whether it is held.
+// If the lock is already held, it verifies the holding connection is still alive.
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		// The session is gone, and the lock with it
+		l.dropConn()
+	}
+
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire connection for advisory lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&acquired); err != nil {
+		conn.Release()
+		return false, fmt.Errorf("failed to try advisory lock: %w", err)
+	}
+
+	if !acquired {
+		conn.Release()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Release releases the lock if it is held.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	var released bool
+	err := l.conn.QueryRow(ctx, `SELECT pg_advisory_unlock($1)`, l.key).Scan(&released)
+	if err != nil {
+		// Closing the session guarantees the lock is dropped
+		l.dropConn()
+		return fmt.Errorf("failed to release advisory lock: %w", err)
+	}
+
+	l.conn.Release()
+	l.conn = nil
+	return nil
+}
+
+// dropConn closes the holding connection instead of returning it to the pool,
+// so that a session which may still hold the lock is never reused.
+func (l *AdvisoryLock) dropConn() {
+	_ = l.conn.Conn().Close(context.Background())
+	l.conn.Release()
+	l.conn = nil
+}
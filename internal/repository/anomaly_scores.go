@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// anomalyScoresRepo implements the AnomalyScoresRepo interface.
+type anomalyScoresRepo struct {
+	db DBTX
+}
+
+// NewAnomalyScoresRepo creates a new anomaly scores repository.
+func NewAnomalyScoresRepo(db DBTX) AnomalyScoresRepo {
+	return &anomalyScoresRepo{db: db}
+}
+
+// Get retrieves a user's anomaly score, a zero score as of the zero time if they have none.
+func (r *anomalyScoresRepo) Get(ctx context.Context, userID uuid.UUID) (*domain.AnomalyScore, error) {
+	query := `SELECT user_id, score, updated_at FROM anomaly_scores WHERE user_id = $1`
+
+	var score domain.AnomalyScore
+	err := r.db.QueryRow(ctx, query, userID).Scan(&score.UserID, &score.Score, &score.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return &domain.AnomalyScore{UserID: userID}, nil
+		}
+		return nil, fmt.Errorf("failed to get anomaly score: %w", err)
+	}
+
+	return &score, nil
+}
+
+// Add decays a user's score to the given time, halving it every halfLife, and adds points to it,
+// returning the new score. The score is updated in a single statement, so concurrent signals are
+// all counted.
+func (r *anomalyScoresRepo) Add(ctx context.Context, userID uuid.UUID, points float64, halfLife time.Duration, at time.Time) (float64, error) {
+	query := `
+		INSERT INTO anomaly_scores (user_id, score, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET score = CASE
+				WHEN $4 > 0 THEN anomaly_scores.score * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM ($3 - anomaly_scores.updated_at)), 0) / $4)
+				ELSE anomaly_scores.score
+			END + $2,
+			updated_at = GREATEST(anomaly_scores.updated_at, $3)
+		RETURNING score`
+
+	var score float64
+	if err := r.db.QueryRow(ctx, query, userID, points, at, halfLife.Seconds()).Scan(&score); err != nil {
+		return 0, fmt.Errorf("failed to add to anomaly score: %w", err)
+	}
+
+	return score, nil
+}
+
+// Reset clears a user's score.
+func (r *anomalyScoresRepo) Reset(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM anomaly_scores WHERE user_id = $1`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to reset anomaly score: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// approvalsRepo implements the ApprovalsRepo interface.
+type approvalsRepo struct {
+	db DBTX
+}
+
+// NewApprovalsRepo creates a new approvals repository.
+func NewApprovalsRepo(db DBTX) ApprovalsRepo {
+	return &approvalsRepo{db: db}
+}
+
+// approvalColumns are the columns scanApproval reads, in order.
+const approvalColumns = `id, action, payload, requested_by, status, reviewed_by, review_note,
+	result, error, created_at, reviewed_at, completed_at`
+
+// Create records a new pending approval.
+func (r *approvalsRepo) Create(ctx context.Context, approval *domain.Approval) error {
+	query := `
+		INSERT INTO approvals (id, action, payload, requested_by, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(ctx, query,
+		approval.ID,
+		approval.Action,
+		string(approval.Payload),
+		approval.RequestedBy,
+		approval.Status,
+		approval.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create approval: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an approval by ID.
+func (r *approvalsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM approvals WHERE id = $1`
+
+	approval, err := scanApproval(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("approval not found")
+		}
+		return nil, fmt.Errorf("failed to get approval by ID: %w", err)
+	}
+
+	return approval, nil
+}
+
+// List retrieves approvals, newest first.
+func (r *approvalsRepo) List(ctx context.Context, filter *domain.ApprovalFilter) ([]*domain.Approval, error) {
+	query := `SELECT ` + approvalColumns + ` FROM approvals`
+
+	var args []interface{}
+	var conditions []string
+	argIndex := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+
+	if filter.Action != nil {
+		conditions = append(conditions, fmt.Sprintf("action = $%d", argIndex))
+		args = append(args, *filter.Action)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id"
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+	defer rows.Close()
+
+	var approvals []*domain.Approval
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
+		}
+		approvals = append(approvals, approval)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// Review moves a pending approval to approved or rejected by reviewerID at the given time.
+func (r *approvalsRepo) Review(ctx context.Context, id, reviewerID uuid.UUID, status domain.ApprovalStatus, note string, at time.Time) error {
+	query := `
+		UPDATE approvals
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = $5
+		WHERE id = $1 AND status = 'pending'`
+
+	result, err := r.db.Exec(ctx, query, id, status, reviewerID, note, at)
+	if err != nil {
+		return fmt.Errorf("failed to review approval: %w", err)
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Tell an approval that does not exist apart from one that was already reviewed
+	var exists bool
+	query = `SELECT EXISTS (SELECT 1 FROM approvals WHERE id = $1)`
+	if err := r.db.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to review approval: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("approval already reviewed")
+	}
+	return fmt.Errorf("approval not found")
+}
+
+// Complete records the outcome of an approved action.
+func (r *approvalsRepo) Complete(ctx context.Context, id uuid.UUID, status domain.ApprovalStatus, result json.RawMessage, errMsg string, at time.Time) error {
+	query := `
+		UPDATE approvals
+		SET status = $2, result = $3::jsonb, error = $4, completed_at = $5
+		WHERE id = $1`
+
+	var resultJSON *string
+	if len(result) > 0 {
+		encoded := string(result)
+		resultJSON = &encoded
+	}
+
+	if _, err := r.db.Exec(ctx, query, id, status, resultJSON, errMsg, at); err != nil {
+		return fmt.Errorf("failed to complete approval: %w", err)
+	}
+
+	return nil
+}
+
+// scanApproval scans an approval row.
+func scanApproval(row pgx.Row) (*domain.Approval, error) {
+	var approval domain.Approval
+	var payload, result []byte
+	err := row.Scan(
+		&approval.ID,
+		&approval.Action,
+		&payload,
+		&approval.RequestedBy,
+		&approval.Status,
+		&approval.ReviewedBy,
+		&approval.ReviewNote,
+		&result,
+		&approval.Error,
+		&approval.CreatedAt,
+		&approval.ReviewedAt,
+		&approval.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	approval.Payload = payload
+	if len(result) > 0 {
+		approval.Result = result
+	}
+	return &approval, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// ArchiveMaintenanceLockKey is the Postgres advisory lock key serializing partition maintenance
+// and archival across instances.
+const ArchiveMaintenanceLockKey int64 = 0x61726368 // "arch"
+
+// archiveSchema is the schema archived events and transactions are moved into.
+const archiveSchema = "archive"
+
+// archiveRepo implements the ArchiveRepo interface on PostgreSQL partitions.
+type archiveRepo struct {
+	db DBTX
+}
+
+// NewArchiveRepo creates a new archive repository.
+func NewArchiveRepo(db DBTX) ArchiveRepo {
+	return &archiveRepo{db: db}
+}
+
+// partitionedTables are the live tables partitioned by month, with their default partitions.
+var partitionedTables = []struct{ table, defaultPartition string }{
+	{"events", "events_default"},
+	{"transactions", "transactions_default"},
+}
+
+// EnsurePartitions creates the missing monthly partitions of the live events and transactions
+// tables for the months from through to, moving any rows the default partitions caught for them.
+func (r *archiveRepo) EnsurePartitions(ctx context.Context, from, to time.Time) ([]string, error) {
+	var created []string
+	err := r.inLockedTx(ctx, func(tx pgx.Tx) error {
+		for _, partitioned := range partitionedTables {
+			for month := domain.MonthStart(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+				name, err := createPartition(ctx, tx, partitioned.table, month, partitioned.defaultPartition)
+				if err != nil {
+					return err
+				}
+				if name != "" {
+					created = append(created, name)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ArchiveEvents moves the live event partitions of months ending by cutoff into the archive schema.
+func (r *archiveRepo) ArchiveEvents(ctx context.Context, cutoff time.Time) ([]string, error) {
+	return r.archivePartitions(ctx, "events", cutoff, nil)
+}
+
+// ArchiveTransactions moves the live transaction partitions of months ending by cutoff into the
+// archive schema. Pending transactions may still change and unresolved disputes may still refund
+// theirs, so a month holding either stays live until they settle.
+func (r *archiveRepo) ArchiveTransactions(ctx context.Context, cutoff time.Time) ([]string, error) {
+	return r.archivePartitions(ctx, "transactions", cutoff, func(ctx context.Context, tx pgx.Tx, partition string) (bool, error) {
+		var unsettled bool
+		err := tx.QueryRow(ctx, fmt.Sprintf(`
+			SELECT EXISTS (
+				SELECT 1 FROM %s t
+				WHERE t.status = 'pending'
+				   OR EXISTS (
+				       SELECT 1 FROM disputes d
+				       WHERE d.transaction_id = t.id AND d.status IN ('open', 'under_review')
+				   )
+			)`, partition)).Scan(&unsettled)
+		return !unsettled, err
+	})
+}
+
+// archivePartitions moves the live partitions of table for months ending by cutoff into the
+// archive schema and returns their names. Partitions that ready rejects stay live.
+func (r *archiveRepo) archivePartitions(ctx context.Context, table string, cutoff time.Time, ready func(ctx context.Context, tx pgx.Tx, partition string) (bool, error)) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		JOIN pg_namespace n ON n.oid = p.relnamespace
+		WHERE n.nspname = 'public' AND p.relname = $1
+		ORDER BY c.relname`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s partitions: %w", table, err)
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s partitions: %w", table, err)
+	}
+
+	var archived []string
+	for _, name := range names {
+		month, ok := domain.ParsePartitionMonth(table, name)
+		if !ok || month.AddDate(0, 1, 0).After(cutoff) {
+			continue
+		}
+
+		// Each partition moves in its own transaction, so a failure keeps the ones already moved
+		moved := false
+		err := r.inLockedTx(ctx, func(tx pgx.Tx) error {
+			live := pgx.Identifier{"public", name}.Sanitize()
+			if ready != nil {
+				ok, err := ready(ctx, tx, live)
+				if err != nil {
+					return fmt.Errorf("failed to check %s partition %s: %w", table, name, err)
+				}
+				if !ok {
+					return nil
+				}
+			}
+
+			statements := []string{
+				fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", pgx.Identifier{"public", table}.Sanitize(), live),
+				fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", live, archiveSchema),
+				fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s %s",
+					pgx.Identifier{archiveSchema, table}.Sanitize(), pgx.Identifier{archiveSchema, name}.Sanitize(), partitionBounds(month)),
+			}
+			for _, statement := range statements {
+				if _, err := tx.Exec(ctx, statement); err != nil {
+					return fmt.Errorf("failed to archive %s partition %s: %w", table, name, err)
+				}
+			}
+			moved = true
+			return nil
+		})
+		if err != nil {
+			return archived, err
+		}
+		if moved {
+			archived = append(archived, name)
+		}
+	}
+
+	return archived, nil
+}
+
+// inLockedTx runs fn in a transaction holding the archive maintenance lock.
+func (r *archiveRepo) inLockedTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Rollback error is typically safe to ignore
+	}()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, ArchiveMaintenanceLockKey); err != nil {
+		return fmt.Errorf("failed to acquire archive maintenance lock: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// createPartition creates the live partition of parent for month unless it exists, and returns its
+// name when created. Rows the default partition holds for the month are moved into it first, since
+// a partition cannot be attached while the default partition holds rows in its range, and the
+// partition copies the parent's check constraints, which it must have to be attached.
+func createPartition(ctx context.Context, tx pgx.Tx, parent string, month time.Time, defaultPartition string) (string, error) {
+	name := domain.PartitionName(parent, month)
+	partition := pgx.Identifier{"public", name}.Sanitize()
+	parentTable := pgx.Identifier{"public", parent}.Sanitize()
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, "public."+name).Scan(&exists); err != nil {
+		return "", fmt.Errorf("failed to check partition %s: %w", name, err)
+	}
+	if exists {
+		return "", nil
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS)", partition, parentTable),
+		fmt.Sprintf("WITH moved AS (DELETE FROM %s WHERE created_at >= '%s' AND created_at < '%s' RETURNING *) INSERT INTO %s SELECT * FROM moved",
+			pgx.Identifier{"public", defaultPartition}.Sanitize(),
+			month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339), partition),
+		fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s %s", parentTable, partition, partitionBounds(month)),
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return "", fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+	}
+
+	return name, nil
+}
+
+// partitionBounds returns the bound clause of the partition for month.
+func partitionBounds(month time.Time) string {
+	return fmt.Sprintf("FOR VALUES FROM ('%s') TO ('%s')", month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339))
+}
@@ -8,17 +8,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // auditRepo implements the AuditRepo interface.
 type auditRepo struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
 // NewAuditRepo creates a new audit repository.
-func NewAuditRepo(db *pgxpool.Pool) AuditRepo {
+func NewAuditRepo(db DBTX) AuditRepo {
 	return &auditRepo{db: db}
 }
 
@@ -202,6 +201,18 @@ func (r *auditRepo) Count(ctx context.Context, filter *domain.AuditLogFilter) (i
 	return count, nil
 }
 
+// DeleteOlderThan deletes audit logs of an entity type created before the given time.
+func (r *auditRepo) DeleteOlderThan(ctx context.Context, entityType string, before time.Time) (int64, error) {
+	query := `DELETE FROM audit_logs WHERE entity_type = $1 AND created_at < $2`
+
+	tag, err := r.db.Exec(ctx, query, entityType, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete audit logs: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
 // executeAuditQuery executes an audit query and returns results.
 func (r *auditRepo) executeAuditQuery(ctx context.Context, query string, args ...interface{}) ([]*domain.AuditLog, error) {
 	rows, err := r.db.Query(ctx, query, args...)
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// balanceAlertsRepo implements the BalanceAlertsRepo interface.
+type balanceAlertsRepo struct {
+	db DBTX
+}
+
+// NewBalanceAlertsRepo creates a new balance alerts repository.
+func NewBalanceAlertsRepo(db DBTX) BalanceAlertsRepo {
+	return &balanceAlertsRepo{db: db}
+}
+
+// Upsert creates or replaces a user's alert threshold for a currency. Changing the threshold re-arms the alert.
+func (r *balanceAlertsRepo) Upsert(ctx context.Context, alert *domain.BalanceAlert) error {
+	query := `
+		INSERT INTO balance_alerts (user_id, currency, threshold, triggered, created_at, updated_at)
+		VALUES ($1, $2, $3, FALSE, $4, $5)
+		ON CONFLICT (user_id, currency) DO UPDATE
+		SET threshold = EXCLUDED.threshold, triggered = FALSE, updated_at = EXCLUDED.updated_at
+		RETURNING triggered, last_triggered_at, created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		alert.UserID,
+		alert.Currency,
+		alert.Threshold,
+		alert.CreatedAt,
+		alert.UpdatedAt,
+	).Scan(&alert.Triggered, &alert.LastTriggeredAt, &alert.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save balance alert: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves a user's alert threshold for a currency.
+func (r *balanceAlertsRepo) Get(ctx context.Context, userID uuid.UUID, currency string) (*domain.BalanceAlert, error) {
+	query := `
+		SELECT user_id, currency, threshold, triggered, last_triggered_at, created_at, updated_at
+		FROM balance_alerts
+		WHERE user_id = $1 AND currency = $2`
+
+	alert, err := scanBalanceAlert(r.db.QueryRow(ctx, query, userID, currency))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("balance alert not found")
+		}
+		return nil, fmt.Errorf("failed to get balance alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// ListByUser retrieves a user's alert thresholds ordered by currency.
+func (r *balanceAlertsRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.BalanceAlert, error) {
+	query := `
+		SELECT user_id, currency, threshold, triggered, last_triggered_at, created_at, updated_at
+		FROM balance_alerts
+		WHERE user_id = $1
+		ORDER BY currency ASC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list balance alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*domain.BalanceAlert
+	for rows.Next() {
+		alert, err := scanBalanceAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan balance alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate balance alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// Delete deletes a user's alert threshold for a currency.
+func (r *balanceAlertsRepo) Delete(ctx context.Context, userID uuid.UUID, currency string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM balance_alerts WHERE user_id = $1 AND currency = $2`, userID, currency)
+	if err != nil {
+		return fmt.Errorf("failed to delete balance alert: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("balance alert not found")
+	}
+
+	return nil
+}
+
+// SetTriggered moves an alert into the triggered or re-armed state and reports whether it changed.
+// Only one of several concurrent balance movements wins the transition, so an alert fires once.
+func (r *balanceAlertsRepo) SetTriggered(ctx context.Context, userID uuid.UUID, currency string, triggered bool, at time.Time) (bool, error) {
+	query := `
+		UPDATE balance_alerts
+		SET triggered = $3,
+		    last_triggered_at = CASE WHEN $3 THEN $4 ELSE last_triggered_at END
+		WHERE user_id = $1 AND currency = $2 AND triggered <> $3`
+
+	result, err := r.db.Exec(ctx, query, userID, currency, triggered, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to update balance alert state: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// scanBalanceAlert scans a single balance alert row.
+func scanBalanceAlert(row pgx.Row) (*domain.BalanceAlert, error) {
+	var alert domain.BalanceAlert
+	err := row.Scan(
+		&alert.UserID,
+		&alert.Currency,
+		&alert.Threshold,
+		&alert.Triggered,
+		&alert.LastTriggeredAt,
+		&alert.CreatedAt,
+		&alert.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
@@ -7,17 +7,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // balancesRepo implements the BalancesRepo interface.
 type balancesRepo struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
 // NewBalancesRepo creates a new balances repository.
-func NewBalancesRepo(db *pgxpool.Pool) BalancesRepo {
+func NewBalancesRepo(db DBTX) BalancesRepo {
 	return &balancesRepo{db: db}
 }
 
@@ -67,6 +66,25 @@ func (r *balancesRepo) Upsert(ctx context.Context, balance *domain.Balance) erro
 	return nil
 }
 
+// CreateMany creates balances in bulk with a single COPY.
+func (r *balancesRepo) CreateMany(ctx context.Context, balances []*domain.Balance) error {
+	now := time.Now()
+	for _, balance := range balances {
+		balance.LastUpdatedAt = now
+	}
+
+	columns := []string{"user_id", "amount", "currency", "last_updated_at"}
+	_, err := r.db.CopyFrom(ctx, pgx.Identifier{"balances"}, columns, pgx.CopyFromSlice(len(balances), func(i int) ([]any, error) {
+		balance := balances[i]
+		return []any{balance.UserID, balance.Amount, balance.Currency, balance.LastUpdatedAt}, nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to create balances: %w", err)
+	}
+
+	return nil
+}
+
 // AddAmountTx adds amount to a user's balance within a transaction.
 // This method should be used within database transactions for atomicity.
 func (r *balancesRepo) AddAmountTx(ctx context.Context, tx interface{}, userID uuid.UUID, delta float64) error {
@@ -76,6 +94,17 @@ func (r *balancesRepo) AddAmountTx(ctx context.Context, tx interface{}, userID u
 		return fmt.Errorf("invalid transaction type")
 	}
 
+	return addAmount(ctx, pgxTx, userID, delta)
+}
+
+// AddAmount adds amount to a user's balance. On the repositories of a unit of work, db is the
+// transaction, so the change commits with it.
+func (r *balancesRepo) AddAmount(ctx context.Context, userID uuid.UUID, delta float64) error {
+	return addAmount(ctx, r.db, userID, delta)
+}
+
+// addAmount adds amount to a user's balance on db, creating a USD balance if the user has none.
+func addAmount(ctx context.Context, db DBTX, userID uuid.UUID, delta float64) error {
 	// Use SELECT FOR UPDATE to prevent concurrent modifications
 	query := `
 		UPDATE balances 
@@ -85,7 +114,7 @@ func (r *balancesRepo) AddAmountTx(ctx context.Context, tx interface{}, userID u
 
 	now := time.Now()
 	var newAmount float64
-	err := pgxTx.QueryRow(ctx, query, userID, delta, now).Scan(&newAmount)
+	err := db.QueryRow(ctx, query, userID, delta, now).Scan(&newAmount)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -94,7 +123,7 @@ func (r *balancesRepo) AddAmountTx(ctx context.Context, tx interface{}, userID u
 				INSERT INTO balances (user_id, amount, currency, last_updated_at)
 				VALUES ($1, $2, $3, $4)`
 
-			_, insertErr := pgxTx.Exec(ctx, insertQuery, userID, delta, "USD", now)
+			_, insertErr := db.Exec(ctx, insertQuery, userID, delta, "USD", now)
 			if insertErr != nil {
 				return fmt.Errorf("failed to create balance: %w", insertErr)
 			}
@@ -207,10 +236,8 @@ func (r *balancesRepo) GetAtTime(ctx context.Context, userID uuid.UUID, timestam
 			$1::uuid as user_id,
 			COALESCE(SUM(
 				CASE
-					WHEN t.type = 'credit' AND t.to_user_id = $1 THEN t.amount
-					WHEN t.type = 'debit' AND t.from_user_id = $1 THEN -t.amount
-					WHEN t.type = 'transfer' AND t.to_user_id = $1 THEN t.amount
-					WHEN t.type = 'transfer' AND t.from_user_id = $1 THEN -t.amount
+					WHEN t.to_user_id = $1 THEN t.amount
+					WHEN t.from_user_id = $1 THEN -t.amount
 					ELSE 0
 				END
 			), 0) as amount,
@@ -235,3 +262,45 @@ func (r *balancesRepo) GetAtTime(ctx context.Context, userID uuid.UUID, timestam
 
 	return &balance, nil
 }
+
+// GetTimeline retrieves the balance at each of the given times in one query, summing the user's
+// successful transactions up to each of them.
+func (r *balancesRepo) GetTimeline(ctx context.Context, userID uuid.UUID, times []time.Time) ([]domain.BalancePoint, error) {
+	query := `
+		SELECT p.at,
+			COALESCE(SUM(
+				CASE
+					WHEN t.to_user_id = $1 THEN t.amount
+					WHEN t.from_user_id = $1 THEN -t.amount
+					ELSE 0
+				END
+			), 0) AS amount
+		FROM unnest($2::timestamptz[]) WITH ORDINALITY AS p(at, n)
+		LEFT JOIN transactions t
+			ON (t.from_user_id = $1 OR t.to_user_id = $1)
+			AND t.status = 'success'
+			AND t.created_at <= p.at
+		GROUP BY p.at, p.n
+		ORDER BY p.n`
+
+	rows, err := r.db.Query(ctx, query, userID, times)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance timeline: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]domain.BalancePoint, 0, len(times))
+	for rows.Next() {
+		var point domain.BalancePoint
+		if err := rows.Scan(&point.Timestamp, &point.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan balance point: %w", err)
+		}
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate balance timeline: %w", err)
+	}
+
+	return points, nil
+}
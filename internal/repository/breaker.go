@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// Circuit breaker names, as reported by the circuit breaker metrics endpoint.
+const (
+	PostgresBreakerName = "postgres"
+	RedisBreakerName    = "redis"
+)
+
+// DBTX is the subset of *pgxpool.Pool the repositories use to run queries.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// BreakerDB guards a connection pool with a circuit breaker. While the breaker is open every
+// call fails fast with a *utils.CircuitBreakerError instead of waiting on an unavailable database.
+type BreakerDB struct {
+	pool    *pgxpool.Pool
+	breaker *utils.CircuitBreaker
+}
+
+// NewBreakerDB wraps pool with breaker.
+func NewBreakerDB(pool *pgxpool.Pool, breaker *utils.CircuitBreaker) *BreakerDB {
+	return &BreakerDB{pool: pool, breaker: breaker}
+}
+
+// Exec executes a statement if the breaker allows it.
+func (db *BreakerDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if err := db.breaker.Allow(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	tag, err := db.pool.Exec(ctx, sql, args...)
+	db.breaker.Record(isDBFailure(err))
+	return tag, err
+}
+
+// Query runs a query if the breaker allows it. Only the error returned when the query is sent
+// is recorded; errors while reading rows surface to the caller as usual.
+func (db *BreakerDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if err := db.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	rows, err := db.pool.Query(ctx, sql, args...)
+	db.breaker.Record(isDBFailure(err))
+	return rows, err
+}
+
+// QueryRow runs a single-row query if the breaker allows it. The outcome is recorded when the row is scanned.
+func (db *BreakerDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if err := db.breaker.Allow(); err != nil {
+		return errRow{err: err}
+	}
+	return breakerRow{row: db.pool.QueryRow(ctx, sql, args...), breaker: db.breaker}
+}
+
+// Begin starts a database transaction if the breaker allows it.
+// Statements run on the returned transaction are not guarded individually.
+func (db *BreakerDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if err := db.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	tx, err := db.pool.Begin(ctx)
+	db.breaker.Record(isDBFailure(err))
+	return tx, err
+}
+
+// CopyFrom bulk copies rows into a table if the breaker allows it.
+func (db *BreakerDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if err := db.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	n, err := db.pool.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	db.breaker.Record(isDBFailure(err))
+	return n, err
+}
+
+// breakerRow records the outcome of a QueryRow call once it is scanned.
+type breakerRow struct {
+	row     pgx.Row
+	breaker *utils.CircuitBreaker
+}
+
+// Scan scans the row and records whether the query failed.
+func (r breakerRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	r.breaker.Record(isDBFailure(err))
+	return err
+}
+
+// errRow is a pgx.Row that always returns err, used when the breaker rejects a QueryRow call.
+type errRow struct {
+	err error
+}
+
+// Scan returns the rejection error.
+func (r errRow) Scan(...any) error {
+	return r.err
+}
+
+// isDBFailure reports whether err indicates the database itself is unavailable. Errors the server
+// answered with (missing rows, constraint violations, ...) and cancelled requests are not failures;
+// connection and resource errors (SQLSTATE classes 08, 53 and 57) are.
+func isDBFailure(err error) bool {
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.Code, "08") ||
+			strings.HasPrefix(pgErr.Code, "53") ||
+			strings.HasPrefix(pgErr.Code, "57")
+	}
+	return true
+}
+
+// redisBreakerHook is a redis.Hook that guards every command and pipeline with a circuit breaker.
+type redisBreakerHook struct {
+	breaker *utils.CircuitBreaker
+}
+
+// DialHook passes dialing through unchanged.
+func (redisBreakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook rejects a command while the breaker is open and records its outcome otherwise.
+func (h redisBreakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		err := next(ctx, cmd)
+		h.breaker.Record(isRedisFailure(err))
+		return err
+	}
+}
+
+// ProcessPipelineHook rejects a pipeline while the breaker is open and records its outcome otherwise.
+func (h redisBreakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		err := next(ctx, cmds)
+		h.breaker.Record(isRedisFailure(err))
+		return err
+	}
+}
+
+// isRedisFailure reports whether err indicates Redis itself is unavailable. Missing keys,
+// error replies from the server and cancelled requests are not failures.
+func isRedisFailure(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var replyErr redis.Error
+	return !errors.As(err, &replyErr)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestIsDBFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"no rows", pgx.ErrNoRows, false},
+		{"wrapped no rows", fmt.Errorf("get user: %w", pgx.ErrNoRows), false},
+		{"cancelled", context.Canceled, false},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, true},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, true},
+		{"network error", errors.New("dial tcp: connection refused"), true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+	}
+
+	for _, tt := range tests {
+		if got := isDBFailure(tt.err); got != tt.want {
+			t.Errorf("%s: isDBFailure(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestIsRedisFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"missing key", redis.Nil, false},
+		{"cancelled", context.Canceled, false},
+		{"network error", errors.New("dial tcp: connection refused"), true},
+	}
+
+	for _, tt := range tests {
+		if got := isRedisFailure(tt.err); got != tt.want {
+			t.Errorf("%s: isRedisFailure(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// budgetsRepo implements the BudgetsRepo interface.
+type budgetsRepo struct {
+	db DBTX
+}
+
+// NewBudgetsRepo creates a new budgets repository.
+func NewBudgetsRepo(db DBTX) BudgetsRepo {
+	return &budgetsRepo{db: db}
+}
+
+// Upsert creates or replaces a user's monthly budget for a category. Changing the limit re-arms
+// the over-budget notification for the current month.
+func (r *budgetsRepo) Upsert(ctx context.Context, budget *domain.Budget) error {
+	query := `
+		INSERT INTO budgets (user_id, category, limit_amount, alerted_month, created_at, updated_at)
+		VALUES ($1, $2, $3, '', $4, $5)
+		ON CONFLICT (user_id, category) DO UPDATE
+		SET limit_amount = EXCLUDED.limit_amount, alerted_month = '', updated_at = EXCLUDED.updated_at
+		RETURNING alerted_month, created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		budget.UserID,
+		budget.Category,
+		budget.Limit,
+		budget.CreatedAt,
+		budget.UpdatedAt,
+	).Scan(&budget.AlertedMonth, &budget.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves a user's budget for a category.
+func (r *budgetsRepo) Get(ctx context.Context, userID uuid.UUID, category string) (*domain.Budget, error) {
+	query := `
+		SELECT user_id, category, limit_amount, alerted_month, created_at, updated_at
+		FROM budgets
+		WHERE user_id = $1 AND category = $2`
+
+	budget, err := scanBudget(r.db.QueryRow(ctx, query, userID, category))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("budget not found")
+		}
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+// ListByUser retrieves a user's budgets ordered by category.
+func (r *budgetsRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error) {
+	query := `
+		SELECT user_id, category, limit_amount, alerted_month, created_at, updated_at
+		FROM budgets
+		WHERE user_id = $1
+		ORDER BY category ASC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var budgets []*domain.Budget
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+// Delete deletes a user's budget for a category.
+func (r *budgetsRepo) Delete(ctx context.Context, userID uuid.UUID, category string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM budgets WHERE user_id = $1 AND category = $2`, userID, category)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("budget not found")
+	}
+
+	return nil
+}
+
+// MarkAlerted records that the user was told they went over a budget during a month, and reports
+// whether it wasn't recorded already. Only one of several concurrent debits wins, so the user is
+// told once a month.
+func (r *budgetsRepo) MarkAlerted(ctx context.Context, userID uuid.UUID, category, month string) (bool, error) {
+	query := `
+		UPDATE budgets
+		SET alerted_month = $3
+		WHERE user_id = $1 AND category = $2 AND alerted_month <> $3`
+
+	result, err := r.db.Exec(ctx, query, userID, category, month)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark budget alerted: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// scanBudget scans a single budget row.
+func scanBudget(row pgx.Row) (*domain.Budget, error) {
+	var budget domain.Budget
+	err := row.Scan(
+		&budget.UserID,
+		&budget.Category,
+		&budget.Limit,
+		&budget.AlertedMonth,
+		&budget.CreatedAt,
+		&budget.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
@@ -8,4 +8,29 @@ package repository
 var _ UsersRepo = (*usersRepo)(nil)
 var _ BalancesRepo = (*balancesRepo)(nil)
 var _ TransactionsRepo = (*transactionsRepo)(nil)
+var _ TransactionAggregatesRepo = (*transactionAggregatesRepo)(nil)
 var _ AuditRepo = (*auditRepo)(nil)
+var _ ImpersonationSessionsRepo = (*impersonationSessionsRepo)(nil)
+var _ KnownDevicesRepo = (*knownDevicesRepo)(nil)
+var _ LoginSessionsRepo = (*loginSessionsRepo)(nil)
+var _ SecurityEventsRepo = (*securityEventsRepo)(nil)
+var _ AnomalyScoresRepo = (*anomalyScoresRepo)(nil)
+var _ ApprovalsRepo = (*approvalsRepo)(nil)
+var _ PoliciesRepo = (*policiesRepo)(nil)
+var _ MonthlySummariesRepo = (*monthlySummariesRepo)(nil)
+var _ DisputesRepo = (*disputesRepo)(nil)
+var _ PaymentRequestsRepo = (*paymentRequestsRepo)(nil)
+var _ TransferTemplatesRepo = (*transferTemplatesRepo)(nil)
+var _ ContactsRepo = (*contactsRepo)(nil)
+var _ NotificationsRepo = (*notificationsRepo)(nil)
+var _ BalanceAlertsRepo = (*balanceAlertsRepo)(nil)
+var _ BudgetsRepo = (*budgetsRepo)(nil)
+var _ DormantAccountsRepo = (*dormantAccountsRepo)(nil)
+var _ SearchRepo = (*searchRepo)(nil)
+var _ TreasuryRepo = (*treasuryRepo)(nil)
+var _ CurrenciesRepo = (*currenciesRepo)(nil)
+var _ NettingRepo = (*nettingRepo)(nil)
+var _ ArchiveRepo = (*archiveRepo)(nil)
+var _ IndexAdvisorRepo = (*indexAdvisorRepo)(nil)
+var _ UnitOfWork = (*unitOfWork)(nil)
+var _ Tx = (*unitOfWorkTx)(nil)
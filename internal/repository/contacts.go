@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// contactsRepo implements the ContactsRepo interface.
+type contactsRepo struct {
+	db DBTX
+}
+
+// NewContactsRepo creates a new contacts repository.
+func NewContactsRepo(db DBTX) ContactsRepo {
+	return &contactsRepo{db: db}
+}
+
+// Create saves a contact. A user saves each counterparty at most once.
+func (r *contactsRepo) Create(ctx context.Context, contact *domain.Contact) error {
+	query := `
+		INSERT INTO contacts (id, user_id, contact_user_id, nickname, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.Exec(ctx, query,
+		contact.ID,
+		contact.UserID,
+		contact.ContactUserID,
+		contact.Nickname,
+		contact.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("contact already exists")
+		}
+		return fmt.Errorf("failed to create contact: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a contact by ID.
+func (r *contactsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Contact, error) {
+	query := `
+		SELECT id, user_id, contact_user_id, nickname, created_at
+		FROM contacts
+		WHERE id = $1`
+
+	contact, err := scanContact(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("contact not found")
+		}
+		return nil, fmt.Errorf("failed to get contact by ID: %w", err)
+	}
+
+	return contact, nil
+}
+
+// ListByUser retrieves all of a user's contacts, oldest first.
+func (r *contactsRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Contact, error) {
+	query := `
+		SELECT id, user_id, contact_user_id, nickname, created_at
+		FROM contacts
+		WHERE user_id = $1
+		ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []*domain.Contact
+	for rows.Next() {
+		contact, err := scanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// Delete deletes a contact.
+func (r *contactsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM contacts WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("contact not found")
+	}
+
+	return nil
+}
+
+// scanContact scans a single contact row.
+func scanContact(row pgx.Row) (*domain.Contact, error) {
+	var contact domain.Contact
+	err := row.Scan(
+		&contact.ID,
+		&contact.UserID,
+		&contact.ContactUserID,
+		&contact.Nickname,
+		&contact.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contact, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// currenciesRepo implements the CurrenciesRepo interface.
+type currenciesRepo struct {
+	db DBTX
+}
+
+// NewCurrenciesRepo creates a new currencies repository.
+func NewCurrenciesRepo(db DBTX) CurrenciesRepo {
+	return &currenciesRepo{db: db}
+}
+
+const currencyColumns = `code, symbol, decimal_places, enabled, created_at, updated_at`
+
+// List retrieves every currency of the registry ordered by code.
+func (r *currenciesRepo) List(ctx context.Context) ([]*domain.CurrencyInfo, error) {
+	query := `SELECT ` + currencyColumns + ` FROM currencies ORDER BY code ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list currencies: %w", err)
+	}
+	defer rows.Close()
+
+	var currencies []*domain.CurrencyInfo
+	for rows.Next() {
+		currency, err := scanCurrency(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan currency: %w", err)
+		}
+		currencies = append(currencies, currency)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate currencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+// Get retrieves a currency by code.
+func (r *currenciesRepo) Get(ctx context.Context, code string) (*domain.CurrencyInfo, error) {
+	query := `SELECT ` + currencyColumns + ` FROM currencies WHERE code = $1`
+
+	currency, err := scanCurrency(r.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("currency not found")
+		}
+		return nil, fmt.Errorf("failed to get currency: %w", err)
+	}
+
+	return currency, nil
+}
+
+// Create adds a currency to the registry and opens its empty treasury in one database
+// transaction, so every currency can be credited from the moment it exists.
+func (r *currenciesRepo) Create(ctx context.Context, currency *domain.CurrencyInfo) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Rollback error is typically safe to ignore
+	}()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO currencies (code, symbol, decimal_places, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		currency.Code,
+		currency.Symbol,
+		currency.DecimalPlaces,
+		currency.Enabled,
+		currency.CreatedAt,
+		currency.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("currency already exists")
+		}
+		return fmt.Errorf("failed to create currency: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO treasury_accounts (currency, created_at, updated_at)
+		VALUES ($1, $2, $2)`,
+		currency.Code, currency.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create treasury account: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces the symbol, decimal places and enabled flag of a currency.
+func (r *currenciesRepo) Update(ctx context.Context, currency *domain.CurrencyInfo) error {
+	query := `
+		UPDATE currencies
+		SET symbol = $2, decimal_places = $3, enabled = $4, updated_at = $5
+		WHERE code = $1
+		RETURNING created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		currency.Code,
+		currency.Symbol,
+		currency.DecimalPlaces,
+		currency.Enabled,
+		currency.UpdatedAt,
+	).Scan(&currency.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("currency not found")
+		}
+		return fmt.Errorf("failed to update currency: %w", err)
+	}
+
+	return nil
+}
+
+// scanCurrency scans a single currency row.
+func scanCurrency(row pgx.Row) (*domain.CurrencyInfo, error) {
+	var currency domain.CurrencyInfo
+	err := row.Scan(
+		&currency.Code,
+		&currency.Symbol,
+		&currency.DecimalPlaces,
+		&currency.Enabled,
+		&currency.CreatedAt,
+		&currency.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currency, nil
+}
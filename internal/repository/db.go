@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
 
 // DB holds the database connection pool.
@@ -15,8 +16,18 @@ type DB struct {
 	Pool *pgxpool.Pool
 }
 
+// PoolConfig holds the connection pool settings. Zero values keep the pgxpool defaults.
+type PoolConfig struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	Metrics           *utils.MetricsCollector // Optional; publishes pool statistics and acquire waits when set
+}
+
 // Connect establishes a connection to PostgreSQL using the provided database URL.
-func Connect(ctx context.Context, dbURL string) (*DB, error) {
+func Connect(ctx context.Context, dbURL string, poolConfig PoolConfig) (*DB, error) {
 	if dbURL == "" {
 		return nil, fmt.Errorf("database URL is required")
 	}
@@ -28,10 +39,24 @@ func Connect(ctx context.Context, dbURL string) (*DB, error) {
 	}
 
 	// Set connection pool settings
-	config.MaxConns = 30
-	config.MinConns = 5
-	config.MaxConnLifetime = time.Hour
-	config.MaxConnIdleTime = time.Minute * 30
+	if poolConfig.MaxConns > 0 {
+		config.MaxConns = poolConfig.MaxConns
+	}
+	if poolConfig.MinConns > 0 {
+		config.MinConns = poolConfig.MinConns
+	}
+	if poolConfig.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = poolConfig.MaxConnLifetime
+	}
+	if poolConfig.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = poolConfig.MaxConnIdleTime
+	}
+	if poolConfig.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = poolConfig.HealthCheckPeriod
+	}
+
+	// Record a span for every query, and how long each waited for a connection
+	config.ConnConfig.Tracer = queryTracer{metrics: poolConfig.Metrics}
 
 	// Create connection pool
 	pool, err := pgxpool.NewWithConfig(ctx, config)
@@ -48,9 +73,28 @@ func Connect(ctx context.Context, dbURL string) (*DB, error) {
 	slog.Info("db connected",
 		slog.String("max_conns", fmt.Sprintf("%d", config.MaxConns)),
 		slog.String("min_conns", fmt.Sprintf("%d", config.MinConns)),
+		slog.Duration("max_conn_lifetime", config.MaxConnLifetime),
+		slog.Duration("health_check_period", config.HealthCheckPeriod),
 	)
 
-	return &DB{Pool: pool}, nil
+	db := &DB{Pool: pool}
+	if poolConfig.Metrics != nil {
+		poolConfig.Metrics.SetDBPoolStats(db.PoolStats)
+	}
+	return db, nil
+}
+
+// PoolStats returns a snapshot of the connection pool.
+func (db *DB) PoolStats() utils.DBPoolStats {
+	stat := db.Pool.Stat()
+	return utils.DBPoolStats{
+		MaxConns:             stat.MaxConns(),
+		IdleConns:            stat.IdleConns(),
+		AcquiredConns:        stat.AcquiredConns(),
+		ConstructingConns:    stat.ConstructingConns(),
+		EmptyAcquireCount:    stat.EmptyAcquireCount(),
+		CanceledAcquireCount: stat.CanceledAcquireCount(),
+	}
 }
 
 // Close closes the database connection pool.
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// disputesRepo implements the DisputesRepo interface.
+type disputesRepo struct {
+	db DBTX
+}
+
+// NewDisputesRepo creates a new disputes repository.
+func NewDisputesRepo(db DBTX) DisputesRepo {
+	return &disputesRepo{db: db}
+}
+
+// Create creates a new dispute.
+func (r *disputesRepo) Create(ctx context.Context, dispute *domain.Dispute) error {
+	query := `
+		INSERT INTO disputes (id, transaction_id, user_id, reason, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, query,
+		dispute.ID,
+		dispute.TransactionID,
+		dispute.UserID,
+		dispute.Reason,
+		dispute.Status,
+		dispute.CreatedAt,
+		dispute.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dispute: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a dispute by ID.
+func (r *disputesRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Dispute, error) {
+	query := `
+		SELECT id, transaction_id, user_id, reason, status, COALESCE(resolution, ''),
+		       resolved_by, refund_transaction_id, created_at, updated_at, resolved_at
+		FROM disputes
+		WHERE id = $1`
+
+	dispute, err := scanDispute(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("dispute not found")
+		}
+		return nil, fmt.Errorf("failed to get dispute by ID: %w", err)
+	}
+
+	return dispute, nil
+}
+
+// GetOpenByTransactionID retrieves the unresolved dispute for a transaction, if any.
+func (r *disputesRepo) GetOpenByTransactionID(ctx context.Context, transactionID uuid.UUID) (*domain.Dispute, error) {
+	query := `
+		SELECT id, transaction_id, user_id, reason, status, COALESCE(resolution, ''),
+		       resolved_by, refund_transaction_id, created_at, updated_at, resolved_at
+		FROM disputes
+		WHERE transaction_id = $1 AND status IN ('open', 'under_review')`
+
+	dispute, err := scanDispute(r.db.QueryRow(ctx, query, transactionID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("dispute not found")
+		}
+		return nil, fmt.Errorf("failed to get dispute by transaction ID: %w", err)
+	}
+
+	return dispute, nil
+}
+
+// List retrieves disputes with filtering.
+func (r *disputesRepo) List(ctx context.Context, filter *domain.DisputeFilter) ([]*domain.Dispute, error) {
+	baseQuery := `
+		SELECT id, transaction_id, user_id, reason, status, COALESCE(resolution, ''),
+		       resolved_by, refund_transaction_id, created_at, updated_at, resolved_at
+		FROM disputes
+		WHERE 1=1`
+
+	args := []interface{}{}
+	conditions := []string{}
+	argIndex := 1
+
+	// Apply filters
+	if filter != nil {
+		if filter.UserID != nil {
+			conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
+			args = append(args, *filter.UserID)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.Status != nil {
+			conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+			args = append(args, *filter.Status)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+	}
+
+	// Build final query
+	query := baseQuery
+	for _, condition := range conditions {
+		query += " AND " + condition
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	// Apply pagination
+	if filter != nil {
+		if filter.Limit > 0 {
+			query += fmt.Sprintf(" LIMIT $%d", argIndex)
+			args = append(args, filter.Limit)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.Offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", argIndex)
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disputes: %w", err)
+	}
+	defer rows.Close()
+
+	var disputes []*domain.Dispute
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dispute: %w", err)
+		}
+		disputes = append(disputes, dispute)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate disputes: %w", err)
+	}
+
+	return disputes, nil
+}
+
+// Update updates the status and resolution fields of a dispute.
+func (r *disputesRepo) Update(ctx context.Context, dispute *domain.Dispute) error {
+	query := `
+		UPDATE disputes
+		SET status = $2, resolution = NULLIF($3, ''), resolved_by = $4,
+		    refund_transaction_id = $5, updated_at = $6, resolved_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query,
+		dispute.ID,
+		dispute.Status,
+		dispute.Resolution,
+		dispute.ResolvedBy,
+		dispute.RefundTransactionID,
+		dispute.UpdatedAt,
+		dispute.ResolvedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("dispute not found")
+	}
+
+	return nil
+}
+
+// AddComment adds a comment to a dispute.
+func (r *disputesRepo) AddComment(ctx context.Context, comment *domain.DisputeComment) error {
+	query := `
+		INSERT INTO dispute_comments (id, dispute_id, author_id, is_admin, body, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(ctx, query,
+		comment.ID,
+		comment.DisputeID,
+		comment.AuthorID,
+		comment.IsAdmin,
+		comment.Body,
+		comment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add dispute comment: %w", err)
+	}
+
+	return nil
+}
+
+// ListComments retrieves comments for a dispute in chronological order.
+func (r *disputesRepo) ListComments(ctx context.Context, disputeID uuid.UUID) ([]*domain.DisputeComment, error) {
+	query := `
+		SELECT id, dispute_id, author_id, is_admin, body, created_at
+		FROM dispute_comments
+		WHERE dispute_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(ctx, query, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispute comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*domain.DisputeComment
+	for rows.Next() {
+		var comment domain.DisputeComment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.DisputeID,
+			&comment.AuthorID,
+			&comment.IsAdmin,
+			&comment.Body,
+			&comment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dispute comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dispute comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// scanDispute scans a single dispute row.
+func scanDispute(row pgx.Row) (*domain.Dispute, error) {
+	var dispute domain.Dispute
+	err := row.Scan(
+		&dispute.ID,
+		&dispute.TransactionID,
+		&dispute.UserID,
+		&dispute.Reason,
+		&dispute.Status,
+		&dispute.Resolution,
+		&dispute.ResolvedBy,
+		&dispute.RefundTransactionID,
+		&dispute.CreatedAt,
+		&dispute.UpdatedAt,
+		&dispute.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// dormantAccountsRepo implements the DormantAccountsRepo interface.
+type dormantAccountsRepo struct {
+	db DBTX
+}
+
+// NewDormantAccountsRepo creates a new dormant accounts repository.
+func NewDormantAccountsRepo(db DBTX) DormantAccountsRepo {
+	return &dormantAccountsRepo{db: db}
+}
+
+// dormantAccountsQuery selects every active user with the time of their last transaction, live or
+// archived, and the time they have been inactive since.
+const dormantAccountsQuery = `
+	WITH activity AS (
+		SELECT u.id, u.username, u.email, u.account_number, u.created_at,
+		       COALESCE(b.currency, '') AS currency, COALESCE(b.amount, 0) AS balance,
+		       GREATEST(
+		           (SELECT MAX(t.created_at) FROM all_transactions t WHERE t.from_user_id = u.id),
+		           (SELECT MAX(t.created_at) FROM all_transactions t WHERE t.to_user_id = u.id)
+		       ) AS last_activity_at
+		FROM users u
+		LEFT JOIN balances b ON b.user_id = u.id
+		WHERE u.is_active = TRUE
+	)
+	SELECT *, COALESCE(last_activity_at, created_at) AS inactive_since
+	FROM activity`
+
+// List retrieves the active users without transactions since cutoff, longest inactive first.
+func (r *dormantAccountsRepo) List(ctx context.Context, cutoff time.Time, limit, offset int) ([]*domain.DormantAccount, error) {
+	query := `
+		SELECT id, username, email, account_number, created_at, currency, balance, last_activity_at, inactive_since
+		FROM (` + dormantAccountsQuery + `) dormant
+		WHERE inactive_since < $1
+		ORDER BY inactive_since ASC, id ASC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, cutoff, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dormant accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*domain.DormantAccount
+	for rows.Next() {
+		var account domain.DormantAccount
+		err := rows.Scan(
+			&account.UserID,
+			&account.Username,
+			&account.Email,
+			&account.AccountNumber,
+			&account.CreatedAt,
+			&account.Currency,
+			&account.Balance,
+			&account.LastActivityAt,
+			&account.InactiveSince,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dormant account: %w", err)
+		}
+		accounts = append(accounts, &account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dormant accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// Count counts the active users without transactions since cutoff.
+func (r *dormantAccountsRepo) Count(ctx context.Context, cutoff time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM (` + dormantAccountsQuery + `) dormant WHERE inactive_since < $1`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, cutoff).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count dormant accounts: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkFlagged records that a user was announced dormant for the inactivity that started at
+// inactiveSince, and reports whether it wasn't recorded already. A user who transacts and then
+// goes dormant again is inactive since a later time, so they are announced again.
+func (r *dormantAccountsRepo) MarkFlagged(ctx context.Context, userID uuid.UUID, inactiveSince, at time.Time) (bool, error) {
+	query := `
+		INSERT INTO dormant_accounts (user_id, inactive_since, flagged_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET inactive_since = EXCLUDED.inactive_since, flagged_at = EXCLUDED.flagged_at
+		WHERE dormant_accounts.inactive_since <> EXCLUDED.inactive_since`
+
+	result, err := r.db.Exec(ctx, query, userID, inactiveSince, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to flag dormant account: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
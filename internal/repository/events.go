@@ -7,17 +7,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // EventRepository handles event sourcing operations
 type EventRepository struct {
-	pool *pgxpool.Pool
+	pool DBTX
 }
 
 // NewEventRepository creates a new event repository
-func NewEventRepository(pool *pgxpool.Pool) *EventRepository {
+func NewEventRepository(pool DBTX) *EventRepository {
 	return &EventRepository{pool: pool}
 }
 
@@ -33,8 +32,8 @@ func (r *EventRepository) AppendEvent(ctx context.Context, event *domain.Event)
 	event.CreatedAt = time.Now()
 
 	query := `
-		INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, created_at
 	`
 
@@ -50,6 +49,7 @@ func (r *EventRepository) AppendEvent(ctx context.Context, event *domain.Event)
 		event.EventMetadata,
 		event.CreatedAt,
 		event.Version,
+		event.EventVersion,
 	).Scan(&eventID, &createdAt)
 
 	if err != nil {
@@ -62,16 +62,17 @@ func (r *EventRepository) AppendEvent(ctx context.Context, event *domain.Event)
 	return event, nil
 }
 
-// GetEventsByAggregate retrieves all events for a specific aggregate
-func (r *EventRepository) GetEventsByAggregate(ctx context.Context, aggregateType domain.AggregateType, aggregateID uuid.UUID) ([]*domain.Event, error) {
-	query := `
-		SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version
-		FROM events
+// eventsByAggregateQuery selects an aggregate's events in version order, archived ones included.
+const eventsByAggregateQuery = `
+		SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version
+		FROM all_events
 		WHERE aggregate_type = $1 AND aggregate_id = $2
 		ORDER BY version ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, string(aggregateType), aggregateID)
+// GetEventsByAggregate retrieves all events for a specific aggregate, archived ones included
+func (r *EventRepository) GetEventsByAggregate(ctx context.Context, aggregateType domain.AggregateType, aggregateID uuid.UUID) ([]*domain.Event, error) {
+	rows, err := r.pool.Query(ctx, eventsByAggregateQuery, string(aggregateType), aggregateID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events by aggregate: %w", err)
 	}
@@ -91,6 +92,7 @@ func (r *EventRepository) GetEventsByAggregate(ctx context.Context, aggregateTyp
 			&eventMetadata,
 			&event.CreatedAt,
 			&event.Version,
+			&event.EventVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
@@ -110,11 +112,11 @@ func (r *EventRepository) GetEventsByAggregate(ctx context.Context, aggregateTyp
 	return events, nil
 }
 
-// GetEventsByType retrieves events by event type
+// GetEventsByType retrieves events by event type, archived ones included
 func (r *EventRepository) GetEventsByType(ctx context.Context, eventType domain.EventType, limit int, offset int) ([]*domain.Event, error) {
 	query := `
-		SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version
-		FROM events
+		SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version
+		FROM all_events
 		WHERE event_type = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
@@ -140,6 +142,7 @@ func (r *EventRepository) GetEventsByType(ctx context.Context, eventType domain.
 			&eventMetadata,
 			&event.CreatedAt,
 			&event.Version,
+			&event.EventVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
@@ -159,11 +162,11 @@ func (r *EventRepository) GetEventsByType(ctx context.Context, eventType domain.
 	return events, nil
 }
 
-// GetEventsSince retrieves events since a specific time
+// GetEventsSince retrieves events since a specific time, archived ones included
 func (r *EventRepository) GetEventsSince(ctx context.Context, since time.Time, limit int) ([]*domain.Event, error) {
 	query := `
-		SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version
-		FROM events
+		SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version
+		FROM all_events
 		WHERE created_at > $1
 		ORDER BY created_at ASC
 		LIMIT $2
@@ -189,6 +192,7 @@ func (r *EventRepository) GetEventsSince(ctx context.Context, since time.Time, l
 			&eventMetadata,
 			&event.CreatedAt,
 			&event.Version,
+			&event.EventVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
@@ -213,11 +217,11 @@ func (r *EventRepository) GetAggregateVersion(ctx context.Context, aggregateType
 	return r.getCurrentVersion(ctx, string(aggregateType), aggregateID)
 }
 
-// getCurrentVersion gets the current version for an aggregate (internal method)
+// getCurrentVersion gets the current version for an aggregate (internal method); archived events count, so versions keep increasing
 func (r *EventRepository) getCurrentVersion(ctx context.Context, aggregateType string, aggregateID uuid.UUID) (int, error) {
 	query := `
 		SELECT COALESCE(MAX(version), 0)
-		FROM events
+		FROM all_events
 		WHERE aggregate_type = $1 AND aggregate_id = $2
 	`
 
@@ -280,8 +284,8 @@ func (r *EventRepository) AppendEvents(ctx context.Context, events []*domain.Eve
 		event.CreatedAt = time.Now()
 
 		query := `
-			INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		`
 
 		_, err = tx.Exec(ctx, query,
@@ -293,6 +297,7 @@ func (r *EventRepository) AppendEvents(ctx context.Context, events []*domain.Eve
 			event.EventMetadata,
 			event.CreatedAt,
 			event.Version,
+			event.EventVersion,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to append event %s: %w", event.EventType, err)
@@ -307,11 +312,59 @@ func (r *EventRepository) AppendEvents(ctx context.Context, events []*domain.Eve
 	return nil
 }
 
+// ImportEvents stores events as given in a single transaction, keeping their IDs, versions and
+// timestamps, and skips the ones already stored
+func (r *EventRepository) ImportEvents(ctx context.Context, events []*domain.Event) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Rollback error is typically safe to ignore
+	}()
+
+	query := `
+		INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT DO NOTHING
+	`
+
+	imported := 0
+	for _, event := range events {
+		result, err := tx.Exec(ctx, query,
+			event.ID,
+			event.AggregateType,
+			event.AggregateID,
+			event.EventType,
+			event.EventData,
+			event.EventMetadata,
+			event.CreatedAt,
+			event.Version,
+			event.EventVersion,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import event %s: %w", event.ID, err)
+		}
+		imported += int(result.RowsAffected())
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return imported, nil
+}
+
 // getCurrentVersionTx gets the current version for an aggregate within a transaction
 func (r *EventRepository) getCurrentVersionTx(ctx context.Context, tx pgx.Tx, aggregateType string, aggregateID uuid.UUID) (int, error) {
 	query := `
 		SELECT COALESCE(MAX(version), 0)
-		FROM events
+		FROM all_events
 		WHERE aggregate_type = $1 AND aggregate_id = $2
 	`
 
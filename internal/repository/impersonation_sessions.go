@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// impersonationSessionsRepo implements the ImpersonationSessionsRepo interface.
+type impersonationSessionsRepo struct {
+	db DBTX
+}
+
+// NewImpersonationSessionsRepo creates a new impersonation sessions repository.
+func NewImpersonationSessionsRepo(db DBTX) ImpersonationSessionsRepo {
+	return &impersonationSessionsRepo{db: db}
+}
+
+// Create records a new impersonation session.
+func (r *impersonationSessionsRepo) Create(ctx context.Context, session *domain.ImpersonationSession) error {
+	query := `
+		INSERT INTO impersonation_sessions (id, admin_id, target_user_id, reason, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(ctx, query,
+		session.ID,
+		session.AdminID,
+		session.TargetUserID,
+		session.Reason,
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+
+	return nil
+}
+
+// ListActive retrieves impersonation sessions that have not expired at the given time.
+func (r *impersonationSessionsRepo) ListActive(ctx context.Context, now time.Time) ([]*domain.ImpersonationSession, error) {
+	query := `
+		SELECT id, admin_id, target_user_id, reason, created_at, expires_at
+		FROM impersonation_sessions
+		WHERE expires_at > $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active impersonation sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*domain.ImpersonationSession
+	for rows.Next() {
+		var session domain.ImpersonationSession
+		err := rows.Scan(
+			&session.ID,
+			&session.AdminID,
+			&session.TargetUserID,
+			&session.Reason,
+			&session.CreatedAt,
+			&session.ExpiresAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan impersonation session: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate impersonation sessions: %w", err)
+	}
+
+	return sessions, nil
+}
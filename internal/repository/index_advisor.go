@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// hotQuery is a query run often enough that its plan decides how the package scales. Its
+// arguments are representative values; the planner estimates them from statistics alone.
+type hotQuery struct {
+	name        string
+	description string
+	query       string
+	args        []interface{}
+}
+
+// hotQueries are explained by the index advisor. They share their SQL with the repositories
+// running them, so the advice follows the queries as they change.
+var hotQueries = []hotQuery{
+	{
+		name:        "transaction_history",
+		description: "A page of a user's transaction history, newest first",
+		query:       listForUserQuery + " ORDER BY created_at DESC LIMIT $2",
+		args:        []interface{}{uuid.Nil, 50},
+	},
+	{
+		name:        "due_schedules",
+		description: "Claiming the scheduled transactions due for execution",
+		query:       claimDueQuery,
+		args:        []interface{}{"index-advisor", 60.0, 100, time.Now()},
+	},
+	{
+		name:        "events_by_aggregate",
+		description: "An aggregate's events in version order, archived ones included",
+		query:       eventsByAggregateQuery,
+		args:        []interface{}{string(domain.AggregateUser), uuid.Nil},
+	},
+}
+
+// indexAdvisorRepo implements the IndexAdvisorRepo interface on PostgreSQL's planner.
+type indexAdvisorRepo struct {
+	db DBTX
+}
+
+// NewIndexAdvisorRepo creates a new index advisor repository.
+func NewIndexAdvisorRepo(db DBTX) IndexAdvisorRepo {
+	return &indexAdvisorRepo{db: db}
+}
+
+// ExplainHotQueries returns the plans of the hottest queries. EXPLAIN without ANALYZE only plans
+// a query, so explaining the claim of due schedules leases nothing.
+func (r *indexAdvisorRepo) ExplainHotQueries(ctx context.Context) ([]*domain.QueryPlan, error) {
+	plans := make([]*domain.QueryPlan, 0, len(hotQueries))
+	for _, hot := range hotQueries {
+		var plan []byte
+		if err := r.db.QueryRow(ctx, "EXPLAIN (VERBOSE, FORMAT JSON) "+hot.query, hot.args...).Scan(&plan); err != nil {
+			return nil, fmt.Errorf("failed to explain %s: %w", hot.name, err)
+		}
+		plans = append(plans, &domain.QueryPlan{
+			Name:        hot.name,
+			Description: hot.description,
+			Plan:        plan,
+		})
+	}
+
+	return plans, nil
+}
+
+// TableStatistics returns the statistics of every table of the public and archive schemas.
+func (r *indexAdvisorRepo) TableStatistics(ctx context.Context) (map[string]domain.TableStatistics, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT n.nspname || '.' || c.relname, c.reltuples, COALESCE(pn.nspname || '.' || p.relname, '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_inherits i ON i.inhrelid = c.oid
+		LEFT JOIN pg_class p ON p.oid = i.inhparent AND p.relkind = 'p'
+		LEFT JOIN pg_namespace pn ON pn.oid = p.relnamespace
+		WHERE c.relkind = 'r' AND n.nspname IN ('public', $1)`, archiveSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table statistics: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]domain.TableStatistics)
+	for rows.Next() {
+		var name string
+		var stats domain.TableStatistics
+		if err := rows.Scan(&name, &stats.Rows, &stats.Parent); err != nil {
+			return nil, fmt.Errorf("failed to scan table statistics: %w", err)
+		}
+		tables[name] = stats
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate table statistics: %w", err)
+	}
+
+	return tables, nil
+}
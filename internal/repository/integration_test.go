@@ -0,0 +1,315 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/repository/repotest"
+)
+
+// newPool connects to Docker, skipping the test when it is unavailable.
+func newPool(t *testing.T) *dockertest.Pool {
+	t.Helper()
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Skipf("docker unavailable: %v", err)
+	}
+	if err := pool.Client.Ping(); err != nil {
+		t.Skipf("docker unavailable: %v", err)
+	}
+	pool.MaxWait = 2 * time.Minute
+
+	return pool
+}
+
+// runContainer starts a throwaway container that is removed when the test ends.
+func runContainer(t *testing.T, pool *dockertest.Pool, repository, tag string, env []string) *dockertest.Resource {
+	t.Helper()
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{Repository: repository, Tag: tag, Env: env}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		t.Fatalf("start %s: %v", repository, err)
+	}
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("remove %s: %v", repository, err)
+		}
+	})
+
+	// Reap the container even if the test binary is killed
+	if err := resource.Expire(600); err != nil {
+		t.Fatalf("expire %s: %v", repository, err)
+	}
+
+	return resource
+}
+
+func TestPostgresConformance(t *testing.T) {
+	ctx := context.Background()
+	pool := newPool(t)
+
+	resource := runContainer(t, pool, "postgres", "16-alpine", []string{
+		"POSTGRES_USER=banking",
+		"POSTGRES_PASSWORD=banking",
+		"POSTGRES_DB=banking",
+	})
+	dbURL := fmt.Sprintf("postgres://banking:banking@%s/banking?sslmode=disable", resource.GetHostPort("5432/tcp"))
+
+	var db *pgxpool.Pool
+	if err := pool.Retry(func() error {
+		var err error
+		db, err = pgxpool.New(ctx, dbURL)
+		if err != nil {
+			return err
+		}
+		if err := db.Ping(ctx); err != nil {
+			db.Close()
+			return err
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("connect to postgres: %v", err)
+	}
+	t.Cleanup(db.Close)
+
+	migrate(t, db)
+
+	// Users inserted by SQL get the same account number as users created through the repository
+	id := uuid.New()
+	var accountNumber string
+	if err := db.QueryRow(ctx, `SELECT account_number_for($1)`, id).Scan(&accountNumber); err != nil {
+		t.Fatalf("account_number_for: %v", err)
+	}
+	if want := domain.AccountNumberFor(id); accountNumber != want {
+		t.Errorf("account_number_for(%s) = %q, want %q", id, accountNumber, want)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Target {
+		reset(t, db)
+		return repotest.Target{Repos: repository.NewRepositories(db), DB: db, UnitOfWork: repository.NewUnitOfWork(db)}
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		reset(t, db)
+		testArchive(t, db)
+	})
+}
+
+// testArchive archives the partitions of an old event and transaction and checks that reads still
+// find them.
+func testArchive(t *testing.T, db *pgxpool.Pool) {
+	ctx := context.Background()
+	repos := repository.NewRepositories(db)
+	archive := repository.NewArchiveRepo(db)
+
+	old := time.Date(2020, time.March, 10, 12, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	user := &domain.User{ID: uuid.New(), Username: "archived", Email: "archived@example.com", PasswordHash: "hash", Role: "user", IsActive: true}
+	if err := repos.Users.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// No partition covers the event's month, so it lands in the default partition
+	aggregateID := uuid.New()
+	if _, err := db.Exec(ctx, `
+		INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, created_at, version)
+		VALUES ($1, 'balance', $2, 'AmountCredited', '{}', $3, 1)`, uuid.New(), aggregateID, old); err != nil {
+		t.Fatalf("insert event: %v", err)
+	}
+	transactionID := uuid.New()
+	if _, err := db.Exec(ctx, `
+		INSERT INTO transactions (id, to_user_id, amount, type, status, created_at)
+		VALUES ($1, $2, 10, 'credit', 'success', $3)`, transactionID, user.ID, old); err != nil {
+		t.Fatalf("insert transaction: %v", err)
+	}
+	// A pending transaction keeps its month live
+	if _, err := db.Exec(ctx, `
+		INSERT INTO transactions (id, to_user_id, amount, type, status, created_at)
+		VALUES ($1, $2, 10, 'credit', 'pending', $3)`, uuid.New(), user.ID, old.AddDate(0, -1, 0)); err != nil {
+		t.Fatalf("insert pending transaction: %v", err)
+	}
+
+	created, err := archive.EnsurePartitions(ctx, old.AddDate(0, -1, 0), old)
+	if err != nil || len(created) != 4 || created[1] != "events_y2020m03" || created[3] != "transactions_y2020m03" {
+		t.Fatalf("EnsurePartitions = %v, %v; want the event and transaction partitions of 2020-02 and 2020-03", created, err)
+	}
+	if created, err := archive.EnsurePartitions(ctx, old, old); err != nil || len(created) != 0 {
+		t.Errorf("EnsurePartitions again = %v, %v; want nothing created", created, err)
+	}
+
+	archived, err := archive.ArchiveEvents(ctx, cutoff)
+	if err != nil || len(archived) != 2 || archived[1] != "events_y2020m03" {
+		t.Fatalf("ArchiveEvents = %v, %v; want events_y2020m02 and events_y2020m03", archived, err)
+	}
+	archived, err = archive.ArchiveTransactions(ctx, cutoff)
+	if err != nil || len(archived) != 1 || archived[0] != "transactions_y2020m03" {
+		t.Fatalf("ArchiveTransactions = %v, %v; want transactions_y2020m03", archived, err)
+	}
+
+	var live int
+	if err := db.QueryRow(ctx, `SELECT (SELECT COUNT(*) FROM events) + (SELECT COUNT(*) FROM transactions)`).Scan(&live); err != nil || live != 1 {
+		t.Errorf("live rows = %d, %v; want only the pending transaction after archiving", live, err)
+	}
+
+	events, err := repos.Events.GetEventsByAggregate(ctx, domain.AggregateBalance, aggregateID)
+	if err != nil || len(events) != 1 {
+		t.Errorf("GetEventsByAggregate = %d events, %v; want the archived event", len(events), err)
+	}
+	if version, err := repos.Events.GetAggregateVersion(ctx, domain.AggregateBalance, aggregateID); err != nil || version != 1 {
+		t.Errorf("GetAggregateVersion = %d, %v; want 1 counting archived events", version, err)
+	}
+	if tx, err := repos.Transactions.GetByID(ctx, transactionID); err != nil || tx.Amount != 10 {
+		t.Errorf("GetByID = %+v, %v; want the archived transaction", tx, err)
+	}
+}
+
+// migrate applies every up migration in order.
+func migrate(t *testing.T, db *pgxpool.Pool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("find migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		if _, err := db.Exec(context.Background(), string(sql)); err != nil {
+			t.Fatalf("apply %s: %v", filepath.Base(file), err)
+		}
+	}
+}
+
+// reset empties every table and restores the currencies and treasuries the migrations create.
+func reset(t *testing.T, db *pgxpool.Pool) {
+	t.Helper()
+	ctx := context.Background()
+
+	rows, err := db.Query(ctx, `SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename NOT IN ('treasury_accounts', 'currencies')`)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatalf("scan table: %v", err)
+		}
+		tables = append(tables, table)
+	}
+	rows.Close()
+
+	if _, err := db.Exec(ctx, "TRUNCATE "+strings.Join(tables, ", ")+" CASCADE"); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	if _, err := db.Exec(ctx, `UPDATE treasury_accounts SET balance = 0, total_minted = 0, total_burned = 0, max_credit_amount = NULL, daily_credit_cap = NULL`); err != nil {
+		t.Fatalf("reset treasuries: %v", err)
+	}
+
+	var defaults []string
+	for _, currency := range domain.DefaultCurrencies() {
+		defaults = append(defaults, currency.Code)
+		if _, err := db.Exec(ctx, `UPDATE currencies SET symbol = $2, decimal_places = $3, enabled = $4 WHERE code = $1`,
+			currency.Code, currency.Symbol, currency.DecimalPlaces, currency.Enabled); err != nil {
+			t.Fatalf("reset currency %s: %v", currency.Code, err)
+		}
+	}
+	if _, err := db.Exec(ctx, `DELETE FROM treasury_accounts WHERE NOT (currency = ANY($1))`, defaults); err != nil {
+		t.Fatalf("delete created treasuries: %v", err)
+	}
+	if _, err := db.Exec(ctx, `DELETE FROM currencies WHERE NOT (code = ANY($1))`, defaults); err != nil {
+		t.Fatalf("delete created currencies: %v", err)
+	}
+}
+
+func TestRedisClientConformance(t *testing.T) {
+	ctx := context.Background()
+	pool := newPool(t)
+
+	resource := runContainer(t, pool, "redis", "7-alpine", nil)
+
+	var client *repository.RedisClient
+	if err := pool.Retry(func() error {
+		var err error
+		client, err = repository.NewRedisClient(repository.RedisConfig{Addr: resource.GetHostPort("6379/tcp")})
+		return err
+	}); err != nil {
+		t.Fatalf("connect to redis: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	type balance struct {
+		Amount float64 `json:"amount"`
+	}
+
+	if err := client.Set(ctx, "balance", balance{Amount: 12.5}, time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	var got balance
+	if err := client.Get(ctx, "balance", &got); err != nil || got.Amount != 12.5 {
+		t.Errorf("get = %+v, %v; want 12.5", got, err)
+	}
+	if ttl, err := client.TTL(ctx, "balance"); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Errorf("ttl = %v, %v; want at most a minute", ttl, err)
+	}
+	if exists, err := client.Exists(ctx, "balance"); err != nil || !exists {
+		t.Errorf("exists = %v, %v; want true", exists, err)
+	}
+	if err := client.Del(ctx, "balance"); err != nil {
+		t.Fatalf("del: %v", err)
+	}
+	if err := client.Get(ctx, "balance", &got); !errors.Is(err, repository.ErrCacheMiss) {
+		t.Errorf("get after del: err = %v, want %v", err, repository.ErrCacheMiss)
+	}
+
+	if err := client.HSet(ctx, "user", "name", "alice"); err != nil {
+		t.Fatalf("hset: %v", err)
+	}
+	var name string
+	if err := client.HGet(ctx, "user", "name", &name); err != nil || name != "alice" {
+		t.Errorf("hget = %q, %v; want alice", name, err)
+	}
+	if err := client.HGet(ctx, "user", "email", &name); !errors.Is(err, repository.ErrCacheMiss) {
+		t.Errorf("hget of a missing field: err = %v, want %v", err, repository.ErrCacheMiss)
+	}
+	if _, err := client.HIncrBy(ctx, "usage", "read", 2, time.Minute); err != nil {
+		t.Fatalf("hincrby: %v", err)
+	}
+	if fields, err := client.HIncrBy(ctx, "usage", "write", 1, time.Minute); err != nil || fields["read"] != "2" || fields["write"] != "1" {
+		t.Errorf("hincrby = %v, %v; want read 2 and write 1", fields, err)
+	}
+
+	if n, err := client.Incr(ctx, "counter"); err != nil || n != 1 {
+		t.Errorf("incr = %d, %v; want 1", n, err)
+	}
+	if ok, err := client.SetNX(ctx, "lock", "a", time.Minute); err != nil || !ok {
+		t.Errorf("first setnx = %v, %v; want true", ok, err)
+	}
+	if ok, err := client.SetNX(ctx, "lock", "b", time.Minute); err != nil || ok {
+		t.Errorf("second setnx = %v, %v; want false", ok, err)
+	}
+}
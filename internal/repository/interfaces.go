@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +15,10 @@ type UsersRepo interface {
 	// Create creates a new user.
 	Create(ctx context.Context, user *domain.User) error
 
+	// CreateMany creates users in bulk, filling in IDs, timestamps and account numbers like Create.
+	// Either every user is created or none is.
+	CreateMany(ctx context.Context, users []*domain.User) error
+
 	// GetByID retrieves a user by ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 
@@ -23,6 +28,9 @@ type UsersRepo interface {
 	// GetByUsername retrieves a user by username.
 	GetByUsername(ctx context.Context, username string) (*domain.User, error)
 
+	// GetByAccountNumber retrieves a user by account number.
+	GetByAccountNumber(ctx context.Context, accountNumber string) (*domain.User, error)
+
 	// Update updates an existing user.
 	Update(ctx context.Context, user *domain.User) error
 
@@ -47,15 +55,27 @@ type BalancesRepo interface {
 	// Upsert creates or updates a balance.
 	Upsert(ctx context.Context, balance *domain.Balance) error
 
+	// CreateMany creates the balances of users that have none yet, in bulk.
+	// Either every balance is created or none is.
+	CreateMany(ctx context.Context, balances []*domain.Balance) error
+
 	// AddAmountTx adds amount to a user's balance within a transaction.
 	// This method should be used within database transactions for atomicity.
 	AddAmountTx(ctx context.Context, tx interface{}, userID uuid.UUID, delta float64) error
 
+	// AddAmount adds amount to a user's balance, failing if the balance would become negative.
+	// Called on the repositories of a unit of work, the change commits with the transaction.
+	AddAmount(ctx context.Context, userID uuid.UUID, delta float64) error
+
 	// GetHistorical retrieves historical balance snapshots.
 	GetHistorical(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.BalanceHistoryItem, error)
 
 	// GetAtTime retrieves balance at a specific time.
 	GetAtTime(ctx context.Context, userID uuid.UUID, timestamp string) (*domain.Balance, error)
+
+	// GetTimeline retrieves the balance after every successful transaction up to and including each
+	// of the given times, one point per time in the same order.
+	GetTimeline(ctx context.Context, userID uuid.UUID, times []time.Time) ([]domain.BalancePoint, error)
 }
 
 // TransactionsRepo defines the interface for transaction data operations.
@@ -66,20 +86,49 @@ type TransactionsRepo interface {
 	// MarkCompleted marks a transaction as completed.
 	MarkCompleted(ctx context.Context, id uuid.UUID) error
 
+	// CreateManyCompleted creates transactions with success status in bulk, for money that has
+	// already moved. Either every transaction is created or none is.
+	CreateManyCompleted(ctx context.Context, txs []*domain.Transaction) error
+
 	// MarkFailed marks a transaction as failed.
 	MarkFailed(ctx context.Context, id uuid.UUID) error
 
 	// GetByID retrieves a transaction by ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
 
+	// RecordReversal adds a rollback amount to the original transaction, linking the rollback once fully reversed.
+	RecordReversal(ctx context.Context, originalID uuid.UUID, reversalID uuid.UUID, amount float64) error
+
 	// ListForUser retrieves transactions for a specific user.
 	ListForUser(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error)
 
+	// ListForUserAfter retrieves up to limit transactions for a user, newest first, that come after cursor.
+	// A nil cursor starts from the newest transaction. Limit and offset in the filter are ignored.
+	ListForUserAfter(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter, cursor *domain.TransactionCursor, limit int) ([]*domain.Transaction, error)
+
 	// List retrieves transactions with filtering.
 	List(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error)
 
 	// Count returns the total number of transactions matching the filter.
 	Count(ctx context.Context, filter *domain.TransactionFilter) (int, error)
+
+	// GetStats computes aggregate transaction statistics for transactions created in [since, until).
+	GetStats(ctx context.Context, since, until time.Time) (*domain.TransactionStats, error)
+
+	// ListMostActiveUserIDs returns the IDs of users with the most transactions created since the given time.
+	ListMostActiveUserIDs(ctx context.Context, since time.Time, limit int) ([]uuid.UUID, error)
+
+	// ListRecentCounterparties summarizes a user's successful outgoing transfers per recipient, most recent first.
+	ListRecentCounterparties(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Counterparty, error)
+}
+
+// TransactionAggregatesRepo defines the interface for per-user monthly transaction totals. The
+// totals are maintained as transactions complete and are rolled back, so reading them does not
+// scan the transactions themselves.
+type TransactionAggregatesRepo interface {
+	// ListMonthly retrieves a user's aggregates of the UTC months starting in [from, to), oldest
+	// month first.
+	ListMonthly(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.TransactionAggregate, error)
 }
 
 // AuditRepo defines the interface for audit log operations.
@@ -98,6 +147,9 @@ type AuditRepo interface {
 
 	// Count returns the total number of audit logs matching the filter.
 	Count(ctx context.Context, filter *domain.AuditLogFilter) (int, error)
+
+	// DeleteOlderThan deletes audit logs of an entity type created before the given time.
+	DeleteOlderThan(ctx context.Context, entityType string, before time.Time) (int64, error)
 }
 
 // EventsRepo defines the interface for event sourcing operations.
@@ -120,6 +172,10 @@ type EventsRepo interface {
 	// AppendEvents appends multiple events in a single transaction
 	AppendEvents(ctx context.Context, events []*domain.Event) error
 
+	// ImportEvents stores events as given, keeping their IDs, versions and timestamps, skips the ones
+	// already stored and returns how many were stored
+	ImportEvents(ctx context.Context, events []*domain.Event) (int, error)
+
 	// LoadEventEnvelope loads an event with its deserialized data
 	LoadEventEnvelope(ctx context.Context, event *domain.Event, target interface{}) (*EventEnvelope, error)
 }
@@ -135,12 +191,26 @@ type ScheduledTransactionsRepo interface {
 	// GetByUserID retrieves scheduled transactions for a user
 	GetByUserID(ctx context.Context, userID uuid.UUID, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransaction, error)
 
-	// GetDueForExecution retrieves scheduled transactions that are due for execution
-	GetDueForExecution(ctx context.Context, limit int) ([]*domain.ScheduledTransaction, error)
+	// ClaimDueForExecution leases scheduled transactions due as of now to owner so no other instance
+	// executes them. Leases run on the wall clock, whatever clock now comes from.
+	ClaimDueForExecution(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) ([]*domain.ScheduledTransaction, error)
+
+	// ReleaseClaim releases owner's lease on a scheduled transaction
+	ReleaseClaim(ctx context.Context, id uuid.UUID, owner string) error
 
 	// Update updates a scheduled transaction
 	Update(ctx context.Context, st *domain.ScheduledTransaction) error
 
+	// Reassign moves a scheduled transaction to another owner, clearing its transfer template
+	// binding, funding alert and pending retry.
+	Reassign(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// ListUpcomingOutgoing lists active debits and transfers due after from and no later than until, grouped by user and earliest first
+	ListUpcomingOutgoing(ctx context.Context, from, until time.Time, limit int) ([]*domain.ScheduledTransaction, error)
+
+	// MarkFundingAlerted records that the owner was warned about insufficient funds for the occurrence at executeAt, reporting false if they already were
+	MarkFundingAlerted(ctx context.Context, id uuid.UUID, executeAt time.Time) (bool, error)
+
 	// ResetStatus resets the status of a scheduled transaction (used for error recovery)
 	ResetStatus(ctx context.Context, id uuid.UUID, status string) error
 
@@ -155,6 +225,415 @@ type ScheduledTransactionsRepo interface {
 
 	// Count counts scheduled transactions matching the filter
 	Count(ctx context.Context, userID uuid.UUID, filter *domain.ScheduledTransactionFilter) (int, error)
+
+	// List retrieves scheduled transactions of all users matching the filter, newest first
+	List(ctx context.Context, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransaction, error)
+
+	// CountAll counts scheduled transactions of all users matching the filter, ignoring pagination
+	CountAll(ctx context.Context, filter *domain.ScheduledTransactionFilter) (int, error)
+
+	// GetFailureReasons aggregates failed executions since the given time by error message prefix, most frequent first
+	GetFailureReasons(ctx context.Context, since time.Time) ([]domain.ScheduledFailureReason, error)
+}
+
+// ImpersonationSessionsRepo defines the interface for admin impersonation session operations.
+type ImpersonationSessionsRepo interface {
+	// Create records a new impersonation session.
+	Create(ctx context.Context, session *domain.ImpersonationSession) error
+
+	// ListActive retrieves impersonation sessions that have not expired at the given time.
+	ListActive(ctx context.Context, now time.Time) ([]*domain.ImpersonationSession, error)
+}
+
+// KnownDevicesRepo defines the interface for the devices users have logged in from.
+type KnownDevicesRepo interface {
+	// Touch records that a user logged in from the device with the fingerprint at the given time,
+	// reporting whether the device was new to the user.
+	Touch(ctx context.Context, userID uuid.UUID, fingerprint string, at time.Time) (bool, error)
+
+	// Count returns the number of devices a user has logged in from.
+	Count(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// LoginSessionsRepo defines the interface for login session operations.
+type LoginSessionsRepo interface {
+	// Create records a new login session.
+	Create(ctx context.Context, session *domain.LoginSession) error
+
+	// GetByID retrieves a login session by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.LoginSession, error)
+
+	// ListActive retrieves a user's sessions that are neither revoked nor expired at the given
+	// time, newest first.
+	ListActive(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.LoginSession, error)
+
+	// Revoke marks one of a user's sessions revoked at the given time. It fails with "login session
+	// not found" if the user has no such session, and "login session already revoked" if it was.
+	Revoke(ctx context.Context, id, userID uuid.UUID, at time.Time) error
+}
+
+// SecurityEventsRepo defines the interface for the security event log.
+type SecurityEventsRepo interface {
+	// Record appends a security event.
+	Record(ctx context.Context, event *domain.SecurityEvent) error
+
+	// List retrieves security events, newest first.
+	List(ctx context.Context, filter *domain.SecurityEventFilter) ([]*domain.SecurityEvent, error)
+}
+
+// AnomalyScoresRepo defines the interface for users' anomaly scores.
+type AnomalyScoresRepo interface {
+	// Get retrieves a user's anomaly score, a zero score as of the zero time if they have none.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.AnomalyScore, error)
+
+	// Add decays a user's score to the given time, halving it every halfLife, and adds points to
+	// it, returning the new score.
+	Add(ctx context.Context, userID uuid.UUID, points float64, halfLife time.Duration, at time.Time) (float64, error)
+
+	// Reset clears a user's score.
+	Reset(ctx context.Context, userID uuid.UUID) error
+}
+
+// ApprovalsRepo defines the interface for the four-eyes approval queue.
+type ApprovalsRepo interface {
+	// Create records a new pending approval.
+	Create(ctx context.Context, approval *domain.Approval) error
+
+	// GetByID retrieves an approval by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Approval, error)
+
+	// List retrieves approvals, newest first.
+	List(ctx context.Context, filter *domain.ApprovalFilter) ([]*domain.Approval, error)
+
+	// Review moves a pending approval to approved or rejected by reviewerID at the given time. It
+	// fails with "approval not found" if there is no such approval, and "approval already
+	// reviewed" if it is no longer pending.
+	Review(ctx context.Context, id, reviewerID uuid.UUID, status domain.ApprovalStatus, note string, at time.Time) error
+
+	// Complete records the outcome of an approved action: executed with what it returned, or
+	// failed with why.
+	Complete(ctx context.Context, id uuid.UUID, status domain.ApprovalStatus, result json.RawMessage, errMsg string, at time.Time) error
+}
+
+// PoliciesRepo defines the interface for the versions of the runtime policy.
+type PoliciesRepo interface {
+	// Create stores a new version of the policy, setting its version number.
+	Create(ctx context.Context, version *domain.PolicyVersion) error
+
+	// GetByVersion retrieves a version of the policy by its number.
+	GetByVersion(ctx context.Context, version int) (*domain.PolicyVersion, error)
+
+	// List retrieves versions of the policy, newest first.
+	List(ctx context.Context, filter *domain.PolicyVersionFilter) ([]*domain.PolicyVersion, error)
+
+	// EffectiveAt retrieves the version in effect at the given time: the one with the latest
+	// effective time not after it, the latest stored on a tie. It fails with "policy version not
+	// found" if no version is in effect yet.
+	EffectiveAt(ctx context.Context, at time.Time) (*domain.PolicyVersion, error)
+
+	// NextAfter retrieves the first version taking effect after the given time, or nil if none is
+	// scheduled.
+	NextAfter(ctx context.Context, at time.Time) (*domain.PolicyVersion, error)
+}
+
+// MonthlySummariesRepo defines the interface for users' monthly account summaries.
+type MonthlySummariesRepo interface {
+	// Create stores a summary unless the user already has one for the month, and reports whether it
+	// was stored, so a summary generated by several instances at once is only announced once.
+	Create(ctx context.Context, summary *domain.MonthlySummary) (bool, error)
+
+	// Get retrieves the user's summary of a yyyy-mm month. It fails with "monthly summary not
+	// found" if none was generated.
+	Get(ctx context.Context, userID uuid.UUID, month string) (*domain.MonthlySummary, error)
+}
+
+// DisputesRepo defines the interface for transaction dispute operations.
+type DisputesRepo interface {
+	// Create creates a new dispute.
+	Create(ctx context.Context, dispute *domain.Dispute) error
+
+	// GetByID retrieves a dispute by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Dispute, error)
+
+	// GetOpenByTransactionID retrieves the unresolved dispute for a transaction, if any.
+	GetOpenByTransactionID(ctx context.Context, transactionID uuid.UUID) (*domain.Dispute, error)
+
+	// List retrieves disputes with filtering.
+	List(ctx context.Context, filter *domain.DisputeFilter) ([]*domain.Dispute, error)
+
+	// Update updates the status and resolution fields of a dispute.
+	Update(ctx context.Context, dispute *domain.Dispute) error
+
+	// AddComment adds a comment to a dispute.
+	AddComment(ctx context.Context, comment *domain.DisputeComment) error
+
+	// ListComments retrieves comments for a dispute in chronological order.
+	ListComments(ctx context.Context, disputeID uuid.UUID) ([]*domain.DisputeComment, error)
+}
+
+// PaymentRequestsRepo defines the interface for payment request operations.
+type PaymentRequestsRepo interface {
+	// Create creates a new payment request.
+	Create(ctx context.Context, request *domain.PaymentRequest) error
+
+	// GetByID retrieves a payment request by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentRequest, error)
+
+	// List retrieves payment requests with filtering.
+	List(ctx context.Context, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error)
+
+	// UpdateStatus updates the status fields of a payment request whose stored status is fromStatus.
+	UpdateStatus(ctx context.Context, request *domain.PaymentRequest, fromStatus string) error
+}
+
+// TransferTemplatesRepo defines the interface for transfer template operations.
+type TransferTemplatesRepo interface {
+	// Create creates a new transfer template.
+	Create(ctx context.Context, template *domain.TransferTemplate) error
+
+	// GetByID retrieves a transfer template by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferTemplate, error)
+
+	// ListByUser retrieves a user's transfer templates with pagination.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.TransferTemplate, error)
+
+	// Update updates the name and transfer details of a template.
+	Update(ctx context.Context, template *domain.TransferTemplate) error
+
+	// Delete deletes a transfer template.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// RecordUse adds a transfer made from the template to its usage statistics.
+	RecordUse(ctx context.Context, id uuid.UUID, amount float64, usedAt time.Time) error
+}
+
+// ContactsRepo defines the interface for saved contact operations.
+type ContactsRepo interface {
+	// Create saves a contact.
+	Create(ctx context.Context, contact *domain.Contact) error
+
+	// GetByID retrieves a contact by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Contact, error)
+
+	// ListByUser retrieves all of a user's contacts, oldest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Contact, error)
+
+	// Delete deletes a contact.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NotificationsRepo defines the interface for notification inbox, preference and delivery operations.
+type NotificationsRepo interface {
+	// CreateNotification adds a notification to a user's inbox.
+	CreateNotification(ctx context.Context, notification *domain.Notification) error
+
+	// GetNotification retrieves an inbox notification by ID.
+	GetNotification(ctx context.Context, id uuid.UUID) (*domain.Notification, error)
+
+	// ListNotifications retrieves a user's inbox with filtering.
+	ListNotifications(ctx context.Context, filter *domain.NotificationFilter) ([]*domain.Notification, error)
+
+	// CountUnread returns the number of unread notifications in a user's inbox.
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// MarkRead marks a notification as read.
+	MarkRead(ctx context.Context, id uuid.UUID, readAt time.Time) error
+
+	// MarkAllRead marks every unread notification of a user as read.
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+
+	// GetPreferences retrieves a user's stored notification preferences.
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error)
+
+	// UpsertPreferences creates or replaces a user's notification preferences.
+	UpsertPreferences(ctx context.Context, prefs *domain.NotificationPreferences) error
+
+	// CreateDelivery queues a notification for delivery on one channel.
+	CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error
+
+	// ClaimDueDeliveries claims pending deliveries that are due, leasing them until leaseUntil.
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.NotificationDelivery, error)
+
+	// UpdateDelivery saves the outcome of a delivery attempt.
+	UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error
+}
+
+// BalanceAlertsRepo defines the interface for low-balance alert operations.
+type BalanceAlertsRepo interface {
+	// Upsert creates or replaces a user's alert threshold for a currency.
+	Upsert(ctx context.Context, alert *domain.BalanceAlert) error
+
+	// Get retrieves a user's alert threshold for a currency.
+	Get(ctx context.Context, userID uuid.UUID, currency string) (*domain.BalanceAlert, error)
+
+	// ListByUser retrieves a user's alert thresholds.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.BalanceAlert, error)
+
+	// Delete deletes a user's alert threshold for a currency.
+	Delete(ctx context.Context, userID uuid.UUID, currency string) error
+
+	// SetTriggered moves an alert into the triggered or re-armed state and reports whether it changed.
+	SetTriggered(ctx context.Context, userID uuid.UUID, currency string, triggered bool, at time.Time) (bool, error)
+}
+
+// BudgetsRepo defines the interface for users' monthly category budgets.
+type BudgetsRepo interface {
+	// Upsert creates or replaces a user's budget for a category, re-arming its over-budget notification.
+	Upsert(ctx context.Context, budget *domain.Budget) error
+
+	// Get retrieves a user's budget for a category. It fails with "budget not found" if there is none.
+	Get(ctx context.Context, userID uuid.UUID, category string) (*domain.Budget, error)
+
+	// ListByUser retrieves a user's budgets ordered by category.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error)
+
+	// Delete deletes a user's budget for a category.
+	Delete(ctx context.Context, userID uuid.UUID, category string) error
+
+	// MarkAlerted records that the user was told they went over a budget during a yyyy-mm month,
+	// and reports whether it wasn't recorded already.
+	MarkAlerted(ctx context.Context, userID uuid.UUID, category, month string) (bool, error)
+}
+
+// DormantAccountsRepo defines the interface for finding accounts without recent transactions.
+type DormantAccountsRepo interface {
+	// List retrieves the active users without transactions since cutoff, longest inactive first.
+	List(ctx context.Context, cutoff time.Time, limit, offset int) ([]*domain.DormantAccount, error)
+
+	// Count counts the active users without transactions since cutoff.
+	Count(ctx context.Context, cutoff time.Time) (int, error)
+
+	// MarkFlagged records that a user was announced dormant for the inactivity that started at
+	// inactiveSince, and reports whether it wasn't recorded already.
+	MarkFlagged(ctx context.Context, userID uuid.UUID, inactiveSince, at time.Time) (bool, error)
+}
+
+// SearchRepo defines the interface for full-text search. Queries use web search syntax and match
+// whole words, ignoring case.
+type SearchRepo interface {
+	// Search retrieves a page of a user's transactions and payment requests matching query, best
+	// match first.
+	Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]*domain.SearchHit, error)
+
+	// Count counts a user's transactions and payment requests matching query.
+	Count(ctx context.Context, userID uuid.UUID, query string) (int, error)
+
+	// SearchAudit retrieves a page of the audit log entries matching query, best match first.
+	SearchAudit(ctx context.Context, query string, limit, offset int) ([]*domain.SearchHit, error)
+
+	// CountAudit counts the audit log entries matching query.
+	CountAudit(ctx context.Context, query string) (int, error)
+}
+
+// TreasuryRepo defines the interface for treasury account and ledger operations.
+type TreasuryRepo interface {
+	// ListAccounts retrieves every treasury account.
+	ListAccounts(ctx context.Context) ([]*domain.TreasuryAccount, error)
+
+	// GetAccount retrieves the treasury account of a currency.
+	GetAccount(ctx context.Context, currency string) (*domain.TreasuryAccount, error)
+
+	// SetCaps replaces the credit caps of a currency's treasury.
+	SetCaps(ctx context.Context, currency string, maxCreditAmount *float64, dailyCreditCap *float64, at time.Time) (*domain.TreasuryAccount, error)
+
+	// Record applies a ledger entry to its treasury and appends it to the ledger atomically.
+	Record(ctx context.Context, entry *domain.TreasuryEntry, enforceCaps bool) (*domain.TreasuryAccount, error)
+
+	// ListEntries retrieves a treasury's ledger.
+	ListEntries(ctx context.Context, filter *domain.TreasuryEntryFilter) ([]*domain.TreasuryEntry, error)
+
+	// SumUserBalances returns the money held by users per currency.
+	SumUserBalances(ctx context.Context) (map[string]float64, error)
+}
+
+// CurrenciesRepo defines the interface for the currency registry.
+type CurrenciesRepo interface {
+	// List retrieves every currency of the registry, disabled ones included.
+	List(ctx context.Context) ([]*domain.CurrencyInfo, error)
+
+	// Get retrieves a currency by code.
+	Get(ctx context.Context, code string) (*domain.CurrencyInfo, error)
+
+	// Create adds a currency to the registry together with its empty treasury account.
+	Create(ctx context.Context, currency *domain.CurrencyInfo) error
+
+	// Update replaces the symbol, decimal places and enabled flag of a currency.
+	Update(ctx context.Context, currency *domain.CurrencyInfo) error
+}
+
+// ArchiveRepo defines the interface for maintaining the monthly partitions of the event store and
+// transactions and archiving old events and transactions. Only PostgreSQL storage implements it.
+type ArchiveRepo interface {
+	// EnsurePartitions creates the missing event and transaction partitions for the months from
+	// through to and returns their names.
+	EnsurePartitions(ctx context.Context, from, to time.Time) ([]string, error)
+
+	// ArchiveEvents moves the event partitions of months ending by cutoff into the archive and
+	// returns their names.
+	ArchiveEvents(ctx context.Context, cutoff time.Time) ([]string, error)
+
+	// ArchiveTransactions moves the transaction partitions of months ending by cutoff into the
+	// archive and returns their names. Months holding pending or disputed transactions stay live.
+	ArchiveTransactions(ctx context.Context, cutoff time.Time) ([]string, error)
+}
+
+// IndexAdvisorRepo defines the interface for explaining the hottest queries against current
+// statistics. Only PostgreSQL storage implements it.
+type IndexAdvisorRepo interface {
+	// ExplainHotQueries returns the plans of the hottest queries, without running them.
+	ExplainHotQueries(ctx context.Context) ([]*domain.QueryPlan, error)
+
+	// TableStatistics returns the statistics of every table, keyed by schema-qualified name.
+	TableStatistics(ctx context.Context) (map[string]domain.TableStatistics, error)
+}
+
+// UnitOfWork begins transactions spanning several repositories, so services can change them
+// atomically without depending on the database driver.
+type UnitOfWork interface {
+	// Begin starts a transaction and returns repositories bound to it.
+	Begin(ctx context.Context) (Tx, error)
+}
+
+// Tx is a transaction begun by a UnitOfWork.
+type Tx interface {
+	// Repos returns the repositories bound to the transaction. Their writes are only visible
+	// to other callers once the transaction commits.
+	Repos() *Repositories
+
+	// Commit commits the transaction.
+	Commit(ctx context.Context) error
+
+	// Rollback discards the transaction's writes. It does nothing once the transaction is committed.
+	Rollback(ctx context.Context) error
+}
+
+// NettingRepo defines the interface for netting batch operations.
+type NettingRepo interface {
+	// OpenBatch returns the open batch of a pair of users in a currency, opening one that closes at
+	// closesAt if there is none. Within a transaction the batch stays locked until it ends, so it
+	// cannot be closed while transfers are being added to it.
+	OpenBatch(ctx context.Context, userAID, userBID uuid.UUID, currency string, closesAt time.Time) (*domain.NettingBatch, error)
+
+	// AddTransfer records a pending transfer in an open batch. It fails with "netting batch not open"
+	// if the batch was closed.
+	AddTransfer(ctx context.Context, batchID uuid.UUID, transfer *domain.NettedTransfer) error
+
+	// ReservedAmount returns the amount a user has sent in a currency through batches still open.
+	ReservedAmount(ctx context.Context, userID uuid.UUID, currency string) (float64, error)
+
+	// ListDue retrieves up to limit open batches whose window closed by now, oldest first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.NettingBatch, error)
+
+	// ListTransfers retrieves the transfers of a batch. Within a transaction it locks the batch
+	// first, so the transfers cannot change until the transaction ends and the batch is closed.
+	ListTransfers(ctx context.Context, batchID uuid.UUID) ([]*domain.NettedTransfer, error)
+
+	// Close records the outcome of an open batch: its status, totals, failure reason and settlement
+	// time. It fails with "netting batch not open" if the batch was already closed.
+	Close(ctx context.Context, batch *domain.NettingBatch) error
+
+	// List retrieves batches, newest first.
+	List(ctx context.Context, filter *domain.NettingBatchFilter) ([]*domain.NettingBatch, error)
 }
 
 // Repositories aggregates all repository interfaces.
@@ -162,7 +641,61 @@ type Repositories struct {
 	Users                 UsersRepo
 	Balances              BalancesRepo
 	Transactions          TransactionsRepo
+	TransactionAggregates TransactionAggregatesRepo
 	Audit                 AuditRepo
 	Events                EventsRepo
 	ScheduledTransactions ScheduledTransactionsRepo
+	ImpersonationSessions ImpersonationSessionsRepo
+	KnownDevices          KnownDevicesRepo
+	LoginSessions         LoginSessionsRepo
+	SecurityEvents        SecurityEventsRepo
+	AnomalyScores         AnomalyScoresRepo
+	Approvals             ApprovalsRepo
+	Policies              PoliciesRepo
+	MonthlySummaries      MonthlySummariesRepo
+	Disputes              DisputesRepo
+	PaymentRequests       PaymentRequestsRepo
+	TransferTemplates     TransferTemplatesRepo
+	Contacts              ContactsRepo
+	Notifications         NotificationsRepo
+	BalanceAlerts         BalanceAlertsRepo
+	Budgets               BudgetsRepo
+	DormantAccounts       DormantAccountsRepo
+	Search                SearchRepo
+	Treasury              TreasuryRepo
+	Currencies            CurrenciesRepo
+	Netting               NettingRepo
+}
+
+// NewRepositories creates every repository on top of db.
+func NewRepositories(db DBTX) *Repositories {
+	return &Repositories{
+		Users:                 NewUsersRepo(db),
+		Balances:              NewBalancesRepo(db),
+		Transactions:          NewTransactionsRepo(db),
+		TransactionAggregates: NewTransactionAggregatesRepo(db),
+		Audit:                 NewAuditRepo(db),
+		Events:                NewEventRepository(db),
+		ScheduledTransactions: NewScheduledTransactionRepository(db),
+		ImpersonationSessions: NewImpersonationSessionsRepo(db),
+		KnownDevices:          NewKnownDevicesRepo(db),
+		LoginSessions:         NewLoginSessionsRepo(db),
+		SecurityEvents:        NewSecurityEventsRepo(db),
+		AnomalyScores:         NewAnomalyScoresRepo(db),
+		Approvals:             NewApprovalsRepo(db),
+		Policies:              NewPoliciesRepo(db),
+		MonthlySummaries:      NewMonthlySummariesRepo(db),
+		Disputes:              NewDisputesRepo(db),
+		PaymentRequests:       NewPaymentRequestsRepo(db),
+		TransferTemplates:     NewTransferTemplatesRepo(db),
+		Contacts:              NewContactsRepo(db),
+		Notifications:         NewNotificationsRepo(db),
+		BalanceAlerts:         NewBalanceAlertsRepo(db),
+		Budgets:               NewBudgetsRepo(db),
+		DormantAccounts:       NewDormantAccountsRepo(db),
+		Search:                NewSearchRepo(db),
+		Treasury:              NewTreasuryRepo(db),
+		Currencies:            NewCurrenciesRepo(db),
+		Netting:               NewNettingRepo(db),
+	}
 }
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// knownDevicesRepo implements the KnownDevicesRepo interface.
+type knownDevicesRepo struct {
+	db DBTX
+}
+
+// NewKnownDevicesRepo creates a new known devices repository.
+func NewKnownDevicesRepo(db DBTX) KnownDevicesRepo {
+	return &knownDevicesRepo{db: db}
+}
+
+// Touch records that a user logged in from the device with the fingerprint at the given time,
+// reporting whether the device was new to the user.
+func (r *knownDevicesRepo) Touch(ctx context.Context, userID uuid.UUID, fingerprint string, at time.Time) (bool, error) {
+	query := `
+		INSERT INTO known_devices (user_id, fingerprint, first_seen_at, last_seen_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (user_id, fingerprint) DO NOTHING`
+
+	result, err := r.db.Exec(ctx, query, userID, fingerprint, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to record known device: %w", err)
+	}
+	if result.RowsAffected() > 0 {
+		return true, nil
+	}
+
+	query = `
+		UPDATE known_devices
+		SET last_seen_at = GREATEST(last_seen_at, $3)
+		WHERE user_id = $1 AND fingerprint = $2`
+
+	if _, err := r.db.Exec(ctx, query, userID, fingerprint, at); err != nil {
+		return false, fmt.Errorf("failed to update known device: %w", err)
+	}
+
+	return false, nil
+}
+
+// Count returns the number of devices a user has logged in from.
+func (r *knownDevicesRepo) Count(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM known_devices WHERE user_id = $1`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count known devices: %w", err)
+	}
+
+	return count, nil
+}
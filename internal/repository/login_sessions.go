@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// loginSessionsRepo implements the LoginSessionsRepo interface.
+type loginSessionsRepo struct {
+	db DBTX
+}
+
+// NewLoginSessionsRepo creates a new login sessions repository.
+func NewLoginSessionsRepo(db DBTX) LoginSessionsRepo {
+	return &loginSessionsRepo{db: db}
+}
+
+// Create records a new login session.
+func (r *loginSessionsRepo) Create(ctx context.Context, session *domain.LoginSession) error {
+	query := `
+		INSERT INTO login_sessions (id, user_id, ip, user_agent, new_device, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, query,
+		session.ID,
+		session.UserID,
+		session.IP,
+		session.UserAgent,
+		session.NewDevice,
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create login session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a login session by ID.
+func (r *loginSessionsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.LoginSession, error) {
+	query := `
+		SELECT id, user_id, ip, user_agent, new_device, created_at, expires_at, revoked_at
+		FROM login_sessions
+		WHERE id = $1`
+
+	session, err := scanLoginSession(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("login session not found")
+		}
+		return nil, fmt.Errorf("failed to get login session by ID: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActive retrieves a user's sessions that are neither revoked nor expired at the given time,
+// newest first.
+func (r *loginSessionsRepo) ListActive(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.LoginSession, error) {
+	query := `
+		SELECT id, user_id, ip, user_agent, new_device, created_at, expires_at, revoked_at
+		FROM login_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, query, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active login sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*domain.LoginSession
+	for rows.Next() {
+		session, err := scanLoginSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan login session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate login sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke marks one of a user's sessions revoked at the given time.
+func (r *loginSessionsRepo) Revoke(ctx context.Context, id, userID uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE login_sessions
+		SET revoked_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, id, userID, at)
+	if err != nil {
+		return fmt.Errorf("failed to revoke login session: %w", err)
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Tell a session that does not exist apart from one that was already revoked
+	var exists bool
+	query = `SELECT EXISTS (SELECT 1 FROM login_sessions WHERE id = $1 AND user_id = $2)`
+	if err := r.db.QueryRow(ctx, query, id, userID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to revoke login session: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("login session already revoked")
+	}
+	return fmt.Errorf("login session not found")
+}
+
+// scanLoginSession scans a login session row.
+func scanLoginSession(row pgx.Row) (*domain.LoginSession, error) {
+	var session domain.LoginSession
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.IP,
+		&session.UserAgent,
+		&session.NewDevice,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
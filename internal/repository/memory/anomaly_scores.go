@@ -0,0 +1,62 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// anomalyScoresRepo implements the AnomalyScoresRepo interface in memory.
+type anomalyScoresRepo struct {
+	store *Store
+}
+
+// NewAnomalyScoresRepo creates a new in-memory anomaly scores repository.
+func NewAnomalyScoresRepo(store *Store) repository.AnomalyScoresRepo {
+	return &anomalyScoresRepo{store: store}
+}
+
+// Get retrieves a user's anomaly score, a zero score as of the zero time if they have none.
+func (r *anomalyScoresRepo) Get(_ context.Context, userID uuid.UUID) (*domain.AnomalyScore, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if score, ok := r.store.anomalyScores[userID]; ok {
+		result := *score
+		return &result, nil
+	}
+	return &domain.AnomalyScore{UserID: userID}, nil
+}
+
+// Add decays a user's score to the given time, halving it every halfLife, and adds points to it,
+// returning the new score.
+func (r *anomalyScoresRepo) Add(_ context.Context, userID uuid.UUID, points float64, halfLife time.Duration, at time.Time) (float64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	score, ok := r.store.anomalyScores[userID]
+	if !ok {
+		score = &domain.AnomalyScore{UserID: userID, UpdatedAt: at}
+		r.store.anomalyScores[userID] = score
+	}
+
+	score.Score = score.At(at, halfLife) + points
+	if at.After(score.UpdatedAt) {
+		score.UpdatedAt = at
+	}
+	return score.Score, nil
+}
+
+// Reset clears a user's score.
+func (r *anomalyScoresRepo) Reset(_ context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.anomalyScores, userID)
+	return nil
+}
@@ -0,0 +1,141 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// approvalsRepo implements the ApprovalsRepo interface in memory.
+type approvalsRepo struct {
+	store *Store
+}
+
+// NewApprovalsRepo creates a new in-memory approvals repository.
+func NewApprovalsRepo(store *Store) repository.ApprovalsRepo {
+	return &approvalsRepo{store: store}
+}
+
+// Create records a new pending approval.
+func (r *approvalsRepo) Create(_ context.Context, approval *domain.Approval) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.approvals = append(r.store.approvals, copyApproval(approval))
+	return nil
+}
+
+// GetByID retrieves an approval by ID.
+func (r *approvalsRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Approval, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	approval := r.find(id)
+	if approval == nil {
+		return nil, fmt.Errorf("approval not found")
+	}
+	return copyApproval(approval), nil
+}
+
+// List retrieves approvals, newest first.
+func (r *approvalsRepo) List(_ context.Context, filter *domain.ApprovalFilter) ([]*domain.Approval, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var approvals []*domain.Approval
+	for i := len(r.store.approvals) - 1; i >= 0; i-- {
+		approval := r.store.approvals[i]
+		if filter.Status != nil && approval.Status != *filter.Status {
+			continue
+		}
+		if filter.Action != nil && approval.Action != *filter.Action {
+			continue
+		}
+		approvals = append(approvals, copyApproval(approval))
+	}
+
+	start, end := paginate(len(approvals), filter.Limit, filter.Offset)
+	return approvals[start:end], nil
+}
+
+// Review moves a pending approval to approved or rejected by reviewerID at the given time.
+func (r *approvalsRepo) Review(_ context.Context, id, reviewerID uuid.UUID, status domain.ApprovalStatus, note string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	approval := r.find(id)
+	if approval == nil {
+		return fmt.Errorf("approval not found")
+	}
+	if approval.Status != domain.ApprovalPending {
+		return fmt.Errorf("approval already reviewed")
+	}
+
+	approval.Status = status
+	approval.ReviewedBy = &reviewerID
+	approval.ReviewNote = note
+	approval.ReviewedAt = &at
+	return nil
+}
+
+// Complete records the outcome of an approved action.
+func (r *approvalsRepo) Complete(_ context.Context, id uuid.UUID, status domain.ApprovalStatus, result json.RawMessage, errMsg string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	approval := r.find(id)
+	if approval == nil {
+		return nil
+	}
+
+	approval.Status = status
+	approval.Result = copyRawMessage(result)
+	approval.Error = errMsg
+	approval.CompletedAt = &at
+	return nil
+}
+
+// find returns the stored approval with the ID, or nil. The caller must hold the store's lock.
+func (r *approvalsRepo) find(id uuid.UUID) *domain.Approval {
+	for _, approval := range r.store.approvals {
+		if approval.ID == id {
+			return approval
+		}
+	}
+	return nil
+}
+
+// copyApproval returns a copy of an approval that shares no memory with it.
+func copyApproval(approval *domain.Approval) *domain.Approval {
+	c := *approval
+	c.Payload = copyRawMessage(approval.Payload)
+	c.Result = copyRawMessage(approval.Result)
+	if approval.ReviewedBy != nil {
+		reviewedBy := *approval.ReviewedBy
+		c.ReviewedBy = &reviewedBy
+	}
+	if approval.ReviewedAt != nil {
+		reviewedAt := *approval.ReviewedAt
+		c.ReviewedAt = &reviewedAt
+	}
+	if approval.CompletedAt != nil {
+		completedAt := *approval.CompletedAt
+		c.CompletedAt = &completedAt
+	}
+	return &c
+}
+
+// copyRawMessage returns a copy of raw JSON, or nil if there is none.
+func copyRawMessage(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	return append(json.RawMessage(nil), raw...)
+}
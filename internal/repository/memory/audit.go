@@ -0,0 +1,152 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// auditRepo implements the AuditRepo interface in memory.
+type auditRepo struct {
+	store *Store
+}
+
+// NewAuditRepo creates a new in-memory audit repository.
+func NewAuditRepo(store *Store) repository.AuditRepo {
+	return &auditRepo{store: store}
+}
+
+// Log creates a new audit log entry.
+func (r *auditRepo) Log(ctx context.Context, entityType string, entityID uuid.UUID, action string, details interface{}) error {
+	switch domain.EntityType(entityType) {
+	case domain.EntityUser, domain.EntityTransaction, domain.EntityBalance, domain.EntityHTTPRequest, domain.EntityTreasury, domain.EntityCurrency, domain.EntityImport, domain.EntityApproval, domain.EntityPolicy:
+	default:
+		// The audit_logs table only accepts these entity types
+		return fmt.Errorf("failed to create audit log: invalid entity type: %s", entityType)
+	}
+
+	var detailsJSON []byte
+	if details != nil {
+		var err error
+		detailsJSON, err = json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit details: %w", err)
+		}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.auditLogs = append(r.store.auditLogs, &domain.AuditLog{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Details:    detailsJSON,
+		CreatedAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// GetByID retrieves an audit log by ID.
+func (r *auditRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.AuditLog, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, log := range r.store.auditLogs {
+		if log.ID == id {
+			return copyAuditLog(log), nil
+		}
+	}
+
+	return nil, fmt.Errorf("audit log not found")
+}
+
+// List retrieves audit logs with filtering, newest first.
+func (r *auditRepo) List(_ context.Context, filter *domain.AuditLogFilter) ([]*domain.AuditLog, error) {
+	logs := r.matching(filter)
+	if filter != nil {
+		start, end := paginate(len(logs), filter.Limit, filter.Offset)
+		logs = logs[start:end]
+	}
+
+	return logs, nil
+}
+
+// ListForEntity retrieves audit logs for a specific entity, newest first.
+func (r *auditRepo) ListForEntity(_ context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*domain.AuditLog, error) {
+	et := domain.EntityType(entityType)
+	logs := r.matching(&domain.AuditLogFilter{EntityType: &et, EntityID: &entityID})
+	start, end := paginate(len(logs), limit, offset)
+
+	return logs[start:end], nil
+}
+
+// Count returns the total number of audit logs matching the filter. Pagination is ignored.
+func (r *auditRepo) Count(_ context.Context, filter *domain.AuditLogFilter) (int, error) {
+	return len(r.matching(filter)), nil
+}
+
+// DeleteOlderThan deletes audit logs of an entity type created before the given time.
+func (r *auditRepo) DeleteOlderThan(_ context.Context, entityType string, before time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	kept := r.store.auditLogs[:0]
+	var deleted int64
+	for _, log := range r.store.auditLogs {
+		if log.EntityType == entityType && log.CreatedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, log)
+	}
+	r.store.auditLogs = kept
+
+	return deleted, nil
+}
+
+// matching returns copies of the audit logs matching the filter's conditions, newest first.
+func (r *auditRepo) matching(filter *domain.AuditLogFilter) []*domain.AuditLog {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var logs []*domain.AuditLog
+	for i := len(r.store.auditLogs) - 1; i >= 0; i-- {
+		log := r.store.auditLogs[i]
+		if filter != nil {
+			if filter.EntityType != nil && log.EntityType != string(*filter.EntityType) {
+				continue
+			}
+			if filter.EntityID != nil && log.EntityID != *filter.EntityID {
+				continue
+			}
+			if filter.Action != nil && log.Action != *filter.Action {
+				continue
+			}
+			if filter.Since != nil && log.CreatedAt.Before(*filter.Since) {
+				continue
+			}
+		}
+		logs = append(logs, copyAuditLog(log))
+	}
+
+	return logs
+}
+
+// copyAuditLog returns a copy of log that shares no data with it.
+func copyAuditLog(log *domain.AuditLog) *domain.AuditLog {
+	c := *log
+	if log.Details != nil {
+		c.Details = append(json.RawMessage(nil), log.Details...)
+	}
+	return &c
+}
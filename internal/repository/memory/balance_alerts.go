@@ -0,0 +1,128 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// balanceAlertKey identifies a balance alert, like the primary key of balance_alerts.
+type balanceAlertKey struct {
+	userID   uuid.UUID
+	currency string
+}
+
+// balanceAlertsRepo implements the BalanceAlertsRepo interface in memory.
+type balanceAlertsRepo struct {
+	store *Store
+}
+
+// NewBalanceAlertsRepo creates a new in-memory balance alerts repository.
+func NewBalanceAlertsRepo(store *Store) repository.BalanceAlertsRepo {
+	return &balanceAlertsRepo{store: store}
+}
+
+// Upsert creates or replaces a user's alert threshold for a currency. Changing the threshold re-arms the alert.
+func (r *balanceAlertsRepo) Upsert(_ context.Context, alert *domain.BalanceAlert) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := balanceAlertKey{userID: alert.UserID, currency: alert.Currency}
+	stored, ok := r.store.balanceAlerts[key]
+	if !ok {
+		stored = &domain.BalanceAlert{
+			UserID:    alert.UserID,
+			Currency:  alert.Currency,
+			CreatedAt: alert.CreatedAt,
+		}
+		r.store.balanceAlerts[key] = stored
+	}
+
+	stored.Threshold = alert.Threshold
+	stored.Triggered = false
+	stored.UpdatedAt = alert.UpdatedAt
+
+	alert.Triggered = stored.Triggered
+	alert.LastTriggeredAt = copyTime(stored.LastTriggeredAt)
+	alert.CreatedAt = stored.CreatedAt
+
+	return nil
+}
+
+// Get retrieves a user's alert threshold for a currency.
+func (r *balanceAlertsRepo) Get(_ context.Context, userID uuid.UUID, currency string) (*domain.BalanceAlert, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	alert, ok := r.store.balanceAlerts[balanceAlertKey{userID: userID, currency: currency}]
+	if !ok {
+		return nil, fmt.Errorf("balance alert not found")
+	}
+
+	return copyBalanceAlert(alert), nil
+}
+
+// ListByUser retrieves a user's alert thresholds ordered by currency.
+func (r *balanceAlertsRepo) ListByUser(_ context.Context, userID uuid.UUID) ([]*domain.BalanceAlert, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var alerts []*domain.BalanceAlert
+	for key, alert := range r.store.balanceAlerts {
+		if key.userID == userID {
+			alerts = append(alerts, copyBalanceAlert(alert))
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Currency < alerts[j].Currency
+	})
+
+	return alerts, nil
+}
+
+// Delete deletes a user's alert threshold for a currency.
+func (r *balanceAlertsRepo) Delete(_ context.Context, userID uuid.UUID, currency string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := balanceAlertKey{userID: userID, currency: currency}
+	if _, ok := r.store.balanceAlerts[key]; !ok {
+		return fmt.Errorf("balance alert not found")
+	}
+	delete(r.store.balanceAlerts, key)
+
+	return nil
+}
+
+// SetTriggered moves an alert into the triggered or re-armed state and reports whether it changed.
+// Only one of several concurrent balance movements wins the transition, so an alert fires once.
+func (r *balanceAlertsRepo) SetTriggered(_ context.Context, userID uuid.UUID, currency string, triggered bool, at time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	alert, ok := r.store.balanceAlerts[balanceAlertKey{userID: userID, currency: currency}]
+	if !ok || alert.Triggered == triggered {
+		return false, nil
+	}
+
+	alert.Triggered = triggered
+	if triggered {
+		alert.LastTriggeredAt = &at
+	}
+
+	return true, nil
+}
+
+// copyBalanceAlert returns a copy of a balance alert that shares no pointers with it.
+func copyBalanceAlert(alert *domain.BalanceAlert) *domain.BalanceAlert {
+	c := *alert
+	c.LastTriggeredAt = copyTime(alert.LastTriggeredAt)
+	return &c
+}
@@ -0,0 +1,274 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// balancesRepo implements the BalancesRepo interface in memory.
+type balancesRepo struct {
+	store *Store
+	tx    *memoryTx // Transaction AddAmount buffers changes in, if bound to one
+}
+
+// NewBalancesRepo creates a new in-memory balances repository.
+func NewBalancesRepo(store *Store) repository.BalancesRepo {
+	return &balancesRepo{store: store}
+}
+
+// GetByUserID retrieves a balance by user ID.
+func (r *balancesRepo) GetByUserID(_ context.Context, userID uuid.UUID) (*domain.Balance, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stored, exists := r.store.balances[userID]
+	if !exists {
+		return nil, fmt.Errorf("balance not found for user")
+	}
+
+	balance := *stored
+	return &balance, nil
+}
+
+// Upsert creates or updates a balance. Like the database constraints, balances cannot be negative
+// and must be in a supported currency.
+func (r *balancesRepo) Upsert(_ context.Context, balance *domain.Balance) error {
+	if balance.Amount < 0 {
+		return fmt.Errorf("failed to upsert balance: amount cannot be negative")
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Balances reference the currency registry, like the foreign key in Postgres
+	if _, known := r.store.currencies[balance.Currency]; !known {
+		return fmt.Errorf("failed to upsert balance: unsupported currency: %s", balance.Currency)
+	}
+
+	balance.LastUpdatedAt = time.Now()
+	stored := *balance
+	r.store.balances[balance.UserID] = &stored
+
+	return nil
+}
+
+// CreateMany creates balances in bulk. Like the database constraints, each user has at most
+// one balance, which cannot be negative and must be in a supported currency.
+func (r *balancesRepo) CreateMany(_ context.Context, balances []*domain.Balance) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	seen := make(map[uuid.UUID]bool, len(balances))
+	for _, balance := range balances {
+		if balance.Amount < 0 {
+			return fmt.Errorf("failed to create balances: amount cannot be negative")
+		}
+		if _, known := r.store.currencies[balance.Currency]; !known {
+			return fmt.Errorf("failed to create balances: unsupported currency: %s", balance.Currency)
+		}
+		if _, exists := r.store.balances[balance.UserID]; exists || seen[balance.UserID] {
+			return fmt.Errorf("failed to create balances: duplicate user_id")
+		}
+		seen[balance.UserID] = true
+	}
+
+	now := time.Now()
+	for _, balance := range balances {
+		balance.LastUpdatedAt = now
+		stored := *balance
+		r.store.balances[balance.UserID] = &stored
+	}
+
+	return nil
+}
+
+// AddAmountTx adds amount to a user's balance within a transaction started with Store.Begin.
+// The change is applied when the transaction commits.
+func (r *balancesRepo) AddAmountTx(_ context.Context, tx interface{}, userID uuid.UUID, delta float64) error {
+	memTx, ok := tx.(*memoryTx)
+	if !ok || memTx.store != r.store {
+		return fmt.Errorf("invalid transaction type")
+	}
+
+	return memTx.addAmount(userID, delta)
+}
+
+// AddAmount adds amount to a user's balance, failing if the balance would become negative.
+// On the repositories of a unit of work the change is applied when the transaction commits.
+func (r *balancesRepo) AddAmount(_ context.Context, userID uuid.UUID, delta float64) error {
+	if r.tx != nil {
+		return r.tx.addAmount(userID, delta)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	balance, exists := r.store.balances[userID]
+	if !exists {
+		// Like the Postgres repository, a missing balance is created in USD
+		r.store.balances[userID] = &domain.Balance{UserID: userID, Amount: delta, Currency: "USD", LastUpdatedAt: time.Now()}
+		return nil
+	}
+	if balance.Amount+delta < 0 {
+		return fmt.Errorf("insufficient funds: balance would be negative (%.2f)", balance.Amount+delta)
+	}
+
+	balance.Amount += delta
+	balance.LastUpdatedAt = time.Now()
+	return nil
+}
+
+// GetHistorical retrieves historical balance snapshots, derived from the user's successful
+// transactions the same way as the Postgres repository.
+func (r *balancesRepo) GetHistorical(_ context.Context, userID uuid.UUID, limit int) ([]*domain.BalanceHistoryItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var transfers []*domain.Transaction
+	for _, row := range r.store.transactions {
+		t := &row.tx
+		if t.Type == string(domain.TypeTransfer) && t.Status == string(domain.StatusSuccess) && involves(t, userID) {
+			transfers = append(transfers, t)
+		}
+	}
+
+	var history []*domain.BalanceHistoryItem
+	var seqs []int64
+	for _, row := range r.store.transactions {
+		t := &row.tx
+		if t.Status != string(domain.StatusSuccess) {
+			continue
+		}
+
+		item := &domain.BalanceHistoryItem{UserID: userID, Timestamp: t.CreatedAt}
+		switch {
+		case t.Type == string(domain.TypeCredit) && isUser(t.ToUserID, userID) && t.FromUserID == nil:
+			item.Amount, item.Reason = t.Amount, "credit"
+		case t.Type == string(domain.TypeDebit) && isUser(t.FromUserID, userID) && t.ToUserID == nil:
+			// Debits within a second of a transfer are excluded, as in the Postgres repository
+			if nearTransfer(t, transfers) {
+				continue
+			}
+			item.Amount, item.Reason = -t.Amount, "debit"
+		case t.Type == string(domain.TypeTransfer) && isUser(t.ToUserID, userID):
+			item.Amount, item.Reason = t.Amount, "transfer_received"
+		case t.Type == string(domain.TypeTransfer) && isUser(t.FromUserID, userID):
+			item.Amount, item.Reason = -t.Amount, "transfer_sent"
+		default:
+			continue
+		}
+
+		history = append(history, item)
+		seqs = append(seqs, row.seq)
+	}
+
+	// Oldest first to compute the running balance, then newest first like the query
+	indexes := make([]int, len(history))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(a, b int) bool {
+		ia, ib := indexes[a], indexes[b]
+		if !history[ia].Timestamp.Equal(history[ib].Timestamp) {
+			return history[ia].Timestamp.Before(history[ib].Timestamp)
+		}
+		return seqs[ia] < seqs[ib]
+	})
+
+	ordered := make([]*domain.BalanceHistoryItem, len(indexes))
+	running := 0.0
+	for i, idx := range indexes {
+		running += history[idx].Amount
+		history[idx].Amount = running
+		ordered[len(indexes)-1-i] = history[idx]
+	}
+
+	start, end := paginate(len(ordered), limit, 0)
+	return ordered[start:end], nil
+}
+
+// GetAtTime retrieves the balance at a specific time by summing the user's successful transactions up to it.
+func (r *balancesRepo) GetAtTime(_ context.Context, userID uuid.UUID, timestamp string) (*domain.Balance, error) {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+	}
+	//add 1 second to ensure the filter balance at specified time
+	t = t.Add(1 * time.Second)
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	balance := &domain.Balance{UserID: userID, Currency: "USD", LastUpdatedAt: t}
+	for _, row := range r.store.transactions {
+		tx := &row.tx
+		if tx.Status != string(domain.StatusSuccess) || tx.CreatedAt.After(t) {
+			continue
+		}
+
+		balance.Amount += tx.BalanceDelta(userID)
+	}
+
+	return balance, nil
+}
+
+// GetTimeline retrieves the balance at each of the given times by summing the user's successful
+// transactions up to each of them.
+func (r *balancesRepo) GetTimeline(_ context.Context, userID uuid.UUID, times []time.Time) ([]domain.BalancePoint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	points := make([]domain.BalancePoint, len(times))
+	for i, at := range times {
+		points[i].Timestamp = at
+	}
+
+	for _, row := range r.store.transactions {
+		tx := &row.tx
+		if tx.Status != string(domain.StatusSuccess) {
+			continue
+		}
+
+		delta := tx.BalanceDelta(userID)
+		if delta == 0 {
+			continue
+		}
+
+		for i := range points {
+			if !tx.CreatedAt.After(points[i].Timestamp) {
+				points[i].Amount += delta
+			}
+		}
+	}
+
+	return points, nil
+}
+
+// isUser reports whether id is set to userID.
+func isUser(id *uuid.UUID, userID uuid.UUID) bool {
+	return id != nil && *id == userID
+}
+
+// involves reports whether a transaction moves money from or to userID.
+func involves(t *domain.Transaction, userID uuid.UUID) bool {
+	return isUser(t.FromUserID, userID) || isUser(t.ToUserID, userID)
+}
+
+// nearTransfer reports whether t was created less than a second from one of transfers.
+func nearTransfer(t *domain.Transaction, transfers []*domain.Transaction) bool {
+	for _, transfer := range transfers {
+		diff := t.CreatedAt.Sub(transfer.CreatedAt)
+		if diff > -time.Second && diff < time.Second {
+			return true
+		}
+	}
+	return false
+}
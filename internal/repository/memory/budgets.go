@@ -0,0 +1,119 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// budgetKey identifies a budget, like the primary key of budgets.
+type budgetKey struct {
+	userID   uuid.UUID
+	category string
+}
+
+// budgetsRepo implements the BudgetsRepo interface in memory.
+type budgetsRepo struct {
+	store *Store
+}
+
+// NewBudgetsRepo creates a new in-memory budgets repository.
+func NewBudgetsRepo(store *Store) repository.BudgetsRepo {
+	return &budgetsRepo{store: store}
+}
+
+// Upsert creates or replaces a user's monthly budget for a category. Changing the limit re-arms
+// the over-budget notification for the current month.
+func (r *budgetsRepo) Upsert(_ context.Context, budget *domain.Budget) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := budgetKey{userID: budget.UserID, category: budget.Category}
+	stored, ok := r.store.budgets[key]
+	if !ok {
+		stored = &domain.Budget{
+			UserID:    budget.UserID,
+			Category:  budget.Category,
+			CreatedAt: budget.CreatedAt,
+		}
+		r.store.budgets[key] = stored
+	}
+
+	stored.Limit = budget.Limit
+	stored.AlertedMonth = ""
+	stored.UpdatedAt = budget.UpdatedAt
+
+	budget.AlertedMonth = stored.AlertedMonth
+	budget.CreatedAt = stored.CreatedAt
+
+	return nil
+}
+
+// Get retrieves a user's budget for a category.
+func (r *budgetsRepo) Get(_ context.Context, userID uuid.UUID, category string) (*domain.Budget, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	budget, ok := r.store.budgets[budgetKey{userID: userID, category: category}]
+	if !ok {
+		return nil, fmt.Errorf("budget not found")
+	}
+
+	c := *budget
+	return &c, nil
+}
+
+// ListByUser retrieves a user's budgets ordered by category.
+func (r *budgetsRepo) ListByUser(_ context.Context, userID uuid.UUID) ([]*domain.Budget, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var budgets []*domain.Budget
+	for key, budget := range r.store.budgets {
+		if key.userID == userID {
+			c := *budget
+			budgets = append(budgets, &c)
+		}
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Category < budgets[j].Category
+	})
+
+	return budgets, nil
+}
+
+// Delete deletes a user's budget for a category.
+func (r *budgetsRepo) Delete(_ context.Context, userID uuid.UUID, category string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := budgetKey{userID: userID, category: category}
+	if _, ok := r.store.budgets[key]; !ok {
+		return fmt.Errorf("budget not found")
+	}
+	delete(r.store.budgets, key)
+
+	return nil
+}
+
+// MarkAlerted records that the user was told they went over a budget during a month, and reports
+// whether it wasn't recorded already. Only one of several concurrent debits wins, so the user is
+// told once a month.
+func (r *budgetsRepo) MarkAlerted(_ context.Context, userID uuid.UUID, category, month string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	budget, ok := r.store.budgets[budgetKey{userID: userID, category: category}]
+	if !ok || budget.AlertedMonth == month {
+		return false, nil
+	}
+
+	budget.AlertedMonth = month
+	return true, nil
+}
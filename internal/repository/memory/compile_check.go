@@ -0,0 +1,37 @@
+//go:build memrepo
+
+package memory
+
+import "github.com/sefa-b/go-banking-sim/internal/repository"
+
+// Compile-time interface checks
+var _ repository.DBTX = (*Store)(nil)
+var _ repository.UsersRepo = (*usersRepo)(nil)
+var _ repository.BalancesRepo = (*balancesRepo)(nil)
+var _ repository.TransactionsRepo = (*transactionsRepo)(nil)
+var _ repository.TransactionAggregatesRepo = (*transactionAggregatesRepo)(nil)
+var _ repository.AuditRepo = (*auditRepo)(nil)
+var _ repository.EventsRepo = (*eventsRepo)(nil)
+var _ repository.ScheduledTransactionsRepo = (*scheduledTransactionsRepo)(nil)
+var _ repository.ImpersonationSessionsRepo = (*impersonationSessionsRepo)(nil)
+var _ repository.KnownDevicesRepo = (*knownDevicesRepo)(nil)
+var _ repository.LoginSessionsRepo = (*loginSessionsRepo)(nil)
+var _ repository.SecurityEventsRepo = (*securityEventsRepo)(nil)
+var _ repository.AnomalyScoresRepo = (*anomalyScoresRepo)(nil)
+var _ repository.ApprovalsRepo = (*approvalsRepo)(nil)
+var _ repository.PoliciesRepo = (*policiesRepo)(nil)
+var _ repository.MonthlySummariesRepo = (*monthlySummariesRepo)(nil)
+var _ repository.DisputesRepo = (*disputesRepo)(nil)
+var _ repository.PaymentRequestsRepo = (*paymentRequestsRepo)(nil)
+var _ repository.TransferTemplatesRepo = (*transferTemplatesRepo)(nil)
+var _ repository.ContactsRepo = (*contactsRepo)(nil)
+var _ repository.NotificationsRepo = (*notificationsRepo)(nil)
+var _ repository.BalanceAlertsRepo = (*balanceAlertsRepo)(nil)
+var _ repository.BudgetsRepo = (*budgetsRepo)(nil)
+var _ repository.DormantAccountsRepo = (*dormantAccountsRepo)(nil)
+var _ repository.SearchRepo = (*searchRepo)(nil)
+var _ repository.TreasuryRepo = (*treasuryRepo)(nil)
+var _ repository.CurrenciesRepo = (*currenciesRepo)(nil)
+var _ repository.NettingRepo = (*nettingRepo)(nil)
+var _ repository.UnitOfWork = (*unitOfWork)(nil)
+var _ repository.Tx = (*unitOfWorkTx)(nil)
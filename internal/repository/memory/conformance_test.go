@@ -0,0 +1,17 @@
+//go:build memrepo
+
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/sefa-b/go-banking-sim/internal/repository/memory"
+	"github.com/sefa-b/go-banking-sim/internal/repository/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Target {
+		store := memory.NewStore()
+		return repotest.Target{Repos: store.Repositories(), DB: store, UnitOfWork: store.UnitOfWork()}
+	})
+}
@@ -0,0 +1,97 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// contactsRepo implements the ContactsRepo interface in memory.
+type contactsRepo struct {
+	store *Store
+}
+
+// NewContactsRepo creates a new in-memory contacts repository.
+func NewContactsRepo(store *Store) repository.ContactsRepo {
+	return &contactsRepo{store: store}
+}
+
+// Create saves a contact. A user saves each counterparty at most once.
+func (r *contactsRepo) Create(_ context.Context, contact *domain.Contact) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.find(contact.ID) != nil {
+		return fmt.Errorf("failed to create contact: duplicate id")
+	}
+	for _, stored := range r.store.contacts {
+		if stored.UserID == contact.UserID && stored.ContactUserID == contact.ContactUserID {
+			return fmt.Errorf("contact already exists")
+		}
+	}
+
+	c := *contact
+	r.store.contacts = append(r.store.contacts, &c)
+
+	return nil
+}
+
+// GetByID retrieves a contact by ID.
+func (r *contactsRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Contact, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	contact := r.find(id)
+	if contact == nil {
+		return nil, fmt.Errorf("contact not found")
+	}
+
+	c := *contact
+	return &c, nil
+}
+
+// ListByUser retrieves all of a user's contacts, oldest first.
+func (r *contactsRepo) ListByUser(_ context.Context, userID uuid.UUID) ([]*domain.Contact, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var contacts []*domain.Contact
+	for _, contact := range r.store.contacts {
+		if contact.UserID == userID {
+			c := *contact
+			contacts = append(contacts, &c)
+		}
+	}
+
+	return contacts, nil
+}
+
+// Delete deletes a contact.
+func (r *contactsRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	i := slices.IndexFunc(r.store.contacts, func(contact *domain.Contact) bool { return contact.ID == id })
+	if i < 0 {
+		return fmt.Errorf("contact not found")
+	}
+	r.store.contacts = slices.Delete(r.store.contacts, i, i+1)
+
+	return nil
+}
+
+// find returns the stored contact with the ID, or nil. The caller must hold the store's lock.
+func (r *contactsRepo) find(id uuid.UUID) *domain.Contact {
+	for _, contact := range r.store.contacts {
+		if contact.ID == id {
+			return contact
+		}
+	}
+	return nil
+}
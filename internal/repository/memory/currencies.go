@@ -0,0 +1,92 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// currenciesRepo implements the CurrenciesRepo interface in memory.
+type currenciesRepo struct {
+	store *Store
+}
+
+// NewCurrenciesRepo creates a new in-memory currencies repository.
+func NewCurrenciesRepo(store *Store) repository.CurrenciesRepo {
+	return &currenciesRepo{store: store}
+}
+
+// List retrieves every currency of the registry ordered by code.
+func (r *currenciesRepo) List(_ context.Context) ([]*domain.CurrencyInfo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	currencies := make([]*domain.CurrencyInfo, 0, len(r.store.currencies))
+	for _, currency := range r.store.currencies {
+		c := *currency
+		currencies = append(currencies, &c)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i].Code < currencies[j].Code })
+
+	return currencies, nil
+}
+
+// Get retrieves a currency by code.
+func (r *currenciesRepo) Get(_ context.Context, code string) (*domain.CurrencyInfo, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	currency, exists := r.store.currencies[code]
+	if !exists {
+		return nil, fmt.Errorf("currency not found")
+	}
+
+	c := *currency
+	return &c, nil
+}
+
+// Create adds a currency to the registry and opens its empty treasury atomically.
+func (r *currenciesRepo) Create(_ context.Context, currency *domain.CurrencyInfo) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.currencies[currency.Code]; exists {
+		return fmt.Errorf("currency already exists")
+	}
+
+	stored := *currency
+	r.store.currencies[currency.Code] = &stored
+	r.store.treasury[currency.Code] = &domain.TreasuryAccount{
+		ID:        uuid.New(),
+		Currency:  currency.Code,
+		CreatedAt: currency.CreatedAt,
+		UpdatedAt: currency.CreatedAt,
+	}
+
+	return nil
+}
+
+// Update replaces the symbol, decimal places and enabled flag of a currency.
+func (r *currenciesRepo) Update(_ context.Context, currency *domain.CurrencyInfo) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, exists := r.store.currencies[currency.Code]
+	if !exists {
+		return fmt.Errorf("currency not found")
+	}
+
+	stored.Symbol = currency.Symbol
+	stored.DecimalPlaces = currency.DecimalPlaces
+	stored.Enabled = currency.Enabled
+	stored.UpdatedAt = currency.UpdatedAt
+	currency.CreatedAt = stored.CreatedAt
+
+	return nil
+}
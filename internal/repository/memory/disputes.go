@@ -0,0 +1,185 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// disputesRepo implements the DisputesRepo interface in memory.
+type disputesRepo struct {
+	store *Store
+}
+
+// NewDisputesRepo creates a new in-memory disputes repository.
+func NewDisputesRepo(store *Store) repository.DisputesRepo {
+	return &disputesRepo{store: store}
+}
+
+// Create creates a new dispute.
+func (r *disputesRepo) Create(_ context.Context, dispute *domain.Dispute) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.find(dispute.ID) != nil {
+		return fmt.Errorf("failed to create dispute: duplicate id")
+	}
+	if isUnresolved(dispute.Status) {
+		for _, stored := range r.store.disputes {
+			if stored.TransactionID == dispute.TransactionID && isUnresolved(stored.Status) {
+				return fmt.Errorf("failed to create dispute: transaction already has an unresolved dispute")
+			}
+		}
+	}
+
+	stored := copyDispute(dispute)
+	stored.Resolution, stored.ResolvedBy, stored.RefundTransactionID, stored.ResolvedAt = "", nil, nil, nil
+	r.store.disputes = append(r.store.disputes, stored)
+
+	return nil
+}
+
+// GetByID retrieves a dispute by ID.
+func (r *disputesRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Dispute, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	dispute := r.find(id)
+	if dispute == nil {
+		return nil, fmt.Errorf("dispute not found")
+	}
+
+	return copyDispute(dispute), nil
+}
+
+// GetOpenByTransactionID retrieves the unresolved dispute for a transaction, if any.
+func (r *disputesRepo) GetOpenByTransactionID(_ context.Context, transactionID uuid.UUID) (*domain.Dispute, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, dispute := range r.store.disputes {
+		if dispute.TransactionID != transactionID {
+			continue
+		}
+		if isUnresolved(dispute.Status) {
+			return copyDispute(dispute), nil
+		}
+	}
+
+	return nil, fmt.Errorf("dispute not found")
+}
+
+// List retrieves disputes with filtering, newest first.
+func (r *disputesRepo) List(_ context.Context, filter *domain.DisputeFilter) ([]*domain.Dispute, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var disputes []*domain.Dispute
+	for i := len(r.store.disputes) - 1; i >= 0; i-- {
+		dispute := r.store.disputes[i]
+		if filter != nil {
+			if filter.UserID != nil && dispute.UserID != *filter.UserID {
+				continue
+			}
+			if filter.Status != nil && dispute.Status != *filter.Status {
+				continue
+			}
+		}
+		disputes = append(disputes, copyDispute(dispute))
+	}
+	sort.SliceStable(disputes, func(i, j int) bool {
+		return disputes[i].CreatedAt.After(disputes[j].CreatedAt)
+	})
+
+	if filter != nil {
+		start, end := paginate(len(disputes), filter.Limit, filter.Offset)
+		disputes = disputes[start:end]
+	}
+
+	return disputes, nil
+}
+
+// Update updates the status and resolution fields of a dispute.
+func (r *disputesRepo) Update(_ context.Context, dispute *domain.Dispute) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := r.find(dispute.ID)
+	if stored == nil {
+		return fmt.Errorf("dispute not found")
+	}
+
+	stored.Status = dispute.Status
+	stored.Resolution = dispute.Resolution
+	stored.ResolvedBy = copyID(dispute.ResolvedBy)
+	stored.RefundTransactionID = copyID(dispute.RefundTransactionID)
+	stored.UpdatedAt = dispute.UpdatedAt
+	stored.ResolvedAt = copyTime(dispute.ResolvedAt)
+
+	return nil
+}
+
+// AddComment adds a comment to a dispute.
+func (r *disputesRepo) AddComment(_ context.Context, comment *domain.DisputeComment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.find(comment.DisputeID) == nil {
+		// Like the foreign key on dispute_comments
+		return fmt.Errorf("failed to add dispute comment: dispute not found")
+	}
+
+	stored := *comment
+	r.store.disputeComments = append(r.store.disputeComments, &stored)
+
+	return nil
+}
+
+// ListComments retrieves comments for a dispute in chronological order.
+func (r *disputesRepo) ListComments(_ context.Context, disputeID uuid.UUID) ([]*domain.DisputeComment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var comments []*domain.DisputeComment
+	for _, comment := range r.store.disputeComments {
+		if comment.DisputeID == disputeID {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	return comments, nil
+}
+
+// find returns the stored dispute with the given ID, or nil. The caller must hold the store's lock.
+func (r *disputesRepo) find(id uuid.UUID) *domain.Dispute {
+	for _, dispute := range r.store.disputes {
+		if dispute.ID == id {
+			return dispute
+		}
+	}
+	return nil
+}
+
+// isUnresolved reports whether a dispute with status is still open or under review.
+func isUnresolved(status string) bool {
+	return status == string(domain.DisputeStatusOpen) || status == string(domain.DisputeStatusUnderReview)
+}
+
+// copyDispute returns a copy of a dispute that shares no pointers with it.
+func copyDispute(dispute *domain.Dispute) *domain.Dispute {
+	c := *dispute
+	c.ResolvedBy = copyID(dispute.ResolvedBy)
+	c.RefundTransactionID = copyID(dispute.RefundTransactionID)
+	c.ResolvedAt = copyTime(dispute.ResolvedAt)
+	return &c
+}
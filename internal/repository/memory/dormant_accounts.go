@@ -0,0 +1,103 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// dormantAccountsRepo implements the DormantAccountsRepo interface in memory.
+type dormantAccountsRepo struct {
+	store *Store
+}
+
+// NewDormantAccountsRepo creates a new in-memory dormant accounts repository.
+func NewDormantAccountsRepo(store *Store) repository.DormantAccountsRepo {
+	return &dormantAccountsRepo{store: store}
+}
+
+// List retrieves the active users without transactions since cutoff, longest inactive first.
+func (r *dormantAccountsRepo) List(_ context.Context, cutoff time.Time, limit, offset int) ([]*domain.DormantAccount, error) {
+	accounts := r.dormant(cutoff)
+	start, end := paginate(len(accounts), limit, offset)
+	return accounts[start:end], nil
+}
+
+// Count counts the active users without transactions since cutoff.
+func (r *dormantAccountsRepo) Count(_ context.Context, cutoff time.Time) (int, error) {
+	return len(r.dormant(cutoff)), nil
+}
+
+// MarkFlagged records that a user was announced dormant for the inactivity that started at
+// inactiveSince, and reports whether it wasn't recorded already.
+func (r *dormantAccountsRepo) MarkFlagged(_ context.Context, userID uuid.UUID, inactiveSince, _ time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if flagged, ok := r.store.dormantFlags[userID]; ok && flagged.Equal(inactiveSince) {
+		return false, nil
+	}
+	r.store.dormantFlags[userID] = inactiveSince
+
+	return true, nil
+}
+
+// dormant returns the active users inactive since before cutoff, longest inactive first.
+func (r *dormantAccountsRepo) dormant(cutoff time.Time) []*domain.DormantAccount {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	lastActivity := make(map[uuid.UUID]time.Time)
+	seen := func(id *uuid.UUID, at time.Time) {
+		if id != nil && at.After(lastActivity[*id]) {
+			lastActivity[*id] = at
+		}
+	}
+	for _, row := range r.store.transactions {
+		seen(row.tx.FromUserID, row.tx.CreatedAt)
+		seen(row.tx.ToUserID, row.tx.CreatedAt)
+	}
+
+	var accounts []*domain.DormantAccount
+	for _, user := range r.store.users {
+		if !user.IsActive {
+			continue
+		}
+
+		account := &domain.DormantAccount{
+			UserID:        user.ID,
+			Username:      user.Username,
+			Email:         user.Email,
+			AccountNumber: user.AccountNumber,
+			InactiveSince: user.CreatedAt,
+			CreatedAt:     user.CreatedAt,
+		}
+		if at, ok := lastActivity[user.ID]; ok {
+			account.LastActivityAt = &at
+			account.InactiveSince = at
+		}
+		if !account.InactiveSince.Before(cutoff) {
+			continue
+		}
+		if balance, ok := r.store.balances[user.ID]; ok {
+			account.Currency = balance.Currency
+			account.Balance = balance.Amount
+		}
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if !accounts[i].InactiveSince.Equal(accounts[j].InactiveSince) {
+			return accounts[i].InactiveSince.Before(accounts[j].InactiveSince)
+		}
+		return accounts[i].UserID.String() < accounts[j].UserID.String()
+	})
+
+	return accounts
+}
@@ -0,0 +1,182 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// eventsRepo implements the EventsRepo interface in memory.
+type eventsRepo struct {
+	store *Store
+}
+
+// NewEventsRepo creates a new in-memory event store.
+func NewEventsRepo(store *Store) repository.EventsRepo {
+	return &eventsRepo{store: store}
+}
+
+// AppendEvent appends a new event to the event store
+func (r *eventsRepo) AppendEvent(_ context.Context, event *domain.Event) (*domain.Event, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.append(event)
+
+	return event, nil
+}
+
+// AppendEvents appends multiple events atomically
+func (r *eventsRepo) AppendEvents(_ context.Context, events []*domain.Event) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, event := range events {
+		r.append(event)
+	}
+
+	return nil
+}
+
+// ImportEvents stores events as given, keeping their IDs, versions and timestamps, and skips the
+// ones already stored
+func (r *eventsRepo) ImportEvents(_ context.Context, events []*domain.Event) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := make(map[uuid.UUID]bool, len(r.store.events))
+	for _, event := range r.store.events {
+		stored[event.ID] = true
+	}
+
+	imported := 0
+	for _, event := range events {
+		if stored[event.ID] {
+			continue
+		}
+		stored[event.ID] = true
+		r.store.events = append(r.store.events, copyEvent(event))
+		imported++
+	}
+
+	// Reads expect events in creation order
+	sort.SliceStable(r.store.events, func(i, j int) bool {
+		return r.store.events[i].CreatedAt.Before(r.store.events[j].CreatedAt)
+	})
+
+	return imported, nil
+}
+
+// append versions and stores an event. The caller must hold s.mu.
+func (r *eventsRepo) append(event *domain.Event) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	event.Version = r.currentVersion(event.AggregateType, event.AggregateID) + 1
+	event.CreatedAt = time.Now()
+
+	r.store.events = append(r.store.events, copyEvent(event))
+}
+
+// GetEventsByAggregate retrieves all events for a specific aggregate, oldest version first
+func (r *eventsRepo) GetEventsByAggregate(_ context.Context, aggregateType domain.AggregateType, aggregateID uuid.UUID) ([]*domain.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// Events are stored in append order, so an aggregate's versions are ascending
+	var events []*domain.Event
+	for _, event := range r.store.events {
+		if event.AggregateType == string(aggregateType) && event.AggregateID == aggregateID {
+			events = append(events, copyEvent(event))
+		}
+	}
+
+	return events, nil
+}
+
+// GetEventsByType retrieves events by event type, newest first
+func (r *eventsRepo) GetEventsByType(_ context.Context, eventType domain.EventType, limit int, offset int) ([]*domain.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []*domain.Event
+	for i := len(r.store.events) - 1; i >= 0; i-- {
+		if r.store.events[i].EventType == string(eventType) {
+			events = append(events, copyEvent(r.store.events[i]))
+		}
+	}
+
+	start, end := paginate(len(events), limit, offset)
+	return events[start:end], nil
+}
+
+// GetEventsSince retrieves up to limit events created after since, oldest first
+func (r *eventsRepo) GetEventsSince(_ context.Context, since time.Time, limit int) ([]*domain.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []*domain.Event
+	for _, event := range r.store.events {
+		if event.CreatedAt.After(since) {
+			events = append(events, copyEvent(event))
+		}
+	}
+
+	start, end := paginate(len(events), limit, 0)
+	return events[start:end], nil
+}
+
+// GetAggregateVersion returns the current version of an aggregate
+func (r *eventsRepo) GetAggregateVersion(_ context.Context, aggregateType domain.AggregateType, aggregateID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.currentVersion(string(aggregateType), aggregateID), nil
+}
+
+// currentVersion returns the highest version of an aggregate, or 0 without events. The caller must hold s.mu.
+func (r *eventsRepo) currentVersion(aggregateType string, aggregateID uuid.UUID) int {
+	version := 0
+	for _, event := range r.store.events {
+		if event.AggregateType == aggregateType && event.AggregateID == aggregateID && event.Version > version {
+			version = event.Version
+		}
+	}
+	return version
+}
+
+// LoadEventEnvelope loads an event with its deserialized data
+func (r *eventsRepo) LoadEventEnvelope(_ context.Context, event *domain.Event, target interface{}) (*repository.EventEnvelope, error) {
+	err := event.UnmarshalData(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
+	}
+
+	metadata, err := event.UnmarshalMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event metadata: %w", err)
+	}
+
+	return &repository.EventEnvelope{
+		Event:    event,
+		Data:     target,
+		Metadata: metadata,
+	}, nil
+}
+
+// copyEvent returns a copy of event that shares no data with it.
+func copyEvent(event *domain.Event) *domain.Event {
+	c := *event
+	c.EventData = append([]byte(nil), event.EventData...)
+	if event.EventMetadata != nil {
+		c.EventMetadata = append([]byte(nil), event.EventMetadata...)
+	}
+	return &c
+}
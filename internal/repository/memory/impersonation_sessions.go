@@ -0,0 +1,59 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// impersonationSessionsRepo implements the ImpersonationSessionsRepo interface in memory.
+type impersonationSessionsRepo struct {
+	store *Store
+}
+
+// NewImpersonationSessionsRepo creates a new in-memory impersonation sessions repository.
+func NewImpersonationSessionsRepo(store *Store) repository.ImpersonationSessionsRepo {
+	return &impersonationSessionsRepo{store: store}
+}
+
+// Create records a new impersonation session.
+func (r *impersonationSessionsRepo) Create(_ context.Context, session *domain.ImpersonationSession) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, stored := range r.store.impersonations {
+		if stored.ID == session.ID {
+			return fmt.Errorf("failed to create impersonation session: duplicate id")
+		}
+	}
+
+	stored := *session
+	r.store.impersonations = append(r.store.impersonations, &stored)
+
+	return nil
+}
+
+// ListActive retrieves impersonation sessions that have not expired at the given time, newest first.
+func (r *impersonationSessionsRepo) ListActive(_ context.Context, now time.Time) ([]*domain.ImpersonationSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var sessions []*domain.ImpersonationSession
+	for i := len(r.store.impersonations) - 1; i >= 0; i-- {
+		if session := r.store.impersonations[i]; session.ExpiresAt.After(now) {
+			c := *session
+			sessions = append(sessions, &c)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	return sessions, nil
+}
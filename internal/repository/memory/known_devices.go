@@ -0,0 +1,65 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// knownDeviceKey identifies a known device, like the primary key of known_devices.
+type knownDeviceKey struct {
+	userID      uuid.UUID
+	fingerprint string
+}
+
+// knownDevicesRepo implements the KnownDevicesRepo interface in memory.
+type knownDevicesRepo struct {
+	store *Store
+}
+
+// NewKnownDevicesRepo creates a new in-memory known devices repository.
+func NewKnownDevicesRepo(store *Store) repository.KnownDevicesRepo {
+	return &knownDevicesRepo{store: store}
+}
+
+// Touch records that a user logged in from the device with the fingerprint at the given time,
+// reporting whether the device was new to the user.
+func (r *knownDevicesRepo) Touch(_ context.Context, userID uuid.UUID, fingerprint string, at time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := knownDeviceKey{userID: userID, fingerprint: fingerprint}
+	if device, ok := r.store.knownDevices[key]; ok {
+		if at.After(device.LastSeenAt) {
+			device.LastSeenAt = at
+		}
+		return false, nil
+	}
+
+	r.store.knownDevices[key] = &domain.KnownDevice{
+		UserID:      userID,
+		Fingerprint: fingerprint,
+		FirstSeenAt: at,
+		LastSeenAt:  at,
+	}
+	return true, nil
+}
+
+// Count returns the number of devices a user has logged in from.
+func (r *knownDevicesRepo) Count(_ context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for key := range r.store.knownDevices {
+		if key.userID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
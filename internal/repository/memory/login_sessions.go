@@ -0,0 +1,108 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// loginSessionsRepo implements the LoginSessionsRepo interface in memory.
+type loginSessionsRepo struct {
+	store *Store
+}
+
+// NewLoginSessionsRepo creates a new in-memory login sessions repository.
+func NewLoginSessionsRepo(store *Store) repository.LoginSessionsRepo {
+	return &loginSessionsRepo{store: store}
+}
+
+// Create records a new login session.
+func (r *loginSessionsRepo) Create(_ context.Context, session *domain.LoginSession) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.find(session.ID) != nil {
+		return fmt.Errorf("failed to create login session: duplicate id")
+	}
+
+	stored := *session
+	r.store.loginSessions = append(r.store.loginSessions, &stored)
+
+	return nil
+}
+
+// GetByID retrieves a login session by ID.
+func (r *loginSessionsRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.LoginSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	session := r.find(id)
+	if session == nil {
+		return nil, fmt.Errorf("login session not found")
+	}
+
+	return copyLoginSession(session), nil
+}
+
+// ListActive retrieves a user's sessions that are neither revoked nor expired at the given time,
+// newest first.
+func (r *loginSessionsRepo) ListActive(_ context.Context, userID uuid.UUID, now time.Time) ([]*domain.LoginSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var sessions []*domain.LoginSession
+	for i := len(r.store.loginSessions) - 1; i >= 0; i-- {
+		if session := r.store.loginSessions[i]; session.UserID == userID && session.IsActive(now) {
+			sessions = append(sessions, copyLoginSession(session))
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	return sessions, nil
+}
+
+// Revoke marks one of a user's sessions revoked at the given time.
+func (r *loginSessionsRepo) Revoke(_ context.Context, id, userID uuid.UUID, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session := r.find(id)
+	if session == nil || session.UserID != userID {
+		return fmt.Errorf("login session not found")
+	}
+	if session.RevokedAt != nil {
+		return fmt.Errorf("login session already revoked")
+	}
+
+	session.RevokedAt = &at
+	return nil
+}
+
+// find returns the stored session with the ID, or nil. The caller must hold the store's lock.
+func (r *loginSessionsRepo) find(id uuid.UUID) *domain.LoginSession {
+	for _, session := range r.store.loginSessions {
+		if session.ID == id {
+			return session
+		}
+	}
+	return nil
+}
+
+// copyLoginSession returns a copy of a stored session that shares no memory with it.
+func copyLoginSession(session *domain.LoginSession) *domain.LoginSession {
+	c := *session
+	if session.RevokedAt != nil {
+		revokedAt := *session.RevokedAt
+		c.RevokedAt = &revokedAt
+	}
+	return &c
+}
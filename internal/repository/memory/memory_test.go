@@ -0,0 +1,489 @@
+//go:build memrepo
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/repository/memory"
+	"github.com/sefa-b/go-banking-sim/internal/service"
+)
+
+// newUser creates an active user with an empty USD balance.
+func newUser(t *testing.T, repos *repository.Repositories, username string) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	user := &domain.User{Username: username, Email: username + "@example.com", PasswordHash: "!", Role: string(domain.RoleUser)}
+	if err := repos.Users.Create(ctx, user); err != nil {
+		t.Fatalf("create user %s: %v", username, err)
+	}
+	if err := repos.Balances.Upsert(ctx, &domain.Balance{UserID: user.ID, Currency: "USD"}); err != nil {
+		t.Fatalf("create balance %s: %v", username, err)
+	}
+
+	return user.ID
+}
+
+func TestTransactionServiceOnMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repos := store.Repositories()
+
+	alice := newUser(t, repos, "alice")
+	bob := newUser(t, repos, "bob")
+
+	treasury := service.NewTreasuryService(repos)
+	if _, err := treasury.Mint(ctx, uuid.New(), "USD", &domain.TreasuryOperationRequest{Amount: 1000, Reason: "test"}); err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+
+	transactions := service.NewTransactionService(repos, service.NewBalanceService(repos), nil, service.NewEventService(repos.Events), store.UnitOfWork())
+	if _, err := transactions.Credit(ctx, alice, &domain.CreditRequest{Amount: 100, Currency: "USD"}); err != nil {
+		t.Fatalf("credit: %v", err)
+	}
+	if _, err := transactions.Transfer(ctx, alice, &domain.TransferRequest{ToUserID: bob, Amount: 40, Currency: "USD"}); err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if _, err := transactions.Transfer(ctx, alice, &domain.TransferRequest{ToUserID: bob, Amount: 100, Currency: "USD"}); err == nil || !strings.Contains(err.Error(), "insufficient funds") {
+		t.Fatalf("overdrawing transfer: err = %v, want insufficient funds", err)
+	}
+
+	for userID, want := range map[uuid.UUID]float64{alice: 60, bob: 40} {
+		balance, err := repos.Balances.GetByUserID(ctx, userID)
+		if err != nil {
+			t.Fatalf("get balance: %v", err)
+		}
+		if balance.Amount != want {
+			t.Errorf("balance = %.2f, want %.2f", balance.Amount, want)
+		}
+	}
+
+	history, err := repos.Transactions.ListForUser(ctx, alice, &domain.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("list transactions: %v", err)
+	}
+	if len(history) != 2 || history[0].Type != string(domain.TypeTransfer) || history[1].Type != string(domain.TypeCredit) {
+		t.Fatalf("history = %+v, want transfer then credit", history)
+	}
+
+	snapshots, err := repos.Balances.GetHistorical(ctx, alice, 10)
+	if err != nil {
+		t.Fatalf("balance history: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Amount != 60 || snapshots[1].Amount != 100 {
+		t.Errorf("balance history = %+v, want running balances 60 then 100", snapshots)
+	}
+
+	supply, err := treasury.ListSupply(ctx)
+	if err != nil {
+		t.Fatalf("list supply: %v", err)
+	}
+	for _, s := range supply {
+		if s.Currency == "USD" && (s.Circulating != 100 || s.UserBalances != 100) {
+			t.Errorf("USD circulating = %.2f, user balances = %.2f, want 100", s.Circulating, s.UserBalances)
+		}
+	}
+}
+
+// deadlineAfterCommit is a unit of work that cancels the request context right after each of its
+// transactions commits, like a request deadline passing at that moment.
+type deadlineAfterCommit struct {
+	repository.UnitOfWork
+	cancel context.CancelFunc
+}
+
+func (u deadlineAfterCommit) Begin(ctx context.Context) (repository.Tx, error) {
+	tx, err := u.UnitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deadlineAfterCommitTx{Tx: tx, cancel: u.cancel}, nil
+}
+
+type deadlineAfterCommitTx struct {
+	repository.Tx
+	cancel context.CancelFunc
+}
+
+func (t deadlineAfterCommitTx) Commit(ctx context.Context) error {
+	err := t.Tx.Commit(ctx)
+	t.cancel()
+	return err
+}
+
+// contextTransactions fails writes once their context is done, like the Postgres repository.
+type contextTransactions struct {
+	repository.TransactionsRepo
+}
+
+func (r contextTransactions) MarkCompleted(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.TransactionsRepo.MarkCompleted(ctx, id)
+}
+
+func TestTransferCompletesWhenDeadlinePassesAfterCommit(t *testing.T) {
+	store := memory.NewStore()
+	repos := store.Repositories()
+	repos.Transactions = contextTransactions{repos.Transactions}
+
+	alice := newUser(t, repos, "alice")
+	bob := newUser(t, repos, "bob")
+	if err := repos.Balances.Upsert(context.Background(), &domain.Balance{UserID: alice, Amount: 100, Currency: "USD"}); err != nil {
+		t.Fatalf("fund: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uow := deadlineAfterCommit{UnitOfWork: store.UnitOfWork(), cancel: cancel}
+	transactions := service.NewTransactionService(repos, service.NewBalanceService(repos), nil, service.NewEventService(repos.Events), uow)
+
+	response, err := transactions.Transfer(ctx, alice, &domain.TransferRequest{ToUserID: bob, Amount: 40, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("the deadline did not pass during the transfer")
+	}
+
+	stored, err := repos.Transactions.GetByID(context.Background(), response.ID)
+	if err != nil || stored.Status != string(domain.StatusSuccess) {
+		t.Errorf("transfer = %+v, %v; want it completed", stored, err)
+	}
+	for userID, want := range map[uuid.UUID]float64{alice: 60, bob: 40} {
+		if balance, _ := repos.Balances.GetByUserID(context.Background(), userID); balance.Amount != want {
+			t.Errorf("balance = %.2f, want %.2f", balance.Amount, want)
+		}
+	}
+}
+
+// failingCredits fails every balance increase, like a receiver whose balance row cannot be written.
+type failingCredits struct {
+	repository.BalancesRepo
+}
+
+func (r failingCredits) AddAmount(ctx context.Context, userID uuid.UUID, amount float64) error {
+	if amount > 0 {
+		return errors.New("balance locked")
+	}
+	return r.BalancesRepo.AddAmount(ctx, userID, amount)
+}
+
+func TestFailedTransferIsRecordedFailed(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repos := store.Repositories()
+
+	alice := newUser(t, repos, "alice")
+	bob := newUser(t, repos, "bob")
+	if err := repos.Balances.Upsert(ctx, &domain.Balance{UserID: alice, Amount: 100, Currency: "USD"}); err != nil {
+		t.Fatalf("fund: %v", err)
+	}
+
+	uow := repository.DecorateUnitOfWork(store.UnitOfWork(), func(repos *repository.Repositories) {
+		repos.Balances = failingCredits{repos.Balances}
+	})
+	transactions := service.NewTransactionService(repos, service.NewBalanceService(repos), nil, service.NewEventService(repos.Events), uow)
+
+	if _, err := transactions.Transfer(ctx, alice, &domain.TransferRequest{ToUserID: bob, Amount: 40, Currency: "USD"}); err == nil {
+		t.Fatal("transfer to a locked balance succeeded")
+	}
+
+	history, err := repos.Transactions.ListForUser(ctx, alice, &domain.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("list transactions: %v", err)
+	}
+	if len(history) != 1 || history[0].Status != string(domain.StatusFailed) {
+		t.Errorf("history = %+v, want one failed transfer", history)
+	}
+	if balance, _ := repos.Balances.GetByUserID(ctx, alice); balance.Amount != 100 {
+		t.Errorf("balance = %.2f after failed transfer, want 100", balance.Amount)
+	}
+}
+
+// fixedPolicy is a policy source whose policy never changes.
+type fixedPolicy struct {
+	policy *domain.Policy
+}
+
+func (p fixedPolicy) Current() *domain.Policy {
+	return p.policy
+}
+
+func TestNettedTransferFees(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repos := store.Repositories()
+
+	alice := newUser(t, repos, "alice")
+	bob := newUser(t, repos, "bob")
+	if err := repos.Balances.Upsert(ctx, &domain.Balance{UserID: alice, Amount: 100, Currency: "USD"}); err != nil {
+		t.Fatalf("fund: %v", err)
+	}
+
+	transactions := service.NewTransactionService(repos, service.NewBalanceService(repos), nil, service.NewEventService(repos.Events), store.UnitOfWork()).(*service.TransactionServiceImpl)
+	transactions.SetNetting(time.Nanosecond, 50, 10)
+	transactions.SetPolicySource(fixedPolicy{&domain.Policy{TransferFees: map[string]domain.TransferFee{"USD": {Fixed: 1}}}})
+
+	expectBalance := func(userID uuid.UUID, want float64) {
+		t.Helper()
+		if balance, _ := repos.Balances.GetByUserID(ctx, userID); balance.Amount != want {
+			t.Errorf("balance = %.2f, want %.2f", balance.Amount, want)
+		}
+	}
+
+	if _, err := transactions.Transfer(ctx, alice, &domain.TransferRequest{ToUserID: bob, Amount: 30, Currency: "USD"}); err != nil {
+		t.Fatalf("netted transfer: %v", err)
+	}
+	expectBalance(alice, 100)
+
+	// Debits cannot spend what the queued transfer reserved
+	if _, err := transactions.Debit(ctx, alice, &domain.DebitRequest{Amount: 80, Currency: "USD"}); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("debit of reserved money: err = %v, want insufficient funds", err)
+	}
+
+	// The fee is charged when the batch settles
+	if settled, err := transactions.SettleDueNetting(ctx); err != nil || settled != 1 {
+		t.Fatalf("settle = %d, %v; want 1", settled, err)
+	}
+	expectBalance(alice, 69)
+	expectBalance(bob, 30)
+
+	// A failed batch charges no fee
+	if _, err := transactions.Transfer(ctx, alice, &domain.TransferRequest{ToUserID: bob, Amount: 30, Currency: "USD"}); err != nil {
+		t.Fatalf("netted transfer: %v", err)
+	}
+	if err := repos.Balances.Upsert(ctx, &domain.Balance{UserID: alice, Amount: 10, Currency: "USD"}); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if settled, err := transactions.SettleDueNetting(ctx); err != nil || settled != 0 {
+		t.Fatalf("settle = %d, %v; want 0", settled, err)
+	}
+	expectBalance(alice, 10)
+	expectBalance(bob, 30)
+}
+
+func TestApprovedBalanceAdjustment(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repos := store.Repositories()
+
+	alice := newUser(t, repos, "alice")
+	requester, reviewer := uuid.New(), uuid.New()
+	if _, err := service.NewTreasuryService(repos).Mint(ctx, requester, "USD", &domain.TreasuryOperationRequest{Amount: 1000, Reason: "test"}); err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+
+	transactions := service.NewTransactionService(repos, service.NewBalanceService(repos), nil, service.NewEventService(repos.Events), store.UnitOfWork())
+	approvals := service.NewApprovalService(repos, transactions, nil, []domain.ApprovalAction{domain.ApprovalBalanceAdjustment})
+
+	adjust := func(kind domain.TransactionType, amount float64) *domain.Approval {
+		t.Helper()
+		payload := &domain.BalanceAdjustmentApproval{
+			UserID:  alice,
+			Request: domain.BalanceAdjustmentRequest{Type: kind, Amount: amount, Currency: "USD", Reason: "correction"},
+		}
+		if !approvals.Required(ctx, domain.ApprovalBalanceAdjustment, payload) {
+			t.Fatal("balance adjustment does not require approval")
+		}
+		approval, err := approvals.Submit(ctx, requester, domain.ApprovalBalanceAdjustment, payload)
+		if err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+		approval, err = approvals.Approve(ctx, approval.ID, reviewer, "")
+		if err != nil {
+			t.Fatalf("approve: %v", err)
+		}
+		return approval
+	}
+	expectBalance := func(want float64) {
+		t.Helper()
+		if balance, err := repos.Balances.GetByUserID(ctx, alice); err != nil || balance.Amount != want {
+			t.Errorf("balance = %+v, %v; want %.2f", balance, err, want)
+		}
+	}
+
+	if approval := adjust(domain.TypeCredit, 50); approval.Status != domain.ApprovalExecuted {
+		t.Fatalf("credit adjustment = %s (%s), want executed", approval.Status, approval.Error)
+	}
+	expectBalance(50)
+
+	if approval := adjust(domain.TypeDebit, 20); approval.Status != domain.ApprovalExecuted {
+		t.Fatalf("debit adjustment = %s (%s), want executed", approval.Status, approval.Error)
+	}
+	expectBalance(30)
+
+	// Adjustments cannot overdraw the balance
+	if approval := adjust(domain.TypeDebit, 40); approval.Status != domain.ApprovalFailed || !strings.Contains(approval.Error, "insufficient funds") {
+		t.Errorf("overdrawing adjustment = %s (%s), want failed with insufficient funds", approval.Status, approval.Error)
+	}
+	expectBalance(30)
+
+	history, err := repos.Transactions.ListForUser(ctx, alice, &domain.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("list transactions: %v", err)
+	}
+	if len(history) != 2 || history[0].Type != string(domain.TypeDebit) || history[1].Type != string(domain.TypeCredit) || history[0].Description != "correction" {
+		t.Errorf("history = %+v, want the debit and credit described by their reason", history)
+	}
+}
+
+func TestConcurrentTransfersNeverOverdraw(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repos := store.Repositories()
+	balances := repos.Balances
+
+	from := newUser(t, repos, "from")
+	to := newUser(t, repos, "to")
+	if err := balances.Upsert(ctx, &domain.Balance{UserID: from, Amount: 50, Currency: "USD"}); err != nil {
+		t.Fatalf("fund: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := store.Begin(ctx)
+			if err != nil {
+				t.Errorf("begin: %v", err)
+				return
+			}
+			defer func() { _ = tx.Rollback(ctx) }()
+			if err := balances.AddAmountTx(ctx, tx, from, -10); err != nil {
+				return
+			}
+			if err := balances.AddAmountTx(ctx, tx, to, 10); err != nil {
+				return
+			}
+			_ = tx.Commit(ctx)
+		}()
+	}
+	wg.Wait()
+
+	fromBalance, _ := balances.GetByUserID(ctx, from)
+	toBalance, _ := balances.GetByUserID(ctx, to)
+	if fromBalance.Amount != 0 || toBalance.Amount != 50 {
+		t.Errorf("balances = %.2f and %.2f, want 0 and 50", fromBalance.Amount, toBalance.Amount)
+	}
+}
+
+func TestRollbackDiscardsBalanceChanges(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repos := store.Repositories()
+	userID := newUser(t, repos, "user")
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := repos.Balances.AddAmountTx(ctx, tx, userID, 25); err != nil {
+		t.Fatalf("add amount: %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	balance, _ := repos.Balances.GetByUserID(ctx, userID)
+	if balance.Amount != 0 {
+		t.Errorf("balance = %.2f after rollback, want 0", balance.Amount)
+	}
+
+	if err := repos.Balances.AddAmountTx(ctx, "not a transaction", userID, 1); err == nil {
+		t.Error("AddAmountTx accepted a foreign transaction")
+	}
+}
+
+func TestUsersRepoSemantics(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewStore().Repositories()
+
+	userID := newUser(t, repos, "carol")
+	if err := repos.Users.Create(ctx, &domain.User{Username: "carol", Email: "other@example.com", Role: string(domain.RoleUser)}); err == nil {
+		t.Error("duplicate username was accepted")
+	}
+
+	user, err := repos.Users.GetByUsername(ctx, "carol")
+	if err != nil || user.ID != userID {
+		t.Fatalf("GetByUsername = %v, %v", user, err)
+	}
+	user.Email = "changed@example.com"
+	stored, _ := repos.Users.GetByID(ctx, userID)
+	if stored.Email != "carol@example.com" {
+		t.Error("mutating a returned user changed the store")
+	}
+
+	if err := repos.Users.Delete(ctx, userID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := repos.Users.GetByID(ctx, userID); err == nil || err.Error() != "user not found" {
+		t.Errorf("GetByID after delete: err = %v, want user not found", err)
+	}
+	if err := repos.Users.Delete(ctx, userID); err == nil {
+		t.Error("deleting an inactive user succeeded")
+	}
+	if count, _ := repos.Users.Count(ctx); count != 0 {
+		t.Errorf("count = %d, want 0", count)
+	}
+}
+
+func TestScheduledTransactionClaims(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewStore().Repositories()
+	scheduled := repos.ScheduledTransactions
+
+	past := time.Now().Add(-time.Minute)
+	st := &domain.ScheduledTransaction{
+		ID:              uuid.New(),
+		UserID:          uuid.New(),
+		TransactionType: string(domain.TypeCredit),
+		Amount:          10,
+		Currency:        "USD",
+		ScheduleType:    "once",
+		ExecuteAt:       past,
+		Status:          "active",
+		IsActive:        true,
+		CreatedAt:       past,
+		UpdatedAt:       past,
+	}
+	if err := scheduled.Create(ctx, st); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	claimed, err := scheduled.ClaimDueForExecution(ctx, "a", time.Now(), time.Minute, 10)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("first claim = %d, %v; want 1", len(claimed), err)
+	}
+	if claimed, _ := scheduled.ClaimDueForExecution(ctx, "b", time.Now(), time.Minute, 10); len(claimed) != 0 {
+		t.Errorf("claim during lease = %d, want 0", len(claimed))
+	}
+
+	// Only the owner can release its claim
+	_ = scheduled.ReleaseClaim(ctx, st.ID, "b")
+	if claimed, _ := scheduled.ClaimDueForExecution(ctx, "b", time.Now(), time.Minute, 10); len(claimed) != 0 {
+		t.Errorf("claim after foreign release = %d, want 0", len(claimed))
+	}
+	_ = scheduled.ReleaseClaim(ctx, st.ID, "a")
+	if claimed, _ := scheduled.ClaimDueForExecution(ctx, "b", time.Now(), time.Minute, 10); len(claimed) != 1 {
+		t.Errorf("claim after release = %d, want 1", len(claimed))
+	}
+
+	if err := scheduled.Delete(ctx, st.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := scheduled.GetByID(ctx, st.ID); err == nil || err.Error() != "scheduled transaction not found" {
+		t.Errorf("GetByID after delete: err = %v, want scheduled transaction not found", err)
+	}
+}
@@ -0,0 +1,61 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// monthlySummaryKey identifies a user's summary of a month.
+type monthlySummaryKey struct {
+	userID uuid.UUID
+	month  string
+}
+
+// monthlySummariesRepo implements the MonthlySummariesRepo interface in memory.
+type monthlySummariesRepo struct {
+	store *Store
+}
+
+// NewMonthlySummariesRepo creates a new in-memory monthly summaries repository.
+func NewMonthlySummariesRepo(store *Store) repository.MonthlySummariesRepo {
+	return &monthlySummariesRepo{store: store}
+}
+
+// Create stores a summary unless the user already has one for the month, and reports whether it was stored.
+func (r *monthlySummariesRepo) Create(_ context.Context, summary *domain.MonthlySummary) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := monthlySummaryKey{userID: summary.UserID, month: summary.Month}
+	if _, ok := r.store.monthlySummaries[key]; ok {
+		return false, nil
+	}
+
+	r.store.monthlySummaries[key] = copyMonthlySummary(summary)
+	return true, nil
+}
+
+// Get retrieves the user's summary of a yyyy-mm month.
+func (r *monthlySummariesRepo) Get(_ context.Context, userID uuid.UUID, month string) (*domain.MonthlySummary, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	summary, ok := r.store.monthlySummaries[monthlySummaryKey{userID: userID, month: month}]
+	if !ok {
+		return nil, fmt.Errorf("monthly summary not found")
+	}
+	return copyMonthlySummary(summary), nil
+}
+
+// copyMonthlySummary returns a copy of a summary that shares no memory with it.
+func copyMonthlySummary(summary *domain.MonthlySummary) *domain.MonthlySummary {
+	c := *summary
+	c.TopCounterparties = append([]domain.SummaryCounterparty{}, summary.TopCounterparties...)
+	return &c
+}
@@ -0,0 +1,199 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// nettedTransferRow is a netted transfer with the batch it belongs to, like a row of
+// netting_batch_transfers.
+type nettedTransferRow struct {
+	batchID  uuid.UUID
+	transfer domain.NettedTransfer
+}
+
+// nettingRepo implements the NettingRepo interface in memory.
+type nettingRepo struct {
+	store *Store
+}
+
+// NewNettingRepo creates a new in-memory netting batches repository.
+func NewNettingRepo(store *Store) repository.NettingRepo {
+	return &nettingRepo{store: store}
+}
+
+// OpenBatch returns the open batch of a pair of users in a currency, opening one if there is none.
+// The store lock serialises callers, so there is no row lock to take.
+func (r *nettingRepo) OpenBatch(_ context.Context, userAID, userBID uuid.UUID, currency string, closesAt time.Time) (*domain.NettingBatch, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if batch := r.openBatch(userAID, userBID, currency); batch != nil {
+		return copyNettingBatch(batch), nil
+	}
+
+	batch := &domain.NettingBatch{
+		ID:       uuid.New(),
+		UserAID:  userAID,
+		UserBID:  userBID,
+		Currency: currency,
+		Status:   domain.NettingOpen,
+		OpenedAt: time.Now(),
+		ClosesAt: closesAt,
+	}
+	r.store.nettingBatches = append(r.store.nettingBatches, batch)
+
+	return copyNettingBatch(batch), nil
+}
+
+// AddTransfer records a pending transfer in an open batch.
+func (r *nettingRepo) AddTransfer(_ context.Context, batchID uuid.UUID, transfer *domain.NettedTransfer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if batch := r.batch(batchID); batch == nil || batch.Status != domain.NettingOpen {
+		return fmt.Errorf("netting batch not open")
+	}
+	for _, row := range r.store.nettedTransfers {
+		if row.transfer.TransactionID == transfer.TransactionID {
+			return fmt.Errorf("failed to add netted transfer: transaction already netted")
+		}
+	}
+
+	r.store.nettedTransfers = append(r.store.nettedTransfers, &nettedTransferRow{batchID: batchID, transfer: *transfer})
+
+	return nil
+}
+
+// ReservedAmount sums a user's outgoing transfers waiting in open batches of a currency.
+func (r *nettingRepo) ReservedAmount(_ context.Context, userID uuid.UUID, currency string) (float64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var reserved float64
+	for _, row := range r.store.nettedTransfers {
+		if row.transfer.FromUserID != userID {
+			continue
+		}
+		batch := r.batch(row.batchID)
+		if batch.Status == domain.NettingOpen && batch.Currency == currency {
+			reserved += row.transfer.Amount
+		}
+	}
+
+	return reserved, nil
+}
+
+// ListDue retrieves open batches whose window closed, oldest first.
+func (r *nettingRepo) ListDue(_ context.Context, now time.Time, limit int) ([]*domain.NettingBatch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var batches []*domain.NettingBatch
+	for _, batch := range r.store.nettingBatches {
+		if batch.Status == domain.NettingOpen && !batch.ClosesAt.After(now) {
+			batches = append(batches, copyNettingBatch(batch))
+		}
+	}
+	sort.SliceStable(batches, func(i, j int) bool { return batches[i].ClosesAt.Before(batches[j].ClosesAt) })
+
+	if limit > 0 && len(batches) > limit {
+		batches = batches[:limit]
+	}
+
+	return batches, nil
+}
+
+// ListTransfers retrieves the transfers of a batch.
+func (r *nettingRepo) ListTransfers(_ context.Context, batchID uuid.UUID) ([]*domain.NettedTransfer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var transfers []*domain.NettedTransfer
+	for _, row := range r.store.nettedTransfers {
+		if row.batchID == batchID {
+			transfer := row.transfer
+			transfers = append(transfers, &transfer)
+		}
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].TransactionID.String() < transfers[j].TransactionID.String()
+	})
+
+	return transfers, nil
+}
+
+// Close records the outcome of an open batch.
+func (r *nettingRepo) Close(_ context.Context, batch *domain.NettingBatch) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := r.batch(batch.ID)
+	if stored == nil || stored.Status != domain.NettingOpen {
+		return fmt.Errorf("netting batch not open")
+	}
+
+	stored.Status = batch.Status
+	stored.TransferCount = batch.TransferCount
+	stored.GrossAmount = batch.GrossAmount
+	stored.NetAmount = batch.NetAmount
+	stored.NetFromUserID = copyID(batch.NetFromUserID)
+	stored.FailureReason = batch.FailureReason
+	stored.SettledAt = copyTime(batch.SettledAt)
+
+	return nil
+}
+
+// List retrieves batches, newest first.
+func (r *nettingRepo) List(_ context.Context, filter *domain.NettingBatchFilter) ([]*domain.NettingBatch, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var batches []*domain.NettingBatch
+	for i := len(r.store.nettingBatches) - 1; i >= 0; i-- {
+		batch := r.store.nettingBatches[i]
+		if filter.Status != nil && batch.Status != *filter.Status {
+			continue
+		}
+		batches = append(batches, copyNettingBatch(batch))
+	}
+
+	start, end := paginate(len(batches), filter.Limit, filter.Offset)
+	return batches[start:end], nil
+}
+
+// openBatch finds the open batch of a pair in a currency. The caller must hold the store lock.
+func (r *nettingRepo) openBatch(userAID, userBID uuid.UUID, currency string) *domain.NettingBatch {
+	for _, batch := range r.store.nettingBatches {
+		if batch.Status == domain.NettingOpen && batch.UserAID == userAID && batch.UserBID == userBID && batch.Currency == currency {
+			return batch
+		}
+	}
+	return nil
+}
+
+// batch finds a batch by ID. The caller must hold the store lock.
+func (r *nettingRepo) batch(id uuid.UUID) *domain.NettingBatch {
+	for _, batch := range r.store.nettingBatches {
+		if batch.ID == id {
+			return batch
+		}
+	}
+	return nil
+}
+
+// copyNettingBatch returns a copy of a batch that shares no pointers with it.
+func copyNettingBatch(batch *domain.NettingBatch) *domain.NettingBatch {
+	c := *batch
+	c.NetFromUserID = copyID(batch.NetFromUserID)
+	c.SettledAt = copyTime(batch.SettledAt)
+	return &c
+}
@@ -0,0 +1,258 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// notificationsRepo implements the NotificationsRepo interface in memory.
+type notificationsRepo struct {
+	store *Store
+}
+
+// NewNotificationsRepo creates a new in-memory notifications repository.
+func NewNotificationsRepo(store *Store) repository.NotificationsRepo {
+	return &notificationsRepo{store: store}
+}
+
+// CreateNotification adds a notification to a user's inbox. Inserting a notification whose ID
+// already exists is a no-op, so a retried delivery cannot show up twice.
+func (r *notificationsRepo) CreateNotification(_ context.Context, notification *domain.Notification) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.findNotification(notification.ID) != nil {
+		return nil
+	}
+
+	stored := copyNotification(notification)
+	stored.ReadAt = nil
+	r.store.notifications = append(r.store.notifications, stored)
+
+	return nil
+}
+
+// GetNotification retrieves an inbox notification by ID.
+func (r *notificationsRepo) GetNotification(_ context.Context, id uuid.UUID) (*domain.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	notification := r.findNotification(id)
+	if notification == nil {
+		return nil, fmt.Errorf("notification not found")
+	}
+
+	return copyNotification(notification), nil
+}
+
+// ListNotifications retrieves a user's inbox with filtering, newest first.
+func (r *notificationsRepo) ListNotifications(_ context.Context, filter *domain.NotificationFilter) ([]*domain.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var notifications []*domain.Notification
+	for i := len(r.store.notifications) - 1; i >= 0; i-- {
+		notification := r.store.notifications[i]
+		if notification.UserID != filter.UserID || (filter.UnreadOnly && notification.ReadAt != nil) {
+			continue
+		}
+		notifications = append(notifications, copyNotification(notification))
+	}
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+	})
+
+	start, end := paginate(len(notifications), filter.Limit, filter.Offset)
+	return notifications[start:end], nil
+}
+
+// CountUnread returns the number of unread notifications in a user's inbox.
+func (r *notificationsRepo) CountUnread(_ context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, notification := range r.store.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification as read. Notifications that were already read keep their original read time.
+func (r *notificationsRepo) MarkRead(_ context.Context, id uuid.UUID, readAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	notification := r.findNotification(id)
+	if notification == nil {
+		return fmt.Errorf("notification not found")
+	}
+	if notification.ReadAt == nil {
+		notification.ReadAt = &readAt
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every unread notification of a user as read and returns how many were marked.
+func (r *notificationsRepo) MarkAllRead(_ context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var marked int64
+	for _, notification := range r.store.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			at := readAt
+			notification.ReadAt = &at
+			marked++
+		}
+	}
+
+	return marked, nil
+}
+
+// GetPreferences retrieves a user's stored notification preferences.
+func (r *notificationsRepo) GetPreferences(_ context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	prefs, ok := r.store.notificationPrefs[userID]
+	if !ok {
+		return nil, fmt.Errorf("notification preferences not found")
+	}
+
+	return copyNotificationPreferences(prefs), nil
+}
+
+// UpsertPreferences creates or replaces a user's notification preferences.
+func (r *notificationsRepo) UpsertPreferences(_ context.Context, prefs *domain.NotificationPreferences) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.notificationPrefs[prefs.UserID] = copyNotificationPreferences(prefs)
+
+	return nil
+}
+
+// CreateDelivery queues a notification for delivery on one channel.
+func (r *notificationsRepo) CreateDelivery(_ context.Context, delivery *domain.NotificationDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.findDelivery(delivery.ID) != nil {
+		return fmt.Errorf("failed to create notification delivery: duplicate id")
+	}
+
+	stored := copyNotificationDelivery(delivery)
+	stored.LastError, stored.SentAt = "", nil
+	r.store.deliveries = append(r.store.deliveries, stored)
+
+	return nil
+}
+
+// ClaimDueDeliveries claims up to limit pending deliveries that are due at now, oldest first.
+// Claimed deliveries are pushed back to leaseUntil so later claims skip them while they are
+// being sent; a delivery whose sender crashes is retried once the lease runs out.
+func (r *notificationsRepo) ClaimDueDeliveries(_ context.Context, now, leaseUntil time.Time, limit int) ([]*domain.NotificationDelivery, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var due []*domain.NotificationDelivery
+	for _, delivery := range r.store.deliveries {
+		if delivery.Status == domain.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if limit >= 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	deliveries := make([]*domain.NotificationDelivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttemptAt = leaseUntil
+		deliveries = append(deliveries, copyNotificationDelivery(delivery))
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery saves the outcome of a delivery attempt.
+func (r *notificationsRepo) UpdateDelivery(_ context.Context, delivery *domain.NotificationDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := r.findDelivery(delivery.ID)
+	if stored == nil {
+		return fmt.Errorf("notification delivery not found")
+	}
+
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.LastError = delivery.LastError
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.SentAt = copyTime(delivery.SentAt)
+
+	return nil
+}
+
+// findNotification returns the stored notification with the given ID, or nil. The caller must hold the store's lock.
+func (r *notificationsRepo) findNotification(id uuid.UUID) *domain.Notification {
+	for _, notification := range r.store.notifications {
+		if notification.ID == id {
+			return notification
+		}
+	}
+	return nil
+}
+
+// findDelivery returns the stored delivery with the given ID, or nil. The caller must hold the store's lock.
+func (r *notificationsRepo) findDelivery(id uuid.UUID) *domain.NotificationDelivery {
+	for _, delivery := range r.store.deliveries {
+		if delivery.ID == id {
+			return delivery
+		}
+	}
+	return nil
+}
+
+// copyNotification returns a copy of a notification that shares no pointers with it.
+func copyNotification(notification *domain.Notification) *domain.Notification {
+	c := *notification
+	c.Data = maps.Clone(notification.Data)
+	c.ReadAt = copyTime(notification.ReadAt)
+	return &c
+}
+
+// copyNotificationPreferences returns a copy of notification preferences that shares no pointers with them.
+func copyNotificationPreferences(prefs *domain.NotificationPreferences) *domain.NotificationPreferences {
+	c := *prefs
+	c.Channels = make(map[domain.NotificationType][]domain.NotificationChannel, len(prefs.Channels))
+	for t, channels := range prefs.Channels {
+		c.Channels[t] = slices.Clone(channels)
+	}
+	return &c
+}
+
+// copyNotificationDelivery returns a copy of a notification delivery that shares no pointers with it.
+func copyNotificationDelivery(delivery *domain.NotificationDelivery) *domain.NotificationDelivery {
+	c := *delivery
+	c.Data = maps.Clone(delivery.Data)
+	c.SentAt = copyTime(delivery.SentAt)
+	return &c
+}
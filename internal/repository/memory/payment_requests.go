@@ -0,0 +1,120 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// paymentRequestsRepo implements the PaymentRequestsRepo interface in memory.
+type paymentRequestsRepo struct {
+	store *Store
+}
+
+// NewPaymentRequestsRepo creates a new in-memory payment requests repository.
+func NewPaymentRequestsRepo(store *Store) repository.PaymentRequestsRepo {
+	return &paymentRequestsRepo{store: store}
+}
+
+// Create creates a new payment request.
+func (r *paymentRequestsRepo) Create(_ context.Context, request *domain.PaymentRequest) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.find(request.ID) != nil {
+		return fmt.Errorf("failed to create payment request: duplicate id")
+	}
+
+	r.store.paymentRequests = append(r.store.paymentRequests, copyPaymentRequest(request))
+
+	return nil
+}
+
+// GetByID retrieves a payment request by ID.
+func (r *paymentRequestsRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.PaymentRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	request := r.find(id)
+	if request == nil {
+		return nil, fmt.Errorf("payment request not found")
+	}
+
+	return copyPaymentRequest(request), nil
+}
+
+// List retrieves payment requests with filtering, newest first.
+func (r *paymentRequestsRepo) List(_ context.Context, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var requests []*domain.PaymentRequest
+	for i := len(r.store.paymentRequests) - 1; i >= 0; i-- {
+		request := r.store.paymentRequests[i]
+		if filter != nil {
+			if filter.RequesterID != nil && request.RequesterID != *filter.RequesterID {
+				continue
+			}
+			if filter.PayerID != nil && request.PayerID != *filter.PayerID {
+				continue
+			}
+			if filter.Status != nil && request.Status != *filter.Status {
+				continue
+			}
+		}
+		requests = append(requests, copyPaymentRequest(request))
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.After(requests[j].CreatedAt)
+	})
+
+	if filter != nil {
+		start, end := paginate(len(requests), filter.Limit, filter.Offset)
+		requests = requests[start:end]
+	}
+
+	return requests, nil
+}
+
+// UpdateStatus saves the status, transaction and response time of a payment request, but only
+// if its stored status is still fromStatus, so two concurrent responses cannot both succeed.
+func (r *paymentRequestsRepo) UpdateStatus(_ context.Context, request *domain.PaymentRequest, fromStatus string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := r.find(request.ID)
+	if stored == nil || stored.Status != fromStatus {
+		return fmt.Errorf("payment request status changed")
+	}
+
+	stored.Status = request.Status
+	stored.TransactionID = copyID(request.TransactionID)
+	stored.UpdatedAt = request.UpdatedAt
+	stored.RespondedAt = copyTime(request.RespondedAt)
+
+	return nil
+}
+
+// find returns the stored payment request with the given ID, or nil. The caller must hold the store's lock.
+func (r *paymentRequestsRepo) find(id uuid.UUID) *domain.PaymentRequest {
+	for _, request := range r.store.paymentRequests {
+		if request.ID == id {
+			return request
+		}
+	}
+	return nil
+}
+
+// copyPaymentRequest returns a copy of a payment request that shares no pointers with it.
+func copyPaymentRequest(request *domain.PaymentRequest) *domain.PaymentRequest {
+	c := *request
+	c.TransactionID = copyID(request.TransactionID)
+	c.RespondedAt = copyTime(request.RespondedAt)
+	return &c
+}
@@ -0,0 +1,126 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// policiesRepo implements the PoliciesRepo interface in memory.
+type policiesRepo struct {
+	store *Store
+}
+
+// NewPoliciesRepo creates a new in-memory policies repository.
+func NewPoliciesRepo(store *Store) repository.PoliciesRepo {
+	return &policiesRepo{store: store}
+}
+
+// Create stores a new version of the policy, setting its version number.
+func (r *policiesRepo) Create(_ context.Context, version *domain.PolicyVersion) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, err := copyPolicyVersion(version)
+	if err != nil {
+		return fmt.Errorf("failed to create policy version: %w", err)
+	}
+
+	stored.Version = len(r.store.policyVersions) + 1
+	r.store.policyVersions = append(r.store.policyVersions, stored)
+	version.Version = stored.Version
+	return nil
+}
+
+// GetByVersion retrieves a version of the policy by its number.
+func (r *policiesRepo) GetByVersion(_ context.Context, version int) (*domain.PolicyVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if version < 1 || version > len(r.store.policyVersions) {
+		return nil, fmt.Errorf("policy version not found")
+	}
+	return copyPolicyVersion(r.store.policyVersions[version-1])
+}
+
+// List retrieves versions of the policy, newest first.
+func (r *policiesRepo) List(_ context.Context, filter *domain.PolicyVersionFilter) ([]*domain.PolicyVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	n := len(r.store.policyVersions)
+	start, end := paginate(n, filter.Limit, filter.Offset)
+
+	var versions []*domain.PolicyVersion
+	for i := start; i < end; i++ {
+		version, err := copyPolicyVersion(r.store.policyVersions[n-1-i])
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// EffectiveAt retrieves the version in effect at the given time.
+func (r *policiesRepo) EffectiveAt(_ context.Context, at time.Time) (*domain.PolicyVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var effective *domain.PolicyVersion
+	for _, version := range r.store.policyVersions {
+		if version.EffectiveFrom.After(at) {
+			continue
+		}
+		if effective == nil || !version.EffectiveFrom.Before(effective.EffectiveFrom) {
+			effective = version
+		}
+	}
+
+	if effective == nil {
+		return nil, fmt.Errorf("policy version not found")
+	}
+	return copyPolicyVersion(effective)
+}
+
+// NextAfter retrieves the first version taking effect after the given time, or nil if none is scheduled.
+func (r *policiesRepo) NextAfter(_ context.Context, at time.Time) (*domain.PolicyVersion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var next *domain.PolicyVersion
+	for _, version := range r.store.policyVersions {
+		if !version.EffectiveFrom.After(at) {
+			continue
+		}
+		if next == nil || !version.EffectiveFrom.After(next.EffectiveFrom) {
+			next = version
+		}
+	}
+
+	if next == nil {
+		return nil, nil
+	}
+	return copyPolicyVersion(next)
+}
+
+// copyPolicyVersion returns a copy of a policy version that shares no memory with it, round-tripping
+// the policy through JSON as the database stores it.
+func copyPolicyVersion(version *domain.PolicyVersion) (*domain.PolicyVersion, error) {
+	c := *version
+	encoded, err := json.Marshal(version.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy: %w", err)
+	}
+	c.Policy = domain.Policy{}
+	if err := json.Unmarshal(encoded, &c.Policy); err != nil {
+		return nil, fmt.Errorf("failed to decode policy: %w", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,481 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// scheduledRow is a stored scheduled transaction and its execution lease.
+type scheduledRow struct {
+	st          domain.ScheduledTransaction
+	lockedBy    string
+	lockedUntil time.Time
+}
+
+// scheduledTransactionsRepo implements the ScheduledTransactionsRepo interface in memory.
+type scheduledTransactionsRepo struct {
+	store *Store
+}
+
+// NewScheduledTransactionsRepo creates a new in-memory scheduled transactions repository.
+func NewScheduledTransactionsRepo(store *Store) repository.ScheduledTransactionsRepo {
+	return &scheduledTransactionsRepo{store: store}
+}
+
+// Create creates a new scheduled transaction
+func (r *scheduledTransactionsRepo) Create(_ context.Context, st *domain.ScheduledTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.scheduled[st.ID]; exists {
+		return fmt.Errorf("failed to create scheduled transaction: duplicate id")
+	}
+
+	row := &scheduledRow{st: *copyScheduled(st)}
+	row.st.NextExecutionAt = st.CalculateNextExecution()
+	row.st.LastExecutedAt = nil
+	row.st.FailedAttempts = 0
+	row.st.RetryAt = nil
+	row.st.FundingAlertedFor = nil
+	if row.st.BusinessDayRule == "" {
+		row.st.BusinessDayRule = domain.BusinessDayNone
+	}
+	r.store.scheduled[st.ID] = row
+
+	return nil
+}
+
+// GetByID retrieves a scheduled transaction by ID
+func (r *scheduledTransactionsRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.ScheduledTransaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, exists := r.store.scheduled[id]
+	if !exists {
+		return nil, fmt.Errorf("scheduled transaction not found")
+	}
+
+	return copyScheduled(&row.st), nil
+}
+
+// GetByUserID retrieves scheduled transactions for a user, earliest execution first
+func (r *scheduledTransactionsRepo) GetByUserID(_ context.Context, userID uuid.UUID, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransaction, error) {
+	transactions := r.matching(&userID, filter)
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].ExecuteAt.Before(transactions[j].ExecuteAt)
+	})
+
+	if filter != nil {
+		start, end := paginate(len(transactions), filter.Limit, filter.Offset)
+		transactions = transactions[start:end]
+	}
+
+	return transactions, nil
+}
+
+// ClaimDueForExecution leases scheduled transactions due as of now to owner so no other instance
+// executes them. Leases run on the wall clock, whatever clock now comes from.
+func (r *scheduledTransactionsRepo) ClaimDueForExecution(_ context.Context, owner string, now time.Time, lease time.Duration, limit int) ([]*domain.ScheduledTransaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	wall := time.Now()
+	var due []*scheduledRow
+	for _, row := range r.store.scheduled {
+		st := &row.st
+		if !st.IsActive || st.Status != "active" || st.DueAt().After(now) {
+			continue
+		}
+		if st.ScheduleType != "recurring" && st.LastExecutedAt != nil {
+			continue
+		}
+		// Rows updated in the last second are skipped, like the Postgres repository
+		if !st.UpdatedAt.IsZero() && !st.UpdatedAt.Before(wall.Add(-time.Second)) {
+			continue
+		}
+		if st.RetryAt != nil && st.RetryAt.After(now) {
+			continue
+		}
+		if !row.lockedUntil.IsZero() && !row.lockedUntil.Before(wall) {
+			continue
+		}
+		due = append(due, row)
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].st.DueAt().Before(due[j].st.DueAt())
+	})
+	start, end := paginate(len(due), limit, 0)
+
+	claimed := make([]*domain.ScheduledTransaction, 0, end-start)
+	for _, row := range due[start:end] {
+		row.lockedBy = owner
+		row.lockedUntil = wall.Add(lease)
+		claimed = append(claimed, copyScheduled(&row.st))
+	}
+
+	return claimed, nil
+}
+
+// ReleaseClaim releases owner's lease on a scheduled transaction
+func (r *scheduledTransactionsRepo) ReleaseClaim(_ context.Context, id uuid.UUID, owner string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if row, exists := r.store.scheduled[id]; exists && row.lockedBy == owner {
+		row.lockedBy = ""
+		row.lockedUntil = time.Time{}
+	}
+
+	return nil
+}
+
+// Update updates the mutable fields of a scheduled transaction
+func (r *scheduledTransactionsRepo) Update(_ context.Context, st *domain.ScheduledTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, exists := r.store.scheduled[st.ID]
+	if !exists {
+		return nil // Like the UPDATE in the Postgres repository, a missing row is not an error
+	}
+
+	updated := copyScheduled(st)
+	row.st.Description = updated.Description
+	row.st.Status = updated.Status
+	row.st.IsActive = updated.IsActive
+	row.st.ExecuteAt = updated.ExecuteAt
+	row.st.RecurrenceEndDate = updated.RecurrenceEndDate
+	row.st.MaxOccurrences = updated.MaxOccurrences
+	row.st.UpdatedAt = time.Now()
+	row.st.NextExecutionAt = st.CalculateNextExecution()
+	row.st.LastExecutedAt = updated.LastExecutedAt
+	row.st.CurrentOccurrence = updated.CurrentOccurrence
+	row.st.FailedAttempts = updated.FailedAttempts
+	row.st.RetryAt = updated.RetryAt
+	row.st.AdjustedExecuteAt = updated.AdjustedExecuteAt
+
+	return nil
+}
+
+// Reassign moves a scheduled transaction to another owner, clearing its transfer template
+// binding, funding alert and pending retry
+func (r *scheduledTransactionsRepo) Reassign(_ context.Context, id uuid.UUID, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, exists := r.store.scheduled[id]
+	if !exists {
+		return fmt.Errorf("scheduled transaction not found")
+	}
+
+	row.st.UserID = userID
+	row.st.TemplateID = nil
+	row.st.FundingAlertedFor = nil
+	row.st.FailedAttempts = 0
+	row.st.RetryAt = nil
+	row.st.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ListUpcomingOutgoing lists active debits and transfers due after from and no later than until,
+// grouped by user and earliest first
+func (r *scheduledTransactionsRepo) ListUpcomingOutgoing(_ context.Context, from, until time.Time, limit int) ([]*domain.ScheduledTransaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var upcoming []*domain.ScheduledTransaction
+	for _, row := range r.store.scheduled {
+		st := &row.st
+		if !st.IsActive || st.Status != "active" || (st.TransactionType != "debit" && st.TransactionType != "transfer") {
+			continue
+		}
+		if !st.DueAt().After(from) || st.DueAt().After(until) {
+			continue
+		}
+		upcoming = append(upcoming, copyScheduled(st))
+	}
+
+	sort.Slice(upcoming, func(i, j int) bool {
+		if upcoming[i].UserID != upcoming[j].UserID {
+			return upcoming[i].UserID.String() < upcoming[j].UserID.String()
+		}
+		return upcoming[i].DueAt().Before(upcoming[j].DueAt())
+	})
+	start, end := paginate(len(upcoming), limit, 0)
+
+	return upcoming[start:end], nil
+}
+
+// MarkFundingAlerted records that the owner was warned about the occurrence at executeAt,
+// reporting false if they already were
+func (r *scheduledTransactionsRepo) MarkFundingAlerted(_ context.Context, id uuid.UUID, executeAt time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, exists := r.store.scheduled[id]
+	if !exists {
+		return false, nil // Like the UPDATE in the Postgres repository, a missing row is not an error
+	}
+	if row.st.FundingAlertedFor != nil && row.st.FundingAlertedFor.Equal(executeAt) {
+		return false, nil
+	}
+
+	row.st.FundingAlertedFor = &executeAt
+	return true, nil
+}
+
+// ResetStatus resets the status of a scheduled transaction (used for error recovery)
+func (r *scheduledTransactionsRepo) ResetStatus(_ context.Context, id uuid.UUID, status string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if row, exists := r.store.scheduled[id]; exists {
+		row.st.Status = status
+		row.st.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// Delete deletes a scheduled transaction and its execution history
+func (r *scheduledTransactionsRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.scheduled[id]; !exists {
+		return fmt.Errorf("scheduled transaction not found")
+	}
+	delete(r.store.scheduled, id)
+
+	kept := r.store.executions[:0]
+	for _, execution := range r.store.executions {
+		if execution.ScheduledTransactionID != id {
+			kept = append(kept, execution)
+		}
+	}
+	r.store.executions = kept
+
+	return nil
+}
+
+// CreateExecution creates an execution record
+func (r *scheduledTransactionsRepo) CreateExecution(_ context.Context, execution *domain.ScheduledTransactionExecution) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.scheduled[execution.ScheduledTransactionID]; !exists {
+		return fmt.Errorf("failed to create execution record: scheduled transaction not found")
+	}
+
+	stored := *execution
+	stored.TransactionID = copyID(execution.TransactionID)
+	r.store.executions = append(r.store.executions, &stored)
+
+	return nil
+}
+
+// GetExecutions retrieves execution history for a scheduled transaction, newest first
+func (r *scheduledTransactionsRepo) GetExecutions(_ context.Context, scheduledTransactionID uuid.UUID, limit int, offset int) ([]*domain.ScheduledTransactionExecution, error) {
+	r.store.mu.RLock()
+	var executions []*domain.ScheduledTransactionExecution
+	for _, stored := range r.store.executions {
+		if stored.ScheduledTransactionID == scheduledTransactionID {
+			execution := *stored
+			execution.TransactionID = copyID(stored.TransactionID)
+			executions = append(executions, &execution)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	// Executions are stored in insertion order; newest first keeps ties in reverse insertion order
+	for i, j := 0, len(executions)-1; i < j; i, j = i+1, j-1 {
+		executions[i], executions[j] = executions[j], executions[i]
+	}
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].ExecutedAt.After(executions[j].ExecutedAt)
+	})
+
+	start, end := paginate(len(executions), limit, offset)
+	return executions[start:end], nil
+}
+
+// Count counts scheduled transactions matching the filter. Execution windows and pagination are ignored.
+func (r *scheduledTransactionsRepo) Count(_ context.Context, userID uuid.UUID, filter *domain.ScheduledTransactionFilter) (int, error) {
+	var countFilter *domain.ScheduledTransactionFilter
+	if filter != nil {
+		countFilter = &domain.ScheduledTransactionFilter{Status: filter.Status, Type: filter.Type, IsActive: filter.IsActive}
+	}
+
+	return len(r.matching(&userID, countFilter)), nil
+}
+
+// List retrieves scheduled transactions of all users matching the filter, newest first
+func (r *scheduledTransactionsRepo) List(_ context.Context, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransaction, error) {
+	var userID *uuid.UUID
+	if filter != nil {
+		userID = filter.UserID
+	}
+
+	transactions := r.matching(userID, filter)
+	for i, j := 0, len(transactions)-1; i < j; i, j = i+1, j-1 {
+		transactions[i], transactions[j] = transactions[j], transactions[i]
+	}
+
+	if filter != nil {
+		start, end := paginate(len(transactions), filter.Limit, filter.Offset)
+		transactions = transactions[start:end]
+	}
+
+	return transactions, nil
+}
+
+// CountAll counts scheduled transactions of all users matching the filter, ignoring pagination
+func (r *scheduledTransactionsRepo) CountAll(_ context.Context, filter *domain.ScheduledTransactionFilter) (int, error) {
+	var userID *uuid.UUID
+	var countFilter *domain.ScheduledTransactionFilter
+	if filter != nil {
+		userID = filter.UserID
+		countFilter = &domain.ScheduledTransactionFilter{Status: filter.Status, Type: filter.Type, IsActive: filter.IsActive, FailedSince: filter.FailedSince}
+	}
+
+	return len(r.matching(userID, countFilter)), nil
+}
+
+// GetFailureReasons aggregates failed executions since the given time by error message prefix,
+// most frequent first
+func (r *scheduledTransactionsRepo) GetFailureReasons(_ context.Context, since time.Time) ([]domain.ScheduledFailureReason, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byReason := make(map[string]*domain.ScheduledFailureReason)
+	schedules := make(map[string]map[uuid.UUID]bool)
+	for _, execution := range r.store.executions {
+		if execution.Status != "failed" || execution.ExecutedAt.Before(since) {
+			continue
+		}
+
+		key, _, _ := strings.Cut(execution.ErrorMessage, ":")
+		reason, exists := byReason[key]
+		if !exists {
+			reason = &domain.ScheduledFailureReason{Reason: key}
+			byReason[key] = reason
+			schedules[key] = make(map[uuid.UUID]bool)
+		}
+		reason.Failures++
+		schedules[key][execution.ScheduledTransactionID] = true
+		if execution.ExecutedAt.After(reason.LastFailedAt) {
+			reason.LastFailedAt = execution.ExecutedAt
+		}
+	}
+
+	reasons := make([]domain.ScheduledFailureReason, 0, len(byReason))
+	for key, reason := range byReason {
+		reason.ScheduledTransactions = len(schedules[key])
+		reasons = append(reasons, *reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].Failures != reasons[j].Failures {
+			return reasons[i].Failures > reasons[j].Failures
+		}
+		return reasons[i].Reason < reasons[j].Reason
+	})
+
+	return reasons, nil
+}
+
+// matching returns copies of the scheduled transactions matching the filter's conditions, oldest
+// first. A nil userID matches every user.
+func (r *scheduledTransactionsRepo) matching(userID *uuid.UUID, filter *domain.ScheduledTransactionFilter) []*domain.ScheduledTransaction {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var failed map[uuid.UUID]bool
+	if filter != nil && filter.FailedSince != nil {
+		failed = make(map[uuid.UUID]bool)
+		for _, execution := range r.store.executions {
+			if execution.Status == "failed" && !execution.ExecutedAt.Before(*filter.FailedSince) {
+				failed[execution.ScheduledTransactionID] = true
+			}
+		}
+	}
+
+	var transactions []*domain.ScheduledTransaction
+	for _, row := range r.store.scheduled {
+		st := &row.st
+		if userID != nil && st.UserID != *userID {
+			continue
+		}
+		if failed != nil && !failed[st.ID] {
+			continue
+		}
+		if filter != nil {
+			if filter.Status != nil && st.Status != *filter.Status {
+				continue
+			}
+			if filter.Type != nil && st.TransactionType != *filter.Type {
+				continue
+			}
+			if filter.IsActive != nil && st.IsActive != *filter.IsActive {
+				continue
+			}
+			if filter.ExecuteFrom != nil && st.ExecuteAt.Before(*filter.ExecuteFrom) {
+				continue
+			}
+			if filter.ExecuteTo != nil && st.ExecuteAt.After(*filter.ExecuteTo) {
+				continue
+			}
+		}
+		transactions = append(transactions, copyScheduled(st))
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if !transactions[i].CreatedAt.Equal(transactions[j].CreatedAt) {
+			return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+		}
+		return transactions[i].ID.String() < transactions[j].ID.String()
+	})
+
+	return transactions
+}
+
+// copyScheduled returns a copy of st that shares no pointers with it.
+func copyScheduled(st *domain.ScheduledTransaction) *domain.ScheduledTransaction {
+	c := *st
+	c.ToUserID = copyID(st.ToUserID)
+	c.TemplateID = copyID(st.TemplateID)
+	if st.RecurrencePattern != nil {
+		pattern := *st.RecurrencePattern
+		c.RecurrencePattern = &pattern
+	}
+	c.RecurrenceEndDate = copyTime(st.RecurrenceEndDate)
+	if st.MaxOccurrences != nil {
+		maxOccurrences := *st.MaxOccurrences
+		c.MaxOccurrences = &maxOccurrences
+	}
+	c.LastExecutedAt = copyTime(st.LastExecutedAt)
+	c.NextExecutionAt = copyTime(st.NextExecutionAt)
+	c.RetryAt = copyTime(st.RetryAt)
+	c.FundingAlertedFor = copyTime(st.FundingAlertedFor)
+	c.AdjustedExecuteAt = copyTime(st.AdjustedExecuteAt)
+	return &c
+}
+
+// copyTime returns a copy of an optional time.
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
@@ -0,0 +1,294 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// searchRepo implements the SearchRepo interface in memory. It approximates Postgres web search
+// queries with the simple configuration: whole words ignoring case, "or" between alternatives,
+// quoted phrases and -word exclusions.
+type searchRepo struct {
+	store *Store
+}
+
+// NewSearchRepo creates a new in-memory search repository.
+func NewSearchRepo(store *Store) repository.SearchRepo {
+	return &searchRepo{store: store}
+}
+
+// Search retrieves a page of a user's transactions and payment requests matching query, best
+// match first.
+func (r *searchRepo) Search(_ context.Context, userID uuid.UUID, query string, limit, offset int) ([]*domain.SearchHit, error) {
+	hits := r.userHits(userID, parseSearchQuery(query))
+	start, end := paginate(len(hits), limit, offset)
+	return hits[start:end], nil
+}
+
+// Count counts a user's transactions and payment requests matching query.
+func (r *searchRepo) Count(_ context.Context, userID uuid.UUID, query string) (int, error) {
+	return len(r.userHits(userID, parseSearchQuery(query))), nil
+}
+
+// SearchAudit retrieves a page of the audit log entries matching query, best match first.
+func (r *searchRepo) SearchAudit(_ context.Context, query string, limit, offset int) ([]*domain.SearchHit, error) {
+	hits := r.auditHits(parseSearchQuery(query))
+	start, end := paginate(len(hits), limit, offset)
+	return hits[start:end], nil
+}
+
+// CountAudit counts the audit log entries matching query.
+func (r *searchRepo) CountAudit(_ context.Context, query string) (int, error) {
+	return len(r.auditHits(parseSearchQuery(query))), nil
+}
+
+// userHits returns a user's transactions and payment requests matching query, best match first.
+func (r *searchRepo) userHits(userID uuid.UUID, query searchQuery) []*domain.SearchHit {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	username := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		if user, ok := r.store.users[*id]; ok {
+			return user.Username
+		}
+		return ""
+	}
+
+	var hits []*domain.SearchHit
+	for _, row := range r.store.transactions {
+		tx := row.tx
+		counterparty := tx.FromUserID
+		switch {
+		case tx.FromUserID != nil && *tx.FromUserID == userID:
+			counterparty = tx.ToUserID
+		case tx.ToUserID == nil || *tx.ToUserID != userID:
+			continue
+		}
+		body := joinNonEmpty(tx.Description, tx.ClientReference, username(counterparty))
+		if hit := query.hit(domain.SearchHitTransaction, tx.ID, body); hit != nil {
+			hit.CreatedAt = tx.CreatedAt
+			hits = append(hits, hit)
+		}
+	}
+	for _, request := range r.store.paymentRequests {
+		counterparty := &request.RequesterID
+		switch {
+		case request.RequesterID == userID:
+			counterparty = &request.PayerID
+		case request.PayerID != userID:
+			continue
+		}
+		body := joinNonEmpty(request.Note, username(counterparty))
+		if hit := query.hit(domain.SearchHitPaymentRequest, request.ID, body); hit != nil {
+			hit.CreatedAt = request.CreatedAt
+			hits = append(hits, hit)
+		}
+	}
+
+	sortSearchHits(hits)
+	return hits
+}
+
+// auditHits returns the audit log entries matching query, best match first.
+func (r *searchRepo) auditHits(query searchQuery) []*domain.SearchHit {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var hits []*domain.SearchHit
+	for _, log := range r.store.auditLogs {
+		strs := []string{log.Action}
+		var details interface{}
+		if len(log.Details) > 0 && json.Unmarshal(log.Details, &details) == nil {
+			strs = appendJSONStrings(strs, details)
+		}
+		if hit := query.hit(domain.SearchHitAuditLog, log.ID, strings.Join(strs, " ")); hit != nil {
+			hit.CreatedAt = log.CreatedAt
+			hits = append(hits, hit)
+		}
+	}
+
+	sortSearchHits(hits)
+	return hits
+}
+
+// searchTerm is a word or quoted phrase of a search query, possibly excluded with a minus sign.
+type searchTerm struct {
+	words   []string
+	negated bool
+}
+
+// searchQuery is a parsed web search query: every clause must match, and a clause matches when any
+// of its terms does.
+type searchQuery [][]searchTerm
+
+// searchToken matches a word or a quoted phrase of a web search query, with an optional minus sign.
+var searchToken = regexp.MustCompile(`-?"[^"]*"?|-?[^\s"]+`)
+
+// parseSearchQuery parses a web search query.
+func parseSearchQuery(query string) searchQuery {
+	var parsed searchQuery
+	or := false
+	for _, token := range searchToken.FindAllString(query, -1) {
+		if strings.EqualFold(token, "or") && len(parsed) > 0 {
+			or = true
+			continue
+		}
+
+		term := searchTerm{negated: strings.HasPrefix(token, "-")}
+		term.words = searchWords(strings.TrimPrefix(token, "-"))
+		if len(term.words) == 0 {
+			continue
+		}
+		if or {
+			parsed[len(parsed)-1] = append(parsed[len(parsed)-1], term)
+		} else {
+			parsed = append(parsed, []searchTerm{term})
+		}
+		or = false
+	}
+	return parsed
+}
+
+// hit returns a search hit for the record with the text body if it matches the query, or nil.
+func (q searchQuery) hit(kind domain.SearchHitKind, id uuid.UUID, body string) *domain.SearchHit {
+	words := searchWords(body)
+	if len(q) == 0 || !q.matches(words) {
+		return nil
+	}
+
+	// Rank by how often the body has the words the query looks for, like ts_rank without length
+	// normalization
+	wanted := make(map[string]bool)
+	for _, clause := range q {
+		for _, term := range clause {
+			for _, word := range term.words {
+				wanted[word] = !term.negated
+			}
+		}
+	}
+	matched := 0
+	for _, word := range words {
+		if wanted[word] {
+			matched++
+		}
+	}
+
+	return &domain.SearchHit{
+		Kind:    kind,
+		ID:      id,
+		Rank:    float64(matched),
+		Snippet: highlightWords(body, wanted),
+	}
+}
+
+// matches reports whether a text of words matches the query.
+func (q searchQuery) matches(words []string) bool {
+	for _, clause := range q {
+		if !slices.ContainsFunc(clause, func(term searchTerm) bool {
+			return containsPhrase(words, term.words) != term.negated
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsPhrase reports whether words contains phrase as consecutive words.
+func containsPhrase(words, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWords splits text into lowercase words of letters and digits.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlightWords wraps the words of text marked wanted in the search highlight markers.
+func highlightWords(text string, wanted map[string]bool) string {
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word := text[start:end]
+		if wanted[strings.ToLower(word)] {
+			word = domain.SearchHighlightStart + word + domain.SearchHighlightStop
+		}
+		b.WriteString(word)
+		start = -1
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+		b.WriteRune(r)
+	}
+	flush(len(text))
+	return b.String()
+}
+
+// appendJSONStrings appends the string values found anywhere in a decoded JSON value.
+func appendJSONStrings(strs []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		strs = append(strs, v)
+	case []interface{}:
+		for _, item := range v {
+			strs = appendJSONStrings(strs, item)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			strs = appendJSONStrings(strs, v[key])
+		}
+	}
+	return strs
+}
+
+// joinNonEmpty joins the non-empty parts with spaces, like concat_ws skipping nulls.
+func joinNonEmpty(parts ...string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(part string) bool { return part == "" }), " ")
+}
+
+// sortSearchHits sorts hits best match first, then newest first.
+func sortSearchHits(hits []*domain.SearchHit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		if !hits[i].CreatedAt.Equal(hits[j].CreatedAt) {
+			return hits[i].CreatedAt.After(hits[j].CreatedAt)
+		}
+		return hits[i].ID.String() < hits[j].ID.String()
+	})
+}
@@ -0,0 +1,67 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// securityEventsRepo implements the SecurityEventsRepo interface in memory.
+type securityEventsRepo struct {
+	store *Store
+}
+
+// NewSecurityEventsRepo creates a new in-memory security events repository.
+func NewSecurityEventsRepo(store *Store) repository.SecurityEventsRepo {
+	return &securityEventsRepo{store: store}
+}
+
+// Record appends a security event.
+func (r *securityEventsRepo) Record(_ context.Context, event *domain.SecurityEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.securityEvents = append(r.store.securityEvents, copySecurityEvent(event))
+	return nil
+}
+
+// List retrieves security events, newest first.
+func (r *securityEventsRepo) List(_ context.Context, filter *domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []*domain.SecurityEvent
+	for i := len(r.store.securityEvents) - 1; i >= 0; i-- {
+		event := r.store.securityEvents[i]
+		if filter.UserID != nil && (event.UserID == nil || *event.UserID != *filter.UserID) {
+			continue
+		}
+		if filter.Type != nil && event.Type != *filter.Type {
+			continue
+		}
+		if filter.Since != nil && event.CreatedAt.Before(*filter.Since) {
+			continue
+		}
+		events = append(events, copySecurityEvent(event))
+	}
+
+	start, end := paginate(len(events), filter.Limit, filter.Offset)
+	return events[start:end], nil
+}
+
+// copySecurityEvent returns a copy of a security event that shares no memory with it.
+func copySecurityEvent(event *domain.SecurityEvent) *domain.SecurityEvent {
+	c := *event
+	if event.UserID != nil {
+		userID := *event.UserID
+		c.UserID = &userID
+	}
+	if event.SessionID != nil {
+		sessionID := *event.SessionID
+		c.SessionID = &sessionID
+	}
+	return &c
+}
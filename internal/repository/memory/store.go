@@ -0,0 +1,364 @@
+//go:build memrepo
+
+// Package memory provides thread-safe in-memory implementations of the repositories, so services
+// and handlers can be tested without Postgres. It is only built with the memrepo build tag:
+//
+//	go test -tags memrepo ./...
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// errNoSQL is returned by the SQL methods of the store and its transactions.
+var errNoSQL = fmt.Errorf("memory store does not execute SQL")
+
+// Store holds the data of the in-memory repositories. Repositories created on the same store
+// share its data like tables of one database, so balances see transactions and transfers see
+// both balances. Records are copied in and out, so callers never alias stored data.
+type Store struct {
+	mu sync.RWMutex
+	// txMu is held by the open unit of work, so units of work run one at a time
+	txMu sync.Mutex
+	tables
+}
+
+// tables is the data of a store, which a unit of work snapshots to restore on rollback.
+type tables struct {
+	// seq orders records created within the same clock tick, like insertion order in Postgres
+	seq int64
+
+	users           map[uuid.UUID]*domain.User
+	userSeq         map[uuid.UUID]int64
+	balances        map[uuid.UUID]*domain.Balance
+	transactions    map[uuid.UUID]*transactionRow
+	auditLogs       []*domain.AuditLog // In insertion order, which is created_at order
+	events          []*domain.Event
+	scheduled       map[uuid.UUID]*scheduledRow
+	executions      []*domain.ScheduledTransactionExecution
+	treasury        map[string]*domain.TreasuryAccount
+	treasuryEntries []*domain.TreasuryEntry
+	currencies      map[string]*domain.CurrencyInfo
+
+	impersonations    []*domain.ImpersonationSession
+	knownDevices      map[knownDeviceKey]*domain.KnownDevice
+	loginSessions     []*domain.LoginSession  // In insertion order
+	securityEvents    []*domain.SecurityEvent // In insertion order, which is created_at order
+	anomalyScores     map[uuid.UUID]*domain.AnomalyScore
+	approvals         []*domain.Approval      // In insertion order, which is created_at order
+	policyVersions    []*domain.PolicyVersion // In version order
+	monthlySummaries  map[monthlySummaryKey]*domain.MonthlySummary
+	disputes          []*domain.Dispute
+	disputeComments   []*domain.DisputeComment
+	paymentRequests   []*domain.PaymentRequest
+	templates         map[uuid.UUID]*domain.TransferTemplate
+	contacts          []*domain.Contact // In insertion order, which is created_at order
+	notifications     []*domain.Notification
+	notificationPrefs map[uuid.UUID]*domain.NotificationPreferences
+	deliveries        []*domain.NotificationDelivery
+	balanceAlerts     map[balanceAlertKey]*domain.BalanceAlert
+	budgets           map[budgetKey]*domain.Budget
+	dormantFlags      map[uuid.UUID]time.Time // The inactivity each user was last announced dormant for
+	nettingBatches    []*domain.NettingBatch  // In insertion order, which is opened_at order
+	nettedTransfers   []*nettedTransferRow
+}
+
+// NewStore creates an empty store holding the default currencies, each with an empty treasury.
+func NewStore() *Store {
+	s := &Store{tables: tables{
+		users:        make(map[uuid.UUID]*domain.User),
+		userSeq:      make(map[uuid.UUID]int64),
+		balances:     make(map[uuid.UUID]*domain.Balance),
+		transactions: make(map[uuid.UUID]*transactionRow),
+		scheduled:    make(map[uuid.UUID]*scheduledRow),
+		treasury:     make(map[string]*domain.TreasuryAccount),
+		currencies:   make(map[string]*domain.CurrencyInfo),
+
+		knownDevices:      make(map[knownDeviceKey]*domain.KnownDevice),
+		anomalyScores:     make(map[uuid.UUID]*domain.AnomalyScore),
+		monthlySummaries:  make(map[monthlySummaryKey]*domain.MonthlySummary),
+		templates:         make(map[uuid.UUID]*domain.TransferTemplate),
+		notificationPrefs: make(map[uuid.UUID]*domain.NotificationPreferences),
+		balanceAlerts:     make(map[balanceAlertKey]*domain.BalanceAlert),
+		budgets:           make(map[budgetKey]*domain.Budget),
+		dormantFlags:      make(map[uuid.UUID]time.Time),
+	}}
+
+	now := time.Now()
+	for _, currency := range domain.DefaultCurrencies() {
+		currency.CreatedAt = now
+		currency.UpdatedAt = now
+		s.currencies[currency.Code] = &currency
+		s.treasury[currency.Code] = &domain.TreasuryAccount{
+			ID:        uuid.New(),
+			Currency:  currency.Code,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	return s
+}
+
+// Repositories returns the in-memory repositories of the store.
+func (s *Store) Repositories() *repository.Repositories {
+	return &repository.Repositories{
+		Users:                 NewUsersRepo(s),
+		Balances:              NewBalancesRepo(s),
+		Transactions:          NewTransactionsRepo(s),
+		TransactionAggregates: NewTransactionAggregatesRepo(s),
+		Audit:                 NewAuditRepo(s),
+		Events:                NewEventsRepo(s),
+		ScheduledTransactions: NewScheduledTransactionsRepo(s),
+		ImpersonationSessions: NewImpersonationSessionsRepo(s),
+		KnownDevices:          NewKnownDevicesRepo(s),
+		LoginSessions:         NewLoginSessionsRepo(s),
+		SecurityEvents:        NewSecurityEventsRepo(s),
+		AnomalyScores:         NewAnomalyScoresRepo(s),
+		Approvals:             NewApprovalsRepo(s),
+		Policies:              NewPoliciesRepo(s),
+		MonthlySummaries:      NewMonthlySummariesRepo(s),
+		Disputes:              NewDisputesRepo(s),
+		PaymentRequests:       NewPaymentRequestsRepo(s),
+		TransferTemplates:     NewTransferTemplatesRepo(s),
+		Contacts:              NewContactsRepo(s),
+		Notifications:         NewNotificationsRepo(s),
+		BalanceAlerts:         NewBalanceAlertsRepo(s),
+		Budgets:               NewBudgetsRepo(s),
+		DormantAccounts:       NewDormantAccountsRepo(s),
+		Search:                NewSearchRepo(s),
+		Treasury:              NewTreasuryRepo(s),
+		Currencies:            NewCurrenciesRepo(s),
+		Netting:               NewNettingRepo(s),
+	}
+}
+
+// clone returns a copy of the tables whose records can be changed without affecting t.
+func (t *tables) clone() tables {
+	return tables{
+		seq:               t.seq,
+		users:             cloneMap(t.users),
+		userSeq:           maps.Clone(t.userSeq),
+		balances:          cloneMap(t.balances),
+		transactions:      cloneMap(t.transactions),
+		auditLogs:         cloneSlice(t.auditLogs),
+		events:            cloneSlice(t.events),
+		scheduled:         cloneMap(t.scheduled),
+		executions:        cloneSlice(t.executions),
+		treasury:          cloneMap(t.treasury),
+		treasuryEntries:   cloneSlice(t.treasuryEntries),
+		currencies:        cloneMap(t.currencies),
+		impersonations:    cloneSlice(t.impersonations),
+		knownDevices:      cloneMap(t.knownDevices),
+		loginSessions:     cloneSlice(t.loginSessions),
+		securityEvents:    cloneSlice(t.securityEvents),
+		anomalyScores:     cloneMap(t.anomalyScores),
+		approvals:         cloneSlice(t.approvals),
+		policyVersions:    cloneSlice(t.policyVersions),
+		monthlySummaries:  cloneMap(t.monthlySummaries),
+		disputes:          cloneSlice(t.disputes),
+		disputeComments:   cloneSlice(t.disputeComments),
+		paymentRequests:   cloneSlice(t.paymentRequests),
+		templates:         cloneMap(t.templates),
+		contacts:          cloneSlice(t.contacts),
+		notifications:     cloneSlice(t.notifications),
+		notificationPrefs: cloneMap(t.notificationPrefs),
+		deliveries:        cloneSlice(t.deliveries),
+		balanceAlerts:     cloneMap(t.balanceAlerts),
+		budgets:           cloneMap(t.budgets),
+		dormantFlags:      maps.Clone(t.dormantFlags),
+		nettingBatches:    cloneSlice(t.nettingBatches),
+		nettedTransfers:   cloneSlice(t.nettedTransfers),
+	}
+}
+
+// cloneMap copies a map of records along with the records.
+func cloneMap[K comparable, V any](m map[K]*V) map[K]*V {
+	c := make(map[K]*V, len(m))
+	for k, v := range m {
+		record := *v
+		c[k] = &record
+	}
+	return c
+}
+
+// cloneSlice copies a slice of records along with the records.
+func cloneSlice[V any](s []*V) []*V {
+	c := make([]*V, len(s))
+	for i, v := range s {
+		record := *v
+		c[i] = &record
+	}
+	return c
+}
+
+// nextSeq returns the next insertion sequence number. The caller must hold s.mu.
+func (s *Store) nextSeq() int64 {
+	s.seq++
+	return s.seq
+}
+
+// Begin starts a transaction. The store implements repository.DBTX so it can be passed to
+// services as their database pool; only balance changes made through AddAmountTx take part in
+// the transaction.
+func (s *Store) Begin(_ context.Context) (pgx.Tx, error) {
+	return &memoryTx{store: s, deltas: make(map[uuid.UUID]float64)}, nil
+}
+
+// Exec is not supported; it exists to satisfy repository.DBTX.
+func (s *Store) Exec(_ context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errNoSQL
+}
+
+// Query is not supported; it exists to satisfy repository.DBTX.
+func (s *Store) Query(_ context.Context, _ string, _ ...any) (pgx.Rows, error) {
+	return nil, errNoSQL
+}
+
+// QueryRow is not supported; it exists to satisfy repository.DBTX.
+func (s *Store) QueryRow(_ context.Context, _ string, _ ...any) pgx.Row {
+	return errRow{}
+}
+
+// CopyFrom is not supported; it exists to satisfy repository.DBTX.
+func (s *Store) CopyFrom(_ context.Context, _ pgx.Identifier, _ []string, _ pgx.CopyFromSource) (int64, error) {
+	return 0, errNoSQL
+}
+
+// errRow is a row whose scan always fails.
+type errRow struct{}
+
+func (errRow) Scan(_ ...any) error {
+	return errNoSQL
+}
+
+// memoryTx buffers balance changes until commit. It embeds pgx.Tx only to satisfy the interface;
+// calling any method that is not defined here panics.
+type memoryTx struct {
+	pgx.Tx
+	store  *Store
+	mu     sync.Mutex
+	deltas map[uuid.UUID]float64
+	order  []uuid.UUID // Users in the order they were first changed
+	done   bool
+}
+
+// addAmount buffers a balance change, failing if it would leave the balance negative.
+func (t *memoryTx) addAmount(userID uuid.UUID, delta float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return fmt.Errorf("failed to add amount to balance: transaction is closed")
+	}
+
+	t.store.mu.RLock()
+	current := 0.0
+	balance, exists := t.store.balances[userID]
+	if exists {
+		current = balance.Amount
+	}
+	t.store.mu.RUnlock()
+
+	pending, changed := t.deltas[userID]
+	newAmount := current + pending + delta
+	if exists && newAmount < 0 {
+		return fmt.Errorf("insufficient funds: balance would be negative (%.2f)", newAmount)
+	}
+
+	if !changed {
+		t.order = append(t.order, userID)
+	}
+	t.deltas[userID] = pending + delta
+
+	return nil
+}
+
+// Commit applies the buffered balance changes atomically. Changes committed by other
+// transactions since they were buffered are re-checked, so no balance ends up negative.
+func (t *memoryTx) Commit(_ context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return pgx.ErrTxClosed
+	}
+	t.done = true
+
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	for _, userID := range t.order {
+		if balance, exists := t.store.balances[userID]; exists && balance.Amount+t.deltas[userID] < 0 {
+			return fmt.Errorf("insufficient funds: balance would be negative (%.2f)", balance.Amount+t.deltas[userID])
+		}
+	}
+
+	now := time.Now()
+	for _, userID := range t.order {
+		balance, exists := t.store.balances[userID]
+		if !exists {
+			// Like the Postgres repository, a missing balance is created in USD
+			balance = &domain.Balance{UserID: userID, Currency: "USD"}
+			t.store.balances[userID] = balance
+		}
+		balance.Amount += t.deltas[userID]
+		balance.LastUpdatedAt = now
+	}
+
+	return nil
+}
+
+// Rollback discards the buffered balance changes.
+func (t *memoryTx) Rollback(_ context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return pgx.ErrTxClosed
+	}
+	t.done = true
+
+	return nil
+}
+
+// Exec is not supported inside memory transactions.
+func (t *memoryTx) Exec(_ context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errNoSQL
+}
+
+// Query is not supported inside memory transactions.
+func (t *memoryTx) Query(_ context.Context, _ string, _ ...any) (pgx.Rows, error) {
+	return nil, errNoSQL
+}
+
+// QueryRow is not supported inside memory transactions.
+func (t *memoryTx) QueryRow(_ context.Context, _ string, _ ...any) pgx.Row {
+	return errRow{}
+}
+
+// paginate applies an offset and a limit to n items, returning the bounds of the page. A
+// non-positive limit returns everything after the offset.
+func paginate(n, limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if limit > 0 && offset+limit < n {
+		end = offset + limit
+	}
+	return offset, end
+}
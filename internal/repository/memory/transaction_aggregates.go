@@ -0,0 +1,97 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// transactionAggregatesRepo implements the TransactionAggregatesRepo interface in memory. The
+// store never archives transactions, so aggregates are summed from them on every read.
+type transactionAggregatesRepo struct {
+	store *Store
+}
+
+// NewTransactionAggregatesRepo creates a new in-memory transaction aggregates repository.
+func NewTransactionAggregatesRepo(store *Store) repository.TransactionAggregatesRepo {
+	return &transactionAggregatesRepo{store: store}
+}
+
+// aggregateKey identifies the aggregate a transaction counts in for one of its parties.
+type aggregateKey struct {
+	month     string
+	direction domain.TransactionDirection
+	txType    string
+	category  string
+	currency  string
+}
+
+// ListMonthly retrieves a user's aggregates of the UTC months starting in [from, to), oldest month
+// first.
+func (r *transactionAggregatesRepo) ListMonthly(_ context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.TransactionAggregate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	fromMonth, toMonth := domain.SummaryMonth(from), domain.SummaryMonth(to)
+	totals := make(map[aggregateKey]*domain.TransactionAggregate)
+	add := func(tx *domain.Transaction, direction domain.TransactionDirection) {
+		key := aggregateKey{domain.SummaryMonth(tx.CreatedAt), direction, tx.Type, tx.Category, tx.Currency}
+		if key.month < fromMonth || key.month >= toMonth {
+			return
+		}
+		aggregate, ok := totals[key]
+		if !ok {
+			aggregate = &domain.TransactionAggregate{
+				UserID:    userID,
+				Month:     key.month,
+				Direction: direction,
+				Type:      tx.Type,
+				Category:  tx.Category,
+				Currency:  tx.Currency,
+			}
+			totals[key] = aggregate
+		}
+		aggregate.Count++
+		aggregate.Amount = domain.RoundAmount(aggregate.Amount+tx.Amount, tx.Currency)
+		aggregate.ReversedAmount = domain.RoundAmount(aggregate.ReversedAmount+tx.ReversedAmount, tx.Currency)
+	}
+	for _, row := range r.store.transactions {
+		tx := row.tx
+		if tx.Status != string(domain.StatusSuccess) {
+			continue
+		}
+		if tx.FromUserID != nil && *tx.FromUserID == userID {
+			add(&tx, domain.DirectionOut)
+		}
+		if tx.ToUserID != nil && *tx.ToUserID == userID {
+			add(&tx, domain.DirectionIn)
+		}
+	}
+
+	aggregates := make([]*domain.TransactionAggregate, 0, len(totals))
+	for _, aggregate := range totals {
+		aggregates = append(aggregates, aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		a, b := aggregates[i], aggregates[j]
+		switch {
+		case a.Month != b.Month:
+			return a.Month < b.Month
+		case a.Direction != b.Direction:
+			return a.Direction < b.Direction
+		case a.Type != b.Type:
+			return a.Type < b.Type
+		case a.Category != b.Category:
+			return a.Category < b.Category
+		}
+		return a.Currency < b.Currency
+	})
+
+	return aggregates, nil
+}
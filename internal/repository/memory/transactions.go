@@ -0,0 +1,434 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// transactionRow is a stored transaction and its insertion order.
+type transactionRow struct {
+	tx  domain.Transaction
+	seq int64
+}
+
+// transactionsRepo implements the TransactionsRepo interface in memory.
+type transactionsRepo struct {
+	store *Store
+}
+
+// NewTransactionsRepo creates a new in-memory transactions repository.
+func NewTransactionsRepo(store *Store) repository.TransactionsRepo {
+	return &transactionsRepo{store: store}
+}
+
+// CreatePending creates a new transaction with pending status.
+func (r *transactionsRepo) CreatePending(_ context.Context, tx *domain.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if tx.ID == uuid.Nil {
+		tx.ID = uuid.New()
+	}
+	if _, exists := r.store.transactions[tx.ID]; exists {
+		return fmt.Errorf("failed to create pending transaction: duplicate id")
+	}
+	tx.Status = string(domain.StatusPending)
+	tx.CreatedAt = time.Now()
+
+	row := &transactionRow{tx: *copyTransaction(tx), seq: r.store.nextSeq()}
+	// Reversal progress is not inserted, like the Postgres repository
+	row.tx.ReversedByTransactionID = nil
+	row.tx.ReversedAmount = 0
+	r.store.transactions[tx.ID] = row
+
+	return nil
+}
+
+// MarkCompleted marks a pending transaction as completed.
+func (r *transactionsRepo) MarkCompleted(_ context.Context, id uuid.UUID) error {
+	return r.updateStatus(id, string(domain.StatusPending), string(domain.StatusSuccess))
+}
+
+// CreateManyCompleted creates transactions with success status in bulk.
+func (r *transactionsRepo) CreateManyCompleted(_ context.Context, txs []*domain.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	seen := make(map[uuid.UUID]bool, len(txs))
+	for _, tx := range txs {
+		if tx.ID == uuid.Nil {
+			tx.ID = uuid.New()
+		}
+		if _, exists := r.store.transactions[tx.ID]; exists || seen[tx.ID] {
+			return fmt.Errorf("failed to create completed transactions: duplicate id")
+		}
+		seen[tx.ID] = true
+	}
+
+	now := time.Now()
+	for _, tx := range txs {
+		tx.Status = string(domain.StatusSuccess)
+		tx.CreatedAt = now
+
+		row := &transactionRow{tx: *copyTransaction(tx), seq: r.store.nextSeq()}
+		row.tx.ReversedByTransactionID = nil
+		row.tx.ReversedAmount = 0
+		r.store.transactions[tx.ID] = row
+	}
+
+	return nil
+}
+
+// MarkFailed marks a pending transaction as failed.
+func (r *transactionsRepo) MarkFailed(_ context.Context, id uuid.UUID) error {
+	return r.updateStatus(id, string(domain.StatusPending), string(domain.StatusFailed))
+}
+
+// updateStatus moves a transaction from expectedCurrentStatus to newStatus.
+func (r *transactionsRepo) updateStatus(id uuid.UUID, expectedCurrentStatus, newStatus string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, exists := r.store.transactions[id]
+	if !exists {
+		return fmt.Errorf("transaction not found")
+	}
+	if row.tx.Status != expectedCurrentStatus {
+		return fmt.Errorf("invalid state transition: cannot change from %s to %s", row.tx.Status, newStatus)
+	}
+
+	row.tx.Status = newStatus
+	return nil
+}
+
+// GetByID retrieves a transaction by ID.
+func (r *transactionsRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, exists := r.store.transactions[id]
+	if !exists {
+		return nil, fmt.Errorf("transaction not found")
+	}
+
+	return copyTransaction(&row.tx), nil
+}
+
+// RecordReversal adds a rollback amount to the original transaction, linking the rollback once fully reversed.
+func (r *transactionsRepo) RecordReversal(_ context.Context, originalID uuid.UUID, reversalID uuid.UUID, amount float64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, exists := r.store.transactions[originalID]
+	if !exists || domain.RoundAmount(row.tx.ReversedAmount+amount, row.tx.Currency) > row.tx.Amount {
+		return fmt.Errorf("transaction not found or reversal exceeds original amount")
+	}
+
+	row.tx.ReversedAmount = domain.RoundAmount(row.tx.ReversedAmount+amount, row.tx.Currency)
+	if row.tx.ReversedAmount >= row.tx.Amount {
+		id := reversalID
+		row.tx.ReversedByTransactionID = &id
+	}
+
+	return nil
+}
+
+// ListForUser retrieves transactions for a specific user, newest first.
+func (r *transactionsRepo) ListForUser(_ context.Context, userID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	userFilter := domain.TransactionFilter{}
+	if filter != nil {
+		userFilter = *filter
+	}
+	userFilter.UserID = &userID
+
+	return r.list(&userFilter), nil
+}
+
+// ListForUserAfter retrieves up to limit transactions for a user, newest first, that come after cursor.
+func (r *transactionsRepo) ListForUserAfter(_ context.Context, userID uuid.UUID, filter *domain.TransactionFilter, cursor *domain.TransactionCursor, limit int) ([]*domain.Transaction, error) {
+	userFilter := domain.TransactionFilter{}
+	if filter != nil {
+		userFilter = *filter
+	}
+	userFilter.UserID = &userID
+	userFilter.Limit, userFilter.Offset = 0, 0
+
+	r.store.mu.RLock()
+	rows := r.matching(&userFilter)
+	r.store.mu.RUnlock()
+
+	// Keyset pagination orders by (created_at, id) DESC
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].tx.CreatedAt.Equal(rows[j].tx.CreatedAt) {
+			return rows[i].tx.CreatedAt.After(rows[j].tx.CreatedAt)
+		}
+		return bytes.Compare(rows[i].tx.ID[:], rows[j].tx.ID[:]) > 0
+	})
+
+	transactions := make([]*domain.Transaction, 0, limit)
+	for _, row := range rows {
+		if cursor != nil && !before(&row.tx, cursor) {
+			continue
+		}
+		transactions = append(transactions, copyTransaction(&row.tx))
+		if len(transactions) == limit {
+			break
+		}
+	}
+
+	return transactions, nil
+}
+
+// List retrieves transactions with filtering, newest first.
+func (r *transactionsRepo) List(_ context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	return r.list(filter), nil
+}
+
+// Count returns the total number of transactions matching the filter. Pagination is ignored.
+func (r *transactionsRepo) Count(_ context.Context, filter *domain.TransactionFilter) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return len(r.matching(filter)), nil
+}
+
+// GetStats computes aggregate transaction statistics for transactions created in [since, until).
+func (r *transactionsRepo) GetStats(_ context.Context, since, until time.Time) (*domain.TransactionStats, error) {
+	stats := &domain.TransactionStats{
+		Since:      since,
+		Until:      until,
+		ByCurrency: []domain.CurrencyVolume{},
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var amounts []float64
+	successAmounts := make(map[string][]float64)
+	for _, row := range r.store.transactions {
+		t := &row.tx
+		if t.CreatedAt.Before(since) || !t.CreatedAt.Before(until) {
+			continue
+		}
+
+		stats.TotalCount++
+		amounts = append(amounts, t.Amount)
+		switch t.Status {
+		case string(domain.StatusSuccess):
+			stats.SuccessCount++
+			successAmounts[t.Currency] = append(successAmounts[t.Currency], t.Amount)
+		case string(domain.StatusFailed):
+			stats.FailedCount++
+		case string(domain.StatusPending):
+			stats.PendingCount++
+		}
+	}
+	stats.P95Amount = percentileCont(amounts, 0.95)
+
+	currencies := make([]string, 0, len(successAmounts))
+	for currency := range successAmounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		volume := domain.CurrencyVolume{
+			Currency:  currency,
+			Count:     int64(len(successAmounts[currency])),
+			P95Amount: percentileCont(successAmounts[currency], 0.95),
+		}
+		for _, amount := range successAmounts[currency] {
+			volume.Volume += amount
+		}
+		stats.ByCurrency = append(stats.ByCurrency, volume)
+	}
+
+	stats.ComputeRates()
+
+	return stats, nil
+}
+
+// ListMostActiveUserIDs returns the IDs of users with the most transactions created since the given time.
+// Both sides of a transfer count towards a user's activity.
+func (r *transactionsRepo) ListMostActiveUserIDs(_ context.Context, since time.Time, limit int) ([]uuid.UUID, error) {
+	r.store.mu.RLock()
+	counts := make(map[uuid.UUID]int)
+	for _, row := range r.store.transactions {
+		if row.tx.CreatedAt.Before(since) {
+			continue
+		}
+		if row.tx.FromUserID != nil {
+			counts[*row.tx.FromUserID]++
+		}
+		if row.tx.ToUserID != nil {
+			counts[*row.tx.ToUserID]++
+		}
+	}
+	r.store.mu.RUnlock()
+
+	userIDs := make([]uuid.UUID, 0, len(counts))
+	for userID := range counts {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool {
+		if counts[userIDs[i]] != counts[userIDs[j]] {
+			return counts[userIDs[i]] > counts[userIDs[j]]
+		}
+		return bytes.Compare(userIDs[i][:], userIDs[j][:]) < 0
+	})
+
+	start, end := paginate(len(userIDs), limit, 0)
+	return userIDs[start:end], nil
+}
+
+// ListRecentCounterparties summarizes a user's successful outgoing transfers per recipient, most
+// recent first.
+func (r *transactionsRepo) ListRecentCounterparties(_ context.Context, userID uuid.UUID, limit int) ([]*domain.Counterparty, error) {
+	r.store.mu.RLock()
+	byUser := make(map[uuid.UUID]*domain.Counterparty)
+	for _, row := range r.store.transactions {
+		tx := row.tx
+		if tx.FromUserID == nil || *tx.FromUserID != userID || tx.ToUserID == nil ||
+			tx.Type != string(domain.TypeTransfer) || tx.Status != string(domain.StatusSuccess) {
+			continue
+		}
+		counterparty, ok := byUser[*tx.ToUserID]
+		if !ok {
+			counterparty = &domain.Counterparty{UserID: *tx.ToUserID}
+			byUser[*tx.ToUserID] = counterparty
+		}
+		counterparty.TransferCount++
+		if tx.CreatedAt.After(counterparty.LastTransferAt) {
+			counterparty.LastTransferAt = tx.CreatedAt
+		}
+	}
+	r.store.mu.RUnlock()
+
+	counterparties := make([]*domain.Counterparty, 0, len(byUser))
+	for _, counterparty := range byUser {
+		counterparties = append(counterparties, counterparty)
+	}
+	sort.Slice(counterparties, func(i, j int) bool {
+		if !counterparties[i].LastTransferAt.Equal(counterparties[j].LastTransferAt) {
+			return counterparties[i].LastTransferAt.After(counterparties[j].LastTransferAt)
+		}
+		return bytes.Compare(counterparties[i].UserID[:], counterparties[j].UserID[:]) < 0
+	})
+
+	start, end := paginate(len(counterparties), limit, 0)
+	return counterparties[start:end], nil
+}
+
+// list returns copies of the transactions matching filter, newest first, paginated by the filter.
+func (r *transactionsRepo) list(filter *domain.TransactionFilter) []*domain.Transaction {
+	r.store.mu.RLock()
+	rows := r.matching(filter)
+	r.store.mu.RUnlock()
+
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].tx.CreatedAt.Equal(rows[j].tx.CreatedAt) {
+			return rows[i].tx.CreatedAt.After(rows[j].tx.CreatedAt)
+		}
+		return rows[i].seq > rows[j].seq
+	})
+
+	start, end := 0, len(rows)
+	if filter != nil {
+		start, end = paginate(len(rows), filter.Limit, filter.Offset)
+	}
+
+	transactions := make([]*domain.Transaction, 0, end-start)
+	for _, row := range rows[start:end] {
+		transactions = append(transactions, copyTransaction(&row.tx))
+	}
+
+	return transactions
+}
+
+// matching returns the rows matching the filter's conditions, ignoring pagination. The caller must hold s.mu.
+func (r *transactionsRepo) matching(filter *domain.TransactionFilter) []transactionRow {
+	rows := make([]transactionRow, 0, len(r.store.transactions))
+	for _, row := range r.store.transactions {
+		t := &row.tx
+		if filter != nil {
+			if filter.UserID != nil && !involves(t, *filter.UserID) {
+				continue
+			}
+			if filter.Type != nil && t.Type != string(*filter.Type) {
+				continue
+			}
+			if filter.Status != nil && t.Status != string(*filter.Status) {
+				continue
+			}
+			if filter.Since != nil && t.CreatedAt.Before(*filter.Since) {
+				continue
+			}
+			if filter.Description != nil && !strings.Contains(strings.ToLower(t.Description), strings.ToLower(*filter.Description)) {
+				continue
+			}
+			if filter.ClientReference != nil && t.ClientReference != *filter.ClientReference {
+				continue
+			}
+		}
+		rows = append(rows, *row)
+	}
+
+	return rows
+}
+
+// before reports whether t sorts after cursor in (created_at, id) DESC order.
+func before(t *domain.Transaction, cursor *domain.TransactionCursor) bool {
+	if !t.CreatedAt.Equal(cursor.CreatedAt) {
+		return t.CreatedAt.Before(cursor.CreatedAt)
+	}
+	return bytes.Compare(t.ID[:], cursor.ID[:]) < 0
+}
+
+// copyTransaction returns a copy of t that shares no pointers with it.
+func copyTransaction(t *domain.Transaction) *domain.Transaction {
+	c := *t
+	c.FromUserID = copyID(t.FromUserID)
+	c.ToUserID = copyID(t.ToUserID)
+	c.ReversalOfTransactionID = copyID(t.ReversalOfTransactionID)
+	c.ReversedByTransactionID = copyID(t.ReversedByTransactionID)
+	c.ScheduledTransactionID = copyID(t.ScheduledTransactionID)
+	c.ScheduledExecutionID = copyID(t.ScheduledExecutionID)
+	return &c
+}
+
+// copyID returns a copy of an optional ID.
+func copyID(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	c := *id
+	return &c
+}
+
+// percentileCont computes a continuous percentile like Postgres' percentile_cont, interpolating
+// between the closest values. It returns 0 for no values.
+func percentileCont(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
@@ -0,0 +1,150 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// transferTemplatesRepo implements the TransferTemplatesRepo interface in memory.
+type transferTemplatesRepo struct {
+	store *Store
+}
+
+// NewTransferTemplatesRepo creates a new in-memory transfer templates repository.
+func NewTransferTemplatesRepo(store *Store) repository.TransferTemplatesRepo {
+	return &transferTemplatesRepo{store: store}
+}
+
+// Create creates a new transfer template. Template names are unique per user.
+func (r *transferTemplatesRepo) Create(_ context.Context, template *domain.TransferTemplate) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.templates[template.ID]; exists {
+		return fmt.Errorf("failed to create transfer template: duplicate id")
+	}
+	if r.nameTaken(template.UserID, template.Name, template.ID) {
+		return fmt.Errorf("template name already exists")
+	}
+
+	r.store.templates[template.ID] = copyTransferTemplate(template)
+
+	return nil
+}
+
+// GetByID retrieves a transfer template by ID.
+func (r *transferTemplatesRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.TransferTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	template, ok := r.store.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("transfer template not found")
+	}
+
+	return copyTransferTemplate(template), nil
+}
+
+// ListByUser retrieves a user's transfer templates ordered by name with pagination.
+func (r *transferTemplatesRepo) ListByUser(_ context.Context, userID uuid.UUID, limit, offset int) ([]*domain.TransferTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var templates []*domain.TransferTemplate
+	for _, template := range r.store.templates {
+		if template.UserID == userID {
+			templates = append(templates, copyTransferTemplate(template))
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	start, end := paginate(len(templates), limit, offset)
+	return templates[start:end], nil
+}
+
+// Update updates the name and transfer details of a template.
+func (r *transferTemplatesRepo) Update(_ context.Context, template *domain.TransferTemplate) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.templates[template.ID]
+	if !ok {
+		return fmt.Errorf("transfer template not found")
+	}
+	if r.nameTaken(stored.UserID, template.Name, template.ID) {
+		return fmt.Errorf("template name already exists")
+	}
+
+	stored.Name = template.Name
+	stored.PayeeID = template.PayeeID
+	stored.DefaultAmount = template.DefaultAmount
+	stored.Currency = template.Currency
+	stored.UpdatedAt = template.UpdatedAt
+
+	return nil
+}
+
+// Delete deletes a transfer template. Scheduled transactions bound to it are unbound.
+func (r *transferTemplatesRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.templates[id]; !ok {
+		return fmt.Errorf("transfer template not found")
+	}
+	delete(r.store.templates, id)
+
+	for _, row := range r.store.scheduled {
+		if row.st.TemplateID != nil && *row.st.TemplateID == id {
+			row.st.TemplateID = nil
+		}
+	}
+
+	return nil
+}
+
+// RecordUse adds a transfer of amount made at usedAt to the template's usage statistics.
+func (r *transferTemplatesRepo) RecordUse(_ context.Context, id uuid.UUID, amount float64, usedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	template, ok := r.store.templates[id]
+	if !ok {
+		return fmt.Errorf("transfer template not found")
+	}
+
+	template.Usage.UseCount++
+	template.Usage.TotalAmount += amount
+	if template.Usage.LastUsedAt == nil || usedAt.After(*template.Usage.LastUsedAt) {
+		template.Usage.LastUsedAt = &usedAt
+	}
+
+	return nil
+}
+
+// nameTaken reports whether another of the user's templates has the name. The caller must hold the store's lock.
+func (r *transferTemplatesRepo) nameTaken(userID uuid.UUID, name string, id uuid.UUID) bool {
+	for _, template := range r.store.templates {
+		if template.UserID == userID && template.Name == name && template.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// copyTransferTemplate returns a copy of a transfer template that shares no pointers with it.
+func copyTransferTemplate(template *domain.TransferTemplate) *domain.TransferTemplate {
+	c := *template
+	c.Usage.LastUsedAt = copyTime(template.Usage.LastUsedAt)
+	return &c
+}
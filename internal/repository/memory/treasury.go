@@ -0,0 +1,162 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// treasuryRepo implements the TreasuryRepo interface in memory. Every credit is issued from the
+// treasury, so services that move money need it alongside balances and transactions.
+type treasuryRepo struct {
+	store *Store
+}
+
+// NewTreasuryRepo creates a new in-memory treasury repository.
+func NewTreasuryRepo(store *Store) repository.TreasuryRepo {
+	return &treasuryRepo{store: store}
+}
+
+// ListAccounts retrieves every treasury account ordered by currency.
+func (r *treasuryRepo) ListAccounts(_ context.Context) ([]*domain.TreasuryAccount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	accounts := make([]*domain.TreasuryAccount, 0, len(r.store.treasury))
+	for _, account := range r.store.treasury {
+		accounts = append(accounts, copyTreasuryAccount(account))
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Currency < accounts[j].Currency })
+
+	return accounts, nil
+}
+
+// GetAccount retrieves the treasury account of a currency.
+func (r *treasuryRepo) GetAccount(_ context.Context, currency string) (*domain.TreasuryAccount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	account, exists := r.store.treasury[currency]
+	if !exists {
+		return nil, fmt.Errorf("treasury account not found")
+	}
+
+	return copyTreasuryAccount(account), nil
+}
+
+// SetCaps replaces the credit caps of a currency's treasury. A nil cap removes it.
+func (r *treasuryRepo) SetCaps(_ context.Context, currency string, maxCreditAmount *float64, dailyCreditCap *float64, at time.Time) (*domain.TreasuryAccount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	account, exists := r.store.treasury[currency]
+	if !exists {
+		return nil, fmt.Errorf("treasury account not found")
+	}
+
+	account.MaxCreditAmount = copyFloat(maxCreditAmount)
+	account.DailyCreditCap = copyFloat(dailyCreditCap)
+	account.UpdatedAt = at
+
+	return copyTreasuryAccount(account), nil
+}
+
+// Record applies a ledger entry to its treasury and appends it to the ledger atomically. When
+// enforceCaps is set, issues are checked against the credit caps.
+func (r *treasuryRepo) Record(_ context.Context, entry *domain.TreasuryEntry, enforceCaps bool) (*domain.TreasuryAccount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, exists := r.store.treasury[entry.Currency]
+	if !exists {
+		return nil, fmt.Errorf("treasury account not found")
+	}
+	account := copyTreasuryAccount(stored)
+
+	if enforceCaps && entry.Kind == domain.TreasuryIssue {
+		since := entry.CreatedAt.UTC().Truncate(24 * time.Hour)
+		issuedToday := 0.0
+		for _, e := range r.store.treasuryEntries {
+			if e.Currency == entry.Currency && e.Kind == domain.TreasuryIssue && !e.CreatedAt.Before(since) {
+				issuedToday += e.Amount
+			}
+		}
+		if err := account.CheckCredit(entry.Amount, issuedToday); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := account.Apply(entry.Kind, entry.Amount); err != nil {
+		return nil, err
+	}
+	account.UpdatedAt = entry.CreatedAt
+	entry.BalanceAfter = account.Balance
+
+	r.store.treasury[entry.Currency] = copyTreasuryAccount(account)
+	r.store.treasuryEntries = append(r.store.treasuryEntries, copyTreasuryEntry(entry))
+
+	return account, nil
+}
+
+// ListEntries retrieves a treasury's ledger, newest first.
+func (r *treasuryRepo) ListEntries(_ context.Context, filter *domain.TreasuryEntryFilter) ([]*domain.TreasuryEntry, error) {
+	r.store.mu.RLock()
+	var entries []*domain.TreasuryEntry
+	for i := len(r.store.treasuryEntries) - 1; i >= 0; i-- {
+		entry := r.store.treasuryEntries[i]
+		if entry.Currency != filter.Currency || (filter.Kind != nil && entry.Kind != *filter.Kind) {
+			continue
+		}
+		entries = append(entries, copyTreasuryEntry(entry))
+	}
+	r.store.mu.RUnlock()
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].CreatedAt.After(entries[j].CreatedAt) })
+
+	start, end := paginate(len(entries), filter.Limit, filter.Offset)
+	return entries[start:end], nil
+}
+
+// SumUserBalances returns the money held by users per currency.
+func (r *treasuryRepo) SumUserBalances(_ context.Context) (map[string]float64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	sums := make(map[string]float64)
+	for _, balance := range r.store.balances {
+		sums[balance.Currency] += balance.Amount
+	}
+
+	return sums, nil
+}
+
+// copyTreasuryAccount returns a copy of account that shares no pointers with it.
+func copyTreasuryAccount(account *domain.TreasuryAccount) *domain.TreasuryAccount {
+	c := *account
+	c.MaxCreditAmount = copyFloat(account.MaxCreditAmount)
+	c.DailyCreditCap = copyFloat(account.DailyCreditCap)
+	return &c
+}
+
+// copyTreasuryEntry returns a copy of entry that shares no pointers with it.
+func copyTreasuryEntry(entry *domain.TreasuryEntry) *domain.TreasuryEntry {
+	c := *entry
+	c.TransactionID = copyID(entry.TransactionID)
+	c.ActorID = copyID(entry.ActorID)
+	return &c
+}
+
+// copyFloat returns a copy of an optional amount.
+func copyFloat(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	return &c
+}
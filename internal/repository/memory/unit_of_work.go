@@ -0,0 +1,83 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// unitOfWork implements the repository.UnitOfWork interface in memory.
+type unitOfWork struct {
+	store *Store
+}
+
+// UnitOfWork returns a unit of work on the store. Every repository takes part in its
+// transactions: they run one at a time, writing to the store directly, and a rollback restores
+// the store as it was when the transaction began. Writes made outside units of work while one is
+// open are lost if it rolls back, so tests should not mix the two concurrently.
+func (s *Store) UnitOfWork() repository.UnitOfWork {
+	return &unitOfWork{store: s}
+}
+
+// Begin waits for the open transaction, if any, to end, then starts a transaction and returns
+// the store's repositories bound to it.
+func (u *unitOfWork) Begin(_ context.Context) (repository.Tx, error) {
+	u.store.txMu.Lock()
+
+	u.store.mu.RLock()
+	snapshot := u.store.tables.clone()
+	u.store.mu.RUnlock()
+
+	return &unitOfWorkTx{store: u.store, snapshot: snapshot, repos: u.store.Repositories()}, nil
+}
+
+// unitOfWorkTx implements the repository.Tx interface on a snapshot of the store.
+type unitOfWorkTx struct {
+	store    *Store
+	snapshot tables // The store's data when the transaction began
+	repos    *repository.Repositories
+	mu       sync.Mutex
+	done     bool
+}
+
+// Repos returns the repositories bound to the transaction.
+func (t *unitOfWorkTx) Repos() *repository.Repositories {
+	return t.repos
+}
+
+// Commit keeps the transaction's writes and lets the next transaction begin.
+func (t *unitOfWorkTx) Commit(_ context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return pgx.ErrTxClosed
+	}
+	t.done = true
+	t.store.txMu.Unlock()
+
+	return nil
+}
+
+// Rollback restores the store as it was when the transaction began. It does nothing once the
+// transaction is committed.
+func (t *unitOfWorkTx) Rollback(_ context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return nil
+	}
+	t.done = true
+
+	t.store.mu.Lock()
+	t.store.tables = t.snapshot
+	t.store.mu.Unlock()
+	t.store.txMu.Unlock()
+
+	return nil
+}
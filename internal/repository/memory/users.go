@@ -0,0 +1,238 @@
+//go:build memrepo
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// usersRepo implements the UsersRepo interface in memory.
+type usersRepo struct {
+	store *Store
+}
+
+// NewUsersRepo creates a new in-memory users repository.
+func NewUsersRepo(store *Store) repository.UsersRepo {
+	return &usersRepo{store: store}
+}
+
+// Create creates a new user. Like the database constraints, usernames and emails are unique and
+// the role must be user or admin.
+func (r *usersRepo) Create(_ context.Context, user *domain.User) error {
+	if !validRole(user.Role) {
+		return fmt.Errorf("failed to create user: invalid role: %s", user.Role)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	if _, exists := r.store.users[user.ID]; exists {
+		return fmt.Errorf("failed to create user: duplicate id")
+	}
+	for _, existing := range r.store.users {
+		if existing.Username == user.Username {
+			return fmt.Errorf("failed to create user: duplicate username")
+		}
+		if existing.Email == user.Email {
+			return fmt.Errorf("failed to create user: duplicate email")
+		}
+	}
+
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	user.IsActive = true // New users are active by default
+	user.AccountNumber = domain.AccountNumberFor(user.ID)
+
+	stored := *user
+	r.store.users[user.ID] = &stored
+	r.store.userSeq[user.ID] = r.store.nextSeq()
+
+	return nil
+}
+
+// CreateMany creates users in bulk. Every user is checked against the constraints Create
+// enforces, and against the others of the batch, before any is stored.
+func (r *usersRepo) CreateMany(_ context.Context, users []*domain.User) error {
+	for _, user := range users {
+		if !validRole(user.Role) {
+			return fmt.Errorf("failed to create users: invalid role: %s", user.Role)
+		}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	ids := make(map[uuid.UUID]bool, len(r.store.users)+len(users))
+	usernames := make(map[string]bool, len(r.store.users)+len(users))
+	emails := make(map[string]bool, len(r.store.users)+len(users))
+	for _, existing := range r.store.users {
+		ids[existing.ID], usernames[existing.Username], emails[existing.Email] = true, true, true
+	}
+	for _, user := range users {
+		if user.ID == uuid.Nil {
+			user.ID = uuid.New()
+		}
+		switch {
+		case ids[user.ID]:
+			return fmt.Errorf("failed to create users: duplicate id")
+		case usernames[user.Username]:
+			return fmt.Errorf("failed to create users: duplicate username")
+		case emails[user.Email]:
+			return fmt.Errorf("failed to create users: duplicate email")
+		}
+		ids[user.ID], usernames[user.Username], emails[user.Email] = true, true, true
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		user.CreatedAt = now
+		user.UpdatedAt = now
+		user.IsActive = true
+		user.AccountNumber = domain.AccountNumberFor(user.ID)
+
+		stored := *user
+		r.store.users[user.ID] = &stored
+		r.store.userSeq[user.ID] = r.store.nextSeq()
+	}
+
+	return nil
+}
+
+// GetByID retrieves an active user by ID.
+func (r *usersRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.ID == id })
+}
+
+// GetByEmail retrieves an active user by email.
+func (r *usersRepo) GetByEmail(_ context.Context, email string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.Email == email })
+}
+
+// GetByUsername retrieves an active user by username.
+func (r *usersRepo) GetByUsername(_ context.Context, username string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.Username == username })
+}
+
+// GetByAccountNumber retrieves an active user by account number.
+func (r *usersRepo) GetByAccountNumber(_ context.Context, accountNumber string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.AccountNumber == accountNumber })
+}
+
+// Update updates an existing user.
+func (r *usersRepo) Update(_ context.Context, user *domain.User) error {
+	if !validRole(user.Role) {
+		return fmt.Errorf("failed to update user: invalid role: %s", user.Role)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, exists := r.store.users[user.ID]
+	if !exists {
+		return fmt.Errorf("user not found")
+	}
+
+	user.UpdatedAt = time.Now()
+	user.CreatedAt = stored.CreatedAt
+	user.AccountNumber = stored.AccountNumber // Never changes, like the ID it is derived from
+	*stored = *user
+
+	return nil
+}
+
+// Delete soft deletes a user by ID.
+func (r *usersRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, exists := r.store.users[id]
+	if !exists || !stored.IsActive {
+		return fmt.Errorf("user not found or already inactive")
+	}
+
+	stored.IsActive = false
+	stored.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ListPaginated retrieves active users with pagination, newest first.
+func (r *usersRepo) ListPaginated(_ context.Context, limit, offset int) ([]*domain.User, error) {
+	users := r.listActive()
+	start, end := paginate(len(users), limit, offset)
+	return users[start:end], nil
+}
+
+// ListAll retrieves all active users, newest first.
+func (r *usersRepo) ListAll(_ context.Context) ([]*domain.User, error) {
+	return r.listActive(), nil
+}
+
+// Count returns the number of active users.
+func (r *usersRepo) Count(_ context.Context) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, u := range r.store.users {
+		if u.IsActive {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// find returns a copy of the first active user matching match.
+func (r *usersRepo) find(match func(*domain.User) bool) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, u := range r.store.users {
+		if u.IsActive && match(u) {
+			user := *u
+			return &user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user not found")
+}
+
+// listActive returns copies of the active users ordered by created_at DESC.
+func (r *usersRepo) listActive() []*domain.User {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := make([]*domain.User, 0, len(r.store.users))
+	for _, u := range r.store.users {
+		if u.IsActive {
+			user := *u
+			users = append(users, &user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.After(users[j].CreatedAt)
+		}
+		return r.store.userSeq[users[i].ID] > r.store.userSeq[users[j].ID]
+	})
+
+	return users
+}
+
+// validRole reports whether role is allowed by the users table.
+func validRole(role string) bool {
+	return role == string(domain.RoleUser) || role == string(domain.RoleAdmin)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// monthlySummariesRepo implements the MonthlySummariesRepo interface.
+type monthlySummariesRepo struct {
+	db DBTX
+}
+
+// NewMonthlySummariesRepo creates a new monthly summaries repository.
+func NewMonthlySummariesRepo(db DBTX) MonthlySummariesRepo {
+	return &monthlySummariesRepo{db: db}
+}
+
+// Create stores a summary unless the user already has one for the month, and reports whether it was stored.
+func (r *monthlySummariesRepo) Create(ctx context.Context, summary *domain.MonthlySummary) (bool, error) {
+	counterparties, err := json.Marshal(summary.TopCounterparties)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode top counterparties: %w", err)
+	}
+
+	query := `
+		INSERT INTO monthly_summaries (id, user_id, month, currency, opening_balance, closing_balance,
+			total_in, total_out, fees_paid, transaction_count, top_counterparties, generated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (user_id, month) DO NOTHING`
+
+	tag, err := r.db.Exec(ctx, query,
+		summary.ID,
+		summary.UserID,
+		summary.Month,
+		summary.Currency,
+		summary.OpeningBalance,
+		summary.ClosingBalance,
+		summary.TotalIn,
+		summary.TotalOut,
+		summary.FeesPaid,
+		summary.TransactionCount,
+		string(counterparties),
+		summary.GeneratedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create monthly summary: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// Get retrieves the user's summary of a yyyy-mm month.
+func (r *monthlySummariesRepo) Get(ctx context.Context, userID uuid.UUID, month string) (*domain.MonthlySummary, error) {
+	query := `
+		SELECT id, user_id, month, currency, opening_balance, closing_balance,
+			total_in, total_out, fees_paid, transaction_count, top_counterparties, generated_at
+		FROM monthly_summaries
+		WHERE user_id = $1 AND month = $2`
+
+	var summary domain.MonthlySummary
+	var counterparties []byte
+	err := r.db.QueryRow(ctx, query, userID, month).Scan(
+		&summary.ID,
+		&summary.UserID,
+		&summary.Month,
+		&summary.Currency,
+		&summary.OpeningBalance,
+		&summary.ClosingBalance,
+		&summary.TotalIn,
+		&summary.TotalOut,
+		&summary.FeesPaid,
+		&summary.TransactionCount,
+		&counterparties,
+		&summary.GeneratedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("monthly summary not found")
+		}
+		return nil, fmt.Errorf("failed to get monthly summary: %w", err)
+	}
+
+	if err := json.Unmarshal(counterparties, &summary.TopCounterparties); err != nil {
+		return nil, fmt.Errorf("failed to decode top counterparties: %w", err)
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// nettingBatchColumns lists the columns scanNettingBatch reads, in order.
+const nettingBatchColumns = `id, user_a_id, user_b_id, currency, status, transfer_count, gross_amount, net_amount,
+	net_from_user_id, COALESCE(failure_reason, ''), opened_at, closes_at, settled_at`
+
+// nettingRepo implements the NettingRepo interface.
+type nettingRepo struct {
+	db DBTX
+}
+
+// NewNettingRepo creates a new netting batches repository.
+func NewNettingRepo(db DBTX) NettingRepo {
+	return &nettingRepo{db: db}
+}
+
+// OpenBatch returns the open batch of a pair, locked with FOR UPDATE, opening one if there is none.
+// A batch closed while this call waited for its lock is skipped and a new one opened.
+func (r *nettingRepo) OpenBatch(ctx context.Context, userAID, userBID uuid.UUID, currency string, closesAt time.Time) (*domain.NettingBatch, error) {
+	insert := `
+		INSERT INTO netting_batches (id, user_a_id, user_b_id, currency, status, opened_at, closes_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_a_id, user_b_id, currency) WHERE status = 'open' DO NOTHING`
+
+	query := `
+		SELECT ` + nettingBatchColumns + `
+		FROM netting_batches
+		WHERE user_a_id = $1 AND user_b_id = $2 AND currency = $3 AND status = $4
+		FOR UPDATE`
+
+	for attempt := 0; attempt < 3; attempt++ {
+		if _, err := r.db.Exec(ctx, insert, uuid.New(), userAID, userBID, currency, domain.NettingOpen, time.Now(), closesAt); err != nil {
+			return nil, fmt.Errorf("failed to open netting batch: %w", err)
+		}
+
+		batch, err := scanNettingBatch(r.db.QueryRow(ctx, query, userAID, userBID, currency, domain.NettingOpen))
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get open netting batch: %w", err)
+		}
+		return batch, nil
+	}
+
+	return nil, fmt.Errorf("failed to open netting batch: batch kept closing")
+}
+
+// AddTransfer records a pending transfer in an open batch.
+func (r *nettingRepo) AddTransfer(ctx context.Context, batchID uuid.UUID, transfer *domain.NettedTransfer) error {
+	query := `
+		INSERT INTO netting_batch_transfers (transaction_id, batch_id, from_user_id, amount)
+		SELECT $1, id, $3, $4
+		FROM netting_batches
+		WHERE id = $2 AND status = $5`
+
+	result, err := r.db.Exec(ctx, query, transfer.TransactionID, batchID, transfer.FromUserID, transfer.Amount, domain.NettingOpen)
+	if err != nil {
+		return fmt.Errorf("failed to add netted transfer: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("netting batch not open")
+	}
+
+	return nil
+}
+
+// ReservedAmount sums a user's outgoing transfers waiting in open batches of a currency.
+func (r *nettingRepo) ReservedAmount(ctx context.Context, userID uuid.UUID, currency string) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(t.amount), 0)
+		FROM netting_batch_transfers t
+		JOIN netting_batches b ON b.id = t.batch_id
+		WHERE t.from_user_id = $1 AND b.currency = $2 AND b.status = $3`
+
+	var reserved float64
+	if err := r.db.QueryRow(ctx, query, userID, currency, domain.NettingOpen).Scan(&reserved); err != nil {
+		return 0, fmt.Errorf("failed to sum reserved netting amount: %w", err)
+	}
+
+	return reserved, nil
+}
+
+// ListDue retrieves open batches whose window closed, oldest first.
+func (r *nettingRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.NettingBatch, error) {
+	query := `
+		SELECT ` + nettingBatchColumns + `
+		FROM netting_batches
+		WHERE status = $1 AND closes_at <= $2
+		ORDER BY closes_at
+		LIMIT $3`
+
+	return r.queryBatches(ctx, query, domain.NettingOpen, now, limit)
+}
+
+// ListTransfers locks a batch and retrieves its transfers.
+func (r *nettingRepo) ListTransfers(ctx context.Context, batchID uuid.UUID) ([]*domain.NettedTransfer, error) {
+	// Transfers being added hold the batch lock, so they are either committed and read here or
+	// wait for the batch to close and are rejected
+	if _, err := r.db.Exec(ctx, `SELECT 1 FROM netting_batches WHERE id = $1 FOR UPDATE`, batchID); err != nil {
+		return nil, fmt.Errorf("failed to lock netting batch: %w", err)
+	}
+
+	query := `
+		SELECT transaction_id, from_user_id, amount
+		FROM netting_batch_transfers
+		WHERE batch_id = $1
+		ORDER BY transaction_id`
+
+	rows, err := r.db.Query(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list netted transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*domain.NettedTransfer
+	for rows.Next() {
+		var transfer domain.NettedTransfer
+		if err := rows.Scan(&transfer.TransactionID, &transfer.FromUserID, &transfer.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan netted transfer: %w", err)
+		}
+		transfers = append(transfers, &transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate netted transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// Close records the outcome of an open batch.
+func (r *nettingRepo) Close(ctx context.Context, batch *domain.NettingBatch) error {
+	query := `
+		UPDATE netting_batches
+		SET status = $2, transfer_count = $3, gross_amount = $4, net_amount = $5, net_from_user_id = $6,
+			failure_reason = NULLIF($7, ''), settled_at = $8
+		WHERE id = $1 AND status = $9`
+
+	result, err := r.db.Exec(ctx, query, batch.ID, batch.Status, batch.TransferCount, batch.GrossAmount, batch.NetAmount,
+		batch.NetFromUserID, batch.FailureReason, batch.SettledAt, domain.NettingOpen)
+	if err != nil {
+		return fmt.Errorf("failed to close netting batch: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("netting batch not open")
+	}
+
+	return nil
+}
+
+// List retrieves batches, newest first.
+func (r *nettingRepo) List(ctx context.Context, filter *domain.NettingBatchFilter) ([]*domain.NettingBatch, error) {
+	query := `
+		SELECT ` + nettingBatchColumns + `
+		FROM netting_batches`
+
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Status != nil {
+		query += fmt.Sprintf(" WHERE status = $%d", argIndex)
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+
+	query += " ORDER BY opened_at DESC, id"
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	return r.queryBatches(ctx, query, args...)
+}
+
+// queryBatches runs a query selecting nettingBatchColumns.
+func (r *nettingRepo) queryBatches(ctx context.Context, query string, args ...interface{}) ([]*domain.NettingBatch, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list netting batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*domain.NettingBatch
+	for rows.Next() {
+		batch, err := scanNettingBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan netting batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate netting batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// scanNettingBatch scans a single row of nettingBatchColumns.
+func scanNettingBatch(row pgx.Row) (*domain.NettingBatch, error) {
+	var batch domain.NettingBatch
+	err := row.Scan(
+		&batch.ID,
+		&batch.UserAID,
+		&batch.UserBID,
+		&batch.Currency,
+		&batch.Status,
+		&batch.TransferCount,
+		&batch.GrossAmount,
+		&batch.NetAmount,
+		&batch.NetFromUserID,
+		&batch.FailureReason,
+		&batch.OpenedAt,
+		&batch.ClosesAt,
+		&batch.SettledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batch, nil
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// notificationsRepo implements the NotificationsRepo interface.
+type notificationsRepo struct {
+	db DBTX
+}
+
+// NewNotificationsRepo creates a new notifications repository.
+func NewNotificationsRepo(db DBTX) NotificationsRepo {
+	return &notificationsRepo{db: db}
+}
+
+// CreateNotification adds a notification to a user's inbox. Inserting a notification whose ID
+// already exists is a no-op, so a retried delivery cannot show up twice.
+func (r *notificationsRepo) CreateNotification(ctx context.Context, notification *domain.Notification) error {
+	query := `
+		INSERT INTO notifications (id, user_id, type, title, body, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Body,
+		notification.Data,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetNotification retrieves an inbox notification by ID.
+func (r *notificationsRepo) GetNotification(ctx context.Context, id uuid.UUID) (*domain.Notification, error) {
+	query := `
+		SELECT id, user_id, type, title, body, data, read_at, created_at
+		FROM notifications
+		WHERE id = $1`
+
+	notification, err := scanNotification(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("notification not found")
+		}
+		return nil, fmt.Errorf("failed to get notification by ID: %w", err)
+	}
+
+	return notification, nil
+}
+
+// ListNotifications retrieves a user's inbox, newest first.
+func (r *notificationsRepo) ListNotifications(ctx context.Context, filter *domain.NotificationFilter) ([]*domain.Notification, error) {
+	baseQuery := `
+		SELECT id, user_id, type, title, body, data, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1`
+
+	args := []interface{}{filter.UserID}
+	argIndex := 2
+
+	query := baseQuery
+	if filter.UnreadOnly {
+		query += " AND read_at IS NULL"
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread returns the number of unread notifications in a user's inbox.
+func (r *notificationsRepo) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification as read. Notifications that were already read keep their original read time.
+func (r *notificationsRepo) MarkRead(ctx context.Context, id uuid.UUID, readAt time.Time) error {
+	result, err := r.db.Exec(ctx, `UPDATE notifications SET read_at = COALESCE(read_at, $2) WHERE id = $1`, id, readAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("notification not found")
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every unread notification of a user as read and returns how many were marked.
+func (r *notificationsRepo) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL`, userID, readAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetPreferences retrieves a user's stored notification preferences.
+func (r *notificationsRepo) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, channels, COALESCE(webhook_url, ''), updated_at
+		FROM notification_preferences
+		WHERE user_id = $1`
+
+	var prefs domain.NotificationPreferences
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&prefs.UserID,
+		&prefs.Channels,
+		&prefs.WebhookURL,
+		&prefs.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("notification preferences not found")
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return &prefs, nil
+}
+
+// UpsertPreferences creates or replaces a user's notification preferences.
+func (r *notificationsRepo) UpsertPreferences(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, channels, webhook_url, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET channels = EXCLUDED.channels, webhook_url = EXCLUDED.webhook_url, updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(ctx, query,
+		prefs.UserID,
+		prefs.Channels,
+		prefs.WebhookURL,
+		prefs.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDelivery queues a notification for delivery on one channel.
+func (r *notificationsRepo) CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	query := `
+		INSERT INTO notification_deliveries (id, user_id, type, channel, data, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(ctx, query,
+		delivery.ID,
+		delivery.UserID,
+		delivery.Type,
+		delivery.Channel,
+		delivery.Data,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries claims up to limit pending deliveries that are due at now, oldest first.
+// Claimed deliveries are pushed back to leaseUntil so other instances skip them while they are
+// being sent; a delivery whose sender crashes is retried once the lease runs out.
+func (r *notificationsRepo) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.NotificationDelivery, error) {
+	query := `
+		UPDATE notification_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, type, channel, data, status, attempts, COALESCE(last_error, ''),
+		          next_attempt_at, created_at, sent_at`
+
+	rows, err := r.db.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notification deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.NotificationDelivery
+	for rows.Next() {
+		var delivery domain.NotificationDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.UserID,
+			&delivery.Type,
+			&delivery.Channel,
+			&delivery.Data,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.SentAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery saves the outcome of a delivery attempt.
+func (r *notificationsRepo) UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = $2, attempts = $3, last_error = NULLIF($4, ''), next_attempt_at = $5, sent_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.SentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("notification delivery not found")
+	}
+
+	return nil
+}
+
+// scanNotification scans a single inbox notification row.
+func scanNotification(row pgx.Row) (*domain.Notification, error) {
+	var notification domain.Notification
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Type,
+		&notification.Title,
+		&notification.Body,
+		&notification.Data,
+		&notification.ReadAt,
+		&notification.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
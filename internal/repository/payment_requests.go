@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// paymentRequestsRepo implements the PaymentRequestsRepo interface.
+type paymentRequestsRepo struct {
+	db DBTX
+}
+
+// NewPaymentRequestsRepo creates a new payment requests repository.
+func NewPaymentRequestsRepo(db DBTX) PaymentRequestsRepo {
+	return &paymentRequestsRepo{db: db}
+}
+
+// Create creates a new payment request.
+func (r *paymentRequestsRepo) Create(ctx context.Context, request *domain.PaymentRequest) error {
+	query := `
+		INSERT INTO payment_requests (id, requester_id, payer_id, amount, currency, note, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)`
+
+	_, err := r.db.Exec(ctx, query,
+		request.ID,
+		request.RequesterID,
+		request.PayerID,
+		request.Amount,
+		request.Currency,
+		request.Note,
+		request.Status,
+		request.ExpiresAt,
+		request.CreatedAt,
+		request.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payment request: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a payment request by ID.
+func (r *paymentRequestsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentRequest, error) {
+	query := `
+		SELECT id, requester_id, payer_id, amount, currency, COALESCE(note, ''), status,
+		       transaction_id, expires_at, created_at, updated_at, responded_at
+		FROM payment_requests
+		WHERE id = $1`
+
+	request, err := scanPaymentRequest(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("payment request not found")
+		}
+		return nil, fmt.Errorf("failed to get payment request by ID: %w", err)
+	}
+
+	return request, nil
+}
+
+// List retrieves payment requests with filtering, newest first.
+func (r *paymentRequestsRepo) List(ctx context.Context, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error) {
+	baseQuery := `
+		SELECT id, requester_id, payer_id, amount, currency, COALESCE(note, ''), status,
+		       transaction_id, expires_at, created_at, updated_at, responded_at
+		FROM payment_requests
+		WHERE 1=1`
+
+	args := []interface{}{}
+	conditions := []string{}
+	argIndex := 1
+
+	// Apply filters
+	if filter != nil {
+		if filter.RequesterID != nil {
+			conditions = append(conditions, fmt.Sprintf("requester_id = $%d", argIndex))
+			args = append(args, *filter.RequesterID)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.PayerID != nil {
+			conditions = append(conditions, fmt.Sprintf("payer_id = $%d", argIndex))
+			args = append(args, *filter.PayerID)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.Status != nil {
+			conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+			args = append(args, *filter.Status)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+	}
+
+	// Build final query
+	query := baseQuery
+	for _, condition := range conditions {
+		query += " AND " + condition
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	// Apply pagination
+	if filter != nil {
+		if filter.Limit > 0 {
+			query += fmt.Sprintf(" LIMIT $%d", argIndex)
+			args = append(args, filter.Limit)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.Offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", argIndex)
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*domain.PaymentRequest
+	for rows.Next() {
+		request, err := scanPaymentRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payment requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// UpdateStatus saves the status, transaction and response time of a payment request, but only
+// if its stored status is still fromStatus, so two concurrent responses cannot both succeed.
+func (r *paymentRequestsRepo) UpdateStatus(ctx context.Context, request *domain.PaymentRequest, fromStatus string) error {
+	query := `
+		UPDATE payment_requests
+		SET status = $3, transaction_id = $4, updated_at = $5, responded_at = $6
+		WHERE id = $1 AND status = $2`
+
+	result, err := r.db.Exec(ctx, query,
+		request.ID,
+		fromStatus,
+		request.Status,
+		request.TransactionID,
+		request.UpdatedAt,
+		request.RespondedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payment request: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("payment request status changed")
+	}
+
+	return nil
+}
+
+// scanPaymentRequest scans a single payment request row.
+func scanPaymentRequest(row pgx.Row) (*domain.PaymentRequest, error) {
+	var request domain.PaymentRequest
+	err := row.Scan(
+		&request.ID,
+		&request.RequesterID,
+		&request.PayerID,
+		&request.Amount,
+		&request.Currency,
+		&request.Note,
+		&request.Status,
+		&request.TransactionID,
+		&request.ExpiresAt,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&request.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// policiesRepo implements the PoliciesRepo interface.
+type policiesRepo struct {
+	db DBTX
+}
+
+// NewPoliciesRepo creates a new policies repository.
+func NewPoliciesRepo(db DBTX) PoliciesRepo {
+	return &policiesRepo{db: db}
+}
+
+// Create stores a new version of the policy, setting its version number.
+func (r *policiesRepo) Create(ctx context.Context, version *domain.PolicyVersion) error {
+	policy, err := json.Marshal(version.Policy)
+	if err != nil {
+		return fmt.Errorf("failed to encode policy: %w", err)
+	}
+
+	query := `
+		INSERT INTO policy_versions (id, policy, effective_from, reason, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING version`
+
+	err = r.db.QueryRow(ctx, query,
+		version.ID,
+		string(policy),
+		version.EffectiveFrom,
+		version.Reason,
+		version.CreatedBy,
+		version.CreatedAt,
+	).Scan(&version.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create policy version: %w", err)
+	}
+
+	return nil
+}
+
+// GetByVersion retrieves a version of the policy by its number.
+func (r *policiesRepo) GetByVersion(ctx context.Context, version int) (*domain.PolicyVersion, error) {
+	query := `
+		SELECT id, version, policy, effective_from, reason, created_by, created_at
+		FROM policy_versions
+		WHERE version = $1`
+
+	policyVersion, err := scanPolicyVersion(r.db.QueryRow(ctx, query, version))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("policy version not found")
+		}
+		return nil, fmt.Errorf("failed to get policy version: %w", err)
+	}
+
+	return policyVersion, nil
+}
+
+// List retrieves versions of the policy, newest first.
+func (r *policiesRepo) List(ctx context.Context, filter *domain.PolicyVersionFilter) ([]*domain.PolicyVersion, error) {
+	query := `
+		SELECT id, version, policy, effective_from, reason, created_by, created_at
+		FROM policy_versions
+		ORDER BY version DESC`
+
+	var args []interface{}
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*domain.PolicyVersion
+	for rows.Next() {
+		version, err := scanPolicyVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan policy version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate policy versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// EffectiveAt retrieves the version in effect at the given time.
+func (r *policiesRepo) EffectiveAt(ctx context.Context, at time.Time) (*domain.PolicyVersion, error) {
+	query := `
+		SELECT id, version, policy, effective_from, reason, created_by, created_at
+		FROM policy_versions
+		WHERE effective_from <= $1
+		ORDER BY effective_from DESC, version DESC
+		LIMIT 1`
+
+	version, err := scanPolicyVersion(r.db.QueryRow(ctx, query, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("policy version not found")
+		}
+		return nil, fmt.Errorf("failed to get effective policy version: %w", err)
+	}
+
+	return version, nil
+}
+
+// NextAfter retrieves the first version taking effect after the given time, or nil if none is scheduled.
+func (r *policiesRepo) NextAfter(ctx context.Context, at time.Time) (*domain.PolicyVersion, error) {
+	query := `
+		SELECT id, version, policy, effective_from, reason, created_by, created_at
+		FROM policy_versions
+		WHERE effective_from > $1
+		ORDER BY effective_from, version DESC
+		LIMIT 1`
+
+	version, err := scanPolicyVersion(r.db.QueryRow(ctx, query, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get next policy version: %w", err)
+	}
+
+	return version, nil
+}
+
+// scanPolicyVersion scans a policy version row.
+func scanPolicyVersion(row pgx.Row) (*domain.PolicyVersion, error) {
+	var version domain.PolicyVersion
+	var policy []byte
+	err := row.Scan(
+		&version.ID,
+		&version.Version,
+		&policy,
+		&version.EffectiveFrom,
+		&version.Reason,
+		&version.CreatedBy,
+		&version.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(policy, &version.Policy); err != nil {
+		return nil, fmt.Errorf("failed to decode policy: %w", err)
+	}
+	return &version, nil
+}
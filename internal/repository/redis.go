@@ -3,18 +3,24 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
 
+// ErrCacheMiss is returned by Get when the key does not exist.
+var ErrCacheMiss = errors.New("key not found")
+
 // RedisConfig holds Redis configuration
 type RedisConfig struct {
 	Addr     string
 	Password string
 	DB       int
+	Breaker  *utils.CircuitBreaker // Optional; guards every command when set
 }
 
 // RedisClient wraps Redis operations
@@ -38,6 +44,14 @@ func NewRedisClient(config RedisConfig) (*RedisClient, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	// Record a span for every command
+	client.AddHook(redisTracingHook{})
+
+	// Fail fast while Redis is unavailable
+	if config.Breaker != nil {
+		client.AddHook(redisBreakerHook{breaker: config.Breaker})
+	}
+
 	utils.Info("connected to Redis", "addr", config.Addr)
 
 	return &RedisClient{client: client}, nil
@@ -63,7 +77,7 @@ func (r *RedisClient) Get(ctx context.Context, key string, dest interface{}) err
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return fmt.Errorf("key not found: %s", key)
+			return fmt.Errorf("%w: %s", ErrCacheMiss, key)
 		}
 		return fmt.Errorf("failed to get key: %w", err)
 	}
@@ -99,9 +113,56 @@ func (r *RedisClient) TTL(ctx context.Context, key string) (time.Duration, error
 	return r.client.TTL(ctx, key).Result()
 }
 
-// Keys returns all keys matching a pattern
-func (r *RedisClient) Keys(ctx context.Context, pattern string) ([]string, error) {
-	return r.client.Keys(ctx, pattern).Result()
+// CountKeys counts keys matching a pattern using SCAN so Redis is never blocked.
+// Scanning stops once maxKeys keys have been counted (0 means no limit); complete
+// reports whether the whole keyspace was scanned.
+func (r *RedisClient) CountKeys(ctx context.Context, pattern string, batchSize int64, maxKeys int64) (count int64, complete bool, err error) {
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, batchSize).Result()
+		if err != nil {
+			return count, false, fmt.Errorf("failed to scan keys: %w", err)
+		}
+
+		count += int64(len(keys))
+		cursor = next
+
+		if cursor == 0 {
+			return count, true, nil
+		}
+		if maxKeys > 0 && count >= maxKeys {
+			return count, false, nil
+		}
+	}
+}
+
+// Info returns the fields of the given INFO sections as a flat key/value map
+func (r *RedisClient) Info(ctx context.Context, sections ...string) (map[string]string, error) {
+	raw, err := r.client.Info(ctx, sections...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redis info: %w", err)
+	}
+
+	return ParseInfo(raw), nil
+}
+
+// ParseInfo parses the output of the INFO command into a key/value map,
+// skipping section headers and blank lines.
+func ParseInfo(raw string) map[string]string {
+	info := make(map[string]string)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		info[key] = value
+	}
+	return info
 }
 
 // FlushAll clears all data in the current database
@@ -129,10 +190,13 @@ func (r *RedisClient) HSet(ctx context.Context, key string, field string, value
 	return r.client.HSet(ctx, key, field, data).Err()
 }
 
-// HGet gets a field from a hash
+// HGet gets a field from a hash, failing with ErrCacheMiss when the field does not exist
 func (r *RedisClient) HGet(ctx context.Context, key string, field string, dest interface{}) error {
 	data, err := r.client.HGet(ctx, key, field).Result()
 	if err != nil {
+		if err == redis.Nil {
+			return fmt.Errorf("%w: %s %s", ErrCacheMiss, key, field)
+		}
 		return err
 	}
 
@@ -158,6 +222,22 @@ func (r *RedisClient) HGetAll(ctx context.Context, key string) (map[string]strin
 	return r.client.HGetAll(ctx, key).Result()
 }
 
+// HIncrBy increments a field of a hash by incr and returns every field of the hash after the
+// increment, in one round trip. A positive expiration (re)sets the TTL of the hash.
+func (r *RedisClient) HIncrBy(ctx context.Context, key string, field string, incr int64, expiration time.Duration) (map[string]string, error) {
+	pipe := r.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, field, incr)
+	fields := pipe.HGetAll(ctx, key)
+	if expiration > 0 {
+		pipe.Expire(ctx, key, expiration)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return fields.Val(), nil
+}
+
 // LPush pushes values to the left of a list
 func (r *RedisClient) LPush(ctx context.Context, key string, values ...interface{}) error {
 	marshaledValues := make([]interface{}, len(values))
@@ -0,0 +1,25 @@
+package repository
+
+import "testing"
+
+func TestParseInfo(t *testing.T) {
+	raw := "# Memory\r\nused_memory:1048576\r\nused_memory_human:1.00M\r\n\r\n# Keyspace\r\ndb0:keys=12,expires=10,avg_ttl=3000\r\n"
+
+	info := ParseInfo(raw)
+
+	tests := map[string]string{
+		"used_memory":       "1048576",
+		"used_memory_human": "1.00M",
+		"db0":               "keys=12,expires=10,avg_ttl=3000",
+	}
+
+	for key, want := range tests {
+		if got := info[key]; got != want {
+			t.Errorf("ParseInfo()[%q] = %q, want %q", key, got, want)
+		}
+	}
+
+	if _, ok := info["# Memory"]; ok {
+		t.Errorf("ParseInfo() should skip section headers")
+	}
+}
@@ -0,0 +1,65 @@
+package repotest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func testAnomalyScores(t *testing.T, target Target) {
+	ctx := context.Background()
+	scores := target.Repos.AnomalyScores
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	near := func(got, want float64) bool {
+		return math.Abs(got-want) < 1e-6
+	}
+
+	// Users without signals have a zero score
+	score, err := scores.Get(ctx, alice)
+	if err != nil || score.Score != 0 || score.UserID != alice || !score.UpdatedAt.IsZero() {
+		t.Fatalf("Get before any signal = %+v, %v; want a zero score", score, err)
+	}
+
+	halfLife := 10 * time.Minute
+	now := time.Now().Truncate(time.Second)
+	if got, err := scores.Add(ctx, alice, 2, halfLife, now); err != nil || !near(got, 2) {
+		t.Fatalf("first Add = %v, %v; want 2", got, err)
+	}
+
+	// The score halves every half-life before points are added
+	if got, err := scores.Add(ctx, alice, 1, halfLife, now.Add(halfLife)); err != nil || !near(got, 2) {
+		t.Fatalf("Add a half-life later = %v, %v; want 2", got, err)
+	}
+
+	// A signal from before the last one does not decay or rewind the score
+	if got, err := scores.Add(ctx, alice, 1, halfLife, now); err != nil || !near(got, 3) {
+		t.Fatalf("Add of an earlier signal = %v, %v; want 3", got, err)
+	}
+
+	score, err = scores.Get(ctx, alice)
+	if err != nil || !near(score.Score, 3) || !score.UpdatedAt.Equal(now.Add(halfLife)) {
+		t.Fatalf("Get = %+v, %v; want 3 as of the latest signal", score, err)
+	}
+	if got := score.At(now.Add(2*halfLife), halfLife); !near(got, 1.5) {
+		t.Errorf("At a half-life later = %v, want 1.5", got)
+	}
+
+	// Scores are per user
+	if score, err := scores.Get(ctx, bob); err != nil || score.Score != 0 {
+		t.Errorf("Get of another user = %+v, %v; want a zero score", score, err)
+	}
+
+	if err := scores.Reset(ctx, alice); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if score, err := scores.Get(ctx, alice); err != nil || score.Score != 0 {
+		t.Errorf("Get after Reset = %+v, %v; want a zero score", score, err)
+	}
+	if err := scores.Reset(ctx, bob); err != nil {
+		t.Errorf("Reset of a user without a score: %v", err)
+	}
+}
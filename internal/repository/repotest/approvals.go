@@ -0,0 +1,116 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testApprovals(t *testing.T, target Target) {
+	ctx := context.Background()
+	approvals := target.Repos.Approvals
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	now := time.Now().Truncate(time.Second)
+	newApproval := func(action domain.ApprovalAction, payload string, createdAt time.Time) *domain.Approval {
+		t.Helper()
+		approval := &domain.Approval{
+			ID:          uuid.New(),
+			Action:      action,
+			Payload:     json.RawMessage(payload),
+			RequestedBy: alice,
+			Status:      domain.ApprovalPending,
+			CreatedAt:   createdAt,
+		}
+		if err := approvals.Create(ctx, approval); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return approval
+	}
+
+	rollback := newApproval(domain.ApprovalRollback, `{"transaction_id":"`+uuid.NewString()+`"}`, now)
+	roleChange := newApproval(domain.ApprovalRoleChange, `{"user_id":"`+bob.String()+`"}`, now.Add(time.Second))
+
+	got, err := approvals.GetByID(ctx, rollback.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Action != domain.ApprovalRollback || got.RequestedBy != alice || got.Status != domain.ApprovalPending ||
+		got.ReviewedBy != nil || got.Result != nil || !got.CreatedAt.Equal(now) {
+		t.Errorf("GetByID = %+v, want the pending rollback", got)
+	}
+	var payload domain.RollbackApproval
+	if err := json.Unmarshal(got.Payload, &payload); err != nil || payload.TransactionID == uuid.Nil {
+		t.Errorf("payload = %s, %v; want the rollback request", got.Payload, err)
+	}
+	if _, err := approvals.GetByID(ctx, uuid.New()); err == nil || err.Error() != "approval not found" {
+		t.Errorf("GetByID of a missing approval = %v, want approval not found", err)
+	}
+
+	// Newest first, filtered by status and action
+	list, err := approvals.List(ctx, &domain.ApprovalFilter{})
+	if err != nil || len(list) != 2 || list[0].ID != roleChange.ID || list[1].ID != rollback.ID {
+		t.Fatalf("List = %v, %v; want the role change then the rollback", list, err)
+	}
+	action := domain.ApprovalRollback
+	if list, err := approvals.List(ctx, &domain.ApprovalFilter{Action: &action}); err != nil || len(list) != 1 || list[0].ID != rollback.ID {
+		t.Errorf("List by action = %v, %v; want the rollback", list, err)
+	}
+	if list, err := approvals.List(ctx, &domain.ApprovalFilter{Limit: 1, Offset: 1}); err != nil || len(list) != 1 || list[0].ID != rollback.ID {
+		t.Errorf("List page 2 = %v, %v; want the rollback", list, err)
+	}
+
+	reviewedAt := now.Add(time.Minute)
+	if err := approvals.Review(ctx, rollback.ID, bob, domain.ApprovalApproved, "checked", reviewedAt); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	if err := approvals.Review(ctx, rollback.ID, bob, domain.ApprovalRejected, "", reviewedAt); err == nil || err.Error() != "approval already reviewed" {
+		t.Errorf("second Review = %v, want approval already reviewed", err)
+	}
+	if err := approvals.Review(ctx, uuid.New(), bob, domain.ApprovalApproved, "", reviewedAt); err == nil || err.Error() != "approval not found" {
+		t.Errorf("Review of a missing approval = %v, want approval not found", err)
+	}
+
+	completedAt := reviewedAt.Add(time.Second)
+	if err := approvals.Complete(ctx, rollback.ID, domain.ApprovalExecuted, json.RawMessage(`{"status":"completed"}`), "", completedAt); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	got, err = approvals.GetByID(ctx, rollback.ID)
+	if err != nil {
+		t.Fatalf("GetByID after Complete: %v", err)
+	}
+	if got.Status != domain.ApprovalExecuted || got.ReviewedBy == nil || *got.ReviewedBy != bob || got.ReviewNote != "checked" ||
+		got.ReviewedAt == nil || !got.ReviewedAt.Equal(reviewedAt) || got.CompletedAt == nil || !got.CompletedAt.Equal(completedAt) {
+		t.Errorf("GetByID after Complete = %+v, want the executed rollback reviewed by bob", got)
+	}
+	var result map[string]string
+	if err := json.Unmarshal(got.Result, &result); err != nil || result["status"] != "completed" {
+		t.Errorf("result = %s, %v; want what the action returned", got.Result, err)
+	}
+
+	// The requester may withdraw by rejecting their own approval; failures keep why
+	if err := approvals.Review(ctx, roleChange.ID, alice, domain.ApprovalRejected, "", reviewedAt); err != nil {
+		t.Fatalf("Review rejecting own approval: %v", err)
+	}
+	status := domain.ApprovalPending
+	if list, err := approvals.List(ctx, &domain.ApprovalFilter{Status: &status}); err != nil || len(list) != 0 {
+		t.Errorf("List pending = %v, %v; want none", list, err)
+	}
+
+	failed := newApproval(domain.ApprovalBalanceAdjustment, `{"request":{"type":"debit"}}`, now.Add(2*time.Second))
+	if err := approvals.Review(ctx, failed.ID, bob, domain.ApprovalApproved, "", reviewedAt); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	if err := approvals.Complete(ctx, failed.ID, domain.ApprovalFailed, nil, "insufficient funds", completedAt); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if got, err := approvals.GetByID(ctx, failed.ID); err != nil || got.Status != domain.ApprovalFailed || got.Error != "insufficient funds" || got.Result != nil {
+		t.Errorf("GetByID of failed = %+v, %v; want the failure", got, err)
+	}
+}
@@ -0,0 +1,126 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testAudit(t *testing.T, target Target) {
+	ctx := context.Background()
+	audit := target.Repos.Audit
+
+	userID := uuid.New()
+	transactionID := uuid.New()
+
+	logs := []struct {
+		entityType domain.EntityType
+		entityID   uuid.UUID
+		action     string
+		details    interface{}
+	}{
+		{domain.EntityUser, userID, "register", map[string]interface{}{"username": "alice"}},
+		{domain.EntityTransaction, transactionID, "credit", map[string]interface{}{"amount": 100.0}},
+		{domain.EntityUser, userID, "login", nil},
+	}
+	for _, l := range logs {
+		if err := audit.Log(ctx, string(l.entityType), l.entityID, l.action, l.details); err != nil {
+			t.Fatalf("log %s: %v", l.action, err)
+		}
+		pause()
+	}
+	cutoff := time.Now()
+	pause()
+	if err := audit.Log(ctx, string(domain.EntityUser), userID, "logout", nil); err != nil {
+		t.Fatalf("log logout: %v", err)
+	}
+
+	if err := audit.Log(ctx, "account", userID, "open", nil); err == nil {
+		t.Error("invalid entity type was accepted")
+	}
+
+	all, err := audit.List(ctx, &domain.AuditLogFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 4 || all[0].Action != "logout" || all[3].Action != "register" {
+		t.Fatalf("audit logs = %v, want logout first and register last", actions(all))
+	}
+
+	got, err := audit.GetByID(ctx, all[2].ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.EntityType != string(domain.EntityTransaction) || got.EntityID != transactionID || got.Action != "credit" {
+		t.Errorf("GetByID = %+v, want the credit", got)
+	}
+	var details map[string]interface{}
+	if err := json.Unmarshal(got.Details, &details); err != nil || details["amount"] != 100.0 {
+		t.Errorf("details = %s, %v; want amount 100", got.Details, err)
+	}
+	_, err = audit.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown log", err, "audit log not found")
+
+	userType := domain.EntityUser
+	filters := []struct {
+		name   string
+		filter *domain.AuditLogFilter
+		want   []string
+	}{
+		{"user logs", &domain.AuditLogFilter{EntityType: &userType}, []string{"logout", "login", "register"}},
+		{"transaction logs", &domain.AuditLogFilter{EntityID: &transactionID}, []string{"credit"}},
+		{"logins", &domain.AuditLogFilter{Action: ptr("login")}, []string{"login"}},
+		{"since cutoff", &domain.AuditLogFilter{Since: &cutoff}, []string{"logout"}},
+		{"second page", &domain.AuditLogFilter{EntityType: &userType, Limit: 1, Offset: 1}, []string{"login"}},
+	}
+	for _, f := range filters {
+		list, err := audit.List(ctx, f.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if got := actions(list); !slices.Equal(got, f.want) {
+			t.Errorf("%s = %v, want %v", f.name, got, f.want)
+		}
+	}
+
+	if count, err := audit.Count(ctx, &domain.AuditLogFilter{EntityType: &userType, Limit: 1}); err != nil || count != 3 {
+		t.Errorf("Count = %d, %v; want 3 regardless of limit", count, err)
+	}
+
+	list, err := audit.ListForEntity(ctx, string(domain.EntityUser), userID, 10, 1)
+	if err != nil || !slices.Equal(actions(list), []string{"login", "register"}) {
+		t.Errorf("ListForEntity = %v, %v; want login then register", actions(list), err)
+	}
+
+	deleted, err := audit.DeleteOlderThan(ctx, string(domain.EntityUser), cutoff)
+	if err != nil || deleted != 2 {
+		t.Errorf("DeleteOlderThan = %d, %v; want the 2 user logs before the cutoff", deleted, err)
+	}
+	if list, _ := audit.List(ctx, nil); !slices.Equal(actions(list), []string{"logout", "credit"}) {
+		t.Errorf("audit logs after delete = %v, want logout and credit", actions(list))
+	}
+
+	// Every entity type the application audits is accepted
+	for _, entityType := range []domain.EntityType{
+		domain.EntityUser, domain.EntityTransaction, domain.EntityBalance, domain.EntityHTTPRequest, domain.EntityTreasury,
+		domain.EntityCurrency, domain.EntityImport, domain.EntityApproval, domain.EntityPolicy,
+	} {
+		if err := audit.Log(ctx, string(entityType), uuid.New(), "check", nil); err != nil {
+			t.Errorf("log %s entity: %v", entityType, err)
+		}
+	}
+}
+
+// actions returns the actions of logs, for readable failures.
+func actions(logs []*domain.AuditLog) []string {
+	out := make([]string, len(logs))
+	for i, log := range logs {
+		out[i] = log.Action
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testBalanceAlerts(t *testing.T, target Target) {
+	ctx := context.Background()
+	alerts := target.Repos.BalanceAlerts
+
+	alice := createUser(t, target.Repos, "alice").ID
+
+	created := time.Now().Add(-time.Hour)
+	for _, currency := range []string{"USD", "EUR"} {
+		alert := &domain.BalanceAlert{UserID: alice, Currency: currency, Threshold: 100, CreatedAt: created, UpdatedAt: created}
+		if err := alerts.Upsert(ctx, alert); err != nil {
+			t.Fatalf("upsert %s: %v", currency, err)
+		}
+	}
+
+	// Ordered by currency
+	list, err := alerts.ListByUser(ctx, alice)
+	if err != nil || len(list) != 2 || list[0].Currency != "EUR" || list[1].Currency != "USD" {
+		t.Errorf("ListByUser = %d alerts, %v; want EUR then USD", len(list), err)
+	}
+	_, err = alerts.Get(ctx, alice, "GBP")
+	expectError(t, "Get of unknown alert", err, "balance alert not found")
+
+	// Only a real transition changes the state
+	at := time.Now()
+	if changed, err := alerts.SetTriggered(ctx, alice, "USD", true, at); err != nil || !changed {
+		t.Errorf("trigger = %v, %v; want a change", changed, err)
+	}
+	if changed, _ := alerts.SetTriggered(ctx, alice, "USD", true, at); changed {
+		t.Error("triggering a triggered alert changed it")
+	}
+	if changed, _ := alerts.SetTriggered(ctx, alice, "GBP", true, at); changed {
+		t.Error("triggering an unknown alert changed it")
+	}
+	got, err := alerts.Get(ctx, alice, "USD")
+	if err != nil || !got.Triggered || got.LastTriggeredAt == nil {
+		t.Fatalf("Get = %+v, %v; want a triggered alert", got, err)
+	}
+	expectTime(t, "last_triggered_at", *got.LastTriggeredAt, at)
+
+	// Replacing the threshold re-arms the alert and keeps its creation time
+	updated := &domain.BalanceAlert{UserID: alice, Currency: "USD", Threshold: 50, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := alerts.Upsert(ctx, updated); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if updated.Triggered || updated.LastTriggeredAt == nil {
+		t.Errorf("replaced alert = %+v, want re-armed with its last trigger time", updated)
+	}
+	expectTime(t, "created_at", updated.CreatedAt, created)
+	if got, _ := alerts.Get(ctx, alice, "USD"); got == nil || got.Threshold != 50 || got.Triggered {
+		t.Errorf("after replace = %+v, want an armed threshold of 50", got)
+	}
+
+	if err := alerts.Delete(ctx, alice, "EUR"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expectError(t, "Delete twice", alerts.Delete(ctx, alice, "EUR"), "balance alert not found")
+	if list, _ := alerts.ListByUser(ctx, alice); len(list) != 1 {
+		t.Errorf("alerts after delete = %d, want 1", len(list))
+	}
+}
@@ -0,0 +1,219 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+func testBalances(t *testing.T, target Target) {
+	ctx := context.Background()
+	balances := target.Repos.Balances
+
+	alice := createUser(t, target.Repos, "alice")
+	bob := createUser(t, target.Repos, "bob")
+
+	_, err := balances.GetByUserID(ctx, uuid.New())
+	expectError(t, "GetByUserID of unknown user", err, "balance not found for user")
+
+	if err := balances.Upsert(ctx, &domain.Balance{UserID: alice.ID, Amount: 100, Currency: "USD"}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if got, err := balances.GetByUserID(ctx, alice.ID); err != nil || got.Amount != 100 || got.Currency != "USD" {
+		t.Errorf("balance after upsert = %+v, %v; want 100 USD", got, err)
+	}
+	if err := balances.Upsert(ctx, &domain.Balance{UserID: alice.ID, Amount: -1, Currency: "USD"}); err == nil {
+		t.Error("negative balance was accepted")
+	}
+
+	// Committed changes apply to both balances
+	tx, err := target.DB.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := balances.AddAmountTx(ctx, tx, alice.ID, -30); err != nil {
+		t.Fatalf("debit alice: %v", err)
+	}
+	if err := balances.AddAmountTx(ctx, tx, bob.ID, 30); err != nil {
+		t.Fatalf("credit bob: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	expectAmount(t, target, alice.ID, 70)
+	expectAmount(t, target, bob.ID, 30)
+
+	// Rolled back changes do not apply
+	tx, err = target.DB.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := balances.AddAmountTx(ctx, tx, bob.ID, 10); err != nil {
+		t.Fatalf("credit bob: %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	expectAmount(t, target, bob.ID, 30)
+
+	// Overdrawing fails
+	tx, err = target.DB.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := balances.AddAmountTx(ctx, tx, bob.ID, -31); err == nil {
+		t.Error("overdrawing AddAmountTx succeeded")
+	}
+	_ = tx.Rollback(ctx)
+	expectAmount(t, target, bob.ID, 30)
+
+	if err := balances.AddAmountTx(ctx, "not a transaction", bob.ID, 1); err == nil {
+		t.Error("AddAmountTx accepted a value that is not a transaction")
+	}
+
+	testUnitOfWork(t, target, alice.ID, bob.ID)
+	testBalanceHistory(t, target, alice.ID, bob.ID)
+}
+
+// testUnitOfWork checks balance changes made through a unit of work, starting from 70 held by
+// alice and 30 by bob.
+func testUnitOfWork(t *testing.T, target Target, alice, bob uuid.UUID) {
+	ctx := context.Background()
+
+	// Changes made within the transaction apply when it commits
+	err := repository.RunInTx(ctx, target.UnitOfWork, func(repos *repository.Repositories) error {
+		if err := repos.Balances.AddAmount(ctx, alice, -20); err != nil {
+			return err
+		}
+		return repos.Balances.AddAmount(ctx, bob, 20)
+	})
+	if err != nil {
+		t.Fatalf("transfer in unit of work: %v", err)
+	}
+	expectAmount(t, target, alice, 50)
+	expectAmount(t, target, bob, 50)
+
+	// A failing step rolls back the earlier ones
+	err = repository.RunInTx(ctx, target.UnitOfWork, func(repos *repository.Repositories) error {
+		if err := repos.Balances.AddAmount(ctx, bob, 10); err != nil {
+			return err
+		}
+		return repos.Balances.AddAmount(ctx, alice, -51)
+	})
+	if err == nil {
+		t.Error("overdrawing in a unit of work succeeded")
+	}
+	expectAmount(t, target, alice, 50)
+	expectAmount(t, target, bob, 50)
+
+	// Rolling back after commit does nothing
+	tx, err := target.UnitOfWork.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := tx.Repos().Balances.AddAmount(ctx, alice, 20); err != nil {
+		t.Fatalf("credit alice: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Errorf("rollback after commit: %v", err)
+	}
+	expectAmount(t, target, alice, 70)
+
+	// Outside a unit of work, AddAmount applies immediately
+	if err := target.Repos.Balances.AddAmount(ctx, bob, -20); err != nil {
+		t.Fatalf("debit bob: %v", err)
+	}
+	expectAmount(t, target, bob, 30)
+	if err := target.Repos.Balances.AddAmount(ctx, bob, -31); err == nil {
+		t.Error("overdrawing AddAmount succeeded")
+	}
+	expectAmount(t, target, bob, 30)
+
+	// A decorated unit of work decorates the repositories bound to its transactions
+	type markedAudit struct{ repository.AuditRepo }
+	decorated := repository.DecorateUnitOfWork(target.UnitOfWork, func(repos *repository.Repositories) {
+		repos.Audit = markedAudit{repos.Audit}
+	})
+	err = repository.RunInTx(ctx, decorated, func(repos *repository.Repositories) error {
+		if _, marked := repos.Audit.(markedAudit); !marked {
+			t.Error("repositories of a decorated unit of work are not decorated")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("decorated unit of work: %v", err)
+	}
+}
+
+// testBalanceHistory checks the balances derived from the transaction history.
+func testBalanceHistory(t *testing.T, target Target, alice, bob uuid.UUID) {
+	ctx := context.Background()
+	balances := target.Repos.Balances
+
+	start := time.Now()
+	createTransaction(t, target.Repos, domain.TypeCredit, nil, &alice, 100, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeTransfer, &alice, &bob, 30, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeCredit, nil, &alice, 5, domain.StatusFailed)
+
+	history, err := balances.GetHistorical(ctx, alice, 10)
+	if err != nil {
+		t.Fatalf("alice's history: %v", err)
+	}
+	if len(history) != 2 || history[0].Amount != 70 || history[0].Reason != "transfer_sent" || history[1].Amount != 100 || history[1].Reason != "credit" {
+		t.Errorf("alice's history = %+v, want 70 transfer_sent then 100 credit", history)
+	}
+
+	history, err = balances.GetHistorical(ctx, bob, 10)
+	if err != nil {
+		t.Fatalf("bob's history: %v", err)
+	}
+	if len(history) != 1 || history[0].Amount != 30 || history[0].Reason != "transfer_received" || history[0].UserID != bob {
+		t.Errorf("bob's history = %+v, want 30 transfer_received", history)
+	}
+
+	if history, _ := balances.GetHistorical(ctx, alice, 1); len(history) != 1 || history[0].Amount != 70 {
+		t.Errorf("limited history = %+v, want the newest snapshot only", history)
+	}
+
+	at, err := balances.GetAtTime(ctx, alice, time.Now().Add(time.Minute).Format(time.RFC3339))
+	if err != nil || at.Amount != 70 || at.Currency != "USD" || at.UserID != alice {
+		t.Errorf("balance now = %+v, %v; want 70 USD", at, err)
+	}
+	if at, err := balances.GetAtTime(ctx, alice, start.Add(-time.Hour).Format(time.RFC3339)); err != nil || at.Amount != 0 {
+		t.Errorf("balance an hour ago = %+v, %v; want 0", at, err)
+	}
+	if _, err := balances.GetAtTime(ctx, alice, "yesterday"); err == nil {
+		t.Error("GetAtTime accepted an invalid timestamp")
+	}
+
+	later := time.Now().Add(time.Minute)
+	timeline, err := balances.GetTimeline(ctx, alice, []time.Time{start.Add(-time.Hour), later})
+	if err != nil {
+		t.Fatalf("alice's timeline: %v", err)
+	}
+	if len(timeline) != 2 || timeline[0].Amount != 0 || timeline[1].Amount != 70 || !timeline[1].Timestamp.Equal(later) {
+		t.Errorf("alice's timeline = %+v, want 0 then 70", timeline)
+	}
+	if timeline, err := balances.GetTimeline(ctx, bob, []time.Time{later, start.Add(-time.Hour)}); err != nil || len(timeline) != 2 || timeline[0].Amount != 30 || timeline[1].Amount != 0 {
+		t.Errorf("bob's timeline out of order = %+v, %v; want 30 then 0 in the order asked", timeline, err)
+	}
+}
+
+// expectAmount fails unless the user's stored balance is want.
+func expectAmount(t *testing.T, target Target, userID uuid.UUID, want float64) {
+	t.Helper()
+	balance, err := target.Repos.Balances.GetByUserID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("get balance: %v", err)
+	}
+	if balance.Amount != want {
+		t.Errorf("balance = %.2f, want %.2f", balance.Amount, want)
+	}
+}
@@ -0,0 +1,72 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testBudgets(t *testing.T, target Target) {
+	ctx := context.Background()
+	budgets := target.Repos.Budgets
+
+	alice := createUser(t, target.Repos, "alice").ID
+
+	created := time.Now().Add(-time.Hour)
+	for _, category := range []string{"groceries", "dining"} {
+		budget := &domain.Budget{UserID: alice, Category: category, Limit: 300, CreatedAt: created, UpdatedAt: created}
+		if err := budgets.Upsert(ctx, budget); err != nil {
+			t.Fatalf("upsert %s: %v", category, err)
+		}
+	}
+
+	// Ordered by category
+	list, err := budgets.ListByUser(ctx, alice)
+	if err != nil || len(list) != 2 || list[0].Category != "dining" || list[1].Category != "groceries" {
+		t.Errorf("ListByUser = %d budgets, %v; want dining then groceries", len(list), err)
+	}
+	_, err = budgets.Get(ctx, alice, "travel")
+	expectError(t, "Get of unknown budget", err, "budget not found")
+
+	// The user is told once a month
+	if marked, err := budgets.MarkAlerted(ctx, alice, "groceries", "2024-05"); err != nil || !marked {
+		t.Errorf("mark = %v, %v; want marked", marked, err)
+	}
+	if marked, _ := budgets.MarkAlerted(ctx, alice, "groceries", "2024-05"); marked {
+		t.Error("marking a budget twice in a month marked it again")
+	}
+	if marked, _ := budgets.MarkAlerted(ctx, alice, "groceries", "2024-06"); !marked {
+		t.Error("marking a budget in the next month did not mark it")
+	}
+	if marked, _ := budgets.MarkAlerted(ctx, alice, "travel", "2024-06"); marked {
+		t.Error("marking an unknown budget marked it")
+	}
+	got, err := budgets.Get(ctx, alice, "groceries")
+	if err != nil || got.AlertedMonth != "2024-06" || got.Limit != 300 {
+		t.Fatalf("Get = %+v, %v; want a 300 budget alerted in 2024-06", got, err)
+	}
+	expectTime(t, "created_at", got.CreatedAt, created)
+
+	// Replacing the limit re-arms the notification and keeps the creation time
+	updated := &domain.Budget{UserID: alice, Category: "groceries", Limit: 450.5, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := budgets.Upsert(ctx, updated); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if updated.AlertedMonth != "" {
+		t.Errorf("replaced budget = %+v, want re-armed", updated)
+	}
+	expectTime(t, "created_at", updated.CreatedAt, created)
+	if got, _ := budgets.Get(ctx, alice, "groceries"); got == nil || got.Limit != 450.5 || got.AlertedMonth != "" {
+		t.Errorf("after replace = %+v, want an armed limit of 450.5", got)
+	}
+
+	if err := budgets.Delete(ctx, alice, "dining"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expectError(t, "Delete twice", budgets.Delete(ctx, alice, "dining"), "budget not found")
+	if list, _ := budgets.ListByUser(ctx, alice); len(list) != 1 {
+		t.Errorf("budgets after delete = %d, want 1", len(list))
+	}
+}
@@ -0,0 +1,78 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testBulkCreate(t *testing.T, target Target) {
+	ctx := context.Background()
+	repos := target.Repos
+
+	alice := createUser(t, repos, "alice")
+
+	users := []*domain.User{
+		{Username: "bulk_one", Email: "bulk_one@example.com", PasswordHash: "!", Role: string(domain.RoleUser)},
+		{Username: "bulk_two", Email: "bulk_two@example.com", PasswordHash: "!", Role: string(domain.RoleAdmin)},
+	}
+	if err := repos.Users.CreateMany(ctx, users); err != nil {
+		t.Fatalf("CreateMany users: %v", err)
+	}
+	for _, user := range users {
+		if user.ID == uuid.Nil || !user.IsActive || user.CreatedAt.IsZero() || user.AccountNumber != domain.AccountNumberFor(user.ID) {
+			t.Errorf("bulk created user = %+v, want an active user with an ID, timestamps and account number", user)
+		}
+		if got, err := repos.Users.GetByUsername(ctx, user.Username); err != nil || got.ID != user.ID || got.Role != user.Role {
+			t.Errorf("GetByUsername(%s) = %+v, %v", user.Username, got, err)
+		}
+	}
+
+	// A batch with one conflicting user is rejected whole
+	conflicting := []*domain.User{
+		{Username: "bulk_three", Email: "bulk_three@example.com", PasswordHash: "!", Role: string(domain.RoleUser)},
+		{Username: "alice", Email: "alice2@example.com", PasswordHash: "!", Role: string(domain.RoleUser)},
+	}
+	if err := repos.Users.CreateMany(ctx, conflicting); err == nil {
+		t.Error("bulk create with a duplicate username was accepted")
+	}
+	if _, err := repos.Users.GetByUsername(ctx, "bulk_three"); err == nil {
+		t.Error("bulk create stored users of a rejected batch")
+	}
+	if count, err := repos.Users.Count(ctx); err != nil || count != 3 {
+		t.Errorf("Count = %d, %v; want 3", count, err)
+	}
+
+	balances := []*domain.Balance{
+		{UserID: users[0].ID, Amount: 125.5, Currency: "USD"},
+		{UserID: users[1].ID, Currency: "EUR"},
+	}
+	if err := repos.Balances.CreateMany(ctx, balances); err != nil {
+		t.Fatalf("CreateMany balances: %v", err)
+	}
+	if got, err := repos.Balances.GetByUserID(ctx, users[0].ID); err != nil || got.Amount != 125.5 || got.Currency != "USD" {
+		t.Errorf("bulk created balance = %+v, %v; want 125.5 USD", got, err)
+	}
+	if got, err := repos.Balances.GetByUserID(ctx, users[1].ID); err != nil || got.Amount != 0 || got.Currency != "EUR" {
+		t.Errorf("bulk created balance = %+v, %v; want 0 EUR", got, err)
+	}
+	if err := repos.Balances.CreateMany(ctx, []*domain.Balance{{UserID: alice.ID, Currency: "USD"}}); err == nil {
+		t.Error("bulk create of an existing balance was accepted")
+	}
+
+	credits := []*domain.Transaction{
+		{ToUserID: &users[0].ID, Amount: 125.5, Currency: "USD", Type: string(domain.TypeCredit), Description: "Opening balance"},
+	}
+	if err := repos.Transactions.CreateManyCompleted(ctx, credits); err != nil {
+		t.Fatalf("CreateManyCompleted: %v", err)
+	}
+	got, err := repos.Transactions.GetByID(ctx, credits[0].ID)
+	if err != nil || got.Status != string(domain.StatusSuccess) || got.Amount != 125.5 || got.Description != "Opening balance" || got.ToUserID == nil || *got.ToUserID != users[0].ID {
+		t.Errorf("bulk created transaction = %+v, %v; want a successful 125.5 credit to %s", got, err, users[0].ID)
+	}
+	if err := repos.Transactions.CreateManyCompleted(ctx, credits); err == nil {
+		t.Error("bulk create of an existing transaction was accepted")
+	}
+}
@@ -0,0 +1,100 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testContacts(t *testing.T, target Target) {
+	ctx := context.Background()
+	contacts := target.Repos.Contacts
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+	carol := createUser(t, target.Repos, "carol").ID
+
+	mom := newContact(alice, bob, "Mom")
+	pause()
+	landlord := newContact(alice, carol, "")
+	for _, contact := range []*domain.Contact{mom, landlord} {
+		if err := contacts.Create(ctx, contact); err != nil {
+			t.Fatalf("create contact: %v", err)
+		}
+	}
+	expectError(t, "Create of a saved counterparty", contacts.Create(ctx, newContact(alice, bob, "again")), "contact already exists")
+	if err := contacts.Create(ctx, newContact(bob, alice, "")); err != nil {
+		t.Errorf("the reverse contact: %v", err)
+	}
+
+	got, err := contacts.GetByID(ctx, mom.ID)
+	if err != nil || got.UserID != alice || got.ContactUserID != bob || got.Nickname != "Mom" {
+		t.Errorf("GetByID = %+v, %v; want alice's contact Mom", got, err)
+	}
+	_, err = contacts.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown contact", err, "contact not found")
+
+	// Oldest first
+	list, err := contacts.ListByUser(ctx, alice)
+	if err != nil || len(list) != 2 || list[0].ID != mom.ID || list[1].ID != landlord.ID {
+		t.Errorf("ListByUser = %d contacts, %v; want Mom then the landlord", len(list), err)
+	}
+
+	if err := contacts.Delete(ctx, mom.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expectError(t, "Delete of deleted contact", contacts.Delete(ctx, mom.ID), "contact not found")
+	if list, _ := contacts.ListByUser(ctx, alice); len(list) != 1 || list[0].ID != landlord.ID {
+		t.Errorf("after delete = %d contacts, want the landlord", len(list))
+	}
+
+	testRecentCounterparties(t, target, alice, bob, carol)
+}
+
+// testRecentCounterparties checks that only successful outgoing transfers count, grouped by recipient.
+func testRecentCounterparties(t *testing.T, target Target, alice, bob, carol uuid.UUID) {
+	ctx := context.Background()
+
+	createTransaction(t, target.Repos, domain.TypeTransfer, &alice, &bob, 10, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeTransfer, &alice, &carol, 20, domain.StatusSuccess)
+	last := createTransaction(t, target.Repos, domain.TypeTransfer, &alice, &bob, 30, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeTransfer, &alice, &carol, 40, domain.StatusFailed)
+	createTransaction(t, target.Repos, domain.TypeTransfer, &carol, &alice, 50, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeDebit, &alice, nil, 60, domain.StatusSuccess)
+
+	counterparties, err := target.Repos.Transactions.ListRecentCounterparties(ctx, alice, 10)
+	if err != nil || len(counterparties) != 2 {
+		t.Fatalf("ListRecentCounterparties = %d counterparties, %v; want bob and carol", len(counterparties), err)
+	}
+	if got := counterparties[0]; got.UserID != bob || got.TransferCount != 2 {
+		t.Errorf("most recent = %+v, want bob with 2 transfers", got)
+	}
+	stored, _ := target.Repos.Transactions.GetByID(ctx, last.ID)
+	if stored != nil {
+		expectTime(t, "last_transfer_at", counterparties[0].LastTransferAt, stored.CreatedAt)
+	}
+	if got := counterparties[1]; got.UserID != carol || got.TransferCount != 1 {
+		t.Errorf("second = %+v, want carol with 1 transfer", got)
+	}
+
+	if counterparties, _ := target.Repos.Transactions.ListRecentCounterparties(ctx, alice, 1); len(counterparties) != 1 || counterparties[0].UserID != bob {
+		t.Errorf("limited to 1 = %d counterparties, want bob", len(counterparties))
+	}
+	if counterparties, err := target.Repos.Transactions.ListRecentCounterparties(ctx, bob, 10); err != nil || len(counterparties) != 0 {
+		t.Errorf("bob's counterparties = %d, %v; want none", len(counterparties), err)
+	}
+}
+
+// newContact returns an unsaved contact of a user.
+func newContact(userID, contactUserID uuid.UUID, nickname string) *domain.Contact {
+	return &domain.Contact{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ContactUserID: contactUserID,
+		Nickname:      nickname,
+		CreatedAt:     time.Now(),
+	}
+}
@@ -0,0 +1,69 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testCurrencies(t *testing.T, target Target) {
+	ctx := context.Background()
+	currencies := target.Repos.Currencies
+
+	listed, err := currencies.List(ctx)
+	if err != nil || len(listed) != len(domain.DefaultCurrencies()) || listed[0].Code != "AUD" {
+		t.Fatalf("List = %+v, %v; want the default currencies by code", listed, err)
+	}
+
+	jpy, err := currencies.Get(ctx, "JPY")
+	if err != nil || jpy.Symbol != "¥" || jpy.DecimalPlaces != 0 || !jpy.Enabled {
+		t.Errorf("JPY = %+v, %v; want an enabled currency without decimal places", jpy, err)
+	}
+	_, err = currencies.Get(ctx, "CHF")
+	expectError(t, "Get of unknown currency", err, "currency not found")
+
+	now := time.Now()
+	chf := &domain.CurrencyInfo{Code: "CHF", Symbol: "Fr.", DecimalPlaces: 2, Enabled: true, CreatedAt: now, UpdatedAt: now}
+	if err := currencies.Create(ctx, chf); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	err = currencies.Create(ctx, &domain.CurrencyInfo{Code: "CHF", Symbol: "CHF", DecimalPlaces: 2, CreatedAt: now, UpdatedAt: now})
+	expectError(t, "Create of existing currency", err, "currency already exists")
+
+	if account, err := target.Repos.Treasury.GetAccount(ctx, "CHF"); err != nil || account.Balance != 0 {
+		t.Errorf("CHF treasury = %+v, %v; want an empty account", account, err)
+	}
+
+	// Balances may be held in every currency of the registry, disabled ones included
+	alice := createUser(t, target.Repos, "alice")
+	if err := target.Repos.Balances.Upsert(ctx, &domain.Balance{UserID: alice.ID, Amount: 5, Currency: "CHF"}); err != nil {
+		t.Errorf("balance in a created currency: %v", err)
+	}
+	if err := target.Repos.Balances.Upsert(ctx, &domain.Balance{UserID: alice.ID, Currency: "XYZ"}); err == nil {
+		t.Error("balance in an unknown currency was accepted")
+	}
+
+	pause()
+	chf.Symbol = "CHF"
+	chf.Enabled = false
+	chf.UpdatedAt = time.Now()
+	if err := currencies.Update(ctx, chf); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	expectTime(t, "created_at after update", chf.CreatedAt, now)
+
+	stored, err := currencies.Get(ctx, "CHF")
+	if err != nil || stored.Symbol != "CHF" || stored.Enabled || stored.DecimalPlaces != 2 {
+		t.Errorf("updated CHF = %+v, %v; want a disabled currency with the new symbol", stored, err)
+	}
+	expectTime(t, "updated_at", stored.UpdatedAt, chf.UpdatedAt)
+
+	err = currencies.Update(ctx, &domain.CurrencyInfo{Code: "XYZ", Symbol: "X", UpdatedAt: now})
+	expectError(t, "Update of unknown currency", err, "currency not found")
+
+	if listed, _ := currencies.List(ctx); len(listed) != len(domain.DefaultCurrencies())+1 {
+		t.Errorf("List = %d currencies, want the defaults and CHF", len(listed))
+	}
+}
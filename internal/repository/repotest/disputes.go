@@ -0,0 +1,109 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testDisputes(t *testing.T, target Target) {
+	ctx := context.Background()
+	disputes := target.Repos.Disputes
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+	admin := createUser(t, target.Repos, "admin").ID
+	debit := createTransaction(t, target.Repos, domain.TypeDebit, &alice, nil, 10, domain.StatusSuccess)
+	transfer := createTransaction(t, target.Repos, domain.TypeTransfer, &bob, &alice, 5, domain.StatusSuccess)
+
+	first := newDispute(debit.ID, alice)
+	if err := disputes.Create(ctx, first); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := disputes.Create(ctx, newDispute(debit.ID, alice)); err == nil {
+		t.Error("second unresolved dispute of a transaction succeeded")
+	}
+	pause()
+	second := newDispute(transfer.ID, bob)
+	if err := disputes.Create(ctx, second); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	got, err := disputes.GetByID(ctx, first.ID)
+	if err != nil || got.TransactionID != debit.ID || got.UserID != alice || got.Reason != "not mine" || got.Status != string(domain.DisputeStatusOpen) {
+		t.Errorf("GetByID = %+v, %v; want alice's open dispute", got, err)
+	}
+	_, err = disputes.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown dispute", err, "dispute not found")
+	if open, err := disputes.GetOpenByTransactionID(ctx, debit.ID); err != nil || open.ID != first.ID {
+		t.Errorf("GetOpenByTransactionID = %+v, %v; want the first dispute", open, err)
+	}
+
+	// Newest first
+	list, err := disputes.List(ctx, &domain.DisputeFilter{})
+	if err != nil || len(list) != 2 || list[0].ID != second.ID || list[1].ID != first.ID {
+		t.Errorf("List = %d disputes, %v; want the second then the first", len(list), err)
+	}
+	if list, _ := disputes.List(ctx, &domain.DisputeFilter{UserID: &alice}); len(list) != 1 || list[0].ID != first.ID {
+		t.Errorf("alice's disputes = %d, want the first", len(list))
+	}
+	if list, _ := disputes.List(ctx, &domain.DisputeFilter{Limit: 1, Offset: 1}); len(list) != 1 || list[0].ID != first.ID {
+		t.Errorf("second page = %d disputes, want the first", len(list))
+	}
+
+	// Resolving frees the transaction for another dispute
+	resolvedAt := time.Now()
+	first.Status = string(domain.DisputeStatusRefunded)
+	first.Resolution = "refunded in full"
+	first.ResolvedBy = &admin
+	first.RefundTransactionID = &transfer.ID
+	first.UpdatedAt = resolvedAt
+	first.ResolvedAt = &resolvedAt
+	if err := disputes.Update(ctx, first); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got, _ = disputes.GetByID(ctx, first.ID)
+	if got == nil || got.Status != first.Status || got.Resolution != first.Resolution || got.ResolvedBy == nil || *got.ResolvedBy != admin ||
+		got.RefundTransactionID == nil || *got.RefundTransactionID != transfer.ID || got.ResolvedAt == nil {
+		t.Errorf("after update = %+v, want the refunded dispute", got)
+	}
+	_, err = disputes.GetOpenByTransactionID(ctx, debit.ID)
+	expectError(t, "GetOpenByTransactionID after resolution", err, "dispute not found")
+	if list, _ := disputes.List(ctx, &domain.DisputeFilter{Status: ptr(string(domain.DisputeStatusOpen))}); len(list) != 1 || list[0].ID != second.ID {
+		t.Errorf("open disputes = %d, want the second", len(list))
+	}
+	expectError(t, "Update of unknown dispute", disputes.Update(ctx, newDispute(debit.ID, alice)), "dispute not found")
+
+	// Comments in chronological order
+	for _, body := range []string{"please check", "looking into it"} {
+		comment := &domain.DisputeComment{ID: uuid.New(), DisputeID: second.ID, AuthorID: bob, Body: body, CreatedAt: time.Now()}
+		if err := disputes.AddComment(ctx, comment); err != nil {
+			t.Fatalf("add comment: %v", err)
+		}
+		pause()
+	}
+	comments, err := disputes.ListComments(ctx, second.ID)
+	if err != nil || len(comments) != 2 || comments[0].Body != "please check" || comments[1].Body != "looking into it" {
+		t.Errorf("ListComments = %d comments, %v; want both in order", len(comments), err)
+	}
+	if comments, _ := disputes.ListComments(ctx, first.ID); len(comments) != 0 {
+		t.Errorf("comments on the first dispute = %d, want 0", len(comments))
+	}
+}
+
+// newDispute returns an open dispute of a transaction by user.
+func newDispute(transactionID, userID uuid.UUID) *domain.Dispute {
+	now := time.Now()
+	return &domain.Dispute{
+		ID:            uuid.New(),
+		TransactionID: transactionID,
+		UserID:        userID,
+		Reason:        "not mine",
+		Status:        string(domain.DisputeStatusOpen),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
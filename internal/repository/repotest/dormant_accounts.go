@@ -0,0 +1,67 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testDormantAccounts(t *testing.T, target Target) {
+	ctx := context.Background()
+	dormant := target.Repos.DormantAccounts
+
+	alice := createUser(t, target.Repos, "alice")
+	pause()
+	bob := createUser(t, target.Repos, "bob")
+	pause()
+	carol := createUser(t, target.Repos, "carol")
+	pause()
+	quiet := time.Now()
+	pause()
+	credit := createTransaction(t, target.Repos, domain.TypeCredit, nil, &alice.ID, 25, domain.StatusSuccess)
+
+	// Users without transactions are inactive since they were created
+	accounts, err := dormant.List(ctx, time.Now().Add(time.Minute), 10, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(accounts) != 3 || accounts[0].UserID != bob.ID || accounts[1].UserID != carol.ID || accounts[2].UserID != alice.ID {
+		t.Fatalf("dormant accounts = %d, want bob, carol, then alice", len(accounts))
+	}
+	if accounts[0].LastActivityAt != nil || accounts[0].Currency != "USD" || accounts[0].Username != "bob" {
+		t.Errorf("bob = %+v, want a USD account without activity", accounts[0])
+	}
+	expectTime(t, "bob's inactive_since", accounts[0].InactiveSince, accounts[0].CreatedAt)
+	if accounts[2].LastActivityAt == nil {
+		t.Fatalf("alice = %+v, want her last activity", accounts[2])
+	}
+	expectTime(t, "alice's last_activity_at", *accounts[2].LastActivityAt, credit.CreatedAt)
+
+	// Alice transacted after the cutoff
+	if accounts, _ := dormant.List(ctx, quiet, 10, 0); len(accounts) != 2 || accounts[0].UserID != bob.ID {
+		t.Errorf("dormant before alice's credit = %d accounts, want bob and carol", len(accounts))
+	}
+	if count, err := dormant.Count(ctx, quiet); err != nil || count != 2 {
+		t.Errorf("Count = %d, %v; want 2", count, err)
+	}
+	if accounts, _ := dormant.List(ctx, quiet, 1, 1); len(accounts) != 1 || accounts[0].UserID != carol.ID {
+		t.Errorf("second page = %d accounts, want carol", len(accounts))
+	}
+	if count, _ := dormant.Count(ctx, alice.CreatedAt.Add(-time.Minute)); count != 0 {
+		t.Errorf("dormant before anyone existed = %d, want 0", count)
+	}
+
+	// Each period of inactivity is flagged once
+	since := accounts[0].InactiveSince
+	if flagged, err := dormant.MarkFlagged(ctx, bob.ID, since, time.Now()); err != nil || !flagged {
+		t.Errorf("flag = %v, %v; want flagged", flagged, err)
+	}
+	if flagged, _ := dormant.MarkFlagged(ctx, bob.ID, since, time.Now()); flagged {
+		t.Error("flagging the same inactivity twice flagged it again")
+	}
+	if flagged, _ := dormant.MarkFlagged(ctx, bob.ID, since.Add(time.Hour), time.Now()); !flagged {
+		t.Error("flagging a later inactivity did not flag it")
+	}
+}
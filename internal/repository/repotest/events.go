@@ -0,0 +1,133 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testEvents(t *testing.T, target Target) {
+	ctx := context.Background()
+	events := target.Repos.Events
+
+	alice := uuid.New()
+	bob := uuid.New()
+	start := time.Now().Add(-time.Second)
+
+	first := appendEvent(t, target, domain.AggregateUser, alice, domain.EventUserRegistered)
+	credited := appendEvent(t, target, domain.AggregateBalance, alice, domain.EventAmountCredited)
+	second := appendEvent(t, target, domain.AggregateUser, alice, domain.EventUserUpdated)
+
+	if first.Version != 1 || second.Version != 2 || credited.Version != 1 {
+		t.Errorf("versions = %d, %d and %d, want 1 and 2 for the user and 1 for the balance", first.Version, second.Version, credited.Version)
+	}
+
+	history, err := events.GetEventsByAggregate(ctx, domain.AggregateUser, alice)
+	if err != nil {
+		t.Fatalf("GetEventsByAggregate: %v", err)
+	}
+	if len(history) != 2 || history[0].ID != first.ID || history[1].ID != second.ID || string(history[1].EventData) == "" {
+		t.Errorf("user history = %+v, want the registration then the update", history)
+	}
+	if history[0].EventVersion != 2 {
+		t.Errorf("event version = %d, want the stored 2", history[0].EventVersion)
+	}
+	if history, _ := events.GetEventsByAggregate(ctx, domain.AggregateUser, bob); len(history) != 0 {
+		t.Errorf("history of unknown aggregate = %+v, want none", history)
+	}
+
+	if version, err := events.GetAggregateVersion(ctx, domain.AggregateUser, alice); err != nil || version != 2 {
+		t.Errorf("GetAggregateVersion = %d, %v; want 2", version, err)
+	}
+	if version, err := events.GetAggregateVersion(ctx, domain.AggregateUser, bob); err != nil || version != 0 {
+		t.Errorf("GetAggregateVersion of unknown aggregate = %d, %v; want 0", version, err)
+	}
+
+	batch := []*domain.Event{
+		newEvent(domain.AggregateUser, bob, domain.EventUserRegistered),
+		newEvent(domain.AggregateUser, bob, domain.EventUserUpdated),
+		newEvent(domain.AggregateUser, alice, domain.EventUserUpdated),
+	}
+	if err := events.AppendEvents(ctx, batch); err != nil {
+		t.Fatalf("AppendEvents: %v", err)
+	}
+	if batch[0].Version != 1 || batch[1].Version != 2 || batch[2].Version != 3 {
+		t.Errorf("batch versions = %d, %d and %d, want 1, 2 and 3", batch[0].Version, batch[1].Version, batch[2].Version)
+	}
+	if err := events.AppendEvents(ctx, nil); err != nil {
+		t.Errorf("AppendEvents of no events: %v", err)
+	}
+
+	updates, err := events.GetEventsByType(ctx, domain.EventUserUpdated, 10, 0)
+	if err != nil {
+		t.Fatalf("GetEventsByType: %v", err)
+	}
+	if len(updates) != 3 {
+		t.Errorf("updates = %d, want 3", len(updates))
+	}
+	if page, _ := events.GetEventsByType(ctx, domain.EventUserUpdated, 1, 2); len(page) != 1 || page[0].ID != second.ID {
+		t.Errorf("oldest update = %+v, want the first appended update", page)
+	}
+
+	since, err := events.GetEventsSince(ctx, start, 2)
+	if err != nil {
+		t.Fatalf("GetEventsSince: %v", err)
+	}
+	if len(since) != 2 || since[0].ID != first.ID || since[1].ID != credited.ID {
+		t.Errorf("oldest events since start = %+v, want the registration and the credit", since)
+	}
+	if since, _ := events.GetEventsSince(ctx, time.Now().Add(time.Minute), 10); len(since) != 0 {
+		t.Errorf("future events = %+v, want none", since)
+	}
+
+	carol := uuid.New()
+	restored := newEvent(domain.AggregateUser, carol, domain.EventUserRegistered)
+	restored.Version = 4
+	restored.CreatedAt = start.Add(-time.Hour).UTC().Truncate(time.Microsecond)
+	imported, err := events.ImportEvents(ctx, []*domain.Event{restored, first})
+	if err != nil {
+		t.Fatalf("ImportEvents: %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("imported = %d, want 1 since the first event is already stored", imported)
+	}
+	if again, err := events.ImportEvents(ctx, []*domain.Event{restored}); err != nil || again != 0 {
+		t.Errorf("ImportEvents of an imported event = %d, %v; want it skipped", again, err)
+	}
+	history, _ = events.GetEventsByAggregate(ctx, domain.AggregateUser, carol)
+	if len(history) != 1 || history[0].ID != restored.ID || history[0].Version != 4 || !history[0].CreatedAt.Equal(restored.CreatedAt) {
+		t.Errorf("imported history = %+v, want the event with its version and timestamp", history)
+	}
+	if since, _ := events.GetEventsSince(ctx, restored.CreatedAt.Add(-time.Second), 1); len(since) != 1 || since[0].ID != restored.ID {
+		t.Errorf("oldest event = %+v, want the imported event", since)
+	}
+}
+
+// appendEvent appends an event and returns it with its version and timestamp.
+func appendEvent(t *testing.T, target Target, aggregateType domain.AggregateType, aggregateID uuid.UUID, eventType domain.EventType) *domain.Event {
+	t.Helper()
+
+	event, err := target.Repos.Events.AppendEvent(context.Background(), newEvent(aggregateType, aggregateID, eventType))
+	if err != nil {
+		t.Fatalf("append %s: %v", eventType, err)
+	}
+	pause()
+
+	return event
+}
+
+// newEvent returns an event with an ID, minimal data and a payload version; event stores keep the
+// ID and payload version they are given.
+func newEvent(aggregateType domain.AggregateType, aggregateID uuid.UUID, eventType domain.EventType) *domain.Event {
+	return &domain.Event{
+		ID:            uuid.New(),
+		AggregateType: string(aggregateType),
+		AggregateID:   aggregateID,
+		EventType:     string(eventType),
+		EventData:     []byte(`{"source":"repotest"}`),
+		EventVersion:  2,
+	}
+}
@@ -0,0 +1,55 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testImpersonationSessions(t *testing.T, target Target) {
+	ctx := context.Background()
+	sessions := target.Repos.ImpersonationSessions
+
+	admin := createUser(t, target.Repos, "admin").ID
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	now := time.Now()
+	expired := newImpersonation(admin, alice, now.Add(-time.Hour), now.Add(-time.Minute))
+	older := newImpersonation(admin, alice, now.Add(-2*time.Minute), now.Add(time.Hour))
+	newer := newImpersonation(admin, bob, now.Add(-time.Minute), now.Add(time.Hour))
+	for _, session := range []*domain.ImpersonationSession{newer, expired, older} {
+		if err := sessions.Create(ctx, session); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	// Only unexpired sessions, newest first
+	active, err := sessions.ListActive(ctx, now)
+	if err != nil || len(active) != 2 || active[0].ID != newer.ID || active[1].ID != older.ID {
+		t.Fatalf("ListActive = %d sessions, %v; want the newer then the older one", len(active), err)
+	}
+	if active[0].AdminID != admin || active[0].TargetUserID != bob || active[0].Reason != "support ticket" {
+		t.Errorf("active session = %+v, want admin impersonating bob", active[0])
+	}
+	expectTime(t, "expires_at", active[0].ExpiresAt, newer.ExpiresAt)
+
+	if active, _ := sessions.ListActive(ctx, now.Add(2*time.Hour)); len(active) != 0 {
+		t.Errorf("active in two hours = %d sessions, want 0", len(active))
+	}
+}
+
+// newImpersonation returns a session of admin impersonating target.
+func newImpersonation(admin, target uuid.UUID, createdAt, expiresAt time.Time) *domain.ImpersonationSession {
+	return &domain.ImpersonationSession{
+		ID:           uuid.New(),
+		AdminID:      admin,
+		TargetUserID: target,
+		Reason:       "support ticket",
+		CreatedAt:    createdAt,
+		ExpiresAt:    expiresAt,
+	}
+}
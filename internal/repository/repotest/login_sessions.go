@@ -0,0 +1,114 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testKnownDevices(t *testing.T, target Target) {
+	ctx := context.Background()
+	devices := target.Repos.KnownDevices
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	if count, err := devices.Count(ctx, alice); err != nil || count != 0 {
+		t.Fatalf("Count before any login = %d, %v; want 0", count, err)
+	}
+
+	now := time.Now()
+	laptop := domain.LoginDevice{IP: "203.0.113.7", UserAgent: "laptop"}.Fingerprint()
+	phone := domain.LoginDevice{IP: "198.51.100.2", UserAgent: "phone"}.Fingerprint()
+
+	if isNew, err := devices.Touch(ctx, alice, laptop, now); err != nil || !isNew {
+		t.Fatalf("first Touch = %v, %v; want a new device", isNew, err)
+	}
+	if isNew, err := devices.Touch(ctx, alice, laptop, now.Add(time.Minute)); err != nil || isNew {
+		t.Errorf("second Touch = %v, %v; want a known device", isNew, err)
+	}
+	if isNew, err := devices.Touch(ctx, alice, phone, now); err != nil || !isNew {
+		t.Errorf("Touch of another device = %v, %v; want a new device", isNew, err)
+	}
+
+	// Devices are known per user
+	if isNew, err := devices.Touch(ctx, bob, laptop, now); err != nil || !isNew {
+		t.Errorf("Touch by another user = %v, %v; want a new device", isNew, err)
+	}
+
+	if count, err := devices.Count(ctx, alice); err != nil || count != 2 {
+		t.Errorf("Count = %d, %v; want 2", count, err)
+	}
+}
+
+func testLoginSessions(t *testing.T, target Target) {
+	ctx := context.Background()
+	sessions := target.Repos.LoginSessions
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	now := time.Now()
+	expired := newLoginSession(alice, now.Add(-8*24*time.Hour), now.Add(-time.Hour))
+	older := newLoginSession(alice, now.Add(-2*time.Minute), now.Add(time.Hour))
+	newer := newLoginSession(alice, now.Add(-time.Minute), now.Add(time.Hour))
+	newer.NewDevice = true
+	other := newLoginSession(bob, now.Add(-time.Minute), now.Add(time.Hour))
+	for _, session := range []*domain.LoginSession{newer, expired, other, older} {
+		if err := sessions.Create(ctx, session); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	got, err := sessions.GetByID(ctx, newer.ID)
+	if err != nil || got.UserID != alice || got.IP != "203.0.113.7" || got.UserAgent != "curl/8.0" || !got.NewDevice || got.RevokedAt != nil {
+		t.Fatalf("GetByID = %+v, %v; want alice's new device session", got, err)
+	}
+	expectTime(t, "expires_at", got.ExpiresAt, newer.ExpiresAt)
+	if _, err := sessions.GetByID(ctx, uuid.New()); err == nil || err.Error() != "login session not found" {
+		t.Errorf("GetByID of unknown session error = %v, want login session not found", err)
+	}
+
+	// Only the user's unexpired sessions, newest first
+	active, err := sessions.ListActive(ctx, alice, now)
+	if err != nil || len(active) != 2 || active[0].ID != newer.ID || active[1].ID != older.ID {
+		t.Fatalf("ListActive = %d sessions, %v; want the newer then the older one", len(active), err)
+	}
+
+	// Another user's session cannot be revoked
+	if err := sessions.Revoke(ctx, other.ID, alice, now); err == nil || err.Error() != "login session not found" {
+		t.Errorf("Revoke of bob's session by alice error = %v, want login session not found", err)
+	}
+
+	if err := sessions.Revoke(ctx, newer.ID, alice, now); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := sessions.Revoke(ctx, newer.ID, alice, now); err == nil || err.Error() != "login session already revoked" {
+		t.Errorf("second Revoke error = %v, want login session already revoked", err)
+	}
+
+	got, err = sessions.GetByID(ctx, newer.ID)
+	if err != nil || got.RevokedAt == nil {
+		t.Fatalf("revoked session = %+v, %v; want revoked_at set", got, err)
+	}
+	expectTime(t, "revoked_at", *got.RevokedAt, now)
+
+	if active, _ := sessions.ListActive(ctx, alice, now); len(active) != 1 || active[0].ID != older.ID {
+		t.Errorf("ListActive after revoking = %d sessions, want only the older one", len(active))
+	}
+}
+
+// newLoginSession returns a session of user.
+func newLoginSession(user uuid.UUID, createdAt, expiresAt time.Time) *domain.LoginSession {
+	return &domain.LoginSession{
+		ID:        uuid.New(),
+		UserID:    user,
+		IP:        "203.0.113.7",
+		UserAgent: "curl/8.0",
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}
+}
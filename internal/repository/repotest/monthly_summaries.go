@@ -0,0 +1,73 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testMonthlySummaries(t *testing.T, target Target) {
+	ctx := context.Background()
+	summaries := target.Repos.MonthlySummaries
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	if _, err := summaries.Get(ctx, alice, "2026-01"); err == nil || err.Error() != "monthly summary not found" {
+		t.Fatalf("Get before any summary = %v, want monthly summary not found", err)
+	}
+
+	summary := &domain.MonthlySummary{
+		ID:               uuid.New(),
+		UserID:           alice,
+		Month:            "2026-01",
+		Currency:         "USD",
+		OpeningBalance:   100,
+		ClosingBalance:   68.5,
+		TotalIn:          20,
+		TotalOut:         51.5,
+		FeesPaid:         1.5,
+		TransactionCount: 3,
+		TopCounterparties: []domain.SummaryCounterparty{
+			{UserID: bob, Sent: 50, Received: 20, TransferCount: 2},
+		},
+		GeneratedAt: time.Now().Truncate(time.Second),
+	}
+	created, err := summaries.Create(ctx, summary)
+	if err != nil || !created {
+		t.Fatalf("Create = %v, %v; want stored", created, err)
+	}
+
+	got, err := summaries.Get(ctx, alice, "2026-01")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ID != summary.ID || got.OpeningBalance != 100 || got.ClosingBalance != 68.5 || got.FeesPaid != 1.5 ||
+		got.TransactionCount != 3 || !got.GeneratedAt.Equal(summary.GeneratedAt) {
+		t.Errorf("Get = %+v, want %+v", got, summary)
+	}
+	if len(got.TopCounterparties) != 1 || got.TopCounterparties[0] != summary.TopCounterparties[0] {
+		t.Errorf("top counterparties = %+v, want %+v", got.TopCounterparties, summary.TopCounterparties)
+	}
+
+	// A second summary of the same month is not stored, whoever generated it
+	again := *summary
+	again.ID, again.ClosingBalance = uuid.New(), 0
+	if created, err := summaries.Create(ctx, &again); err != nil || created {
+		t.Errorf("second Create of the month = %v, %v; want not stored", created, err)
+	}
+	if got, _ := summaries.Get(ctx, alice, "2026-01"); got == nil || got.ID != summary.ID {
+		t.Errorf("Get after second Create = %+v, want the first summary", got)
+	}
+
+	// Other months and users are kept apart
+	if _, err := summaries.Get(ctx, alice, "2026-02"); err == nil {
+		t.Error("Get of another month found a summary")
+	}
+	if _, err := summaries.Get(ctx, bob, "2026-01"); err == nil {
+		t.Error("Get of another user found a summary")
+	}
+}
@@ -0,0 +1,117 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testNetting(t *testing.T, target Target) {
+	ctx := context.Background()
+	netting := target.Repos.Netting
+
+	userA, userB := domain.NettingPair(createUser(t, target.Repos, "alice").ID, createUser(t, target.Repos, "bob").ID)
+	carol := createUser(t, target.Repos, "carol").ID
+
+	// A pair has one open batch per currency
+	closesAt := time.Now().Add(-time.Second)
+	batch, err := netting.OpenBatch(ctx, userA, userB, "USD", closesAt)
+	if err != nil {
+		t.Fatalf("open batch: %v", err)
+	}
+	if batch.Status != domain.NettingOpen || batch.TransferCount != 0 {
+		t.Errorf("opened batch = %+v, want an empty open batch", batch)
+	}
+	expectTime(t, "closes_at", batch.ClosesAt, closesAt)
+	again, err := netting.OpenBatch(ctx, userA, userB, "USD", time.Now().Add(time.Hour))
+	if err != nil || again.ID != batch.ID {
+		t.Errorf("reopen = %v, %v; want batch %s", again, err, batch.ID)
+	}
+	expectTime(t, "closes_at after reopen", again.ClosesAt, closesAt)
+	eur, err := netting.OpenBatch(ctx, userA, userB, "EUR", time.Now().Add(time.Hour))
+	if err != nil || eur.ID == batch.ID {
+		t.Fatalf("open EUR batch = %v, %v; want a new batch", eur, err)
+	}
+
+	transfers := []*domain.NettedTransfer{
+		{TransactionID: uuid.New(), FromUserID: userA, Amount: 10},
+		{TransactionID: uuid.New(), FromUserID: userB, Amount: 4},
+		{TransactionID: uuid.New(), FromUserID: userA, Amount: 2.5},
+	}
+	for _, transfer := range transfers {
+		if err := netting.AddTransfer(ctx, batch.ID, transfer); err != nil {
+			t.Fatalf("add transfer: %v", err)
+		}
+	}
+	if err := netting.AddTransfer(ctx, batch.ID, transfers[0]); err == nil {
+		t.Error("adding a transfer twice succeeded")
+	}
+	if err := netting.AddTransfer(ctx, eur.ID, &domain.NettedTransfer{TransactionID: uuid.New(), FromUserID: userA, Amount: 7}); err != nil {
+		t.Fatalf("add EUR transfer: %v", err)
+	}
+
+	// Open batches reserve what each user sent, per currency
+	for _, tc := range []struct {
+		user     uuid.UUID
+		currency string
+		want     float64
+	}{{userA, "USD", 12.5}, {userB, "USD", 4}, {userA, "EUR", 7}, {carol, "USD", 0}} {
+		if reserved, err := netting.ReservedAmount(ctx, tc.user, tc.currency); err != nil || reserved != tc.want {
+			t.Errorf("reserved %s = %v, %v; want %v", tc.currency, reserved, err, tc.want)
+		}
+	}
+
+	listed, err := netting.ListTransfers(ctx, batch.ID)
+	if err != nil || len(listed) != 3 {
+		t.Fatalf("ListTransfers = %d, %v; want 3", len(listed), err)
+	}
+
+	// Only batches whose window closed are due
+	due, err := netting.ListDue(ctx, time.Now(), 10)
+	if err != nil || len(due) != 1 || due[0].ID != batch.ID {
+		t.Fatalf("ListDue = %d batches, %v; want the USD batch", len(due), err)
+	}
+
+	due[0].Net(listed)
+	settledAt := time.Now()
+	due[0].Status = domain.NettingSettled
+	due[0].SettledAt = &settledAt
+	if err := netting.Close(ctx, due[0]); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	expectError(t, "Close twice", netting.Close(ctx, due[0]), "netting batch not open")
+	expectError(t, "AddTransfer to a closed batch", netting.AddTransfer(ctx, batch.ID, &domain.NettedTransfer{TransactionID: uuid.New(), FromUserID: userA, Amount: 1}), "netting batch not open")
+
+	// A closed batch releases its reservation and the pair gets a new batch
+	if reserved, _ := netting.ReservedAmount(ctx, userA, "USD"); reserved != 0 {
+		t.Errorf("reserved after settling = %v, want 0", reserved)
+	}
+	if due, _ := netting.ListDue(ctx, time.Now(), 10); len(due) != 0 {
+		t.Errorf("due after settling = %d, want 0", len(due))
+	}
+	next, err := netting.OpenBatch(ctx, userA, userB, "USD", time.Now().Add(time.Hour))
+	if err != nil || next.ID == batch.ID {
+		t.Errorf("open after settling = %v, %v; want a new batch", next, err)
+	}
+
+	// Newest first, filtered by status
+	all, err := netting.List(ctx, &domain.NettingBatchFilter{})
+	if err != nil || len(all) != 3 || all[0].ID != next.ID || all[2].ID != batch.ID {
+		t.Fatalf("List = %d batches, %v; want 3 newest first", len(all), err)
+	}
+	settled := domain.NettingSettled
+	list, err := netting.List(ctx, &domain.NettingBatchFilter{Status: &settled})
+	if err != nil || len(list) != 1 {
+		t.Fatalf("List settled = %d batches, %v; want 1", len(list), err)
+	}
+	got := list[0]
+	if got.TransferCount != 3 || got.GrossAmount != 16.5 || got.NetAmount != 8.5 || got.NetFromUserID == nil || *got.NetFromUserID != userA || got.SettledAt == nil {
+		t.Errorf("settled batch = %+v, want 3 transfers netting 8.5 from user A", got)
+	}
+	if page, _ := netting.List(ctx, &domain.NettingBatchFilter{Limit: 1, Offset: 1}); len(page) != 1 || page[0].ID != eur.ID {
+		t.Errorf("second page = %v, want the EUR batch", page)
+	}
+}
@@ -0,0 +1,156 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testNotifications(t *testing.T, target Target) {
+	ctx := context.Background()
+	notifications := target.Repos.Notifications
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	var inbox []*domain.Notification
+	for _, title := range []string{"first", "second", "third"} {
+		notification := newNotification(alice, title)
+		if err := notifications.CreateNotification(ctx, notification); err != nil {
+			t.Fatalf("create notification: %v", err)
+		}
+		inbox = append(inbox, notification)
+		pause()
+	}
+	// A retried notification is not added twice
+	if err := notifications.CreateNotification(ctx, inbox[0]); err != nil {
+		t.Errorf("create duplicate notification: %v", err)
+	}
+
+	got, err := notifications.GetNotification(ctx, inbox[0].ID)
+	if err != nil || got.UserID != alice || got.Title != "first" || got.Data["amount"] != 10.0 || got.ReadAt != nil {
+		t.Errorf("GetNotification = %+v, %v; want alice's unread first notification", got, err)
+	}
+	_, err = notifications.GetNotification(ctx, uuid.New())
+	expectError(t, "GetNotification of unknown notification", err, "notification not found")
+
+	// Newest first
+	list, err := notifications.ListNotifications(ctx, &domain.NotificationFilter{UserID: alice, Limit: 10})
+	if err != nil || len(list) != 3 || list[0].Title != "third" || list[2].Title != "first" {
+		t.Errorf("ListNotifications = %d notifications, %v; want third to first", len(list), err)
+	}
+	if list, _ := notifications.ListNotifications(ctx, &domain.NotificationFilter{UserID: alice, Limit: 1, Offset: 1}); len(list) != 1 || list[0].Title != "second" {
+		t.Errorf("second page = %d notifications, want second", len(list))
+	}
+
+	// Reading keeps the first read time
+	readAt := time.Now()
+	if err := notifications.MarkRead(ctx, inbox[1].ID, readAt); err != nil {
+		t.Fatalf("mark read: %v", err)
+	}
+	if err := notifications.MarkRead(ctx, inbox[1].ID, readAt.Add(time.Hour)); err != nil {
+		t.Fatalf("mark read again: %v", err)
+	}
+	if got, _ := notifications.GetNotification(ctx, inbox[1].ID); got == nil || got.ReadAt == nil {
+		t.Errorf("read notification = %+v, want a read time", got)
+	} else {
+		expectTime(t, "read_at", *got.ReadAt, readAt)
+	}
+	expectError(t, "MarkRead of unknown notification", notifications.MarkRead(ctx, uuid.New(), readAt), "notification not found")
+
+	if list, _ := notifications.ListNotifications(ctx, &domain.NotificationFilter{UserID: alice, UnreadOnly: true, Limit: 10}); len(list) != 2 {
+		t.Errorf("unread notifications = %d, want 2", len(list))
+	}
+	if count, err := notifications.CountUnread(ctx, alice); err != nil || count != 2 {
+		t.Errorf("CountUnread = %d, %v; want 2", count, err)
+	}
+	if marked, err := notifications.MarkAllRead(ctx, alice, time.Now()); err != nil || marked != 2 {
+		t.Errorf("MarkAllRead = %d, %v; want 2", marked, err)
+	}
+	if count, _ := notifications.CountUnread(ctx, alice); count != 0 {
+		t.Errorf("unread after MarkAllRead = %d, want 0", count)
+	}
+
+	// Preferences
+	_, err = notifications.GetPreferences(ctx, bob)
+	expectError(t, "GetPreferences before saving", err, "notification preferences not found")
+	prefs := domain.DefaultNotificationPreferences(bob)
+	prefs.Channels[domain.NotificationTypes[0]] = []domain.NotificationChannel{domain.ChannelInApp, domain.ChannelWebhook}
+	prefs.WebhookURL = "https://example.com/hook"
+	prefs.UpdatedAt = time.Now()
+	if err := notifications.UpsertPreferences(ctx, prefs); err != nil {
+		t.Fatalf("upsert preferences: %v", err)
+	}
+	prefs.WebhookURL = ""
+	if err := notifications.UpsertPreferences(ctx, prefs); err != nil {
+		t.Fatalf("replace preferences: %v", err)
+	}
+	if got, err := notifications.GetPreferences(ctx, bob); err != nil || got.WebhookURL != "" || len(got.Channels[domain.NotificationTypes[0]]) != 2 {
+		t.Errorf("GetPreferences = %+v, %v; want the replaced preferences", got, err)
+	}
+
+	// Deliveries are claimed oldest first, leased, and not claimed again until the lease ends
+	now := time.Now()
+	due := newDelivery(bob, now.Add(-time.Minute))
+	older := newDelivery(bob, now.Add(-time.Hour))
+	future := newDelivery(bob, now.Add(time.Hour))
+	for _, delivery := range []*domain.NotificationDelivery{due, older, future} {
+		if err := notifications.CreateDelivery(ctx, delivery); err != nil {
+			t.Fatalf("create delivery: %v", err)
+		}
+	}
+	lease := now.Add(time.Minute)
+	claimed, err := notifications.ClaimDueDeliveries(ctx, now, lease, 10)
+	if err != nil || len(claimed) != 2 || claimed[0].ID != older.ID || claimed[1].ID != due.ID {
+		t.Fatalf("ClaimDueDeliveries = %d deliveries, %v; want the older then the due one", len(claimed), err)
+	}
+	expectTime(t, "leased next_attempt_at", claimed[0].NextAttemptAt, lease)
+	if again, _ := notifications.ClaimDueDeliveries(ctx, now, lease, 10); len(again) != 0 {
+		t.Errorf("claim during the lease = %d deliveries, want 0", len(again))
+	}
+	if one, _ := notifications.ClaimDueDeliveries(ctx, lease, lease.Add(time.Minute), 1); len(one) != 1 {
+		t.Errorf("claim after the lease with limit 1 = %d deliveries, want 1", len(one))
+	}
+
+	sentAt := time.Now()
+	claimed[1].Status = domain.DeliverySent
+	claimed[1].Attempts = 1
+	claimed[1].SentAt = &sentAt
+	if err := notifications.UpdateDelivery(ctx, claimed[1]); err != nil {
+		t.Fatalf("update delivery: %v", err)
+	}
+	if again, _ := notifications.ClaimDueDeliveries(ctx, lease.Add(time.Hour), lease.Add(2*time.Hour), 10); len(again) != 2 {
+		t.Errorf("claim after every lease = %d deliveries, want the two pending ones", len(again))
+	}
+	expectError(t, "UpdateDelivery of unknown delivery", notifications.UpdateDelivery(ctx, newDelivery(bob, now)), "notification delivery not found")
+}
+
+// newNotification returns an inbox notification of user.
+func newNotification(userID uuid.UUID, title string) *domain.Notification {
+	return &domain.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      domain.NotificationTypes[0],
+		Title:     title,
+		Body:      "body",
+		Data:      map[string]interface{}{"amount": 10.0},
+		CreatedAt: time.Now(),
+	}
+}
+
+// newDelivery returns a pending webhook delivery to user due at nextAttemptAt.
+func newDelivery(userID uuid.UUID, nextAttemptAt time.Time) *domain.NotificationDelivery {
+	return &domain.NotificationDelivery{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Type:          domain.NotificationTypes[0],
+		Channel:       domain.ChannelWebhook,
+		Data:          map[string]interface{}{},
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     time.Now(),
+	}
+}
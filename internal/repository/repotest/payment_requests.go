@@ -0,0 +1,86 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testPaymentRequests(t *testing.T, target Target) {
+	ctx := context.Background()
+	requests := target.Repos.PaymentRequests
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+	carol := createUser(t, target.Repos, "carol").ID
+	transfer := createTransaction(t, target.Repos, domain.TypeTransfer, &bob, &alice, 25, domain.StatusSuccess)
+
+	fromBob := newPaymentRequest(alice, bob)
+	if err := requests.Create(ctx, fromBob); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	pause()
+	fromCarol := newPaymentRequest(alice, carol)
+	if err := requests.Create(ctx, fromCarol); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	got, err := requests.GetByID(ctx, fromBob.ID)
+	if err != nil || got.RequesterID != alice || got.PayerID != bob || got.Amount != 25 || got.Note != "dinner" || got.Status != string(domain.PaymentRequestPending) {
+		t.Errorf("GetByID = %+v, %v; want alice's pending request to bob", got, err)
+	}
+	_, err = requests.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown payment request", err, "payment request not found")
+
+	// Newest first
+	list, err := requests.List(ctx, &domain.PaymentRequestFilter{RequesterID: &alice})
+	if err != nil || len(list) != 2 || list[0].ID != fromCarol.ID || list[1].ID != fromBob.ID {
+		t.Errorf("alice's requests = %d, %v; want carol's then bob's", len(list), err)
+	}
+	if list, _ := requests.List(ctx, &domain.PaymentRequestFilter{PayerID: &bob}); len(list) != 1 || list[0].ID != fromBob.ID {
+		t.Errorf("requests to bob = %d, want one", len(list))
+	}
+	if list, _ := requests.List(ctx, &domain.PaymentRequestFilter{Limit: 1, Offset: 1}); len(list) != 1 || list[0].ID != fromBob.ID {
+		t.Errorf("second page = %d requests, want bob's", len(list))
+	}
+
+	// Only the first of two responses wins
+	respondedAt := time.Now()
+	fromBob.Status = string(domain.PaymentRequestApproved)
+	fromBob.TransactionID = &transfer.ID
+	fromBob.UpdatedAt = respondedAt
+	fromBob.RespondedAt = &respondedAt
+	if err := requests.UpdateStatus(ctx, fromBob, string(domain.PaymentRequestPending)); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	fromBob.Status = string(domain.PaymentRequestDeclined)
+	expectError(t, "second response", requests.UpdateStatus(ctx, fromBob, string(domain.PaymentRequestPending)), "payment request status changed")
+
+	got, _ = requests.GetByID(ctx, fromBob.ID)
+	if got == nil || got.Status != string(domain.PaymentRequestApproved) || got.TransactionID == nil || *got.TransactionID != transfer.ID || got.RespondedAt == nil {
+		t.Errorf("after approval = %+v, want the approved request", got)
+	}
+	if list, _ := requests.List(ctx, &domain.PaymentRequestFilter{Status: ptr(string(domain.PaymentRequestPending))}); len(list) != 1 || list[0].ID != fromCarol.ID {
+		t.Errorf("pending requests = %d, want carol's", len(list))
+	}
+}
+
+// newPaymentRequest returns a pending request from requester to payer.
+func newPaymentRequest(requester, payer uuid.UUID) *domain.PaymentRequest {
+	now := time.Now()
+	return &domain.PaymentRequest{
+		ID:          uuid.New(),
+		RequesterID: requester,
+		PayerID:     payer,
+		Amount:      25,
+		Currency:    "USD",
+		Note:        "dinner",
+		Status:      string(domain.PaymentRequestPending),
+		ExpiresAt:   now.Add(24 * time.Hour),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
@@ -0,0 +1,85 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testPolicies(t *testing.T, target Target) {
+	ctx := context.Background()
+	policies := target.Repos.Policies
+
+	admin := createUser(t, target.Repos, "alice").ID
+
+	now := time.Now().Truncate(time.Second)
+	if _, err := policies.EffectiveAt(ctx, now); err == nil || err.Error() != "policy version not found" {
+		t.Fatalf("EffectiveAt before any version = %v, want policy version not found", err)
+	}
+	if next, err := policies.NextAfter(ctx, now); err != nil || next != nil {
+		t.Fatalf("NextAfter before any version = %+v, %v; want none", next, err)
+	}
+
+	create := func(window time.Duration, effectiveFrom time.Time) *domain.PolicyVersion {
+		t.Helper()
+		version := &domain.PolicyVersion{
+			ID: uuid.New(),
+			Policy: domain.Policy{
+				RollbackWindow: domain.Duration(window),
+				AmountLimits:   domain.AmountLimits{"USD": {domain.TypeTransfer: {Min: 1, Max: 500}}},
+				TransferFees:   map[string]domain.TransferFee{"USD": {Fixed: 0.5, Percent: 1}},
+			},
+			EffectiveFrom: effectiveFrom,
+			Reason:        "test",
+			CreatedBy:     admin,
+			CreatedAt:     now,
+		}
+		if err := policies.Create(ctx, version); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return version
+	}
+
+	first := create(time.Hour, now.Add(-time.Hour))
+	second := create(2*time.Hour, now.Add(-time.Hour)) // Takes over from the first as it is stored later
+	future := create(3*time.Hour, now.Add(time.Hour))
+	if first.Version < 1 || second.Version <= first.Version || future.Version <= second.Version {
+		t.Fatalf("versions = %d, %d, %d; want increasing from 1", first.Version, second.Version, future.Version)
+	}
+
+	got, err := policies.GetByVersion(ctx, first.Version)
+	if err != nil {
+		t.Fatalf("GetByVersion: %v", err)
+	}
+	if time.Duration(got.Policy.RollbackWindow) != time.Hour || got.Policy.AmountLimits["USD"][domain.TypeTransfer].Max != 500 ||
+		got.Policy.TransferFees["USD"].Percent != 1 || got.Reason != "test" || got.CreatedBy != admin || got.ID != first.ID {
+		t.Errorf("GetByVersion = %+v, want the first version", got)
+	}
+	if _, err := policies.GetByVersion(ctx, future.Version+1); err == nil || err.Error() != "policy version not found" {
+		t.Errorf("GetByVersion of a missing version = %v, want policy version not found", err)
+	}
+
+	if got, err := policies.EffectiveAt(ctx, now); err != nil || got.Version != second.Version {
+		t.Errorf("EffectiveAt now = %+v, %v; want the second version", got, err)
+	}
+	if got, err := policies.EffectiveAt(ctx, now.Add(2*time.Hour)); err != nil || got.Version != future.Version {
+		t.Errorf("EffectiveAt later = %+v, %v; want the future version", got, err)
+	}
+	if got, err := policies.NextAfter(ctx, now); err != nil || got == nil || got.Version != future.Version {
+		t.Errorf("NextAfter now = %+v, %v; want the future version", got, err)
+	}
+	if got, err := policies.NextAfter(ctx, now.Add(time.Hour)); err != nil || got != nil {
+		t.Errorf("NextAfter the last version = %+v, %v; want none", got, err)
+	}
+
+	list, err := policies.List(ctx, &domain.PolicyVersionFilter{})
+	if err != nil || len(list) != 3 || list[0].Version != future.Version || list[2].Version != first.Version {
+		t.Fatalf("List = %v, %v; want every version, newest first", list, err)
+	}
+	if list, err := policies.List(ctx, &domain.PolicyVersionFilter{Limit: 1, Offset: 1}); err != nil || len(list) != 1 || list[0].Version != second.Version {
+		t.Errorf("List page 2 = %v, %v; want the second version", list, err)
+	}
+}
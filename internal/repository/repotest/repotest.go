@@ -0,0 +1,160 @@
+// Package repotest is a conformance suite for repository implementations. The PostgreSQL
+// repositories and the in-memory ones run the same suite, so both behave the same way.
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// Target is a set of repositories under test and the database they share.
+type Target struct {
+	Repos      *repository.Repositories
+	DB         repository.DBTX       // Begins the transactions balance changes run in
+	UnitOfWork repository.UnitOfWork // Begins transactions over Repos' database
+}
+
+// Run runs the conformance suite. newTarget is called once per subtest and must return
+// repositories over an empty database holding the default currencies, each with an empty treasury.
+func Run(t *testing.T, newTarget func(t *testing.T) Target) {
+	suites := []struct {
+		name string
+		run  func(t *testing.T, target Target)
+	}{
+		{"Users", testUsers},
+		{"Balances", testBalances},
+		{"BulkCreate", testBulkCreate},
+		{"Transactions", testTransactions},
+		{"ConcurrentReversals", testConcurrentReversals},
+		{"Audit", testAudit},
+		{"Events", testEvents},
+		{"ScheduledTransactions", testScheduledTransactions},
+		{"ScheduledFunding", testScheduledFunding},
+		{"ScheduledAdmin", testScheduledAdmin},
+		{"ScheduledBusinessDays", testScheduledBusinessDays},
+		{"ImpersonationSessions", testImpersonationSessions},
+		{"KnownDevices", testKnownDevices},
+		{"LoginSessions", testLoginSessions},
+		{"SecurityEvents", testSecurityEvents},
+		{"AnomalyScores", testAnomalyScores},
+		{"Approvals", testApprovals},
+		{"Policies", testPolicies},
+		{"MonthlySummaries", testMonthlySummaries},
+		{"Disputes", testDisputes},
+		{"PaymentRequests", testPaymentRequests},
+		{"TransferTemplates", testTransferTemplates},
+		{"Contacts", testContacts},
+		{"Notifications", testNotifications},
+		{"BalanceAlerts", testBalanceAlerts},
+		{"Budgets", testBudgets},
+		{"DormantAccounts", testDormantAccounts},
+		{"Search", testSearch},
+		{"TransactionAggregates", testTransactionAggregates},
+		{"Treasury", testTreasury},
+		{"Currencies", testCurrencies},
+		{"Netting", testNetting},
+	}
+
+	for _, suite := range suites {
+		t.Run(suite.name, func(t *testing.T) {
+			suite.run(t, newTarget(t))
+		})
+	}
+}
+
+// createUser creates an active user with an empty USD balance.
+func createUser(t *testing.T, repos *repository.Repositories, username string) *domain.User {
+	t.Helper()
+	ctx := context.Background()
+
+	user := &domain.User{
+		Username:     username,
+		Email:        username + "@example.com",
+		PasswordHash: "!",
+		Role:         string(domain.RoleUser),
+	}
+	if err := repos.Users.Create(ctx, user); err != nil {
+		t.Fatalf("create user %s: %v", username, err)
+	}
+
+	if err := repos.Balances.Upsert(ctx, &domain.Balance{UserID: user.ID, Currency: "USD"}); err != nil {
+		t.Fatalf("create balance of %s: %v", username, err)
+	}
+
+	return user
+}
+
+// createTransaction creates a transaction and moves it to status.
+func createTransaction(t *testing.T, repos *repository.Repositories, txType domain.TransactionType, from, to *uuid.UUID, amount float64, status domain.TransactionStatus) *domain.Transaction {
+	t.Helper()
+	ctx := context.Background()
+
+	tx := &domain.Transaction{
+		FromUserID: from,
+		ToUserID:   to,
+		Amount:     amount,
+		Currency:   "USD",
+		Type:       string(txType),
+	}
+	if err := repos.Transactions.CreatePending(ctx, tx); err != nil {
+		t.Fatalf("create %s transaction: %v", txType, err)
+	}
+
+	var err error
+	switch status {
+	case domain.StatusSuccess:
+		err = repos.Transactions.MarkCompleted(ctx, tx.ID)
+	case domain.StatusFailed:
+		err = repos.Transactions.MarkFailed(ctx, tx.ID)
+	}
+	if err != nil {
+		t.Fatalf("mark transaction %s: %v", status, err)
+	}
+	tx.Status = string(status)
+
+	// Keep created_at distinct, so newest-first ordering is well defined
+	pause()
+
+	return tx
+}
+
+// pause waits long enough for the next record to get a later timestamp than the previous one.
+func pause() {
+	time.Sleep(2 * time.Millisecond)
+}
+
+// expectError fails unless err is an error with exactly the message want.
+func expectError(t *testing.T, what string, err error, want string) {
+	t.Helper()
+	if err == nil || err.Error() != want {
+		t.Errorf("%s: err = %v, want %q", what, err, want)
+	}
+}
+
+// expectTime fails unless got is within a millisecond of want; databases round timestamps.
+func expectTime(t *testing.T, what string, got, want time.Time) {
+	t.Helper()
+	if diff := got.Sub(want); diff < -time.Millisecond || diff > time.Millisecond {
+		t.Errorf("%s = %v, want %v", what, got, want)
+	}
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ids returns the IDs of transactions, for readable failures.
+func ids(transactions []*domain.Transaction) []string {
+	out := make([]string, len(transactions))
+	for i, tx := range transactions {
+		out[i] = fmt.Sprintf("%s %.2f", tx.Type, tx.Amount)
+	}
+	return out
+}
@@ -0,0 +1,424 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testScheduledTransactions(t *testing.T, target Target) {
+	ctx := context.Background()
+	scheduled := target.Repos.ScheduledTransactions
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	due := newScheduled(alice, domain.TypeCredit, "one-time", time.Now().Add(-time.Minute))
+	later := newScheduled(alice, domain.TypeTransfer, "recurring", time.Now().Add(time.Hour))
+	later.ToUserID = &bob
+	later.RecurrencePattern = ptr("daily")
+	for _, st := range []*domain.ScheduledTransaction{later, due} {
+		if err := scheduled.Create(ctx, st); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	got, err := scheduled.GetByID(ctx, later.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.UserID != alice || got.ToUserID == nil || *got.ToUserID != bob || got.ScheduleType != "recurring" || got.Amount != 10 || got.Description != "rent" {
+		t.Errorf("GetByID = %+v, want alice's recurring transfer to bob", got)
+	}
+	expectTime(t, "execute_at", got.ExecuteAt, later.ExecuteAt)
+	_, err = scheduled.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown scheduled transaction", err, "scheduled transaction not found")
+
+	// Ordered by execution time
+	list, err := scheduled.GetByUserID(ctx, alice, nil)
+	if err != nil || len(list) != 2 || list[0].ID != due.ID || list[1].ID != later.ID {
+		t.Errorf("GetByUserID = %d items, %v; want the due one then the later one", len(list), err)
+	}
+	if list, _ := scheduled.GetByUserID(ctx, alice, &domain.ScheduledTransactionFilter{Type: ptr(string(domain.TypeTransfer))}); len(list) != 1 || list[0].ID != later.ID {
+		t.Errorf("transfers = %d items, want the later one", len(list))
+	}
+	if list, _ := scheduled.GetByUserID(ctx, alice, &domain.ScheduledTransactionFilter{ExecuteTo: ptr(time.Now())}); len(list) != 1 || list[0].ID != due.ID {
+		t.Errorf("due by now = %d items, want the due one", len(list))
+	}
+	if list, _ := scheduled.GetByUserID(ctx, alice, &domain.ScheduledTransactionFilter{Limit: 1, Offset: 1}); len(list) != 1 || list[0].ID != later.ID {
+		t.Errorf("second page = %d items, want the later one", len(list))
+	}
+	if list, _ := scheduled.GetByUserID(ctx, bob, nil); len(list) != 0 {
+		t.Errorf("bob's scheduled transactions = %d, want 0", len(list))
+	}
+
+	// Only due transactions are claimed, and a claim holds until its lease expires or its owner releases it
+	claimed, err := scheduled.ClaimDueForExecution(ctx, "a", time.Now(), time.Minute, 10)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Fatalf("first claim = %d items, %v; want the due one", len(claimed), err)
+	}
+	if claimed, _ := scheduled.ClaimDueForExecution(ctx, "b", time.Now(), time.Minute, 10); len(claimed) != 0 {
+		t.Errorf("claim during lease = %d items, want 0", len(claimed))
+	}
+	if err := scheduled.ReleaseClaim(ctx, due.ID, "b"); err != nil {
+		t.Fatalf("foreign release: %v", err)
+	}
+	if claimed, _ := scheduled.ClaimDueForExecution(ctx, "b", time.Now(), time.Minute, 10); len(claimed) != 0 {
+		t.Errorf("claim after foreign release = %d items, want 0", len(claimed))
+	}
+	if err := scheduled.ReleaseClaim(ctx, due.ID, "a"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if claimed, _ := scheduled.ClaimDueForExecution(ctx, "b", time.Now(), time.Minute, 10); len(claimed) != 1 {
+		t.Errorf("claim after release = %d items, want 1", len(claimed))
+	}
+
+	// Transactions are due as of the time claimed for, e.g. a simulation clock running ahead
+	claimed, err = scheduled.ClaimDueForExecution(ctx, "c", time.Now().Add(2*time.Hour), time.Minute, 10)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != later.ID {
+		t.Errorf("claim two hours ahead = %d items, %v; want the later one", len(claimed), err)
+	}
+	if err := scheduled.ReleaseClaim(ctx, later.ID, "c"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+
+	executedAt := time.Now()
+	due.Status = "completed"
+	due.IsActive = false
+	due.LastExecutedAt = &executedAt
+	due.CurrentOccurrence = 1
+	if err := scheduled.Update(ctx, due); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ := scheduled.GetByID(ctx, due.ID); got == nil || got.Status != "completed" || got.IsActive || got.CurrentOccurrence != 1 || got.LastExecutedAt == nil {
+		t.Errorf("after update = %+v, want a completed execution", got)
+	}
+	if err := scheduled.ResetStatus(ctx, later.ID, "paused"); err != nil {
+		t.Fatalf("reset status: %v", err)
+	}
+	if got, _ := scheduled.GetByID(ctx, later.ID); got == nil || got.Status != "paused" {
+		t.Errorf("after reset = %+v, want paused", got)
+	}
+
+	counts := []struct {
+		name   string
+		filter *domain.ScheduledTransactionFilter
+		want   int
+	}{
+		{"all", nil, 2},
+		{"paused", &domain.ScheduledTransactionFilter{Status: ptr("paused")}, 1},
+		{"inactive", &domain.ScheduledTransactionFilter{IsActive: ptr(false)}, 1},
+		{"credits", &domain.ScheduledTransactionFilter{Type: ptr(string(domain.TypeCredit))}, 1},
+	}
+	for _, c := range counts {
+		if count, err := scheduled.Count(ctx, alice, c.filter); err != nil || count != c.want {
+			t.Errorf("%s: Count = %d, %v; want %d", c.name, count, err, c.want)
+		}
+	}
+
+	// Pausing and resuming are recorded alongside executions
+	for i, status := range []string{"paused", "resumed", "failed", "success"} {
+		execution := &domain.ScheduledTransactionExecution{
+			ID:                     uuid.New(),
+			ScheduledTransactionID: due.ID,
+			ExecutedAt:             executedAt.Add(time.Duration(i) * time.Second),
+			Status:                 status,
+			Amount:                 due.Amount,
+			Currency:               due.Currency,
+		}
+		if err := scheduled.CreateExecution(ctx, execution); err != nil {
+			t.Fatalf("create %s execution: %v", status, err)
+		}
+	}
+	executions, err := scheduled.GetExecutions(ctx, due.ID, 10, 0)
+	if err != nil || len(executions) != 4 || executions[0].Status != "success" || executions[1].Status != "failed" || executions[3].Status != "paused" {
+		t.Errorf("executions = %d, %v; want newest first", len(executions), err)
+	}
+	if executions, _ := scheduled.GetExecutions(ctx, due.ID, 1, 1); len(executions) != 1 || executions[0].Status != "failed" {
+		t.Errorf("second execution = %+v, want the failed one", executions)
+	}
+
+	if err := scheduled.Delete(ctx, due.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = scheduled.GetByID(ctx, due.ID)
+	expectError(t, "GetByID of deleted scheduled transaction", err, "scheduled transaction not found")
+	expectError(t, "second Delete", scheduled.Delete(ctx, due.ID), "scheduled transaction not found")
+	if executions, _ := scheduled.GetExecutions(ctx, due.ID, 10, 0); len(executions) != 0 {
+		t.Errorf("executions of deleted scheduled transaction = %d, want none", len(executions))
+	}
+}
+
+func testScheduledFunding(t *testing.T, target Target) {
+	ctx := context.Background()
+	scheduled := target.Repos.ScheduledTransactions
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	now := time.Now().Truncate(time.Millisecond)
+	rent := newScheduled(bob, domain.TypeDebit, "recurring", now.Add(time.Hour))
+	gym := newScheduled(alice, domain.TypeTransfer, "one-time", now.Add(90*time.Minute))
+	phone := newScheduled(alice, domain.TypeDebit, "one-time", now.Add(30*time.Minute))
+	salary := newScheduled(alice, domain.TypeCredit, "one-time", now.Add(time.Hour))
+	overdue := newScheduled(alice, domain.TypeDebit, "one-time", now.Add(-time.Minute))
+	tooLate := newScheduled(alice, domain.TypeDebit, "one-time", now.Add(3*time.Hour))
+	paused := newScheduled(alice, domain.TypeDebit, "one-time", now.Add(time.Hour))
+	paused.Status = "paused"
+	for _, st := range []*domain.ScheduledTransaction{rent, gym, phone, salary, overdue, tooLate, paused} {
+		if err := scheduled.Create(ctx, st); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	upcoming, err := scheduled.ListUpcomingOutgoing(ctx, now, now.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("list upcoming: %v", err)
+	}
+	want := []uuid.UUID{phone.ID, gym.ID, rent.ID}
+	if bob.String() < alice.String() {
+		want = []uuid.UUID{rent.ID, phone.ID, gym.ID}
+	}
+	if len(upcoming) != len(want) {
+		t.Fatalf("upcoming = %d, want %d", len(upcoming), len(want))
+	}
+	for i, st := range upcoming {
+		if st.ID != want[i] {
+			t.Errorf("upcoming[%d] = %s, want %s (grouped by user, earliest first)", i, st.ID, want[i])
+		}
+	}
+	if limited, _ := scheduled.ListUpcomingOutgoing(ctx, now, now.Add(2*time.Hour), 1); len(limited) != 1 {
+		t.Errorf("limited upcoming = %d, want 1", len(limited))
+	}
+
+	if alerted, err := scheduled.MarkFundingAlerted(ctx, rent.ID, rent.ExecuteAt); err != nil || !alerted {
+		t.Errorf("first MarkFundingAlerted = %v, %v; want true", alerted, err)
+	}
+	if alerted, err := scheduled.MarkFundingAlerted(ctx, rent.ID, rent.ExecuteAt); err != nil || alerted {
+		t.Errorf("second MarkFundingAlerted = %v, %v; want false", alerted, err)
+	}
+	got, err := scheduled.GetByID(ctx, rent.ID)
+	if err != nil || got.FundingAlertedFor == nil {
+		t.Fatalf("after alert = %+v, %v; want funding_alerted_for", got, err)
+	}
+	expectTime(t, "funding_alerted_for", *got.FundingAlertedFor, rent.ExecuteAt)
+	if got.NeedsFundingAlert() {
+		t.Error("NeedsFundingAlert() after alert = true, want false")
+	}
+	if alerted, err := scheduled.MarkFundingAlerted(ctx, rent.ID, rent.ExecuteAt.AddDate(0, 1, 0)); err != nil || !alerted {
+		t.Errorf("MarkFundingAlerted of the next occurrence = %v, %v; want true", alerted, err)
+	}
+
+	// Retries of a failed execution are kept until the next update
+	retryAt := now.Add(time.Hour)
+	overdue.FailedAttempts = 2
+	overdue.RetryAt = &retryAt
+	if err := scheduled.Update(ctx, overdue); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got, err = scheduled.GetByID(ctx, overdue.ID)
+	if err != nil || got.FailedAttempts != 2 || got.RetryAt == nil {
+		t.Fatalf("after retry = %+v, %v; want 2 failed attempts and a retry time", got, err)
+	}
+	expectTime(t, "retry_at", *got.RetryAt, retryAt)
+}
+
+func testScheduledAdmin(t *testing.T, target Target) {
+	ctx := context.Background()
+	scheduled := target.Repos.ScheduledTransactions
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	now := time.Now().Truncate(time.Millisecond)
+	rent := newScheduled(alice, domain.TypeDebit, "recurring", now.Add(time.Hour))
+	gym := newScheduled(bob, domain.TypeTransfer, "one-time", now.Add(time.Hour))
+	gym.CreatedAt = rent.CreatedAt.Add(time.Second)
+	phone := newScheduled(alice, domain.TypeDebit, "one-time", now.Add(time.Hour))
+	phone.CreatedAt = rent.CreatedAt.Add(2 * time.Second)
+	phone.Status = "cancelled"
+	phone.IsActive = false
+	for _, st := range []*domain.ScheduledTransaction{rent, gym, phone} {
+		if err := scheduled.Create(ctx, st); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	failures := []struct {
+		st         *domain.ScheduledTransaction
+		executedAt time.Time
+		message    string
+	}{
+		{rent, now.Add(-time.Hour), "insufficient funds: current balance 5.00 USD, requested 10.00 USD"},
+		{rent, now.Add(-2 * time.Hour), "insufficient funds: current balance 1.00 USD, requested 10.00 USD"},
+		{gym, now.Add(-30 * time.Minute), "insufficient funds: current balance 0.00 USD, requested 10.00 USD"},
+		{phone, now.Add(-3 * time.Hour), "recipient not found"},
+		{phone, now.Add(-10 * 24 * time.Hour), "treasury cap exceeded: daily cap reached"},
+	}
+	for _, failure := range failures {
+		if err := scheduled.CreateExecution(ctx, &domain.ScheduledTransactionExecution{
+			ID:                     uuid.New(),
+			ScheduledTransactionID: failure.st.ID,
+			ExecutedAt:             failure.executedAt,
+			Status:                 "failed",
+			ErrorMessage:           failure.message,
+			Amount:                 10,
+			Currency:               "USD",
+		}); err != nil {
+			t.Fatalf("create execution: %v", err)
+		}
+	}
+	if err := scheduled.CreateExecution(ctx, &domain.ScheduledTransactionExecution{
+		ID: uuid.New(), ScheduledTransactionID: gym.ID, ExecutedAt: now, Status: "success", Amount: 10, Currency: "USD",
+	}); err != nil {
+		t.Fatalf("create execution: %v", err)
+	}
+
+	all, err := scheduled.List(ctx, &domain.ScheduledTransactionFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != phone.ID || all[1].ID != gym.ID || all[2].ID != rent.ID {
+		t.Errorf("list = %d scheduled transactions, want phone, gym and rent, newest first", len(all))
+	}
+	if page, _ := scheduled.List(ctx, &domain.ScheduledTransactionFilter{Limit: 1, Offset: 1}); len(page) != 1 || page[0].ID != gym.ID {
+		t.Errorf("second page = %v, want gym", page)
+	}
+
+	since := now.Add(-24 * time.Hour)
+	filters := []struct {
+		name   string
+		filter *domain.ScheduledTransactionFilter
+		want   int
+	}{
+		{"user", &domain.ScheduledTransactionFilter{UserID: &alice}, 2},
+		{"status", &domain.ScheduledTransactionFilter{Status: ptr("active")}, 2},
+		{"failed since", &domain.ScheduledTransactionFilter{FailedSince: &since}, 3},
+		{"user and failed since", &domain.ScheduledTransactionFilter{UserID: &bob, FailedSince: &since}, 1},
+		{"failed recently", &domain.ScheduledTransactionFilter{FailedSince: ptr(now.Add(-45 * time.Minute))}, 1},
+	}
+	for _, tc := range filters {
+		listed, err := scheduled.List(ctx, tc.filter)
+		if err != nil || len(listed) != tc.want {
+			t.Errorf("list by %s = %d, %v; want %d", tc.name, len(listed), err, tc.want)
+		}
+		tc.filter.Limit = 1
+		if count, err := scheduled.CountAll(ctx, tc.filter); err != nil || count != tc.want {
+			t.Errorf("count by %s = %d, %v; want %d", tc.name, count, err, tc.want)
+		}
+	}
+
+	reasons, err := scheduled.GetFailureReasons(ctx, since)
+	if err != nil {
+		t.Fatalf("failure reasons: %v", err)
+	}
+	if len(reasons) != 2 {
+		t.Fatalf("failure reasons = %+v, want 2", reasons)
+	}
+	if reasons[0].Reason != "insufficient funds" || reasons[0].Failures != 3 || reasons[0].ScheduledTransactions != 2 {
+		t.Errorf("top reason = %+v, want 3 insufficient funds failures of 2 schedules", reasons[0])
+	}
+	expectTime(t, "last failed at", reasons[0].LastFailedAt, now.Add(-30*time.Minute))
+	if reasons[1].Reason != "recipient not found" || reasons[1].Failures != 1 || reasons[1].ScheduledTransactions != 1 {
+		t.Errorf("second reason = %+v, want 1 recipient not found failure", reasons[1])
+	}
+	if none, err := scheduled.GetFailureReasons(ctx, now.Add(time.Minute)); err != nil || len(none) != 0 {
+		t.Errorf("failure reasons in the future = %+v, %v; want none", none, err)
+	}
+
+	// Reassigning hands a schedule to another owner without the previous owner's funding alert
+	if _, err := scheduled.MarkFundingAlerted(ctx, rent.ID, rent.ExecuteAt); err != nil {
+		t.Fatalf("mark funding alerted: %v", err)
+	}
+	if err := scheduled.Reassign(ctx, rent.ID, bob); err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if got, err := scheduled.GetByID(ctx, rent.ID); err != nil || got.UserID != bob || got.FundingAlertedFor != nil || got.TemplateID != nil {
+		t.Errorf("after reassign = %+v, %v; want bob's without a funding alert", got, err)
+	}
+	if list, _ := scheduled.GetByUserID(ctx, bob, nil); len(list) != 2 {
+		t.Errorf("bob's scheduled transactions after reassign = %d, want 2", len(list))
+	}
+	expectError(t, "Reassign of unknown scheduled transaction", scheduled.Reassign(ctx, uuid.New(), bob), "scheduled transaction not found")
+}
+
+func testScheduledBusinessDays(t *testing.T, target Target) {
+	ctx := context.Background()
+	scheduled := target.Repos.ScheduledTransactions
+
+	alice := createUser(t, target.Repos, "alice").ID
+
+	// An occurrence moved to an earlier business day is due then, not on its own date
+	now := time.Now().Truncate(time.Millisecond)
+	moved := newScheduled(alice, domain.TypeDebit, "recurring", now.Add(48*time.Hour))
+	moved.RecurrencePattern = ptr("monthly")
+	moved.BusinessDayRule = domain.BusinessDayPreceding
+	moved.CalendarRegion = "TR"
+	movedTo := now.Add(-time.Minute)
+	moved.AdjustedExecuteAt = &movedTo
+	plain := newScheduled(alice, domain.TypeDebit, "one-time", now.Add(time.Hour))
+	for _, st := range []*domain.ScheduledTransaction{moved, plain} {
+		if err := scheduled.Create(ctx, st); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	got, err := scheduled.GetByID(ctx, moved.ID)
+	if err != nil || got.BusinessDayRule != domain.BusinessDayPreceding || got.CalendarRegion != "TR" || got.AdjustedExecuteAt == nil {
+		t.Fatalf("GetByID = %+v, %v; want the preceding rule in TR with an adjusted time", got, err)
+	}
+	expectTime(t, "adjusted_execute_at", *got.AdjustedExecuteAt, movedTo)
+	if got, _ := scheduled.GetByID(ctx, plain.ID); got == nil || got.BusinessDayRule != domain.BusinessDayNone || got.CalendarRegion != "" {
+		t.Errorf("schedule without a rule = %+v, want the none rule", got)
+	}
+
+	claimed, err := scheduled.ClaimDueForExecution(ctx, "a", time.Now(), time.Minute, 10)
+	if err != nil || len(claimed) != 1 || claimed[0].ID != moved.ID {
+		t.Fatalf("claim = %d items, %v; want the moved one", len(claimed), err)
+	}
+	if err := scheduled.ReleaseClaim(ctx, moved.ID, "a"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+
+	// Funding checks look at when occurrences are due
+	movedTo = now.Add(30 * time.Minute)
+	moved.AdjustedExecuteAt = &movedTo
+	if err := scheduled.Update(ctx, moved); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	upcoming, err := scheduled.ListUpcomingOutgoing(ctx, now, now.Add(2*time.Hour), 10)
+	if err != nil || len(upcoming) != 2 || upcoming[0].ID != moved.ID {
+		t.Fatalf("upcoming = %d, %v; want the moved one first", len(upcoming), err)
+	}
+
+	// Clearing the adjustment makes the occurrence due on its own date again
+	moved.AdjustedExecuteAt = nil
+	if err := scheduled.Update(ctx, moved); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if upcoming, _ := scheduled.ListUpcomingOutgoing(ctx, now, now.Add(2*time.Hour), 10); len(upcoming) != 1 || upcoming[0].ID != plain.ID {
+		t.Errorf("upcoming after clearing = %d, want the plain one", len(upcoming))
+	}
+}
+
+// newScheduled returns an active scheduled transaction of 10 USD, created a minute ago so it can be claimed at once.
+func newScheduled(userID uuid.UUID, txType domain.TransactionType, scheduleType string, executeAt time.Time) *domain.ScheduledTransaction {
+	created := time.Now().Add(-time.Minute)
+	return &domain.ScheduledTransaction{
+		ID:              uuid.New(),
+		UserID:          userID,
+		TransactionType: string(txType),
+		Amount:          10,
+		Currency:        "USD",
+		Description:     "rent",
+		ScheduleType:    scheduleType,
+		ExecuteAt:       executeAt,
+		Status:          "active",
+		IsActive:        true,
+		CreatedAt:       created,
+		UpdatedAt:       created,
+	}
+}
@@ -0,0 +1,128 @@
+package repotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testSearch(t *testing.T, target Target) {
+	ctx := context.Background()
+	search := target.Repos.Search
+
+	alice := createUser(t, target.Repos, "alice")
+	bob := createUser(t, target.Repos, "bob")
+	carol := createUser(t, target.Repos, "carol")
+
+	transfer := func(from, to uuid.UUID, description, clientReference string) *domain.Transaction {
+		t.Helper()
+		tx := &domain.Transaction{
+			FromUserID:      &from,
+			ToUserID:        &to,
+			Amount:          10,
+			Currency:        "USD",
+			Type:            string(domain.TypeTransfer),
+			Description:     description,
+			ClientReference: clientReference,
+		}
+		if err := target.Repos.Transactions.CreatePending(ctx, tx); err != nil {
+			t.Fatalf("create transfer: %v", err)
+		}
+		pause()
+		return tx
+	}
+	rent := transfer(alice.ID, bob.ID, "Rent for May", "")
+	groceries := transfer(alice.ID, carol.ID, "Weekly groceries", "REF7781")
+	dinner := transfer(bob.ID, alice.ID, "Dinner with the rent committee, rent split", "")
+	transfer(bob.ID, carol.ID, "Rent for June", "")
+
+	request := newPaymentRequest(carol.ID, alice.ID)
+	request.Note = "Concert tickets"
+	if err := target.Repos.PaymentRequests.Create(ctx, request); err != nil {
+		t.Fatalf("create payment request: %v", err)
+	}
+
+	kinds := func(hits []*domain.SearchHit) map[uuid.UUID]domain.SearchHitKind {
+		found := make(map[uuid.UUID]domain.SearchHitKind)
+		for _, hit := range hits {
+			found[hit.ID] = hit.Kind
+		}
+		return found
+	}
+
+	// Words match whole and ignoring case, only among the user's own records
+	hits, err := search.Search(ctx, alice.ID, "RENT", 10, 0)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 2 || hits[0].ID != dinner.ID || hits[1].ID != rent.ID {
+		t.Fatalf("alice's rent hits = %v, want the dinner, which mentions rent twice, then the rent", kinds(hits))
+	}
+	if hits[0].Kind != domain.SearchHitTransaction || hits[0].Rank <= 0 || hits[0].CreatedAt.IsZero() {
+		t.Errorf("dinner hit = %+v, want a ranked transaction", hits[0])
+	}
+	if !strings.Contains(hits[1].Snippet, domain.SearchHighlightStart+"Rent"+domain.SearchHighlightStop) {
+		t.Errorf("rent snippet = %q, want Rent highlighted", hits[1].Snippet)
+	}
+	if hits, _ := search.Search(ctx, alice.ID, "ren", 10, 0); len(hits) != 0 {
+		t.Errorf("partial word hits = %d, want 0", len(hits))
+	}
+	if count, err := search.Count(ctx, alice.ID, "rent"); err != nil || count != 2 {
+		t.Errorf("Count = %d, %v; want 2", count, err)
+	}
+	if hits, _ := search.Search(ctx, alice.ID, "rent", 1, 1); len(hits) != 1 || hits[0].ID != rent.ID {
+		t.Errorf("second page = %v, want the rent", kinds(hits))
+	}
+
+	// Client references, the other party's username and payment request notes are searched too
+	if hits, _ := search.Search(ctx, alice.ID, "ref7781", 10, 0); len(hits) != 1 || hits[0].ID != groceries.ID {
+		t.Errorf("client reference hits = %v, want the groceries", kinds(hits))
+	}
+	hits, err = search.Search(ctx, alice.ID, "carol", 10, 0)
+	if found := kinds(hits); err != nil || len(found) != 2 || found[groceries.ID] != domain.SearchHitTransaction || found[request.ID] != domain.SearchHitPaymentRequest {
+		t.Errorf("carol hits = %v, %v; want the groceries and her payment request", found, err)
+	}
+	if hits, _ := search.Search(ctx, carol.ID, "concert", 10, 0); len(hits) != 1 || hits[0].ID != request.ID {
+		t.Errorf("requester's concert hits = %v, want the payment request", kinds(hits))
+	}
+
+	// Web search syntax
+	if hits, _ := search.Search(ctx, alice.ID, "rent -dinner", 10, 0); len(hits) != 1 || hits[0].ID != rent.ID {
+		t.Errorf("rent without dinner = %v, want the rent", kinds(hits))
+	}
+	if count, _ := search.Count(ctx, alice.ID, "groceries or concert"); count != 2 {
+		t.Errorf("groceries or concert = %d hits, want 2", count)
+	}
+	if hits, _ := search.Search(ctx, alice.ID, `"for may"`, 10, 0); len(hits) != 1 || hits[0].ID != rent.ID {
+		t.Errorf("phrase hits = %v, want the rent", kinds(hits))
+	}
+	if count, _ := search.Count(ctx, alice.ID, `"may for"`); count != 0 {
+		t.Errorf("reversed phrase = %d hits, want 0", count)
+	}
+	if count, _ := search.Count(ctx, alice.ID, "!!!"); count != 0 {
+		t.Errorf("query without words = %d hits, want 0", count)
+	}
+
+	// The audit log is searched by action and the strings of its details
+	_ = target.Repos.Audit.Log(ctx, "user", alice.ID, "api_quota_set", map[string]interface{}{"reason": "Suspicious scraping", "daily_limit": 100})
+	_ = target.Repos.Audit.Log(ctx, "user", bob.ID, "user_suspended", map[string]interface{}{"reason": "scraping again"})
+	auditHits, err := search.SearchAudit(ctx, "scraping", 10, 0)
+	if err != nil {
+		t.Fatalf("SearchAudit: %v", err)
+	}
+	if len(auditHits) != 2 || auditHits[0].Kind != domain.SearchHitAuditLog {
+		t.Fatalf("scraping audit hits = %d, want 2 audit logs", len(auditHits))
+	}
+	if count, err := search.CountAudit(ctx, "user_suspended"); err != nil || count != 1 {
+		t.Errorf("action hits = %d, %v; want 1", count, err)
+	}
+	if count, _ := search.CountAudit(ctx, "suspended"); count != 1 {
+		t.Errorf("action word hits = %d, want 1", count)
+	}
+	if count, _ := search.CountAudit(ctx, "100"); count != 0 {
+		t.Errorf("number detail hits = %d, want 0: only strings are searched", count)
+	}
+}
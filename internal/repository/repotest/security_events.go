@@ -0,0 +1,78 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testSecurityEvents(t *testing.T, target Target) {
+	ctx := context.Background()
+	events := target.Repos.SecurityEvents
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+
+	device := domain.LoginDevice{IP: "203.0.113.7", UserAgent: "curl/8.0"}
+	now := time.Now()
+	newEvent := func(eventType domain.SecurityEventType, userID *uuid.UUID, age time.Duration) *domain.SecurityEvent {
+		event := domain.NewSecurityEvent(eventType, userID, device)
+		event.CreatedAt = now.Add(-age)
+		return event
+	}
+
+	sessionID := uuid.New()
+	failed := newEvent(domain.SecurityLoginFailed, &alice, 3*time.Hour)
+	failed.Email = "alice@example.com"
+	failed.Reason = "wrong password"
+	unknown := newEvent(domain.SecurityLoginFailed, nil, 2*time.Hour)
+	unknown.Email = "mallory@example.com"
+	login := newEvent(domain.SecurityLogin, &alice, time.Hour)
+	login.SessionID = &sessionID
+	bobs := newEvent(domain.SecurityLogin, &bob, time.Minute)
+	for _, event := range []*domain.SecurityEvent{failed, unknown, login, bobs} {
+		if err := events.Record(ctx, event); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	// Every event, newest first
+	all, err := events.List(ctx, &domain.SecurityEventFilter{})
+	if err != nil || len(all) != 4 || all[0].ID != bobs.ID || all[3].ID != failed.ID {
+		t.Fatalf("List = %d events, %v; want all four, newest first", len(all), err)
+	}
+	if all[2].UserID != nil || all[2].Email != "mallory@example.com" {
+		t.Errorf("unknown email event = %+v, want no user and the attempted email", all[2])
+	}
+
+	// A user's own events
+	own, err := events.List(ctx, &domain.SecurityEventFilter{UserID: &alice})
+	if err != nil || len(own) != 2 || own[0].ID != login.ID || own[1].ID != failed.ID {
+		t.Fatalf("List of alice = %d events, %v; want her login then her failed login", len(own), err)
+	}
+	if own[0].SessionID == nil || *own[0].SessionID != sessionID || own[0].IP != device.IP || own[0].UserAgent != device.UserAgent {
+		t.Errorf("login = %+v, want the session and device", own[0])
+	}
+	if own[1].Reason != "wrong password" || own[1].Type != domain.SecurityLoginFailed {
+		t.Errorf("failed login = %+v, want the reason", own[1])
+	}
+	expectTime(t, "created_at", own[0].CreatedAt, login.CreatedAt)
+
+	eventType := domain.SecurityLoginFailed
+	if failures, _ := events.List(ctx, &domain.SecurityEventFilter{Type: &eventType}); len(failures) != 2 {
+		t.Errorf("List of failed logins = %d events, want 2", len(failures))
+	}
+
+	since := now.Add(-90 * time.Minute)
+	if recent, _ := events.List(ctx, &domain.SecurityEventFilter{Since: &since}); len(recent) != 2 {
+		t.Errorf("List since 90 minutes ago = %d events, want 2", len(recent))
+	}
+
+	page, err := events.List(ctx, &domain.SecurityEventFilter{Limit: 2, Offset: 1})
+	if err != nil || len(page) != 2 || page[0].ID != login.ID || page[1].ID != unknown.ID {
+		t.Errorf("List page = %d events, %v; want the second and third newest", len(page), err)
+	}
+}
@@ -0,0 +1,81 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testTransactionAggregates(t *testing.T, target Target) {
+	ctx := context.Background()
+	aggregates := target.Repos.TransactionAggregates
+
+	alice := createUser(t, target.Repos, "alice")
+	bob := createUser(t, target.Repos, "bob")
+	month, nextMonth := domain.ActivityRange(time.Now(), 1)
+
+	createTransaction(t, target.Repos, domain.TypeCredit, nil, &alice.ID, 100, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeCredit, nil, &alice.ID, 50, domain.StatusSuccess)
+	transfer := createTransaction(t, target.Repos, domain.TypeTransfer, &alice.ID, &bob.ID, 40, domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeDebit, &alice.ID, nil, 999, domain.StatusFailed)
+	pending := createTransaction(t, target.Repos, domain.TypeDebit, &alice.ID, nil, 7, domain.StatusPending)
+
+	byKey := func(list []*domain.TransactionAggregate) map[string]*domain.TransactionAggregate {
+		keyed := make(map[string]*domain.TransactionAggregate)
+		for _, aggregate := range list {
+			keyed[string(aggregate.Direction)+" "+aggregate.Type] = aggregate
+		}
+		return keyed
+	}
+
+	// Only successful transactions count, on each party's side
+	list, err := aggregates.ListMonthly(ctx, alice.ID, month, nextMonth)
+	if err != nil {
+		t.Fatalf("ListMonthly: %v", err)
+	}
+	got := byKey(list)
+	if len(list) != 2 {
+		t.Fatalf("alice's aggregates = %d, want credits in and the transfer out", len(list))
+	}
+	if credits := got["in credit"]; credits == nil || credits.Count != 2 || credits.Amount != 150 || credits.Month != domain.SummaryMonth(time.Now()) || credits.Currency != "USD" {
+		t.Errorf("credits = %+v, want 2 worth 150 this month", credits)
+	}
+	if sent := got["out transfer"]; sent == nil || sent.Count != 1 || sent.Amount != 40 || sent.UserID != alice.ID {
+		t.Errorf("sent = %+v, want 1 transfer worth 40", sent)
+	}
+	if list, _ := aggregates.ListMonthly(ctx, bob.ID, month, nextMonth); len(list) != 1 || list[0].Direction != domain.DirectionIn || list[0].Amount != 40 {
+		t.Errorf("bob's aggregates = %d, want the transfer in", len(list))
+	}
+
+	// Completing and rolling back transactions updates their aggregates
+	if err := target.Repos.Transactions.MarkCompleted(ctx, pending.ID); err != nil {
+		t.Fatalf("complete debit: %v", err)
+	}
+	reversal := createTransaction(t, target.Repos, domain.TypeTransfer, &bob.ID, &alice.ID, 15, domain.StatusSuccess)
+	if err := target.Repos.Transactions.RecordReversal(ctx, transfer.ID, reversal.ID, 15); err != nil {
+		t.Fatalf("reverse transfer: %v", err)
+	}
+	if list, err = aggregates.ListMonthly(ctx, alice.ID, month, nextMonth); err != nil {
+		t.Fatalf("ListMonthly: %v", err)
+	}
+	got = byKey(list)
+	if debits := got["out debit"]; debits == nil || debits.Count != 1 || debits.Amount != 7 {
+		t.Errorf("debits = %+v, want the completed debit", debits)
+	}
+	if sent := got["out transfer"]; sent == nil || sent.Count != 1 || sent.Amount != 40 || sent.ReversedAmount != 15 {
+		t.Errorf("sent = %+v, want 40 with 15 reversed", sent)
+	}
+	if received := got["in transfer"]; received == nil || received.Amount != 15 {
+		t.Errorf("received = %+v, want the 15 rolled back", received)
+	}
+
+	// Months outside the range are left out
+	if list, _ := aggregates.ListMonthly(ctx, alice.ID, nextMonth, nextMonth.AddDate(0, 1, 0)); len(list) != 0 {
+		t.Errorf("next month's aggregates = %d, want none", len(list))
+	}
+	if list, _ := aggregates.ListMonthly(ctx, alice.ID, month.AddDate(0, -1, 0), month); len(list) != 0 {
+		t.Errorf("last month's aggregates = %d, want none", len(list))
+	}
+}
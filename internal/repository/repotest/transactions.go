@@ -0,0 +1,349 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+func testTransactions(t *testing.T, target Target) {
+	ctx := context.Background()
+	transactions := target.Repos.Transactions
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+	carol := createUser(t, target.Repos, "carol").ID
+
+	start := time.Now().Add(-time.Second)
+	credit := createTransaction(t, target.Repos, domain.TypeCredit, nil, &alice, 100, domain.StatusSuccess)
+	transfer := createTransaction(t, target.Repos, domain.TypeTransfer, &alice, &bob, 40, domain.StatusSuccess)
+	failed := createTransaction(t, target.Repos, domain.TypeDebit, &alice, nil, 500, domain.StatusFailed)
+	pending := createTransaction(t, target.Repos, domain.TypeCredit, nil, &carol, 7.5, domain.StatusPending)
+
+	got, err := transactions.GetByID(ctx, credit.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != string(domain.StatusSuccess) || got.Amount != 100 || got.Currency != "USD" || got.FromUserID != nil || got.ToUserID == nil || *got.ToUserID != alice {
+		t.Errorf("GetByID = %+v, want a successful 100 USD credit to alice", got)
+	}
+	expectTime(t, "created_at", got.CreatedAt, credit.CreatedAt)
+
+	_, err = transactions.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown transaction", err, "transaction not found")
+	expectError(t, "MarkCompleted of unknown transaction", transactions.MarkCompleted(ctx, uuid.New()), "transaction not found")
+	expectError(t, "second MarkCompleted", transactions.MarkCompleted(ctx, credit.ID), "invalid state transition: cannot change from success to success")
+	expectError(t, "MarkCompleted of failed transaction", transactions.MarkCompleted(ctx, failed.ID), "invalid state transition: cannot change from failed to success")
+
+	// Filters and pagination, newest first
+	list, err := transactions.ListForUser(ctx, alice, &domain.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("ListForUser: %v", err)
+	}
+	if len(list) != 3 || list[0].ID != failed.ID || list[1].ID != transfer.ID || list[2].ID != credit.ID {
+		t.Errorf("alice's transactions = %v, want debit, transfer, credit", ids(list))
+	}
+	if list, _ := transactions.ListForUser(ctx, alice, &domain.TransactionFilter{Status: ptr(domain.StatusSuccess), Limit: 1, Offset: 1}); len(list) != 1 || list[0].ID != credit.ID {
+		t.Errorf("second successful transaction of alice = %v, want the credit", ids(list))
+	}
+	if list, _ := transactions.ListForUser(ctx, bob, &domain.TransactionFilter{Type: ptr(domain.TypeTransfer)}); len(list) != 1 || list[0].ID != transfer.ID {
+		t.Errorf("bob's transfers = %v, want the transfer", ids(list))
+	}
+	if list, _ := transactions.List(ctx, &domain.TransactionFilter{Type: ptr(domain.TypeCredit)}); len(list) != 2 || list[0].ID != pending.ID {
+		t.Errorf("credits = %v, want the pending and successful credits", ids(list))
+	}
+	if list, _ := transactions.List(ctx, &domain.TransactionFilter{Since: ptr(time.Now().Add(time.Minute))}); len(list) != 0 {
+		t.Errorf("future transactions = %v, want none", ids(list))
+	}
+
+	counts := []struct {
+		name   string
+		filter *domain.TransactionFilter
+		want   int
+	}{
+		{"all", nil, 4},
+		{"alice", &domain.TransactionFilter{UserID: &alice}, 3},
+		{"pending", &domain.TransactionFilter{Status: ptr(domain.StatusPending)}, 1},
+		{"alice's debits", &domain.TransactionFilter{UserID: &alice, Type: ptr(domain.TypeDebit)}, 1},
+		{"since start", &domain.TransactionFilter{Since: &start}, 4},
+	}
+	for _, c := range counts {
+		if count, err := transactions.Count(ctx, c.filter); err != nil || count != c.want {
+			t.Errorf("%s: Count = %d, %v; want %d", c.name, count, err, c.want)
+		}
+	}
+
+	// Keyset pagination
+	page, err := transactions.ListForUserAfter(ctx, alice, nil, nil, 2)
+	if err != nil || len(page) != 2 || page[0].ID != failed.ID || page[1].ID != transfer.ID {
+		t.Fatalf("first keyset page = %v, %v; want debit then transfer", ids(page), err)
+	}
+	cursor := &domain.TransactionCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+	if page, _ := transactions.ListForUserAfter(ctx, alice, nil, cursor, 2); len(page) != 1 || page[0].ID != credit.ID {
+		t.Errorf("second keyset page = %v, want the credit", ids(page))
+	}
+
+	stats, err := transactions.GetStats(ctx, start, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if stats.TotalCount != 4 || stats.SuccessCount != 2 || stats.FailedCount != 1 || stats.PendingCount != 1 {
+		t.Errorf("stats = %+v, want 4 transactions of which 2 succeeded, 1 failed and 1 is pending", stats)
+	}
+	if len(stats.ByCurrency) != 1 || stats.ByCurrency[0].Currency != "USD" || stats.ByCurrency[0].Count != 2 || stats.ByCurrency[0].Volume != 140 {
+		t.Errorf("volume = %+v, want 2 successful USD transactions worth 140", stats.ByCurrency)
+	}
+
+	active, err := transactions.ListMostActiveUserIDs(ctx, start, 2)
+	if err != nil || len(active) != 2 || active[0] != alice {
+		t.Errorf("most active users = %v, %v; want alice first", active, err)
+	}
+
+	testDescriptionSearch(t, target, alice, bob)
+	testClientReferences(t, target, alice, bob)
+	testOrigins(t, target, alice)
+	testReversals(t, target, transfer)
+	testCategorySpending(t, target)
+}
+
+// testDescriptionSearch checks that descriptions are stored and matched as case-insensitive substrings.
+func testDescriptionSearch(t *testing.T, target Target, alice, bob uuid.UUID) {
+	ctx := context.Background()
+	transactions := target.Repos.Transactions
+
+	rent := &domain.Transaction{FromUserID: &alice, ToUserID: &bob, Amount: 800, Currency: "USD", Type: string(domain.TypeTransfer), Description: "Rent for May"}
+	if err := transactions.CreatePending(ctx, rent); err != nil {
+		t.Fatalf("create described transaction: %v", err)
+	}
+	if got, _ := transactions.GetByID(ctx, rent.ID); got == nil || got.Description != "Rent for May" {
+		t.Errorf("GetByID = %+v, want the description", got)
+	}
+
+	filter := &domain.TransactionFilter{Description: ptr("RENT")}
+	if list, err := transactions.ListForUser(ctx, bob, filter); err != nil || len(list) != 1 || list[0].ID != rent.ID {
+		t.Errorf("bob's rent transactions = %v, %v; want the rent transfer", ids(list), err)
+	}
+	if list, _ := transactions.ListForUserAfter(ctx, alice, filter, nil, 10); len(list) != 1 || list[0].ID != rent.ID {
+		t.Errorf("alice's rent transactions after no cursor = %v, want the rent transfer", ids(list))
+	}
+	if count, err := transactions.Count(ctx, filter); err != nil || count != 1 {
+		t.Errorf("count of rent transactions = %d, %v; want 1", count, err)
+	}
+	if list, _ := transactions.List(ctx, &domain.TransactionFilter{Description: ptr("june")}); len(list) != 0 {
+		t.Errorf("june transactions = %v, want none", ids(list))
+	}
+}
+
+// testClientReferences checks that client references are stored and matched exactly.
+func testClientReferences(t *testing.T, target Target, alice, bob uuid.UUID) {
+	ctx := context.Background()
+	transactions := target.Repos.Transactions
+
+	invoice := &domain.Transaction{FromUserID: &alice, ToUserID: &bob, Amount: 42, Currency: "USD", Type: string(domain.TypeTransfer), ClientReference: "INV-2026-001"}
+	if err := transactions.CreatePending(ctx, invoice); err != nil {
+		t.Fatalf("create referenced transaction: %v", err)
+	}
+	if got, _ := transactions.GetByID(ctx, invoice.ID); got == nil || got.ClientReference != "INV-2026-001" {
+		t.Errorf("GetByID = %+v, want the client reference", got)
+	}
+
+	filter := &domain.TransactionFilter{ClientReference: ptr("INV-2026-001")}
+	if list, err := transactions.ListForUser(ctx, bob, filter); err != nil || len(list) != 1 || list[0].ID != invoice.ID {
+		t.Errorf("bob's INV-2026-001 transactions = %v, %v; want the invoice transfer", ids(list), err)
+	}
+	if list, _ := transactions.ListForUserAfter(ctx, alice, filter, nil, 10); len(list) != 1 || list[0].ID != invoice.ID {
+		t.Errorf("alice's INV-2026-001 transactions after no cursor = %v, want the invoice transfer", ids(list))
+	}
+	if count, err := transactions.Count(ctx, filter); err != nil || count != 1 {
+		t.Errorf("count of INV-2026-001 transactions = %d, %v; want 1", count, err)
+	}
+	if list, _ := transactions.List(ctx, &domain.TransactionFilter{ClientReference: ptr("inv-2026-001")}); len(list) != 0 {
+		t.Errorf("inv-2026-001 transactions = %v, want none", ids(list))
+	}
+}
+
+// testOrigins checks that the scheduled execution a transaction originates from is stored.
+func testOrigins(t *testing.T, target Target, user uuid.UUID) {
+	ctx := context.Background()
+	transactions := target.Repos.Transactions
+
+	origin := &domain.TransactionOrigin{ScheduledTransactionID: uuid.New(), ExecutionID: uuid.New()}
+	scheduled := &domain.Transaction{ToUserID: &user, Amount: 12, Currency: "USD", Type: string(domain.TypeCredit), ClientReference: "scheduled-origin"}
+	scheduled.SetOrigin(origin)
+	if err := transactions.CreatePending(ctx, scheduled); err != nil {
+		t.Fatalf("create scheduled transaction: %v", err)
+	}
+
+	if got, _ := transactions.GetByID(ctx, scheduled.ID); got == nil || got.Origin() == nil || *got.Origin() != *origin {
+		t.Errorf("GetByID origin = %+v, want %+v", got, origin)
+	}
+	filter := &domain.TransactionFilter{ClientReference: ptr("scheduled-origin")}
+	if list, err := transactions.ListForUser(ctx, user, filter); err != nil || len(list) != 1 || list[0].Origin() == nil || *list[0].Origin() != *origin {
+		t.Errorf("ListForUser = %v, %v; want the transaction with its origin", ids(list), err)
+	}
+}
+
+// testReversals checks that reversals accumulate up to the original amount.
+func testReversals(t *testing.T, target Target, original *domain.Transaction) {
+	ctx := context.Background()
+	transactions := target.Repos.Transactions
+
+	reversal := createTransaction(t, target.Repos, domain.TypeTransfer, original.ToUserID, original.FromUserID, original.Amount, domain.StatusSuccess)
+
+	if err := transactions.RecordReversal(ctx, original.ID, reversal.ID, 15); err != nil {
+		t.Fatalf("partial reversal: %v", err)
+	}
+	if got, _ := transactions.GetByID(ctx, original.ID); got == nil || got.ReversedAmount != 15 || got.ReversedByTransactionID != nil {
+		t.Errorf("after partial reversal = %+v, want 15 reversed and no link", got)
+	}
+
+	expectError(t, "excessive reversal", transactions.RecordReversal(ctx, original.ID, reversal.ID, original.Amount), "transaction not found or reversal exceeds original amount")
+	expectError(t, "reversal of unknown transaction", transactions.RecordReversal(ctx, uuid.New(), reversal.ID, 1), "transaction not found or reversal exceeds original amount")
+
+	if err := transactions.RecordReversal(ctx, original.ID, reversal.ID, original.Amount-15); err != nil {
+		t.Fatalf("final reversal: %v", err)
+	}
+	got, _ := transactions.GetByID(ctx, original.ID)
+	if got == nil || got.ReversedAmount != original.Amount || got.ReversedByTransactionID == nil || *got.ReversedByTransactionID != reversal.ID {
+		t.Errorf("after full reversal = %+v, want fully reversed by %s", got, reversal.ID)
+	}
+}
+
+// testConcurrentReversals checks that rollbacks of one transaction racing each other, each
+// recording its reversal and moving the money back in a unit of work, refund at most the
+// original amount.
+func testConcurrentReversals(t *testing.T, target Target) {
+	ctx := context.Background()
+
+	alice := createUser(t, target.Repos, "alice")
+	bob := createUser(t, target.Repos, "bob")
+	if err := target.Repos.Balances.AddAmount(ctx, bob.ID, 100); err != nil {
+		t.Fatalf("fund bob: %v", err)
+	}
+	original := createTransaction(t, target.Repos, domain.TypeTransfer, &alice.ID, &bob.ID, 100, domain.StatusSuccess)
+
+	const rollbacks = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, rollbacks)
+	for range rollbacks {
+		reversal := createTransaction(t, target.Repos, domain.TypeTransfer, &bob.ID, &alice.ID, 60, domain.StatusPending)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repository.RunInTx(ctx, target.UnitOfWork, func(repos *repository.Repositories) error {
+				if err := repos.Transactions.RecordReversal(ctx, original.ID, reversal.ID, 60); err != nil {
+					return err
+				}
+				if err := repos.Balances.AddAmount(ctx, bob.ID, -60); err != nil {
+					return err
+				}
+				return repos.Balances.AddAmount(ctx, alice.ID, 60)
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d of %d concurrent rollbacks of 60 out of 100 succeeded, want 1", succeeded, rollbacks)
+	}
+	if got, _ := target.Repos.Transactions.GetByID(ctx, original.ID); got == nil || got.ReversedAmount != 60 {
+		t.Errorf("original = %+v, want 60 reversed", got)
+	}
+	expectAmount(t, target, alice.ID, 60)
+	expectAmount(t, target, bob.ID, 40)
+
+	// A rollback failing after its reversal is recorded leaves the original as it was
+	reversal := createTransaction(t, target.Repos, domain.TypeTransfer, &bob.ID, &alice.ID, 40, domain.StatusPending)
+	err := repository.RunInTx(ctx, target.UnitOfWork, func(repos *repository.Repositories) error {
+		if err := repos.Transactions.RecordReversal(ctx, original.ID, reversal.ID, 40); err != nil {
+			return err
+		}
+		return repos.Balances.AddAmount(ctx, bob.ID, -41)
+	})
+	if err == nil {
+		t.Fatal("overdrawing rollback succeeded")
+	}
+	if got, _ := target.Repos.Transactions.GetByID(ctx, original.ID); got == nil || got.ReversedAmount != 60 || got.ReversedByTransactionID != nil {
+		t.Errorf("original after failed rollback = %+v, want 60 reversed and no reversal linked", got)
+	}
+	expectAmount(t, target, bob.ID, 40)
+}
+
+// testCategorySpending checks that categories are stored and that the monthly aggregates sum
+// successful outgoing money per category, less reversals.
+func testCategorySpending(t *testing.T, target Target) {
+	ctx := context.Background()
+	transactions := target.Repos.Transactions
+
+	dave := createUser(t, target.Repos, "dave").ID
+	erin := createUser(t, target.Repos, "erin").ID
+
+	month, nextMonth := domain.ActivityRange(time.Now(), 1)
+	spendingOf := func(userID uuid.UUID, from, to time.Time) map[string]float64 {
+		t.Helper()
+		aggregates, err := target.Repos.TransactionAggregates.ListMonthly(ctx, userID, from, to)
+		if err != nil {
+			t.Fatalf("ListMonthly: %v", err)
+		}
+		return domain.SpendingByCategory(aggregates)
+	}
+	spend := func(txType domain.TransactionType, to *uuid.UUID, amount float64, category string, status domain.TransactionStatus) *domain.Transaction {
+		t.Helper()
+		tx := &domain.Transaction{FromUserID: &dave, ToUserID: to, Amount: amount, Currency: "USD", Type: string(txType), Category: category}
+		if err := transactions.CreatePending(ctx, tx); err != nil {
+			t.Fatalf("create %s spending: %v", category, err)
+		}
+		if status == domain.StatusSuccess {
+			if err := transactions.MarkCompleted(ctx, tx.ID); err != nil {
+				t.Fatalf("complete %s spending: %v", category, err)
+			}
+		}
+		return tx
+	}
+
+	groceries := spend(domain.TypeDebit, nil, 30.25, "groceries", domain.StatusSuccess)
+	spend(domain.TypeDebit, nil, 10, "groceries", domain.StatusSuccess)
+	spend(domain.TypeTransfer, &erin, 500, "housing", domain.StatusSuccess)
+	spend(domain.TypeDebit, nil, 99, "dining", domain.StatusPending)
+	spend(domain.TypeDebit, nil, 5, "", domain.StatusSuccess)
+	createTransaction(t, target.Repos, domain.TypeCredit, nil, &dave, 1000, domain.StatusSuccess)
+
+	if got, _ := transactions.GetByID(ctx, groceries.ID); got == nil || got.Category != "groceries" {
+		t.Errorf("GetByID = %+v, want the category", got)
+	}
+
+	reversal := createTransaction(t, target.Repos, domain.TypeCredit, nil, &dave, 10, domain.StatusSuccess)
+	if err := transactions.RecordReversal(ctx, groceries.ID, reversal.ID, 10); err != nil {
+		t.Fatalf("reverse groceries: %v", err)
+	}
+
+	spending := spendingOf(dave, month, nextMonth)
+	want := map[string]float64{"groceries": 30.25, "housing": 500, "": 5}
+	if len(spending) != len(want) {
+		t.Errorf("spending = %v, want %v", spending, want)
+	}
+	for category, amount := range want {
+		if spending[category] != amount {
+			t.Errorf("spending on %q = %v, want %v", category, spending[category], amount)
+		}
+	}
+
+	if spending := spendingOf(erin, month, nextMonth); len(spending) != 0 {
+		t.Errorf("erin's spending = %v, want none; received transfers are not spending", spending)
+	}
+	if spending := spendingOf(dave, nextMonth, nextMonth.AddDate(0, 1, 0)); len(spending) != 0 {
+		t.Errorf("future spending = %v, want none", spending)
+	}
+}
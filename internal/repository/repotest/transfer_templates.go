@@ -0,0 +1,105 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testTransferTemplates(t *testing.T, target Target) {
+	ctx := context.Background()
+	templates := target.Repos.TransferTemplates
+
+	alice := createUser(t, target.Repos, "alice").ID
+	bob := createUser(t, target.Repos, "bob").ID
+	carol := createUser(t, target.Repos, "carol").ID
+
+	rent := newTemplate(alice, bob, "rent")
+	gym := newTemplate(alice, carol, "gym")
+	for _, template := range []*domain.TransferTemplate{rent, gym} {
+		if err := templates.Create(ctx, template); err != nil {
+			t.Fatalf("create %s: %v", template.Name, err)
+		}
+	}
+	expectError(t, "Create with a taken name", templates.Create(ctx, newTemplate(alice, carol, "rent")), "template name already exists")
+	if err := templates.Create(ctx, newTemplate(bob, carol, "rent")); err != nil {
+		t.Errorf("another user's template of the same name: %v", err)
+	}
+
+	got, err := templates.GetByID(ctx, rent.ID)
+	if err != nil || got.UserID != alice || got.PayeeID != bob || got.DefaultAmount != 50 || got.Usage.UseCount != 0 || got.Usage.LastUsedAt != nil {
+		t.Errorf("GetByID = %+v, %v; want alice's unused rent template", got, err)
+	}
+	_, err = templates.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown template", err, "transfer template not found")
+
+	// Ordered by name
+	list, err := templates.ListByUser(ctx, alice, 10, 0)
+	if err != nil || len(list) != 2 || list[0].Name != "gym" || list[1].Name != "rent" {
+		t.Errorf("ListByUser = %d templates, %v; want gym then rent", len(list), err)
+	}
+	if list, _ := templates.ListByUser(ctx, alice, 1, 1); len(list) != 1 || list[0].Name != "rent" {
+		t.Errorf("second page = %d templates, want rent", len(list))
+	}
+
+	gym.Name = "rent"
+	expectError(t, "Update to a taken name", templates.Update(ctx, gym), "template name already exists")
+	gym.Name = "fitness"
+	gym.DefaultAmount = 30
+	if err := templates.Update(ctx, gym); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ := templates.GetByID(ctx, gym.ID); got == nil || got.Name != "fitness" || got.DefaultAmount != 30 {
+		t.Errorf("after update = %+v, want fitness for 30", got)
+	}
+	expectError(t, "Update of unknown template", templates.Update(ctx, newTemplate(alice, bob, "other")), "transfer template not found")
+
+	// Usage keeps the latest use even when uses are recorded out of order
+	later := time.Now()
+	earlier := later.Add(-time.Hour)
+	for _, usedAt := range []time.Time{later, earlier} {
+		if err := templates.RecordUse(ctx, rent.ID, 50, usedAt); err != nil {
+			t.Fatalf("record use: %v", err)
+		}
+	}
+	got, _ = templates.GetByID(ctx, rent.ID)
+	if got == nil || got.Usage.UseCount != 2 || got.Usage.TotalAmount != 100 || got.Usage.LastUsedAt == nil {
+		t.Fatalf("usage = %+v, want 2 uses totalling 100", got)
+	}
+	expectTime(t, "last_used_at", *got.Usage.LastUsedAt, later)
+	expectError(t, "RecordUse of unknown template", templates.RecordUse(ctx, uuid.New(), 1, later), "transfer template not found")
+
+	// Deleting a template unbinds the scheduled transactions made from it
+	st := newScheduled(alice, domain.TypeTransfer, "recurring", time.Now().Add(time.Hour))
+	st.ToUserID = &bob
+	st.RecurrencePattern = ptr("monthly")
+	st.TemplateID = &rent.ID
+	if err := target.Repos.ScheduledTransactions.Create(ctx, st); err != nil {
+		t.Fatalf("create scheduled transaction: %v", err)
+	}
+	if err := templates.Delete(ctx, rent.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expectError(t, "Delete twice", templates.Delete(ctx, rent.ID), "transfer template not found")
+	if got, err := target.Repos.ScheduledTransactions.GetByID(ctx, st.ID); err != nil || got.TemplateID != nil {
+		t.Errorf("scheduled transaction after delete = %+v, %v; want it unbound", got, err)
+	}
+}
+
+// newTemplate returns a template of user for transfers of 50 USD to payee.
+func newTemplate(userID, payeeID uuid.UUID, name string) *domain.TransferTemplate {
+	now := time.Now()
+	return &domain.TransferTemplate{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Name:          name,
+		PayeeID:       payeeID,
+		DefaultAmount: 50,
+		Currency:      "USD",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
@@ -0,0 +1,116 @@
+package repotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testTreasury(t *testing.T, target Target) {
+	ctx := context.Background()
+	treasury := target.Repos.Treasury
+
+	accounts, err := treasury.ListAccounts(ctx)
+	if err != nil || len(accounts) != len(domain.DefaultCurrencies()) {
+		t.Fatalf("ListAccounts = %d accounts, %v; want one per default currency", len(accounts), err)
+	}
+
+	account, err := treasury.GetAccount(ctx, "USD")
+	if err != nil || account.Balance != 0 || account.TotalMinted != 0 || account.MaxCreditAmount != nil {
+		t.Fatalf("USD treasury = %+v, %v; want an empty uncapped account", account, err)
+	}
+	_, err = treasury.GetAccount(ctx, "XYZ")
+	expectError(t, "GetAccount of unknown currency", err, "treasury account not found")
+	_, err = treasury.Record(ctx, newEntry("XYZ", domain.TreasuryMint, 1), false)
+	expectError(t, "Record on unknown currency", err, "treasury account not found")
+
+	if account, err = treasury.Record(ctx, newEntry("USD", domain.TreasuryMint, 1000), false); err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+	if account.Balance != 1000 || account.TotalMinted != 1000 {
+		t.Errorf("after mint = %+v, want 1000 held and minted", account)
+	}
+	pause()
+
+	account, err = treasury.SetCaps(ctx, "USD", ptr(100.0), ptr(150.0), time.Now())
+	if err != nil || account.MaxCreditAmount == nil || *account.MaxCreditAmount != 100 || *account.DailyCreditCap != 150 {
+		t.Fatalf("SetCaps = %+v, %v; want caps of 100 and 150", account, err)
+	}
+	_, err = treasury.SetCaps(ctx, "XYZ", nil, nil, time.Now())
+	expectError(t, "SetCaps of unknown currency", err, "treasury account not found")
+
+	// Issues are checked against the caps only when asked to
+	issues := []struct {
+		amount      float64
+		enforceCaps bool
+		wantErr     bool
+	}{
+		{101, true, true},
+		{100, true, false},
+		{60, true, true},
+		{60, false, false},
+	}
+	for _, issue := range issues {
+		_, err := treasury.Record(ctx, newEntry("USD", domain.TreasuryIssue, issue.amount), issue.enforceCaps)
+		if issue.wantErr && (err == nil || !strings.HasPrefix(err.Error(), "treasury cap exceeded")) {
+			t.Errorf("issue of %.2f: err = %v, want a cap error", issue.amount, err)
+		}
+		if !issue.wantErr && err != nil {
+			t.Errorf("issue of %.2f: %v", issue.amount, err)
+		}
+		pause()
+	}
+
+	if _, err := treasury.Record(ctx, newEntry("USD", domain.TreasuryBurn, 841), false); err == nil {
+		t.Error("burning more than the treasury holds succeeded")
+	}
+	if account, err = treasury.Record(ctx, newEntry("USD", domain.TreasuryBurn, 40), false); err != nil {
+		t.Fatalf("burn: %v", err)
+	}
+	if account.Balance != 800 || account.TotalBurned != 40 || account.Circulating() != 160 {
+		t.Errorf("after burn = %+v, want 800 held, 40 burned and 160 circulating", account)
+	}
+	if stored, _ := treasury.GetAccount(ctx, "USD"); stored == nil || stored.Balance != account.Balance || stored.TotalBurned != account.TotalBurned {
+		t.Errorf("stored account = %+v, want %+v", stored, account)
+	}
+
+	entries, err := treasury.ListEntries(ctx, &domain.TreasuryEntryFilter{Currency: "USD"})
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 4 || entries[0].Kind != domain.TreasuryBurn || entries[0].BalanceAfter != 800 || entries[3].Kind != domain.TreasuryMint {
+		t.Errorf("ledger = %+v, want the burn first and the mint last", entries)
+	}
+	issueKind := domain.TreasuryIssue
+	if entries, _ := treasury.ListEntries(ctx, &domain.TreasuryEntryFilter{Currency: "USD", Kind: &issueKind, Limit: 1, Offset: 1}); len(entries) != 1 || entries[0].Amount != 100 {
+		t.Errorf("first issue = %+v, want the issue of 100", entries)
+	}
+	if entries, _ := treasury.ListEntries(ctx, &domain.TreasuryEntryFilter{Currency: "EUR"}); len(entries) != 0 {
+		t.Errorf("EUR ledger = %d entries, want none", len(entries))
+	}
+
+	alice := createUser(t, target.Repos, "alice")
+	if err := target.Repos.Balances.Upsert(ctx, &domain.Balance{UserID: alice.ID, Amount: 160, Currency: "USD"}); err != nil {
+		t.Fatalf("fund alice: %v", err)
+	}
+	sums, err := treasury.SumUserBalances(ctx)
+	if err != nil || sums["USD"] != 160 {
+		t.Errorf("SumUserBalances = %v, %v; want 160 USD", sums, err)
+	}
+}
+
+// newEntry returns an unattributed ledger entry of amount.
+func newEntry(currency string, kind domain.TreasuryEntryKind, amount float64) *domain.TreasuryEntry {
+	return &domain.TreasuryEntry{
+		ID:        uuid.New(),
+		Currency:  currency,
+		Kind:      kind,
+		Amount:    amount,
+		Reason:    "repotest",
+		CreatedAt: time.Now(),
+	}
+}
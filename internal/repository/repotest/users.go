@@ -0,0 +1,100 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+func testUsers(t *testing.T, target Target) {
+	ctx := context.Background()
+	users := target.Repos.Users
+
+	alice := createUser(t, target.Repos, "alice")
+	pause()
+	bob := createUser(t, target.Repos, "bob")
+	pause()
+	carol := createUser(t, target.Repos, "carol")
+
+	if alice.ID == uuid.Nil || !alice.IsActive || alice.CreatedAt.IsZero() {
+		t.Errorf("created user = %+v, want an active user with an ID and timestamps", alice)
+	}
+
+	duplicate := &domain.User{Username: "alice", Email: "other@example.com", PasswordHash: "!", Role: string(domain.RoleUser)}
+	if err := users.Create(ctx, duplicate); err == nil {
+		t.Error("duplicate username was accepted")
+	}
+	duplicate = &domain.User{Username: "other", Email: "alice@example.com", PasswordHash: "!", Role: string(domain.RoleUser)}
+	if err := users.Create(ctx, duplicate); err == nil {
+		t.Error("duplicate email was accepted")
+	}
+	invalid := &domain.User{Username: "root", Email: "root@example.com", PasswordHash: "!", Role: "root"}
+	if err := users.Create(ctx, invalid); err == nil {
+		t.Error("invalid role was accepted")
+	}
+
+	if got, err := users.GetByID(ctx, alice.ID); err != nil || got.Username != "alice" {
+		t.Errorf("GetByID = %+v, %v", got, err)
+	}
+	if got, err := users.GetByEmail(ctx, "bob@example.com"); err != nil || got.ID != bob.ID {
+		t.Errorf("GetByEmail = %+v, %v", got, err)
+	}
+	if got, err := users.GetByUsername(ctx, "carol"); err != nil || got.ID != carol.ID {
+		t.Errorf("GetByUsername = %+v, %v", got, err)
+	}
+	if alice.AccountNumber != domain.AccountNumberFor(alice.ID) {
+		t.Errorf("account number = %q, want %q", alice.AccountNumber, domain.AccountNumberFor(alice.ID))
+	}
+	if got, err := users.GetByAccountNumber(ctx, carol.AccountNumber); err != nil || got.ID != carol.ID || got.AccountNumber != carol.AccountNumber {
+		t.Errorf("GetByAccountNumber = %+v, %v", got, err)
+	}
+	_, err := users.GetByAccountNumber(ctx, domain.AccountNumberFor(uuid.New()))
+	expectError(t, "GetByAccountNumber of unknown number", err, "user not found")
+	_, err = users.GetByID(ctx, uuid.New())
+	expectError(t, "GetByID of unknown user", err, "user not found")
+
+	alice.Role = string(domain.RoleAdmin)
+	if err := users.Update(ctx, alice); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ := users.GetByID(ctx, alice.ID); got == nil || got.Role != string(domain.RoleAdmin) {
+		t.Errorf("role after update = %+v, want admin", got)
+	}
+	expectError(t, "Update of unknown user", users.Update(ctx, &domain.User{ID: uuid.New(), Username: "x", Email: "x@example.com", Role: string(domain.RoleUser)}), "user not found")
+
+	list, err := users.ListPaginated(ctx, 2, 0)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != carol.ID || list[1].ID != bob.ID {
+		t.Errorf("first page = %v, want carol then bob", usernames(list))
+	}
+	if list, _ := users.ListPaginated(ctx, 2, 2); len(list) != 1 || list[0].ID != alice.ID {
+		t.Errorf("second page = %v, want alice", usernames(list))
+	}
+
+	if err := users.Delete(ctx, bob.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = users.GetByID(ctx, bob.ID)
+	expectError(t, "GetByID of deleted user", err, "user not found")
+	expectError(t, "second Delete", users.Delete(ctx, bob.ID), "user not found or already inactive")
+
+	if all, _ := users.ListAll(ctx); len(all) != 2 {
+		t.Errorf("ListAll = %v, want the 2 active users", usernames(all))
+	}
+	if count, err := users.Count(ctx); err != nil || count != 2 {
+		t.Errorf("Count = %d, %v; want 2", count, err)
+	}
+}
+
+// usernames returns the usernames of users, for readable failures.
+func usernames(users []*domain.User) []string {
+	out := make([]string, len(users))
+	for i, u := range users {
+		out[i] = u.Username
+	}
+	return out
+}
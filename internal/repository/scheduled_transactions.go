@@ -7,17 +7,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // ScheduledTransactionRepository handles scheduled transaction operations
 type ScheduledTransactionRepository struct {
-	pool *pgxpool.Pool
+	pool DBTX
 }
 
 // NewScheduledTransactionRepository creates a new scheduled transaction repository
-func NewScheduledTransactionRepository(pool *pgxpool.Pool) *ScheduledTransactionRepository {
+func NewScheduledTransactionRepository(pool DBTX) *ScheduledTransactionRepository {
 	return &ScheduledTransactionRepository{pool: pool}
 }
 
@@ -28,9 +27,10 @@ func (r *ScheduledTransactionRepository) Create(ctx context.Context, st *domain.
 			id, user_id, transaction_type, amount, currency, description, to_user_id,
 			schedule_type, execute_at, recurrence_pattern, recurrence_end_date,
 			max_occurrences, current_occurrence, status, is_active, created_at, updated_at,
-			next_execution_at
+			next_execution_at, template_id, business_day_rule, calendar_region, adjusted_execute_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+			COALESCE(NULLIF($20, ''), 'none'), NULLIF($21, ''), $22
 		)
 	`
 
@@ -55,6 +55,10 @@ func (r *ScheduledTransactionRepository) Create(ctx context.Context, st *domain.
 		st.CreatedAt,
 		st.UpdatedAt,
 		nextExecution,
+		st.TemplateID,
+		st.BusinessDayRule,
+		st.CalendarRegion,
+		st.AdjustedExecuteAt,
 	)
 
 	if err != nil {
@@ -70,7 +74,9 @@ func (r *ScheduledTransactionRepository) GetByID(ctx context.Context, id uuid.UU
 		SELECT id, user_id, transaction_type, amount, currency, description, to_user_id,
 			   schedule_type, execute_at, recurrence_pattern, recurrence_end_date,
 			   max_occurrences, current_occurrence, status, is_active, created_at,
-			   updated_at, last_executed_at, next_execution_at
+			   updated_at, last_executed_at, next_execution_at, template_id,
+			   failed_attempts, retry_at, funding_alerted_for, business_day_rule,
+			   COALESCE(calendar_region, ''), adjusted_execute_at
 		FROM scheduled_transactions
 		WHERE id = $1
 	`
@@ -104,6 +110,13 @@ func (r *ScheduledTransactionRepository) GetByID(ctx context.Context, id uuid.UU
 		&updatedAt,
 		&lastExecutedAt,
 		&nextExecutionAt,
+		&st.TemplateID,
+		&st.FailedAttempts,
+		&st.RetryAt,
+		&st.FundingAlertedFor,
+		&st.BusinessDayRule,
+		&st.CalendarRegion,
+		&st.AdjustedExecuteAt,
 	)
 
 	if err != nil {
@@ -135,7 +148,9 @@ func (r *ScheduledTransactionRepository) GetByUserID(ctx context.Context, userID
 		SELECT id, user_id, transaction_type, amount, currency, description, to_user_id,
 			   schedule_type, execute_at, recurrence_pattern, recurrence_end_date,
 			   max_occurrences, current_occurrence, status, is_active, created_at,
-			   updated_at, last_executed_at, next_execution_at
+			   updated_at, last_executed_at, next_execution_at, template_id,
+			   failed_attempts, retry_at, funding_alerted_for, business_day_rule,
+			   COALESCE(calendar_region, ''), adjusted_execute_at
 		FROM scheduled_transactions
 		WHERE user_id = $1
 	`
@@ -225,6 +240,13 @@ func (r *ScheduledTransactionRepository) GetByUserID(ctx context.Context, userID
 			&updatedAt,
 			&lastExecutedAt,
 			&nextExecutionAt,
+			&st.TemplateID,
+			&st.FailedAttempts,
+			&st.RetryAt,
+			&st.FundingAlertedFor,
+			&st.BusinessDayRule,
+			&st.CalendarRegion,
+			&st.AdjustedExecuteAt,
 		)
 
 		if err != nil {
@@ -250,77 +272,41 @@ func (r *ScheduledTransactionRepository) GetByUserID(ctx context.Context, userID
 	return transactions, nil
 }
 
-// GetDueForExecution retrieves scheduled transactions that are due for execution
-func (r *ScheduledTransactionRepository) GetDueForExecution(ctx context.Context, limit int) ([]*domain.ScheduledTransaction, error) {
-	// Get due transactions with time buffer to prevent immediate re-processing
-	query := `
-		SELECT id, user_id, transaction_type, amount, currency, description, to_user_id,
+// claimDueQuery leases up to $3 scheduled transactions due as of $4 to $1 for $2 seconds. Leases
+// and the skipping of transactions updated within the last second, which prevents immediate
+// re-processing, run on the database clock.
+const claimDueQuery = `
+		UPDATE scheduled_transactions
+		SET locked_by = $1, locked_until = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM scheduled_transactions
+			WHERE is_active = true
+			  AND status = 'active'
+			  AND COALESCE(adjusted_execute_at, execute_at) <= $4
+			  AND (schedule_type = 'recurring' OR last_executed_at IS NULL)
+			  AND (updated_at IS NULL OR updated_at < NOW() - INTERVAL '1 seconds')
+			  AND (retry_at IS NULL OR retry_at <= $4)
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY COALESCE(adjusted_execute_at, execute_at) ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, transaction_type, amount, currency, description, to_user_id,
 			   schedule_type, execute_at, recurrence_pattern, recurrence_end_date,
 			   max_occurrences, current_occurrence, status, is_active, created_at,
-			   updated_at, last_executed_at, next_execution_at
-		FROM scheduled_transactions
-		WHERE is_active = true
-		  AND status = 'active'
-		  AND execute_at <= NOW()
-		  AND (schedule_type = 'recurring' OR last_executed_at IS NULL)
-		  AND (updated_at IS NULL OR updated_at < NOW() - INTERVAL '1 seconds')
-		ORDER BY execute_at ASC
-		LIMIT $1
-		FOR UPDATE SKIP LOCKED
+			   updated_at, last_executed_at, next_execution_at, template_id,
+			   failed_attempts, retry_at, funding_alerted_for, business_day_rule,
+			   COALESCE(calendar_region, ''), adjusted_execute_at
 	`
 
-	// Debug: Check different conditions
-	totalQuery := `SELECT COUNT(*) FROM scheduled_transactions`
-	var totalCount int
-	if err := r.pool.QueryRow(ctx, totalQuery).Scan(&totalCount); err != nil {
-		totalCount = -1 // Indicate error
-	}
-
-	activeQuery := `SELECT COUNT(*) FROM scheduled_transactions WHERE status = 'active'`
-	var activeCount int
-	if err := r.pool.QueryRow(ctx, activeQuery).Scan(&activeCount); err != nil {
-		activeCount = -1 // Indicate error
-	}
-
-	dueQuery := `SELECT COUNT(*) FROM scheduled_transactions WHERE execute_at <= NOW()`
-	var dueCount int
-	if err := r.pool.QueryRow(ctx, dueQuery).Scan(&dueCount); err != nil {
-		dueCount = -1 // Indicate error
-	}
-
-	bufferQuery := `SELECT COUNT(*) FROM scheduled_transactions WHERE updated_at < NOW() - INTERVAL '1 seconds'`
-	var bufferCount int
-	if err := r.pool.QueryRow(ctx, bufferQuery).Scan(&bufferCount); err != nil {
-		bufferCount = -1 // Indicate error
-	}
-
-	fmt.Printf("DEBUG: Total transactions: %d, Active: %d, Due: %d, After buffer: %d\n",
-		totalCount, activeCount, dueCount, bufferCount)
-
-	// Show recent transactions
-	recentQuery := `
-		SELECT id, status, is_active, execute_at, updated_at, last_executed_at
-		FROM scheduled_transactions
-		ORDER BY updated_at DESC LIMIT 3
-	`
-	rows, _ := r.pool.Query(ctx, recentQuery)
-	for rows.Next() {
-		var id string
-		var status string
-		var isActive bool
-		var executeAt, updatedAt time.Time
-		var lastExecutedAt *time.Time
-		if err := rows.Scan(&id, &status, &isActive, &executeAt, &updatedAt, &lastExecutedAt); err != nil {
-			continue // Skip this row on scan error
-		}
-		fmt.Printf("Recent transaction: ID=%s, Status=%s, Active=%t, ExecuteAt=%v, UpdatedAt=%v\n",
-			id[:8]+"...", status, isActive, executeAt, updatedAt)
-	}
-	rows.Close()
-
-	rows, err := r.pool.Query(ctx, query, limit)
+// ClaimDueForExecution leases scheduled transactions that are due for execution as of now to owner.
+// Claiming is a single UPDATE, so concurrent instances never receive the same row while its
+// lease is held. Rows whose lease has expired (e.g. after a crashed instance) can be claimed again.
+func (r *ScheduledTransactionRepository) ClaimDueForExecution(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) ([]*domain.ScheduledTransaction, error) {
+	rows, err := r.pool.Query(ctx, claimDueQuery, owner, lease.Seconds(), limit, now)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get due transactions: %w", err)
+		return nil, fmt.Errorf("failed to claim due transactions: %w", err)
 	}
 	defer rows.Close()
 
@@ -355,6 +341,13 @@ func (r *ScheduledTransactionRepository) GetDueForExecution(ctx context.Context,
 			&updatedAt,
 			&lastExecutedAt,
 			&nextExecutionAt,
+			&st.TemplateID,
+			&st.FailedAttempts,
+			&st.RetryAt,
+			&st.FundingAlertedFor,
+			&st.BusinessDayRule,
+			&st.CalendarRegion,
+			&st.AdjustedExecuteAt,
 		)
 
 		if err != nil {
@@ -380,14 +373,31 @@ func (r *ScheduledTransactionRepository) GetDueForExecution(ctx context.Context,
 	return transactions, nil
 }
 
+// ReleaseClaim releases owner's lease on a scheduled transaction.
+func (r *ScheduledTransactionRepository) ReleaseClaim(ctx context.Context, id uuid.UUID, owner string) error {
+	query := `
+		UPDATE scheduled_transactions
+		SET locked_by = NULL, locked_until = NULL
+		WHERE id = $1 AND locked_by = $2
+	`
+
+	_, err := r.pool.Exec(ctx, query, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to release scheduled transaction claim: %w", err)
+	}
+
+	return nil
+}
+
 // Update updates a scheduled transaction
 func (r *ScheduledTransactionRepository) Update(ctx context.Context, st *domain.ScheduledTransaction) error {
 	query := `
 		UPDATE scheduled_transactions
 		SET description = $1, status = $2, is_active = $3, execute_at = $4,
 			recurrence_end_date = $5, max_occurrences = $6, updated_at = $7,
-			next_execution_at = $8, last_executed_at = $9, current_occurrence = $10
-		WHERE id = $11
+			next_execution_at = $8, last_executed_at = $9, current_occurrence = $10,
+			failed_attempts = $11, retry_at = $12, adjusted_execute_at = $13
+		WHERE id = $14
 	`
 
 	nextExecution := st.CalculateNextExecution()
@@ -403,6 +413,9 @@ func (r *ScheduledTransactionRepository) Update(ctx context.Context, st *domain.
 		nextExecution,
 		st.LastExecutedAt,
 		st.CurrentOccurrence,
+		st.FailedAttempts,
+		st.RetryAt,
+		st.AdjustedExecuteAt,
 		st.ID,
 	)
 
@@ -413,6 +426,207 @@ func (r *ScheduledTransactionRepository) Update(ctx context.Context, st *domain.
 	return nil
 }
 
+// Reassign moves a scheduled transaction to another owner, clearing its transfer template
+// binding, funding alert and pending retry
+func (r *ScheduledTransactionRepository) Reassign(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	query := `
+		UPDATE scheduled_transactions
+		SET user_id = $2, template_id = NULL, funding_alerted_for = NULL, failed_attempts = 0,
+			retry_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to reassign scheduled transaction: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled transaction not found")
+	}
+
+	return nil
+}
+
+// ListUpcomingOutgoing lists active debits and transfers due after from and no later than until,
+// grouped by user and earliest first
+func (r *ScheduledTransactionRepository) ListUpcomingOutgoing(ctx context.Context, from, until time.Time, limit int) ([]*domain.ScheduledTransaction, error) {
+	query := `
+		SELECT id, user_id, transaction_type, amount, currency, description, to_user_id,
+			   schedule_type, execute_at, recurrence_pattern, recurrence_end_date,
+			   max_occurrences, current_occurrence, status, is_active, created_at,
+			   updated_at, last_executed_at, next_execution_at, template_id,
+			   failed_attempts, retry_at, funding_alerted_for, business_day_rule,
+			   COALESCE(calendar_region, ''), adjusted_execute_at
+		FROM scheduled_transactions
+		WHERE is_active = true
+		  AND status = 'active'
+		  AND transaction_type IN ('debit', 'transfer')
+		  AND COALESCE(adjusted_execute_at, execute_at) > $1
+		  AND COALESCE(adjusted_execute_at, execute_at) <= $2
+		ORDER BY user_id, COALESCE(adjusted_execute_at, execute_at) ASC
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, from, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming scheduled transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanScheduledTransactions(rows)
+}
+
+// List retrieves scheduled transactions of all users matching the filter, newest first
+func (r *ScheduledTransactionRepository) List(ctx context.Context, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransaction, error) {
+	conditions, args := scheduledAdminConditions(filter)
+	query := `
+		SELECT id, user_id, transaction_type, amount, currency, description, to_user_id,
+			   schedule_type, execute_at, recurrence_pattern, recurrence_end_date,
+			   max_occurrences, current_occurrence, status, is_active, created_at,
+			   updated_at, last_executed_at, next_execution_at, template_id,
+			   failed_attempts, retry_at, funding_alerted_for, business_day_rule,
+			   COALESCE(calendar_region, ''), adjusted_execute_at
+		FROM scheduled_transactions
+		WHERE 1=1` + conditions + `
+		ORDER BY created_at DESC, id DESC`
+
+	if filter != nil && filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	if filter != nil && filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanScheduledTransactions(rows)
+}
+
+// CountAll counts scheduled transactions of all users matching the filter, ignoring pagination
+func (r *ScheduledTransactionRepository) CountAll(ctx context.Context, filter *domain.ScheduledTransactionFilter) (int, error) {
+	conditions, args := scheduledAdminConditions(filter)
+	query := `SELECT COUNT(*) FROM scheduled_transactions WHERE 1=1` + conditions
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count scheduled transactions: %w", err)
+	}
+
+	return count, nil
+}
+
+// scheduledAdminConditions builds the WHERE conditions shared by List and CountAll.
+func scheduledAdminConditions(filter *domain.ScheduledTransactionFilter) (string, []interface{}) {
+	var conditions string
+	var args []interface{}
+	if filter == nil {
+		return conditions, args
+	}
+
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conditions += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	if filter.Type != nil {
+		args = append(args, *filter.Type)
+		conditions += fmt.Sprintf(" AND transaction_type = $%d", len(args))
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions += fmt.Sprintf(" AND is_active = $%d", len(args))
+	}
+
+	if filter.FailedSince != nil {
+		args = append(args, *filter.FailedSince)
+		conditions += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM scheduled_transaction_executions e
+			WHERE e.scheduled_transaction_id = scheduled_transactions.id
+			  AND e.status = 'failed' AND e.executed_at >= $%d)`, len(args))
+	}
+
+	return conditions, args
+}
+
+// scanScheduledTransactions scans rows selecting every scheduled transaction column.
+func scanScheduledTransactions(rows pgx.Rows) ([]*domain.ScheduledTransaction, error) {
+	var transactions []*domain.ScheduledTransaction
+	for rows.Next() {
+		var st domain.ScheduledTransaction
+		err := rows.Scan(
+			&st.ID,
+			&st.UserID,
+			&st.TransactionType,
+			&st.Amount,
+			&st.Currency,
+			&st.Description,
+			&st.ToUserID,
+			&st.ScheduleType,
+			&st.ExecuteAt,
+			&st.RecurrencePattern,
+			&st.RecurrenceEndDate,
+			&st.MaxOccurrences,
+			&st.CurrentOccurrence,
+			&st.Status,
+			&st.IsActive,
+			&st.CreatedAt,
+			&st.UpdatedAt,
+			&st.LastExecutedAt,
+			&st.NextExecutionAt,
+			&st.TemplateID,
+			&st.FailedAttempts,
+			&st.RetryAt,
+			&st.FundingAlertedFor,
+			&st.BusinessDayRule,
+			&st.CalendarRegion,
+			&st.AdjustedExecuteAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled transaction: %w", err)
+		}
+
+		transactions = append(transactions, &st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate scheduled transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// MarkFundingAlerted records that the owner was warned about insufficient funds for the
+// occurrence at executeAt. It reports false if they already were, so that concurrent
+// instances send a single alert.
+func (r *ScheduledTransactionRepository) MarkFundingAlerted(ctx context.Context, id uuid.UUID, executeAt time.Time) (bool, error) {
+	query := `
+		UPDATE scheduled_transactions
+		SET funding_alerted_for = $2
+		WHERE id = $1 AND funding_alerted_for IS DISTINCT FROM $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, executeAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark funding alert: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
 // ResetStatus resets the status of a scheduled transaction (used for error recovery)
 func (r *ScheduledTransactionRepository) ResetStatus(ctx context.Context, id uuid.UUID, status string) error {
 	query := `
@@ -519,6 +733,42 @@ func (r *ScheduledTransactionRepository) GetExecutions(ctx context.Context, sche
 	return executions, nil
 }
 
+// GetFailureReasons aggregates failed executions since the given time by error message prefix,
+// most frequent first
+func (r *ScheduledTransactionRepository) GetFailureReasons(ctx context.Context, since time.Time) ([]domain.ScheduledFailureReason, error) {
+	query := `
+		SELECT split_part(error_message, ':', 1) AS reason,
+			   COUNT(*),
+			   COUNT(DISTINCT scheduled_transaction_id),
+			   MAX(executed_at)
+		FROM scheduled_transaction_executions
+		WHERE status = 'failed' AND executed_at >= $1
+		GROUP BY reason
+		ORDER BY COUNT(*) DESC, reason
+	`
+
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate scheduled failures: %w", err)
+	}
+	defer rows.Close()
+
+	reasons := []domain.ScheduledFailureReason{}
+	for rows.Next() {
+		var reason domain.ScheduledFailureReason
+		if err := rows.Scan(&reason.Reason, &reason.Failures, &reason.ScheduledTransactions, &reason.LastFailedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled failure reason: %w", err)
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate scheduled failure reasons: %w", err)
+	}
+
+	return reasons, nil
+}
+
 // Count counts scheduled transactions matching the filter
 func (r *ScheduledTransactionRepository) Count(ctx context.Context, userID uuid.UUID, filter *domain.ScheduledTransactionFilter) (int, error) {
 	query := `SELECT COUNT(*) FROM scheduled_transactions WHERE user_id = $1`
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// searchRepo implements the SearchRepo interface with Postgres full-text search. Documents are
+// parsed with the simple configuration, which lowercases words without stemming them, so that
+// searches behave the same whatever language a description is written in.
+type searchRepo struct {
+	db DBTX
+}
+
+// NewSearchRepo creates a new search repository.
+func NewSearchRepo(db DBTX) SearchRepo {
+	return &searchRepo{db: db}
+}
+
+// userSearchDocuments selects the text searched for each of user $1's transactions, live or
+// archived, and payment requests: their description, client reference or note, and the username
+// of the other party.
+const userSearchDocuments = `
+	SELECT 'transaction' AS kind, t.id, t.created_at,
+	       concat_ws(' ', t.description, t.client_reference, counterparty.username) AS body
+	FROM all_transactions t
+	LEFT JOIN users counterparty
+	       ON counterparty.id = CASE WHEN t.from_user_id = $1 THEN t.to_user_id ELSE t.from_user_id END
+	WHERE t.from_user_id = $1 OR t.to_user_id = $1
+	UNION ALL
+	SELECT 'payment_request', p.id, p.created_at, concat_ws(' ', p.note, counterparty.username)
+	FROM payment_requests p
+	JOIN users counterparty
+	  ON counterparty.id = CASE WHEN p.requester_id = $1 THEN p.payer_id ELSE p.requester_id END
+	WHERE p.requester_id = $1 OR p.payer_id = $1`
+
+// auditSearchDocument is the document searched for each audit log entry: its action and the string
+// values of its details. idx_audit_logs_search indexes this very expression.
+const auditSearchDocument = `(to_tsvector('simple', action) || jsonb_to_tsvector('simple', COALESCE(details, '{}'::jsonb), '["string"]'))`
+
+// searchHeadlineOptions highlights matching words with domain.SearchHighlightStart and
+// domain.SearchHighlightStop in up to two fragments of long texts.
+const searchHeadlineOptions = `'StartSel=<b>, StopSel=</b>, MaxFragments=2'`
+
+// Search retrieves a page of a user's transactions and payment requests matching query, best
+// match first.
+func (r *searchRepo) Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]*domain.SearchHit, error) {
+	sql := `
+		SELECT d.kind, d.id, ts_rank(to_tsvector('simple', d.body), q)::float8 AS rank,
+		       ts_headline('simple', d.body, q, ` + searchHeadlineOptions + `), d.created_at
+		FROM (` + userSearchDocuments + `) d, websearch_to_tsquery('simple', $2) q
+		WHERE to_tsvector('simple', d.body) @@ q
+		ORDER BY rank DESC, d.created_at DESC, d.id ASC
+		LIMIT $3 OFFSET $4`
+
+	return r.executeSearchQuery(ctx, sql, userID, query, limit, offset)
+}
+
+// Count counts a user's transactions and payment requests matching query.
+func (r *searchRepo) Count(ctx context.Context, userID uuid.UUID, query string) (int, error) {
+	sql := `
+		SELECT COUNT(*)
+		FROM (` + userSearchDocuments + `) d, websearch_to_tsquery('simple', $2) q
+		WHERE to_tsvector('simple', d.body) @@ q`
+
+	var count int
+	if err := r.db.QueryRow(ctx, sql, userID, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	return count, nil
+}
+
+// SearchAudit retrieves a page of the audit log entries matching query, best match first.
+func (r *searchRepo) SearchAudit(ctx context.Context, query string, limit, offset int) ([]*domain.SearchHit, error) {
+	sql := `
+		SELECT 'audit_log', id, ts_rank(` + auditSearchDocument + `, q)::float8 AS rank,
+		       ts_headline('simple', action || ' ' || COALESCE(details::text, ''), q, ` + searchHeadlineOptions + `), created_at
+		FROM audit_logs, websearch_to_tsquery('simple', $1) q
+		WHERE ` + auditSearchDocument + ` @@ q
+		ORDER BY rank DESC, created_at DESC, id ASC
+		LIMIT $2 OFFSET $3`
+
+	return r.executeSearchQuery(ctx, sql, query, limit, offset)
+}
+
+// CountAudit counts the audit log entries matching query.
+func (r *searchRepo) CountAudit(ctx context.Context, query string) (int, error) {
+	sql := `
+		SELECT COUNT(*)
+		FROM audit_logs, websearch_to_tsquery('simple', $1) q
+		WHERE ` + auditSearchDocument + ` @@ q`
+
+	var count int
+	if err := r.db.QueryRow(ctx, sql, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit search results: %w", err)
+	}
+
+	return count, nil
+}
+
+// executeSearchQuery runs a query selecting kind, id, rank, snippet and created_at of search hits.
+func (r *searchRepo) executeSearchQuery(ctx context.Context, sql string, args ...interface{}) ([]*domain.SearchHit, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		var kind string
+		if err := rows.Scan(&kind, &hit.ID, &hit.Rank, &hit.Snippet, &hit.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+		hit.Kind = domain.SearchHitKind(kind)
+		hits = append(hits, &hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate search hits: %w", err)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// securityEventsRepo implements the SecurityEventsRepo interface.
+type securityEventsRepo struct {
+	db DBTX
+}
+
+// NewSecurityEventsRepo creates a new security events repository.
+func NewSecurityEventsRepo(db DBTX) SecurityEventsRepo {
+	return &securityEventsRepo{db: db}
+}
+
+// Record appends a security event.
+func (r *securityEventsRepo) Record(ctx context.Context, event *domain.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (id, user_id, email, type, ip, user_agent, session_id, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(ctx, query,
+		event.ID,
+		event.UserID,
+		event.Email,
+		event.Type,
+		event.IP,
+		event.UserAgent,
+		event.SessionID,
+		event.Reason,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record security event: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves security events, newest first.
+func (r *securityEventsRepo) List(ctx context.Context, filter *domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	query := `
+		SELECT id, user_id, email, type, ip, user_agent, session_id, reason, created_at
+		FROM security_events`
+
+	var args []interface{}
+	var conditions []string
+	argIndex := 1
+
+	if filter.UserID != nil {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
+		args = append(args, *filter.UserID)
+		argIndex++
+	}
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("type = $%d", argIndex))
+		args = append(args, *filter.Type)
+		argIndex++
+	}
+
+	if filter.Since != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *filter.Since)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id"
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.SecurityEvent
+	for rows.Next() {
+		var event domain.SecurityEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Email,
+			&event.Type,
+			&event.IP,
+			&event.UserAgent,
+			&event.SessionID,
+			&event.Reason,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate security events: %w", err)
+	}
+
+	return events, nil
+}
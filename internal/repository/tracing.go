@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	dbTracerName    = "postgres"
+	redisTracerName = "redis"
+)
+
+// queryTracer is a pgx.QueryTracer that records a span for every query, including
+// the BEGIN/COMMIT/ROLLBACK statements issued for database transactions. As a
+// pgxpool.AcquireTracer it also records how long queries wait for a pool connection.
+type queryTracer struct {
+	metrics *utils.MetricsCollector // Optional
+}
+
+// acquireStartKey is the context key for the time a pool connection was requested.
+type acquireStartKey struct{}
+
+// TraceAcquireStart notes when a connection was requested from the pool.
+func (t queryTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	if t.metrics == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+// TraceAcquireEnd records how long a successful acquire waited for a connection.
+func (t queryTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	start, ok := ctx.Value(acquireStartKey{}).(time.Time)
+	if !ok || data.Err != nil {
+		return
+	}
+	t.metrics.ObserveDBPoolAcquire(time.Since(start))
+}
+
+// TraceQueryStart starts a span named after the statement's operation and table.
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation, table := statementName(data.SQL)
+
+	name := "db " + operation
+	if table != "" {
+		name += " " + table
+	}
+
+	ctx, _ = utils.GetTracer(dbTracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.sql.table", table),
+			attribute.String("db.statement", data.SQL), // Parameterized, so no values are recorded
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd ends the span started by TraceQueryStart.
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	utils.EndSpan(span, data.Err)
+}
+
+// statementName returns the upper-cased SQL operation and, where it can be determined,
+// the primary table a statement targets (e.g. "UPDATE", "balances").
+func statementName(sql string) (operation string, table string) {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "UNKNOWN", ""
+	}
+
+	operation = strings.ToUpper(fields[0])
+
+	// The table follows the first keyword that introduces it
+	var marker string
+	switch operation {
+	case "SELECT", "DELETE", "WITH":
+		marker = "FROM"
+	case "INSERT":
+		marker = "INTO"
+	case "UPDATE":
+		return operation, tableName(fields, 1)
+	default:
+		return operation, ""
+	}
+
+	for i, field := range fields {
+		if strings.ToUpper(field) == marker {
+			return operation, tableName(fields, i+1)
+		}
+	}
+	return operation, ""
+}
+
+// tableName returns the identifier at fields[i], stripped of punctuation, or "" if it is not a plain table name.
+func tableName(fields []string, i int) string {
+	if i >= len(fields) {
+		return ""
+	}
+	name := strings.Trim(fields[i], `"(),;`)
+	if name == "" || strings.HasPrefix(fields[i], "(") {
+		return "" // Subquery
+	}
+	return name
+}
+
+// redisTracingHook is a go-redis hook that records a span for every command and pipeline.
+type redisTracingHook struct{}
+
+// DialHook passes dials through untraced.
+func (redisTracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook records a span for a single command. Keys are not recorded since they contain user IDs.
+func (redisTracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		operation := strings.ToUpper(cmd.Name())
+		ctx, span := utils.GetTracer(redisTracerName).Start(ctx, "redis "+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", operation),
+			),
+		)
+
+		err := next(ctx, cmd)
+		utils.EndSpan(span, redisSpanError(err))
+		return err
+	}
+}
+
+// ProcessPipelineHook records a single span for a pipeline of commands.
+func (redisTracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := utils.GetTracer(redisTracerName).Start(ctx, "redis pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", "PIPELINE"),
+				attribute.Int("db.redis.num_cmd", len(cmds)),
+			),
+		)
+
+		err := next(ctx, cmds)
+		utils.EndSpan(span, redisSpanError(err))
+		return err
+	}
+}
+
+// redisSpanError returns err unless it is a cache miss, which is not a failure.
+func redisSpanError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
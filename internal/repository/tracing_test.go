@@ -0,0 +1,27 @@
+package repository
+
+import "testing"
+
+func TestStatementName(t *testing.T) {
+	tests := []struct {
+		sql       string
+		operation string
+		table     string
+	}{
+		{"SELECT id, amount FROM transactions WHERE id = $1", "SELECT", "transactions"},
+		{"\n\t\tINSERT INTO balances (user_id, amount)\n\t\tVALUES ($1, $2)", "INSERT", "balances"},
+		{"UPDATE scheduled_transactions SET locked_by = NULL WHERE id = $1", "UPDATE", "scheduled_transactions"},
+		{"delete from users where id = $1", "DELETE", "users"},
+		{"SELECT COUNT(*) FROM (SELECT id FROM users) sub", "SELECT", ""},
+		{"SELECT pg_try_advisory_lock($1)", "SELECT", ""},
+		{"begin", "BEGIN", ""},
+		{"", "UNKNOWN", ""},
+	}
+
+	for _, tt := range tests {
+		operation, table := statementName(tt.sql)
+		if operation != tt.operation || table != tt.table {
+			t.Errorf("statementName(%q) = (%q, %q), want (%q, %q)", tt.sql, operation, table, tt.operation, tt.table)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// transactionAggregatesRepo implements the TransactionAggregatesRepo interface. The
+// transaction_monthly_aggregates table is maintained by a trigger on transactions, so every way
+// of completing or rolling back a transaction keeps it current, and archiving a transaction,
+// which deletes it from transactions, leaves its totals in place.
+type transactionAggregatesRepo struct {
+	db DBTX
+}
+
+// NewTransactionAggregatesRepo creates a new transaction aggregates repository.
+func NewTransactionAggregatesRepo(db DBTX) TransactionAggregatesRepo {
+	return &transactionAggregatesRepo{db: db}
+}
+
+// ListMonthly retrieves a user's aggregates of the UTC months starting in [from, to), oldest month
+// first.
+func (r *transactionAggregatesRepo) ListMonthly(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.TransactionAggregate, error) {
+	query := `
+		SELECT user_id, to_char(month, 'YYYY-MM'), direction, type, category, currency, count, amount, reversed_amount
+		FROM transaction_monthly_aggregates
+		WHERE user_id = $1 AND month >= ($2::timestamptz AT TIME ZONE 'UTC')::date
+		  AND month < ($3::timestamptz AT TIME ZONE 'UTC')::date AND count > 0
+		ORDER BY month ASC, direction ASC, type ASC, category ASC, currency ASC`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transaction aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	var aggregates []*domain.TransactionAggregate
+	for rows.Next() {
+		var aggregate domain.TransactionAggregate
+		var direction string
+		err := rows.Scan(
+			&aggregate.UserID,
+			&aggregate.Month,
+			&direction,
+			&aggregate.Type,
+			&aggregate.Category,
+			&aggregate.Currency,
+			&aggregate.Count,
+			&aggregate.Amount,
+			&aggregate.ReversedAmount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction aggregate: %w", err)
+		}
+		aggregate.Direction = domain.TransactionDirection(direction)
+		aggregates = append(aggregates, &aggregate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transaction aggregates: %w", err)
+	}
+
+	return aggregates, nil
+}
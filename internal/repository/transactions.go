@@ -7,25 +7,24 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // transactionsRepo implements the TransactionsRepo interface.
 type transactionsRepo struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
 // NewTransactionsRepo creates a new transactions repository.
-func NewTransactionsRepo(db *pgxpool.Pool) TransactionsRepo {
+func NewTransactionsRepo(db DBTX) TransactionsRepo {
 	return &transactionsRepo{db: db}
 }
 
 // CreatePending creates a new transaction with pending status.
 func (r *transactionsRepo) CreatePending(ctx context.Context, tx *domain.Transaction) error {
 	query := `
-		INSERT INTO transactions (id, from_user_id, to_user_id, amount, type, status, created_at, currency)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO transactions (id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 
 	if tx.ID == uuid.Nil {
 		tx.ID = uuid.New()
@@ -33,7 +32,7 @@ func (r *transactionsRepo) CreatePending(ctx context.Context, tx *domain.Transac
 	tx.Status = string(domain.StatusPending)
 	tx.CreatedAt = time.Now()
 
-	_, err := r.db.Exec(ctx, query, tx.ID, tx.FromUserID, tx.ToUserID, tx.Amount, tx.Type, tx.Status, tx.CreatedAt, tx.Currency)
+	_, err := r.db.Exec(ctx, query, tx.ID, tx.FromUserID, tx.ToUserID, tx.Amount, tx.Type, tx.Status, tx.CreatedAt, tx.Currency, tx.ReversalOfTransactionID, tx.Description, tx.Category, tx.ClientReference, tx.ScheduledTransactionID, tx.ScheduledExecutionID)
 	if err != nil {
 		return fmt.Errorf("failed to create pending transaction: %w", err)
 	}
@@ -46,6 +45,29 @@ func (r *transactionsRepo) MarkCompleted(ctx context.Context, id uuid.UUID) erro
 	return r.updateTransactionStatus(ctx, id, string(domain.StatusPending), string(domain.StatusSuccess))
 }
 
+// CreateManyCompleted creates successful transactions in bulk with a single COPY.
+func (r *transactionsRepo) CreateManyCompleted(ctx context.Context, txs []*domain.Transaction) error {
+	now := time.Now()
+	for _, tx := range txs {
+		if tx.ID == uuid.Nil {
+			tx.ID = uuid.New()
+		}
+		tx.Status = string(domain.StatusSuccess)
+		tx.CreatedAt = now
+	}
+
+	columns := []string{"id", "from_user_id", "to_user_id", "amount", "type", "status", "created_at", "currency", "reversal_of_transaction_id", "description", "category", "client_reference", "scheduled_transaction_id", "scheduled_execution_id"}
+	_, err := r.db.CopyFrom(ctx, pgx.Identifier{"transactions"}, columns, pgx.CopyFromSlice(len(txs), func(i int) ([]any, error) {
+		tx := txs[i]
+		return []any{tx.ID, tx.FromUserID, tx.ToUserID, tx.Amount, tx.Type, tx.Status, tx.CreatedAt, tx.Currency, tx.ReversalOfTransactionID, tx.Description, tx.Category, tx.ClientReference, tx.ScheduledTransactionID, tx.ScheduledExecutionID}, nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to create completed transactions: %w", err)
+	}
+
+	return nil
+}
+
 // MarkFailed marks a transaction as failed.
 func (r *transactionsRepo) MarkFailed(ctx context.Context, id uuid.UUID) error {
 	return r.updateTransactionStatus(ctx, id, string(domain.StatusPending), string(domain.StatusFailed))
@@ -82,11 +104,11 @@ func (r *transactionsRepo) updateTransactionStatus(ctx context.Context, id uuid.
 	return nil
 }
 
-// GetByID retrieves a transaction by ID.
+// GetByID retrieves a transaction by ID, looking in the archive when it is no longer live.
 func (r *transactionsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
 	query := `
-		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency
-		FROM transactions
+		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id
+		FROM all_transactions
 		WHERE id = $1`
 
 	var tx domain.Transaction
@@ -99,6 +121,14 @@ func (r *transactionsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.T
 		&tx.Status,
 		&tx.CreatedAt,
 		&tx.Currency,
+		&tx.ReversalOfTransactionID,
+		&tx.ReversedByTransactionID,
+		&tx.ReversedAmount,
+		&tx.Description,
+		&tx.Category,
+		&tx.ClientReference,
+		&tx.ScheduledTransactionID,
+		&tx.ScheduledExecutionID,
 	)
 
 	if err != nil {
@@ -111,13 +141,41 @@ func (r *transactionsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.T
 	return &tx, nil
 }
 
-// ListForUser retrieves transactions for a specific user.
-func (r *transactionsRepo) ListForUser(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
-	baseQuery := `
-		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency
+// RecordReversal adds a rollback amount to the original transaction, linking the rollback
+// once the original is fully reversed. Fails if the cumulative amount would exceed the original.
+func (r *transactionsRepo) RecordReversal(ctx context.Context, originalID uuid.UUID, reversalID uuid.UUID, amount float64) error {
+	query := `
+		UPDATE transactions
+		SET reversed_amount = reversed_amount + $3,
+		    reversed_by_transaction_id = CASE
+		        WHEN reversed_amount + $3 >= amount THEN $2
+		        ELSE reversed_by_transaction_id
+		    END
+		WHERE id = $1 AND reversed_amount + $3 <= amount`
+
+	result, err := r.db.Exec(ctx, query, originalID, reversalID, amount)
+	if err != nil {
+		return fmt.Errorf("failed to record reversal: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("transaction not found or reversal exceeds original amount")
+	}
+
+	return nil
+}
+
+// listForUserQuery selects a user's transactions, before ListForUser adds filters, ordering and
+// pagination.
+const listForUserQuery = `
+		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id
 		FROM transactions
 		WHERE (from_user_id = $1 OR to_user_id = $1)`
 
+// ListForUser retrieves transactions for a specific user.
+func (r *transactionsRepo) ListForUser(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	baseQuery := listForUserQuery
+
 	args := []interface{}{userID}
 	conditions := []string{}
 	argIndex := 2
@@ -141,6 +199,18 @@ func (r *transactionsRepo) ListForUser(ctx context.Context, userID uuid.UUID, fi
 			args = append(args, *filter.Since)
 			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
 		}
+
+		if filter.Description != nil {
+			conditions = append(conditions, fmt.Sprintf("strpos(lower(description), lower($%d)) > 0", argIndex))
+			args = append(args, *filter.Description)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.ClientReference != nil {
+			conditions = append(conditions, fmt.Sprintf("client_reference = $%d", argIndex))
+			args = append(args, *filter.ClientReference)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
 	}
 
 	// Build final query
@@ -171,10 +241,66 @@ func (r *transactionsRepo) ListForUser(ctx context.Context, userID uuid.UUID, fi
 	return r.executeTransactionQuery(ctx, query, args...)
 }
 
+// ListForUserAfter retrieves up to limit transactions for a user, newest first, that come after cursor.
+// Keyset pagination on (created_at, id) keeps each page cheap however deep into the history it is.
+func (r *transactionsRepo) ListForUserAfter(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter, cursor *domain.TransactionCursor, limit int) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id
+		FROM transactions
+		WHERE (from_user_id = $1 OR to_user_id = $1)`
+
+	args := []interface{}{userID}
+	argIndex := 2
+
+	// Apply filters
+	if filter != nil {
+		if filter.Type != nil {
+			query += fmt.Sprintf(" AND type = $%d", argIndex)
+			args = append(args, string(*filter.Type))
+			argIndex++
+		}
+
+		if filter.Status != nil {
+			query += fmt.Sprintf(" AND status = $%d", argIndex)
+			args = append(args, string(*filter.Status))
+			argIndex++
+		}
+
+		if filter.Since != nil {
+			query += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+			args = append(args, *filter.Since)
+			argIndex++
+		}
+
+		if filter.Description != nil {
+			query += fmt.Sprintf(" AND strpos(lower(description), lower($%d)) > 0", argIndex)
+			args = append(args, *filter.Description)
+			argIndex++
+		}
+
+		if filter.ClientReference != nil {
+			query += fmt.Sprintf(" AND client_reference = $%d", argIndex)
+			args = append(args, *filter.ClientReference)
+			argIndex++
+		}
+	}
+
+	if cursor != nil {
+		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", argIndex, argIndex+1)
+		args = append(args, cursor.CreatedAt, cursor.ID)
+		argIndex += 2
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", argIndex)
+	args = append(args, limit)
+
+	return r.executeTransactionQuery(ctx, query, args...)
+}
+
 // List retrieves transactions with filtering.
 func (r *transactionsRepo) List(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
 	baseQuery := `
-		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency
+		SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id
 		FROM transactions
 		WHERE 1=1`
 
@@ -207,6 +333,18 @@ func (r *transactionsRepo) List(ctx context.Context, filter *domain.TransactionF
 			args = append(args, *filter.Since)
 			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
 		}
+
+		if filter.Description != nil {
+			conditions = append(conditions, fmt.Sprintf("strpos(lower(description), lower($%d)) > 0", argIndex))
+			args = append(args, *filter.Description)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.ClientReference != nil {
+			conditions = append(conditions, fmt.Sprintf("client_reference = $%d", argIndex))
+			args = append(args, *filter.ClientReference)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
 	}
 
 	// Build final query
@@ -267,6 +405,18 @@ func (r *transactionsRepo) Count(ctx context.Context, filter *domain.Transaction
 			args = append(args, *filter.Since)
 			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
 		}
+
+		if filter.Description != nil {
+			conditions = append(conditions, fmt.Sprintf("strpos(lower(description), lower($%d)) > 0", argIndex))
+			args = append(args, *filter.Description)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
+
+		if filter.ClientReference != nil {
+			conditions = append(conditions, fmt.Sprintf("client_reference = $%d", argIndex))
+			args = append(args, *filter.ClientReference)
+			argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+		}
 	}
 
 	// Build final query
@@ -284,6 +434,138 @@ func (r *transactionsRepo) Count(ctx context.Context, filter *domain.Transaction
 	return count, nil
 }
 
+// GetStats computes aggregate transaction statistics for transactions created in [since, until).
+func (r *transactionsRepo) GetStats(ctx context.Context, since, until time.Time) (*domain.TransactionStats, error) {
+	totalsQuery := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'success'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY amount), 0)
+		FROM transactions
+		WHERE created_at >= $1 AND created_at < $2`
+
+	stats := &domain.TransactionStats{
+		Since:      since,
+		Until:      until,
+		ByCurrency: []domain.CurrencyVolume{},
+	}
+
+	err := r.db.QueryRow(ctx, totalsQuery, since, until).Scan(
+		&stats.TotalCount,
+		&stats.SuccessCount,
+		&stats.FailedCount,
+		&stats.PendingCount,
+		&stats.P95Amount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute transaction totals: %w", err)
+	}
+
+	volumeQuery := `
+		SELECT
+			currency,
+			COUNT(*),
+			COALESCE(SUM(amount), 0),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY amount), 0)
+		FROM transactions
+		WHERE created_at >= $1 AND created_at < $2 AND status = 'success'
+		GROUP BY currency
+		ORDER BY currency`
+
+	rows, err := r.db.Query(ctx, volumeQuery, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute transaction volume: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var volume domain.CurrencyVolume
+		if err := rows.Scan(&volume.Currency, &volume.Count, &volume.Volume, &volume.P95Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction volume: %w", err)
+		}
+		stats.ByCurrency = append(stats.ByCurrency, volume)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transaction volume: %w", err)
+	}
+
+	stats.ComputeRates()
+
+	return stats, nil
+}
+
+// ListMostActiveUserIDs returns the IDs of users with the most transactions created since the given time.
+// Both sides of a transfer count towards a user's activity.
+func (r *transactionsRepo) ListMostActiveUserIDs(ctx context.Context, since time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT user_id
+		FROM (
+			SELECT from_user_id AS user_id FROM transactions WHERE created_at >= $1 AND from_user_id IS NOT NULL
+			UNION ALL
+			SELECT to_user_id AS user_id FROM transactions WHERE created_at >= $1 AND to_user_id IS NOT NULL
+		) activity
+		GROUP BY user_id
+		ORDER BY COUNT(*) DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list most active users: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate most active users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// ListRecentCounterparties summarizes a user's successful outgoing transfers per recipient, most
+// recent first.
+func (r *transactionsRepo) ListRecentCounterparties(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Counterparty, error) {
+	query := `
+		SELECT to_user_id, COUNT(*), MAX(created_at)
+		FROM transactions
+		WHERE from_user_id = $1 AND type = 'transfer' AND status = 'success' AND to_user_id IS NOT NULL
+		GROUP BY to_user_id
+		ORDER BY MAX(created_at) DESC, to_user_id
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent counterparties: %w", err)
+	}
+	defer rows.Close()
+
+	var counterparties []*domain.Counterparty
+	for rows.Next() {
+		var counterparty domain.Counterparty
+		if err := rows.Scan(&counterparty.UserID, &counterparty.TransferCount, &counterparty.LastTransferAt); err != nil {
+			return nil, fmt.Errorf("failed to scan counterparty: %w", err)
+		}
+		counterparties = append(counterparties, &counterparty)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate recent counterparties: %w", err)
+	}
+
+	return counterparties, nil
+}
+
 // executeTransactionQuery executes a transaction query and returns results.
 func (r *transactionsRepo) executeTransactionQuery(ctx context.Context, query string, args ...interface{}) ([]*domain.Transaction, error) {
 	rows, err := r.db.Query(ctx, query, args...)
@@ -304,6 +586,14 @@ func (r *transactionsRepo) executeTransactionQuery(ctx context.Context, query st
 			&tx.Status,
 			&tx.CreatedAt,
 			&tx.Currency,
+			&tx.ReversalOfTransactionID,
+			&tx.ReversedByTransactionID,
+			&tx.ReversedAmount,
+			&tx.Description,
+			&tx.Category,
+			&tx.ClientReference,
+			&tx.ScheduledTransactionID,
+			&tx.ScheduledExecutionID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// transferTemplatesRepo implements the TransferTemplatesRepo interface.
+type transferTemplatesRepo struct {
+	db DBTX
+}
+
+// NewTransferTemplatesRepo creates a new transfer templates repository.
+func NewTransferTemplatesRepo(db DBTX) TransferTemplatesRepo {
+	return &transferTemplatesRepo{db: db}
+}
+
+// Create creates a new transfer template.
+func (r *transferTemplatesRepo) Create(ctx context.Context, template *domain.TransferTemplate) error {
+	query := `
+		INSERT INTO transfer_templates (id, user_id, name, payee_id, default_amount, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.Exec(ctx, query,
+		template.ID,
+		template.UserID,
+		template.Name,
+		template.PayeeID,
+		template.DefaultAmount,
+		template.Currency,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("template name already exists")
+		}
+		return fmt.Errorf("failed to create transfer template: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a transfer template by ID.
+func (r *transferTemplatesRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferTemplate, error) {
+	query := `
+		SELECT id, user_id, name, payee_id, default_amount, currency,
+		       use_count, total_amount, last_used_at, created_at, updated_at
+		FROM transfer_templates
+		WHERE id = $1`
+
+	template, err := scanTransferTemplate(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("transfer template not found")
+		}
+		return nil, fmt.Errorf("failed to get transfer template by ID: %w", err)
+	}
+
+	return template, nil
+}
+
+// ListByUser retrieves a user's transfer templates ordered by name.
+func (r *transferTemplatesRepo) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.TransferTemplate, error) {
+	query := `
+		SELECT id, user_id, name, payee_id, default_amount, currency,
+		       use_count, total_amount, last_used_at, created_at, updated_at
+		FROM transfer_templates
+		WHERE user_id = $1
+		ORDER BY name ASC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*domain.TransferTemplate
+	for rows.Next() {
+		template, err := scanTransferTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transfer templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update updates the name and transfer details of a template.
+func (r *transferTemplatesRepo) Update(ctx context.Context, template *domain.TransferTemplate) error {
+	query := `
+		UPDATE transfer_templates
+		SET name = $2, payee_id = $3, default_amount = $4, currency = $5, updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query,
+		template.ID,
+		template.Name,
+		template.PayeeID,
+		template.DefaultAmount,
+		template.Currency,
+		template.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("template name already exists")
+		}
+		return fmt.Errorf("failed to update transfer template: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("transfer template not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a transfer template. Scheduled transactions bound to it are unbound.
+func (r *transferTemplatesRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM transfer_templates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer template: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("transfer template not found")
+	}
+
+	return nil
+}
+
+// RecordUse adds a transfer of amount made at usedAt to the template's usage statistics.
+func (r *transferTemplatesRepo) RecordUse(ctx context.Context, id uuid.UUID, amount float64, usedAt time.Time) error {
+	query := `
+		UPDATE transfer_templates
+		SET use_count = use_count + 1, total_amount = total_amount + $2,
+		    last_used_at = GREATEST(COALESCE(last_used_at, $3), $3)
+		WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id, amount, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record transfer template use: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("transfer template not found")
+	}
+
+	return nil
+}
+
+// scanTransferTemplate scans a single transfer template row.
+func scanTransferTemplate(row pgx.Row) (*domain.TransferTemplate, error) {
+	var template domain.TransferTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.PayeeID,
+		&template.DefaultAmount,
+		&template.Currency,
+		&template.Usage.UseCount,
+		&template.Usage.TotalAmount,
+		&template.Usage.LastUsedAt,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
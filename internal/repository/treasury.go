@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// treasuryRepo implements the TreasuryRepo interface.
+type treasuryRepo struct {
+	db DBTX
+}
+
+// NewTreasuryRepo creates a new treasury repository.
+func NewTreasuryRepo(db DBTX) TreasuryRepo {
+	return &treasuryRepo{db: db}
+}
+
+const treasuryAccountColumns = `id, currency, balance, total_minted, total_burned, max_credit_amount, daily_credit_cap, created_at, updated_at`
+
+// ListAccounts retrieves every treasury account ordered by currency.
+func (r *treasuryRepo) ListAccounts(ctx context.Context) ([]*domain.TreasuryAccount, error) {
+	query := `SELECT ` + treasuryAccountColumns + ` FROM treasury_accounts ORDER BY currency ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list treasury accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*domain.TreasuryAccount
+	for rows.Next() {
+		account, err := scanTreasuryAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan treasury account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate treasury accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// GetAccount retrieves the treasury account of a currency.
+func (r *treasuryRepo) GetAccount(ctx context.Context, currency string) (*domain.TreasuryAccount, error) {
+	query := `SELECT ` + treasuryAccountColumns + ` FROM treasury_accounts WHERE currency = $1`
+
+	account, err := scanTreasuryAccount(r.db.QueryRow(ctx, query, currency))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("treasury account not found")
+		}
+		return nil, fmt.Errorf("failed to get treasury account: %w", err)
+	}
+
+	return account, nil
+}
+
+// SetCaps replaces the credit caps of a currency's treasury. A nil cap removes it.
+func (r *treasuryRepo) SetCaps(ctx context.Context, currency string, maxCreditAmount *float64, dailyCreditCap *float64, at time.Time) (*domain.TreasuryAccount, error) {
+	query := `
+		UPDATE treasury_accounts
+		SET max_credit_amount = $2, daily_credit_cap = $3, updated_at = $4
+		WHERE currency = $1
+		RETURNING ` + treasuryAccountColumns
+
+	account, err := scanTreasuryAccount(r.db.QueryRow(ctx, query, currency, maxCreditAmount, dailyCreditCap, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("treasury account not found")
+		}
+		return nil, fmt.Errorf("failed to set treasury caps: %w", err)
+	}
+
+	return account, nil
+}
+
+// Record applies a ledger entry to its treasury and appends it to the ledger in one database
+// transaction. The account row is locked, so concurrent credits cannot overdraw the treasury or
+// exceed its caps together. When enforceCaps is set, issues are checked against the credit caps.
+func (r *treasuryRepo) Record(ctx context.Context, entry *domain.TreasuryEntry, enforceCaps bool) (*domain.TreasuryAccount, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Rollback error is typically safe to ignore
+	}()
+
+	query := `SELECT ` + treasuryAccountColumns + ` FROM treasury_accounts WHERE currency = $1 FOR UPDATE`
+	account, err := scanTreasuryAccount(tx.QueryRow(ctx, query, entry.Currency))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("treasury account not found")
+		}
+		return nil, fmt.Errorf("failed to lock treasury account: %w", err)
+	}
+
+	if enforceCaps && entry.Kind == domain.TreasuryIssue {
+		issuedToday, err := issuedSince(ctx, tx, entry.Currency, entry.CreatedAt.UTC().Truncate(24*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		if err := account.CheckCredit(entry.Amount, issuedToday); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := account.Apply(entry.Kind, entry.Amount); err != nil {
+		return nil, err
+	}
+	account.UpdatedAt = entry.CreatedAt
+	entry.BalanceAfter = account.Balance
+
+	_, err = tx.Exec(ctx, `
+		UPDATE treasury_accounts
+		SET balance = $2, total_minted = $3, total_burned = $4, updated_at = $5
+		WHERE currency = $1`,
+		account.Currency, account.Balance, account.TotalMinted, account.TotalBurned, account.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update treasury account: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO treasury_entries (id, currency, kind, amount, balance_after, transaction_id, actor_id, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		entry.ID,
+		entry.Currency,
+		entry.Kind,
+		entry.Amount,
+		entry.BalanceAfter,
+		entry.TransactionID,
+		entry.ActorID,
+		entry.Reason,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record treasury entry: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return account, nil
+}
+
+// issuedSince sums the money a treasury has issued since a point in time.
+func issuedSince(ctx context.Context, tx pgx.Tx, currency string, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM treasury_entries
+		WHERE currency = $1 AND kind = $2 AND created_at >= $3`
+
+	var issued float64
+	if err := tx.QueryRow(ctx, query, currency, domain.TreasuryIssue, since).Scan(&issued); err != nil {
+		return 0, fmt.Errorf("failed to sum issued credits: %w", err)
+	}
+
+	return issued, nil
+}
+
+// ListEntries retrieves a treasury's ledger, newest first.
+func (r *treasuryRepo) ListEntries(ctx context.Context, filter *domain.TreasuryEntryFilter) ([]*domain.TreasuryEntry, error) {
+	baseQuery := `
+		SELECT id, currency, kind, amount, balance_after, transaction_id, actor_id, COALESCE(reason, ''), created_at
+		FROM treasury_entries
+		WHERE currency = $1`
+
+	args := []interface{}{filter.Currency}
+	argIndex := 2
+
+	query := baseQuery
+	if filter.Kind != nil {
+		query += fmt.Sprintf(" AND kind = $%d", argIndex)
+		args = append(args, *filter.Kind)
+		argIndex++
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++ //nolint:ineffassign // argIndex is used to generate SQL parameter placeholders
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list treasury entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.TreasuryEntry
+	for rows.Next() {
+		var entry domain.TreasuryEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.Currency,
+			&entry.Kind,
+			&entry.Amount,
+			&entry.BalanceAfter,
+			&entry.TransactionID,
+			&entry.ActorID,
+			&entry.Reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan treasury entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate treasury entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SumUserBalances returns the money held by users per currency.
+func (r *treasuryRepo) SumUserBalances(ctx context.Context) (map[string]float64, error) {
+	rows, err := r.db.Query(ctx, `SELECT currency, COALESCE(SUM(amount), 0) FROM balances GROUP BY currency`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum user balances: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[string]float64)
+	for rows.Next() {
+		var currency string
+		var sum float64
+		if err := rows.Scan(&currency, &sum); err != nil {
+			return nil, fmt.Errorf("failed to scan balance sum: %w", err)
+		}
+		sums[currency] = sum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate balance sums: %w", err)
+	}
+
+	return sums, nil
+}
+
+// scanTreasuryAccount scans a single treasury account row.
+func scanTreasuryAccount(row pgx.Row) (*domain.TreasuryAccount, error) {
+	var account domain.TreasuryAccount
+	err := row.Scan(
+		&account.ID,
+		&account.Currency,
+		&account.Balance,
+		&account.TotalMinted,
+		&account.TotalBurned,
+		&account.MaxCreditAmount,
+		&account.DailyCreditCap,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
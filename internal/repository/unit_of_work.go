@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// unitOfWork implements the UnitOfWork interface on a connection pool.
+type unitOfWork struct {
+	db DBTX
+}
+
+// NewUnitOfWork creates a unit of work whose transactions run on db.
+func NewUnitOfWork(db DBTX) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Begin starts a database transaction and returns every repository bound to it.
+func (u *unitOfWork) Begin(ctx context.Context) (Tx, error) {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	return &unitOfWorkTx{tx: tx, repos: NewRepositories(tx)}, nil
+}
+
+// unitOfWorkTx implements the Tx interface on a pgx transaction.
+type unitOfWorkTx struct {
+	tx    pgx.Tx
+	repos *Repositories
+}
+
+// Repos returns the repositories bound to the transaction.
+func (t *unitOfWorkTx) Repos() *Repositories {
+	return t.repos
+}
+
+// Commit commits the transaction.
+func (t *unitOfWorkTx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+// Rollback rolls the transaction back. It does nothing once the transaction is committed.
+func (t *unitOfWorkTx) Rollback(ctx context.Context) error {
+	if err := t.tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		return err
+	}
+	return nil
+}
+
+// DecorateUnitOfWork returns a unit of work beginning the transactions of uow with their
+// repositories passed through decorate, so decorators of the pool's repositories, such as the
+// impersonation details of audit entries, apply within transactions too.
+func DecorateUnitOfWork(uow UnitOfWork, decorate func(repos *Repositories)) UnitOfWork {
+	return &decoratedUnitOfWork{UnitOfWork: uow, decorate: decorate}
+}
+
+// decoratedUnitOfWork implements the UnitOfWork interface by decorating the repositories of
+// another unit of work.
+type decoratedUnitOfWork struct {
+	UnitOfWork
+	decorate func(repos *Repositories)
+}
+
+// Begin starts a transaction and decorates the repositories bound to it.
+func (u *decoratedUnitOfWork) Begin(ctx context.Context) (Tx, error) {
+	tx, err := u.UnitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u.decorate(tx.Repos())
+	return tx, nil
+}
+
+// RunInTx runs fn with repositories bound to a new transaction of uow. The transaction is
+// committed if fn succeeds and rolled back otherwise.
+func RunInTx(ctx context.Context, uow UnitOfWork, fn func(repos *Repositories) error) error {
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Rollback error is typically safe to ignore
+	}()
+
+	if err := fn(tx.Repos()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -7,25 +7,24 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 )
 
 // usersRepo implements the UsersRepo interface.
 type usersRepo struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
 // NewUsersRepo creates a new users repository.
-func NewUsersRepo(db *pgxpool.Pool) UsersRepo {
+func NewUsersRepo(db DBTX) UsersRepo {
 	return &usersRepo{db: db}
 }
 
 // Create creates a new user.
 func (r *usersRepo) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, username, email, password_hash, role, created_at, updated_at, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO users (id, username, email, password_hash, role, created_at, updated_at, is_active, account_number)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
 	now := time.Now()
 	if user.ID == uuid.Nil {
@@ -34,8 +33,9 @@ func (r *usersRepo) Create(ctx context.Context, user *domain.User) error {
 	user.CreatedAt = now
 	user.UpdatedAt = now
 	user.IsActive = true // New users are active by default
+	user.AccountNumber = domain.AccountNumberFor(user.ID)
 
-	_, err := r.db.Exec(ctx, query, user.ID, user.Username, user.Email, user.PasswordHash, user.Role, user.CreatedAt, user.UpdatedAt, user.IsActive)
+	_, err := r.db.Exec(ctx, query, user.ID, user.Username, user.Email, user.PasswordHash, user.Role, user.CreatedAt, user.UpdatedAt, user.IsActive, user.AccountNumber)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -43,10 +43,35 @@ func (r *usersRepo) Create(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// CreateMany creates users in bulk with a single COPY.
+func (r *usersRepo) CreateMany(ctx context.Context, users []*domain.User) error {
+	now := time.Now()
+	for _, user := range users {
+		if user.ID == uuid.Nil {
+			user.ID = uuid.New()
+		}
+		user.CreatedAt = now
+		user.UpdatedAt = now
+		user.IsActive = true
+		user.AccountNumber = domain.AccountNumberFor(user.ID)
+	}
+
+	columns := []string{"id", "username", "email", "password_hash", "role", "created_at", "updated_at", "is_active", "account_number"}
+	_, err := r.db.CopyFrom(ctx, pgx.Identifier{"users"}, columns, pgx.CopyFromSlice(len(users), func(i int) ([]any, error) {
+		user := users[i]
+		return []any{user.ID, user.Username, user.Email, user.PasswordHash, user.Role, user.CreatedAt, user.UpdatedAt, user.IsActive, user.AccountNumber}, nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to create users: %w", err)
+	}
+
+	return nil
+}
+
 // GetByID retrieves a user by ID.
 func (r *usersRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active
+		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active, account_number
 		FROM users
 		WHERE id = $1 AND is_active = TRUE`
 
@@ -60,6 +85,7 @@ func (r *usersRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, er
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.IsActive,
+		&user.AccountNumber,
 	)
 
 	if err != nil {
@@ -75,7 +101,7 @@ func (r *usersRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, er
 // GetByEmail retrieves a user by email.
 func (r *usersRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active
+		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active, account_number
 		FROM users
 		WHERE email = $1 AND is_active = TRUE`
 
@@ -89,6 +115,7 @@ func (r *usersRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.IsActive,
+		&user.AccountNumber,
 	)
 
 	if err != nil {
@@ -104,7 +131,7 @@ func (r *usersRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 // GetByUsername retrieves a user by username.
 func (r *usersRepo) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active
+		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active, account_number
 		FROM users
 		WHERE username = $1 AND is_active = TRUE`
 
@@ -118,6 +145,7 @@ func (r *usersRepo) GetByUsername(ctx context.Context, username string) (*domain
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.IsActive,
+		&user.AccountNumber,
 	)
 
 	if err != nil {
@@ -130,6 +158,36 @@ func (r *usersRepo) GetByUsername(ctx context.Context, username string) (*domain
 	return &user, nil
 }
 
+// GetByAccountNumber retrieves a user by account number.
+func (r *usersRepo) GetByAccountNumber(ctx context.Context, accountNumber string) (*domain.User, error) {
+	query := `
+		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active, account_number
+		FROM users
+		WHERE account_number = $1 AND is_active = TRUE`
+
+	var user domain.User
+	err := r.db.QueryRow(ctx, query, accountNumber).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.IsActive,
+		&user.AccountNumber,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user by account number: %w", err)
+	}
+
+	return &user, nil
+}
+
 // Update updates an existing user.
 func (r *usersRepo) Update(ctx context.Context, user *domain.User) error {
 	query := `
@@ -172,7 +230,7 @@ func (r *usersRepo) Delete(ctx context.Context, id uuid.UUID) error {
 // ListPaginated retrieves users with pagination.
 func (r *usersRepo) ListPaginated(ctx context.Context, limit, offset int) ([]*domain.User, error) {
 	baseQuery := `
-		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active
+		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active, account_number
 		FROM users
 		WHERE is_active = TRUE
 		ORDER BY created_at DESC`
@@ -207,6 +265,7 @@ func (r *usersRepo) ListPaginated(ctx context.Context, limit, offset int) ([]*do
 			&user.CreatedAt,
 			&user.UpdatedAt,
 			&user.IsActive,
+			&user.AccountNumber,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -237,7 +296,7 @@ func (r *usersRepo) Count(ctx context.Context) (int, error) {
 // ListAll retrieves all users without pagination (for testing purposes).
 func (r *usersRepo) ListAll(ctx context.Context) ([]*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active
+		SELECT id, username, email, password_hash, role, created_at, updated_at, is_active, account_number
 		FROM users
 		WHERE is_active = TRUE
 		ORDER BY created_at DESC`
@@ -260,6 +319,7 @@ func (r *usersRepo) ListAll(ctx context.Context) ([]*domain.User, error) {
 			&user.CreatedAt,
 			&user.UpdatedAt,
 			&user.IsActive,
+			&user.AccountNumber,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
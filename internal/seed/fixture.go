@@ -0,0 +1,288 @@
+// Package seed loads reproducible demo and test datasets from YAML fixture files.
+package seed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// userNamespace derives seeded user IDs from usernames, so the same fixture always produces the same IDs.
+var userNamespace = uuid.MustParse("6f1c8e2a-4b7d-4e0a-9c53-2d8f1b7a6e90")
+
+// Fixture describes a dataset: users with opening balances, transactions executed in order after
+// every user is funded, and scheduled transactions.
+type Fixture struct {
+	DefaultPassword       string                 `yaml:"default_password"` // Used by users without their own password
+	Users                 []UserFixture          `yaml:"users"`
+	GenerateUsers         *GeneratedUsers        `yaml:"generate_users"`
+	Transactions          []TransactionFixture   `yaml:"transactions"`
+	ScheduledTransactions []ScheduledTxnFixture  `yaml:"scheduled_transactions"`
+	users                 map[string]UserFixture // All users by username, generated ones included; set by Validate
+}
+
+// UserFixture describes one user and their opening balance.
+type UserFixture struct {
+	Username string  `yaml:"username"`
+	Email    string  `yaml:"email"`    // Defaults to <username>@example.com
+	Password string  `yaml:"password"` // Defaults to the fixture's default password
+	Role     string  `yaml:"role"`     // Defaults to user
+	Currency string  `yaml:"currency"` // Defaults to USD
+	Balance  float64 `yaml:"balance"`
+}
+
+// GeneratedUsers describes Count regular users named <prefix>1 to <prefix>Count with the same opening balance.
+type GeneratedUsers struct {
+	Count          int     `yaml:"count"`
+	UsernamePrefix string  `yaml:"username_prefix"` // Defaults to user
+	Currency       string  `yaml:"currency"`
+	Balance        float64 `yaml:"balance"`
+}
+
+// TransactionFixture describes a credit, debit or transfer made by User. Currency defaults to the user's.
+type TransactionFixture struct {
+	Type     string  `yaml:"type"`
+	User     string  `yaml:"user"`
+	To       string  `yaml:"to"` // Recipient of a transfer
+	Amount   float64 `yaml:"amount"`
+	Currency string  `yaml:"currency"`
+}
+
+// ScheduledTxnFixture describes a scheduled transaction of User, first executing ExecuteIn after seeding.
+type ScheduledTxnFixture struct {
+	TransactionFixture `yaml:",inline"`
+	Description        string        `yaml:"description"`
+	ScheduleType       string        `yaml:"schedule_type"` // once or recurring; defaults to once
+	ExecuteIn          time.Duration `yaml:"execute_in"`
+	RecurrencePattern  string        `yaml:"recurrence_pattern"`
+	MaxOccurrences     int           `yaml:"max_occurrences"`
+}
+
+// LoadFixture reads and validates the fixture file at path. Unknown keys are rejected so typos are not silently ignored.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file: %w", err)
+	}
+
+	return ParseFixture(data)
+}
+
+// ParseFixture parses and validates a YAML fixture.
+func ParseFixture(data []byte) (*Fixture, error) {
+	var fixture Fixture
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fixture); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+
+	if err := fixture.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+
+	return &fixture, nil
+}
+
+// Validate fills in defaults, expands generated users and checks that every transaction refers to
+// a known user in a matching currency.
+func (f *Fixture) Validate() error {
+	users := make([]UserFixture, 0, len(f.Users))
+	users = append(users, f.Users...)
+
+	if g := f.GenerateUsers; g != nil {
+		if g.Count < 1 {
+			return fmt.Errorf("generate_users.count: count must be at least 1")
+		}
+		prefix := g.UsernamePrefix
+		if prefix == "" {
+			prefix = "user"
+		}
+		for i := 1; i <= g.Count; i++ {
+			users = append(users, UserFixture{
+				Username: fmt.Sprintf("%s%d", prefix, i),
+				Currency: g.Currency,
+				Balance:  g.Balance,
+			})
+		}
+	}
+
+	if len(users) == 0 {
+		return fmt.Errorf("users: at least one user is required")
+	}
+
+	f.users = make(map[string]UserFixture, len(users))
+	hasAdmin := false
+	for i := range users {
+		u := &users[i]
+		if err := u.applyDefaults(f.DefaultPassword); err != nil {
+			return fmt.Errorf("users[%s]: %w", u.Username, err)
+		}
+		if _, exists := f.users[u.Username]; exists {
+			return fmt.Errorf("users[%s]: duplicate username", u.Username)
+		}
+		if u.Role == string(domain.RoleAdmin) {
+			hasAdmin = true
+		}
+		f.users[u.Username] = *u
+	}
+
+	if !hasAdmin {
+		return fmt.Errorf("users: at least one admin is required to mint the dataset's money")
+	}
+
+	for i := range f.Transactions {
+		if err := f.Transactions[i].resolve(f.users); err != nil {
+			return fmt.Errorf("transactions[%d]: %w", i, err)
+		}
+	}
+
+	for i := range f.ScheduledTransactions {
+		st := &f.ScheduledTransactions[i]
+		if err := st.resolve(f.users); err != nil {
+			return fmt.Errorf("scheduled_transactions[%d]: %w", i, err)
+		}
+		if st.ScheduleType == "" {
+			st.ScheduleType = "once"
+		}
+		if st.ExecuteIn <= 0 {
+			return fmt.Errorf("scheduled_transactions[%d]: execute_in must be positive", i)
+		}
+	}
+
+	return nil
+}
+
+// SeededUsers returns every user of a validated fixture, generated ones included, ordered by username.
+func (f *Fixture) SeededUsers() []UserFixture {
+	users := make([]UserFixture, 0, len(f.users))
+	for _, u := range f.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users
+}
+
+// Funding returns the money the treasury of each currency must hold to seed a validated fixture:
+// every opening balance plus every credit.
+func (f *Fixture) Funding() map[string]float64 {
+	funding := make(map[string]float64)
+	for _, u := range f.users {
+		if u.Balance > 0 {
+			funding[u.Currency] += u.Balance
+		}
+	}
+	for _, t := range f.Transactions {
+		if t.Type == string(domain.TypeCredit) {
+			funding[t.Currency] += t.Amount
+		}
+	}
+	for currency, amount := range funding {
+		funding[currency] = math.Round(amount*100) / 100
+	}
+	return funding
+}
+
+// Admin returns the first admin of a validated fixture by username; it mints the dataset's money.
+func (f *Fixture) Admin() UserFixture {
+	for _, u := range f.SeededUsers() {
+		if u.Role == string(domain.RoleAdmin) {
+			return u
+		}
+	}
+	return UserFixture{}
+}
+
+// UserID returns the ID a user with username is seeded with.
+func UserID(username string) uuid.UUID {
+	return uuid.NewSHA1(userNamespace, []byte(username))
+}
+
+// applyDefaults fills in a user's optional fields and validates them.
+func (u *UserFixture) applyDefaults(defaultPassword string) error {
+	if strings.TrimSpace(u.Username) == "" {
+		return fmt.Errorf("username is required")
+	}
+
+	if u.Email == "" {
+		u.Email = u.Username + "@example.com"
+	}
+	u.Email = strings.ToLower(u.Email)
+
+	if u.Password == "" {
+		u.Password = defaultPassword
+	}
+	if u.Password == "" {
+		return fmt.Errorf("password is required without a default_password")
+	}
+
+	if u.Role == "" {
+		u.Role = string(domain.RoleUser)
+	}
+	if u.Role != string(domain.RoleUser) && u.Role != string(domain.RoleAdmin) {
+		return fmt.Errorf("role must be user or admin")
+	}
+
+	u.Currency = strings.ToUpper(u.Currency)
+	if u.Currency == "" {
+		u.Currency = "USD"
+	}
+	if !domain.IsValidCurrency(u.Currency) {
+		return fmt.Errorf("unsupported currency: %s", u.Currency)
+	}
+
+	if u.Balance < 0 || u.Balance > 1000000 {
+		return fmt.Errorf("balance must be between 0 and 1,000,000")
+	}
+
+	return nil
+}
+
+// resolve checks that a transaction's users exist and defaults its currency to the initiating user's.
+func (t *TransactionFixture) resolve(users map[string]UserFixture) error {
+	user, ok := users[t.User]
+	if !ok {
+		return fmt.Errorf("unknown user: %q", t.User)
+	}
+
+	t.Currency = strings.ToUpper(t.Currency)
+	if t.Currency == "" {
+		t.Currency = user.Currency
+	}
+	if t.Currency != user.Currency {
+		return fmt.Errorf("currency %s does not match %s's balance in %s", t.Currency, t.User, user.Currency)
+	}
+
+	switch t.Type {
+	case string(domain.TypeCredit), string(domain.TypeDebit):
+		if t.To != "" {
+			return fmt.Errorf("to is only allowed for transfers")
+		}
+	case string(domain.TypeTransfer):
+		to, ok := users[t.To]
+		if !ok {
+			return fmt.Errorf("unknown recipient: %q", t.To)
+		}
+		if to.Currency != t.Currency {
+			return fmt.Errorf("recipient %s holds %s, not %s", t.To, to.Currency, t.Currency)
+		}
+	default:
+		return fmt.Errorf("type must be credit, debit or transfer")
+	}
+
+	if t.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/auth"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/service"
+)
+
+// maxMint is the largest amount minted in one treasury operation; larger funding is split.
+const maxMint = 1000000
+
+// Seeder writes a fixture's dataset. Money is minted through the treasury and moved through the
+// transaction service, so the seeded balances, history and money supply are consistent.
+type Seeder struct {
+	repos          *repository.Repositories
+	transactionSvc service.TransactionService
+	scheduledSvc   service.ScheduledTransactionService
+	treasurySvc    service.TreasuryService
+}
+
+// Summary counts what a seed run created.
+type Summary struct {
+	Users                 int
+	Transactions          int // Opening balance credits included
+	ScheduledTransactions int
+	Minted                map[string]float64
+}
+
+// NewSeeder creates a new seeder.
+func NewSeeder(repos *repository.Repositories, transactionSvc service.TransactionService, scheduledSvc service.ScheduledTransactionService, treasurySvc service.TreasuryService) *Seeder {
+	return &Seeder{
+		repos:          repos,
+		transactionSvc: transactionSvc,
+		scheduledSvc:   scheduledSvc,
+		treasurySvc:    treasurySvc,
+	}
+}
+
+// Run seeds a validated fixture. It refuses to run when any of the fixture's users already
+// exists, so a dataset is only ever seeded once into a database.
+func (s *Seeder) Run(ctx context.Context, fixture *Fixture) (*Summary, error) {
+	users := fixture.SeededUsers()
+	for _, u := range users {
+		if existing, err := s.repos.Users.GetByUsername(ctx, u.Username); err == nil && existing != nil {
+			return nil, fmt.Errorf("user %s already exists: seed an empty database", u.Username)
+		}
+	}
+
+	summary := &Summary{Minted: fixture.Funding()}
+
+	for _, u := range users {
+		if err := s.createUser(ctx, u); err != nil {
+			return nil, fmt.Errorf("failed to seed user %s: %w", u.Username, err)
+		}
+		summary.Users++
+	}
+
+	adminID := UserID(fixture.Admin().Username)
+	currencies := make([]string, 0, len(summary.Minted))
+	for currency := range summary.Minted {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		if err := s.mint(ctx, adminID, currency, summary.Minted[currency]); err != nil {
+			return nil, fmt.Errorf("failed to mint %s: %w", currency, err)
+		}
+	}
+
+	for _, u := range users {
+		if u.Balance <= 0 {
+			continue
+		}
+		credit := &domain.CreditRequest{Amount: u.Balance, Currency: u.Currency}
+		if _, err := s.transactionSvc.Credit(ctx, UserID(u.Username), credit); err != nil {
+			return nil, fmt.Errorf("failed to fund user %s: %w", u.Username, err)
+		}
+		summary.Transactions++
+	}
+
+	for i, t := range fixture.Transactions {
+		if err := s.execute(ctx, t); err != nil {
+			return nil, fmt.Errorf("transactions[%d]: %w", i, err)
+		}
+		summary.Transactions++
+	}
+
+	now := time.Now()
+	for i, st := range fixture.ScheduledTransactions {
+		if _, err := s.scheduledSvc.Create(ctx, UserID(st.User), scheduledRequest(st, now)); err != nil {
+			return nil, fmt.Errorf("scheduled_transactions[%d]: %w", i, err)
+		}
+		summary.ScheduledTransactions++
+	}
+
+	return summary, nil
+}
+
+// createUser creates a user with their fixture ID and an empty balance.
+func (s *Seeder) createUser(ctx context.Context, u UserFixture) error {
+	hashedPassword, err := auth.HashPassword(u.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &domain.User{
+		ID:           UserID(u.Username),
+		Username:     u.Username,
+		Email:        u.Email,
+		PasswordHash: hashedPassword,
+		Role:         u.Role,
+		IsActive:     true,
+	}
+	if err := s.repos.Users.Create(ctx, user); err != nil {
+		return err
+	}
+
+	balance := &domain.Balance{
+		UserID:   user.ID,
+		Amount:   0.00,
+		Currency: u.Currency,
+	}
+	if err := s.repos.Balances.Upsert(ctx, balance); err != nil {
+		return fmt.Errorf("failed to create balance: %w", err)
+	}
+
+	return nil
+}
+
+// mint creates amount in a currency's treasury, split into operations of at most maxMint.
+func (s *Seeder) mint(ctx context.Context, adminID uuid.UUID, currency string, amount float64) error {
+	for amount > 0 {
+		chunk := math.Min(amount, maxMint)
+		req := &domain.TreasuryOperationRequest{Amount: chunk, Reason: "seed data"}
+		if _, err := s.treasurySvc.Mint(ctx, adminID, currency, req); err != nil {
+			return err
+		}
+		amount = math.Round((amount-chunk)*100) / 100
+	}
+	return nil
+}
+
+// execute performs one fixture transaction.
+func (s *Seeder) execute(ctx context.Context, t TransactionFixture) error {
+	userID := UserID(t.User)
+
+	var err error
+	switch t.Type {
+	case string(domain.TypeCredit):
+		_, err = s.transactionSvc.Credit(ctx, userID, &domain.CreditRequest{Amount: t.Amount, Currency: t.Currency})
+	case string(domain.TypeDebit):
+		_, err = s.transactionSvc.Debit(ctx, userID, &domain.DebitRequest{Amount: t.Amount, Currency: t.Currency})
+	case string(domain.TypeTransfer):
+		_, err = s.transactionSvc.Transfer(ctx, userID, &domain.TransferRequest{ToUserID: UserID(t.To), Amount: t.Amount, Currency: t.Currency})
+	}
+
+	return err
+}
+
+// scheduledRequest converts a fixture scheduled transaction into a request, executing ExecuteIn after now.
+func scheduledRequest(st ScheduledTxnFixture, now time.Time) *domain.ScheduledTransactionRequest {
+	req := &domain.ScheduledTransactionRequest{
+		TransactionType: st.Type,
+		Amount:          st.Amount,
+		Currency:        st.Currency,
+		Description:     st.Description,
+		ScheduleType:    st.ScheduleType,
+		ExecuteAt:       now.Add(st.ExecuteIn),
+	}
+
+	if st.Type == string(domain.TypeTransfer) {
+		toUserID := UserID(st.To)
+		req.ToUserID = &toUserID
+	}
+
+	if st.RecurrencePattern != "" {
+		pattern := st.RecurrencePattern
+		req.RecurrencePattern = &pattern
+	}
+
+	if st.MaxOccurrences > 0 {
+		maxOccurrences := st.MaxOccurrences
+		req.MaxOccurrences = &maxOccurrences
+	}
+
+	return req
+}
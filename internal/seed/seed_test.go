@@ -0,0 +1,118 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFixture(t *testing.T) {
+	fixture, err := ParseFixture([]byte(`
+default_password: secret123
+users:
+  - username: root
+    role: admin
+  - username: alice
+    balance: 100
+generate_users:
+  count: 2
+  balance: 50
+  currency: eur
+transactions:
+  - type: transfer
+    user: alice
+    to: root
+    amount: 10
+  - type: credit
+    user: user1
+    amount: 20.10
+scheduled_transactions:
+  - type: debit
+    user: alice
+    amount: 5
+    execute_in: 1h
+`))
+	if err != nil {
+		t.Fatalf("ParseFixture() error = %v", err)
+	}
+
+	users := fixture.SeededUsers()
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	if got := strings.Join(names, ","); got != "alice,root,user1,user2" {
+		t.Errorf("SeededUsers() = %s, want alice,root,user1,user2", got)
+	}
+
+	alice := fixture.users["alice"]
+	if alice.Email != "alice@example.com" || alice.Password != "secret123" || alice.Role != "user" || alice.Currency != "USD" {
+		t.Errorf("alice defaults = %+v", alice)
+	}
+
+	if fixture.Transactions[1].Currency != "EUR" {
+		t.Errorf("credit currency = %s, want the user's EUR", fixture.Transactions[1].Currency)
+	}
+
+	if fixture.ScheduledTransactions[0].ScheduleType != "once" || fixture.ScheduledTransactions[0].ExecuteIn != time.Hour {
+		t.Errorf("scheduled transaction = %+v", fixture.ScheduledTransactions[0])
+	}
+
+	funding := fixture.Funding()
+	if funding["USD"] != 100 || funding["EUR"] != 120.10 || len(funding) != 2 {
+		t.Errorf("Funding() = %v, want USD 100 and EUR 120.10", funding)
+	}
+
+	if fixture.Admin().Username != "root" {
+		t.Errorf("Admin() = %s, want root", fixture.Admin().Username)
+	}
+}
+
+func TestParseFixtureRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		wantErr string
+	}{
+		{name: "unknown key", fixture: "users: []\nuserz: []", wantErr: "failed to parse fixture"},
+		{name: "no admin", fixture: "default_password: x\nusers:\n  - username: alice", wantErr: "at least one admin"},
+		{name: "no password", fixture: "users:\n  - username: root\n    role: admin", wantErr: "password is required"},
+		{name: "duplicate user", fixture: "default_password: x\nusers:\n  - username: root\n    role: admin\n  - username: root", wantErr: "duplicate username"},
+		{name: "unknown recipient", fixture: "default_password: x\nusers:\n  - username: root\n    role: admin\ntransactions:\n  - type: transfer\n    user: root\n    to: bob\n    amount: 1", wantErr: "unknown recipient"},
+		{name: "currency mismatch", fixture: "default_password: x\nusers:\n  - username: root\n    role: admin\ntransactions:\n  - type: credit\n    user: root\n    currency: EUR\n    amount: 1", wantErr: "does not match"},
+		{name: "past schedule", fixture: "default_password: x\nusers:\n  - username: root\n    role: admin\nscheduled_transactions:\n  - type: debit\n    user: root\n    amount: 1", wantErr: "execute_in must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFixture([]byte(tt.fixture))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseFixture() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserIDIsDeterministic(t *testing.T) {
+	if UserID("alice") != UserID("alice") {
+		t.Error("UserID() differs between calls")
+	}
+	if UserID("alice") == UserID("bob") {
+		t.Error("UserID() collides for different usernames")
+	}
+}
+
+func TestBundledFixtureIsValid(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(file), "..", "..", "scripts", "seed.yaml")
+	if _, err := os.Stat(path); err != nil {
+		t.Skipf("bundled fixture not found: %v", err)
+	}
+
+	if _, err := LoadFixture(path); err != nil {
+		t.Errorf("LoadFixture(%s) error = %v", path, err)
+	}
+}
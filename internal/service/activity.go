@@ -0,0 +1,44 @@
+// Package service provides monthly activity reports.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// ActivityServiceImpl implements ActivityService. Reports are built from the monthly transaction
+// aggregates, so they cost the same however many transactions a user has.
+type ActivityServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewActivityService creates a new activity service.
+func NewActivityService(repos *repository.Repositories) ActivityService {
+	return &ActivityServiceImpl{repos: repos}
+}
+
+// Monthly reports the money the user received and sent in each of the last months UTC months, the
+// current one included.
+func (s *ActivityServiceImpl) Monthly(ctx context.Context, userID uuid.UUID, months int) (*domain.MonthlyActivityReport, error) {
+	if err := domain.ValidateActivityMonths(months); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	from, to := domain.ActivityRange(time.Now(), months)
+	aggregates, err := s.repos.TransactionAggregates.ListMonthly(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transaction aggregates: %w", err)
+	}
+
+	return domain.BuildMonthlyActivityReport(userID, balance.Currency, aggregates, from, to), nil
+}
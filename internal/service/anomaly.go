@@ -0,0 +1,91 @@
+// Package service provides anomaly scoring of money movement.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// AnomalyPolicy is a MoneyMovementPolicy scoring users by the signals their money movement raises,
+// such as rapid rollbacks or repeated transfers they cannot fund. Scores decay over time; a user
+// whose score reaches the threshold must step up before moving money again. Scores are stored in
+// the database, so every instance enforces them.
+type AnomalyPolicy struct {
+	repos     *repository.Repositories
+	threshold float64
+	halfLife  time.Duration
+	weights   map[domain.AnomalySignal]float64 // Points added per signal; signals without weight are ignored
+}
+
+// NewAnomalyPolicy creates an anomaly policy requiring step-up at threshold, with scores halving
+// every halfLife and each signal adding its weight.
+func NewAnomalyPolicy(repos *repository.Repositories, threshold float64, halfLife time.Duration, weights map[domain.AnomalySignal]float64) *AnomalyPolicy {
+	return &AnomalyPolicy{
+		repos:     repos,
+		threshold: threshold,
+		halfLife:  halfLife,
+		weights:   weights,
+	}
+}
+
+// Allow returns domain.ErrStepUpRequired when the user's score has reached the threshold. Scores
+// that cannot be read are logged and money movement is allowed, as with the rate limiter when
+// Redis is unavailable.
+func (p *AnomalyPolicy) Allow(ctx context.Context, userID uuid.UUID) error {
+	score, err := p.repos.AnomalyScores.Get(ctx, userID)
+	if err != nil {
+		utils.ErrorContext(ctx, "failed to get anomaly score", "user_id", userID.String(), "error", err.Error())
+		return nil
+	}
+
+	if score.At(time.Now(), p.halfLife) >= p.threshold {
+		return domain.ErrStepUpRequired
+	}
+	return nil
+}
+
+// Observe adds the weight of signal to the user's score. The first signal taking the score to the
+// threshold is recorded as a security event.
+func (p *AnomalyPolicy) Observe(ctx context.Context, userID uuid.UUID, signal domain.AnomalySignal) {
+	weight := p.weights[signal]
+	if weight <= 0 {
+		return
+	}
+
+	score, err := p.repos.AnomalyScores.Add(ctx, userID, weight, p.halfLife, time.Now())
+	if err != nil {
+		utils.ErrorContext(ctx, "failed to add to anomaly score",
+			"user_id", userID.String(),
+			"signal", string(signal),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	if score < p.threshold || score-weight >= p.threshold {
+		return
+	}
+
+	utils.WarnContext(ctx, "money movement requires step-up authentication",
+		"user_id", userID.String(),
+		"signal", string(signal),
+		"score", score,
+	)
+	event := domain.NewSecurityEvent(domain.SecurityStepUpRequired, &userID, domain.LoginDevice{})
+	event.Reason = string(signal)
+	recordSecurityEvent(ctx, p.repos, event)
+}
+
+// SteppedUp clears the user's score.
+func (p *AnomalyPolicy) SteppedUp(ctx context.Context, userID uuid.UUID) error {
+	if err := p.repos.AnomalyScores.Reset(ctx, userID); err != nil {
+		return fmt.Errorf("failed to clear anomaly score: %w", err)
+	}
+	return nil
+}
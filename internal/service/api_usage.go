@@ -0,0 +1,173 @@
+// Package service provides per-user API usage tracking and quotas.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/auth"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// API usage is counted in a Redis hash per user and UTC day, one field per endpoint class, and
+// quotas are kept in a single hash, one field per user.
+const (
+	apiUsagePrefix    = "api_usage:"
+	apiQuotasKey      = "api_quotas"
+	apiUsageRetention = (domain.MaxAPIUsageDays + 1) * 24 * time.Hour
+)
+
+// APIUsageServiceImpl implements APIUsageService. Usage and quotas live in Redis, so they are
+// shared by every instance; this is separate from the IP-based rate limits of public routes.
+type APIUsageServiceImpl struct {
+	redisClient *repository.RedisClient
+	repos       *repository.Repositories
+}
+
+// NewAPIUsageService creates a new API usage service.
+func NewAPIUsageService(redisClient *repository.RedisClient, repos *repository.Repositories) APIUsageService {
+	return &APIUsageServiceImpl{
+		redisClient: redisClient,
+		repos:       repos,
+	}
+}
+
+// MeterCall counts a call of a user to an endpoint class. A call that would go over the user's
+// quota is not counted and fails with an *auth.QuotaExceededError.
+func (s *APIUsageServiceImpl) MeterCall(ctx context.Context, userID uuid.UUID, class string) error {
+	now := time.Now()
+	key := apiUsageKey(userID, domain.APIUsageDate(now))
+
+	fields, err := s.redisClient.HIncrBy(ctx, key, class, 1, apiUsageRetention)
+	if err != nil {
+		return fmt.Errorf("failed to count API call: %w", err)
+	}
+
+	quota, err := s.quota(ctx, userID)
+	if err != nil || quota == nil {
+		return err
+	}
+
+	usage := domain.NewAPIUsageDay(domain.APIUsageDate(now), parseUsageCounts(fields))
+	exceeded, limit := quota.Exceeded(&usage, class)
+	if !exceeded {
+		return nil
+	}
+
+	// Rejected calls do not use up the quota
+	if _, err := s.redisClient.HIncrBy(ctx, key, class, -1, 0); err != nil {
+		return fmt.Errorf("failed to uncount API call: %w", err)
+	}
+	return &auth.QuotaExceededError{Limit: limit, ResetAt: domain.APIUsageResetAt(now)}
+}
+
+// Usage reports a user's API calls of the last days UTC days, today first, and what remains of
+// their quota today.
+func (s *APIUsageServiceImpl) Usage(ctx context.Context, userID uuid.UUID, days int) (*domain.APIUsageReport, error) {
+	if err := domain.ValidateAPIUsageDays(days); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	now := time.Now().UTC()
+	usage := make([]domain.APIUsageDay, 0, days)
+	for i := 0; i < days; i++ {
+		date := domain.APIUsageDate(now.AddDate(0, 0, -i))
+		fields, err := s.redisClient.HGetAll(ctx, apiUsageKey(userID, date))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API usage: %w", err)
+		}
+		usage = append(usage, domain.NewAPIUsageDay(date, parseUsageCounts(fields)))
+	}
+
+	quota, err := s.quota(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BuildAPIUsageReport(userID, quota, usage, now), nil
+}
+
+// SetQuota creates or replaces a user's API quota.
+func (s *APIUsageServiceImpl) SetQuota(ctx context.Context, adminID, userID uuid.UUID, req *domain.SetAPIQuotaRequest) (*domain.APIQuota, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if _, err := s.repos.Users.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	quota := &domain.APIQuota{
+		UserID:      userID,
+		DailyLimit:  req.DailyLimit,
+		ClassLimits: req.ClassLimits,
+		UpdatedBy:   adminID,
+		UpdatedAt:   time.Now(),
+	}
+	if err := s.redisClient.HSet(ctx, apiQuotasKey, userID.String(), quota); err != nil {
+		return nil, fmt.Errorf("failed to set API quota: %w", err)
+	}
+
+	// Log the audit event
+	_ = s.repos.Audit.Log(ctx, "user", userID, "api_quota_set", map[string]interface{}{
+		"admin_id":     adminID,
+		"daily_limit":  quota.DailyLimit,
+		"class_limits": quota.ClassLimits,
+	})
+
+	return quota, nil
+}
+
+// DeleteQuota removes a user's API quota, leaving their calls unlimited.
+func (s *APIUsageServiceImpl) DeleteQuota(ctx context.Context, adminID, userID uuid.UUID) error {
+	exists, err := s.redisClient.HExists(ctx, apiQuotasKey, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to get API quota: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("api quota not found")
+	}
+
+	if err := s.redisClient.HDel(ctx, apiQuotasKey, userID.String()); err != nil {
+		return fmt.Errorf("failed to delete API quota: %w", err)
+	}
+
+	// Log the audit event
+	_ = s.repos.Audit.Log(ctx, "user", userID, "api_quota_deleted", map[string]interface{}{
+		"admin_id": adminID,
+	})
+
+	return nil
+}
+
+// quota returns a user's API quota, or nil when they have none.
+func (s *APIUsageServiceImpl) quota(ctx context.Context, userID uuid.UUID) (*domain.APIQuota, error) {
+	var quota domain.APIQuota
+	if err := s.redisClient.HGet(ctx, apiQuotasKey, userID.String(), &quota); err != nil {
+		if errors.Is(err, repository.ErrCacheMiss) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API quota: %w", err)
+	}
+	return &quota, nil
+}
+
+// apiUsageKey returns the key of the hash counting a user's API calls of a UTC day.
+func apiUsageKey(userID uuid.UUID, date string) string {
+	return apiUsagePrefix + userID.String() + ":" + date
+}
+
+// parseUsageCounts parses the per-class counts of a usage hash, skipping malformed ones.
+func parseUsageCounts(fields map[string]string) map[string]int64 {
+	counts := make(map[string]int64, len(fields))
+	for class, value := range fields {
+		if count, err := strconv.ParseInt(value, 10, 64); err == nil && count > 0 {
+			counts[class] = count
+		}
+	}
+	return counts
+}
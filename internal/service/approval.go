@@ -0,0 +1,280 @@
+// Package service provides four-eyes approval of destructive admin operations.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// ApprovalServiceImpl implements ApprovalService. Actions that require approval are queued with
+// the request they were made with and executed on behalf of their requester once a second admin
+// approves them.
+type ApprovalServiceImpl struct {
+	repos       *repository.Repositories
+	transaction TransactionService
+	user        UserService
+	policy      PolicyService // Optional; sets the approval thresholds and executes policy changes
+	required    map[domain.ApprovalAction]bool
+}
+
+// NewApprovalService creates a new approval service requiring approval of the given actions.
+func NewApprovalService(repos *repository.Repositories, transaction TransactionService, user UserService, required []domain.ApprovalAction) ApprovalService {
+	s := &ApprovalServiceImpl{
+		repos:       repos,
+		transaction: transaction,
+		user:        user,
+		required:    make(map[domain.ApprovalAction]bool, len(required)),
+	}
+	for _, action := range required {
+		s.required[action] = true
+	}
+	return s
+}
+
+// SetPolicyService sets the policy whose thresholds exempt small amounts from approval, and
+// through which approved policy changes are stored.
+func (s *ApprovalServiceImpl) SetPolicyService(policy PolicyService) {
+	s.policy = policy
+}
+
+// Required reports whether the action with its payload must be approved by a second admin. Of
+// the actions that require approval, rollbacks and balance adjustments below the policy's
+// threshold execute at once.
+func (s *ApprovalServiceImpl) Required(ctx context.Context, action domain.ApprovalAction, payload any) bool {
+	if !s.required[action] {
+		return false
+	}
+	if s.policy == nil {
+		return true
+	}
+
+	var amount float64
+	switch payload := payload.(type) {
+	case *domain.RollbackApproval:
+		if payload.Amount != nil {
+			amount = *payload.Amount
+			break
+		}
+		// A full rollback reverses whatever is left; when that cannot be told, approval is required
+		tx, err := s.repos.Transactions.GetByID(ctx, payload.TransactionID)
+		if err != nil {
+			return true
+		}
+		amount = tx.RemainingReversibleAmount()
+	case *domain.BalanceAdjustmentApproval:
+		amount = payload.Request.Amount
+	default:
+		return true
+	}
+
+	return s.policy.Current().NeedsApproval(action, amount)
+}
+
+// Submit queues an action with its payload until an admin other than the requester approves it.
+func (s *ApprovalServiceImpl) Submit(ctx context.Context, requesterID uuid.UUID, action domain.ApprovalAction, payload any) (*domain.Approval, error) {
+	// Reject requests that could never execute now rather than when they are approved
+	if adjustment, ok := payload.(*domain.BalanceAdjustmentApproval); ok {
+		if err := adjustment.Request.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode approval payload: %w", err)
+	}
+
+	approval := &domain.Approval{
+		ID:          uuid.New(),
+		Action:      action,
+		Payload:     encoded,
+		RequestedBy: requesterID,
+		Status:      domain.ApprovalPending,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repos.Approvals.Create(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to create approval: %w", err)
+	}
+
+	s.audit(ctx, approval.ID, domain.ActionCreated, map[string]interface{}{
+		"action":       approval.Action,
+		"requested_by": requesterID,
+		"payload":      approval.Payload,
+	})
+
+	return approval, nil
+}
+
+// List retrieves approvals, newest first.
+func (s *ApprovalServiceImpl) List(ctx context.Context, filter *domain.ApprovalFilter) ([]*domain.Approval, error) {
+	approvals, err := s.repos.Approvals.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+
+	if approvals == nil {
+		approvals = []*domain.Approval{}
+	}
+
+	return approvals, nil
+}
+
+// GetByID retrieves an approval by ID.
+func (s *ApprovalServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Approval, error) {
+	approval, err := s.repos.Approvals.GetByID(ctx, id)
+	if err != nil {
+		if err.Error() == "approval not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+
+	return approval, nil
+}
+
+// Approve executes a pending action on behalf of its requester and records its outcome. An action
+// that fails to execute is not retried; it is left failed with why, and must be requested again.
+func (s *ApprovalServiceImpl) Approve(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) (*domain.Approval, error) {
+	approval, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval.RequestedBy == reviewerID {
+		return nil, fmt.Errorf("cannot approve your own request")
+	}
+
+	// Claiming the approval first makes sure concurrent approvals execute it only once
+	if err := s.review(ctx, id, reviewerID, domain.ApprovalApproved, note); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, id, domain.ActionApproved, map[string]interface{}{
+		"action":      approval.Action,
+		"reviewed_by": reviewerID,
+		"note":        note,
+	})
+
+	status, errMsg := domain.ApprovalExecuted, ""
+	var encoded json.RawMessage
+	result, err := s.execute(ctx, approval)
+	if err == nil {
+		encoded, err = json.Marshal(result)
+	}
+	if err != nil {
+		status, errMsg = domain.ApprovalFailed, err.Error()
+	}
+
+	if err := s.repos.Approvals.Complete(ctx, id, status, encoded, errMsg, time.Now()); err != nil {
+		// The action already ran, so report its outcome even though it could not be recorded
+		utils.ErrorContext(ctx, "failed to record approval outcome",
+			"approval_id", id.String(),
+			"status", string(status),
+			"error", err.Error(),
+		)
+	}
+
+	action := domain.ActionCompleted
+	if status == domain.ApprovalFailed {
+		action = domain.ActionFailed
+	}
+	s.audit(ctx, id, action, map[string]interface{}{
+		"action": approval.Action,
+		"error":  errMsg,
+	})
+
+	return s.GetByID(ctx, id)
+}
+
+// Reject discards a pending action. The requester may reject their own action to withdraw it.
+func (s *ApprovalServiceImpl) Reject(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) (*domain.Approval, error) {
+	if err := s.review(ctx, id, reviewerID, domain.ApprovalRejected, note); err != nil {
+		return nil, err
+	}
+
+	approval, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, id, domain.ActionRejected, map[string]interface{}{
+		"action":      approval.Action,
+		"reviewed_by": reviewerID,
+		"note":        note,
+	})
+
+	return approval, nil
+}
+
+// review moves a pending approval to approved or rejected.
+func (s *ApprovalServiceImpl) review(ctx context.Context, id, reviewerID uuid.UUID, status domain.ApprovalStatus, note string) error {
+	err := s.repos.Approvals.Review(ctx, id, reviewerID, status, strings.TrimSpace(note), time.Now())
+	if err != nil {
+		if err.Error() == "approval not found" || err.Error() == "approval already reviewed" {
+			return err
+		}
+		return fmt.Errorf("failed to review approval: %w", err)
+	}
+
+	return nil
+}
+
+// execute runs an approved action with the request it was queued with.
+func (s *ApprovalServiceImpl) execute(ctx context.Context, approval *domain.Approval) (any, error) {
+	switch approval.Action {
+	case domain.ApprovalRollback:
+		var payload domain.RollbackApproval
+		if err := json.Unmarshal(approval.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode approval payload: %w", err)
+		}
+		if payload.Amount != nil {
+			return s.transaction.PartialRollbackByAdmin(ctx, payload.TransactionID, *payload.Amount)
+		}
+		return s.transaction.RollbackByAdmin(ctx, payload.TransactionID)
+
+	case domain.ApprovalBalanceAdjustment:
+		var payload domain.BalanceAdjustmentApproval
+		if err := json.Unmarshal(approval.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode approval payload: %w", err)
+		}
+		return s.transaction.AdjustBalance(ctx, payload.UserID, &payload.Request)
+
+	case domain.ApprovalRoleChange:
+		var payload domain.RoleChangeApproval
+		if err := json.Unmarshal(approval.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode approval payload: %w", err)
+		}
+		return s.user.Update(ctx, payload.UserID, &payload.Request)
+
+	case domain.ApprovalPolicyChange:
+		if s.policy == nil {
+			return nil, fmt.Errorf("policy changes are not available")
+		}
+		var payload domain.SetPolicyRequest
+		if err := json.Unmarshal(approval.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode approval payload: %w", err)
+		}
+		return s.policy.Set(ctx, approval.RequestedBy, &payload)
+
+	default:
+		return nil, fmt.Errorf("unknown approval action: %s", approval.Action)
+	}
+}
+
+// audit records an approval in the audit log. The queue is already updated, so a failure is logged
+// rather than returned.
+func (s *ApprovalServiceImpl) audit(ctx context.Context, approvalID uuid.UUID, action domain.AuditAction, details map[string]interface{}) {
+	if err := s.repos.Audit.Log(ctx, string(domain.EntityApproval), approvalID, string(action), details); err != nil {
+		utils.WarnContext(ctx, "failed to audit approval",
+			"approval_id", approvalID.String(),
+			"action", string(action),
+			"error", err.Error(),
+		)
+	}
+}
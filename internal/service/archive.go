@@ -0,0 +1,61 @@
+// Package service provides partition maintenance and archival of events and transactions.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// ArchiveServiceImpl implements ArchiveService.
+type ArchiveServiceImpl struct {
+	repo            repository.ArchiveRepo
+	partitionsAhead int // Months of partitions kept ready beyond the current one
+	afterMonths     int // Age in months after which rows are archived; 0 keeps everything live
+}
+
+// NewArchiveService creates a new archive service.
+func NewArchiveService(repo repository.ArchiveRepo, partitionsAhead, afterMonths int) ArchiveService {
+	return &ArchiveServiceImpl{
+		repo:            repo,
+		partitionsAhead: partitionsAhead,
+		afterMonths:     afterMonths,
+	}
+}
+
+// RunMaintenance creates the event and transaction partitions from the current month through
+// partitionsAhead months ahead, then archives events and transactions older than afterMonths whole
+// months.
+func (s *ArchiveServiceImpl) RunMaintenance(ctx context.Context) (*domain.ArchiveRun, error) {
+	now := time.Now()
+	run := &domain.ArchiveRun{PartitionsCreated: []string{}, EventPartitionsArchived: []string{}, TransactionPartitionsArchived: []string{}}
+
+	created, err := s.repo.EnsurePartitions(ctx, domain.MonthStart(now), domain.MonthStart(now).AddDate(0, s.partitionsAhead, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partitions: %w", err)
+	}
+	run.PartitionsCreated = append(run.PartitionsCreated, created...)
+
+	cutoff, ok := domain.ArchiveCutoff(now, s.afterMonths)
+	if !ok {
+		return run, nil
+	}
+	run.Cutoff = &cutoff
+
+	archived, err := s.repo.ArchiveEvents(ctx, cutoff)
+	run.EventPartitionsArchived = append(run.EventPartitionsArchived, archived...)
+	if err != nil {
+		return run, fmt.Errorf("failed to archive events: %w", err)
+	}
+
+	archived, err = s.repo.ArchiveTransactions(ctx, cutoff)
+	run.TransactionPartitionsArchived = append(run.TransactionPartitionsArchived, archived...)
+	if err != nil {
+		return run, fmt.Errorf("failed to archive transactions: %w", err)
+	}
+
+	return run, nil
+}
@@ -0,0 +1,51 @@
+// Package service flags audit entries logged under an impersonation token.
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/auth"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// impersonationAuditRepo wraps an AuditRepo, adding the impersonator to the details of entries
+// logged by requests made with an impersonation token.
+type impersonationAuditRepo struct {
+	repository.AuditRepo
+}
+
+// NewImpersonationAuditRepo wraps audit so every entry logged under an impersonation token is
+// flagged as impersonated, with the impersonating admin and session in its details.
+func NewImpersonationAuditRepo(audit repository.AuditRepo) repository.AuditRepo {
+	return &impersonationAuditRepo{AuditRepo: audit}
+}
+
+// Log creates a new audit log entry, flagged if ctx carries an impersonation.
+func (r *impersonationAuditRepo) Log(ctx context.Context, entityType string, entityID uuid.UUID, action string, details interface{}) error {
+	if impersonation, ok := auth.ImpersonationFromContext(ctx); ok {
+		details = withImpersonationDetails(details, impersonation)
+	}
+	return r.AuditRepo.Log(ctx, entityType, entityID, action, details)
+}
+
+// withImpersonationDetails adds impersonation markers to audit details.
+func withImpersonationDetails(details interface{}, impersonation *auth.Impersonation) map[string]interface{} {
+	flagged := map[string]interface{}{}
+
+	switch d := details.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, value := range d {
+			flagged[key] = value
+		}
+	default:
+		flagged["details"] = d
+	}
+
+	flagged["impersonated"] = true
+	flagged["impersonator_id"] = impersonation.ImpersonatorID
+	flagged["impersonation_session_id"] = impersonation.SessionID
+
+	return flagged
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/auth"
@@ -12,24 +13,51 @@ import (
 	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
 
-// authService implements the AuthService interface.
-type authService struct {
-	repos      *repository.Repositories
-	jwtManager *auth.JWTManager
-	eventSvc   *EventService // Event service for publishing domain events
+// registrationCurrency is the currency of the balance every new user starts with.
+const registrationCurrency = "USD"
+
+// AuthServiceImpl implements the AuthService interface.
+type AuthServiceImpl struct {
+	repos        *repository.Repositories
+	jwtManager   *auth.JWTManager
+	eventSvc     *EventService         // Event service for publishing domain events
+	uow          repository.UnitOfWork // Creates the user, balance and events atomically
+	welcomeBonus float64               // Issued from the treasury to each new user; 0 disables
+	notifier     Notifier              // Optional; users are notified of logins from new devices through it
+	local        *LocalCache           // Optional; holds whether login sessions were revoked
+	policy       MoneyMovementPolicy   // Optional; lets users move money again once they stepped up
 }
 
-// NewAuthService creates a new authentication service.
-func NewAuthService(repos *repository.Repositories, jwtManager *auth.JWTManager, eventSvc *EventService) AuthService {
-	return &authService{
-		repos:      repos,
-		jwtManager: jwtManager,
-		eventSvc:   eventSvc,
+// NewAuthService creates a new authentication service. New users are issued welcomeBonus from the
+// treasury; 0 disables the bonus.
+func NewAuthService(repos *repository.Repositories, jwtManager *auth.JWTManager, eventSvc *EventService, uow repository.UnitOfWork, welcomeBonus float64) AuthService {
+	return &AuthServiceImpl{
+		repos:        repos,
+		jwtManager:   jwtManager,
+		eventSvc:     eventSvc,
+		uow:          uow,
+		welcomeBonus: welcomeBonus,
 	}
 }
 
+// SetNotifier sets the notifier that users are told of logins from new devices through.
+func (s *AuthServiceImpl) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetLocalCache sets the local cache that holds whether login sessions were revoked, sparing the
+// database a lookup on every authenticated request.
+func (s *AuthServiceImpl) SetLocalCache(local *LocalCache) {
+	s.local = local
+}
+
+// SetMoneyMovementPolicy sets the policy that users who step up are allowed to move money again by.
+func (s *AuthServiceImpl) SetMoneyMovementPolicy(policy MoneyMovementPolicy) {
+	s.policy = policy
+}
+
 // Register creates a new user account with an initial balance.
-func (s *authService) Register(ctx context.Context, req *domain.CreateUserRequest) (*domain.UserResponse, error) {
+func (s *AuthServiceImpl) Register(ctx context.Context, req *domain.CreateUserRequest) (*domain.UserResponse, error) {
 	// Validate the request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -68,38 +96,38 @@ func (s *authService) Register(ctx context.Context, req *domain.CreateUserReques
 		IsActive:     true,
 	}
 
-	if err := s.repos.Users.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+	// Create the user with its initial balance and registration events in one transaction
+	err = repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		if err := repos.Users.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
 
-	// Create initial balance directly
-	balance := &domain.Balance{
-		UserID:   user.ID,
-		Amount:   0.00,
-		Currency: "USD",
-	}
+		balance := &domain.Balance{
+			UserID:   user.ID,
+			Currency: registrationCurrency,
+		}
 
-	if err := s.repos.Balances.Upsert(ctx, balance); err != nil {
-		return nil, fmt.Errorf("failed to create initial balance: %w", err)
-	}
+		bonus, err := s.grantWelcomeBonus(ctx, repos, user)
+		if err != nil {
+			return err
+		}
+		balance.Amount = bonus
 
-	// Publish domain events for event sourcing
-	if s.eventSvc != nil {
-		// Publish UserRegistered event
-		if err := s.eventSvc.UserRegistered(ctx, user); err != nil {
-			utils.Error("failed to publish UserRegistered event",
-				"user_id", user.ID,
-				"error", err.Error(),
-			)
+		if err := repos.Balances.Upsert(ctx, balance); err != nil {
+			return fmt.Errorf("failed to create initial balance: %w", err)
 		}
 
-		// Publish BalanceInitialized event
-		if err := s.eventSvc.AmountCredited(ctx, user.ID, 0.00, "USD", uuid.Nil, "initial_balance"); err != nil {
-			utils.Error("failed to publish BalanceInitialized event",
-				"user_id", user.ID,
-				"error", err.Error(),
-			)
+		if s.eventSvc == nil {
+			return nil
+		}
+		events, err := s.eventSvc.RegistrationEvents(ctx, user, balance)
+		if err != nil {
+			return err
 		}
+		return s.eventSvc.PublishEventsIn(ctx, repos, events)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Log the registration for audit
@@ -124,30 +152,102 @@ func (s *authService) Register(ctx context.Context, req *domain.CreateUserReques
 	return &response, nil
 }
 
-// Login authenticates a user and returns tokens.
-func (s *authService) Login(ctx context.Context, email, password string) (*LoginResponse, error) {
+// grantWelcomeBonus issues the welcome bonus of a new user from the treasury and records it as a
+// completed credit, returning the amount granted. The user starts without a bonus when the treasury
+// cannot fund it, so registration never depends on the treasury.
+func (s *AuthServiceImpl) grantWelcomeBonus(ctx context.Context, repos *repository.Repositories, user *domain.User) (float64, error) {
+	if s.welcomeBonus <= 0 {
+		return 0, nil
+	}
+
+	credit := &domain.Transaction{
+		ID:          uuid.New(),
+		ToUserID:    &user.ID,
+		Amount:      s.welcomeBonus,
+		Currency:    registrationCurrency,
+		Type:        string(domain.TypeCredit),
+		Description: "Welcome bonus",
+	}
+
+	entry := &domain.TreasuryEntry{
+		ID:            uuid.New(),
+		Currency:      credit.Currency,
+		Kind:          domain.TreasuryIssue,
+		Amount:        credit.Amount,
+		TransactionID: &credit.ID,
+		Reason:        "welcome bonus",
+		CreatedAt:     time.Now(),
+	}
+	if _, err := repos.Treasury.Record(ctx, entry, false); err != nil {
+		utils.WarnContext(ctx, "welcome bonus not granted",
+			"user_id", user.ID.String(),
+			"amount", s.welcomeBonus,
+			"error", err.Error(),
+		)
+		return 0, nil
+	}
+
+	if err := repos.Transactions.CreatePending(ctx, credit); err != nil {
+		return 0, fmt.Errorf("failed to create welcome bonus transaction: %w", err)
+	}
+	if err := repos.Transactions.MarkCompleted(ctx, credit.ID); err != nil {
+		return 0, fmt.Errorf("failed to complete welcome bonus transaction: %w", err)
+	}
+
+	return credit.Amount, nil
+}
+
+// Login authenticates a user and returns tokens for a new login session. A login from a device the
+// user has not used before publishes a LoginFromNewDevice event and notifies the user, who can
+// revoke the session if it was not theirs. The first device a user logs in from is not reported.
+func (s *AuthServiceImpl) Login(ctx context.Context, email, password string, device domain.LoginDevice) (*LoginResponse, error) {
 	// Get user by email
 	user, err := s.repos.Users.GetByEmail(ctx, strings.ToLower(email))
 	if err != nil {
+		failure := domain.NewSecurityEvent(domain.SecurityLoginFailed, nil, device)
+		failure.Email = strings.ToLower(email)
+		failure.Reason = "unknown email"
+		recordSecurityEvent(ctx, s.repos, failure)
 		return nil, fmt.Errorf("invalid email or password")
 	}
 
 	// Verify password
 	if !auth.ComparePassword(user.PasswordHash, password) {
+		failure := domain.NewSecurityEvent(domain.SecurityLoginFailed, &user.ID, device)
+		failure.Email = user.Email
+		failure.Reason = "wrong password"
+		recordSecurityEvent(ctx, s.repos, failure)
 		return nil, fmt.Errorf("invalid email or password")
 	}
 
+	now := time.Now()
+	session := &domain.LoginSession{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		IP:        device.IP,
+		UserAgent: device.UserAgent,
+		NewDevice: s.isNewDevice(ctx, user.ID, device, now),
+		CreatedAt: now,
+		ExpiresAt: now.Add(auth.RefreshTokenDuration),
+	}
+
 	// Generate token pair
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Username, user.Email, user.Role)
+	tokenPair, err := s.jwtManager.GenerateSessionTokenPair(session.ID, user.ID, user.Username, user.Email, user.Role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
+	if err := s.repos.LoginSessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to record login session: %w", err)
+	}
+
 	// Log the login for audit
 	if s.repos.Audit != nil {
 		auditDetails := map[string]interface{}{
-			"user_id": user.ID,
-			"email":   user.Email,
+			"user_id":    user.ID,
+			"email":      user.Email,
+			"session_id": session.ID,
+			"new_device": session.NewDevice,
 		}
 		if err := s.repos.Audit.Log(ctx, "user", user.ID, "login", auditDetails); err != nil {
 			utils.Error("failed to log login audit",
@@ -157,6 +257,15 @@ func (s *authService) Login(ctx context.Context, email, password string) (*Login
 		}
 	}
 
+	event := domain.NewSecurityEvent(domain.SecurityLogin, &user.ID, device)
+	event.Email = user.Email
+	event.SessionID = &session.ID
+	recordSecurityEvent(ctx, s.repos, event)
+
+	if session.NewDevice {
+		s.reportNewDevice(ctx, session)
+	}
+
 	userResponse := user.ToResponse()
 	return &LoginResponse{
 		User:         &userResponse,
@@ -166,14 +275,80 @@ func (s *authService) Login(ctx context.Context, email, password string) (*Login
 	}, nil
 }
 
-// RefreshToken generates a new access token from a refresh token.
-func (s *authService) RefreshToken(_ context.Context, refreshToken string) (*TokenResponse, error) {
+// isNewDevice records that the user logged in from device, reporting whether the device is new to
+// a user who had logged in from others before. Failing to track devices never fails the login;
+// the device is then treated as known.
+func (s *AuthServiceImpl) isNewDevice(ctx context.Context, userID uuid.UUID, device domain.LoginDevice, now time.Time) bool {
+	known, err := s.repos.KnownDevices.Count(ctx, userID)
+	if err != nil {
+		utils.WarnContext(ctx, "failed to count known devices", "user_id", userID.String(), "error", err.Error())
+		return false
+	}
+
+	isNew, err := s.repos.KnownDevices.Touch(ctx, userID, device.Fingerprint(), now)
+	if err != nil {
+		utils.WarnContext(ctx, "failed to record known device", "user_id", userID.String(), "error", err.Error())
+		return false
+	}
+
+	return isNew && known > 0
+}
+
+// reportNewDevice publishes the security event of a login from a new device and notifies the user.
+// Failures are logged, as the login itself has succeeded.
+func (s *AuthServiceImpl) reportNewDevice(ctx context.Context, session *domain.LoginSession) {
+	utils.WarnContext(ctx, "login from new device",
+		"user_id", session.UserID.String(),
+		"session_id", session.ID.String(),
+		"ip", session.IP,
+	)
+
+	if s.eventSvc != nil {
+		if err := s.eventSvc.LoginFromNewDevice(ctx, session); err != nil {
+			utils.WarnContext(ctx, "failed to publish new device login event",
+				"user_id", session.UserID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+
+	if s.notifier != nil {
+		data := map[string]interface{}{
+			"session_id":   session.ID.String(),
+			"ip":           session.IP,
+			"user_agent":   session.UserAgent,
+			"logged_in_at": session.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := s.notifier.Notify(ctx, session.UserID, domain.NotificationNewDeviceLogin, data); err != nil {
+			utils.WarnContext(ctx, "failed to queue new device login notification",
+				"user_id", session.UserID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// RefreshToken generates a new access token from a refresh token of a session that was not revoked.
+func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string, device domain.LoginDevice) (*TokenResponse, error) {
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refresh token: %w", err)
+	}
+
+	if err := s.jwtManager.CheckSession(ctx, claims); err != nil {
+		return nil, fmt.Errorf("invalid refresh token: %w", err)
+	}
+
 	// Generate new access token
 	newAccessToken, err := s.jwtManager.RefreshAccessToken(refreshToken)
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
 
+	event := domain.NewSecurityEvent(domain.SecurityTokenRefreshed, &claims.UserID, device)
+	event.SessionID = claims.SessionID
+	recordSecurityEvent(ctx, s.repos, event)
+
 	return &TokenResponse{
 		AccessToken: newAccessToken,
 		ExpiresIn:   int(auth.AccessTokenDuration.Seconds()),
@@ -181,7 +356,7 @@ func (s *authService) RefreshToken(_ context.Context, refreshToken string) (*Tok
 }
 
 // ValidateToken validates an access token and returns user info.
-func (s *authService) ValidateToken(ctx context.Context, token string) (*domain.UserResponse, error) {
+func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*domain.UserResponse, error) {
 	claims, err := s.jwtManager.ValidateAccessToken(token)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
@@ -198,7 +373,7 @@ func (s *authService) ValidateToken(ctx context.Context, token string) (*domain.
 }
 
 // Logout invalidates a refresh token.
-func (s *authService) Logout(ctx context.Context, refreshToken string) error {
+func (s *AuthServiceImpl) Logout(ctx context.Context, refreshToken string) error {
 	// For MVP, we don't maintain a blacklist of tokens
 	// In production, you would store invalidated tokens in Redis or database
 	// For now, we just validate that the token is valid before "invalidating" it
@@ -226,3 +401,182 @@ func (s *authService) Logout(ctx context.Context, refreshToken string) error {
 
 	return nil
 }
+
+// Impersonate mints a short-lived token that lets an admin act as another user.
+func (s *AuthServiceImpl) Impersonate(ctx context.Context, adminID, targetUserID uuid.UUID, reason string) (*ImpersonationResponse, error) {
+	if adminID == targetUserID {
+		return nil, fmt.Errorf("cannot impersonate yourself")
+	}
+
+	// Impersonation tokens must never be used to chain into another impersonation
+	if _, ok := auth.ImpersonationFromContext(ctx); ok {
+		return nil, fmt.Errorf("cannot impersonate while impersonating")
+	}
+
+	target, err := s.repos.Users.GetByID(ctx, targetUserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if target.Role == string(domain.RoleAdmin) {
+		return nil, fmt.Errorf("cannot impersonate an admin")
+	}
+
+	if !target.IsActive {
+		return nil, fmt.Errorf("cannot impersonate an inactive user")
+	}
+
+	token, claims, err := s.jwtManager.GenerateImpersonationToken(target.ID, target.Username, target.Email, target.Role, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	sessionID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid impersonation session ID: %w", err)
+	}
+
+	session := &domain.ImpersonationSession{
+		ID:           sessionID,
+		AdminID:      adminID,
+		TargetUserID: target.ID,
+		Reason:       strings.TrimSpace(reason),
+		CreatedAt:    claims.IssuedAt.Time,
+		ExpiresAt:    claims.ExpiresAt.Time,
+	}
+
+	if err := s.repos.ImpersonationSessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to record impersonation session: %w", err)
+	}
+
+	// Log the impersonation for audit
+	if s.repos.Audit != nil {
+		auditDetails := map[string]interface{}{
+			"admin_id":   adminID,
+			"user_id":    target.ID,
+			"session_id": session.ID,
+			"reason":     session.Reason,
+			"expires_at": session.ExpiresAt,
+		}
+		if err := s.repos.Audit.Log(ctx, "user", target.ID, "impersonation_started", auditDetails); err != nil {
+			utils.Error("failed to log impersonation audit",
+				"admin_id", adminID,
+				"user_id", target.ID,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	return &ImpersonationResponse{
+		Session:     session,
+		AccessToken: token,
+		ExpiresIn:   int(auth.ImpersonationTokenDuration.Seconds()),
+	}, nil
+}
+
+// ListSessions retrieves a user's login sessions that are neither revoked nor expired.
+func (s *AuthServiceImpl) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.LoginSession, error) {
+	sessions, err := s.repos.LoginSessions.ListActive(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes one of a user's login sessions, so its tokens are rejected from then on.
+func (s *AuthServiceImpl) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, device domain.LoginDevice) error {
+	if err := s.repos.LoginSessions.Revoke(ctx, sessionID, userID, time.Now()); err != nil {
+		return err
+	}
+	s.local.Invalidate(ctx, loginSessionKey(sessionID))
+
+	event := domain.NewSecurityEvent(domain.SecuritySessionRevoked, &userID, device)
+	event.SessionID = &sessionID
+	recordSecurityEvent(ctx, s.repos, event)
+
+	// Log the revocation for audit
+	if s.repos.Audit != nil {
+		auditDetails := map[string]interface{}{
+			"user_id":    userID,
+			"session_id": sessionID,
+		}
+		if err := s.repos.Audit.Log(ctx, "user", userID, "session_revoked", auditDetails); err != nil {
+			utils.Error("failed to log session revocation audit",
+				"user_id", userID,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// StepUp confirms the password of a user whose money movement the policy holds up, letting them
+// move money again.
+func (s *AuthServiceImpl) StepUp(ctx context.Context, userID uuid.UUID, password string, device domain.LoginDevice) error {
+	user, err := s.repos.Users.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !auth.ComparePassword(user.PasswordHash, password) {
+		failure := domain.NewSecurityEvent(domain.SecurityStepUpFailed, &userID, device)
+		failure.Reason = "wrong password"
+		recordSecurityEvent(ctx, s.repos, failure)
+		return fmt.Errorf("invalid password")
+	}
+
+	if s.policy != nil {
+		if err := s.policy.SteppedUp(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	recordSecurityEvent(ctx, s.repos, domain.NewSecurityEvent(domain.SecurityStepUp, &userID, device))
+
+	// Log the step-up for audit
+	if s.repos.Audit != nil {
+		auditDetails := map[string]interface{}{
+			"user_id": userID,
+		}
+		if err := s.repos.Audit.Log(ctx, "user", userID, "step_up", auditDetails); err != nil {
+			utils.Error("failed to log step-up audit",
+				"user_id", userID,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// SessionRevoked reports whether a login session was revoked. A session that does not exist counts
+// as revoked, so tokens outliving their session are rejected.
+func (s *AuthServiceImpl) SessionRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	return loadLocal(s.local, loginSessionKey(sessionID), func() (bool, error) {
+		session, err := s.repos.LoginSessions.GetByID(ctx, sessionID)
+		if err != nil {
+			if err.Error() == "login session not found" {
+				return true, nil
+			}
+			return false, err
+		}
+		return session.RevokedAt != nil, nil
+	})
+}
+
+// loginSessionKey is the local cache key holding whether a login session was revoked.
+func loginSessionKey(sessionID uuid.UUID) string {
+	return "login_session:" + sessionID.String()
+}
+
+// ListActiveImpersonations retrieves impersonation sessions that have not expired.
+func (s *AuthServiceImpl) ListActiveImpersonations(ctx context.Context) ([]*domain.ImpersonationSession, error) {
+	sessions, err := s.repos.ImpersonationSessions.ListActive(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impersonation sessions: %w", err)
+	}
+
+	return sessions, nil
+}
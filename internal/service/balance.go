@@ -3,17 +3,20 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
+	"golang.org/x/sync/singleflight"
 )
 
 // BalanceServiceImpl implements the BalanceService interface.
 type BalanceServiceImpl struct {
 	repos *repository.Repositories
-	cache CacheService // Optional cache service
+	cache CacheService       // Optional cache service
+	loads singleflight.Group // Coalesces concurrent cache-miss loads per user
 }
 
 // NewBalanceService creates a new balance service.
@@ -33,21 +36,35 @@ func (s *BalanceServiceImpl) SetCacheService(cache CacheService) {
 func (s *BalanceServiceImpl) GetCurrent(ctx context.Context, userID uuid.UUID) (*domain.BalanceResponse, error) {
 	// Try cache first if available
 	if s.cache != nil {
-		cachedBalance, err := s.cache.GetCachedBalance(ctx, userID)
+		cachedBalance, stale, err := s.cache.GetCachedBalance(ctx, userID)
 		if err == nil {
-			utils.Info("cache hit for balance", "user_id", userID.String())
+			if stale {
+				// Serve the stale balance now and refresh it for later reads
+				s.refresh(ctx, userID)
+			}
 			return cachedBalance, nil
 		}
 		// Cache miss or error - continue to database
-		utils.Info("cache miss for balance", "user_id", userID.String())
 	}
 
-	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	// Concurrent misses for the same user share a single database load
+	result, err, _ := s.loads.Do(userID.String(), func() (interface{}, error) {
+		return s.load(ctx, userID)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
 
-	response := balance.ToResponse()
+	response := result.(*domain.Balance).ToResponse()
+	return &response, nil
+}
+
+// load reads a user's balance from the database and caches it.
+func (s *BalanceServiceImpl) load(ctx context.Context, userID uuid.UUID) (*domain.Balance, error) {
+	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Cache the result if cache is available
 	if s.cache != nil {
@@ -57,7 +74,24 @@ func (s *BalanceServiceImpl) GetCurrent(ctx context.Context, userID uuid.UUID) (
 		}
 	}
 
-	return &response, nil
+	return balance, nil
+}
+
+// refresh reloads a stale cached balance in the background. It shares the load of concurrent
+// cache misses and refreshes of the same user, and outlives the request that triggered it.
+func (s *BalanceServiceImpl) refresh(ctx context.Context, userID uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+	s.loads.DoChan(userID.String(), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, cacheRefreshTimeout)
+		defer cancel()
+
+		balance, err := s.load(ctx, userID)
+		if err != nil {
+			utils.Warn("failed to refresh cached balance", "user_id", userID.String(), "error", err.Error())
+			return nil, err
+		}
+		return balance, nil
+	})
 }
 
 // GetHistorical retrieves historical balance snapshots.
@@ -82,6 +116,38 @@ func (s *BalanceServiceImpl) GetAtTime(ctx context.Context, userID uuid.UUID, ti
 	return &response, nil
 }
 
+// GetTimeline retrieves the balance at from, at every interval after it and at to, computed from
+// the user's transactions.
+func (s *BalanceServiceImpl) GetTimeline(ctx context.Context, userID uuid.UUID, from, to time.Time, interval domain.TimelineInterval) (*domain.BalanceTimeline, error) {
+	times, err := domain.TimelinePoints(from, to, interval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// The timeline is in the currency the user's balance is held in
+	current, err := s.GetCurrent(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.repos.Balances.GetTimeline(ctx, userID, times)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance timeline: %w", err)
+	}
+	for i := range points {
+		points[i].Amount = domain.RoundAmount(points[i].Amount, current.Currency)
+	}
+
+	return &domain.BalanceTimeline{
+		UserID:   userID,
+		Currency: current.Currency,
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Points:   points,
+	}, nil
+}
+
 // Initialize creates an initial balance for a new user.
 func (s *BalanceServiceImpl) Initialize(ctx context.Context, userID uuid.UUID, initialAmount float64, currency string) error {
 	// Validate currency
@@ -100,13 +166,8 @@ func (s *BalanceServiceImpl) Initialize(ctx context.Context, userID uuid.UUID, i
 		return fmt.Errorf("failed to initialize balance: %w", err)
 	}
 
-	// Invalidate any cached balance for this user since we created a new one
-	if s.cache != nil {
-		if err := s.cache.InvalidateBalanceCache(ctx, userID); err != nil {
-			utils.Error("failed to invalidate balance cache during initialization", "user_id", userID.String(), "error", err.Error())
-			// Don't fail the operation if cache invalidation fails
-		}
-	}
+	// Write the new balance through to the cache so the first read doesn't miss
+	writeThroughBalance(ctx, s.cache, balance)
 
 	// Log the balance initialization for audit
 	if s.repos.Audit != nil {
@@ -117,9 +178,44 @@ func (s *BalanceServiceImpl) Initialize(ctx context.Context, userID uuid.UUID, i
 		}
 		if err := s.repos.Audit.Log(ctx, "balance", userID, "initialize", auditDetails); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to log balance initialize audit: %v\n", err)
+			utils.ErrorContext(ctx, "failed to log balance initialize audit", "error", err.Error())
 		}
 	}
 
 	return nil
 }
+
+// writeThroughBalance caches a balance just written to the database, so reads after a mutation
+// don't all miss. If the entry cannot be written it is invalidated instead, so a stale balance
+// is never served. Caching failures never fail the mutation.
+func writeThroughBalance(ctx context.Context, cache CacheService, balance *domain.Balance) {
+	if cache == nil {
+		return
+	}
+
+	if err := cache.CacheBalance(ctx, balance); err != nil {
+		utils.Error("failed to write balance through to cache", "user_id", balance.UserID.String(), "error", err.Error())
+		if err := cache.InvalidateBalanceCache(ctx, balance.UserID); err != nil {
+			utils.Error("failed to invalidate balance cache", "user_id", balance.UserID.String(), "error", err.Error())
+		}
+	}
+}
+
+// refreshBalanceCache writes a user's stored balance through to the cache, for mutations such as
+// transfers that change balances in the database without knowing the result.
+func refreshBalanceCache(ctx context.Context, cache CacheService, balances repository.BalancesRepo, userID uuid.UUID) {
+	if cache == nil {
+		return
+	}
+
+	balance, err := balances.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.Error("failed to load balance for cache", "user_id", userID.String(), "error", err.Error())
+		if err := cache.InvalidateBalanceCache(ctx, userID); err != nil {
+			utils.Error("failed to invalidate balance cache", "user_id", userID.String(), "error", err.Error())
+		}
+		return
+	}
+
+	writeThroughBalance(ctx, cache, balance)
+}
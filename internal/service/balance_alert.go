@@ -0,0 +1,158 @@
+// Package service provides business logic for low-balance alerts.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// BalanceAlertServiceImpl implements BalanceAlertService.
+type BalanceAlertServiceImpl struct {
+	repos    *repository.Repositories
+	eventSvc *EventService // Optional; publishes LowBalanceAlert events
+	notifier Notifier      // Optional; notifies users of low balances
+}
+
+// NewBalanceAlertService creates a new balance alert service.
+func NewBalanceAlertService(repos *repository.Repositories, eventSvc *EventService, notifier Notifier) BalanceAlertService {
+	return &BalanceAlertServiceImpl{
+		repos:    repos,
+		eventSvc: eventSvc,
+		notifier: notifier,
+	}
+}
+
+// SetThreshold creates or changes the user's alert threshold for a currency and re-arms the alert.
+func (s *BalanceAlertServiceImpl) SetThreshold(ctx context.Context, userID uuid.UUID, currency string, req *domain.SetBalanceAlertRequest) (*domain.BalanceAlert, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	currency = strings.ToUpper(currency)
+	if !domain.IsValidCurrency(currency) {
+		return nil, fmt.Errorf("invalid request: currency: unsupported currency: %s", currency)
+	}
+
+	now := time.Now()
+	alert := &domain.BalanceAlert{
+		UserID:    userID,
+		Currency:  currency,
+		Threshold: req.Threshold,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repos.BalanceAlerts.Upsert(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to set balance alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// List retrieves the user's alert thresholds.
+func (s *BalanceAlertServiceImpl) List(ctx context.Context, userID uuid.UUID) ([]*domain.BalanceAlert, error) {
+	alerts, err := s.repos.BalanceAlerts.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list balance alerts: %w", err)
+	}
+
+	if alerts == nil {
+		alerts = []*domain.BalanceAlert{}
+	}
+
+	return alerts, nil
+}
+
+// Delete removes the user's alert threshold for a currency.
+func (s *BalanceAlertServiceImpl) Delete(ctx context.Context, userID uuid.UUID, currency string) error {
+	err := s.repos.BalanceAlerts.Delete(ctx, userID, strings.ToUpper(currency))
+	if err != nil {
+		if err.Error() == "balance alert not found" {
+			return err
+		}
+		return fmt.Errorf("failed to delete balance alert: %w", err)
+	}
+
+	return nil
+}
+
+// CheckBalance evaluates the user's alert after a balance movement. A debit that leaves the
+// balance below the threshold fires the alert once; it fires again only after the balance has
+// recovered to the re-arm level. Failures are logged because the money has already moved.
+func (s *BalanceAlertServiceImpl) CheckBalance(ctx context.Context, userID uuid.UUID, debited bool, transactionID uuid.UUID) {
+	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	if err != nil {
+		utils.WarnContext(ctx, "failed to get balance for alert check",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	alert, err := s.repos.BalanceAlerts.Get(ctx, userID, balance.Currency)
+	if err != nil {
+		if err.Error() != "balance alert not found" {
+			utils.WarnContext(ctx, "failed to get balance alert",
+				"user_id", userID.String(),
+				"error", err.Error(),
+			)
+		}
+		return
+	}
+
+	switch alert.Evaluate(balance.Amount, debited) {
+	case domain.BalanceAlertTriggered:
+		changed, err := s.repos.BalanceAlerts.SetTriggered(ctx, userID, alert.Currency, true, time.Now())
+		if err != nil {
+			utils.WarnContext(ctx, "failed to trigger balance alert",
+				"user_id", userID.String(),
+				"error", err.Error(),
+			)
+			return
+		}
+		if changed {
+			s.alert(ctx, alert, balance.Amount, transactionID)
+		}
+	case domain.BalanceAlertRearmed:
+		if _, err := s.repos.BalanceAlerts.SetTriggered(ctx, userID, alert.Currency, false, time.Now()); err != nil {
+			utils.WarnContext(ctx, "failed to re-arm balance alert",
+				"user_id", userID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// alert publishes the LowBalanceAlert event and notifies the user of a fired alert.
+func (s *BalanceAlertServiceImpl) alert(ctx context.Context, alert *domain.BalanceAlert, balance float64, transactionID uuid.UUID) {
+	if s.eventSvc != nil {
+		if err := s.eventSvc.LowBalanceAlert(ctx, alert, balance, transactionID); err != nil {
+			utils.WarnContext(ctx, "failed to publish low balance event",
+				"user_id", alert.UserID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+
+	if s.notifier != nil {
+		data := map[string]interface{}{
+			"currency":       alert.Currency,
+			"balance":        balance,
+			"threshold":      alert.Threshold,
+			"transaction_id": transactionID.String(),
+		}
+		if err := s.notifier.Notify(ctx, alert.UserID, domain.NotificationLowBalance, data); err != nil {
+			utils.WarnContext(ctx, "failed to queue low balance notification",
+				"user_id", alert.UserID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+}
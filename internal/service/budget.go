@@ -0,0 +1,203 @@
+// Package service provides business logic for category budgets.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// BudgetServiceImpl implements BudgetService. Budgets are monthly limits on what a user spends in
+// a category; spending is counted from the user's categorized debits and outgoing transfers.
+type BudgetServiceImpl struct {
+	repos    *repository.Repositories
+	notifier Notifier // Optional; notifies users who go over a budget
+}
+
+// NewBudgetService creates a new budget service.
+func NewBudgetService(repos *repository.Repositories, notifier Notifier) BudgetService {
+	return &BudgetServiceImpl{
+		repos:    repos,
+		notifier: notifier,
+	}
+}
+
+// Set creates or changes the user's monthly budget for a category. Changing the limit re-arms the
+// over-budget notification, so going over the new limit is reported even in the same month.
+func (s *BudgetServiceImpl) Set(ctx context.Context, userID uuid.UUID, category string, req *domain.SetBudgetRequest) (*domain.Budget, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	category = strings.ToLower(category)
+	if !domain.IsTransactionCategory(category) {
+		return nil, fmt.Errorf("invalid request: category must be one of %s, got %q", strings.Join(domain.TransactionCategories, ", "), category)
+	}
+
+	now := time.Now()
+	budget := &domain.Budget{
+		UserID:    userID,
+		Category:  category,
+		Limit:     req.Limit,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repos.Budgets.Upsert(ctx, budget); err != nil {
+		return nil, fmt.Errorf("failed to set budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+// List retrieves the user's budgets.
+func (s *BudgetServiceImpl) List(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error) {
+	budgets, err := s.repos.Budgets.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	if budgets == nil {
+		budgets = []*domain.Budget{}
+	}
+
+	return budgets, nil
+}
+
+// Delete removes the user's budget for a category.
+func (s *BudgetServiceImpl) Delete(ctx context.Context, userID uuid.UUID, category string) error {
+	err := s.repos.Budgets.Delete(ctx, userID, strings.ToLower(category))
+	if err != nil {
+		if err.Error() == "budget not found" {
+			return err
+		}
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return nil
+}
+
+// Spending reports the user's spending of a yyyy-mm month by category against their budgets. An
+// empty month is the current one.
+func (s *BudgetServiceImpl) Spending(ctx context.Context, userID uuid.UUID, month string) (*domain.SpendingReport, error) {
+	if month == "" {
+		month = domain.SummaryMonth(time.Now())
+	}
+	start, end, err := domain.ParseSummaryMonth(month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	spent, err := s.spending(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending: %w", err)
+	}
+
+	budgets, err := s.repos.Budgets.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	return domain.BuildSpendingReport(userID, month, balance.Currency, spent, budgets), nil
+}
+
+// CheckBudget evaluates the sender's budget for the category of a completed transaction of a
+// spending type, e.g. a debit or transfer.
+// The first time in a month spending goes over the limit, the user is notified. Failures are
+// logged because the money has already moved.
+func (s *BudgetServiceImpl) CheckBudget(ctx context.Context, tx *domain.Transaction) {
+	if tx.Category == "" || !tx.IsSpending() {
+		return
+	}
+	userID := *tx.FromUserID
+
+	budget, err := s.repos.Budgets.Get(ctx, userID, tx.Category)
+	if err != nil {
+		if err.Error() != "budget not found" {
+			utils.WarnContext(ctx, "failed to get budget",
+				"user_id", userID.String(),
+				"category", tx.Category,
+				"error", err.Error(),
+			)
+		}
+		return
+	}
+
+	month := domain.SummaryMonth(tx.CreatedAt)
+	if budget.AlertedMonth == month {
+		return
+	}
+
+	start, end, err := domain.ParseSummaryMonth(month)
+	if err != nil {
+		return
+	}
+	spent, err := s.spending(ctx, userID, start, end)
+	if err != nil {
+		utils.WarnContext(ctx, "failed to get spending for budget check",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+	if spent[tx.Category] <= budget.Limit {
+		return
+	}
+
+	marked, err := s.repos.Budgets.MarkAlerted(ctx, userID, tx.Category, month)
+	if err != nil {
+		utils.WarnContext(ctx, "failed to mark budget alerted",
+			"user_id", userID.String(),
+			"category", tx.Category,
+			"error", err.Error(),
+		)
+		return
+	}
+	if marked {
+		s.alert(ctx, budget, month, spent[tx.Category], tx)
+	}
+}
+
+// alert notifies the user that they went over a budget.
+func (s *BudgetServiceImpl) alert(ctx context.Context, budget *domain.Budget, month string, spent float64, tx *domain.Transaction) {
+	if s.notifier == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"category":       budget.Category,
+		"month":          month,
+		"currency":       tx.Currency,
+		"spent":          spent,
+		"limit":          budget.Limit,
+		"transaction_id": tx.ID.String(),
+	}
+	if err := s.notifier.Notify(ctx, budget.UserID, domain.NotificationBudgetExceeded, data); err != nil {
+		utils.WarnContext(ctx, "failed to queue budget exceeded notification",
+			"user_id", budget.UserID.String(),
+			"category", budget.Category,
+			"error", err.Error(),
+		)
+	}
+}
+
+// spending sums a user's spending of the months in [start, end) by category from their monthly
+// transaction aggregates.
+func (s *BudgetServiceImpl) spending(ctx context.Context, userID uuid.UUID, start, end time.Time) (map[string]float64, error) {
+	aggregates, err := s.repos.TransactionAggregates.ListMonthly(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SpendingByCategory(aggregates), nil
+}
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/featureflags"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
@@ -15,12 +20,12 @@ import (
 type CacheService interface {
 	// User cache operations
 	CacheUser(ctx context.Context, user *domain.User) error
-	GetCachedUser(ctx context.Context, userID uuid.UUID) (*domain.UserResponse, error)
+	GetCachedUser(ctx context.Context, userID uuid.UUID) (*domain.UserResponse, bool, error) // Also reports whether the user is past its soft TTL
 	InvalidateUserCache(ctx context.Context, userID uuid.UUID) error
 
 	// Balance cache operations
 	CacheBalance(ctx context.Context, balance *domain.Balance) error
-	GetCachedBalance(ctx context.Context, userID uuid.UUID) (*domain.BalanceResponse, error)
+	GetCachedBalance(ctx context.Context, userID uuid.UUID) (*domain.BalanceResponse, bool, error) // Also reports whether the balance is past its soft TTL
 	InvalidateBalanceCache(ctx context.Context, userID uuid.UUID) error
 
 	// Transaction cache operations
@@ -28,6 +33,10 @@ type CacheService interface {
 	GetCachedTransaction(ctx context.Context, transactionID uuid.UUID) (*domain.TransactionResponse, error)
 	InvalidateTransactionCache(ctx context.Context, transactionID uuid.UUID) error
 	InvalidateTransactionHistoryCache(ctx context.Context, userID uuid.UUID) error
+	CacheTransactionHistory(ctx context.Context, userID uuid.UUID, limit int, history []*domain.TransactionResponse) error
+	GetCachedTransactionHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.TransactionResponse, error)
+	CacheTransactionStats(ctx context.Context, stats *domain.TransactionStats) error
+	GetCachedTransactionStats(ctx context.Context, window string) (*domain.TransactionStats, error)
 
 	// Session operations
 	CacheSession(ctx context.Context, sessionID string, userID uuid.UUID, expiration time.Duration) error
@@ -44,46 +53,173 @@ type CacheService interface {
 	CacheMultipleUsers(ctx context.Context, users []*domain.User) error
 	CacheMultipleBalances(ctx context.Context, balances []*domain.Balance) error
 
+	// Cache warming
+	MarkCacheWarmed(ctx context.Context, entityType string, entityID string) error
+	IsCacheWarmed(ctx context.Context, entityType string, entityID string) (bool, error)
+
 	// Health and stats
 	Health(ctx context.Context) error
-	GetCacheStats(ctx context.Context) (map[string]int64, error)
+	GetCacheStats(ctx context.Context) (*CacheStats, error)
+}
+
+// CacheMetrics defines the metrics recorded for cache lookups
+type CacheMetrics interface {
+	RecordCacheHit(entity string)
+	RecordCacheStale(entity string)
+	RecordCacheMiss(entity string)
+	RecordCacheError(entity string)
 }
 
 // cacheServiceImpl provides caching functionality for the banking application
 type cacheServiceImpl struct {
 	redisClient *repository.RedisClient
+	metrics     CacheMetrics // Optional cache lookup metrics
+	flags       FeatureFlags // Optional; the caching flag turns entity caching off
+	ttls        CacheTTLs
+	local       *LocalCache // Optional; holds transaction history versions in process
 }
 
-// NewCacheService creates a new cache service
-func NewCacheService(redisClient *repository.RedisClient) CacheService {
+// CacheTTLs are the TTLs of cached balances and users. Entries past their soft TTL are still
+// served, flagged as stale so the caller refreshes them in the background; entries past their
+// hard TTL are evicted by Redis.
+type CacheTTLs struct {
+	BalanceSoft time.Duration
+	BalanceHard time.Duration
+	UserSoft    time.Duration
+	UserHard    time.Duration
+}
+
+// NewCacheService creates a new cache service. metrics, flags and local may be nil.
+func NewCacheService(redisClient *repository.RedisClient, metrics CacheMetrics, flags FeatureFlags, ttls CacheTTLs, local *LocalCache) CacheService {
 	return &cacheServiceImpl{
 		redisClient: redisClient,
+		metrics:     metrics,
+		flags:       flags,
+		ttls:        ttls,
+		local:       local,
+	}
+}
+
+// errCachingDisabled is returned by entity lookups while the caching flag is off.
+var errCachingDisabled = fmt.Errorf("%w: caching disabled", repository.ErrCacheMiss)
+
+// cachingEnabled reports whether entities are read from and written to the cache. Invalidations,
+// sessions and rate limits ignore the flag, so turning it back on never serves stale entries.
+func (c *cacheServiceImpl) cachingEnabled(ctx context.Context) bool {
+	return c.flags == nil || c.flags.Enabled(ctx, featureflags.Caching)
+}
+
+// store caches an entity unless caching is disabled
+func (c *cacheServiceImpl) store(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if !c.cachingEnabled(ctx) {
+		return nil
 	}
+	return c.redisClient.Set(ctx, key, value, ttl)
+}
+
+// ttlJitterFraction is the maximum fraction by which entry TTLs are randomly shortened or extended
+const ttlJitterFraction = 0.1
+
+// jitterTTL randomizes a TTL by up to ±ttlJitterFraction so entries cached together don't expire together
+func jitterTTL(ttl time.Duration) time.Duration {
+	spread := int64(float64(ttl) * ttlJitterFraction)
+	if spread <= 0 {
+		return ttl
+	}
+	return ttl - time.Duration(spread) + time.Duration(rand.Int64N(2*spread+1))
+}
+
+// Cache entity labels for lookup metrics
+const (
+	cacheEntityUser             = "user"
+	cacheEntityBalance          = "balance"
+	cacheEntityTransaction      = "transaction"
+	cacheEntityTransactionStats = "transaction_stats"
+	cacheEntityHistory          = "transaction_history"
+	cacheEntitySession          = "session"
+)
+
+// lookup reads a cached value and records a hit, miss or error for the entity type
+func (c *cacheServiceImpl) lookup(ctx context.Context, entity string, key string, dest interface{}) error {
+	if entity != cacheEntitySession && !c.cachingEnabled(ctx) {
+		return errCachingDisabled
+	}
+
+	err := c.redisClient.Get(ctx, key, dest)
+
+	switch {
+	case err == nil:
+		utils.Debug("cache hit", "entity", entity, "key", key)
+		if c.metrics != nil {
+			c.metrics.RecordCacheHit(entity)
+		}
+	case errors.Is(err, repository.ErrCacheMiss):
+		utils.Debug("cache miss", "entity", entity, "key", key)
+		if c.metrics != nil {
+			c.metrics.RecordCacheMiss(entity)
+		}
+	default:
+		utils.Warn("cache lookup failed", "entity", entity, "key", key, "error", err.Error())
+		if c.metrics != nil {
+			c.metrics.RecordCacheError(entity)
+		}
+	}
+
+	return err
 }
 
 // User cache operations
 const (
 	userCachePrefix    = "user:"
-	userCacheTTL       = 30 * time.Minute
 	balanceCachePrefix = "balance:"
-	balanceCacheTTL    = 10 * time.Minute
 )
 
+// cacheRefreshTimeout bounds background refreshes of stale entries
+const cacheRefreshTimeout = 5 * time.Second
+
+// softEntry is a cached value with the time it goes stale. Redis evicts it at the hard TTL.
+type softEntry[T any] struct {
+	Value      T         `json:"value"`
+	FreshUntil time.Time `json:"fresh_until"`
+}
+
+// storeSoft caches value so it is fresh for softTTL and evicted after a jittered hardTTL
+func storeSoft[T any](ctx context.Context, c *cacheServiceImpl, key string, value T, softTTL, hardTTL time.Duration) error {
+	entry := softEntry[T]{Value: value, FreshUntil: time.Now().Add(jitterTTL(softTTL))}
+	return c.store(ctx, key, entry, jitterTTL(hardTTL))
+}
+
+// lookupSoft reads a value cached with storeSoft and reports whether it is past its soft TTL.
+// Entries written before soft TTLs were introduced have no freshness and are treated as misses.
+func lookupSoft[T any](ctx context.Context, c *cacheServiceImpl, entity string, key string) (*T, bool, error) {
+	var entry softEntry[T]
+	if err := c.lookup(ctx, entity, key, &entry); err != nil {
+		return nil, false, err
+	}
+	if entry.FreshUntil.IsZero() {
+		return nil, false, repository.ErrCacheMiss
+	}
+
+	stale := time.Now().After(entry.FreshUntil)
+	if stale {
+		utils.Debug("cache entry stale", "entity", entity, "key", key)
+		if c.metrics != nil {
+			c.metrics.RecordCacheStale(entity)
+		}
+	}
+	return &entry.Value, stale, nil
+}
+
 // CacheUser caches user information
 func (c *cacheServiceImpl) CacheUser(ctx context.Context, user *domain.User) error {
 	key := userCachePrefix + user.ID.String()
-	return c.redisClient.Set(ctx, key, user.ToResponse(), userCacheTTL)
+	return storeSoft(ctx, c, key, user.ToResponse(), c.ttls.UserSoft, c.ttls.UserHard)
 }
 
-// GetCachedUser retrieves a cached user
-func (c *cacheServiceImpl) GetCachedUser(ctx context.Context, userID uuid.UUID) (*domain.UserResponse, error) {
+// GetCachedUser retrieves a cached user and reports whether it should be refreshed
+func (c *cacheServiceImpl) GetCachedUser(ctx context.Context, userID uuid.UUID) (*domain.UserResponse, bool, error) {
 	key := userCachePrefix + userID.String()
-	var user domain.UserResponse
-	err := c.redisClient.Get(ctx, key, &user)
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
+	return lookupSoft[domain.UserResponse](ctx, c, cacheEntityUser, key)
 }
 
 // InvalidateUserCache removes user from cache
@@ -95,18 +231,13 @@ func (c *cacheServiceImpl) InvalidateUserCache(ctx context.Context, userID uuid.
 // CacheBalance caches balance information
 func (c *cacheServiceImpl) CacheBalance(ctx context.Context, balance *domain.Balance) error {
 	key := balanceCachePrefix + balance.UserID.String()
-	return c.redisClient.Set(ctx, key, balance.ToResponse(), balanceCacheTTL)
+	return storeSoft(ctx, c, key, balance.ToResponse(), c.ttls.BalanceSoft, c.ttls.BalanceHard)
 }
 
-// GetCachedBalance retrieves a cached balance
-func (c *cacheServiceImpl) GetCachedBalance(ctx context.Context, userID uuid.UUID) (*domain.BalanceResponse, error) {
+// GetCachedBalance retrieves a cached balance and reports whether it should be refreshed
+func (c *cacheServiceImpl) GetCachedBalance(ctx context.Context, userID uuid.UUID) (*domain.BalanceResponse, bool, error) {
 	key := balanceCachePrefix + userID.String()
-	var balance domain.BalanceResponse
-	err := c.redisClient.Get(ctx, key, &balance)
-	if err != nil {
-		return nil, err
-	}
-	return &balance, nil
+	return lookupSoft[domain.BalanceResponse](ctx, c, cacheEntityBalance, key)
 }
 
 // InvalidateBalanceCache removes balance from cache
@@ -149,8 +280,10 @@ func (c *cacheServiceImpl) InvalidateTransactionRelatedCache(ctx context.Context
 		keysToDelete = append(keysToDelete,
 			userCachePrefix+userIDStr,
 			balanceCachePrefix+userIDStr,
-			transactionHistoryPrefix+userIDStr,
 		)
+		if err := c.InvalidateTransactionHistoryCache(ctx, *transaction.FromUserID); err != nil {
+			return err
+		}
 	}
 
 	if transaction.ToUserID != nil {
@@ -158,8 +291,10 @@ func (c *cacheServiceImpl) InvalidateTransactionRelatedCache(ctx context.Context
 		keysToDelete = append(keysToDelete,
 			userCachePrefix+userIDStr,
 			balanceCachePrefix+userIDStr,
-			transactionHistoryPrefix+userIDStr,
 		)
+		if err := c.InvalidateTransactionHistoryCache(ctx, *transaction.ToUserID); err != nil {
+			return err
+		}
 	}
 
 	if len(keysToDelete) > 0 {
@@ -174,19 +309,26 @@ const (
 	transactionCachePrefix   = "transaction:"
 	transactionHistoryPrefix = "transaction_history:"
 	transactionCacheTTL      = 15 * time.Minute
+
+	// History entries are keyed by a per-user version that is bumped on every
+	// transaction affecting the user, so invalidation is a single INCR and
+	// stale entries simply expire. Versions are also held in the local cache,
+	// which every instance drops when the version is bumped.
+	transactionHistoryVersionPrefix = "transaction_history_version:"
+	transactionHistoryTTL           = 5 * time.Minute
 )
 
 // CacheTransaction caches transaction information
 func (c *cacheServiceImpl) CacheTransaction(ctx context.Context, transaction *domain.Transaction) error {
 	key := transactionCachePrefix + transaction.ID.String()
-	return c.redisClient.Set(ctx, key, transaction.ToResponse(), transactionCacheTTL)
+	return c.store(ctx, key, transaction.ToResponse(), jitterTTL(transactionCacheTTL))
 }
 
 // GetCachedTransaction retrieves a cached transaction
 func (c *cacheServiceImpl) GetCachedTransaction(ctx context.Context, transactionID uuid.UUID) (*domain.TransactionResponse, error) {
 	key := transactionCachePrefix + transactionID.String()
 	var transaction domain.TransactionResponse
-	err := c.redisClient.Get(ctx, key, &transaction)
+	err := c.lookup(ctx, cacheEntityTransaction, key, &transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -199,10 +341,78 @@ func (c *cacheServiceImpl) InvalidateTransactionCache(ctx context.Context, trans
 	return c.redisClient.Del(ctx, key)
 }
 
-// InvalidateTransactionHistoryCache removes transaction history cache for a user
+// InvalidateTransactionHistoryCache invalidates all cached history pages for a user by bumping their history version
 func (c *cacheServiceImpl) InvalidateTransactionHistoryCache(ctx context.Context, userID uuid.UUID) error {
-	key := transactionHistoryPrefix + userID.String()
-	return c.redisClient.Del(ctx, key)
+	key := transactionHistoryVersionPrefix + userID.String()
+	if _, err := c.redisClient.Incr(ctx, key); err != nil {
+		return err
+	}
+	c.local.Invalidate(ctx, key)
+	return nil
+}
+
+// CacheTransactionHistory caches the first page of a user's unfiltered transaction history
+func (c *cacheServiceImpl) CacheTransactionHistory(ctx context.Context, userID uuid.UUID, limit int, history []*domain.TransactionResponse) error {
+	key, err := c.transactionHistoryKey(ctx, userID, limit)
+	if err != nil {
+		return err
+	}
+	return c.store(ctx, key, history, jitterTTL(transactionHistoryTTL))
+}
+
+// GetCachedTransactionHistory retrieves the cached first page of a user's unfiltered transaction history
+func (c *cacheServiceImpl) GetCachedTransactionHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.TransactionResponse, error) {
+	key, err := c.transactionHistoryKey(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*domain.TransactionResponse
+	if err := c.lookup(ctx, cacheEntityHistory, key, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// transactionHistoryKey builds the versioned history key for a user and page size
+func (c *cacheServiceImpl) transactionHistoryKey(ctx context.Context, userID uuid.UUID, limit int) (string, error) {
+	versionKey := transactionHistoryVersionPrefix + userID.String()
+	version, err := loadLocal(c.local, versionKey, func() (int64, error) {
+		var version int64
+		err := c.redisClient.Get(ctx, versionKey, &version)
+		if errors.Is(err, repository.ErrCacheMiss) {
+			return 0, nil
+		}
+		return version, err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s:v%d:limit%d", transactionHistoryPrefix, userID.String(), version, limit), nil
+}
+
+// Transaction stats cache operations
+const (
+	transactionStatsPrefix = "transaction_stats:"
+	transactionStatsTTL    = 1 * time.Minute
+)
+
+// CacheTransactionStats caches aggregate transaction statistics for a window
+func (c *cacheServiceImpl) CacheTransactionStats(ctx context.Context, stats *domain.TransactionStats) error {
+	key := transactionStatsPrefix + stats.Window
+	return c.store(ctx, key, stats, transactionStatsTTL)
+}
+
+// GetCachedTransactionStats retrieves cached aggregate transaction statistics for a window
+func (c *cacheServiceImpl) GetCachedTransactionStats(ctx context.Context, window string) (*domain.TransactionStats, error) {
+	key := transactionStatsPrefix + window
+	var stats domain.TransactionStats
+	err := c.lookup(ctx, cacheEntityTransactionStats, key, &stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
 }
 
 // Session cache operations
@@ -224,7 +434,7 @@ func (c *cacheServiceImpl) CacheSession(ctx context.Context, sessionID string, u
 func (c *cacheServiceImpl) GetCachedSession(ctx context.Context, sessionID string) (uuid.UUID, error) {
 	key := sessionCachePrefix + sessionID
 	var sessionData map[string]interface{}
-	err := c.redisClient.Get(ctx, key, &sessionData)
+	err := c.lookup(ctx, cacheEntitySession, key, &sessionData)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -333,30 +543,106 @@ func (c *cacheServiceImpl) Health(ctx context.Context) error {
 }
 
 // Statistics
-// GetCacheStats returns basic cache statistics
-func (c *cacheServiceImpl) GetCacheStats(ctx context.Context) (map[string]int64, error) {
-	stats := make(map[string]int64)
+const (
+	statsScanBatchSize = 500    // Keys requested per SCAN call
+	statsScanMaxKeys   = 100000 // Per-prefix budget before counting stops
+)
 
-	// Count users in cache
-	userKeys, err := c.redisClient.Keys(ctx, userCachePrefix+"*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user keys: %w", err)
+// CacheStats represents cache key counts and Redis server statistics
+type CacheStats struct {
+	CachedUsers        int64                    `json:"cached_users"`
+	CachedBalances     int64                    `json:"cached_balances"`
+	CachedTransactions int64                    `json:"cached_transactions"`
+	CountsComplete     bool                     `json:"counts_complete"`
+	UsedMemoryBytes    int64                    `json:"used_memory_bytes"`
+	UsedMemoryPeak     int64                    `json:"used_memory_peak_bytes"`
+	MaxMemoryBytes     int64                    `json:"maxmemory_bytes"`
+	KeyspaceHits       int64                    `json:"keyspace_hits"`
+	KeyspaceMisses     int64                    `json:"keyspace_misses"`
+	ExpiredKeys        int64                    `json:"expired_keys"`
+	EvictedKeys        int64                    `json:"evicted_keys"`
+	Keyspace           map[string]KeyspaceStats `json:"keyspace"`
+}
+
+// KeyspaceStats represents the INFO keyspace entry for a single Redis database
+type KeyspaceStats struct {
+	Keys    int64 `json:"keys"`
+	Expires int64 `json:"expires"`
+	AvgTTL  int64 `json:"avg_ttl_ms"`
+}
+
+// GetCacheStats returns cache key counts (via SCAN with a count budget) and memory/keyspace stats from INFO
+func (c *cacheServiceImpl) GetCacheStats(ctx context.Context) (*CacheStats, error) {
+	stats := &CacheStats{
+		CountsComplete: true,
+		Keyspace:       make(map[string]KeyspaceStats),
 	}
-	stats["cached_users"] = int64(len(userKeys))
 
-	// Count balances in cache
-	balanceKeys, err := c.redisClient.Keys(ctx, balanceCachePrefix+"*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to get balance keys: %w", err)
+	counts := []struct {
+		prefix string
+		dest   *int64
+	}{
+		{userCachePrefix, &stats.CachedUsers},
+		{balanceCachePrefix, &stats.CachedBalances},
+		{transactionCachePrefix, &stats.CachedTransactions},
+	}
+
+	for _, cnt := range counts {
+		count, complete, err := c.redisClient.CountKeys(ctx, cnt.prefix+"*", statsScanBatchSize, statsScanMaxKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s keys: %w", strings.TrimSuffix(cnt.prefix, ":"), err)
+		}
+		*cnt.dest = count
+		stats.CountsComplete = stats.CountsComplete && complete
 	}
-	stats["cached_balances"] = int64(len(balanceKeys))
 
-	// Count transactions in cache
-	transactionKeys, err := c.redisClient.Keys(ctx, transactionCachePrefix+"*")
+	info, err := c.redisClient.Info(ctx, "memory", "stats", "keyspace")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction keys: %w", err)
+		return nil, err
+	}
+
+	stats.UsedMemoryBytes = parseInfoInt(info["used_memory"])
+	stats.UsedMemoryPeak = parseInfoInt(info["used_memory_peak"])
+	stats.MaxMemoryBytes = parseInfoInt(info["maxmemory"])
+	stats.KeyspaceHits = parseInfoInt(info["keyspace_hits"])
+	stats.KeyspaceMisses = parseInfoInt(info["keyspace_misses"])
+	stats.ExpiredKeys = parseInfoInt(info["expired_keys"])
+	stats.EvictedKeys = parseInfoInt(info["evicted_keys"])
+
+	for key, value := range info {
+		if strings.HasPrefix(key, "db") {
+			stats.Keyspace[key] = parseKeyspaceStats(value)
+		}
 	}
-	stats["cached_transactions"] = int64(len(transactionKeys))
 
 	return stats, nil
 }
+
+// parseInfoInt parses an integer INFO field, returning 0 if missing or malformed
+func parseInfoInt(value string) int64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// parseKeyspaceStats parses an INFO keyspace value such as "keys=12,expires=10,avg_ttl=3000"
+func parseKeyspaceStats(value string) KeyspaceStats {
+	var ks KeyspaceStats
+	for _, field := range strings.Split(value, ",") {
+		name, v, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "keys":
+			ks.Keys = parseInfoInt(v)
+		case "expires":
+			ks.Expires = parseInfoInt(v)
+		case "avg_ttl":
+			ks.AvgTTL = parseInfoInt(v)
+		}
+	}
+	return ks
+}
@@ -0,0 +1,151 @@
+// Package service provides an in-process cache kept coherent across instances.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// cacheInvalidationChannel is the Redis pub/sub channel local cache invalidations are broadcast on.
+const cacheInvalidationChannel = "cache:invalidate"
+
+// cacheInvalidation names the keys every instance drops from its local cache.
+type cacheInvalidation struct {
+	Keys []string `json:"keys"`
+}
+
+// LocalCache keeps hot entries in process memory in front of Redis, saving a round trip on every
+// read. Invalidations are broadcast on Redis pub/sub so every instance drops the entry promptly;
+// the TTL bounds how long an entry outlives an invalidation that was missed, e.g. while
+// reconnecting to Redis. All methods are safe to call on a nil cache, which caches nothing.
+type LocalCache struct {
+	redis *repository.RedisClient
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]localEntry
+	epoch   uint64    // Bumped on every invalidation, so loads racing one are not cached
+	swept   time.Time // When expired entries were last removed
+}
+
+// localEntry is a locally cached value with the time it expires.
+type localEntry struct {
+	value   any
+	expires time.Time
+}
+
+// NewLocalCache creates a local cache whose entries live for ttl.
+func NewLocalCache(redis *repository.RedisClient, ttl time.Duration) *LocalCache {
+	return &LocalCache{
+		redis:   redis,
+		ttl:     ttl,
+		entries: make(map[string]localEntry),
+	}
+}
+
+// loadLocal returns the locally cached value of key, or loads it with load and caches it. A value
+// loaded while any key was invalidated is returned but not cached, as it may predate the
+// invalidation.
+func loadLocal[T any](l *LocalCache, key string, load func() (T, error)) (T, error) {
+	if l == nil {
+		return load()
+	}
+
+	l.mu.Lock()
+	entry, ok := l.entries[key]
+	epoch := l.epoch
+	l.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		if value, ok := entry.value.(T); ok {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	l.mu.Lock()
+	if l.epoch == epoch {
+		l.entries[key] = localEntry{value: value, expires: time.Now().Add(l.ttl)}
+	}
+	l.mu.Unlock()
+	return value, nil
+}
+
+// Invalidate drops keys from this instance and broadcasts the invalidation to the others. Call it
+// after changing the entries in Redis. A failed broadcast is logged; other instances then serve
+// the old entries until they expire.
+func (l *LocalCache) Invalidate(ctx context.Context, keys ...string) {
+	if l == nil || len(keys) == 0 {
+		return
+	}
+
+	l.drop(keys)
+
+	if l.redis != nil {
+		if err := l.redis.Publish(ctx, cacheInvalidationChannel, cacheInvalidation{Keys: keys}); err != nil {
+			utils.WarnContext(ctx, "failed to broadcast cache invalidation", "keys", keys, "error", err.Error())
+		}
+	}
+}
+
+// Run drops the keys other instances invalidate until ctx is cancelled. It returns immediately
+// when the cache has no Redis client.
+func (l *LocalCache) Run(ctx context.Context) {
+	if l == nil || l.redis == nil {
+		return
+	}
+
+	pubsub := l.redis.Subscribe(ctx, cacheInvalidationChannel)
+	defer func() {
+		_ = pubsub.Close()
+	}()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var invalidation cacheInvalidation
+			if err := json.Unmarshal([]byte(msg.Payload), &invalidation); err != nil {
+				utils.Warn("ignoring malformed cache invalidation", "error", err.Error())
+				continue
+			}
+			l.drop(invalidation.Keys)
+		}
+	}
+}
+
+// drop removes keys, and once per TTL every expired entry.
+func (l *LocalCache) drop(keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.epoch++
+	for _, key := range keys {
+		delete(l.entries, key)
+	}
+
+	now := time.Now()
+	if now.Sub(l.swept) < l.ttl {
+		return
+	}
+	l.swept = now
+	for key, entry := range l.entries {
+		if !now.Before(entry.expires) {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+const (
+	// cacheWarmupEntity and cacheWarmupScope identify the warm-up marker key.
+	// The marker expires with the warm-up TTL and disappears on a cache flush,
+	// so its absence means the cache needs warming again.
+	cacheWarmupEntity = "active_users"
+	cacheWarmupScope  = "all"
+
+	// cacheWarmupLookback is how far back transactions are considered when ranking users by activity.
+	cacheWarmupLookback = 7 * 24 * time.Hour
+	// cacheWarmupUserLimit is the maximum number of users preloaded per warm-up.
+	cacheWarmupUserLimit = 100
+)
+
+// CacheWarmupServiceImpl implements the CacheWarmupService interface.
+type CacheWarmupServiceImpl struct {
+	repos *repository.Repositories
+	cache CacheService
+}
+
+// NewCacheWarmupService creates a new cache warm-up service.
+func NewCacheWarmupService(repos *repository.Repositories, cache CacheService) CacheWarmupService {
+	return &CacheWarmupServiceImpl{
+		repos: repos,
+		cache: cache,
+	}
+}
+
+// WarmUp preloads the profiles and balances of the users with the most recent transactions.
+func (s *CacheWarmupServiceImpl) WarmUp(ctx context.Context) (int, error) {
+	userIDs, err := s.repos.Transactions.ListMostActiveUserIDs(ctx, time.Now().Add(-cacheWarmupLookback), cacheWarmupUserLimit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active users: %w", err)
+	}
+
+	users := make([]*domain.User, 0, len(userIDs))
+	balances := make([]*domain.Balance, 0, len(userIDs))
+	for _, userID := range userIDs {
+		user, err := s.repos.Users.GetByID(ctx, userID)
+		if err != nil {
+			// Deleted or inactive users are skipped rather than failing the warm-up
+			utils.Debug("skipping user during cache warm-up", "user_id", userID.String(), "error", err.Error())
+			continue
+		}
+		users = append(users, user)
+
+		balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+		if err != nil {
+			utils.Debug("skipping balance during cache warm-up", "user_id", userID.String(), "error", err.Error())
+			continue
+		}
+		balances = append(balances, balance)
+	}
+
+	if err := s.cache.CacheMultipleUsers(ctx, users); err != nil {
+		return 0, fmt.Errorf("failed to cache users: %w", err)
+	}
+	if err := s.cache.CacheMultipleBalances(ctx, balances); err != nil {
+		return 0, fmt.Errorf("failed to cache balances: %w", err)
+	}
+
+	if err := s.cache.MarkCacheWarmed(ctx, cacheWarmupEntity, cacheWarmupScope); err != nil {
+		return len(users), fmt.Errorf("failed to mark cache warmed: %w", err)
+	}
+
+	return len(users), nil
+}
+
+// WarmIfCold warms the cache unless the warm-up marker is still present.
+func (s *CacheWarmupServiceImpl) WarmIfCold(ctx context.Context) error {
+	warmed, err := s.cache.IsCacheWarmed(ctx, cacheWarmupEntity, cacheWarmupScope)
+	if err != nil {
+		return fmt.Errorf("failed to check cache warm-up marker: %w", err)
+	}
+	if warmed {
+		return nil
+	}
+
+	count, err := s.WarmUp(ctx)
+	if err != nil {
+		return err
+	}
+
+	utils.Info("cache warmed", "users", count)
+	return nil
+}
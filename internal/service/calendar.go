@@ -0,0 +1,33 @@
+// Package service provides the business day calendar scheduled transactions execute by.
+package service
+
+import (
+	"context"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+)
+
+// CalendarServiceImpl implements CalendarService.
+type CalendarServiceImpl struct {
+	calendar *domain.BusinessCalendar
+}
+
+// NewCalendarService creates a new calendar service.
+func NewCalendarService(calendar *domain.BusinessCalendar) CalendarService {
+	return &CalendarServiceImpl{calendar: calendar}
+}
+
+// Calendar returns the calendar scheduled transactions are moved to business days by.
+func (s *CalendarServiceImpl) Calendar() *domain.BusinessCalendar {
+	return s.calendar
+}
+
+// Region describes the weekends and holidays of a region; the empty region is the default one.
+func (s *CalendarServiceImpl) Region(_ context.Context, region string) (*domain.CalendarRegion, error) {
+	return s.calendar.Region(region)
+}
+
+// Regions lists the known regions in alphabetical order.
+func (s *CalendarServiceImpl) Regions(_ context.Context) []string {
+	return s.calendar.Regions()
+}
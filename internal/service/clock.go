@@ -0,0 +1,51 @@
+// Package service provides the simulation clock admins fast-forward.
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/clock"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// ClockServiceImpl implements ClockService.
+type ClockServiceImpl struct {
+	repos *repository.Repositories
+	clock *clock.Simulated
+}
+
+// NewClockService creates a new clock service fast-forwarding clock.
+func NewClockService(repos *repository.Repositories, clock *clock.Simulated) ClockService {
+	return &ClockServiceImpl{repos: repos, clock: clock}
+}
+
+// State reports the time the simulation clock shows.
+func (s *ClockServiceImpl) State() *domain.ClockState {
+	return &domain.ClockState{
+		Now:    s.clock.Now().UTC(),
+		Offset: s.clock.Offset().String(),
+	}
+}
+
+// Advance fast-forwards the simulation clock. Features running on it catch up on their next
+// cycle, one occurrence of a recurring scheduled transaction per cycle.
+func (s *ClockServiceImpl) Advance(ctx context.Context, actorID uuid.UUID, req *domain.ClockAdvanceRequest) (*domain.ClockState, error) {
+	advance, err := domain.ParseClockAdvance(req.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if err := s.clock.Advance(advance); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	state := s.State()
+	_ = s.repos.Audit.Log(ctx, "user", actorID, "clock_advanced", map[string]interface{}{
+		"duration": advance.String(),
+		"offset":   state.Offset,
+	})
+
+	return state, nil
+}
@@ -3,10 +3,40 @@ package service
 
 // Compile-time checks to ensure all service implementations satisfy their interfaces.
 var (
-	_ AuthService        = (*authService)(nil)
-	_ UserService        = (*UserServiceImpl)(nil)
-	_ BalanceService     = (*BalanceServiceImpl)(nil)
-	_ TransactionService = (*TransactionServiceImpl)(nil)
+	_ AuthService              = (*AuthServiceImpl)(nil)
+	_ UserService              = (*UserServiceImpl)(nil)
+	_ BalanceService           = (*BalanceServiceImpl)(nil)
+	_ TransactionService       = (*TransactionServiceImpl)(nil)
+	_ CacheWarmupService       = (*CacheWarmupServiceImpl)(nil)
+	_ RequestLogService        = (*RequestLogServiceImpl)(nil)
+	_ TransactionExportService = (*TransactionExportServiceImpl)(nil)
+	_ TransactionStatusService = (*TransactionStatusServiceImpl)(nil)
+	_ PaymentRequestService    = (*PaymentRequestServiceImpl)(nil)
+	_ TransferTemplateService  = (*TransferTemplateServiceImpl)(nil)
+	_ ContactService           = (*ContactServiceImpl)(nil)
+	_ NotificationService      = (*NotificationServiceImpl)(nil)
+	_ BalanceAlertService      = (*BalanceAlertServiceImpl)(nil)
+	_ BudgetService            = (*BudgetServiceImpl)(nil)
+	_ DormancyService          = (*DormancyServiceImpl)(nil)
+	_ SearchService            = (*SearchServiceImpl)(nil)
+	_ APIUsageService          = (*APIUsageServiceImpl)(nil)
+	_ TreasuryService          = (*TreasuryServiceImpl)(nil)
+	_ CurrencyService          = (*CurrencyServiceImpl)(nil)
+	_ CalendarService          = (*CalendarServiceImpl)(nil)
+	_ ReceiptService           = (*ReceiptServiceImpl)(nil)
+	_ PaymentQRService         = (*PaymentQRServiceImpl)(nil)
+	_ InvariantService         = (*InvariantServiceImpl)(nil)
+	_ IndexAdvisorService      = (*IndexAdvisorServiceImpl)(nil)
+	_ SimulationService        = (*SimulationServiceImpl)(nil)
+	_ ClockService             = (*ClockServiceImpl)(nil)
+	_ NettingService           = (*TransactionServiceImpl)(nil)
+	_ SecurityService          = (*SecurityServiceImpl)(nil)
+	_ MoneyMovementPolicy      = (*AnomalyPolicy)(nil)
+	_ ApprovalService          = (*ApprovalServiceImpl)(nil)
+	_ PolicyService            = (*PolicyServiceImpl)(nil)
+	_ MonthlySummaryService    = (*MonthlySummaryServiceImpl)(nil)
+	_ ForecastService          = (*ForecastServiceImpl)(nil)
+	_ ActivityService          = (*ActivityServiceImpl)(nil)
 )
 
 // These ensure that concrete types implement the expected interfaces.
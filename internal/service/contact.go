@@ -0,0 +1,176 @@
+// Package service provides business logic for contacts.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// ContactServiceImpl implements ContactService.
+type ContactServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewContactService creates a new contact service.
+func NewContactService(repos *repository.Repositories) ContactService {
+	return &ContactServiceImpl{repos: repos}
+}
+
+// List returns the user's saved contacts, in the order they were saved, followed by up to
+// recentLimit other users the user recently transferred to, most recent first. Saved contacts
+// carry their transfer statistics too. Deactivated users are left out.
+func (s *ContactServiceImpl) List(ctx context.Context, userID uuid.UUID, recentLimit int) ([]*domain.ContactEntry, error) {
+	contacts, err := s.repos.Contacts.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	// Saved contacts may be among the recent counterparties, so fetch enough to fill the recent ones
+	counterparties, err := s.repos.Transactions.ListRecentCounterparties(ctx, userID, recentLimit+len(contacts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent counterparties: %w", err)
+	}
+	byUser := make(map[uuid.UUID]*domain.Counterparty, len(counterparties))
+	for _, counterparty := range counterparties {
+		byUser[counterparty.UserID] = counterparty
+	}
+
+	entries := []*domain.ContactEntry{}
+	saved := make(map[uuid.UUID]bool, len(contacts))
+	for _, contact := range contacts {
+		saved[contact.ContactUserID] = true
+
+		entry, ok := s.entryFor(ctx, contact.ContactUserID, byUser[contact.ContactUserID])
+		if !ok {
+			continue
+		}
+		entry.Saved = true
+		entry.ContactID = &contact.ID
+		entry.Nickname = contact.Nickname
+		entries = append(entries, entry)
+	}
+
+	recent := 0
+	for _, counterparty := range counterparties {
+		if recent == recentLimit {
+			break
+		}
+		if saved[counterparty.UserID] {
+			continue
+		}
+
+		entry, ok := s.entryFor(ctx, counterparty.UserID, counterparty)
+		if !ok {
+			continue
+		}
+		entries = append(entries, entry)
+		recent++
+	}
+
+	return entries, nil
+}
+
+// Create saves another user, given by ID or account number, as one of the user's contacts.
+func (s *ContactServiceImpl) Create(ctx context.Context, userID uuid.UUID, req *domain.CreateContactRequest) (*domain.Contact, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	contactUserID, err := s.resolveContact(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if contactUserID == userID {
+		return nil, fmt.Errorf("cannot add self as contact")
+	}
+
+	contact := &domain.Contact{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ContactUserID: contactUserID,
+		Nickname:      strings.TrimSpace(req.Nickname),
+		CreatedAt:     time.Now(),
+	}
+
+	if err := s.repos.Contacts.Create(ctx, contact); err != nil {
+		if err.Error() == "contact already exists" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create contact: %w", err)
+	}
+
+	return contact, nil
+}
+
+// Delete removes one of the user's saved contacts. Transfers to the user keep them among the
+// recent counterparties.
+func (s *ContactServiceImpl) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	contact, err := s.repos.Contacts.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("contact not found")
+	}
+
+	if contact.UserID != userID {
+		return fmt.Errorf("access denied: not owner of contact")
+	}
+
+	if err := s.repos.Contacts.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+
+	return nil
+}
+
+// resolveContact returns the ID of the active user a create request names.
+func (s *ContactServiceImpl) resolveContact(ctx context.Context, req *domain.CreateContactRequest) (uuid.UUID, error) {
+	var (
+		user *domain.User
+		err  error
+	)
+	if strings.TrimSpace(req.AccountNumber) != "" {
+		number, parseErr := domain.ParseAccountNumber(req.AccountNumber)
+		if parseErr != nil {
+			return uuid.Nil, fmt.Errorf("invalid request: %w", parseErr)
+		}
+		user, err = s.repos.Users.GetByAccountNumber(ctx, number)
+		if err == nil && req.ContactUserID != uuid.Nil && req.ContactUserID != user.ID {
+			return uuid.Nil, fmt.Errorf("invalid request: contact_user_id does not match account_number")
+		}
+	} else {
+		user, err = s.repos.Users.GetByID(ctx, req.ContactUserID)
+	}
+
+	if err != nil || !user.IsActive {
+		return uuid.Nil, fmt.Errorf("contact user not found")
+	}
+
+	return user.ID, nil
+}
+
+// entryFor builds the contact entry of an active user, reporting false if the user is gone.
+func (s *ContactServiceImpl) entryFor(ctx context.Context, userID uuid.UUID, counterparty *domain.Counterparty) (*domain.ContactEntry, bool) {
+	user, err := s.repos.Users.GetByID(ctx, userID)
+	if err != nil || !user.IsActive {
+		return nil, false
+	}
+
+	entry := &domain.ContactEntry{
+		UserID:        user.ID,
+		Username:      user.Username,
+		AccountNumber: user.AccountNumber,
+	}
+	if counterparty != nil {
+		entry.TransferCount = counterparty.TransferCount
+		lastTransferAt := counterparty.LastTransferAt
+		entry.LastTransferAt = &lastTransferAt
+	}
+
+	return entry, true
+}
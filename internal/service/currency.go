@@ -0,0 +1,157 @@
+// Package service provides business logic for the currency registry.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// CurrencyServiceImpl implements CurrencyService. The registry is persisted in the currencies
+// table; validation and formatting read the copy Refresh loads into the domain package.
+type CurrencyServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewCurrencyService creates a new currency service.
+func NewCurrencyService(repos *repository.Repositories) CurrencyService {
+	return &CurrencyServiceImpl{repos: repos}
+}
+
+// List retrieves the currencies of the registry. Disabled currencies are left out unless includeDisabled is set.
+func (s *CurrencyServiceImpl) List(ctx context.Context, includeDisabled bool) ([]*domain.CurrencyInfo, error) {
+	currencies, err := s.repos.Currencies.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list currencies: %w", err)
+	}
+
+	listed := make([]*domain.CurrencyInfo, 0, len(currencies))
+	for _, currency := range currencies {
+		if currency.Enabled || includeDisabled {
+			listed = append(listed, currency)
+		}
+	}
+
+	return listed, nil
+}
+
+// Create adds a currency to the registry and opens its treasury.
+func (s *CurrencyServiceImpl) Create(ctx context.Context, actorID uuid.UUID, req *domain.CreateCurrencyRequest) (*domain.CurrencyInfo, error) {
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	now := time.Now()
+	currency := &domain.CurrencyInfo{
+		Code:          req.Code,
+		Symbol:        strings.TrimSpace(req.Symbol),
+		DecimalPlaces: *req.DecimalPlaces,
+		Enabled:       req.Enabled == nil || *req.Enabled,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.repos.Currencies.Create(ctx, currency); err != nil {
+		if err.Error() == "currency already exists" {
+			return nil, fmt.Errorf("invalid request: code: currency already exists")
+		}
+		return nil, fmt.Errorf("failed to create currency: %w", err)
+	}
+
+	s.refreshAfterChange(ctx)
+	s.audit(ctx, currency.Code, domain.ActionCreated, map[string]interface{}{
+		"currency":       currency.Code,
+		"actor_id":       actorID,
+		"symbol":         currency.Symbol,
+		"decimal_places": currency.DecimalPlaces,
+		"enabled":        currency.Enabled,
+	})
+
+	return currency, nil
+}
+
+// Update changes the symbol, decimal places or enabled flag of a currency. Disabling a currency
+// keeps its balances but rejects new requests in it.
+func (s *CurrencyServiceImpl) Update(ctx context.Context, actorID uuid.UUID, code string, req *domain.UpdateCurrencyRequest) (*domain.CurrencyInfo, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	currency, err := s.repos.Currencies.Get(ctx, strings.ToUpper(code))
+	if err != nil {
+		if err.Error() == "currency not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get currency: %w", err)
+	}
+
+	req.Apply(currency)
+	currency.UpdatedAt = time.Now()
+
+	if err := s.repos.Currencies.Update(ctx, currency); err != nil {
+		if err.Error() == "currency not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update currency: %w", err)
+	}
+
+	s.refreshAfterChange(ctx)
+	s.audit(ctx, currency.Code, domain.ActionUpdated, map[string]interface{}{
+		"currency":       currency.Code,
+		"actor_id":       actorID,
+		"symbol":         req.Symbol,
+		"decimal_places": req.DecimalPlaces,
+		"enabled":        req.Enabled,
+	})
+
+	return currency, nil
+}
+
+// Refresh loads the persisted registry into the domain package, so validation and formatting
+// see currencies changed by any instance.
+func (s *CurrencyServiceImpl) Refresh(ctx context.Context) error {
+	currencies, err := s.repos.Currencies.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list currencies: %w", err)
+	}
+
+	registry := make([]domain.CurrencyInfo, 0, len(currencies))
+	for _, currency := range currencies {
+		registry = append(registry, *currency)
+	}
+	domain.SetCurrencies(registry)
+
+	return nil
+}
+
+// refreshAfterChange applies a committed registry change to this instance. Other instances pick
+// it up on their next refresh, so a failure is logged rather than returned.
+func (s *CurrencyServiceImpl) refreshAfterChange(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil {
+		utils.WarnContext(ctx, "failed to refresh currency registry", "error", err.Error())
+	}
+}
+
+// audit records a registry change in the audit log under the currency's treasury account, since
+// currencies have no ID of their own. The change is already committed, so a failure is logged
+// rather than returned.
+func (s *CurrencyServiceImpl) audit(ctx context.Context, code string, action domain.AuditAction, details map[string]interface{}) {
+	account, err := s.repos.Treasury.GetAccount(ctx, code)
+	if err == nil {
+		err = s.repos.Audit.Log(ctx, string(domain.EntityCurrency), account.ID, string(action), details)
+	}
+	if err != nil {
+		utils.WarnContext(ctx, "failed to audit currency change",
+			"currency", code,
+			"action", string(action),
+			"error", err.Error(),
+		)
+	}
+}
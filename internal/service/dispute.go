@@ -0,0 +1,245 @@
+// Package service provides business logic for transaction disputes.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// DisputeServiceImpl implements DisputeService.
+type DisputeServiceImpl struct {
+	repos          *repository.Repositories
+	transactionSvc TransactionService
+	eventSvc       *EventService // Event service for publishing domain events
+}
+
+// NewDisputeService creates a new dispute service.
+func NewDisputeService(repos *repository.Repositories, transactionSvc TransactionService, eventSvc *EventService) DisputeService {
+	return &DisputeServiceImpl{
+		repos:          repos,
+		transactionSvc: transactionSvc,
+		eventSvc:       eventSvc,
+	}
+}
+
+// Open opens a dispute on a completed transaction the user took part in.
+func (s *DisputeServiceImpl) Open(ctx context.Context, transactionID uuid.UUID, userID uuid.UUID, req *domain.OpenDisputeRequest) (*domain.Dispute, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	transaction, err := s.repos.Transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction not found")
+	}
+
+	isParticipant := (transaction.FromUserID != nil && *transaction.FromUserID == userID) ||
+		(transaction.ToUserID != nil && *transaction.ToUserID == userID)
+	if !isParticipant {
+		return nil, fmt.Errorf("access denied: not a participant in transaction")
+	}
+
+	if transaction.Status != string(domain.StatusSuccess) {
+		return nil, fmt.Errorf("can only dispute completed transactions")
+	}
+
+	if _, err := s.repos.Disputes.GetOpenByTransactionID(ctx, transactionID); err == nil {
+		return nil, fmt.Errorf("transaction already has an open dispute")
+	}
+
+	now := time.Now()
+	dispute := &domain.Dispute{
+		ID:            uuid.New(),
+		TransactionID: transactionID,
+		UserID:        userID,
+		Reason:        req.Reason,
+		Status:        string(domain.DisputeStatusOpen),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.repos.Disputes.Create(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to create dispute: %w", err)
+	}
+
+	if s.eventSvc != nil {
+		if err := s.eventSvc.DisputeOpened(ctx, dispute); err != nil {
+			utils.Error("failed to publish dispute opened event", "error", err.Error())
+		}
+	}
+
+	_ = s.repos.Audit.Log(ctx, "transaction", transactionID, "dispute_opened", map[string]interface{}{
+		"dispute_id": dispute.ID,
+		"user_id":    userID,
+		"reason":     req.Reason,
+	})
+
+	return dispute, nil
+}
+
+// GetByID retrieves a dispute and its comments.
+func (s *DisputeServiceImpl) GetByID(ctx context.Context, id uuid.UUID, requestingUserID uuid.UUID, isAdmin bool) (*DisputeDetails, error) {
+	dispute, err := s.getAccessible(ctx, id, requestingUserID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repos.Disputes.ListComments(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispute comments: %w", err)
+	}
+
+	if comments == nil {
+		comments = []*domain.DisputeComment{}
+	}
+
+	return &DisputeDetails{
+		Dispute:  dispute,
+		Comments: comments,
+	}, nil
+}
+
+// List retrieves disputes. Regular users only see their own disputes.
+func (s *DisputeServiceImpl) List(ctx context.Context, requestingUserID uuid.UUID, isAdmin bool, filter *domain.DisputeFilter) ([]*domain.Dispute, error) {
+	if filter == nil {
+		filter = &domain.DisputeFilter{}
+	}
+
+	if !isAdmin {
+		filter.UserID = &requestingUserID
+	}
+
+	disputes, err := s.repos.Disputes.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disputes: %w", err)
+	}
+
+	return disputes, nil
+}
+
+// AddComment adds a comment to an unresolved dispute. The first admin comment
+// on an open dispute moves it under review.
+func (s *DisputeServiceImpl) AddComment(ctx context.Context, disputeID uuid.UUID, authorID uuid.UUID, isAdmin bool, req *domain.DisputeCommentRequest) (*domain.DisputeComment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	dispute, err := s.getAccessible(ctx, disputeID, authorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if dispute.IsClosed() {
+		return nil, fmt.Errorf("dispute is already resolved")
+	}
+
+	comment := &domain.DisputeComment{
+		ID:        uuid.New(),
+		DisputeID: disputeID,
+		AuthorID:  authorID,
+		IsAdmin:   isAdmin,
+		Body:      req.Body,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repos.Disputes.AddComment(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to add dispute comment: %w", err)
+	}
+
+	if isAdmin && dispute.Status == string(domain.DisputeStatusOpen) {
+		oldStatus := dispute.Status
+		dispute.Status = string(domain.DisputeStatusUnderReview)
+		dispute.UpdatedAt = time.Now()
+
+		if err := s.repos.Disputes.Update(ctx, dispute); err != nil {
+			return nil, fmt.Errorf("failed to update dispute: %w", err)
+		}
+
+		s.publishStatusChanged(ctx, dispute, oldStatus, authorID)
+	}
+
+	return comment, nil
+}
+
+// Resolve resolves a dispute by refunding the transaction or rejecting the dispute.
+func (s *DisputeServiceImpl) Resolve(ctx context.Context, disputeID uuid.UUID, adminID uuid.UUID, req *domain.ResolveDisputeRequest) (*domain.Dispute, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	dispute, err := s.repos.Disputes.GetByID(ctx, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("dispute not found")
+	}
+
+	if dispute.IsClosed() {
+		return nil, fmt.Errorf("dispute is already resolved")
+	}
+
+	oldStatus := dispute.Status
+
+	switch domain.DisputeResolution(req.Action) {
+	case domain.ResolutionRefund:
+		refund, err := s.transactionSvc.RollbackByAdmin(ctx, dispute.TransactionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund transaction: %w", err)
+		}
+		dispute.RefundTransactionID = &refund.ID
+		dispute.Status = string(domain.DisputeStatusRefunded)
+	case domain.ResolutionReject:
+		dispute.Status = string(domain.DisputeStatusRejected)
+	}
+
+	now := time.Now()
+	dispute.Resolution = req.Resolution
+	dispute.ResolvedBy = &adminID
+	dispute.ResolvedAt = &now
+	dispute.UpdatedAt = now
+
+	if err := s.repos.Disputes.Update(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	s.publishStatusChanged(ctx, dispute, oldStatus, adminID)
+
+	_ = s.repos.Audit.Log(ctx, "transaction", dispute.TransactionID, "dispute_resolved", map[string]interface{}{
+		"dispute_id":            dispute.ID,
+		"admin_id":              adminID,
+		"status":                dispute.Status,
+		"resolution":            dispute.Resolution,
+		"refund_transaction_id": dispute.RefundTransactionID,
+	})
+
+	return dispute, nil
+}
+
+// getAccessible loads a dispute and checks the requesting user may see it.
+func (s *DisputeServiceImpl) getAccessible(ctx context.Context, id uuid.UUID, requestingUserID uuid.UUID, isAdmin bool) (*domain.Dispute, error) {
+	dispute, err := s.repos.Disputes.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("dispute not found")
+	}
+
+	if !isAdmin && dispute.UserID != requestingUserID {
+		return nil, fmt.Errorf("access denied: not owner of dispute")
+	}
+
+	return dispute, nil
+}
+
+// publishStatusChanged publishes a DisputeStatusChanged event if events are enabled.
+func (s *DisputeServiceImpl) publishStatusChanged(ctx context.Context, dispute *domain.Dispute, oldStatus string, changedBy uuid.UUID) {
+	if s.eventSvc == nil {
+		return
+	}
+
+	if err := s.eventSvc.DisputeStatusChanged(ctx, dispute, oldStatus, changedBy); err != nil {
+		utils.Error("failed to publish dispute status changed event", "error", err.Error())
+	}
+}
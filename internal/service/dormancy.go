@@ -0,0 +1,163 @@
+// Package service provides reporting on dormant accounts.
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// dormancyBatchSize is how many dormant accounts are read at a time while exporting or flagging them.
+const dormancyBatchSize = 500
+
+// DormancyServiceImpl implements DormancyService. An account is dormant once its user has neither
+// sent nor received a transaction for a number of days; users who never had one count from the
+// creation of their account.
+type DormancyServiceImpl struct {
+	repos    *repository.Repositories
+	eventSvc *EventService // Optional; publishes AccountDormant events
+}
+
+// NewDormancyService creates a new dormancy service.
+func NewDormancyService(repos *repository.Repositories, eventSvc *EventService) DormancyService {
+	return &DormancyServiceImpl{
+		repos:    repos,
+		eventSvc: eventSvc,
+	}
+}
+
+// Report lists a page of the accounts dormant for at least days days, longest inactive first.
+func (s *DormancyServiceImpl) Report(ctx context.Context, days, limit, offset int) (*domain.DormantAccountsReport, error) {
+	if err := domain.ValidateDormancyDays(days); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	now := time.Now()
+	cutoff := domain.DormancyCutoff(now, days)
+
+	total, err := s.repos.DormantAccounts.Count(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dormant accounts: %w", err)
+	}
+
+	accounts, err := s.repos.DormantAccounts.List(ctx, cutoff, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dormant accounts: %w", err)
+	}
+	if accounts == nil {
+		accounts = []*domain.DormantAccount{}
+	}
+	for _, account := range accounts {
+		account.SetDaysInactive(now)
+	}
+
+	return &domain.DormantAccountsReport{
+		Days:     days,
+		Cutoff:   cutoff,
+		Total:    total,
+		Accounts: accounts,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+// WriteCSV writes every account dormant for at least days days to w as CSV, one account per row
+// after a header row, and returns the number of accounts written.
+func (s *DormancyServiceImpl) WriteCSV(ctx context.Context, w io.Writer, days int) (int, error) {
+	if err := domain.ValidateDormancyDays(days); err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(domain.DormantAccountCSVHeader); err != nil {
+		return 0, fmt.Errorf("failed to write dormant accounts: %w", err)
+	}
+
+	now := time.Now()
+	written, err := s.each(ctx, domain.DormancyCutoff(now, days), func(account *domain.DormantAccount) error {
+		account.SetDaysInactive(now)
+		return writer.Write(account.CSVRecord())
+	})
+	if err != nil {
+		return written, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return written, fmt.Errorf("failed to write dormant accounts: %w", err)
+	}
+
+	return written, nil
+}
+
+// FlagDormant publishes an AccountDormant event for every account that has become dormant for
+// days days since it was last flagged, and returns the number of events published. An account
+// is flagged once per period of inactivity, so running again only flags new ones.
+func (s *DormancyServiceImpl) FlagDormant(ctx context.Context, days int) (int, error) {
+	if s.eventSvc == nil {
+		return 0, nil
+	}
+	if err := domain.ValidateDormancyDays(days); err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// Flagging does not change the listing, so paging through it while flagging is safe
+	flagged, failed := 0, 0
+	_, err := s.each(ctx, domain.DormancyCutoff(time.Now(), days), func(account *domain.DormantAccount) error {
+		marked, err := s.repos.DormantAccounts.MarkFlagged(ctx, account.UserID, account.InactiveSince, time.Now())
+		if err != nil {
+			return err
+		}
+		if !marked {
+			return nil
+		}
+
+		if err := s.eventSvc.AccountDormant(ctx, account, days); err != nil {
+			utils.ErrorContext(ctx, "failed to publish account dormant event",
+				"user_id", account.UserID.String(),
+				"error", err.Error(),
+			)
+			failed++
+			return nil
+		}
+		flagged++
+		return nil
+	})
+	if err != nil {
+		return flagged, err
+	}
+
+	if failed > 0 {
+		return flagged, fmt.Errorf("failed to publish %d account dormant events", failed)
+	}
+	return flagged, nil
+}
+
+// each calls fn with every account inactive since before cutoff, longest inactive first, and
+// returns the number of accounts it was called with.
+func (s *DormancyServiceImpl) each(ctx context.Context, cutoff time.Time, fn func(*domain.DormantAccount) error) (int, error) {
+	count := 0
+	for offset := 0; ; offset += dormancyBatchSize {
+		accounts, err := s.repos.DormantAccounts.List(ctx, cutoff, dormancyBatchSize, offset)
+		if err != nil {
+			return count, fmt.Errorf("failed to list dormant accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			if err := fn(account); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(accounts) < dormancyBatchSize {
+			return count, nil
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/featureflags"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
@@ -14,6 +15,7 @@ import (
 // EventService handles event sourcing operations
 type EventService struct {
 	eventRepo repository.EventsRepo
+	flags     FeatureFlags // Optional; the event_publishing flag turns publishing off
 }
 
 // NewEventService creates a new event service
@@ -23,13 +25,29 @@ func NewEventService(eventRepo repository.EventsRepo) *EventService {
 	}
 }
 
-// PublishEvent publishes an event to the event store
+// SetFeatureFlags sets the feature flags that decide whether events are published.
+func (s *EventService) SetFeatureFlags(flags FeatureFlags) {
+	s.flags = flags
+}
+
+// publishingEnabled reports whether events should be appended to the event store.
+func (s *EventService) publishingEnabled(ctx context.Context) bool {
+	return s.flags == nil || s.flags.Enabled(ctx, featureflags.EventPublishing)
+}
+
+// PublishEvent publishes an event to the event store.
+// While event publishing is disabled the event is built but not stored.
 func (s *EventService) PublishEvent(ctx context.Context, aggregateType domain.AggregateType, aggregateID uuid.UUID, eventType domain.EventType, eventData interface{}, metadata *domain.EventMetadata) (*domain.Event, error) {
 	event, err := domain.NewEvent(aggregateType, aggregateID, eventType, eventData, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
 
+	if !s.publishingEnabled(ctx) {
+		utils.DebugContext(ctx, "event publishing disabled, skipping event", "event_type", eventType)
+		return event, nil
+	}
+
 	publishedEvent, err := s.eventRepo.AppendEvent(ctx, event)
 	if err != nil {
 		return nil, fmt.Errorf("failed to publish event: %w", err)
@@ -47,6 +65,11 @@ func (s *EventService) PublishEvent(ctx context.Context, aggregateType domain.Ag
 
 // PublishEvents publishes multiple events atomically
 func (s *EventService) PublishEvents(ctx context.Context, events []*domain.Event) error {
+	if !s.publishingEnabled(ctx) {
+		utils.DebugContext(ctx, "event publishing disabled, skipping events", "count", len(events))
+		return nil
+	}
+
 	err := s.eventRepo.AppendEvents(ctx, events)
 	if err != nil {
 		return fmt.Errorf("failed to publish events: %w", err)
@@ -56,6 +79,22 @@ func (s *EventService) PublishEvents(ctx context.Context, events []*domain.Event
 	return nil
 }
 
+// PublishEventsIn publishes events through the event store of repos, such as repositories bound to
+// a unit of work, so that they are stored only if it commits.
+func (s *EventService) PublishEventsIn(ctx context.Context, repos *repository.Repositories, events []*domain.Event) error {
+	if !s.publishingEnabled(ctx) {
+		utils.DebugContext(ctx, "event publishing disabled, skipping events", "count", len(events))
+		return nil
+	}
+
+	if err := repos.Events.AppendEvents(ctx, events); err != nil {
+		return fmt.Errorf("failed to publish events: %w", err)
+	}
+
+	utils.Info("events published", "count", len(events))
+	return nil
+}
+
 // GetAggregateEvents retrieves all events for an aggregate
 func (s *EventService) GetAggregateEvents(ctx context.Context, aggregateType domain.AggregateType, aggregateID uuid.UUID) ([]*domain.Event, error) {
 	return s.eventRepo.GetEventsByAggregate(ctx, aggregateType, aggregateID)
@@ -81,6 +120,87 @@ func (s *EventService) ReplayAggregateEvents(ctx context.Context, aggregateType
 	return envelopes, nil
 }
 
+const (
+	// eventQueryBatch is how many events QueryEvents reads from the event store at a time.
+	eventQueryBatch = 500
+	// maxEventQueryScan bounds how many events one QueryEvents call reads, so that a filter matching
+	// few events answers with a continuation instead of scanning the whole store.
+	maxEventQueryScan = 10000
+)
+
+// QueryEvents lists events matching the filter, oldest first. An aggregate's events are read
+// directly; other filters scan the store in creation order from the filter's start.
+func (s *EventService) QueryEvents(ctx context.Context, filter *domain.EventFilter) (*domain.EventPage, error) {
+	page := &domain.EventPage{Events: []domain.EventResponse{}}
+
+	if filter.AggregateID != nil {
+		if filter.AggregateType == nil {
+			return nil, fmt.Errorf("invalid request: aggregate_type is required with aggregate_id")
+		}
+
+		events, err := s.eventRepo.GetEventsByAggregate(ctx, *filter.AggregateType, *filter.AggregateID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query events: %w", err)
+		}
+		for _, event := range events {
+			if !filter.Matches(event) {
+				continue
+			}
+			if len(page.Events) == filter.Limit {
+				next := page.Events[len(page.Events)-1].CreatedAt
+				page.NextSince = &next
+				break
+			}
+			page.Events = append(page.Events, event.ToResponse())
+		}
+
+		page.Count = len(page.Events)
+		return page, nil
+	}
+
+	// GetEventsSince lists events created strictly after its argument
+	var after time.Time
+	if filter.Since != nil {
+		after = filter.Since.Add(-time.Nanosecond)
+	}
+
+	for scanned := 0; scanned < maxEventQueryScan; {
+		batch, err := s.eventRepo.GetEventsSince(ctx, after, eventQueryBatch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query events: %w", err)
+		}
+
+		for _, event := range batch {
+			if filter.Until != nil && !event.CreatedAt.Before(*filter.Until) {
+				page.Count = len(page.Events)
+				return page, nil
+			}
+			after = event.CreatedAt
+			if !filter.Matches(event) {
+				continue
+			}
+			page.Events = append(page.Events, event.ToResponse())
+			if len(page.Events) == filter.Limit {
+				next := event.CreatedAt
+				page.NextSince = &next
+				page.Count = len(page.Events)
+				return page, nil
+			}
+		}
+
+		scanned += len(batch)
+		if len(batch) < eventQueryBatch {
+			page.Count = len(page.Events)
+			return page, nil
+		}
+	}
+
+	// The scan limit was reached before the page filled up
+	page.NextSince = &after
+	page.Count = len(page.Events)
+	return page, nil
+}
+
 // GetEventsByType retrieves events by type
 func (s *EventService) GetEventsByType(ctx context.Context, eventType domain.EventType, limit int, offset int) ([]*domain.Event, error) {
 	return s.eventRepo.GetEventsByType(ctx, eventType, limit, offset)
@@ -115,6 +235,37 @@ func (s *EventService) UserRegistered(ctx context.Context, user *domain.User) er
 	return err
 }
 
+// RegistrationEvents builds the UserRegistered and BalanceInitialized events of a new user, to be
+// published together with the user and balance.
+func (s *EventService) RegistrationEvents(ctx context.Context, user *domain.User, balance *domain.Balance) ([]*domain.Event, error) {
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     getUserAgent(ctx),
+		IP:            getClientIP(ctx),
+	}
+
+	registered, err := domain.NewEvent(domain.AggregateUser, user.ID, domain.EventUserRegistered, &domain.UserRegisteredEvent{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Username: user.Username,
+		Role:     user.Role,
+	}, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	initialized, err := domain.NewEvent(domain.AggregateBalance, user.ID, domain.EventBalanceInitialized, &domain.BalanceInitializedEvent{
+		UserID:   user.ID,
+		Amount:   balance.Amount,
+		Currency: balance.Currency,
+	}, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return []*domain.Event{registered, initialized}, nil
+}
+
 // AmountCredited publishes an AmountCredited event
 func (s *EventService) AmountCredited(ctx context.Context, userID uuid.UUID, amount float64, currency string, transactionID uuid.UUID, reason string) error {
 	eventData := &domain.AmountCreditedEvent{
@@ -178,11 +329,15 @@ func (s *EventService) TransferExecuted(ctx context.Context, fromUserID, toUserI
 // TransactionStarted publishes a TransactionStarted event
 func (s *EventService) TransactionStarted(ctx context.Context, transactionID uuid.UUID, transaction *domain.Transaction) error {
 	eventData := &domain.TransactionStartedEvent{
-		TransactionID: transactionID,
-		FromUserID:    transaction.FromUserID,
-		ToUserID:      transaction.ToUserID,
-		Amount:        transaction.Amount,
-		Type:          transaction.Type,
+		TransactionID:   transactionID,
+		FromUserID:      transaction.FromUserID,
+		ToUserID:        transaction.ToUserID,
+		Amount:          transaction.Amount,
+		Type:            transaction.Type,
+		Description:     transaction.Description,
+		Category:        transaction.Category,
+		ClientReference: transaction.ClientReference,
+		Origin:          transaction.Origin(),
 	}
 
 	metadata := &domain.EventMetadata{
@@ -251,9 +406,158 @@ func (s *EventService) TransactionFailed(ctx context.Context, transactionID uuid
 	return err
 }
 
+// TransactionReversed publishes a TransactionReversed event on the aggregate of the original transaction
+func (s *EventService) TransactionReversed(ctx context.Context, rollbackTx *domain.Transaction, originalTx *domain.Transaction, reversedBy uuid.UUID) error {
+	eventData := &domain.TransactionReversedEvent{
+		TransactionID:         rollbackTx.ID,
+		OriginalTransactionID: originalTx.ID,
+		FromUserID:            rollbackTx.FromUserID,
+		ToUserID:              rollbackTx.ToUserID,
+		Amount:                rollbackTx.Amount,
+		Currency:              rollbackTx.Currency,
+		Type:                  rollbackTx.Type,
+		Partial:               rollbackTx.Amount < originalTx.Amount,
+	}
+	if reversedBy != uuid.Nil {
+		eventData.ReversedBy = &reversedBy
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     getUserAgent(ctx),
+		IP:            getClientIP(ctx),
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregateTransaction, originalTx.ID, domain.EventTransactionReversed, eventData, metadata)
+	return err
+}
+
+// DisputeOpened publishes a DisputeOpened event
+func (s *EventService) DisputeOpened(ctx context.Context, dispute *domain.Dispute) error {
+	eventData := &domain.DisputeOpenedEvent{
+		DisputeID:     dispute.ID,
+		TransactionID: dispute.TransactionID,
+		UserID:        dispute.UserID,
+		Reason:        dispute.Reason,
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     getUserAgent(ctx),
+		IP:            getClientIP(ctx),
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregateDispute, dispute.ID, domain.EventDisputeOpened, eventData, metadata)
+	return err
+}
+
+// DisputeStatusChanged publishes a DisputeStatusChanged event
+func (s *EventService) DisputeStatusChanged(ctx context.Context, dispute *domain.Dispute, oldStatus string, changedBy uuid.UUID) error {
+	eventData := &domain.DisputeStatusChangedEvent{
+		DisputeID:           dispute.ID,
+		TransactionID:       dispute.TransactionID,
+		OldStatus:           oldStatus,
+		NewStatus:           dispute.Status,
+		ChangedBy:           changedBy,
+		Resolution:          dispute.Resolution,
+		RefundTransactionID: dispute.RefundTransactionID,
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     getUserAgent(ctx),
+		IP:            getClientIP(ctx),
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregateDispute, dispute.ID, domain.EventDisputeStatusChanged, eventData, metadata)
+	return err
+}
+
+// PaymentRequestEvent publishes a payment request event of the given type
+func (s *EventService) PaymentRequestEvent(ctx context.Context, eventType domain.EventType, request *domain.PaymentRequest) error {
+	eventData := &domain.PaymentRequestEvent{
+		PaymentRequestID: request.ID,
+		RequesterID:      request.RequesterID,
+		PayerID:          request.PayerID,
+		Amount:           request.Amount,
+		Currency:         request.Currency,
+		Note:             request.Note,
+		Status:           request.Status,
+		TransactionID:    request.TransactionID,
+		ExpiresAt:        request.ExpiresAt,
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     getUserAgent(ctx),
+		IP:            getClientIP(ctx),
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregatePaymentRequest, request.ID, eventType, eventData, metadata)
+	return err
+}
+
+// LowBalanceAlert publishes a LowBalanceAlert event
+func (s *EventService) LowBalanceAlert(ctx context.Context, alert *domain.BalanceAlert, balance float64, transactionID uuid.UUID) error {
+	eventData := &domain.LowBalanceAlertEvent{
+		UserID:        alert.UserID,
+		Currency:      alert.Currency,
+		Balance:       balance,
+		Threshold:     alert.Threshold,
+		TransactionID: transactionID,
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     getUserAgent(ctx),
+		IP:            getClientIP(ctx),
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregateBalanceAlert, alert.UserID, domain.EventLowBalanceAlert, eventData, metadata)
+	return err
+}
+
+// LoginFromNewDevice publishes a LoginFromNewDevice event
+func (s *EventService) LoginFromNewDevice(ctx context.Context, session *domain.LoginSession) error {
+	eventData := &domain.LoginFromNewDeviceEvent{
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		IP:        session.IP,
+		UserAgent: session.UserAgent,
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+		UserAgent:     session.UserAgent,
+		IP:            session.IP,
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregateUser, session.UserID, domain.EventLoginFromNewDevice, eventData, metadata)
+	return err
+}
+
+// AccountDormant publishes an AccountDormant event
+func (s *EventService) AccountDormant(ctx context.Context, account *domain.DormantAccount, days int) error {
+	eventData := &domain.AccountDormantEvent{
+		UserID:         account.UserID,
+		Currency:       account.Currency,
+		Balance:        account.Balance,
+		LastActivityAt: account.LastActivityAt,
+		InactiveSince:  account.InactiveSince,
+		DormancyDays:   days,
+	}
+
+	metadata := &domain.EventMetadata{
+		CorrelationID: getCorrelationID(ctx),
+	}
+
+	_, err := s.PublishEvent(ctx, domain.AggregateUser, account.UserID, domain.EventAccountDormant, eventData, metadata)
+	return err
+}
+
 // Helper functions to extract context values
 func getCorrelationID(ctx context.Context) string {
-	if correlationID, ok := ctx.Value("correlation_id").(string); ok {
+	if correlationID := utils.CorrelationIDFromContext(ctx); correlationID != "" {
 		return correlationID
 	}
 	return uuid.New().String()
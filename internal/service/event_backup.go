@@ -0,0 +1,245 @@
+// Package service provides export of the event store to object storage and restores from it.
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/objectstore"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+const (
+	// eventExportBatch is the most events written to one export object.
+	eventExportBatch = 5000
+	// eventExportSettle is how old events must be before they are exported. Events are timestamped
+	// before their transaction commits, so a younger event may still be joined by earlier ones.
+	eventExportSettle = time.Minute
+	// maxEventBackupLine bounds the size of one exported event.
+	maxEventBackupLine = 16 << 20
+)
+
+// EventBackupServiceImpl implements EventBackupService.
+type EventBackupServiceImpl struct {
+	eventRepo repository.EventsRepo
+	store     objectstore.Store
+	prefix    string // Key prefix of exports and the checkpoint in the bucket
+	now       func() time.Time
+}
+
+// NewEventBackupService creates a new event backup service.
+func NewEventBackupService(eventRepo repository.EventsRepo, store objectstore.Store, prefix string) EventBackupService {
+	return &EventBackupServiceImpl{
+		eventRepo: eventRepo,
+		store:     store,
+		prefix:    prefix,
+		now:       time.Now,
+	}
+}
+
+// Export writes the events created since the last export to gzipped NDJSON objects, one
+// EventResponse per line and at most eventExportBatch events per object, and advances the
+// checkpoint after each object.
+func (s *EventBackupServiceImpl) Export(ctx context.Context) (*domain.EventExportRun, error) {
+	run := &domain.EventExportRun{Objects: []string{}}
+
+	checkpoint, err := s.loadCheckpoint(ctx)
+	if err != nil {
+		return run, err
+	}
+
+	until := s.now().Add(-eventExportSettle)
+	for {
+		batch, err := s.eventRepo.GetEventsSince(ctx, checkpoint.LastCreatedAt, eventExportBatch)
+		if err != nil {
+			return run, fmt.Errorf("failed to read events: %w", err)
+		}
+
+		full := len(batch) == eventExportBatch
+		settled := 0
+		for settled < len(batch) && batch[settled].CreatedAt.Before(until) {
+			settled++
+		}
+		caughtUp := !full || settled < len(batch)
+		batch = batch[:settled]
+
+		// The next export starts after the last exported timestamp, so a full batch must not end
+		// partway through events sharing one; a batch of nothing else is exported whole
+		if !caughtUp {
+			last := batch[len(batch)-1].CreatedAt
+			trimmed := len(batch)
+			for trimmed > 0 && batch[trimmed-1].CreatedAt.Equal(last) {
+				trimmed--
+			}
+			if trimmed > 0 {
+				batch = batch[:trimmed]
+			}
+		}
+
+		if len(batch) == 0 {
+			return run, nil
+		}
+
+		key, err := s.writeEvents(ctx, batch)
+		if err != nil {
+			return run, err
+		}
+
+		last := batch[len(batch)-1]
+		checkpoint = &domain.EventBackupCheckpoint{
+			LastCreatedAt: last.CreatedAt,
+			LastEventID:   last.ID,
+			LastObject:    key,
+			UpdatedAt:     s.now(),
+		}
+		if err := s.saveCheckpoint(ctx, checkpoint); err != nil {
+			return run, err
+		}
+
+		run.Objects = append(run.Objects, key)
+		run.Events += len(batch)
+		through := last.CreatedAt
+		run.Through = &through
+
+		if caughtUp {
+			return run, nil
+		}
+	}
+}
+
+// Restore imports every exported event in creation order. Events already in the event store are
+// skipped, so an interrupted restore can be run again; unless allowExisting is set, a restore
+// into an event store that holds events is refused.
+func (s *EventBackupServiceImpl) Restore(ctx context.Context, allowExisting bool) (*domain.EventRestoreRun, error) {
+	run := &domain.EventRestoreRun{}
+
+	if !allowExisting {
+		existing, err := s.eventRepo.GetEventsSince(ctx, time.Time{}, 1)
+		if err != nil {
+			return run, fmt.Errorf("failed to read events: %w", err)
+		}
+		if len(existing) > 0 {
+			return run, fmt.Errorf("refusing to restore into an event store that already holds events")
+		}
+	}
+
+	keys, err := s.store.List(ctx, domain.EventBackupPrefix(s.prefix))
+	if err != nil {
+		return run, fmt.Errorf("failed to list event exports: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return run, fmt.Errorf("restore interrupted after %d of %d objects: %w", run.Objects, len(keys), err)
+		}
+
+		events, err := s.readEvents(ctx, key)
+		if err != nil {
+			return run, err
+		}
+
+		imported, err := s.eventRepo.ImportEvents(ctx, events)
+		if err != nil {
+			return run, fmt.Errorf("failed to import %s: %w", key, err)
+		}
+
+		run.Objects++
+		run.EventsRead += len(events)
+		run.EventsImported += imported
+	}
+
+	return run, nil
+}
+
+// writeEvents stores events as one gzipped NDJSON object and returns its key.
+func (s *EventBackupServiceImpl) writeEvents(ctx context.Context, events []*domain.Event) (string, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, event := range events {
+		if err := encoder.Encode(event.ToResponse()); err != nil {
+			return "", fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress events: %w", err)
+	}
+
+	key := domain.EventBackupKey(s.prefix, events[0].CreatedAt)
+	if err := s.store.Put(ctx, key, buf.Bytes(), "application/gzip"); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	return key, nil
+}
+
+// readEvents reads the events of one export object.
+func (s *EventBackupServiceImpl) readEvents(ctx context.Context, key string) ([]*domain.Event, error) {
+	body, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", key, err)
+	}
+	defer gz.Close()
+
+	var events []*domain.Event
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), maxEventBackupLine)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var response domain.EventResponse
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			return nil, fmt.Errorf("failed to decode %s line %d: %w", key, line, err)
+		}
+		events = append(events, response.ToEvent())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	return events, nil
+}
+
+// loadCheckpoint reads the export checkpoint; without one, exports start from the first event.
+func (s *EventBackupServiceImpl) loadCheckpoint(ctx context.Context) (*domain.EventBackupCheckpoint, error) {
+	body, err := s.store.Get(ctx, domain.EventBackupCheckpointKey(s.prefix))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return &domain.EventBackupCheckpoint{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export checkpoint: %w", err)
+	}
+
+	var checkpoint domain.EventBackupCheckpoint
+	if err := json.Unmarshal(body, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode export checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// saveCheckpoint records how far events were exported.
+func (s *EventBackupServiceImpl) saveCheckpoint(ctx context.Context, checkpoint *domain.EventBackupCheckpoint) error {
+	body, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode export checkpoint: %w", err)
+	}
+
+	if err := s.store.Put(ctx, domain.EventBackupCheckpointKey(s.prefix), body, "application/json"); err != nil {
+		return fmt.Errorf("failed to write export checkpoint: %w", err)
+	}
+	return nil
+}
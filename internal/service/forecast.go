@@ -0,0 +1,73 @@
+// Package service provides cash-flow forecasts.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// forecastBatchSize is how many of a user's transactions are read at a time while detecting
+// recurring patterns.
+const forecastBatchSize = 100
+
+// ForecastServiceImpl implements ForecastService. Forecasts combine the current balance, the
+// projected executions of the user's scheduled transactions and the recurring patterns detected
+// in their recent history.
+type ForecastServiceImpl struct {
+	repos     *repository.Repositories
+	scheduled ScheduledTransactionService
+}
+
+// NewForecastService creates a new forecast service.
+func NewForecastService(repos *repository.Repositories, scheduled ScheduledTransactionService) ForecastService {
+	return &ForecastServiceImpl{repos: repos, scheduled: scheduled}
+}
+
+// Forecast projects the user's balance at the end of each of the next days, today included.
+func (s *ForecastServiceImpl) Forecast(ctx context.Context, userID uuid.UUID, days int) (*domain.CashFlowForecast, error) {
+	if err := domain.ValidateForecastDays(days); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+	upcoming, err := s.scheduled.Upcoming(ctx, userID, now, to)
+	if err != nil {
+		return nil, err
+	}
+
+	since := now.Add(-domain.RecurringLookback)
+	status := domain.StatusSuccess
+	filter := &domain.TransactionFilter{Status: &status, Since: &since}
+	var cursor *domain.TransactionCursor
+	var transactions []*domain.Transaction
+	for {
+		page, err := s.repos.Transactions.ListForUserAfter(ctx, userID, filter, cursor, forecastBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transactions: %w", err)
+		}
+		transactions = append(transactions, page...)
+
+		if len(page) < forecastBatchSize {
+			break
+		}
+		last := page[len(page)-1]
+		cursor = &domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	patterns := domain.DetectRecurring(userID, transactions, now)
+	forecast := domain.ForecastCashFlow(userID, balance.Currency, balance.Amount, now, days, upcoming.Executions, patterns)
+	forecast.Truncated = upcoming.Truncated
+
+	return forecast, nil
+}
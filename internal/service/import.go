@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// importBatchSize is how many users an import copies into the database at a time.
+const importBatchSize = 1000
+
+// ImportServiceImpl implements ImportService.
+type ImportServiceImpl struct {
+	repos    *repository.Repositories
+	uow      repository.UnitOfWork // Imports every user or none
+	eventSvc *EventService         // Publishes the registration events of imported users
+}
+
+// NewImportService creates a new import service.
+func NewImportService(repos *repository.Repositories, uow repository.UnitOfWork, eventSvc *EventService) ImportService {
+	return &ImportServiceImpl{repos: repos, uow: uow, eventSvc: eventSvc}
+}
+
+// Import creates the users of a CSV with their opening balances. Every row is validated first,
+// against the rest of the file and the users that already exist; if any row has errors, or on a
+// dry run, nothing is written and the result reports the errors. Otherwise the users, balances and
+// opening credits are copied in batches in one transaction, and the opening balances of each
+// currency are minted into its treasury and issued from it, so the money supply stays balanced.
+func (s *ImportServiceImpl) Import(ctx context.Context, actorID uuid.UUID, r io.Reader, dryRun bool) (*domain.ImportResult, error) {
+	rows, rowErrors, err := domain.ParseImportCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	rows, existingErrors, err := s.checkExisting(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	rowErrors = append(rowErrors, existingErrors...)
+	sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Line < rowErrors[j].Line })
+
+	result := &domain.ImportResult{
+		ID:      uuid.New(),
+		DryRun:  dryRun,
+		Rows:    len(rows) + len(rowErrors),
+		Funding: importFunding(rows),
+		Errors:  rowErrors,
+	}
+	if result.Errors == nil {
+		result.Errors = []domain.ImportRowError{}
+	}
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	err = repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		for start := 0; start < len(rows); start += importBatchSize {
+			if err := s.importBatch(ctx, repos, rows[start:min(start+importBatchSize, len(rows))]); err != nil {
+				return err
+			}
+		}
+		return s.fundImport(ctx, repos, actorID, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = len(rows)
+
+	if err := s.repos.Audit.Log(ctx, string(domain.EntityImport), result.ID, string(domain.ActionCompleted), map[string]interface{}{
+		"actor_id": actorID,
+		"imported": result.Imported,
+		"funding":  result.Funding,
+	}); err != nil {
+		utils.WarnContext(ctx, "failed to audit import",
+			"import_id", result.ID.String(),
+			"error", err.Error(),
+		)
+	}
+
+	utils.InfoContext(ctx, "users imported",
+		"import_id", result.ID.String(),
+		"imported", result.Imported,
+	)
+
+	return result, nil
+}
+
+// checkExisting reports the rows whose username or email is already taken, returning the others,
+// and the rows whose currency has no treasury to fund the opening balance.
+func (s *ImportServiceImpl) checkExisting(ctx context.Context, rows []*domain.ImportRow) ([]*domain.ImportRow, []domain.ImportRowError, error) {
+	var valid []*domain.ImportRow
+	var rowErrors []domain.ImportRowError
+	treasuries := make(map[string]bool)
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		if existing, err := s.repos.Users.GetByUsername(ctx, row.Username); err == nil && existing != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Line: row.Line, Field: domain.ImportColumnUsername, Message: "username already taken"})
+			continue
+		}
+		if existing, err := s.repos.Users.GetByEmail(ctx, row.Email); err == nil && existing != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Line: row.Line, Field: domain.ImportColumnEmail, Message: "email already registered"})
+			continue
+		}
+
+		funded, checked := treasuries[row.Currency]
+		if !checked {
+			_, err := s.repos.Treasury.GetAccount(ctx, row.Currency)
+			if err != nil && err.Error() != "treasury account not found" {
+				return nil, nil, fmt.Errorf("failed to get treasury account: %w", err)
+			}
+			funded = err == nil
+			treasuries[row.Currency] = funded
+		}
+		if !funded {
+			rowErrors = append(rowErrors, domain.ImportRowError{Line: row.Line, Field: domain.ImportColumnCurrency, Message: "no treasury account for currency " + row.Currency})
+			continue
+		}
+
+		valid = append(valid, row)
+	}
+
+	return valid, rowErrors, nil
+}
+
+// importBatch copies one batch of users with their balances and opening credits.
+func (s *ImportServiceImpl) importBatch(ctx context.Context, repos *repository.Repositories, rows []*domain.ImportRow) error {
+	users := make([]*domain.User, len(rows))
+	balances := make([]*domain.Balance, len(rows))
+	var credits []*domain.Transaction
+
+	for i, row := range rows {
+		users[i] = &domain.User{
+			ID:           uuid.New(),
+			Username:     row.Username,
+			Email:        row.Email,
+			PasswordHash: row.PasswordHash,
+			Role:         row.Role,
+		}
+		balances[i] = &domain.Balance{
+			UserID:   users[i].ID,
+			Amount:   row.OpeningBalance,
+			Currency: row.Currency,
+		}
+		if row.OpeningBalance > 0 {
+			credits = append(credits, &domain.Transaction{
+				ID:          uuid.New(),
+				ToUserID:    &users[i].ID,
+				Amount:      row.OpeningBalance,
+				Currency:    row.Currency,
+				Type:        string(domain.TypeCredit),
+				Description: "Opening balance",
+			})
+		}
+	}
+
+	if err := repos.Users.CreateMany(ctx, users); err != nil {
+		return fmt.Errorf("failed to import users: %w", err)
+	}
+	if err := repos.Balances.CreateMany(ctx, balances); err != nil {
+		return fmt.Errorf("failed to import balances: %w", err)
+	}
+	if len(credits) > 0 {
+		if err := repos.Transactions.CreateManyCompleted(ctx, credits); err != nil {
+			return fmt.Errorf("failed to import opening balances: %w", err)
+		}
+	}
+
+	if s.eventSvc == nil {
+		return nil
+	}
+	events := make([]*domain.Event, 0, 2*len(users))
+	for i, user := range users {
+		registration, err := s.eventSvc.RegistrationEvents(ctx, user, balances[i])
+		if err != nil {
+			return err
+		}
+		events = append(events, registration...)
+	}
+	return s.eventSvc.PublishEventsIn(ctx, repos, events)
+}
+
+// fundImport mints the opening balances of each currency into its treasury and issues them, so
+// the imported money is accounted for like any other credit.
+func (s *ImportServiceImpl) fundImport(ctx context.Context, repos *repository.Repositories, actorID uuid.UUID, result *domain.ImportResult) error {
+	currencies := make([]string, 0, len(result.Funding))
+	for currency := range result.Funding {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	reason := "import " + result.ID.String()
+	for _, currency := range currencies {
+		amount := result.Funding[currency]
+		if amount <= 0 {
+			continue
+		}
+
+		for _, kind := range []domain.TreasuryEntryKind{domain.TreasuryMint, domain.TreasuryIssue} {
+			entry := &domain.TreasuryEntry{
+				ID:        uuid.New(),
+				Currency:  currency,
+				Kind:      kind,
+				Amount:    amount,
+				Reason:    reason,
+				CreatedAt: time.Now(),
+			}
+			if kind == domain.TreasuryMint {
+				entry.ActorID = &actorID
+			}
+			if _, err := repos.Treasury.Record(ctx, entry, false); err != nil {
+				return fmt.Errorf("failed to fund imported %s balances: %w", currency, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// importFunding sums the opening balances of the rows per currency.
+func importFunding(rows []*domain.ImportRow) map[string]float64 {
+	funding := make(map[string]float64)
+	for _, row := range rows {
+		if row.OpeningBalance > 0 {
+			funding[row.Currency] += row.OpeningBalance
+		}
+	}
+	for currency, amount := range funding {
+		funding[currency] = domain.RoundAmount(amount, currency)
+	}
+	return funding
+}
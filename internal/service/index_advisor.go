@@ -0,0 +1,51 @@
+// Package service provides the index advisor for the hottest queries.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// IndexAdvisorServiceImpl implements IndexAdvisorService. Plans follow the statistics of the last
+// analyze, so a freshly simulated dataset should be analyzed before asking for advice.
+type IndexAdvisorServiceImpl struct {
+	repo repository.IndexAdvisorRepo
+}
+
+// NewIndexAdvisorService creates a new index advisor service.
+func NewIndexAdvisorService(repo repository.IndexAdvisorRepo) IndexAdvisorService {
+	return &IndexAdvisorServiceImpl{repo: repo}
+}
+
+// Report explains the hottest queries and suggests an index for each filtered sequential scan of
+// a table of at least domain.MinIndexAdviceTableRows rows.
+func (s *IndexAdvisorServiceImpl) Report(ctx context.Context) (*domain.IndexAdvisorReport, error) {
+	tables, err := s.repo.TableStatistics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.repo.ExplainHotQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.IndexAdvisorReport{
+		GeneratedAt:  time.Now().UTC(),
+		MinTableRows: domain.MinIndexAdviceTableRows,
+		Queries:      make([]domain.QueryPlanAdvice, 0, len(plans)),
+	}
+	for _, plan := range plans {
+		advice, err := domain.AdviseIndexes(plan, tables, domain.MinIndexAdviceTableRows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to advise indexes: %w", err)
+		}
+		report.Queries = append(report.Queries, *advice)
+	}
+
+	return report, nil
+}
@@ -3,10 +3,12 @@ package service
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/featureflags"
 )
 
 // AuthService defines the interface for authentication operations.
@@ -14,17 +16,32 @@ type AuthService interface {
 	// Register creates a new user account.
 	Register(ctx context.Context, req *domain.CreateUserRequest) (*domain.UserResponse, error)
 
-	// Login authenticates a user and returns tokens.
-	Login(ctx context.Context, email, password string) (*LoginResponse, error)
+	// Login authenticates a user logging in from device and returns tokens for a new login session.
+	Login(ctx context.Context, email, password string, device domain.LoginDevice) (*LoginResponse, error)
 
-	// RefreshToken generates a new access token from a refresh token.
-	RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error)
+	// RefreshToken generates a new access token from a refresh token presented by device.
+	RefreshToken(ctx context.Context, refreshToken string, device domain.LoginDevice) (*TokenResponse, error)
 
 	// ValidateToken validates an access token and returns user info.
 	ValidateToken(ctx context.Context, token string) (*domain.UserResponse, error)
 
 	// Logout invalidates a refresh token.
 	Logout(ctx context.Context, refreshToken string) error
+
+	// Impersonate mints a short-lived token that lets an admin act as another user.
+	Impersonate(ctx context.Context, adminID, targetUserID uuid.UUID, reason string) (*ImpersonationResponse, error)
+
+	// ListActiveImpersonations retrieves impersonation sessions that have not expired.
+	ListActiveImpersonations(ctx context.Context) ([]*domain.ImpersonationSession, error)
+
+	// ListSessions retrieves a user's login sessions that are neither revoked nor expired.
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.LoginSession, error)
+
+	// RevokeSession revokes one of a user's login sessions from device.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, device domain.LoginDevice) error
+
+	// StepUp confirms the password of a user on device, letting them move money again.
+	StepUp(ctx context.Context, userID uuid.UUID, password string, device domain.LoginDevice) error
 }
 
 // UserService defines the interface for user management operations.
@@ -46,6 +63,9 @@ type UserService interface {
 
 	// UpdateProfile updates the current user's profile.
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *domain.UpdateUserRequest) (*domain.UserResponse, error)
+
+	// LookupAccount resolves an account number to the account it belongs to.
+	LookupAccount(ctx context.Context, accountNumber string) (*domain.AccountLookup, error)
 }
 
 // BalanceService defines the interface for balance operations.
@@ -59,6 +79,9 @@ type BalanceService interface {
 	// GetAtTime retrieves balance at a specific time.
 	GetAtTime(ctx context.Context, userID uuid.UUID, timestamp string) (*domain.BalanceResponse, error)
 
+	// GetTimeline retrieves the balance at every interval between two times.
+	GetTimeline(ctx context.Context, userID uuid.UUID, from, to time.Time, interval domain.TimelineInterval) (*domain.BalanceTimeline, error)
+
 	// Initialize creates an initial balance for a new user.
 	Initialize(ctx context.Context, userID uuid.UUID, initialAmount float64, currency string) error
 }
@@ -83,12 +106,24 @@ type TransactionService interface {
 	// ListAll retrieves all transactions (admin only).
 	ListAll(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.TransactionResponse, error)
 
-	// Rollback reverses a completed transaction (if within policy window).
+	// GetStats returns aggregate transaction statistics over a time window (admin only).
+	GetStats(ctx context.Context, window string) (*domain.TransactionStats, error)
+
+	// Rollback reverses a completed transaction (if within policy window and not already rolled back).
 	Rollback(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error)
 
 	// RollbackByAdmin reverses a completed transaction (admin version without permission checks).
 	RollbackByAdmin(ctx context.Context, transactionID uuid.UUID) (*domain.TransactionResponse, error)
 
+	// PartialRollback reverses part of a completed transaction (if within policy window).
+	PartialRollback(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID, amount float64) (*domain.TransactionResponse, error)
+
+	// PartialRollbackByAdmin reverses part of a completed transaction (admin version without permission checks).
+	PartialRollbackByAdmin(ctx context.Context, transactionID uuid.UUID, amount float64) (*domain.TransactionResponse, error)
+
+	// AdjustBalance corrects a user's balance with a credit or debit (admin only).
+	AdjustBalance(ctx context.Context, userID uuid.UUID, req *domain.BalanceAdjustmentRequest) (*domain.TransactionResponse, error)
+
 	// Sync methods for worker pool
 	CreditSync(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error)
 	DebitSync(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error)
@@ -96,10 +131,10 @@ type TransactionService interface {
 	RollbackSync(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error)
 
 	// SetPool sets the worker pool for async processing.
-	SetPool(pool interface{})
+	SetPool(pool WorkerService)
 
 	// SetMetricsCollector sets the metrics collector for tracking metrics.
-	SetMetricsCollector(collector interface{})
+	SetMetricsCollector(collector MetricsSink)
 }
 
 // ScheduledTransactionService defines the interface for scheduled transaction operations.
@@ -113,21 +148,528 @@ type ScheduledTransactionService interface {
 	// List retrieves scheduled transactions for a user.
 	List(ctx context.Context, userID uuid.UUID, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransactionResponse, error)
 
+	// ListAll retrieves scheduled transactions of all users with the total matching the filter (admin only).
+	ListAll(ctx context.Context, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransactionResponse, int, error)
+
+	// FailureReport aggregates failed scheduled executions since the given time by reason (admin only).
+	FailureReport(ctx context.Context, since time.Time) (*domain.ScheduledFailureReport, error)
+
+	// Upcoming projects the executions of the user's active scheduled transactions within a window.
+	Upcoming(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.UpcomingExecutions, error)
+
 	// Cancel cancels a scheduled transaction.
 	Cancel(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 
+	// CancelForUser cancels the open scheduled transactions of a user (admin only).
+	CancelForUser(ctx context.Context, adminID, userID uuid.UUID) (*domain.ScheduledOwnerAction, error)
+
+	// ReassignForUser hands the open scheduled transactions of a deactivated user to an active one (admin only).
+	ReassignForUser(ctx context.Context, adminID, userID uuid.UUID, req *domain.ScheduledReassignRequest) (*domain.ScheduledOwnerAction, error)
+
+	// Pause stops a scheduled transaction from executing until it is resumed.
+	Pause(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error)
+
+	// Resume reactivates a paused scheduled transaction, recalculating its next execution.
+	Resume(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error)
+
+	// SkipNext moves a recurring scheduled transaction past its upcoming occurrence without executing it.
+	SkipNext(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error)
+
 	// ProcessDueTransactions processes all scheduled transactions that are due for execution.
 	ProcessDueTransactions(ctx context.Context) error
+
+	// CheckUpcomingFunding warns owners of upcoming debits and transfers their balance does not cover.
+	CheckUpcomingFunding(ctx context.Context) error
+}
+
+// CalendarService defines the interface for the business day calendar of scheduled transactions.
+type CalendarService interface {
+	// Calendar returns the calendar scheduled transactions are moved to business days by.
+	Calendar() *domain.BusinessCalendar
+
+	// Region describes the weekends and holidays of a region; the empty region is the default one.
+	Region(ctx context.Context, region string) (*domain.CalendarRegion, error)
+
+	// Regions lists the known regions.
+	Regions(ctx context.Context) []string
+}
+
+// ReceiptService defines the interface for signed transaction receipts.
+type ReceiptService interface {
+	// Issue signs a receipt of a successful transaction the user sent or received.
+	Issue(ctx context.Context, transactionID uuid.UUID, userID uuid.UUID) (*domain.SignedReceipt, error)
+
+	// Verify checks that a receipt was signed by this server and still describes the stored transaction.
+	Verify(ctx context.Context, signed *domain.SignedReceipt) (*domain.ReceiptVerification, error)
+}
+
+// PaymentQRService defines the interface for payment QR codes.
+type PaymentQRService interface {
+	// Generate creates a payment QR code asking for a transfer to the user's account.
+	Generate(ctx context.Context, userID uuid.UUID, req *domain.PaymentQRRequest) (*domain.PaymentQR, error)
+
+	// PrefillTransfer decodes a scanned payment QR payload into a transfer for the payer to confirm.
+	PrefillTransfer(ctx context.Context, userID uuid.UUID, req *domain.QRTransferRequest) (*domain.QRTransferDraft, error)
+}
+
+// DisputeService defines the interface for transaction dispute operations.
+type DisputeService interface {
+	// Open opens a dispute on a completed transaction.
+	Open(ctx context.Context, transactionID uuid.UUID, userID uuid.UUID, req *domain.OpenDisputeRequest) (*domain.Dispute, error)
+
+	// GetByID retrieves a dispute with its comments.
+	GetByID(ctx context.Context, id uuid.UUID, requestingUserID uuid.UUID, isAdmin bool) (*DisputeDetails, error)
+
+	// List retrieves disputes visible to the requesting user.
+	List(ctx context.Context, requestingUserID uuid.UUID, isAdmin bool, filter *domain.DisputeFilter) ([]*domain.Dispute, error)
+
+	// AddComment adds a comment to an unresolved dispute.
+	AddComment(ctx context.Context, disputeID uuid.UUID, authorID uuid.UUID, isAdmin bool, req *domain.DisputeCommentRequest) (*domain.DisputeComment, error)
+
+	// Resolve resolves a dispute by refunding or rejecting it (admin only).
+	Resolve(ctx context.Context, disputeID uuid.UUID, adminID uuid.UUID, req *domain.ResolveDisputeRequest) (*domain.Dispute, error)
+}
+
+// CacheWarmupService defines the interface for preloading hot data into the cache.
+type CacheWarmupService interface {
+	// WarmUp preloads the most active users' profiles and balances into the cache.
+	// It returns the number of users warmed.
+	WarmUp(ctx context.Context) (int, error)
+
+	// WarmIfCold warms the cache only if it has not been warmed recently,
+	// e.g. on startup or after the cache has been flushed.
+	WarmIfCold(ctx context.Context) error
+}
+
+// PaymentRequestService defines the interface for request-to-pay operations.
+type PaymentRequestService interface {
+	// Create sends a payment request from the requester to the payer.
+	Create(ctx context.Context, requesterID uuid.UUID, req *domain.CreatePaymentRequestRequest) (*domain.PaymentRequest, error)
+
+	// GetByID retrieves a payment request the user is the requester or payer of.
+	GetByID(ctx context.Context, id uuid.UUID, requestingUserID uuid.UUID) (*domain.PaymentRequest, error)
+
+	// ListIncoming retrieves payment requests the user has been asked to pay.
+	ListIncoming(ctx context.Context, payerID uuid.UUID, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error)
+
+	// ListOutgoing retrieves payment requests the user has sent.
+	ListOutgoing(ctx context.Context, requesterID uuid.UUID, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error)
+
+	// Approve pays a pending payment request with a transfer from the payer to the requester.
+	Approve(ctx context.Context, id uuid.UUID, payerID uuid.UUID) (*domain.PaymentRequest, error)
+
+	// Decline declines a pending payment request.
+	Decline(ctx context.Context, id uuid.UUID, payerID uuid.UUID) (*domain.PaymentRequest, error)
+}
+
+// TransferTemplateService defines the interface for saved transfer template operations.
+type TransferTemplateService interface {
+	// Create saves a new transfer template for the user.
+	Create(ctx context.Context, userID uuid.UUID, req *domain.TransferTemplateRequest) (*domain.TransferTemplate, error)
+
+	// GetByID retrieves one of the user's transfer templates with its usage statistics.
+	GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.TransferTemplate, error)
+
+	// List retrieves the user's transfer templates with pagination.
+	List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.TransferTemplate, error)
+
+	// Update changes the name or transfer details of one of the user's templates.
+	Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *domain.UpdateTransferTemplateRequest) (*domain.TransferTemplate, error)
+
+	// Delete deletes one of the user's transfer templates.
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// Execute transfers money to the template's payee.
+	Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *domain.ExecuteTransferTemplateRequest) (*domain.TransactionResponse, error)
+}
+
+// ContactService defines the interface for saved contacts and recent transfer counterparties.
+type ContactService interface {
+	// List returns the user's saved contacts followed by their other recent transfer counterparties.
+	List(ctx context.Context, userID uuid.UUID, recentLimit int) ([]*domain.ContactEntry, error)
+
+	// Create saves another user as one of the user's contacts.
+	Create(ctx context.Context, userID uuid.UUID, req *domain.CreateContactRequest) (*domain.Contact, error)
+
+	// Delete removes one of the user's saved contacts.
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+// SecurityService defines the interface for the security event log.
+type SecurityService interface {
+	// List retrieves security events, newest first.
+	List(ctx context.Context, filter *domain.SecurityEventFilter) ([]*domain.SecurityEvent, error)
+}
+
+// PolicySource provides the policy in effect now.
+type PolicySource interface {
+	// Current returns the policy in effect now. Callers must not modify it.
+	Current() *domain.Policy
+}
+
+// PolicyService defines the interface for the versioned runtime policy.
+type PolicyService interface {
+	PolicySource
+
+	// Effective returns the version in effect now; version 0 is the configured policy.
+	Effective() *domain.PolicyVersion
+
+	// List retrieves stored versions of the policy, newest first.
+	List(ctx context.Context, filter *domain.PolicyVersionFilter) ([]*domain.PolicyVersion, error)
+
+	// GetByVersion retrieves a stored version of the policy.
+	GetByVersion(ctx context.Context, version int) (*domain.PolicyVersion, error)
+
+	// Set stores a new version of the policy, taking effect at its effective time.
+	Set(ctx context.Context, actorID uuid.UUID, req *domain.SetPolicyRequest) (*domain.PolicyVersion, error)
+
+	// Refresh loads the version in effect now, including versions stored through other instances.
+	Refresh(ctx context.Context) error
+}
+
+// MonthlySummaryService defines the interface for monthly account summaries.
+type MonthlySummaryService interface {
+	// Get retrieves a user's summary of a yyyy-mm month that has ended.
+	Get(ctx context.Context, userID uuid.UUID, month string) (*domain.MonthlySummary, error)
+
+	// GenerateDue generates every user's summary of the month that ended last, returning how many
+	// it generated.
+	GenerateDue(ctx context.Context) (int, error)
+}
+
+// ForecastService defines the interface for cash-flow forecasts.
+type ForecastService interface {
+	// Forecast projects the user's balance at the end of each of the next days, today included.
+	Forecast(ctx context.Context, userID uuid.UUID, days int) (*domain.CashFlowForecast, error)
+}
+
+// ActivityService defines the interface for monthly activity reports.
+type ActivityService interface {
+	// Monthly reports the money the user received and sent in each of the last months UTC months.
+	Monthly(ctx context.Context, userID uuid.UUID, months int) (*domain.MonthlyActivityReport, error)
+}
+
+// ApprovalService defines the interface for four-eyes approval of destructive admin operations.
+type ApprovalService interface {
+	// Required reports whether the action with its payload must be approved by a second admin.
+	Required(ctx context.Context, action domain.ApprovalAction, payload any) bool
+
+	// Submit queues an action with its payload until an admin other than the requester approves it.
+	Submit(ctx context.Context, requesterID uuid.UUID, action domain.ApprovalAction, payload any) (*domain.Approval, error)
+
+	// List retrieves approvals, newest first.
+	List(ctx context.Context, filter *domain.ApprovalFilter) ([]*domain.Approval, error)
+
+	// GetByID retrieves an approval by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Approval, error)
+
+	// Approve executes a pending action on behalf of its requester. The requester cannot approve
+	// their own action.
+	Approve(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) (*domain.Approval, error)
+
+	// Reject discards a pending action. The requester may reject their own action to withdraw it.
+	Reject(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, note string) (*domain.Approval, error)
+}
+
+// TransactionStatusService defines the interface for following a transaction's status live.
+type TransactionStatusService interface {
+	// Watch streams a transaction's current status and then each transition until it reaches
+	// a terminal status. Only participants in the transaction may watch it.
+	Watch(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (<-chan domain.TransactionStatusUpdate, error)
+
+	// AwaitSettled blocks until a transaction leaves pending or ctx is done, and reports whether it
+	// left pending. Only participants in the transaction may wait for it.
+	AwaitSettled(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (bool, error)
+}
+
+// TransactionExportService defines the interface for exporting transaction history.
+type TransactionExportService interface {
+	// Count returns the number of transactions an export of the user's history with filter would contain.
+	Count(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter) (int, error)
+
+	// Write streams every transaction of the user matching filter to w and returns the number written.
+	Write(ctx context.Context, w io.Writer, userID uuid.UUID, filter *domain.TransactionFilter, format domain.ExportFormat, locale domain.Locale) (int, error)
+
+	// StartBackground generates an export in the background for later download.
+	StartBackground(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter, format domain.ExportFormat, locale domain.Locale) (*domain.TransactionExport, error)
+
+	// Get retrieves a background export owned by the user.
+	Get(ctx context.Context, userID, exportID uuid.UUID) (*domain.TransactionExport, error)
+
+	// Open opens a ready background export owned by the user for reading.
+	Open(ctx context.Context, userID, exportID uuid.UUID) (io.ReadCloser, *domain.TransactionExport, error)
+}
+
+// RequestLogService defines the interface for retrieving and purging recorded request/response exchanges.
+type RequestLogService interface {
+	// List retrieves recorded exchanges, newest first, with the total number matching the filter.
+	List(ctx context.Context, filter *domain.RequestLogFilter) ([]*domain.RequestLog, int, error)
+
+	// GetByRequestID retrieves the exchange recorded for a request ID.
+	GetByRequestID(ctx context.Context, requestID uuid.UUID) (*domain.RequestLog, error)
+
+	// PurgeExpired deletes exchanges older than the retention period and returns how many were deleted.
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// Notifier queues notifications for delivery on the user's preferred channels.
+type Notifier interface {
+	// Notify queues a notification of the given type, rendered later from data.
+	Notify(ctx context.Context, userID uuid.UUID, notificationType domain.NotificationType, data map[string]interface{}) error
+}
+
+// NotificationService defines the interface for notification inbox, preference and dispatch operations.
+type NotificationService interface {
+	Notifier
+
+	// List retrieves the user's in-app inbox with the number of unread notifications.
+	List(ctx context.Context, userID uuid.UUID, filter *domain.NotificationFilter) ([]*domain.Notification, int, error)
+
+	// MarkRead marks one of the user's notifications as read.
+	MarkRead(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Notification, error)
+
+	// MarkAllRead marks every unread notification of the user as read.
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// GetPreferences retrieves the user's notification preferences.
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error)
+
+	// UpdatePreferences changes the user's notification preferences.
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferences, error)
+
+	// DispatchPending sends queued notifications that are due and returns how many were sent.
+	DispatchPending(ctx context.Context) (int, error)
+}
+
+// BalanceMonitor checks balances against the users' low-balance alerts.
+type BalanceMonitor interface {
+	// CheckBalance evaluates the user's alert after the balance was debited or credited by a transaction.
+	CheckBalance(ctx context.Context, userID uuid.UUID, debited bool, transactionID uuid.UUID)
+}
+
+// BudgetMonitor checks spending against the users' category budgets.
+type BudgetMonitor interface {
+	// CheckBudget evaluates the sender's budget for the category of a completed debit or transfer.
+	CheckBudget(ctx context.Context, tx *domain.Transaction)
+}
+
+// MoneyMovementPolicy decides whether users may move money, learning from what their money
+// movement signals.
+type MoneyMovementPolicy interface {
+	// Allow returns domain.ErrStepUpRequired when the user must confirm their password before moving money.
+	Allow(ctx context.Context, userID uuid.UUID) error
+
+	// Observe records a signal raised by the user's money movement.
+	Observe(ctx context.Context, userID uuid.UUID, signal domain.AnomalySignal)
+
+	// SteppedUp lets the user move money again after they confirmed their password.
+	SteppedUp(ctx context.Context, userID uuid.UUID) error
+}
+
+// BalanceAlertService defines the interface for low-balance alert operations.
+type BalanceAlertService interface {
+	BalanceMonitor
+
+	// SetThreshold creates or changes the user's alert threshold for a currency.
+	SetThreshold(ctx context.Context, userID uuid.UUID, currency string, req *domain.SetBalanceAlertRequest) (*domain.BalanceAlert, error)
+
+	// List retrieves the user's alert thresholds.
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.BalanceAlert, error)
+
+	// Delete removes the user's alert threshold for a currency.
+	Delete(ctx context.Context, userID uuid.UUID, currency string) error
+}
+
+// BudgetService defines the interface for category budget operations.
+type BudgetService interface {
+	BudgetMonitor
+
+	// Set creates or changes the user's monthly budget for a category.
+	Set(ctx context.Context, userID uuid.UUID, category string, req *domain.SetBudgetRequest) (*domain.Budget, error)
+
+	// List retrieves the user's budgets.
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error)
+
+	// Delete removes the user's budget for a category.
+	Delete(ctx context.Context, userID uuid.UUID, category string) error
+
+	// Spending reports the user's spending of a yyyy-mm month by category against their budgets.
+	Spending(ctx context.Context, userID uuid.UUID, month string) (*domain.SpendingReport, error)
+}
+
+// DormancyService defines the interface for reporting and flagging dormant accounts.
+type DormancyService interface {
+	// Report lists a page of the accounts dormant for at least days days (admin only).
+	Report(ctx context.Context, days, limit, offset int) (*domain.DormantAccountsReport, error)
+
+	// WriteCSV writes every account dormant for at least days days to w as CSV (admin only).
+	WriteCSV(ctx context.Context, w io.Writer, days int) (int, error)
+
+	// FlagDormant publishes an AccountDormant event for every account newly dormant for days days.
+	FlagDormant(ctx context.Context, days int) (int, error)
+}
+
+// SearchService defines the interface for full-text search.
+type SearchService interface {
+	// Search searches a user's transactions and payment requests, or every audit log entry for the
+	// audit scope (admin only).
+	Search(ctx context.Context, userID uuid.UUID, query string, scope domain.SearchScope, limit, offset int) (*domain.SearchResults, error)
+}
+
+// APIUsageService defines the interface for per-user API usage tracking and quotas.
+type APIUsageService interface {
+	// MeterCall counts a call of the user to an endpoint class, failing with an
+	// *auth.QuotaExceededError instead when it would go over their quota.
+	MeterCall(ctx context.Context, userID uuid.UUID, class string) error
+
+	// Usage reports the user's API calls of the last days UTC days and what remains of their quota.
+	Usage(ctx context.Context, userID uuid.UUID, days int) (*domain.APIUsageReport, error)
+
+	// SetQuota creates or replaces a user's API quota (admin only).
+	SetQuota(ctx context.Context, adminID, userID uuid.UUID, req *domain.SetAPIQuotaRequest) (*domain.APIQuota, error)
+
+	// DeleteQuota removes a user's API quota (admin only).
+	DeleteQuota(ctx context.Context, adminID, userID uuid.UUID) error
+}
+
+// TreasuryService defines the interface for treasury and money supply operations.
+type TreasuryService interface {
+	// ListSupply reports the money supply of every currency.
+	ListSupply(ctx context.Context) ([]*domain.TreasurySupply, error)
+
+	// ListEntries retrieves the ledger of a currency's treasury.
+	ListEntries(ctx context.Context, filter *domain.TreasuryEntryFilter) ([]*domain.TreasuryEntry, error)
+
+	// Mint creates money in a currency's treasury.
+	Mint(ctx context.Context, actorID uuid.UUID, currency string, req *domain.TreasuryOperationRequest) (*domain.TreasuryEntry, error)
+
+	// Burn destroys money held by a currency's treasury.
+	Burn(ctx context.Context, actorID uuid.UUID, currency string, req *domain.TreasuryOperationRequest) (*domain.TreasuryEntry, error)
+
+	// SetCaps replaces the credit caps of a currency's treasury.
+	SetCaps(ctx context.Context, actorID uuid.UUID, currency string, req *domain.SetTreasuryCapsRequest) (*domain.TreasuryAccount, error)
+}
+
+// ImportService defines the interface for bulk imports of users and their opening balances.
+type ImportService interface {
+	// Import creates the users of a CSV with their opening balances, or only validates them on a dry run.
+	Import(ctx context.Context, actorID uuid.UUID, r io.Reader, dryRun bool) (*domain.ImportResult, error)
+}
+
+// CurrencyService defines the interface for managing the currency registry.
+type CurrencyService interface {
+	// List retrieves the currencies of the registry, disabled ones only if includeDisabled is set.
+	List(ctx context.Context, includeDisabled bool) ([]*domain.CurrencyInfo, error)
+
+	// Create adds a currency to the registry.
+	Create(ctx context.Context, actorID uuid.UUID, req *domain.CreateCurrencyRequest) (*domain.CurrencyInfo, error)
+
+	// Update changes a currency of the registry.
+	Update(ctx context.Context, actorID uuid.UUID, code string, req *domain.UpdateCurrencyRequest) (*domain.CurrencyInfo, error)
+
+	// Refresh loads the persisted registry into validation and formatting.
+	Refresh(ctx context.Context) error
+}
+
+// InvariantService defines the interface for checking system-wide accounting invariants.
+type InvariantService interface {
+	// Check verifies the money supply invariant of every currency.
+	Check(ctx context.Context) (*domain.InvariantReport, error)
+}
+
+// ClockService defines the interface for fast-forwarding the simulation clock (admin only).
+type ClockService interface {
+	// State reports the time the simulation clock shows.
+	State() *domain.ClockState
+
+	// Advance fast-forwards the simulation clock, auditing it under actorID.
+	Advance(ctx context.Context, actorID uuid.UUID, req *domain.ClockAdvanceRequest) (*domain.ClockState, error)
+}
+
+// IndexAdvisorService defines the interface for advising on the indexes the hottest queries miss.
+type IndexAdvisorService interface {
+	// Report explains the hottest queries against current statistics and suggests missing indexes.
+	Report(ctx context.Context) (*domain.IndexAdvisorReport, error)
+}
+
+// ArchiveService defines the interface for partitioning the event store and transactions and
+// archiving old events and transactions.
+type ArchiveService interface {
+	// RunMaintenance creates upcoming partitions and archives events and transactions past the configured age.
+	RunMaintenance(ctx context.Context) (*domain.ArchiveRun, error)
+}
+
+// EventBackupService defines the interface for exporting the event store to object storage and
+// restoring it from there.
+type EventBackupService interface {
+	// Export writes the events created since the last export.
+	Export(ctx context.Context) (*domain.EventExportRun, error)
+
+	// Restore imports every exported event; allowExisting permits an event store that holds events.
+	Restore(ctx context.Context, allowExisting bool) (*domain.EventRestoreRun, error)
+}
+
+// NettingService defines the interface for settling netted transfers.
+type NettingService interface {
+	// SettleDueNetting settles the netting batches whose window closed, returning how many settled.
+	SettleDueNetting(ctx context.Context) (int, error)
+
+	// ListNettingBatches retrieves netting batches, newest first (admin only).
+	ListNettingBatches(ctx context.Context, filter *domain.NettingBatchFilter) ([]*domain.NettingBatch, error)
+}
+
+// SimulationService defines the interface for synthetic traffic simulation (admin only).
+type SimulationService interface {
+	// Start creates a run's synthetic users and money and generates its traffic in the background.
+	Start(ctx context.Context, actorID uuid.UUID, req *domain.SimulationRequest) (*domain.SimulationRun, error)
+
+	// List retrieves the retained runs, newest first.
+	List(ctx context.Context) ([]*domain.SimulationRun, error)
+
+	// Get retrieves a run and its results so far.
+	Get(ctx context.Context, id uuid.UUID) (*domain.SimulationRun, error)
+
+	// Cancel stops a running simulation.
+	Cancel(ctx context.Context, id uuid.UUID) (*domain.SimulationRun, error)
+
+	// Trace retrieves the trace of a run's operations so far, in sequence order.
+	Trace(ctx context.Context, id uuid.UUID) ([]domain.SimulationTraceEntry, error)
+}
+
+// ProjectionRebuildService defines the interface for on-demand read model rebuilds.
+type ProjectionRebuildService interface {
+	// Start begins rebuilding the read models a request selects in the background.
+	Start(ctx context.Context, actorID uuid.UUID, req *domain.ProjectionRebuildRequest) (*domain.ProjectionRebuild, error)
+
+	// Status reports whether a rebuild is running and the recent rebuilds, newest first.
+	Status(ctx context.Context) (*domain.ProjectionStatus, error)
 }
 
 // WorkerService defines the interface for worker operations needed by services.
 type WorkerService interface {
-	// SubmitTransaction submits a transaction for async processing.
-	SubmitTransaction(job interface{}) error
+	// ProcessCredit queues a credit to userID and waits for its result.
+	ProcessCredit(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error)
+	// ProcessDebit queues a debit from userID and waits for its result.
+	ProcessDebit(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error)
+	// ProcessTransfer queues a transfer from fromUserID and waits for its result.
+	ProcessTransfer(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (*domain.TransactionResponse, error)
 	// GetQueueDepth returns the current queue depth.
 	GetQueueDepth() int
 }
 
+// MetricsSink records transaction metrics.
+type MetricsSink interface {
+	// IncrementTransactionsProcessed counts a processed transaction.
+	IncrementTransactionsProcessed()
+	// ObserveTransaction records the duration and outcome of a transaction operation.
+	ObserveTransaction(txType, outcome string, duration time.Duration)
+}
+
+// FeatureFlags reports whether optional features are enabled.
+type FeatureFlags interface {
+	Enabled(ctx context.Context, flag featureflags.Flag) bool
+}
+
 // ProjectorServiceInterface defines the interface for projector services
 type ProjectorServiceInterface interface {
 	ProcessEventsSince(ctx context.Context, since time.Time) error
@@ -141,9 +683,44 @@ type Services struct {
 	Balance              BalanceService
 	Transaction          TransactionService
 	ScheduledTransaction ScheduledTransactionService
+	Calendar             CalendarService
+	Receipt              ReceiptService
+	PaymentQR            PaymentQRService
+	Dispute              DisputeService
 	Event                *EventService
 	Projector            *ProjectorService
+	ProjectionRebuild    ProjectionRebuildService
 	Cache                CacheService
+	CacheWarmup          CacheWarmupService
+	FeatureFlags         *featureflags.Manager
+	RequestLog           RequestLogService
+	TransactionExport    TransactionExportService
+	TransactionStatus    TransactionStatusService
+	PaymentRequest       PaymentRequestService
+	TransferTemplate     TransferTemplateService
+	Contact              ContactService
+	Security             SecurityService
+	Approval             ApprovalService
+	Policy               PolicyService
+	MonthlySummary       MonthlySummaryService
+	Forecast             ForecastService
+	Activity             ActivityService
+	Notification         NotificationService
+	BalanceAlert         BalanceAlertService
+	Budget               BudgetService
+	Dormancy             DormancyService
+	Search               SearchService
+	Treasury             TreasuryService
+	Currency             CurrencyService
+	Import               ImportService
+	Invariant            InvariantService
+	IndexAdvisor         IndexAdvisorService // Nil without PostgreSQL storage
+	Archive              ArchiveService      // Nil unless archival is enabled with PostgreSQL storage
+	EventBackup          EventBackupService  // Nil unless event backups are enabled
+	Simulation           SimulationService   // Nil unless simulation is enabled
+	Clock                ClockService        // Nil unless the simulation clock is enabled
+	Netting              NettingService      // Nil unless netting is enabled
+	APIUsage             APIUsageService     // Nil without Redis
 }
 
 // LoginResponse represents the response from login operation.
@@ -159,3 +736,16 @@ type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int    `json:"expires_in"`
 }
+
+// ImpersonationResponse represents the response from starting an impersonation session.
+type ImpersonationResponse struct {
+	Session     *domain.ImpersonationSession `json:"session"`
+	AccessToken string                       `json:"access_token"`
+	ExpiresIn   int                          `json:"expires_in"`
+}
+
+// DisputeDetails represents a dispute together with its comments.
+type DisputeDetails struct {
+	Dispute  *domain.Dispute          `json:"dispute"`
+	Comments []*domain.DisputeComment `json:"comments"`
+}
@@ -0,0 +1,95 @@
+// Package service provides the checker for system-wide accounting invariants.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// SupplyDriftMetrics defines the metric recorded for money supply drift.
+type SupplyDriftMetrics interface {
+	SetMoneySupplyDrift(currency string, drift float64)
+}
+
+// InvariantServiceImpl implements InvariantService. It catches monetary leaks: a bug that moves
+// money without going through the treasury shows up as drift between the money users and the
+// treasury hold and the money minted minus burned.
+type InvariantServiceImpl struct {
+	repos   *repository.Repositories
+	metrics SupplyDriftMetrics // Optional; exports drift per currency
+}
+
+// NewInvariantService creates a new invariant service.
+func NewInvariantService(repos *repository.Repositories) InvariantService {
+	return &InvariantServiceImpl{repos: repos}
+}
+
+// SetMetrics sets the metrics that drift is exported to.
+func (s *InvariantServiceImpl) SetMetrics(metrics SupplyDriftMetrics) {
+	s.metrics = metrics
+}
+
+// Check verifies the money supply invariant of every currency, exports the drift and logs an
+// error for every currency where it does not hold.
+func (s *InvariantServiceImpl) Check(ctx context.Context) (*domain.InvariantReport, error) {
+	accounts, err := s.repos.Treasury.ListAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list treasury accounts: %w", err)
+	}
+
+	userBalances, err := s.repos.Treasury.SumUserBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum user balances: %w", err)
+	}
+
+	// Balances in a currency without a treasury were never minted, so they are checked against an empty one
+	for currency := range userBalances {
+		if !hasTreasury(accounts, currency) {
+			accounts = append(accounts, &domain.TreasuryAccount{Currency: currency})
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Currency < accounts[j].Currency })
+
+	report := &domain.InvariantReport{
+		Holds:       true,
+		MoneySupply: make([]*domain.MoneySupplyCheck, 0, len(accounts)),
+		CheckedAt:   time.Now(),
+	}
+
+	for _, account := range accounts {
+		check := domain.NewMoneySupplyCheck(account, userBalances[account.Currency])
+		report.MoneySupply = append(report.MoneySupply, check)
+
+		if s.metrics != nil {
+			s.metrics.SetMoneySupplyDrift(check.Currency, check.Drift)
+		}
+
+		if !check.Holds {
+			report.Holds = false
+			utils.ErrorContext(ctx, "money supply invariant violated",
+				"currency", check.Currency,
+				"expected_supply", check.ExpectedSupply,
+				"actual_supply", check.ActualSupply,
+				"drift", check.Drift,
+			)
+		}
+	}
+
+	return report, nil
+}
+
+// hasTreasury reports whether accounts include the treasury of currency.
+func hasTreasury(accounts []*domain.TreasuryAccount, currency string) bool {
+	for _, account := range accounts {
+		if account.Currency == currency {
+			return true
+		}
+	}
+	return false
+}
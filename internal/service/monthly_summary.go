@@ -0,0 +1,202 @@
+// Package service provides monthly account summaries.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// summaryBatchSize is how many users, and how many of a user's transactions, are read at a time
+// while generating summaries.
+const summaryBatchSize = 100
+
+// MonthlySummaryServiceImpl implements MonthlySummaryService. Summaries are generated for every
+// user once a month ends and stored unchanged afterwards; a summary that was never generated, e.g.
+// for a month before summaries existed, is generated when first requested.
+type MonthlySummaryServiceImpl struct {
+	repos    *repository.Repositories
+	notifier Notifier // Optional; tells users their summary is ready
+
+	mu        sync.Mutex
+	completed string // The last month GenerateDue generated every summary of
+}
+
+// NewMonthlySummaryService creates a new monthly summary service.
+func NewMonthlySummaryService(repos *repository.Repositories) MonthlySummaryService {
+	return &MonthlySummaryServiceImpl{repos: repos}
+}
+
+// SetNotifier sets the notifier that tells users their summary of a month with activity is ready.
+func (s *MonthlySummaryServiceImpl) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// Get retrieves a user's summary of a month that has ended, generating it if it was never stored.
+func (s *MonthlySummaryServiceImpl) Get(ctx context.Context, userID uuid.UUID, month string) (*domain.MonthlySummary, error) {
+	_, end, err := domain.ParseSummaryMonth(month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if end.After(time.Now()) {
+		return nil, fmt.Errorf("invalid request: month %s has not ended yet", month)
+	}
+
+	summary, err := s.repos.MonthlySummaries.Get(ctx, userID, month)
+	if err == nil {
+		return summary, nil
+	}
+	if err.Error() != "monthly summary not found" {
+		return nil, fmt.Errorf("failed to get monthly summary: %w", err)
+	}
+
+	summary, _, err = s.generate(ctx, userID, month)
+	return summary, err
+}
+
+// GenerateDue generates every user's summary of the month that ended last, and notifies users
+// with activity that month. Summaries already stored are left alone, so a pass that was
+// interrupted can simply run again. It returns the number of summaries generated.
+func (s *MonthlySummaryServiceImpl) GenerateDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	month := domain.SummaryMonth(now.AddDate(0, 0, -now.Day()))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.completed == month {
+		return 0, nil
+	}
+
+	_, end, err := domain.ParseSummaryMonth(month)
+	if err != nil {
+		return 0, err
+	}
+
+	generated, failed := 0, 0
+	for offset := 0; ; offset += summaryBatchSize {
+		users, err := s.repos.Users.ListPaginated(ctx, summaryBatchSize, offset)
+		if err != nil {
+			return generated, fmt.Errorf("failed to list users: %w", err)
+		}
+
+		for _, user := range users {
+			if !user.CreatedAt.Before(end) {
+				continue
+			}
+
+			summary, created, err := s.generate(ctx, user.ID, month)
+			if err != nil {
+				utils.ErrorContext(ctx, "failed to generate monthly summary",
+					"user_id", user.ID.String(),
+					"month", month,
+					"error", err.Error(),
+				)
+				failed++
+				continue
+			}
+			if !created {
+				continue
+			}
+			generated++
+
+			if s.notifier != nil && summary.TransactionCount > 0 {
+				s.notify(ctx, summary)
+			}
+		}
+
+		if len(users) < summaryBatchSize {
+			break
+		}
+	}
+
+	if failed > 0 {
+		return generated, fmt.Errorf("failed to generate %d monthly summaries for %s", failed, month)
+	}
+
+	s.completed = month
+	return generated, nil
+}
+
+// generate builds a user's summary of a month from their transactions and stores it. When
+// another instance stored the summary first, the stored one is returned and created is false.
+func (s *MonthlySummaryServiceImpl) generate(ctx context.Context, userID uuid.UUID, month string) (summary *domain.MonthlySummary, created bool, err error) {
+	start, end, err := domain.ParseSummaryMonth(month)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid request: %w", err)
+	}
+
+	balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	// The balance at the end of the instant before the month starts is the one it opened with
+	opening, err := s.repos.Balances.GetTimeline(ctx, userID, []time.Time{start.Add(-time.Microsecond)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get opening balance: %w", err)
+	}
+
+	status := domain.StatusSuccess
+	filter := &domain.TransactionFilter{Status: &status, Since: &start}
+	// The zero ID sorts before every other, so the first page starts with the month's last transaction
+	cursor := &domain.TransactionCursor{CreatedAt: end, ID: uuid.Nil}
+	var transactions []*domain.Transaction
+	for {
+		page, err := s.repos.Transactions.ListForUserAfter(ctx, userID, filter, cursor, summaryBatchSize)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list transactions: %w", err)
+		}
+		transactions = append(transactions, page...)
+
+		if len(page) < summaryBatchSize {
+			break
+		}
+		last := page[len(page)-1]
+		cursor = &domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	summary = domain.SummarizeMonth(userID, month, balance.Currency, opening[0].Amount, transactions)
+	summary.ID = uuid.New()
+	summary.GeneratedAt = time.Now()
+
+	created, err = s.repos.MonthlySummaries.Create(ctx, summary)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create monthly summary: %w", err)
+	}
+	if !created {
+		summary, err = s.repos.MonthlySummaries.Get(ctx, userID, month)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get monthly summary: %w", err)
+		}
+	}
+
+	return summary, created, nil
+}
+
+// notify tells a user their summary is ready. The summary is already stored, so a failure is
+// logged rather than returned.
+func (s *MonthlySummaryServiceImpl) notify(ctx context.Context, summary *domain.MonthlySummary) {
+	err := s.notifier.Notify(ctx, summary.UserID, domain.NotificationMonthlySummary, map[string]interface{}{
+		"month":             summary.Month,
+		"currency":          summary.Currency,
+		"opening_balance":   summary.OpeningBalance,
+		"closing_balance":   summary.ClosingBalance,
+		"total_in":          summary.TotalIn,
+		"total_out":         summary.TotalOut,
+		"fees_paid":         summary.FeesPaid,
+		"transaction_count": summary.TransactionCount,
+	})
+	if err != nil {
+		utils.WarnContext(ctx, "failed to notify monthly summary",
+			"user_id", summary.UserID.String(),
+			"month", summary.Month,
+			"error", err.Error(),
+		)
+	}
+}
@@ -0,0 +1,333 @@
+// Package service provides business logic for user notifications.
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/notifications"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+const (
+	// notificationDispatchBatch is the most deliveries claimed per dispatch run.
+	notificationDispatchBatch = 100
+	// notificationDispatchLease is how long a claimed delivery is hidden from other instances.
+	// It must exceed the time needed to send one batch.
+	notificationDispatchLease = 5 * time.Minute
+	// notificationRetryBase is the delay before the first retry; it doubles with every attempt.
+	notificationRetryBase = 30 * time.Second
+	// notificationRetryMax caps the delay between retries.
+	notificationRetryMax = time.Hour
+)
+
+// NotificationServiceImpl implements NotificationService. Notifications are queued per channel
+// and rendered and sent later by DispatchPending, so a slow or failing channel never holds up
+// the money movement that triggered the notification.
+type NotificationServiceImpl struct {
+	repos       *repository.Repositories
+	channels    map[domain.NotificationChannel]notifications.Channel
+	maxAttempts int // Attempts per delivery before giving up
+}
+
+// NewNotificationService creates a new notification service with the in-app channel registered.
+func NewNotificationService(repos *repository.Repositories, maxAttempts int) NotificationService {
+	s := &NotificationServiceImpl{
+		repos:       repos,
+		channels:    make(map[domain.NotificationChannel]notifications.Channel),
+		maxAttempts: maxAttempts,
+	}
+	s.RegisterChannel(notifications.NewInAppChannel(repos.Notifications))
+	return s
+}
+
+// RegisterChannel adds a delivery channel. Users can only be notified on registered channels;
+// a preference for an unregistered channel is ignored. It must be called before dispatching starts.
+func (s *NotificationServiceImpl) RegisterChannel(channel notifications.Channel) {
+	s.channels[channel.Name()] = channel
+}
+
+// Notify queues a notification for delivery on each channel the user has enabled for its type.
+func (s *NotificationServiceImpl) Notify(ctx context.Context, userID uuid.UUID, notificationType domain.NotificationType, data map[string]interface{}) error {
+	prefs, err := s.preferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, channel := range prefs.ChannelsFor(notificationType) {
+		if _, ok := s.channels[channel]; !ok {
+			utils.DebugContext(ctx, "skipping unconfigured notification channel",
+				"user_id", userID.String(),
+				"channel", string(channel),
+			)
+			continue
+		}
+
+		delivery := &domain.NotificationDelivery{
+			ID:            uuid.New(),
+			UserID:        userID,
+			Type:          notificationType,
+			Channel:       channel,
+			Data:          data,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := s.repos.Notifications.CreateDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("failed to queue notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// List retrieves the user's in-app inbox with the number of unread notifications.
+func (s *NotificationServiceImpl) List(ctx context.Context, userID uuid.UUID, filter *domain.NotificationFilter) ([]*domain.Notification, int, error) {
+	filter.UserID = userID
+
+	items, err := s.repos.Notifications.ListNotifications(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	unread, err := s.repos.Notifications.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	if items == nil {
+		items = []*domain.Notification{}
+	}
+
+	return items, unread, nil
+}
+
+// MarkRead marks one of the user's notifications as read.
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Notification, error) {
+	notification, err := s.repos.Notifications.GetNotification(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("notification not found")
+	}
+
+	if notification.UserID != userID {
+		return nil, fmt.Errorf("access denied: not owner of notification")
+	}
+
+	if notification.ReadAt != nil {
+		return notification, nil
+	}
+
+	now := time.Now()
+	if err := s.repos.Notifications.MarkRead(ctx, id, now); err != nil {
+		return nil, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	notification.ReadAt = &now
+
+	return notification, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read and returns how many were marked.
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	marked, err := s.repos.Notifications.MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return marked, nil
+}
+
+// GetPreferences retrieves the user's notification preferences, listing every notification type.
+func (s *NotificationServiceImpl) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	return s.preferences(ctx, userID)
+}
+
+// UpdatePreferences changes the channels of the given notification types and the webhook URL.
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	prefs, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for notificationType, channels := range req.Channels {
+		prefs.Channels[notificationType] = uniqueChannels(channels)
+	}
+	if req.WebhookURL != nil {
+		prefs.WebhookURL = *req.WebhookURL
+	}
+
+	if prefs.WebhookURL == "" {
+		for notificationType, channels := range prefs.Channels {
+			for _, channel := range channels {
+				if channel == domain.ChannelWebhook {
+					return nil, fmt.Errorf("invalid request: webhook_url: webhook_url is required to use the webhook channel for %s", notificationType)
+				}
+			}
+		}
+	}
+
+	prefs.UpdatedAt = time.Now()
+	if err := s.repos.Notifications.UpsertPreferences(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// DispatchPending renders and sends due deliveries and returns how many were sent.
+// Failed deliveries are retried with exponential backoff until the maximum number of attempts.
+func (s *NotificationServiceImpl) DispatchPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := s.repos.Notifications.ClaimDueDeliveries(ctx, now, now.Add(notificationDispatchLease), notificationDispatchBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim notification deliveries: %w", err)
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		if s.deliver(ctx, delivery) {
+			sent++
+		}
+	}
+
+	if len(deliveries) > 0 {
+		utils.InfoContext(ctx, "dispatched notifications",
+			slog.Int("claimed", len(deliveries)),
+			slog.Int("sent", sent),
+		)
+	}
+
+	return sent, nil
+}
+
+// deliver makes one attempt at a delivery, records the outcome and reports whether it was sent.
+func (s *NotificationServiceImpl) deliver(ctx context.Context, delivery *domain.NotificationDelivery) bool {
+	sendErr := s.send(ctx, delivery)
+
+	now := time.Now()
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.Status = domain.DeliverySent
+		delivery.SentAt = &now
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = sendErr.Error()
+		if delivery.Attempts >= s.maxAttempts {
+			delivery.Status = domain.DeliveryFailed
+			utils.WarnContext(ctx, "giving up on notification delivery",
+				"delivery_id", delivery.ID.String(),
+				"channel", string(delivery.Channel),
+				"attempts", delivery.Attempts,
+				"error", sendErr.Error(),
+			)
+		} else {
+			delivery.NextAttemptAt = now.Add(notificationRetryDelay(delivery.Attempts))
+		}
+	}
+
+	if err := s.repos.Notifications.UpdateDelivery(ctx, delivery); err != nil {
+		// The lease expires and the delivery is attempted again; the in-app channel is idempotent
+		utils.ErrorContext(ctx, "failed to record notification delivery",
+			"delivery_id", delivery.ID.String(),
+			"error", err.Error(),
+		)
+	}
+
+	return sendErr == nil
+}
+
+// send renders a delivery and sends it on its channel.
+func (s *NotificationServiceImpl) send(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	channel, ok := s.channels[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("notification channel %s is not configured", delivery.Channel)
+	}
+
+	title, body, err := notifications.Render(delivery.Type, delivery.Data)
+	if err != nil {
+		return err
+	}
+
+	msg := &notifications.Message{
+		ID:        delivery.ID,
+		UserID:    delivery.UserID,
+		Type:      delivery.Type,
+		Title:     title,
+		Body:      body,
+		Data:      delivery.Data,
+		CreatedAt: delivery.CreatedAt,
+	}
+
+	switch delivery.Channel {
+	case domain.ChannelEmail:
+		user, err := s.repos.Users.GetByID(ctx, delivery.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get recipient: %w", err)
+		}
+		msg.Email = user.Email
+	case domain.ChannelWebhook:
+		prefs, err := s.preferences(ctx, delivery.UserID)
+		if err != nil {
+			return err
+		}
+		msg.WebhookURL = prefs.WebhookURL
+	}
+
+	return channel.Send(ctx, msg)
+}
+
+// preferences retrieves the user's stored preferences, filling in the default for every
+// notification type without a stored preference.
+func (s *NotificationServiceImpl) preferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	prefs := domain.DefaultNotificationPreferences(userID)
+
+	stored, err := s.repos.Notifications.GetPreferences(ctx, userID)
+	if err != nil {
+		if err.Error() == "notification preferences not found" {
+			return prefs, nil
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	for notificationType, channels := range stored.Channels {
+		prefs.Channels[notificationType] = channels
+	}
+	prefs.WebhookURL = stored.WebhookURL
+	prefs.UpdatedAt = stored.UpdatedAt
+
+	return prefs, nil
+}
+
+// notificationRetryDelay returns how long to wait before retrying a delivery that has failed attempts times.
+func notificationRetryDelay(attempts int) time.Duration {
+	delay := notificationRetryBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= notificationRetryMax {
+			return notificationRetryMax
+		}
+	}
+	return delay
+}
+
+// uniqueChannels returns channels without duplicates, keeping the first occurrence of each.
+func uniqueChannels(channels []domain.NotificationChannel) []domain.NotificationChannel {
+	unique := make([]domain.NotificationChannel, 0, len(channels))
+	seen := make(map[domain.NotificationChannel]bool, len(channels))
+	for _, channel := range channels {
+		if !seen[channel] {
+			seen[channel] = true
+			unique = append(unique, channel)
+		}
+	}
+	return unique
+}
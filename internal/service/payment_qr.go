@@ -0,0 +1,91 @@
+// Package service provides payment QR codes and transfers prefilled from them.
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/qrcode"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// PaymentQRServiceImpl implements PaymentQRService.
+type PaymentQRServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewPaymentQRService creates a new payment QR service.
+func NewPaymentQRService(repos *repository.Repositories) PaymentQRService {
+	return &PaymentQRServiceImpl{repos: repos}
+}
+
+// Generate creates a payment QR code asking for a transfer to the user's account. Without a
+// currency it asks for the currency of the user's balance.
+func (s *PaymentQRServiceImpl) Generate(ctx context.Context, userID uuid.UUID, req *domain.PaymentQRRequest) (*domain.PaymentQR, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	user, err := s.repos.Users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		balance, err := s.repos.Balances.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance: %w", err)
+		}
+		currency = balance.Currency
+		if req.Amount > 0 {
+			if err := domain.ValidateCurrencyAmount(currency, req.Amount); err != nil {
+				return nil, fmt.Errorf("invalid request: amount: %w", err)
+			}
+		}
+	}
+
+	q := &domain.PaymentQR{
+		AccountNumber: user.AccountNumber,
+		Amount:        req.Amount,
+		Currency:      currency,
+		Description:   req.Description,
+	}
+	if len(q.Payload()) > qrcode.MaxDataLength {
+		return nil, fmt.Errorf("invalid request: description is too long for a payment QR code")
+	}
+
+	return q, nil
+}
+
+// PrefillTransfer decodes a scanned payment QR payload into a transfer for the payer to confirm.
+func (s *PaymentQRServiceImpl) PrefillTransfer(ctx context.Context, userID uuid.UUID, req *domain.QRTransferRequest) (*domain.QRTransferDraft, error) {
+	q, err := domain.ParsePaymentQR(req.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	recipient, err := s.repos.Users.GetByAccountNumber(ctx, q.AccountNumber)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, fmt.Errorf("account not found")
+		}
+		return nil, fmt.Errorf("failed to look up account: %w", err)
+	}
+	if recipient.ID == userID {
+		return nil, fmt.Errorf("invalid request: transfer cannot be to the same user")
+	}
+
+	draft, err := domain.NewQRTransferDraft(q, req, &domain.AccountLookup{
+		AccountNumber: recipient.AccountNumber,
+		UserID:        recipient.ID,
+		Username:      recipient.Username,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	return draft, nil
+}
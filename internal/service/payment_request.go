@@ -0,0 +1,261 @@
+// Package service provides business logic for payment requests.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// PaymentRequestServiceImpl implements PaymentRequestService.
+type PaymentRequestServiceImpl struct {
+	repos          *repository.Repositories
+	transactionSvc TransactionService
+	eventSvc       *EventService // Event service for publishing domain events
+}
+
+// NewPaymentRequestService creates a new payment request service.
+func NewPaymentRequestService(repos *repository.Repositories, transactionSvc TransactionService, eventSvc *EventService) PaymentRequestService {
+	return &PaymentRequestServiceImpl{
+		repos:          repos,
+		transactionSvc: transactionSvc,
+		eventSvc:       eventSvc,
+	}
+}
+
+// Create sends a payment request from the requester to the payer.
+func (s *PaymentRequestServiceImpl) Create(ctx context.Context, requesterID uuid.UUID, req *domain.CreatePaymentRequestRequest) (*domain.PaymentRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if req.PayerID == requesterID {
+		return nil, fmt.Errorf("cannot request payment from yourself")
+	}
+
+	payer, err := s.repos.Users.GetByID(ctx, req.PayerID)
+	if err != nil || !payer.IsActive {
+		return nil, fmt.Errorf("payer not found")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(domain.DefaultPaymentRequestExpiry)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	request := &domain.PaymentRequest{
+		ID:          uuid.New(),
+		RequesterID: requesterID,
+		PayerID:     req.PayerID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Note:        strings.TrimSpace(req.Note),
+		Status:      string(domain.PaymentRequestPending),
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repos.PaymentRequests.Create(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to create payment request: %w", err)
+	}
+
+	s.publish(ctx, domain.EventPaymentRequested, request)
+
+	return request, nil
+}
+
+// GetByID retrieves a payment request the user is the requester or payer of.
+func (s *PaymentRequestServiceImpl) GetByID(ctx context.Context, id uuid.UUID, requestingUserID uuid.UUID) (*domain.PaymentRequest, error) {
+	request, err := s.repos.PaymentRequests.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("payment request not found")
+	}
+
+	if request.RequesterID != requestingUserID && request.PayerID != requestingUserID {
+		return nil, fmt.Errorf("access denied: not a party to payment request")
+	}
+
+	s.expireIfDue(ctx, request)
+
+	return request, nil
+}
+
+// ListIncoming retrieves payment requests the user has been asked to pay.
+func (s *PaymentRequestServiceImpl) ListIncoming(ctx context.Context, payerID uuid.UUID, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error) {
+	if filter == nil {
+		filter = &domain.PaymentRequestFilter{}
+	}
+	filter.PayerID = &payerID
+	filter.RequesterID = nil
+
+	return s.list(ctx, filter)
+}
+
+// ListOutgoing retrieves payment requests the user has sent.
+func (s *PaymentRequestServiceImpl) ListOutgoing(ctx context.Context, requesterID uuid.UUID, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error) {
+	if filter == nil {
+		filter = &domain.PaymentRequestFilter{}
+	}
+	filter.RequesterID = &requesterID
+	filter.PayerID = nil
+
+	return s.list(ctx, filter)
+}
+
+// Approve pays a pending payment request by transferring the amount from the payer to the requester.
+// If the transfer fails the request stays pending so the payer can try again.
+func (s *PaymentRequestServiceImpl) Approve(ctx context.Context, id uuid.UUID, payerID uuid.UUID) (*domain.PaymentRequest, error) {
+	request, err := s.getRespondable(ctx, id, payerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the request before paying so a concurrent approval cannot pay it twice
+	now := time.Now()
+	request.Status = string(domain.PaymentRequestApproved)
+	request.RespondedAt = &now
+	request.UpdatedAt = now
+	if err := s.repos.PaymentRequests.UpdateStatus(ctx, request, string(domain.PaymentRequestPending)); err != nil {
+		return nil, s.updateError(err)
+	}
+
+	transfer, err := s.transactionSvc.Transfer(ctx, payerID, &domain.TransferRequest{
+		ToUserID:    request.RequesterID,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		Description: request.Note,
+	})
+	if err != nil {
+		request.Status = string(domain.PaymentRequestPending)
+		request.RespondedAt = nil
+		request.UpdatedAt = time.Now()
+		if revertErr := s.repos.PaymentRequests.UpdateStatus(ctx, request, string(domain.PaymentRequestApproved)); revertErr != nil {
+			utils.ErrorContext(ctx, "failed to reopen payment request after failed transfer",
+				"payment_request_id", request.ID.String(), "error", revertErr.Error())
+		}
+		return nil, fmt.Errorf("failed to pay payment request: %w", err)
+	}
+
+	request.TransactionID = &transfer.ID
+	request.UpdatedAt = time.Now()
+	if err := s.repos.PaymentRequests.UpdateStatus(ctx, request, string(domain.PaymentRequestApproved)); err != nil {
+		utils.ErrorContext(ctx, "failed to link payment request to transfer",
+			"payment_request_id", request.ID.String(), "transaction_id", transfer.ID.String(), "error", err.Error())
+	}
+
+	s.publish(ctx, domain.EventPaymentRequestApproved, request)
+
+	_ = s.repos.Audit.Log(ctx, "transaction", transfer.ID, "payment_request_approved", map[string]interface{}{
+		"payment_request_id": request.ID,
+		"requester_id":       request.RequesterID,
+		"payer_id":           request.PayerID,
+		"amount":             request.Amount,
+	})
+
+	return request, nil
+}
+
+// Decline declines a pending payment request without moving any money.
+func (s *PaymentRequestServiceImpl) Decline(ctx context.Context, id uuid.UUID, payerID uuid.UUID) (*domain.PaymentRequest, error) {
+	request, err := s.getRespondable(ctx, id, payerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = string(domain.PaymentRequestDeclined)
+	request.RespondedAt = &now
+	request.UpdatedAt = now
+	if err := s.repos.PaymentRequests.UpdateStatus(ctx, request, string(domain.PaymentRequestPending)); err != nil {
+		return nil, s.updateError(err)
+	}
+
+	s.publish(ctx, domain.EventPaymentRequestDeclined, request)
+
+	return request, nil
+}
+
+// list retrieves payment requests, expiring any that are past due. When filtering on pending,
+// requests that just expired are left out.
+func (s *PaymentRequestServiceImpl) list(ctx context.Context, filter *domain.PaymentRequestFilter) ([]*domain.PaymentRequest, error) {
+	requests, err := s.repos.PaymentRequests.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment requests: %w", err)
+	}
+
+	listed := make([]*domain.PaymentRequest, 0, len(requests))
+	for _, request := range requests {
+		s.expireIfDue(ctx, request)
+		if filter.Status != nil && request.Status != *filter.Status {
+			continue
+		}
+		listed = append(listed, request)
+	}
+
+	return listed, nil
+}
+
+// getRespondable loads a payment request the payer can still approve or decline.
+func (s *PaymentRequestServiceImpl) getRespondable(ctx context.Context, id uuid.UUID, payerID uuid.UUID) (*domain.PaymentRequest, error) {
+	request, err := s.GetByID(ctx, id, payerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.PayerID != payerID {
+		return nil, fmt.Errorf("access denied: only the payer can respond to a payment request")
+	}
+
+	switch request.Status {
+	case string(domain.PaymentRequestPending):
+		return request, nil
+	case string(domain.PaymentRequestExpired):
+		return nil, fmt.Errorf("payment request has expired")
+	default:
+		return nil, fmt.Errorf("payment request is no longer pending")
+	}
+}
+
+// expireIfDue marks a pending payment request past its expiry as expired.
+func (s *PaymentRequestServiceImpl) expireIfDue(ctx context.Context, request *domain.PaymentRequest) {
+	now := time.Now()
+	if !request.IsExpiredAt(now) {
+		return
+	}
+
+	request.Status = string(domain.PaymentRequestExpired)
+	request.UpdatedAt = now
+	if err := s.repos.PaymentRequests.UpdateStatus(ctx, request, string(domain.PaymentRequestPending)); err != nil {
+		// Another request expired or answered it first; the next read shows the stored status
+		return
+	}
+
+	s.publish(ctx, domain.EventPaymentRequestExpired, request)
+}
+
+// updateError maps a failed conditional status update to a service error.
+func (s *PaymentRequestServiceImpl) updateError(err error) error {
+	if err.Error() == "payment request status changed" {
+		return fmt.Errorf("payment request is no longer pending")
+	}
+	return fmt.Errorf("failed to update payment request: %w", err)
+}
+
+// publish publishes a payment request event if events are enabled.
+func (s *PaymentRequestServiceImpl) publish(ctx context.Context, eventType domain.EventType, request *domain.PaymentRequest) {
+	if s.eventSvc == nil {
+		return
+	}
+
+	if err := s.eventSvc.PaymentRequestEvent(ctx, eventType, request); err != nil {
+		utils.Error("failed to publish payment request event", "event_type", string(eventType), "error", err.Error())
+	}
+}
@@ -0,0 +1,164 @@
+// Package service provides the versioned runtime policy.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// PolicyServiceImpl implements PolicyService. The version in effect is kept in memory and reloaded
+// by Refresh; a version scheduled for later takes effect on its effective time through a timer,
+// and versions stored through other instances are picked up on the next Refresh.
+type PolicyServiceImpl struct {
+	repos *repository.Repositories
+	base  domain.Policy // The configured policy, in effect until a version is stored
+
+	mu      sync.RWMutex
+	current *domain.PolicyVersion
+	timer   *time.Timer // Fires when the next scheduled version takes effect
+}
+
+// NewPolicyService creates a new policy service with the configured policy in effect as version 0.
+func NewPolicyService(repos *repository.Repositories, base domain.Policy) PolicyService {
+	s := &PolicyServiceImpl{repos: repos, base: base}
+	s.apply(&domain.PolicyVersion{Policy: base})
+	return s
+}
+
+// Current returns the policy in effect now. Callers must not modify it.
+func (s *PolicyServiceImpl) Current() *domain.Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &s.current.Policy
+}
+
+// Effective returns the version in effect now; version 0 is the configured policy.
+func (s *PolicyServiceImpl) Effective() *domain.PolicyVersion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// List retrieves stored versions of the policy, newest first.
+func (s *PolicyServiceImpl) List(ctx context.Context, filter *domain.PolicyVersionFilter) ([]*domain.PolicyVersion, error) {
+	versions, err := s.repos.Policies.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy versions: %w", err)
+	}
+
+	if versions == nil {
+		versions = []*domain.PolicyVersion{}
+	}
+
+	return versions, nil
+}
+
+// GetByVersion retrieves a stored version of the policy.
+func (s *PolicyServiceImpl) GetByVersion(ctx context.Context, version int) (*domain.PolicyVersion, error) {
+	policyVersion, err := s.repos.Policies.GetByVersion(ctx, version)
+	if err != nil {
+		if err.Error() == "policy version not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get policy version: %w", err)
+	}
+
+	return policyVersion, nil
+}
+
+// Set stores a new version of the policy. A version without an effective time, or with one that
+// has already passed, e.g. because it waited for approval, takes effect at once.
+func (s *PolicyServiceImpl) Set(ctx context.Context, actorID uuid.UUID, req *domain.SetPolicyRequest) (*domain.PolicyVersion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	now := time.Now()
+	effectiveFrom := now
+	if req.EffectiveFrom != nil && req.EffectiveFrom.After(now) {
+		effectiveFrom = *req.EffectiveFrom
+	}
+
+	version := &domain.PolicyVersion{
+		ID:            uuid.New(),
+		Policy:        req.Policy,
+		EffectiveFrom: effectiveFrom,
+		Reason:        req.Reason,
+		CreatedBy:     actorID,
+		CreatedAt:     now,
+	}
+	if err := s.repos.Policies.Create(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to create policy version: %w", err)
+	}
+
+	if err := s.repos.Audit.Log(ctx, string(domain.EntityPolicy), version.ID, string(domain.ActionCreated), map[string]interface{}{
+		"version":        version.Version,
+		"effective_from": version.EffectiveFrom,
+		"reason":         version.Reason,
+		"actor_id":       actorID,
+		"policy":         version.Policy,
+	}); err != nil {
+		utils.WarnContext(ctx, "failed to audit policy version",
+			"version", version.Version,
+			"error", err.Error(),
+		)
+	}
+
+	if err := s.Refresh(ctx); err != nil {
+		utils.ErrorContext(ctx, "failed to load new policy version", "version", version.Version, "error", err.Error())
+	}
+
+	return version, nil
+}
+
+// Refresh loads the version in effect now and schedules the next one. On failure the version
+// already loaded stays in effect.
+func (s *PolicyServiceImpl) Refresh(ctx context.Context) error {
+	now := time.Now()
+	version, err := s.repos.Policies.EffectiveAt(ctx, now)
+	if err != nil {
+		if err.Error() != "policy version not found" {
+			return fmt.Errorf("failed to get effective policy version: %w", err)
+		}
+		version = &domain.PolicyVersion{Policy: s.base}
+	}
+
+	next, err := s.repos.Policies.NextAfter(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get next policy version: %w", err)
+	}
+
+	s.apply(version)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if next != nil {
+		s.timer = time.AfterFunc(time.Until(next.EffectiveFrom), func() {
+			if err := s.Refresh(context.Background()); err != nil {
+				utils.Error("failed to load scheduled policy version", "version", next.Version, "error", err.Error())
+			}
+		})
+	}
+
+	return nil
+}
+
+// apply puts a version in effect, including the amount limits request validation consults.
+func (s *PolicyServiceImpl) apply(version *domain.PolicyVersion) {
+	domain.SetAmountLimits(version.Policy.AmountLimits)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = version
+}
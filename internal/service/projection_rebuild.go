@@ -0,0 +1,131 @@
+// Package service provides on-demand read model rebuilds.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// maxRetainedRebuilds is how many rebuilds are kept in memory; older finished ones are dropped.
+const maxRetainedRebuilds = 10
+
+// ProjectionRebuildServiceImpl implements ProjectionRebuildService. Rebuilds run in the background
+// on the projector and are kept in memory only, so each instance reports its own. One runs at a time.
+type ProjectionRebuildServiceImpl struct {
+	projector *ProjectorService
+
+	mu       sync.Mutex
+	rebuilds []*domain.ProjectionRebuild // Oldest first
+}
+
+// NewProjectionRebuildService creates a new projection rebuild service.
+func NewProjectionRebuildService(projector *ProjectorService) ProjectionRebuildService {
+	return &ProjectionRebuildServiceImpl{projector: projector}
+}
+
+// Start begins rebuilding the read models a request selects in the background.
+func (s *ProjectionRebuildServiceImpl) Start(ctx context.Context, actorID uuid.UUID, req *domain.ProjectionRebuildRequest) (*domain.ProjectionRebuild, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rebuild := range s.rebuilds {
+		if rebuild.Status == domain.ProjectionRebuildRunning {
+			return nil, fmt.Errorf("projection rebuild already running: %s", rebuild.ID)
+		}
+	}
+
+	rebuild := &domain.ProjectionRebuild{
+		ID:        uuid.New(),
+		Status:    domain.ProjectionRebuildRunning,
+		Request:   *req,
+		StartedBy: actorID,
+		StartedAt: time.Now(),
+	}
+	s.rebuilds = append(s.rebuilds, rebuild)
+	s.prune()
+
+	// The rebuild outlives the request that started it
+	go s.execute(context.WithoutCancel(ctx), rebuild)
+
+	return s.snapshot(rebuild), nil
+}
+
+// Status reports whether a rebuild is running and the retained rebuilds, newest first.
+func (s *ProjectionRebuildServiceImpl) Status(ctx context.Context) (*domain.ProjectionStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := &domain.ProjectionStatus{Rebuilds: make([]*domain.ProjectionRebuild, 0, len(s.rebuilds))}
+	for i := len(s.rebuilds) - 1; i >= 0; i-- {
+		status.Running = status.Running || s.rebuilds[i].Status == domain.ProjectionRebuildRunning
+		status.Rebuilds = append(status.Rebuilds, s.snapshot(s.rebuilds[i]))
+	}
+
+	return status, nil
+}
+
+// execute replays a rebuild's events, recording its progress as it goes.
+func (s *ProjectionRebuildServiceImpl) execute(ctx context.Context, rebuild *domain.ProjectionRebuild) {
+	err := s.projector.Rebuild(ctx, &rebuild.Request, func(processed, failed, total int) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		rebuild.EventsProcessed = processed
+		rebuild.EventsFailed = failed
+		rebuild.EventsTotal = total
+	})
+
+	now := time.Now()
+	s.mu.Lock()
+	if err != nil {
+		rebuild.Status = domain.ProjectionRebuildFailed
+		rebuild.Error = err.Error()
+	} else {
+		rebuild.Status = domain.ProjectionRebuildCompleted
+	}
+	rebuild.FinishedAt = &now
+	finished := s.snapshot(rebuild)
+	s.mu.Unlock()
+
+	if err != nil {
+		utils.WarnContext(ctx, "projection rebuild failed",
+			"rebuild_id", finished.ID.String(),
+			"error", finished.Error,
+		)
+		return
+	}
+
+	utils.InfoContext(ctx, "projection rebuild finished",
+		"rebuild_id", finished.ID.String(),
+		"events_processed", finished.EventsProcessed,
+		"events_failed", finished.EventsFailed,
+	)
+}
+
+// snapshot returns a copy of a rebuild with its progress computed. The caller must hold s.mu.
+func (s *ProjectionRebuildServiceImpl) snapshot(rebuild *domain.ProjectionRebuild) *domain.ProjectionRebuild {
+	c := *rebuild
+	c.ComputeProgress()
+	return &c
+}
+
+// prune drops the oldest finished rebuilds beyond the retention limit. The caller must hold s.mu.
+func (s *ProjectionRebuildServiceImpl) prune() {
+	for i := 0; len(s.rebuilds) > maxRetainedRebuilds && i < len(s.rebuilds); {
+		if s.rebuilds[i].Status == domain.ProjectionRebuildRunning {
+			i++
+			continue
+		}
+		s.rebuilds = append(s.rebuilds[:i], s.rebuilds[i+1:]...)
+	}
+}
@@ -0,0 +1,282 @@
+// Package service provides dry-run replays of the event store for diagnosing read model drift.
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// Replay projects the events a request selects into a scratch read model that starts empty, and
+// compares every record the replay produced with the stored read models, which are left untouched.
+// A slice that starts after an aggregate's first event lacks its earlier history, so its balance
+// only matches when the slice holds every change to it.
+func (p *ProjectorService) Replay(ctx context.Context, req *domain.ProjectionReplayRequest) (*domain.ProjectionReplayReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	events, err := p.rebuildEvents(ctx, &req.ProjectionRebuildRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	scratch := newReplayReadModel()
+	replayer := NewProjectorService(p.eventRepo, scratch.users, scratch.balances, scratch.transactions)
+
+	report := &domain.ProjectionReplayReport{Differences: []domain.ProjectionDifference{}}
+	for _, event := range events {
+		if req.Since != nil && event.CreatedAt.Before(*req.Since) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("replay interrupted after %d events: %w", report.EventsReplayed, err)
+		}
+
+		report.EventsReplayed++
+		if err := replayer.projectEvent(ctx, event); err != nil {
+			report.EventsFailed++
+		}
+	}
+
+	report.Users = len(scratch.users.users)
+	report.Balances = len(scratch.balances.balances)
+	report.Transactions = len(scratch.transactions.transactions)
+
+	for _, projected := range scratch.users.users {
+		current, err := p.userRepo.GetByID(ctx, projected.ID)
+		report.Differences = append(report.Differences, diffUser(projected, current, err)...)
+	}
+	for _, projected := range scratch.balances.balances {
+		current, err := p.balanceRepo.GetByUserID(ctx, projected.UserID)
+		report.Differences = append(report.Differences, diffBalance(projected, current, err)...)
+	}
+	for _, projected := range scratch.transactions.transactions {
+		current, err := p.transactionRepo.GetByID(ctx, projected.ID)
+		report.Differences = append(report.Differences, diffTransaction(projected, current, err)...)
+	}
+
+	sort.SliceStable(report.Differences, func(i, j int) bool {
+		a, b := report.Differences[i], report.Differences[j]
+		if a.AggregateType != b.AggregateType {
+			return a.AggregateType < b.AggregateType
+		}
+		return a.AggregateID.String() < b.AggregateID.String()
+	})
+
+	return report, nil
+}
+
+// replayDiffer collects the differences of one replayed record.
+type replayDiffer struct {
+	aggregateType domain.AggregateType
+	aggregateID   uuid.UUID
+	differences   []domain.ProjectionDifference
+}
+
+// missing records that the read model has no such record.
+func (d *replayDiffer) missing(err error) []domain.ProjectionDifference {
+	return []domain.ProjectionDifference{{
+		AggregateType: d.aggregateType,
+		AggregateID:   d.aggregateID,
+		Field:         "missing",
+		Projected:     "present",
+		Current:       err.Error(),
+	}}
+}
+
+// compare records a field whose projected and current values differ.
+func (d *replayDiffer) compare(field, projected, current string) {
+	if projected != current {
+		d.differences = append(d.differences, domain.ProjectionDifference{
+			AggregateType: d.aggregateType,
+			AggregateID:   d.aggregateID,
+			Field:         field,
+			Projected:     projected,
+			Current:       current,
+		})
+	}
+}
+
+// compareAmount records an amount whose projected and current values differ by a cent or more.
+func (d *replayDiffer) compareAmount(field string, projected, current float64) {
+	if math.Abs(projected-current) >= 0.01 {
+		d.compare(field, strconv.FormatFloat(projected, 'f', 2, 64), strconv.FormatFloat(current, 'f', 2, 64))
+	}
+}
+
+// diffUser compares a replayed user with the stored one.
+func diffUser(projected, current *domain.User, err error) []domain.ProjectionDifference {
+	d := &replayDiffer{aggregateType: domain.AggregateUser, aggregateID: projected.ID}
+	if err != nil {
+		return d.missing(err)
+	}
+
+	d.compare("username", projected.Username, current.Username)
+	d.compare("email", projected.Email, current.Email)
+	d.compare("role", projected.Role, current.Role)
+	d.compare("is_active", strconv.FormatBool(projected.IsActive), strconv.FormatBool(current.IsActive))
+	return d.differences
+}
+
+// diffBalance compares a replayed balance with the stored one. Balances first seen through a credit
+// have no currency, which is then not compared.
+func diffBalance(projected, current *domain.Balance, err error) []domain.ProjectionDifference {
+	d := &replayDiffer{aggregateType: domain.AggregateBalance, aggregateID: projected.UserID}
+	if err != nil {
+		return d.missing(err)
+	}
+
+	d.compareAmount("amount", projected.Amount, current.Amount)
+	if projected.Currency != "" {
+		d.compare("currency", projected.Currency, current.Currency)
+	}
+	return d.differences
+}
+
+// diffTransaction compares a replayed transaction with the stored one. Transaction events carry no
+// currency or description, so those are not compared.
+func diffTransaction(projected, current *domain.Transaction, err error) []domain.ProjectionDifference {
+	d := &replayDiffer{aggregateType: domain.AggregateTransaction, aggregateID: projected.ID}
+	if err != nil {
+		return d.missing(err)
+	}
+
+	d.compare("type", projected.Type, current.Type)
+	d.compare("status", projected.Status, current.Status)
+	d.compareAmount("amount", projected.Amount, current.Amount)
+	d.compareAmount("reversed_amount", projected.ReversedAmount, current.ReversedAmount)
+	return d.differences
+}
+
+// replayReadModel is the scratch read model a replay projects into. Its repositories implement
+// only what the projector calls; the embedded interfaces are nil and any other call panics.
+type replayReadModel struct {
+	users        *replayUsers
+	balances     *replayBalances
+	transactions *replayTransactions
+}
+
+// newReplayReadModel creates an empty scratch read model.
+func newReplayReadModel() *replayReadModel {
+	return &replayReadModel{
+		users:        &replayUsers{users: make(map[uuid.UUID]*domain.User)},
+		balances:     &replayBalances{balances: make(map[uuid.UUID]*domain.Balance)},
+		transactions: &replayTransactions{transactions: make(map[uuid.UUID]*domain.Transaction)},
+	}
+}
+
+// replayUsers holds replayed users.
+type replayUsers struct {
+	repository.UsersRepo
+	users map[uuid.UUID]*domain.User
+}
+
+// Create stores a replayed user unless one with its ID exists.
+func (r *replayUsers) Create(_ context.Context, user *domain.User) error {
+	if _, exists := r.users[user.ID]; exists {
+		return fmt.Errorf("user already exists")
+	}
+	c := *user
+	r.users[user.ID] = &c
+	return nil
+}
+
+// GetByID retrieves a replayed user.
+func (r *replayUsers) GetByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	user, exists := r.users[id]
+	if !exists {
+		return nil, fmt.Errorf("user not found")
+	}
+	c := *user
+	return &c, nil
+}
+
+// Update replaces a replayed user.
+func (r *replayUsers) Update(_ context.Context, user *domain.User) error {
+	if _, exists := r.users[user.ID]; !exists {
+		return fmt.Errorf("user not found")
+	}
+	c := *user
+	r.users[user.ID] = &c
+	return nil
+}
+
+// replayBalances holds replayed balances.
+type replayBalances struct {
+	repository.BalancesRepo
+	balances map[uuid.UUID]*domain.Balance
+}
+
+// GetByUserID retrieves a replayed balance.
+func (r *replayBalances) GetByUserID(_ context.Context, userID uuid.UUID) (*domain.Balance, error) {
+	balance, exists := r.balances[userID]
+	if !exists {
+		return nil, fmt.Errorf("balance not found for user")
+	}
+	c := *balance
+	return &c, nil
+}
+
+// Upsert stores a replayed balance.
+func (r *replayBalances) Upsert(_ context.Context, balance *domain.Balance) error {
+	c := *balance
+	r.balances[balance.UserID] = &c
+	return nil
+}
+
+// replayTransactions holds replayed transactions.
+type replayTransactions struct {
+	repository.TransactionsRepo
+	transactions map[uuid.UUID]*domain.Transaction
+}
+
+// CreatePending stores a replayed transaction with the status the projector gave it.
+func (r *replayTransactions) CreatePending(_ context.Context, tx *domain.Transaction) error {
+	if _, exists := r.transactions[tx.ID]; exists {
+		return fmt.Errorf("failed to create pending transaction: duplicate id")
+	}
+	c := *tx
+	r.transactions[tx.ID] = &c
+	return nil
+}
+
+// MarkCompleted marks a replayed transaction as completed.
+func (r *replayTransactions) MarkCompleted(_ context.Context, id uuid.UUID) error {
+	return r.setStatus(id, domain.StatusSuccess)
+}
+
+// MarkFailed marks a replayed transaction as failed.
+func (r *replayTransactions) MarkFailed(_ context.Context, id uuid.UUID) error {
+	return r.setStatus(id, domain.StatusFailed)
+}
+
+// setStatus changes the status of a replayed transaction.
+func (r *replayTransactions) setStatus(id uuid.UUID, status domain.TransactionStatus) error {
+	tx, exists := r.transactions[id]
+	if !exists {
+		return fmt.Errorf("transaction not found")
+	}
+	tx.Status = string(status)
+	return nil
+}
+
+// RecordReversal adds a rollback amount to a replayed transaction, linking the rollback once fully reversed.
+func (r *replayTransactions) RecordReversal(_ context.Context, originalID uuid.UUID, reversalID uuid.UUID, amount float64) error {
+	tx, exists := r.transactions[originalID]
+	if !exists || tx.ReversedAmount+amount > tx.Amount {
+		return fmt.Errorf("transaction not found or reversal exceeds original amount")
+	}
+
+	tx.ReversedAmount += amount
+	if tx.ReversedAmount >= tx.Amount {
+		tx.ReversedByTransactionID = &reversalID
+	}
+	return nil
+}
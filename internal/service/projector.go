@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -338,14 +339,18 @@ func (p *ProjectorService) projectTransactionEvent(ctx context.Context, event *d
 		}
 
 		transaction := &domain.Transaction{
-			ID:         eventData.TransactionID,
-			FromUserID: eventData.FromUserID,
-			ToUserID:   eventData.ToUserID,
-			Amount:     eventData.Amount,
-			Type:       eventData.Type,
-			Status:     string(domain.StatusPending),
-			CreatedAt:  event.CreatedAt,
+			ID:              eventData.TransactionID,
+			FromUserID:      eventData.FromUserID,
+			ToUserID:        eventData.ToUserID,
+			Amount:          eventData.Amount,
+			Type:            eventData.Type,
+			Status:          string(domain.StatusPending),
+			Description:     eventData.Description,
+			Category:        eventData.Category,
+			ClientReference: eventData.ClientReference,
+			CreatedAt:       event.CreatedAt,
 		}
+		transaction.SetOrigin(eventData.Origin)
 		return p.transactionRepo.CreatePending(ctx, transaction)
 
 	case string(domain.EventTransactionCompleted):
@@ -378,20 +383,150 @@ func (p *ProjectorService) projectTransactionEvent(ctx context.Context, event *d
 			CreatedAt:  event.CreatedAt,
 		}
 		return p.transactionRepo.CreatePending(ctx, transaction)
+
+	case string(domain.EventTransactionReversed):
+		var eventData domain.TransactionReversedEvent
+		if err := event.UnmarshalData(&eventData); err != nil {
+			return err
+		}
+		return p.transactionRepo.RecordReversal(ctx, eventData.OriginalTransactionID, eventData.TransactionID, eventData.Amount)
 	}
 
 	return nil
 }
 
+// ProjectionProgress is told how many of a rebuild's events were processed, and how many of those
+// could not be applied, after each event.
+type ProjectionProgress func(processed, failed, total int)
+
+// projectorBatch is how many events of a type a rebuild reads from the event store at a time.
+const projectorBatch = 1000
+
+// projectedEventTypes lists the event types the projector applies, by aggregate type.
+var projectedEventTypes = map[domain.AggregateType][]domain.EventType{
+	domain.AggregateUser: {
+		domain.EventUserRegistered,
+		domain.EventUserUpdated,
+	},
+	domain.AggregateBalance: {
+		domain.EventBalanceInitialized,
+		domain.EventAmountCredited,
+		domain.EventAmountDebited,
+	},
+	domain.AggregateTransaction: {
+		domain.EventTransactionStarted,
+		domain.EventTransactionCompleted,
+		domain.EventTransactionFailed,
+		domain.EventTransferExecuted,
+		domain.EventTransactionReversed,
+	},
+}
+
+// replayOnlyEventTypes set or add to read models the write path has already changed when they
+// happen. Applying them again would reset or double count balances, so only rebuilds replay them.
+var replayOnlyEventTypes = map[string]bool{
+	string(domain.EventBalanceInitialized):  true,
+	string(domain.EventAmountCredited):      true,
+	string(domain.EventAmountDebited):       true,
+	string(domain.EventTransactionReversed): true,
+}
+
 // RebuildReadModels completely rebuilds all read models from scratch
 func (p *ProjectorService) RebuildReadModels(ctx context.Context) error {
-	utils.Info("starting complete read model rebuild")
+	return p.Rebuild(ctx, &domain.ProjectionRebuildRequest{}, nil)
+}
+
+// Rebuild replays the events a request selects in the order they were created. Events that cannot
+// be applied are logged and skipped, like during regular projection. progress may be nil.
+func (p *ProjectorService) Rebuild(ctx context.Context, req *domain.ProjectionRebuildRequest, progress ProjectionProgress) error {
+	utils.InfoContext(ctx, "starting read model rebuild")
+
+	events, err := p.rebuildEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	if progress != nil {
+		progress(0, failed, len(events))
+	}
+
+	for i, event := range events {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("rebuild interrupted after %d of %d events: %w", i, len(events), err)
+		}
+
+		if err := p.projectEvent(ctx, event); err != nil {
+			failed++
+			utils.Error("failed to project event", "error", err.Error(), "event_type", event.EventType)
+		}
+
+		if progress != nil {
+			progress(i+1, failed, len(events))
+		}
+	}
+
+	utils.InfoContext(ctx, "completed read model rebuild", "events", len(events), "failed", failed)
+	return nil
+}
+
+// rebuildEvents reads the events a rebuild request selects, oldest first.
+func (p *ProjectorService) rebuildEvents(ctx context.Context, req *domain.ProjectionRebuildRequest) ([]*domain.Event, error) {
+	if req.AggregateID != nil {
+		events, err := p.eventRepo.GetEventsByAggregate(ctx, *req.AggregateType, *req.AggregateID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s events: %w", *req.AggregateType, err)
+		}
+		return events, nil
+	}
+
+	aggregateTypes := domain.ProjectedAggregateTypes
+	if req.AggregateType != nil {
+		aggregateTypes = []domain.AggregateType{*req.AggregateType}
+	}
+
+	var events []*domain.Event
+	for _, aggregateType := range aggregateTypes {
+		for _, eventType := range projectedEventTypes[aggregateType] {
+			for offset := 0; ; offset += projectorBatch {
+				batch, err := p.eventRepo.GetEventsByType(ctx, eventType, projectorBatch, offset)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get %s events: %w", eventType, err)
+				}
+				events = append(events, batch...)
+				if len(batch) < projectorBatch {
+					break
+				}
+			}
+		}
+	}
 
-	// Clear existing data (optional - depends on requirements)
-	// This would require additional methods in repositories to truncate tables
+	// Events of each type come newest first; replay them all in the order they happened
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
 
-	// Project all aggregates
-	return p.ProjectAll(ctx)
+	return events, nil
+}
+
+// projectEvent applies a single event to the read model of its aggregate.
+func (p *ProjectorService) projectEvent(ctx context.Context, event *domain.Event) error {
+	switch domain.AggregateType(event.AggregateType) {
+	case domain.AggregateUser:
+		var eventData domain.UserRegisteredEvent
+		if err := event.UnmarshalData(&eventData); err != nil {
+			return err
+		}
+		return p.projectUserEvents(ctx, event.AggregateID, []*domain.Event{event})
+
+	case domain.AggregateBalance:
+		return p.projectBalanceEvent(ctx, event)
+
+	case domain.AggregateTransaction:
+		return p.projectTransactionEvent(ctx, event)
+	}
+
+	return nil
 }
 
 // ProcessAllEvents processes all events from the beginning
@@ -403,7 +538,8 @@ func (p *ProjectorService) ProcessAllEvents(ctx context.Context) error {
 	return p.ProcessEventsSince(ctx, since)
 }
 
-// ProcessEventsSince processes events since a specific time
+// ProcessEventsSince processes events since a specific time. Events are read again on every run,
+// so balance initializations, balance changes and reversals are left to rebuilds.
 func (p *ProjectorService) ProcessEventsSince(ctx context.Context, since time.Time) error {
 	utils.Info("processing events since", "since", since.Format(time.RFC3339))
 
@@ -413,26 +549,11 @@ func (p *ProjectorService) ProcessEventsSince(ctx context.Context, since time.Ti
 	}
 
 	for _, event := range events {
-		switch domain.AggregateType(event.AggregateType) {
-		case domain.AggregateUser:
-			var eventData domain.UserRegisteredEvent
-			if err := event.UnmarshalData(&eventData); err != nil {
-				utils.Error("failed to process user event", "error", err.Error())
-				continue
-			}
-			if err := p.projectUserEvents(ctx, event.AggregateID, []*domain.Event{event}); err != nil {
-				utils.Error("failed to project user event", "error", err.Error())
-			}
-
-		case domain.AggregateBalance:
-			if err := p.projectBalanceEvent(ctx, event); err != nil {
-				utils.Error("failed to project balance event", "error", err.Error())
-			}
-
-		case domain.AggregateTransaction:
-			if err := p.projectTransactionEvent(ctx, event); err != nil {
-				utils.Error("failed to project transaction event", "error", err.Error())
-			}
+		if replayOnlyEventTypes[event.EventType] {
+			continue
+		}
+		if err := p.projectEvent(ctx, event); err != nil {
+			utils.Error("failed to project "+event.AggregateType+" event", "error", err.Error())
 		}
 	}
 
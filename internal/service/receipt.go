@@ -0,0 +1,84 @@
+// Package service provides signed receipts of successful transactions.
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// ReceiptServiceImpl implements ReceiptService.
+type ReceiptServiceImpl struct {
+	repos *repository.Repositories
+	key   []byte
+}
+
+// NewReceiptService creates a new receipt service signing receipts with key.
+func NewReceiptService(repos *repository.Repositories, key []byte) ReceiptService {
+	return &ReceiptServiceImpl{repos: repos, key: key}
+}
+
+// ReceiptKeyFromSecret derives a receipt signing key from another secret, e.g. the JWT secret
+// when no receipt signing key is configured, so the secret itself never signs receipts.
+func ReceiptKeyFromSecret(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("transaction-receipts"))
+	return mac.Sum(nil)
+}
+
+// Issue signs a receipt of a successful transaction the user sent or received.
+func (s *ReceiptServiceImpl) Issue(ctx context.Context, transactionID uuid.UUID, userID uuid.UUID) (*domain.SignedReceipt, error) {
+	transaction, err := s.repos.Transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	isSender := transaction.FromUserID != nil && *transaction.FromUserID == userID
+	isRecipient := transaction.ToUserID != nil && *transaction.ToUserID == userID
+	if !isSender && !isRecipient {
+		return nil, fmt.Errorf("access denied: you don't have permission to view this transaction")
+	}
+
+	receipt, err := domain.NewTransactionReceipt(transaction, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt.Sign(s.key)
+}
+
+// Verify checks that a receipt was signed by this server and still describes the stored
+// transaction. Anyone holding a receipt may verify it.
+func (s *ReceiptServiceImpl) Verify(ctx context.Context, signed *domain.SignedReceipt) (*domain.ReceiptVerification, error) {
+	verification := &domain.ReceiptVerification{TransactionID: signed.Receipt.TransactionID}
+
+	if !signed.VerifySignature(s.key) {
+		verification.Reason = "signature does not match the receipt"
+		return verification, nil
+	}
+
+	transaction, err := s.repos.Transactions.GetByID(ctx, signed.Receipt.TransactionID)
+	if err != nil {
+		if err.Error() == "transaction not found" {
+			verification.Reason = "transaction not found"
+			return verification, nil
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if !signed.Receipt.Matches(transaction) {
+		verification.Reason = "receipt does not match the transaction"
+		return verification, nil
+	}
+
+	verification.Valid = true
+	verification.TransactionStatus = transaction.Status
+	verification.ReversedAmount = transaction.ReversedAmount
+	return verification, nil
+}
@@ -0,0 +1,87 @@
+// Package service provides retrieval and retention of recorded request/response exchanges.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// RequestLogServiceImpl implements RequestLogService on top of the audit store.
+type RequestLogServiceImpl struct {
+	repos     *repository.Repositories
+	retention time.Duration // How long exchanges are kept
+}
+
+// NewRequestLogService creates a new request log service.
+func NewRequestLogService(repos *repository.Repositories, retention time.Duration) RequestLogService {
+	return &RequestLogServiceImpl{
+		repos:     repos,
+		retention: retention,
+	}
+}
+
+// List retrieves recorded exchanges, newest first, with the total number matching the filter.
+func (s *RequestLogServiceImpl) List(ctx context.Context, filter *domain.RequestLogFilter) ([]*domain.RequestLog, int, error) {
+	entityType := domain.EntityHTTPRequest
+	auditFilter := &domain.AuditLogFilter{EntityType: &entityType}
+	if filter != nil {
+		auditFilter.Since = filter.Since
+		auditFilter.Limit = filter.Limit
+		auditFilter.Offset = filter.Offset
+	}
+
+	entries, err := s.repos.Audit.List(ctx, auditFilter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list request logs: %w", err)
+	}
+
+	total, err := s.repos.Audit.Count(ctx, auditFilter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count request logs: %w", err)
+	}
+
+	requestLogs := make([]*domain.RequestLog, 0, len(entries))
+	for _, entry := range entries {
+		requestLog, err := domain.RequestLogFromAudit(entry)
+		if err != nil {
+			return nil, 0, err
+		}
+		requestLogs = append(requestLogs, requestLog)
+	}
+
+	return requestLogs, total, nil
+}
+
+// GetByRequestID retrieves the exchange recorded for a request ID.
+func (s *RequestLogServiceImpl) GetByRequestID(ctx context.Context, requestID uuid.UUID) (*domain.RequestLog, error) {
+	entries, err := s.repos.Audit.ListForEntity(ctx, string(domain.EntityHTTPRequest), requestID, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request log: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("request log not found")
+	}
+
+	return domain.RequestLogFromAudit(entries[0])
+}
+
+// PurgeExpired deletes exchanges older than the retention period and returns how many were deleted.
+func (s *RequestLogServiceImpl) PurgeExpired(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-s.retention)
+
+	deleted, err := s.repos.Audit.DeleteOlderThan(ctx, string(domain.EntityHTTPRequest), cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge request logs: %w", err)
+	}
+
+	if deleted > 0 {
+		utils.InfoContext(ctx, "purged expired request logs", "deleted", deleted, "cutoff", cutoff)
+	}
+	return deleted, nil
+}
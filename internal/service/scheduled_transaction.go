@@ -4,29 +4,104 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/clock"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// scheduledClaimLease is how long an instance holds a due scheduled transaction before
+// another instance may pick it up. It must exceed the time needed to execute one batch.
+const scheduledClaimLease = 5 * time.Minute
+
+// maxUpcomingExecutions caps how many projected executions one calendar request lists.
+const maxUpcomingExecutions = 1000
+
+// upcomingFundingCheckLimit caps how many upcoming scheduled transactions one funding check covers.
+const upcomingFundingCheckLimit = 1000
+
 // ScheduledTransactionServiceImpl implements ScheduledTransactionService.
 type ScheduledTransactionServiceImpl struct {
-	repos          *repository.Repositories
-	transactionSvc TransactionService
+	repos            *repository.Repositories
+	transactionSvc   TransactionService
+	instanceID       string        // Lease owner identifying this server instance
+	notifier         Notifier      // Optional; notifies owners of failed executions and missing funds
+	fundingCheckLead time.Duration // How far ahead owners are warned about missing funds; 0 disables
+	graceRetries     int           // Same-day retries of a failed execution
+	retryInterval    time.Duration // Wait between retries of a failed execution
+	calendar         *domain.BusinessCalendar
+	clock            clock.Clock // Decides when occurrences are due; the wall clock until SetClock
 }
 
 // NewScheduledTransactionService creates a new scheduled transaction service.
 func NewScheduledTransactionService(repos *repository.Repositories, transactionSvc TransactionService) ScheduledTransactionService {
 	return &ScheduledTransactionServiceImpl{
-		repos:          repos,
-		transactionSvc: transactionSvc,
+		repos:            repos,
+		transactionSvc:   transactionSvc,
+		instanceID:       newInstanceID(),
+		fundingCheckLead: 24 * time.Hour,
+		graceRetries:     3,
+		retryInterval:    time.Hour,
+		calendar:         weekendCalendar,
+		clock:            clock.Real{},
+	}
+}
+
+// weekendCalendar knows weekends only; it is used until SetCalendar.
+var weekendCalendar, _ = domain.NewBusinessCalendar("", nil)
+
+// SetNotifier sets the notifier that owners of failed executions are notified through.
+func (s *ScheduledTransactionServiceImpl) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetExecutionPolicy sets how far ahead owners are warned about missing funds, and how often
+// and how far apart failed executions are retried on the same day.
+func (s *ScheduledTransactionServiceImpl) SetExecutionPolicy(fundingCheckLead time.Duration, graceRetries int, retryInterval time.Duration) {
+	s.fundingCheckLead = fundingCheckLead
+	s.graceRetries = graceRetries
+	s.retryInterval = retryInterval
+}
+
+// SetCalendar sets the calendar whose weekends and holidays occurrences are moved off under their
+// business day rule.
+func (s *ScheduledTransactionServiceImpl) SetCalendar(calendar *domain.BusinessCalendar) {
+	s.calendar = calendar
+}
+
+// SetClock sets the clock deciding when occurrences are due and when they ran, e.g. a simulation
+// clock running ahead of the wall clock. Leases and created and updated times stay on the wall
+// clock.
+func (s *ScheduledTransactionServiceImpl) SetClock(clock clock.Clock) {
+	s.clock = clock
+}
+
+// newInstanceID returns an identifier unique to this process, used as the scheduled transaction lease owner.
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
 	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString())
 }
 
 // Create creates a new scheduled transaction.
 func (s *ScheduledTransactionServiceImpl) Create(ctx context.Context, userID uuid.UUID, req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransactionResponse, error) {
+	// Fill in transfer details from a bound template before validating
+	if req.TemplateID != nil {
+		if err := s.applyTemplate(ctx, userID, req); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
@@ -37,6 +112,18 @@ func (s *ScheduledTransactionServiceImpl) Create(ctx context.Context, userID uui
 		req.ScheduleType = "one-time"
 	}
 
+	// The clock may run ahead of the wall clock the request was validated against
+	if req.ExecuteAt.Before(s.clock.Now()) {
+		return nil, fmt.Errorf("invalid request: execute_at must be in the future")
+	}
+
+	if req.BusinessDayRule == "" {
+		req.BusinessDayRule = domain.BusinessDayNone
+	}
+	if !s.calendar.HasRegion(req.CalendarRegion) {
+		return nil, fmt.Errorf("invalid request: unknown calendar region: %s", req.CalendarRegion)
+	}
+
 	// Validate transaction type and related fields
 	switch req.TransactionType {
 	case "credit":
@@ -67,8 +154,11 @@ func (s *ScheduledTransactionServiceImpl) Create(ctx context.Context, userID uui
 		Currency:          req.Currency,
 		Description:       req.Description,
 		ToUserID:          req.ToUserID,
+		TemplateID:        req.TemplateID,
 		ScheduleType:      req.ScheduleType,
 		ExecuteAt:         req.ExecuteAt,
+		BusinessDayRule:   req.BusinessDayRule,
+		CalendarRegion:    req.CalendarRegion,
 		CurrentOccurrence: 0,
 		Status:            "active",
 		IsActive:          true,
@@ -83,6 +173,7 @@ func (s *ScheduledTransactionServiceImpl) Create(ctx context.Context, userID uui
 
 	// Calculate next execution time
 	st.NextExecutionAt = st.CalculateNextExecution()
+	st.ApplyCalendar(s.calendar)
 
 	// Save to database
 	if err := s.repos.ScheduledTransactions.Create(ctx, st); err != nil {
@@ -94,6 +185,37 @@ func (s *ScheduledTransactionServiceImpl) Create(ctx context.Context, userID uui
 	return &response, nil
 }
 
+// applyTemplate fills the transfer details a request leaves empty from the user's transfer template.
+func (s *ScheduledTransactionServiceImpl) applyTemplate(ctx context.Context, userID uuid.UUID, req *domain.ScheduledTransactionRequest) error {
+	template, err := s.repos.TransferTemplates.GetByID(ctx, *req.TemplateID)
+	if err != nil {
+		return fmt.Errorf("transfer template not found")
+	}
+
+	if template.UserID != userID {
+		return fmt.Errorf("access denied: not owner of transfer template")
+	}
+
+	if req.TransactionType == "" {
+		req.TransactionType = "transfer"
+	}
+	if req.TransactionType != "transfer" {
+		return fmt.Errorf("template_id can only be used with transfer transactions")
+	}
+
+	if req.ToUserID == nil {
+		req.ToUserID = &template.PayeeID
+	}
+	if req.Amount == 0 {
+		req.Amount = template.DefaultAmount
+	}
+	if req.Currency == "" {
+		req.Currency = template.Currency
+	}
+
+	return nil
+}
+
 // GetByID retrieves a scheduled transaction by ID.
 func (s *ScheduledTransactionServiceImpl) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error) {
 	st, err := s.repos.ScheduledTransactions.GetByID(ctx, id)
@@ -126,6 +248,85 @@ func (s *ScheduledTransactionServiceImpl) List(ctx context.Context, userID uuid.
 	return responses, nil
 }
 
+// ListAll retrieves scheduled transactions of all users for operators, with the total number
+// matching the filter.
+func (s *ScheduledTransactionServiceImpl) ListAll(ctx context.Context, filter *domain.ScheduledTransactionFilter) ([]*domain.ScheduledTransactionResponse, int, error) {
+	transactions, err := s.repos.ScheduledTransactions.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list scheduled transactions: %w", err)
+	}
+
+	total, err := s.repos.ScheduledTransactions.CountAll(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count scheduled transactions: %w", err)
+	}
+
+	responses := make([]*domain.ScheduledTransactionResponse, 0, len(transactions))
+	for _, st := range transactions {
+		response := st.ToResponse()
+		responses = append(responses, &response)
+	}
+
+	return responses, total, nil
+}
+
+// FailureReport aggregates failed scheduled executions since the given time by reason.
+func (s *ScheduledTransactionServiceImpl) FailureReport(ctx context.Context, since time.Time) (*domain.ScheduledFailureReport, error) {
+	reasons, err := s.repos.ScheduledTransactions.GetFailureReasons(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build scheduled failure report: %w", err)
+	}
+
+	report := &domain.ScheduledFailureReport{Since: since, Reasons: reasons}
+	for _, reason := range reasons {
+		report.TotalFailures += reason.Failures
+	}
+
+	return report, nil
+}
+
+// Upcoming projects the executions of the user's active scheduled transactions within a window,
+// expanding recurring ones into each occurrence. Nothing is persisted.
+func (s *ScheduledTransactionServiceImpl) Upcoming(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.UpcomingExecutions, error) {
+	if err := domain.ValidateUpcomingWindow(from, to); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// Occurrences after the window may move into it to an earlier business day
+	active, isActive := "active", true
+	executeTo := to.AddDate(0, 0, domain.MaxBusinessDayShift)
+	transactions, err := s.repos.ScheduledTransactions.GetByUserID(ctx, userID, &domain.ScheduledTransactionFilter{
+		Status:    &active,
+		IsActive:  &isActive,
+		ExecuteTo: &executeTo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transactions: %w", err)
+	}
+
+	executions := []domain.UpcomingExecution{}
+	for _, st := range transactions {
+		// One more than the cap tells whether the list was truncated
+		executions = append(executions, st.ProjectExecutions(from, to, maxUpcomingExecutions+1, s.calendar)...)
+	}
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].ExecuteAt.Before(executions[j].ExecuteAt)
+	})
+
+	truncated := len(executions) > maxUpcomingExecutions
+	if truncated {
+		executions = executions[:maxUpcomingExecutions]
+	}
+
+	return &domain.UpcomingExecutions{
+		From:       from,
+		To:         to,
+		Executions: executions,
+		Count:      len(executions),
+		Truncated:  truncated,
+	}, nil
+}
+
 // Cancel cancels a scheduled transaction.
 func (s *ScheduledTransactionServiceImpl) Cancel(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
 	// Get the transaction first to verify ownership
@@ -151,32 +352,208 @@ func (s *ScheduledTransactionServiceImpl) Cancel(ctx context.Context, id uuid.UU
 	return nil
 }
 
+// CancelForUser cancels the open scheduled transactions of a user, e.g. on account closure.
+func (s *ScheduledTransactionServiceImpl) CancelForUser(ctx context.Context, adminID, userID uuid.UUID) (*domain.ScheduledOwnerAction, error) {
+	open, err := s.openForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &domain.ScheduledOwnerAction{
+		UserID:                  userID,
+		ScheduledTransactionIDs: []uuid.UUID{},
+		Skipped:                 []domain.SkippedScheduledTransaction{},
+	}
+	for _, st := range open {
+		st.Status = "cancelled"
+		st.IsActive = false
+		st.UpdatedAt = time.Now()
+
+		if err := s.repos.ScheduledTransactions.Update(ctx, st); err != nil {
+			return nil, fmt.Errorf("failed to cancel scheduled transaction: %w", err)
+		}
+		action.ScheduledTransactionIDs = append(action.ScheduledTransactionIDs, st.ID)
+	}
+
+	// Log the audit event
+	_ = s.repos.Audit.Log(ctx, "user", userID, "scheduled_cancel", map[string]interface{}{
+		"admin_id":                  adminID,
+		"scheduled_transaction_ids": action.ScheduledTransactionIDs,
+	})
+
+	return action, nil
+}
+
+// ReassignForUser hands the open scheduled transactions of a deactivated user to an active one.
+// Transfers to the new owner are left as they are, since a user cannot transfer to themselves.
+func (s *ScheduledTransactionServiceImpl) ReassignForUser(ctx context.Context, adminID, userID uuid.UUID, req *domain.ScheduledReassignRequest) (*domain.ScheduledOwnerAction, error) {
+	if req.ToUserID == userID {
+		return nil, fmt.Errorf("invalid request: cannot reassign scheduled transactions to the same user")
+	}
+
+	// Users.GetByID only finds active users
+	if _, err := s.repos.Users.GetByID(ctx, userID); err == nil {
+		return nil, fmt.Errorf("cannot reassign scheduled transactions of an active user")
+	} else if err.Error() != "user not found" {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if _, err := s.repos.Users.GetByID(ctx, req.ToUserID); err != nil {
+		if err.Error() == "user not found" {
+			return nil, fmt.Errorf("invalid request: new owner not found or inactive")
+		}
+		return nil, fmt.Errorf("failed to get new owner: %w", err)
+	}
+
+	open, err := s.openForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &domain.ScheduledOwnerAction{
+		UserID:                  userID,
+		ToUserID:                &req.ToUserID,
+		ScheduledTransactionIDs: []uuid.UUID{},
+		Skipped:                 []domain.SkippedScheduledTransaction{},
+	}
+	for _, st := range open {
+		if err := st.Reassign(req.ToUserID, s.clock.Now()); err != nil {
+			action.Skipped = append(action.Skipped, domain.SkippedScheduledTransaction{ID: st.ID, Reason: err.Error()})
+			continue
+		}
+
+		if err := s.repos.ScheduledTransactions.Reassign(ctx, st.ID, req.ToUserID); err != nil {
+			return nil, fmt.Errorf("failed to reassign scheduled transaction: %w", err)
+		}
+		action.ScheduledTransactionIDs = append(action.ScheduledTransactionIDs, st.ID)
+	}
+
+	// Log the audit event
+	_ = s.repos.Audit.Log(ctx, "user", userID, "scheduled_reassign", map[string]interface{}{
+		"admin_id":                  adminID,
+		"to_user_id":                req.ToUserID,
+		"scheduled_transaction_ids": action.ScheduledTransactionIDs,
+	})
+
+	return action, nil
+}
+
+// openForUser retrieves the active and paused scheduled transactions of a user.
+func (s *ScheduledTransactionServiceImpl) openForUser(ctx context.Context, userID uuid.UUID) ([]*domain.ScheduledTransaction, error) {
+	transactions, err := s.repos.ScheduledTransactions.GetByUserID(ctx, userID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transactions: %w", err)
+	}
+
+	open := make([]*domain.ScheduledTransaction, 0, len(transactions))
+	for _, st := range transactions {
+		if st.IsOpen() {
+			open = append(open, st)
+		}
+	}
+	return open, nil
+}
+
+// Pause stops a scheduled transaction from executing until it is resumed.
+func (s *ScheduledTransactionServiceImpl) Pause(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error) {
+	return s.changeState(ctx, id, userID, "paused", (*domain.ScheduledTransaction).Pause)
+}
+
+// Resume reactivates a paused scheduled transaction, recalculating its next execution.
+func (s *ScheduledTransactionServiceImpl) Resume(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error) {
+	return s.changeState(ctx, id, userID, "resumed", (*domain.ScheduledTransaction).Resume)
+}
+
+// SkipNext moves a recurring scheduled transaction past its upcoming occurrence without executing it.
+func (s *ScheduledTransactionServiceImpl) SkipNext(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ScheduledTransactionResponse, error) {
+	return s.changeState(ctx, id, userID, "skipped", (*domain.ScheduledTransaction).SkipNext)
+}
+
+// changeState applies a pause, resume or skip to a scheduled transaction owned by userID and
+// records it in the execution history under action.
+func (s *ScheduledTransactionServiceImpl) changeState(ctx context.Context, id uuid.UUID, userID uuid.UUID, action string, apply func(*domain.ScheduledTransaction, time.Time) error) (*domain.ScheduledTransactionResponse, error) {
+	st, err := s.repos.ScheduledTransactions.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled transaction: %w", err)
+	}
+
+	if st.UserID != userID {
+		return nil, fmt.Errorf("access denied: not owner of scheduled transaction")
+	}
+
+	now := s.clock.Now()
+	if err := apply(st, now); err != nil {
+		return nil, err
+	}
+	st.ApplyCalendar(s.calendar)
+
+	if err := s.repos.ScheduledTransactions.Update(ctx, st); err != nil {
+		return nil, fmt.Errorf("failed to update scheduled transaction: %w", err)
+	}
+
+	execution := &domain.ScheduledTransactionExecution{
+		ID:                     uuid.New(),
+		ScheduledTransactionID: st.ID,
+		ExecutedAt:             now,
+		Status:                 action,
+		Amount:                 st.Amount,
+		Currency:               st.Currency,
+	}
+	if err := s.repos.ScheduledTransactions.CreateExecution(ctx, execution); err != nil {
+		return nil, fmt.Errorf("failed to create execution record: %w", err)
+	}
+
+	response := st.ToResponse()
+	return &response, nil
+}
+
 // ProcessDueTransactions processes all scheduled transactions that are due for execution.
 func (s *ScheduledTransactionServiceImpl) ProcessDueTransactions(ctx context.Context) error {
-	fmt.Printf("ProcessDueTransactions: Starting to check for due transactions\n")
-
-	// Get all due transactions
-	dueTransactions, err := s.repos.ScheduledTransactions.GetDueForExecution(ctx, 100) // Process up to 100 at a time
+	// Claim due transactions so that other instances skip them while we execute
+	dueTransactions, err := s.repos.ScheduledTransactions.ClaimDueForExecution(ctx, s.instanceID, s.clock.Now(), scheduledClaimLease, 100) // Process up to 100 at a time
 	if err != nil {
-		fmt.Printf("ProcessDueTransactions: Error getting due transactions: %v\n", err)
 		return fmt.Errorf("failed to get due transactions: %w", err)
 	}
 
-	fmt.Printf("ProcessDueTransactions: Found %d due transactions to process\n", len(dueTransactions))
+	utils.DebugContext(ctx, "claimed due scheduled transactions", "count", len(dueTransactions))
 
 	for _, st := range dueTransactions {
-		fmt.Printf("ProcessDueTransactions: Processing transaction %s (type: %s, status: %s, active: %t)\n",
-			st.ID, st.TransactionType, st.Status, st.IsActive)
-		if err := s.processScheduledTransaction(ctx, st); err != nil {
+		if err := s.executeScheduledTransaction(ctx, st); err != nil {
 			// Log error but continue processing other transactions
-			fmt.Printf("Failed to process scheduled transaction %s: %v\n", st.ID, err)
+			utils.ErrorContext(ctx, "failed to process scheduled transaction",
+				"scheduled_transaction_id", st.ID.String(),
+				"error", err.Error(),
+			)
+		}
+		if err := s.repos.ScheduledTransactions.ReleaseClaim(ctx, st.ID, s.instanceID); err != nil {
+			// The lease expires on its own, so this only delays the next execution
+			utils.WarnContext(ctx, "failed to release scheduled transaction claim",
+				"scheduled_transaction_id", st.ID.String(),
+				"error", err.Error(),
+			)
 		}
 	}
 
-	fmt.Printf("ProcessDueTransactions: Completed processing %d transactions\n", len(dueTransactions))
+	utils.DebugContext(ctx, "completed processing due scheduled transactions", "count", len(dueTransactions))
 	return nil
 }
 
+// executeScheduledTransaction executes a single scheduled transaction in its own span
+// with a fresh correlation ID, so the resulting transaction and events can be traced back to it.
+func (s *ScheduledTransactionServiceImpl) executeScheduledTransaction(ctx context.Context, st *domain.ScheduledTransaction) (err error) {
+	ctx = utils.WithCorrelationID(ctx, uuid.NewString())
+	ctx, span := utils.GetTracer("scheduled-transaction-service").Start(ctx, "ScheduledTransactionService.Execute",
+		trace.WithAttributes(
+			attribute.String("scheduled_transaction.id", st.ID.String()),
+			attribute.String("user.id", st.UserID.String()),
+			attribute.String("transaction.type", st.TransactionType),
+			attribute.String("correlation_id", utils.CorrelationIDFromContext(ctx)),
+		),
+	)
+	defer func() { utils.EndSpan(span, err) }()
+
+	return s.processScheduledTransaction(ctx, st)
+}
+
 // processScheduledTransaction executes a single scheduled transaction.
 func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx context.Context, st *domain.ScheduledTransaction) error {
 	// Skip if already completed
@@ -184,26 +561,42 @@ func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx contex
 		return nil // Already completed, skip silently
 	}
 
-	// Log processing for debugging
-	fmt.Printf("Processing scheduled transaction: ID=%s, Status=%s, IsActive=%t, LastExecutedAt=%v\n",
-		st.ID, st.Status, st.IsActive, st.LastExecutedAt)
+	utils.DebugContext(ctx, "processing scheduled transaction",
+		"scheduled_transaction_id", st.ID.String(),
+		"transaction_type", st.TransactionType,
+		"status", st.Status,
+	)
+
+	// A deactivated owner's schedule would fail on every occurrence until an admin cancels or
+	// reassigns it, so it is paused instead
+	if _, err := s.repos.Users.GetByID(ctx, st.UserID); err != nil && err.Error() == "user not found" {
+		return s.pauseForInactiveOwner(ctx, st)
+	}
 
 	var transactionResponse *domain.TransactionResponse
 	var err error
 
+	// The execution is recorded once the transaction has run, under the ID the transaction
+	// already carries as its origin
+	origin := &domain.TransactionOrigin{ScheduledTransactionID: st.ID, ExecutionID: uuid.New()}
+
 	// Execute based on transaction type
 	switch st.TransactionType {
 	case "credit":
 		creditReq := &domain.CreditRequest{
-			Amount:   st.Amount,
-			Currency: st.Currency,
+			Amount:      st.Amount,
+			Currency:    st.Currency,
+			Description: st.Description,
+			Origin:      origin,
 		}
 		transactionResponse, err = s.transactionSvc.CreditSync(ctx, st.UserID, creditReq)
 
 	case "debit":
 		debitReq := &domain.DebitRequest{
-			Amount:   st.Amount,
-			Currency: st.Currency,
+			Amount:      st.Amount,
+			Currency:    st.Currency,
+			Description: st.Description,
+			Origin:      origin,
 		}
 		transactionResponse, err = s.transactionSvc.DebitSync(ctx, st.UserID, debitReq)
 
@@ -212,9 +605,11 @@ func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx contex
 			return fmt.Errorf("transfer transaction missing to_user_id")
 		}
 		transferReq := &domain.TransferRequest{
-			ToUserID: *st.ToUserID,
-			Amount:   st.Amount,
-			Currency: st.Currency,
+			ToUserID:    *st.ToUserID,
+			Amount:      st.Amount,
+			Currency:    st.Currency,
+			Description: st.Description,
+			Origin:      origin,
 		}
 		transactionResponse, err = s.transactionSvc.TransferSync(ctx, st.UserID, transferReq)
 
@@ -225,9 +620,9 @@ func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx contex
 	if err != nil {
 		// Create execution record with failure
 		execution := &domain.ScheduledTransactionExecution{
-			ID:                     uuid.New(),
+			ID:                     origin.ExecutionID,
 			ScheduledTransactionID: st.ID,
-			ExecutedAt:             time.Now(),
+			ExecutedAt:             s.clock.Now(),
 			Status:                 "failed",
 			ErrorMessage:           err.Error(),
 			Amount:                 st.Amount,
@@ -236,14 +631,31 @@ func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx contex
 		if err := s.repos.ScheduledTransactions.CreateExecution(ctx, execution); err != nil {
 			return fmt.Errorf("failed to create execution record: %w", err)
 		}
+
+		// Retry later the same day, e.g. once the owner has topped up, before giving up
+		if st.ScheduleRetry(execution.ExecutedAt, s.graceRetries, s.retryInterval) {
+			utils.InfoContext(ctx, "scheduled transaction will be retried",
+				"scheduled_transaction_id", st.ID.String(),
+				"attempt", st.FailedAttempts,
+				"retry_at", st.RetryAt.Format(time.RFC3339),
+			)
+		} else {
+			st.MarkFailed()
+			st.ApplyCalendar(s.calendar)
+			s.notifyExecutionFailed(ctx, st, execution)
+		}
+		if err := s.repos.ScheduledTransactions.Update(ctx, st); err != nil {
+			return fmt.Errorf("failed to update scheduled transaction: %w", err)
+		}
+
 		return fmt.Errorf("transaction execution failed: %w", err)
 	}
 
 	// Create execution record with success
 	execution := &domain.ScheduledTransactionExecution{
-		ID:                     uuid.New(),
+		ID:                     origin.ExecutionID,
 		ScheduledTransactionID: st.ID,
-		ExecutedAt:             time.Now(),
+		ExecutedAt:             s.clock.Now(),
 		Status:                 "success",
 		TransactionID:          &transactionResponse.ID,
 		Amount:                 st.Amount,
@@ -253,25 +665,42 @@ func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx contex
 		return fmt.Errorf("failed to create execution record: %w", err)
 	}
 
+	if st.TemplateID != nil {
+		if err := s.repos.TransferTemplates.RecordUse(ctx, *st.TemplateID, st.Amount, execution.ExecutedAt); err != nil {
+			// The template may have been deleted since; its usage statistics are informational only
+			utils.WarnContext(ctx, "failed to record transfer template use",
+				"template_id", st.TemplateID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+
 	// Update scheduled transaction
 	st.LastExecutedAt = &execution.ExecutedAt
 	st.CurrentOccurrence++
+	st.FailedAttempts = 0
+	st.RetryAt = nil
 
 	// Check if we should deactivate based on recurrence rules
 	if st.MaxOccurrences != nil && st.CurrentOccurrence >= *st.MaxOccurrences {
 		st.IsActive = false
 		st.Status = "completed"
-	} else if st.RecurrenceEndDate != nil && time.Now().After(*st.RecurrenceEndDate) {
+	} else if st.RecurrenceEndDate != nil && execution.ExecutedAt.After(*st.RecurrenceEndDate) {
 		st.IsActive = false
 		st.Status = "completed"
 	} else if st.ScheduleType == "once" || st.ScheduleType == "one-time" {
 		// One-time transactions should be deactivated after execution
 		st.IsActive = false
 		st.Status = "completed"
+	} else if !st.AdvanceOccurrence() {
+		// No occurrence remains before the recurrence end date
+		st.IsActive = false
+		st.Status = "completed"
 	} else {
 		// For recurring transactions, reset status to active for next execution
 		st.Status = "active"
 		st.NextExecutionAt = st.CalculateNextExecution()
+		st.ApplyCalendar(s.calendar)
 	}
 
 	st.UpdatedAt = time.Now()
@@ -282,3 +711,135 @@ func (s *ScheduledTransactionServiceImpl) processScheduledTransaction(ctx contex
 
 	return nil
 }
+
+// pauseForInactiveOwner pauses a due scheduled transaction whose owner is no longer active and
+// records it in the execution history.
+func (s *ScheduledTransactionServiceImpl) pauseForInactiveOwner(ctx context.Context, st *domain.ScheduledTransaction) error {
+	now := s.clock.Now()
+	if err := st.Pause(now); err != nil {
+		return err
+	}
+	st.FailedAttempts = 0
+	st.RetryAt = nil
+
+	if err := s.repos.ScheduledTransactions.Update(ctx, st); err != nil {
+		return fmt.Errorf("failed to update scheduled transaction: %w", err)
+	}
+
+	execution := &domain.ScheduledTransactionExecution{
+		ID:                     uuid.New(),
+		ScheduledTransactionID: st.ID,
+		ExecutedAt:             now,
+		Status:                 "paused",
+		ErrorMessage:           "owner account is inactive",
+		Amount:                 st.Amount,
+		Currency:               st.Currency,
+	}
+	if err := s.repos.ScheduledTransactions.CreateExecution(ctx, execution); err != nil {
+		return fmt.Errorf("failed to create execution record: %w", err)
+	}
+
+	utils.WarnContext(ctx, "paused scheduled transaction of inactive owner",
+		"scheduled_transaction_id", st.ID.String(),
+		"user_id", st.UserID.String(),
+	)
+	return nil
+}
+
+// CheckUpcomingFunding warns owners of debits and transfers due within the funding check lead
+// that their balance does not cover. A user's upcoming transactions draw on the balance earliest
+// first, so a later one is flagged when earlier ones use it up. Each occurrence is flagged once.
+func (s *ScheduledTransactionServiceImpl) CheckUpcomingFunding(ctx context.Context) error {
+	if s.fundingCheckLead <= 0 || s.notifier == nil {
+		return nil
+	}
+
+	now := s.clock.Now()
+	upcoming, err := s.repos.ScheduledTransactions.ListUpcomingOutgoing(ctx, now, now.Add(s.fundingCheckLead), upcomingFundingCheckLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list upcoming scheduled transactions: %w", err)
+	}
+
+	balances := make(map[uuid.UUID]*domain.Balance) // nil when the balance could not be read
+	available := make(map[uuid.UUID]float64)
+	for _, st := range upcoming {
+		balance, seen := balances[st.UserID]
+		if !seen {
+			balance, err = s.repos.Balances.GetByUserID(ctx, st.UserID)
+			if err != nil {
+				utils.WarnContext(ctx, "failed to get balance for funding check",
+					"user_id", st.UserID.String(),
+					"error", err.Error(),
+				)
+				balance = nil
+			}
+			balances[st.UserID] = balance
+			if balance != nil {
+				available[st.UserID] = balance.Amount
+			}
+		}
+		if balance == nil || balance.Currency != st.Currency {
+			continue
+		}
+
+		available[st.UserID] -= st.Amount
+		if available[st.UserID] >= 0 || !st.NeedsFundingAlert() {
+			continue
+		}
+
+		alerted, err := s.repos.ScheduledTransactions.MarkFundingAlerted(ctx, st.ID, st.ExecuteAt)
+		if err != nil {
+			utils.WarnContext(ctx, "failed to mark funding alert",
+				"scheduled_transaction_id", st.ID.String(),
+				"error", err.Error(),
+			)
+			continue
+		}
+		if alerted {
+			s.notifyInsufficientFunds(ctx, st, balance.Amount, math.Min(st.Amount, -available[st.UserID]))
+		}
+	}
+
+	return nil
+}
+
+// notifyInsufficientFunds queues a notification telling the owner an upcoming scheduled
+// transaction is short of funds.
+func (s *ScheduledTransactionServiceImpl) notifyInsufficientFunds(ctx context.Context, st *domain.ScheduledTransaction, balance, shortfall float64) {
+	data := map[string]interface{}{
+		"scheduled_transaction_id": st.ID.String(),
+		"transaction_type":         st.TransactionType,
+		"amount":                   st.Amount,
+		"currency":                 st.Currency,
+		"execute_at":               st.DueAt().UTC().Format(time.RFC3339),
+		"balance":                  balance,
+		"shortfall":                shortfall,
+	}
+	if err := s.notifier.Notify(ctx, st.UserID, domain.NotificationScheduledInsufficientFunds, data); err != nil {
+		utils.WarnContext(ctx, "failed to queue insufficient funds notification",
+			"scheduled_transaction_id", st.ID.String(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// notifyExecutionFailed queues a notification telling the owner a scheduled execution failed.
+func (s *ScheduledTransactionServiceImpl) notifyExecutionFailed(ctx context.Context, st *domain.ScheduledTransaction, execution *domain.ScheduledTransactionExecution) {
+	if s.notifier == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"scheduled_transaction_id": st.ID.String(),
+		"transaction_type":         st.TransactionType,
+		"amount":                   execution.Amount,
+		"currency":                 execution.Currency,
+		"error":                    execution.ErrorMessage,
+	}
+	if err := s.notifier.Notify(ctx, st.UserID, domain.NotificationScheduledExecutionFailed, data); err != nil {
+		utils.WarnContext(ctx, "failed to queue scheduled execution notification",
+			"scheduled_transaction_id", st.ID.String(),
+			"error", err.Error(),
+		)
+	}
+}
@@ -0,0 +1,64 @@
+// Package service provides full-text search.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+)
+
+// SearchServiceImpl implements SearchService. Users search their own transactions and payment
+// requests by description, client reference, note and the other party's username; admins can
+// search the audit log as well.
+type SearchServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewSearchService creates a new search service.
+func NewSearchService(repos *repository.Repositories) SearchService {
+	return &SearchServiceImpl{repos: repos}
+}
+
+// Search searches a user's transactions and payment requests, or every audit log entry for the
+// audit scope, and returns a page of the hits, best match first.
+func (s *SearchServiceImpl) Search(ctx context.Context, userID uuid.UUID, query string, scope domain.SearchScope, limit, offset int) (*domain.SearchResults, error) {
+	if err := domain.ValidateSearchQuery(query); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	query = strings.TrimSpace(query)
+
+	var total int
+	var hits []*domain.SearchHit
+	var err error
+	switch scope {
+	case domain.SearchScopeAudit:
+		if total, err = s.repos.Search.CountAudit(ctx, query); err == nil {
+			hits, err = s.repos.Search.SearchAudit(ctx, query, limit, offset)
+		}
+	case domain.SearchScopeMine:
+		if total, err = s.repos.Search.Count(ctx, userID, query); err == nil {
+			hits, err = s.repos.Search.Search(ctx, userID, query, limit, offset)
+		}
+	default:
+		return nil, fmt.Errorf("invalid request: unknown search scope %q", scope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	if hits == nil {
+		hits = []*domain.SearchHit{}
+	}
+
+	return &domain.SearchResults{
+		Query:  query,
+		Scope:  scope,
+		Total:  total,
+		Hits:   hits,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Package service provides the security event log.
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// SecurityServiceImpl implements SecurityService.
+type SecurityServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewSecurityService creates a new security service.
+func NewSecurityService(repos *repository.Repositories) SecurityService {
+	return &SecurityServiceImpl{repos: repos}
+}
+
+// List retrieves security events, newest first. Filter by user to list one user's activity.
+func (s *SecurityServiceImpl) List(ctx context.Context, filter *domain.SecurityEventFilter) ([]*domain.SecurityEvent, error) {
+	events, err := s.repos.SecurityEvents.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+
+	return events, nil
+}
+
+// recordSecurityEvent appends an event to the security event log. A failure is logged rather than
+// returned, so the action it records is not undone for want of its record.
+func recordSecurityEvent(ctx context.Context, repos *repository.Repositories, event *domain.SecurityEvent) {
+	if repos.SecurityEvents == nil {
+		return
+	}
+
+	if err := repos.SecurityEvents.Record(ctx, event); err != nil {
+		utils.ErrorContext(ctx, "failed to record security event",
+			"type", string(event.Type),
+			"error", err.Error(),
+		)
+	}
+}
@@ -0,0 +1,602 @@
+// Package service provides synthetic traffic simulation.
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/clock"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// maxRetainedSimulations is how many runs are kept in memory; older finished runs are dropped.
+const maxRetainedSimulations = 20
+
+// SimulationServiceImpl implements SimulationService. Each run creates its own synthetic users,
+// mints their funding through the treasury, and drives credits, debits and transfers through the
+// transaction service like real clients would. Runs are kept in memory only, and one runs at a time.
+//
+// All of a run's randomness, from the IDs of its run and users to the operations it generates,
+// derives from its seed, so a run can be replayed against a fresh store.
+type SimulationServiceImpl struct {
+	repos          *repository.Repositories
+	transactionSvc TransactionService
+	treasurySvc    TreasuryService
+	clock          clock.Clock // Timestamps runs; the wall clock until SetClock
+	seed           uint64      // Seeds the runs started without one; 0 draws a random seed per run
+
+	mu      sync.Mutex
+	runs    []*simulation // Oldest first
+	started uint64        // Runs started so far, numbering the seeds derived from seed
+}
+
+// simulation tracks one run. run is guarded by SimulationServiceImpl.mu.
+type simulation struct {
+	run      domain.SimulationRun
+	cancel   context.CancelFunc
+	recorder *simulationRecorder
+	random   *simulationRandom // Used by the run's own goroutine only, after Start
+}
+
+// NewSimulationService creates a new simulation service.
+func NewSimulationService(repos *repository.Repositories, transactionSvc TransactionService, treasurySvc TreasuryService) SimulationService {
+	return &SimulationServiceImpl{
+		repos:          repos,
+		transactionSvc: transactionSvc,
+		treasurySvc:    treasurySvc,
+		clock:          clock.Real{},
+	}
+}
+
+// SetClock sets the clock runs are timestamped with, e.g. the simulation clock.
+func (s *SimulationServiceImpl) SetClock(clock clock.Clock) {
+	s.clock = clock
+}
+
+// SetSeed sets the seed the seeds of runs started without one derive from, so the server's
+// sequence of runs is reproducible. 0 draws a random seed for every run.
+func (s *SimulationServiceImpl) SetSeed(seed uint64) {
+	s.seed = seed
+}
+
+// Start creates a run's synthetic users and money and generates its traffic in the background.
+func (s *SimulationServiceImpl) Start(ctx context.Context, actorID uuid.UUID, req *domain.SimulationRequest) (*domain.SimulationRun, error) {
+	req.ApplyDefaults()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sim := range s.runs {
+		if sim.run.Status == domain.SimulationRunning {
+			return nil, fmt.Errorf("simulation already running: %s", sim.run.ID)
+		}
+	}
+
+	if req.Seed == nil {
+		seed := s.nextSeed()
+		req.Seed = &seed
+	}
+	random := newSimulationRandom(*req.Seed)
+	id := random.uuid()
+	if s.find(id) != nil {
+		return nil, fmt.Errorf("simulation seed already used: replay seed %d against a fresh store", *req.Seed)
+	}
+	s.started++
+
+	// The run outlives the request that started it
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	sim := &simulation{
+		run: domain.SimulationRun{
+			ID:        id,
+			Status:    domain.SimulationRunning,
+			Request:   *req,
+			StartedBy: actorID,
+			StartedAt: s.clock.Now(),
+		},
+		cancel:   cancel,
+		recorder: newSimulationRecorder(),
+		random:   random,
+	}
+	s.runs = append(s.runs, sim)
+	s.prune()
+
+	go s.execute(runCtx, sim)
+
+	return sim.snapshot(), nil
+}
+
+// List retrieves the retained runs, newest first.
+func (s *SimulationServiceImpl) List(ctx context.Context) ([]*domain.SimulationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := make([]*domain.SimulationRun, 0, len(s.runs))
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		runs = append(runs, s.runs[i].snapshot())
+	}
+
+	return runs, nil
+}
+
+// Get retrieves a run and its results so far.
+func (s *SimulationServiceImpl) Get(ctx context.Context, id uuid.UUID) (*domain.SimulationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sim := s.find(id)
+	if sim == nil {
+		return nil, fmt.Errorf("simulation not found")
+	}
+
+	return sim.snapshot(), nil
+}
+
+// Cancel stops a running simulation. Operations already in flight complete, so the run reports
+// cancelled shortly after rather than immediately.
+func (s *SimulationServiceImpl) Cancel(ctx context.Context, id uuid.UUID) (*domain.SimulationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sim := s.find(id)
+	if sim == nil {
+		return nil, fmt.Errorf("simulation not found")
+	}
+
+	if sim.run.Status != domain.SimulationRunning {
+		return nil, fmt.Errorf("simulation is not running: status is %s", sim.run.Status)
+	}
+
+	sim.cancel()
+
+	return sim.snapshot(), nil
+}
+
+// Trace retrieves the trace of a run's operations so far, in sequence order.
+func (s *SimulationServiceImpl) Trace(ctx context.Context, id uuid.UUID) ([]domain.SimulationTraceEntry, error) {
+	s.mu.Lock()
+	sim := s.find(id)
+	s.mu.Unlock()
+
+	if sim == nil {
+		return nil, fmt.Errorf("simulation not found")
+	}
+
+	return sim.recorder.traceEntries(), nil
+}
+
+// nextSeed returns the seed of a run started without one. Derived seeds are spread with
+// SplitMix64 so consecutive runs do not get related seeds. The caller must hold s.mu.
+func (s *SimulationServiceImpl) nextSeed() uint64 {
+	if s.seed == 0 {
+		return rand.Uint64()
+	}
+
+	z := s.seed + (s.started+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// find returns the retained run with id, or nil. The caller must hold s.mu.
+func (s *SimulationServiceImpl) find(id uuid.UUID) *simulation {
+	for _, sim := range s.runs {
+		if sim.run.ID == id {
+			return sim
+		}
+	}
+	return nil
+}
+
+// prune drops the oldest finished runs beyond the retention limit. The caller must hold s.mu.
+func (s *SimulationServiceImpl) prune() {
+	for i := 0; len(s.runs) > maxRetainedSimulations && i < len(s.runs); {
+		if s.runs[i].run.Status == domain.SimulationRunning {
+			i++
+			continue
+		}
+		s.runs = append(s.runs[:i], s.runs[i+1:]...)
+	}
+}
+
+// execute sets up a run and generates its traffic until its duration elapses or it is cancelled.
+func (s *SimulationServiceImpl) execute(ctx context.Context, sim *simulation) {
+	defer sim.cancel()
+
+	req := sim.run.Request // Immutable once the run is created
+
+	users, err := s.setUp(ctx, sim.run.ID, sim.run.StartedBy, &req, sim.random)
+	if err != nil {
+		s.finish(ctx, sim, err)
+		return
+	}
+
+	s.generate(ctx, sim.recorder, &req, users, sim.random)
+	s.finish(ctx, sim, nil)
+}
+
+// setUp creates a run's users with an empty balance, mints their funding and credits it to them.
+// The users and their money are left in place when the run ends.
+func (s *SimulationServiceImpl) setUp(ctx context.Context, runID uuid.UUID, actorID uuid.UUID, req *domain.SimulationRequest, random *simulationRandom) ([]uuid.UUID, error) {
+	prefix := "sim-" + runID.String()[:8]
+
+	users := make([]uuid.UUID, 0, req.Users)
+	for i := 0; i < req.Users; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// The password hash is not a valid bcrypt hash, so nobody can log in as a simulated user
+		user := &domain.User{
+			ID:           random.uuid(),
+			Username:     fmt.Sprintf("%s-%d", prefix, i),
+			Email:        fmt.Sprintf("%s-%d@simulation.invalid", prefix, i),
+			PasswordHash: "!",
+			Role:         string(domain.RoleUser),
+			IsActive:     true,
+		}
+		if err := s.repos.Users.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create simulated user: %w", err)
+		}
+
+		balance := &domain.Balance{
+			UserID:   user.ID,
+			Amount:   0.00,
+			Currency: req.Currency,
+		}
+		if err := s.repos.Balances.Upsert(ctx, balance); err != nil {
+			return nil, fmt.Errorf("failed to create simulated balance: %w", err)
+		}
+
+		users = append(users, user.ID)
+	}
+
+	funding := &domain.TreasuryOperationRequest{
+		Amount: math.Round(float64(req.Users)*req.InitialBalance*100) / 100,
+		Reason: fmt.Sprintf("funding for traffic simulation %s", runID),
+	}
+	if _, err := s.treasurySvc.Mint(ctx, actorID, req.Currency, funding); err != nil {
+		return nil, fmt.Errorf("failed to mint simulation funding: %w", err)
+	}
+
+	for _, userID := range users {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		credit := &domain.CreditRequest{
+			Amount:   req.InitialBalance,
+			Currency: req.Currency,
+		}
+		if _, err := s.transactionSvc.Credit(ctx, userID, credit); err != nil {
+			return nil, fmt.Errorf("failed to fund simulated user: %w", err)
+		}
+	}
+
+	return users, nil
+}
+
+// generate issues operations as a Poisson process at the target rate, at most Concurrency at a
+// time, until the run's duration elapses or ctx is cancelled. When the server cannot keep up, new
+// operations wait for a free slot and the measured throughput falls below the target. Operations
+// are planned here, in order, so the same seed plans the same operations whatever the concurrency.
+func (s *SimulationServiceImpl) generate(ctx context.Context, recorder *simulationRecorder, req *domain.SimulationRequest, users []uuid.UUID, random *simulationRandom) {
+	start := time.Now()
+	recorder.start(start)
+
+	// Operations in flight when the run is cancelled still complete
+	opCtx := context.WithoutCancel(ctx)
+	deadline := start.Add(time.Duration(req.DurationSeconds) * time.Second)
+	slots := make(chan struct{}, req.Concurrency)
+	var wg sync.WaitGroup
+
+	next := start
+loop:
+	for seq := int64(0); ; seq++ {
+		// Exponential inter-arrival times make arrivals a Poisson process
+		next = next.Add(time.Duration(random.rng.ExpFloat64() / req.TargetTPS * float64(time.Second)))
+		if next.After(deadline) {
+			break
+		}
+		op := planSimulationOperation(random, req, len(users), seq, next.Sub(start))
+
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-time.After(time.Until(next)):
+		}
+
+		select {
+		case <-ctx.Done():
+			break loop
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			s.operate(opCtx, recorder, req, users, op)
+		}()
+	}
+
+	wg.Wait()
+	recorder.stop(time.Now())
+}
+
+// simulationOperation is one operation planned by a run's seed.
+type simulationOperation struct {
+	trace     domain.SimulationTraceEntry // Result is filled in once the operation ran
+	recipient uuid.UUID                   // Unknown recipient of an injected transfer failure
+}
+
+// planSimulationOperation plans a random operation between a run's users, the seq-th of the run,
+// due at offset. With probability FailureRate the operation is made invalid so that it must be
+// rejected.
+func planSimulationOperation(random *simulationRandom, req *domain.SimulationRequest, users int, seq int64, offset time.Duration) simulationOperation {
+	op := simulationOperation{trace: domain.SimulationTraceEntry{Seq: seq, AtMs: offset.Milliseconds()}}
+	op.trace.Op = pickSimulationOperation(random.rng, req)
+	op.trace.Injected = random.rng.Float64() < req.FailureRate
+	op.trace.Amount = math.Max(domain.RoundAmount(0.01, req.Currency), domain.RoundAmount(random.rng.Float64()*req.MaxAmount, req.Currency))
+	if op.trace.Amount == 0 {
+		op.trace.Amount = 1 // Currencies without decimal places cannot move less than one unit
+	}
+	op.trace.From = random.rng.IntN(users)
+
+	if op.trace.Op == domain.SimulationTransfer {
+		to := random.rng.IntN(users - 1)
+		if to >= op.trace.From {
+			to++
+		}
+		op.trace.To = &to
+		if op.trace.Injected {
+			op.recipient = random.uuid() // Recipient does not exist
+		}
+	}
+
+	return op
+}
+
+// operate performs a planned operation between the run's users.
+func (s *SimulationServiceImpl) operate(ctx context.Context, recorder *simulationRecorder, req *domain.SimulationRequest, users []uuid.UUID, op simulationOperation) {
+	amount, injected := op.trace.Amount, op.trace.Injected
+	userID := users[op.trace.From]
+
+	started := time.Now()
+	var err error
+	switch op.trace.Op {
+	case domain.SimulationCredit:
+		credit := &domain.CreditRequest{Amount: amount, Currency: req.Currency}
+		if injected {
+			credit.Amount = -amount // Rejected by validation
+		}
+		_, err = s.transactionSvc.Credit(ctx, userID, credit)
+	case domain.SimulationDebit:
+		debit := &domain.DebitRequest{Amount: amount, Currency: req.Currency}
+		if injected {
+			debit.Currency = "XXX" // Unsupported currency
+		}
+		_, err = s.transactionSvc.Debit(ctx, userID, debit)
+	case domain.SimulationTransfer:
+		transfer := &domain.TransferRequest{ToUserID: users[*op.trace.To], Amount: amount, Currency: req.Currency}
+		if injected {
+			transfer.ToUserID = op.recipient
+		}
+		_, err = s.transactionSvc.Transfer(ctx, userID, transfer)
+	}
+
+	recorder.record(op.trace, time.Since(started), err)
+}
+
+// finish records the end of a run and logs its results. Cancellation takes precedence over the
+// error it causes.
+func (s *SimulationServiceImpl) finish(ctx context.Context, sim *simulation, err error) {
+	now := s.clock.Now()
+	digest := sim.recorder.traceDigest()
+
+	s.mu.Lock()
+	switch {
+	case ctx.Err() != nil:
+		sim.run.Status = domain.SimulationCancelled
+	case err != nil:
+		sim.run.Status = domain.SimulationFailed
+		sim.run.Error = err.Error()
+	default:
+		sim.run.Status = domain.SimulationCompleted
+	}
+	sim.run.FinishedAt = &now
+	sim.run.TraceDigest = digest
+	run := sim.snapshot()
+	s.mu.Unlock()
+
+	if run.Status == domain.SimulationFailed {
+		utils.WarnContext(ctx, "traffic simulation failed",
+			"simulation_id", run.ID.String(),
+			"error", run.Error,
+		)
+		return
+	}
+
+	utils.InfoContext(ctx, "traffic simulation finished",
+		"simulation_id", run.ID.String(),
+		"seed", *run.Request.Seed,
+		"trace_digest", run.TraceDigest,
+		"status", string(run.Status),
+		"attempted", run.Stats.Attempted,
+		"failed", run.Stats.Failed,
+		"throughput_tps", run.Stats.ThroughputTPS,
+		"error_rate", run.Stats.ErrorRate,
+	)
+}
+
+// snapshot returns a copy of the run with its current stats. The caller must hold the service's mutex.
+func (sim *simulation) snapshot() *domain.SimulationRun {
+	run := sim.run
+	run.Stats = sim.recorder.snapshot()
+	return &run
+}
+
+// pickSimulationOperation picks an operation kind in proportion to the request's weights.
+func pickSimulationOperation(rng *rand.Rand, req *domain.SimulationRequest) domain.SimulationOperation {
+	r := rng.Float64() * (req.CreditWeight + req.DebitWeight + req.TransferWeight)
+	switch {
+	case r < req.CreditWeight:
+		return domain.SimulationCredit
+	case r < req.CreditWeight+req.DebitWeight:
+		return domain.SimulationDebit
+	default:
+		return domain.SimulationTransfer
+	}
+}
+
+// simulationRandom is the source of all of a run's randomness, seeded from the run's seed.
+type simulationRandom struct {
+	source *rand.ChaCha8
+	rng    *rand.Rand
+}
+
+func newSimulationRandom(seed uint64) *simulationRandom {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+	source := rand.NewChaCha8(key)
+	return &simulationRandom{source: source, rng: rand.New(source)}
+}
+
+// uuid returns a random version 4 UUID drawn from the run's randomness.
+func (r *simulationRandom) uuid() uuid.UUID {
+	id, _ := uuid.NewRandomFromReader(r.source) // ChaCha8 reads never fail
+	return id
+}
+
+// simulationRecorder collects the outcomes of a run's operations.
+type simulationRecorder struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	stoppedAt time.Time
+	stats     domain.SimulationStats // Counts only; rates and latencies are derived in snapshot
+	latencies []float64              // Milliseconds
+	trace     *domain.SimulationTrace
+}
+
+func newSimulationRecorder() *simulationRecorder {
+	return &simulationRecorder{
+		trace: domain.NewSimulationTrace(),
+		stats: domain.SimulationStats{
+			ByOperation: map[domain.SimulationOperation]*domain.SimulationOperationStats{
+				domain.SimulationCredit:   {},
+				domain.SimulationDebit:    {},
+				domain.SimulationTransfer: {},
+			},
+			Errors: map[string]int64{},
+		},
+	}
+}
+
+func (r *simulationRecorder) start(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.startedAt = at
+}
+
+func (r *simulationRecorder) stop(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stoppedAt = at
+}
+
+// record adds the outcome of one operation to the stats and the trace. Failures are grouped by
+// the message before the first colon.
+func (r *simulationRecorder) record(entry domain.SimulationTraceEntry, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, injected := entry.Op, entry.Injected
+	opStats := r.stats.ByOperation[op]
+	r.stats.Attempted++
+	opStats.Attempted++
+
+	if injected {
+		r.stats.InjectedFailures++
+	}
+
+	if err != nil {
+		r.stats.Failed++
+		opStats.Failed++
+		key, _, _ := strings.Cut(err.Error(), ":")
+		r.stats.Errors[key]++
+		entry.Result = key
+	} else {
+		r.stats.Succeeded++
+		opStats.Succeeded++
+		if injected {
+			r.stats.InjectedSucceeded++
+		}
+		entry.Result = "ok"
+	}
+	r.trace.Add(entry)
+
+	r.latencies = append(r.latencies, float64(latency.Microseconds())/1000)
+}
+
+// traceEntries returns the kept entries of the trace so far.
+func (r *simulationRecorder) traceEntries() []domain.SimulationTraceEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.trace.Entries()
+}
+
+// traceDigest returns the digest of the trace, or "" if the run never started its traffic.
+func (r *simulationRecorder) traceDigest() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.startedAt.IsZero() {
+		return ""
+	}
+	return r.trace.Digest()
+}
+
+// snapshot returns the stats so far, with rates measured over the traffic phase of the run.
+func (r *simulationRecorder) snapshot() *domain.SimulationStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.stats
+	stats.ByOperation = make(map[domain.SimulationOperation]*domain.SimulationOperationStats, len(r.stats.ByOperation))
+	for op, opStats := range r.stats.ByOperation {
+		copied := *opStats
+		stats.ByOperation[op] = &copied
+	}
+	stats.Errors = make(map[string]int64, len(r.stats.Errors))
+	for key, count := range r.stats.Errors {
+		stats.Errors[key] = count
+	}
+
+	if !r.startedAt.IsZero() {
+		end := r.stoppedAt
+		if end.IsZero() {
+			end = time.Now()
+		}
+		stats.ElapsedSeconds = math.Round(end.Sub(r.startedAt).Seconds()*1000) / 1000
+	}
+
+	if stats.ElapsedSeconds > 0 {
+		stats.ThroughputTPS = math.Round(float64(stats.Attempted)/stats.ElapsedSeconds*100) / 100
+	}
+	if stats.Attempted > 0 {
+		stats.ErrorRate = math.Round(float64(stats.Failed)/float64(stats.Attempted)*10000) / 10000
+	}
+	stats.Latency = domain.SummarizeLatencies(r.latencies)
+
+	return &stats
+}
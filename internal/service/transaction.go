@@ -4,12 +4,17 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/featureflags"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 )
 
 // TransactionServiceImpl implements the TransactionService interface.
@@ -17,21 +22,35 @@ type TransactionServiceImpl struct {
 	repos            *repository.Repositories
 	balanceService   BalanceService
 	workerPool       WorkerService
-	metricsCollector interface{}   // Will hold metrics collector to avoid circular imports
-	cache            CacheService  // Optional cache service
-	eventSvc         *EventService // Event service for publishing domain events
-	dbPool           interface{}   // Database pool for transactions
+	metricsCollector MetricsSink              // Optional metrics collector
+	cache            CacheService             // Optional cache service
+	eventSvc         *EventService            // Event service for publishing domain events
+	uow              repository.UnitOfWork    // Runs balance changes atomically
+	rollbackWindow   time.Duration            // How long users may roll back their own transactions
+	loads            singleflight.Group       // Coalesces concurrent cache-miss loads per transaction
+	flags            FeatureFlags             // Optional feature flags
+	statusBroker     *TransactionStatusBroker // Optional; announces status transitions to stream subscribers
+	notifier         Notifier                 // Optional; notifies participants of completed transactions
+	balanceMonitor   BalanceMonitor           // Optional; checks low-balance alerts of participants
+	budgetMonitor    BudgetMonitor            // Optional; checks the sender's category budget
+	nettingWindow    time.Duration            // How long netted transfers wait for their batch to settle; 0 disables netting
+	nettingMax       float64                  // Largest transfer that is netted
+	nettingBatchSize int                      // Most batches settled per SettleDueNetting call
+	nettingMetrics   NettingMetrics           // Optional; records closed netting batches
+	policy           MoneyMovementPolicy      // Optional; may hold up users' debits, transfers and rollbacks
+	policies         PolicySource             // Optional; overrides the rollback window and charges transfer fees
 }
 
 // NewTransactionService creates a new transaction service.
-func NewTransactionService(repos *repository.Repositories, balanceService BalanceService, workerPool WorkerService, eventSvc *EventService, dbPool interface{}) TransactionService {
+func NewTransactionService(repos *repository.Repositories, balanceService BalanceService, workerPool WorkerService, eventSvc *EventService, uow repository.UnitOfWork) TransactionService {
 	return &TransactionServiceImpl{
 		repos:          repos,
 		balanceService: balanceService,
 		workerPool:     workerPool,
 		cache:          nil, // Will be set later if cache is available
 		eventSvc:       eventSvc,
-		dbPool:         dbPool,
+		uow:            uow,
+		rollbackWindow: domain.DefaultRollbackWindow,
 	}
 }
 
@@ -40,28 +59,325 @@ func (s *TransactionServiceImpl) SetCacheService(cache CacheService) {
 	s.cache = cache
 }
 
+// SetRollbackWindow sets how long after creation users may roll back their own transactions.
+func (s *TransactionServiceImpl) SetRollbackWindow(window time.Duration) {
+	if window > 0 {
+		s.rollbackWindow = window
+	}
+}
+
+// SetPolicySource sets the runtime policy whose rollback window overrides the configured one and
+// whose fees are charged to the senders of transfers.
+func (s *TransactionServiceImpl) SetPolicySource(policies PolicySource) {
+	s.policies = policies
+}
+
+// currentRollbackWindow returns how long users may roll back their own transactions now.
+func (s *TransactionServiceImpl) currentRollbackWindow() time.Duration {
+	if s.policies != nil {
+		if window := time.Duration(s.policies.Current().RollbackWindow); window > 0 {
+			return window
+		}
+	}
+	return s.rollbackWindow
+}
+
+// transferFee returns the fee the sender pays for a transfer under the current policy.
+func (s *TransactionServiceImpl) transferFee(amount float64, currency string) float64 {
+	if s.policies == nil {
+		return 0
+	}
+	return s.policies.Current().TransferFee(amount, currency)
+}
+
+// chargeTransferFee debits the fee of a transfer from its sender. The transfer has already gone
+// through, so a failure is logged rather than returned.
+func (s *TransactionServiceImpl) chargeTransferFee(ctx context.Context, fromUserID uuid.UUID, transaction *domain.Transaction, fee float64) {
+	if fee <= 0 {
+		return
+	}
+	_, err := s.debit(ctx, fromUserID, &domain.DebitRequest{
+		Amount:      fee,
+		Currency:    transaction.Currency,
+		Description: domain.TransferFeeDescription(transaction.ID),
+	})
+	if err != nil {
+		utils.ErrorContext(ctx, "failed to charge transfer fee",
+			"transaction_id", transaction.ID.String(),
+			"fee", fee,
+			"error", err.Error(),
+		)
+	}
+}
+
 // SetMetricsCollector sets the metrics collector for tracking transaction metrics.
-func (s *TransactionServiceImpl) SetMetricsCollector(collector interface{}) {
+func (s *TransactionServiceImpl) SetMetricsCollector(collector MetricsSink) {
 	s.metricsCollector = collector
 }
 
 // incrementTransactionCounter increments the transaction processed counter if metrics collector is available.
 func (s *TransactionServiceImpl) incrementTransactionCounter() {
 	if s.metricsCollector != nil {
-		// Use reflection-like approach to call IncrementTransactionsProcessed
-		if mc, ok := s.metricsCollector.(interface{ IncrementTransactionsProcessed() }); ok {
-			mc.IncrementTransactionsProcessed()
+		s.metricsCollector.IncrementTransactionsProcessed()
+	}
+}
+
+// observeTransaction records the duration and outcome of a transaction operation if metrics collector is available.
+func (s *TransactionServiceImpl) observeTransaction(txType string, start time.Time, err *error) {
+	if s.metricsCollector == nil {
+		return
+	}
+
+	outcome := utils.OutcomeSuccess
+	if *err != nil {
+		outcome = utils.OutcomeFailure
+	}
+	s.metricsCollector.ObserveTransaction(txType, outcome, time.Since(start))
+}
+
+// startTransactionSpan starts a tracing span for a transaction operation of the given type.
+func startTransactionSpan(ctx context.Context, operation string, txType string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("transaction.type", txType))
+	return utils.GetTracer("transaction-service").Start(ctx, "TransactionService."+operation, trace.WithAttributes(attrs...))
+}
+
+// SetFeatureFlags sets the feature flags that decide whether money movements use the worker pool.
+func (s *TransactionServiceImpl) SetFeatureFlags(flags FeatureFlags) {
+	s.flags = flags
+}
+
+// useWorkerPool reports whether credits, debits and transfers should be processed on the worker pool.
+func (s *TransactionServiceImpl) useWorkerPool(ctx context.Context) bool {
+	return s.workerPool != nil && s.flags != nil && s.flags.Enabled(ctx, featureflags.AsyncProcessing)
+}
+
+// SetStatusBroker sets the broker that status transitions are announced on.
+func (s *TransactionServiceImpl) SetStatusBroker(broker *TransactionStatusBroker) {
+	s.statusBroker = broker
+}
+
+// SetNotifier sets the notifier that participants of completed transactions are notified through.
+func (s *TransactionServiceImpl) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetBalanceMonitor sets the monitor that checks participants' balances against their low-balance alerts.
+func (s *TransactionServiceImpl) SetBalanceMonitor(monitor BalanceMonitor) {
+	s.balanceMonitor = monitor
+}
+
+// SetBudgetMonitor sets the monitor that checks senders' spending against their category budgets.
+func (s *TransactionServiceImpl) SetBudgetMonitor(monitor BudgetMonitor) {
+	s.budgetMonitor = monitor
+}
+
+// SetMoneyMovementPolicy sets the policy deciding whether users may debit, transfer and roll back
+// their own transactions, and observing what those signal.
+func (s *TransactionServiceImpl) SetMoneyMovementPolicy(policy MoneyMovementPolicy) {
+	s.policy = policy
+}
+
+// allowMoneyMovement asks the policy whether the user may move money.
+func (s *TransactionServiceImpl) allowMoneyMovement(ctx context.Context, userID uuid.UUID) error {
+	if s.policy == nil {
+		return nil
+	}
+	return s.policy.Allow(ctx, userID)
+}
+
+// observeMoneyMovement tells the policy of a debit or transfer of the user rejected for
+// insufficient funds.
+func (s *TransactionServiceImpl) observeMoneyMovement(ctx context.Context, userID uuid.UUID, err error) {
+	if s.policy != nil && err != nil && strings.HasPrefix(err.Error(), "insufficient funds") {
+		s.policy.Observe(ctx, userID, domain.AnomalyInsufficientFunds)
+	}
+}
+
+// markCompleted marks a transaction as completed, announces the transition, notifies its
+// participants and checks their low-balance alerts and the sender's budget.
+func (s *TransactionServiceImpl) markCompleted(ctx context.Context, tx *domain.Transaction) error {
+	if err := s.repos.Transactions.MarkCompleted(ctx, tx.ID); err != nil {
+		return err
+	}
+	s.announceCompleted(ctx, tx)
+	return nil
+}
+
+// announceCompleted announces the completion of a transaction already marked completed, notifies
+// its participants and checks their low-balance alerts and the sender's budget.
+func (s *TransactionServiceImpl) announceCompleted(ctx context.Context, tx *domain.Transaction) {
+	tx.Status = string(domain.StatusSuccess)
+	s.statusBroker.Publish(ctx, domain.NewTransactionStatusUpdate(tx))
+	s.publishEvent(ctx, domain.EventTransactionCompleted, func() error {
+		return s.eventSvc.TransactionCompleted(ctx, tx.ID, tx)
+	})
+	s.notifyCompleted(ctx, tx)
+	s.checkBalances(ctx, tx)
+	if s.budgetMonitor != nil {
+		s.budgetMonitor.CheckBudget(ctx, tx)
+	}
+}
+
+// checkBalances checks the low-balance alerts of a completed transaction's participants.
+func (s *TransactionServiceImpl) checkBalances(ctx context.Context, tx *domain.Transaction) {
+	if s.balanceMonitor == nil {
+		return
+	}
+
+	if tx.FromUserID != nil {
+		s.balanceMonitor.CheckBalance(ctx, *tx.FromUserID, true, tx.ID)
+	}
+	if tx.ToUserID != nil {
+		s.balanceMonitor.CheckBalance(ctx, *tx.ToUserID, false, tx.ID)
+	}
+}
+
+// notifyCompleted queues a transaction completed notification for each participant. Transfers
+// tell the sender and the recipient apart; failures are logged because the money has already moved.
+func (s *TransactionServiceImpl) notifyCompleted(ctx context.Context, tx *domain.Transaction) {
+	if s.notifier == nil {
+		return
+	}
+
+	notify := func(userID uuid.UUID, direction string) {
+		data := map[string]interface{}{
+			"transaction_id": tx.ID.String(),
+			"type":           tx.Type,
+			"direction":      direction,
+			"amount":         tx.Amount,
+			"currency":       tx.Currency,
 		}
+		if err := s.notifier.Notify(ctx, userID, domain.NotificationTransactionCompleted, data); err != nil {
+			utils.WarnContext(ctx, "failed to queue transaction notification",
+				"transaction_id", tx.ID.String(),
+				"user_id", userID.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+
+	switch {
+	case tx.FromUserID != nil && tx.ToUserID != nil:
+		notify(*tx.FromUserID, "sent")
+		notify(*tx.ToUserID, "received")
+	case tx.ToUserID != nil:
+		notify(*tx.ToUserID, "")
+	case tx.FromUserID != nil:
+		notify(*tx.FromUserID, "")
 	}
 }
 
-// SetPool sets the worker pool for async processing.
-func (s *TransactionServiceImpl) SetPool(pool interface{}) {
-	if wp, ok := pool.(WorkerService); ok {
-		s.workerPool = wp
+// markFailed marks a transaction as failed because of cause and announces the transition. Errors
+// are ignored because the caller is already returning the error that caused the failure. The
+// failure is recorded even once ctx is done, which is often what caused it.
+func (s *TransactionServiceImpl) markFailed(ctx context.Context, tx *domain.Transaction, cause error) {
+	ctx = context.WithoutCancel(ctx)
+	if err := s.repos.Transactions.MarkFailed(ctx, tx.ID); err != nil {
+		return
+	}
+	s.announceFailed(ctx, tx, cause)
+}
+
+// recordFailed records a transaction whose unit of work failed, which rolled back its creation, as
+// failed because of cause and announces the transition. It is created and marked failed in one
+// database transaction, so it is never left pending. Errors are ignored like in markFailed.
+func (s *TransactionServiceImpl) recordFailed(ctx context.Context, tx *domain.Transaction, cause error) {
+	ctx = context.WithoutCancel(ctx)
+	err := repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		if err := repos.Transactions.CreatePending(ctx, tx); err != nil {
+			return err
+		}
+		return repos.Transactions.MarkFailed(ctx, tx.ID)
+	})
+	if err != nil {
+		return
+	}
+	s.publishEvent(ctx, domain.EventTransactionStarted, func() error {
+		return s.eventSvc.TransactionStarted(ctx, tx.ID, tx)
+	})
+	s.announceFailed(ctx, tx, cause)
+}
+
+// announceFailed announces the failure of a transaction already marked failed.
+func (s *TransactionServiceImpl) announceFailed(ctx context.Context, tx *domain.Transaction, cause error) {
+	tx.Status = string(domain.StatusFailed)
+	s.statusBroker.Publish(ctx, domain.NewTransactionStatusUpdate(tx))
+	s.publishEvent(ctx, domain.EventTransactionFailed, func() error {
+		return s.eventSvc.TransactionFailed(ctx, tx.ID, tx, cause.Error())
+	})
+}
+
+// transferKey marks the context of a transfer.
+type transferKey struct{}
+
+// withinTransfer marks ctx as belonging to a transfer. A transfer publishes TransferExecuted as its
+// only event, so the steps it runs publish none of their own.
+func withinTransfer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transferKey{}, true)
+}
+
+// isWithinTransfer reports whether ctx belongs to a transfer.
+func isWithinTransfer(ctx context.Context) bool {
+	within, _ := ctx.Value(transferKey{}).(bool)
+	return within
+}
+
+// publishEvent runs publish unless there is no event service or a transfer publishes the event
+// itself. Failures are logged because the money has already moved.
+func (s *TransactionServiceImpl) publishEvent(ctx context.Context, eventType domain.EventType, publish func() error) {
+	if s.eventSvc == nil || isWithinTransfer(ctx) {
+		return
+	}
+	if err := publish(); err != nil {
+		utils.Error("failed to publish event", "event_type", eventType, "error", err.Error())
+	}
+}
+
+// issueFromTreasury draws the money of a transaction that adds money to a user from the
+// currency's treasury. Credits are checked against the treasury's caps when enforceCaps is set.
+func (s *TransactionServiceImpl) issueFromTreasury(ctx context.Context, tx *domain.Transaction, enforceCaps bool) error {
+	if _, err := s.repos.Treasury.Record(ctx, treasuryEntry(tx, domain.TreasuryIssue, ""), enforceCaps); err != nil {
+		if strings.HasPrefix(err.Error(), "insufficient treasury funds") || strings.HasPrefix(err.Error(), "treasury cap exceeded") {
+			return err
+		}
+		return fmt.Errorf("failed to issue from treasury: %w", err)
+	}
+
+	return nil
+}
+
+// redeemToTreasury returns the money of a transaction that removed money from a user, or of a
+// failed issue, to the currency's treasury. Failures are logged because the balance has already
+// changed; they show up as a difference between circulating supply and user balances.
+func (s *TransactionServiceImpl) redeemToTreasury(ctx context.Context, tx *domain.Transaction, reason string) {
+	if _, err := s.repos.Treasury.Record(ctx, treasuryEntry(tx, domain.TreasuryRedeem, reason), false); err != nil {
+		utils.WarnContext(ctx, "failed to redeem to treasury",
+			"transaction_id", tx.ID.String(),
+			"currency", tx.Currency,
+			"amount", tx.Amount,
+			"error", err.Error(),
+		)
+	}
+}
+
+// treasuryEntry builds the treasury entry moving the money of tx in or out of its currency's treasury.
+func treasuryEntry(tx *domain.Transaction, kind domain.TreasuryEntryKind, reason string) *domain.TreasuryEntry {
+	return &domain.TreasuryEntry{
+		ID:            uuid.New(),
+		Currency:      tx.Currency,
+		Kind:          kind,
+		Amount:        tx.Amount,
+		TransactionID: &tx.ID,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
 	}
 }
 
+// SetPool sets the worker pool for async processing.
+func (s *TransactionServiceImpl) SetPool(pool WorkerService) {
+	s.workerPool = pool
+}
+
 // SyncTransactionService provides synchronous transaction operations for worker pool.
 type SyncTransactionService interface {
 	CreditSync(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error)
@@ -74,7 +390,16 @@ type SyncTransactionService interface {
 var _ SyncTransactionService = (*TransactionServiceImpl)(nil)
 
 // CreditSync processes a credit synchronously (for internal use by worker pool).
-func (s *TransactionServiceImpl) CreditSync(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error) {
+func (s *TransactionServiceImpl) CreditSync(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (_ *domain.TransactionResponse, err error) {
+	defer s.observeTransaction(string(domain.TypeCredit), time.Now(), &err)
+
+	ctx, span := startTransactionSpan(ctx, "CreditSync", string(domain.TypeCredit),
+		attribute.String("user.id", userID.String()),
+		attribute.String("transaction.currency", req.Currency),
+		utils.AmountAttribute("transaction.amount_range", req.Amount),
+	)
+	defer func() { utils.EndSpan(span, err) }()
+
 	// Validate the request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid credit request: %w", err)
@@ -118,40 +443,56 @@ func (s *TransactionServiceImpl) CreditSync(ctx context.Context, userID uuid.UUI
 
 	// Create the transaction record as pending first
 	transaction := &domain.Transaction{
-		FromUserID: nil,     // Credits don't have a source
-		ToUserID:   &userID, // The user receiving the credit
-		Amount:     req.Amount,
-		Currency:   req.Currency,
-		Type:       string(domain.TypeCredit),
-		Status:     string(domain.StatusPending), // Start as pending
+		FromUserID:      nil,     // Credits don't have a source
+		ToUserID:        &userID, // The user receiving the credit
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Type:            string(domain.TypeCredit),
+		Status:          string(domain.StatusPending), // Start as pending
+		Description:     strings.TrimSpace(req.Description),
+		ClientReference: req.ClientReference,
 	}
+	transaction.SetOrigin(req.Origin)
 
 	// Create the transaction in the database
 	if err := s.repos.Transactions.CreatePending(ctx, transaction); err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
+	s.publishEvent(ctx, domain.EventTransactionStarted, func() error {
+		return s.eventSvc.TransactionStarted(ctx, transaction.ID, transaction)
+	})
+
+	// Draw the money from the currency's treasury; credits never create money
+	if err := s.issueFromTreasury(ctx, transaction, true); err != nil {
+		s.markFailed(ctx, transaction, err)
+		return nil, err
+	}
 
 	// Update the balance
 	if err := s.repos.Balances.Upsert(ctx, newBalance); err != nil {
-		// Mark transaction as failed if balance update fails
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
+		// Return the money to the treasury and mark transaction as failed if balance update fails
+		s.redeemToTreasury(ctx, transaction, "credit failed")
+		s.markFailed(ctx, transaction, err)
 		return nil, fmt.Errorf("failed to update balance: %w", err)
 	}
 
+	// The money has moved, so the remaining steps run even if the request's deadline passes
+	ctx = context.WithoutCancel(ctx)
+
 	// Mark transaction as completed only after successful balance update
-	if err := s.repos.Transactions.MarkCompleted(ctx, transaction.ID); err != nil {
+	if err := s.markCompleted(ctx, transaction); err != nil {
 		return nil, fmt.Errorf("failed to mark transaction completed: %w", err)
 	}
 
-	// Note: Events are published at higher levels (e.g., in Transfer method)
-	// to avoid double-counting when CreditSync/DebitSync are called from Transfer
+	// Publish the balance change; nothing is published when called from a transfer
+	s.publishEvent(ctx, domain.EventAmountCredited, func() error {
+		return s.eventSvc.AmountCredited(ctx, userID, req.Amount, req.Currency, transaction.ID, string(domain.TypeCredit))
+	})
 
-	// Invalidate related caches after successful update
+	// Update related caches after successful update
 	if s.cache != nil {
-		// Invalidate balance cache
-		if err := s.cache.InvalidateBalanceCache(ctx, userID); err != nil {
-			utils.Error("failed to invalidate balance cache", "user_id", userID.String(), "error", err.Error())
-		}
+		// Write the new balance through to the cache
+		writeThroughBalance(ctx, s.cache, newBalance)
 
 		// Invalidate transaction history cache for the user
 		if err := s.cache.InvalidateTransactionHistoryCache(ctx, userID); err != nil {
@@ -179,12 +520,27 @@ func (s *TransactionServiceImpl) CreditSync(ctx context.Context, userID uuid.UUI
 }
 
 // DebitSync removes money from a user's account synchronously (for internal use by worker pool).
-func (s *TransactionServiceImpl) DebitSync(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error) {
+func (s *TransactionServiceImpl) DebitSync(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (_ *domain.TransactionResponse, err error) {
+	defer s.observeTransaction(string(domain.TypeDebit), time.Now(), &err)
+
+	ctx, span := startTransactionSpan(ctx, "DebitSync", string(domain.TypeDebit),
+		attribute.String("user.id", userID.String()),
+		attribute.String("transaction.currency", req.Currency),
+		utils.AmountAttribute("transaction.amount_range", req.Amount),
+	)
+	defer func() { utils.EndSpan(span, err) }()
+
 	// Validate the request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid debit request: %w", err)
 	}
 
+	return s.debit(ctx, userID, req)
+}
+
+// debit removes money from a user's account without validating the request against the amount
+// limits, which do not apply to fees.
+func (s *TransactionServiceImpl) debit(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error) {
 	// Check if user has sufficient balance
 	balanceResp, err := s.balanceService.GetCurrent(ctx, userID)
 	if err != nil {
@@ -201,24 +557,40 @@ func (s *TransactionServiceImpl) DebitSync(ctx context.Context, userID uuid.UUID
 		return nil, fmt.Errorf("currency mismatch: user balance is in %s but transaction is in %s", balance.Currency, req.Currency)
 	}
 
-	if balance.Amount < req.Amount {
+	// Transfers waiting for netting have not left the balance yet but are already promised
+	reserved, err := s.reservedAmount(ctx, userID, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance.Amount-reserved < req.Amount {
+		if reserved > 0 {
+			return nil, fmt.Errorf("insufficient funds: current balance %.2f %s with %.2f %s reserved by pending transfers, requested %.2f %s", balance.Amount, balance.Currency, reserved, req.Currency, req.Amount, req.Currency)
+		}
 		return nil, fmt.Errorf("insufficient funds: current balance %.2f %s, requested %.2f %s", balance.Amount, balance.Currency, req.Amount, req.Currency)
 	}
 
 	// Create the transaction record
 	transaction := &domain.Transaction{
-		FromUserID: &userID, // The user being debited
-		ToUserID:   nil,     // Debits don't have a destination
-		Amount:     req.Amount,
-		Currency:   req.Currency,
-		Type:       string(domain.TypeDebit),
-		Status:     string(domain.StatusPending),
-	}
+		FromUserID:      &userID, // The user being debited
+		ToUserID:        nil,     // Debits don't have a destination
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Type:            string(domain.TypeDebit),
+		Status:          string(domain.StatusPending),
+		Description:     strings.TrimSpace(req.Description),
+		Category:        req.Category,
+		ClientReference: req.ClientReference,
+	}
+	transaction.SetOrigin(req.Origin)
 
 	// Create the transaction in the database
 	if err := s.repos.Transactions.CreatePending(ctx, transaction); err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
+	s.publishEvent(ctx, domain.EventTransactionStarted, func() error {
+		return s.eventSvc.TransactionStarted(ctx, transaction.ID, transaction)
+	})
 
 	// Update the user's balance (negative amount for debit)
 	newAmount := balance.Amount - req.Amount
@@ -230,24 +602,30 @@ func (s *TransactionServiceImpl) DebitSync(ctx context.Context, userID uuid.UUID
 
 	if err := s.repos.Balances.Upsert(ctx, newBalance); err != nil {
 		// Mark transaction as failed
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
+		s.markFailed(ctx, transaction, err)
 		return nil, fmt.Errorf("failed to update balance: %w", err)
 	}
 
+	// The money has moved, so the remaining steps run even if the request's deadline passes
+	ctx = context.WithoutCancel(ctx)
+
+	// Return the money to the currency's treasury
+	s.redeemToTreasury(ctx, transaction, "")
+
 	// Mark transaction as completed
-	if err := s.repos.Transactions.MarkCompleted(ctx, transaction.ID); err != nil {
+	if err := s.markCompleted(ctx, transaction); err != nil {
 		return nil, fmt.Errorf("failed to mark transaction completed: %w", err)
 	}
 
-	// Note: Events are published at higher levels (e.g., in Transfer method)
-	// to avoid double-counting when CreditSync/DebitSync are called from Transfer
+	// Publish the balance change; nothing is published when called from a transfer
+	s.publishEvent(ctx, domain.EventAmountDebited, func() error {
+		return s.eventSvc.AmountDebited(ctx, userID, req.Amount, req.Currency, transaction.ID, string(domain.TypeDebit))
+	})
 
-	// Invalidate related caches after successful update
+	// Update related caches after successful update
 	if s.cache != nil {
-		// Invalidate balance cache
-		if err := s.cache.InvalidateBalanceCache(ctx, userID); err != nil {
-			utils.Error("failed to invalidate balance cache", "user_id", userID.String(), "error", err.Error())
-		}
+		// Write the new balance through to the cache
+		writeThroughBalance(ctx, s.cache, newBalance)
 
 		// Invalidate transaction history cache for the user
 		if err := s.cache.InvalidateTransactionHistoryCache(ctx, userID); err != nil {
@@ -274,16 +652,42 @@ func (s *TransactionServiceImpl) DebitSync(ctx context.Context, userID uuid.UUID
 	return &response, nil
 }
 
-// Debit removes money from a user's account asynchronously.
-func (s *TransactionServiceImpl) Debit(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error) {
-	// For now, always use sync processing to avoid worker pool complexity
-	// TODO: Fix worker pool implementation
+// Debit removes money from a user's account, on the worker pool when async processing is enabled.
+// The money movement policy may hold it up.
+func (s *TransactionServiceImpl) Debit(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (_ *domain.TransactionResponse, err error) {
+	if err := s.allowMoneyMovement(ctx, userID); err != nil {
+		return nil, err
+	}
+	defer func() { s.observeMoneyMovement(ctx, userID, err) }()
+
+	if s.useWorkerPool(ctx) {
+		return s.workerPool.ProcessDebit(ctx, userID, req)
+	}
 	return s.DebitSync(ctx, userID, req)
 }
 
 // TransferSync moves money between user accounts synchronously (for internal use by worker pool).
-func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (*domain.TransactionResponse, error) {
+func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (_ *domain.TransactionResponse, err error) {
+	defer s.observeTransaction(string(domain.TypeTransfer), time.Now(), &err)
+
+	ctx, span := startTransactionSpan(ctx, "TransferSync", string(domain.TypeTransfer),
+		attribute.String("user.id", fromUserID.String()),
+		attribute.String("transaction.to_user_id", req.ToUserID.String()),
+		attribute.String("transaction.currency", req.Currency),
+		utils.AmountAttribute("transaction.amount_range", req.Amount),
+	)
+	defer func() { utils.EndSpan(span, err) }()
+
+	// The fee is an ordinary debit, published unlike the transfer's own balance changes
+	feeCtx := ctx
+
+	// The transfer is recorded by its TransferExecuted event alone
+	ctx = withinTransfer(ctx)
+
 	// Validate the request
+	if err := s.resolveTransferDestination(ctx, req); err != nil {
+		return nil, err
+	}
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid transfer request: %w", err)
 	}
@@ -303,8 +707,23 @@ func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uu
 		return nil, fmt.Errorf("currency mismatch: sender balance is in %s but transaction is in %s", fromBalance.Currency, req.Currency)
 	}
 
-	if fromBalance.Amount < req.Amount {
-		return nil, fmt.Errorf("insufficient funds: current balance %.2f %s, requested %.2f %s", fromBalance.Amount, fromBalance.Currency, req.Amount, req.Currency)
+	// Transfers waiting for netting have not left the balance yet but are already promised
+	reserved, err := s.reservedAmount(ctx, fromUserID, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sender must also be able to pay the transfer's fee
+	fee := s.transferFee(req.Amount, req.Currency)
+	if fromBalance.Amount-reserved < req.Amount+fee {
+		requested := fmt.Sprintf("%.2f %s", req.Amount, req.Currency)
+		if fee > 0 {
+			requested += fmt.Sprintf(" plus a fee of %.2f %s", fee, req.Currency)
+		}
+		if reserved > 0 {
+			return nil, fmt.Errorf("insufficient funds: current balance %.2f %s with %.2f %s reserved by pending transfers, requested %s", fromBalance.Amount, fromBalance.Currency, reserved, req.Currency, requested)
+		}
+		return nil, fmt.Errorf("insufficient funds: current balance %.2f %s, requested %s", fromBalance.Amount, fromBalance.Currency, requested)
 	}
 
 	// Check receiver's balance and currency
@@ -323,64 +742,59 @@ func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uu
 
 	// Create the transaction record
 	transaction := &domain.Transaction{
-		FromUserID: &fromUserID,
-		ToUserID:   &req.ToUserID,
-		Amount:     req.Amount,
-		Currency:   req.Currency,
-		Type:       string(domain.TypeTransfer),
-		Status:     string(domain.StatusPending),
-	}
-
-	// Create the transaction in the database
-	if err := s.repos.Transactions.CreatePending(ctx, transaction); err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
-	}
+		FromUserID:      &fromUserID,
+		ToUserID:        &req.ToUserID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Type:            string(domain.TypeTransfer),
+		Status:          string(domain.StatusPending),
+		Description:     strings.TrimSpace(req.Description),
+		Category:        req.Category,
+		ClientReference: req.ClientReference,
+	}
+	transaction.SetOrigin(req.Origin)
 
 	// Use database transaction to ensure atomicity
-	if s.dbPool == nil {
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
+	if s.uow == nil {
 		return nil, fmt.Errorf("database pool not available")
 	}
 
-	// Type assert to pgxpool.Pool
-	pool, ok := s.dbPool.(*pgxpool.Pool)
-	if !ok {
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
-		return nil, fmt.Errorf("invalid database pool type")
+	// Small transfers wait in the batch of their pair of users and move money, and pay their fee,
+	// when it settles
+	if s.netted(req.Amount) {
+		return s.queueTransfer(ctx, transaction)
 	}
 
-	// Begin database transaction
-	tx, err := pool.Begin(ctx)
-	if err != nil {
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() {
-		_ = tx.Rollback(ctx) // Rollback error is typically safe to ignore
-	}()
+	// Create the transaction, move the money and complete the transaction in one database
+	// transaction, so no transfer is left pending with its money moved or not
+	err = repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		if err := repos.Transactions.CreatePending(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
 
-	// Debit sender (subtract amount)
-	if err := s.repos.Balances.AddAmountTx(ctx, tx, fromUserID, -req.Amount); err != nil {
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
-		return nil, fmt.Errorf("failed to debit sender: %w", err)
-	}
+		// Debit sender (subtract amount)
+		if err := repos.Balances.AddAmount(ctx, fromUserID, -req.Amount); err != nil {
+			return fmt.Errorf("failed to debit sender: %w", err)
+		}
 
-	// Credit receiver (add amount)
-	if err := s.repos.Balances.AddAmountTx(ctx, tx, req.ToUserID, req.Amount); err != nil {
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
-		return nil, fmt.Errorf("failed to credit receiver: %w", err)
-	}
+		// Credit receiver (add amount)
+		if err := repos.Balances.AddAmount(ctx, req.ToUserID, req.Amount); err != nil {
+			return fmt.Errorf("failed to credit receiver: %w", err)
+		}
 
-	// Commit the database transaction
-	if err := tx.Commit(ctx); err != nil {
-		_ = s.repos.Transactions.MarkFailed(ctx, transaction.ID)
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		if err := repos.Transactions.MarkCompleted(ctx, transaction.ID); err != nil {
+			return fmt.Errorf("failed to mark transaction completed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.recordFailed(ctx, transaction, err)
+		return nil, err
 	}
 
-	// Mark transaction as completed
-	if err := s.repos.Transactions.MarkCompleted(ctx, transaction.ID); err != nil {
-		return nil, fmt.Errorf("failed to mark transaction completed: %w", err)
-	}
+	// The money has moved, so the remaining steps run even if the request's deadline passes
+	ctx, feeCtx = context.WithoutCancel(ctx), context.WithoutCancel(feeCtx)
+	s.announceCompleted(ctx, transaction)
 
 	// Publish events for the transfer
 	if s.eventSvc != nil {
@@ -389,17 +803,11 @@ func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uu
 		}
 	}
 
-	// Invalidate related caches after successful update
+	// Update related caches after successful update
 	if s.cache != nil {
-		// Invalidate sender's balance cache
-		if err := s.cache.InvalidateBalanceCache(ctx, fromUserID); err != nil {
-			utils.Error("failed to invalidate sender balance cache", "user_id", fromUserID.String(), "error", err.Error())
-		}
-
-		// Invalidate receiver's balance cache
-		if err := s.cache.InvalidateBalanceCache(ctx, req.ToUserID); err != nil {
-			utils.Error("failed to invalidate receiver balance cache", "user_id", req.ToUserID.String(), "error", err.Error())
-		}
+		// Write both new balances through to the cache
+		refreshBalanceCache(ctx, s.cache, s.repos.Balances, fromUserID)
+		refreshBalanceCache(ctx, s.cache, s.repos.Balances, req.ToUserID)
 
 		// Invalidate transaction history cache for both users
 		if err := s.cache.InvalidateTransactionHistoryCache(ctx, fromUserID); err != nil {
@@ -423,6 +831,8 @@ func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uu
 		"amount":       req.Amount,
 	})
 
+	s.chargeTransferFee(feeCtx, fromUserID, transaction, fee)
+
 	// Increment transaction counter for metrics
 	s.incrementTransactionCounter()
 
@@ -431,30 +841,72 @@ func (s *TransactionServiceImpl) TransferSync(ctx context.Context, fromUserID uu
 	return &response, nil
 }
 
-// Transfer moves money between user accounts asynchronously.
-func (s *TransactionServiceImpl) Transfer(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (*domain.TransactionResponse, error) {
-	// For now, always use sync processing to avoid worker pool complexity
-	// TODO: Fix worker pool implementation
+// Transfer moves money between user accounts, on the worker pool when async processing is enabled.
+// The money movement policy may hold it up.
+func (s *TransactionServiceImpl) Transfer(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (_ *domain.TransactionResponse, err error) {
+	if err := s.allowMoneyMovement(ctx, fromUserID); err != nil {
+		return nil, err
+	}
+	defer func() { s.observeMoneyMovement(ctx, fromUserID, err) }()
+
+	if err := s.resolveTransferDestination(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if s.useWorkerPool(ctx) {
+		return s.workerPool.ProcessTransfer(ctx, fromUserID, req)
+	}
 	return s.TransferSync(ctx, fromUserID, req)
 }
 
+// resolveTransferDestination fills in the receiver of a transfer addressed by account number.
+// A request naming both a user and an account number must name the same account.
+func (s *TransactionServiceImpl) resolveTransferDestination(ctx context.Context, req *domain.TransferRequest) error {
+	if req.ToAccountNumber == "" {
+		return nil
+	}
+
+	number, err := domain.ParseAccountNumber(req.ToAccountNumber)
+	if err != nil {
+		return fmt.Errorf("invalid transfer request: to_account_number: %w", err)
+	}
+
+	receiver, err := s.repos.Users.GetByAccountNumber(ctx, number)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return fmt.Errorf("invalid transfer request: no account with number %s", number)
+		}
+		return fmt.Errorf("failed to resolve receiver account: %w", err)
+	}
+
+	if req.ToUserID != uuid.Nil && req.ToUserID != receiver.ID {
+		return fmt.Errorf("invalid transfer request: to_user_id and to_account_number name different accounts")
+	}
+
+	req.ToUserID = receiver.ID
+	req.ToAccountNumber = number
+	return nil
+}
+
 // GetByID retrieves a transaction by ID.
 func (s *TransactionServiceImpl) GetByID(ctx context.Context, id uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error) {
 	// Try cache first if available
 	if s.cache != nil {
 		cachedTransaction, err := s.cache.GetCachedTransaction(ctx, id)
 		if err == nil {
-			utils.Info("cache hit for transaction", "transaction_id", id.String())
 			return cachedTransaction, nil
 		}
 		// Cache miss or error - continue to database
-		utils.Info("cache miss for transaction", "transaction_id", id.String())
 	}
 
-	transaction, err := s.repos.Transactions.GetByID(ctx, id)
+	// Concurrent misses for the same transaction share a single database load
+	result, err, _ := s.loads.Do(id.String(), func() (interface{}, error) {
+		return s.repos.Transactions.GetByID(ctx, id)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
+	transaction := result.(*domain.Transaction)
 
 	// Check if user has permission to view this transaction
 	// Users can view transactions they're involved in, admins can view all
@@ -485,10 +937,11 @@ func (s *TransactionServiceImpl) GetByID(ctx context.Context, id uuid.UUID, requ
 	return &response, nil
 }
 
-// Credit adds money to a user's account asynchronously.
+// Credit adds money to a user's account, on the worker pool when async processing is enabled.
 func (s *TransactionServiceImpl) Credit(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error) {
-	// For now, always use sync processing to avoid worker pool complexity
-	// TODO: Fix worker pool implementation
+	if s.useWorkerPool(ctx) {
+		return s.workerPool.ProcessCredit(ctx, userID, req)
+	}
 	return s.CreditSync(ctx, userID, req)
 }
 
@@ -500,11 +953,16 @@ func (s *TransactionServiceImpl) GetHistory(ctx context.Context, userID uuid.UUI
 	}
 	filter.UserID = &userID
 
-	// Try cache first if available and no complex filters are used
+	// Only the first page of unfiltered history is cached; filtered queries go to the database
 	useCache := s.cache != nil && filter.Limit <= 50 && filter.Offset == 0 &&
-		filter.Type == nil && filter.Status == nil && filter.Since == nil
+		filter.Type == nil && filter.Status == nil && filter.Since == nil && filter.Description == nil &&
+		filter.ClientReference == nil
 
-	// TODO: Implement more sophisticated caching based on filter parameters
+	if useCache {
+		if cached, err := s.cache.GetCachedTransactionHistory(ctx, userID, filter.Limit); err == nil {
+			return cached, nil
+		}
+	}
 
 	transactions, err := s.repos.Transactions.ListForUser(ctx, userID, filter)
 	if err != nil {
@@ -526,9 +984,12 @@ func (s *TransactionServiceImpl) GetHistory(ctx context.Context, userID uuid.UUI
 		}
 	}
 
-	// Simple caching for basic queries (no filters, small result set)
-	// Note: Slice caching not implemented yet - individual transactions are cached above
-	_ = useCache && s.cache != nil && len(responses) <= 20 // Placeholder for future slice caching
+	if useCache {
+		if err := s.cache.CacheTransactionHistory(ctx, userID, filter.Limit, responses); err != nil {
+			utils.Error("failed to cache transaction history", "user_id", userID.String(), "error", err.Error())
+			// Don't fail the request if caching fails
+		}
+	}
 
 	return responses, nil
 }
@@ -552,13 +1013,107 @@ func (s *TransactionServiceImpl) ListAll(ctx context.Context, filter *domain.Tra
 	return responses, nil
 }
 
+// GetStats returns aggregate transaction statistics over a time window (admin only).
+func (s *TransactionServiceImpl) GetStats(ctx context.Context, window string) (*domain.TransactionStats, error) {
+	if window == "" {
+		window = domain.DefaultStatsWindow
+	}
+
+	duration, err := domain.ParseStatsWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try cache first if available
+	if s.cache != nil {
+		if cached, err := s.cache.GetCachedTransactionStats(ctx, window); err == nil {
+			return cached, nil
+		}
+	}
+
+	until := time.Now()
+	stats, err := s.repos.Transactions.GetStats(ctx, until.Add(-duration), until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction stats: %w", err)
+	}
+	stats.Window = window
+
+	if s.cache != nil {
+		if err := s.cache.CacheTransactionStats(ctx, stats); err != nil {
+			utils.Error("failed to cache transaction stats", "window", window, "error", err.Error())
+			// Don't fail the request if caching fails
+		}
+	}
+
+	return stats, nil
+}
+
 // RollbackSync reverses a completed transaction synchronously (for internal use by worker pool).
 func (s *TransactionServiceImpl) RollbackSync(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error) {
+	return s.rollbackForUser(ctx, transactionID, requestingUserID, nil)
+}
+
+// Rollback reverses a completed transaction asynchronously (if within policy window).
+func (s *TransactionServiceImpl) Rollback(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error) {
+	// For now, always use sync processing to avoid worker pool complexity
+	// TODO: Fix worker pool implementation
+	return s.RollbackSync(ctx, transactionID, requestingUserID)
+}
+
+// PartialRollback reverses part of a completed transaction (if within policy window).
+func (s *TransactionServiceImpl) PartialRollback(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID, amount float64) (*domain.TransactionResponse, error) {
+	return s.rollbackForUser(ctx, transactionID, requestingUserID, &amount)
+}
+
+// RollbackByAdmin reverses a completed transaction (admin version without permission checks).
+func (s *TransactionServiceImpl) RollbackByAdmin(ctx context.Context, transactionID uuid.UUID) (*domain.TransactionResponse, error) {
+	return s.rollbackForAdmin(ctx, transactionID, nil)
+}
+
+// PartialRollbackByAdmin reverses part of a completed transaction (admin version without permission checks).
+func (s *TransactionServiceImpl) PartialRollbackByAdmin(ctx context.Context, transactionID uuid.UUID, amount float64) (*domain.TransactionResponse, error) {
+	return s.rollbackForAdmin(ctx, transactionID, &amount)
+}
+
+// AdjustBalance corrects a user's balance (admin only) with an ordinary credit or debit described
+// by the adjustment's reason. Unlike the user's own debits it is not held up by the money movement
+// policy, but it cannot overdraw the balance either.
+func (s *TransactionServiceImpl) AdjustBalance(ctx context.Context, userID uuid.UUID, req *domain.BalanceAdjustmentRequest) (*domain.TransactionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid balance adjustment: %w", err)
+	}
+
+	if req.Type == domain.TypeDebit {
+		return s.DebitSync(ctx, userID, &domain.DebitRequest{
+			Amount:      req.Amount,
+			Currency:    req.Currency,
+			Description: req.Reason,
+		})
+	}
+	return s.CreditSync(ctx, userID, &domain.CreditRequest{
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Reason,
+	})
+}
+
+// rollbackForUser checks the user's permission, policy window and money movement policy, then
+// reverses the given amount (or everything not yet reversed when amount is nil).
+func (s *TransactionServiceImpl) rollbackForUser(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID, amount *float64) (_ *domain.TransactionResponse, err error) {
+	defer s.observeTransaction("rollback", time.Now(), &err)
+
+	ctx, span := startTransactionSpan(ctx, "Rollback", "rollback",
+		attribute.String("user.id", requestingUserID.String()),
+		attribute.String("transaction.original_id", transactionID.String()),
+	)
+	defer func() { utils.EndSpan(span, err) }()
+
 	// Get the original transaction
 	originalTx, err := s.repos.Transactions.GetByID(ctx, transactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get original transaction: %w", err)
 	}
+	span.SetAttributes(utils.AmountAttribute("transaction.amount_range", originalTx.Amount))
 
 	// Check if transaction is completed and can be rolled back
 	if originalTx.Status != string(domain.StatusSuccess) {
@@ -577,264 +1132,184 @@ func (s *TransactionServiceImpl) RollbackSync(ctx context.Context, transactionID
 		return nil, fmt.Errorf("access denied: you don't have permission to rollback this transaction")
 	}
 
-	return s.rollbackTransaction(ctx, originalTx, requestingUserID)
-}
+	// Users may only roll back within the policy window; admins are not limited
+	if window := s.currentRollbackWindow(); !originalTx.WithinRollbackWindow(window, time.Now()) {
+		return nil, fmt.Errorf("rollback window expired: transactions can only be rolled back within %s", window)
+	}
 
-// Rollback reverses a completed transaction asynchronously (if within policy window).
-func (s *TransactionServiceImpl) Rollback(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error) {
-	// For now, always use sync processing to avoid worker pool complexity
-	// TODO: Fix worker pool implementation
-	return s.RollbackSync(ctx, transactionID, requestingUserID)
+	if err := s.allowMoneyMovement(ctx, requestingUserID); err != nil {
+		return nil, err
+	}
+
+	response, err := s.rollbackTransaction(ctx, originalTx, requestingUserID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rapid rollbacks raise the user's anomaly score
+	if s.policy != nil {
+		s.policy.Observe(ctx, requestingUserID, domain.AnomalyRollback)
+	}
+	return response, nil
 }
 
-// RollbackByAdmin reverses a completed transaction (admin version without permission checks).
-func (s *TransactionServiceImpl) RollbackByAdmin(ctx context.Context, transactionID uuid.UUID) (*domain.TransactionResponse, error) {
+// rollbackForAdmin reverses the given amount of a completed transaction without permission checks
+// (or everything not yet reversed when amount is nil).
+func (s *TransactionServiceImpl) rollbackForAdmin(ctx context.Context, transactionID uuid.UUID, amount *float64) (_ *domain.TransactionResponse, err error) {
+	defer s.observeTransaction("rollback", time.Now(), &err)
+
+	ctx, span := startTransactionSpan(ctx, "RollbackByAdmin", "rollback",
+		attribute.String("transaction.original_id", transactionID.String()),
+	)
+	defer func() { utils.EndSpan(span, err) }()
+
 	// Get the original transaction
 	originalTx, err := s.repos.Transactions.GetByID(ctx, transactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get original transaction: %w", err)
 	}
+	span.SetAttributes(utils.AmountAttribute("transaction.amount_range", originalTx.Amount))
 
 	// Check if transaction is completed and can be rolled back
 	if originalTx.Status != string(domain.StatusSuccess) {
 		return nil, fmt.Errorf("can only rollback completed transactions")
 	}
 
-	return s.rollbackTransaction(ctx, originalTx, uuid.Nil) // No specific user for admin rollbacks
+	return s.rollbackTransaction(ctx, originalTx, uuid.Nil, amount) // No specific user for admin rollbacks
 }
 
 // rollbackTransaction performs the actual rollback logic without permission checks.
-func (s *TransactionServiceImpl) rollbackTransaction(ctx context.Context, originalTx *domain.Transaction, requestingUserID uuid.UUID) (*domain.TransactionResponse, error) {
-	// Determine the correct rollback transaction type and user assignments
-	var rollbackType string
-	var fromUserID, toUserID *uuid.UUID
-
-	switch originalTx.Type {
-	case string(domain.TypeCredit):
-		// Original credit: NULL -> user123
-		// Rollback debit: user123 -> NULL (remove money from user)
-		rollbackType = string(domain.TypeDebit)
-		fromUserID = originalTx.ToUserID
-		toUserID = nil
-	case string(domain.TypeDebit):
-		// Original debit: user123 -> NULL
-		// Rollback credit: NULL -> user123 (add money to user)
-		rollbackType = string(domain.TypeCredit)
-		fromUserID = nil
-		toUserID = originalTx.FromUserID
-	case string(domain.TypeTransfer):
-		// Original transfer: user123 -> user456
-		// Rollback transfer: user456 -> user123 (reverse direction)
-		rollbackType = string(domain.TypeTransfer)
-		fromUserID = originalTx.ToUserID
-		toUserID = originalTx.FromUserID
-	}
-
-	// Create a rollback transaction
-	rollbackTx := &domain.Transaction{
-		FromUserID: fromUserID,
-		ToUserID:   toUserID,
-		Amount:     originalTx.Amount,
-		Currency:   originalTx.Currency,
-		Type:       rollbackType,
-		Status:     string(domain.StatusPending),
-	}
-
-	// Create the rollback transaction
-	if err := s.repos.Transactions.CreatePending(ctx, rollbackTx); err != nil {
-		return nil, fmt.Errorf("failed to create rollback transaction: %w", err)
-	}
-
-	// Execute the rollback based on rollback transaction type (not original)
-	switch rollbackType {
-	case string(domain.TypeCredit):
-		// Rollback credit: add money to the user (rollback of debit transaction)
-		if toUserID != nil {
-			currentBalance, err := s.repos.Balances.GetByUserID(ctx, *toUserID)
-			if err != nil && !isNotFoundError(err) {
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("failed to get balance for rollback: %w", err)
-			}
+// A nil amount reverses everything not yet reversed.
+func (s *TransactionServiceImpl) rollbackTransaction(ctx context.Context, originalTx *domain.Transaction, requestingUserID uuid.UUID, amount *float64) (*domain.TransactionResponse, error) {
+	// Rollback transactions cannot themselves be rolled back
+	if originalTx.ReversalOfTransactionID != nil {
+		return nil, fmt.Errorf("cannot rollback a rollback transaction")
+	}
 
-			var newAmount float64
-			if currentBalance != nil {
-				// User has existing balance, add the rollback amount
-				newAmount = currentBalance.Amount + originalTx.Amount
-			} else {
-				// User has no balance record, create one with the rollback amount
-				newAmount = originalTx.Amount
-			}
+	// Prevent double rollbacks
+	remaining := originalTx.RemainingReversibleAmount()
+	if originalTx.IsReversed() || remaining <= 0 {
+		return nil, fmt.Errorf("transaction has already been rolled back")
+	}
 
-			// Ensure amount is not negative (defensive check)
-			if newAmount < 0 {
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("rollback would result in negative balance: current=%.2f, rollback_amount=%.2f",
-					currentBalance.Amount, originalTx.Amount)
-			}
+	// Cumulative refunds may never exceed the original amount
+	rollbackAmount := remaining
+	if amount != nil {
+		if err := (&domain.RollbackRequest{Amount: amount}).Validate(); err != nil {
+			return nil, fmt.Errorf("invalid rollback request: %w", err)
+		}
+		if err := domain.ValidateCurrencyAmount(originalTx.Currency, *amount); err != nil {
+			return nil, fmt.Errorf("invalid rollback request: amount: %w", err)
+		}
+		if *amount > remaining {
+			return nil, fmt.Errorf("rollback amount exceeds remaining reversible amount: requested=%.2f, remaining=%.2f", *amount, remaining)
+		}
+		rollbackAmount = *amount
+	}
 
-			newBalance := &domain.Balance{
-				UserID:   *toUserID,
-				Amount:   newAmount,
-				Currency: originalTx.Currency,
-			}
-			if err := s.repos.Balances.Upsert(ctx, newBalance); err != nil {
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("failed to rollback credit: %w", err)
-			}
+	// The original's type declares the type of its rollback, which swaps sender and receiver
+	rollbackTx, rollbackSpec, err := originalTx.Reversal(rollbackAmount)
+	if err != nil {
+		return nil, err
+	}
+	fromUserID, toUserID := rollbackTx.FromUserID, rollbackTx.ToUserID
+
+	if s.uow == nil {
+		return nil, fmt.Errorf("database pool not available")
+	}
+
+	// Create the rollback transaction, record the reversal on the original, move the money and
+	// complete the rollback in one database transaction. The reversal is recorded before the money
+	// moves: it locks the original, so concurrent rollbacks of it run one after the other, and
+	// fails once cumulative refunds would exceed the original amount.
+	err = repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		if err := repos.Transactions.CreatePending(ctx, rollbackTx); err != nil {
+			return fmt.Errorf("failed to create rollback transaction: %w", err)
 		}
-	case string(domain.TypeDebit):
-		// Rollback debit: remove money from the user (rollback of credit transaction)
-		if fromUserID != nil {
-			currentBalance, err := s.repos.Balances.GetByUserID(ctx, *fromUserID)
-			if err != nil && !isNotFoundError(err) {
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("failed to get balance for rollback: %w", err)
-			}
-			if currentBalance != nil {
-				newAmount := currentBalance.Amount - originalTx.Amount
-
-				// Ensure amount is not negative (defensive check)
-				if newAmount < 0 {
-					_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-					return nil, fmt.Errorf("rollback would result in negative balance: current=%.2f, rollback_amount=%.2f",
-						currentBalance.Amount, originalTx.Amount)
-				}
 
-				newBalance := &domain.Balance{
-					UserID:   *fromUserID,
-					Amount:   newAmount,
-					Currency: originalTx.Currency,
-				}
-				if err := s.repos.Balances.Upsert(ctx, newBalance); err != nil {
-					_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-					return nil, fmt.Errorf("failed to rollback debit: %w", err)
-				}
+		if err := repos.Transactions.RecordReversal(ctx, originalTx.ID, rollbackTx.ID, rollbackAmount); err != nil {
+			if err.Error() == "transaction not found or reversal exceeds original amount" {
+				return fmt.Errorf("transaction has already been rolled back")
 			}
+			return fmt.Errorf("failed to record reversal: %w", err)
 		}
-	case string(domain.TypeTransfer):
-		// Rollback transfer: move money back from recipient to sender
-		if fromUserID != nil && toUserID != nil {
-			// Debit the recipient (who was the original sender)
-			recipientBalance, err := s.repos.Balances.GetByUserID(ctx, *fromUserID)
-			if err != nil && !isNotFoundError(err) {
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("failed to get recipient balance for rollback: %w", err)
-			}
-			if recipientBalance != nil {
-				recipientNewAmount := recipientBalance.Amount - originalTx.Amount
-
-				// Ensure amount is not negative (defensive check)
-				if recipientNewAmount < 0 {
-					_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-					return nil, fmt.Errorf("transfer rollback would result in negative balance for recipient: current=%.2f, rollback_amount=%.2f",
-						recipientBalance.Amount, originalTx.Amount)
-				}
 
-				recipientNewBalance := &domain.Balance{
-					UserID:   *fromUserID,
-					Amount:   recipientNewAmount,
-					Currency: originalTx.Currency,
+		// Execute the rollback by how its type settles (not the original's)
+		switch rollbackSpec.Settlement {
+		case domain.SettlementIssue:
+			// Issue to the receiver, e.g. a credit rolling back a debit. Refunds are issued from
+			// the treasury but are not subject to its credit caps.
+			if toUserID != nil {
+				if _, err := repos.Treasury.Record(ctx, treasuryEntry(rollbackTx, domain.TreasuryIssue, ""), false); err != nil {
+					if strings.HasPrefix(err.Error(), "insufficient treasury funds") {
+						return err
+					}
+					return fmt.Errorf("failed to issue from treasury: %w", err)
 				}
-				if err := s.repos.Balances.Upsert(ctx, recipientNewBalance); err != nil {
-					_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-					return nil, fmt.Errorf("failed to rollback transfer (debit recipient): %w", err)
+				if err := repos.Balances.AddAmount(ctx, *toUserID, rollbackAmount); err != nil {
+					return fmt.Errorf("failed to rollback credit: %w", err)
 				}
 			}
-
-			// Credit the sender (who was the original recipient)
-			senderBalance, err := s.repos.Balances.GetByUserID(ctx, *toUserID)
-			if err != nil && !isNotFoundError(err) {
-				// Rollback the previous operation
-				if recipientBalance != nil {
-					_ = s.repos.Balances.Upsert(ctx, recipientBalance)
+		case domain.SettlementRedeem:
+			// Redeem from the sender, e.g. a debit rolling back a credit (fails on negative balance)
+			if fromUserID != nil {
+				if err := repos.Balances.AddAmount(ctx, *fromUserID, -rollbackAmount); err != nil {
+					return fmt.Errorf("failed to rollback debit: %w", err)
+				}
+				if _, err := repos.Treasury.Record(ctx, treasuryEntry(rollbackTx, domain.TreasuryRedeem, ""), false); err != nil {
+					return fmt.Errorf("failed to redeem to treasury: %w", err)
 				}
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("failed to get sender balance for rollback: %w", err)
-			}
-			var senderNewAmount float64
-			if senderBalance != nil {
-				senderNewAmount = senderBalance.Amount + originalTx.Amount
-			} else {
-				senderNewAmount = originalTx.Amount
 			}
-
-			// Ensure amount is not negative (defensive check)
-			if senderNewAmount < 0 {
-				// Rollback the previous operation
-				if recipientBalance != nil {
-					_ = s.repos.Balances.Upsert(ctx, recipientBalance)
+		case domain.SettlementMove:
+			// Move the money back from recipient to sender
+			if fromUserID != nil && toUserID != nil {
+				if err := repos.Balances.AddAmount(ctx, *fromUserID, -rollbackAmount); err != nil {
+					return fmt.Errorf("failed to rollback transfer: failed to debit recipient: %w", err)
 				}
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				currentAmount := 0.0
-				if senderBalance != nil {
-					currentAmount = senderBalance.Amount
+				if err := repos.Balances.AddAmount(ctx, *toUserID, rollbackAmount); err != nil {
+					return fmt.Errorf("failed to rollback transfer: failed to credit sender: %w", err)
 				}
-				return nil, fmt.Errorf("transfer rollback would result in negative balance for sender: current=%.2f, rollback_amount=%.2f",
-					currentAmount, originalTx.Amount)
 			}
+		}
 
-			senderNewBalance := &domain.Balance{
-				UserID:   *toUserID,
-				Amount:   senderNewAmount,
-				Currency: originalTx.Currency,
-			}
-			if err := s.repos.Balances.Upsert(ctx, senderNewBalance); err != nil {
-				// Rollback the previous operation
-				if recipientBalance != nil {
-					_ = s.repos.Balances.Upsert(ctx, recipientBalance)
-				}
-				_ = s.repos.Transactions.MarkFailed(ctx, rollbackTx.ID)
-				return nil, fmt.Errorf("failed to rollback transfer (credit sender): %w", err)
-			}
+		if err := repos.Transactions.MarkCompleted(ctx, rollbackTx.ID); err != nil {
+			return fmt.Errorf("failed to mark rollback completed: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		s.recordFailed(ctx, rollbackTx, err)
+		return nil, err
 	}
 
-	// Mark rollback transaction as completed
-	if err := s.repos.Transactions.MarkCompleted(ctx, rollbackTx.ID); err != nil {
-		return nil, fmt.Errorf("failed to mark rollback completed: %w", err)
+	// The money has moved, so the remaining steps run even if the request's deadline passes
+	ctx = context.WithoutCancel(ctx)
+	s.publishEvent(ctx, domain.EventTransactionStarted, func() error {
+		return s.eventSvc.TransactionStarted(ctx, rollbackTx.ID, rollbackTx)
+	})
+	s.announceCompleted(ctx, rollbackTx)
+
+	// Publish the reversal and, unless a transfer was reversed, the balance change it made
+	s.publishEvent(ctx, domain.EventTransactionReversed, func() error {
+		return s.eventSvc.TransactionReversed(ctx, rollbackTx, originalTx, requestingUserID)
+	})
+	if toUserID != nil {
+		s.publishBalanceEvent(ctx, rollbackSpec.ReceiverEvent, *toUserID, rollbackTx, "rollback")
+	}
+	if fromUserID != nil {
+		s.publishBalanceEvent(ctx, rollbackSpec.SenderEvent, *fromUserID, rollbackTx, "rollback")
 	}
 
-	// Invalidate related caches after successful rollback
+	// Update related caches after successful rollback
 	if s.cache != nil {
-		// Determine which users' caches need to be invalidated based on rollback type
-		switch rollbackType {
-		case string(domain.TypeCredit):
-			// Rollback credit: affects the recipient's balance and transaction history
-			if toUserID != nil {
-				if err := s.cache.InvalidateBalanceCache(ctx, *toUserID); err != nil {
-					utils.Error("failed to invalidate balance cache during rollback", "user_id", toUserID.String(), "error", err.Error())
-				}
-				if err := s.cache.InvalidateTransactionHistoryCache(ctx, *toUserID); err != nil {
-					utils.Error("failed to invalidate transaction history cache during rollback", "user_id", toUserID.String(), "error", err.Error())
-				}
+		// The rollback changed the balance and transaction history of each of its parties
+		for _, userID := range []*uuid.UUID{fromUserID, toUserID} {
+			if userID == nil {
+				continue
 			}
-		case string(domain.TypeDebit):
-			// Rollback debit: affects the sender's balance and transaction history
-			if fromUserID != nil {
-				if err := s.cache.InvalidateBalanceCache(ctx, *fromUserID); err != nil {
-					utils.Error("failed to invalidate balance cache during rollback", "user_id", fromUserID.String(), "error", err.Error())
-				}
-				if err := s.cache.InvalidateTransactionHistoryCache(ctx, *fromUserID); err != nil {
-					utils.Error("failed to invalidate transaction history cache during rollback", "user_id", fromUserID.String(), "error", err.Error())
-				}
-			}
-		case string(domain.TypeTransfer):
-			// Rollback transfer: affects both sender and receiver
-			if fromUserID != nil {
-				if err := s.cache.InvalidateBalanceCache(ctx, *fromUserID); err != nil {
-					utils.Error("failed to invalidate sender balance cache during rollback", "user_id", fromUserID.String(), "error", err.Error())
-				}
-				if err := s.cache.InvalidateTransactionHistoryCache(ctx, *fromUserID); err != nil {
-					utils.Error("failed to invalidate sender transaction history cache during rollback", "user_id", fromUserID.String(), "error", err.Error())
-				}
-			}
-			if toUserID != nil {
-				if err := s.cache.InvalidateBalanceCache(ctx, *toUserID); err != nil {
-					utils.Error("failed to invalidate receiver balance cache during rollback", "user_id", toUserID.String(), "error", err.Error())
-				}
-				if err := s.cache.InvalidateTransactionHistoryCache(ctx, *toUserID); err != nil {
-					utils.Error("failed to invalidate receiver transaction history cache during rollback", "user_id", toUserID.String(), "error", err.Error())
-				}
+			refreshBalanceCache(ctx, s.cache, s.repos.Balances, *userID)
+			if err := s.cache.InvalidateTransactionHistoryCache(ctx, *userID); err != nil {
+				utils.Error("failed to invalidate transaction history cache during rollback", "user_id", userID.String(), "error", err.Error())
 			}
 		}
 
@@ -853,7 +1328,8 @@ func (s *TransactionServiceImpl) rollbackTransaction(ctx context.Context, origin
 	_ = s.repos.Audit.Log(ctx, "transaction", rollbackTx.ID, "rollback", map[string]interface{}{
 		"original_transaction_id": originalTx.ID,
 		"user_id":                 requestingUserID,
-		"amount":                  originalTx.Amount,
+		"amount":                  rollbackAmount,
+		"partial":                 rollbackAmount < originalTx.Amount,
 	})
 
 	// Increment transaction counter for metrics (rollback is also a transaction)
@@ -863,6 +1339,21 @@ func (s *TransactionServiceImpl) rollbackTransaction(ctx context.Context, origin
 	return &response, nil
 }
 
+// publishBalanceEvent publishes the balance event a transaction type declares for one of its
+// parties; an empty event type publishes nothing.
+func (s *TransactionServiceImpl) publishBalanceEvent(ctx context.Context, eventType domain.EventType, userID uuid.UUID, tx *domain.Transaction, reason string) {
+	switch eventType {
+	case domain.EventAmountCredited:
+		s.publishEvent(ctx, eventType, func() error {
+			return s.eventSvc.AmountCredited(ctx, userID, tx.Amount, tx.Currency, tx.ID, reason)
+		})
+	case domain.EventAmountDebited:
+		s.publishEvent(ctx, eventType, func() error {
+			return s.eventSvc.AmountDebited(ctx, userID, tx.Amount, tx.Currency, tx.ID, reason)
+		})
+	}
+}
+
 // isNotFoundError checks if an error indicates a "not found" condition.
 func isNotFoundError(err error) bool {
 	return err != nil && err.Error() == "balance not found for user"
@@ -0,0 +1,354 @@
+// Package service provides CSV and JSON export of transaction history.
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+const (
+	// BackgroundExportThreshold is the number of rows above which exports are generated in the background
+	// rather than streamed in the response.
+	BackgroundExportThreshold = 10000
+
+	// exportBatchSize is the number of transactions read from the repository per cursor page.
+	exportBatchSize = 500
+	// exportTTL is how long a background export can be downloaded before it is deleted.
+	exportTTL = time.Hour
+)
+
+// exportCSVHeader lists the CSV columns in order.
+var exportCSVHeader = []string{
+	"id", "type", "status", "amount", "currency", "from_user_id", "to_user_id",
+	"reversed_amount", "reversal_of_transaction_id", "reversed_by_transaction_id", "created_at",
+	"description",
+}
+
+// TransactionExportServiceImpl implements the TransactionExportService interface.
+// Background exports are written to temporary files on the instance that generated them.
+type TransactionExportServiceImpl struct {
+	repos *repository.Repositories
+	dir   string // Directory background exports are written to
+
+	mu      sync.Mutex
+	exports map[uuid.UUID]*exportJob
+}
+
+// exportJob tracks a background export and the file it is written to.
+type exportJob struct {
+	export domain.TransactionExport
+	path   string
+}
+
+// NewTransactionExportService creates a new transaction export service.
+func NewTransactionExportService(repos *repository.Repositories) TransactionExportService {
+	return &TransactionExportServiceImpl{
+		repos:   repos,
+		dir:     os.TempDir(),
+		exports: make(map[uuid.UUID]*exportJob),
+	}
+}
+
+// Count returns the number of transactions an export of the user's history with filter would contain.
+func (s *TransactionExportServiceImpl) Count(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter) (int, error) {
+	countFilter := exportFilter(userID, filter)
+
+	count, err := s.repos.Transactions.Count(ctx, countFilter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions for export: %w", err)
+	}
+
+	return count, nil
+}
+
+// Write streams every transaction of the user matching filter to w, newest first, reading the
+// history page by page with a cursor. It returns the number of transactions written.
+func (s *TransactionExportServiceImpl) Write(ctx context.Context, w io.Writer, userID uuid.UUID, filter *domain.TransactionFilter, format domain.ExportFormat, locale domain.Locale) (int, error) {
+	encoder := newExportEncoder(w, format, locale)
+	if err := encoder.begin(); err != nil {
+		return 0, err
+	}
+
+	filter = exportFilter(userID, filter)
+	var cursor *domain.TransactionCursor
+	rows := 0
+
+	for {
+		transactions, err := s.repos.Transactions.ListForUserAfter(ctx, userID, filter, cursor, exportBatchSize)
+		if err != nil {
+			return rows, fmt.Errorf("failed to read transactions for export: %w", err)
+		}
+
+		for _, tx := range transactions {
+			if err := encoder.write(tx.ToResponse()); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+
+		if err := encoder.flush(); err != nil {
+			return rows, err
+		}
+
+		if len(transactions) < exportBatchSize {
+			break
+		}
+		last := transactions[len(transactions)-1]
+		cursor = &domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return rows, encoder.end()
+}
+
+// StartBackground generates an export in the background. It can be downloaded with Open once ready.
+func (s *TransactionExportServiceImpl) StartBackground(ctx context.Context, userID uuid.UUID, filter *domain.TransactionFilter, format domain.ExportFormat, locale domain.Locale) (*domain.TransactionExport, error) {
+	s.removeExpired()
+
+	file, err := os.CreateTemp(s.dir, "transactions-export-*."+string(format))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	now := time.Now()
+	job := &exportJob{
+		export: domain.TransactionExport{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Format:    format,
+			Locale:    locale,
+			Status:    domain.ExportStatusPending,
+			CreatedAt: now,
+			ExpiresAt: now.Add(exportTTL),
+		},
+		path: file.Name(),
+	}
+
+	s.mu.Lock()
+	s.exports[job.export.ID] = job
+	export := job.export
+	s.mu.Unlock()
+
+	// Copy the filter so later changes by the caller do not affect the export
+	filterCopy := exportFilter(userID, filter)
+
+	// Keep request-scoped values such as the correlation ID, but not the request's cancellation
+	bgCtx := context.WithoutCancel(ctx)
+	go s.generate(bgCtx, job.export.ID, file, userID, filterCopy, format, locale)
+
+	utils.InfoContext(ctx, "transaction export started", "export_id", export.ID.String(), "format", string(format))
+	return &export, nil
+}
+
+// generate writes a background export to file and records the outcome.
+func (s *TransactionExportServiceImpl) generate(ctx context.Context, exportID uuid.UUID, file *os.File, userID uuid.UUID, filter *domain.TransactionFilter, format domain.ExportFormat, locale domain.Locale) {
+	rows, err := s.Write(ctx, file, userID, filter, format, locale)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write export file: %w", closeErr)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.exports[exportID]
+	if !ok {
+		_ = os.Remove(file.Name())
+		return
+	}
+
+	completedAt := time.Now()
+	job.export.Rows = rows
+	job.export.CompletedAt = &completedAt
+	if err != nil {
+		job.export.Status = domain.ExportStatusFailed
+		job.export.Error = "export failed"
+		_ = os.Remove(job.path)
+		utils.ErrorContext(ctx, "transaction export failed", "export_id", exportID.String(), "error", err.Error())
+		return
+	}
+
+	job.export.Status = domain.ExportStatusReady
+	utils.InfoContext(ctx, "transaction export ready", "export_id", exportID.String(), "rows", rows)
+}
+
+// Get retrieves a background export owned by userID.
+func (s *TransactionExportServiceImpl) Get(_ context.Context, userID, exportID uuid.UUID) (*domain.TransactionExport, error) {
+	s.removeExpired()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.exports[exportID]
+	if !ok || job.export.UserID != userID {
+		return nil, fmt.Errorf("export not found")
+	}
+
+	export := job.export
+	return &export, nil
+}
+
+// Open opens a ready background export owned by userID for reading.
+func (s *TransactionExportServiceImpl) Open(ctx context.Context, userID, exportID uuid.UUID) (io.ReadCloser, *domain.TransactionExport, error) {
+	export, err := s.Get(ctx, userID, exportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != domain.ExportStatusReady {
+		return nil, export, fmt.Errorf("export is not ready")
+	}
+
+	s.mu.Lock()
+	path := s.exports[exportID].path
+	s.mu.Unlock()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, export, fmt.Errorf("failed to open export file: %w", err)
+	}
+
+	return file, export, nil
+}
+
+// removeExpired deletes background exports past their expiry along with their files.
+func (s *TransactionExportServiceImpl) removeExpired() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, job := range s.exports {
+		if now.After(job.export.ExpiresAt) {
+			if job.export.Status != domain.ExportStatusPending {
+				_ = os.Remove(job.path)
+			}
+			delete(s.exports, id)
+		}
+	}
+}
+
+// exportFilter returns a copy of filter restricted to userID, without pagination.
+func exportFilter(userID uuid.UUID, filter *domain.TransactionFilter) *domain.TransactionFilter {
+	exported := &domain.TransactionFilter{}
+	if filter != nil {
+		exported.Type = filter.Type
+		exported.Status = filter.Status
+		exported.Since = filter.Since
+		exported.Description = filter.Description
+		exported.ClientReference = filter.ClientReference
+	}
+	exported.UserID = &userID
+	return exported
+}
+
+// exportEncoder writes transactions in an export format.
+type exportEncoder interface {
+	begin() error
+	write(tx domain.TransactionResponse) error
+	flush() error
+	end() error
+}
+
+// newExportEncoder returns an encoder writing format to w. JSON exports keep amounts as numbers,
+// so locale only applies to CSV.
+func newExportEncoder(w io.Writer, format domain.ExportFormat, locale domain.Locale) exportEncoder {
+	if format == domain.ExportFormatJSON {
+		return &jsonExportEncoder{w: w}
+	}
+	return &csvExportEncoder{w: csv.NewWriter(w), locale: locale}
+}
+
+// csvExportEncoder writes one transaction per CSV row after a header row. Amounts have their
+// currency's decimal places and, with a locale, its separators.
+type csvExportEncoder struct {
+	w      *csv.Writer
+	locale domain.Locale
+}
+
+func (e *csvExportEncoder) begin() error {
+	return e.w.Write(exportCSVHeader)
+}
+
+func (e *csvExportEncoder) write(tx domain.TransactionResponse) error {
+	return e.w.Write([]string{
+		tx.ID.String(),
+		tx.Type,
+		tx.Status,
+		domain.FormatDecimal(tx.Amount, tx.Currency, e.locale),
+		tx.Currency,
+		optionalUUID(tx.FromUserID),
+		optionalUUID(tx.ToUserID),
+		domain.FormatDecimal(tx.ReversedAmount, tx.Currency, e.locale),
+		optionalUUID(tx.ReversalOfTransactionID),
+		optionalUUID(tx.ReversedByTransactionID),
+		tx.CreatedAt.UTC().Format(time.RFC3339),
+		tx.Description,
+	})
+}
+
+func (e *csvExportEncoder) flush() error {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV export: %w", err)
+	}
+	return nil
+}
+
+func (e *csvExportEncoder) end() error {
+	return e.flush()
+}
+
+// jsonExportEncoder writes a JSON array with one transaction per line.
+type jsonExportEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonExportEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExportEncoder) write(tx domain.TransactionResponse) error {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction for export: %w", err)
+	}
+
+	separator := "\n"
+	if e.count > 0 {
+		separator = ",\n"
+	}
+	e.count++
+
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportEncoder) flush() error {
+	return nil
+}
+
+func (e *jsonExportEncoder) end() error {
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+// optionalUUID formats an optional ID, using an empty string when it is not set.
+func optionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,297 @@
+// Package service provides netting of small transfers between the same pair of users.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// NettingMetrics defines the metrics recorded for closed netting batches.
+type NettingMetrics interface {
+	RecordNettingBatch(status string, transfers, movementsSaved int)
+}
+
+// SetNetting enables netting: transfers of at most maxAmount wait in the batch of their pair of
+// users for window and are settled by SettleDueNetting, at most batchSize batches per call.
+func (s *TransactionServiceImpl) SetNetting(window time.Duration, maxAmount float64, batchSize int) {
+	s.nettingWindow = window
+	s.nettingMax = maxAmount
+	s.nettingBatchSize = batchSize
+}
+
+// SetNettingMetrics sets the metrics closed netting batches are recorded to.
+func (s *TransactionServiceImpl) SetNettingMetrics(metrics NettingMetrics) {
+	s.nettingMetrics = metrics
+}
+
+// netted reports whether a transfer of amount waits for netting instead of moving money at once.
+func (s *TransactionServiceImpl) netted(amount float64) bool {
+	return s.nettingWindow > 0 && amount <= s.nettingMax
+}
+
+// reservedAmount returns what a user has sent in a currency through netting batches that have not
+// settled yet. That money is still in the balance but already promised.
+func (s *TransactionServiceImpl) reservedAmount(ctx context.Context, userID uuid.UUID, currency string) (float64, error) {
+	if s.nettingWindow <= 0 {
+		return 0, nil
+	}
+
+	reserved, err := s.repos.Netting.ReservedAmount(ctx, userID, currency)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reserved amount: %w", err)
+	}
+	return reserved, nil
+}
+
+// queueTransfer creates a transfer pending and adds it to the open batch of its pair of users in
+// one database transaction, opening a batch that closes after the netting window if there is none,
+// and returns it still pending.
+func (s *TransactionServiceImpl) queueTransfer(ctx context.Context, transaction *domain.Transaction) (*domain.TransactionResponse, error) {
+	userA, userB := domain.NettingPair(*transaction.FromUserID, *transaction.ToUserID)
+
+	var batchID uuid.UUID
+	err := repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		if err := repos.Transactions.CreatePending(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+
+		batch, err := repos.Netting.OpenBatch(ctx, userA, userB, transaction.Currency, time.Now().Add(s.nettingWindow))
+		if err != nil {
+			return err
+		}
+		batchID = batch.ID
+
+		return repos.Netting.AddTransfer(ctx, batch.ID, &domain.NettedTransfer{
+			TransactionID: transaction.ID,
+			FromUserID:    *transaction.FromUserID,
+			Amount:        transaction.Amount,
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to queue transfer for netting: %w", err)
+		s.recordFailed(ctx, transaction, err)
+		return nil, err
+	}
+
+	// The transfer is queued, so the remaining steps run even if the request's deadline passes
+	ctx = context.WithoutCancel(ctx)
+
+	s.invalidateNettedTransfer(ctx, transaction)
+
+	// Log the audit event
+	_ = s.repos.Audit.Log(ctx, "transaction", transaction.ID, "transfer", map[string]interface{}{
+		"from_user_id":     transaction.FromUserID,
+		"to_user_id":       transaction.ToUserID,
+		"amount":           transaction.Amount,
+		"netting_batch_id": batchID,
+	})
+
+	response := transaction.ToResponse()
+	return &response, nil
+}
+
+// SettleDueNetting settles the netting batches whose window closed. Each batch moves its net
+// amount from the user who sent more to the other in a single balance movement and completes its
+// transfers in the same database transaction. A batch whose payer can no longer cover the net
+// amount fails with all its transfers; a batch that hits any other error stays open and is
+// retried. It returns how many batches were settled.
+func (s *TransactionServiceImpl) SettleDueNetting(ctx context.Context) (int, error) {
+	if s.uow == nil {
+		return 0, fmt.Errorf("database pool not available")
+	}
+
+	batches, err := s.repos.Netting.ListDue(ctx, time.Now(), s.nettingBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due netting batches: %w", err)
+	}
+
+	settled := 0
+	for _, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			return settled, err
+		}
+		if s.settleBatch(ctx, batch) {
+			settled++
+		}
+	}
+
+	return settled, nil
+}
+
+// ListNettingBatches retrieves netting batches, newest first (admin only).
+func (s *TransactionServiceImpl) ListNettingBatches(ctx context.Context, filter *domain.NettingBatchFilter) ([]*domain.NettingBatch, error) {
+	batches, err := s.repos.Netting.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list netting batches: %w", err)
+	}
+	return batches, nil
+}
+
+// settleBatch settles one due batch and reports whether it settled. The fee of each transfer is
+// charged once it settled, under the policy then in effect, so transfers of failed batches pay none.
+func (s *TransactionServiceImpl) settleBatch(ctx context.Context, batch *domain.NettingBatch) bool {
+	// The fees are ordinary debits, published unlike the transfers' own balance changes
+	feeCtx := ctx
+
+	// Each transfer is recorded by its TransferExecuted event alone
+	ctx = withinTransfer(ctx)
+
+	var transfers []*domain.NettedTransfer
+	err := repository.RunInTx(ctx, s.uow, func(repos *repository.Repositories) error {
+		var err error
+		transfers, err = repos.Netting.ListTransfers(ctx, batch.ID)
+		if err != nil {
+			return err
+		}
+
+		batch.Net(transfers)
+		if batch.NetFromUserID != nil {
+			payer := *batch.NetFromUserID
+			payee := batch.UserAID
+			if payer == batch.UserAID {
+				payee = batch.UserBID
+			}
+
+			balance, err := repos.Balances.GetByUserID(ctx, payer)
+			if err != nil {
+				return fmt.Errorf("failed to get net payer balance: %w", err)
+			}
+			if balance.Amount < batch.NetAmount {
+				return fmt.Errorf("insufficient funds: net payer balance %.2f %s, net amount %.2f %s", balance.Amount, balance.Currency, batch.NetAmount, batch.Currency)
+			}
+
+			if err := repos.Balances.AddAmount(ctx, payer, -batch.NetAmount); err != nil {
+				return fmt.Errorf("failed to debit net payer: %w", err)
+			}
+			if err := repos.Balances.AddAmount(ctx, payee, batch.NetAmount); err != nil {
+				return fmt.Errorf("failed to credit net payee: %w", err)
+			}
+		}
+
+		settledAt := time.Now()
+		batch.Status = domain.NettingSettled
+		batch.SettledAt = &settledAt
+		if err := repos.Netting.Close(ctx, batch); err != nil {
+			return err
+		}
+
+		for _, transfer := range transfers {
+			if err := repos.Transactions.MarkCompleted(ctx, transfer.TransactionID); err != nil {
+				return fmt.Errorf("failed to complete netted transfer %s: %w", transfer.TransactionID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case err.Error() == "netting batch not open":
+			// Another instance closed the batch first
+		case strings.HasPrefix(err.Error(), "insufficient funds"):
+			s.failBatch(ctx, batch, err)
+		default:
+			utils.ErrorContext(ctx, "failed to settle netting batch, will retry",
+				"batch_id", batch.ID.String(),
+				"error", err.Error(),
+			)
+		}
+		return false
+	}
+
+	for _, transfer := range transfers {
+		transaction, err := s.repos.Transactions.GetByID(ctx, transfer.TransactionID)
+		if err != nil {
+			utils.WarnContext(ctx, "failed to load settled transfer",
+				"transaction_id", transfer.TransactionID.String(),
+				"error", err.Error(),
+			)
+			continue
+		}
+
+		s.announceCompleted(ctx, transaction)
+		if s.eventSvc != nil {
+			if err := s.eventSvc.TransferExecuted(ctx, *transaction.FromUserID, *transaction.ToUserID, transaction.Amount, transaction.Currency, transaction.ID); err != nil {
+				utils.Error("failed to publish transfer executed event", "error", err.Error())
+			}
+		}
+		s.invalidateNettedTransfer(ctx, transaction)
+		s.chargeTransferFee(feeCtx, *transaction.FromUserID, transaction, s.transferFee(transaction.Amount, transaction.Currency))
+		s.incrementTransactionCounter()
+	}
+
+	if s.nettingMetrics != nil {
+		s.nettingMetrics.RecordNettingBatch(string(domain.NettingSettled), batch.TransferCount, batch.MovementsSaved())
+	}
+
+	utils.InfoContext(ctx, "netting batch settled",
+		"batch_id", batch.ID.String(),
+		"transfers", batch.TransferCount,
+		"gross_amount", batch.GrossAmount,
+		"net_amount", batch.NetAmount,
+		"currency", batch.Currency,
+	)
+
+	return true
+}
+
+// failBatch closes a batch whose net amount could not be moved as failed and fails its transfers.
+// If closing fails the batch stays open and is retried.
+func (s *TransactionServiceImpl) failBatch(ctx context.Context, batch *domain.NettingBatch, cause error) {
+	transfers, err := s.repos.Netting.ListTransfers(ctx, batch.ID)
+	if err != nil {
+		utils.ErrorContext(ctx, "failed to list transfers of failed netting batch",
+			"batch_id", batch.ID.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	batch.Net(transfers)
+	batch.Status = domain.NettingFailed
+	batch.FailureReason = cause.Error()
+	batch.SettledAt = nil
+	if err := s.repos.Netting.Close(ctx, batch); err != nil {
+		utils.ErrorContext(ctx, "failed to close failed netting batch",
+			"batch_id", batch.ID.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	for _, transfer := range transfers {
+		transaction, err := s.repos.Transactions.GetByID(ctx, transfer.TransactionID)
+		if err != nil {
+			continue
+		}
+		s.markFailed(ctx, transaction, cause)
+		s.invalidateNettedTransfer(ctx, transaction)
+	}
+
+	if s.nettingMetrics != nil {
+		s.nettingMetrics.RecordNettingBatch(string(domain.NettingFailed), batch.TransferCount, 0)
+	}
+
+	utils.WarnContext(ctx, "netting batch failed",
+		"batch_id", batch.ID.String(),
+		"transfers", batch.TransferCount,
+		"error", cause.Error(),
+	)
+}
+
+// invalidateNettedTransfer drops the cached transfer, balances and histories of its participants
+// after its status changed.
+func (s *TransactionServiceImpl) invalidateNettedTransfer(ctx context.Context, transaction *domain.Transaction) {
+	if s.cache == nil {
+		return
+	}
+
+	if err := s.cache.InvalidateTransactionRelatedCache(ctx, transaction); err != nil {
+		utils.Error("failed to invalidate netted transfer cache", "transaction_id", transaction.ID.String(), "error", err.Error())
+	}
+}
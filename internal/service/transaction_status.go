@@ -0,0 +1,246 @@
+// Package service provides live transaction status updates.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+const (
+	// transactionStatusChannel is the Redis pub/sub channel status updates are broadcast on.
+	transactionStatusChannel = "transactions:status"
+
+	// transactionStatusPollInterval is how often a watched transaction is re-read from the database,
+	// so a watcher still sees the outcome if a notification is missed or Redis is unavailable.
+	transactionStatusPollInterval = 5 * time.Second
+
+	// statusSubscriberBuffer is the number of updates buffered per subscriber before updates are dropped.
+	statusSubscriberBuffer = 4
+)
+
+// TransactionStatusBroker fans transaction status updates out to subscribers. With Redis, updates are
+// broadcast to every instance so a watcher sees transitions made elsewhere; without it only updates
+// made on this instance are delivered. All methods are safe to call on a nil broker.
+type TransactionStatusBroker struct {
+	redis *repository.RedisClient // Optional
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan domain.TransactionStatusUpdate]struct{}
+}
+
+// NewTransactionStatusBroker creates a broker. redis may be nil.
+func NewTransactionStatusBroker(redis *repository.RedisClient) *TransactionStatusBroker {
+	return &TransactionStatusBroker{
+		redis:       redis,
+		subscribers: make(map[uuid.UUID]map[chan domain.TransactionStatusUpdate]struct{}),
+	}
+}
+
+// Publish announces a status update. With Redis it reaches this instance through Run; if the
+// broadcast fails it is still delivered locally.
+func (b *TransactionStatusBroker) Publish(ctx context.Context, update domain.TransactionStatusUpdate) {
+	if b == nil {
+		return
+	}
+
+	if b.redis != nil {
+		err := b.redis.Publish(ctx, transactionStatusChannel, update)
+		if err == nil {
+			return
+		}
+		utils.WarnContext(ctx, "failed to broadcast transaction status", "transaction_id", update.TransactionID.String(), "error", err.Error())
+	}
+
+	b.deliver(update)
+}
+
+// Subscribe returns a channel receiving status updates for a transaction and a function ending the subscription.
+// A slow subscriber misses updates rather than blocking publishers.
+func (b *TransactionStatusBroker) Subscribe(transactionID uuid.UUID) (<-chan domain.TransactionStatusUpdate, func()) {
+	if b == nil {
+		return nil, func() {}
+	}
+
+	ch := make(chan domain.TransactionStatusUpdate, statusSubscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[transactionID] == nil {
+		b.subscribers[transactionID] = make(map[chan domain.TransactionStatusUpdate]struct{})
+	}
+	b.subscribers[transactionID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers[transactionID], ch)
+			if len(b.subscribers[transactionID]) == 0 {
+				delete(b.subscribers, transactionID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Run relays updates broadcast through Redis to local subscribers until ctx is cancelled.
+// It returns immediately when the broker has no Redis client.
+func (b *TransactionStatusBroker) Run(ctx context.Context) {
+	if b == nil || b.redis == nil {
+		return
+	}
+
+	pubsub := b.redis.Subscribe(ctx, transactionStatusChannel)
+	defer func() {
+		_ = pubsub.Close()
+	}()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var update domain.TransactionStatusUpdate
+			if err := json.Unmarshal([]byte(msg.Payload), &update); err != nil {
+				utils.Warn("ignoring malformed transaction status update", "error", err.Error())
+				continue
+			}
+			b.deliver(update)
+		}
+	}
+}
+
+// deliver sends an update to the local subscribers of its transaction.
+func (b *TransactionStatusBroker) deliver(update domain.TransactionStatusUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[update.TransactionID] {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+// TransactionStatusServiceImpl implements the TransactionStatusService interface.
+type TransactionStatusServiceImpl struct {
+	repos        *repository.Repositories
+	broker       *TransactionStatusBroker
+	pollInterval time.Duration
+}
+
+// NewTransactionStatusService creates a new transaction status service. broker may be nil,
+// in which case watchers rely on polling alone.
+func NewTransactionStatusService(repos *repository.Repositories, broker *TransactionStatusBroker) TransactionStatusService {
+	return &TransactionStatusServiceImpl{
+		repos:        repos,
+		broker:       broker,
+		pollInterval: transactionStatusPollInterval,
+	}
+}
+
+// Watch streams a transaction's status to a participant: first its current status, then each
+// transition. The channel is closed after a terminal status or when ctx is done.
+func (s *TransactionStatusServiceImpl) Watch(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (<-chan domain.TransactionStatusUpdate, error) {
+	// Subscribe before reading the current status so no transition in between is missed
+	updates, unsubscribe := s.broker.Subscribe(transactionID)
+
+	transaction, err := s.repos.Transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		unsubscribe()
+		if err.Error() == "transaction not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	isParticipant := (transaction.FromUserID != nil && *transaction.FromUserID == requestingUserID) ||
+		(transaction.ToUserID != nil && *transaction.ToUserID == requestingUserID)
+	if !isParticipant {
+		unsubscribe()
+		return nil, fmt.Errorf("access denied: you don't have permission to view this transaction")
+	}
+
+	out := make(chan domain.TransactionStatusUpdate, 1)
+	go s.watch(ctx, transactionID, domain.NewTransactionStatusUpdate(transaction), updates, unsubscribe, out)
+
+	return out, nil
+}
+
+// AwaitSettled blocks until a transaction a user takes part in succeeds or fails, or ctx is done,
+// and reports whether it settled. Transactions that already settled return at once.
+func (s *TransactionStatusServiceImpl) AwaitSettled(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (bool, error) {
+	updates, err := s.Watch(ctx, transactionID, requestingUserID)
+	if err != nil {
+		return false, err
+	}
+
+	settled := false
+	for update := range updates {
+		settled = update.IsTerminal()
+	}
+	return settled, nil
+}
+
+// watch forwards status changes for a transaction to out until it reaches a terminal status.
+func (s *TransactionStatusServiceImpl) watch(ctx context.Context, transactionID uuid.UUID, current domain.TransactionStatusUpdate, updates <-chan domain.TransactionStatusUpdate, unsubscribe func(), out chan<- domain.TransactionStatusUpdate) {
+	defer close(out)
+	defer unsubscribe()
+
+	send := func(update domain.TransactionStatusUpdate) bool {
+		select {
+		case out <- update:
+			return !update.IsTerminal()
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if !send(current) {
+		return
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		var next domain.TransactionStatusUpdate
+		select {
+		case <-ctx.Done():
+			return
+		case next = <-updates:
+		case <-ticker.C:
+			transaction, err := s.repos.Transactions.GetByID(ctx, transactionID)
+			if err != nil {
+				continue
+			}
+			next = domain.NewTransactionStatusUpdate(transaction)
+		}
+
+		if next.Status == current.Status {
+			continue
+		}
+		current = next
+		if !send(current) {
+			return
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package service provides business logic for transfer templates.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// TransferTemplateServiceImpl implements TransferTemplateService.
+type TransferTemplateServiceImpl struct {
+	repos          *repository.Repositories
+	transactionSvc TransactionService
+}
+
+// NewTransferTemplateService creates a new transfer template service.
+func NewTransferTemplateService(repos *repository.Repositories, transactionSvc TransactionService) TransferTemplateService {
+	return &TransferTemplateServiceImpl{
+		repos:          repos,
+		transactionSvc: transactionSvc,
+	}
+}
+
+// Create saves a new transfer template for the user.
+func (s *TransferTemplateServiceImpl) Create(ctx context.Context, userID uuid.UUID, req *domain.TransferTemplateRequest) (*domain.TransferTemplate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if err := s.checkPayee(ctx, userID, req.PayeeID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &domain.TransferTemplate{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Name:          strings.TrimSpace(req.Name),
+		PayeeID:       req.PayeeID,
+		DefaultAmount: req.DefaultAmount,
+		Currency:      req.Currency,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.repos.TransferTemplates.Create(ctx, template); err != nil {
+		if err.Error() == "template name already exists" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create transfer template: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetByID retrieves one of the user's transfer templates with its usage statistics.
+func (s *TransferTemplateServiceImpl) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.TransferTemplate, error) {
+	template, err := s.repos.TransferTemplates.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("transfer template not found")
+	}
+
+	if template.UserID != userID {
+		return nil, fmt.Errorf("access denied: not owner of transfer template")
+	}
+
+	return template, nil
+}
+
+// List retrieves the user's transfer templates.
+func (s *TransferTemplateServiceImpl) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.TransferTemplate, error) {
+	templates, err := s.repos.TransferTemplates.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer templates: %w", err)
+	}
+
+	if templates == nil {
+		templates = []*domain.TransferTemplate{}
+	}
+
+	return templates, nil
+}
+
+// Update changes the name or transfer details of one of the user's templates. Scheduled transactions
+// already bound to the template keep the details they were created with.
+func (s *TransferTemplateServiceImpl) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *domain.UpdateTransferTemplateRequest) (*domain.TransferTemplate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	template, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.PayeeID != nil {
+		if err := s.checkPayee(ctx, userID, *req.PayeeID); err != nil {
+			return nil, err
+		}
+		template.PayeeID = *req.PayeeID
+	}
+	if req.DefaultAmount != nil {
+		template.DefaultAmount = *req.DefaultAmount
+	}
+	if req.Currency != nil {
+		template.Currency = *req.Currency
+	}
+	template.UpdatedAt = time.Now()
+
+	if err := s.repos.TransferTemplates.Update(ctx, template); err != nil {
+		if err.Error() == "template name already exists" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update transfer template: %w", err)
+	}
+
+	return template, nil
+}
+
+// Delete deletes one of the user's transfer templates. Bound scheduled transactions keep running unbound.
+func (s *TransferTemplateServiceImpl) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.GetByID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.repos.TransferTemplates.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete transfer template: %w", err)
+	}
+
+	return nil
+}
+
+// Execute transfers money to the template's payee, using the template's default amount unless
+// the request overrides it, and records the use in the template's statistics.
+func (s *TransferTemplateServiceImpl) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *domain.ExecuteTransferTemplateRequest) (*domain.TransactionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	template, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := template.DefaultAmount
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	transaction, err := s.transactionSvc.Transfer(ctx, userID, &domain.TransferRequest{
+		ToUserID:    template.PayeeID,
+		Amount:      amount,
+		Currency:    template.Currency,
+		Description: template.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute transfer template: %w", err)
+	}
+
+	if err := s.repos.TransferTemplates.RecordUse(ctx, template.ID, amount, time.Now()); err != nil {
+		// The transfer went through; only the statistics are behind
+		utils.WarnContext(ctx, "failed to record transfer template use",
+			"template_id", template.ID.String(),
+			"error", err.Error(),
+		)
+	}
+
+	return transaction, nil
+}
+
+// checkPayee checks that the payee is another active user.
+func (s *TransferTemplateServiceImpl) checkPayee(ctx context.Context, userID, payeeID uuid.UUID) error {
+	if payeeID == userID {
+		return fmt.Errorf("cannot transfer to self")
+	}
+
+	payee, err := s.repos.Users.GetByID(ctx, payeeID)
+	if err != nil || !payee.IsActive {
+		return fmt.Errorf("payee not found")
+	}
+
+	return nil
+}
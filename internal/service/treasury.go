@@ -0,0 +1,177 @@
+// Package service provides business logic for treasury accounts and the money supply.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/repository"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// TreasuryServiceImpl implements TreasuryService. Minting and burning are the only ways money
+// enters or leaves the system; both are recorded on the treasury ledger and in the audit log.
+type TreasuryServiceImpl struct {
+	repos *repository.Repositories
+}
+
+// NewTreasuryService creates a new treasury service.
+func NewTreasuryService(repos *repository.Repositories) TreasuryService {
+	return &TreasuryServiceImpl{repos: repos}
+}
+
+// ListSupply reports the money supply of every currency, reconciled against user balances.
+func (s *TreasuryServiceImpl) ListSupply(ctx context.Context) ([]*domain.TreasurySupply, error) {
+	accounts, err := s.repos.Treasury.ListAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list treasury accounts: %w", err)
+	}
+
+	userBalances, err := s.repos.Treasury.SumUserBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum user balances: %w", err)
+	}
+
+	supply := make([]*domain.TreasurySupply, 0, len(accounts))
+	for _, account := range accounts {
+		supply = append(supply, &domain.TreasurySupply{
+			TreasuryAccount: account,
+			TotalSupply:     account.TotalSupply(),
+			Circulating:     account.Circulating(),
+			UserBalances:    userBalances[account.Currency],
+		})
+	}
+
+	return supply, nil
+}
+
+// ListEntries retrieves the ledger of a currency's treasury.
+func (s *TreasuryServiceImpl) ListEntries(ctx context.Context, filter *domain.TreasuryEntryFilter) ([]*domain.TreasuryEntry, error) {
+	filter.Currency = strings.ToUpper(filter.Currency)
+	if _, err := s.account(ctx, filter.Currency); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repos.Treasury.ListEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list treasury entries: %w", err)
+	}
+
+	if entries == nil {
+		entries = []*domain.TreasuryEntry{}
+	}
+
+	return entries, nil
+}
+
+// Mint creates money in a currency's treasury.
+func (s *TreasuryServiceImpl) Mint(ctx context.Context, actorID uuid.UUID, currency string, req *domain.TreasuryOperationRequest) (*domain.TreasuryEntry, error) {
+	return s.operate(ctx, actorID, currency, domain.TreasuryMint, domain.ActionMinted, req)
+}
+
+// Burn destroys money held by a currency's treasury. Money held by users cannot be burned.
+func (s *TreasuryServiceImpl) Burn(ctx context.Context, actorID uuid.UUID, currency string, req *domain.TreasuryOperationRequest) (*domain.TreasuryEntry, error) {
+	return s.operate(ctx, actorID, currency, domain.TreasuryBurn, domain.ActionBurned, req)
+}
+
+// SetCaps replaces the credit caps of a currency's treasury.
+func (s *TreasuryServiceImpl) SetCaps(ctx context.Context, actorID uuid.UUID, currency string, req *domain.SetTreasuryCapsRequest) (*domain.TreasuryAccount, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	currency = strings.ToUpper(currency)
+	if _, err := s.account(ctx, currency); err != nil {
+		return nil, err
+	}
+
+	account, err := s.repos.Treasury.SetCaps(ctx, currency, req.MaxCreditAmount, req.DailyCreditCap, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to set treasury caps: %w", err)
+	}
+
+	s.audit(ctx, account.ID, domain.ActionUpdated, map[string]interface{}{
+		"currency":          currency,
+		"actor_id":          actorID,
+		"max_credit_amount": req.MaxCreditAmount,
+		"daily_credit_cap":  req.DailyCreditCap,
+	})
+
+	return account, nil
+}
+
+// operate records a mint or burn on a currency's treasury ledger and audits it.
+func (s *TreasuryServiceImpl) operate(ctx context.Context, actorID uuid.UUID, currency string, kind domain.TreasuryEntryKind, action domain.AuditAction, req *domain.TreasuryOperationRequest) (*domain.TreasuryEntry, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	currency = strings.ToUpper(currency)
+	if _, err := s.account(ctx, currency); err != nil {
+		return nil, err
+	}
+
+	entry := &domain.TreasuryEntry{
+		ID:        uuid.New(),
+		Currency:  currency,
+		Kind:      kind,
+		Amount:    req.Amount,
+		ActorID:   &actorID,
+		Reason:    strings.TrimSpace(req.Reason),
+		CreatedAt: time.Now(),
+	}
+
+	account, err := s.repos.Treasury.Record(ctx, entry, false)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "insufficient treasury funds") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to %s: %w", kind, err)
+	}
+
+	s.audit(ctx, account.ID, action, map[string]interface{}{
+		"entry_id":      entry.ID,
+		"currency":      currency,
+		"amount":        entry.Amount,
+		"balance_after": entry.BalanceAfter,
+		"total_supply":  account.TotalSupply(),
+		"actor_id":      actorID,
+		"reason":        entry.Reason,
+	})
+
+	return entry, nil
+}
+
+// account retrieves a currency's treasury, rejecting unknown currencies as invalid requests.
+// Disabled currencies keep their treasury, so money held in them can still be managed.
+func (s *TreasuryServiceImpl) account(ctx context.Context, currency string) (*domain.TreasuryAccount, error) {
+	if !domain.IsKnownCurrency(currency) {
+		return nil, fmt.Errorf("invalid request: currency: unsupported currency: %s", currency)
+	}
+
+	account, err := s.repos.Treasury.GetAccount(ctx, currency)
+	if err != nil {
+		if err.Error() == "treasury account not found" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get treasury account: %w", err)
+	}
+
+	return account, nil
+}
+
+// audit records a treasury operation in the audit log. The treasury ledger is already committed,
+// so a failure is logged rather than returned.
+func (s *TreasuryServiceImpl) audit(ctx context.Context, accountID uuid.UUID, action domain.AuditAction, details map[string]interface{}) {
+	if err := s.repos.Audit.Log(ctx, string(domain.EntityTreasury), accountID, string(action), details); err != nil {
+		utils.WarnContext(ctx, "failed to audit treasury operation",
+			"account_id", accountID.String(),
+			"action", string(action),
+			"error", err.Error(),
+		)
+	}
+}
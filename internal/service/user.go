@@ -8,12 +8,14 @@ import (
 	"github.com/sefa-b/go-banking-sim/internal/domain"
 	"github.com/sefa-b/go-banking-sim/internal/repository"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
+	"golang.org/x/sync/singleflight"
 )
 
 // UserServiceImpl implements the UserService interface.
 type UserServiceImpl struct {
 	repos *repository.Repositories
-	cache CacheService // Optional cache service
+	cache CacheService       // Optional cache service
+	loads singleflight.Group // Coalesces concurrent cache-miss loads per user
 }
 
 // NewUserService creates a new user service.
@@ -33,21 +35,35 @@ func (s *UserServiceImpl) SetCacheService(cache CacheService) {
 func (s *UserServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserResponse, error) {
 	// Try cache first if available
 	if s.cache != nil {
-		cachedUser, err := s.cache.GetCachedUser(ctx, id)
+		cachedUser, stale, err := s.cache.GetCachedUser(ctx, id)
 		if err == nil {
-			utils.Info("cache hit for user", "user_id", id.String())
+			if stale {
+				// Serve the stale user now and refresh it for later reads
+				s.refresh(ctx, id)
+			}
 			return cachedUser, nil
 		}
 		// Cache miss or error - continue to database
-		utils.Info("cache miss for user", "user_id", id.String())
 	}
 
-	user, err := s.repos.Users.GetByID(ctx, id)
+	// Concurrent misses for the same user share a single database load
+	result, err, _ := s.loads.Do(id.String(), func() (interface{}, error) {
+		return s.load(ctx, id)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	response := user.ToResponse()
+	response := result.(*domain.User).ToResponse()
+	return &response, nil
+}
+
+// load reads a user from the database and caches it.
+func (s *UserServiceImpl) load(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.repos.Users.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
 	// Cache the result if cache is available
 	if s.cache != nil {
@@ -57,7 +73,24 @@ func (s *UserServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Us
 		}
 	}
 
-	return &response, nil
+	return user, nil
+}
+
+// refresh reloads a stale cached user in the background. It shares the load of concurrent cache
+// misses and refreshes of the same user, and outlives the request that triggered it.
+func (s *UserServiceImpl) refresh(ctx context.Context, id uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+	s.loads.DoChan(id.String(), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, cacheRefreshTimeout)
+		defer cancel()
+
+		user, err := s.load(ctx, id)
+		if err != nil {
+			utils.Warn("failed to refresh cached user", "user_id", id.String(), "error", err.Error())
+			return nil, err
+		}
+		return user, nil
+	})
 }
 
 // List retrieves users with pagination (admin only).
@@ -134,7 +167,7 @@ func (s *UserServiceImpl) Update(ctx context.Context, id uuid.UUID, req *domain.
 		}
 		if err := s.repos.Audit.Log(ctx, "user", user.ID, "update", auditDetails); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to log user update audit: %v\n", err)
+			utils.ErrorContext(ctx, "failed to log user update audit", "error", err.Error())
 		}
 	}
 
@@ -185,7 +218,7 @@ func (s *UserServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 		}
 		if err := s.repos.Audit.Log(ctx, "user", user.ID, "delete", auditDetails); err != nil {
 			// Log error but don't fail the operation
-			fmt.Printf("failed to log user delete audit: %v\n", err)
+			utils.ErrorContext(ctx, "failed to log user delete audit", "error", err.Error())
 		}
 	}
 
@@ -201,3 +234,26 @@ func (s *UserServiceImpl) GetProfile(ctx context.Context, userID uuid.UUID) (*do
 func (s *UserServiceImpl) UpdateProfile(ctx context.Context, userID uuid.UUID, req *domain.UpdateUserRequest) (*domain.UserResponse, error) {
 	return s.Update(ctx, userID, req)
 }
+
+// LookupAccount resolves an account number to the account it belongs to, so a sender can confirm
+// who they are paying. Only active accounts are found.
+func (s *UserServiceImpl) LookupAccount(ctx context.Context, accountNumber string) (*domain.AccountLookup, error) {
+	number, err := domain.ParseAccountNumber(accountNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	user, err := s.repos.Users.GetByAccountNumber(ctx, number)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, fmt.Errorf("account not found")
+		}
+		return nil, fmt.Errorf("failed to look up account: %w", err)
+	}
+
+	return &domain.AccountLookup{
+		AccountNumber: user.AccountNumber,
+		UserID:        user.ID,
+		Username:      user.Username,
+	}, nil
+}
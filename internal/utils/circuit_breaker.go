@@ -123,8 +123,8 @@ func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
 
 // Call executes a function with circuit breaker protection
 func (cb *CircuitBreaker) Call(ctx context.Context, fn func(context.Context) error) error {
-	if !cb.canExecute() {
-		return NewCircuitBreakerError("circuit breaker is open", cb.getState())
+	if err := cb.Allow(); err != nil {
+		return err
 	}
 
 	// Create timeout context
@@ -133,21 +133,44 @@ func (cb *CircuitBreaker) Call(ctx context.Context, fn func(context.Context) err
 
 	// Execute the function
 	err := fn(callCtx)
+	cb.Record(err != nil)
+	return err
+}
+
+// Allow reports whether a call may proceed, returning a CircuitBreakerError while the circuit is open.
+// Callers that cannot wrap their work in Call (e.g. streamed query results) pair it with Record.
+func (cb *CircuitBreaker) Allow() error {
+	if !cb.canExecute() {
+		return NewCircuitBreakerError("circuit breaker is open", cb.getState())
+	}
+	return nil
+}
 
-	// Update metrics and state
+// Record records the outcome of a call admitted by Allow.
+func (cb *CircuitBreaker) Record(failed bool) {
 	atomic.AddInt64(&cb.totalRequests, 1)
 
-	if err != nil {
+	if failed {
 		cb.recordFailure()
 		atomic.AddInt64(&cb.totalFailures, 1)
 		atomic.StoreInt32(&cb.consecutiveSuccesses, 0)
-		return err
+		return
 	}
 
 	cb.recordSuccess()
 	atomic.AddInt64(&cb.totalSuccesses, 1)
 	atomic.AddInt32(&cb.consecutiveSuccesses, 1)
-	return nil
+}
+
+// IsOpen reports whether the circuit is open and still rejecting calls.
+// It does not move the breaker to half-open once the reset timeout has passed.
+func (cb *CircuitBreaker) IsOpen() bool {
+	return cb.getState() == StateOpen && !cb.shouldAttemptReset()
+}
+
+// Name returns the name the circuit breaker was created with.
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
 }
 
 // canExecute determines if a call can be made based on current state
@@ -180,10 +203,11 @@ func (cb *CircuitBreaker) recordFailure() {
 	}
 }
 
-// recordSuccess records a success and potentially closes the circuit
+// recordSuccess records a success and potentially closes the circuit.
+// Any success resets the failure count so only consecutive failures open the circuit.
 func (cb *CircuitBreaker) recordSuccess() {
+	atomic.StoreInt32(&cb.failures, 0)
 	if cb.getState() == StateHalfOpen {
-		atomic.StoreInt32(&cb.failures, 0)
 		cb.setState(StateClosed)
 	}
 }
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DBPoolStats is a snapshot of the database connection pool.
+type DBPoolStats struct {
+	MaxConns             int32
+	IdleConns            int32
+	AcquiredConns        int32
+	ConstructingConns    int32
+	EmptyAcquireCount    int64 // Acquires that waited because no idle connection was available
+	CanceledAcquireCount int64 // Acquires abandoned because their context ended
+}
+
+var (
+	dbPoolAcquireWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "banking_db_pool_acquire_wait_seconds",
+		Help:    "Time queries waited to acquire a connection from the database pool",
+		Buckets: []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	})
+
+	dbPoolConnectionsDesc = prometheus.NewDesc("banking_db_pool_connections",
+		"Database pool connections by state: idle, acquired or constructing", []string{"state"}, nil)
+	dbPoolMaxConnectionsDesc = prometheus.NewDesc("banking_db_pool_max_connections",
+		"Maximum size of the database pool", nil, nil)
+	dbPoolEmptyAcquiresDesc = prometheus.NewDesc("banking_db_pool_empty_acquires_total",
+		"Acquires that waited for a connection because the database pool had none idle", nil, nil)
+	dbPoolCanceledAcquiresDesc = prometheus.NewDesc("banking_db_pool_canceled_acquires_total",
+		"Acquires abandoned because their context ended while waiting for a database connection", nil, nil)
+)
+
+// dbPoolStats reads the pool statistics published at scrape time; nil until a database is connected.
+var dbPoolStats atomic.Pointer[func() DBPoolStats]
+
+func init() {
+	prometheus.MustRegister(dbPoolCollector{})
+}
+
+// dbPoolCollector publishes the statistics of the database pool whenever metrics are scraped.
+type dbPoolCollector struct{}
+
+// Describe sends the descriptors of the pool metrics.
+func (dbPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dbPoolConnectionsDesc
+	ch <- dbPoolMaxConnectionsDesc
+	ch <- dbPoolEmptyAcquiresDesc
+	ch <- dbPoolCanceledAcquiresDesc
+}
+
+// Collect sends the current pool statistics, or nothing without a database.
+func (dbPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := dbPoolStats.Load()
+	if stats == nil {
+		return
+	}
+
+	s := (*stats)()
+	ch <- prometheus.MustNewConstMetric(dbPoolConnectionsDesc, prometheus.GaugeValue, float64(s.IdleConns), "idle")
+	ch <- prometheus.MustNewConstMetric(dbPoolConnectionsDesc, prometheus.GaugeValue, float64(s.AcquiredConns), "acquired")
+	ch <- prometheus.MustNewConstMetric(dbPoolConnectionsDesc, prometheus.GaugeValue, float64(s.ConstructingConns), "constructing")
+	ch <- prometheus.MustNewConstMetric(dbPoolMaxConnectionsDesc, prometheus.GaugeValue, float64(s.MaxConns))
+	ch <- prometheus.MustNewConstMetric(dbPoolEmptyAcquiresDesc, prometheus.CounterValue, float64(s.EmptyAcquireCount))
+	ch <- prometheus.MustNewConstMetric(dbPoolCanceledAcquiresDesc, prometheus.CounterValue, float64(s.CanceledAcquireCount))
+}
+
+// SetDBPoolStats publishes the statistics of the database pool, read by stats at every scrape.
+func (m *MetricsCollector) SetDBPoolStats(stats func() DBPoolStats) {
+	dbPoolStats.Store(&stats)
+}
+
+// ObserveDBPoolAcquire records how long a query waited to acquire a database connection.
+func (m *MetricsCollector) ObserveDBPoolAcquire(wait time.Duration) {
+	dbPoolAcquireWait.Observe(wait.Seconds())
+}
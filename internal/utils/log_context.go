@@ -0,0 +1,152 @@
+// Package utils provides request-scoped log enrichment.
+package utils
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogFields holds request-scoped fields that are added to every log record written
+// with a context carrying them. Fields are filled in as the request passes through
+// middleware (e.g. the user ID once authenticated), so the holder is shared and mutable.
+type LogFields struct {
+	mu        sync.RWMutex
+	requestID string
+	userID    string
+	route     string
+	traceID   string
+}
+
+// logFieldsKey is the context key for LogFields.
+type logFieldsKey struct{}
+
+// WithLogFields returns a context carrying a new, empty LogFields holder.
+func WithLogFields(ctx context.Context) (context.Context, *LogFields) {
+	fields := &LogFields{}
+	return context.WithValue(ctx, logFieldsKey{}, fields), fields
+}
+
+// LogFieldsFromContext returns the LogFields carried by ctx, or nil.
+// All LogFields methods are safe to call on a nil receiver.
+func LogFieldsFromContext(ctx context.Context) *LogFields {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(logFieldsKey{}).(*LogFields)
+	return fields
+}
+
+// SetRequestID sets the request ID.
+func (f *LogFields) SetRequestID(requestID string) {
+	f.set(func() { f.requestID = requestID })
+}
+
+// SetUserID sets the authenticated user ID.
+func (f *LogFields) SetUserID(userID string) {
+	f.set(func() { f.userID = userID })
+}
+
+// SetRoute sets the matched route pattern.
+func (f *LogFields) SetRoute(route string) {
+	f.set(func() { f.route = route })
+}
+
+// SetTraceID sets the trace ID.
+func (f *LogFields) SetTraceID(traceID string) {
+	f.set(func() { f.traceID = traceID })
+}
+
+// UserID returns the authenticated user ID, or an empty string.
+func (f *LogFields) UserID() string {
+	if f == nil {
+		return ""
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.userID
+}
+
+// Route returns the matched route pattern, or an empty string.
+func (f *LogFields) Route() string {
+	if f == nil {
+		return ""
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.route
+}
+
+// set applies assign under the lock; it is a no-op on a nil receiver.
+func (f *LogFields) set(assign func()) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assign()
+}
+
+// attrs returns the non-empty fields as log attributes.
+func (f *LogFields) attrs() []slog.Attr {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	attrs := make([]slog.Attr, 0, 4)
+	for _, field := range []struct{ key, value string }{
+		{"request_id", f.requestID},
+		{"user_id", f.userID},
+		{"route", f.route},
+		{"trace_id", f.traceID},
+	} {
+		if field.value != "" {
+			attrs = append(attrs, slog.String(field.key, field.value))
+		}
+	}
+	return attrs
+}
+
+// contextHandler is a slog.Handler that enriches records with fields from the logging context:
+// LogFields, the correlation ID, and the trace ID of the active span.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds context fields to the record before passing it on.
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		fields := LogFieldsFromContext(ctx)
+		hasTraceID := false
+		if fields != nil {
+			attrs := fields.attrs()
+			for _, attr := range attrs {
+				hasTraceID = hasTraceID || attr.Key == "trace_id"
+			}
+			record.AddAttrs(attrs...)
+		}
+
+		if !hasTraceID {
+			if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+				record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+			}
+		}
+
+		if correlationID := CorrelationIDFromContext(ctx); correlationID != "" {
+			record.AddAttrs(slog.String("correlation_id", correlationID))
+		}
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the given attributes that still enriches records.
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler with the given group that still enriches records.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
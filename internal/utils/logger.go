@@ -2,21 +2,67 @@
 package utils
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/config"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Logger is the global structured logger instance.
 var Logger *slog.Logger
 
-// InitLogger initializes the structured logger with JSON output.
-func InitLogger(env, service string) {
+// InitLogger initializes the structured logger from the logging configuration.
+func InitLogger(env, service string, cfg config.LogConfig) {
+	level := parseLevel(cfg.Level, slog.LevelInfo)
+
+	// The underlying handler must let through the most verbose configured level;
+	// levelFilterHandler applies the per-module thresholds
+	minLevel := level
+	moduleLevels := make(map[string]slog.Level, len(cfg.ModuleLevels))
+	for module, moduleLevel := range cfg.ModuleLevels {
+		moduleLevels[module] = parseLevel(moduleLevel, level)
+		minLevel = min(minLevel, moduleLevels[module])
+	}
+
+	var out io.Writer = os.Stdout
+	if cfg.File != "" {
+		out = io.MultiWriter(os.Stdout, &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.FileMaxSizeMB,
+			MaxBackups: cfg.FileMaxBackups,
+			MaxAge:     cfg.FileMaxAgeDays,
+			Compress:   true,
+		})
+	}
+
 	opts := &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+		Level: minLevel,
 	}
 
-	// Use JSON handler for structured logging
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(out, opts)
+	} else {
+		// Use JSON handler for structured logging
+		handler = slog.NewJSONHandler(out, opts)
+	}
+
+	// Enrich records with request context fields, then filter by module level and sample debug logs
+	handler = &levelFilterHandler{
+		Handler:      contextHandler{Handler: handler},
+		level:        level,
+		moduleLevels: moduleLevels,
+		sampleEvery:  uint64(max(cfg.DebugSampleEvery, 1)),
+		debugCount:   new(atomic.Uint64),
+	}
 	Logger = slog.New(handler)
 
 	// Set as default logger
@@ -24,28 +70,150 @@ func InitLogger(env, service string) {
 
 	// Log initialization with required fields
 	Logger.Info("logger initialized",
-		slog.String("level", "info"),
+		slog.String("level", level.String()),
+		slog.String("format", cfg.Format),
+		slog.String("file", cfg.File),
+		slog.Int("debug_sample_every", cfg.DebugSampleEvery),
 		slog.String("env", env),
 		slog.String("service", service),
 	)
 }
 
+// parseLevel parses a level name (debug, info, warn, error), returning defaultLevel if it is not recognized.
+func parseLevel(name string, defaultLevel slog.Level) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return defaultLevel
+	}
+	return level
+}
+
+// levelFilterHandler drops records below the level configured for the logging module
+// and keeps only one in sampleEvery debug records.
+type levelFilterHandler struct {
+	slog.Handler
+	level        slog.Level
+	moduleLevels map[string]slog.Level
+	sampleEvery  uint64
+	debugCount   *atomic.Uint64 // Shared by handlers derived via WithAttrs/WithGroup
+}
+
+// Handle filters the record and passes it on.
+func (h *levelFilterHandler) Handle(ctx context.Context, record slog.Record) error {
+	threshold := h.level
+	if len(h.moduleLevels) > 0 {
+		if moduleLevel, ok := h.moduleLevels[moduleOf(record.PC)]; ok {
+			threshold = moduleLevel
+		}
+	}
+	if record.Level < threshold {
+		return nil
+	}
+
+	if record.Level <= slog.LevelDebug && h.sampleEvery > 1 {
+		if (h.debugCount.Add(1)-1)%h.sampleEvery != 0 {
+			return nil
+		}
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the given attributes that still filters records.
+func (h *levelFilterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithAttrs(attrs)
+	return &clone
+}
+
+// WithGroup returns a handler with the given group that still filters records.
+func (h *levelFilterHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithGroup(name)
+	return &clone
+}
+
+// modules caches the module name resolved for each program counter.
+var modules sync.Map
+
+// moduleOf returns the name of the package that logged at pc, e.g. "worker" or "repository".
+func moduleOf(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	if module, ok := modules.Load(pc); ok {
+		return module.(string)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	// frame.Function looks like "github.com/org/repo/internal/worker.(*Pool).Start"
+	module := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+	if i := strings.Index(module, "."); i >= 0 {
+		module = module[:i]
+	}
+
+	modules.Store(pc, module)
+	return module
+}
+
+// logAt logs a record attributed to the caller of the exported logging helper,
+// so that source locations and module levels refer to the real call site.
+func logAt(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logger := Logger
+	if logger == nil {
+		logger = slog.Default() // Before InitLogger, e.g. in tests
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // Skip runtime.Callers, logAt and the exported helper
+
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	record.Add(args...)
+	_ = logger.Handler().Handle(ctx, record)
+}
+
 // Info logs an info level message with optional key-value pairs.
 func Info(msg string, args ...any) {
-	Logger.Info(msg, args...)
+	logAt(context.Background(), slog.LevelInfo, msg, args...)
 }
 
 // Error logs an error level message with optional key-value pairs.
 func Error(msg string, args ...any) {
-	Logger.Error(msg, args...)
+	logAt(context.Background(), slog.LevelError, msg, args...)
 }
 
 // Debug logs a debug level message with optional key-value pairs.
 func Debug(msg string, args ...any) {
-	Logger.Debug(msg, args...)
+	logAt(context.Background(), slog.LevelDebug, msg, args...)
 }
 
 // Warn logs a warning level message with optional key-value pairs.
 func Warn(msg string, args ...any) {
-	Logger.Warn(msg, args...)
+	logAt(context.Background(), slog.LevelWarn, msg, args...)
+}
+
+// InfoContext logs an info level message enriched with the request context fields carried by ctx.
+func InfoContext(ctx context.Context, msg string, args ...any) {
+	logAt(ctx, slog.LevelInfo, msg, args...)
+}
+
+// ErrorContext logs an error level message enriched with the request context fields carried by ctx.
+func ErrorContext(ctx context.Context, msg string, args ...any) {
+	logAt(ctx, slog.LevelError, msg, args...)
+}
+
+// DebugContext logs a debug level message enriched with the request context fields carried by ctx.
+func DebugContext(ctx context.Context, msg string, args ...any) {
+	logAt(ctx, slog.LevelDebug, msg, args...)
+}
+
+// WarnContext logs a warning level message enriched with the request context fields carried by ctx.
+func WarnContext(ctx context.Context, msg string, args ...any) {
+	logAt(ctx, slog.LevelWarn, msg, args...)
 }
@@ -2,6 +2,7 @@
 package utils
 
 import (
+	"context"
 	"runtime"
 	"strconv"
 	"sync/atomic"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Prometheus metrics
@@ -44,14 +46,107 @@ var (
 
 	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "banking_http_requests_total",
-		Help: "Total number of HTTP requests",
+		Help: "Total number of HTTP requests by route template",
 	}, []string{"method", "endpoint", "status_code"})
 
+	httpRequestErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_http_request_errors_total",
+		Help: "Total number of HTTP requests answered with a 5xx status by route template",
+	}, []string{"method", "endpoint"})
+
 	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "banking_http_request_duration_seconds",
-		Help:    "HTTP request duration in seconds",
+		Help:    "HTTP request duration in seconds by route template",
 		Buckets: prometheus.DefBuckets,
 	}, []string{"method", "endpoint"})
+
+	httpRequestTimeoutsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_http_request_timeouts_total",
+		Help: "Total number of HTTP requests answered with a 504 because their deadline passed, by route template",
+	}, []string{"method", "endpoint"})
+
+	httpRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "banking_http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served by method",
+	}, []string{"method"})
+
+	bulkheadInUse = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "banking_http_bulkhead_in_use",
+		Help: "Number of requests holding a slot of their route group's bulkhead",
+	}, []string{"group"})
+
+	bulkheadRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_http_bulkhead_rejections_total",
+		Help: "Total number of requests answered with a 503 because their route group's bulkhead was full, by reason (queue_full, queue_timeout)",
+	}, []string{"group", "reason"})
+
+	transactionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_transactions_total",
+		Help: "Total number of transactions by type and outcome",
+	}, []string{"type", "outcome"})
+
+	transactionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "banking_transaction_duration_seconds",
+		Help:    "Transaction processing duration in seconds by type and outcome",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"type", "outcome"})
+
+	transactionQueueWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "banking_transaction_queue_wait_seconds",
+		Help:    "Time transaction jobs spend waiting in the queue before processing",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"type"})
+
+	cacheHitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_cache_hits_total",
+		Help: "Total number of cache hits by entity type",
+	}, []string{"entity"})
+
+	cacheStaleHitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_cache_stale_hits_total",
+		Help: "Total number of cache hits past their soft TTL, served while refreshed in the background, by entity type",
+	}, []string{"entity"})
+
+	cacheMissesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_cache_misses_total",
+		Help: "Total number of cache misses by entity type",
+	}, []string{"entity"})
+
+	cacheErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_cache_errors_total",
+		Help: "Total number of cache lookup errors by entity type",
+	}, []string{"entity"})
+
+	projectorLeader = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "banking_projector_leader",
+		Help: "Whether this instance is the elected event projector leader (1) or not (0)",
+	})
+
+	moneySupplyDrift = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "banking_money_supply_drift",
+		Help: "User balances plus treasury balance minus money minted and not burned, by currency; non-zero means money was created or lost",
+	}, []string{"currency"})
+
+	nettingBatchesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_netting_batches_total",
+		Help: "Total number of closed netting batches by status",
+	}, []string{"status"})
+
+	nettingTransfersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "banking_netting_transfers_total",
+		Help: "Total number of transfers in closed netting batches by batch status",
+	}, []string{"status"})
+
+	nettingMovementsSavedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "banking_netting_movements_saved_total",
+		Help: "Total number of balance movements saved by settling transfers as one net movement per batch",
+	})
+)
+
+// Transaction outcome label values.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
 )
 
 // MetricsCollector collects basic application metrics.
@@ -80,10 +175,116 @@ func (m *MetricsCollector) SetQueueDepth(depth int) {
 	transactionQueueDepth.Set(float64(depth))
 }
 
-// RecordHTTPRequest records an HTTP request metric.
-func (m *MetricsCollector) RecordHTTPRequest(method, endpoint string, statusCode int, duration time.Duration) {
-	httpRequestsTotal.WithLabelValues(method, endpoint, strconv.Itoa(statusCode)).Inc()
-	httpRequestDuration.WithLabelValues(method, endpoint).Observe(duration.Seconds())
+// RecordHTTPRequest records the rate, errors and duration of an HTTP request to a route template.
+// The duration carries the request's trace ID as an exemplar when the trace is sampled.
+func (m *MetricsCollector) RecordHTTPRequest(ctx context.Context, method, route string, statusCode int, duration time.Duration) {
+	exemplar := traceExemplar(ctx)
+
+	addWithExemplar(httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(statusCode)), exemplar)
+	if statusCode >= 500 {
+		addWithExemplar(httpRequestErrorsTotal.WithLabelValues(method, route), exemplar)
+	}
+
+	observer := httpRequestDuration.WithLabelValues(method, route)
+	if eo, ok := observer.(prometheus.ExemplarObserver); ok && exemplar != nil {
+		eo.ObserveWithExemplar(duration.Seconds(), exemplar)
+		return
+	}
+	observer.Observe(duration.Seconds())
+}
+
+// RecordHTTPTimeout records a request to a route template that failed because its deadline passed.
+func (m *MetricsCollector) RecordHTTPTimeout(method, route string) {
+	httpRequestTimeoutsTotal.WithLabelValues(method, route).Inc()
+}
+
+// TrackHTTPRequest counts a request as in flight until the returned function is called.
+func (m *MetricsCollector) TrackHTTPRequest(method string) func() {
+	gauge := httpRequestsInFlight.WithLabelValues(method)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// TrackBulkheadSlot counts a slot of a bulkhead as in use until the returned function is called.
+func (m *MetricsCollector) TrackBulkheadSlot(group string) func() {
+	gauge := bulkheadInUse.WithLabelValues(group)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// RecordBulkheadRejection records a request rejected because its route group's bulkhead was full.
+func (m *MetricsCollector) RecordBulkheadRejection(group, reason string) {
+	bulkheadRejectionsTotal.WithLabelValues(group, reason).Inc()
+}
+
+// traceExemplar returns exemplar labels linking a metric to the sampled trace of ctx, or nil.
+func traceExemplar(ctx context.Context) prometheus.Labels {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{"trace_id": spanContext.TraceID().String()}
+}
+
+// addWithExemplar increments counter, attaching exemplar when there is one.
+func addWithExemplar(counter prometheus.Counter, exemplar prometheus.Labels) {
+	if ea, ok := counter.(prometheus.ExemplarAdder); ok && exemplar != nil {
+		ea.AddWithExemplar(1, exemplar)
+		return
+	}
+	counter.Inc()
+}
+
+// ObserveTransaction records the duration and outcome of a transaction operation.
+func (m *MetricsCollector) ObserveTransaction(txType, outcome string, duration time.Duration) {
+	transactionsTotal.WithLabelValues(txType, outcome).Inc()
+	transactionDuration.WithLabelValues(txType, outcome).Observe(duration.Seconds())
+}
+
+// ObserveQueueWait records how long a job waited in the queue before a worker picked it up.
+func (m *MetricsCollector) ObserveQueueWait(jobType string, wait time.Duration) {
+	transactionQueueWait.WithLabelValues(jobType).Observe(wait.Seconds())
+}
+
+// RecordCacheHit records a cache hit for an entity type.
+func (m *MetricsCollector) RecordCacheHit(entity string) {
+	cacheHitsTotal.WithLabelValues(entity).Inc()
+}
+
+// RecordCacheStale records a cache hit past its soft TTL for an entity type.
+func (m *MetricsCollector) RecordCacheStale(entity string) {
+	cacheStaleHitsTotal.WithLabelValues(entity).Inc()
+}
+
+// RecordCacheMiss records a cache miss for an entity type.
+func (m *MetricsCollector) RecordCacheMiss(entity string) {
+	cacheMissesTotal.WithLabelValues(entity).Inc()
+}
+
+// RecordCacheError records a failed cache lookup (other than a miss) for an entity type.
+func (m *MetricsCollector) RecordCacheError(entity string) {
+	cacheErrorsTotal.WithLabelValues(entity).Inc()
+}
+
+// SetProjectorLeader records whether this instance currently runs event projections.
+func (m *MetricsCollector) SetProjectorLeader(isLeader bool) {
+	if isLeader {
+		projectorLeader.Set(1)
+		return
+	}
+	projectorLeader.Set(0)
+}
+
+// SetMoneySupplyDrift records the money supply drift of a currency found by the invariant checker.
+func (m *MetricsCollector) SetMoneySupplyDrift(currency string, drift float64) {
+	moneySupplyDrift.WithLabelValues(currency).Set(drift)
+}
+
+// RecordNettingBatch records a closed netting batch, its transfers and the balance movements netting saved.
+func (m *MetricsCollector) RecordNettingBatch(status string, transfers, movementsSaved int) {
+	nettingBatchesTotal.WithLabelValues(status).Inc()
+	nettingTransfersTotal.WithLabelValues(status).Add(float64(transfers))
+	nettingMovementsSavedTotal.Add(float64(movementsSaved))
 }
 
 // GetMetrics returns the current metrics as a JSON-serializable struct.
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// RetryPolicy configures retries with exponential backoff.
+type RetryPolicy struct {
+	InitialBackoff time.Duration // Wait after the first failed attempt; doubled after each further failure
+	MaxBackoff     time.Duration // Longest wait between attempts
+	MaxWait        time.Duration // Total time to keep retrying; 0 makes a single attempt
+}
+
+// Retry calls attempt until it succeeds, ctx is done or policy.MaxWait has passed, backing off
+// exponentially between attempts. Every failed attempt is logged with the dependency name, so a
+// slow start is visible; the last attempt's error is returned when retries are exhausted.
+func Retry(ctx context.Context, name string, policy RetryPolicy, attempt func(ctx context.Context) error) error {
+	start := time.Now()
+	deadline := start.Add(policy.MaxWait)
+	backoff := policy.InitialBackoff
+
+	for n := 1; ; n++ {
+		err := attempt(ctx)
+		if err == nil {
+			if n > 1 {
+				Info("dependency available",
+					slog.String("dependency", name),
+					slog.Int("attempts", n),
+					slog.Duration("waited", time.Since(start).Round(time.Millisecond)),
+				)
+			}
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%s unavailable after %d attempts over %s: %w", name, n, time.Since(start).Round(time.Millisecond), err)
+		}
+		wait := min(backoff, remaining)
+
+		Warn("dependency unavailable, retrying",
+			slog.String("dependency", name),
+			slog.Int("attempt", n),
+			slog.Duration("retry_in", wait.Round(time.Millisecond)),
+			slog.Duration("remaining", remaining.Round(time.Millisecond)),
+			slog.String("error", err.Error()),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s unavailable: %w", name, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, policy.MaxBackoff)
+	}
+}
@@ -5,21 +5,32 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/sefa-b/go-banking-sim/internal/config"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
-// InitTracer initializes OpenTelemetry tracing with Jaeger OTLP exporter.
-func InitTracer(ctx context.Context, serviceName, serviceVersion, jaegerEndpoint string) (func(), error) {
+// InitTracer initializes OpenTelemetry tracing with an OTLP exporter configured by cfg.
+// When tracing is disabled the global no-op tracer provider is left in place.
+func InitTracer(ctx context.Context, serviceName, serviceVersion string, cfg config.TracingConfig) (func(), error) {
+	// Propagate W3C trace context so spans can be continued across async boundaries
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if !cfg.Enabled {
+		Info("tracing disabled")
+		return func() {}, nil
+	}
+
 	// Set up OTLP exporter
-	exporter, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithEndpoint(jaegerEndpoint),
-		otlptracegrpc.WithInsecure(),
-	)
+	exporter, err := newTraceExporter(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
 	}
@@ -36,16 +47,22 @@ func InitTracer(ctx context.Context, serviceName, serviceVersion, jaegerEndpoint
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// Create trace provider
+	// Create trace provider; child spans follow their parent's sampling decision
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
 	)
 
 	// Set global tracer provider
 	otel.SetTracerProvider(tp)
 
+	Info("tracing enabled",
+		"endpoint", cfg.Endpoint,
+		"protocol", cfg.Protocol,
+		"sample_ratio", cfg.SampleRatio,
+	)
+
 	// Return shutdown function
 	return func() {
 		if err := tp.Shutdown(ctx); err != nil {
@@ -54,6 +71,32 @@ func InitTracer(ctx context.Context, serviceName, serviceVersion, jaegerEndpoint
 	}, nil
 }
 
+// newTraceExporter creates an OTLP gRPC or HTTP exporter.
+func newTraceExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Protocol {
+	case config.TracingProtocolGRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+			otlptracegrpc.WithHeaders(cfg.Headers),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case config.TracingProtocolHTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.Endpoint),
+			otlptracehttp.WithHeaders(cfg.Headers),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol: %s", cfg.Protocol)
+	}
+}
+
 // GetTracer returns a tracer with the given name.
 func GetTracer(name string) trace.Tracer {
 	return otel.Tracer(name)
@@ -68,7 +111,69 @@ func TraceIDFromContext(ctx context.Context) string {
 	return span.SpanContext().TraceID().String()
 }
 
+// InjectTraceContext serializes the trace context of ctx so it can travel with async work.
+// It returns nil if ctx carries no trace.
+func InjectTraceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// ExtractTraceContext restores a trace context serialized by InjectTraceContext into ctx,
+// so spans started from the returned context are children of the originating span.
+func ExtractTraceContext(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// correlationIDKey is the context key for the correlation ID.
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying the given correlation ID.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx, or an empty string.
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
 // SpanFromContext extracts span from context.
 func SpanFromContext(ctx context.Context) trace.Span {
 	return trace.SpanFromContext(ctx)
 }
+
+// EndSpan records err on the span, if any, and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// AmountAttribute returns a span attribute describing an amount by order of magnitude,
+// so traces never carry exact monetary values.
+func AmountAttribute(key string, amount float64) attribute.KeyValue {
+	var bucket string
+	switch {
+	case amount < 10:
+		bucket = "<10"
+	case amount < 100:
+		bucket = "10-100"
+	case amount < 1000:
+		bucket = "100-1k"
+	case amount < 10000:
+		bucket = "1k-10k"
+	default:
+		bucket = ">=10k"
+	}
+	return attribute.String(key, bucket)
+}
@@ -0,0 +1,124 @@
+// Package worker provides a background worker that maintains monthly partitions and archives old rows.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// ArchiveMaintainer defines the interface for partition maintenance and archival.
+type ArchiveMaintainer interface {
+	RunMaintenance(ctx context.Context) (*domain.ArchiveRun, error)
+}
+
+// ArchiveWorker periodically creates upcoming event and transaction partitions and archives events
+// and transactions past the configured age.
+type ArchiveWorker struct {
+	maintainer ArchiveMaintainer
+	ticker     *time.Ticker
+	stopChan   chan struct{}
+	running    bool
+}
+
+// NewArchiveWorker creates a new archive worker.
+func NewArchiveWorker(maintainer ArchiveMaintainer) *ArchiveWorker {
+	return &ArchiveWorker{
+		maintainer: maintainer,
+		stopChan:   make(chan struct{}),
+		running:    false,
+	}
+}
+
+// Start runs maintenance immediately and then on every interval.
+func (w *ArchiveWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("archive worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting archive worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the archive worker.
+func (w *ArchiveWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping archive worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("archive worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("archive worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop runs maintenance on boot and then on every tick.
+func (w *ArchiveWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.maintain()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.maintain()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// maintain runs partition maintenance and archival, logging what changed.
+func (w *ArchiveWorker) maintain() {
+	ctx := context.Background()
+
+	run, err := w.maintainer.RunMaintenance(ctx)
+	if err != nil {
+		utils.Error("failed to run archive maintenance", slog.String("error", err.Error()))
+	}
+	if run == nil {
+		return
+	}
+
+	if len(run.PartitionsCreated) > 0 || len(run.EventPartitionsArchived) > 0 || len(run.TransactionPartitionsArchived) > 0 {
+		utils.Info("archive maintenance finished",
+			slog.Any("partitions_created", run.PartitionsCreated),
+			slog.Any("event_partitions_archived", run.EventPartitionsArchived),
+			slog.Any("transaction_partitions_archived", run.TransactionPartitionsArchived),
+		)
+	}
+}
@@ -0,0 +1,111 @@
+// Package worker provides background workers for warming the cache.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// CacheWarmer defines the interface for warming the cache.
+type CacheWarmer interface {
+	WarmIfCold(ctx context.Context) error
+}
+
+// CacheWarmupWorker preloads frequently accessed data into the cache on startup
+// and re-warms it whenever the cache has been flushed or the warm-up has expired.
+type CacheWarmupWorker struct {
+	warmer   CacheWarmer
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewCacheWarmupWorker creates a new cache warm-up worker.
+func NewCacheWarmupWorker(warmer CacheWarmer) *CacheWarmupWorker {
+	return &CacheWarmupWorker{
+		warmer:   warmer,
+		stopChan: make(chan struct{}),
+		running:  false,
+	}
+}
+
+// Start warms the cache immediately and then checks it on every interval.
+func (w *CacheWarmupWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("cache warm-up worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting cache warm-up worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the cache warm-up worker.
+func (w *CacheWarmupWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping cache warm-up worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("cache warm-up worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("cache warm-up worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop warms the cache on boot and then re-checks it on every tick.
+func (w *CacheWarmupWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.warmCache()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.warmCache()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// warmCache warms the cache if it is cold.
+func (w *CacheWarmupWorker) warmCache() {
+	ctx := context.Background()
+
+	if err := w.warmer.WarmIfCold(ctx); err != nil {
+		utils.Error("failed to warm cache", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,108 @@
+// Package worker provides a background worker that reloads the currency registry.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// CurrencyRefresher defines the interface for reloading the currency registry.
+type CurrencyRefresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// CurrencyRefreshWorker periodically reloads the currency registry, so currencies added or
+// changed through another instance are validated and formatted the same way here.
+type CurrencyRefreshWorker struct {
+	refresher CurrencyRefresher
+	ticker    *time.Ticker
+	stopChan  chan struct{}
+	running   bool
+}
+
+// NewCurrencyRefreshWorker creates a new currency refresh worker.
+func NewCurrencyRefreshWorker(refresher CurrencyRefresher) *CurrencyRefreshWorker {
+	return &CurrencyRefreshWorker{
+		refresher: refresher,
+		stopChan:  make(chan struct{}),
+		running:   false,
+	}
+}
+
+// Start reloads the registry on every interval. The registry is expected to be loaded once
+// at startup, before requests are served.
+func (w *CurrencyRefreshWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("currency refresh worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting currency refresh worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the currency refresh worker.
+func (w *CurrencyRefreshWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping currency refresh worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("currency refresh worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("currency refresh worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop reloads the registry on every tick.
+func (w *CurrencyRefreshWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.refresh()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// refresh reloads the registry; on failure the previously loaded registry stays in use.
+func (w *CurrencyRefreshWorker) refresh() {
+	if err := w.refresher.Refresh(context.Background()); err != nil {
+		utils.Error("failed to refresh currency registry", slog.String("error", err.Error()))
+	}
+}
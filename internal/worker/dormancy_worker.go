@@ -0,0 +1,117 @@
+// Package worker provides a background worker that announces dormant accounts.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// DormancyFlagger defines the interface for announcing accounts that became dormant.
+type DormancyFlagger interface {
+	FlagDormant(ctx context.Context, days int) (int, error)
+}
+
+// DormancyWorker periodically publishes an AccountDormant event for every account that went days
+// days without transactions since it was last announced.
+type DormancyWorker struct {
+	flagger  DormancyFlagger
+	days     int
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewDormancyWorker creates a new dormancy worker flagging accounts after days days of inactivity.
+func NewDormancyWorker(flagger DormancyFlagger, days int) *DormancyWorker {
+	return &DormancyWorker{
+		flagger:  flagger,
+		days:     days,
+		stopChan: make(chan struct{}),
+		running:  false,
+	}
+}
+
+// Start flags dormant accounts immediately and then on every interval.
+func (w *DormancyWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("dormancy worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting dormancy worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the dormancy worker.
+func (w *DormancyWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping dormancy worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("dormancy worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("dormancy worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop flags on boot and then on every tick.
+func (w *DormancyWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.flag()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.flag()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// flag announces the accounts that became dormant.
+func (w *DormancyWorker) flag() {
+	ctx := context.Background()
+
+	flagged, err := w.flagger.FlagDormant(ctx, w.days)
+	if err != nil {
+		utils.Error("failed to flag dormant accounts", slog.String("error", err.Error()))
+	}
+	if flagged > 0 {
+		utils.Info("flagged dormant accounts", slog.Int("count", flagged))
+	}
+}
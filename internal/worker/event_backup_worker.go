@@ -0,0 +1,144 @@
+// Package worker provides a background worker that exports new events to object storage.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// EventExporter defines the interface for exporting new events.
+type EventExporter interface {
+	Export(ctx context.Context) (*domain.EventExportRun, error)
+}
+
+// EventBackupWorker periodically exports the events created since its last export.
+type EventBackupWorker struct {
+	exporter EventExporter
+	elector  LeaderElector // Optional; without one this instance always exports
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewEventBackupWorker creates a new event backup worker.
+func NewEventBackupWorker(exporter EventExporter) *EventBackupWorker {
+	return &EventBackupWorker{
+		exporter: exporter,
+		stopChan: make(chan struct{}),
+		running:  false,
+	}
+}
+
+// SetLeaderElector sets the leader elector used to ensure only one instance exports events,
+// since instances exporting at once would write overlapping objects. Must be called before Start.
+func (w *EventBackupWorker) SetLeaderElector(elector LeaderElector) {
+	w.elector = elector
+}
+
+// Start exports immediately and then on every interval.
+func (w *EventBackupWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("event backup worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting event backup worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the event backup worker.
+func (w *EventBackupWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping event backup worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("event backup worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("event backup worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop exports on boot and then on every tick.
+func (w *EventBackupWorker) processLoop() {
+	defer func() {
+		if w.elector != nil {
+			if err := w.elector.Release(context.Background()); err != nil {
+				utils.Error("failed to release event backup leadership", slog.String("error", err.Error()))
+			}
+		}
+		w.running = false
+	}()
+
+	w.export()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.export()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// export writes new events if this instance leads, logging what was exported.
+func (w *EventBackupWorker) export() {
+	ctx := context.Background()
+
+	if w.elector != nil {
+		acquired, err := w.elector.TryAcquire(ctx)
+		if err != nil {
+			utils.Error("event backup leader election failed", slog.String("error", err.Error()))
+		}
+		if !acquired {
+			utils.Debug("another instance is exporting events, skipping this cycle")
+			return
+		}
+	}
+
+	run, err := w.exporter.Export(ctx)
+	if err != nil {
+		utils.Error("failed to export events", slog.String("error", err.Error()))
+	}
+	if run == nil || run.Events == 0 {
+		return
+	}
+
+	utils.Info("exported events",
+		slog.Int("events", run.Events),
+		slog.Int("objects", len(run.Objects)),
+		slog.String("through", run.Through.Format(time.RFC3339Nano)),
+	)
+}
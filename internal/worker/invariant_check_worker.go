@@ -0,0 +1,112 @@
+// Package worker provides a background worker that checks accounting invariants.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// InvariantChecker defines the interface for checking accounting invariants.
+type InvariantChecker interface {
+	Check(ctx context.Context) (*domain.InvariantReport, error)
+}
+
+// InvariantCheckWorker periodically checks the money supply invariant so the drift metric
+// stays current and leaks are logged soon after they happen.
+type InvariantCheckWorker struct {
+	checker  InvariantChecker
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewInvariantCheckWorker creates a new invariant check worker.
+func NewInvariantCheckWorker(checker InvariantChecker) *InvariantCheckWorker {
+	return &InvariantCheckWorker{
+		checker:  checker,
+		stopChan: make(chan struct{}),
+		running:  false,
+	}
+}
+
+// Start checks the invariants immediately and then on every interval.
+func (w *InvariantCheckWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("invariant check worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting invariant check worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the invariant check worker.
+func (w *InvariantCheckWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping invariant check worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("invariant check worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("invariant check worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop checks on boot and then on every tick.
+func (w *InvariantCheckWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.check()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.check()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// check runs the invariant checker; violations are logged by the checker itself.
+func (w *InvariantCheckWorker) check() {
+	ctx := context.Background()
+
+	if _, err := w.checker.Check(ctx); err != nil {
+		utils.Error("failed to check invariants", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,115 @@
+// Package worker provides a background worker that generates monthly account summaries.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// MonthlySummaryGenerator defines the interface for generating the summaries of the month that ended last.
+type MonthlySummaryGenerator interface {
+	GenerateDue(ctx context.Context) (int, error)
+}
+
+// MonthlySummaryWorker periodically generates every user's summary of the month that ended last.
+// Once a month's summaries are all generated, later ticks do nothing until the next month ends.
+type MonthlySummaryWorker struct {
+	generator MonthlySummaryGenerator
+	ticker    *time.Ticker
+	stopChan  chan struct{}
+	running   bool
+}
+
+// NewMonthlySummaryWorker creates a new monthly summary worker.
+func NewMonthlySummaryWorker(generator MonthlySummaryGenerator) *MonthlySummaryWorker {
+	return &MonthlySummaryWorker{
+		generator: generator,
+		stopChan:  make(chan struct{}),
+		running:   false,
+	}
+}
+
+// Start generates due monthly summaries immediately and then on every interval.
+func (w *MonthlySummaryWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("monthly summary worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting monthly summary worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the monthly summary worker.
+func (w *MonthlySummaryWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping monthly summary worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("monthly summary worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("monthly summary worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop generates on boot and then on every tick.
+func (w *MonthlySummaryWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.generate()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.generate()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// generate generates the monthly summaries that are due.
+func (w *MonthlySummaryWorker) generate() {
+	ctx := context.Background()
+
+	generated, err := w.generator.GenerateDue(ctx)
+	if err != nil {
+		utils.Error("failed to generate monthly summaries", slog.String("error", err.Error()))
+	}
+	if generated > 0 {
+		utils.Info("generated monthly summaries", slog.Int("count", generated))
+	}
+}
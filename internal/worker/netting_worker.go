@@ -0,0 +1,111 @@
+// Package worker provides a background worker that settles netted transfers.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// NettingSettler defines the interface for settling netting batches whose window closed.
+type NettingSettler interface {
+	SettleDueNetting(ctx context.Context) (int, error)
+}
+
+// NettingWorker periodically settles the netting batches whose window closed, so netted
+// transfers complete soon after their window.
+type NettingWorker struct {
+	settler  NettingSettler
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewNettingWorker creates a new netting worker.
+func NewNettingWorker(settler NettingSettler) *NettingWorker {
+	return &NettingWorker{
+		settler:  settler,
+		stopChan: make(chan struct{}),
+		running:  false,
+	}
+}
+
+// Start settles due batches immediately and then on every interval.
+func (w *NettingWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("netting worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting netting worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the netting worker.
+func (w *NettingWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping netting worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("netting worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("netting worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop settles on boot and then on every tick.
+func (w *NettingWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.settle()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.settle()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// settle settles the due batches; each batch is logged by the settler itself.
+func (w *NettingWorker) settle() {
+	ctx := context.Background()
+
+	if _, err := w.settler.SettleDueNetting(ctx); err != nil {
+		utils.Error("failed to settle netting batches", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,111 @@
+// Package worker provides a background worker that dispatches queued notifications.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// NotificationDispatcher defines the interface for sending queued notifications.
+type NotificationDispatcher interface {
+	DispatchPending(ctx context.Context) (int, error)
+}
+
+// NotificationDispatchWorker periodically renders and sends queued notifications
+// to the in-app inbox, email and webhook channels.
+type NotificationDispatchWorker struct {
+	dispatcher NotificationDispatcher
+	ticker     *time.Ticker
+	stopChan   chan struct{}
+	running    bool
+}
+
+// NewNotificationDispatchWorker creates a new notification dispatch worker.
+func NewNotificationDispatchWorker(dispatcher NotificationDispatcher) *NotificationDispatchWorker {
+	return &NotificationDispatchWorker{
+		dispatcher: dispatcher,
+		stopChan:   make(chan struct{}),
+		running:    false,
+	}
+}
+
+// Start dispatches due notifications immediately and then on every interval.
+func (w *NotificationDispatchWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("notification dispatch worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting notification dispatch worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the notification dispatch worker.
+func (w *NotificationDispatchWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping notification dispatch worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("notification dispatch worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("notification dispatch worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop dispatches on boot and then on every tick.
+func (w *NotificationDispatchWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.dispatch()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.dispatch()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// dispatch sends the notifications that are due.
+func (w *NotificationDispatchWorker) dispatch() {
+	ctx := context.Background()
+
+	if _, err := w.dispatcher.DispatchPending(ctx); err != nil {
+		utils.Error("failed to dispatch notifications", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,108 @@
+// Package worker provides a background worker that reloads the runtime policy.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// PolicyRefresher defines the interface for reloading the runtime policy.
+type PolicyRefresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// PolicyRefreshWorker periodically reloads the runtime policy, so versions stored through another
+// instance take effect here too.
+type PolicyRefreshWorker struct {
+	refresher PolicyRefresher
+	ticker    *time.Ticker
+	stopChan  chan struct{}
+	running   bool
+}
+
+// NewPolicyRefreshWorker creates a new policy refresh worker.
+func NewPolicyRefreshWorker(refresher PolicyRefresher) *PolicyRefreshWorker {
+	return &PolicyRefreshWorker{
+		refresher: refresher,
+		stopChan:  make(chan struct{}),
+		running:   false,
+	}
+}
+
+// Start reloads the policy on every interval. The policy is expected to be loaded once at
+// startup, before requests are served.
+func (w *PolicyRefreshWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("policy refresh worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting policy refresh worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the policy refresh worker.
+func (w *PolicyRefreshWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping policy refresh worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("policy refresh worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("policy refresh worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop reloads the policy on every tick.
+func (w *PolicyRefreshWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.refresh()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// refresh reloads the policy; on failure the previously loaded version stays in effect.
+func (w *PolicyRefreshWorker) refresh() {
+	if err := w.refresher.Refresh(context.Background()); err != nil {
+		utils.Error("failed to refresh policy", slog.String("error", err.Error()))
+	}
+}
@@ -9,22 +9,35 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/service"
 	"github.com/sefa-b/go-banking-sim/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TransactionService defines the interface for transaction operations needed by the worker pool.
+// The service layer's TransactionService satisfies it.
 type TransactionService interface {
-	CreditSync(ctx context.Context, userID string, req interface{}) (interface{}, error)
-	DebitSync(ctx context.Context, userID string, req interface{}) (interface{}, error)
-	TransferSync(ctx context.Context, fromUserID string, req interface{}) (interface{}, error)
-	RollbackSync(ctx context.Context, transactionID string, requestingUserID string) (interface{}, error)
+	CreditSync(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error)
+	DebitSync(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error)
+	TransferSync(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (*domain.TransactionResponse, error)
+	RollbackSync(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error)
 }
 
-// Pool manages a pool of workers that process transaction jobs asynchronously.
+// QueueMetrics defines the metrics recorded by the worker pool.
+type QueueMetrics interface {
+	ObserveQueueWait(jobType string, wait time.Duration)
+	SetQueueDepth(depth int)
+}
+
+// Pool manages a pool of workers that process jobs asynchronously. Transaction jobs are built in;
+// other kinds of jobs reuse the pool through NewJob and Submit.
 type Pool struct {
 	jobQueue       *JobQueue
 	transactionSvc TransactionService
+	metrics        QueueMetrics // Optional queue metrics
 	workers        []*Worker
 	wg             sync.WaitGroup
 	stopped        chan struct{}
@@ -32,12 +45,14 @@ type Pool struct {
 	mu             sync.RWMutex
 }
 
+// Ensure Pool implements the service layer's WorkerService
+var _ service.WorkerService = (*Pool)(nil)
+
 // Worker represents a single worker in the pool.
 type Worker struct {
 	id       int
 	jobQueue *JobQueue
-	svc      TransactionService
-	stopped  chan struct{}
+	metrics  QueueMetrics
 }
 
 // Stats represents worker pool statistics.
@@ -56,6 +71,15 @@ func NewPool(jobQueue *JobQueue, transactionSvc TransactionService) *Pool {
 	}
 }
 
+// SetMetrics sets the metrics collector used to record queue wait time and depth.
+// Must be called before Start.
+func (wp *Pool) SetMetrics(metrics QueueMetrics) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.metrics = metrics
+}
+
 // Start starts the specified number of workers.
 func (wp *Pool) Start(numWorkers int) {
 	wp.mu.Lock()
@@ -69,8 +93,7 @@ func (wp *Pool) Start(numWorkers int) {
 		worker := &Worker{
 			id:       i + 1,
 			jobQueue: wp.jobQueue,
-			svc:      wp.transactionSvc,
-			stopped:  make(chan struct{}),
+			metrics:  wp.metrics,
 		}
 
 		wp.workers = append(wp.workers, worker)
@@ -116,27 +139,68 @@ func (wp *Pool) Stop(ctx context.Context) error {
 	return nil
 }
 
-// SubmitJob submits a job to the worker pool.
-func (wp *Pool) SubmitJob(job *TransactionJob) {
+// SubmitJob submits a job of any kind to the worker pool.
+func (wp *Pool) SubmitJob(job Runnable) {
+	job.Enqueued(time.Now())
+
 	select {
 	case wp.jobQueue.SubmitChan <- job:
 		utils.Debug("job submitted successfully",
-			slog.String("job_id", job.ID.String()),
-			slog.String("type", string(job.Type)),
+			slog.String("job_id", job.JobID().String()),
+			slog.String("type", string(job.JobType())),
 		)
+		if wp.metrics != nil {
+			wp.metrics.SetQueueDepth(len(wp.jobQueue.SubmitChan))
+		}
 	default:
 		// Queue is full, return error via response channel
-		result := job.ToResult(nil, fmt.Errorf("job queue is full"))
-		select {
-		case job.ResponseChan <- result:
-		default:
+		if !job.Reject(fmt.Errorf("job queue is full")) {
 			utils.Warn("could not send job result - response channel full",
-				slog.String("job_id", job.ID.String()),
+				slog.String("job_id", job.JobID().String()),
 			)
 		}
 	}
 }
 
+// Submit submits a job to the pool and waits for its result.
+func Submit[T, R any](ctx context.Context, wp *Pool, job *Job[T, R]) (R, error) {
+	wp.SubmitJob(job)
+
+	select {
+	case result := <-job.ResponseChan:
+		return result.Value, result.Error
+	case <-ctx.Done():
+		var zero R
+		return zero, ctx.Err()
+	}
+}
+
+// ProcessCredit queues a credit to userID and waits for its result.
+// It implements the service layer's WorkerService, as do ProcessDebit and ProcessTransfer.
+func (wp *Pool) ProcessCredit(ctx context.Context, userID uuid.UUID, req *domain.CreditRequest) (*domain.TransactionResponse, error) {
+	return Submit(ctx, wp, NewJob(ctx, JobTypeCredit, CreditJob{UserID: userID, Request: req}, wp.processCredit))
+}
+
+// ProcessDebit queues a debit from userID and waits for its result.
+func (wp *Pool) ProcessDebit(ctx context.Context, userID uuid.UUID, req *domain.DebitRequest) (*domain.TransactionResponse, error) {
+	return Submit(ctx, wp, NewJob(ctx, JobTypeDebit, DebitJob{UserID: userID, Request: req}, wp.processDebit))
+}
+
+// ProcessTransfer queues a transfer from fromUserID and waits for its result.
+func (wp *Pool) ProcessTransfer(ctx context.Context, fromUserID uuid.UUID, req *domain.TransferRequest) (*domain.TransactionResponse, error) {
+	return Submit(ctx, wp, NewJob(ctx, JobTypeTransfer, TransferJob{FromUserID: fromUserID, Request: req}, wp.processTransfer))
+}
+
+// ProcessRollback queues a rollback of a transaction on behalf of requestingUserID and waits for its result.
+func (wp *Pool) ProcessRollback(ctx context.Context, transactionID uuid.UUID, requestingUserID uuid.UUID) (*domain.TransactionResponse, error) {
+	return Submit(ctx, wp, NewJob(ctx, JobTypeRollback, RollbackJob{TransactionID: transactionID, RequestingUserID: requestingUserID}, wp.processRollback))
+}
+
+// GetQueueDepth returns the number of jobs waiting to be processed.
+func (wp *Pool) GetQueueDepth() int {
+	return len(wp.jobQueue.SubmitChan)
+}
+
 // GetStats returns current worker pool statistics.
 func (wp *Pool) GetStats() Stats {
 	wp.mu.RLock()
@@ -159,6 +223,17 @@ func (wp *Pool) IsStopped() bool {
 	}
 }
 
+// CheckHealth reports an error if the worker pool is stopped or has no workers.
+func (wp *Pool) CheckHealth(_ context.Context) error {
+	if wp.IsStopped() {
+		return fmt.Errorf("worker pool is stopped")
+	}
+	if stats := wp.GetStats(); stats.ActiveWorkers == 0 {
+		return fmt.Errorf("worker pool has no active workers")
+	}
+	return nil
+}
+
 // start begins processing jobs for a worker.
 func (w *Worker) start(wg *sync.WaitGroup, jobsProcessed *int64) {
 	defer wg.Done()
@@ -172,7 +247,7 @@ func (w *Worker) start(wg *sync.WaitGroup, jobsProcessed *int64) {
 		case job := <-w.jobQueue.SubmitChan:
 			w.processJob(job, jobsProcessed)
 
-		case <-w.stopped:
+		case <-w.jobQueue.QuitChan:
 			utils.Info("worker stopped",
 				slog.Int("worker_id", w.id),
 			)
@@ -181,137 +256,90 @@ func (w *Worker) start(wg *sync.WaitGroup, jobsProcessed *int64) {
 	}
 }
 
-// processJob processes a single transaction job.
-func (w *Worker) processJob(job *TransactionJob, jobsProcessed *int64) {
+// processJob processes a single job.
+func (w *Worker) processJob(job Runnable, jobsProcessed *int64) {
 	startTime := time.Now()
 
+	if w.metrics != nil {
+		if enqueuedAt := job.EnqueuedTime(); !enqueuedAt.IsZero() {
+			w.metrics.ObserveQueueWait(string(job.JobType()), startTime.Sub(enqueuedAt))
+		}
+		w.metrics.SetQueueDepth(len(w.jobQueue.SubmitChan))
+	}
+
 	utils.Debug("processing job",
-		slog.String("job_id", job.ID.String()),
-		slog.String("type", string(job.Type)),
+		slog.String("job_id", job.JobID().String()),
+		slog.String("type", string(job.JobType())),
 		slog.Int("worker_id", w.id),
 	)
 
-	var result *TransactionJobResult
-	var err error
-
-	// Process the job based on its type
-	switch job.Type {
-	case JobTypeCredit:
-		result, err = w.processCredit(job)
-	case JobTypeDebit:
-		result, err = w.processDebit(job)
-	case JobTypeTransfer:
-		result, err = w.processTransfer(job)
-	case JobTypeRollback:
-		result, err = w.processRollback(job)
-	default:
-		err = fmt.Errorf("unknown job type: %s", job.Type)
-		result = job.ToResult(nil, err)
-	}
+	// Process the job as a child span of the request that submitted it
+	ctx := job.processingContext()
+	ctx, span := utils.GetTracer("worker-pool").Start(ctx, "worker.process_job",
+		trace.WithAttributes(
+			attribute.String("job.id", job.JobID().String()),
+			attribute.String("job.type", string(job.JobType())),
+			attribute.String("correlation_id", utils.CorrelationIDFromContext(ctx)),
+			attribute.Int("worker.id", w.id),
+		),
+	)
 
+	err := job.Run(ctx)
 	if err != nil {
 		utils.Error("job processing failed",
-			slog.String("job_id", job.ID.String()),
-			slog.String("type", string(job.Type)),
+			slog.String("job_id", job.JobID().String()),
+			slog.String("type", string(job.JobType())),
 			slog.String("error", err.Error()),
 			slog.Duration("duration", time.Since(startTime)),
 		)
-		result = job.ToResult(nil, err)
 	} else {
 		utils.Info("job processed successfully",
-			slog.String("job_id", job.ID.String()),
-			slog.String("type", string(job.Type)),
+			slog.String("job_id", job.JobID().String()),
+			slog.String("type", string(job.JobType())),
 			slog.Duration("duration", time.Since(startTime)),
 		)
 	}
 
+	utils.EndSpan(span, err)
+
 	// Send result back via response channel
-	select {
-	case job.ResponseChan <- result:
+	if job.Deliver(5 * time.Second) {
 		atomic.AddInt64(jobsProcessed, 1)
-	case <-time.After(5 * time.Second):
+	} else {
 		utils.Warn("timeout sending job result",
-			slog.String("job_id", job.ID.String()),
+			slog.String("job_id", job.JobID().String()),
 		)
 	}
 }
 
 // processCredit processes a credit job.
-func (w *Worker) processCredit(job *TransactionJob) (*TransactionJobResult, error) {
-	if job.CreditRequest == nil {
-		return job.ToResult(nil, fmt.Errorf("invalid credit job: missing credit_request")), nil
-	}
-
-	transaction, err := w.svc.CreditSync(job.Ctx, job.UserID.String(), job.CreditRequest)
-	if err != nil {
-		return job.ToResult(nil, err), nil
+func (wp *Pool) processCredit(ctx context.Context, job CreditJob) (*domain.TransactionResponse, error) {
+	if job.Request == nil {
+		return nil, fmt.Errorf("invalid credit job: missing credit_request")
 	}
 
-	// Type assert the result
-	txResponse, ok := transaction.(*domain.TransactionResponse)
-	if !ok {
-		return job.ToResult(nil, fmt.Errorf("invalid response type from credit operation")), nil
-	}
-
-	return job.ToResult(txResponse, nil), nil
+	return wp.transactionSvc.CreditSync(ctx, job.UserID, job.Request)
 }
 
 // processDebit processes a debit job.
-func (w *Worker) processDebit(job *TransactionJob) (*TransactionJobResult, error) {
-	if job.DebitRequest == nil {
-		return job.ToResult(nil, fmt.Errorf("invalid debit job: missing debit_request")), nil
+func (wp *Pool) processDebit(ctx context.Context, job DebitJob) (*domain.TransactionResponse, error) {
+	if job.Request == nil {
+		return nil, fmt.Errorf("invalid debit job: missing debit_request")
 	}
 
-	transaction, err := w.svc.DebitSync(job.Ctx, job.UserID.String(), job.DebitRequest)
-	if err != nil {
-		return job.ToResult(nil, err), nil
-	}
-
-	// Type assert the result
-	txResponse, ok := transaction.(*domain.TransactionResponse)
-	if !ok {
-		return job.ToResult(nil, fmt.Errorf("invalid response type from debit operation")), nil
-	}
-
-	return job.ToResult(txResponse, nil), nil
+	return wp.transactionSvc.DebitSync(ctx, job.UserID, job.Request)
 }
 
 // processTransfer processes a transfer job.
-func (w *Worker) processTransfer(job *TransactionJob) (*TransactionJobResult, error) {
-	if job.FromUserID == nil || job.TransferRequest == nil {
-		return job.ToResult(nil, fmt.Errorf("invalid transfer job: missing from_user_id or transfer_request")), nil
-	}
-
-	transaction, err := w.svc.TransferSync(job.Ctx, job.FromUserID.String(), job.TransferRequest)
-	if err != nil {
-		return job.ToResult(nil, err), nil
-	}
-
-	// Type assert the result
-	txResponse, ok := transaction.(*domain.TransactionResponse)
-	if !ok {
-		return job.ToResult(nil, fmt.Errorf("invalid response type from transfer operation")), nil
+func (wp *Pool) processTransfer(ctx context.Context, job TransferJob) (*domain.TransactionResponse, error) {
+	if job.Request == nil {
+		return nil, fmt.Errorf("invalid transfer job: missing transfer_request")
 	}
 
-	return job.ToResult(txResponse, nil), nil
+	return wp.transactionSvc.TransferSync(ctx, job.FromUserID, job.Request)
 }
 
 // processRollback processes a rollback job.
-func (w *Worker) processRollback(job *TransactionJob) (*TransactionJobResult, error) {
-	if job.OriginalTxID == nil {
-		return job.ToResult(nil, fmt.Errorf("invalid rollback job: missing original_tx_id")), nil
-	}
-
-	transaction, err := w.svc.RollbackSync(job.Ctx, job.OriginalTxID.String(), job.UserID.String())
-	if err != nil {
-		return job.ToResult(nil, err), nil
-	}
-
-	// Type assert the result
-	txResponse, ok := transaction.(*domain.TransactionResponse)
-	if !ok {
-		return job.ToResult(nil, fmt.Errorf("invalid response type from rollback operation")), nil
-	}
-
-	return job.ToResult(txResponse, nil), nil
+func (wp *Pool) processRollback(ctx context.Context, job RollbackJob) (*domain.TransactionResponse, error) {
+	return wp.transactionSvc.RollbackSync(ctx, job.TransactionID, job.RequestingUserID)
 }
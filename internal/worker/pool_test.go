@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubmit(t *testing.T) {
+	pool := NewPool(NewJobQueue(4), nil)
+	pool.Start(2)
+	defer func() { _ = pool.Stop(context.Background()) }()
+
+	errDeclined := errors.New("declined")
+
+	tests := []struct {
+		name    string
+		handler Handler[int, string]
+		cancel  bool
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "result",
+			handler: func(_ context.Context, n int) (string, error) { return "done", nil },
+			want:    "done",
+		},
+		{
+			name:    "error",
+			handler: func(_ context.Context, n int) (string, error) { return "partial", errDeclined },
+			wantErr: errDeclined,
+		},
+		{
+			name: "cancelled while processing",
+			handler: func(ctx context.Context, n int) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			},
+			cancel:  true,
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				time.AfterFunc(20*time.Millisecond, cancel)
+			}
+
+			got, err := Submit(ctx, pool, NewJob(ctx, JobTypeCredit, 1, tt.handler))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubmitQueueFull(t *testing.T) {
+	// Without workers or buffer nothing takes the job
+	pool := NewPool(NewJobQueue(0), nil)
+
+	_, err := Submit(context.Background(), pool, NewJob(context.Background(), JobTypeCredit, 1,
+		func(_ context.Context, n int) (int, error) { return n, nil }))
+	if err == nil || err.Error() != "job queue is full" {
+		t.Fatalf("expected job queue is full, got %v", err)
+	}
+}
+
+func TestSubmitCancelledWhileQueued(t *testing.T) {
+	// Without workers the job stays queued until the submitter gives up
+	pool := NewPool(NewJobQueue(1), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := Submit(ctx, pool, NewJob(ctx, JobTypeCredit, 1,
+		func(_ context.Context, n int) (int, error) { return n, nil }))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestPoolStop(t *testing.T) {
+	pool := NewPool(NewJobQueue(4), nil)
+	pool.Start(2)
+
+	if err := pool.CheckHealth(context.Background()); err != nil {
+		t.Fatalf("running pool: expected healthy, got %v", err)
+	}
+	if got, err := Submit(context.Background(), pool, NewJob(context.Background(), JobTypeCredit, 2,
+		func(_ context.Context, n int) (int, error) { return n * 2, nil })); err != nil || got != 4 {
+		t.Fatalf("expected 4, got %d, %v", got, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := pool.Stop(ctx); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	if !pool.IsStopped() {
+		t.Error("expected pool to be stopped")
+	}
+	if err := pool.CheckHealth(context.Background()); err == nil {
+		t.Error("stopped pool: expected unhealthy")
+	}
+	if stats := pool.GetStats(); stats.JobsProcessed != 1 {
+		t.Errorf("expected 1 job processed, got %d", stats.JobsProcessed)
+	}
+}
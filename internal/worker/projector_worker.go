@@ -3,7 +3,9 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/sefa-b/go-banking-sim/internal/service"
@@ -13,12 +15,28 @@ import (
 // ProjectorWorker processes events and updates read models through projectors
 type ProjectorWorker struct {
 	projectorSvc service.ProjectorServiceInterface
-	dbPool       interface{} //nolint:unused // Reserved for future database locking functionality
+	elector      LeaderElector // Optional; without one this instance always leads
+	metrics      LeaderMetrics // Optional leadership metric
+	isLeader     atomic.Bool
+	interval     time.Duration
+	lastRunAt    atomic.Int64 // Unix nanoseconds of the last successful projection cycle
 	ticker       *time.Ticker
 	stopChan     chan struct{}
 	running      bool
 }
 
+// LeaderElector decides which instance runs projections.
+// TryAcquire is called on every cycle, so a follower takes over once the leader goes away.
+type LeaderElector interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+// LeaderMetrics defines the metric recorded for projector leadership.
+type LeaderMetrics interface {
+	SetProjectorLeader(isLeader bool)
+}
+
 // ProjectorServiceInterface defines the interface for projector services
 type ProjectorServiceInterface interface {
 	ProcessEventsSince(ctx context.Context, since time.Time) error
@@ -34,6 +52,18 @@ func NewProjectorWorker(projectorSvc service.ProjectorServiceInterface) *Project
 	}
 }
 
+// SetLeaderElector sets the leader elector used to ensure only one instance runs projections.
+// Must be called before Start.
+func (w *ProjectorWorker) SetLeaderElector(elector LeaderElector) {
+	w.elector = elector
+}
+
+// SetMetrics sets the metrics collector used to report leadership.
+// Must be called before Start.
+func (w *ProjectorWorker) SetMetrics(metrics LeaderMetrics) {
+	w.metrics = metrics
+}
+
 // Start begins the projector processing loop
 func (w *ProjectorWorker) Start(interval time.Duration) {
 	if w.running {
@@ -42,6 +72,7 @@ func (w *ProjectorWorker) Start(interval time.Duration) {
 	}
 
 	w.running = true
+	w.interval = interval
 	w.ticker = time.NewTicker(interval)
 
 	utils.Info("starting event projector worker", slog.String("interval", interval.String()))
@@ -88,48 +119,46 @@ func (w *ProjectorWorker) Stop(ctx context.Context) error {
 // processLoop runs the main processing loop for event projection
 func (w *ProjectorWorker) processLoop() {
 	defer func() {
+		w.stepDown()
 		w.running = false
 	}()
 
-	// Only the first instance should process existing events on startup
-	// Use a simple database lock to coordinate between instances
-	if w.tryAcquireLock("projector_startup_lock") {
-		utils.Info("acquired startup lock, processing all existing events")
-		if err := w.projectorSvc.ProcessAllEvents(context.Background()); err != nil {
-			utils.Error("failed to process existing events", slog.String("error", err.Error()))
-		}
-		w.releaseLock("projector_startup_lock")
-	} else {
-		utils.Info("another instance is processing startup events, skipping")
-	}
+	w.processNewEventsAsLeader()
 
 	for {
 		select {
 		case <-w.ticker.C:
-			w.processNewEventsWithLock()
+			w.processNewEventsAsLeader()
 		case <-w.stopChan:
 			return
 		}
 	}
 }
 
-// processNewEventsWithLock processes new events with locking to prevent race conditions
-func (w *ProjectorWorker) processNewEventsWithLock() {
-	lockKey := "projector_processing_lock"
+// processNewEventsAsLeader processes new events if this instance is the elected leader
+func (w *ProjectorWorker) processNewEventsAsLeader() {
+	ctx := context.Background()
 
-	if !w.tryAcquireLock(lockKey) {
-		utils.Info("another instance is processing events, skipping this cycle")
+	wasLeader := w.isLeader.Load()
+	if !w.electLeader(ctx) {
 		return
 	}
 
-	defer w.releaseLock(lockKey)
-
-	ctx := context.Background()
+	// A newly elected leader cannot know where the previous one stopped, so it catches up on everything
+	if !wasLeader {
+		utils.Info("elected projector leader, processing all existing events")
+		if err := w.projectorSvc.ProcessAllEvents(ctx); err != nil {
+			utils.Error("failed to process existing events", slog.String("error", err.Error()))
+			return
+		}
+		w.lastRunAt.Store(time.Now().UnixNano())
+		return
+	}
 
 	// Process events from the last 5 minutes to catch any missed events
 	since := time.Now().Add(-5 * time.Minute)
 
-	utils.Info("processing new events with lock", slog.String("since", since.Format(time.RFC3339)))
+	utils.Info("processing new events as leader", slog.String("since", since.Format(time.RFC3339)))
 
 	err := w.projectorSvc.ProcessEventsSince(ctx, since)
 	if err != nil {
@@ -137,22 +166,75 @@ func (w *ProjectorWorker) processNewEventsWithLock() {
 		return
 	}
 
-	utils.Info("completed processing new events with lock")
+	w.lastRunAt.Store(time.Now().UnixNano())
+	utils.Info("completed processing new events as leader")
 }
 
-// tryAcquireLock attempts to acquire a database lock
-func (w *ProjectorWorker) tryAcquireLock(lockKey string) bool {
-	// For now, implement a simple instance-based locking
-	// In production, you'd use Redis, database locks, or leader election
+// electLeader attempts to become (or remain) the projector leader and reports whether this instance leads
+func (w *ProjectorWorker) electLeader(ctx context.Context) bool {
+	isLeader := true
+	if w.elector != nil {
+		acquired, err := w.elector.TryAcquire(ctx)
+		if err != nil {
+			utils.Error("projector leader election failed", slog.String("error", err.Error()))
+		}
+		isLeader = acquired
+	}
 
-	// Simple approach: only allow instance 1 to process events
-	// This is a temporary solution for the MVP
-	utils.Info("lock acquisition attempted", slog.String("lock_key", lockKey))
-	return true // Allow all for now, will implement proper locking later
+	if isLeader != w.isLeader.Load() {
+		if isLeader {
+			utils.Info("acquired projector leadership")
+		} else {
+			utils.Info("lost projector leadership")
+		}
+	} else if !isLeader {
+		utils.Debug("another instance is the projector leader, skipping this cycle")
+	}
+
+	w.setLeader(isLeader)
+	return isLeader
+}
+
+// stepDown releases leadership so that another instance can take over immediately
+func (w *ProjectorWorker) stepDown() {
+	if w.elector != nil && w.isLeader.Load() {
+		if err := w.elector.Release(context.Background()); err != nil {
+			utils.Error("failed to release projector leadership", slog.String("error", err.Error()))
+		}
+	}
+	w.setLeader(false)
+}
+
+// setLeader records the current leadership state
+func (w *ProjectorWorker) setLeader(isLeader bool) {
+	w.isLeader.Store(isLeader)
+	if w.metrics != nil {
+		w.metrics.SetProjectorLeader(isLeader)
+	}
 }
 
-// releaseLock releases a database lock
-func (w *ProjectorWorker) releaseLock(lockKey string) {
-	utils.Info("lock released", slog.String("lock_key", lockKey))
-	// TODO: Implement proper lock release
+// projectorLagTolerance is how many missed processing intervals are tolerated before the projector is unhealthy
+const projectorLagTolerance = 3
+
+// CheckHealth reports an error if this instance leads projections but has not completed a cycle recently.
+// Followers are healthy since another instance is responsible for projections.
+func (w *ProjectorWorker) CheckHealth(_ context.Context) error {
+	if !w.running {
+		return fmt.Errorf("projector worker is not running")
+	}
+	if !w.isLeader.Load() {
+		return nil
+	}
+
+	lastRunAt := w.lastRunAt.Load()
+	if lastRunAt == 0 {
+		return fmt.Errorf("projector has not completed a processing cycle yet")
+	}
+
+	lag := time.Since(time.Unix(0, lastRunAt))
+	if lag > projectorLagTolerance*w.interval {
+		return fmt.Errorf("projector lag %s exceeds %s", lag.Round(time.Second), projectorLagTolerance*w.interval)
+	}
+
+	return nil
 }
@@ -0,0 +1,111 @@
+// Package worker provides a background worker that enforces request log retention.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sefa-b/go-banking-sim/internal/utils"
+)
+
+// RequestLogPurger defines the interface for deleting expired request logs.
+type RequestLogPurger interface {
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// RequestLogRetentionWorker periodically deletes recorded request/response exchanges
+// that are older than the configured retention period.
+type RequestLogRetentionWorker struct {
+	purger   RequestLogPurger
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewRequestLogRetentionWorker creates a new request log retention worker.
+func NewRequestLogRetentionWorker(purger RequestLogPurger) *RequestLogRetentionWorker {
+	return &RequestLogRetentionWorker{
+		purger:   purger,
+		stopChan: make(chan struct{}),
+		running:  false,
+	}
+}
+
+// Start purges expired request logs immediately and then on every interval.
+func (w *RequestLogRetentionWorker) Start(interval time.Duration) {
+	if w.running {
+		utils.Warn("request log retention worker is already running")
+		return
+	}
+
+	w.running = true
+	w.ticker = time.NewTicker(interval)
+
+	utils.Info("starting request log retention worker", slog.String("interval", interval.String()))
+
+	go w.processLoop()
+}
+
+// Stop gracefully stops the request log retention worker.
+func (w *RequestLogRetentionWorker) Stop(ctx context.Context) error {
+	if !w.running {
+		return nil
+	}
+
+	utils.Info("stopping request log retention worker")
+
+	// Signal stop
+	close(w.stopChan)
+
+	// Stop ticker
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+
+	// Wait for graceful shutdown or context timeout
+	done := make(chan struct{})
+	go func() {
+		// Wait for the processing loop to finish
+		for w.running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		utils.Info("request log retention worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		utils.Warn("request log retention worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// processLoop purges on boot and then on every tick.
+func (w *RequestLogRetentionWorker) processLoop() {
+	defer func() {
+		w.running = false
+	}()
+
+	w.purge()
+
+	for {
+		select {
+		case <-w.ticker.C:
+			w.purge()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// purge deletes expired request logs.
+func (w *RequestLogRetentionWorker) purge() {
+	ctx := context.Background()
+
+	if _, err := w.purger.PurgeExpired(ctx); err != nil {
+		utils.Error("failed to purge expired request logs", slog.String("error", err.Error()))
+	}
+}
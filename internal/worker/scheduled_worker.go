@@ -12,6 +12,7 @@ import (
 // ScheduledTransactionProcessor defines the interface for processing scheduled transactions.
 type ScheduledTransactionProcessor interface {
 	ProcessDueTransactions(ctx context.Context) error
+	CheckUpcomingFunding(ctx context.Context) error
 }
 
 // ScheduledWorker processes scheduled transactions that are due for execution.
@@ -92,6 +93,7 @@ func (w *ScheduledWorker) processLoop() {
 		select {
 		case <-w.ticker.C:
 			w.processDueTransactions()
+			w.checkUpcomingFunding()
 		case <-w.stopChan:
 			return
 		}
@@ -100,15 +102,28 @@ func (w *ScheduledWorker) processLoop() {
 
 // processDueTransactions processes all scheduled transactions that are due.
 func (w *ScheduledWorker) processDueTransactions() {
-	ctx := context.Background()
+	// Each cycle is a root span; executions are traced as its children
+	ctx, span := utils.GetTracer("scheduled-worker").Start(context.Background(), "scheduler.process_due")
 
-	utils.Info("checking for due scheduled transactions")
+	utils.InfoContext(ctx, "checking for due scheduled transactions")
 
 	err := w.scheduledSvc.ProcessDueTransactions(ctx)
+	utils.EndSpan(span, err)
 	if err != nil {
-		utils.Error("failed to process due transactions", slog.String("error", err.Error()))
+		utils.ErrorContext(ctx, "failed to process due transactions", slog.String("error", err.Error()))
 		return
 	}
 
-	utils.Info("completed processing due scheduled transactions")
+	utils.InfoContext(ctx, "completed processing due scheduled transactions")
+}
+
+// checkUpcomingFunding warns owners of upcoming scheduled transactions their balance does not cover.
+func (w *ScheduledWorker) checkUpcomingFunding() {
+	ctx, span := utils.GetTracer("scheduled-worker").Start(context.Background(), "scheduler.check_funding")
+
+	err := w.scheduledSvc.CheckUpcomingFunding(ctx)
+	utils.EndSpan(span, err)
+	if err != nil {
+		utils.ErrorContext(ctx, "failed to check upcoming scheduled transaction funding", slog.String("error", err.Error()))
+	}
 }
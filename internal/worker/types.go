@@ -3,84 +3,204 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sefa-b/go-banking-sim/internal/domain"
+	"github.com/sefa-b/go-banking-sim/internal/utils"
 )
 
-// TransactionJobType defines the type of transaction job.
-type TransactionJobType string
+// JobType names a kind of job, for logs, spans and queue metrics.
+type JobType string
 
 const (
 	// JobTypeCredit represents credit transaction job type
-	JobTypeCredit TransactionJobType = "credit"
+	JobTypeCredit JobType = "credit"
 	// JobTypeDebit represents debit transaction job type
-	JobTypeDebit TransactionJobType = "debit"
+	JobTypeDebit JobType = "debit"
 	// JobTypeTransfer represents transfer transaction job type
-	JobTypeTransfer TransactionJobType = "transfer"
+	JobTypeTransfer JobType = "transfer"
 	// JobTypeRollback represents rollback transaction job type
-	JobTypeRollback TransactionJobType = "rollback"
+	JobTypeRollback JobType = "rollback"
 )
 
-// TransactionJob represents a job for asynchronous transaction processing.
-type TransactionJob struct {
-	ID              uuid.UUID                  `json:"id"`
-	Type            TransactionJobType         `json:"type"`
-	UserID          uuid.UUID                  `json:"user_id"`
-	FromUserID      *uuid.UUID                 `json:"from_user_id,omitempty"`
-	ToUserID        *uuid.UUID                 `json:"to_user_id,omitempty"`
-	Amount          float64                    `json:"amount"`
-	OriginalTxID    *uuid.UUID                 `json:"original_tx_id,omitempty"` // For rollbacks
-	CreditRequest   *domain.CreditRequest      `json:"credit_request,omitempty"`
-	DebitRequest    *domain.DebitRequest       `json:"debit_request,omitempty"`
-	TransferRequest *domain.TransferRequest    `json:"transfer_request,omitempty"`
-	ResponseChan    chan *TransactionJobResult `json:"-"` // Channel for job results
-	Ctx             context.Context            `json:"-"` // Context for cancellation
-}
-
-// TransactionJobResult represents the result of a transaction job.
-type TransactionJobResult struct {
-	JobID       uuid.UUID                   `json:"job_id"`
-	Transaction *domain.TransactionResponse `json:"transaction,omitempty"`
-	Error       error                       `json:"error,omitempty"`
-	Success     bool                        `json:"success"`
+// Handler processes the payload of a job and returns its result.
+type Handler[T, R any] func(ctx context.Context, payload T) (R, error)
+
+// Job is a unit of work for the worker pool: a typed payload, the handler that processes it
+// and the channel its typed result is delivered on.
+type Job[T, R any] struct {
+	ID            uuid.UUID
+	Type          JobType
+	Payload       T
+	EnqueuedAt    time.Time
+	CorrelationID string
+	TraceContext  map[string]string  // W3C trace context of the submitting request
+	ResponseChan  chan *JobResult[R] // Channel for job results
+	Ctx           context.Context    // Context for cancellation
+
+	handler Handler[T, R]
+	pending *JobResult[R] // Result of Run until it is delivered
+}
+
+// JobResult represents the result of a job.
+type JobResult[R any] struct {
+	JobID   uuid.UUID
+	Value   R
+	Error   error
+	Success bool
+}
+
+// Runnable is a queued job whatever its payload and result types, so one queue carries every kind.
+type Runnable interface {
+	// JobID returns the job's ID.
+	JobID() uuid.UUID
+	// JobType returns the kind of job.
+	JobType() JobType
+	// Enqueued records when the job was queued.
+	Enqueued(at time.Time)
+	// EnqueuedTime returns when the job was queued.
+	EnqueuedTime() time.Time
+	// Run processes the job in ctx and returns its result's error.
+	Run(ctx context.Context) error
+	// Deliver sends the job's result to its submitter, giving up after timeout.
+	Deliver(timeout time.Duration) bool
+	// Reject delivers err without processing the job.
+	Reject(err error) bool
+
+	processingContext() context.Context
 }
 
 // JobQueue represents the channels for job submission and control.
 type JobQueue struct {
-	SubmitChan chan *TransactionJob // Channel for submitting jobs
-	QuitChan   chan struct{}        // Channel for graceful shutdown
+	SubmitChan chan Runnable // Channel for submitting jobs
+	QuitChan   chan struct{} // Channel for graceful shutdown
 }
 
 // NewJobQueue creates a new job queue with the specified buffer size.
 func NewJobQueue(bufferSize int) *JobQueue {
 	return &JobQueue{
-		SubmitChan: make(chan *TransactionJob, bufferSize),
+		SubmitChan: make(chan Runnable, bufferSize),
 		QuitChan:   make(chan struct{}),
 	}
 }
 
-// NewTransactionJob creates a new transaction job with a unique ID and response channel.
-func NewTransactionJob(ctx context.Context, jobType TransactionJobType) *TransactionJob {
-	return &TransactionJob{
-		ID:           uuid.New(),
-		Type:         jobType,
-		ResponseChan: make(chan *TransactionJobResult, 1),
-		Ctx:          ctx,
+// NewJob creates a job with a unique ID and response channel that handler processes.
+// The trace context and correlation ID of ctx are captured so the job is processed as part of the same trace.
+func NewJob[T, R any](ctx context.Context, jobType JobType, payload T, handler Handler[T, R]) *Job[T, R] {
+	return &Job[T, R]{
+		ID:            uuid.New(),
+		Type:          jobType,
+		Payload:       payload,
+		CorrelationID: utils.CorrelationIDFromContext(ctx),
+		TraceContext:  utils.InjectTraceContext(ctx),
+		ResponseChan:  make(chan *JobResult[R], 1),
+		Ctx:           ctx,
+		handler:       handler,
+	}
+}
+
+// JobID returns the job's ID.
+func (j *Job[T, R]) JobID() uuid.UUID {
+	return j.ID
+}
+
+// JobType returns the kind of job.
+func (j *Job[T, R]) JobType() JobType {
+	return j.Type
+}
+
+// Enqueued records when the job was queued.
+func (j *Job[T, R]) Enqueued(at time.Time) {
+	j.EnqueuedAt = at
+}
+
+// EnqueuedTime returns when the job was queued.
+func (j *Job[T, R]) EnqueuedTime() time.Time {
+	return j.EnqueuedAt
+}
+
+// processingContext returns the context a worker processes the job in, restoring the
+// submitting request's trace context and correlation ID.
+func (j *Job[T, R]) processingContext() context.Context {
+	ctx := j.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = utils.ExtractTraceContext(ctx, j.TraceContext)
+	if j.CorrelationID != "" {
+		ctx = utils.WithCorrelationID(ctx, j.CorrelationID)
+	}
+	return ctx
+}
+
+// Ensure every Job can be queued
+var _ Runnable = (*Job[struct{}, struct{}])(nil)
+
+// Run processes the job's payload with its handler and keeps the result for Deliver.
+func (j *Job[T, R]) Run(ctx context.Context) error {
+	value, err := j.handler(ctx, j.Payload)
+	j.pending = j.ToResult(value, err)
+	return err
+}
+
+// Deliver sends the result of Run to the job's response channel, giving up after timeout.
+func (j *Job[T, R]) Deliver(timeout time.Duration) bool {
+	select {
+	case j.ResponseChan <- j.pending:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Reject sends err to the job's response channel without processing the job.
+func (j *Job[T, R]) Reject(err error) bool {
+	var zero R
+	select {
+	case j.ResponseChan <- j.ToResult(zero, err):
+		return true
+	default:
+		return false
 	}
 }
 
 // ToResult creates a job result from the current job state.
-func (j *TransactionJob) ToResult(transaction *domain.TransactionResponse, err error) *TransactionJobResult {
-	result := &TransactionJobResult{
+func (j *Job[T, R]) ToResult(value R, err error) *JobResult[R] {
+	result := &JobResult[R]{
 		JobID:   j.ID,
 		Success: err == nil,
 		Error:   err,
 	}
 
-	if transaction != nil {
-		result.Transaction = transaction
+	if err == nil {
+		result.Value = value
 	}
 
 	return result
 }
+
+// CreditJob is the payload of a credit job.
+type CreditJob struct {
+	UserID  uuid.UUID
+	Request *domain.CreditRequest
+}
+
+// DebitJob is the payload of a debit job.
+type DebitJob struct {
+	UserID  uuid.UUID
+	Request *domain.DebitRequest
+}
+
+// TransferJob is the payload of a transfer job.
+type TransferJob struct {
+	FromUserID uuid.UUID
+	Request    *domain.TransferRequest
+}
+
+// RollbackJob is the payload of a rollback job.
+type RollbackJob struct {
+	TransactionID    uuid.UUID
+	RequestingUserID uuid.UUID
+}
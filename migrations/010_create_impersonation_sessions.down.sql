@@ -0,0 +1,5 @@
+-- Drop impersonation sessions table
+DROP INDEX IF EXISTS idx_impersonation_sessions_target_user_id;
+DROP INDEX IF EXISTS idx_impersonation_sessions_admin_id;
+DROP INDEX IF EXISTS idx_impersonation_sessions_expires_at;
+DROP TABLE IF EXISTS impersonation_sessions;
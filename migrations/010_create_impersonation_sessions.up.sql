@@ -0,0 +1,16 @@
+-- Create impersonation_sessions table to track admin impersonation tokens
+CREATE TABLE impersonation_sessions (
+    id UUID PRIMARY KEY, -- Matches the jti claim of the impersonation token
+    admin_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Index for listing active sessions
+CREATE INDEX idx_impersonation_sessions_expires_at ON impersonation_sessions(expires_at);
+
+-- Indexes for lookups by admin and target user
+CREATE INDEX idx_impersonation_sessions_admin_id ON impersonation_sessions(admin_id);
+CREATE INDEX idx_impersonation_sessions_target_user_id ON impersonation_sessions(target_user_id);
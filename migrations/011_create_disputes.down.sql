@@ -0,0 +1,9 @@
+-- Drop dispute tables
+DROP INDEX IF EXISTS idx_dispute_comments_dispute_id;
+DROP TABLE IF EXISTS dispute_comments;
+
+DROP INDEX IF EXISTS idx_disputes_created_at;
+DROP INDEX IF EXISTS idx_disputes_status;
+DROP INDEX IF EXISTS idx_disputes_user_id;
+DROP INDEX IF EXISTS idx_disputes_transaction_unresolved;
+DROP TABLE IF EXISTS disputes;
@@ -0,0 +1,35 @@
+-- Create disputes table for the transaction disputes workflow
+CREATE TABLE disputes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'under_review', 'refunded', 'rejected')),
+    resolution TEXT,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    refund_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Only one unresolved dispute per transaction
+CREATE UNIQUE INDEX idx_disputes_transaction_unresolved ON disputes(transaction_id)
+    WHERE status IN ('open', 'under_review');
+
+-- Indexes for listing
+CREATE INDEX idx_disputes_user_id ON disputes(user_id);
+CREATE INDEX idx_disputes_status ON disputes(status);
+CREATE INDEX idx_disputes_created_at ON disputes(created_at);
+
+-- Create dispute_comments table for the review conversation
+CREATE TABLE dispute_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dispute_id UUID NOT NULL REFERENCES disputes(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_dispute_comments_dispute_id ON dispute_comments(dispute_id, created_at);
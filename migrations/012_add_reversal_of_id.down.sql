@@ -0,0 +1,3 @@
+-- Remove rollback linkage from transactions table
+DROP INDEX IF EXISTS idx_transactions_reversal_of_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reversal_of_id;
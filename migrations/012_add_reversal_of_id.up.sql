@@ -0,0 +1,6 @@
+-- Link rollback transactions to the transaction they reverse
+ALTER TABLE transactions ADD COLUMN reversal_of_id UUID REFERENCES transactions(id) ON DELETE SET NULL;
+
+-- Prevent double rollbacks: at most one non-failed reversal per original transaction
+CREATE UNIQUE INDEX idx_transactions_reversal_of_id ON transactions(reversal_of_id)
+    WHERE reversal_of_id IS NOT NULL AND status != 'failed';
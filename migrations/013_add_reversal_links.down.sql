@@ -0,0 +1,5 @@
+-- Remove reversed_by link and restore the original reversal column name
+ALTER TABLE transactions DROP COLUMN IF EXISTS reversed_by_transaction_id;
+
+ALTER INDEX IF EXISTS idx_transactions_reversal_of_transaction_id RENAME TO idx_transactions_reversal_of_id;
+ALTER TABLE transactions RENAME COLUMN reversal_of_transaction_id TO reversal_of_id;
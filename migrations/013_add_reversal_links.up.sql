@@ -0,0 +1,11 @@
+-- Link original and rollback transactions in both directions
+ALTER TABLE transactions RENAME COLUMN reversal_of_id TO reversal_of_transaction_id;
+ALTER INDEX idx_transactions_reversal_of_id RENAME TO idx_transactions_reversal_of_transaction_id;
+
+ALTER TABLE transactions ADD COLUMN reversed_by_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL;
+
+-- Backfill reversed_by_transaction_id from existing completed rollbacks
+UPDATE transactions o
+SET reversed_by_transaction_id = r.id
+FROM transactions r
+WHERE r.reversal_of_transaction_id = o.id AND r.status = 'success';
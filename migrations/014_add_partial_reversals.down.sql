@@ -0,0 +1,7 @@
+-- Remove partial rollback support
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_transactions_reversed_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reversed_amount;
+
+DROP INDEX IF EXISTS idx_transactions_reversal_of_transaction_id;
+CREATE UNIQUE INDEX idx_transactions_reversal_of_transaction_id ON transactions(reversal_of_transaction_id)
+    WHERE reversal_of_transaction_id IS NOT NULL AND status != 'failed';
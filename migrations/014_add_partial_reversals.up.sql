@@ -0,0 +1,14 @@
+-- Allow several partial rollbacks per transaction while capping the cumulative refund.
+-- The unique index cannot stay since each partial rollback adds a reversal row; instead the
+-- CHECK below plus the conditional reversed_amount UPDATE, run in the rollback's own
+-- database transaction before any balance moves, stop concurrent rollbacks over-refunding.
+DROP INDEX IF EXISTS idx_transactions_reversal_of_transaction_id;
+CREATE INDEX idx_transactions_reversal_of_transaction_id ON transactions(reversal_of_transaction_id)
+    WHERE reversal_of_transaction_id IS NOT NULL;
+
+ALTER TABLE transactions ADD COLUMN reversed_amount NUMERIC(18,2) NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_reversed_amount
+    CHECK (reversed_amount >= 0 AND reversed_amount <= amount);
+
+-- Backfill fully reversed transactions
+UPDATE transactions SET reversed_amount = amount WHERE reversed_by_transaction_id IS NOT NULL;
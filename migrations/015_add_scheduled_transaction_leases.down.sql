@@ -0,0 +1,5 @@
+-- Remove scheduled transaction leases
+DROP INDEX IF EXISTS idx_scheduled_transactions_locked_until;
+
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS locked_by;
@@ -0,0 +1,6 @@
+-- Per-item leases so that only one server instance executes a due scheduled transaction
+ALTER TABLE scheduled_transactions ADD COLUMN locked_by TEXT;
+ALTER TABLE scheduled_transactions ADD COLUMN locked_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_scheduled_transactions_locked_until ON scheduled_transactions(locked_until)
+    WHERE locked_until IS NOT NULL;
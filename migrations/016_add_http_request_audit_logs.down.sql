@@ -0,0 +1,6 @@
+-- Remove recorded HTTP exchanges and restore the original entity types
+DELETE FROM audit_logs WHERE entity_type = 'http_request';
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance'));
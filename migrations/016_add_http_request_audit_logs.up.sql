@@ -0,0 +1,4 @@
+-- Allow recorded HTTP request/response exchanges in the audit log
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request'));
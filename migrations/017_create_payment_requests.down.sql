@@ -0,0 +1,4 @@
+-- Drop payment requests table
+DROP INDEX IF EXISTS idx_payment_requests_requester;
+DROP INDEX IF EXISTS idx_payment_requests_payer;
+DROP TABLE IF EXISTS payment_requests;
@@ -0,0 +1,20 @@
+-- Create payment_requests table for the request-to-pay workflow
+CREATE TABLE payment_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    requester_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount NUMERIC(18,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    note TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'declined', 'expired')),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT chk_payment_requests_parties CHECK (requester_id <> payer_id)
+);
+
+-- Indexes for each side's listing
+CREATE INDEX idx_payment_requests_payer ON payment_requests(payer_id, status, created_at);
+CREATE INDEX idx_payment_requests_requester ON payment_requests(requester_id, status, created_at);
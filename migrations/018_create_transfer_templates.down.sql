@@ -0,0 +1,6 @@
+-- Drop transfer templates
+DROP INDEX IF EXISTS idx_scheduled_transactions_template_id;
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS template_id;
+
+DROP INDEX IF EXISTS idx_transfer_templates_user_name;
+DROP TABLE IF EXISTS transfer_templates;
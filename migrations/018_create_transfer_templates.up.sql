@@ -0,0 +1,27 @@
+-- Create transfer_templates table for saved, reusable transfers
+CREATE TABLE transfer_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    payee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    default_amount NUMERIC(18,2) NOT NULL CHECK (default_amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+
+    -- Usage statistics
+    use_count INTEGER NOT NULL DEFAULT 0,
+    total_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_transfer_templates_payee CHECK (user_id <> payee_id)
+);
+
+-- Template names are unique per user
+CREATE UNIQUE INDEX idx_transfer_templates_user_name ON transfer_templates(user_id, name);
+
+-- Scheduled transactions may be bound to a template; they keep their own copy of the
+-- transfer details, so deleting the template only unbinds them
+ALTER TABLE scheduled_transactions ADD COLUMN template_id UUID REFERENCES transfer_templates(id) ON DELETE SET NULL;
+CREATE INDEX idx_scheduled_transactions_template_id ON scheduled_transactions(template_id)
+    WHERE template_id IS NOT NULL;
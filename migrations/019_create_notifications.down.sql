@@ -0,0 +1,9 @@
+-- Drop notifications
+DROP INDEX IF EXISTS idx_notification_deliveries_due;
+DROP TABLE IF EXISTS notification_deliveries;
+
+DROP TABLE IF EXISTS notification_preferences;
+
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+DROP INDEX IF EXISTS idx_notifications_user_created;
+DROP TABLE IF EXISTS notifications;
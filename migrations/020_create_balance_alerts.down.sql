@@ -0,0 +1,2 @@
+-- Drop balance alerts
+DROP TABLE IF EXISTS balance_alerts;
@@ -0,0 +1,14 @@
+-- Create balance_alerts table holding per-currency low-balance alert thresholds
+CREATE TABLE balance_alerts (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD')),
+    threshold NUMERIC(18,2) NOT NULL CHECK (threshold > 0),
+
+    -- Hysteresis state: set when the alert fires, cleared once the balance recovers
+    triggered BOOLEAN NOT NULL DEFAULT FALSE,
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, currency)
+);
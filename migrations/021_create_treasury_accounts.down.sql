@@ -0,0 +1,10 @@
+-- Remove treasury audit entries and restore the previous entity types
+DELETE FROM audit_logs WHERE entity_type = 'treasury';
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request'));
+
+-- Drop treasury ledger and accounts
+DROP TABLE IF EXISTS treasury_entries;
+DROP TABLE IF EXISTS treasury_accounts;
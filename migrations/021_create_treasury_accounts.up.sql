@@ -0,0 +1,42 @@
+-- Create treasury_accounts table: one system account per currency that every credit is issued from
+CREATE TABLE treasury_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    currency VARCHAR(3) NOT NULL UNIQUE CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD')),
+    balance NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    total_minted NUMERIC(18,2) NOT NULL DEFAULT 0,
+    total_burned NUMERIC(18,2) NOT NULL DEFAULT 0,
+
+    -- Credit caps; NULL means uncapped
+    max_credit_amount NUMERIC(18,2) CHECK (max_credit_amount > 0),
+    daily_credit_cap NUMERIC(18,2) CHECK (daily_credit_cap > 0),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create treasury_entries table: the append-only ledger of every treasury movement
+CREATE TABLE treasury_entries (
+    id UUID PRIMARY KEY,
+    currency VARCHAR(3) NOT NULL REFERENCES treasury_accounts(currency),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('mint', 'burn', 'issue', 'redeem')),
+    amount NUMERIC(18,2) NOT NULL CHECK (amount > 0),
+    balance_after NUMERIC(18,2) NOT NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_treasury_entries_currency_created_at ON treasury_entries(currency, created_at DESC);
+CREATE INDEX idx_treasury_entries_kind_created_at ON treasury_entries(currency, kind, created_at);
+
+-- Open a treasury per currency. Balances held before treasuries existed count as already
+-- minted and in circulation, so total_minted - total_burned = balance + user balances holds from the start.
+INSERT INTO treasury_accounts (currency, total_minted)
+SELECT c.currency, COALESCE((SELECT SUM(b.amount) FROM balances b WHERE b.currency = c.currency), 0)
+FROM (VALUES ('USD'), ('EUR'), ('GBP'), ('JPY'), ('CAD'), ('AUD')) AS c(currency);
+
+-- Allow treasury operations in the audit log
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury'));
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_users_account_number ON users;
+DROP FUNCTION IF EXISTS set_user_account_number();
+DROP INDEX IF EXISTS idx_users_account_number;
+ALTER TABLE users DROP COLUMN IF EXISTS account_number;
+DROP FUNCTION IF EXISTS account_number_for(UUID);
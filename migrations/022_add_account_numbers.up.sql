@@ -0,0 +1,30 @@
+-- Account numbers follow the IBAN layout: XS, two ISO 13616 check digits and 16 digits derived
+-- from the user ID. domain.AccountNumberFor computes the same number in Go.
+CREATE OR REPLACE FUNCTION account_number_for(user_id UUID) RETURNS VARCHAR(20) AS $$
+DECLARE
+    bban TEXT := lpad(((('x' || substr(md5(user_id::text), 1, 15))::bit(60)::bigint) % 10000000000000000)::text, 16, '0');
+BEGIN
+    -- XS as digits is 3328; the check digits make the rearranged number 1 modulo 97
+    RETURN 'XS' || lpad((98 - mod((bban || '332800')::numeric, 97))::text, 2, '0') || bban;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE users ADD COLUMN account_number VARCHAR(20);
+UPDATE users SET account_number = account_number_for(id);
+ALTER TABLE users ALTER COLUMN account_number SET NOT NULL;
+CREATE UNIQUE INDEX idx_users_account_number ON users(account_number);
+
+-- Users inserted without an account number, e.g. by scripts/seed.sql, get the derived one
+CREATE OR REPLACE FUNCTION set_user_account_number() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.account_number IS NULL THEN
+        NEW.account_number := account_number_for(NEW.id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_users_account_number
+    BEFORE INSERT ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION set_user_account_number();
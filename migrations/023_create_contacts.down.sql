@@ -0,0 +1,5 @@
+-- Drop contacts
+DROP INDEX IF EXISTS idx_transactions_from_user_transfers;
+
+DROP INDEX IF EXISTS idx_contacts_user_contact;
+DROP TABLE IF EXISTS contacts;
@@ -0,0 +1,16 @@
+-- Create contacts table for the counterparties users save as favorites
+CREATE TABLE contacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    contact_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    nickname VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_contacts_not_self CHECK (user_id <> contact_user_id)
+);
+
+-- A counterparty is saved at most once per user
+CREATE UNIQUE INDEX idx_contacts_user_contact ON contacts(user_id, contact_user_id);
+
+-- Recent counterparties are derived from the user's outgoing transfers
+CREATE INDEX idx_transactions_from_user_transfers ON transactions(from_user_id, created_at DESC)
+    WHERE type = 'transfer' AND status = 'success';
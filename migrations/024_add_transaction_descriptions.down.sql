@@ -0,0 +1,2 @@
+-- Drop transaction descriptions
+ALTER TABLE transactions DROP COLUMN IF EXISTS description;
@@ -0,0 +1,2 @@
+-- Add an optional description to transactions, like scheduled transactions have
+ALTER TABLE transactions ADD COLUMN description TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- Drop pause and resume records from the execution history
+DELETE FROM scheduled_transaction_executions WHERE status IN ('paused', 'resumed');
+ALTER TABLE scheduled_transaction_executions DROP CONSTRAINT IF EXISTS scheduled_transaction_executions_status_check;
+ALTER TABLE scheduled_transaction_executions ADD CONSTRAINT scheduled_transaction_executions_status_check
+    CHECK (status IN ('success', 'failed', 'skipped'));
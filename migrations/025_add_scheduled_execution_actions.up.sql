@@ -0,0 +1,4 @@
+-- Record pausing and resuming in the execution history of scheduled transactions
+ALTER TABLE scheduled_transaction_executions DROP CONSTRAINT IF EXISTS scheduled_transaction_executions_status_check;
+ALTER TABLE scheduled_transaction_executions ADD CONSTRAINT scheduled_transaction_executions_status_check
+    CHECK (status IN ('success', 'failed', 'skipped', 'paused', 'resumed'));
@@ -0,0 +1,4 @@
+-- Drop scheduled execution retries and funding alerts
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS funding_alerted_for;
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS retry_at;
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS failed_attempts;
@@ -0,0 +1,4 @@
+-- Track grace retries of failed scheduled executions and upcoming insufficient funds alerts
+ALTER TABLE scheduled_transactions ADD COLUMN failed_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE scheduled_transactions ADD COLUMN retry_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE scheduled_transactions ADD COLUMN funding_alerted_for TIMESTAMP WITH TIME ZONE;
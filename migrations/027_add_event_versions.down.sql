@@ -0,0 +1,2 @@
+-- Drop event payload versions
+ALTER TABLE events DROP COLUMN IF EXISTS event_version;
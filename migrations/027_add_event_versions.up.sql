@@ -0,0 +1,2 @@
+-- Record the payload shape of each event so old shapes can be upcast on replay
+ALTER TABLE events ADD COLUMN event_version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,115 @@
+-- Return archived rows to the live tables and drop partitioning
+DROP VIEW IF EXISTS all_transactions;
+DROP VIEW IF EXISTS all_events;
+
+ALTER TABLE transactions RENAME TO transactions_partitioned;
+ALTER TABLE transactions_partitioned RENAME CONSTRAINT transactions_pkey TO transactions_partitioned_pkey;
+DROP INDEX idx_transactions_from_user;
+DROP INDEX idx_transactions_to_user;
+DROP INDEX idx_transactions_type;
+DROP INDEX idx_transactions_status;
+DROP INDEX idx_transactions_created_at;
+DROP INDEX idx_transactions_user_history;
+DROP INDEX idx_transactions_type_status;
+DROP INDEX idx_transactions_currency;
+DROP INDEX idx_transactions_reversal_of_transaction_id;
+DROP INDEX idx_transactions_from_user_transfers;
+
+CREATE TABLE transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    from_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    to_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    amount NUMERIC(18,2) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    reversal_of_transaction_id UUID,
+    reversed_by_transaction_id UUID,
+    reversed_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+    CONSTRAINT chk_transactions_type CHECK (type IN ('credit', 'debit', 'transfer')),
+    CONSTRAINT chk_transactions_status CHECK (status IN ('pending', 'success', 'failed')),
+    CONSTRAINT chk_transactions_amount_positive CHECK (amount >= 0),
+    CONSTRAINT chk_transactions_transfer_users CHECK (
+        (type = 'transfer' AND from_user_id IS NOT NULL AND to_user_id IS NOT NULL) OR
+        (type != 'transfer')
+    ),
+    CONSTRAINT chk_transactions_credit_users CHECK (
+        (type = 'credit' AND to_user_id IS NOT NULL AND from_user_id IS NULL) OR
+        (type != 'credit')
+    ),
+    CONSTRAINT chk_transactions_debit_users CHECK (
+        (type = 'debit' AND from_user_id IS NOT NULL AND to_user_id IS NULL) OR
+        (type != 'debit')
+    ),
+    CONSTRAINT chk_transactions_currency CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD')),
+    CONSTRAINT chk_transactions_reversed_amount CHECK (reversed_amount >= 0 AND reversed_amount <= amount)
+);
+
+INSERT INTO transactions (id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description)
+SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description FROM transactions_partitioned
+UNION ALL
+SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description FROM archive.transactions;
+
+CREATE INDEX idx_transactions_from_user ON transactions(from_user_id);
+CREATE INDEX idx_transactions_to_user ON transactions(to_user_id);
+CREATE INDEX idx_transactions_type ON transactions(type);
+CREATE INDEX idx_transactions_status ON transactions(status);
+CREATE INDEX idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX idx_transactions_user_history ON transactions(from_user_id, to_user_id, created_at DESC);
+CREATE INDEX idx_transactions_type_status ON transactions(type, status);
+CREATE INDEX idx_transactions_currency ON transactions(currency);
+CREATE INDEX idx_transactions_reversal_of_transaction_id ON transactions(reversal_of_transaction_id)
+    WHERE reversal_of_transaction_id IS NOT NULL;
+CREATE INDEX idx_transactions_from_user_transfers ON transactions(from_user_id, created_at DESC)
+    WHERE type = 'transfer' AND status = 'success';
+
+DROP TABLE transactions_partitioned;
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_reversal_of_id_fkey
+    FOREIGN KEY (reversal_of_transaction_id) REFERENCES transactions(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD CONSTRAINT transactions_reversed_by_transaction_id_fkey
+    FOREIGN KEY (reversed_by_transaction_id) REFERENCES transactions(id) ON DELETE SET NULL;
+ALTER TABLE treasury_entries ADD CONSTRAINT treasury_entries_transaction_id_fkey
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE SET NULL;
+ALTER TABLE payment_requests ADD CONSTRAINT payment_requests_transaction_id_fkey
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE SET NULL;
+ALTER TABLE scheduled_transaction_executions ADD CONSTRAINT scheduled_transaction_executions_transaction_id_fkey
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE SET NULL;
+ALTER TABLE disputes ADD CONSTRAINT disputes_transaction_id_fkey
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE;
+ALTER TABLE disputes ADD CONSTRAINT disputes_refund_transaction_id_fkey
+    FOREIGN KEY (refund_transaction_id) REFERENCES transactions(id) ON DELETE SET NULL;
+
+ALTER TABLE events RENAME TO events_partitioned;
+ALTER TABLE events_partitioned RENAME CONSTRAINT events_pkey TO events_partitioned_pkey;
+DROP INDEX idx_events_aggregate;
+DROP INDEX idx_events_type;
+DROP INDEX idx_events_created_at;
+DROP INDEX idx_events_version;
+
+CREATE TABLE events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    event_data JSONB NOT NULL,
+    event_metadata JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    event_version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version)
+SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version FROM events_partitioned
+UNION ALL
+SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version FROM archive.events;
+
+CREATE INDEX idx_events_aggregate ON events(aggregate_type, aggregate_id);
+CREATE INDEX idx_events_type ON events(event_type);
+CREATE INDEX idx_events_created_at ON events(created_at);
+CREATE INDEX idx_events_version ON events(aggregate_type, aggregate_id, version);
+
+DROP TABLE events_partitioned;
+DROP SCHEMA archive CASCADE;
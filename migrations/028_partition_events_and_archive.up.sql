@@ -0,0 +1,170 @@
+-- Partition the event store and transactions by month and add an archive schema for old ones.
+-- Months are UTC months. The archive maintenance worker creates upcoming partitions; the default
+-- partitions only catch rows written while none exists.
+
+-- Rebuild events as a partitioned table; its primary key must include the partition key
+ALTER TABLE events RENAME TO events_unpartitioned;
+ALTER TABLE events_unpartitioned RENAME CONSTRAINT events_pkey TO events_unpartitioned_pkey;
+DROP INDEX idx_events_aggregate;
+DROP INDEX idx_events_type;
+DROP INDEX idx_events_created_at;
+DROP INDEX idx_events_version;
+
+CREATE TABLE events (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    event_data JSONB NOT NULL,
+    event_metadata JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    event_version INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX idx_events_aggregate ON events(aggregate_type, aggregate_id);
+CREATE INDEX idx_events_type ON events(event_type);
+CREATE INDEX idx_events_created_at ON events(created_at);
+CREATE INDEX idx_events_version ON events(aggregate_type, aggregate_id, version);
+
+CREATE TABLE events_default PARTITION OF events DEFAULT;
+
+-- One partition per month from the oldest event through three months ahead
+DO $$
+DECLARE
+    m TIMESTAMP;
+    last_month TIMESTAMP := date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '3 months';
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(created_at), NOW()) AT TIME ZONE 'UTC') INTO m FROM events_unpartitioned;
+    WHILE m <= last_month LOOP
+        EXECUTE format('CREATE TABLE %I PARTITION OF events FOR VALUES FROM (%L) TO (%L)',
+            'events_' || to_char(m, '"y"YYYY"m"MM'),
+            m AT TIME ZONE 'UTC',
+            (m + INTERVAL '1 month') AT TIME ZONE 'UTC');
+        m := m + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+INSERT INTO events (id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version)
+SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, COALESCE(created_at, NOW()), version, event_version
+FROM events_unpartitioned;
+
+DROP TABLE events_unpartitioned;
+
+-- Rows referring to a transaction keep its ID once it is archived, and the primary key of the
+-- partitioned table includes created_at, so these references are no longer foreign keys
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_reversal_of_id_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_reversed_by_transaction_id_fkey;
+ALTER TABLE treasury_entries DROP CONSTRAINT IF EXISTS treasury_entries_transaction_id_fkey;
+ALTER TABLE payment_requests DROP CONSTRAINT IF EXISTS payment_requests_transaction_id_fkey;
+ALTER TABLE scheduled_transaction_executions DROP CONSTRAINT IF EXISTS scheduled_transaction_executions_transaction_id_fkey;
+ALTER TABLE disputes DROP CONSTRAINT IF EXISTS disputes_transaction_id_fkey;
+ALTER TABLE disputes DROP CONSTRAINT IF EXISTS disputes_refund_transaction_id_fkey;
+
+-- Rebuild transactions as a partitioned table the same way
+ALTER TABLE transactions RENAME TO transactions_unpartitioned;
+ALTER TABLE transactions_unpartitioned RENAME CONSTRAINT transactions_pkey TO transactions_unpartitioned_pkey;
+DROP INDEX idx_transactions_from_user;
+DROP INDEX idx_transactions_to_user;
+DROP INDEX idx_transactions_type;
+DROP INDEX idx_transactions_status;
+DROP INDEX idx_transactions_created_at;
+DROP INDEX idx_transactions_user_history;
+DROP INDEX idx_transactions_type_status;
+DROP INDEX idx_transactions_currency;
+DROP INDEX idx_transactions_reversal_of_transaction_id;
+DROP INDEX idx_transactions_from_user_transfers;
+
+CREATE TABLE transactions (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    from_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    to_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    amount NUMERIC(18,2) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    reversal_of_transaction_id UUID,
+    reversed_by_transaction_id UUID,
+    reversed_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (id, created_at),
+    CONSTRAINT chk_transactions_type CHECK (type IN ('credit', 'debit', 'transfer')),
+    CONSTRAINT chk_transactions_status CHECK (status IN ('pending', 'success', 'failed')),
+    CONSTRAINT chk_transactions_amount_positive CHECK (amount >= 0),
+    CONSTRAINT chk_transactions_transfer_users CHECK (
+        (type = 'transfer' AND from_user_id IS NOT NULL AND to_user_id IS NOT NULL) OR
+        (type != 'transfer')
+    ),
+    CONSTRAINT chk_transactions_credit_users CHECK (
+        (type = 'credit' AND to_user_id IS NOT NULL AND from_user_id IS NULL) OR
+        (type != 'credit')
+    ),
+    CONSTRAINT chk_transactions_debit_users CHECK (
+        (type = 'debit' AND from_user_id IS NOT NULL AND to_user_id IS NULL) OR
+        (type != 'debit')
+    ),
+    CONSTRAINT chk_transactions_currency CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD')),
+    CONSTRAINT chk_transactions_reversed_amount CHECK (reversed_amount >= 0 AND reversed_amount <= amount)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX idx_transactions_from_user ON transactions(from_user_id);
+CREATE INDEX idx_transactions_to_user ON transactions(to_user_id);
+CREATE INDEX idx_transactions_type ON transactions(type);
+CREATE INDEX idx_transactions_status ON transactions(status);
+CREATE INDEX idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX idx_transactions_user_history ON transactions(from_user_id, to_user_id, created_at DESC);
+CREATE INDEX idx_transactions_type_status ON transactions(type, status);
+CREATE INDEX idx_transactions_currency ON transactions(currency);
+CREATE INDEX idx_transactions_reversal_of_transaction_id ON transactions(reversal_of_transaction_id)
+    WHERE reversal_of_transaction_id IS NOT NULL;
+CREATE INDEX idx_transactions_from_user_transfers ON transactions(from_user_id, created_at DESC)
+    WHERE type = 'transfer' AND status = 'success';
+
+CREATE TABLE transactions_default PARTITION OF transactions DEFAULT;
+
+DO $$
+DECLARE
+    m TIMESTAMP;
+    last_month TIMESTAMP := date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '3 months';
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(created_at), NOW()) AT TIME ZONE 'UTC') INTO m FROM transactions_unpartitioned;
+    WHILE m <= last_month LOOP
+        EXECUTE format('CREATE TABLE %I PARTITION OF transactions FOR VALUES FROM (%L) TO (%L)',
+            'transactions_' || to_char(m, '"y"YYYY"m"MM'),
+            m AT TIME ZONE 'UTC',
+            (m + INTERVAL '1 month') AT TIME ZONE 'UTC');
+        m := m + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+INSERT INTO transactions (id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description)
+SELECT id, from_user_id, to_user_id, amount, type, status, COALESCE(created_at, NOW()), currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description
+FROM transactions_unpartitioned;
+
+DROP TABLE transactions_unpartitioned;
+
+-- Archived event and transaction partitions are moved here whole. Columns added to events or
+-- transactions must be added to their archive tables as well.
+CREATE SCHEMA IF NOT EXISTS archive;
+
+CREATE TABLE archive.events (LIKE events INCLUDING DEFAULTS) PARTITION BY RANGE (created_at);
+ALTER TABLE archive.events ADD PRIMARY KEY (id, created_at);
+CREATE INDEX idx_archived_events_version ON archive.events(aggregate_type, aggregate_id, version);
+
+CREATE TABLE archive.transactions (LIKE transactions INCLUDING DEFAULTS) PARTITION BY RANGE (created_at);
+ALTER TABLE archive.transactions ADD PRIMARY KEY (id, created_at);
+CREATE INDEX idx_archived_transactions_from_user ON archive.transactions(from_user_id, created_at DESC);
+CREATE INDEX idx_archived_transactions_to_user ON archive.transactions(to_user_id, created_at DESC);
+
+-- Reads that must see archived rows go through these views
+CREATE VIEW all_events AS
+    SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version FROM events
+    UNION ALL
+    SELECT id, aggregate_type, aggregate_id, event_type, event_data, event_metadata, created_at, version, event_version FROM archive.events;
+
+CREATE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description FROM archive.transactions;
@@ -0,0 +1,30 @@
+-- Remove currency audit entries and restore the previous entity types
+DELETE FROM audit_logs WHERE entity_type = 'currency';
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury'));
+
+-- Drop the treasuries of currencies added after the registry; restoring the hardcoded lists
+-- fails while balances or transactions are held in them
+DELETE FROM treasury_entries WHERE currency NOT IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD');
+DELETE FROM treasury_accounts WHERE currency NOT IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD');
+
+ALTER TABLE treasury_accounts DROP CONSTRAINT IF EXISTS fk_treasury_accounts_currency;
+ALTER TABLE treasury_accounts ADD CONSTRAINT treasury_accounts_currency_check
+    CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD'));
+
+ALTER TABLE balance_alerts DROP CONSTRAINT IF EXISTS fk_balance_alerts_currency;
+ALTER TABLE balance_alerts ADD CONSTRAINT balance_alerts_currency_check
+    CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD'));
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS fk_transactions_currency;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_currency
+    CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD'));
+
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS fk_balances_currency;
+ALTER TABLE balances ADD CONSTRAINT chk_balances_currency
+    CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD'));
+
+-- Drop currency registry
+DROP TABLE IF EXISTS currencies;
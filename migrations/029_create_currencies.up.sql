@@ -0,0 +1,37 @@
+-- Create currencies table: the registry of currencies balances and transactions may be held in
+CREATE TABLE currencies (
+    code VARCHAR(3) PRIMARY KEY CHECK (code ~ '^[A-Z]{3}$'),
+    symbol VARCHAR(8) NOT NULL,
+    -- Digits allowed after the decimal point; amounts are stored with two
+    decimal_places SMALLINT NOT NULL CHECK (decimal_places BETWEEN 0 AND 2),
+    -- Disabled currencies keep their balances but accept no new requests
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO currencies (code, symbol, decimal_places) VALUES
+    ('USD', '$', 2),
+    ('EUR', '€', 2),
+    ('GBP', '£', 2),
+    ('JPY', '¥', 0),
+    ('CAD', 'CA$', 2),
+    ('AUD', 'A$', 2);
+
+-- Replace the hardcoded currency lists with references to the registry
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS chk_balances_currency;
+ALTER TABLE balances ADD CONSTRAINT fk_balances_currency FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_transactions_currency;
+ALTER TABLE transactions ADD CONSTRAINT fk_transactions_currency FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE balance_alerts DROP CONSTRAINT IF EXISTS balance_alerts_currency_check;
+ALTER TABLE balance_alerts ADD CONSTRAINT fk_balance_alerts_currency FOREIGN KEY (currency) REFERENCES currencies(code);
+
+ALTER TABLE treasury_accounts DROP CONSTRAINT IF EXISTS treasury_accounts_currency_check;
+ALTER TABLE treasury_accounts ADD CONSTRAINT fk_treasury_accounts_currency FOREIGN KEY (currency) REFERENCES currencies(code);
+
+-- Allow currency changes in the audit log
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury', 'currency'));
@@ -0,0 +1,3 @@
+-- Drop netting batches
+DROP TABLE IF EXISTS netting_batch_transfers;
+DROP TABLE IF EXISTS netting_batches;
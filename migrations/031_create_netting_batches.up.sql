@@ -0,0 +1,37 @@
+-- Create netting_batches: transfers between a pair of users accumulated over a window and
+-- settled as one net balance movement
+CREATE TABLE netting_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- The pair is stored in a fixed order, so transfers in both directions share a batch
+    user_a_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_b_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL REFERENCES currencies(code),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'settled', 'failed')),
+    transfer_count INTEGER NOT NULL DEFAULT 0,
+    gross_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    net_amount NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (net_amount >= 0),
+    -- Payer of the net amount; NULL when the transfers cancel out
+    net_from_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    failure_reason TEXT,
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closes_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    settled_at TIMESTAMP WITH TIME ZONE,
+    CHECK (user_a_id < user_b_id)
+);
+
+-- At most one batch per pair and currency accepts transfers at a time
+CREATE UNIQUE INDEX idx_netting_batches_open_pair ON netting_batches (user_a_id, user_b_id, currency) WHERE status = 'open';
+CREATE INDEX idx_netting_batches_due ON netting_batches (closes_at) WHERE status = 'open';
+CREATE INDEX idx_netting_batches_opened_at ON netting_batches (opened_at DESC);
+
+-- Transfers waiting in a batch; the sender and amount are kept so funds held by open batches
+-- can be summed without reading the partitioned transactions table
+CREATE TABLE netting_batch_transfers (
+    transaction_id UUID PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES netting_batches(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount NUMERIC(18,2) NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX idx_netting_batch_transfers_batch ON netting_batch_transfers (batch_id);
+CREATE INDEX idx_netting_batch_transfers_from_user ON netting_batch_transfers (from_user_id);
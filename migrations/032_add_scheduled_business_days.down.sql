@@ -0,0 +1,4 @@
+-- Drop business day handling of scheduled executions
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS adjusted_execute_at;
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS calendar_region;
+ALTER TABLE scheduled_transactions DROP COLUMN IF EXISTS business_day_rule;
@@ -0,0 +1,5 @@
+-- Move scheduled executions falling on weekends and holidays to a business day
+ALTER TABLE scheduled_transactions ADD COLUMN business_day_rule VARCHAR(20) NOT NULL DEFAULT 'none'
+    CHECK (business_day_rule IN ('none', 'following', 'preceding'));
+ALTER TABLE scheduled_transactions ADD COLUMN calendar_region VARCHAR(20);
+ALTER TABLE scheduled_transactions ADD COLUMN adjusted_execute_at TIMESTAMP WITH TIME ZONE;
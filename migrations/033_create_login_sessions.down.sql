@@ -0,0 +1,4 @@
+-- Drop login sessions and known devices tables
+DROP INDEX IF EXISTS idx_login_sessions_user_id;
+DROP TABLE IF EXISTS login_sessions;
+DROP TABLE IF EXISTS known_devices;
@@ -0,0 +1,23 @@
+-- Create known_devices table holding the hashed fingerprints of the devices each user logged in from
+CREATE TABLE known_devices (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint TEXT NOT NULL, -- SHA-256 of the user agent and IP
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, fingerprint)
+);
+
+-- Create login_sessions table to track the sessions logins start
+CREATE TABLE login_sessions (
+    id UUID PRIMARY KEY, -- Matches the sid claim of the session's tokens
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    new_device BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Index for listing a user's active sessions
+CREATE INDEX idx_login_sessions_user_id ON login_sessions(user_id, created_at DESC) WHERE revoked_at IS NULL;
@@ -0,0 +1,4 @@
+-- Drop security events table
+DROP INDEX IF EXISTS idx_security_events_created_at;
+DROP INDEX IF EXISTS idx_security_events_user_id;
+DROP TABLE IF EXISTS security_events;
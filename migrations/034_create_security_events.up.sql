@@ -0,0 +1,18 @@
+-- Create security_events table, the log of security-relevant actions on accounts
+CREATE TABLE security_events (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL for failed logins with an unknown email
+    email TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL,
+    ip TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    session_id UUID,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for a user's recent activity
+CREATE INDEX idx_security_events_user_id ON security_events(user_id, created_at DESC);
+
+-- Index for the recent activity of all users
+CREATE INDEX idx_security_events_created_at ON security_events(created_at DESC);
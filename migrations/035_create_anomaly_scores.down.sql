@@ -0,0 +1,2 @@
+-- Drop anomaly scores table
+DROP TABLE IF EXISTS anomaly_scores;
@@ -0,0 +1,6 @@
+-- Create anomaly_scores table holding each user's decaying score of suspicious money movement
+CREATE TABLE anomaly_scores (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0, -- As of updated_at; halves every configured half-life
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
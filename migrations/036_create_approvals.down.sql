@@ -0,0 +1,10 @@
+-- Remove approval audit entries and restore the previous entity types
+DELETE FROM audit_logs WHERE entity_type = 'approval';
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury', 'currency', 'import'));
+
+-- Drop approvals table
+DROP INDEX IF EXISTS idx_approvals_status;
+DROP TABLE IF EXISTS approvals;
@@ -0,0 +1,24 @@
+-- Create approvals table, the four-eyes queue of destructive admin operations
+CREATE TABLE approvals (
+    id UUID PRIMARY KEY,
+    action TEXT NOT NULL, -- rollback, balance_adjustment or role_change
+    payload JSONB NOT NULL, -- The request the action is executed with
+    requested_by UUID NOT NULL REFERENCES users(id),
+    status TEXT NOT NULL DEFAULT 'pending',
+    reviewed_by UUID REFERENCES users(id),
+    review_note TEXT NOT NULL DEFAULT '',
+    result JSONB, -- What the executed action returned
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT approvals_reviewer_differs CHECK (reviewed_by IS NULL OR reviewed_by <> requested_by OR status = 'rejected')
+);
+
+-- Index for the queue of pending approvals
+CREATE INDEX idx_approvals_status ON approvals(status, created_at DESC);
+
+-- Allow approvals in the audit log
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury', 'currency', 'import', 'approval'));
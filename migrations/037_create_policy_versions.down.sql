@@ -0,0 +1,10 @@
+-- Remove policy audit entries and restore the previous entity types
+DELETE FROM audit_logs WHERE entity_type = 'policy';
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury', 'currency', 'import', 'approval'));
+
+-- Drop policy_versions table
+DROP INDEX IF EXISTS idx_policy_versions_effective_from;
+DROP TABLE IF EXISTS policy_versions;
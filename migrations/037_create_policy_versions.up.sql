@@ -0,0 +1,18 @@
+-- Create policy_versions table: every version of the runtime policy, each taking effect at its effective time
+CREATE TABLE policy_versions (
+    version INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    id UUID NOT NULL UNIQUE, -- Keys the version in the audit log
+    policy JSONB NOT NULL, -- The whole policy: rollback window, amount limits, approval thresholds and transfer fees
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for finding the version in effect at a time
+CREATE INDEX idx_policy_versions_effective_from ON policy_versions(effective_from DESC, version DESC);
+
+-- Allow policy changes in the audit log
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_entity_type;
+ALTER TABLE audit_logs ADD CONSTRAINT chk_audit_logs_entity_type
+    CHECK (entity_type IN ('user', 'transaction', 'balance', 'http_request', 'treasury', 'currency', 'import', 'approval', 'policy'));
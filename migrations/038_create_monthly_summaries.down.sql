@@ -0,0 +1,2 @@
+-- Drop monthly_summaries table
+DROP TABLE IF EXISTS monthly_summaries;
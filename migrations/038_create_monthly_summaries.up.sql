@@ -0,0 +1,16 @@
+-- Create monthly_summaries table: each user's account summary of every ended calendar month
+CREATE TABLE monthly_summaries (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    month CHAR(7) NOT NULL, -- yyyy-mm, in UTC
+    currency VARCHAR(3) NOT NULL,
+    opening_balance NUMERIC(18,2) NOT NULL,
+    closing_balance NUMERIC(18,2) NOT NULL,
+    total_in NUMERIC(18,2) NOT NULL,
+    total_out NUMERIC(18,2) NOT NULL, -- Includes fees
+    fees_paid NUMERIC(18,2) NOT NULL,
+    transaction_count INTEGER NOT NULL,
+    top_counterparties JSONB NOT NULL DEFAULT '[]',
+    generated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_monthly_summaries_user_month UNIQUE (user_id, month)
+);
@@ -0,0 +1,13 @@
+-- Drop budgets and transaction categories
+DROP TABLE IF EXISTS budgets;
+DROP INDEX IF EXISTS idx_transactions_from_user_spending;
+
+-- A replaced view cannot lose columns, so it is recreated
+DROP VIEW all_transactions;
+CREATE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description FROM archive.transactions;
+
+ALTER TABLE archive.transactions DROP COLUMN IF EXISTS category;
+ALTER TABLE transactions DROP COLUMN IF EXISTS category;
@@ -0,0 +1,23 @@
+-- Tag spending with an optional category, tracked against monthly budgets
+ALTER TABLE transactions ADD COLUMN category TEXT NOT NULL DEFAULT '';
+ALTER TABLE archive.transactions ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+CREATE OR REPLACE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category FROM archive.transactions;
+
+-- Spending of a month is summed per sender, so look it up by sender and time
+CREATE INDEX idx_transactions_from_user_spending ON transactions(from_user_id, created_at DESC)
+    WHERE type IN ('debit', 'transfer') AND status = 'success';
+
+-- Create budgets table holding each user's monthly spending limit per category
+CREATE TABLE budgets (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    limit_amount NUMERIC(18,2) NOT NULL CHECK (limit_amount > 0),
+    alerted_month CHAR(7) NOT NULL DEFAULT '', -- The last yyyy-mm month the user was told they went over
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, category)
+);
@@ -0,0 +1,5 @@
+-- Drop dormant_accounts table
+DROP INDEX IF EXISTS idx_transactions_to_user_activity;
+DROP INDEX IF EXISTS idx_transactions_from_user_activity;
+
+DROP TABLE IF EXISTS dormant_accounts;
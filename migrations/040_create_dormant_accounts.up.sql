@@ -0,0 +1,11 @@
+-- Create dormant_accounts table: the accounts an AccountDormant event was published for, with the
+-- inactivity it was published for, so each period of dormancy is announced once
+CREATE TABLE dormant_accounts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    inactive_since TIMESTAMP WITH TIME ZONE NOT NULL, -- The last transaction, or account creation without one
+    flagged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- The last activity of a user is looked up from both sides of their transactions
+CREATE INDEX idx_transactions_from_user_activity ON transactions(from_user_id, created_at DESC);
+CREATE INDEX idx_transactions_to_user_activity ON transactions(to_user_id, created_at DESC);
@@ -0,0 +1,12 @@
+-- Drop transaction client references
+DROP INDEX IF EXISTS idx_transactions_client_reference;
+
+-- A replaced view cannot lose columns, so it is recreated
+DROP VIEW all_transactions;
+CREATE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category FROM archive.transactions;
+
+ALTER TABLE archive.transactions DROP COLUMN IF EXISTS client_reference;
+ALTER TABLE transactions DROP COLUMN IF EXISTS client_reference;
@@ -0,0 +1,12 @@
+-- Let callers tag money movements with their own reference to correlate them with
+ALTER TABLE transactions ADD COLUMN client_reference TEXT NOT NULL DEFAULT '';
+ALTER TABLE archive.transactions ADD COLUMN client_reference TEXT NOT NULL DEFAULT '';
+
+CREATE OR REPLACE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference FROM archive.transactions;
+
+-- Transactions are looked up by the reference their caller gave them
+CREATE INDEX idx_transactions_client_reference ON transactions(client_reference)
+    WHERE client_reference <> '';
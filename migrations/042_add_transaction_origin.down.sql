@@ -0,0 +1,14 @@
+-- Drop transaction origins
+DROP INDEX IF EXISTS idx_transactions_scheduled_transaction;
+
+-- A replaced view cannot lose columns, so it is recreated
+DROP VIEW all_transactions;
+CREATE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference FROM archive.transactions;
+
+ALTER TABLE archive.transactions DROP COLUMN IF EXISTS scheduled_execution_id;
+ALTER TABLE archive.transactions DROP COLUMN IF EXISTS scheduled_transaction_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS scheduled_execution_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS scheduled_transaction_id;
@@ -0,0 +1,15 @@
+-- Link transactions to the scheduled execution that created them. Executions are recorded after
+-- their transaction runs, so the columns carry no foreign keys.
+ALTER TABLE transactions ADD COLUMN scheduled_transaction_id UUID;
+ALTER TABLE transactions ADD COLUMN scheduled_execution_id UUID;
+ALTER TABLE archive.transactions ADD COLUMN scheduled_transaction_id UUID;
+ALTER TABLE archive.transactions ADD COLUMN scheduled_execution_id UUID;
+
+CREATE OR REPLACE VIEW all_transactions AS
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id FROM transactions
+    UNION ALL
+    SELECT id, from_user_id, to_user_id, amount, type, status, created_at, currency, reversal_of_transaction_id, reversed_by_transaction_id, reversed_amount, description, category, client_reference, scheduled_transaction_id, scheduled_execution_id FROM archive.transactions;
+
+-- Find the transactions a schedule created
+CREATE INDEX idx_transactions_scheduled_transaction ON transactions(scheduled_transaction_id)
+    WHERE scheduled_transaction_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Restore the check on the built-in transaction types. Rows of the types registered since are kept,
+-- so the check applies to new rows only
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_type
+    CHECK (type IN ('credit', 'debit', 'transfer')) NOT VALID;
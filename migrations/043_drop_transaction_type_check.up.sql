@@ -0,0 +1,3 @@
+-- Transaction types are declared by the application's type registry, which validates them along
+-- with the parties each type needs, so new types no longer need a schema change
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_transactions_type;
@@ -0,0 +1,6 @@
+-- Full-text search over audit log entries, which admins search across every entry, so it needs an
+-- index. The expression must match the document the search repository queries. Users' searches of
+-- their own transactions and payment requests are narrowed by user first and need none.
+CREATE INDEX idx_audit_logs_search ON audit_logs USING gin (
+    (to_tsvector('simple', action) || jsonb_to_tsvector('simple', COALESCE(details, '{}'::jsonb), '["string"]'))
+);
@@ -0,0 +1,6 @@
+-- Drop transaction_monthly_aggregates and the trigger maintaining it
+DROP TRIGGER IF EXISTS maintain_transaction_aggregates ON transactions;
+DROP FUNCTION IF EXISTS maintain_transaction_aggregates();
+DROP FUNCTION IF EXISTS apply_transaction_aggregate(transactions, INTEGER);
+
+DROP TABLE IF EXISTS transaction_monthly_aggregates;
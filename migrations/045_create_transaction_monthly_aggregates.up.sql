@@ -0,0 +1,59 @@
+-- Per-user monthly totals of successful transactions, by side, type, category and currency, so
+-- that spending reports and budget checks read a handful of rows instead of scanning transactions.
+-- Months are UTC months, stored as their first day.
+CREATE TABLE transaction_monthly_aggregates (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    direction VARCHAR(3) NOT NULL CHECK (direction IN ('in', 'out')),
+    type VARCHAR(20) NOT NULL,
+    category TEXT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    reversed_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, month, direction, type, category, currency)
+);
+
+-- Adds a successful transaction to, or with sign -1 removes it from, the aggregates of its parties
+CREATE FUNCTION apply_transaction_aggregate(tx transactions, sign INTEGER) RETURNS VOID AS $$
+BEGIN
+    IF tx.status <> 'success' THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO transaction_monthly_aggregates (user_id, month, direction, type, category, currency, count, amount, reversed_amount)
+    SELECT party.user_id, date_trunc('month', tx.created_at AT TIME ZONE 'UTC')::date, party.direction,
+           tx.type, tx.category, tx.currency, sign, sign * tx.amount, sign * tx.reversed_amount
+    FROM (VALUES (tx.from_user_id, 'out'), (tx.to_user_id, 'in')) AS party(user_id, direction)
+    WHERE party.user_id IS NOT NULL
+    ON CONFLICT (user_id, month, direction, type, category, currency) DO UPDATE
+    SET count = transaction_monthly_aggregates.count + EXCLUDED.count,
+        amount = transaction_monthly_aggregates.amount + EXCLUDED.amount,
+        reversed_amount = transaction_monthly_aggregates.reversed_amount + EXCLUDED.reversed_amount;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Keeps the aggregates current as transactions are created, complete and are rolled back. Deletes
+-- are ignored: archiving a transaction moves it out of transactions but it still counts.
+CREATE FUNCTION maintain_transaction_aggregates() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        PERFORM apply_transaction_aggregate(OLD, -1);
+    END IF;
+    PERFORM apply_transaction_aggregate(NEW, 1);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER maintain_transaction_aggregates
+    AFTER INSERT OR UPDATE OF status, reversed_amount ON transactions
+    FOR EACH ROW EXECUTE FUNCTION maintain_transaction_aggregates();
+
+-- Aggregate the transactions made so far, live or archived
+INSERT INTO transaction_monthly_aggregates (user_id, month, direction, type, category, currency, count, amount, reversed_amount)
+SELECT party.user_id, date_trunc('month', t.created_at AT TIME ZONE 'UTC')::date, party.direction,
+       t.type, t.category, t.currency, COUNT(*), SUM(t.amount), SUM(t.reversed_amount)
+FROM all_transactions t
+CROSS JOIN LATERAL (VALUES (t.from_user_id, 'out'), (t.to_user_id, 'in')) AS party(user_id, direction)
+WHERE t.status = 'success' AND party.user_id IS NOT NULL
+GROUP BY 1, 2, 3, 4, 5, 6;